@@ -24,6 +24,7 @@ import (
 	"github.com/fundament-oss/fundament/authn-api/pkg/authn"
 	"github.com/fundament-oss/fundament/authn-api/pkg/authnhttp"
 	"github.com/fundament-oss/fundament/authn-api/pkg/proto/gen/authn/v1/authnv1connect"
+	"github.com/fundament-oss/fundament/common/auth"
 	"github.com/fundament-oss/fundament/common/authz"
 	"github.com/fundament-oss/fundament/common/connectrecovery"
 	"github.com/fundament-oss/fundament/common/dbversion"
@@ -35,6 +36,9 @@ type config struct {
 	Database           psqldb.Config
 	OpenFGA            authz.Config
 	JWTSecret          string        `env:"JWT_SECRET,required,notEmpty" `
+	JWTSigningKeysDir  string        `env:"JWT_SIGNING_KEYS_DIR"`     // PEM private keys; when empty, tokens are signed HS256 with JWTSecret
+	JWTAcceptSecret    bool          `env:"JWT_ACCEPT_SHARED_SECRET"` // keep accepting HS256 tokens signed with JWTSecret next to the signing keys
+	JWTKeysReload      time.Duration `env:"JWT_SIGNING_KEYS_RELOAD_INTERVAL" envDefault:"1m"`
 	OIDCIssuer         string        `env:"OIDC_ISSUER,required,notEmpty" envDefault:"http://localhost:5556"`
 	OIDCDiscoveryURL   string        `env:"OIDC_DISCOVERY_URL"` // URL to fetch OIDC discovery document (defaults to OIDCIssuer)
 	ClientID           string        `env:"OIDC_CLIENT_ID,required,notEmpty" envDefault:"authn-api"`
@@ -146,13 +150,28 @@ func run() error {
 	sessionStore := authn.NewSessionStore([]byte(cfg.JWTSecret))
 	sessionStore.ConfigureOptions(cfg.CookieDomain, cfg.CookieSecure)
 
+	// JWTSecret keeps protecting the OAuth session cookie either way; only
+	// token signing moves to the key set.
+	signer := auth.NewSharedSecretSigner([]byte(cfg.JWTSecret))
+	if cfg.JWTSigningKeysDir != "" {
+		signer, err = auth.LoadSigner(cfg.JWTSigningKeysDir)
+		if err != nil {
+			return fmt.Errorf("failed to load JWT signing keys: %w", err)
+		}
+		logger.Info("signing tokens with asymmetric keys", "keys", len(signer.JWKS().Keys))
+		go signer.Watch(ctx, cfg.JWTKeysReload, logger)
+	}
+
 	authnCfg := &authn.Config{
 		TokenExpiry:  cfg.TokenExpiry,
-		JWTSecret:    []byte(cfg.JWTSecret),
+		Signer:       signer,
 		CookieDomain: cfg.CookieDomain,
 		CookieSecure: cfg.CookieSecure,
 		FrontendURL:  cfg.FrontendURL,
 	}
+	if cfg.JWTSigningKeysDir != "" && cfg.JWTAcceptSecret {
+		authnCfg.SharedSecret = []byte(cfg.JWTSecret)
+	}
 
 	pluginProxyClient := pluginproxyv1connect.NewPluginInstallationServiceClient(
 		&http.Client{Timeout: 10 * time.Second}, cfg.PluginProxyURL)
@@ -165,6 +184,8 @@ func run() error {
 
 	mux := http.NewServeMux()
 
+	mux.Handle(auth.JWKSPath, signer.JWKSHandler())
+
 	// Health endpoints
 	mux.HandleFunc("/livez", func(w http.ResponseWriter, _ *http.Request) {
 		w.WriteHeader(http.StatusOK)
@@ -225,6 +246,9 @@ func run() error {
 	// HTTP endpoints for authentication flow (registers routes on mux)
 	_ = authnhttp.HandlerFromMux(server, mux)
 
+	// SCIM 2.0 provisioning endpoints for identity providers
+	mux.Handle(authn.SCIMPath, server.SCIMHandler())
+
 	corsHandler := cors.New(cors.Options{
 		AllowedOrigins:   cfg.CORSAllowedOrigins,
 		AllowedMethods:   []string{"GET", "POST", "OPTIONS"},
@@ -32,12 +32,14 @@ type user struct {
 	OrganizationIDs []uuid.UUID
 	Name            string
 	ExternalRef     string
+	Scope           *auth.Scope
 }
 
 // Config holds the configuration for the authentication server.
 type Config struct {
 	TokenExpiry  time.Duration
-	JWTSecret    []byte
+	Signer       *auth.Signer
+	SharedSecret []byte // when set, HS256 tokens signed with it stay valid next to Signer's keys
 	CookieDomain string
 	CookieSecure bool
 	FrontendURL  string
@@ -74,13 +76,24 @@ func New(logger *slog.Logger, cfg *Config, oauth2Config *oauth2.Config, verifier
 		db:                  database,
 		queries:             db.New(database.Pool),
 		sessionStore:        sessionStore,
-		validator:           auth.NewValidatorForAudience(cfg.JWTSecret, auth.ConsoleAuthCookieName, auth.ConsoleIssuer, auth.TokenTypeUser, logger),
+		validator:           newValidator(cfg, logger),
 		cookieBuilder:       auth.NewCookieBuilder(cfg.CookieDomain, cfg.CookieSecure, auth.ConsoleAuthCookieName),
 		authz:               authzClient,
 		pluginInstallations: pluginInstallations,
 	}, nil
 }
 
+// newValidator returns the validator for the console tokens presented to
+// authn-api. It trusts the Signer's keys and, while switching over, the shared
+// secret, so tokens minted before the switch can still be refreshed.
+func newValidator(cfg *Config, logger *slog.Logger) *auth.Validator {
+	var keys auth.Keys = cfg.Signer
+	if len(cfg.SharedSecret) > 0 {
+		keys = auth.ChainKeys(cfg.Signer, auth.SharedSecret(cfg.SharedSecret))
+	}
+	return auth.NewValidatorForAudience(keys, auth.ConsoleAuthCookieName, auth.ConsoleIssuer, auth.TokenTypeUser, logger)
+}
+
 // getUserOrganizationIDs fetches the accepted organization IDs for a user.
 func (s *AuthnServer) getUserOrganizationIDs(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error) {
 	memberships, err := s.queries.UserListOrganizations(ctx, db.UserListOrganizationsParams{UserID: userID})
@@ -116,10 +129,10 @@ func (s *AuthnServer) generateJWTWithExpiry(u *user, groups []string, expiry tim
 		OrganizationIDs: u.OrganizationIDs,
 		Name:            u.Name,
 		Groups:          groups,
+		Scope:           u.Scope,
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	signed, err := token.SignedString(s.config.JWTSecret)
+	signed, err := s.config.Signer.Sign(claims)
 	if err != nil {
 		return "", fmt.Errorf("signing token: %w", err)
 	}
@@ -2,6 +2,8 @@ package authn
 
 import (
 	"context"
+	"crypto/ed25519"
+	"crypto/rand"
 	"io"
 	"log/slog"
 	"net/http"
@@ -23,7 +25,7 @@ func TestGenerateJWT_SetsUserAudience(t *testing.T) {
 	secret := []byte("test-secret")
 	server := &AuthnServer{
 		config: &Config{
-			JWTSecret:   secret,
+			Signer:      auth.NewSharedSecretSigner(secret),
 			TokenExpiry: 15 * time.Minute,
 		},
 	}
@@ -48,6 +50,79 @@ func TestGenerateJWT_SetsUserAudience(t *testing.T) {
 	require.Equal(t, auth.TokenTypeUser, claims.Audience[0])
 }
 
+// TestGenerateJWT_VerifiesAgainstPublishedKeys checks that a token signed with
+// an asymmetric key verifies for a service that only knows the JWKS endpoint.
+func TestGenerateJWT_VerifiesAgainstPublishedKeys(t *testing.T) {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	signer, err := auth.NewSigner(key)
+	require.NoError(t, err)
+
+	server := &AuthnServer{
+		config: &Config{Signer: signer, TokenExpiry: time.Minute},
+	}
+	tokenStr, err := server.generateJWT(&user{ID: uuid.New()}, nil)
+	require.NoError(t, err)
+
+	jwks := httptest.NewServer(signer.JWKSHandler())
+	t.Cleanup(jwks.Close)
+
+	validator := auth.NewValidatorForAudience(auth.NewRemoteKeys(jwks.URL, nil), auth.ConsoleAuthCookieName, auth.ConsoleIssuer, auth.TokenTypeUser, nil)
+	header := http.Header{}
+	header.Set("Authorization", "Bearer "+tokenStr)
+	_, err = validator.Validate(header)
+	require.NoError(t, err)
+}
+
+// TestNewValidator_AcceptsSharedSecretWhileSwitching checks that HS256 tokens
+// minted before the switch to signing keys keep validating while the shared
+// secret is accepted, and only then.
+func TestNewValidator_AcceptsSharedSecretWhileSwitching(t *testing.T) {
+	secret := []byte("test-secret")
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	signer, err := auth.NewSigner(key)
+	require.NoError(t, err)
+
+	server := &AuthnServer{
+		config: &Config{Signer: signer, TokenExpiry: time.Minute},
+	}
+	asymmetric, err := server.generateJWT(&user{ID: uuid.New()}, nil)
+	require.NoError(t, err)
+
+	server.config.Signer = auth.NewSharedSecretSigner(secret)
+	shared, err := server.generateJWT(&user{ID: uuid.New()}, nil)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name         string
+		sharedSecret []byte
+		token        string
+		wantErr      bool
+	}{
+		{name: "signing key", token: asymmetric},
+		{name: "signing key while switching", sharedSecret: secret, token: asymmetric},
+		{name: "shared secret while switching", sharedSecret: secret, token: shared},
+		{name: "shared secret after switching", token: shared, wantErr: true},
+		{name: "wrong shared secret", sharedSecret: []byte("other-secret"), token: shared, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			validator := newValidator(&Config{Signer: signer, SharedSecret: tt.sharedSecret}, nil)
+
+			header := http.Header{}
+			header.Set("Authorization", "Bearer "+tt.token)
+			_, err := validator.Validate(header)
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
 // TestGetUserInfo_RejectsPluginToken verifies that a PluginToken presented to
 // GetUserInfo over the real Connect HTTP path is rejected with Unauthenticated.
 // This exercises the wire-up between the Connect handler and the audience-aware
@@ -59,9 +134,9 @@ func TestGetUserInfo_RejectsPluginToken(t *testing.T) {
 	// AuthnServer with only the fields it touches lets the test stay
 	// DB-less. The full authn.New constructor requires a real Postgres pool.
 	server := &AuthnServer{
-		config:    &Config{JWTSecret: secret, TokenExpiry: time.Minute},
+		config:    &Config{Signer: auth.NewSharedSecretSigner(secret), TokenExpiry: time.Minute},
 		logger:    slog.New(slog.NewTextHandler(io.Discard, nil)),
-		validator: auth.NewValidatorForAudience(secret, auth.ConsoleAuthCookieName, auth.ConsoleIssuer, auth.TokenTypeUser, nil),
+		validator: auth.NewValidatorForAudience(auth.SharedSecret(secret), auth.ConsoleAuthCookieName, auth.ConsoleIssuer, auth.TokenTypeUser, nil),
 	}
 
 	path, handler := authnv1connect.NewAuthnServiceHandler(server)
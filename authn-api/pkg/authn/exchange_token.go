@@ -4,14 +4,17 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"time"
 
 	"connectrpc.com/connect"
+	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 
 	db "github.com/fundament-oss/fundament/authn-api/pkg/db/gen"
 	authnv1 "github.com/fundament-oss/fundament/authn-api/pkg/proto/gen/authn/v1"
 	"github.com/fundament-oss/fundament/common/apitoken"
+	"github.com/fundament-oss/fundament/common/auth"
 	"github.com/fundament-oss/fundament/common/authz"
 )
 
@@ -73,6 +76,14 @@ func (s *AuthnServer) ExchangeToken(
 		ExternalRef:     dbUser.ExternalRef.String,
 	}
 
+	if scope := apiKeyScope(apiKey); scope != nil {
+		// A scoped key only works in the organization it was created in.
+		u.OrganizationIDs = slices.DeleteFunc(u.OrganizationIDs, func(id uuid.UUID) bool {
+			return id != apiKey.OrganizationID
+		})
+		u.Scope = scope
+	}
+
 	accessToken, err := s.generateJWTWithExpiry(u, []string{}, APITokenExpiry)
 	if err != nil {
 		s.logger.Error("failed to generate jwt for api token", "error", err)
@@ -83,6 +94,7 @@ func (s *AuthnServer) ExchangeToken(
 		"api_key_id", apiKey.ID,
 		"user_id", dbUser.ID,
 		"organization_ids", u.OrganizationIDs,
+		"scoped", u.Scope != nil,
 	)
 
 	return authnv1.ExchangeTokenResponse_builder{
@@ -92,6 +104,22 @@ func (s *AuthnServer) ExchangeToken(
 	}.Build(), nil
 }
 
+// apiKeyScope returns the scope to carry in the token exchanged for the API
+// key, or nil if the key is not restricted.
+func apiKeyScope(apiKey *db.APIKeyGetByHashRow) *auth.Scope {
+	if len(apiKey.ScopeProjectIds) == 0 && len(apiKey.ScopeClusterIds) == 0 &&
+		len(apiKey.ScopeActions) == 0 && !apiKey.ScopeReadOnly {
+		return nil
+	}
+	return &auth.Scope{
+		OrganizationID: apiKey.OrganizationID,
+		ProjectIDs:     apiKey.ScopeProjectIds,
+		ClusterIDs:     apiKey.ScopeClusterIds,
+		Actions:        apiKey.ScopeActions,
+		ReadOnly:       apiKey.ScopeReadOnly,
+	}
+}
+
 // extractBearerToken extracts the token from a Bearer authorization header.
 func extractBearerToken(authHeader string) (string, error) {
 	if len(authHeader) < 8 || authHeader[:7] != "Bearer " {
@@ -39,6 +39,12 @@ func (s *AuthnServer) MintPluginToken(
 		return nil, connect.NewError(connect.CodeUnauthenticated, fmt.Errorf("invalid user token"))
 	}
 
+	// A plugin token acts with the user's full rights, so a token exchanged
+	// for a scoped API key must not be able to mint one.
+	if claims.Scope != nil {
+		return nil, connect.NewError(connect.CodePermissionDenied, fmt.Errorf("scoped API key tokens cannot mint plugin tokens"))
+	}
+
 	clusterID := uuid.MustParse(req.GetClusterId())
 	installationID := uuid.MustParse(req.GetInstallationId())
 	userID := claims.UserID()
@@ -128,8 +134,7 @@ func (s *AuthnServer) signPluginToken(
 		DefinitionHash:   manifest.DefinitionHash,
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	signed, err := token.SignedString(s.config.JWTSecret)
+	signed, err := s.config.Signer.Sign(claims)
 	if err != nil {
 		return "", fmt.Errorf("signing plugin token: %w", err)
 	}
@@ -70,11 +70,11 @@ func newMintHarness(t *testing.T, allow bool, manifest *InstallationManifest, lo
 	lk := &fakeLookup{manifest: manifest, err: lookupErr}
 	server := &AuthnServer{
 		config: &Config{
-			JWTSecret:   secret,
+			Signer:      auth.NewSharedSecretSigner(secret),
 			TokenExpiry: 15 * time.Minute,
 		},
 		logger:              logger,
-		validator:           auth.NewValidatorForAudience(secret, auth.ConsoleAuthCookieName, auth.ConsoleIssuer, auth.TokenTypeUser, logger),
+		validator:           auth.NewValidatorForAudience(auth.SharedSecret(secret), auth.ConsoleAuthCookieName, auth.ConsoleIssuer, auth.TokenTypeUser, logger),
 		authz:               az,
 		pluginInstallations: lk,
 	}
@@ -145,7 +145,7 @@ func TestMintPluginToken_Success(t *testing.T) {
 	assert.Equal(t, "Bearer", resp.GetTokenType())
 	assert.Equal(t, int64(PluginTokenExpiry.Seconds()), resp.GetExpiresIn())
 
-	claims, err := auth.ParsePluginToken(resp.GetAccessToken(), h.secret)
+	claims, err := auth.ParsePluginToken(resp.GetAccessToken(), auth.SharedSecret(h.secret))
 	require.NoError(t, err, "parse minted token")
 
 	assert.Equal(t, h.userID.String(), claims.Subject)
@@ -207,7 +207,7 @@ func TestMintedTokenRejectedAsUserToken(t *testing.T) {
 	resp, err := h.mint(t, "Bearer "+h.userToken(t))
 	require.NoError(t, err, "MintPluginToken")
 
-	userValidator := auth.NewValidatorForAudience(h.secret,
+	userValidator := auth.NewValidatorForAudience(auth.SharedSecret(h.secret),
 		auth.ConsoleAuthCookieName, auth.ConsoleIssuer, auth.TokenTypeUser, nil)
 	header := http.Header{}
 	header.Set("Authorization", "Bearer "+resp.GetAccessToken())
@@ -96,6 +96,13 @@ func (s *AuthnServer) handleExistingUser(ctx context.Context, claims *oidcClaims
 		return nil, "", fmt.Errorf("getting user organizations: %w", err)
 	}
 
+	// A failed sync fails the login, so a user who lost a group cannot keep
+	// the roles its team granted.
+	if err := s.syncTeamMemberships(ctx, row.ID, claims.Groups); err != nil {
+		s.logger.Error("failed to sync team memberships", "error", err)
+		return nil, "", fmt.Errorf("syncing team memberships: %w", err)
+	}
+
 	u := &user{
 		ID:              row.ID,
 		OrganizationIDs: organizationIDs,
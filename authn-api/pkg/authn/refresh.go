@@ -14,6 +14,13 @@ func (s *AuthnServer) HandleRefresh(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Refreshing would drop the scope and extend the lifetime of a token
+	// exchanged for a scoped API key; exchange the key again instead.
+	if claims.Scope != nil {
+		s.writeErrorJSON(w, http.StatusForbidden, "Scoped API key tokens cannot be refreshed")
+		return
+	}
+
 	userID := claims.UserID()
 
 	organizationIDs, err := s.getUserOrganizationIDs(r.Context(), userID)
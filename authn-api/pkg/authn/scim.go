@@ -0,0 +1,339 @@
+package authn
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/fundament-oss/fundament/common/apitoken"
+	"github.com/fundament-oss/fundament/common/authz"
+)
+
+// SCIMPath is the prefix the SCIM 2.0 endpoints are served under.
+const SCIMPath = "/scim/v2/"
+
+const (
+	scimContentType = "application/scim+json"
+
+	scimSchemaUser          = "urn:ietf:params:scim:schemas:core:2.0:User"
+	scimSchemaGroup         = "urn:ietf:params:scim:schemas:core:2.0:Group"
+	scimSchemaListResponse  = "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+	scimSchemaError         = "urn:ietf:params:scim:api:messages:2.0:Error"
+	scimSchemaServiceConfig = "urn:ietf:params:scim:schemas:core:2.0:ServiceProviderConfig"
+
+	scimDefaultCount = 100
+	scimMaxCount     = 500
+)
+
+// scimActions are the organization actions the API key used by an identity
+// provider must allow: SCIM creates and removes members and manages teams.
+var scimActions = []authz.Action{
+	authz.CanInviteMember(),
+	authz.CanDeleteMember(),
+	authz.CanManageTeams(),
+}
+
+// scimError is an error reported to the identity provider in the SCIM error
+// format. Any other error a handler returns becomes a 500.
+type scimError struct {
+	status   int
+	scimType string
+	detail   string
+}
+
+func (e *scimError) Error() string {
+	return e.detail
+}
+
+func newSCIMError(status int, scimType, detail string) *scimError {
+	return &scimError{status: status, scimType: scimType, detail: detail}
+}
+
+type scimErrorResponse struct {
+	Schemas  []string `json:"schemas"`
+	Status   string   `json:"status"`
+	ScimType string   `json:"scimType,omitempty"`
+	Detail   string   `json:"detail,omitempty"`
+}
+
+type scimListResponse[T any] struct {
+	Schemas      []string `json:"schemas"`
+	TotalResults int64    `json:"totalResults"`
+	StartIndex   int32    `json:"startIndex"`
+	ItemsPerPage int      `json:"itemsPerPage"`
+	Resources    []T      `json:"Resources"`
+}
+
+type scimMeta struct {
+	ResourceType string    `json:"resourceType"`
+	Created      time.Time `json:"created"`
+}
+
+type scimPatchRequest struct {
+	Schemas    []string      `json:"schemas"`
+	Operations []scimPatchOp `json:"Operations"`
+}
+
+type scimPatchOp struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	Value json.RawMessage `json:"value"`
+}
+
+// scimHandlerFunc handles a SCIM request on behalf of the organization the
+// API key belongs to.
+type scimHandlerFunc func(w http.ResponseWriter, r *http.Request, organizationID uuid.UUID) error
+
+// SCIMHandler returns the SCIM 2.0 endpoints identity providers push users
+// and groups to. Requests are authenticated with an API key of an
+// organization admin and act on the key's organization. Users become
+// organization members; groups become teams, so the organization role a
+// group grants is the permission of its team.
+func (s *AuthnServer) SCIMHandler() http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET "+SCIMPath+"ServiceProviderConfig", s.scimRoute(s.scimServiceProviderConfig))
+
+	mux.HandleFunc("GET "+SCIMPath+"Users", s.scimRoute(s.scimListUsers))
+	mux.HandleFunc("POST "+SCIMPath+"Users", s.scimRoute(s.scimCreateUser))
+	mux.HandleFunc("GET "+SCIMPath+"Users/{id}", s.scimRoute(s.scimGetUser))
+	mux.HandleFunc("PUT "+SCIMPath+"Users/{id}", s.scimRoute(s.scimReplaceUser))
+	mux.HandleFunc("PATCH "+SCIMPath+"Users/{id}", s.scimRoute(s.scimPatchUser))
+	mux.HandleFunc("DELETE "+SCIMPath+"Users/{id}", s.scimRoute(s.scimDeleteUser))
+
+	mux.HandleFunc("GET "+SCIMPath+"Groups", s.scimRoute(s.scimListGroups))
+	mux.HandleFunc("POST "+SCIMPath+"Groups", s.scimRoute(s.scimCreateGroup))
+	mux.HandleFunc("GET "+SCIMPath+"Groups/{id}", s.scimRoute(s.scimGetGroup))
+	mux.HandleFunc("PUT "+SCIMPath+"Groups/{id}", s.scimRoute(s.scimReplaceGroup))
+	mux.HandleFunc("PATCH "+SCIMPath+"Groups/{id}", s.scimRoute(s.scimPatchGroup))
+	mux.HandleFunc("DELETE "+SCIMPath+"Groups/{id}", s.scimRoute(s.scimDeleteGroup))
+
+	return mux
+}
+
+// scimRoute authenticates the request and turns the handler's error into a
+// SCIM error response.
+func (s *AuthnServer) scimRoute(h scimHandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		organizationID, err := s.scimAuthenticate(r)
+		if err == nil {
+			err = h(w, r, organizationID)
+		}
+		if err == nil {
+			return
+		}
+
+		scimErr, ok := errors.AsType[*scimError](err)
+		if !ok {
+			s.logger.Error("scim request failed", "error", err, "method", r.Method, "path", r.URL.Path)
+			scimErr = newSCIMError(http.StatusInternalServerError, "", "internal error")
+		}
+		s.writeSCIM(w, scimErr.status, scimErrorResponse{
+			Schemas:  []string{scimSchemaError},
+			Status:   strconv.Itoa(scimErr.status),
+			ScimType: scimErr.scimType,
+			Detail:   scimErr.detail,
+		})
+	}
+}
+
+// scimAuthenticate returns the organization of the API key in the request's
+// bearer token, after checking that the key and its user may manage the
+// organization's members and teams.
+func (s *AuthnServer) scimAuthenticate(r *http.Request) (uuid.UUID, error) {
+	ctx := r.Context()
+
+	token, err := extractBearerToken(r.Header.Get("Authorization"))
+	if err != nil {
+		return uuid.Nil, newSCIMError(http.StatusUnauthorized, "", err.Error())
+	}
+	if !apitoken.IsAPIToken(token) || apitoken.ValidateFormat(token) != nil {
+		return uuid.Nil, newSCIMError(http.StatusUnauthorized, "", "invalid token")
+	}
+
+	apiKey, err := s.lookupAPIKey(ctx, token)
+	if err != nil {
+		if connect.CodeOf(err) == connect.CodeUnauthenticated {
+			return uuid.Nil, newSCIMError(http.StatusUnauthorized, "", err.Error())
+		}
+		return uuid.Nil, err
+	}
+
+	scope := apiKeyScope(apiKey)
+	if scope != nil && scope.RestrictsResources() {
+		return uuid.Nil, newSCIMError(http.StatusForbidden, "", "api key is restricted to projects or clusters")
+	}
+
+	for _, action := range scimActions {
+		if scope != nil && !scope.AllowsAction(string(action.Name)) {
+			return uuid.Nil, newSCIMError(http.StatusForbidden, "", fmt.Sprintf("api key scope does not allow %s", action.Name))
+		}
+
+		decision, err := s.authz.Evaluate(ctx, authz.EvaluationRequest{
+			Subject:  authz.User(apiKey.UserID),
+			Action:   action,
+			Resource: authz.Organization(apiKey.OrganizationID),
+		})
+		if err != nil {
+			return uuid.Nil, fmt.Errorf("evaluate %s: %w", action.Name, err)
+		}
+		if !decision.Decision {
+			return uuid.Nil, newSCIMError(http.StatusForbidden, "", "organization admin role required")
+		}
+	}
+
+	return apiKey.OrganizationID, nil
+}
+
+func (s *AuthnServer) scimServiceProviderConfig(w http.ResponseWriter, _ *http.Request, _ uuid.UUID) error {
+	type supported struct {
+		Supported bool `json:"supported"`
+	}
+	type filter struct {
+		Supported  bool `json:"supported"`
+		MaxResults int  `json:"maxResults"`
+	}
+	type bulk struct {
+		Supported      bool `json:"supported"`
+		MaxOperations  int  `json:"maxOperations"`
+		MaxPayloadSize int  `json:"maxPayloadSize"`
+	}
+	type authenticationScheme struct {
+		Type        string `json:"type"`
+		Name        string `json:"name"`
+		Description string `json:"description"`
+	}
+
+	s.writeSCIM(w, http.StatusOK, struct {
+		Schemas               []string               `json:"schemas"`
+		Patch                 supported              `json:"patch"`
+		Bulk                  bulk                   `json:"bulk"`
+		Filter                filter                 `json:"filter"`
+		ChangePassword        supported              `json:"changePassword"`
+		Sort                  supported              `json:"sort"`
+		ETag                  supported              `json:"etag"`
+		AuthenticationSchemes []authenticationScheme `json:"authenticationSchemes"`
+	}{
+		Schemas: []string{scimSchemaServiceConfig},
+		Patch:   supported{Supported: true},
+		Filter:  filter{Supported: true, MaxResults: scimMaxCount},
+		AuthenticationSchemes: []authenticationScheme{{
+			Type:        "oauthbearertoken",
+			Name:        "API key",
+			Description: "API key of an organization admin, sent as a bearer token",
+		}},
+	})
+	return nil
+}
+
+// writeSCIM writes a SCIM JSON response, logging any write failure.
+func (s *AuthnServer) writeSCIM(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", scimContentType)
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		s.logger.Error("failed to write SCIM response", "error", err)
+	}
+}
+
+// decodeSCIM decodes a SCIM request body into v.
+func decodeSCIM(r *http.Request, v any) error {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		return newSCIMError(http.StatusBadRequest, "invalidSyntax", fmt.Sprintf("invalid request body: %v", err))
+	}
+	return nil
+}
+
+// scimPathID parses the resource ID in the request path. An ID that is not
+// a UUID cannot name a resource, so it is reported as not found.
+func scimPathID(r *http.Request) (uuid.UUID, error) {
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		return uuid.Nil, newSCIMError(http.StatusNotFound, "", "resource not found")
+	}
+	return id, nil
+}
+
+// scimFilterPattern matches the only filter form identity providers use to
+// look up a resource before creating it: `attribute eq "value"`.
+var scimFilterPattern = regexp.MustCompile(`^\s*([A-Za-z][\w.]*)\s+(?i:eq)\s+("(?:[^"\\]|\\.)*")\s*$`)
+
+// parseSCIMFilter returns the value the filter compares the attribute with,
+// or an invalid filter text when filter is empty. Attribute names are case
+// insensitive.
+func parseSCIMFilter(filter, attribute string) (pgtype.Text, error) {
+	if filter == "" {
+		return pgtype.Text{}, nil
+	}
+
+	m := scimFilterPattern.FindStringSubmatch(filter)
+	if m == nil || !strings.EqualFold(m[1], attribute) {
+		return pgtype.Text{}, newSCIMError(http.StatusBadRequest, "invalidFilter",
+			fmt.Sprintf("only %s eq \"value\" filters are supported", attribute))
+	}
+
+	var value string
+	if err := json.Unmarshal([]byte(m[2]), &value); err != nil {
+		return pgtype.Text{}, newSCIMError(http.StatusBadRequest, "invalidFilter", "invalid filter value")
+	}
+	return pgtype.Text{String: value, Valid: true}, nil
+}
+
+// scimPage is the page of a list request, from its 1-based startIndex and
+// count query parameters.
+type scimPage struct {
+	startIndex int32
+	count      int32
+}
+
+func parseSCIMPage(r *http.Request) (scimPage, error) {
+	page := scimPage{startIndex: 1, count: scimDefaultCount}
+
+	if v := r.URL.Query().Get("startIndex"); v != "" {
+		n, err := strconv.ParseInt(v, 10, 32)
+		if err != nil {
+			return page, newSCIMError(http.StatusBadRequest, "invalidValue", "invalid startIndex")
+		}
+		page.startIndex = int32(max(n, 1))
+	}
+
+	if v := r.URL.Query().Get("count"); v != "" {
+		n, err := strconv.ParseInt(v, 10, 32)
+		if err != nil {
+			return page, newSCIMError(http.StatusBadRequest, "invalidValue", "invalid count")
+		}
+		page.count = int32(min(max(n, 0), scimMaxCount))
+	}
+
+	return page, nil
+}
+
+func (p scimPage) offset() int32 {
+	return p.startIndex - 1
+}
+
+// parseSCIMBool parses a boolean attribute value. Some identity providers
+// send booleans as strings, e.g. "False".
+func parseSCIMBool(raw json.RawMessage) (bool, error) {
+	var b bool
+	if err := json.Unmarshal(raw, &b); err == nil {
+		return b, nil
+	}
+
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		if b, err := strconv.ParseBool(s); err == nil {
+			return b, nil
+		}
+	}
+
+	return false, newSCIMError(http.StatusBadRequest, "invalidValue", "invalid boolean value")
+}
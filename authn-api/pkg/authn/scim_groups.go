@@ -0,0 +1,502 @@
+package authn
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	db "github.com/fundament-oss/fundament/authn-api/pkg/db/gen"
+	"github.com/fundament-oss/fundament/common/rollback"
+)
+
+// scimGroup is a SCIM Group resource. Every team linked to an identity
+// provider group is one: its id is the team's ID and its displayName the
+// team's OIDC group.
+type scimGroup struct {
+	Schemas     []string     `json:"schemas"`
+	ID          string       `json:"id,omitempty"`
+	DisplayName string       `json:"displayName"`
+	Members     []scimMember `json:"members,omitempty"`
+	Meta        *scimMeta    `json:"meta,omitempty"`
+}
+
+type scimMember struct {
+	Value   string `json:"value"`
+	Display string `json:"display,omitempty"`
+}
+
+// scimMemberFilterPattern matches the path identity providers use to remove
+// a single member: members[value eq "<user id>"].
+var scimMemberFilterPattern = regexp.MustCompile(`^(?i:members)\[\s*(?i:value)\s+(?i:eq)\s+"([^"]*)"\s*\]$`)
+
+// scimMemberIDs parses the user IDs of the members.
+func scimMemberIDs(members []scimMember) ([]uuid.UUID, error) {
+	ids := make([]uuid.UUID, 0, len(members))
+	for _, member := range members {
+		id, err := uuid.Parse(member.Value)
+		if err != nil {
+			return nil, newSCIMError(http.StatusBadRequest, "invalidValue", fmt.Sprintf("invalid member %q", member.Value))
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+func (s *AuthnServer) scimListGroups(w http.ResponseWriter, r *http.Request, organizationID uuid.UUID) error {
+	ctx := r.Context()
+
+	page, err := parseSCIMPage(r)
+	if err != nil {
+		return err
+	}
+
+	displayName, err := parseSCIMFilter(r.URL.Query().Get("filter"), "displayName")
+	if err != nil {
+		return err
+	}
+
+	rows, err := s.queries.ScimGroupList(ctx, db.ScimGroupListParams{
+		OrganizationID: organizationID,
+		DisplayName:    displayName,
+		PageLimit:      page.count,
+		PageOffset:     page.offset(),
+	})
+	if err != nil {
+		return fmt.Errorf("list groups: %w", err)
+	}
+
+	teamIDs := make([]uuid.UUID, 0, len(rows))
+	for _, row := range rows {
+		teamIDs = append(teamIDs, row.ID)
+	}
+
+	// Identity providers exclude members when they only look a group up.
+	var members map[uuid.UUID][]scimMember
+	if !strings.Contains(strings.ToLower(r.URL.Query().Get("excludedAttributes")), "members") {
+		members, err = s.scimGroupMembers(ctx, s.queries, teamIDs)
+		if err != nil {
+			return err
+		}
+	}
+
+	res := scimListResponse[scimGroup]{
+		Schemas:    []string{scimSchemaListResponse},
+		StartIndex: page.startIndex,
+		Resources:  make([]scimGroup, 0, len(rows)),
+	}
+	for _, row := range rows {
+		res.TotalResults = row.Total
+		res.Resources = append(res.Resources, scimGroupFromRow(&db.ScimGroupGetRow{
+			ID:          row.ID,
+			DisplayName: row.DisplayName,
+			Created:     row.Created,
+		}, members[row.ID]))
+	}
+	res.ItemsPerPage = len(res.Resources)
+
+	s.writeSCIM(w, http.StatusOK, res)
+	return nil
+}
+
+func (s *AuthnServer) scimGetGroup(w http.ResponseWriter, r *http.Request, organizationID uuid.UUID) error {
+	id, err := scimPathID(r)
+	if err != nil {
+		return err
+	}
+
+	group, err := s.scimGetGroupResource(r.Context(), s.queries, organizationID, id)
+	if err != nil {
+		return err
+	}
+
+	s.writeSCIM(w, http.StatusOK, group)
+	return nil
+}
+
+// scimCreateGroup creates a team linked to the group. The team has no
+// organization role until an admin gives it one; to map a group onto a role
+// up front, link an existing team to the group instead, which the identity
+// provider then finds by displayName.
+func (s *AuthnServer) scimCreateGroup(w http.ResponseWriter, r *http.Request, organizationID uuid.UUID) error {
+	ctx := r.Context()
+
+	var req scimGroup
+	if err := decodeSCIM(r, &req); err != nil {
+		return err
+	}
+	if req.DisplayName == "" {
+		return newSCIMError(http.StatusBadRequest, "invalidValue", "displayName is required")
+	}
+
+	memberIDs, err := scimMemberIDs(req.Members)
+	if err != nil {
+		return err
+	}
+
+	tx, err := s.db.Pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer rollback.Rollback(ctx, tx, s.logger)
+
+	qtx := s.queries.WithTx(tx)
+
+	_, err = qtx.ScimGroupGetByDisplayName(ctx, db.ScimGroupGetByDisplayNameParams{
+		OrganizationID: organizationID,
+		DisplayName:    req.DisplayName,
+	})
+	if err == nil {
+		return newSCIMError(http.StatusConflict, "uniqueness", "a team is already linked to the group")
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("get group by display name: %w", err)
+	}
+
+	teamID, err := s.scimCreateTeam(ctx, qtx, organizationID, req.DisplayName)
+	if err != nil {
+		return err
+	}
+
+	if len(memberIDs) > 0 {
+		if err := qtx.ScimGroupMemberAdd(ctx, db.ScimGroupMemberAddParams{UserIds: memberIDs, TeamID: teamID}); err != nil {
+			return fmt.Errorf("add group members: %w", err)
+		}
+	}
+
+	group, err := s.scimGetGroupResource(ctx, qtx, organizationID, teamID)
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+
+	s.logger.Info("scim group provisioned", "organization_id", organizationID, "team_id", teamID, "group", req.DisplayName)
+
+	s.writeSCIM(w, http.StatusCreated, group)
+	return nil
+}
+
+func (s *AuthnServer) scimReplaceGroup(w http.ResponseWriter, r *http.Request, organizationID uuid.UUID) error {
+	ctx := r.Context()
+
+	id, err := scimPathID(r)
+	if err != nil {
+		return err
+	}
+
+	var req scimGroup
+	if err := decodeSCIM(r, &req); err != nil {
+		return err
+	}
+
+	memberIDs, err := scimMemberIDs(req.Members)
+	if err != nil {
+		return err
+	}
+
+	tx, err := s.db.Pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer rollback.Rollback(ctx, tx, s.logger)
+
+	qtx := s.queries.WithTx(tx)
+
+	if err := s.scimRenameGroup(ctx, qtx, organizationID, id, req.DisplayName); err != nil {
+		return err
+	}
+	if err := s.scimReplaceGroupMembers(ctx, qtx, id, memberIDs); err != nil {
+		return err
+	}
+
+	group, err := s.scimGetGroupResource(ctx, qtx, organizationID, id)
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+
+	s.writeSCIM(w, http.StatusOK, group)
+	return nil
+}
+
+// scimPatchGroup applies operations on displayName and members. Removing the
+// members attribute without a value removes every member.
+func (s *AuthnServer) scimPatchGroup(w http.ResponseWriter, r *http.Request, organizationID uuid.UUID) error {
+	ctx := r.Context()
+
+	id, err := scimPathID(r)
+	if err != nil {
+		return err
+	}
+
+	var req scimPatchRequest
+	if err := decodeSCIM(r, &req); err != nil {
+		return err
+	}
+
+	tx, err := s.db.Pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer rollback.Rollback(ctx, tx, s.logger)
+
+	qtx := s.queries.WithTx(tx)
+
+	if _, err := s.scimGetGroupRow(ctx, qtx, organizationID, id); err != nil {
+		return err
+	}
+
+	for _, op := range req.Operations {
+		if err := s.scimApplyGroupOp(ctx, qtx, organizationID, id, op); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+// scimDeleteGroup unlinks the team from the group and removes the members
+// SCIM added. The team itself, its roles and the members added by other
+// means stay: an admin may have configured them.
+func (s *AuthnServer) scimDeleteGroup(w http.ResponseWriter, r *http.Request, organizationID uuid.UUID) error {
+	ctx := r.Context()
+
+	id, err := scimPathID(r)
+	if err != nil {
+		return err
+	}
+
+	tx, err := s.db.Pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer rollback.Rollback(ctx, tx, s.logger)
+
+	qtx := s.queries.WithTx(tx)
+
+	rows, err := qtx.ScimGroupUnlink(ctx, db.ScimGroupUnlinkParams{ID: id, OrganizationID: organizationID})
+	if err != nil {
+		return fmt.Errorf("unlink group: %w", err)
+	}
+	if rows == 0 {
+		return newSCIMError(http.StatusNotFound, "", "group not found")
+	}
+
+	if err := qtx.ScimGroupMemberRemoveScim(ctx, db.ScimGroupMemberRemoveScimParams{TeamID: id}); err != nil {
+		return fmt.Errorf("remove group members: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+
+	s.logger.Info("scim group deprovisioned", "organization_id", organizationID, "team_id", id)
+
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+func (s *AuthnServer) scimApplyGroupOp(ctx context.Context, qtx *db.Queries, organizationID, id uuid.UUID, op scimPatchOp) error {
+	kind := strings.ToLower(op.Op)
+
+	if m := scimMemberFilterPattern.FindStringSubmatch(op.Path); m != nil && kind == "remove" {
+		memberIDs, err := scimMemberIDs([]scimMember{{Value: m[1]}})
+		if err != nil {
+			return err
+		}
+		if err := qtx.ScimGroupMemberRemove(ctx, db.ScimGroupMemberRemoveParams{TeamID: id, UserIds: memberIDs}); err != nil {
+			return fmt.Errorf("remove group member: %w", err)
+		}
+		return nil
+	}
+
+	if kind == "remove" && strings.EqualFold(op.Path, "members") && len(op.Value) == 0 {
+		return s.scimReplaceGroupMembers(ctx, qtx, id, nil)
+	}
+
+	attributes, err := scimPatchAttributes(op)
+	if err != nil {
+		return err
+	}
+
+	for path, value := range attributes {
+		switch path {
+		case "displayname":
+			var displayName string
+			if err := json.Unmarshal(value, &displayName); err != nil {
+				return newSCIMError(http.StatusBadRequest, "invalidValue", "invalid displayName")
+			}
+			if kind != "remove" {
+				if err := s.scimRenameGroup(ctx, qtx, organizationID, id, displayName); err != nil {
+					return err
+				}
+			}
+		case "members":
+			var members []scimMember
+			if err := json.Unmarshal(value, &members); err != nil {
+				return newSCIMError(http.StatusBadRequest, "invalidValue", "invalid members")
+			}
+			memberIDs, err := scimMemberIDs(members)
+			if err != nil {
+				return err
+			}
+
+			switch kind {
+			case "add":
+				err = qtx.ScimGroupMemberAdd(ctx, db.ScimGroupMemberAddParams{UserIds: memberIDs, TeamID: id})
+			case "remove":
+				err = qtx.ScimGroupMemberRemove(ctx, db.ScimGroupMemberRemoveParams{TeamID: id, UserIds: memberIDs})
+			case "replace":
+				err = s.scimReplaceGroupMembers(ctx, qtx, id, memberIDs)
+			default:
+				return newSCIMError(http.StatusBadRequest, "invalidSyntax", fmt.Sprintf("unsupported operation %q", op.Op))
+			}
+			if err != nil {
+				return fmt.Errorf("%s group members: %w", kind, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// scimCreateTeam creates the team for a group, named after the group. A
+// number is appended to the name while it is taken.
+func (s *AuthnServer) scimCreateTeam(ctx context.Context, qtx *db.Queries, organizationID uuid.UUID, displayName string) (uuid.UUID, error) {
+	name := toName(displayName)
+
+	for attempt := 0; attempt < 10; attempt++ {
+		candidateName := name
+		if attempt > 0 {
+			suffix := fmt.Sprintf("-%d", attempt+1)
+			base := name
+			if len(base)+len(suffix) > 63 {
+				base = strings.TrimRight(base[:63-len(suffix)], "-")
+			}
+			candidateName = base + suffix
+		}
+
+		id, err := qtx.ScimGroupCreate(ctx, db.ScimGroupCreateParams{
+			OrganizationID: organizationID,
+			Name:           candidateName,
+			DisplayName:    displayName,
+		})
+		if err == nil {
+			return id, nil
+		}
+		if !errors.Is(err, pgx.ErrNoRows) {
+			return uuid.Nil, fmt.Errorf("create team: %w", err)
+		}
+	}
+
+	return uuid.Nil, newSCIMError(http.StatusConflict, "uniqueness", "no free team name for the group")
+}
+
+// scimRenameGroup links the team to the group under its new name. An empty
+// name leaves the team as it is.
+func (s *AuthnServer) scimRenameGroup(ctx context.Context, qtx *db.Queries, organizationID, id uuid.UUID, displayName string) error {
+	if displayName == "" {
+		return nil
+	}
+
+	rows, err := qtx.ScimGroupRename(ctx, db.ScimGroupRenameParams{
+		DisplayName:    displayName,
+		ID:             id,
+		OrganizationID: organizationID,
+	})
+	if err != nil {
+		return fmt.Errorf("rename group: %w", err)
+	}
+	if rows == 0 {
+		return newSCIMError(http.StatusNotFound, "", "group not found")
+	}
+	return nil
+}
+
+// scimReplaceGroupMembers makes the users the team's only members.
+func (s *AuthnServer) scimReplaceGroupMembers(ctx context.Context, qtx *db.Queries, id uuid.UUID, memberIDs []uuid.UUID) error {
+	if memberIDs == nil {
+		memberIDs = []uuid.UUID{}
+	}
+
+	if err := qtx.ScimGroupMemberRemoveOthers(ctx, db.ScimGroupMemberRemoveOthersParams{TeamID: id, UserIds: memberIDs}); err != nil {
+		return fmt.Errorf("remove group members: %w", err)
+	}
+	if len(memberIDs) == 0 {
+		return nil
+	}
+	if err := qtx.ScimGroupMemberAdd(ctx, db.ScimGroupMemberAddParams{UserIds: memberIDs, TeamID: id}); err != nil {
+		return fmt.Errorf("add group members: %w", err)
+	}
+	return nil
+}
+
+func (s *AuthnServer) scimGetGroupRow(ctx context.Context, q *db.Queries, organizationID, id uuid.UUID) (db.ScimGroupGetRow, error) {
+	row, err := q.ScimGroupGet(ctx, db.ScimGroupGetParams{ID: id, OrganizationID: organizationID})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return row, newSCIMError(http.StatusNotFound, "", "group not found")
+		}
+		return row, fmt.Errorf("get group: %w", err)
+	}
+	return row, nil
+}
+
+func (s *AuthnServer) scimGetGroupResource(ctx context.Context, q *db.Queries, organizationID, id uuid.UUID) (scimGroup, error) {
+	row, err := s.scimGetGroupRow(ctx, q, organizationID, id)
+	if err != nil {
+		return scimGroup{}, err
+	}
+
+	members, err := s.scimGroupMembers(ctx, q, []uuid.UUID{id})
+	if err != nil {
+		return scimGroup{}, err
+	}
+
+	return scimGroupFromRow(&row, members[id]), nil
+}
+
+// scimGroupMembers returns the members of the teams, whatever the source of
+// their membership, keyed by team ID.
+func (s *AuthnServer) scimGroupMembers(ctx context.Context, q *db.Queries, teamIDs []uuid.UUID) (map[uuid.UUID][]scimMember, error) {
+	members := make(map[uuid.UUID][]scimMember, len(teamIDs))
+	if len(teamIDs) == 0 {
+		return members, nil
+	}
+
+	rows, err := q.ScimGroupMemberList(ctx, db.ScimGroupMemberListParams{TeamIds: teamIDs})
+	if err != nil {
+		return nil, fmt.Errorf("list group members: %w", err)
+	}
+	for _, row := range rows {
+		members[row.TeamID] = append(members[row.TeamID], scimMember{Value: row.UserID.String(), Display: row.Name})
+	}
+	return members, nil
+}
+
+func scimGroupFromRow(row *db.ScimGroupGetRow, members []scimMember) scimGroup {
+	return scimGroup{
+		Schemas:     []string{scimSchemaGroup},
+		ID:          row.ID.String(),
+		DisplayName: row.DisplayName,
+		Members:     members,
+		Meta:        &scimMeta{ResourceType: "Group", Created: row.Created.Time},
+	}
+}
@@ -0,0 +1,134 @@
+package authn
+
+import (
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSCIMHandler_RejectsMissingOrNonAPIKeyToken(t *testing.T) {
+	server := &AuthnServer{logger: slog.New(slog.NewTextHandler(io.Discard, nil))}
+	srv := httptest.NewServer(server.SCIMHandler())
+	t.Cleanup(srv.Close)
+
+	for _, authHeader := range []string{"", "Bearer not-an-api-key"} {
+		req, err := http.NewRequest(http.MethodGet, srv.URL+SCIMPath+"Users", nil)
+		require.NoError(t, err)
+		if authHeader != "" {
+			req.Header.Set("Authorization", authHeader)
+		}
+
+		res, err := srv.Client().Do(req)
+		require.NoError(t, err)
+
+		require.Equal(t, http.StatusUnauthorized, res.StatusCode)
+		require.Equal(t, scimContentType, res.Header.Get("Content-Type"))
+
+		var body scimErrorResponse
+		require.NoError(t, json.NewDecoder(res.Body).Decode(&body))
+		require.NoError(t, res.Body.Close())
+		require.Equal(t, []string{scimSchemaError}, body.Schemas)
+		require.Equal(t, "401", body.Status)
+	}
+}
+
+func TestParseSCIMFilter(t *testing.T) {
+	value, err := parseSCIMFilter(`userName eq "alice@example.com"`, "userName")
+	require.NoError(t, err)
+	require.True(t, value.Valid)
+	require.Equal(t, "alice@example.com", value.String)
+
+	// Attribute names and the operator are case insensitive; the value is a
+	// JSON string.
+	value, err = parseSCIMFilter(`displayname EQ "Platform \"Admins\""`, "displayName")
+	require.NoError(t, err)
+	require.Equal(t, `Platform "Admins"`, value.String)
+
+	value, err = parseSCIMFilter("", "userName")
+	require.NoError(t, err)
+	require.False(t, value.Valid)
+
+	for _, filter := range []string{
+		`displayName eq "admins"`,
+		`userName sw "alice"`,
+		`userName eq "a" and active eq true`,
+	} {
+		_, err := parseSCIMFilter(filter, "userName")
+		var scimErr *scimError
+		require.ErrorAs(t, err, &scimErr, filter)
+		require.Equal(t, "invalidFilter", scimErr.scimType)
+	}
+}
+
+func TestParseSCIMPage(t *testing.T) {
+	page, err := parseSCIMPage(httptest.NewRequest(http.MethodGet, "/Users", nil))
+	require.NoError(t, err)
+	require.Equal(t, scimPage{startIndex: 1, count: scimDefaultCount}, page)
+	require.Equal(t, int32(0), page.offset())
+
+	page, err = parseSCIMPage(httptest.NewRequest(http.MethodGet, "/Users?startIndex=11&count=10", nil))
+	require.NoError(t, err)
+	require.Equal(t, int32(10), page.offset())
+	require.Equal(t, int32(10), page.count)
+
+	page, err = parseSCIMPage(httptest.NewRequest(http.MethodGet, "/Users?startIndex=0&count=100000", nil))
+	require.NoError(t, err)
+	require.Equal(t, int32(1), page.startIndex)
+	require.Equal(t, int32(scimMaxCount), page.count)
+
+	_, err = parseSCIMPage(httptest.NewRequest(http.MethodGet, "/Users?count=ten", nil))
+	require.Error(t, err)
+}
+
+func TestParseSCIMBool(t *testing.T) {
+	for raw, want := range map[string]bool{`true`: true, `false`: false, `"False"`: false, `"True"`: true} {
+		got, err := parseSCIMBool(json.RawMessage(raw))
+		require.NoError(t, err, raw)
+		require.Equal(t, want, got, raw)
+	}
+
+	_, err := parseSCIMBool(json.RawMessage(`"maybe"`))
+	require.Error(t, err)
+}
+
+func TestSCIMUser_EmailAndDisplayName(t *testing.T) {
+	u := scimUser{
+		UserName: "alice",
+		Emails: []scimEmail{
+			{Value: "alice@home.example"},
+			{Value: "alice@example.com", Primary: true},
+		},
+		Name: &scimName{GivenName: "Alice", FamilyName: "Smith"},
+	}
+	require.Equal(t, "alice@example.com", u.email())
+	require.Equal(t, "Alice Smith", u.displayName())
+
+	u = scimUser{UserName: "bob@example.com"}
+	require.Equal(t, "bob@example.com", u.email())
+	require.Equal(t, "bob@example.com", u.displayName())
+}
+
+func TestSCIMPatchAttributes(t *testing.T) {
+	attributes, err := scimPatchAttributes(scimPatchOp{Op: "replace", Path: "active", Value: json.RawMessage(`false`)})
+	require.NoError(t, err)
+	require.Equal(t, json.RawMessage(`false`), attributes["active"])
+
+	attributes, err = scimPatchAttributes(scimPatchOp{Op: "replace", Value: json.RawMessage(`{"displayName":"Alice","Active":true}`)})
+	require.NoError(t, err)
+	require.Equal(t, json.RawMessage(`"Alice"`), attributes["displayname"])
+	require.Equal(t, json.RawMessage(`true`), attributes["active"])
+
+	_, err = scimPatchAttributes(scimPatchOp{Op: "replace", Value: json.RawMessage(`false`)})
+	require.Error(t, err)
+}
+
+func TestSCIMMemberFilterPattern(t *testing.T) {
+	m := scimMemberFilterPattern.FindStringSubmatch(`members[value eq "0190c1f2-0000-7000-8000-000000000001"]`)
+	require.Equal(t, "0190c1f2-0000-7000-8000-000000000001", m[1])
+	require.Nil(t, scimMemberFilterPattern.FindStringSubmatch("members"))
+}
@@ -0,0 +1,428 @@
+package authn
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	db "github.com/fundament-oss/fundament/authn-api/pkg/db/gen"
+	"github.com/fundament-oss/fundament/common/rollback"
+)
+
+// scimUser is a SCIM User resource. userName is the user's email address,
+// which is how an invited user is matched when they first log in.
+type scimUser struct {
+	Schemas     []string    `json:"schemas"`
+	ID          string      `json:"id,omitempty"`
+	UserName    string      `json:"userName"`
+	DisplayName string      `json:"displayName,omitempty"`
+	Name        *scimName   `json:"name,omitempty"`
+	Emails      []scimEmail `json:"emails,omitempty"`
+	Active      *bool       `json:"active,omitempty"`
+	Meta        *scimMeta   `json:"meta,omitempty"`
+}
+
+type scimName struct {
+	Formatted  string `json:"formatted,omitempty"`
+	GivenName  string `json:"givenName,omitempty"`
+	FamilyName string `json:"familyName,omitempty"`
+}
+
+type scimEmail struct {
+	Value   string `json:"value"`
+	Type    string `json:"type,omitempty"`
+	Primary bool   `json:"primary,omitempty"`
+}
+
+// email returns the primary email address, falling back to the first email
+// address and then to userName.
+func (u *scimUser) email() string {
+	for _, email := range u.Emails {
+		if email.Primary && email.Value != "" {
+			return email.Value
+		}
+	}
+	if len(u.Emails) > 0 && u.Emails[0].Value != "" {
+		return u.Emails[0].Value
+	}
+	return u.UserName
+}
+
+// displayName returns the name to show for the user until they log in.
+func (u *scimUser) displayName() string {
+	if u.DisplayName != "" {
+		return u.DisplayName
+	}
+	if u.Name != nil {
+		if u.Name.Formatted != "" {
+			return u.Name.Formatted
+		}
+		if name := strings.TrimSpace(u.Name.GivenName + " " + u.Name.FamilyName); name != "" {
+			return name
+		}
+	}
+	return u.email()
+}
+
+func scimUserFromRow(row *db.ScimUserGetRow) scimUser {
+	userName := row.Email.String
+	if !row.Email.Valid {
+		userName = row.Name
+	}
+
+	u := scimUser{
+		Schemas:     []string{scimSchemaUser},
+		ID:          row.ID.String(),
+		UserName:    userName,
+		DisplayName: row.Name,
+		Active:      &row.Active,
+		Meta:        &scimMeta{ResourceType: "User", Created: row.Created.Time},
+	}
+	if row.Email.Valid {
+		u.Emails = []scimEmail{{Value: row.Email.String, Type: "work", Primary: true}}
+	}
+	return u
+}
+
+func (s *AuthnServer) scimListUsers(w http.ResponseWriter, r *http.Request, organizationID uuid.UUID) error {
+	page, err := parseSCIMPage(r)
+	if err != nil {
+		return err
+	}
+
+	email, err := parseSCIMFilter(r.URL.Query().Get("filter"), "userName")
+	if err != nil {
+		return err
+	}
+
+	rows, err := s.queries.ScimUserList(r.Context(), db.ScimUserListParams{
+		OrganizationID: organizationID,
+		Email:          email,
+		PageLimit:      page.count,
+		PageOffset:     page.offset(),
+	})
+	if err != nil {
+		return fmt.Errorf("list users: %w", err)
+	}
+
+	res := scimListResponse[scimUser]{
+		Schemas:    []string{scimSchemaListResponse},
+		StartIndex: page.startIndex,
+		Resources:  make([]scimUser, 0, len(rows)),
+	}
+	for _, row := range rows {
+		res.TotalResults = row.Total
+		res.Resources = append(res.Resources, scimUserFromRow(&db.ScimUserGetRow{
+			ID:      row.ID,
+			Name:    row.Name,
+			Email:   row.Email,
+			Created: row.Created,
+			Active:  row.Active,
+		}))
+	}
+	res.ItemsPerPage = len(res.Resources)
+
+	s.writeSCIM(w, http.StatusOK, res)
+	return nil
+}
+
+func (s *AuthnServer) scimGetUser(w http.ResponseWriter, r *http.Request, organizationID uuid.UUID) error {
+	id, err := scimPathID(r)
+	if err != nil {
+		return err
+	}
+
+	row, err := s.scimGetUserRow(r.Context(), s.queries, organizationID, id)
+	if err != nil {
+		return err
+	}
+
+	u := scimUserFromRow(&row)
+	s.writeSCIM(w, http.StatusOK, u)
+	return nil
+}
+
+// scimCreateUser makes the user an organization member, creating the user
+// if no user has the email address yet. A user who already existed is only
+// invited: they may have declined or never answered an invitation, and SCIM
+// does not decide for them. A user who already is a member is a conflict,
+// which tells the identity provider to look the user up instead.
+func (s *AuthnServer) scimCreateUser(w http.ResponseWriter, r *http.Request, organizationID uuid.UUID) error {
+	ctx := r.Context()
+
+	var req scimUser
+	if err := decodeSCIM(r, &req); err != nil {
+		return err
+	}
+
+	email := req.email()
+	if email == "" {
+		return newSCIMError(http.StatusBadRequest, "invalidValue", "userName is required")
+	}
+
+	tx, err := s.db.Pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer rollback.Rollback(ctx, tx, s.logger)
+
+	qtx := s.queries.WithTx(tx)
+
+	userID, err := qtx.ScimUserGetByEmail(ctx, db.ScimUserGetByEmailParams{Email: email})
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		userID, err = qtx.ScimUserCreate(ctx, db.ScimUserCreateParams{
+			Name:           req.displayName(),
+			Email:          email,
+			OrganizationID: organizationID,
+		})
+		if err != nil {
+			return fmt.Errorf("create user: %w", err)
+		}
+	case err != nil:
+		return fmt.Errorf("get user by email: %w", err)
+	default:
+		existing, err := qtx.ScimUserGet(ctx, db.ScimUserGetParams{OrganizationID: organizationID, ID: userID})
+		if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("get user: %w", err)
+		}
+		if err == nil && existing.Active {
+			return newSCIMError(http.StatusConflict, "uniqueness", "user is already a member of the organization")
+		}
+	}
+
+	// A user created inactive still gets a (revoked) membership, so the
+	// identity provider can find and activate them later.
+	if err := s.scimSetUserActive(ctx, qtx, organizationID, userID, true); err != nil {
+		return err
+	}
+	if req.Active != nil && !*req.Active {
+		if err := s.scimSetUserActive(ctx, qtx, organizationID, userID, false); err != nil {
+			return err
+		}
+	}
+
+	row, err := qtx.ScimUserGet(ctx, db.ScimUserGetParams{OrganizationID: organizationID, ID: userID})
+	if err != nil {
+		return fmt.Errorf("get user: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+
+	s.logger.Info("scim user provisioned", "organization_id", organizationID, "user_id", userID, "active", row.Active)
+
+	u := scimUserFromRow(&row)
+	s.writeSCIM(w, http.StatusCreated, u)
+	return nil
+}
+
+func (s *AuthnServer) scimReplaceUser(w http.ResponseWriter, r *http.Request, organizationID uuid.UUID) error {
+	id, err := scimPathID(r)
+	if err != nil {
+		return err
+	}
+
+	var req scimUser
+	if err := decodeSCIM(r, &req); err != nil {
+		return err
+	}
+
+	active := req.Active == nil || *req.Active
+	return s.scimUpdateUser(w, r, organizationID, id, req.displayName(), &active)
+}
+
+// scimPatchUser applies the replace and add operations on active,
+// displayName and name.formatted. Operations on other attributes are
+// ignored: Fundament does not store them.
+func (s *AuthnServer) scimPatchUser(w http.ResponseWriter, r *http.Request, organizationID uuid.UUID) error {
+	id, err := scimPathID(r)
+	if err != nil {
+		return err
+	}
+
+	var req scimPatchRequest
+	if err := decodeSCIM(r, &req); err != nil {
+		return err
+	}
+
+	var (
+		name   string
+		active *bool
+	)
+	for _, op := range req.Operations {
+		if !strings.EqualFold(op.Op, "replace") && !strings.EqualFold(op.Op, "add") {
+			continue
+		}
+
+		attributes, err := scimPatchAttributes(op)
+		if err != nil {
+			return err
+		}
+
+		for path, value := range attributes {
+			switch path {
+			case "active":
+				b, err := parseSCIMBool(value)
+				if err != nil {
+					return err
+				}
+				active = &b
+			case "displayname", "name.formatted":
+				if err := json.Unmarshal(value, &name); err != nil {
+					return newSCIMError(http.StatusBadRequest, "invalidValue", "invalid name")
+				}
+			}
+		}
+	}
+
+	return s.scimUpdateUser(w, r, organizationID, id, name, active)
+}
+
+// scimDeleteUser revokes the user's membership, including a pending
+// invitation. The user itself stays: it may be a member of other
+// organizations.
+func (s *AuthnServer) scimDeleteUser(w http.ResponseWriter, r *http.Request, organizationID uuid.UUID) error {
+	id, err := scimPathID(r)
+	if err != nil {
+		return err
+	}
+
+	ctx := r.Context()
+
+	tx, err := s.db.Pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer rollback.Rollback(ctx, tx, s.logger)
+
+	qtx := s.queries.WithTx(tx)
+
+	if _, err := s.scimGetUserRow(ctx, qtx, organizationID, id); err != nil {
+		return err
+	}
+
+	if err := s.scimSetUserActive(ctx, qtx, organizationID, id, false); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+
+	s.logger.Info("scim user deprovisioned", "organization_id", organizationID, "user_id", id)
+
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+// scimUpdateUser sets the user's name, when not empty and the organization's
+// SCIM provisioning created the user, and activates or deactivates their
+// membership, when active is set, and responds with the updated user.
+func (s *AuthnServer) scimUpdateUser(w http.ResponseWriter, r *http.Request, organizationID, id uuid.UUID, name string, active *bool) error {
+	ctx := r.Context()
+
+	tx, err := s.db.Pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer rollback.Rollback(ctx, tx, s.logger)
+
+	qtx := s.queries.WithTx(tx)
+
+	row, err := s.scimGetUserRow(ctx, qtx, organizationID, id)
+	if err != nil {
+		return err
+	}
+
+	if name != "" && name != row.Name {
+		if err := qtx.ScimUserSetName(ctx, db.ScimUserSetNameParams{Name: name, ID: id, OrganizationID: organizationID}); err != nil {
+			return fmt.Errorf("set user name: %w", err)
+		}
+	}
+
+	if active != nil && *active != row.Active {
+		if err := s.scimSetUserActive(ctx, qtx, organizationID, id, *active); err != nil {
+			return err
+		}
+	}
+
+	row, err = qtx.ScimUserGet(ctx, db.ScimUserGetParams{OrganizationID: organizationID, ID: id})
+	if err != nil {
+		return fmt.Errorf("get user: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+
+	u := scimUserFromRow(&row)
+	s.writeSCIM(w, http.StatusOK, u)
+	return nil
+}
+
+// scimSetUserActive activates the user's membership, or revokes it. A user
+// the organization's SCIM provisioning created has a pending invitation
+// accepted or is made a viewer; any other user is invited and stays inactive
+// until they accept. Only accepted memberships count as active. A deactivated user also
+// leaves the organization's teams, so no team keeps granting them a role.
+// The triggers on the membership and team tables queue the authz and
+// cluster user sync.
+func (s *AuthnServer) scimSetUserActive(ctx context.Context, qtx *db.Queries, organizationID, userID uuid.UUID, active bool) error {
+	if active {
+		if err := qtx.ScimMembershipAccept(ctx, db.ScimMembershipAcceptParams{OrganizationID: organizationID, UserID: userID}); err != nil {
+			return fmt.Errorf("accept membership: %w", err)
+		}
+		if err := qtx.ScimMembershipCreate(ctx, db.ScimMembershipCreateParams{OrganizationID: organizationID, UserID: userID}); err != nil {
+			return fmt.Errorf("create membership: %w", err)
+		}
+		return nil
+	}
+
+	if err := qtx.ScimMembershipDeactivate(ctx, db.ScimMembershipDeactivateParams{OrganizationID: organizationID, UserID: userID}); err != nil {
+		return fmt.Errorf("deactivate membership: %w", err)
+	}
+	if err := qtx.ScimTeamMembersRemoveForUser(ctx, db.ScimTeamMembersRemoveForUserParams{UserID: userID, OrganizationID: organizationID}); err != nil {
+		return fmt.Errorf("remove team memberships: %w", err)
+	}
+	return nil
+}
+
+// scimGetUserRow gets a user that is or was a member of the organization.
+func (s *AuthnServer) scimGetUserRow(ctx context.Context, q *db.Queries, organizationID, id uuid.UUID) (db.ScimUserGetRow, error) {
+	row, err := q.ScimUserGet(ctx, db.ScimUserGetParams{OrganizationID: organizationID, ID: id})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return row, newSCIMError(http.StatusNotFound, "", "user not found")
+		}
+		return row, fmt.Errorf("get user: %w", err)
+	}
+	return row, nil
+}
+
+// scimPatchAttributes returns the attributes a patch operation sets, keyed
+// by lower-cased path. An operation without a path carries its attributes
+// as an object.
+func scimPatchAttributes(op scimPatchOp) (map[string]json.RawMessage, error) {
+	if op.Path != "" {
+		return map[string]json.RawMessage{strings.ToLower(op.Path): op.Value}, nil
+	}
+
+	var values map[string]json.RawMessage
+	if err := json.Unmarshal(op.Value, &values); err != nil {
+		return nil, newSCIMError(http.StatusBadRequest, "invalidValue", "operation without a path needs an object value")
+	}
+
+	attributes := make(map[string]json.RawMessage, len(values))
+	for path, value := range values {
+		attributes[strings.ToLower(path)] = value
+	}
+	return attributes, nil
+}
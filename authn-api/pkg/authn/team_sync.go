@@ -0,0 +1,49 @@
+package authn
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	db "github.com/fundament-oss/fundament/authn-api/pkg/db/gen"
+	"github.com/fundament-oss/fundament/common/rollback"
+)
+
+// syncTeamMemberships brings the user's OIDC-sourced team memberships in line
+// with the groups claim of this login: teams linked to one of the groups gain
+// the user, and memberships from groups the user lost are removed. Manual
+// memberships are never touched. authz-worker picks up the changes through
+// the authz outbox.
+func (s *AuthnServer) syncTeamMemberships(ctx context.Context, userID uuid.UUID, groups []string) error {
+	if groups == nil {
+		groups = []string{}
+	}
+
+	tx, err := s.db.Pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer rollback.Rollback(ctx, tx, s.logger)
+
+	qtx := s.queries.WithTx(tx)
+
+	if err := qtx.TeamMemberRemoveStaleOidc(ctx, db.TeamMemberRemoveStaleOidcParams{
+		UserID: userID,
+		Groups: groups,
+	}); err != nil {
+		return fmt.Errorf("remove stale team memberships: %w", err)
+	}
+
+	if err := qtx.TeamMemberAddFromGroups(ctx, db.TeamMemberAddFromGroupsParams{
+		UserID: userID,
+		Groups: groups,
+	}); err != nil {
+		return fmt.Errorf("add team memberships: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+	return nil
+}
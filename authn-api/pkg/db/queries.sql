@@ -61,9 +61,275 @@ ORDER BY organizations_users.created ASC;
 
 -- name: APIKeyGetByHash :one
 -- Uses SECURITY DEFINER function to bypass RLS (we don't know org_id before lookup)
-SELECT id, organization_id, user_id, name, token_prefix, expires, revoked, last_used, created, deleted
+SELECT id, organization_id, user_id, name, token_prefix, expires, revoked, last_used, created, deleted,
+  scope_project_ids, scope_cluster_ids, scope_actions, scope_read_only
 FROM authn.api_key_get_by_hash($1);
 
 -- name: APIKeyUpdateLastUsed :exec
 -- Uses SECURITY DEFINER function to bypass RLS
 SELECT authn.api_key_update_last_used($1);
+
+-- name: TeamMemberAddFromGroups :exec
+-- Adds the user to every team linked to one of their OIDC groups, in the
+-- organizations they are an accepted member of. Existing memberships,
+-- including manual ones, are left as they are.
+INSERT INTO tenant.team_members (team_id, user_id, source)
+SELECT teams.id, organizations_users.user_id, 'oidc'
+FROM tenant.teams
+INNER JOIN tenant.organizations_users
+    ON organizations_users.organization_id = teams.organization_id
+    AND organizations_users.user_id = @user_id
+    AND organizations_users.status = 'accepted'
+    AND organizations_users.deleted IS NULL
+WHERE teams.oidc_group = ANY(@groups::text[])
+    AND teams.deleted IS NULL
+ON CONFLICT ON CONSTRAINT team_members_uq_team_user DO NOTHING;
+
+-- name: TeamMemberRemoveStaleOidc :exec
+-- Removes the user's memberships that came from an OIDC group they no longer
+-- have, or from a team that is no longer linked to one of their groups.
+UPDATE tenant.team_members
+SET deleted = now()
+WHERE team_members.user_id = @user_id
+    AND team_members.source = 'oidc'
+    AND team_members.deleted IS NULL
+    AND NOT EXISTS (
+        SELECT 1 FROM tenant.teams
+        WHERE teams.id = team_members.team_id
+            AND teams.oidc_group = ANY(@groups::text[])
+    );
+
+-- name: ScimUserList :many
+-- Lists the users that are or were a member of the organization, with their
+-- latest membership deciding whether they are active. total is the number of
+-- matching users across all pages.
+SELECT
+    users.id,
+    users.name,
+    users.email,
+    users.created,
+    (memberships.deleted IS NULL AND memberships.status = 'accepted')::boolean AS active,
+    count(*) OVER () AS total
+FROM tenant.users
+INNER JOIN LATERAL (
+    SELECT organizations_users.status, organizations_users.deleted
+    FROM tenant.organizations_users
+    WHERE organizations_users.organization_id = @organization_id
+        AND organizations_users.user_id = users.id
+    ORDER BY organizations_users.created DESC
+    LIMIT 1
+) AS memberships ON true
+WHERE users.deleted IS NULL
+    AND (sqlc.narg('email')::text IS NULL OR lower(users.email) = lower(sqlc.narg('email')::text))
+ORDER BY users.created, users.id
+LIMIT @page_limit OFFSET @page_offset;
+
+-- name: ScimUserGet :one
+SELECT
+    users.id,
+    users.name,
+    users.email,
+    users.created,
+    (memberships.deleted IS NULL AND memberships.status = 'accepted')::boolean AS active
+FROM tenant.users
+INNER JOIN LATERAL (
+    SELECT organizations_users.status, organizations_users.deleted
+    FROM tenant.organizations_users
+    WHERE organizations_users.organization_id = @organization_id
+        AND organizations_users.user_id = users.id
+    ORDER BY organizations_users.created DESC
+    LIMIT 1
+) AS memberships ON true
+WHERE users.id = @id
+    AND users.deleted IS NULL;
+
+-- name: ScimUserGetByEmail :one
+-- Finds the user an identity provider pushes by email, whether or not they
+-- have logged in yet.
+SELECT id
+FROM tenant.users
+WHERE lower(email) = lower(@email::text)
+    AND deleted IS NULL
+ORDER BY created
+LIMIT 1;
+
+-- name: ScimUserCreate :one
+-- Creates a user the organization's identity provider pushes, recording that
+-- the organization's SCIM provisioning owns the user's name.
+INSERT INTO tenant.users (name, email, scim_organization_id)
+VALUES (@name, @email::text, @organization_id::uuid)
+RETURNING id;
+
+-- name: ScimUserSetName :exec
+-- Only names of users the organization's SCIM provisioning created and who
+-- have not logged in yet are set. Users are shared between organizations, so
+-- no other organization may rename them; after the first login the name
+-- comes from the ID token.
+UPDATE tenant.users
+SET name = @name
+WHERE id = @id
+    AND scim_organization_id = @organization_id::uuid
+    AND external_ref IS NULL
+    AND deleted IS NULL;
+
+-- name: ScimMembershipAccept :exec
+-- Accepts a pending invitation on behalf of the user, if the organization's
+-- SCIM provisioning created the user. Anyone else decides for themselves.
+UPDATE tenant.organizations_users
+SET status = 'accepted'
+FROM tenant.users
+WHERE organizations_users.organization_id = @organization_id
+    AND organizations_users.user_id = @user_id
+    AND organizations_users.status = 'pending'
+    AND organizations_users.deleted IS NULL
+    AND users.id = organizations_users.user_id
+    AND users.scim_organization_id = @organization_id;
+
+-- name: ScimMembershipCreate :exec
+-- Makes the user an organization viewer unless they already are a member.
+-- A user the organization's SCIM provisioning created joins right away; any
+-- other user is invited and becomes a member once they accept. Teams linked
+-- to the user's groups grant anything more.
+INSERT INTO tenant.organizations_users (organization_id, user_id, permission, status)
+SELECT
+    @organization_id::uuid,
+    users.id,
+    'viewer',
+    CASE WHEN users.scim_organization_id = @organization_id::uuid THEN 'accepted' ELSE 'pending' END
+FROM tenant.users
+WHERE users.id = @user_id
+ON CONFLICT (organization_id, user_id) WHERE deleted IS NULL AND status NOT IN ('declined', 'revoked')
+DO NOTHING;
+
+-- name: ScimMembershipDeactivate :exec
+UPDATE tenant.organizations_users
+SET deleted = now(), status = 'revoked'
+WHERE organization_id = @organization_id
+    AND user_id = @user_id
+    AND deleted IS NULL;
+
+-- name: ScimTeamMembersRemoveForUser :exec
+-- Removes the user from every team of the organization, whatever the source
+-- of the membership, so a deactivated user keeps no role through a team.
+UPDATE tenant.team_members
+SET deleted = now()
+WHERE team_members.user_id = @user_id
+    AND team_members.deleted IS NULL
+    AND EXISTS (
+        SELECT 1 FROM tenant.teams
+        WHERE teams.id = team_members.team_id
+            AND teams.organization_id = @organization_id
+    );
+
+-- name: ScimGroupList :many
+-- Lists the organization's teams that are linked to an identity provider
+-- group. total is the number of matching teams across all pages.
+SELECT
+    teams.id,
+    teams.oidc_group::text AS display_name,
+    teams.created,
+    count(*) OVER () AS total
+FROM tenant.teams
+WHERE teams.organization_id = @organization_id
+    AND teams.oidc_group IS NOT NULL
+    AND teams.deleted IS NULL
+    AND (sqlc.narg('display_name')::text IS NULL OR teams.oidc_group = sqlc.narg('display_name')::text)
+ORDER BY teams.created, teams.id
+LIMIT @page_limit OFFSET @page_offset;
+
+-- name: ScimGroupGet :one
+SELECT
+    teams.id,
+    teams.oidc_group::text AS display_name,
+    teams.created
+FROM tenant.teams
+WHERE teams.id = @id
+    AND teams.organization_id = @organization_id
+    AND teams.oidc_group IS NOT NULL
+    AND teams.deleted IS NULL;
+
+-- name: ScimGroupGetByDisplayName :one
+-- Finds the team an administrator already linked to the group. When several
+-- teams share the group, the oldest one represents it.
+SELECT id
+FROM tenant.teams
+WHERE organization_id = @organization_id
+    AND oidc_group = @display_name::text
+    AND deleted IS NULL
+ORDER BY created, id
+LIMIT 1;
+
+-- name: ScimGroupCreate :one
+-- Returns no row when the organization already has a team with the name.
+INSERT INTO tenant.teams (organization_id, name, oidc_group)
+VALUES (@organization_id, @name, @display_name::text)
+ON CONFLICT ON CONSTRAINT teams_uq_organization_name DO NOTHING
+RETURNING id;
+
+-- name: ScimGroupRename :execrows
+UPDATE tenant.teams
+SET oidc_group = @display_name::text
+WHERE id = @id
+    AND organization_id = @organization_id
+    AND oidc_group IS NOT NULL
+    AND deleted IS NULL;
+
+-- name: ScimGroupUnlink :execrows
+-- Unlinks the team from its group. The team and its roles stay; only the
+-- memberships pushed over SCIM are removed, by ScimGroupMemberRemoveScim.
+UPDATE tenant.teams
+SET oidc_group = NULL
+WHERE id = @id
+    AND organization_id = @organization_id
+    AND oidc_group IS NOT NULL
+    AND deleted IS NULL;
+
+-- name: ScimGroupMemberList :many
+SELECT
+    team_members.team_id,
+    team_members.user_id,
+    users.name
+FROM tenant.team_members
+INNER JOIN tenant.users
+    ON users.id = team_members.user_id
+WHERE team_members.team_id = ANY(@team_ids::uuid[])
+    AND team_members.deleted IS NULL
+    AND users.deleted IS NULL
+ORDER BY team_members.created, team_members.id;
+
+-- name: ScimGroupMemberAdd :exec
+-- Adds the users to the team. Users who are not an accepted member of the
+-- team's organization are skipped, as are existing memberships.
+INSERT INTO tenant.team_members (team_id, user_id, source)
+SELECT teams.id, organizations_users.user_id, 'scim'
+FROM tenant.teams
+INNER JOIN tenant.organizations_users
+    ON organizations_users.organization_id = teams.organization_id
+    AND organizations_users.user_id = ANY(@user_ids::uuid[])
+    AND organizations_users.status = 'accepted'
+    AND organizations_users.deleted IS NULL
+WHERE teams.id = @team_id
+    AND teams.deleted IS NULL
+ON CONFLICT ON CONSTRAINT team_members_uq_team_user DO NOTHING;
+
+-- name: ScimGroupMemberRemove :exec
+UPDATE tenant.team_members
+SET deleted = now()
+WHERE team_id = @team_id
+    AND user_id = ANY(@user_ids::uuid[])
+    AND deleted IS NULL;
+
+-- name: ScimGroupMemberRemoveOthers :exec
+-- Removes every member of the team that is not one of the given users.
+UPDATE tenant.team_members
+SET deleted = now()
+WHERE team_id = @team_id
+    AND NOT (user_id = ANY(@user_ids::uuid[]))
+    AND deleted IS NULL;
+
+-- name: ScimGroupMemberRemoveScim :exec
+UPDATE tenant.team_members
+SET deleted = now()
+WHERE team_id = @team_id
+    AND source = 'scim'
+    AND deleted IS NULL;
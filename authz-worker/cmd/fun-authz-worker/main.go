@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"errors"
+	"flag"
 	"fmt"
 	"log"
 	"log/slog"
@@ -17,6 +18,7 @@ import (
 	"github.com/openfga/go-sdk/client"
 	"golang.org/x/sync/errgroup"
 
+	"github.com/fundament-oss/fundament/authz-worker/pkg/reconcile"
 	"github.com/fundament-oss/fundament/authz-worker/pkg/worker"
 	"github.com/fundament-oss/fundament/common/authz"
 	"github.com/fundament-oss/fundament/common/dbversion"
@@ -35,6 +37,38 @@ type config struct {
 	BackoffDelay    time.Duration `env:"BACKOFF_DELAY" envDefault:"5s"`
 	HealthPort      int           `env:"HEALTH_PORT" envDefault:"8097"`
 	ShutdownTimeout time.Duration `env:"SHUTDOWN_TIMEOUT" envDefault:"30s"`
+
+	// ReconcileInterval is the time between two comparisons of the OpenFGA
+	// store with the database; 0 disables them.
+	ReconcileInterval time.Duration `env:"RECONCILE_INTERVAL" envDefault:"1h"`
+	ReconcileRepair   bool          `env:"RECONCILE_REPAIR" envDefault:"false"`
+}
+
+// command holds the command line arguments. Without arguments the worker runs;
+// "reconcile [-repair]" compares the OpenFGA store with the database once.
+type command struct {
+	reconcile bool
+	repair    bool
+}
+
+func parseCommand(args []string) (command, error) {
+	if len(args) == 0 {
+		return command{}, nil
+	}
+	if args[0] != "reconcile" {
+		return command{}, fmt.Errorf("unknown command %q", args[0])
+	}
+
+	fs := flag.NewFlagSet("reconcile", flag.ContinueOnError)
+	repair := fs.Bool("repair", false, "write missing tuples and delete extra ones")
+	if err := fs.Parse(args[1:]); err != nil {
+		return command{}, fmt.Errorf("parse reconcile flags: %w", err)
+	}
+	if fs.NArg() > 0 {
+		return command{}, fmt.Errorf("unexpected arguments: %v", fs.Args())
+	}
+
+	return command{reconcile: true, repair: *repair}, nil
 }
 
 // ReadyChecker reports whether a worker is ready to serve traffic.
@@ -49,6 +83,11 @@ func main() {
 }
 
 func run() error {
+	cmd, err := parseCommand(os.Args[1:])
+	if err != nil {
+		return err
+	}
+
 	var cfg config
 	if err := env.Parse(&cfg); err != nil {
 		return fmt.Errorf("env parse: %w", err)
@@ -67,6 +106,8 @@ func run() error {
 		"max_backoff", cfg.MaxBackoff,
 		"max_retries", cfg.MaxRetries,
 		"backoff_delay", cfg.BackoffDelay,
+		"reconcile_interval", cfg.ReconcileInterval,
+		"reconcile_repair", cfg.ReconcileRepair,
 	)
 
 	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
@@ -124,6 +165,10 @@ func run() error {
 		"model_id", model.AuthorizationModel.GetId(),
 	)
 
+	if cmd.reconcile {
+		return runReconcile(ctx, pool, fgaClient, logger, cmd.repair)
+	}
+
 	w := worker.New(pool, fgaClient, logger, worker.Config{
 		PollInterval: cfg.PollInterval,
 		BatchSize:    cfg.BatchSize,
@@ -139,6 +184,15 @@ func run() error {
 	g.Go(func() error {
 		return w.Run(ctx)
 	})
+	if cfg.ReconcileInterval > 0 {
+		r := reconcile.New(pool, fgaClient, logger, reconcile.Config{
+			Interval: cfg.ReconcileInterval,
+			Repair:   cfg.ReconcileRepair,
+		})
+		g.Go(func() error {
+			return r.Run(ctx)
+		})
+	}
 
 	err = g.Wait()
 
@@ -158,6 +212,23 @@ func run() error {
 	return nil
 }
 
+// runReconcile compares the OpenFGA store with the database once. Drift that
+// is not repaired fails the command, so a scheduled run surfaces it.
+func runReconcile(ctx context.Context, pool *pgxpool.Pool, fgaClient *client.OpenFgaClient, logger *slog.Logger, repair bool) error {
+	r := reconcile.New(pool, fgaClient, logger, reconcile.Config{Repair: repair})
+
+	drift, err := r.Reconcile(ctx, repair)
+	if err != nil {
+		return fmt.Errorf("reconcile: %w", err)
+	}
+	if !repair && !drift.Empty() {
+		return fmt.Errorf("OpenFGA store drifted from the database: %d missing, %d extra, %d changed tuples",
+			len(drift.Missing), len(drift.Extra), len(drift.Changed))
+	}
+
+	return nil
+}
+
 func startHealthServer(cfg *config, logger *slog.Logger, checkers ...ReadyChecker) *http.Server {
 	healthMux := http.NewServeMux()
 	healthMux.HandleFunc("/livez", func(resp http.ResponseWriter, _ *http.Request) {
@@ -11,6 +11,11 @@ SELECT
     api_key_id,
     organization_user_id,
     plugin_id,
+    namespace_role_binding_id,
+    team_id,
+    team_member_id,
+    project_team_id,
+    access_elevation_id,
     created,
     retries
 FROM authz.outbox
@@ -27,6 +32,11 @@ SET processed = now(),
     status_info = NULL
 WHERE id = @id;
 
+-- name: NotifyTuplesChanged :exec
+-- Tells organization-api that OpenFGA tuples changed, so it drops the
+-- authorization decisions it cached. Delivered when the transaction commits.
+SELECT pg_notify('authz_tuples_changed', @payload::text);
+
 -- name: MarkOutboxRowRetry :one
 -- Marks a row for retry with exponential backoff.
 -- The backoff is calculated as: base_interval * 2^retries, capped at max_backoff.
@@ -94,3 +104,93 @@ SELECT id, organization_id, deleted
 FROM appstore.plugins
 WHERE id = @id;
 
+
+-- name: GetNamespaceRoleBindingByID :one
+SELECT
+    tenant.namespace_role_bindings.id,
+    tenant.namespace_role_bindings.project_id,
+    tenant.namespace_role_bindings.namespace_pattern,
+    tenant.project_members.user_id
+FROM tenant.namespace_role_bindings
+JOIN tenant.project_members
+    ON tenant.project_members.id = tenant.namespace_role_bindings.project_member_id
+WHERE tenant.namespace_role_bindings.id = @id;
+
+-- name: ListNamespacesMatchingPattern :many
+-- The project's active namespaces whose name matches a namespace role binding
+-- pattern.
+SELECT id
+FROM tenant.namespaces
+WHERE project_id = @project_id
+  AND deleted IS NULL
+  AND tenant.namespace_matches_pattern(name, @namespace_pattern);
+
+-- name: ListNamespaceRoleGrants :many
+-- The user/role pairs that active namespace role bindings of active project
+-- members grant in a namespace. The namespace itself may be soft-deleted, so
+-- its grants can still be revoked.
+SELECT DISTINCT
+    tenant.project_members.user_id,
+    tenant.namespace_role_bindings.role
+FROM tenant.namespaces
+JOIN tenant.namespace_role_bindings
+    ON tenant.namespace_role_bindings.project_id = tenant.namespaces.project_id
+    AND tenant.namespace_role_bindings.deleted IS NULL
+JOIN tenant.project_members
+    ON tenant.project_members.id = tenant.namespace_role_bindings.project_member_id
+    AND tenant.project_members.deleted IS NULL
+WHERE tenant.namespaces.id = @namespace_id
+  AND tenant.namespace_matches_pattern(tenant.namespaces.name, tenant.namespace_role_bindings.namespace_pattern);
+
+-- name: GetTeamByID :one
+SELECT id, organization_id, permission, deleted
+FROM tenant.teams
+WHERE id = @id;
+
+-- name: GetTeamMemberByID :one
+-- A membership of a deleted team counts as deleted.
+SELECT
+    tenant.team_members.id,
+    tenant.team_members.team_id,
+    tenant.team_members.user_id,
+    COALESCE(tenant.team_members.deleted, tenant.teams.deleted)::timestamptz AS deleted
+FROM tenant.team_members
+JOIN tenant.teams
+    ON tenant.teams.id = tenant.team_members.team_id
+WHERE tenant.team_members.id = @id;
+
+-- name: GetProjectTeamByID :one
+-- An assignment of a deleted team counts as deleted.
+SELECT
+    tenant.project_teams.id,
+    tenant.project_teams.project_id,
+    tenant.project_teams.team_id,
+    tenant.project_teams.role,
+    COALESCE(tenant.project_teams.deleted, tenant.teams.deleted)::timestamptz AS deleted
+FROM tenant.project_teams
+JOIN tenant.teams
+    ON tenant.teams.id = tenant.project_teams.team_id
+WHERE tenant.project_teams.id = @id;
+
+-- name: GetAccessElevationByID :one
+-- active_expires is the expiry of the approved elevation granting the same
+-- role to the same user, if any. At most one elevation can be approved per
+-- role, and it may be another one than the elevation asked for.
+SELECT
+    tenant.access_elevations.id,
+    tenant.access_elevations.organization_id,
+    tenant.access_elevations.user_id,
+    tenant.access_elevations.role,
+    tenant.access_elevations.project_id,
+    tenant.access_elevations.status,
+    (
+        SELECT active.expires
+        FROM tenant.access_elevations AS active
+        WHERE active.organization_id = tenant.access_elevations.organization_id
+          AND active.user_id = tenant.access_elevations.user_id
+          AND active.role = tenant.access_elevations.role
+          AND active.project_id IS NOT DISTINCT FROM tenant.access_elevations.project_id
+          AND active.status = 'approved'
+    )::timestamptz AS active_expires
+FROM tenant.access_elevations
+WHERE tenant.access_elevations.id = @id;
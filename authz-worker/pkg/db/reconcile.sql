@@ -0,0 +1,157 @@
+-- name: GetDatabaseTime :one
+SELECT now()::timestamptz AS now;
+
+-- name: ListAcceptedOrganizationUsers :many
+SELECT organization_id, user_id, permission
+FROM tenant.organizations_users
+WHERE deleted IS NULL
+  AND status = 'accepted';
+
+-- name: ListActiveProjects :many
+SELECT id, cluster_id
+FROM tenant.projects
+WHERE deleted IS NULL;
+
+-- name: ListActiveProjectMembers :many
+SELECT id, project_id, user_id, role
+FROM tenant.project_members
+WHERE deleted IS NULL;
+
+-- name: ListActiveClusters :many
+SELECT id, organization_id
+FROM tenant.clusters
+WHERE deleted IS NULL;
+
+-- name: ListActiveNodePools :many
+SELECT id, cluster_id
+FROM tenant.node_pools
+WHERE deleted IS NULL;
+
+-- name: ListActiveNamespaces :many
+SELECT id, project_id
+FROM tenant.namespaces
+WHERE deleted IS NULL;
+
+-- name: ListActiveNamespaceRoleGrants :many
+-- The namespace/user/role triples that active namespace role bindings of
+-- active project members grant in active namespaces.
+SELECT DISTINCT
+    tenant.namespaces.id AS namespace_id,
+    tenant.project_members.user_id,
+    tenant.namespace_role_bindings.role
+FROM tenant.namespaces
+JOIN tenant.namespace_role_bindings
+    ON tenant.namespace_role_bindings.project_id = tenant.namespaces.project_id
+    AND tenant.namespace_role_bindings.deleted IS NULL
+JOIN tenant.project_members
+    ON tenant.project_members.id = tenant.namespace_role_bindings.project_member_id
+    AND tenant.project_members.deleted IS NULL
+WHERE tenant.namespaces.deleted IS NULL
+  AND tenant.namespace_matches_pattern(tenant.namespaces.name, tenant.namespace_role_bindings.namespace_pattern);
+
+-- name: ListActiveApiKeys :many
+SELECT id, organization_id, user_id, expires, revoked
+FROM authn.api_keys
+WHERE deleted IS NULL;
+
+-- name: ListActivePlugins :many
+SELECT id, organization_id
+FROM appstore.plugins
+WHERE deleted IS NULL;
+
+-- name: ListActiveTeams :many
+SELECT id, organization_id, permission
+FROM tenant.teams
+WHERE deleted IS NULL;
+
+-- name: ListActiveTeamMembers :many
+-- Memberships of deleted teams are left out, as GetTeamMemberByID treats them
+-- as deleted.
+SELECT tenant.team_members.team_id, tenant.team_members.user_id
+FROM tenant.team_members
+JOIN tenant.teams
+    ON tenant.teams.id = tenant.team_members.team_id
+WHERE tenant.team_members.deleted IS NULL
+  AND tenant.teams.deleted IS NULL;
+
+-- name: ListActiveProjectTeams :many
+-- Assignments of deleted teams are left out, as GetProjectTeamByID treats them
+-- as deleted.
+SELECT tenant.project_teams.project_id, tenant.project_teams.team_id, tenant.project_teams.role
+FROM tenant.project_teams
+JOIN tenant.teams
+    ON tenant.teams.id = tenant.project_teams.team_id
+WHERE tenant.project_teams.deleted IS NULL
+  AND tenant.teams.deleted IS NULL;
+-- name: ListApprovedAccessElevations :many
+SELECT organization_id, user_id, role, project_id, expires
+FROM tenant.access_elevations
+WHERE status = 'approved';
+
+
+-- name: ListInFlightOutboxObjects :many
+-- The OpenFGA objects whose tuples an outbox row may still change: rows that
+-- are pending or retrying, and rows processed at or after @since. Objects are
+-- formatted as OpenFGA object strings, e.g. cluster:<id>.
+SELECT DISTINCT objects.object::text AS object
+FROM authz.outbox
+CROSS JOIN LATERAL (
+    SELECT 'organization:' || tenant.organizations_users.organization_id
+    FROM tenant.organizations_users
+    WHERE tenant.organizations_users.id = authz.outbox.organization_user_id
+    UNION ALL
+    SELECT 'project:' || authz.outbox.project_id
+    WHERE authz.outbox.project_id IS NOT NULL
+    UNION ALL
+    SELECT 'project:' || tenant.project_members.project_id
+    FROM tenant.project_members
+    WHERE tenant.project_members.id = authz.outbox.project_member_id
+    UNION ALL
+    SELECT 'project_member:' || authz.outbox.project_member_id
+    WHERE authz.outbox.project_member_id IS NOT NULL
+    UNION ALL
+    SELECT 'cluster:' || authz.outbox.cluster_id
+    WHERE authz.outbox.cluster_id IS NOT NULL
+    UNION ALL
+    SELECT 'node_pool:' || authz.outbox.node_pool_id
+    WHERE authz.outbox.node_pool_id IS NOT NULL
+    UNION ALL
+    SELECT 'namespace:' || authz.outbox.namespace_id
+    WHERE authz.outbox.namespace_id IS NOT NULL
+    UNION ALL
+    SELECT 'api_key:' || authz.outbox.api_key_id
+    WHERE authz.outbox.api_key_id IS NOT NULL
+    UNION ALL
+    SELECT 'plugin:' || authz.outbox.plugin_id
+    WHERE authz.outbox.plugin_id IS NOT NULL
+    UNION ALL
+    SELECT 'namespace:' || tenant.namespaces.id
+    FROM tenant.namespace_role_bindings
+    JOIN tenant.namespaces
+        ON tenant.namespaces.project_id = tenant.namespace_role_bindings.project_id
+    WHERE tenant.namespace_role_bindings.id = authz.outbox.namespace_role_binding_id
+    UNION ALL
+    SELECT 'team:' || authz.outbox.team_id
+    WHERE authz.outbox.team_id IS NOT NULL
+    UNION ALL
+    SELECT 'organization:' || tenant.teams.organization_id
+    FROM tenant.teams
+    WHERE tenant.teams.id = authz.outbox.team_id
+    UNION ALL
+    SELECT 'team:' || tenant.team_members.team_id
+    FROM tenant.team_members
+    WHERE tenant.team_members.id = authz.outbox.team_member_id
+    UNION ALL
+    SELECT 'project:' || tenant.project_teams.project_id
+    FROM tenant.project_teams
+    WHERE tenant.project_teams.id = authz.outbox.project_team_id
+    UNION ALL
+    SELECT COALESCE(
+        'project:' || tenant.access_elevations.project_id,
+        'organization:' || tenant.access_elevations.organization_id
+    )
+    FROM tenant.access_elevations
+    WHERE tenant.access_elevations.id = authz.outbox.access_elevation_id
+) AS objects(object)
+WHERE authz.outbox.status IN ('pending', 'retrying')
+   OR authz.outbox.processed >= @since;
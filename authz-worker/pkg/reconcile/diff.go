@@ -0,0 +1,97 @@
+package reconcile
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	openfga "github.com/openfga/go-sdk"
+)
+
+// Drift holds the differences between the tuples the database calls for and
+// the tuples in the OpenFGA store.
+type Drift struct {
+	// Missing holds expected tuples the store lacks.
+	Missing []openfga.TupleKey
+	// Extra holds store tuples no database row accounts for.
+	Extra []openfga.TupleKey
+	// Changed holds expected tuples the store has with another condition.
+	Changed []openfga.TupleKey
+	// Skipped counts the differences left out because an outbox row may still
+	// change the object they are on.
+	Skipped int
+}
+
+// Empty reports whether the store matches the database.
+func (d *Drift) Empty() bool {
+	return len(d.Missing) == 0 && len(d.Extra) == 0 && len(d.Changed) == 0
+}
+
+// tupleID identifies a tuple the way OpenFGA does: a store holds at most one
+// tuple per user, relation and object, whatever its condition.
+func tupleID(user, relation, object string) string {
+	return user + " " + relation + " " + object
+}
+
+// diff compares the expected tuples to the store's. Differences on an object in
+// inFlight are skipped, since the outbox may not have brought the store up to
+// date with them yet.
+func diff(expected, actual tupleSet, inFlight map[string]bool) *Drift {
+	drift := &Drift{}
+
+	for _, id := range slices.Sorted(maps.Keys(expected)) {
+		want := expected[id]
+		got, ok := actual[id]
+		if ok && conditionKey(want.Condition) == conditionKey(got.Condition) {
+			continue
+		}
+		if inFlight[want.Object] {
+			drift.Skipped++
+			continue
+		}
+		if ok {
+			drift.Changed = append(drift.Changed, want)
+		} else {
+			drift.Missing = append(drift.Missing, want)
+		}
+	}
+
+	for _, id := range slices.Sorted(maps.Keys(actual)) {
+		if _, ok := expected[id]; ok {
+			continue
+		}
+		got := actual[id]
+		if inFlight[got.Object] {
+			drift.Skipped++
+			continue
+		}
+		drift.Extra = append(drift.Extra, got)
+	}
+
+	return drift
+}
+
+// conditionKey renders a condition for comparison. OpenFGA may hand back a
+// timestamp in another form than it was written in, so timestamps are
+// normalized to UTC seconds.
+func conditionKey(condition *openfga.RelationshipCondition) string {
+	if condition == nil {
+		return ""
+	}
+	if condition.Context == nil {
+		return condition.Name
+	}
+
+	context := *condition.Context
+	parts := make([]string, 0, len(context))
+	for _, name := range slices.Sorted(maps.Keys(context)) {
+		value := fmt.Sprint(context[name])
+		if t, err := time.Parse(time.RFC3339Nano, value); err == nil {
+			value = t.UTC().Truncate(time.Second).Format(time.RFC3339)
+		}
+		parts = append(parts, name+"="+value)
+	}
+	return condition.Name + "(" + strings.Join(parts, ",") + ")"
+}
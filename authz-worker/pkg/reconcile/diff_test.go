@@ -0,0 +1,99 @@
+package reconcile
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	openfga "github.com/openfga/go-sdk"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/fundament-oss/fundament/common/authz"
+)
+
+func TestDiff(t *testing.T) {
+	org := authz.Organization(uuid.New())
+	user := authz.User(uuid.New())
+	apiKey := authz.ApiKey(uuid.New())
+	cluster := authz.Cluster(uuid.New())
+	busyCluster := authz.Cluster(uuid.New())
+
+	expiring := func(expiration string) openfga.TupleKey {
+		tk := tuple(user, authz.ActionUsableBy, apiKey)
+		tk.Condition = &openfga.RelationshipCondition{
+			Name:    "is_not_expired",
+			Context: &map[string]any{"expiration": expiration},
+		}
+		return tk
+	}
+
+	admin := tuple(user, authz.ActionAdmin, org)
+	owner := tuple(org, authz.ActionOwner, cluster)
+	creator := tuple(user, authz.ActionCreator, apiKey)
+	stale := tuple(user, authz.ActionViewer, org)
+	busy := tuple(org, authz.ActionOwner, busyCluster)
+
+	expected := tupleSet{}
+	for _, tk := range []openfga.TupleKey{admin, owner, creator, busy, expiring("2026-03-01T12:00:00+01:00")} {
+		expected.add(tk)
+	}
+
+	actual := tupleSet{}
+	// The store holds usable_by with the same expiration in UTC, a viewer
+	// tuple the database no longer has and no owner for either cluster.
+	for _, tk := range []openfga.TupleKey{admin, creator, stale, expiring("2026-03-01T11:00:00.000Z")} {
+		actual.add(tk)
+	}
+
+	drift := diff(expected, actual, map[string]bool{busyCluster.String(): true})
+	assert.Equal(t, []openfga.TupleKey{owner}, drift.Missing)
+	assert.Equal(t, []openfga.TupleKey{stale}, drift.Extra)
+	assert.Empty(t, drift.Changed)
+	assert.Equal(t, 1, drift.Skipped)
+	assert.False(t, drift.Empty())
+
+	actual.add(expiring("2026-04-01T11:00:00Z"))
+	drift = diff(expected, actual, nil)
+	assert.Equal(t, []openfga.TupleKey{expiring("2026-03-01T12:00:00+01:00")}, drift.Changed)
+	assert.Len(t, drift.Missing, 2)
+
+	assert.True(t, diff(expected, expected, nil).Empty())
+}
+
+func TestConditionKey(t *testing.T) {
+	tests := []struct {
+		name      string
+		condition *openfga.RelationshipCondition
+		want      string
+	}{
+		{
+			name: "none",
+		},
+		{
+			name:      "without context",
+			condition: &openfga.RelationshipCondition{Name: "is_not_expired"},
+			want:      "is_not_expired",
+		},
+		{
+			name: "timestamp normalized",
+			condition: &openfga.RelationshipCondition{
+				Name:    "is_not_expired",
+				Context: &map[string]any{"expiration": "2026-03-01T12:00:00.25+01:00"},
+			},
+			want: "is_not_expired(expiration=2026-03-01T11:00:00Z)",
+		},
+		{
+			name: "sorted context",
+			condition: &openfga.RelationshipCondition{
+				Name:    "in_range",
+				Context: &map[string]any{"to": 5, "from": "a"},
+			},
+			want: "in_range(from=a,to=5)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, conditionKey(tt.condition))
+		})
+	}
+}
@@ -0,0 +1,313 @@
+package reconcile
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	openfga "github.com/openfga/go-sdk"
+
+	db "github.com/fundament-oss/fundament/authz-worker/pkg/db/gen"
+	"github.com/fundament-oss/fundament/authz-worker/pkg/worker/handler"
+	"github.com/fundament-oss/fundament/common/authz"
+	"github.com/fundament-oss/fundament/common/dbconst"
+)
+
+// tupleSet holds tuples keyed by tupleID.
+type tupleSet map[string]openfga.TupleKey
+
+func (s tupleSet) add(tk openfga.TupleKey) {
+	s[tupleID(tk.User, tk.Relation, tk.Object)] = tk
+}
+
+// expectFunc adds the tuples one outbox handler leaves in OpenFGA to expected.
+type expectFunc func(ctx context.Context, qtx *db.Queries, expected tupleSet) error
+
+// expectations derive the tuples of one outbox handler each, so their parity
+// with the handlers can be tested per entity type.
+var expectations = []expectFunc{
+	expectOrganizationUsers,
+	expectClusters,
+	expectProjects,
+	expectProjectMembers,
+	expectNodePools,
+	expectNamespaces,
+	expectNamespaceRoleBindings,
+	expectApiKeys,
+	expectPlugins,
+	expectTeams,
+	expectTeamMembers,
+	expectProjectTeams,
+	expectAccessElevations,
+}
+
+// expectedTuples derives the tuples the outbox handlers leave in OpenFGA for
+// the current database state. It must stay in line with the handlers in
+// pkg/worker/handler.
+func expectedTuples(ctx context.Context, qtx *db.Queries) (tupleSet, error) {
+	expected := make(tupleSet)
+	for _, expect := range expectations {
+		if err := expect(ctx, qtx, expected); err != nil {
+			return nil, err
+		}
+	}
+	return expected, nil
+}
+
+// expectOrganizationUsers adds the tuples handler.OrganizationUser writes for
+// the accepted organization memberships.
+func expectOrganizationUsers(ctx context.Context, qtx *db.Queries, expected tupleSet) error {
+	orgUsers, err := qtx.ListAcceptedOrganizationUsers(ctx)
+	if err != nil {
+		return fmt.Errorf("list organization users: %w", err)
+	}
+	for _, orgUser := range orgUsers {
+		var action authz.ActionName
+		switch orgUser.Permission {
+		case dbconst.OrganizationsUserPermission_Admin:
+			action = authz.ActionAdmin
+		case dbconst.OrganizationsUserPermission_Viewer:
+			action = authz.ActionViewer
+		default:
+			panic(fmt.Sprintf("unknown organization user permission: %s", orgUser.Permission))
+		}
+		expected.add(tuple(authz.User(orgUser.UserID), action, authz.Organization(orgUser.OrganizationID)))
+	}
+
+	return nil
+}
+
+// expectClusters adds the tuples handler.Cluster writes for the active
+// clusters.
+func expectClusters(ctx context.Context, qtx *db.Queries, expected tupleSet) error {
+	clusters, err := qtx.ListActiveClusters(ctx)
+	if err != nil {
+		return fmt.Errorf("list clusters: %w", err)
+	}
+	for _, cluster := range clusters {
+		expected.add(tuple(authz.Organization(cluster.OrganizationID), authz.ActionOwner, authz.Cluster(cluster.ID)))
+	}
+
+	return nil
+}
+
+// expectProjects adds the tuples handler.Project writes for the active
+// projects.
+func expectProjects(ctx context.Context, qtx *db.Queries, expected tupleSet) error {
+	projects, err := qtx.ListActiveProjects(ctx)
+	if err != nil {
+		return fmt.Errorf("list projects: %w", err)
+	}
+	for _, project := range projects {
+		expected.add(tuple(authz.Cluster(project.ClusterID), authz.ActionParent, authz.Project(project.ID)))
+	}
+
+	return nil
+}
+
+// expectProjectMembers adds the tuples handler.ProjectMember writes for the
+// active project members.
+func expectProjectMembers(ctx context.Context, qtx *db.Queries, expected tupleSet) error {
+	members, err := qtx.ListActiveProjectMembers(ctx)
+	if err != nil {
+		return fmt.Errorf("list project members: %w", err)
+	}
+	for _, member := range members {
+		var action authz.ActionName
+		switch member.Role {
+		case dbconst.ProjectMemberRole_Admin:
+			action = authz.ActionProjectAdmin
+		case dbconst.ProjectMemberRole_Viewer:
+			action = authz.ActionProjectViewer
+		default:
+			panic(fmt.Sprintf("unknown project member role: %s", member.Role))
+		}
+		project := authz.Project(member.ProjectID)
+		expected.add(tuple(authz.User(member.UserID), action, project))
+		expected.add(tuple(project, authz.ActionParent, authz.ProjectMember(member.ID)))
+	}
+
+	return nil
+}
+
+// expectNodePools adds the tuples handler.NodePool writes for the active node
+// pools.
+func expectNodePools(ctx context.Context, qtx *db.Queries, expected tupleSet) error {
+	nodePools, err := qtx.ListActiveNodePools(ctx)
+	if err != nil {
+		return fmt.Errorf("list node pools: %w", err)
+	}
+	for _, nodePool := range nodePools {
+		expected.add(tuple(authz.Cluster(nodePool.ClusterID), authz.ActionParent, authz.NodePool(nodePool.ID)))
+	}
+
+	return nil
+}
+
+// expectNamespaces adds the tuples handler.Namespace writes for the active
+// namespaces.
+func expectNamespaces(ctx context.Context, qtx *db.Queries, expected tupleSet) error {
+	namespaces, err := qtx.ListActiveNamespaces(ctx)
+	if err != nil {
+		return fmt.Errorf("list namespaces: %w", err)
+	}
+	for _, namespace := range namespaces {
+		expected.add(tuple(authz.Project(namespace.ProjectID), authz.ActionParent, authz.Namespace(namespace.ID)))
+	}
+
+	return nil
+}
+
+// expectNamespaceRoleBindings adds the tuples handler.NamespaceRoleBinding
+// writes for the roles active namespace role bindings grant. handler.Namespace
+// writes them too, for namespaces created after the binding.
+func expectNamespaceRoleBindings(ctx context.Context, qtx *db.Queries, expected tupleSet) error {
+	grants, err := qtx.ListActiveNamespaceRoleGrants(ctx)
+	if err != nil {
+		return fmt.Errorf("list namespace role grants: %w", err)
+	}
+	for _, grant := range grants {
+		expected.add(tuple(authz.User(grant.UserID), handler.NamespaceRoleRelation(grant.Role), authz.Namespace(grant.NamespaceID)))
+	}
+
+	return nil
+}
+
+// expectApiKeys adds the tuples handler.ApiKey writes for the active API keys.
+func expectApiKeys(ctx context.Context, qtx *db.Queries, expected tupleSet) error {
+	apiKeys, err := qtx.ListActiveApiKeys(ctx)
+	if err != nil {
+		return fmt.Errorf("list api keys: %w", err)
+	}
+	for _, apiKey := range apiKeys {
+		user := authz.User(apiKey.UserID)
+		apiKeyObj := authz.ApiKey(apiKey.ID)
+		expected.add(tuple(authz.Organization(apiKey.OrganizationID), authz.ActionOwner, apiKeyObj))
+		expected.add(tuple(user, authz.ActionCreator, apiKeyObj))
+
+		// A revoked key keeps its owner and creator, only usable_by is removed.
+		if apiKey.Revoked.Valid {
+			continue
+		}
+		usableBy := tuple(user, authz.ActionUsableBy, apiKeyObj)
+		if apiKey.Expires.Valid {
+			usableBy.Condition = &openfga.RelationshipCondition{
+				Name: "is_not_expired",
+				Context: &map[string]any{
+					"expiration": apiKey.Expires.Time.Format(time.RFC3339),
+				},
+			}
+		}
+		expected.add(usableBy)
+	}
+
+	return nil
+}
+
+// expectPlugins adds the tuples handler.Plugin writes for the active plugins.
+func expectPlugins(ctx context.Context, qtx *db.Queries, expected tupleSet) error {
+	plugins, err := qtx.ListActivePlugins(ctx)
+	if err != nil {
+		return fmt.Errorf("list plugins: %w", err)
+	}
+	for _, plugin := range plugins {
+		expected.add(tuple(authz.Organization(plugin.OrganizationID), authz.ActionOwner, authz.Plugin(plugin.ID)))
+	}
+
+	return nil
+}
+
+// expectTeams adds the tuples handler.Team writes for the active teams.
+func expectTeams(ctx context.Context, qtx *db.Queries, expected tupleSet) error {
+	teams, err := qtx.ListActiveTeams(ctx)
+	if err != nil {
+		return fmt.Errorf("list teams: %w", err)
+	}
+	for _, team := range teams {
+		org := authz.Organization(team.OrganizationID)
+		teamObj := authz.Team(team.ID)
+		expected.add(tuple(org, authz.ActionOwner, teamObj))
+
+		if !team.Permission.Valid {
+			continue
+		}
+		members := userset(teamObj, authz.ActionMember)
+		switch dbconst.TeamPermission(team.Permission.String) {
+		case dbconst.TeamPermission_Admin:
+			expected.add(tuple(members, authz.ActionAdmin, org))
+		case dbconst.TeamPermission_Viewer:
+			expected.add(tuple(members, authz.ActionViewer, org))
+		default:
+			panic(fmt.Sprintf("unknown team permission: %s", team.Permission.String))
+		}
+	}
+
+	return nil
+}
+
+// expectTeamMembers adds the tuples handler.TeamMember writes for the active
+// team memberships.
+func expectTeamMembers(ctx context.Context, qtx *db.Queries, expected tupleSet) error {
+	teamMembers, err := qtx.ListActiveTeamMembers(ctx)
+	if err != nil {
+		return fmt.Errorf("list team members: %w", err)
+	}
+	for _, member := range teamMembers {
+		expected.add(tuple(authz.User(member.UserID), authz.ActionMember, authz.Team(member.TeamID)))
+	}
+
+	return nil
+}
+
+// expectProjectTeams adds the tuples handler.ProjectTeam writes for the active
+// project assignments of teams.
+func expectProjectTeams(ctx context.Context, qtx *db.Queries, expected tupleSet) error {
+	projectTeams, err := qtx.ListActiveProjectTeams(ctx)
+	if err != nil {
+		return fmt.Errorf("list project teams: %w", err)
+	}
+	for _, projectTeam := range projectTeams {
+		var action authz.ActionName
+		switch projectTeam.Role {
+		case dbconst.ProjectTeamRole_Admin:
+			action = authz.ActionProjectAdmin
+		case dbconst.ProjectTeamRole_Viewer:
+			action = authz.ActionProjectViewer
+		default:
+			panic(fmt.Sprintf("unknown project team role: %s", projectTeam.Role))
+		}
+		members := userset(authz.Team(projectTeam.TeamID), authz.ActionMember)
+		expected.add(tuple(members, action, authz.Project(projectTeam.ProjectID)))
+	}
+
+	return nil
+}
+
+// expectAccessElevations adds the tuples handler.AccessElevation writes for the
+// approved access elevations.
+func expectAccessElevations(ctx context.Context, qtx *db.Queries, expected tupleSet) error {
+	elevations, err := qtx.ListApprovedAccessElevations(ctx)
+	if err != nil {
+		return fmt.Errorf("list access elevations: %w", err)
+	}
+	for _, elevation := range elevations {
+		object := handler.AccessElevationObject(elevation.Role, elevation.OrganizationID, elevation.ProjectID)
+		expected.add(handler.ElevatedAdminTuple(authz.User(elevation.UserID), object, elevation.Expires.Time))
+	}
+
+	return nil
+}
+
+func tuple(subject authz.Object, relation authz.ActionName, object authz.Object) openfga.TupleKey {
+	return openfga.TupleKey{
+		User:     subject.String(),
+		Relation: string(relation),
+		Object:   object.String(),
+	}
+}
+
+// userset returns the subject for every object holding relation on obj, e.g.
+// team:<id>#member.
+func userset(obj authz.Object, relation authz.ActionName) authz.Object {
+	return authz.Object{Type: obj.Type, ID: obj.ID + "#" + string(relation)}
+}
@@ -0,0 +1,290 @@
+package reconcile
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	openfga "github.com/openfga/go-sdk"
+	"github.com/openfga/go-sdk/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	db "github.com/fundament-oss/fundament/authz-worker/pkg/db/gen"
+	"github.com/fundament-oss/fundament/authz-worker/pkg/worker/handler"
+)
+
+// parityFixtures adds rows next to the test data for each state a handler
+// tells apart: deleted, pending, revoked, expiring, unset permission and so
+// on. It runs as one statement batch, so the deferred check that a project
+// has an admin passes.
+const parityFixtures = `
+INSERT INTO tenant.organizations_users (id, organization_id, user_id, permission, status, deleted) VALUES
+    ('019b4000-f100-7000-8000-000000000001', '019b4000-0000-7000-8000-000000000002', '019b4000-1000-7000-8000-000000000001', 'viewer', 'pending', NULL),
+    ('019b4000-f100-7000-8000-000000000002', '019b4000-0000-7000-8000-000000000002', '019b4000-1000-7000-8000-000000000002', 'viewer', 'declined', NULL),
+    ('019b4000-f100-7000-8000-000000000003', '019b4000-0000-7000-8000-000000000002', '019b4000-1000-7000-8000-000000000003', 'admin', 'accepted', now()),
+    ('019b4000-f100-7000-8000-000000000004', '019b4000-0000-7000-8000-000000000001', '019b4000-1000-7000-8000-000000000006', 'admin', 'revoked', NULL),
+    ('019b4000-f100-7000-8000-000000000005', '019b4000-0000-7000-8000-000000000001', '019b4000-1000-7000-8000-000000000007', 'viewer', 'accepted', NULL);
+
+INSERT INTO tenant.clusters (id, organization_id, name, region, kubernetes_version, deleted) VALUES
+    ('019b4000-f200-7000-8000-000000000001', '019b4000-0000-7000-8000-000000000002', 'globex-cluster', 'eu-west-1', '1.31.0', NULL),
+    ('019b4000-f200-7000-8000-000000000002', '019b4000-0000-7000-8000-000000000002', 'globex-old', 'eu-west-1', '1.31.0', now());
+
+INSERT INTO tenant.projects (id, cluster_id, name, alias, deleted) VALUES
+    ('019b4000-f300-7000-8000-000000000001', '019b4000-2000-7000-8000-000000000001', 'acme-tools', 'Acme tools', NULL),
+    ('019b4000-f300-7000-8000-000000000002', '019b4000-2000-7000-8000-000000000001', 'acme-old', 'Acme old', now());
+
+INSERT INTO tenant.project_members (id, project_id, user_id, role, deleted) VALUES
+    ('019b4000-f400-7000-8000-000000000001', '019b4000-f300-7000-8000-000000000001', '019b4000-1000-7000-8000-000000000001', 'admin', NULL),
+    ('019b4000-f400-7000-8000-000000000002', '019b4000-f300-7000-8000-000000000001', '019b4000-1000-7000-8000-000000000003', 'viewer', now()),
+    ('019b4000-f400-7000-8000-000000000003', '019b4000-f300-7000-8000-000000000001', '019b4000-1000-7000-8000-000000000002', 'viewer', NULL),
+    ('019b4000-f400-7000-8000-000000000004', '019b4000-f300-7000-8000-000000000002', '019b4000-1000-7000-8000-000000000001', 'admin', NULL);
+
+INSERT INTO tenant.node_pools (id, cluster_id, name, machine_type, autoscale_min, autoscale_max, deleted) VALUES
+    ('019b4000-f500-7000-8000-000000000001', '019b4000-2000-7000-8000-000000000001', 'workers', 'standard', 1, 3, NULL),
+    ('019b4000-f500-7000-8000-000000000002', '019b4000-2000-7000-8000-000000000001', 'retired', 'standard', 1, 3, now());
+
+INSERT INTO tenant.namespaces (id, project_id, name, deleted) VALUES
+    ('019b4000-f600-7000-8000-000000000001', '019b4000-9000-7000-8000-000000000001', 'staging-api', NULL),
+    ('019b4000-f600-7000-8000-000000000002', '019b4000-9000-7000-8000-000000000001', 'staging-web', NULL),
+    ('019b4000-f600-7000-8000-000000000003', '019b4000-9000-7000-8000-000000000001', 'prod', NULL),
+    ('019b4000-f600-7000-8000-000000000004', '019b4000-9000-7000-8000-000000000001', 'staging-old', now()),
+    ('019b4000-f600-7000-8000-000000000005', '019b4000-f300-7000-8000-000000000001', 'staging-api', NULL);
+
+-- Two bindings grant Bart deploy in staging-api; the binding of the deleted
+-- member grants nothing.
+INSERT INTO tenant.namespace_role_bindings (id, project_id, project_member_id, namespace_pattern, role, deleted) VALUES
+    ('019b4000-f700-7000-8000-000000000001', '019b4000-9000-7000-8000-000000000001', '019b4000-a000-7000-8000-000000000001', 'staging-*', 'deploy', NULL),
+    ('019b4000-f700-7000-8000-000000000002', '019b4000-9000-7000-8000-000000000001', '019b4000-a000-7000-8000-000000000001', 'staging-api', 'deploy', NULL),
+    ('019b4000-f700-7000-8000-000000000003', '019b4000-9000-7000-8000-000000000001', '019b4000-a000-7000-8000-000000000002', 'prod', 'view_pods', NULL),
+    ('019b4000-f700-7000-8000-000000000004', '019b4000-9000-7000-8000-000000000001', '019b4000-a000-7000-8000-000000000002', 'staging-*', 'view_logs', now()),
+    ('019b4000-f700-7000-8000-000000000005', '019b4000-f300-7000-8000-000000000001', '019b4000-f400-7000-8000-000000000002', '*', 'manage_services', NULL),
+    ('019b4000-f700-7000-8000-000000000006', '019b4000-f300-7000-8000-000000000001', '019b4000-f400-7000-8000-000000000001', '*', 'view_logs', NULL);
+
+INSERT INTO authn.api_keys (id, organization_id, user_id, name, token_hash, token_prefix, expires, revoked, deleted) VALUES
+    ('019b4000-f800-7000-8000-000000000001', '019b4000-0000-7000-8000-000000000001', '019b4000-1000-7000-8000-000000000001', 'ci', '\x01', 'fun_01', NULL, NULL, NULL),
+    ('019b4000-f800-7000-8000-000000000002', '019b4000-0000-7000-8000-000000000001', '019b4000-1000-7000-8000-000000000001', 'expiring', '\x02', 'fun_02', now() + interval '30 days', NULL, NULL),
+    ('019b4000-f800-7000-8000-000000000003', '019b4000-0000-7000-8000-000000000001', '019b4000-1000-7000-8000-000000000001', 'expired', '\x03', 'fun_03', now() - interval '1 day', NULL, NULL),
+    ('019b4000-f800-7000-8000-000000000004', '019b4000-0000-7000-8000-000000000001', '019b4000-1000-7000-8000-000000000002', 'revoked', '\x04', 'fun_04', NULL, now(), NULL),
+    ('019b4000-f800-7000-8000-000000000005', '019b4000-0000-7000-8000-000000000001', '019b4000-1000-7000-8000-000000000002', 'deleted', '\x05', 'fun_05', NULL, NULL, now());
+
+INSERT INTO appstore.plugins (id, organization_id, name, description, deleted) VALUES
+    ('019b4000-f900-7000-8000-000000000001', '019b4000-0000-7000-8000-000000000001', 'acme-backup', 'Backups for acme', NULL),
+    ('019b4000-f900-7000-8000-000000000002', '019b4000-0000-7000-8000-000000000001', 'acme-retired', 'No longer offered', now());
+
+INSERT INTO tenant.teams (id, organization_id, name, permission, deleted) VALUES
+    ('019b4000-fa00-7000-8000-000000000001', '019b4000-0000-7000-8000-000000000001', 'platform', 'admin', NULL),
+    ('019b4000-fa00-7000-8000-000000000002', '019b4000-0000-7000-8000-000000000001', 'auditors', 'viewer', NULL),
+    ('019b4000-fa00-7000-8000-000000000003', '019b4000-0000-7000-8000-000000000001', 'guests', NULL, NULL),
+    ('019b4000-fa00-7000-8000-000000000004', '019b4000-0000-7000-8000-000000000001', 'former', 'admin', now());
+
+INSERT INTO tenant.team_members (id, team_id, user_id, deleted) VALUES
+    ('019b4000-fb00-7000-8000-000000000001', '019b4000-fa00-7000-8000-000000000001', '019b4000-1000-7000-8000-000000000001', NULL),
+    ('019b4000-fb00-7000-8000-000000000002', '019b4000-fa00-7000-8000-000000000002', '019b4000-1000-7000-8000-000000000002', now()),
+    ('019b4000-fb00-7000-8000-000000000003', '019b4000-fa00-7000-8000-000000000004', '019b4000-1000-7000-8000-000000000003', NULL);
+
+INSERT INTO tenant.project_teams (id, project_id, team_id, role, deleted) VALUES
+    ('019b4000-fc00-7000-8000-000000000001', '019b4000-9000-7000-8000-000000000001', '019b4000-fa00-7000-8000-000000000001', 'admin', NULL),
+    ('019b4000-fc00-7000-8000-000000000002', '019b4000-9000-7000-8000-000000000001', '019b4000-fa00-7000-8000-000000000002', 'viewer', NULL),
+    ('019b4000-fc00-7000-8000-000000000003', '019b4000-f300-7000-8000-000000000001', '019b4000-fa00-7000-8000-000000000003', 'admin', now()),
+    ('019b4000-fc00-7000-8000-000000000004', '019b4000-f300-7000-8000-000000000001', '019b4000-fa00-7000-8000-000000000004', 'viewer', NULL);
+
+-- Alice's revoked elevation is for the role her approved one grants.
+INSERT INTO tenant.access_elevations (id, organization_id, user_id, role, project_id, duration_minutes, reason, status, expires) VALUES
+    ('019b4000-fd00-7000-8000-000000000001', '019b4000-0000-7000-8000-000000000001', '019b4000-1000-7000-8000-000000000001', 'admin', NULL, 60, 'incident', 'approved', now() + interval '1 hour'),
+    ('019b4000-fd00-7000-8000-000000000002', '019b4000-0000-7000-8000-000000000001', '019b4000-1000-7000-8000-000000000001', 'admin', NULL, 60, 'incident', 'revoked', now() - interval '1 hour'),
+    ('019b4000-fd00-7000-8000-000000000003', '019b4000-0000-7000-8000-000000000001', '019b4000-1000-7000-8000-000000000002', 'project_admin', '019b4000-9000-7000-8000-000000000001', 30, 'deploy fix', 'approved', now() + interval '30 minutes'),
+    ('019b4000-fd00-7000-8000-000000000004', '019b4000-0000-7000-8000-000000000001', '019b4000-1000-7000-8000-000000000003', 'admin', NULL, 60, 'audit', 'pending', NULL),
+    ('019b4000-fd00-7000-8000-000000000005', '019b4000-0000-7000-8000-000000000001', '019b4000-1000-7000-8000-000000000003', 'project_admin', '019b4000-f300-7000-8000-000000000001', 60, 'audit', 'expired', now() - interval '1 day'),
+    ('019b4000-fd00-7000-8000-000000000006', '019b4000-0000-7000-8000-000000000001', '019b4000-1000-7000-8000-000000000002', 'admin', NULL, 60, 'curious', 'denied', NULL);
+`
+
+// fakeStore is an OpenFGA store that serves the write endpoint the handlers
+// use, applying deletes before writes as OpenFGA does.
+type fakeStore struct {
+	mu     sync.Mutex
+	tuples tupleSet
+}
+
+func (s *fakeStore) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost || !strings.HasSuffix(r.URL.Path, "/write") {
+		http.NotFound(w, r)
+		return
+	}
+
+	var req openfga.WriteRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if req.Deletes != nil {
+		for _, tk := range req.Deletes.TupleKeys {
+			delete(s.tuples, tupleID(tk.User, tk.Relation, tk.Object))
+		}
+	}
+	if req.Writes != nil {
+		for _, tk := range req.Writes.TupleKeys {
+			s.tuples.add(tk)
+		}
+	}
+
+	_, _ = w.Write([]byte("{}"))
+}
+
+// newTestHandler returns a handler writing to a fresh fake store.
+func newTestHandler(t *testing.T) (*handler.Handler, *fakeStore) {
+	t.Helper()
+
+	store := &fakeStore{tuples: make(tupleSet)}
+	server := httptest.NewServer(store)
+	t.Cleanup(server.Close)
+
+	fga, err := client.NewSdkClient(&client.ClientConfiguration{
+		ApiUrl:               server.URL,
+		StoreId:              "01HVMMBCMGZNT3SED4Z17ECXCA",
+		AuthorizationModelId: "01HVMMBD6SXWBJS5A4J2JWZ6S3",
+	})
+	require.NoError(t, err)
+
+	return handler.New(fga, slog.New(slog.NewTextHandler(io.Discard, nil))), store
+}
+
+// TestExpectedTuples_MatchHandlers runs an entity type's outbox handler for
+// every row of its table, deleted ones included, and checks that the store
+// ends up holding exactly the tuples expectedTuples derives for it. Repair
+// deletes whatever the store holds beyond those, so any difference here is a
+// tuple a repair would break.
+func TestExpectedTuples_MatchHandlers(t *testing.T) {
+	pool := createTestDB(t)
+
+	_, err := pool.Exec(t.Context(), parityFixtures)
+	require.NoError(t, err)
+
+	qtx := db.New(pool)
+
+	tests := []struct {
+		name   string
+		table  string
+		handle func(*handler.Handler, context.Context, *db.Queries, uuid.UUID) error
+		expect []expectFunc
+	}{
+		{
+			name:   "organization user",
+			table:  "tenant.organizations_users",
+			handle: (*handler.Handler).OrganizationUser,
+			expect: []expectFunc{expectOrganizationUsers},
+		},
+		{
+			name:   "cluster",
+			table:  "tenant.clusters",
+			handle: (*handler.Handler).Cluster,
+			expect: []expectFunc{expectClusters},
+		},
+		{
+			name:   "project",
+			table:  "tenant.projects",
+			handle: (*handler.Handler).Project,
+			expect: []expectFunc{expectProjects},
+		},
+		{
+			name:   "project member",
+			table:  "tenant.project_members",
+			handle: (*handler.Handler).ProjectMember,
+			expect: []expectFunc{expectProjectMembers},
+		},
+		{
+			name:   "node pool",
+			table:  "tenant.node_pools",
+			handle: (*handler.Handler).NodePool,
+			expect: []expectFunc{expectNodePools},
+		},
+		{
+			// A namespace's row also writes the roles bindings grant in it.
+			name:   "namespace",
+			table:  "tenant.namespaces",
+			handle: (*handler.Handler).Namespace,
+			expect: []expectFunc{expectNamespaces, expectNamespaceRoleBindings},
+		},
+		{
+			name:   "namespace role binding",
+			table:  "tenant.namespace_role_bindings",
+			handle: (*handler.Handler).NamespaceRoleBinding,
+			expect: []expectFunc{expectNamespaceRoleBindings},
+		},
+		{
+			name:   "api key",
+			table:  "authn.api_keys",
+			handle: (*handler.Handler).ApiKey,
+			expect: []expectFunc{expectApiKeys},
+		},
+		{
+			name:   "plugin",
+			table:  "appstore.plugins",
+			handle: (*handler.Handler).Plugin,
+			expect: []expectFunc{expectPlugins},
+		},
+		{
+			name:   "team",
+			table:  "tenant.teams",
+			handle: (*handler.Handler).Team,
+			expect: []expectFunc{expectTeams},
+		},
+		{
+			name:   "team member",
+			table:  "tenant.team_members",
+			handle: (*handler.Handler).TeamMember,
+			expect: []expectFunc{expectTeamMembers},
+		},
+		{
+			name:   "project team",
+			table:  "tenant.project_teams",
+			handle: (*handler.Handler).ProjectTeam,
+			expect: []expectFunc{expectProjectTeams},
+		},
+		{
+			name:   "access elevation",
+			table:  "tenant.access_elevations",
+			handle: (*handler.Handler).AccessElevation,
+			expect: []expectFunc{expectAccessElevations},
+		},
+	}
+
+	require.Len(t, tests, len(expectations), "every expectation needs a parity test")
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h, store := newTestHandler(t)
+
+			rows, err := pool.Query(t.Context(), "SELECT id FROM "+tt.table+" ORDER BY created, id")
+			require.NoError(t, err)
+			ids, err := pgx.CollectRows(rows, pgx.RowTo[uuid.UUID])
+			require.NoError(t, err)
+
+			for _, id := range ids {
+				require.NoError(t, tt.handle(h, t.Context(), qtx, id), "handle %s", id)
+			}
+
+			expected := make(tupleSet)
+			for _, expect := range tt.expect {
+				require.NoError(t, expect(t.Context(), qtx, expected))
+			}
+			require.NotEmpty(t, expected, "the fixtures should give %s tuples", tt.table)
+
+			drift := diff(expected, store.tuples, nil)
+			assert.Empty(t, drift.Missing, "tuples expected but not written by the handler")
+			assert.Empty(t, drift.Extra, "tuples written by the handler but not expected")
+			assert.Empty(t, drift.Changed, "tuples expected with another condition")
+		})
+	}
+}
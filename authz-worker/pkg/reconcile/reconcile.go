@@ -0,0 +1,249 @@
+// Package reconcile detects and repairs drift between the tuples the database
+// calls for and the tuples in the OpenFGA store. The outbox only carries
+// incremental changes, so a tuple lost to a failed write, a manual edit or a
+// store restore is otherwise never corrected.
+package reconcile
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	openfga "github.com/openfga/go-sdk"
+	"github.com/openfga/go-sdk/client"
+
+	db "github.com/fundament-oss/fundament/authz-worker/pkg/db/gen"
+	"github.com/fundament-oss/fundament/common/rollback"
+)
+
+const (
+	// readPageSize is the number of tuples read from the store per request.
+	readPageSize = 100
+
+	// writeChunkSize stays within OpenFGA's default limit of 100 tuples per
+	// write request.
+	writeChunkSize = 100
+)
+
+// Config holds configuration for the reconciler.
+type Config struct {
+	// Interval is the time between two reconciliations in Run.
+	Interval time.Duration
+	// Repair makes Run write missing tuples and delete extra ones, instead of
+	// only reporting them.
+	Repair bool
+}
+
+// Reconciler compares the OpenFGA store with the database.
+type Reconciler struct {
+	pool    *pgxpool.Pool
+	queries *db.Queries
+	fga     *client.OpenFgaClient
+	logger  *slog.Logger
+	cfg     Config
+}
+
+// New creates a new reconciler.
+func New(pool *pgxpool.Pool, fgaClient *client.OpenFgaClient, logger *slog.Logger, cfg Config) *Reconciler {
+	return &Reconciler{
+		pool:    pool,
+		queries: db.New(pool),
+		fga:     fgaClient,
+		logger:  logger.With("component", "reconciler"),
+		cfg:     cfg,
+	}
+}
+
+// Run reconciles every interval until the context is cancelled. A failed
+// reconciliation is logged and tried again at the next interval.
+func (r *Reconciler) Run(ctx context.Context) error {
+	r.logger.Info("starting authz reconciler", "interval", r.cfg.Interval, "repair", r.cfg.Repair)
+
+	ticker := time.NewTicker(r.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("reconciler stopped: %w", ctx.Err())
+		case <-ticker.C:
+		}
+
+		if _, err := r.Reconcile(ctx, r.cfg.Repair); err != nil {
+			r.logger.Error("failed to reconcile", "error", err)
+		}
+	}
+}
+
+// Reconcile compares the store with the database, logs the drift and, when
+// repair is set, corrects it.
+//
+// The store is read before the database, so the database is never older than
+// the store it is compared with. Objects with outbox rows that are not
+// processed, or were processed since the store read started, are left out:
+// the worker may still be bringing their tuples up to date.
+func (r *Reconciler) Reconcile(ctx context.Context, repair bool) (*Drift, error) {
+	start := time.Now()
+
+	since, err := r.queries.GetDatabaseTime(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get database time: %w", err)
+	}
+
+	actual, err := r.readStore(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	expected, inFlight, err := r.readDatabase(ctx, since)
+	if err != nil {
+		return nil, err
+	}
+
+	drift := diff(expected, actual, inFlight)
+	r.logDrift(ctx, drift)
+
+	if repair && !drift.Empty() {
+		// A repair that fails halfway has still changed tuples, so the
+		// notification goes out either way.
+		repairErr := r.repair(ctx, drift)
+		if err := r.queries.NotifyTuplesChanged(ctx, db.NotifyTuplesChangedParams{Payload: "reconcile"}); err != nil {
+			return drift, errors.Join(repairErr, fmt.Errorf("notify tuples changed: %w", err))
+		}
+		if repairErr != nil {
+			return drift, repairErr
+		}
+	}
+
+	r.logger.InfoContext(ctx, "reconciled authz tuples",
+		"expected", len(expected),
+		"actual", len(actual),
+		"missing", len(drift.Missing),
+		"extra", len(drift.Extra),
+		"changed", len(drift.Changed),
+		"skipped", drift.Skipped,
+		"repaired", repair && !drift.Empty(),
+		"duration", time.Since(start),
+	)
+
+	return drift, nil
+}
+
+// readStore reads every tuple in the store.
+func (r *Reconciler) readStore(ctx context.Context) (tupleSet, error) {
+	actual := make(tupleSet)
+	consistency := openfga.CONSISTENCYPREFERENCE_HIGHER_CONSISTENCY
+	var token string
+
+	for {
+		res, err := r.fga.Read(ctx).
+			Body(client.ClientReadRequest{}).
+			Options(client.ClientReadOptions{
+				PageSize:          openfga.PtrInt32(readPageSize),
+				ContinuationToken: &token,
+				Consistency:       &consistency,
+			}).
+			Execute()
+		if err != nil {
+			return nil, fmt.Errorf("read tuples: %w", err)
+		}
+
+		for _, t := range res.Tuples {
+			actual.add(t.Key)
+		}
+
+		if res.ContinuationToken == "" {
+			return actual, nil
+		}
+		token = res.ContinuationToken
+	}
+}
+
+// readDatabase returns the expected tuples and the objects with in-flight
+// outbox rows from one snapshot of the database.
+func (r *Reconciler) readDatabase(ctx context.Context, since pgtype.Timestamptz) (tupleSet, map[string]bool, error) {
+	tx, err := r.pool.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.RepeatableRead, AccessMode: pgx.ReadOnly})
+	if err != nil {
+		return nil, nil, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer rollback.Rollback(ctx, tx, r.logger)
+
+	qtx := r.queries.WithTx(tx)
+
+	expected, err := expectedTuples(ctx, qtx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	objects, err := qtx.ListInFlightOutboxObjects(ctx, db.ListInFlightOutboxObjectsParams{Since: since})
+	if err != nil {
+		return nil, nil, fmt.Errorf("list in-flight outbox objects: %w", err)
+	}
+	inFlight := make(map[string]bool, len(objects))
+	for _, object := range objects {
+		inFlight[object] = true
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, nil, fmt.Errorf("commit: %w", err)
+	}
+
+	return expected, inFlight, nil
+}
+
+func (r *Reconciler) logDrift(ctx context.Context, drift *Drift) {
+	for _, kind := range []struct {
+		name   string
+		tuples []openfga.TupleKey
+	}{
+		{"missing", drift.Missing},
+		{"extra", drift.Extra},
+		{"changed", drift.Changed},
+	} {
+		for _, t := range kind.tuples {
+			r.logger.WarnContext(ctx, "authz tuple drift",
+				"drift", kind.name,
+				"user", t.User,
+				"relation", t.Relation,
+				"object", t.Object,
+			)
+		}
+	}
+}
+
+// repair deletes the extra and changed tuples, then writes the missing and
+// changed ones. Like the outbox handlers it ignores tuples that were already
+// written or deleted in the meantime.
+func (r *Reconciler) repair(ctx context.Context, drift *Drift) error {
+	opts := client.ClientWriteOptions{
+		Conflict: client.ClientWriteConflictOptions{
+			OnDuplicateWrites: client.CLIENT_WRITE_REQUEST_ON_DUPLICATE_WRITES_IGNORE,
+			OnMissingDeletes:  client.CLIENT_WRITE_REQUEST_ON_MISSING_DELETES_IGNORE,
+		},
+	}
+
+	deletes := make([]openfga.TupleKeyWithoutCondition, 0, len(drift.Extra)+len(drift.Changed))
+	for _, t := range slices.Concat(drift.Extra, drift.Changed) {
+		deletes = append(deletes, openfga.TupleKeyWithoutCondition{User: t.User, Relation: t.Relation, Object: t.Object})
+	}
+	for chunk := range slices.Chunk(deletes, writeChunkSize) {
+		if _, err := r.fga.DeleteTuples(ctx).Body(chunk).Options(opts).Execute(); err != nil {
+			return fmt.Errorf("delete tuples: %w", err)
+		}
+	}
+
+	writes := slices.Concat(drift.Missing, drift.Changed)
+	for chunk := range slices.Chunk(writes, writeChunkSize) {
+		if _, err := r.fga.WriteTuples(ctx).Body(chunk).Options(opts).Execute(); err != nil {
+			return fmt.Errorf("write tuples: %w", err)
+		}
+	}
+
+	return nil
+}
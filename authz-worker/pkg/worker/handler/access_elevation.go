@@ -0,0 +1,73 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	openfga "github.com/openfga/go-sdk"
+
+	db "github.com/fundament-oss/fundament/authz-worker/pkg/db/gen"
+	"github.com/fundament-oss/fundament/common/authz"
+	"github.com/fundament-oss/fundament/common/dbconst"
+)
+
+// AccessElevation syncs the elevated role an access elevation asks for to
+// OpenFGA. The role is held while an elevation for it is approved; the tuple
+// carries the expiry as a condition, so the role also ends on time when the
+// elevation is not yet marked expired.
+//
+// The tuple is derived from the approved elevation for the same role rather
+// than from this one: a late row of an elevation that ended must not remove
+// the role a newer elevation granted.
+func (h *Handler) AccessElevation(ctx context.Context, qtx *db.Queries, elevationID uuid.UUID) error {
+	elevation, err := qtx.GetAccessElevationByID(ctx, db.GetAccessElevationByIDParams{ID: elevationID})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("access elevation not found: %s", elevationID)
+		}
+
+		return fmt.Errorf("get access elevation: %w", err)
+	}
+
+	h.logger.DebugContext(ctx, "handle access_elevation", "access_elevation", elevation)
+
+	user := authz.User(elevation.UserID)
+	object := AccessElevationObject(elevation.Role, elevation.OrganizationID, elevation.ProjectID)
+
+	if !elevation.ActiveExpires.Valid {
+		return h.deleteTuplesIfExist(ctx, tupleDelete(user, authz.ActionElevatedAdmin, object))
+	}
+
+	return h.writeTuplesIfNotExist(ctx, ElevatedAdminTuple(user, object, elevation.ActiveExpires.Time))
+}
+
+// AccessElevationObject returns the object an access elevation for role
+// grants elevated_admin on.
+func AccessElevationObject(role dbconst.AccessElevationRole, organizationID uuid.UUID, projectID pgtype.UUID) authz.Object {
+	switch role {
+	case dbconst.AccessElevationRole_Admin:
+		return authz.Organization(organizationID)
+	case dbconst.AccessElevationRole_ProjectAdmin:
+		return authz.Project(projectID.Bytes)
+	default:
+		panic(fmt.Sprintf("unknown access elevation role: %s", role))
+	}
+}
+
+// ElevatedAdminTuple returns the elevated_admin tuple of user on object that
+// holds until expires.
+func ElevatedAdminTuple(user, object authz.Object, expires time.Time) openfga.TupleKey {
+	t := tuple(user, authz.ActionElevatedAdmin, object)
+	t.Condition = &openfga.RelationshipCondition{
+		Name: "is_not_expired",
+		Context: &map[string]any{
+			"expiration": expires.UTC().Format(time.RFC3339),
+		},
+	}
+	return t
+}
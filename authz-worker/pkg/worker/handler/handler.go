@@ -73,3 +73,9 @@ func tupleDelete(subject authz.Object, relation authz.ActionName, object authz.O
 		Object:   object.String(),
 	}
 }
+
+// userset returns the subject for every object holding relation on obj, e.g.
+// team:<id>#member, so a single tuple grants a relation to a whole group.
+func userset(obj authz.Object, relation authz.ActionName) authz.Object {
+	return authz.Object{Type: obj.Type, ID: obj.ID + "#" + string(relation)}
+}
@@ -7,12 +7,15 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
+	openfga "github.com/openfga/go-sdk"
 
 	db "github.com/fundament-oss/fundament/authz-worker/pkg/db/gen"
 	"github.com/fundament-oss/fundament/common/authz"
 )
 
-// Namespace syncs a namespace's project and cluster relationships to OpenFGA.
+// Namespace syncs a namespace's project and cluster relationships to OpenFGA,
+// together with the relations namespace role bindings grant in it. A namespace
+// created after a binding whose pattern matches it gets its relations here.
 func (h *Handler) Namespace(ctx context.Context, qtx *db.Queries, namespaceID uuid.UUID) error {
 	namespace, err := qtx.GetNamespaceByID(ctx, db.GetNamespaceByIDParams{ID: namespaceID})
 	if err != nil {
@@ -28,11 +31,22 @@ func (h *Handler) Namespace(ctx context.Context, qtx *db.Queries, namespaceID uu
 	projectObj := authz.Project(namespace.ProjectID)
 	namespaceObj := authz.Namespace(namespace.ID)
 
+	grants, err := qtx.ListNamespaceRoleGrants(ctx, db.ListNamespaceRoleGrantsParams{NamespaceID: namespace.ID})
+	if err != nil {
+		return fmt.Errorf("list namespace role grants: %w", err)
+	}
+
 	if namespace.Deleted.Valid {
-		return h.deleteTuplesIfExist(ctx,
-			tupleDelete(projectObj, authz.ActionParent, namespaceObj),
-		)
+		deletes := []openfga.TupleKeyWithoutCondition{tupleDelete(projectObj, authz.ActionParent, namespaceObj)}
+		for _, grant := range grants {
+			deletes = append(deletes, tupleDelete(authz.User(grant.UserID), NamespaceRoleRelation(grant.Role), namespaceObj))
+		}
+		return h.deleteTuplesIfExist(ctx, deletes...)
 	}
 
-	return h.writeTuplesIfNotExist(ctx, tuple(projectObj, authz.ActionParent, namespaceObj))
+	writes := []openfga.TupleKey{tuple(projectObj, authz.ActionParent, namespaceObj)}
+	for _, grant := range grants {
+		writes = append(writes, tuple(authz.User(grant.UserID), NamespaceRoleRelation(grant.Role), namespaceObj))
+	}
+	return h.writeTuplesIfNotExist(ctx, writes...)
 }
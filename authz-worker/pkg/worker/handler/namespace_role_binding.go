@@ -0,0 +1,113 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	openfga "github.com/openfga/go-sdk"
+
+	db "github.com/fundament-oss/fundament/authz-worker/pkg/db/gen"
+	"github.com/fundament-oss/fundament/common/authz"
+	"github.com/fundament-oss/fundament/common/dbconst"
+)
+
+// namespaceRoles are the namespace role binding roles, in the order their
+// relations are written.
+var namespaceRoles = []dbconst.NamespaceRoleBindingRole{
+	dbconst.NamespaceRoleBindingRole_Deploy,
+	dbconst.NamespaceRoleBindingRole_ViewPods,
+	dbconst.NamespaceRoleBindingRole_ViewLogs,
+	dbconst.NamespaceRoleBindingRole_ManageServices,
+}
+
+// NamespaceRoleRelation returns the namespace relation a role binding role
+// grants.
+func NamespaceRoleRelation(role dbconst.NamespaceRoleBindingRole) authz.ActionName {
+	switch role {
+	case dbconst.NamespaceRoleBindingRole_Deploy:
+		return authz.ActionDeployer
+	case dbconst.NamespaceRoleBindingRole_ViewPods:
+		return authz.ActionPodViewer
+	case dbconst.NamespaceRoleBindingRole_ViewLogs:
+		return authz.ActionLogViewer
+	case dbconst.NamespaceRoleBindingRole_ManageServices:
+		return authz.ActionServiceManager
+	default:
+		panic(fmt.Sprintf("unknown namespace role binding role: %s", role))
+	}
+}
+
+// NamespaceRoleBinding syncs the namespace relations of a binding's user in
+// every namespace the binding's pattern matches.
+//
+// Two bindings can grant the same role in the same namespace (staging-* and
+// staging-api), so removing one must not drop the relation. The user's
+// relations in each matched namespace are therefore recomputed from all of
+// their bindings rather than derived from this binding alone.
+func (h *Handler) NamespaceRoleBinding(ctx context.Context, qtx *db.Queries, bindingID uuid.UUID) error {
+	binding, err := qtx.GetNamespaceRoleBindingByID(ctx, db.GetNamespaceRoleBindingByIDParams{ID: bindingID})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("namespace role binding not found: %s", bindingID)
+		}
+
+		return fmt.Errorf("get namespace role binding: %w", err)
+	}
+
+	h.logger.DebugContext(ctx, "handle namespace role binding", "binding", binding)
+
+	namespaceIDs, err := qtx.ListNamespacesMatchingPattern(ctx, db.ListNamespacesMatchingPatternParams{
+		ProjectID:        binding.ProjectID,
+		NamespacePattern: binding.NamespacePattern,
+	})
+	if err != nil {
+		return fmt.Errorf("list namespaces matching %q: %w", binding.NamespacePattern, err)
+	}
+
+	for _, namespaceID := range namespaceIDs {
+		if err := h.syncUserNamespaceRoles(ctx, qtx, binding.UserID, namespaceID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// syncUserNamespaceRoles makes the user's namespace relations match the roles
+// their active bindings grant in the namespace.
+func (h *Handler) syncUserNamespaceRoles(ctx context.Context, qtx *db.Queries, userID, namespaceID uuid.UUID) error {
+	grants, err := qtx.ListNamespaceRoleGrants(ctx, db.ListNamespaceRoleGrantsParams{NamespaceID: namespaceID})
+	if err != nil {
+		return fmt.Errorf("list namespace role grants: %w", err)
+	}
+
+	granted := make(map[dbconst.NamespaceRoleBindingRole]bool)
+	for _, grant := range grants {
+		if grant.UserID == userID {
+			granted[grant.Role] = true
+		}
+	}
+
+	user := authz.User(userID)
+	namespace := authz.Namespace(namespaceID)
+
+	var writes []openfga.TupleKey
+	var deletes []openfga.TupleKeyWithoutCondition
+	for _, role := range namespaceRoles {
+		relation := NamespaceRoleRelation(role)
+		if granted[role] {
+			writes = append(writes, tuple(user, relation, namespace))
+		} else {
+			deletes = append(deletes, tupleDelete(user, relation, namespace))
+		}
+	}
+
+	if err := h.deleteTuplesIfExist(ctx, deletes...); err != nil {
+		return err
+	}
+
+	return h.writeTuplesIfNotExist(ctx, writes...)
+}
@@ -0,0 +1,127 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	openfga "github.com/openfga/go-sdk"
+
+	db "github.com/fundament-oss/fundament/authz-worker/pkg/db/gen"
+	"github.com/fundament-oss/fundament/common/authz"
+	"github.com/fundament-oss/fundament/common/dbconst"
+)
+
+// Team syncs a team's organization and the organization role its members
+// hold to OpenFGA. Memberships and project assignments are synced by their
+// own outbox rows.
+func (h *Handler) Team(ctx context.Context, qtx *db.Queries, teamID uuid.UUID) error {
+	team, err := qtx.GetTeamByID(ctx, db.GetTeamByIDParams{ID: teamID})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("team not found: %s", teamID)
+		}
+
+		return fmt.Errorf("get team: %w", err)
+	}
+
+	h.logger.DebugContext(ctx, "handle team", "team", team)
+
+	org := authz.Organization(team.OrganizationID)
+	teamObj := authz.Team(team.ID)
+	members := userset(teamObj, authz.ActionMember)
+
+	// Remove conflicting role tuples first
+	if err := h.deleteTuplesIfExist(ctx,
+		tupleDelete(members, authz.ActionAdmin, org),
+		tupleDelete(members, authz.ActionViewer, org),
+	); err != nil {
+		return err
+	}
+
+	if team.Deleted.Valid {
+		return h.deleteTuplesIfExist(ctx, tupleDelete(org, authz.ActionOwner, teamObj))
+	}
+
+	writes := []openfga.TupleKey{tuple(org, authz.ActionOwner, teamObj)}
+
+	if team.Permission.Valid {
+		switch dbconst.TeamPermission(team.Permission.String) {
+		case dbconst.TeamPermission_Admin:
+			writes = append(writes, tuple(members, authz.ActionAdmin, org))
+		case dbconst.TeamPermission_Viewer:
+			writes = append(writes, tuple(members, authz.ActionViewer, org))
+		default:
+			panic(fmt.Sprintf("unknown team permission: %s", team.Permission.String))
+		}
+	}
+
+	return h.writeTuplesIfNotExist(ctx, writes...)
+}
+
+// TeamMember syncs a user's membership of a team to OpenFGA.
+func (h *Handler) TeamMember(ctx context.Context, qtx *db.Queries, memberID uuid.UUID) error {
+	member, err := qtx.GetTeamMemberByID(ctx, db.GetTeamMemberByIDParams{ID: memberID})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("team member not found: %s", memberID)
+		}
+
+		return fmt.Errorf("get team member: %w", err)
+	}
+
+	h.logger.DebugContext(ctx, "handle team member", "member", member)
+
+	user := authz.User(member.UserID)
+	team := authz.Team(member.TeamID)
+
+	if member.Deleted.Valid {
+		return h.deleteTuplesIfExist(ctx, tupleDelete(user, authz.ActionMember, team))
+	}
+
+	return h.writeTuplesIfNotExist(ctx, tuple(user, authz.ActionMember, team))
+}
+
+// ProjectTeam syncs the project role a team's members hold to OpenFGA.
+func (h *Handler) ProjectTeam(ctx context.Context, qtx *db.Queries, projectTeamID uuid.UUID) error {
+	projectTeam, err := qtx.GetProjectTeamByID(ctx, db.GetProjectTeamByIDParams{ID: projectTeamID})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("project team not found: %s", projectTeamID)
+		}
+
+		return fmt.Errorf("get project team: %w", err)
+	}
+
+	h.logger.DebugContext(ctx, "handle project team", "project_team", projectTeam)
+
+	members := userset(authz.Team(projectTeam.TeamID), authz.ActionMember)
+	project := authz.Project(projectTeam.ProjectID)
+
+	// Remove conflicting role tuples first
+	if err := h.deleteTuplesIfExist(ctx,
+		tupleDelete(members, authz.ActionProjectAdmin, project),
+		tupleDelete(members, authz.ActionProjectViewer, project),
+	); err != nil {
+		return err
+	}
+
+	if projectTeam.Deleted.Valid {
+		return nil
+	}
+
+	var action authz.ActionName
+
+	switch projectTeam.Role {
+	case dbconst.ProjectTeamRole_Admin:
+		action = authz.ActionProjectAdmin
+	case dbconst.ProjectTeamRole_Viewer:
+		action = authz.ActionProjectViewer
+	default:
+		panic(fmt.Sprintf("unknown project team role: %s", projectTeam.Role))
+	}
+
+	return h.writeTuplesIfNotExist(ctx, tuple(members, action, project))
+}
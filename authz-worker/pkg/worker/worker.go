@@ -305,6 +305,10 @@ func (w *Worker) processOneItem(ctx context.Context) (found bool, err error) {
 		return true, fmt.Errorf("mark as processed: %w", err)
 	}
 
+	if err := qtx.NotifyTuplesChanged(finalizeCtx, db.NotifyTuplesChangedParams{Payload: item.ID.String()}); err != nil {
+		return true, fmt.Errorf("notify tuples changed: %w", err)
+	}
+
 	if err := tx.Commit(finalizeCtx); err != nil {
 		return true, fmt.Errorf("commit: %w", err)
 	}
@@ -368,6 +372,16 @@ func (w *Worker) dispatchItem(ctx context.Context, qtx *db.Queries, item *db.Get
 		return w.handler.ApiKey(ctx, qtx, item.ApiKeyID.Bytes)
 	case item.PluginID.Valid:
 		return w.handler.Plugin(ctx, qtx, item.PluginID.Bytes)
+	case item.NamespaceRoleBindingID.Valid:
+		return w.handler.NamespaceRoleBinding(ctx, qtx, item.NamespaceRoleBindingID.Bytes)
+	case item.TeamID.Valid:
+		return w.handler.Team(ctx, qtx, item.TeamID.Bytes)
+	case item.TeamMemberID.Valid:
+		return w.handler.TeamMember(ctx, qtx, item.TeamMemberID.Bytes)
+	case item.ProjectTeamID.Valid:
+		return w.handler.ProjectTeam(ctx, qtx, item.ProjectTeamID.Bytes)
+	case item.AccessElevationID.Valid:
+		return w.handler.AccessElevation(ctx, qtx, item.AccessElevationID.Bytes)
 	default:
 		return fmt.Errorf("unknown outbox subject FK")
 	}
@@ -78,6 +78,25 @@ JWT Secret environment variable - supports both direct value and secretRef
 {{- end }}
 {{- end }}
 
+{{/*
+Token verification environment for services that validate console tokens:
+authn-api's key set when signing keys are configured, the shared JWT secret
+otherwise, or both while switching over. Pass "jwksHost" to verify against
+another issuer's key set, e.g. dcim-authn-api for DCIM tokens.
+*/}}
+{{- define "fundament.jwtVerificationEnv" -}}
+{{- $root := .root | default . }}
+{{- with $root }}
+{{- if .Values.jwtSigningKeys.secretName }}
+- name: JWKS_URL
+  value: http://{{ $.jwksHost | default "authn-api" }}:8080/.well-known/jwks.json
+{{- end }}
+{{- if or (not .Values.jwtSigningKeys.secretName) .Values.jwtSigningKeys.acceptSharedSecret }}
+{{ include "fundament.jwtSecretEnv" . }}
+{{- end }}
+{{- end }}
+{{- end }}
+
 {{/*
 Subdomain infix for ingress hostnames (e.g., "pr123." for PR environments)
 Inserted between service name and domain: service.pr123.domain
@@ -18,10 +18,13 @@ import (
 	"github.com/fundament-oss/fundament/cluster-worker/pkg/handler"
 	clusterhandler "github.com/fundament-oss/fundament/cluster-worker/pkg/handler/cluster"
 	namespacehandler "github.com/fundament-oss/fundament/cluster-worker/pkg/handler/namespace"
+	"github.com/fundament-oss/fundament/cluster-worker/pkg/handler/pluginstatus"
 	"github.com/fundament-oss/fundament/cluster-worker/pkg/handler/usersync"
+	"github.com/fundament-oss/fundament/cluster-worker/pkg/lease"
 	"github.com/fundament-oss/fundament/cluster-worker/pkg/outbox"
 	"github.com/fundament-oss/fundament/cluster-worker/pkg/reconcile"
 	"github.com/fundament-oss/fundament/cluster-worker/pkg/status"
+	"github.com/fundament-oss/fundament/cluster-worker/pkg/webhook"
 	"github.com/fundament-oss/fundament/common/dbconst"
 )
 
@@ -32,10 +35,15 @@ type Config struct {
 
 	Gardener GardenerConfig `envPrefix:"GARDENER_"`
 
+	Lease lease.Config `envPrefix:"LEASE_"`
+
 	Outbox    outbox.Config         `envPrefix:"OUTBOX_"`
 	Status    status.Config         `envPrefix:"STATUS_"`
 	Reconcile reconcile.Config      `envPrefix:"RECONCILE_"`
 	Cluster   clusterhandler.Config `envPrefix:"CLUSTER_"`
+
+	Webhook      webhook.Config      `envPrefix:"WEBHOOK_"`
+	PluginStatus pluginstatus.Config `envPrefix:"PLUGIN_STATUS_"`
 }
 
 // GardenerConfig configures the Gardener client and the provider defaults the
@@ -84,9 +92,11 @@ type ReadyChecker interface {
 type App struct {
 	pool            *pgxpool.Pool
 	registry        *handler.Registry
+	leases          *lease.Leases
 	outboxWorker    *outbox.Worker
 	statusWorker    *status.Worker
 	reconcileWorker *reconcile.Worker
+	webhookWorker   *webhook.Worker
 	healthServer    *http.Server
 	logger          *slog.Logger
 	cfg             *Config
@@ -94,6 +104,10 @@ type App struct {
 
 // New creates and wires up the cluster-worker application.
 func New(pool *pgxpool.Pool, logger *slog.Logger, cfg *Config) (*App, error) {
+	if cfg.Outbox.Shards < 1 {
+		return nil, fmt.Errorf("OUTBOX_SHARDS must be at least 1, got %d", cfg.Outbox.Shards)
+	}
+
 	gardenerClient, err := createGardenerClient(cfg, logger)
 	if err != nil {
 		return nil, err
@@ -108,7 +122,7 @@ func New(pool *pgxpool.Pool, logger *slog.Logger, cfg *Config) (*App, error) {
 	registry.RegisterStatus(ch)
 	registry.RegisterReconcile(ch)
 
-	// User sync handler (SA/CRB lifecycle on shoots)
+	// User sync handler (SA/CRB lifecycle on shoots, expiry of elevated access)
 	shootAccess, err := createShootAccess(cfg.Gardener.Mode, gardenerClient, logger)
 	if err != nil {
 		return nil, err
@@ -116,7 +130,9 @@ func New(pool *pgxpool.Pool, logger *slog.Logger, cfg *Config) (*App, error) {
 	ush := usersync.New(pool, shootAccess, logger)
 	registry.RegisterSync(handler.EntityOrgUser, ush)
 	registry.RegisterSync(handler.EntityProjectMember, ush)
+	registry.RegisterSync(handler.EntityAccessElevation, ush)
 	registry.RegisterSyncForEvent(handler.EntityCluster, dbconst.ClusterOutboxEvent_Ready, ush)
+	registry.RegisterStatus(ush)
 	registry.RegisterReconcile(ush)
 
 	// Namespace sync handler (v1/Namespace lifecycle on shoots). Shares the same
@@ -129,20 +145,29 @@ func New(pool *pgxpool.Pool, logger *slog.Logger, cfg *Config) (*App, error) {
 	registry.RegisterSyncForEvent(handler.EntityCluster, dbconst.ClusterOutboxEvent_Ready, nsh)
 	registry.RegisterReconcile(nsh)
 
-	// Workers
-	outboxWorker := outbox.New(pool, registry, logger, cfg.Outbox)
-	statusWorker := status.New(registry, logger, cfg.Status)
-	reconcileWorker := reconcile.New(registry, logger, cfg.Reconcile)
+	// Plugin status handler (records PluginInstallation phases so phase
+	// changes reach the organization's webhooks).
+	registry.RegisterStatus(pluginstatus.New(pool, shootAccess, logger, cfg.PluginStatus))
+
+	// Workers. Replicas share the outbox shards through leases; only the
+	// leader runs the status and reconcile loops.
+	leases := lease.New(pool, logger, cfg.Lease)
+	outboxWorker := outbox.New(pool, registry, leases, logger, cfg.Outbox)
+	statusWorker := status.New(registry, leases, logger, cfg.Status)
+	reconcileWorker := reconcile.New(registry, leases, logger, cfg.Reconcile)
+	webhookWorker := webhook.New(pool, logger, cfg.Webhook)
 
 	// Health server
-	healthServer := startHealthServer(cfg.HealthPort, logger, outboxWorker, statusWorker, reconcileWorker)
+	healthServer := startHealthServer(cfg.HealthPort, logger, leases, outboxWorker, statusWorker, reconcileWorker, webhookWorker)
 
 	return &App{
 		pool:            pool,
 		registry:        registry,
+		leases:          leases,
 		outboxWorker:    outboxWorker,
 		statusWorker:    statusWorker,
 		reconcileWorker: reconcileWorker,
+		webhookWorker:   webhookWorker,
 		healthServer:    healthServer,
 		logger:          logger,
 		cfg:             cfg,
@@ -156,13 +181,20 @@ func (a *App) Run(ctx context.Context) error {
 		"reconcile_interval", a.cfg.Reconcile.Interval,
 		"status_interval", a.cfg.Status.Interval,
 		"max_retries", a.cfg.Outbox.MaxRetries,
+		"outbox_shards", a.cfg.Outbox.Shards,
 		"gardener_mode", a.cfg.Gardener.Mode)
 
+	// Take the heartbeat and, if free, the leader lease before the loops
+	// start, so their first run does not wait a renew interval.
+	a.leases.Renew(ctx)
+
 	g, ctx := errgroup.WithContext(ctx)
 
+	g.Go(func() error { return a.leases.Run(ctx) })
 	g.Go(func() error { return a.outboxWorker.Run(ctx) })
 	g.Go(func() error { return a.statusWorker.Run(ctx) })
 	g.Go(func() error { return a.reconcileWorker.Run(ctx) })
+	g.Go(func() error { return a.webhookWorker.Run(ctx) })
 
 	err := g.Wait()
 
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	corev1 "k8s.io/api/core/v1"
 )
 
 // ShootStatusType represents the reconciliation state of a Shoot.
@@ -21,6 +22,7 @@ const (
 	StatusError       ShootStatusType = "error"
 	StatusDeleting    ShootStatusType = "deleting"
 	StatusDeleted     ShootStatusType = "deleted"
+	StatusHibernated  ShootStatusType = "hibernated"
 )
 
 // ShootStatus contains the current status and a descriptive message.
@@ -31,8 +33,9 @@ type ShootStatus struct {
 
 // Status message constants for consistent messaging.
 const (
-	MsgShootNotFound = "Shoot not found in Gardener"
-	MsgShootReady    = "Shoot is ready"
+	MsgShootNotFound   = "Shoot not found in Gardener"
+	MsgShootReady      = "Shoot is ready"
+	MsgShootHibernated = "Shoot is hibernated"
 )
 
 // AdminKubeconfig holds the result of an AdminKubeconfigRequest.
@@ -73,10 +76,17 @@ type NodePool struct {
 	MachineType  string
 	AutoscaleMin int32
 	AutoscaleMax int32
-	// Zone is the availability zone for this worker pool (e.g., "eu-central-1a").
-	// Empty string means no zone constraint, which is required for the local provider.
-	// TODO: populate from tenant.node_pools once a zone column is added to the schema.
-	Zone string
+	// Labels and Taints are set on every node of the pool.
+	Labels map[string]string
+	Taints []corev1.Taint
+	// Zones are the availability zones the pool is spread over (e.g.,
+	// "eu-central-1a"). Empty means no zone constraint, which is required for
+	// the local provider.
+	Zones []string
+	// MaxSurge and MaxUnavailable bound a rolling update of the pool's nodes.
+	// Both zero means unset and falls back to 1 and 0.
+	MaxSurge       int32
+	MaxUnavailable int32
 }
 
 // ClusterToSync contains all the information needed to sync a cluster to Gardener.
@@ -96,6 +106,15 @@ type ClusterToSync struct {
 	Deleted            *time.Time
 	NodePools          []NodePool // Node pool configurations for Gardener worker groups
 	NodeLimits         NodeLimits // Owning organization's node caps, applied at Shoot build time
+	// MaintenanceWindow is nil when the cluster leaves the window to Gardener.
+	MaintenanceWindow             *MaintenanceWindow
+	AutoUpdateKubernetesVersion   bool
+	AutoUpdateMachineImageVersion bool
+	// Hibernated is the last manually requested hibernation state, made at
+	// HibernationRequested (nil when never requested).
+	Hibernated           bool
+	HibernationRequested *time.Time
+	HibernationSchedules []HibernationSchedule
 }
 
 // NodeLimits are an organization's node caps from tenant.organization_limits.
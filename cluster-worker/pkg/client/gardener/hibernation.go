@@ -0,0 +1,64 @@
+package gardener
+
+import (
+	"time"
+
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+)
+
+// HibernationSchedule is a cron-style hibernation schedule. The cluster is
+// hibernated at Start and woken at End (either may be empty), evaluated in
+// the Location time zone.
+type HibernationSchedule struct {
+	Start    string
+	End      string
+	Location string
+}
+
+// applyHibernation maps the cluster's hibernation state and schedules onto
+// the Shoot. Gardener flips spec.hibernation.enabled itself when a schedule
+// fires, so enabled is only overwritten for a manual request the Shoot has
+// not seen yet; the applied request is tracked in
+// AnnotationHibernationRequested. Shoot annotations must be non-nil.
+func applyHibernation(shoot *gardencorev1beta1.Shoot, cluster *ClusterToSync) {
+	if shoot.Spec.Hibernation == nil && !cluster.Hibernated && len(cluster.HibernationSchedules) == 0 {
+		return
+	}
+
+	hibernation := &gardencorev1beta1.Hibernation{}
+	if shoot.Spec.Hibernation != nil {
+		hibernation = shoot.Spec.Hibernation.DeepCopy()
+	}
+	hibernation.Schedules = hibernationSchedules(cluster.HibernationSchedules)
+
+	var requested string
+	if cluster.HibernationRequested != nil {
+		requested = cluster.HibernationRequested.UTC().Format(time.RFC3339Nano)
+	}
+	if hibernation.Enabled == nil || shoot.Annotations[AnnotationHibernationRequested] != requested {
+		hibernation.Enabled = new(cluster.Hibernated)
+	}
+	if requested != "" {
+		shoot.Annotations[AnnotationHibernationRequested] = requested
+	}
+
+	shoot.Spec.Hibernation = hibernation
+}
+
+func hibernationSchedules(schedules []HibernationSchedule) []gardencorev1beta1.HibernationSchedule {
+	if len(schedules) == 0 {
+		return nil
+	}
+	result := make([]gardencorev1beta1.HibernationSchedule, 0, len(schedules))
+	for _, s := range schedules {
+		schedule := gardencorev1beta1.HibernationSchedule{Location: new(s.Location)}
+		if s.Start != "" {
+			schedule.Start = new(s.Start)
+		}
+		if s.End != "" {
+			schedule.End = new(s.End)
+		}
+		result = append(result, schedule)
+	}
+	return result
+}
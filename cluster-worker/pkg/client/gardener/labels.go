@@ -17,4 +17,8 @@ var (
 	// AnnotationClusterName is the annotation key for the original cluster name.
 	// Stored as annotation (not label) since it may change and is for reference only.
 	AnnotationClusterName = LabelPrefix + "/cluster-name"
+
+	// AnnotationHibernationRequested records which manual hibernate/wake
+	// request was last applied to the Shoot's spec.hibernation.enabled.
+	AnnotationHibernationRequested = LabelPrefix + "/hibernation-requested"
 )
@@ -0,0 +1,80 @@
+package gardener
+
+import (
+	"fmt"
+	"time"
+
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	"k8s.io/apimachinery/pkg/util/version"
+)
+
+// MaintenanceWindow is a cluster's daily maintenance window in UTC, as
+// offsets from midnight. End before Start is a window spanning midnight.
+type MaintenanceWindow struct {
+	Start time.Duration
+	End   time.Duration
+}
+
+// Contains reports whether t falls inside the window.
+func (w MaintenanceWindow) Contains(t time.Time) bool {
+	t = t.UTC()
+	offset := t.Sub(time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC))
+	if w.Start <= w.End {
+		return offset >= w.Start && offset < w.End
+	}
+	return offset >= w.Start || offset < w.End
+}
+
+// timeWindow formats the window in Gardener's HHMMSS+ZONE notation.
+func (w MaintenanceWindow) timeWindow() *gardencorev1beta1.MaintenanceTimeWindow {
+	return &gardencorev1beta1.MaintenanceTimeWindow{
+		Begin: formatWindowTime(w.Start),
+		End:   formatWindowTime(w.End),
+	}
+}
+
+func formatWindowTime(d time.Duration) string {
+	d = d.Truncate(time.Second)
+	return fmt.Sprintf("%02d%02d%02d+0000", int(d.Hours()), int(d.Minutes())%60, int(d.Seconds())%60)
+}
+
+// buildMaintenance maps the cluster's maintenance policy onto the Shoot. A
+// cluster without a window keeps the shoot's current one (existing may be
+// nil), so Gardener does not pick a new random window on every update.
+func buildMaintenance(cluster *ClusterToSync, existing *gardencorev1beta1.Maintenance) *gardencorev1beta1.Maintenance {
+	maintenance := &gardencorev1beta1.Maintenance{}
+	if existing != nil {
+		maintenance = existing.DeepCopy()
+	}
+
+	maintenance.AutoUpdate = &gardencorev1beta1.MaintenanceAutoUpdate{
+		KubernetesVersion:   cluster.AutoUpdateKubernetesVersion,
+		MachineImageVersion: new(cluster.AutoUpdateMachineImageVersion),
+	}
+	if cluster.MaintenanceWindow != nil {
+		maintenance.TimeWindow = cluster.MaintenanceWindow.timeWindow()
+	}
+	return maintenance
+}
+
+// shootKubernetesVersion returns the version to write to an existing Shoot.
+// With Kubernetes auto-updates enabled Gardener may already run a newer patch
+// release than the cluster record; writing the older version back would be
+// rejected as a downgrade, so the newer one is kept.
+func shootKubernetesVersion(current string, cluster *ClusterToSync) string {
+	if !cluster.AutoUpdateKubernetesVersion || current == "" {
+		return cluster.KubernetesVersion
+	}
+	currentVersion, err := version.ParseGeneric(current)
+	if err != nil {
+		return cluster.KubernetesVersion
+	}
+	wantVersion, err := version.ParseGeneric(cluster.KubernetesVersion)
+	if err != nil {
+		return cluster.KubernetesVersion
+	}
+	if currentVersion.GreaterThan(wantVersion) {
+		return current
+	}
+	return cluster.KubernetesVersion
+}
@@ -334,6 +334,9 @@ func (m *MockClient) GetShootStatus(ctx context.Context, cluster *ClusterToSync)
 				Status:  StatusProgressing,
 				Message: fmt.Sprintf("Shoot is being created (%.0f%% complete)", progress),
 			}
+		case shoot.Cluster.Hibernated:
+			// Schedules are not simulated; only the manual request counts
+			status = &ShootStatus{Status: StatusHibernated, Message: MsgShootHibernated}
 		default:
 			status = &ShootStatus{
 				Status:  StatusReady,
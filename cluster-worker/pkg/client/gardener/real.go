@@ -299,6 +299,12 @@ func (r *RealClient) GetShootStatus(ctx context.Context, cluster *ClusterToSync)
 		return &ShootStatus{Status: StatusDeleting, Message: "Shoot is being deleted"}, nil
 	}
 
+	// A spec change (e.g. a version upgrade) Gardener has not picked up yet
+	// would otherwise still report the previous operation's outcome.
+	if shoot.Status.LastOperation != nil && shoot.Status.ObservedGeneration < shoot.Generation {
+		return &ShootStatus{Status: StatusProgressing, Message: "Shoot spec change is waiting to be reconciled"}, nil
+	}
+
 	if shoot.Status.LastOperation != nil {
 		op := shoot.Status.LastOperation
 
@@ -308,6 +314,9 @@ func (r *RealClient) GetShootStatus(ctx context.Context, cluster *ClusterToSync)
 		case gardencorev1beta1.LastOperationStateError, gardencorev1beta1.LastOperationStateFailed:
 			return &ShootStatus{Status: StatusError, Message: op.Description}, nil
 		case gardencorev1beta1.LastOperationStateSucceeded:
+			if shoot.Status.IsHibernated {
+				return &ShootStatus{Status: StatusHibernated, Message: MsgShootHibernated}, nil
+			}
 			msg := MsgShootReady
 			if !r.isShootHealthy(shoot) {
 				msg = "Shoot reconciled but not all conditions healthy"
@@ -615,10 +624,12 @@ func (r *RealClient) buildShootSpec(cluster *ClusterToSync) (*gardencorev1beta1.
 			Kubernetes: gardencorev1beta1.Kubernetes{
 				Version: cluster.KubernetesVersion,
 			},
-			Provider:   provider,
-			Networking: r.buildNetworking(),
+			Provider:    provider,
+			Networking:  r.buildNetworking(),
+			Maintenance: buildMaintenance(cluster, nil),
 		},
 	}
+	applyHibernation(shoot, cluster)
 
 	// Set CredentialsBindingName (required for all providers, including local)
 	if r.provider.CredentialsBindingName != "" {
@@ -683,7 +694,6 @@ func (r *RealClient) buildNetworking() *gardencorev1beta1.Networking {
 // If the cluster has no node pools, a default worker group is created.
 // All workers share the same machine image from ProviderConfig.
 // TODO: support per-pool machine image once tenant.node_pools gains an image column.
-// TODO: populate NodePool.Zone from tenant.node_pools once a zone column is added.
 func (r *RealClient) buildWorkers(cluster *ClusterToSync) ([]gardencorev1beta1.Worker, error) {
 	if err := validateNodeLimits(cluster.NodePools, cluster.NodeLimits); err != nil {
 		return nil, err
@@ -721,8 +731,11 @@ func (r *RealClient) buildWorkers(cluster *ClusterToSync) ([]gardencorev1beta1.W
 	for i, np := range cluster.NodePools {
 		// Declare per iteration to avoid pointer aliasing: each worker gets its own
 		// maxSurge/maxUnavailable/imageVersion address, not a shared loop variable.
-		maxSurge := intstr.FromInt32(1)
-		maxUnavailable := intstr.FromInt32(0)
+		maxSurge := intstr.FromInt32(np.MaxSurge)
+		maxUnavailable := intstr.FromInt32(np.MaxUnavailable)
+		if np.MaxSurge == 0 && np.MaxUnavailable == 0 {
+			maxSurge = intstr.FromInt32(1)
+		}
 		imageVersion := r.provider.MachineImageVersion
 
 		// The local Gardener provider only supports machine type "local", but the
@@ -749,9 +762,12 @@ func (r *RealClient) buildWorkers(cluster *ClusterToSync) ([]gardencorev1beta1.W
 			Maximum:        np.AutoscaleMax,
 			MaxSurge:       &maxSurge,
 			MaxUnavailable: &maxUnavailable,
+			Labels:         np.Labels,
+			Taints:         np.Taints,
 		}
-		if np.Zone != "" {
-			workers[i].Zones = []string{np.Zone}
+		// The local provider has no zones to place workers in.
+		if r.provider.Type != "local" {
+			workers[i].Zones = np.Zones
 		}
 	}
 	clampWorkerMaxima(workers, cluster.NodeLimits.MaxNodesPerNodePool)
@@ -777,7 +793,9 @@ func (r *RealClient) updateShootSpec(shoot *gardencorev1beta1.Shoot, cluster *Cl
 	shoot.Annotations[AnnotationClusterName] = cluster.Name
 
 	shoot.Spec.Region = r.region(cluster)
-	shoot.Spec.Kubernetes.Version = cluster.KubernetesVersion
+	shoot.Spec.Kubernetes.Version = shootKubernetesVersion(shoot.Spec.Kubernetes.Version, cluster)
 	shoot.Spec.Provider.Workers = workers
+	shoot.Spec.Maintenance = buildMaintenance(cluster, shoot.Spec.Maintenance)
+	applyHibernation(shoot, cluster)
 	return nil
 }
@@ -3,9 +3,12 @@ package gardener
 import (
 	"log/slog"
 	"testing"
+	"time"
 
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
 )
 
 func testClient(provider ProviderConfig) *RealClient {
@@ -72,6 +75,42 @@ func TestBuildShootSpec_MetalProvider(t *testing.T) {
 	require.Equal(t, "fundament", shoot.Annotations["cluster.metal-stack.io/tenant"])
 }
 
+// Node pool labels, taints, zones and rolling update settings reach the workers.
+func TestBuildWorkers_NodePoolPlacement(t *testing.T) {
+	cluster := testCluster()
+	cluster.NodePools = []NodePool{
+		{
+			Name: "ingress", MachineType: "c1-large", AutoscaleMin: 1, AutoscaleMax: 3,
+			Labels:         map[string]string{"workload": "ingress"},
+			Taints:         []corev1.Taint{{Key: "dedicated", Value: "ingress", Effect: corev1.TaintEffectNoSchedule}},
+			Zones:          []string{"zone-a", "zone-b"},
+			MaxSurge:       0,
+			MaxUnavailable: 1,
+		},
+		{Name: "default", MachineType: "c1-large", AutoscaleMin: 1, AutoscaleMax: 3},
+	}
+
+	workers, err := testClient(ProviderConfig{Type: "metal"}).buildWorkers(cluster)
+	require.NoError(t, err)
+	require.Len(t, workers, 2)
+
+	require.Equal(t, map[string]string{"workload": "ingress"}, workers[0].Labels)
+	require.Equal(t, cluster.NodePools[0].Taints, workers[0].Taints)
+	require.Equal(t, []string{"zone-a", "zone-b"}, workers[0].Zones)
+	require.Equal(t, int32(0), workers[0].MaxSurge.IntVal)
+	require.Equal(t, int32(1), workers[0].MaxUnavailable.IntVal)
+
+	// Unset rolling update settings fall back to Gardener's defaults.
+	require.Equal(t, int32(1), workers[1].MaxSurge.IntVal)
+	require.Equal(t, int32(0), workers[1].MaxUnavailable.IntVal)
+
+	// The local provider has no zones.
+	workers, err = testClient(ProviderConfig{Type: "local", DefaultMachineType: "local"}).buildWorkers(cluster)
+	require.NoError(t, err)
+	require.Empty(t, workers[0].Zones)
+	require.Equal(t, map[string]string{"workload": "ingress"}, workers[0].Labels)
+}
+
 // An empty cluster region falls back to the provider default.
 func TestBuildShootSpec_RegionFallback(t *testing.T) {
 	r := testClient(ProviderConfig{Type: "metal", CloudProfile: "metal", Region: "local"})
@@ -112,3 +151,117 @@ func TestBuildShootSpec_LegacyClusterUsesProviderDefaults(t *testing.T) {
 	require.Equal(t, "metal", shoot.Spec.CloudProfile.Name)
 	require.Equal(t, "local", shoot.Spec.Region)
 }
+
+// The maintenance policy is stamped onto new shoots; without a window the
+// choice is left to Gardener.
+func TestBuildShootSpec_Maintenance(t *testing.T) {
+	r := testClient(NewProviderConfig())
+
+	cluster := testCluster()
+	cluster.AutoUpdateMachineImageVersion = true
+	shoot, err := r.buildShootSpec(cluster)
+	require.NoError(t, err)
+	require.NotNil(t, shoot.Spec.Maintenance)
+	require.False(t, shoot.Spec.Maintenance.AutoUpdate.KubernetesVersion)
+	require.True(t, *shoot.Spec.Maintenance.AutoUpdate.MachineImageVersion)
+	require.Nil(t, shoot.Spec.Maintenance.TimeWindow)
+
+	cluster.MaintenanceWindow = &MaintenanceWindow{Start: 22 * time.Hour, End: 90 * time.Minute}
+	shoot, err = r.buildShootSpec(cluster)
+	require.NoError(t, err)
+	require.Equal(t, "220000+0000", shoot.Spec.Maintenance.TimeWindow.Begin)
+	require.Equal(t, "013000+0000", shoot.Spec.Maintenance.TimeWindow.End)
+}
+
+// Updating a shoot without a window keeps the window Gardener assigned, and a
+// newer auto-updated patch release is not downgraded.
+func TestUpdateShootSpec_Maintenance(t *testing.T) {
+	r := testClient(NewProviderConfig())
+	cluster := testCluster()
+	cluster.AutoUpdateKubernetesVersion = true
+
+	shoot, err := r.buildShootSpec(cluster)
+	require.NoError(t, err)
+	shoot.Spec.Kubernetes.Version = "1.35.8"
+	shoot.Spec.Maintenance.TimeWindow = &gardencorev1beta1.MaintenanceTimeWindow{Begin: "030000+0000", End: "040000+0000"}
+
+	require.NoError(t, r.updateShootSpec(shoot, cluster))
+	require.Equal(t, "1.35.8", shoot.Spec.Kubernetes.Version)
+	require.Equal(t, "030000+0000", shoot.Spec.Maintenance.TimeWindow.Begin)
+
+	cluster.KubernetesVersion = "1.36.0"
+	require.NoError(t, r.updateShootSpec(shoot, cluster))
+	require.Equal(t, "1.36.0", shoot.Spec.Kubernetes.Version)
+
+	cluster.AutoUpdateKubernetesVersion = false
+	cluster.KubernetesVersion = "1.35.6"
+	require.NoError(t, r.updateShootSpec(shoot, cluster))
+	require.Equal(t, "1.35.6", shoot.Spec.Kubernetes.Version)
+}
+
+func TestMaintenanceWindow_Contains(t *testing.T) {
+	day := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	window := MaintenanceWindow{Start: 2 * time.Hour, End: 4 * time.Hour}
+	require.False(t, window.Contains(day.Add(time.Hour)))
+	require.True(t, window.Contains(day.Add(2*time.Hour)))
+	require.False(t, window.Contains(day.Add(4*time.Hour)))
+
+	overnight := MaintenanceWindow{Start: 23 * time.Hour, End: time.Hour}
+	require.True(t, overnight.Contains(day.Add(23*time.Hour+30*time.Minute)))
+	require.True(t, overnight.Contains(day.Add(30*time.Minute)))
+	require.False(t, overnight.Contains(day.Add(12*time.Hour)))
+}
+
+// Clusters without hibernation settings get no hibernation spec; schedules
+// and a manual request are stamped onto new shoots.
+func TestBuildShootSpec_Hibernation(t *testing.T) {
+	r := testClient(NewProviderConfig())
+
+	cluster := testCluster()
+	shoot, err := r.buildShootSpec(cluster)
+	require.NoError(t, err)
+	require.Nil(t, shoot.Spec.Hibernation)
+
+	requested := time.Date(2026, 3, 1, 18, 0, 0, 0, time.UTC)
+	cluster.Hibernated = true
+	cluster.HibernationRequested = &requested
+	cluster.HibernationSchedules = []HibernationSchedule{
+		{Start: "0 19 * * 1-5", End: "0 7 * * 1-5", Location: "Europe/Amsterdam"},
+		{Start: "0 0 * * 6", Location: "UTC"},
+	}
+	shoot, err = r.buildShootSpec(cluster)
+	require.NoError(t, err)
+	require.NotNil(t, shoot.Spec.Hibernation)
+	require.True(t, *shoot.Spec.Hibernation.Enabled)
+	require.Len(t, shoot.Spec.Hibernation.Schedules, 2)
+	require.Equal(t, "0 19 * * 1-5", *shoot.Spec.Hibernation.Schedules[0].Start)
+	require.Equal(t, "Europe/Amsterdam", *shoot.Spec.Hibernation.Schedules[0].Location)
+	require.Nil(t, shoot.Spec.Hibernation.Schedules[1].End)
+	require.Equal(t, "2026-03-01T18:00:00Z", shoot.Annotations[AnnotationHibernationRequested])
+}
+
+// A schedule-driven state change survives later syncs; only a new manual
+// request overrides it.
+func TestUpdateShootSpec_Hibernation(t *testing.T) {
+	r := testClient(NewProviderConfig())
+	cluster := testCluster()
+	cluster.HibernationSchedules = []HibernationSchedule{{Start: "0 19 * * *", End: "0 7 * * *", Location: "UTC"}}
+
+	shoot, err := r.buildShootSpec(cluster)
+	require.NoError(t, err)
+	require.False(t, *shoot.Spec.Hibernation.Enabled)
+
+	// Gardener's hibernation controller fires the start schedule.
+	shoot.Spec.Hibernation.Enabled = new(true)
+	require.NoError(t, r.updateShootSpec(shoot, cluster))
+	require.True(t, *shoot.Spec.Hibernation.Enabled)
+
+	requested := time.Date(2026, 3, 1, 20, 0, 0, 0, time.UTC)
+	cluster.HibernationRequested = &requested
+	require.NoError(t, r.updateShootSpec(shoot, cluster))
+	require.False(t, *shoot.Spec.Hibernation.Enabled, "wake request overrides the schedule")
+
+	cluster.HibernationSchedules = nil
+	require.NoError(t, r.updateShootSpec(shoot, cluster))
+	require.Empty(t, shoot.Spec.Hibernation.Schedules)
+}
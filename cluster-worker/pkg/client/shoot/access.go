@@ -2,6 +2,7 @@ package shoot
 
 import (
 	"context"
+	"strings"
 
 	"github.com/google/uuid"
 	rbacv1 "k8s.io/api/rbac/v1"
@@ -21,6 +22,15 @@ const (
 	// the merged organization/project per-container resource defaults inside a
 	// project namespace.
 	LimitRangeName = "fundament-defaults"
+
+	// ResourceQuotaName is the name of the managed ResourceQuota that
+	// materializes the merged project/namespace quota inside a project
+	// namespace.
+	ResourceQuotaName = "fundament-quota"
+
+	// LabelNamespaceRole marks the Roles and RoleBindings that materialize
+	// namespace role bindings; its value is the role (e.g. view_pods).
+	LabelNamespaceRole = "fundament.io/namespace-role"
 )
 
 // LimitDefaults are the effective per-container resource defaults applied as
@@ -34,6 +44,21 @@ type LimitDefaults struct {
 	MemoryLimitMi   *int32
 }
 
+// QuotaLimits is the effective ResourceQuota of a namespace. Nil fields are
+// omitted from the object. CPU values are millicores, memory values mebibytes
+// and storage gibibytes (matching the tenant.*_limits column units); the rest
+// are object counts.
+type QuotaLimits struct {
+	CPURequestMilli        *int32
+	CPULimitMilli          *int32
+	MemoryRequestMi        *int32
+	MemoryLimitMi          *int32
+	StorageGi              *int32
+	Pods                   *int32
+	PersistentVolumeClaims *int32
+	Services               *int32
+}
+
 // SAName returns the ServiceAccount name for a user.
 func SAName(userID uuid.UUID) string {
 	return "fundament-" + userID.String()
@@ -44,6 +69,17 @@ func CRBName(userID uuid.UUID) string {
 	return "fundament:admin:" + userID.String()
 }
 
+// NamespaceRoleName returns the name of the Role granting a namespace role.
+func NamespaceRoleName(role string) string {
+	return "fundament-" + strings.ReplaceAll(role, "_", "-")
+}
+
+// NamespaceRoleBindingName returns the name of the RoleBinding granting a
+// namespace role to a user.
+func NamespaceRoleBindingName(role string, userID uuid.UUID) string {
+	return NamespaceRoleName(role) + "-" + userID.String()
+}
+
 // ResourceInfo contains the metadata needed by reconciliation.
 type ResourceInfo struct {
 	Name        string
@@ -53,6 +89,14 @@ type ResourceInfo struct {
 	Subjects    []rbacv1.Subject
 }
 
+// PluginInstallationStatus is the observed status of a PluginInstallation
+// (plugins.fundament.io/v1) on a shoot.
+type PluginInstallationStatus struct {
+	Name    string
+	Phase   string
+	Message string
+}
+
 // ShootAccess provides operations on shoot clusters for user access management.
 type ShootAccess interface {
 	// EnsureNamespace creates the namespace if it doesn't exist.
@@ -82,6 +126,33 @@ type ShootAccess interface {
 	// a namespace (no-op if absent).
 	DeleteLimitRange(ctx context.Context, clusterID uuid.UUID, namespace string) error
 
+	// EnsureResourceQuota creates or updates the managed fundament-quota
+	// ResourceQuota in a namespace to match the given quota.
+	EnsureResourceQuota(ctx context.Context, clusterID uuid.UUID, namespace string, quota QuotaLimits, labels map[string]string) error
+
+	// DeleteResourceQuota deletes the managed fundament-quota ResourceQuota
+	// from a namespace (no-op if absent).
+	DeleteResourceQuota(ctx context.Context, clusterID uuid.UUID, namespace string) error
+
+	// EnsureRole creates or updates a Role in a namespace with the given rules.
+	EnsureRole(ctx context.Context, clusterID uuid.UUID, namespace, name string, rules []rbacv1.PolicyRule, labels map[string]string) error
+
+	// EnsureRoleBinding creates or updates a RoleBinding in a namespace that
+	// binds the Role roleName to a ServiceAccount.
+	EnsureRoleBinding(ctx context.Context, clusterID uuid.UUID, namespace, name, roleName, saNamespace, saName string, labels map[string]string) error
+
+	// DeleteRole deletes a Role (no-op if absent).
+	DeleteRole(ctx context.Context, clusterID uuid.UUID, namespace, name string) error
+
+	// DeleteRoleBinding deletes a RoleBinding (no-op if absent).
+	DeleteRoleBinding(ctx context.Context, clusterID uuid.UUID, namespace, name string) error
+
+	// ListRoles lists Roles in a namespace filtered by label key existence.
+	ListRoles(ctx context.Context, clusterID uuid.UUID, namespace, labelKey string) ([]ResourceInfo, error)
+
+	// ListRoleBindings lists RoleBindings in a namespace filtered by label key existence.
+	ListRoleBindings(ctx context.Context, clusterID uuid.UUID, namespace, labelKey string) ([]ResourceInfo, error)
+
 	// EnsureServiceAccount creates or updates a ServiceAccount.
 	EnsureServiceAccount(ctx context.Context, clusterID uuid.UUID, namespace, name string, labels, annotations map[string]string) error
 
@@ -99,4 +170,8 @@ type ShootAccess interface {
 
 	// ListClusterRoleBindings lists ClusterRoleBindings filtered by label key existence.
 	ListClusterRoleBindings(ctx context.Context, clusterID uuid.UUID, labelKey string) ([]ResourceInfo, error)
+
+	// ListPluginInstallations lists PluginInstallations with their status.
+	// Returns an empty list when the plugin CRD is not installed on the shoot.
+	ListPluginInstallations(ctx context.Context, clusterID uuid.UUID) ([]PluginInstallationStatus, error)
 }
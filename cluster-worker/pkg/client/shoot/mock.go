@@ -4,6 +4,7 @@ import (
 	"context"
 	"log/slog"
 	"maps"
+	"slices"
 	"sync"
 
 	"github.com/google/uuid"
@@ -25,6 +26,14 @@ type MockShootAccess struct {
 	Namespaces map[uuid.UUID]map[string]ResourceInfo
 	// LimitRanges: clusterID -> namespace name -> the managed fundament-defaults LimitRange
 	LimitRanges map[uuid.UUID]map[string]MockLimitRange
+	// ResourceQuotas: clusterID -> namespace name -> the managed fundament-quota ResourceQuota
+	ResourceQuotas map[uuid.UUID]map[string]MockResourceQuota
+	// Roles: clusterID -> namespace name -> Role name -> the managed Role
+	Roles map[uuid.UUID]map[string]map[string]MockRole
+	// RoleBindings: clusterID -> namespace name -> RoleBinding name -> resource metadata
+	RoleBindings map[uuid.UUID]map[string]map[string]ResourceInfo
+	// PluginInstallations: clusterID -> PluginInstallations and their status
+	PluginInstallations map[uuid.UUID][]PluginInstallationStatus
 
 	// Configurable errors for testing
 	EnsureNamespaceError          error
@@ -41,6 +50,15 @@ type MockShootAccess struct {
 	ListNamespacesError           error
 	EnsureLimitRangeError         error
 	DeleteLimitRangeError         error
+	EnsureResourceQuotaError      error
+	DeleteResourceQuotaError      error
+	EnsureRoleError               error
+	EnsureRoleBindingError        error
+	DeleteRoleError               error
+	DeleteRoleBindingError        error
+	ListRolesError                error
+	ListRoleBindingsError         error
+	ListPluginInstallationsError  error
 }
 
 // MockLimitRange is the in-memory representation of the managed LimitRange.
@@ -49,6 +67,18 @@ type MockLimitRange struct {
 	Labels   map[string]string
 }
 
+// MockResourceQuota is the in-memory representation of the managed ResourceQuota.
+type MockResourceQuota struct {
+	Quota  QuotaLimits
+	Labels map[string]string
+}
+
+// MockRole is the in-memory representation of a managed Role.
+type MockRole struct {
+	Rules  []rbacv1.PolicyRule
+	Labels map[string]string
+}
+
 func NewMockShootAccess(logger *slog.Logger) *MockShootAccess {
 	return &MockShootAccess{
 		logger:              logger.With("component", "mock-shoot-access"),
@@ -56,6 +86,10 @@ func NewMockShootAccess(logger *slog.Logger) *MockShootAccess {
 		ClusterRoleBindings: make(map[uuid.UUID]map[string]ResourceInfo),
 		Namespaces:          make(map[uuid.UUID]map[string]ResourceInfo),
 		LimitRanges:         make(map[uuid.UUID]map[string]MockLimitRange),
+		ResourceQuotas:      make(map[uuid.UUID]map[string]MockResourceQuota),
+		Roles:               make(map[uuid.UUID]map[string]map[string]MockRole),
+		RoleBindings:        make(map[uuid.UUID]map[string]map[string]ResourceInfo),
+		PluginInstallations: make(map[uuid.UUID][]PluginInstallationStatus),
 	}
 }
 
@@ -361,6 +395,196 @@ func (m *MockShootAccess) GetLimitRange(clusterID uuid.UUID, namespace string) *
 	return &clone
 }
 
+func (m *MockShootAccess) EnsureResourceQuota(_ context.Context, clusterID uuid.UUID, namespace string, quota QuotaLimits, labels map[string]string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.EnsureResourceQuotaError != nil {
+		return m.EnsureResourceQuotaError
+	}
+
+	if m.ResourceQuotas[clusterID] == nil {
+		m.ResourceQuotas[clusterID] = make(map[string]MockResourceQuota)
+	}
+	m.ResourceQuotas[clusterID][namespace] = MockResourceQuota{Quota: quota, Labels: maps.Clone(labels)}
+	m.logger.Debug("MOCK: ensured resource quota", "cluster_id", clusterID, "namespace", namespace)
+	return nil
+}
+
+func (m *MockShootAccess) DeleteResourceQuota(_ context.Context, clusterID uuid.UUID, namespace string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.DeleteResourceQuotaError != nil {
+		return m.DeleteResourceQuotaError
+	}
+
+	delete(m.ResourceQuotas[clusterID], namespace)
+	m.logger.Debug("MOCK: deleted resource quota", "cluster_id", clusterID, "namespace", namespace)
+	return nil
+}
+
+// GetResourceQuota returns the managed ResourceQuota for a namespace, or nil if absent.
+func (m *MockShootAccess) GetResourceQuota(clusterID uuid.UUID, namespace string) *MockResourceQuota {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	rq, ok := m.ResourceQuotas[clusterID][namespace]
+	if !ok {
+		return nil
+	}
+	clone := MockResourceQuota{Quota: rq.Quota, Labels: maps.Clone(rq.Labels)}
+	return &clone
+}
+
+func (m *MockShootAccess) EnsureRole(_ context.Context, clusterID uuid.UUID, namespace, name string, rules []rbacv1.PolicyRule, labels map[string]string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.EnsureRoleError != nil {
+		return m.EnsureRoleError
+	}
+
+	if m.Roles[clusterID] == nil {
+		m.Roles[clusterID] = make(map[string]map[string]MockRole)
+	}
+	if m.Roles[clusterID][namespace] == nil {
+		m.Roles[clusterID][namespace] = make(map[string]MockRole)
+	}
+	m.Roles[clusterID][namespace][name] = MockRole{Rules: slices.Clone(rules), Labels: maps.Clone(labels)}
+	m.logger.Debug("MOCK: ensured role", "cluster_id", clusterID, "namespace", namespace, "name", name)
+	return nil
+}
+
+func (m *MockShootAccess) EnsureRoleBinding(_ context.Context, clusterID uuid.UUID, namespace, name, roleName, saNamespace, saName string, labels map[string]string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.EnsureRoleBindingError != nil {
+		return m.EnsureRoleBindingError
+	}
+
+	if m.RoleBindings[clusterID] == nil {
+		m.RoleBindings[clusterID] = make(map[string]map[string]ResourceInfo)
+	}
+	if m.RoleBindings[clusterID][namespace] == nil {
+		m.RoleBindings[clusterID][namespace] = make(map[string]ResourceInfo)
+	}
+	m.RoleBindings[clusterID][namespace][name] = ResourceInfo{
+		Name:   name,
+		Labels: maps.Clone(labels),
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: "rbac.authorization.k8s.io",
+			Kind:     "Role",
+			Name:     roleName,
+		},
+		Subjects: []rbacv1.Subject{{Kind: "ServiceAccount", Name: saName, Namespace: saNamespace}},
+	}
+	m.logger.Debug("MOCK: ensured role binding", "cluster_id", clusterID, "namespace", namespace, "name", name)
+	return nil
+}
+
+func (m *MockShootAccess) DeleteRole(_ context.Context, clusterID uuid.UUID, namespace, name string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.DeleteRoleError != nil {
+		return m.DeleteRoleError
+	}
+
+	delete(m.Roles[clusterID][namespace], name)
+	m.logger.Debug("MOCK: deleted role", "cluster_id", clusterID, "namespace", namespace, "name", name)
+	return nil
+}
+
+func (m *MockShootAccess) DeleteRoleBinding(_ context.Context, clusterID uuid.UUID, namespace, name string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.DeleteRoleBindingError != nil {
+		return m.DeleteRoleBindingError
+	}
+
+	delete(m.RoleBindings[clusterID][namespace], name)
+	m.logger.Debug("MOCK: deleted role binding", "cluster_id", clusterID, "namespace", namespace, "name", name)
+	return nil
+}
+
+func (m *MockShootAccess) ListRoles(_ context.Context, clusterID uuid.UUID, namespace, labelKey string) ([]ResourceInfo, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if m.ListRolesError != nil {
+		return nil, m.ListRolesError
+	}
+
+	var result []ResourceInfo
+	for name, role := range m.Roles[clusterID][namespace] {
+		if labelKey != "" {
+			if _, ok := role.Labels[labelKey]; !ok {
+				continue
+			}
+		}
+		result = append(result, ResourceInfo{Name: name, Labels: maps.Clone(role.Labels)})
+	}
+	return result, nil
+}
+
+func (m *MockShootAccess) ListRoleBindings(_ context.Context, clusterID uuid.UUID, namespace, labelKey string) ([]ResourceInfo, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if m.ListRoleBindingsError != nil {
+		return nil, m.ListRoleBindingsError
+	}
+
+	var result []ResourceInfo
+	for _, resource := range m.RoleBindings[clusterID][namespace] {
+		if labelKey != "" {
+			if _, ok := resource.Labels[labelKey]; !ok {
+				continue
+			}
+		}
+		result = append(result, resource)
+	}
+	return result, nil
+}
+
+// GetRole returns a managed Role, or nil if absent.
+func (m *MockShootAccess) GetRole(clusterID uuid.UUID, namespace, name string) *MockRole {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	role, ok := m.Roles[clusterID][namespace][name]
+	if !ok {
+		return nil
+	}
+	clone := MockRole{Rules: slices.Clone(role.Rules), Labels: maps.Clone(role.Labels)}
+	return &clone
+}
+
+// GetRoleBinding returns a managed RoleBinding, or nil if absent.
+func (m *MockShootAccess) GetRoleBinding(clusterID uuid.UUID, namespace, name string) *ResourceInfo {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	rb, ok := m.RoleBindings[clusterID][namespace][name]
+	if !ok {
+		return nil
+	}
+	return &rb
+}
+
+func (m *MockShootAccess) ListPluginInstallations(_ context.Context, clusterID uuid.UUID) ([]PluginInstallationStatus, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if m.ListPluginInstallationsError != nil {
+		return nil, m.ListPluginInstallationsError
+	}
+	return slices.Clone(m.PluginInstallations[clusterID]), nil
+}
+
 // Reset clears all state.
 func (m *MockShootAccess) Reset() {
 	m.mu.Lock()
@@ -369,6 +593,9 @@ func (m *MockShootAccess) Reset() {
 	m.ClusterRoleBindings = make(map[uuid.UUID]map[string]ResourceInfo)
 	m.Namespaces = make(map[uuid.UUID]map[string]ResourceInfo)
 	m.LimitRanges = make(map[uuid.UUID]map[string]MockLimitRange)
+	m.Roles = make(map[uuid.UUID]map[string]map[string]MockRole)
+	m.RoleBindings = make(map[uuid.UUID]map[string]map[string]ResourceInfo)
+	m.PluginInstallations = make(map[uuid.UUID][]PluginInstallationStatus)
 }
 
 var _ ShootAccess = (*MockShootAccess)(nil)
@@ -392,6 +392,204 @@ func (r *RealShootAccess) DeleteLimitRange(ctx context.Context, clusterID uuid.U
 	return deleteResource(ctx, cs.CoreV1().LimitRanges(namespace), LimitRangeName, fmt.Sprintf("LimitRange %s/%s", namespace, LimitRangeName))
 }
 
+func (r *RealShootAccess) EnsureResourceQuota(ctx context.Context, clusterID uuid.UUID, namespace string, quota QuotaLimits, labels map[string]string) error {
+	cs, err := r.clientForCluster(ctx, clusterID)
+	if err != nil {
+		return err
+	}
+
+	rq := &corev1.ResourceQuota{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ResourceQuotaName,
+			Namespace: namespace,
+			Labels:    labels,
+		},
+		Spec: resourceQuotaSpec(quota),
+	}
+
+	return ensureResource(ctx, cs.CoreV1().ResourceQuotas(namespace), ResourceQuotaName, fmt.Sprintf("ResourceQuota %s/%s", namespace, ResourceQuotaName), rq,
+		func(existing *corev1.ResourceQuota) bool {
+			mergeMeta(&existing.ObjectMeta, labels, nil)
+			existing.Spec = rq.Spec
+			return false
+		})
+}
+
+func (r *RealShootAccess) DeleteResourceQuota(ctx context.Context, clusterID uuid.UUID, namespace string) error {
+	cs, err := r.clientForCluster(ctx, clusterID)
+	if err != nil {
+		return err
+	}
+
+	return deleteResource(ctx, cs.CoreV1().ResourceQuotas(namespace), ResourceQuotaName, fmt.Sprintf("ResourceQuota %s/%s", namespace, ResourceQuotaName))
+}
+
+func (r *RealShootAccess) EnsureRole(ctx context.Context, clusterID uuid.UUID, namespace, name string, rules []rbacv1.PolicyRule, labels map[string]string) error {
+	cs, err := r.clientForCluster(ctx, clusterID)
+	if err != nil {
+		return err
+	}
+
+	role := &rbacv1.Role{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels:    labels,
+		},
+		Rules: rules,
+	}
+
+	return ensureResource(ctx, cs.RbacV1().Roles(namespace), name, fmt.Sprintf("Role %s/%s", namespace, name), role,
+		func(existing *rbacv1.Role) bool {
+			mergeMeta(&existing.ObjectMeta, labels, nil)
+			existing.Rules = rules
+			return false
+		})
+}
+
+func (r *RealShootAccess) EnsureRoleBinding(ctx context.Context, clusterID uuid.UUID, namespace, name, roleName, saNamespace, saName string, labels map[string]string) error {
+	cs, err := r.clientForCluster(ctx, clusterID)
+	if err != nil {
+		return err
+	}
+
+	rb := &rbacv1.RoleBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels:    labels,
+		},
+		Subjects: []rbacv1.Subject{
+			{
+				Kind:      "ServiceAccount",
+				Name:      saName,
+				Namespace: saNamespace,
+			},
+		},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: "rbac.authorization.k8s.io",
+			Kind:     "Role",
+			Name:     roleName,
+		},
+	}
+
+	return ensureResource(ctx, cs.RbacV1().RoleBindings(namespace), name, fmt.Sprintf("RoleBinding %s/%s", namespace, name), rb,
+		func(existing *rbacv1.RoleBinding) bool {
+			if existing.RoleRef != rb.RoleRef {
+				return true
+			}
+			mergeMeta(&existing.ObjectMeta, labels, nil)
+			existing.Subjects = rb.Subjects
+			return false
+		})
+}
+
+func (r *RealShootAccess) DeleteRole(ctx context.Context, clusterID uuid.UUID, namespace, name string) error {
+	cs, err := r.clientForCluster(ctx, clusterID)
+	if err != nil {
+		return err
+	}
+
+	return deleteResource(ctx, cs.RbacV1().Roles(namespace), name, fmt.Sprintf("Role %s/%s", namespace, name))
+}
+
+func (r *RealShootAccess) DeleteRoleBinding(ctx context.Context, clusterID uuid.UUID, namespace, name string) error {
+	cs, err := r.clientForCluster(ctx, clusterID)
+	if err != nil {
+		return err
+	}
+
+	return deleteResource(ctx, cs.RbacV1().RoleBindings(namespace), name, fmt.Sprintf("RoleBinding %s/%s", namespace, name))
+}
+
+func (r *RealShootAccess) ListRoles(ctx context.Context, clusterID uuid.UUID, namespace, labelKey string) ([]ResourceInfo, error) {
+	cs, err := r.clientForCluster(ctx, clusterID)
+	if err != nil {
+		return nil, err
+	}
+
+	list, err := cs.RbacV1().Roles(namespace).List(ctx, metav1.ListOptions{LabelSelector: labelKey})
+	if err != nil {
+		return nil, fmt.Errorf("list Roles in %s: %w", namespace, err)
+	}
+
+	result := make([]ResourceInfo, len(list.Items))
+	for i := range list.Items {
+		result[i] = ResourceInfo{
+			Name:        list.Items[i].Name,
+			Labels:      maps.Clone(list.Items[i].Labels),
+			Annotations: maps.Clone(list.Items[i].Annotations),
+		}
+	}
+	return result, nil
+}
+
+func (r *RealShootAccess) ListRoleBindings(ctx context.Context, clusterID uuid.UUID, namespace, labelKey string) ([]ResourceInfo, error) {
+	cs, err := r.clientForCluster(ctx, clusterID)
+	if err != nil {
+		return nil, err
+	}
+
+	list, err := cs.RbacV1().RoleBindings(namespace).List(ctx, metav1.ListOptions{LabelSelector: labelKey})
+	if err != nil {
+		return nil, fmt.Errorf("list RoleBindings in %s: %w", namespace, err)
+	}
+
+	result := make([]ResourceInfo, len(list.Items))
+	for i := range list.Items {
+		result[i] = ResourceInfo{
+			Name:        list.Items[i].Name,
+			Labels:      maps.Clone(list.Items[i].Labels),
+			Annotations: maps.Clone(list.Items[i].Annotations),
+			RoleRef:     list.Items[i].RoleRef,
+			Subjects:    append([]rbacv1.Subject(nil), list.Items[i].Subjects...),
+		}
+	}
+	return result, nil
+}
+
+// pluginInstallationsPath lists the cluster-scoped PluginInstallation CRs. They
+// are read as raw JSON so the worker does not depend on the plugin-controller types.
+const pluginInstallationsPath = "/apis/plugins.fundament.io/v1/plugininstallations"
+
+func (r *RealShootAccess) ListPluginInstallations(ctx context.Context, clusterID uuid.UUID) ([]PluginInstallationStatus, error) {
+	cs, err := r.clientForCluster(ctx, clusterID)
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := cs.Discovery().RESTClient().Get().AbsPath(pluginInstallationsPath).DoRaw(ctx)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("list plugin installations: %w", err)
+	}
+
+	var list struct {
+		Items []struct {
+			Metadata metav1.ObjectMeta `json:"metadata"`
+			Status   struct {
+				Phase   string `json:"phase"`
+				Message string `json:"message"`
+			} `json:"status"`
+		} `json:"items"`
+	}
+	if err := json.Unmarshal(raw, &list); err != nil {
+		return nil, fmt.Errorf("decode plugin installations: %w", err)
+	}
+
+	result := make([]PluginInstallationStatus, len(list.Items))
+	for i := range list.Items {
+		result[i] = PluginInstallationStatus{
+			Name:    list.Items[i].Metadata.Name,
+			Phase:   list.Items[i].Status.Phase,
+			Message: list.Items[i].Status.Message,
+		}
+	}
+	return result, nil
+}
+
 // limitRangeSpec converts the defaults to a single-Container LimitRangeSpec.
 // Only set fields are populated: `default` (the limit ceiling) from the limit
 // values, `defaultRequest` from the request values; CPU as millicores (500m),
@@ -422,6 +620,38 @@ func limitRangeSpec(defaults LimitDefaults) corev1.LimitRangeSpec {
 	return corev1.LimitRangeSpec{Limits: []corev1.LimitRangeItem{item}}
 }
 
+// resourceQuotaSpec converts the quota to a ResourceQuotaSpec with one hard
+// entry per set field: CPU as millicores (500m), memory as mebibytes (512Mi),
+// storage as gibibytes (10Gi) and object counts as plain integers.
+func resourceQuotaSpec(quota QuotaLimits) corev1.ResourceQuotaSpec {
+	hard := corev1.ResourceList{}
+	if quota.CPURequestMilli != nil {
+		hard[corev1.ResourceRequestsCPU] = *resource.NewMilliQuantity(int64(*quota.CPURequestMilli), resource.DecimalSI)
+	}
+	if quota.CPULimitMilli != nil {
+		hard[corev1.ResourceLimitsCPU] = *resource.NewMilliQuantity(int64(*quota.CPULimitMilli), resource.DecimalSI)
+	}
+	if quota.MemoryRequestMi != nil {
+		hard[corev1.ResourceRequestsMemory] = *resource.NewQuantity(int64(*quota.MemoryRequestMi)<<20, resource.BinarySI)
+	}
+	if quota.MemoryLimitMi != nil {
+		hard[corev1.ResourceLimitsMemory] = *resource.NewQuantity(int64(*quota.MemoryLimitMi)<<20, resource.BinarySI)
+	}
+	if quota.StorageGi != nil {
+		hard[corev1.ResourceRequestsStorage] = *resource.NewQuantity(int64(*quota.StorageGi)<<30, resource.BinarySI)
+	}
+	if quota.Pods != nil {
+		hard[corev1.ResourcePods] = *resource.NewQuantity(int64(*quota.Pods), resource.DecimalSI)
+	}
+	if quota.PersistentVolumeClaims != nil {
+		hard[corev1.ResourcePersistentVolumeClaims] = *resource.NewQuantity(int64(*quota.PersistentVolumeClaims), resource.DecimalSI)
+	}
+	if quota.Services != nil {
+		hard[corev1.ResourceServices] = *resource.NewQuantity(int64(*quota.Services), resource.DecimalSI)
+	}
+	return corev1.ResourceQuotaSpec{Hard: hard}
+}
+
 // ClusterRoleBindingNeedsRecreate returns true if the RoleRef has changed (immutable field).
 func ClusterRoleBindingNeedsRecreate(existing, desired *rbacv1.ClusterRoleBinding) bool {
 	return existing.RoleRef != desired.RoleRef
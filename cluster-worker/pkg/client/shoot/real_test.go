@@ -81,3 +81,29 @@ func TestEnsureClusterRoleBinding_MergesOntoNilMaps(t *testing.T) {
 	require.Equal(t, "u1", got.Labels["fundament.io/user-id"])
 	require.Equal(t, "managed", got.Annotations["note"])
 }
+
+// A RoleBinding's RoleRef is immutable, so an existing binding pointing at
+// another Role must be deleted and recreated rather than updated in place.
+func TestEnsureRoleBinding_RecreatesOnRoleRefChange(t *testing.T) {
+	t.Parallel()
+	existing := &rbacv1.RoleBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: "rb", Namespace: "ns"},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: "rbac.authorization.k8s.io",
+			Kind:     "Role",
+			Name:     "fundament-view-pods",
+		},
+	}
+	cs := fake.NewClientset(existing)
+	r := realAccessWith(t, cs)
+
+	err := r.EnsureRoleBinding(context.Background(), uuid.New(), "ns", "rb", "fundament-deploy",
+		FundamentNamespace, "fundament-u1", map[string]string{LabelNamespaceRole: "deploy"})
+	require.NoError(t, err)
+
+	got, err := cs.RbacV1().RoleBindings("ns").Get(context.Background(), "rb", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Equal(t, "fundament-deploy", got.RoleRef.Name)
+	require.Equal(t, "deploy", got.Labels[LabelNamespaceRole])
+	require.Equal(t, []rbacv1.Subject{{Kind: "ServiceAccount", Name: "fundament-u1", Namespace: FundamentNamespace}}, got.Subjects)
+}
@@ -0,0 +1,74 @@
+package shoot
+
+import (
+	"log/slog"
+	"os"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+)
+
+func TestResourceQuotaSpec_AllFieldsFormatted(t *testing.T) {
+	spec := resourceQuotaSpec(QuotaLimits{
+		CPURequestMilli:        ptr.To[int32](2000),
+		CPULimitMilli:          ptr.To[int32](4500),
+		MemoryRequestMi:        ptr.To[int32](4096),
+		MemoryLimitMi:          ptr.To[int32](8192),
+		StorageGi:              ptr.To[int32](50),
+		Pods:                   ptr.To[int32](30),
+		PersistentVolumeClaims: ptr.To[int32](5),
+		Services:               ptr.To[int32](10),
+	})
+
+	want := map[corev1.ResourceName]string{
+		corev1.ResourceRequestsCPU:            "2",
+		corev1.ResourceLimitsCPU:              "4500m",
+		corev1.ResourceRequestsMemory:         "4Gi",
+		corev1.ResourceLimitsMemory:           "8Gi",
+		corev1.ResourceRequestsStorage:        "50Gi",
+		corev1.ResourcePods:                   "30",
+		corev1.ResourcePersistentVolumeClaims: "5",
+		corev1.ResourceServices:               "10",
+	}
+	require.Len(t, spec.Hard, len(want))
+	for name, value := range want {
+		quantity := spec.Hard[name]
+		require.Equal(t, value, quantity.String(), name)
+	}
+}
+
+func TestResourceQuotaSpec_PartialFieldsOmitted(t *testing.T) {
+	spec := resourceQuotaSpec(QuotaLimits{Pods: ptr.To[int32](10)})
+
+	require.Len(t, spec.Hard, 1)
+	pods := spec.Hard[corev1.ResourcePods]
+	require.Equal(t, "10", pods.String())
+	require.NotContains(t, spec.Hard, corev1.ResourceRequestsCPU)
+}
+
+func TestMockResourceQuotaRoundTrip(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+	m := NewMockShootAccess(logger)
+	clusterID := uuid.New()
+	labels := map[string]string{"fundament.io/managed-by": "cluster-worker"}
+
+	first := QuotaLimits{Pods: ptr.To[int32](10)}
+	require.NoError(t, m.EnsureResourceQuota(t.Context(), clusterID, "team-a", first, labels))
+	rq := m.GetResourceQuota(clusterID, "team-a")
+	require.NotNil(t, rq)
+	require.Equal(t, first, rq.Quota)
+	require.Equal(t, labels, rq.Labels)
+
+	second := QuotaLimits{Pods: ptr.To[int32](5), StorageGi: ptr.To[int32](20)}
+	require.NoError(t, m.EnsureResourceQuota(t.Context(), clusterID, "team-a", second, labels))
+	rq = m.GetResourceQuota(clusterID, "team-a")
+	require.NotNil(t, rq)
+	require.Equal(t, second, rq.Quota)
+
+	require.NoError(t, m.DeleteResourceQuota(t.Context(), clusterID, "team-a"))
+	require.Nil(t, m.GetResourceQuota(clusterID, "team-a"))
+	require.NoError(t, m.DeleteResourceQuota(t.Context(), clusterID, "team-a"))
+}
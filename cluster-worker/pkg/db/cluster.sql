@@ -65,6 +65,11 @@ SELECT
     COALESCE(catalog.machine_types.name, tenant.node_pools.machine_type) AS machine_type,
     tenant.node_pools.autoscale_min,
     tenant.node_pools.autoscale_max,
+    tenant.node_pools.labels,
+    tenant.node_pools.taints,
+    tenant.node_pools.zones,
+    tenant.node_pools.max_surge,
+    tenant.node_pools.max_unavailable,
     tenant.node_pools.created
 FROM
     tenant.node_pools
@@ -98,10 +103,27 @@ SELECT EXISTS (
       AND tenant.cluster_outbox.status = 'completed'
 )::boolean AS has_been_synced;
 
+-- name: ClusterHibernationScheduleListByClusterID :many
+-- Fetch a cluster's hibernation schedules, mapped onto the Shoot's
+-- spec.hibernation.schedules by the cluster handler.
+SELECT
+    tenant.cluster_hibernation_schedules.start,
+    tenant.cluster_hibernation_schedules."end",
+    tenant.cluster_hibernation_schedules.location
+FROM
+    tenant.cluster_hibernation_schedules
+WHERE
+    tenant.cluster_hibernation_schedules.cluster_id = @cluster_id
+ORDER BY
+    tenant.cluster_hibernation_schedules.created,
+    tenant.cluster_hibernation_schedules.id;
+
 -- name: ClusterListNeedingStatusCheck :many
 -- Get clusters where we need to check Gardener status (active clusters).
 -- Polls clusters in non-terminal states: NULL (never checked), pending,
--- progressing, error.
+-- progressing, error, and ready clusters with an upgrade in progress or a
+-- pending hibernate/wake request. Hibernated clusters and clusters with
+-- hibernation schedules are re-checked every five minutes.
 SELECT
     tenant.clusters.id,
     tenant.clusters.name,
@@ -128,8 +150,27 @@ WHERE
         tenant.clusters.shoot_status IS NULL -- Never checked
         OR tenant.clusters.shoot_status = 'pending' -- Shoot not yet visible in Gardener
         OR tenant.clusters.shoot_status = 'progressing' -- Gardener creating/updating
-        OR tenant.clusters.shoot_status = 'error'
-    ) -- Failed, might recover
+        OR tenant.clusters.shoot_status = 'error' -- Failed, might recover
+        OR EXISTS (
+            SELECT 1
+            FROM tenant.cluster_upgrades
+            WHERE tenant.cluster_upgrades.cluster_id = tenant.clusters.id
+              AND tenant.cluster_upgrades.status = 'in_progress'
+        ) -- Upgrade in progress: follow the shoot back to ready
+        OR tenant.clusters.hibernation_requested > tenant.clusters.shoot_status_updated -- Hibernate/wake requested since the last check
+        OR ( -- Hibernation schedules change the state without a sync; check occasionally
+            (
+                tenant.clusters.shoot_status = 'hibernated'
+                OR tenant.clusters.hibernated
+                OR EXISTS (
+                    SELECT 1
+                    FROM tenant.cluster_hibernation_schedules
+                    WHERE tenant.cluster_hibernation_schedules.cluster_id = tenant.clusters.id
+                )
+            )
+            AND tenant.clusters.shoot_status_updated < now() - INTERVAL '5 minutes'
+        )
+    )
     AND (
         tenant.clusters.shoot_status_updated IS NULL -- Never checked
         OR tenant.clusters.shoot_status_updated < now() - INTERVAL '30 seconds'
@@ -12,7 +12,13 @@ SELECT
     tenant.clusters.organization_id,
     tenant.organizations.name AS organization_name,
     catalog.regions.cloud_profile,
-    catalog.regions.cloud_profile_region
+    catalog.regions.cloud_profile_region,
+    tenant.clusters.maintenance_window_start,
+    tenant.clusters.maintenance_window_end,
+    tenant.clusters.auto_update_kubernetes_version,
+    tenant.clusters.auto_update_machine_image_version,
+    tenant.clusters.hibernated,
+    tenant.clusters.hibernation_requested
 FROM
     tenant.clusters
     JOIN tenant.organizations ON tenant.organizations.id = tenant.clusters.organization_id
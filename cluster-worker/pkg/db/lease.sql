@@ -0,0 +1,31 @@
+-- name: LeaseAcquire :one
+-- Takes a lease, or extends it when this holder already has it. A lease held
+-- by another replica is only taken over once it has expired; no row comes
+-- back while someone else holds it.
+INSERT INTO tenant.cluster_worker_leases (name, holder, expires)
+VALUES (@name, @holder, now() + @duration::interval)
+ON CONFLICT (name) DO UPDATE
+SET holder = EXCLUDED.holder,
+    acquired = CASE
+        WHEN tenant.cluster_worker_leases.holder = EXCLUDED.holder THEN tenant.cluster_worker_leases.acquired
+        ELSE now()
+    END,
+    expires = EXCLUDED.expires
+WHERE tenant.cluster_worker_leases.holder = EXCLUDED.holder
+   OR tenant.cluster_worker_leases.expires <= now()
+RETURNING acquired;
+
+-- name: LeaseRelease :exec
+-- Gives up leases so another replica can take them over right away instead
+-- of waiting for them to expire. Leases held by others are left alone.
+DELETE FROM tenant.cluster_worker_leases
+WHERE name = ANY(@names::text[])
+  AND holder = @holder;
+
+-- name: LeaseCountLive :one
+-- Counts the unexpired leases whose name starts with @prefix, e.g. the
+-- replica heartbeats.
+SELECT count(*)::integer
+FROM tenant.cluster_worker_leases
+WHERE name LIKE @prefix::text || '%'
+  AND expires > now();
@@ -13,6 +13,9 @@
 -- so a project default can only tighten the organization default) when
 -- building the namespace's LimitRange. The merge lives in Go rather than a SQL
 -- LEAST() because sqlc cannot infer a nullable type for computed columns.
+-- The project_quota_*/namespace_quota_* columns are the project quota and the
+-- namespace's own override of it, merged the same way into the namespace's
+-- ResourceQuota.
 SELECT
     tenant.namespaces.id,
     tenant.namespaces.project_id,
@@ -29,7 +32,23 @@ SELECT
     tenant.project_limits.default_cpu_request_m AS project_default_cpu_request_m,
     tenant.project_limits.default_cpu_limit_m AS project_default_cpu_limit_m,
     tenant.project_limits.default_memory_request_mi AS project_default_memory_request_mi,
-    tenant.project_limits.default_memory_limit_mi AS project_default_memory_limit_mi
+    tenant.project_limits.default_memory_limit_mi AS project_default_memory_limit_mi,
+    tenant.project_limits.quota_cpu_request_m AS project_quota_cpu_request_m,
+    tenant.project_limits.quota_cpu_limit_m AS project_quota_cpu_limit_m,
+    tenant.project_limits.quota_memory_request_mi AS project_quota_memory_request_mi,
+    tenant.project_limits.quota_memory_limit_mi AS project_quota_memory_limit_mi,
+    tenant.project_limits.quota_storage_gi AS project_quota_storage_gi,
+    tenant.project_limits.quota_pods AS project_quota_pods,
+    tenant.project_limits.quota_persistent_volume_claims AS project_quota_persistent_volume_claims,
+    tenant.project_limits.quota_services AS project_quota_services,
+    tenant.namespace_limits.quota_cpu_request_m AS namespace_quota_cpu_request_m,
+    tenant.namespace_limits.quota_cpu_limit_m AS namespace_quota_cpu_limit_m,
+    tenant.namespace_limits.quota_memory_request_mi AS namespace_quota_memory_request_mi,
+    tenant.namespace_limits.quota_memory_limit_mi AS namespace_quota_memory_limit_mi,
+    tenant.namespace_limits.quota_storage_gi AS namespace_quota_storage_gi,
+    tenant.namespace_limits.quota_pods AS namespace_quota_pods,
+    tenant.namespace_limits.quota_persistent_volume_claims AS namespace_quota_persistent_volume_claims,
+    tenant.namespace_limits.quota_services AS namespace_quota_services
 FROM tenant.namespaces
 JOIN tenant.projects ON tenant.projects.id = tenant.namespaces.project_id
 JOIN tenant.clusters ON tenant.clusters.id = tenant.projects.cluster_id
@@ -37,6 +56,8 @@ LEFT JOIN tenant.project_limits ON tenant.project_limits.project_id = tenant.nam
     AND tenant.project_limits.deleted IS NULL
 LEFT JOIN tenant.organization_limits ON tenant.organization_limits.organization_id = tenant.clusters.organization_id
     AND tenant.organization_limits.deleted IS NULL
+LEFT JOIN tenant.namespace_limits ON tenant.namespace_limits.namespace_id = tenant.namespaces.id
+    AND tenant.namespace_limits.deleted IS NULL
 WHERE tenant.namespaces.id = @id;
 
 -- name: NamespaceListActiveForCluster :many
@@ -49,3 +70,22 @@ FROM tenant.namespaces
 JOIN tenant.projects ON tenant.projects.id = tenant.namespaces.project_id
 WHERE tenant.projects.cluster_id = @cluster_id
   AND tenant.namespaces.deleted IS NULL;
+
+-- name: NamespaceRoleGrantsForSync :many
+-- The user/role pairs the namespace role bindings of active project members
+-- grant in a namespace: every active binding whose namespace_pattern matches
+-- the namespace name. The handler materializes each role as a Role and each
+-- pair as a RoleBinding to the user's ServiceAccount.
+SELECT DISTINCT
+    tenant.project_members.user_id,
+    tenant.namespace_role_bindings.role
+FROM tenant.namespaces
+JOIN tenant.namespace_role_bindings
+    ON tenant.namespace_role_bindings.project_id = tenant.namespaces.project_id
+    AND tenant.namespace_role_bindings.deleted IS NULL
+JOIN tenant.project_members
+    ON tenant.project_members.id = tenant.namespace_role_bindings.project_member_id
+    AND tenant.project_members.deleted IS NULL
+WHERE tenant.namespaces.id = @namespace_id
+  AND tenant.namespace_matches_pattern(tenant.namespaces.name, tenant.namespace_role_bindings.namespace_pattern)
+ORDER BY tenant.namespace_role_bindings.role, tenant.project_members.user_id;
@@ -1,40 +1,109 @@
 -- name: OutboxGetAndLock :one
--- Claims the next pending/retryable cluster outbox row.
+-- Claims the next pending/retrying cluster outbox row of one shard.
 -- Picks up all entity types: cluster, organization_user, project_member,
--- node_pool, and namespace.
+-- node_pool, namespace, and access_elevation.
+-- A row belongs to shard tenant.cluster_outbox_shard(partition_key), so every
+-- row of a cluster lands in the same shard; rows from before partitioning fall
+-- back to their own id. The claim only succeeds while @holder still holds the
+-- shard's lease, which keeps a replica that lost its lease from picking up
+-- more work.
+-- Organization-wide rows (partitioned by the organization rather than a
+-- cluster) touch every cluster of the organization, whose rows sit in other
+-- shards. They are serialized against those rows: a row waits for the older
+-- ready rows of the other kind in its organization, and the claim takes a
+-- transaction-level advisory lock on the organization, exclusive for an
+-- organization-wide row and shared for a cluster row. Rows whose lock is taken
+-- are skipped. The lock is tried in the outer query, over the ordered and
+-- locked candidates, so it is only taken for the row that is returned.
+-- Organizations take turns: rows of the organizations after
+-- @after_organization_id (the one served last) come first, wrapping around,
+-- and within an organization the oldest row goes first.
 -- Uses FOR NO KEY UPDATE SKIP LOCKED for concurrent worker safety.
-SELECT id,
-       cluster_id,
-       organization_user_id,
-       project_member_id,
-       node_pool_id,
-       namespace_id,
-       event,
-       source,
-       status,
-       retries,
-       deferrals,
-       status_info
-FROM tenant.cluster_outbox
-WHERE status IN ('pending', 'retrying')
-  AND (retry_after IS NULL OR retry_after <= now())
-ORDER BY id ASC
-LIMIT 1
-FOR NO KEY UPDATE SKIP LOCKED;
+SELECT next.id,
+       next.cluster_id,
+       next.organization_user_id,
+       next.project_member_id,
+       next.node_pool_id,
+       next.namespace_id,
+       next.access_elevation_id,
+       next.organization_id,
+       next.event,
+       next.source,
+       next.status,
+       next.retries,
+       next.deferrals,
+       next.status_info
+FROM (
+    SELECT tenant.cluster_outbox.id,
+           tenant.cluster_outbox.cluster_id,
+           tenant.cluster_outbox.organization_user_id,
+           tenant.cluster_outbox.project_member_id,
+           tenant.cluster_outbox.node_pool_id,
+           tenant.cluster_outbox.namespace_id,
+           tenant.cluster_outbox.access_elevation_id,
+           tenant.cluster_outbox.organization_id,
+           tenant.cluster_outbox.partition_key,
+           tenant.cluster_outbox.event,
+           tenant.cluster_outbox.source,
+           tenant.cluster_outbox.status,
+           tenant.cluster_outbox.retries,
+           tenant.cluster_outbox.deferrals,
+           tenant.cluster_outbox.status_info
+    FROM tenant.cluster_outbox
+    WHERE tenant.cluster_outbox.status IN ('pending', 'retrying')
+      AND (tenant.cluster_outbox.retry_after IS NULL OR tenant.cluster_outbox.retry_after <= now())
+      AND tenant.cluster_outbox_shard(COALESCE(tenant.cluster_outbox.partition_key, tenant.cluster_outbox.id), @shard_count::integer) = @shard::integer
+      AND EXISTS (
+          SELECT 1 FROM tenant.cluster_worker_leases
+          WHERE tenant.cluster_worker_leases.name = @lease_name
+            AND tenant.cluster_worker_leases.holder = @holder
+            AND tenant.cluster_worker_leases.expires > now()
+      )
+      AND NOT EXISTS (
+          SELECT 1 FROM tenant.cluster_outbox AS earlier
+          WHERE earlier.organization_id = tenant.cluster_outbox.organization_id
+            AND earlier.id < tenant.cluster_outbox.id
+            AND earlier.status IN ('pending', 'retrying')
+            AND (earlier.retry_after IS NULL OR earlier.retry_after <= now())
+            AND (earlier.partition_key = earlier.organization_id) <> (tenant.cluster_outbox.partition_key = tenant.cluster_outbox.organization_id)
+      )
+    ORDER BY COALESCE(tenant.cluster_outbox.organization_id, '00000000-0000-0000-0000-000000000000'::uuid) <= @after_organization_id::uuid,
+             tenant.cluster_outbox.organization_id,
+             tenant.cluster_outbox.id
+    FOR NO KEY UPDATE SKIP LOCKED
+) AS next
+WHERE CASE
+    WHEN next.organization_id IS NULL THEN true
+    WHEN next.partition_key = next.organization_id
+        THEN pg_try_advisory_xact_lock(hashtextextended('cluster_outbox/' || next.organization_id::text, 0))
+    ELSE pg_try_advisory_xact_lock_shared(hashtextextended('cluster_outbox/' || next.organization_id::text, 0))
+END
+LIMIT 1;
 
--- name: OutboxMarkProcessed :exec
+-- name: OutboxMarkProcessed :execrows
+-- Only while @holder still holds the shard's lease. The lease row is share
+-- locked until the transaction ends, so a replica taking the shard over waits
+-- for this result before it can claim the shard's rows.
 UPDATE tenant.cluster_outbox
 SET status = 'completed',
     processed = now(),
     status_info = NULL,
     retry_after = NULL
-WHERE id = @id;
+WHERE id = @id
+  AND EXISTS (
+      SELECT 1 FROM tenant.cluster_worker_leases
+      WHERE tenant.cluster_worker_leases.name = @lease_name
+        AND tenant.cluster_worker_leases.holder = @holder
+        AND tenant.cluster_worker_leases.expires > now()
+      FOR SHARE
+  );
 
 -- name: OutboxMarkRetry :one
 -- Marks a row for retry with exponential backoff.
 -- The backoff is calculated as: base_interval * 2^(retries+1), capped at max_backoff.
 -- retries+1 is used because PostgreSQL evaluates expressions using the old row value,
 -- but we want the delay to reflect the new retry count (incremented in the same UPDATE).
+-- Only while @holder still holds the shard's lease, as OutboxMarkProcessed.
 UPDATE tenant.cluster_outbox
 SET retries = retries + 1,
     retry_after = now() + LEAST(
@@ -44,24 +113,47 @@ SET retries = retries + 1,
     status = 'retrying',
     status_info = @status_info
 WHERE id = @id
+  AND EXISTS (
+      SELECT 1 FROM tenant.cluster_worker_leases
+      WHERE tenant.cluster_worker_leases.name = @lease_name
+        AND tenant.cluster_worker_leases.holder = @holder
+        AND tenant.cluster_worker_leases.expires > now()
+      FOR SHARE
+  )
 RETURNING retries;
 
--- name: OutboxMarkFailed :exec
+-- name: OutboxMarkFailed :execrows
 -- Marks a row as permanently failed after exceeding max retries.
+-- Only while @holder still holds the shard's lease, as OutboxMarkProcessed.
 UPDATE tenant.cluster_outbox
 SET status = 'failed', failed = now(), status_info = @status_info
-WHERE id = @id;
+WHERE id = @id
+  AND EXISTS (
+      SELECT 1 FROM tenant.cluster_worker_leases
+      WHERE tenant.cluster_worker_leases.name = @lease_name
+        AND tenant.cluster_worker_leases.holder = @holder
+        AND tenant.cluster_worker_leases.expires > now()
+      FOR SHARE
+  );
 
 -- name: OutboxDeferWithoutRetry :one
 -- Defers a row for later processing without incrementing the retry counter.
 -- Increments deferrals to track how many times a precondition was not met.
 -- Used when a handler returns PreconditionError — the row is not yet processable
 -- but this is not a failure. The precondition reason is written to status_info.
+-- Only while @holder still holds the shard's lease, as OutboxMarkProcessed.
 UPDATE tenant.cluster_outbox
 SET retry_after = now() + @delay::interval,
     deferrals = deferrals + 1,
     status_info = @status_info
 WHERE id = @id
+  AND EXISTS (
+      SELECT 1 FROM tenant.cluster_worker_leases
+      WHERE tenant.cluster_worker_leases.name = @lease_name
+        AND tenant.cluster_worker_leases.holder = @holder
+        AND tenant.cluster_worker_leases.expires > now()
+      FOR SHARE
+  )
 RETURNING deferrals;
 
 -- name: OutboxInsertReady :exec
@@ -0,0 +1,17 @@
+-- name: ClusterPluginStatusUpsert :exec
+-- Records the observed phase of a PluginInstallation. Rows are only touched
+-- when something changed, so the webhook trigger fires on real transitions.
+INSERT INTO tenant.cluster_plugin_status (cluster_id, name, phase, message)
+VALUES (@cluster_id, @name, @phase, @message)
+ON CONFLICT (cluster_id, name) DO UPDATE
+SET phase = EXCLUDED.phase,
+    message = EXCLUDED.message,
+    updated = now()
+WHERE tenant.cluster_plugin_status.phase IS DISTINCT FROM EXCLUDED.phase
+   OR tenant.cluster_plugin_status.message IS DISTINCT FROM EXCLUDED.message;
+
+-- name: ClusterPluginStatusDeleteMissing :exec
+-- Forgets PluginInstallations that no longer exist on the cluster.
+DELETE FROM tenant.cluster_plugin_status
+WHERE tenant.cluster_plugin_status.cluster_id = @cluster_id
+  AND NOT (tenant.cluster_plugin_status.name = ANY (@names::text[]));
@@ -0,0 +1,96 @@
+-- name: ClusterUpgradeListDue :many
+-- Scheduled upgrades whose not_before has passed, with the cluster's
+-- maintenance window. The caller checks the window before starting one, and
+-- pages past the upgrades it skipped with the (not_before, id) of the last
+-- row; a NULL after_not_before starts at the first.
+SELECT
+    tenant.cluster_upgrades.id,
+    tenant.cluster_upgrades.cluster_id,
+    tenant.cluster_upgrades.from_kubernetes_version,
+    tenant.cluster_upgrades.to_kubernetes_version,
+    tenant.cluster_upgrades.to_kubernetes_version_id,
+    tenant.cluster_upgrades.skip_maintenance_window,
+    tenant.cluster_upgrades.not_before,
+    tenant.clusters.deleted,
+    tenant.clusters.maintenance_window_start,
+    tenant.clusters.maintenance_window_end
+FROM
+    tenant.cluster_upgrades
+    JOIN tenant.clusters ON tenant.clusters.id = tenant.cluster_upgrades.cluster_id
+WHERE
+    tenant.cluster_upgrades.status = 'scheduled'
+    AND tenant.cluster_upgrades.not_before <= now()
+    AND (
+        sqlc.narg('after_not_before')::timestamptz IS NULL
+        OR (tenant.cluster_upgrades.not_before, tenant.cluster_upgrades.id)
+            > (sqlc.narg('after_not_before')::timestamptz, sqlc.narg('after_id')::uuid)
+    )
+ORDER BY
+    tenant.cluster_upgrades.not_before,
+    tenant.cluster_upgrades.id
+LIMIT
+    @limit_count;
+
+-- name: ClusterUpgradeStart :execrows
+-- Moves a scheduled upgrade to in_progress; 0 rows when it was cancelled or
+-- started concurrently.
+UPDATE tenant.cluster_upgrades
+SET
+    status = 'in_progress',
+    started = now()
+WHERE
+    id = @upgrade_id
+    AND status = 'scheduled';
+
+-- name: ClusterSetKubernetesVersion :execrows
+-- Applies an upgrade's target version. The cluster outbox trigger picks up
+-- the change and syncs it to Gardener.
+UPDATE tenant.clusters
+SET
+    kubernetes_version = @kubernetes_version,
+    kubernetes_version_id = COALESCE(sqlc.narg('kubernetes_version_id'), kubernetes_version_id)
+WHERE
+    id = @cluster_id
+    AND deleted IS NULL;
+
+-- name: ClusterUpgradeListInProgress :many
+-- Upgrades that have been started, with the cluster's sync and shoot state.
+-- last_synced is the latest sync_succeeded event, so the caller can tell a
+-- shoot status observed after the upgrade was applied from an earlier one.
+SELECT
+    tenant.cluster_upgrades.id,
+    tenant.cluster_upgrades.cluster_id,
+    tenant.cluster_upgrades.from_kubernetes_version,
+    tenant.cluster_upgrades.to_kubernetes_version,
+    tenant.cluster_upgrades.started,
+    tenant.clusters.deleted,
+    tenant.clusters.outbox_status,
+    tenant.clusters.outbox_error,
+    tenant.clusters.shoot_status,
+    tenant.clusters.shoot_status_message,
+    tenant.clusters.shoot_status_updated,
+    (
+        SELECT max(tenant.cluster_events.created)
+        FROM tenant.cluster_events
+        WHERE tenant.cluster_events.cluster_id = tenant.cluster_upgrades.cluster_id
+          AND tenant.cluster_events.event_type = 'sync_succeeded'
+    )::timestamptz AS last_synced
+FROM
+    tenant.cluster_upgrades
+    JOIN tenant.clusters ON tenant.clusters.id = tenant.cluster_upgrades.cluster_id
+WHERE
+    tenant.cluster_upgrades.status = 'in_progress'
+ORDER BY
+    tenant.cluster_upgrades.started,
+    tenant.cluster_upgrades.id;
+
+-- name: ClusterUpgradeFinish :execrows
+-- Records the outcome of a scheduled or in-progress upgrade.
+UPDATE tenant.cluster_upgrades
+SET
+    status = @status,
+    message = @message,
+    finished = now()
+WHERE
+    id = @upgrade_id
+    AND status IN ('scheduled', 'in_progress');
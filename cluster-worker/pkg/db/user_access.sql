@@ -1,7 +1,10 @@
 -- name: ResolveUserAccess :one
 -- Determines the desired access level for a user on a cluster.
--- Returns 'admin' if the user is an accepted org admin, 'member' if the user
--- is a project member on any project in the cluster, or 'none' otherwise.
+-- Returns 'admin' if the user is an accepted org admin, is on a team with the
+-- org admin role or holds an active elevation to org admin, 'member' if the
+-- user is a project member on any project in the cluster, directly or through
+-- a team, or holds an active elevation to project admin on one, or 'none'
+-- otherwise.
 -- Used by the write path (UserSyncHandler).
 -- NOTE: Duplicated in authn-api/pkg/db/queries.sql — keep both in sync.
 SELECT
@@ -15,6 +18,26 @@ SELECT
                 AND tenant.organizations_users.status = 'accepted'
                 AND tenant.organizations_users.deleted IS NULL
         )
+            OR EXISTS (
+                SELECT 1
+                FROM tenant.teams
+                JOIN tenant.team_members
+                    ON tenant.team_members.team_id = tenant.teams.id
+                WHERE tenant.teams.organization_id = tenant.clusters.organization_id
+                    AND tenant.teams.permission = 'admin'
+                    AND tenant.teams.deleted IS NULL
+                    AND tenant.team_members.user_id = @user_id
+                    AND tenant.team_members.deleted IS NULL
+            )
+            OR EXISTS (
+                SELECT 1
+                FROM tenant.access_elevations
+                WHERE tenant.access_elevations.organization_id = tenant.clusters.organization_id
+                    AND tenant.access_elevations.user_id = @user_id
+                    AND tenant.access_elevations.role = 'admin'
+                    AND tenant.access_elevations.status = 'approved'
+                    AND tenant.access_elevations.expires > now()
+            )
             THEN 'admin'
         WHEN EXISTS (
             SELECT 1
@@ -26,6 +49,34 @@ SELECT
                 AND tenant.project_members.user_id = @user_id
                 AND tenant.project_members.deleted IS NULL
         )
+            OR EXISTS (
+                SELECT 1
+                FROM tenant.projects
+                JOIN tenant.project_teams
+                    ON tenant.project_teams.project_id = tenant.projects.id
+                JOIN tenant.teams
+                    ON tenant.teams.id = tenant.project_teams.team_id
+                JOIN tenant.team_members
+                    ON tenant.team_members.team_id = tenant.teams.id
+                WHERE tenant.projects.cluster_id = tenant.clusters.id
+                    AND tenant.projects.deleted IS NULL
+                    AND tenant.project_teams.deleted IS NULL
+                    AND tenant.teams.deleted IS NULL
+                    AND tenant.team_members.user_id = @user_id
+                    AND tenant.team_members.deleted IS NULL
+            )
+            OR EXISTS (
+                SELECT 1
+                FROM tenant.projects
+                JOIN tenant.access_elevations
+                    ON tenant.access_elevations.project_id = tenant.projects.id
+                WHERE tenant.projects.cluster_id = tenant.clusters.id
+                    AND tenant.projects.deleted IS NULL
+                    AND tenant.access_elevations.user_id = @user_id
+                    AND tenant.access_elevations.role = 'project_admin'
+                    AND tenant.access_elevations.status = 'approved'
+                    AND tenant.access_elevations.expires > now()
+            )
             THEN 'member'
         ELSE 'none'
     END AS access_level
@@ -36,41 +87,77 @@ LIMIT 1;
 -- name: UserListForCluster :many
 -- Returns all users who should have access to a cluster, with their access level.
 -- Used by the reconciliation loop to compare against actual state on the shoot.
+-- Team memberships and active elevations count like the role they grant, as
+-- in ResolveUserAccess.
+WITH admins AS (
+    SELECT tenant.organizations_users.user_id
+    FROM tenant.clusters
+    JOIN tenant.organizations_users
+        ON tenant.organizations_users.organization_id = tenant.clusters.organization_id
+    WHERE tenant.clusters.id = @cluster_id
+        AND tenant.organizations_users.permission = 'admin'
+        AND tenant.organizations_users.status = 'accepted'
+        AND tenant.organizations_users.deleted IS NULL
+    UNION
+    SELECT tenant.team_members.user_id
+    FROM tenant.clusters
+    JOIN tenant.teams
+        ON tenant.teams.organization_id = tenant.clusters.organization_id
+    JOIN tenant.team_members
+        ON tenant.team_members.team_id = tenant.teams.id AND tenant.team_members.deleted IS NULL
+    WHERE tenant.clusters.id = @cluster_id
+        AND tenant.teams.permission = 'admin'
+        AND tenant.teams.deleted IS NULL
+    UNION
+    SELECT tenant.access_elevations.user_id
+    FROM tenant.clusters
+    JOIN tenant.access_elevations
+        ON tenant.access_elevations.organization_id = tenant.clusters.organization_id
+    WHERE tenant.clusters.id = @cluster_id
+        AND tenant.access_elevations.role = 'admin'
+        AND tenant.access_elevations.status = 'approved'
+        AND tenant.access_elevations.expires > now()
+),
+members AS (
+    SELECT tenant.project_members.user_id
+    FROM tenant.projects
+    JOIN tenant.project_members
+        ON tenant.project_members.project_id = tenant.projects.id AND tenant.project_members.deleted IS NULL
+    WHERE tenant.projects.cluster_id = @cluster_id
+        AND tenant.projects.deleted IS NULL
+    UNION
+    SELECT tenant.team_members.user_id
+    FROM tenant.projects
+    JOIN tenant.project_teams
+        ON tenant.project_teams.project_id = tenant.projects.id AND tenant.project_teams.deleted IS NULL
+    JOIN tenant.teams
+        ON tenant.teams.id = tenant.project_teams.team_id AND tenant.teams.deleted IS NULL
+    JOIN tenant.team_members
+        ON tenant.team_members.team_id = tenant.teams.id AND tenant.team_members.deleted IS NULL
+    WHERE tenant.projects.cluster_id = @cluster_id
+        AND tenant.projects.deleted IS NULL
+    UNION
+    SELECT tenant.access_elevations.user_id
+    FROM tenant.projects
+    JOIN tenant.access_elevations
+        ON tenant.access_elevations.project_id = tenant.projects.id
+    WHERE tenant.projects.cluster_id = @cluster_id
+        AND tenant.projects.deleted IS NULL
+        AND tenant.access_elevations.role = 'project_admin'
+        AND tenant.access_elevations.status = 'approved'
+        AND tenant.access_elevations.expires > now()
+)
 SELECT
     tenant.users.id AS user_id,
     tenant.users.email,
-    CASE
-        WHEN tenant.organizations_users.permission = 'admin'
-            AND tenant.organizations_users.status = 'accepted'
-            AND tenant.organizations_users.deleted IS NULL
-            THEN 'admin'
-        ELSE 'member'
-    END AS access_level
-FROM tenant.clusters
-JOIN tenant.organizations_users
-    ON tenant.organizations_users.organization_id = tenant.clusters.organization_id
-    AND tenant.organizations_users.status = 'accepted'
-    AND tenant.organizations_users.deleted IS NULL
-JOIN tenant.users ON tenant.users.id = tenant.organizations_users.user_id AND tenant.users.deleted IS NULL
-WHERE tenant.clusters.id = @cluster_id
-    AND tenant.organizations_users.permission = 'admin'
+    'admin' AS access_level
+FROM admins
+JOIN tenant.users ON tenant.users.id = admins.user_id AND tenant.users.deleted IS NULL
 UNION ALL
-SELECT DISTINCT
+SELECT
     tenant.users.id AS user_id,
     tenant.users.email,
     'member' AS access_level
-FROM tenant.clusters
-JOIN tenant.projects
-    ON tenant.projects.cluster_id = tenant.clusters.id AND tenant.projects.deleted IS NULL
-JOIN tenant.project_members
-    ON tenant.project_members.project_id = tenant.projects.id AND tenant.project_members.deleted IS NULL
-JOIN tenant.users ON tenant.users.id = tenant.project_members.user_id AND tenant.users.deleted IS NULL
-WHERE tenant.clusters.id = @cluster_id
-    AND NOT EXISTS (
-        SELECT 1 FROM tenant.organizations_users
-        WHERE tenant.organizations_users.organization_id = tenant.clusters.organization_id
-            AND tenant.organizations_users.user_id = tenant.project_members.user_id
-            AND tenant.organizations_users.permission = 'admin'
-            AND tenant.organizations_users.status = 'accepted'
-            AND tenant.organizations_users.deleted IS NULL
-    );
+FROM members
+JOIN tenant.users ON tenant.users.id = members.user_id AND tenant.users.deleted IS NULL
+WHERE members.user_id NOT IN (SELECT admins.user_id FROM admins);
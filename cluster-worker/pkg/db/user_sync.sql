@@ -49,3 +49,39 @@ WHERE tenant.users.id = @id;
 -- Insert a user_sync_succeeded or user_sync_failed event.
 INSERT INTO tenant.cluster_events (cluster_id, event_type, message)
 VALUES (@cluster_id, @event_type, @message);
+
+-- name: AccessElevationGetForSync :one
+-- Get an access elevation for the UserSyncHandler. The access it grants is
+-- resolved per cluster by ResolveUserAccess, which only counts it while it is
+-- approved and not past its expiry.
+SELECT
+    tenant.access_elevations.user_id,
+    tenant.access_elevations.role,
+    tenant.access_elevations.status,
+    tenant.access_elevations.expires
+FROM tenant.access_elevations
+WHERE tenant.access_elevations.id = @id;
+
+-- name: AccessElevationListReadyClusters :many
+-- List the ready clusters an access elevation reaches: every cluster of the
+-- organization for an organization admin elevation, or the project's cluster
+-- for a project admin elevation.
+SELECT tenant.clusters.id
+FROM tenant.access_elevations
+JOIN tenant.clusters
+    ON tenant.clusters.organization_id = tenant.access_elevations.organization_id
+LEFT JOIN tenant.projects
+    ON tenant.projects.id = tenant.access_elevations.project_id
+WHERE tenant.access_elevations.id = @id
+  AND (tenant.access_elevations.project_id IS NULL OR tenant.clusters.id = tenant.projects.cluster_id)
+  AND tenant.clusters.shoot_status = 'ready'
+  AND tenant.clusters.deleted IS NULL;
+
+-- name: AccessElevationExpire :execrows
+-- Marks approved access elevations past their expiry as expired. The
+-- cluster_outbox trigger then queues the removal of the access they granted.
+UPDATE tenant.access_elevations
+SET status = 'expired',
+    ended = now()
+WHERE status = 'approved'
+  AND expires <= now();
@@ -0,0 +1,75 @@
+-- name: WebhookDeliveryClaim :one
+-- Claims the next pending/retryable webhook delivery together with the
+-- endpoint it goes to, by pushing its retry_after out by lease. Other workers
+-- skip the delivery while it is sent outside any transaction; if the worker
+-- dies mid-send, the delivery is retried once the lease ends. Deliveries of
+-- the webhooks in busy_webhook_ids are skipped, so that one endpoint cannot
+-- take every slot. Deliveries of deleted or disabled webhooks are returned as
+-- well so the worker can fail them instead of leaving them pending.
+WITH next AS (
+    SELECT tenant.webhook_deliveries.id
+    FROM tenant.webhook_deliveries
+    WHERE tenant.webhook_deliveries.status IN ('pending', 'retrying')
+      AND (tenant.webhook_deliveries.retry_after IS NULL OR tenant.webhook_deliveries.retry_after <= now())
+      AND NOT tenant.webhook_deliveries.webhook_id = ANY(@busy_webhook_ids::uuid[])
+    ORDER BY tenant.webhook_deliveries.id ASC
+    LIMIT 1
+    FOR NO KEY UPDATE SKIP LOCKED
+)
+UPDATE tenant.webhook_deliveries
+SET retry_after = now() + @lease::interval
+FROM next, tenant.webhooks
+WHERE tenant.webhook_deliveries.id = next.id
+  AND tenant.webhooks.id = tenant.webhook_deliveries.webhook_id
+RETURNING tenant.webhook_deliveries.id,
+          tenant.webhook_deliveries.webhook_id,
+          tenant.webhook_deliveries.event_type,
+          tenant.webhook_deliveries.payload,
+          tenant.webhook_deliveries.attempts,
+          tenant.webhook_deliveries.retry_after::timestamptz AS leased_until,
+          tenant.webhooks.url,
+          tenant.webhooks.secret,
+          tenant.webhooks.enabled,
+          tenant.webhooks.deleted;
+
+-- name: WebhookDeliveryMarkSucceeded :execrows
+-- Only while the worker still holds the lease it claimed the delivery with.
+UPDATE tenant.webhook_deliveries
+SET status = 'succeeded',
+    attempts = attempts + 1,
+    response_status = @response_status,
+    status_info = NULL,
+    retry_after = NULL,
+    completed = now()
+WHERE id = @id
+  AND retry_after = @leased_until;
+
+-- name: WebhookDeliveryMarkRetry :one
+-- Marks a delivery for retry with exponential backoff, calculated the same way
+-- as OutboxMarkRetry: base_interval * 2^(attempts+1), capped at max_backoff.
+-- Only while the worker still holds the lease it claimed the delivery with.
+UPDATE tenant.webhook_deliveries
+SET attempts = attempts + 1,
+    retry_after = now() + LEAST(
+        sqlc.arg('base_interval')::interval * (1 << (attempts + 1)),
+        @max_backoff::interval
+    ),
+    status = 'retrying',
+    response_status = @response_status,
+    status_info = @status_info
+WHERE id = @id
+  AND retry_after = @leased_until
+RETURNING attempts;
+
+-- name: WebhookDeliveryMarkFailed :execrows
+-- Marks a delivery as permanently failed. Only while the worker still holds
+-- the lease it claimed the delivery with.
+UPDATE tenant.webhook_deliveries
+SET status = 'failed',
+    attempts = attempts + 1,
+    response_status = @response_status,
+    status_info = @status_info,
+    retry_after = NULL,
+    completed = now()
+WHERE id = @id
+  AND retry_after = @leased_until;
@@ -313,6 +313,58 @@ func TestNamespaceSync_LimitRangeFromMergedDefaults(t *testing.T) {
 	require.Nil(t, mock.GetLimitRange(clusterID, clusterNS), "cleared defaults must remove the LimitRange")
 }
 
+// The namespace sync merges the project quota with the namespace override
+// lowest-wins into the managed ResourceQuota; writing an override enqueues a
+// sync of just that namespace.
+func TestNamespaceSync_ResourceQuotaFromProjectAndOverride(t *testing.T) {
+	db := createTestDB(t)
+	mock := newMockShoot(t)
+	h := newNamespaceHandler(t, db, mock)
+	ctx := t.Context()
+
+	clusterID := insertCluster(t, db, acmeCorpOrgID, "ns-quota-e2e")
+	setShootStatus(t, db, clusterID, "ready")
+	projectID := insertProject(t, db, clusterID, "proj-quota")
+	nsID := insertNamespace(t, db, projectID, "team-a")
+	insertNamespace(t, db, projectID, "team-b")
+	clusterNS := kubename.GenerateNamespace("proj-quota", projectID, "team-a")
+
+	_, namespacesBefore := outboxCounts(t, db)
+	_, err := db.adminPool.Exec(ctx,
+		`INSERT INTO tenant.project_limits (project_id, quota_pods, quota_storage_gi)
+		 VALUES ($1, 30, 100)`, projectID)
+	require.NoError(t, err)
+	_, namespacesAfter := outboxCounts(t, db)
+	require.Equal(t, namespacesBefore+2, namespacesAfter, "a quota change re-syncs every namespace of the project")
+
+	_, err = db.adminPool.Exec(ctx,
+		`INSERT INTO tenant.namespace_limits (namespace_id, quota_pods, quota_services)
+		 VALUES ($1, 10, 5)`, nsID)
+	require.NoError(t, err)
+	_, namespacesFinal := outboxCounts(t, db)
+	require.Equal(t, namespacesAfter+1, namespacesFinal, "an override re-syncs only its namespace")
+
+	require.NoError(t, h.Sync(ctx, nsID, nsSyncCtx))
+
+	rq := mock.GetResourceQuota(clusterID, clusterNS)
+	require.NotNil(t, rq, "managed ResourceQuota must be applied")
+	require.NotNil(t, rq.Quota.Pods)
+	require.EqualValues(t, 10, *rq.Quota.Pods, "lower namespace override wins")
+	require.NotNil(t, rq.Quota.StorageGi)
+	require.EqualValues(t, 100, *rq.Quota.StorageGi, "project quota applies")
+	require.NotNil(t, rq.Quota.Services)
+	require.EqualValues(t, 5, *rq.Quota.Services, "override-only field applies")
+	require.Nil(t, mock.GetLimitRange(clusterID, clusterNS), "a quota alone creates no LimitRange")
+
+	_, err = db.adminPool.Exec(ctx, `UPDATE tenant.project_limits SET deleted = now() WHERE project_id = $1`, projectID)
+	require.NoError(t, err)
+	_, err = db.adminPool.Exec(ctx, `UPDATE tenant.namespace_limits SET deleted = now() WHERE namespace_id = $1`, nsID)
+	require.NoError(t, err)
+
+	require.NoError(t, h.Sync(ctx, nsID, nsSyncCtx))
+	require.Nil(t, mock.GetResourceQuota(clusterID, clusterNS), "cleared quotas must remove the ResourceQuota")
+}
+
 // Task 1.12: a limit change affecting zero active clusters/namespaces inserts
 // no rows and does not error.
 func TestLimitsTrigger_NoActiveTargetsNoop(t *testing.T) {
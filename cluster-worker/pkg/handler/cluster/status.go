@@ -13,7 +13,8 @@ import (
 	"github.com/fundament-oss/fundament/common/dbconst"
 )
 
-// CheckStatus polls Gardener for shoot status and updates the database.
+// CheckStatus polls Gardener for shoot status and updates the database, then
+// starts due cluster upgrades and settles the ones in progress.
 func (h *Handler) CheckStatus(ctx context.Context) error {
 	var errs []error
 	if err := h.pollActiveClusters(ctx); err != nil {
@@ -22,6 +23,9 @@ func (h *Handler) CheckStatus(ctx context.Context) error {
 	if err := h.pollDeletedClusters(ctx); err != nil {
 		errs = append(errs, err)
 	}
+	if err := h.runUpgrades(ctx); err != nil {
+		errs = append(errs, err)
+	}
 	if err := errors.Join(errs...); err != nil {
 		return fmt.Errorf("check status: %w", err)
 	}
@@ -92,6 +96,8 @@ func (h *Handler) pollActiveClusters(ctx context.Context) error {
 				eventType = dbconst.ClusterEventEventType_StatusReady
 			case gardener.StatusError:
 				eventType = dbconst.ClusterEventEventType_StatusError
+			case gardener.StatusHibernated:
+				eventType = dbconst.ClusterEventEventType_StatusHibernated
 			case gardener.StatusPending, gardener.StatusDeleting:
 				// No event for these transient states
 			case gardener.StatusDeleted:
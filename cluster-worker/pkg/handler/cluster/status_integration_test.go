@@ -94,3 +94,36 @@ func TestCheckStatusErrorTransition(t *testing.T) {
 
 	assertEventExists(t, db, clusterID, "status_error")
 }
+
+func TestCheckStatusHibernatedAfterRequest(t *testing.T) {
+	t.Parallel()
+
+	db := createTestDB(t)
+	mock := newMock(t)
+	h := newTestHandler(t, db, mock)
+
+	clusterID := insertCluster(t, db, acmeCorpOrgID, "status-hibernated")
+	markOutboxCompleted(t, db, clusterID)
+
+	// Ready cluster, then a hibernate request newer than the last status check.
+	setShootStatus(t, db, clusterID, "ready")
+	_, err := db.adminPool.Exec(t.Context(),
+		`UPDATE tenant.clusters SET hibernated = true, hibernation_requested = now() WHERE id = $1`,
+		clusterID,
+	)
+	require.NoError(t, err)
+
+	sc := handler.SyncContext{EntityType: handler.EntityCluster, Event: dbconst.ClusterOutboxEvent_Updated, Source: dbconst.ClusterOutboxSource_Trigger}
+	err = h.Sync(t.Context(), clusterID, sc)
+	require.NoError(t, err)
+	require.True(t, mock.ApplyCalls[len(mock.ApplyCalls)-1].Hibernated)
+
+	err = h.CheckStatus(t.Context())
+	require.NoError(t, err)
+
+	status := getClusterShootStatus(t, db, clusterID)
+	require.NotNil(t, status)
+	require.Equal(t, "hibernated", *status)
+
+	assertEventExists(t, db, clusterID, "status_hibernated")
+}
@@ -2,6 +2,7 @@ package cluster
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"time"
@@ -9,6 +10,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
+	corev1 "k8s.io/api/core/v1"
 
 	"github.com/fundament-oss/fundament/cluster-worker/pkg/client/gardener"
 	db "github.com/fundament-oss/fundament/cluster-worker/pkg/db/gen"
@@ -109,35 +111,66 @@ func (h *Handler) syncCluster(ctx context.Context, id uuid.UUID, sc handler.Sync
 		return h.syncError(ctx, cluster.ID, syncAction, "load organization limits", err)
 	}
 
-	// 7. Build ClusterToSync and apply
+	// 7. Load hibernation schedules
+	scheduleRows, err := h.queries.ClusterHibernationScheduleListByClusterID(ctx, db.ClusterHibernationScheduleListByClusterIDParams{ClusterID: cluster.ID})
+	if err != nil {
+		return h.syncError(ctx, cluster.ID, syncAction, "load hibernation schedules", err)
+	}
+
+	// 8. Build ClusterToSync and apply
 	clusterToSync := clusterToSyncBase(cluster.ID, cluster.Name, cluster.OrganizationName, cluster.OrganizationID, namespace, cluster.Region, cluster.KubernetesVersion, cluster.CloudProfile, cluster.CloudProfileRegion)
 	clusterToSync.ShootName = kubename.GenerateShootName(cluster.Name, cluster.ID)
 	clusterToSync.Deleted = deleted
-	clusterToSync.NodePools = toGardenerNodePools(nodePoolRows)
+	clusterToSync.NodePools, err = toGardenerNodePools(nodePoolRows)
+	if err != nil {
+		return h.syncError(ctx, cluster.ID, syncAction, "load node pools", err)
+	}
 	clusterToSync.NodeLimits = toGardenerNodeLimits(limitsRow)
+	clusterToSync.MaintenanceWindow = toMaintenanceWindow(cluster.MaintenanceWindowStart, cluster.MaintenanceWindowEnd)
+	clusterToSync.AutoUpdateKubernetesVersion = cluster.AutoUpdateKubernetesVersion
+	clusterToSync.AutoUpdateMachineImageVersion = cluster.AutoUpdateMachineImageVersion
+	clusterToSync.Hibernated = cluster.Hibernated
+	if cluster.HibernationRequested.Valid {
+		clusterToSync.HibernationRequested = &cluster.HibernationRequested.Time
+	}
+	clusterToSync.HibernationSchedules = toHibernationSchedules(scheduleRows)
 
 	if err := h.gardener.ApplyShoot(ctx, clusterToSync); err != nil {
 		return h.syncError(ctx, cluster.ID, syncAction, "apply shoot", err)
 	}
 
-	// 7. Success
+	// 9. Success
 	h.createSyncSucceededEvent(ctx, cluster.ID, syncAction, syncMessage(sc.Event, sc.EntityType))
 	h.logger.Info("synced cluster to gardener", "cluster_id", cluster.ID, "name", cluster.Name)
 	return nil
 }
 
 // toGardenerNodePools converts DB rows to the gardener.NodePool slice expected by ClusterToSync.
-func toGardenerNodePools(rows []db.NodePoolListByClusterIDRow) []gardener.NodePool {
+// Labels and taints are stored as JSON in the shape of their Kubernetes types.
+func toGardenerNodePools(rows []db.NodePoolListByClusterIDRow) ([]gardener.NodePool, error) {
 	pools := make([]gardener.NodePool, len(rows))
 	for i, np := range rows {
+		var labels map[string]string
+		if err := json.Unmarshal(np.Labels, &labels); err != nil {
+			return nil, fmt.Errorf("node pool %q: decode labels: %w", np.Name, err)
+		}
+		var taints []corev1.Taint
+		if err := json.Unmarshal(np.Taints, &taints); err != nil {
+			return nil, fmt.Errorf("node pool %q: decode taints: %w", np.Name, err)
+		}
 		pools[i] = gardener.NodePool{
-			Name:         np.Name,
-			MachineType:  np.MachineType,
-			AutoscaleMin: np.AutoscaleMin,
-			AutoscaleMax: np.AutoscaleMax,
+			Name:           np.Name,
+			MachineType:    np.MachineType,
+			AutoscaleMin:   np.AutoscaleMin,
+			AutoscaleMax:   np.AutoscaleMax,
+			Labels:         labels,
+			Taints:         taints,
+			Zones:          np.Zones,
+			MaxSurge:       np.MaxSurge,
+			MaxUnavailable: np.MaxUnavailable,
 		}
 	}
-	return pools
+	return pools, nil
 }
 
 // toGardenerNodeLimits converts the nullable org node caps to gardener.NodeLimits.
@@ -150,6 +183,32 @@ func toGardenerNodeLimits(row db.OrganizationLimitsGetByOrgIDRow) gardener.NodeL
 	}
 }
 
+// toMaintenanceWindow converts the nullable window columns to a
+// gardener.MaintenanceWindow; nil unless both ends are set.
+func toMaintenanceWindow(start, end pgtype.Time) *gardener.MaintenanceWindow {
+	if !start.Valid || !end.Valid {
+		return nil
+	}
+	return &gardener.MaintenanceWindow{
+		Start: time.Duration(start.Microseconds) * time.Microsecond,
+		End:   time.Duration(end.Microseconds) * time.Microsecond,
+	}
+}
+
+// toHibernationSchedules converts DB rows to gardener.HibernationSchedule; a
+// NULL start or end becomes the empty string.
+func toHibernationSchedules(rows []db.ClusterHibernationScheduleListByClusterIDRow) []gardener.HibernationSchedule {
+	schedules := make([]gardener.HibernationSchedule, len(rows))
+	for i, row := range rows {
+		schedules[i] = gardener.HibernationSchedule{
+			Start:    row.Start.String,
+			End:      row.End.String,
+			Location: row.Location,
+		}
+	}
+	return schedules
+}
+
 // int4Ptr converts a nullable pgtype.Int4 to *int32 (NULL -> nil).
 func int4Ptr(v pgtype.Int4) *int32 {
 	if !v.Valid {
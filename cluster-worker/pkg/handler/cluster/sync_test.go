@@ -1,10 +1,12 @@
 package cluster
 
 import (
+	"reflect"
 	"testing"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgtype"
+	corev1 "k8s.io/api/core/v1"
 
 	"github.com/fundament-oss/fundament/cluster-worker/pkg/client/gardener"
 	db "github.com/fundament-oss/fundament/cluster-worker/pkg/db/gen"
@@ -61,38 +63,68 @@ func TestToGardenerNodePools(t *testing.T) {
 			MachineType:  "n1-standard-4",
 			AutoscaleMin: 1,
 			AutoscaleMax: 5,
+			Labels:       []byte(`{}`),
+			Taints:       []byte(`[]`),
+			MaxSurge:     1,
 			Created:      pgtype.Timestamptz{Valid: true},
 		},
 		{
-			ID:           uuid.New(),
-			Name:         "worker-2",
-			MachineType:  "n1-standard-8",
-			AutoscaleMin: 2,
-			AutoscaleMax: 10,
-			Created:      pgtype.Timestamptz{Valid: true},
+			ID:             uuid.New(),
+			Name:           "worker-2",
+			MachineType:    "n1-standard-8",
+			AutoscaleMin:   2,
+			AutoscaleMax:   10,
+			Labels:         []byte(`{"workload":"batch"}`),
+			Taints:         []byte(`[{"key":"dedicated","value":"batch","effect":"NoSchedule"}]`),
+			Zones:          []string{"eu-central-1a", "eu-central-1b"},
+			MaxSurge:       2,
+			MaxUnavailable: 1,
+			Created:        pgtype.Timestamptz{Valid: true},
 		},
 	}
 
-	pools := toGardenerNodePools(rows)
+	pools, err := toGardenerNodePools(rows)
+	if err != nil {
+		t.Fatalf("toGardenerNodePools: %v", err)
+	}
 
 	if len(pools) != 2 {
 		t.Fatalf("expected 2 pools, got %d", len(pools))
 	}
 
 	for i, want := range []gardener.NodePool{
-		{Name: "worker-1", MachineType: "n1-standard-4", AutoscaleMin: 1, AutoscaleMax: 5},
-		{Name: "worker-2", MachineType: "n1-standard-8", AutoscaleMin: 2, AutoscaleMax: 10},
+		{Name: "worker-1", MachineType: "n1-standard-4", AutoscaleMin: 1, AutoscaleMax: 5, Labels: map[string]string{}, Taints: []corev1.Taint{}, MaxSurge: 1},
+		{
+			Name: "worker-2", MachineType: "n1-standard-8", AutoscaleMin: 2, AutoscaleMax: 10,
+			Labels:         map[string]string{"workload": "batch"},
+			Taints:         []corev1.Taint{{Key: "dedicated", Value: "batch", Effect: corev1.TaintEffectNoSchedule}},
+			Zones:          []string{"eu-central-1a", "eu-central-1b"},
+			MaxSurge:       2,
+			MaxUnavailable: 1,
+		},
 	} {
 		got := pools[i]
-		if got != want {
+		if !reflect.DeepEqual(got, want) {
 			t.Errorf("pool[%d] = %+v, want %+v", i, got, want)
 		}
 	}
 }
 
 func TestToGardenerNodePools_Empty(t *testing.T) {
-	pools := toGardenerNodePools(nil)
+	pools, err := toGardenerNodePools(nil)
+	if err != nil {
+		t.Fatalf("toGardenerNodePools: %v", err)
+	}
 	if len(pools) != 0 {
 		t.Errorf("expected 0 pools, got %d", len(pools))
 	}
 }
+
+func TestToGardenerNodePools_InvalidTaints(t *testing.T) {
+	_, err := toGardenerNodePools([]db.NodePoolListByClusterIDRow{
+		{Name: "worker-1", Labels: []byte(`{}`), Taints: []byte(`{}`)},
+	})
+	if err == nil {
+		t.Fatal("expected an error for taints that are not an array")
+	}
+}
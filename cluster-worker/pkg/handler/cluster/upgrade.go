@@ -0,0 +1,266 @@
+package cluster
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/fundament-oss/fundament/cluster-worker/pkg/client/gardener"
+	db "github.com/fundament-oss/fundament/cluster-worker/pkg/db/gen"
+	"github.com/fundament-oss/fundament/common/dbconst"
+	"github.com/fundament-oss/fundament/common/rollback"
+)
+
+// errUpgradeNotStarted is returned by startUpgrade when the upgrade or its
+// cluster changed between listing and starting it.
+var errUpgradeNotStarted = errors.New("upgrade no longer startable")
+
+// runUpgrades starts scheduled upgrades that are due and follows in-progress
+// upgrades until the shoot reports the outcome.
+func (h *Handler) runUpgrades(ctx context.Context) error {
+	var errs []error
+	if err := h.startDueUpgrades(ctx); err != nil {
+		errs = append(errs, err)
+	}
+	if err := h.pollUpgrades(ctx); err != nil {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+// startDueUpgrades applies the target version of every due upgrade whose
+// cluster is inside its maintenance window. Setting kubernetes_version fires
+// the cluster outbox trigger, so the regular sync path rolls it out. It pages
+// past upgrades waiting for their window until it has handled a batch, so
+// those never crowd out upgrades that may start.
+func (h *Handler) startDueUpgrades(ctx context.Context) error {
+	params := db.ClusterUpgradeListDueParams{LimitCount: h.cfg.StatusBatchSize}
+	now := time.Now()
+	var handled int32
+	for handled < h.cfg.StatusBatchSize {
+		upgrades, err := h.queries.ClusterUpgradeListDue(ctx, params)
+		if err != nil {
+			h.logger.Error("failed to list due cluster upgrades", "error", err)
+			return fmt.Errorf("list due cluster upgrades: %w", err)
+		}
+
+		for i := range upgrades {
+			if ctx.Err() != nil {
+				return nil //nolint:nilerr // graceful shutdown
+			}
+			if h.startDueUpgrade(ctx, &upgrades[i], now) {
+				handled++
+			}
+		}
+
+		if len(upgrades) < int(params.LimitCount) {
+			return nil
+		}
+		last := upgrades[len(upgrades)-1]
+		params.AfterNotBefore = last.NotBefore
+		params.AfterID = pgtype.UUID{Bytes: last.ID, Valid: true}
+	}
+	return nil
+}
+
+// startDueUpgrade starts a due upgrade, or cancels it when its cluster was
+// deleted. It returns false when the upgrade waits for its maintenance window.
+func (h *Handler) startDueUpgrade(ctx context.Context, upgrade *db.ClusterUpgradeListDueRow, now time.Time) bool {
+	if upgrade.Deleted.Valid {
+		h.finishUpgrade(ctx, upgrade.ID, upgrade.ClusterID, dbconst.ClusterUpgradeStatus_Cancelled, "", "Cluster was deleted")
+		return true
+	}
+
+	window := toMaintenanceWindow(upgrade.MaintenanceWindowStart, upgrade.MaintenanceWindowEnd)
+	if !upgradeMayStart(window, upgrade.SkipMaintenanceWindow, now) {
+		return false
+	}
+
+	if err := h.startUpgrade(ctx, upgrade); err != nil {
+		if errors.Is(err, errUpgradeNotStarted) {
+			h.logger.Debug("skipping cluster upgrade", "upgrade_id", upgrade.ID, "cluster_id", upgrade.ClusterID)
+			return true
+		}
+		h.logger.Error("failed to start cluster upgrade",
+			"upgrade_id", upgrade.ID,
+			"cluster_id", upgrade.ClusterID,
+			"error", err)
+		return true
+	}
+
+	h.logger.Info("started cluster upgrade",
+		"upgrade_id", upgrade.ID,
+		"cluster_id", upgrade.ClusterID,
+		"from", upgrade.FromKubernetesVersion,
+		"to", upgrade.ToKubernetesVersion)
+	return true
+}
+
+// startUpgrade marks the upgrade in progress and sets the cluster's version
+// in one transaction, so a cancelled upgrade never reaches the cluster.
+func (h *Handler) startUpgrade(ctx context.Context, upgrade *db.ClusterUpgradeListDueRow) error {
+	tx, err := h.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer rollback.Rollback(ctx, tx, h.logger)
+
+	qtx := h.queries.WithTx(tx)
+
+	started, err := qtx.ClusterUpgradeStart(ctx, db.ClusterUpgradeStartParams{UpgradeID: upgrade.ID})
+	if err != nil {
+		return fmt.Errorf("mark upgrade started: %w", err)
+	}
+	if started == 0 {
+		return errUpgradeNotStarted
+	}
+
+	updated, err := qtx.ClusterSetKubernetesVersion(ctx, db.ClusterSetKubernetesVersionParams{
+		KubernetesVersion:   upgrade.ToKubernetesVersion,
+		KubernetesVersionID: upgrade.ToKubernetesVersionID,
+		ClusterID:           upgrade.ClusterID,
+	})
+	if err != nil {
+		return fmt.Errorf("set kubernetes version: %w", err)
+	}
+	if updated == 0 {
+		return errUpgradeNotStarted
+	}
+
+	if _, err := qtx.ClusterCreateStatusEvent(ctx, db.ClusterCreateStatusEventParams{
+		ClusterID: upgrade.ClusterID,
+		EventType: string(dbconst.ClusterEventEventType_UpgradeStarted),
+		Message: pgtype.Text{
+			String: fmt.Sprintf("Upgrading Kubernetes from %s to %s", upgrade.FromKubernetesVersion, upgrade.ToKubernetesVersion),
+			Valid:  true,
+		},
+	}); err != nil {
+		return fmt.Errorf("create upgrade_started event: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+	return nil
+}
+
+// pollUpgrades completes or fails in-progress upgrades based on the cluster's
+// sync and shoot status.
+func (h *Handler) pollUpgrades(ctx context.Context) error {
+	upgrades, err := h.queries.ClusterUpgradeListInProgress(ctx)
+	if err != nil {
+		h.logger.Error("failed to list in-progress cluster upgrades", "error", err)
+		return fmt.Errorf("list in-progress cluster upgrades: %w", err)
+	}
+
+	for i := range upgrades {
+		if ctx.Err() != nil {
+			return nil //nolint:nilerr // graceful shutdown
+		}
+		upgrade := &upgrades[i]
+
+		status, message := upgradeOutcome(upgrade)
+		if status == "" {
+			continue
+		}
+
+		var eventType dbconst.ClusterEventEventType
+		switch status {
+		case dbconst.ClusterUpgradeStatus_Completed:
+			eventType = dbconst.ClusterEventEventType_UpgradeCompleted
+		case dbconst.ClusterUpgradeStatus_Failed:
+			eventType = dbconst.ClusterEventEventType_UpgradeFailed
+		case dbconst.ClusterUpgradeStatus_Cancelled:
+			// No event: the cluster is gone
+		case dbconst.ClusterUpgradeStatus_Scheduled, dbconst.ClusterUpgradeStatus_InProgress:
+			panic(fmt.Sprintf("upgradeOutcome returned non-terminal status %q", status))
+		default:
+			panic(fmt.Sprintf("unhandled cluster upgrade status: %s", status))
+		}
+
+		h.finishUpgrade(ctx, upgrade.ID, upgrade.ClusterID, status, eventType, message)
+	}
+	return nil
+}
+
+// finishUpgrade records the final status of an upgrade and, when eventType is
+// set, a matching cluster event.
+func (h *Handler) finishUpgrade(ctx context.Context, upgradeID, clusterID uuid.UUID, status dbconst.ClusterUpgradeStatus, eventType dbconst.ClusterEventEventType, message string) {
+	finished, err := h.queries.ClusterUpgradeFinish(ctx, db.ClusterUpgradeFinishParams{
+		Status:    string(status),
+		Message:   pgtype.Text{String: message, Valid: message != ""},
+		UpgradeID: upgradeID,
+	})
+	if err != nil {
+		h.logger.Error("failed to finish cluster upgrade",
+			"upgrade_id", upgradeID,
+			"cluster_id", clusterID,
+			"status", status,
+			"error", err)
+		return
+	}
+	if finished == 0 {
+		return
+	}
+
+	if eventType != "" {
+		if _, err := h.queries.ClusterCreateStatusEvent(ctx, db.ClusterCreateStatusEventParams{
+			ClusterID: clusterID,
+			EventType: string(eventType),
+			Message:   pgtype.Text{String: message, Valid: message != ""},
+		}); err != nil {
+			h.logger.Warn("failed to create upgrade event",
+				"cluster_id", clusterID,
+				"event_type", eventType,
+				"error", err)
+		}
+	}
+
+	h.logger.Info("finished cluster upgrade",
+		"upgrade_id", upgradeID,
+		"cluster_id", clusterID,
+		"status", status)
+}
+
+// upgradeMayStart reports whether a due upgrade may start at now. Clusters
+// without a window of their own are upgraded as soon as the upgrade is due.
+func upgradeMayStart(window *gardener.MaintenanceWindow, skipWindow bool, now time.Time) bool {
+	if window == nil || skipWindow {
+		return true
+	}
+	return window.Contains(now)
+}
+
+// upgradeOutcome decides whether an in-progress upgrade has finished. It
+// returns an empty status while the upgrade is still rolling out. The shoot
+// status only counts once the new version has been synced to Gardener and
+// the status was checked after that sync.
+func upgradeOutcome(upgrade *db.ClusterUpgradeListInProgressRow) (dbconst.ClusterUpgradeStatus, string) {
+	if upgrade.Deleted.Valid {
+		return dbconst.ClusterUpgradeStatus_Cancelled, "Cluster was deleted"
+	}
+
+	if upgrade.OutboxStatus.Valid && upgrade.OutboxStatus.String == string(dbconst.ClusterOutboxStatus_Failed) {
+		return dbconst.ClusterUpgradeStatus_Failed, "Sync to Gardener failed: " + textOrEmpty(upgrade.OutboxError)
+	}
+
+	if !upgrade.Started.Valid || !upgrade.LastSynced.Valid || !upgrade.ShootStatusUpdated.Valid {
+		return "", ""
+	}
+	if !upgrade.LastSynced.Time.After(upgrade.Started.Time) || !upgrade.ShootStatusUpdated.Time.After(upgrade.LastSynced.Time) {
+		return "", ""
+	}
+
+	switch gardener.ShootStatusType(textOrEmpty(upgrade.ShootStatus)) {
+	case gardener.StatusReady, gardener.StatusHibernated:
+		return dbconst.ClusterUpgradeStatus_Completed, fmt.Sprintf("Upgraded Kubernetes from %s to %s", upgrade.FromKubernetesVersion, upgrade.ToKubernetesVersion)
+	case gardener.StatusError:
+		return dbconst.ClusterUpgradeStatus_Failed, textOrEmpty(upgrade.ShootStatusMessage)
+	default:
+		return "", ""
+	}
+}
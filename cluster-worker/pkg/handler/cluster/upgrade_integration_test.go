@@ -0,0 +1,75 @@
+package cluster_test
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fundament-oss/fundament/cluster-worker/pkg/handler/cluster"
+)
+
+// insertUpgrade schedules an upgrade of clusterID to 1.32.0 that became due
+// dueAgo ago.
+func insertUpgrade(t *testing.T, db *testDB, clusterID uuid.UUID, dueAgo string, skipWindow bool) uuid.UUID {
+	t.Helper()
+
+	var id uuid.UUID
+	err := db.adminPool.QueryRow(t.Context(),
+		`INSERT INTO tenant.cluster_upgrades (cluster_id, from_kubernetes_version, to_kubernetes_version, not_before, skip_maintenance_window)
+		 VALUES ($1, '1.31.1', '1.32.0', now() - $2::interval, $3)
+		 RETURNING id`,
+		clusterID, dueAgo, skipWindow,
+	).Scan(&id)
+	require.NoError(t, err)
+
+	return id
+}
+
+func getUpgradeStatus(t *testing.T, db *testDB, upgradeID uuid.UUID) string {
+	t.Helper()
+
+	var status string
+	err := db.adminPool.QueryRow(t.Context(),
+		`SELECT status FROM tenant.cluster_upgrades WHERE id = $1`, upgradeID,
+	).Scan(&status)
+	require.NoError(t, err)
+
+	return status
+}
+
+// TestStartDueUpgradesPagesPastClosedWindows checks that upgrades waiting for
+// their maintenance window do not keep a later upgrade that may start out of
+// the batch.
+func TestStartDueUpgradesPagesPastClosedWindows(t *testing.T) {
+	t.Parallel()
+
+	db := createTestDB(t)
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+	mock := newMock(t)
+	h := cluster.New(db.workerPool, mock, mock, logger, cluster.Config{StatusBatchSize: 2, MaxRetries: 10})
+
+	var waiting []uuid.UUID
+	for i := range 5 {
+		clusterID := insertCluster(t, db, acmeCorpOrgID, fmt.Sprintf("upgrade-waiting-%d", i))
+		_, err := db.adminPool.Exec(t.Context(),
+			`UPDATE tenant.clusters
+			 SET maintenance_window_start = (now() AT TIME ZONE 'UTC' + interval '2 hours')::time,
+			     maintenance_window_end = (now() AT TIME ZONE 'UTC' + interval '3 hours')::time
+			 WHERE id = $1`, clusterID)
+		require.NoError(t, err)
+		waiting = append(waiting, insertUpgrade(t, db, clusterID, "2 hours", false))
+	}
+
+	urgent := insertUpgrade(t, db, insertCluster(t, db, acmeCorpOrgID, "upgrade-urgent"), "1 minute", true)
+
+	require.NoError(t, h.CheckStatus(t.Context()))
+
+	require.Equal(t, "in_progress", getUpgradeStatus(t, db, urgent))
+	for _, id := range waiting {
+		require.Equal(t, "scheduled", getUpgradeStatus(t, db, id))
+	}
+}
@@ -0,0 +1,152 @@
+package cluster
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/fundament-oss/fundament/cluster-worker/pkg/client/gardener"
+	db "github.com/fundament-oss/fundament/cluster-worker/pkg/db/gen"
+	"github.com/fundament-oss/fundament/common/dbconst"
+)
+
+func TestToMaintenanceWindow(t *testing.T) {
+	start := pgtype.Time{Microseconds: (22 * time.Hour).Microseconds(), Valid: true}
+	end := pgtype.Time{Microseconds: (2*time.Hour + 30*time.Minute).Microseconds(), Valid: true}
+
+	got := toMaintenanceWindow(start, end)
+	if got == nil {
+		t.Fatal("expected a window, got nil")
+	}
+	if got.Start != 22*time.Hour || got.End != 2*time.Hour+30*time.Minute {
+		t.Errorf("toMaintenanceWindow = %+v, want 22h0m0s-2h30m0s", *got)
+	}
+
+	if got := toMaintenanceWindow(start, pgtype.Time{}); got != nil {
+		t.Errorf("toMaintenanceWindow with NULL end = %+v, want nil", *got)
+	}
+}
+
+func TestUpgradeMayStart(t *testing.T) {
+	window := &gardener.MaintenanceWindow{Start: 22 * time.Hour, End: 2 * time.Hour}
+	inside := time.Date(2026, 3, 1, 23, 0, 0, 0, time.UTC)
+	outside := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		window     *gardener.MaintenanceWindow
+		skipWindow bool
+		now        time.Time
+		want       bool
+	}{
+		{name: "no window", window: nil, now: outside, want: true},
+		{name: "inside window", window: window, now: inside, want: true},
+		{name: "outside window", window: window, now: outside, want: false},
+		{name: "outside window, skipped", window: window, skipWindow: true, now: outside, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := upgradeMayStart(tt.window, tt.skipWindow, tt.now); got != tt.want {
+				t.Errorf("upgradeMayStart() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUpgradeOutcome(t *testing.T) {
+	started := time.Date(2026, 3, 1, 23, 0, 0, 0, time.UTC)
+	ts := func(d time.Duration) pgtype.Timestamptz {
+		return pgtype.Timestamptz{Time: started.Add(d), Valid: true}
+	}
+	text := func(s string) pgtype.Text {
+		return pgtype.Text{String: s, Valid: true}
+	}
+
+	tests := []struct {
+		name    string
+		row     db.ClusterUpgradeListInProgressRow
+		want    dbconst.ClusterUpgradeStatus
+		wantMsg string
+	}{
+		{
+			name: "not synced yet",
+			row: db.ClusterUpgradeListInProgressRow{
+				Started:            ts(0),
+				LastSynced:         ts(-time.Hour),
+				ShootStatus:        text("ready"),
+				ShootStatusUpdated: ts(time.Minute),
+			},
+		},
+		{
+			name: "status not checked since sync",
+			row: db.ClusterUpgradeListInProgressRow{
+				Started:            ts(0),
+				LastSynced:         ts(time.Minute),
+				ShootStatus:        text("ready"),
+				ShootStatusUpdated: ts(30 * time.Second),
+			},
+		},
+		{
+			name: "still progressing",
+			row: db.ClusterUpgradeListInProgressRow{
+				Started:            ts(0),
+				LastSynced:         ts(time.Minute),
+				ShootStatus:        text("progressing"),
+				ShootStatusUpdated: ts(2 * time.Minute),
+			},
+		},
+		{
+			name: "ready after sync",
+			row: db.ClusterUpgradeListInProgressRow{
+				FromKubernetesVersion: "1.31.4",
+				ToKubernetesVersion:   "1.32.1",
+				Started:               ts(0),
+				LastSynced:            ts(time.Minute),
+				ShootStatus:           text("ready"),
+				ShootStatusUpdated:    ts(10 * time.Minute),
+			},
+			want:    dbconst.ClusterUpgradeStatus_Completed,
+			wantMsg: "Upgraded Kubernetes from 1.31.4 to 1.32.1",
+		},
+		{
+			name: "error after sync",
+			row: db.ClusterUpgradeListInProgressRow{
+				Started:            ts(0),
+				LastSynced:         ts(time.Minute),
+				ShootStatus:        text("error"),
+				ShootStatusMessage: text("kube-apiserver unhealthy"),
+				ShootStatusUpdated: ts(10 * time.Minute),
+			},
+			want:    dbconst.ClusterUpgradeStatus_Failed,
+			wantMsg: "kube-apiserver unhealthy",
+		},
+		{
+			name: "sync failed",
+			row: db.ClusterUpgradeListInProgressRow{
+				Started:      ts(0),
+				OutboxStatus: text("failed"),
+				OutboxError:  text("version not supported"),
+			},
+			want:    dbconst.ClusterUpgradeStatus_Failed,
+			wantMsg: "Sync to Gardener failed: version not supported",
+		},
+		{
+			name: "cluster deleted",
+			row: db.ClusterUpgradeListInProgressRow{
+				Started: ts(0),
+				Deleted: ts(time.Minute),
+			},
+			want:    dbconst.ClusterUpgradeStatus_Cancelled,
+			wantMsg: "Cluster was deleted",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, msg := upgradeOutcome(&tt.row)
+			if got != tt.want || msg != tt.wantMsg {
+				t.Errorf("upgradeOutcome() = (%q, %q), want (%q, %q)", got, msg, tt.want, tt.wantMsg)
+			}
+		})
+	}
+}
@@ -205,6 +205,84 @@ func TestResolveUserAccessMultiProject(t *testing.T) {
 	require.Equal(t, "member", access)
 }
 
+func TestResolveUserAccessElevatedAdmin(t *testing.T) {
+	t.Parallel()
+
+	db := createTestDB(t)
+	queries := dbgen.New(db.adminPool)
+
+	clusterID := insertCluster(t, db, acmeCorpOrgID, "access-elevated-admin")
+	userID := insertUser(t, db, "Elevated Admin User")
+	insertOrgUser(t, db, acmeCorpOrgID, userID, "viewer", "accepted")
+	insertAccessElevation(t, db, acmeCorpOrgID, userID, "admin", nil, "1 hour")
+
+	access, err := queries.ResolveUserAccess(t.Context(), dbgen.ResolveUserAccessParams{
+		UserID:    userID,
+		ClusterID: clusterID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, "admin", access)
+
+	users, err := queries.UserListForCluster(t.Context(), dbgen.UserListForClusterParams{ClusterID: clusterID})
+	require.NoError(t, err)
+	var listed []string
+	for _, u := range users {
+		if u.UserID == userID {
+			listed = append(listed, u.AccessLevel)
+		}
+	}
+	require.Equal(t, []string{"admin"}, listed)
+}
+
+func TestResolveUserAccessElevatedProjectAdmin(t *testing.T) {
+	t.Parallel()
+
+	db := createTestDB(t)
+	queries := dbgen.New(db.adminPool)
+
+	clusterID := insertCluster(t, db, acmeCorpOrgID, "access-elevated-project")
+	userID := insertUser(t, db, "Elevated Project Admin User")
+	projectAdminID := insertUser(t, db, "Project Admin EP")
+	insertOrgUser(t, db, acmeCorpOrgID, userID, "viewer", "accepted")
+	projectID := insertProjectWithMembers(t, db, clusterID,
+		projectMember{UserID: projectAdminID, Role: "admin"},
+	)
+	insertAccessElevation(t, db, acmeCorpOrgID, userID, "project_admin", &projectID, "1 hour")
+
+	access, err := queries.ResolveUserAccess(t.Context(), dbgen.ResolveUserAccessParams{
+		UserID:    userID,
+		ClusterID: clusterID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, "member", access)
+}
+
+func TestResolveUserAccessExpiredElevation(t *testing.T) {
+	t.Parallel()
+
+	db := createTestDB(t)
+	queries := dbgen.New(db.adminPool)
+
+	clusterID := insertCluster(t, db, acmeCorpOrgID, "access-expired-elevation")
+	userID := insertUser(t, db, "Expired Elevation User")
+	insertOrgUser(t, db, acmeCorpOrgID, userID, "viewer", "accepted")
+
+	// Approved, but past its expiry: it no longer counts, even before the
+	// status handler marks it expired.
+	insertAccessElevation(t, db, acmeCorpOrgID, userID, "admin", nil, "-1 minute")
+
+	access, err := queries.ResolveUserAccess(t.Context(), dbgen.ResolveUserAccessParams{
+		UserID:    userID,
+		ClusterID: clusterID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, "none", access)
+
+	expired, err := queries.AccessElevationExpire(t.Context())
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, expired, int64(1))
+}
+
 // insertOrgUser inserts an organizations_users row.
 func insertOrgUser(t *testing.T, db *testDB, orgID, userID uuid.UUID, permission, status string) {
 	t.Helper()
@@ -327,3 +405,17 @@ func insertProjectWithMembers(t *testing.T, db *testDB, clusterID uuid.UUID, mem
 
 	return projectID
 }
+
+// insertAccessElevation inserts an approved access elevation that expires
+// after the given interval.
+func insertAccessElevation(t *testing.T, db *testDB, orgID, userID uuid.UUID, role string, projectID *uuid.UUID, expiresIn string) {
+	t.Helper()
+
+	_, err := db.adminPool.Exec(t.Context(),
+		`INSERT INTO tenant.access_elevations
+		     (organization_id, user_id, role, project_id, duration_minutes, reason, status, decided, expires)
+		 VALUES ($1, $2, $3, $4, 60, 'incident', 'approved', now(), now() + $5::interval)`,
+		orgID, userID, role, projectID, expiresIn,
+	)
+	require.NoError(t, err)
+}
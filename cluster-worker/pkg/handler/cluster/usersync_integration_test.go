@@ -4,8 +4,10 @@ import (
 	"log/slog"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/stretchr/testify/require"
 
 	"github.com/fundament-oss/fundament/cluster-worker/pkg/client/shoot"
@@ -190,6 +192,123 @@ func TestSyncAdminRemovedStillProjectMember(t *testing.T) {
 	require.False(t, mock.HasCRB(clusterID, userID), "CRB should be deleted (no longer admin)")
 }
 
+// --- Team members: access through a team only ---
+
+// insertTeam creates a team in an organization with the given members and
+// returns its ID. permission is the team's organization role; "" for none.
+func insertTeam(t *testing.T, db *testDB, orgID uuid.UUID, name, permission string, memberIDs ...uuid.UUID) uuid.UUID {
+	t.Helper()
+	var teamID uuid.UUID
+	err := db.adminPool.QueryRow(t.Context(),
+		`INSERT INTO tenant.teams (organization_id, name, permission)
+		 VALUES ($1, $2, NULLIF($3, ''))
+		 RETURNING id`,
+		orgID, name, permission,
+	).Scan(&teamID)
+	require.NoError(t, err)
+
+	for _, userID := range memberIDs {
+		_, err := db.adminPool.Exec(t.Context(),
+			`INSERT INTO tenant.team_members (team_id, user_id) VALUES ($1, $2)`,
+			teamID, userID,
+		)
+		require.NoError(t, err)
+	}
+	return teamID
+}
+
+func TestSyncProjectTeamMember(t *testing.T) {
+	t.Parallel()
+
+	db := createTestDB(t)
+	mock := newMockShootAccess(t)
+	h := newUserSyncHandler(t, db, mock)
+
+	clusterID := insertCluster(t, db, acmeCorpOrgID, "sync-project-team")
+	makeClusterReady(t, db, clusterID)
+	markOutboxCompleted(t, db, clusterID)
+
+	userID := insertUser(t, db, "Project Team Member")
+	projectAdminID := insertUser(t, db, "Proj Admin PT")
+	insertOrgUser(t, db, acmeCorpOrgID, userID, "viewer", "accepted")
+	insertOrgUser(t, db, acmeCorpOrgID, projectAdminID, "admin", "accepted")
+
+	// The user is on the project only through the team.
+	projectID := insertProjectWithMembers(t, db, clusterID,
+		projectMember{UserID: projectAdminID, Role: "admin"},
+	)
+	teamID := insertTeam(t, db, acmeCorpOrgID, "project-team", "", userID)
+	_, err := db.adminPool.Exec(t.Context(),
+		`INSERT INTO tenant.project_teams (project_id, team_id, role) VALUES ($1, $2, 'viewer')`,
+		projectID, teamID,
+	)
+	require.NoError(t, err)
+
+	orgUserID := getOrgUserID(t, db, acmeCorpOrgID, userID)
+	err = h.Sync(t.Context(), orgUserID, handler.SyncContext{
+		EntityType: handler.EntityOrgUser,
+		Event:      dbconst.ClusterOutboxEvent_Updated,
+		Source:     dbconst.ClusterOutboxSource_Trigger,
+	})
+	require.NoError(t, err)
+
+	require.True(t, mock.HasSA(clusterID, userID), "SA should exist for a project team member")
+	require.False(t, mock.HasCRB(clusterID, userID), "CRB should NOT exist for a project team member")
+
+	// Removing the team from the project revokes the access.
+	_, err = db.adminPool.Exec(t.Context(),
+		`UPDATE tenant.project_teams SET deleted = now() WHERE team_id = $1`,
+		teamID,
+	)
+	require.NoError(t, err)
+	err = h.Sync(t.Context(), orgUserID, handler.SyncContext{
+		EntityType: handler.EntityOrgUser,
+		Event:      dbconst.ClusterOutboxEvent_Updated,
+		Source:     dbconst.ClusterOutboxSource_Trigger,
+	})
+	require.NoError(t, err)
+
+	require.False(t, mock.HasSA(clusterID, userID), "SA should be removed with the project team")
+}
+
+func TestSyncAdminTeamMember(t *testing.T) {
+	t.Parallel()
+
+	db := createTestDB(t)
+	mock := newMockShootAccess(t)
+	h := newUserSyncHandler(t, db, mock)
+
+	clusterID := insertCluster(t, db, acmeCorpOrgID, "sync-admin-team")
+	makeClusterReady(t, db, clusterID)
+	markOutboxCompleted(t, db, clusterID)
+
+	userID := insertUser(t, db, "Admin Team Member")
+	insertOrgUser(t, db, acmeCorpOrgID, userID, "viewer", "accepted")
+	insertTeam(t, db, acmeCorpOrgID, "admin-team", "admin", userID)
+
+	orgUserID := getOrgUserID(t, db, acmeCorpOrgID, userID)
+	err := h.Sync(t.Context(), orgUserID, handler.SyncContext{
+		EntityType: handler.EntityOrgUser,
+		Event:      dbconst.ClusterOutboxEvent_Updated,
+		Source:     dbconst.ClusterOutboxSource_Trigger,
+	})
+	require.NoError(t, err)
+
+	require.True(t, mock.HasSA(clusterID, userID), "SA should exist for an admin team member")
+	require.True(t, mock.HasCRB(clusterID, userID), "CRB should exist for an admin team member")
+
+	users, err := dbgen.New(db.workerPool).UserListForCluster(t.Context(), dbgen.UserListForClusterParams{ClusterID: clusterID})
+	require.NoError(t, err)
+	var found bool
+	for _, user := range users {
+		if user.UserID == userID {
+			found = true
+			require.Equal(t, "admin", user.AccessLevel)
+		}
+	}
+	require.True(t, found, "admin team member should be listed for reconciliation")
+}
+
 // --- Non-ready skip-path tests ---
 
 func TestSyncOrgUserAllClustersNotReady(t *testing.T) {
@@ -334,6 +453,32 @@ func TestTriggerProjectMemberInsertCreatesOutboxRow(t *testing.T) {
 	require.Greater(t, count, 0, "project member trigger should create outbox row")
 }
 
+func TestTriggerTeamMemberInsertCreatesOutboxRow(t *testing.T) {
+	t.Parallel()
+
+	db := createTestDB(t)
+	userID := insertUser(t, db, "Trigger Team Member")
+	insertOrgUser(t, db, acmeCorpOrgID, userID, "viewer", "accepted")
+	orgUserID := getOrgUserID(t, db, acmeCorpOrgID, userID)
+
+	var before int
+	err := db.adminPool.QueryRow(t.Context(),
+		`SELECT count(*) FROM tenant.cluster_outbox WHERE organization_user_id = $1`,
+		orgUserID,
+	).Scan(&before)
+	require.NoError(t, err)
+
+	insertTeam(t, db, acmeCorpOrgID, "trigger-team", "admin", userID)
+
+	var after int
+	err = db.adminPool.QueryRow(t.Context(),
+		`SELECT count(*) FROM tenant.cluster_outbox WHERE organization_user_id = $1`,
+		orgUserID,
+	).Scan(&after)
+	require.NoError(t, err)
+	require.Greater(t, after, before, "team member trigger should queue the member's org user row")
+}
+
 // --- Outbox routing tests ---
 
 func TestOutboxRowCanBeLocked(t *testing.T) {
@@ -345,8 +490,21 @@ func TestOutboxRowCanBeLocked(t *testing.T) {
 	userID := insertUser(t, db, "Entity Detect User")
 	insertOrgUser(t, db, acmeCorpOrgID, userID, "admin", "accepted")
 
+	// A single shard holds every row; the claim needs its lease.
+	_, err := queries.LeaseAcquire(t.Context(), dbgen.LeaseAcquireParams{
+		Name:     "outbox-shard/0",
+		Holder:   "test",
+		Duration: pgtype.Interval{Microseconds: time.Minute.Microseconds(), Valid: true},
+	})
+	require.NoError(t, err)
+
 	// Verify OutboxGetAndLock can read and lock rows with different FK columns set.
-	row, err := queries.OutboxGetAndLock(t.Context())
+	row, err := queries.OutboxGetAndLock(t.Context(), dbgen.OutboxGetAndLockParams{
+		ShardCount: 1,
+		Shard:      0,
+		LeaseName:  "outbox-shard/0",
+		Holder:     "test",
+	})
 	require.NoError(t, err)
 	require.NotEqual(t, uuid.Nil, row.ID, "should get a valid outbox row")
 }
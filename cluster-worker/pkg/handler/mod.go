@@ -27,11 +27,12 @@ func NewPreconditionError(reason string) *PreconditionError {
 type EntityType string
 
 const (
-	EntityCluster       EntityType = "cluster"
-	EntityOrgUser       EntityType = "org_user"
-	EntityProjectMember EntityType = "project_member"
-	EntityNodePool      EntityType = "node_pool"
-	EntityNamespace     EntityType = "namespace"
+	EntityCluster         EntityType = "cluster"
+	EntityOrgUser         EntityType = "org_user"
+	EntityProjectMember   EntityType = "project_member"
+	EntityNodePool        EntityType = "node_pool"
+	EntityNamespace       EntityType = "namespace"
+	EntityAccessElevation EntityType = "access_elevation"
 )
 
 // SyncContext carries metadata from the outbox row to the sync handler.
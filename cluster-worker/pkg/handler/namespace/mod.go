@@ -83,7 +83,8 @@ func (h *Handler) Sync(ctx context.Context, id uuid.UUID, sc handler.SyncContext
 }
 
 // syncNamespace converges a single tenant.namespaces row to its cluster-side
-// v1/Namespace. It is idempotent and event-agnostic: it reloads the row and
+// v1/Namespace and the Roles/RoleBindings of the namespace role bindings that
+// match it. It is idempotent and event-agnostic: it reloads the row and
 // reconciles to the desired state, so created/updated/deleted/reconcile rows
 // all resolve correctly without branching on the event.
 func (h *Handler) syncNamespace(ctx context.Context, id uuid.UUID) error {
@@ -105,18 +106,25 @@ func (h *Handler) syncNamespace(ctx context.Context, id uuid.UUID) error {
 	if row.Deleted.Valid {
 		return h.delete(ctx, &row)
 	}
-	return h.ensure(ctx, &row)
+	if err := h.ensure(ctx, &row); err != nil {
+		return err
+	}
+	return h.syncNamespaceRoles(ctx, &row)
 }
 
 // ensure creates or label-reconciles the cluster-side namespace for an active
 // row, then reconciles the managed LimitRange inside it from the merged
-// org/project resource defaults.
+// org/project resource defaults and the managed ResourceQuota from the merged
+// project/namespace quota.
 func (h *Handler) ensure(ctx context.Context, row *db.NamespaceGetForSyncRow) error {
 	name := kubename.GenerateNamespace(row.ProjectName, row.ProjectID, row.Name)
 	if err := h.ensureNamespace(ctx, row, name); err != nil {
 		return err
 	}
-	return h.reconcileLimitRange(ctx, row, name)
+	if err := h.reconcileLimitRange(ctx, row, name); err != nil {
+		return err
+	}
+	return h.reconcileResourceQuota(ctx, row, name)
 }
 
 // ensureNamespace creates or label-reconciles the cluster-side namespace.
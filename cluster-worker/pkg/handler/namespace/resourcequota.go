@@ -0,0 +1,53 @@
+package namespace
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/fundament-oss/fundament/cluster-worker/pkg/client/shoot"
+	db "github.com/fundament-oss/fundament/cluster-worker/pkg/db/gen"
+)
+
+// mergedQuota computes the namespace's effective ResourceQuota from the
+// project quota and the namespace's override: per field the lowest non-NULL
+// value wins, so an override can only tighten the project quota. Unlike the
+// LimitRange defaults there is no request/limit guard: a limits quota below
+// the requests quota is valid, if useless, and the kube-apiserver accepts it.
+// hasAny reports whether any field is set.
+func mergedQuota(row *db.NamespaceGetForSyncRow) (quota shoot.QuotaLimits, hasAny bool) {
+	quota = shoot.QuotaLimits{
+		CPURequestMilli:        leastInt4(row.NamespaceQuotaCpuRequestM, row.ProjectQuotaCpuRequestM),
+		CPULimitMilli:          leastInt4(row.NamespaceQuotaCpuLimitM, row.ProjectQuotaCpuLimitM),
+		MemoryRequestMi:        leastInt4(row.NamespaceQuotaMemoryRequestMi, row.ProjectQuotaMemoryRequestMi),
+		MemoryLimitMi:          leastInt4(row.NamespaceQuotaMemoryLimitMi, row.ProjectQuotaMemoryLimitMi),
+		StorageGi:              leastInt4(row.NamespaceQuotaStorageGi, row.ProjectQuotaStorageGi),
+		Pods:                   leastInt4(row.NamespaceQuotaPods, row.ProjectQuotaPods),
+		PersistentVolumeClaims: leastInt4(row.NamespaceQuotaPersistentVolumeClaims, row.ProjectQuotaPersistentVolumeClaims),
+		Services:               leastInt4(row.NamespaceQuotaServices, row.ProjectQuotaServices),
+	}
+
+	hasAny = quota.CPURequestMilli != nil || quota.CPULimitMilli != nil ||
+		quota.MemoryRequestMi != nil || quota.MemoryLimitMi != nil ||
+		quota.StorageGi != nil || quota.Pods != nil ||
+		quota.PersistentVolumeClaims != nil || quota.Services != nil
+	return quota, hasAny
+}
+
+// reconcileResourceQuota materializes the merged quota as the managed
+// fundament-quota ResourceQuota in the (already ensured) namespace, or removes
+// it when no quota applies.
+func (h *Handler) reconcileResourceQuota(ctx context.Context, row *db.NamespaceGetForSyncRow, name string) error {
+	quota, hasAny := mergedQuota(row)
+
+	if !hasAny {
+		if err := h.shoot.DeleteResourceQuota(ctx, row.ClusterID, name); err != nil {
+			return fmt.Errorf("delete resource quota in namespace %s: %w", name, err)
+		}
+		return nil
+	}
+
+	if err := h.shoot.EnsureResourceQuota(ctx, row.ClusterID, name, quota, desiredLabels(row)); err != nil {
+		return fmt.Errorf("ensure resource quota in namespace %s: %w", name, err)
+	}
+	return nil
+}
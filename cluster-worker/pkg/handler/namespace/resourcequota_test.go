@@ -0,0 +1,109 @@
+package namespace
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+	"k8s.io/utils/ptr"
+
+	"github.com/fundament-oss/fundament/cluster-worker/pkg/client/shoot"
+	db "github.com/fundament-oss/fundament/cluster-worker/pkg/db/gen"
+)
+
+func TestMergedQuota(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		row        db.NamespaceGetForSyncRow
+		want       shoot.QuotaLimits
+		wantHasAny bool
+	}{
+		{
+			name: "neither source set",
+			row:  db.NamespaceGetForSyncRow{},
+			want: shoot.QuotaLimits{},
+		},
+		{
+			name: "project only",
+			row: db.NamespaceGetForSyncRow{
+				ProjectQuotaCpuRequestM: i4(2000), ProjectQuotaPods: i4(30),
+			},
+			want:       shoot.QuotaLimits{CPURequestMilli: ptr.To[int32](2000), Pods: ptr.To[int32](30)},
+			wantHasAny: true,
+		},
+		{
+			name: "namespace only",
+			row: db.NamespaceGetForSyncRow{
+				NamespaceQuotaStorageGi: i4(20),
+			},
+			want:       shoot.QuotaLimits{StorageGi: ptr.To[int32](20)},
+			wantHasAny: true,
+		},
+		{
+			name: "both set, lowest wins per field",
+			row: db.NamespaceGetForSyncRow{
+				ProjectQuotaPods: i4(30), NamespaceQuotaPods: i4(10),
+				ProjectQuotaServices: i4(5), NamespaceQuotaServices: i4(50),
+				ProjectQuotaMemoryLimitMi: i4(8192),
+			},
+			want: shoot.QuotaLimits{
+				Pods:          ptr.To[int32](10),   // namespace tightened
+				Services:      ptr.To[int32](5),    // project wins over higher override
+				MemoryLimitMi: ptr.To[int32](8192), // only project set
+			},
+			wantHasAny: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			quota, hasAny := mergedQuota(&tt.row)
+			require.Equal(t, tt.wantHasAny, hasAny)
+			require.Equal(t, tt.want, quota)
+		})
+	}
+}
+
+func TestEnsure_AppliesAndRemovesResourceQuota(t *testing.T) {
+	t.Parallel()
+	h, mock := newTestHandler(t)
+	row := testRow("team-a")
+	row.ProjectQuotaPods = i4(30)
+	row.NamespaceQuotaPods = i4(10)
+	row.ProjectQuotaStorageGi = i4(100)
+
+	require.NoError(t, h.ensure(context.Background(), row))
+
+	rq := mock.GetResourceQuota(row.ClusterID, clusterName(row))
+	require.NotNil(t, rq)
+	require.Equal(t, shoot.QuotaLimits{
+		Pods:      ptr.To[int32](10),
+		StorageGi: ptr.To[int32](100),
+	}, rq.Quota)
+	require.Equal(t, ManagedByValue, rq.Labels[LabelManagedBy])
+	require.Equal(t, row.ID.String(), rq.Labels[LabelNamespaceID])
+	require.Nil(t, mock.GetLimitRange(row.ClusterID, clusterName(row)), "a quota alone creates no LimitRange")
+
+	row.ProjectQuotaPods = pgtype.Int4{}
+	row.NamespaceQuotaPods = pgtype.Int4{}
+	row.ProjectQuotaStorageGi = pgtype.Int4{}
+	require.NoError(t, h.ensure(context.Background(), row))
+	require.Nil(t, mock.GetResourceQuota(row.ClusterID, clusterName(row)))
+}
+
+func TestEnsure_ResourceQuotaErrorPropagates(t *testing.T) {
+	t.Parallel()
+	h, mock := newTestHandler(t)
+	row := testRow("team-a")
+	row.ProjectQuotaPods = i4(30)
+	mock.EnsureResourceQuotaError = errors.New("boom")
+
+	err := h.ensure(context.Background(), row)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "ensure resource quota")
+}
@@ -0,0 +1,125 @@
+package namespace
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+
+	"github.com/fundament-oss/fundament/cluster-worker/pkg/client/shoot"
+	db "github.com/fundament-oss/fundament/cluster-worker/pkg/db/gen"
+	"github.com/fundament-oss/fundament/common/dbconst"
+	"github.com/fundament-oss/fundament/common/kubename"
+)
+
+var (
+	readVerbs  = []string{"get", "list", "watch"}
+	writeVerbs = []string{"get", "list", "watch", "create", "update", "patch", "delete"}
+)
+
+// namespaceRoleRules are the fixed RBAC rules of each namespace role binding
+// role. deploy also lets the user see the pods it rolls out, matching the
+// OpenFGA model where deployers can view pods.
+var namespaceRoleRules = map[dbconst.NamespaceRoleBindingRole][]rbacv1.PolicyRule{
+	dbconst.NamespaceRoleBindingRole_Deploy: {
+		{APIGroups: []string{"apps"}, Resources: []string{"deployments", "statefulsets", "daemonsets", "replicasets"}, Verbs: writeVerbs},
+		{APIGroups: []string{"batch"}, Resources: []string{"jobs", "cronjobs"}, Verbs: writeVerbs},
+		{APIGroups: []string{""}, Resources: []string{"configmaps"}, Verbs: writeVerbs},
+		{APIGroups: []string{""}, Resources: []string{"pods"}, Verbs: []string{"get", "list", "watch", "delete"}},
+		{APIGroups: []string{""}, Resources: []string{"events"}, Verbs: readVerbs},
+	},
+	dbconst.NamespaceRoleBindingRole_ViewPods: {
+		{APIGroups: []string{""}, Resources: []string{"pods", "events"}, Verbs: readVerbs},
+	},
+	dbconst.NamespaceRoleBindingRole_ViewLogs: {
+		{APIGroups: []string{""}, Resources: []string{"pods"}, Verbs: []string{"get", "list"}},
+		{APIGroups: []string{""}, Resources: []string{"pods/log"}, Verbs: []string{"get"}},
+	},
+	dbconst.NamespaceRoleBindingRole_ManageServices: {
+		{APIGroups: []string{""}, Resources: []string{"services"}, Verbs: writeVerbs},
+		{APIGroups: []string{"networking.k8s.io"}, Resources: []string{"ingresses"}, Verbs: writeVerbs},
+		{APIGroups: []string{"discovery.k8s.io"}, Resources: []string{"endpointslices"}, Verbs: readVerbs},
+	},
+}
+
+// syncNamespaceRoles loads the role grants of an active namespace and
+// reconciles its managed Roles and RoleBindings.
+func (h *Handler) syncNamespaceRoles(ctx context.Context, row *db.NamespaceGetForSyncRow) error {
+	grants, err := h.queries.NamespaceRoleGrantsForSync(ctx, db.NamespaceRoleGrantsForSyncParams{NamespaceID: row.ID})
+	if err != nil {
+		return fmt.Errorf("list namespace role grants: %w", err)
+	}
+	return h.reconcileNamespaceRoles(ctx, row, grants)
+}
+
+// reconcileNamespaceRoles materializes the namespace role bindings that match
+// the (already ensured) namespace: one Role per granted role and one
+// RoleBinding per user and role, bound to the user's ServiceAccount in
+// fundament-system. Managed Roles and RoleBindings no longer granted are
+// removed; only objects carrying shoot.LabelNamespaceRole are ever touched.
+func (h *Handler) reconcileNamespaceRoles(ctx context.Context, row *db.NamespaceGetForSyncRow, grants []db.NamespaceRoleGrantsForSyncRow) error {
+	name := kubename.GenerateNamespace(row.ProjectName, row.ProjectID, row.Name)
+
+	wantRoles := make(map[string]bool)
+	wantBindings := make(map[string]bool)
+	for _, grant := range grants {
+		rules, ok := namespaceRoleRules[dbconst.NamespaceRoleBindingRole(grant.Role)]
+		if !ok {
+			return fmt.Errorf("unknown namespace role %q", grant.Role)
+		}
+
+		labels := desiredLabels(row)
+		labels[shoot.LabelNamespaceRole] = grant.Role
+
+		roleName := shoot.NamespaceRoleName(grant.Role)
+		if !wantRoles[roleName] {
+			if err := h.shoot.EnsureRole(ctx, row.ClusterID, name, roleName, rules, labels); err != nil {
+				return fmt.Errorf("ensure role %s in namespace %s: %w", roleName, name, err)
+			}
+			wantRoles[roleName] = true
+		}
+
+		bindingLabels := maps.Clone(labels)
+		bindingLabels[shoot.LabelUserID] = grant.UserID.String()
+		bindingName := shoot.NamespaceRoleBindingName(grant.Role, grant.UserID)
+		if err := h.shoot.EnsureRoleBinding(ctx, row.ClusterID, name, bindingName, roleName,
+			shoot.FundamentNamespace, shoot.SAName(grant.UserID), bindingLabels); err != nil {
+			return fmt.Errorf("ensure role binding %s in namespace %s: %w", bindingName, name, err)
+		}
+		wantBindings[bindingName] = true
+	}
+
+	var errs []error
+
+	bindings, err := h.shoot.ListRoleBindings(ctx, row.ClusterID, name, shoot.LabelNamespaceRole)
+	if err != nil {
+		return fmt.Errorf("list role bindings in namespace %s: %w", name, err)
+	}
+	for _, rb := range bindings {
+		if wantBindings[rb.Name] {
+			continue
+		}
+		if err := h.shoot.DeleteRoleBinding(ctx, row.ClusterID, name, rb.Name); err != nil {
+			errs = append(errs, fmt.Errorf("delete role binding %s in namespace %s: %w", rb.Name, name, err))
+			continue
+		}
+		h.logger.Info("deleted namespace role binding", "namespace_id", row.ID, "name", rb.Name)
+	}
+
+	roles, err := h.shoot.ListRoles(ctx, row.ClusterID, name, shoot.LabelNamespaceRole)
+	if err != nil {
+		return fmt.Errorf("list roles in namespace %s: %w", name, err)
+	}
+	for _, role := range roles {
+		if wantRoles[role.Name] {
+			continue
+		}
+		if err := h.shoot.DeleteRole(ctx, row.ClusterID, name, role.Name); err != nil {
+			errs = append(errs, fmt.Errorf("delete role %s in namespace %s: %w", role.Name, name, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
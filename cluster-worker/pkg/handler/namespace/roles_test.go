@@ -0,0 +1,99 @@
+package namespace
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fundament-oss/fundament/cluster-worker/pkg/client/shoot"
+	db "github.com/fundament-oss/fundament/cluster-worker/pkg/db/gen"
+	"github.com/fundament-oss/fundament/common/dbconst"
+)
+
+func TestNamespaceRoleRules_CoverEveryRole(t *testing.T) {
+	t.Parallel()
+	for _, role := range []dbconst.NamespaceRoleBindingRole{
+		dbconst.NamespaceRoleBindingRole_Deploy,
+		dbconst.NamespaceRoleBindingRole_ViewPods,
+		dbconst.NamespaceRoleBindingRole_ViewLogs,
+		dbconst.NamespaceRoleBindingRole_ManageServices,
+	} {
+		require.NotEmpty(t, namespaceRoleRules[role], "no rules for role %s", role)
+	}
+}
+
+// Each granted role becomes one Role; each user/role pair one RoleBinding to
+// the user's ServiceAccount in fundament-system.
+func TestReconcileNamespaceRoles_CreatesRolesAndBindings(t *testing.T) {
+	t.Parallel()
+	h, mock := newTestHandler(t)
+	row := testRow("staging-api")
+	name := clusterName(row)
+	alice, bob := uuid.New(), uuid.New()
+
+	require.NoError(t, h.reconcileNamespaceRoles(context.Background(), row, []db.NamespaceRoleGrantsForSyncRow{
+		{UserID: alice, Role: "deploy"},
+		{UserID: alice, Role: "view_logs"},
+		{UserID: bob, Role: "deploy"},
+	}))
+
+	role := mock.GetRole(row.ClusterID, name, "fundament-deploy")
+	require.NotNil(t, role)
+	require.Equal(t, namespaceRoleRules[dbconst.NamespaceRoleBindingRole_Deploy], role.Rules)
+	require.Equal(t, "deploy", role.Labels[shoot.LabelNamespaceRole])
+	require.Equal(t, row.ID.String(), role.Labels[LabelNamespaceID])
+	require.NotNil(t, mock.GetRole(row.ClusterID, name, "fundament-view-logs"))
+	require.Nil(t, mock.GetRole(row.ClusterID, name, "fundament-view-pods"))
+
+	rb := mock.GetRoleBinding(row.ClusterID, name, shoot.NamespaceRoleBindingName("deploy", bob))
+	require.NotNil(t, rb)
+	require.Equal(t, "fundament-deploy", rb.RoleRef.Name)
+	require.Len(t, rb.Subjects, 1)
+	require.Equal(t, shoot.SAName(bob), rb.Subjects[0].Name)
+	require.Equal(t, shoot.FundamentNamespace, rb.Subjects[0].Namespace)
+	require.Equal(t, bob.String(), rb.Labels[shoot.LabelUserID])
+	require.NotNil(t, mock.GetRoleBinding(row.ClusterID, name, shoot.NamespaceRoleBindingName("deploy", alice)))
+	require.NotNil(t, mock.GetRoleBinding(row.ClusterID, name, shoot.NamespaceRoleBindingName("view_logs", alice)))
+}
+
+// Grants that are gone remove their managed RoleBindings and Roles, but
+// unmanaged ones in the same namespace are left alone.
+func TestReconcileNamespaceRoles_RemovesRevokedGrants(t *testing.T) {
+	t.Parallel()
+	h, mock := newTestHandler(t)
+	row := testRow("staging-api")
+	name := clusterName(row)
+	alice := uuid.New()
+	ctx := context.Background()
+
+	require.NoError(t, h.reconcileNamespaceRoles(ctx, row, []db.NamespaceRoleGrantsForSyncRow{
+		{UserID: alice, Role: "deploy"},
+		{UserID: alice, Role: "view_pods"},
+	}))
+	require.NoError(t, mock.EnsureRoleBinding(ctx, row.ClusterID, name, "operator-binding", "operator",
+		"kube-system", "operator", map[string]string{"team": "ops"}))
+
+	require.NoError(t, h.reconcileNamespaceRoles(ctx, row, []db.NamespaceRoleGrantsForSyncRow{
+		{UserID: alice, Role: "view_pods"},
+	}))
+
+	require.Nil(t, mock.GetRole(row.ClusterID, name, "fundament-deploy"))
+	require.Nil(t, mock.GetRoleBinding(row.ClusterID, name, shoot.NamespaceRoleBindingName("deploy", alice)))
+	require.NotNil(t, mock.GetRole(row.ClusterID, name, "fundament-view-pods"))
+	require.NotNil(t, mock.GetRoleBinding(row.ClusterID, name, shoot.NamespaceRoleBindingName("view_pods", alice)))
+	require.NotNil(t, mock.GetRoleBinding(row.ClusterID, name, "operator-binding"))
+}
+
+func TestReconcileNamespaceRoles_UnknownRoleFails(t *testing.T) {
+	t.Parallel()
+	h, mock := newTestHandler(t)
+	row := testRow("staging-api")
+
+	err := h.reconcileNamespaceRoles(context.Background(), row, []db.NamespaceRoleGrantsForSyncRow{
+		{UserID: uuid.New(), Role: "cluster_admin"},
+	})
+	require.ErrorContains(t, err, "unknown namespace role")
+	require.Empty(t, mock.Roles[row.ClusterID])
+}
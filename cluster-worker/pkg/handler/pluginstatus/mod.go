@@ -0,0 +1,111 @@
+// Package pluginstatus records the phase of the PluginInstallations on every
+// ready cluster in tenant.cluster_plugin_status, where phase changes are turned
+// into plugin webhook events.
+package pluginstatus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/fundament-oss/fundament/cluster-worker/pkg/client/shoot"
+	db "github.com/fundament-oss/fundament/cluster-worker/pkg/db/gen"
+)
+
+// Config holds configuration for the plugin status handler.
+type Config struct {
+	// Interval between polls. Every poll requests an admin kubeconfig per
+	// ready cluster, so this is kept coarser than the status loop interval.
+	Interval time.Duration `env:"INTERVAL" envDefault:"1m"`
+}
+
+// Handler polls PluginInstallation status on ready clusters.
+type Handler struct {
+	queries *db.Queries
+	shoot   shoot.ShootAccess
+	logger  *slog.Logger
+	cfg     Config
+
+	now      func() time.Time
+	lastPoll time.Time
+}
+
+func New(pool *pgxpool.Pool, shootAccess shoot.ShootAccess, logger *slog.Logger, cfg Config) *Handler {
+	return &Handler{
+		queries: db.New(pool),
+		shoot:   shootAccess,
+		logger:  logger.With("handler", "pluginstatus"),
+		cfg:     cfg,
+		now:     time.Now,
+	}
+}
+
+// CheckStatus refreshes the recorded plugin phases of all ready clusters, at
+// most once per configured interval. A cluster that cannot be reached keeps
+// its last recorded phases.
+func (h *Handler) CheckStatus(ctx context.Context) error {
+	now := h.now()
+	if !h.lastPoll.IsZero() && now.Sub(h.lastPoll) < h.cfg.Interval {
+		return nil
+	}
+	h.lastPoll = now
+
+	clusterIDs, err := h.queries.ClusterListReady(ctx)
+	if err != nil {
+		return fmt.Errorf("list ready clusters: %w", err)
+	}
+
+	var errs []error
+	for _, clusterID := range clusterIDs {
+		if ctx.Err() != nil {
+			return nil //nolint:nilerr // graceful shutdown
+		}
+		if err := h.syncCluster(ctx, clusterID); err != nil {
+			h.logger.Warn("failed to check plugin status", "cluster_id", clusterID, "error", err)
+			errs = append(errs, fmt.Errorf("cluster %s: %w", clusterID, err))
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		return fmt.Errorf("check plugin status: %w", err)
+	}
+	return nil
+}
+
+func (h *Handler) syncCluster(ctx context.Context, clusterID uuid.UUID) error {
+	installations, err := h.shoot.ListPluginInstallations(ctx, clusterID)
+	if err != nil {
+		return err
+	}
+
+	names := make([]string, 0, len(installations))
+	for _, pi := range installations {
+		names = append(names, pi.Name)
+		// A freshly created installation has no phase until the controller
+		// picks it up; record it once it has one.
+		if pi.Phase == "" {
+			continue
+		}
+		if err := h.queries.ClusterPluginStatusUpsert(ctx, db.ClusterPluginStatusUpsertParams{
+			ClusterID: clusterID,
+			Name:      pi.Name,
+			Phase:     pi.Phase,
+			Message:   pgtype.Text{String: pi.Message, Valid: pi.Message != ""},
+		}); err != nil {
+			return fmt.Errorf("record plugin installation %s: %w", pi.Name, err)
+		}
+	}
+
+	if err := h.queries.ClusterPluginStatusDeleteMissing(ctx, db.ClusterPluginStatusDeleteMissingParams{
+		ClusterID: clusterID,
+		Names:     names,
+	}); err != nil {
+		return fmt.Errorf("delete removed plugin installations: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,141 @@
+package pluginstatus
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/fundament-oss/fundament/cluster-worker/pkg/client/shoot"
+	db "github.com/fundament-oss/fundament/cluster-worker/pkg/db/gen"
+)
+
+// fakeDBTX answers ClusterListReady with clusters and records executed statements.
+type fakeDBTX struct {
+	clusters []uuid.UUID
+	execs    []execCall
+}
+
+type execCall struct {
+	sql  string
+	args []any
+}
+
+func (f *fakeDBTX) Exec(_ context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	f.execs = append(f.execs, execCall{sql: sql, args: args})
+	return pgconn.CommandTag{}, nil
+}
+
+func (f *fakeDBTX) Query(_ context.Context, _ string, _ ...any) (pgx.Rows, error) {
+	return &uuidRows{ids: f.clusters, pos: -1}, nil
+}
+
+func (f *fakeDBTX) QueryRow(_ context.Context, _ string, _ ...any) pgx.Row {
+	panic("QueryRow should not be called")
+}
+
+func (f *fakeDBTX) count(query string) int {
+	n := 0
+	for _, e := range f.execs {
+		if strings.Contains(e.sql, query) {
+			n++
+		}
+	}
+	return n
+}
+
+type uuidRows struct {
+	pgx.Rows
+	ids []uuid.UUID
+	pos int
+}
+
+func (r *uuidRows) Next() bool             { r.pos++; return r.pos < len(r.ids) }
+func (r *uuidRows) Scan(dest ...any) error { *dest[0].(*uuid.UUID) = r.ids[r.pos]; return nil }
+func (r *uuidRows) Close()                 {}
+func (r *uuidRows) Err() error             { return nil }
+
+func newTestHandler(t *testing.T, clusters ...uuid.UUID) (*Handler, *fakeDBTX, *shoot.MockShootAccess) {
+	t.Helper()
+	logger := slog.New(slog.DiscardHandler)
+	fake := &fakeDBTX{clusters: clusters}
+	mock := shoot.NewMockShootAccess(logger)
+	h := &Handler{
+		queries: db.New(fake),
+		shoot:   mock,
+		logger:  logger,
+		cfg:     Config{Interval: time.Minute},
+		now:     time.Now,
+	}
+	return h, fake, mock
+}
+
+func TestCheckStatus_RecordsPhases(t *testing.T) {
+	clusterID := uuid.New()
+	h, fake, mock := newTestHandler(t, clusterID)
+	mock.PluginInstallations[clusterID] = []shoot.PluginInstallationStatus{
+		{Name: "cert-manager", Phase: "Running"},
+		{Name: "grafana", Phase: "Degraded", Message: "1/2 replicas ready"},
+		{Name: "new", Phase: ""},
+	}
+
+	if err := h.CheckStatus(t.Context()); err != nil {
+		t.Fatalf("CheckStatus() error = %v", err)
+	}
+
+	if got := fake.count("ClusterPluginStatusUpsert"); got != 2 {
+		t.Errorf("upserts = %d, want 2 (installations without a phase are skipped)", got)
+	}
+	if got := fake.count("ClusterPluginStatusDeleteMissing"); got != 1 {
+		t.Fatalf("deletes = %d, want 1", got)
+	}
+	last := fake.execs[len(fake.execs)-1]
+	names, _ := last.args[1].([]string)
+	if !slices.Equal(names, []string{"cert-manager", "grafana", "new"}) {
+		t.Errorf("kept names = %v", names)
+	}
+}
+
+func TestCheckStatus_Throttled(t *testing.T) {
+	h, fake, _ := newTestHandler(t, uuid.New())
+	now := time.Now()
+	h.now = func() time.Time { return now }
+
+	if err := h.CheckStatus(t.Context()); err != nil {
+		t.Fatal(err)
+	}
+	now = now.Add(30 * time.Second)
+	if err := h.CheckStatus(t.Context()); err != nil {
+		t.Fatal(err)
+	}
+	if got := fake.count("ClusterPluginStatusDeleteMissing"); got != 1 {
+		t.Errorf("polls within interval = %d, want 1", got)
+	}
+
+	now = now.Add(time.Minute)
+	if err := h.CheckStatus(t.Context()); err != nil {
+		t.Fatal(err)
+	}
+	if got := fake.count("ClusterPluginStatusDeleteMissing"); got != 2 {
+		t.Errorf("polls after interval = %d, want 2", got)
+	}
+}
+
+func TestCheckStatus_UnreachableClusterKeepsState(t *testing.T) {
+	h, fake, mock := newTestHandler(t, uuid.New())
+	mock.ListPluginInstallationsError = errors.New("connection refused")
+
+	if err := h.CheckStatus(t.Context()); err == nil {
+		t.Fatal("expected error")
+	}
+	if len(fake.execs) != 0 {
+		t.Errorf("expected no writes for an unreachable cluster, got %d", len(fake.execs))
+	}
+}
@@ -0,0 +1,76 @@
+package usersync
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	db "github.com/fundament-oss/fundament/cluster-worker/pkg/db/gen"
+	"github.com/fundament-oss/fundament/common/dbconst"
+)
+
+// syncAccessElevation handles an access elevation being granted or ending:
+// re-resolve the user's access on every ready cluster the elevation reaches.
+// Non-ready clusters pick the elevation up when they become ready.
+func (h *Handler) syncAccessElevation(ctx context.Context, elevationID uuid.UUID) error {
+	elevation, err := h.queries.AccessElevationGetForSync(ctx, db.AccessElevationGetForSyncParams{ID: elevationID})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			h.logger.Info("access elevation not found, skipping", "access_elevation_id", elevationID)
+			return nil
+		}
+		return fmt.Errorf("get access elevation: %w", err)
+	}
+
+	clusters, err := h.queries.AccessElevationListReadyClusters(ctx, db.AccessElevationListReadyClustersParams{ID: elevationID})
+	if err != nil {
+		return fmt.Errorf("list ready clusters for access elevation: %w", err)
+	}
+
+	eventType := dbconst.ClusterEventEventType_AccessElevationEnded
+	message := fmt.Sprintf("Elevated %s access %s for user %s", elevation.Role, elevation.Status, elevation.UserID)
+	if dbconst.AccessElevationStatus(elevation.Status) == dbconst.AccessElevationStatus_Approved {
+		eventType = dbconst.ClusterEventEventType_AccessElevationGranted
+		message = fmt.Sprintf("Elevated %s access granted to user %s until %s",
+			elevation.Role, elevation.UserID, elevation.Expires.Time.UTC().Format(time.RFC3339))
+	}
+
+	var errs []error
+	for _, clusterID := range clusters {
+		if err := h.syncUserToCluster(ctx, elevation.UserID, clusterID); err != nil {
+			h.logger.Error("failed to sync access elevation to cluster",
+				"access_elevation_id", elevationID,
+				"user_id", elevation.UserID,
+				"cluster_id", clusterID,
+				"error", err)
+			h.createUserSyncEvent(ctx, clusterID, dbconst.ClusterEventEventType_UserSyncFailed,
+				fmt.Sprintf("Access elevation sync failed for user %s: %s", elevation.UserID, err))
+			errs = append(errs, err)
+		} else {
+			h.createUserSyncEvent(ctx, clusterID, eventType, message)
+		}
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return fmt.Errorf("sync access elevation %s: %w", elevationID, err)
+	}
+	return nil
+}
+
+// CheckStatus marks approved access elevations past their expiry as expired,
+// which queues the removal of the cluster access they granted. The OpenFGA
+// role already ended at the expiry through its condition.
+func (h *Handler) CheckStatus(ctx context.Context) error {
+	expired, err := h.queries.AccessElevationExpire(ctx)
+	if err != nil {
+		return fmt.Errorf("expire access elevations: %w", err)
+	}
+	if expired > 0 {
+		h.logger.Info("expired access elevations", "count", expired)
+	}
+	return nil
+}
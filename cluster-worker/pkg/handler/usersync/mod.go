@@ -46,6 +46,8 @@ func (h *Handler) Sync(ctx context.Context, id uuid.UUID, sc handler.SyncContext
 		return h.syncProjectMember(ctx, id)
 	case handler.EntityCluster:
 		return h.syncClusterReady(ctx, id)
+	case handler.EntityAccessElevation:
+		return h.syncAccessElevation(ctx, id)
 	default:
 		return fmt.Errorf("unexpected entity type %s for user sync handler", sc.EntityType)
 	}
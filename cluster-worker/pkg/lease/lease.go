@@ -0,0 +1,194 @@
+// Package lease coordinates cluster-worker replicas through leases in
+// tenant.cluster_worker_leases. Every replica keeps a heartbeat lease so the
+// others know how many replicas share the outbox shards, and one of them holds
+// the leader lease that lets it run the periodic status and reconcile loops.
+package lease
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"sync/atomic"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	db "github.com/fundament-oss/fundament/cluster-worker/pkg/db/gen"
+)
+
+const (
+	leaderLease        = "leader"
+	replicaLeasePrefix = "replica/"
+
+	// releaseTimeout bounds giving up leases on shutdown, when the worker's
+	// own context is already cancelled.
+	releaseTimeout = 5 * time.Second
+)
+
+// Config holds configuration for the leases.
+type Config struct {
+	// Duration is how long a lease stays valid without renewal: the time
+	// another replica waits before taking over from one that died.
+	Duration time.Duration `env:"DURATION" envDefault:"30s"`
+	// RenewInterval is how often held leases are renewed. Must be well below
+	// Duration so a single slow renewal does not lose them.
+	RenewInterval time.Duration `env:"RENEW_INTERVAL" envDefault:"10s"`
+	// Holder names this replica in the lease table, e.g. its pod name. It
+	// must differ between replicas; the default is the hostname and process
+	// ID.
+	Holder string `env:"HOLDER"`
+}
+
+// Leader reports whether this replica currently holds the leader lease.
+type Leader interface {
+	IsLeader() bool
+}
+
+// Leases takes, renews and releases the leases of this replica.
+type Leases struct {
+	queries *db.Queries
+	holder  string
+	logger  *slog.Logger
+	cfg     Config
+
+	leader   atomic.Bool
+	replicas atomic.Int32
+	ready    atomic.Bool
+}
+
+func New(pool *pgxpool.Pool, logger *slog.Logger, cfg Config) *Leases {
+	holder := cfg.Holder
+	if holder == "" {
+		hostname, _ := os.Hostname()
+		holder = fmt.Sprintf("%s-%d", hostname, os.Getpid())
+	}
+
+	l := &Leases{
+		queries: db.New(pool),
+		holder:  holder,
+		logger:  logger.With("holder", holder, "worker", "lease"),
+		cfg:     cfg,
+	}
+	l.replicas.Store(1)
+	return l
+}
+
+// Holder returns the name this replica holds its leases under.
+func (l *Leases) Holder() string {
+	return l.holder
+}
+
+// RenewInterval returns how often held leases must be renewed.
+func (l *Leases) RenewInterval() time.Duration {
+	return l.cfg.RenewInterval
+}
+
+// IsLeader returns true while this replica holds the leader lease.
+func (l *Leases) IsLeader() bool {
+	return l.leader.Load()
+}
+
+// Replicas returns the number of live replicas seen at the last heartbeat,
+// this one included.
+func (l *Leases) Replicas() int {
+	return int(l.replicas.Load())
+}
+
+// IsReady returns true once the first heartbeat has been written.
+func (l *Leases) IsReady() bool {
+	return l.ready.Load()
+}
+
+// Acquire takes the named lease or extends it if this replica already holds
+// it. It returns false when another replica holds an unexpired lease.
+func (l *Leases) Acquire(ctx context.Context, name string) (bool, error) {
+	_, err := l.queries.LeaseAcquire(ctx, db.LeaseAcquireParams{
+		Name:     name,
+		Holder:   l.holder,
+		Duration: pgtype.Interval{Microseconds: l.cfg.Duration.Microseconds(), Valid: true},
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("acquire lease %s: %w", name, err)
+	}
+	return true, nil
+}
+
+// Release gives up the named leases if this replica holds them. It also runs
+// when ctx is already cancelled, so leases are handed over on shutdown.
+func (l *Leases) Release(ctx context.Context, names ...string) error {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), releaseTimeout)
+	defer cancel()
+
+	if err := l.queries.LeaseRelease(ctx, db.LeaseReleaseParams{
+		Names:  names,
+		Holder: l.holder,
+	}); err != nil {
+		return fmt.Errorf("release leases: %w", err)
+	}
+	return nil
+}
+
+// Run renews the heartbeat and leader leases until ctx is cancelled, then
+// releases them so the other replicas take over without waiting for expiry.
+func (l *Leases) Run(ctx context.Context) error {
+	l.logger.Info("starting lease loop", "duration", l.cfg.Duration, "renew_interval", l.cfg.RenewInterval)
+
+	ticker := time.NewTicker(l.cfg.RenewInterval)
+	defer ticker.Stop()
+
+	for {
+		l.Renew(ctx)
+
+		select {
+		case <-ctx.Done():
+			l.leader.Store(false)
+			if err := l.Release(ctx, replicaLeasePrefix+l.holder, leaderLease); err != nil {
+				l.logger.Warn("failed to release leases on shutdown", "error", err)
+			}
+			return fmt.Errorf("lease loop stopped: %w", ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+// Renew writes the heartbeat, counts the live replicas and tries to take or
+// keep the leader lease. When the database cannot be reached this replica
+// steps down: it cannot tell whether another one has taken over.
+func (l *Leases) Renew(ctx context.Context) {
+	if ctx.Err() != nil {
+		return
+	}
+
+	if _, err := l.Acquire(ctx, replicaLeasePrefix+l.holder); err != nil {
+		l.logger.Error("failed to renew heartbeat", "error", err)
+		l.setLeader(false)
+		return
+	}
+	l.ready.Store(true)
+
+	replicas, err := l.queries.LeaseCountLive(ctx, db.LeaseCountLiveParams{Prefix: replicaLeasePrefix})
+	if err != nil {
+		l.logger.Error("failed to count replicas", "error", err)
+	} else {
+		l.replicas.Store(max(replicas, 1))
+	}
+
+	leader, err := l.Acquire(ctx, leaderLease)
+	if err != nil {
+		l.logger.Error("failed to renew leader lease", "error", err)
+	}
+	l.setLeader(leader)
+}
+
+func (l *Leases) setLeader(leader bool) {
+	if l.leader.Swap(leader) != leader {
+		l.logger.Info("leadership changed", "leader", leader, "replicas", l.Replicas())
+	}
+}
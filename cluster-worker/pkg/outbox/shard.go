@@ -0,0 +1,222 @@
+package outbox
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// shard is one slice of the outbox, processed by its own goroutine while this
+// replica holds the shard's lease. Rows are claimed one at a time, so two rows
+// of the same cluster (which always share a shard) never run concurrently.
+type shard struct {
+	id     int32
+	lease  string
+	logger *slog.Logger
+
+	// wake is signalled when new rows may be available; stop is closed when
+	// the shard is handed over.
+	wake chan struct{}
+	stop chan struct{}
+
+	// stopping is set once stop is closed. Guarded by Worker.mu.
+	stopping bool
+
+	// lastOrganization is the organization served last; the next claim
+	// starts after it so organizations take turns. Only the shard's own
+	// goroutine touches it.
+	lastOrganization uuid.UUID
+}
+
+func shardLease(id int32) string {
+	return fmt.Sprintf("outbox-shard/%d", id)
+}
+
+// balance keeps this replica's share of the shards until ctx is cancelled,
+// then waits for the shard goroutines to hand their shards back.
+func (w *Worker) balance(ctx context.Context) error {
+	w.logger.Info("starting shard balancer", "shards", w.cfg.Shards)
+
+	ticker := time.NewTicker(w.leases.RenewInterval())
+	defer ticker.Stop()
+
+	for {
+		w.rebalance(ctx)
+
+		select {
+		case <-ctx.Done():
+			w.wg.Wait()
+			return fmt.Errorf("shard balancer stopped: %w", ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+// rebalance renews the leases of the shards held, gives up shards above this
+// replica's fair share and takes free ones up to it. A replica that joins
+// starts without shards; the others shed theirs over the next renewals until
+// every replica holds its share.
+func (w *Worker) rebalance(ctx context.Context) {
+	// Until the first heartbeat the replica count is unknown; taking every
+	// shard only to give most of them back would churn the leases.
+	if ctx.Err() != nil || !w.leases.IsReady() {
+		return
+	}
+
+	target := fairShare(w.cfg.Shards, w.leases.Replicas())
+
+	w.mu.Lock()
+	held := make([]*shard, 0, len(w.shards))
+	for _, s := range w.shards {
+		held = append(held, s)
+	}
+	w.mu.Unlock()
+	slices.SortFunc(held, func(a, b *shard) int { return int(a.id - b.id) })
+
+	// Renew every lease held, including those of shards still finishing a
+	// row after being stopped. A lease another replica took over after it
+	// expired stops the shard.
+	active := make([]*shard, 0, len(held))
+	for _, s := range held {
+		ok, err := w.leases.Acquire(ctx, s.lease)
+		if err != nil {
+			s.logger.Error("failed to renew shard lease", "error", err)
+		} else if !ok {
+			s.logger.Warn("shard lease lost to another replica")
+			w.stopShard(s)
+			continue
+		}
+		if !w.isStopping(s) {
+			active = append(active, s)
+		}
+	}
+
+	// Shed the highest shards above the fair share.
+	for len(active) > target {
+		s := active[len(active)-1]
+		active = active[:len(active)-1]
+		s.logger.Info("handing over shard", "target", target)
+		w.stopShard(s)
+	}
+
+	// Take free shards up to the fair share. Each replica starts looking at
+	// its own offset so they do not all compete for the same shards.
+	offset := shardOffset(w.leases.Holder(), w.cfg.Shards)
+	for i := range w.cfg.Shards {
+		if len(active) >= target {
+			return
+		}
+		id := (offset + i) % w.cfg.Shards
+		if w.holds(id) {
+			continue
+		}
+		ok, err := w.leases.Acquire(ctx, shardLease(id))
+		if err != nil {
+			w.logger.Error("failed to acquire shard lease", "shard", id, "error", err)
+			return
+		}
+		if ok {
+			active = append(active, w.startShard(ctx, id))
+		}
+	}
+}
+
+// startShard starts processing a shard whose lease was just acquired.
+func (w *Worker) startShard(ctx context.Context, id int32) *shard {
+	s := &shard{
+		id:     id,
+		lease:  shardLease(id),
+		logger: w.logger.With("shard", id),
+		wake:   make(chan struct{}, 1),
+		stop:   make(chan struct{}),
+	}
+
+	w.mu.Lock()
+	w.shards[id] = s
+	w.mu.Unlock()
+
+	s.logger.Info("processing shard")
+	w.wg.Go(func() { w.runShard(ctx, s) })
+	return s
+}
+
+// runShard drains the shard whenever it is woken, until it is stopped or ctx
+// is cancelled. On the way out it releases the lease so another replica can
+// take the shard over immediately.
+func (w *Worker) runShard(ctx context.Context, s *shard) {
+	defer func() {
+		if err := w.leases.Release(ctx, s.lease); err != nil {
+			s.logger.Warn("failed to release shard lease", "error", err)
+		}
+		w.mu.Lock()
+		delete(w.shards, s.id)
+		w.mu.Unlock()
+		s.logger.Info("stopped processing shard")
+	}()
+
+	for {
+		w.processAllRows(ctx, s)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.stop:
+			return
+		case <-s.wake:
+		}
+	}
+}
+
+// stopShard asks a shard to stop after the row it is processing.
+func (w *Worker) stopShard(s *shard) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if !s.stopping {
+		s.stopping = true
+		close(s.stop)
+	}
+}
+
+func (w *Worker) isStopping(s *shard) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return s.stopping
+}
+
+func (w *Worker) holds(id int32) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	_, ok := w.shards[id]
+	return ok
+}
+
+// wakeShards tells every shard held that new rows may be available.
+func (w *Worker) wakeShards() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for _, s := range w.shards {
+		select {
+		case s.wake <- struct{}{}:
+		default: // already signalled
+		}
+	}
+}
+
+// fairShare is the number of shards each replica should hold: all shards
+// divided over the replicas, rounded up so every shard has an owner.
+func fairShare(shards int32, replicas int) int {
+	replicas = max(replicas, 1)
+	return (int(shards) + replicas - 1) / replicas
+}
+
+// shardOffset spreads the replicas' first pick over the shards.
+func shardOffset(holder string, shards int32) int32 {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(holder))
+	return int32(h.Sum32() % uint32(shards)) //nolint:gosec // shards is positive and small
+}
@@ -0,0 +1,35 @@
+package outbox
+
+import "testing"
+
+func TestFairShare(t *testing.T) {
+	tests := []struct {
+		shards   int32
+		replicas int
+		want     int
+	}{
+		{shards: 16, replicas: 1, want: 16},
+		{shards: 16, replicas: 2, want: 8},
+		{shards: 16, replicas: 3, want: 6},
+		{shards: 16, replicas: 32, want: 1},
+		{shards: 16, replicas: 0, want: 16},
+	}
+
+	for _, tt := range tests {
+		if got := fairShare(tt.shards, tt.replicas); got != tt.want {
+			t.Errorf("fairShare(%d, %d) = %d, want %d", tt.shards, tt.replicas, got, tt.want)
+		}
+	}
+}
+
+func TestShardOffset(t *testing.T) {
+	for _, holder := range []string{"cluster-worker-0-1", "cluster-worker-1-1", ""} {
+		got := shardOffset(holder, 16)
+		if got < 0 || got >= 16 {
+			t.Errorf("shardOffset(%q, 16) = %d, want within [0, 16)", holder, got)
+		}
+		if again := shardOffset(holder, 16); again != got {
+			t.Errorf("shardOffset(%q, 16) not stable: %d then %d", holder, got, again)
+		}
+	}
+}
@@ -1,5 +1,7 @@
 // Package outbox implements the cluster outbox worker.
 // It processes outbox rows and dispatches to entity-specific sync handlers.
+// The outbox is split into shards that replicas divide between them through
+// leases; each shard held is processed by its own goroutine.
 package outbox
 
 import (
@@ -7,7 +9,7 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
-	"os"
+	"sync"
 	"sync/atomic"
 	"time"
 
@@ -15,9 +17,11 @@ import (
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"golang.org/x/sync/errgroup"
 
 	db "github.com/fundament-oss/fundament/cluster-worker/pkg/db/gen"
 	"github.com/fundament-oss/fundament/cluster-worker/pkg/handler"
+	"github.com/fundament-oss/fundament/cluster-worker/pkg/lease"
 	"github.com/fundament-oss/fundament/common/dbconst"
 	"github.com/fundament-oss/fundament/common/rollback"
 )
@@ -35,6 +39,10 @@ type Config struct {
 	BackoffDelay             time.Duration `env:"BACKOFF_DELAY" envDefault:"5s"`
 	PreconditionDelay        time.Duration `env:"PRECONDITION_DELAY" envDefault:"30s"`
 	MaxPreconditionDeferrals int32         `env:"MAX_PRECONDITION_DEFERRALS" envDefault:"100"`
+	// Shards is the number of slices the outbox is split into; it bounds how
+	// many rows are processed at once across all replicas. Every replica must
+	// use the same value.
+	Shards int32 `env:"SHARDS" envDefault:"16"`
 }
 
 // Worker processes the cluster outbox table and dispatches to handlers.
@@ -42,22 +50,28 @@ type Worker struct {
 	pool     *pgxpool.Pool
 	queries  *db.Queries
 	registry *handler.Registry
+	leases   *lease.Leases
 	logger   *slog.Logger
 	cfg      Config
 
 	ready atomic.Bool
-}
 
-func New(pool *pgxpool.Pool, registry *handler.Registry, logger *slog.Logger, cfg Config) *Worker {
-	hostname, _ := os.Hostname()
-	workerID := fmt.Sprintf("%s-%d", hostname, os.Getpid())
+	// mu guards shards, the shards this replica holds (including ones that
+	// are stopping). wg tracks their goroutines.
+	mu     sync.Mutex
+	shards map[int32]*shard
+	wg     sync.WaitGroup
+}
 
+func New(pool *pgxpool.Pool, registry *handler.Registry, leases *lease.Leases, logger *slog.Logger, cfg Config) *Worker {
 	return &Worker{
 		pool:     pool,
 		queries:  db.New(pool),
 		registry: registry,
-		logger:   logger.With("worker_id", workerID, "worker", "outbox"),
+		leases:   leases,
+		logger:   logger.With("worker_id", leases.Holder(), "worker", "outbox"),
 		cfg:      cfg,
+		shards:   make(map[int32]*shard),
 	}
 }
 
@@ -66,8 +80,21 @@ func (w *Worker) IsReady() bool {
 	return w.ready.Load()
 }
 
-// Run starts the worker with automatic reconnection on LISTEN connection loss.
+// Run starts the worker: the shard balancer, which starts and stops shard
+// goroutines as leases are won and lost, and the LISTEN loop that wakes them.
 func (w *Worker) Run(ctx context.Context) error {
+	g, ctx := errgroup.WithContext(ctx)
+	g.Go(func() error { return w.balance(ctx) })
+	g.Go(func() error { return w.listen(ctx) })
+	if err := g.Wait(); err != nil {
+		return fmt.Errorf("outbox worker: %w", err)
+	}
+	return nil
+}
+
+// listen waits for outbox notifications with automatic reconnection on LISTEN
+// connection loss.
+func (w *Worker) listen(ctx context.Context) error {
 	for {
 		err := w.runWithConnection(ctx)
 		if ctx.Err() != nil {
@@ -97,7 +124,7 @@ func (w *Worker) runWithConnection(ctx context.Context) error {
 	w.ready.Store(true)
 
 	// Process any pending work on startup
-	w.processAllRows(ctx)
+	w.wakeShards()
 
 	for {
 		w.logger.Debug("waiting for notification or poll timeout", "poll_interval", w.cfg.PollInterval)
@@ -111,7 +138,7 @@ func (w *Worker) runWithConnection(ctx context.Context) error {
 		} else {
 			w.logger.Debug("woke up from poll timeout")
 		}
-		w.processAllRows(ctx)
+		w.wakeShards()
 	}
 }
 
@@ -143,15 +170,22 @@ func (w *Worker) waitForNotification(ctx context.Context, conn *pgxpool.Conn) (b
 	}
 }
 
-// processAllRows drains all processable outbox rows in a loop.
+// processAllRows drains all processable rows of a shard in a loop, stopping
+// early when the shard is handed over.
 // Row-level errors (handler failures, invalid rows) are handled inside processNextRow.
 // Infrastructure errors (connection loss, commit failure) cause a backoff to avoid a
 // tight retry loop.
-func (w *Worker) processAllRows(ctx context.Context) {
+func (w *Worker) processAllRows(ctx context.Context, s *shard) {
 	for {
-		hasNext, err := w.processNextRow(ctx)
+		select {
+		case <-s.stop:
+			return
+		default:
+		}
+
+		hasNext, err := w.processNextRow(ctx, s)
 		if err != nil {
-			w.logger.Error("failed to process outbox item", "error", err)
+			s.logger.Error("failed to process outbox item", "error", err)
 			select {
 			case <-ctx.Done():
 			case <-time.After(w.cfg.BackoffDelay):
@@ -165,12 +199,12 @@ func (w *Worker) processAllRows(ctx context.Context) {
 }
 
 // processNextRow is a thin orchestrator: claim → process → complete.
-func (w *Worker) processNextRow(ctx context.Context) (hasNext bool, err error) {
+func (w *Worker) processNextRow(ctx context.Context, s *shard) (hasNext bool, err error) {
 	if ctx.Err() != nil {
 		return false, nil
 	}
 
-	row, tx, err := w.claim(ctx)
+	row, tx, err := w.claim(ctx, s)
 	if err != nil {
 		return false, err
 	}
@@ -181,28 +215,37 @@ func (w *Worker) processNextRow(ctx context.Context) (hasNext bool, err error) {
 
 	entityType, processErr := w.process(ctx, row)
 
-	return w.complete(ctx, row, tx, entityType, processErr)
+	return w.complete(ctx, s, row, tx, entityType, processErr)
 }
 
-// claim begins a transaction and locks the next pending outbox row.
+// claim begins a transaction and locks the next pending row of the shard.
 // Returns nil row if no rows are available.
-func (w *Worker) claim(ctx context.Context) (*db.OutboxGetAndLockRow, pgx.Tx, error) {
+func (w *Worker) claim(ctx context.Context, s *shard) (*db.OutboxGetAndLockRow, pgx.Tx, error) {
 	tx, err := w.pool.Begin(ctx)
 	if err != nil {
 		return nil, nil, fmt.Errorf("begin transaction: %w", err)
 	}
 
 	qtx := w.queries.WithTx(tx)
-	row, err := qtx.OutboxGetAndLock(ctx)
+	row, err := qtx.OutboxGetAndLock(ctx, db.OutboxGetAndLockParams{
+		ShardCount:          w.cfg.Shards,
+		Shard:               s.id,
+		LeaseName:           s.lease,
+		Holder:              w.leases.Holder(),
+		AfterOrganizationID: s.lastOrganization,
+	})
 	if err != nil {
 		_ = tx.Rollback(ctx)
 		if errors.Is(err, pgx.ErrNoRows) {
-			w.logger.Debug("no pending outbox rows")
+			s.logger.Debug("no pending outbox rows")
 			return nil, nil, nil
 		}
 		return nil, nil, fmt.Errorf("get next outbox row: %w", err)
 	}
 
+	if row.OrganizationID.Valid {
+		s.lastOrganization = row.OrganizationID.Bytes
+	}
 	return &row, tx, nil
 }
 
@@ -266,7 +309,9 @@ func (w *Worker) process(ctx context.Context, row *db.OutboxGetAndLockRow) (hand
 // On success: mark processed + commit inside the same tx.
 // On PreconditionError: rollback tx, defer without retry increment.
 // On other error: rollback tx (releases lock), then mark retry/failed via pool.
-func (w *Worker) complete(ctx context.Context, row *db.OutboxGetAndLockRow, tx pgx.Tx, entityType handler.EntityType, processErr error) (bool, error) {
+// Every outcome is only recorded while the replica still holds the shard's
+// lease; once another replica took the shard over, the row is left for it.
+func (w *Worker) complete(ctx context.Context, s *shard, row *db.OutboxGetAndLockRow, tx pgx.Tx, entityType handler.EntityType, processErr error) (bool, error) {
 	if processErr != nil {
 		// Non-retryable errors (invalid row, missing handler) fail immediately.
 		if errors.Is(processErr, errNonRetryable) {
@@ -275,20 +320,25 @@ func (w *Worker) complete(ctx context.Context, row *db.OutboxGetAndLockRow, tx p
 				"entity_type", entityType,
 				"error", processErr)
 			_ = tx.Rollback(ctx)
-			markErr := w.queries.OutboxMarkFailed(ctx, db.OutboxMarkFailedParams{
+			marked, markErr := w.queries.OutboxMarkFailed(ctx, db.OutboxMarkFailedParams{
 				ID:         row.ID,
 				StatusInfo: pgtype.Text{String: processErr.Error(), Valid: true},
+				LeaseName:  s.lease,
+				Holder:     w.leases.Holder(),
 			})
 			if markErr != nil {
 				return false, fmt.Errorf("mark failed for non-retryable error: %w", markErr)
 			}
+			if marked == 0 {
+				return w.leaseLost(s, row)
+			}
 			return true, nil
 		}
 
 		// Check if precondition is not present yet
 		precondErr, isPrecond := errors.AsType[*handler.PreconditionError](processErr)
 		if isPrecond {
-			return w.handlePreconditionError(ctx, row, tx, entityType, precondErr)
+			return w.handlePreconditionError(ctx, s, row, tx, entityType, precondErr)
 		}
 
 		w.logger.Warn("handler returned error",
@@ -296,18 +346,27 @@ func (w *Worker) complete(ctx context.Context, row *db.OutboxGetAndLockRow, tx p
 			"entity_type", entityType,
 			"error", processErr)
 		_ = tx.Rollback(ctx) // release lock before marking via pool
-		markErr := w.handleRowError(ctx, w.queries, row, processErr)
+		hasNext, markErr := w.handleRowError(ctx, w.queries, s, row, processErr)
 		if markErr != nil {
 			return false, fmt.Errorf("handle processing error: %w", markErr)
 		}
-		return true, nil
+		return hasNext, nil
 	}
 
 	// Happy path: mark processed and commit in the same transaction.
 	qtx := w.queries.WithTx(tx)
-	if err := qtx.OutboxMarkProcessed(ctx, db.OutboxMarkProcessedParams{ID: row.ID}); err != nil {
+	marked, err := qtx.OutboxMarkProcessed(ctx, db.OutboxMarkProcessedParams{
+		ID:        row.ID,
+		LeaseName: s.lease,
+		Holder:    w.leases.Holder(),
+	})
+	if err != nil {
 		return false, fmt.Errorf("mark as processed: %w", err)
 	}
+	if marked == 0 {
+		_ = tx.Rollback(ctx)
+		return w.leaseLost(s, row)
+	}
 
 	if err := tx.Commit(ctx); err != nil {
 		return false, fmt.Errorf("commit: %w", err)
@@ -326,7 +385,19 @@ func (w *Worker) complete(ctx context.Context, row *db.OutboxGetAndLockRow, tx p
 	return true, nil
 }
 
-func (w *Worker) handleRowError(ctx context.Context, qtx *db.Queries, row *db.OutboxGetAndLockRow, processErr error) error {
+// leaseLost stops the shard's loop after a result could not be recorded
+// because the shard's lease moved to another replica, which processes the row
+// again. Handlers are idempotent, so the repeated sync is harmless.
+func (w *Worker) leaseLost(s *shard, row *db.OutboxGetAndLockRow) (bool, error) {
+	s.logger.Warn("shard lease lost while processing, leaving the row to the new holder",
+		"outbox_id", row.ID)
+	return false, nil
+}
+
+// handleRowError marks a failed row for retry, or failed once it ran out of
+// retries. It reports whether the shard's loop should go on; it stops when
+// the shard's lease was lost.
+func (w *Worker) handleRowError(ctx context.Context, qtx *db.Queries, s *shard, row *db.OutboxGetAndLockRow, processErr error) (bool, error) {
 	statusInfo := pgtype.Text{String: processErr.Error(), Valid: true}
 
 	// Check if we've exceeded max retries. row.Retries is the current count
@@ -338,13 +409,19 @@ func (w *Worker) handleRowError(ctx context.Context, qtx *db.Queries, row *db.Ou
 			"max_retries", w.cfg.MaxRetries,
 			"error", processErr)
 
-		if err := qtx.OutboxMarkFailed(ctx, db.OutboxMarkFailedParams{
+		marked, err := qtx.OutboxMarkFailed(ctx, db.OutboxMarkFailedParams{
 			ID:         row.ID,
 			StatusInfo: statusInfo,
-		}); err != nil {
-			return fmt.Errorf("mark outbox failed: %w", err)
+			LeaseName:  s.lease,
+			Holder:     w.leases.Holder(),
+		})
+		if err != nil {
+			return false, fmt.Errorf("mark outbox failed: %w", err)
 		}
-		return nil
+		if marked == 0 {
+			return w.leaseLost(s, row)
+		}
+		return true, nil
 	}
 
 	retries, err := qtx.OutboxMarkRetry(ctx, db.OutboxMarkRetryParams{
@@ -352,9 +429,14 @@ func (w *Worker) handleRowError(ctx context.Context, qtx *db.Queries, row *db.Ou
 		BaseInterval: durationToInterval(w.cfg.BaseBackoff),
 		MaxBackoff:   durationToInterval(w.cfg.MaxBackoff),
 		StatusInfo:   statusInfo,
+		LeaseName:    s.lease,
+		Holder:       w.leases.Holder(),
 	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return w.leaseLost(s, row)
+	}
 	if err != nil {
-		return fmt.Errorf("mark outbox retry: %w", err)
+		return false, fmt.Errorf("mark outbox retry: %w", err)
 	}
 
 	w.logger.Warn("failed to process outbox item, will retry",
@@ -362,7 +444,7 @@ func (w *Worker) handleRowError(ctx context.Context, qtx *db.Queries, row *db.Ou
 		"retries", retries,
 		"error", processErr)
 
-	return nil
+	return true, nil
 }
 
 // entityFromRow determines the entity type and ID from the outbox row's FK columns.
@@ -379,6 +461,8 @@ func entityFromRow(row *db.OutboxGetAndLockRow) (handler.EntityType, uuid.UUID,
 		return handler.EntityNodePool, uuid.UUID(row.NodePoolID.Bytes), nil
 	case row.NamespaceID.Valid:
 		return handler.EntityNamespace, uuid.UUID(row.NamespaceID.Bytes), nil
+	case row.AccessElevationID.Valid:
+		return handler.EntityAccessElevation, uuid.UUID(row.AccessElevationID.Bytes), nil
 	default:
 		return "", uuid.Nil, fmt.Errorf("no valid entity FK in outbox row %s", row.ID)
 	}
@@ -387,7 +471,7 @@ func entityFromRow(row *db.OutboxGetAndLockRow) (handler.EntityType, uuid.UUID,
 // handlePreconditionError defers a row without incrementing retries.
 // If the deferral count exceeds MaxPreconditionDeferrals, it falls through to
 // regular error handling (increments retries, applies backoff/fail logic).
-func (w *Worker) handlePreconditionError(ctx context.Context, row *db.OutboxGetAndLockRow, tx pgx.Tx, entityType handler.EntityType, precondErr *handler.PreconditionError) (bool, error) {
+func (w *Worker) handlePreconditionError(ctx context.Context, s *shard, row *db.OutboxGetAndLockRow, tx pgx.Tx, entityType handler.EntityType, precondErr *handler.PreconditionError) (bool, error) {
 	_ = tx.Rollback(ctx) // release lock before deferring via pool
 
 	// deferrals is checked before the UPDATE increments it, so +1 to reflect the new count.
@@ -399,18 +483,24 @@ func (w *Worker) handlePreconditionError(ctx context.Context, row *db.OutboxGetA
 			"entity_type", entityType,
 			"deferrals", deferrals,
 			"reason", precondErr.Reason)
-		markErr := w.handleRowError(ctx, w.queries, row, precondErr)
+		hasNext, markErr := w.handleRowError(ctx, w.queries, s, row, precondErr)
 		if markErr != nil {
 			return false, fmt.Errorf("handle processing error: %w", markErr)
 		}
-		return true, nil
+		return hasNext, nil
 	}
 
-	if _, err := w.queries.OutboxDeferWithoutRetry(ctx, db.OutboxDeferWithoutRetryParams{
+	_, err := w.queries.OutboxDeferWithoutRetry(ctx, db.OutboxDeferWithoutRetryParams{
 		ID:         row.ID,
 		Delay:      durationToInterval(w.cfg.PreconditionDelay),
 		StatusInfo: pgtype.Text{String: precondErr.Reason, Valid: true},
-	}); err != nil {
+		LeaseName:  s.lease,
+		Holder:     w.leases.Holder(),
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return w.leaseLost(s, row)
+	}
+	if err != nil {
 		return false, fmt.Errorf("defer outbox row: %w", err)
 	}
 
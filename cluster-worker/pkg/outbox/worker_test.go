@@ -15,19 +15,21 @@ import (
 
 	db "github.com/fundament-oss/fundament/cluster-worker/pkg/db/gen"
 	"github.com/fundament-oss/fundament/cluster-worker/pkg/handler"
+	"github.com/fundament-oss/fundament/cluster-worker/pkg/lease"
 )
 
 // mockDBTX implements db.DBTX for testing handleRowError.
 type mockDBTX struct {
 	execCalled     bool
 	queryRowCalled bool
+	execTag        pgconn.CommandTag
 	execErr        error
 	queryRowResult pgx.Row
 }
 
 func (m *mockDBTX) Exec(_ context.Context, _ string, _ ...any) (pgconn.CommandTag, error) {
 	m.execCalled = true
-	return pgconn.CommandTag{}, m.execErr
+	return m.execTag, m.execErr
 }
 
 func (m *mockDBTX) Query(_ context.Context, _ string, _ ...any) (pgx.Rows, error) {
@@ -143,8 +145,29 @@ func TestEntityFromRow_NamespaceID(t *testing.T) {
 	}
 }
 
+func TestEntityFromRow_AccessElevationID(t *testing.T) {
+	id := uuid.New()
+	row := &db.OutboxGetAndLockRow{
+		ID:                uuid.New(),
+		AccessElevationID: pgtype.UUID{Bytes: id, Valid: true},
+	}
+
+	entityType, entityID, err := entityFromRow(row)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if entityType != handler.EntityAccessElevation {
+		t.Errorf("expected EntityAccessElevation, got %q", entityType)
+	}
+	if entityID != id {
+		t.Errorf("expected %s, got %s", id, entityID)
+	}
+}
+
 func newTestWorker() *Worker {
 	return &Worker{
+		leases: lease.New(nil, slog.Default(), lease.Config{Holder: "test"}),
 		logger: slog.Default(),
 		cfg: Config{
 			MaxRetries:               10,
@@ -156,17 +179,24 @@ func newTestWorker() *Worker {
 	}
 }
 
+func testShard() *shard {
+	return &shard{id: 0, lease: shardLease(0), logger: slog.Default()}
+}
+
 func TestHandleProcessingError_RetryBelowMax(t *testing.T) {
 	mock := &mockDBTX{queryRowResult: &mockRow{retries: 6}}
 	w := newTestWorker()
 	qtx := db.New(mock)
 	row := &db.OutboxGetAndLockRow{ID: uuid.New(), Retries: 5}
 
-	err := w.handleRowError(context.Background(), qtx, row, errors.New("sync failed"))
+	hasNext, err := w.handleRowError(context.Background(), qtx, testShard(), row, errors.New("sync failed"))
 
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
+	if !hasNext {
+		t.Error("expected the shard loop to go on")
+	}
 	if !mock.queryRowCalled {
 		t.Error("expected OutboxMarkRetry (QueryRow) to be called")
 	}
@@ -176,16 +206,19 @@ func TestHandleProcessingError_RetryBelowMax(t *testing.T) {
 }
 
 func TestHandleProcessingError_ExceedMaxRetries(t *testing.T) {
-	mock := &mockDBTX{}
+	mock := &mockDBTX{execTag: pgconn.NewCommandTag("UPDATE 1")}
 	w := newTestWorker()
 	qtx := db.New(mock)
 	row := &db.OutboxGetAndLockRow{ID: uuid.New(), Retries: 9}
 
-	err := w.handleRowError(context.Background(), qtx, row, errors.New("sync failed"))
+	hasNext, err := w.handleRowError(context.Background(), qtx, testShard(), row, errors.New("sync failed"))
 
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
+	if !hasNext {
+		t.Error("expected the shard loop to go on")
+	}
 	if !mock.execCalled {
 		t.Error("expected OutboxMarkFailed (Exec) to be called")
 	}
@@ -200,7 +233,7 @@ func TestHandleProcessingError_MarkRetryFails(t *testing.T) {
 	qtx := db.New(mock)
 	row := &db.OutboxGetAndLockRow{ID: uuid.New(), Retries: 5}
 
-	err := w.handleRowError(context.Background(), qtx, row, errors.New("sync failed"))
+	_, err := w.handleRowError(context.Background(), qtx, testShard(), row, errors.New("sync failed"))
 
 	if err == nil {
 		t.Fatal("expected error")
@@ -216,7 +249,7 @@ func TestHandleProcessingError_MarkFailedFails(t *testing.T) {
 	qtx := db.New(mock)
 	row := &db.OutboxGetAndLockRow{ID: uuid.New(), Retries: 9}
 
-	err := w.handleRowError(context.Background(), qtx, row, errors.New("sync failed"))
+	_, err := w.handleRowError(context.Background(), qtx, testShard(), row, errors.New("sync failed"))
 
 	if err == nil {
 		t.Fatal("expected error")
@@ -225,3 +258,35 @@ func TestHandleProcessingError_MarkFailedFails(t *testing.T) {
 		t.Errorf("expected error to contain 'mark outbox failed', got: %v", err)
 	}
 }
+
+func TestHandleProcessingError_RetryLeaseLost(t *testing.T) {
+	mock := &mockDBTX{queryRowResult: &mockRow{err: pgx.ErrNoRows}}
+	w := newTestWorker()
+	qtx := db.New(mock)
+	row := &db.OutboxGetAndLockRow{ID: uuid.New(), Retries: 5}
+
+	hasNext, err := w.handleRowError(context.Background(), qtx, testShard(), row, errors.New("sync failed"))
+
+	if err != nil {
+		t.Fatalf("a lost lease is not an error, got: %v", err)
+	}
+	if hasNext {
+		t.Error("expected the shard loop to stop after losing the lease")
+	}
+}
+
+func TestHandleProcessingError_FailedLeaseLost(t *testing.T) {
+	mock := &mockDBTX{execTag: pgconn.NewCommandTag("UPDATE 0")}
+	w := newTestWorker()
+	qtx := db.New(mock)
+	row := &db.OutboxGetAndLockRow{ID: uuid.New(), Retries: 9}
+
+	hasNext, err := w.handleRowError(context.Background(), qtx, testShard(), row, errors.New("sync failed"))
+
+	if err != nil {
+		t.Fatalf("a lost lease is not an error, got: %v", err)
+	}
+	if hasNext {
+		t.Error("expected the shard loop to stop after losing the lease")
+	}
+}
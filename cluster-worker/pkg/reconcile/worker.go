@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/fundament-oss/fundament/cluster-worker/pkg/handler"
+	"github.com/fundament-oss/fundament/cluster-worker/pkg/lease"
 )
 
 // maxConsecutiveFailures is the number of consecutive failed ticks before the worker exits.
@@ -21,18 +22,21 @@ type Config struct {
 	Interval time.Duration `env:"INTERVAL" envDefault:"5m"`
 }
 
-// Worker periodically calls all registered ReconcileHandlers.
+// Worker periodically calls all registered ReconcileHandlers. Reconciliation
+// deletes orphaned shoots, so with several replicas only the leader runs it.
 type Worker struct {
 	registry         *handler.Registry
+	leader           lease.Leader
 	logger           *slog.Logger
 	cfg              Config
 	ready            atomic.Bool
 	consecutiveFails int
 }
 
-func New(registry *handler.Registry, logger *slog.Logger, cfg Config) *Worker {
+func New(registry *handler.Registry, leader lease.Leader, logger *slog.Logger, cfg Config) *Worker {
 	return &Worker{
 		registry: registry,
+		leader:   leader,
 		logger:   logger.With("worker", "reconcile"),
 		cfg:      cfg,
 	}
@@ -76,6 +80,10 @@ func (w *Worker) reconcileAll(ctx context.Context) error {
 	if ctx.Err() != nil {
 		return nil //nolint:nilerr // graceful shutdown
 	}
+	if !w.leader.IsLeader() {
+		w.logger.Debug("not the leader, skipping reconciliation")
+		return nil
+	}
 
 	w.logger.Info("starting reconciliation")
 
@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/fundament-oss/fundament/cluster-worker/pkg/handler"
+	"github.com/fundament-oss/fundament/cluster-worker/pkg/lease"
 )
 
 // maxConsecutiveFailures is the number of consecutive failed ticks before the worker exits.
@@ -20,18 +21,22 @@ type Config struct {
 	Interval time.Duration `env:"INTERVAL" envDefault:"30s"`
 }
 
-// Worker periodically calls all registered StatusHandlers.
+// Worker periodically calls all registered StatusHandlers. With several
+// replicas only the leader polls; the others skip their ticks until they
+// take the leader lease over.
 type Worker struct {
 	registry         *handler.Registry
+	leader           lease.Leader
 	logger           *slog.Logger
 	cfg              Config
 	ready            atomic.Bool
 	consecutiveFails int
 }
 
-func New(registry *handler.Registry, logger *slog.Logger, cfg Config) *Worker {
+func New(registry *handler.Registry, leader lease.Leader, logger *slog.Logger, cfg Config) *Worker {
 	return &Worker{
 		registry: registry,
+		leader:   leader,
 		logger:   logger.With("worker", "status"),
 		cfg:      cfg,
 	}
@@ -74,6 +79,10 @@ func (w *Worker) runAllHandlers(ctx context.Context) error {
 	if ctx.Err() != nil {
 		return nil //nolint:nilerr // graceful shutdown
 	}
+	if !w.leader.IsLeader() {
+		w.logger.Debug("not the leader, skipping status poll")
+		return nil
+	}
 
 	var errs []error
 	for _, h := range w.registry.StatusHandlers() {
@@ -20,13 +20,18 @@ func (m *mockStatusHandler) CheckStatus(_ context.Context) error {
 	return nil
 }
 
+// isLeader is a fixed answer to whether this replica leads.
+type isLeader bool
+
+func (l isLeader) IsLeader() bool { return bool(l) }
+
 func TestStatusWorker_RunsOnStartup(t *testing.T) {
 	registry := handler.NewRegistry()
 	mock := &mockStatusHandler{}
 	registry.RegisterStatus(mock)
 
 	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
-	w := New(registry, logger, Config{Interval: 1 * time.Hour})
+	w := New(registry, isLeader(true), logger, Config{Interval: 1 * time.Hour})
 
 	ctx, cancel := context.WithCancel(context.Background())
 
@@ -60,7 +65,7 @@ func TestStatusWorker_StopsOnContextCancel(t *testing.T) {
 	registry.RegisterStatus(mock)
 
 	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
-	w := New(registry, logger, Config{Interval: 1 * time.Hour})
+	w := New(registry, isLeader(true), logger, Config{Interval: 1 * time.Hour})
 
 	ctx, cancel := context.WithCancel(context.Background())
 
@@ -82,3 +87,19 @@ func TestStatusWorker_StopsOnContextCancel(t *testing.T) {
 		t.Fatal("timed out waiting for worker to stop")
 	}
 }
+
+func TestStatusWorker_SkipsWhenNotLeader(t *testing.T) {
+	registry := handler.NewRegistry()
+	mock := &mockStatusHandler{}
+	registry.RegisterStatus(mock)
+
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+	w := New(registry, isLeader(false), logger, Config{Interval: 1 * time.Hour})
+
+	if err := w.runAllHandlers(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if count := mock.callCount.Load(); count != 0 {
+		t.Errorf("expected no calls on a replica that does not lead, got %d", count)
+	}
+}
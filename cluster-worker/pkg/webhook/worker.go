@@ -0,0 +1,487 @@
+// Package webhook implements the webhook delivery worker.
+// It POSTs rows from tenant.webhook_deliveries to the organization's endpoints,
+// signing each request with the webhook's secret.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/netip"
+	"os"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	db "github.com/fundament-oss/fundament/cluster-worker/pkg/db/gen"
+)
+
+// Request headers sent with every delivery.
+const (
+	HeaderSignature = "Fundament-Signature"
+	HeaderEvent     = "Fundament-Event"
+	HeaderDelivery  = "Fundament-Delivery"
+)
+
+// errEndpointDisabled fails deliveries whose webhook was deleted or disabled
+// after they were queued.
+var errEndpointDisabled = errors.New("webhook deleted or disabled")
+
+// errPrivateAddress is returned when an endpoint resolves to a non-public address.
+var errPrivateAddress = errors.New("endpoint address is not public")
+
+// leaseMargin is how much longer than the request timeout a claimed delivery
+// stays leased, leaving time to record the result.
+const leaseMargin = 30 * time.Second
+
+// Config holds configuration for the webhook worker.
+type Config struct {
+	PollInterval time.Duration `env:"POLL_INTERVAL" envDefault:"5s"`
+	Timeout      time.Duration `env:"TIMEOUT" envDefault:"10s"`
+	BaseBackoff  time.Duration `env:"BASE_BACKOFF" envDefault:"5s"`
+	MaxBackoff   time.Duration `env:"MAX_BACKOFF" envDefault:"1h"`
+	MaxAttempts  int32         `env:"MAX_ATTEMPTS" envDefault:"12"`
+	BackoffDelay time.Duration `env:"BACKOFF_DELAY" envDefault:"5s"`
+	// Concurrency bounds the deliveries in flight at once, and
+	// ConcurrencyPerWebhook those to a single webhook, so a slow endpoint
+	// holds at most that many of the slots.
+	Concurrency           int `env:"CONCURRENCY" envDefault:"16"`
+	ConcurrencyPerWebhook int `env:"CONCURRENCY_PER_WEBHOOK" envDefault:"2"`
+	// AllowPrivateNetworks permits endpoints on loopback, private and
+	// link-local addresses. Off by default so an organization cannot use
+	// webhooks to reach services inside the platform's own network.
+	AllowPrivateNetworks bool `env:"ALLOW_PRIVATE_NETWORKS" envDefault:"false"`
+}
+
+// Worker delivers queued webhook events.
+type Worker struct {
+	pool    *pgxpool.Pool
+	queries *db.Queries
+	client  *http.Client
+	logger  *slog.Logger
+	cfg     Config
+	now     func() time.Time
+
+	ready atomic.Bool
+
+	// slots holds a token per delivery in flight; finished is signalled
+	// whenever one completes, so that the next due delivery is claimed.
+	slots    chan struct{}
+	finished chan struct{}
+	inFlight sync.WaitGroup
+
+	mu         sync.Mutex
+	perWebhook map[uuid.UUID]int
+}
+
+func New(pool *pgxpool.Pool, logger *slog.Logger, cfg Config) *Worker {
+	hostname, _ := os.Hostname()
+	workerID := fmt.Sprintf("%s-%d", hostname, os.Getpid())
+
+	if cfg.Concurrency < 1 {
+		cfg.Concurrency = 1
+	}
+	if cfg.ConcurrencyPerWebhook < 1 {
+		cfg.ConcurrencyPerWebhook = 1
+	}
+
+	dialer := &net.Dialer{Timeout: cfg.Timeout}
+	if !cfg.AllowPrivateNetworks {
+		dialer.Control = rejectPrivateAddress
+	}
+
+	return &Worker{
+		pool:    pool,
+		queries: db.New(pool),
+		client: &http.Client{
+			Timeout:   cfg.Timeout,
+			Transport: &http.Transport{DialContext: dialer.DialContext},
+			// Endpoints must answer themselves; following redirects would
+			// send the signed payload somewhere the organization did not register.
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		logger:     logger.With("worker_id", workerID, "worker", "webhook"),
+		cfg:        cfg,
+		now:        time.Now,
+		slots:      make(chan struct{}, cfg.Concurrency),
+		finished:   make(chan struct{}, 1),
+		perWebhook: make(map[uuid.UUID]int),
+	}
+}
+
+// IsReady returns true if the worker is connected and processing.
+func (w *Worker) IsReady() bool {
+	return w.ready.Load()
+}
+
+// Sign returns the Fundament-Signature header value for body sent at ts:
+// "t=<unix seconds>,v1=<hex HMAC-SHA256 of "<unix seconds>.<body>">".
+// Receivers recompute the HMAC with their secret and reject stale timestamps
+// to guard against replays.
+func Sign(secret string, ts time.Time, body []byte) string {
+	t := strconv.FormatInt(ts.Unix(), 10)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(t))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "t=" + t + ",v1=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// nonGlobalPrefixes are IPv4 ranges that are not reachable on the public
+// internet but that netip has no predicate for. 100.64.0.0/10 matters most:
+// Gardener puts cluster service and pod networks in it.
+var nonGlobalPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),     // "this network"
+	netip.MustParsePrefix("100.64.0.0/10"), // shared address space (CGNAT)
+	netip.MustParsePrefix("192.0.0.0/24"),  // IETF protocol assignments
+	netip.MustParsePrefix("198.18.0.0/15"), // benchmarking
+	netip.MustParsePrefix("240.0.0.0/4"),   // reserved, including broadcast
+}
+
+// nat64Prefix is the well-known NAT64 prefix; its last 32 bits are an IPv4
+// address, which the NAT64 gateway connects to. localNAT64Prefix is the
+// prefix for NAT64 gateways of a local network, which is refused outright.
+var (
+	nat64Prefix      = netip.MustParsePrefix("64:ff9b::/96")
+	localNAT64Prefix = netip.MustParsePrefix("64:ff9b:1::/48")
+)
+
+// rejectPrivateAddress is a net.Dialer Control function that refuses
+// connections to non-public addresses. It runs after DNS resolution, so a
+// public hostname resolving to an internal address is refused as well.
+func rejectPrivateAddress(_, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip, err := netip.ParseAddr(host)
+	if err != nil {
+		return err
+	}
+	if !isPublicAddress(ip) {
+		return fmt.Errorf("%w: %s", errPrivateAddress, ip)
+	}
+	return nil
+}
+
+// isPublicAddress reports whether ip is globally reachable. IPv4 addresses
+// embedded in IPv4-mapped and NAT64 IPv6 addresses are checked as IPv4.
+func isPublicAddress(ip netip.Addr) bool {
+	ip = ip.Unmap()
+	if nat64Prefix.Contains(ip) {
+		b := ip.As16()
+		return isPublicAddress(netip.AddrFrom4([4]byte(b[12:])))
+	}
+	if localNAT64Prefix.Contains(ip) {
+		return false
+	}
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() || ip.IsUnspecified() {
+		return false
+	}
+	for _, prefix := range nonGlobalPrefixes {
+		if prefix.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
+// Run starts the worker with automatic reconnection on LISTEN connection loss.
+// On shutdown it waits for the deliveries in flight.
+func (w *Worker) Run(ctx context.Context) error {
+	defer w.inFlight.Wait()
+	for {
+		err := w.runWithConnection(ctx)
+		if ctx.Err() != nil {
+			return fmt.Errorf("worker stopped: %w", ctx.Err())
+		}
+		w.logger.Error("connection lost, reconnecting", "error", err, "delay", w.cfg.BackoffDelay)
+		w.ready.Store(false)
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("worker stopped: %w", ctx.Err())
+		case <-time.After(w.cfg.BackoffDelay):
+		}
+	}
+}
+
+func (w *Worker) runWithConnection(ctx context.Context) error {
+	conn, err := w.pool.Acquire(ctx)
+	if err != nil {
+		return fmt.Errorf("acquire listen connection: %w", err)
+	}
+	defer conn.Release()
+
+	if _, err := conn.Exec(ctx, "LISTEN webhook_deliveries"); err != nil {
+		return fmt.Errorf("listen: %w", err)
+	}
+	w.logger.Info("listening for webhook_deliveries notifications")
+	w.ready.Store(true)
+
+	w.deliverAll(ctx)
+
+	for {
+		if err := w.waitForNotification(ctx, conn); err != nil {
+			return err
+		}
+		w.deliverAll(ctx)
+	}
+}
+
+// waitForNotification blocks until a notification arrives, a delivery
+// finishes or the poll interval elapses. It only returns an error on shutdown
+// or connection loss.
+func (w *Worker) waitForNotification(ctx context.Context, conn *pgxpool.Conn) error {
+	waitCtx, cancel := context.WithTimeout(ctx, w.cfg.PollInterval)
+	defer cancel()
+	go func() {
+		select {
+		case <-w.finished:
+			cancel()
+		case <-waitCtx.Done():
+		}
+	}()
+
+	_, err := conn.Conn().WaitForNotification(waitCtx)
+
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, context.DeadlineExceeded), errors.Is(err, context.Canceled):
+		if ctx.Err() != nil {
+			return fmt.Errorf("shutdown requested: %w", ctx.Err())
+		}
+		return nil
+	case conn.Conn().IsClosed():
+		return fmt.Errorf("connection closed")
+	default:
+		w.logger.Warn("unexpected error waiting for notification", "error", err)
+		return nil
+	}
+}
+
+// deliverAll claims due deliveries until none are left or every slot is
+// taken, and sends each in its own goroutine. Infrastructure errors cause a
+// backoff to avoid a tight retry loop; endpoint errors are recorded on the row.
+func (w *Worker) deliverAll(ctx context.Context) {
+	for ctx.Err() == nil {
+		select {
+		case w.slots <- struct{}{}:
+		default:
+			// A finishing delivery wakes waitForNotification.
+			return
+		}
+
+		row, err := w.claim(ctx)
+		if err != nil {
+			<-w.slots
+			if errors.Is(err, pgx.ErrNoRows) {
+				return
+			}
+			w.logger.Error("failed to claim webhook delivery", "error", err)
+			select {
+			case <-ctx.Done():
+			case <-time.After(w.cfg.BackoffDelay):
+			}
+			return
+		}
+
+		w.inFlight.Go(func() {
+			defer w.release(row.WebhookID)
+			w.deliver(ctx, &row)
+		})
+	}
+}
+
+// claim leases the next due delivery of a webhook below its concurrency
+// limit. It commits right away: the delivery is sent outside any transaction.
+func (w *Worker) claim(ctx context.Context) (db.WebhookDeliveryClaimRow, error) {
+	row, err := w.queries.WebhookDeliveryClaim(ctx, db.WebhookDeliveryClaimParams{
+		BusyWebhookIds: w.busyWebhooks(),
+		Lease:          durationToInterval(w.cfg.Timeout + leaseMargin),
+	})
+	if err != nil {
+		return row, fmt.Errorf("claim next webhook delivery: %w", err)
+	}
+
+	w.mu.Lock()
+	w.perWebhook[row.WebhookID]++
+	w.mu.Unlock()
+	return row, nil
+}
+
+// busyWebhooks returns the webhooks that have reached ConcurrencyPerWebhook.
+func (w *Worker) busyWebhooks() []uuid.UUID {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	busy := []uuid.UUID{}
+	for id, n := range w.perWebhook {
+		if n >= w.cfg.ConcurrencyPerWebhook {
+			busy = append(busy, id)
+		}
+	}
+	return busy
+}
+
+func (w *Worker) release(webhookID uuid.UUID) {
+	w.mu.Lock()
+	w.perWebhook[webhookID]--
+	if w.perWebhook[webhookID] <= 0 {
+		delete(w.perWebhook, webhookID)
+	}
+	w.mu.Unlock()
+
+	<-w.slots
+	select {
+	case w.finished <- struct{}{}:
+	default:
+	}
+}
+
+// deliver sends one claimed delivery and records the outcome. A delivery cut
+// short by shutdown is not recorded; it is retried once its lease ends.
+func (w *Worker) deliver(ctx context.Context, row *db.WebhookDeliveryClaimRow) {
+	var (
+		status  int
+		sendErr error
+	)
+	if !row.Enabled || row.Deleted.Valid {
+		sendErr = errEndpointDisabled
+	} else {
+		status, sendErr = w.send(ctx, row)
+	}
+	if ctx.Err() != nil {
+		return
+	}
+
+	if err := w.complete(ctx, w.queries, row, status, sendErr); err != nil {
+		w.logger.Error("failed to record webhook delivery", "delivery_id", row.ID, "error", err)
+	}
+}
+
+// send POSTs the payload and returns the response status. A non-2xx status is
+// returned together with an error.
+func (w *Worker) send(ctx context.Context, row *db.WebhookDeliveryClaimRow) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, row.Url, bytes.NewReader(row.Payload))
+	if err != nil {
+		return 0, fmt.Errorf("build request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "Fundament-Webhooks/1")
+	req.Header.Set(HeaderEvent, row.EventType)
+	req.Header.Set(HeaderDelivery, row.ID.String())
+	req.Header.Set(HeaderSignature, Sign(row.Secret, w.now(), row.Payload))
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("send: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("endpoint responded %s", resp.Status)
+	}
+	return resp.StatusCode, nil
+}
+
+// complete records the outcome of one attempt, unless the lease on the row
+// ran out and another worker may have claimed it since.
+func (w *Worker) complete(ctx context.Context, qtx *db.Queries, row *db.WebhookDeliveryClaimRow, status int, sendErr error) error {
+	responseStatus := pgtype.Int4{Int32: int32(status), Valid: status != 0} //nolint:gosec // HTTP status codes fit in int32
+
+	if sendErr == nil {
+		n, err := qtx.WebhookDeliveryMarkSucceeded(ctx, db.WebhookDeliveryMarkSucceededParams{
+			ResponseStatus: responseStatus,
+			ID:             row.ID,
+			LeasedUntil:    row.LeasedUntil,
+		})
+		if err != nil {
+			return fmt.Errorf("mark webhook delivery succeeded: %w", err)
+		}
+		if n == 0 {
+			w.logLeaseLost(row)
+			return nil
+		}
+		w.logger.Debug("webhook delivered", "delivery_id", row.ID, "webhook_id", row.WebhookID, "status", status)
+		return nil
+	}
+
+	statusInfo := pgtype.Text{String: sendErr.Error(), Valid: true}
+
+	// row.Attempts is the count before this attempt, so +1 is the count after it.
+	if errors.Is(sendErr, errEndpointDisabled) || row.Attempts+1 >= w.cfg.MaxAttempts {
+		w.logger.Warn("webhook delivery failed permanently",
+			"delivery_id", row.ID,
+			"webhook_id", row.WebhookID,
+			"attempts", row.Attempts+1,
+			"error", sendErr)
+		n, err := qtx.WebhookDeliveryMarkFailed(ctx, db.WebhookDeliveryMarkFailedParams{
+			ResponseStatus: responseStatus,
+			StatusInfo:     statusInfo,
+			ID:             row.ID,
+			LeasedUntil:    row.LeasedUntil,
+		})
+		if err != nil {
+			return fmt.Errorf("mark webhook delivery failed: %w", err)
+		}
+		if n == 0 {
+			w.logLeaseLost(row)
+		}
+		return nil
+	}
+
+	attempts, err := qtx.WebhookDeliveryMarkRetry(ctx, db.WebhookDeliveryMarkRetryParams{
+		BaseInterval:   durationToInterval(w.cfg.BaseBackoff),
+		MaxBackoff:     durationToInterval(w.cfg.MaxBackoff),
+		ResponseStatus: responseStatus,
+		StatusInfo:     statusInfo,
+		ID:             row.ID,
+		LeasedUntil:    row.LeasedUntil,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		w.logLeaseLost(row)
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("mark webhook delivery retry: %w", err)
+	}
+
+	w.logger.Info("webhook delivery failed, will retry",
+		"delivery_id", row.ID,
+		"webhook_id", row.WebhookID,
+		"attempts", attempts,
+		"error", sendErr)
+	return nil
+}
+
+func (w *Worker) logLeaseLost(row *db.WebhookDeliveryClaimRow) {
+	w.logger.Warn("webhook delivery lease ran out before its result was recorded",
+		"delivery_id", row.ID,
+		"webhook_id", row.WebhookID)
+}
+
+func durationToInterval(d time.Duration) pgtype.Interval {
+	return pgtype.Interval{
+		Microseconds: d.Microseconds(),
+		Valid:        true,
+	}
+}
@@ -0,0 +1,260 @@
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	db "github.com/fundament-oss/fundament/cluster-worker/pkg/db/gen"
+)
+
+// mockDBTX records which query ran so complete() can be tested without a database.
+type mockDBTX struct {
+	execSQL     string
+	queryRowSQL string
+}
+
+func (m *mockDBTX) Exec(_ context.Context, sql string, _ ...any) (pgconn.CommandTag, error) {
+	m.execSQL = sql
+	return pgconn.CommandTag{}, nil
+}
+
+func (m *mockDBTX) Query(_ context.Context, _ string, _ ...any) (pgx.Rows, error) {
+	panic("Query should not be called")
+}
+
+func (m *mockDBTX) QueryRow(_ context.Context, sql string, _ ...any) pgx.Row {
+	m.queryRowSQL = sql
+	return attemptsRow(1)
+}
+
+type attemptsRow int32
+
+func (r attemptsRow) Scan(dest ...any) error {
+	*dest[0].(*int32) = int32(r)
+	return nil
+}
+
+func newTestWorker() *Worker {
+	w := New(nil, slog.New(slog.DiscardHandler), Config{Timeout: time.Second, MaxAttempts: 3, AllowPrivateNetworks: true})
+	w.now = func() time.Time { return time.Unix(1700000000, 0) }
+	return w
+}
+
+func TestSign(t *testing.T) {
+	body := []byte(`{"type":"ping"}`)
+	got := Sign("whsec_test", time.Unix(1700000000, 0), body)
+
+	mac := hmac.New(sha256.New, []byte("whsec_test"))
+	mac.Write([]byte("1700000000." + string(body)))
+	want := "t=1700000000,v1=" + hex.EncodeToString(mac.Sum(nil))
+
+	if got != want {
+		t.Errorf("Sign() = %q, want %q", got, want)
+	}
+	if Sign("other", time.Unix(1700000000, 0), body) == got {
+		t.Error("signature does not depend on the secret")
+	}
+}
+
+func TestSend(t *testing.T) {
+	var gotHeaders http.Header
+	var gotBody string
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		gotHeaders = r.Header
+		b, _ := io.ReadAll(r.Body)
+		gotBody = string(b)
+		rw.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	w := newTestWorker()
+	row := &db.WebhookDeliveryClaimRow{
+		ID:        uuid.New(),
+		EventType: "cluster_ready",
+		Payload:   []byte(`{"type":"cluster_ready"}`),
+		Url:       srv.URL,
+		Secret:    "whsec_test",
+	}
+
+	status, err := w.send(t.Context(), row)
+	if err != nil {
+		t.Fatalf("send() error = %v", err)
+	}
+	if status != http.StatusNoContent {
+		t.Errorf("status = %d, want %d", status, http.StatusNoContent)
+	}
+	if gotBody != string(row.Payload) {
+		t.Errorf("body = %q, want %q", gotBody, row.Payload)
+	}
+	if got := gotHeaders.Get(HeaderEvent); got != "cluster_ready" {
+		t.Errorf("%s = %q", HeaderEvent, got)
+	}
+	if got := gotHeaders.Get(HeaderDelivery); got != row.ID.String() {
+		t.Errorf("%s = %q, want %q", HeaderDelivery, got, row.ID)
+	}
+	if got, want := gotHeaders.Get(HeaderSignature), Sign(row.Secret, w.now(), row.Payload); got != want {
+		t.Errorf("%s = %q, want %q", HeaderSignature, got, want)
+	}
+}
+
+func TestSend_Non2xx(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, _ *http.Request) {
+		rw.WriteHeader(http.StatusBadGateway)
+	}))
+	defer srv.Close()
+
+	status, err := newTestWorker().send(t.Context(), &db.WebhookDeliveryClaimRow{
+		ID: uuid.New(), Payload: []byte(`{}`), Url: srv.URL,
+	})
+	if err == nil {
+		t.Fatal("expected error for 502 response")
+	}
+	if status != http.StatusBadGateway {
+		t.Errorf("status = %d, want %d", status, http.StatusBadGateway)
+	}
+}
+
+func TestSend_DoesNotFollowRedirects(t *testing.T) {
+	followed := false
+	target := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) { followed = true }))
+	defer target.Close()
+	srv := httptest.NewServer(http.RedirectHandler(target.URL, http.StatusTemporaryRedirect))
+	defer srv.Close()
+
+	status, err := newTestWorker().send(t.Context(), &db.WebhookDeliveryClaimRow{
+		ID: uuid.New(), Payload: []byte(`{}`), Url: srv.URL,
+	})
+	if err == nil || status != http.StatusTemporaryRedirect {
+		t.Errorf("send() = %d, %v; want 307 and an error", status, err)
+	}
+	if followed {
+		t.Error("redirect was followed")
+	}
+}
+
+func TestSend_RejectsPrivateAddresses(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		t.Error("request reached a loopback endpoint")
+	}))
+	defer srv.Close()
+
+	w := New(nil, slog.New(slog.DiscardHandler), Config{Timeout: time.Second})
+	_, err := w.send(t.Context(), &db.WebhookDeliveryClaimRow{
+		ID: uuid.New(), Payload: []byte(`{}`), Url: srv.URL,
+	})
+	if !errors.Is(err, errPrivateAddress) {
+		t.Errorf("send() error = %v, want %v", err, errPrivateAddress)
+	}
+}
+
+func TestRejectPrivateAddress(t *testing.T) {
+	for addr, wantErr := range map[string]bool{
+		"127.0.0.1:443":            true,
+		"10.1.2.3:443":             true,
+		"192.168.0.1:80":           true,
+		"169.254.169.254:80":       true,
+		"[::1]:443":                true,
+		"[::ffff:10.0.0.1]:80":     true,
+		"0.0.0.0:80":               true,
+		"0.1.2.3:80":               true,
+		"100.64.0.1:443":           true,
+		"100.96.0.10:443":          true,
+		"100.127.255.254:443":      true,
+		"192.0.0.8:443":            true,
+		"198.18.0.1:443":           true,
+		"198.19.255.1:443":         true,
+		"240.0.0.1:443":            true,
+		"255.255.255.255:443":      true,
+		"[::ffff:100.64.0.1]:80":   true,
+		"[::ffff:198.18.0.1]:80":   true,
+		"[64:ff9b::7f00:1]:80":     true,
+		"[64:ff9b::a00:1]:80":      true,
+		"[64:ff9b::6440:1]:80":     true,
+		"[64:ff9b:1::a00:1]:80":    true,
+		"[64:ff9b::5db8:d70e]:443": false,
+		"100.128.0.1:443":          false,
+		"198.20.0.1:443":           false,
+		"93.184.215.14:443":        false,
+		"[2606:4700::1]:443":       false,
+	} {
+		if err := rejectPrivateAddress("tcp", addr, nil); (err != nil) != wantErr {
+			t.Errorf("rejectPrivateAddress(%s) error = %v, want error %v", addr, err, wantErr)
+		}
+	}
+}
+
+func TestComplete(t *testing.T) {
+	tests := []struct {
+		name     string
+		attempts int32
+		sendErr  error
+		wantExec string
+		wantRow  string
+	}{
+		{name: "success", wantExec: "WebhookDeliveryMarkSucceeded"},
+		{name: "retry below max", attempts: 0, sendErr: errors.New("boom"), wantRow: "WebhookDeliveryMarkRetry"},
+		{name: "last attempt", attempts: 2, sendErr: errors.New("boom"), wantExec: "WebhookDeliveryMarkFailed"},
+		{name: "disabled endpoint", sendErr: errEndpointDisabled, wantExec: "WebhookDeliveryMarkFailed"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &mockDBTX{}
+			row := &db.WebhookDeliveryClaimRow{ID: uuid.New(), Attempts: tt.attempts}
+
+			if err := newTestWorker().complete(t.Context(), db.New(m), row, 500, tt.sendErr); err != nil {
+				t.Fatalf("complete() error = %v", err)
+			}
+			if tt.wantExec != "" && !strings.Contains(m.execSQL, tt.wantExec) {
+				t.Errorf("exec = %q, want %s", firstLine(m.execSQL), tt.wantExec)
+			}
+			if tt.wantRow != "" && !strings.Contains(m.queryRowSQL, tt.wantRow) {
+				t.Errorf("query = %q, want %s", firstLine(m.queryRowSQL), tt.wantRow)
+			}
+		})
+	}
+}
+
+func TestBusyWebhooks(t *testing.T) {
+	w := New(nil, slog.New(slog.DiscardHandler), Config{ConcurrencyPerWebhook: 2})
+	slow, other := uuid.New(), uuid.New()
+
+	if busy := w.busyWebhooks(); busy == nil || len(busy) != 0 {
+		t.Fatalf("busyWebhooks() = %v, want an empty, non-nil slice", busy)
+	}
+
+	w.perWebhook[slow] = 2
+	w.perWebhook[other] = 1
+	if busy := w.busyWebhooks(); len(busy) != 1 || busy[0] != slow {
+		t.Errorf("busyWebhooks() = %v, want [%s]", busy, slow)
+	}
+
+	w.slots <- struct{}{}
+	w.release(slow)
+	if busy := w.busyWebhooks(); len(busy) != 0 {
+		t.Errorf("busyWebhooks() after release = %v, want none", busy)
+	}
+	select {
+	case <-w.finished:
+	default:
+		t.Error("release() did not signal a finished delivery")
+	}
+}
+
+func firstLine(s string) string {
+	line, _, _ := strings.Cut(s, "\n")
+	return line
+}
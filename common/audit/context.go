@@ -0,0 +1,40 @@
+package audit
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type contextKeyAnnotations struct{}
+
+// annotations lets a handler add what the interceptor cannot derive from the
+// request and response messages.
+type annotations struct {
+	organizationID uuid.UUID
+	resourceType   string
+	resourceID     string
+}
+
+func withAnnotations(ctx context.Context) (context.Context, *annotations) {
+	a := &annotations{}
+	return context.WithValue(ctx, contextKeyAnnotations{}, a), a
+}
+
+// SetOrganizationID records the organization a request acted on, for
+// procedures that run without an organization in context (such as accepting
+// an invitation). It is a no-op outside an audited request.
+func SetOrganizationID(ctx context.Context, organizationID uuid.UUID) {
+	if a, ok := ctx.Value(contextKeyAnnotations{}).(*annotations); ok {
+		a.organizationID = organizationID
+	}
+}
+
+// SetResource overrides the resource the interceptor would derive from the
+// request and response messages. It is a no-op outside an audited request.
+func SetResource(ctx context.Context, resourceType, resourceID string) {
+	if a, ok := ctx.Value(contextKeyAnnotations{}).(*annotations); ok {
+		a.resourceType = resourceType
+		a.resourceID = resourceID
+	}
+}
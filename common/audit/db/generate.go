@@ -0,0 +1,2 @@
+//go:generate sqlc generate
+package db
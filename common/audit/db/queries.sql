@@ -0,0 +1,28 @@
+-- name: AuditEventCreate :exec
+INSERT INTO tenant.audit_events (
+	source,
+	organization_id,
+	cluster_id,
+	actor_user_id,
+	action,
+	resource_type,
+	resource_id,
+	outcome,
+	error_code,
+	details,
+	remote_addr,
+	user_agent
+) VALUES (
+	@source,
+	sqlc.narg('organization_id'),
+	sqlc.narg('cluster_id'),
+	sqlc.narg('actor_user_id'),
+	@action,
+	sqlc.narg('resource_type'),
+	sqlc.narg('resource_id'),
+	@outcome,
+	sqlc.narg('error_code'),
+	sqlc.narg('details'),
+	sqlc.narg('remote_addr'),
+	sqlc.narg('user_agent')
+);
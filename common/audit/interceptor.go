@@ -0,0 +1,249 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"unicode"
+
+	"connectrpc.com/connect"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	"github.com/fundament-oss/fundament/common/auth"
+	"github.com/fundament-oss/fundament/common/dbconst"
+)
+
+// maxDetailsBytes caps the request JSON stored with an event. Larger requests
+// (plugin manifests, layouts) are recorded without details.
+const maxDetailsBytes = 8 << 10
+
+// IDExtractor extracts an ID (user, organization) from context.
+type IDExtractor func(ctx context.Context) (uuid.UUID, bool)
+
+// NewInterceptor returns a Connect unary interceptor that records every
+// mutating procedure in the audit log once the handler has run. Read
+// procedures (see auth.IsReadProcedure) and requests without an authenticated
+// user are not recorded. organizationIDExtractor may be nil for services that
+// are not organization-scoped.
+//
+// Recording failures are logged and never fail the request: the change has
+// already been made by the time the event is written.
+func NewInterceptor(
+	logger *slog.Logger,
+	recorder Recorder,
+	source dbconst.AuditEventSource,
+	userIDExtractor IDExtractor,
+	organizationIDExtractor IDExtractor,
+) connect.UnaryInterceptorFunc {
+	return func(next connect.UnaryFunc) connect.UnaryFunc {
+		if recorder == nil {
+			return next
+		}
+
+		return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+			procedure := req.Spec().Procedure
+			if auth.IsReadProcedure(procedure) {
+				return next(ctx, req)
+			}
+
+			userID, ok := userIDExtractor(ctx)
+			if !ok {
+				return next(ctx, req)
+			}
+
+			ctx, annotations := withAnnotations(ctx)
+			resp, err := next(ctx, req)
+
+			event := &Event{
+				Source:      source,
+				ActorUserID: userID,
+				Action:      procedure,
+				Outcome:     outcomeOf(err),
+				RemoteAddr:  req.Peer().Addr,
+				UserAgent:   req.Header().Get("User-Agent"),
+			}
+			if err != nil {
+				event.ErrorCode = connect.CodeOf(err).String()
+			}
+
+			if organizationIDExtractor != nil {
+				if organizationID, ok := organizationIDExtractor(ctx); ok {
+					event.OrganizationID = organizationID
+				}
+			}
+			if annotations.organizationID != uuid.Nil {
+				event.OrganizationID = annotations.organizationID
+			}
+
+			reqMsg, _ := req.Any().(proto.Message)
+			var respMsg proto.Message
+			if err == nil && resp != nil {
+				respMsg, _ = resp.Any().(proto.Message)
+			}
+
+			event.ResourceType, event.ResourceID = resourceOf(procedure, respMsg, reqMsg)
+			if annotations.resourceID != "" {
+				event.ResourceType, event.ResourceID = annotations.resourceType, annotations.resourceID
+			}
+			event.ClusterID = clusterIDOf(respMsg, reqMsg)
+			event.Details = detailsOf(ctx, logger, reqMsg)
+
+			// Record even when the client has gone away; the outcome is final.
+			if recordErr := recorder.Record(context.WithoutCancel(ctx), event); recordErr != nil {
+				logger.ErrorContext(ctx, "failed to record audit event",
+					"error", recordErr,
+					"procedure", procedure,
+					"user_id", userID,
+				)
+			}
+
+			return resp, err
+		}
+	}
+}
+
+func outcomeOf(err error) dbconst.AuditEventOutcome {
+	if err == nil {
+		return dbconst.AuditEventOutcome_Succeeded
+	}
+
+	var connectErr *connect.Error
+	if errors.As(err, &connectErr) {
+		switch connectErr.Code() {
+		case connect.CodePermissionDenied, connect.CodeUnauthenticated:
+			return dbconst.AuditEventOutcome_Denied
+		}
+	}
+	return dbconst.AuditEventOutcome_Failed
+}
+
+// resourceOf returns the first populated `id` or `*_id` string field of the
+// given messages, in order. A `*_id` field names the resource type itself; a
+// plain `id` takes its type from the procedure (DeleteAsset → asset).
+func resourceOf(procedure string, msgs ...proto.Message) (string, string) {
+	for _, msg := range msgs {
+		if msg == nil {
+			continue
+		}
+		m := msg.ProtoReflect()
+		fields := m.Descriptor().Fields()
+		for i := range fields.Len() {
+			fd := fields.Get(i)
+			name := string(fd.Name())
+			if fd.Kind() != protoreflect.StringKind || fd.IsList() || (name != "id" && !strings.HasSuffix(name, "_id")) {
+				continue
+			}
+			value := m.Get(fd).String()
+			if value == "" {
+				continue
+			}
+			if name == "id" {
+				return resourceTypeOfProcedure(procedure), value
+			}
+			return strings.TrimSuffix(name, "_id"), value
+		}
+	}
+	return "", ""
+}
+
+// resourceTypeOfProcedure turns the noun of a procedure's method into
+// snake_case: /dcim.v1.CatalogService/DeletePortDefinition → port_definition.
+func resourceTypeOfProcedure(procedure string) string {
+	method := []rune(procedure[strings.LastIndex(procedure, "/")+1:])
+
+	// Skip the verb.
+	start := 1
+	for start < len(method) && !unicode.IsUpper(method[start]) {
+		start++
+	}
+
+	var b strings.Builder
+	for i := start; i < len(method); i++ {
+		r := method[i]
+		if unicode.IsUpper(r) && i > start {
+			prevLower := unicode.IsLower(method[i-1])
+			nextLower := i+1 < len(method) && unicode.IsLower(method[i+1])
+			if prevLower || (unicode.IsUpper(method[i-1]) && nextLower) {
+				b.WriteByte('_')
+			}
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+	return b.String()
+}
+
+// clusterIDOf returns the top-level cluster_id of the given messages, so
+// cluster events can be filtered by cluster whatever resource they touch.
+func clusterIDOf(msgs ...proto.Message) uuid.UUID {
+	for _, msg := range msgs {
+		if msg == nil {
+			continue
+		}
+		m := msg.ProtoReflect()
+		fd := m.Descriptor().Fields().ByName("cluster_id")
+		if fd == nil || fd.Kind() != protoreflect.StringKind || fd.IsList() {
+			continue
+		}
+		if id, err := uuid.Parse(m.Get(fd).String()); err == nil {
+			return id
+		}
+	}
+	return uuid.Nil
+}
+
+// detailsOf renders the request as JSON, leaving out fields marked with the
+// debug_redact option.
+func detailsOf(ctx context.Context, logger *slog.Logger, msg proto.Message) []byte {
+	if msg == nil {
+		return nil
+	}
+
+	msg = proto.Clone(msg)
+	redact(msg.ProtoReflect())
+
+	b, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(msg)
+	if err != nil {
+		logger.WarnContext(ctx, "failed to marshal audit details", "error", err)
+		return nil
+	}
+	if len(b) > maxDetailsBytes {
+		return nil
+	}
+	return b
+}
+
+// redact clears the fields marked with the debug_redact option in m and in
+// every message it holds, list elements and map values included.
+func redact(m protoreflect.Message) {
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		if opts, ok := fd.Options().(*descriptorpb.FieldOptions); ok && opts.GetDebugRedact() {
+			m.Clear(fd)
+			return true
+		}
+
+		switch {
+		case fd.IsMap():
+			if fd.MapValue().Message() != nil {
+				v.Map().Range(func(_ protoreflect.MapKey, value protoreflect.Value) bool {
+					redact(value.Message())
+					return true
+				})
+			}
+		case fd.IsList():
+			if fd.Message() != nil {
+				list := v.List()
+				for i := range list.Len() {
+					redact(list.Get(i).Message())
+				}
+			}
+		case fd.Message() != nil:
+			redact(v.Message())
+		}
+		return true
+	})
+}
@@ -0,0 +1,400 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	authnv1 "github.com/fundament-oss/fundament/authn-api/pkg/proto/gen/authn/v1"
+	"github.com/fundament-oss/fundament/common/dbconst"
+	organizationv1 "github.com/fundament-oss/fundament/organization-api/pkg/proto/gen/v1"
+)
+
+type fakeRecorder struct {
+	events []*Event
+	err    error
+}
+
+func (r *fakeRecorder) Record(_ context.Context, event *Event) error {
+	r.events = append(r.events, event)
+	return r.err
+}
+
+var testLogger = slog.New(slog.NewTextHandler(io.Discard, nil))
+
+type contextKeyTestID struct{}
+
+func testIDFromContext(ctx context.Context) (uuid.UUID, bool) {
+	id, ok := ctx.Value(contextKeyTestID{}).(uuid.UUID)
+	return id, ok
+}
+
+// newTestServer serves procedure with handler behind the audit interceptor.
+// The user ID in the test context is taken from the X-Test-User header.
+func newTestServer(
+	t *testing.T,
+	recorder Recorder,
+	procedure string,
+	handler func(context.Context, *connect.Request[wrapperspb.StringValue]) (*connect.Response[wrapperspb.StringValue], error),
+) *connect.Client[wrapperspb.StringValue, wrapperspb.StringValue] {
+	t.Helper()
+
+	userInterceptor := connect.UnaryInterceptorFunc(func(next connect.UnaryFunc) connect.UnaryFunc {
+		return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+			if id, err := uuid.Parse(req.Header().Get("X-Test-User")); err == nil {
+				ctx = context.WithValue(ctx, contextKeyTestID{}, id)
+			}
+			return next(ctx, req)
+		}
+	})
+
+	mux := http.NewServeMux()
+	mux.Handle(procedure, connect.NewUnaryHandler(procedure, handler, connect.WithInterceptors(
+		userInterceptor,
+		NewInterceptor(testLogger, recorder, dbconst.AuditEventSource_OrganizationApi, testIDFromContext, nil),
+	)))
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	return connect.NewClient[wrapperspb.StringValue, wrapperspb.StringValue](server.Client(), server.URL+procedure)
+}
+
+func newTestRequest(userID uuid.UUID) *connect.Request[wrapperspb.StringValue] {
+	req := connect.NewRequest(wrapperspb.String("hello"))
+	req.Header().Set("X-Test-User", userID.String())
+	req.Header().Set("User-Agent", "audit-test")
+	return req
+}
+
+func TestInterceptor_RecordsOutcome(t *testing.T) {
+	const procedure = "/test.v1.ThingService/DeleteThing"
+
+	tests := []struct {
+		name        string
+		err         error
+		wantOutcome dbconst.AuditEventOutcome
+		wantCode    string
+	}{
+		{name: "succeeded", wantOutcome: dbconst.AuditEventOutcome_Succeeded},
+		{name: "failed", err: connect.NewError(connect.CodeNotFound, errors.New("no thing")), wantOutcome: dbconst.AuditEventOutcome_Failed, wantCode: "not_found"},
+		{name: "denied", err: connect.NewError(connect.CodePermissionDenied, errors.New("no")), wantOutcome: dbconst.AuditEventOutcome_Denied, wantCode: "permission_denied"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := &fakeRecorder{}
+			client := newTestServer(t, recorder, procedure, func(context.Context, *connect.Request[wrapperspb.StringValue]) (*connect.Response[wrapperspb.StringValue], error) {
+				if tt.err != nil {
+					return nil, tt.err
+				}
+				return connect.NewResponse(wrapperspb.String("done")), nil
+			})
+
+			userID := uuid.New()
+			_, err := client.CallUnary(context.Background(), newTestRequest(userID))
+			if tt.err != nil {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.Len(t, recorder.events, 1)
+			event := recorder.events[0]
+			assert.Equal(t, dbconst.AuditEventSource_OrganizationApi, event.Source)
+			assert.Equal(t, userID, event.ActorUserID)
+			assert.Equal(t, procedure, event.Action)
+			assert.Equal(t, tt.wantOutcome, event.Outcome)
+			assert.Equal(t, tt.wantCode, event.ErrorCode)
+			assert.Equal(t, "audit-test", event.UserAgent)
+			assert.JSONEq(t, `"hello"`, string(event.Details))
+		})
+	}
+}
+
+func TestInterceptor_Skips(t *testing.T) {
+	t.Run("read procedure", func(t *testing.T) {
+		recorder := &fakeRecorder{}
+		client := newTestServer(t, recorder, "/test.v1.ThingService/GetThing", echo)
+
+		_, err := client.CallUnary(context.Background(), newTestRequest(uuid.New()))
+		require.NoError(t, err)
+		assert.Empty(t, recorder.events)
+	})
+
+	t.Run("no user", func(t *testing.T) {
+		recorder := &fakeRecorder{}
+		client := newTestServer(t, recorder, "/test.v1.ThingService/DeleteThing", echo)
+
+		_, err := client.CallUnary(context.Background(), connect.NewRequest(wrapperspb.String("hello")))
+		require.NoError(t, err)
+		assert.Empty(t, recorder.events)
+	})
+
+	t.Run("nil recorder", func(t *testing.T) {
+		client := newTestServer(t, nil, "/test.v1.ThingService/DeleteThing", echo)
+
+		_, err := client.CallUnary(context.Background(), newTestRequest(uuid.New()))
+		require.NoError(t, err)
+	})
+}
+
+func TestInterceptor_RecordErrorDoesNotFailRequest(t *testing.T) {
+	recorder := &fakeRecorder{err: errors.New("database down")}
+	client := newTestServer(t, recorder, "/test.v1.ThingService/DeleteThing", echo)
+
+	_, err := client.CallUnary(context.Background(), newTestRequest(uuid.New()))
+	require.NoError(t, err)
+	assert.Len(t, recorder.events, 1)
+}
+
+func TestInterceptor_Annotations(t *testing.T) {
+	organizationID := uuid.New()
+	recorder := &fakeRecorder{}
+	client := newTestServer(t, recorder, "/test.v1.InviteService/AcceptInvitation",
+		func(ctx context.Context, req *connect.Request[wrapperspb.StringValue]) (*connect.Response[wrapperspb.StringValue], error) {
+			SetOrganizationID(ctx, organizationID)
+			SetResource(ctx, "invitation", "inv-1")
+			return echo(ctx, req)
+		})
+
+	_, err := client.CallUnary(context.Background(), newTestRequest(uuid.New()))
+	require.NoError(t, err)
+
+	require.Len(t, recorder.events, 1)
+	assert.Equal(t, organizationID, recorder.events[0].OrganizationID)
+	assert.Equal(t, "invitation", recorder.events[0].ResourceType)
+	assert.Equal(t, "inv-1", recorder.events[0].ResourceID)
+}
+
+func echo(_ context.Context, req *connect.Request[wrapperspb.StringValue]) (*connect.Response[wrapperspb.StringValue], error) {
+	return connect.NewResponse(req.Msg), nil
+}
+
+// testFile describes a Thing message with id, cluster_id, project_id and a
+// redacted secret field, and a Bundle that holds Things in a message field, a
+// list and a map, without needing generated code.
+func testFile(t *testing.T) protoreflect.FileDescriptor {
+	t.Helper()
+
+	field := func(name string, number int32, redacted bool) *descriptorpb.FieldDescriptorProto {
+		return &descriptorpb.FieldDescriptorProto{
+			Name:     proto.String(name),
+			JsonName: proto.String(name),
+			Number:   proto.Int32(number),
+			Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+			Type:     descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum(),
+			Options:  &descriptorpb.FieldOptions{DebugRedact: proto.Bool(redacted)},
+		}
+	}
+	thingField := func(name string, number int32, label descriptorpb.FieldDescriptorProto_Label, typeName string) *descriptorpb.FieldDescriptorProto {
+		return &descriptorpb.FieldDescriptorProto{
+			Name:     proto.String(name),
+			JsonName: proto.String(name),
+			Number:   proto.Int32(number),
+			Label:    label.Enum(),
+			Type:     descriptorpb.FieldDescriptorProto_TYPE_MESSAGE.Enum(),
+			TypeName: proto.String(typeName),
+		}
+	}
+	fd, err := protodesc.NewFile(&descriptorpb.FileDescriptorProto{
+		Name:    proto.String("audit_test.proto"),
+		Package: proto.String("audit.test"),
+		Syntax:  proto.String("proto3"),
+		MessageType: []*descriptorpb.DescriptorProto{{
+			Name: proto.String("Thing"),
+			Field: []*descriptorpb.FieldDescriptorProto{
+				field("id", 1, false),
+				field("cluster_id", 2, false),
+				field("project_id", 3, false),
+				field("secret", 4, true),
+			},
+		}, {
+			Name: proto.String("Bundle"),
+			Field: []*descriptorpb.FieldDescriptorProto{
+				thingField("thing", 1, descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL, ".audit.test.Thing"),
+				thingField("things", 2, descriptorpb.FieldDescriptorProto_LABEL_REPEATED, ".audit.test.Thing"),
+				thingField("things_by_name", 3, descriptorpb.FieldDescriptorProto_LABEL_REPEATED, ".audit.test.Bundle.ThingsByNameEntry"),
+			},
+			NestedType: []*descriptorpb.DescriptorProto{{
+				Name: proto.String("ThingsByNameEntry"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					field("key", 1, false),
+					thingField("value", 2, descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL, ".audit.test.Thing"),
+				},
+				Options: &descriptorpb.MessageOptions{MapEntry: proto.Bool(true)},
+			}},
+		}},
+	}, nil)
+	require.NoError(t, err)
+	return fd
+}
+
+// newThingMessage builds a Thing with the given string fields set.
+func newThingMessage(t *testing.T, values map[string]string) proto.Message {
+	t.Helper()
+
+	desc := testFile(t).Messages().ByName("Thing")
+	msg := dynamicpb.NewMessage(desc)
+	for name, value := range values {
+		msg.Set(desc.Fields().ByName(protoreflect.Name(name)), protoreflect.ValueOfString(value))
+	}
+	return msg
+}
+
+func TestResourceOf(t *testing.T) {
+	clusterID := uuid.New()
+
+	tests := []struct {
+		name      string
+		procedure string
+		resp      map[string]string
+		req       map[string]string
+		wantType  string
+		wantID    string
+	}{
+		{
+			name:      "response id wins for creates",
+			procedure: "/dcim.v1.CatalogService/CreatePortDefinition",
+			resp:      map[string]string{"id": "new"},
+			req:       map[string]string{"project_id": "p"},
+			wantType:  "port_definition",
+			wantID:    "new",
+		},
+		{
+			name:      "named id field",
+			procedure: "/organization.v1.ClusterService/DeleteCluster",
+			req:       map[string]string{"cluster_id": clusterID.String()},
+			wantType:  "cluster",
+			wantID:    clusterID.String(),
+		},
+		{
+			name:      "acronym in procedure",
+			procedure: "/organization.v1.APIKeyService/RevokeAPIKey",
+			req:       map[string]string{"id": "k"},
+			wantType:  "api_key",
+			wantID:    "k",
+		},
+		{
+			name:      "no id",
+			procedure: "/organization.v1.ThingService/DoThing",
+			req:       map[string]string{"secret": "s"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var resp proto.Message
+			if tt.resp != nil {
+				resp = newThingMessage(t, tt.resp)
+			}
+			gotType, gotID := resourceOf(tt.procedure, resp, newThingMessage(t, tt.req))
+			assert.Equal(t, tt.wantType, gotType)
+			assert.Equal(t, tt.wantID, gotID)
+		})
+	}
+}
+
+func TestClusterIDOf(t *testing.T) {
+	clusterID := uuid.New()
+
+	assert.Equal(t, clusterID, clusterIDOf(nil, newThingMessage(t, map[string]string{"cluster_id": clusterID.String()})))
+	assert.Equal(t, uuid.Nil, clusterIDOf(newThingMessage(t, map[string]string{"cluster_id": "not-a-uuid"})))
+	assert.Equal(t, uuid.Nil, clusterIDOf(wrapperspb.String("x")))
+}
+
+func TestDetailsOf_RedactsAndCaps(t *testing.T) {
+	details := detailsOf(context.Background(), testLogger, newThingMessage(t, map[string]string{"id": "a", "secret": "hunter2"}))
+	assert.JSONEq(t, `{"id":"a"}`, string(details))
+
+	large := make([]byte, maxDetailsBytes+1)
+	for i := range large {
+		large[i] = 'x'
+	}
+	assert.Nil(t, detailsOf(context.Background(), testLogger, wrapperspb.String(string(large))))
+}
+
+func TestDetailsOf_RedactsNestedMessages(t *testing.T) {
+	fd := testFile(t)
+	thingDesc := fd.Messages().ByName("Thing")
+	bundleDesc := fd.Messages().ByName("Bundle")
+	newThing := func(id string) protoreflect.Value {
+		thing := dynamicpb.NewMessage(thingDesc)
+		thing.Set(thingDesc.Fields().ByName("id"), protoreflect.ValueOfString(id))
+		thing.Set(thingDesc.Fields().ByName("secret"), protoreflect.ValueOfString("hunter2"))
+		return protoreflect.ValueOfMessage(thing)
+	}
+
+	bundle := dynamicpb.NewMessage(bundleDesc)
+	fields := bundleDesc.Fields()
+	bundle.Set(fields.ByName("thing"), newThing("a"))
+	bundle.Mutable(fields.ByName("things")).List().Append(newThing("b"))
+	bundle.Mutable(fields.ByName("things_by_name")).Map().Set(protoreflect.ValueOfString("c").MapKey(), newThing("c"))
+
+	details := detailsOf(context.Background(), testLogger, bundle)
+	assert.JSONEq(t, `{"thing":{"id":"a"},"things":[{"id":"b"}],"things_by_name":{"c":{"id":"c"}}}`, string(details))
+
+	// The caller's message keeps its values.
+	assert.Equal(t, "hunter2", bundle.Get(fields.ByName("thing")).Message().Get(thingDesc.Fields().ByName("secret")).String())
+}
+
+// TestInterceptor_RedactsSecretFields checks that the generated secret fields
+// carry debug_redact and stay out of the details handed to the recorder.
+func TestInterceptor_RedactsSecretFields(t *testing.T) {
+	tests := []struct {
+		name string
+		req  connect.AnyRequest
+		want string
+	}{
+		{
+			name: "api key token",
+			req:  connect.NewRequest(organizationv1.CreateAPIKeyResponse_builder{Id: "k", Token: "fun_secret", TokenPrefix: "fun_secr"}.Build()),
+			want: `{"id":"k","token_prefix":"fun_secr"}`,
+		},
+		{
+			name: "webhook secret",
+			req:  connect.NewRequest(organizationv1.CreateWebhookResponse_builder{Id: "w", Secret: "whsec"}.Build()),
+			want: `{"id":"w"}`,
+		},
+		{
+			name: "exchanged access token",
+			req:  connect.NewRequest(authnv1.ExchangeTokenResponse_builder{AccessToken: "jwt", TokenType: "Bearer"}.Build()),
+			want: `{"token_type":"Bearer"}`,
+		},
+		{
+			name: "plugin access token",
+			req:  connect.NewRequest(authnv1.MintPluginTokenResponse_builder{AccessToken: "jwt", TokenType: "Bearer"}.Build()),
+			want: `{"token_type":"Bearer"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := &fakeRecorder{}
+			userID := uuid.New()
+			interceptor := NewInterceptor(testLogger, recorder, dbconst.AuditEventSource_OrganizationApi,
+				func(context.Context) (uuid.UUID, bool) { return userID, true }, nil)
+
+			next := func(context.Context, connect.AnyRequest) (connect.AnyResponse, error) {
+				return connect.NewResponse(&emptypb.Empty{}), nil
+			}
+			_, err := interceptor(next)(context.Background(), tt.req)
+			require.NoError(t, err)
+
+			require.Len(t, recorder.events, 1)
+			assert.JSONEq(t, tt.want, string(recorder.events[0].Details))
+		})
+	}
+}
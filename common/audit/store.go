@@ -0,0 +1,85 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	db "github.com/fundament-oss/fundament/common/audit/db/gen"
+	"github.com/fundament-oss/fundament/common/dbconst"
+)
+
+// Event is a single entry in tenant.audit_events.
+type Event struct {
+	Source         dbconst.AuditEventSource
+	OrganizationID uuid.UUID // uuid.Nil when unknown
+	ClusterID      uuid.UUID // uuid.Nil when the event is not about a cluster
+	ActorUserID    uuid.UUID
+	Action         string
+	ResourceType   string
+	ResourceID     string
+	Outcome        dbconst.AuditEventOutcome
+	ErrorCode      string
+	Details        []byte // JSON object, nil when there are none
+	RemoteAddr     string
+	UserAgent      string
+}
+
+// Recorder appends audit events.
+type Recorder interface {
+	Record(ctx context.Context, event *Event) error
+}
+
+// Store records audit events in the database.
+type Store struct {
+	queries *db.Queries
+	logger  *slog.Logger
+}
+
+var _ Recorder = (*Store)(nil)
+
+// NewStore creates a new audit Store. The pool must connect as a role that may
+// insert events for the sources it records.
+func NewStore(pool db.DBTX, logger *slog.Logger) *Store {
+	return &Store{queries: db.New(pool), logger: logger}
+}
+
+// Record appends event to the audit log.
+func (s *Store) Record(ctx context.Context, event *Event) error {
+	err := s.queries.AuditEventCreate(ctx, db.AuditEventCreateParams{
+		Source:         string(event.Source),
+		OrganizationID: optionalUUID(event.OrganizationID),
+		ClusterID:      optionalUUID(event.ClusterID),
+		ActorUserID:    optionalUUID(event.ActorUserID),
+		Action:         event.Action,
+		ResourceType:   optionalText(event.ResourceType),
+		ResourceID:     optionalText(event.ResourceID),
+		Outcome:        string(event.Outcome),
+		ErrorCode:      optionalText(event.ErrorCode),
+		Details:        event.Details,
+		RemoteAddr:     optionalText(event.RemoteAddr),
+		UserAgent:      optionalText(event.UserAgent),
+	})
+	if err != nil {
+		return fmt.Errorf("create audit event: %w", err)
+	}
+
+	s.logger.DebugContext(ctx, "audit event recorded",
+		"source", event.Source,
+		"action", event.Action,
+		"outcome", event.Outcome,
+		"resource_id", event.ResourceID,
+	)
+	return nil
+}
+
+func optionalUUID(id uuid.UUID) pgtype.UUID {
+	return pgtype.UUID{Bytes: id, Valid: id != uuid.Nil}
+}
+
+func optionalText(s string) pgtype.Text {
+	return pgtype.Text{String: s, Valid: s != ""}
+}
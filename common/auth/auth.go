@@ -20,20 +20,21 @@ const DCIMAuthCookieName = "dcim_auth"
 
 // Issuer values for the JWTs minted by each authentication service. Validators
 // pin the expected issuer so that a token minted for one trust domain cannot be
-// replayed against another service that happens to share the same JWT secret.
+// replayed against another service that happens to trust the same keys.
 const (
 	ConsoleIssuer = "fundament-authn-api"
 	DCIMIssuer    = "dcim-authn-api"
 )
 
 // TokenType is the value carried in the JWT `aud` claim. It distinguishes
-// user tokens from plugin tokens so that services can refuse the wrong kind
+// user, plugin and service tokens so that services can refuse the wrong kind
 // at validation time.
 type TokenType = string
 
 const (
-	TokenTypeUser   TokenType = "fundament-user"
-	TokenTypePlugin TokenType = "fundament-plugin"
+	TokenTypeUser    TokenType = "fundament-user"
+	TokenTypePlugin  TokenType = "fundament-plugin"
+	TokenTypeService TokenType = "fundament-service"
 )
 
 // Claims represents the JWT claims used across fundament services.
@@ -42,6 +43,8 @@ type Claims struct {
 	OrganizationIDs []uuid.UUID `json:"organization_ids"`
 	Groups          []string    `json:"groups"`
 	Name            string      `json:"name"`
+	// Scope is set on tokens exchanged for a scoped API key.
+	Scope *Scope `json:"scope,omitempty"`
 }
 
 func (c *Claims) UserID() uuid.UUID {
@@ -50,7 +53,7 @@ func (c *Claims) UserID() uuid.UUID {
 
 // Validator handles JWT validation from HTTP headers.
 type Validator struct {
-	jwtSecret        []byte
+	keys             Keys
 	cookieName       string
 	expectedIssuer   string
 	expectedAudience TokenType // empty = accept any audience (legacy)
@@ -61,12 +64,12 @@ type Validator struct {
 // whose `iss` does not match are rejected. The validator accepts any audience
 // — prefer NewValidatorForAudience so a service explicitly declares the token
 // type it accepts. Logger is optional.
-func NewValidator(jwtSecret []byte, cookieName, expectedIssuer string, logger *slog.Logger) *Validator {
+func NewValidator(keys Keys, cookieName, expectedIssuer string, logger *slog.Logger) *Validator {
 	if logger == nil {
 		logger = slog.New(slog.NewTextHandler(io.Discard, nil))
 	}
 	return &Validator{
-		jwtSecret:      jwtSecret,
+		keys:           keys,
 		cookieName:     cookieName,
 		expectedIssuer: expectedIssuer,
 		logger:         logger,
@@ -76,8 +79,8 @@ func NewValidator(jwtSecret []byte, cookieName, expectedIssuer string, logger *s
 // NewValidatorForAudience creates a Validator that, in addition to pinning the
 // cookie name and issuer, requires the JWT `aud` claim to contain the given
 // TokenType.
-func NewValidatorForAudience(jwtSecret []byte, cookieName, expectedIssuer string, audience TokenType, logger *slog.Logger) *Validator {
-	v := NewValidator(jwtSecret, cookieName, expectedIssuer, logger)
+func NewValidatorForAudience(keys Keys, cookieName, expectedIssuer string, audience TokenType, logger *slog.Logger) *Validator {
+	v := NewValidator(keys, cookieName, expectedIssuer, logger)
 	v.expectedAudience = audience
 	return v
 }
@@ -121,18 +124,19 @@ func (v *Validator) extractCookieToken(header http.Header) string {
 // validateToken parses and validates a JWT token string.
 func (v *Validator) validateToken(tokenString string) (*Claims, error) {
 	parserOpts := []jwt.ParserOption{
-		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithValidMethods(signingMethods),
 		jwt.WithExpirationRequired(),
 	}
 	if v.expectedIssuer != "" {
 		parserOpts = append(parserOpts, jwt.WithIssuer(v.expectedIssuer))
 	}
 	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (any, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			v.logger.Debug("unexpected signing method", "alg", token.Header["alg"])
-			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		key, err := v.keys.VerificationKey(token)
+		if err != nil {
+			v.logger.Debug("no verification key for token", "alg", token.Header["alg"], "kid", token.Header["kid"], "error", err)
+			return nil, err
 		}
-		return v.jwtSecret, nil
+		return key, nil
 	}, parserOpts...)
 	if err != nil {
 		v.logger.Debug("token validation failed", "error", err)
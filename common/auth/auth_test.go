@@ -23,7 +23,7 @@ func signToken(t *testing.T, claims *Claims) string {
 }
 
 func newValidator() *Validator {
-	return NewValidator(testSecret, ConsoleAuthCookieName, ConsoleIssuer, nil)
+	return NewValidator(SharedSecret(testSecret), ConsoleAuthCookieName, ConsoleIssuer, nil)
 }
 
 func validUserClaims(subject string) *Claims {
@@ -153,7 +153,7 @@ func TestClaimsUserID(t *testing.T) {
 func TestValidatorForAudience_AcceptsMatchingAudience(t *testing.T) {
 	tokenString := signToken(t, validUserClaims(uuid.New().String()))
 
-	v := NewValidatorForAudience(testSecret, ConsoleAuthCookieName, ConsoleIssuer, TokenTypeUser, nil)
+	v := NewValidatorForAudience(SharedSecret(testSecret), ConsoleAuthCookieName, ConsoleIssuer, TokenTypeUser, nil)
 	header := http.Header{}
 	header.Set("Authorization", "Bearer "+tokenString)
 
@@ -167,7 +167,7 @@ func TestValidatorForAudience_RejectsMismatchedAudience(t *testing.T) {
 	c.Audience = jwt.ClaimStrings{TokenTypePlugin}
 	tokenString := signToken(t, c)
 
-	v := NewValidatorForAudience(testSecret, ConsoleAuthCookieName, ConsoleIssuer, TokenTypeUser, nil)
+	v := NewValidatorForAudience(SharedSecret(testSecret), ConsoleAuthCookieName, ConsoleIssuer, TokenTypeUser, nil)
 	header := http.Header{}
 	header.Set("Authorization", "Bearer "+tokenString)
 
@@ -185,7 +185,7 @@ func TestValidatorForAudience_AcceptsMultiAudienceWhenExpectedPresent(t *testing
 	c.Audience = jwt.ClaimStrings{TokenTypePlugin, TokenTypeUser}
 	tokenString := signToken(t, c)
 
-	v := NewValidatorForAudience(testSecret, ConsoleAuthCookieName, ConsoleIssuer, TokenTypeUser, nil)
+	v := NewValidatorForAudience(SharedSecret(testSecret), ConsoleAuthCookieName, ConsoleIssuer, TokenTypeUser, nil)
 	header := http.Header{}
 	header.Set("Authorization", "Bearer "+tokenString)
 
@@ -199,7 +199,7 @@ func TestValidatorForAudience_RejectsMissingAudience(t *testing.T) {
 	c.Audience = nil
 	tokenString := signToken(t, c)
 
-	v := NewValidatorForAudience(testSecret, ConsoleAuthCookieName, ConsoleIssuer, TokenTypeUser, nil)
+	v := NewValidatorForAudience(SharedSecret(testSecret), ConsoleAuthCookieName, ConsoleIssuer, TokenTypeUser, nil)
 	header := http.Header{}
 	header.Set("Authorization", "Bearer "+tokenString)
 
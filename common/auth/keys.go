@@ -0,0 +1,83 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// signingMethods are the algorithms a console token may be signed with. The
+// Keys implementation decides which of them it actually trusts.
+var signingMethods = []string{
+	jwt.SigningMethodHS256.Alg(),
+	jwt.SigningMethodRS256.Alg(),
+	jwt.SigningMethodEdDSA.Alg(),
+}
+
+// Keys resolves the key that verifies a token's signature.
+type Keys interface {
+	// VerificationKey returns the key for the token's alg and kid header, or an
+	// error when the token was not signed by a key this set trusts.
+	VerificationKey(token *jwt.Token) (any, error)
+}
+
+// SharedSecret verifies HS256 tokens with a secret shared between the issuer
+// and every validator.
+type SharedSecret []byte
+
+// VerificationKey implements Keys.
+func (s SharedSecret) VerificationKey(token *jwt.Token) (any, error) {
+	if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+		return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+	}
+	return []byte(s), nil
+}
+
+// keyChain tries each Keys in order and uses the first that resolves.
+type keyChain []Keys
+
+func (c keyChain) VerificationKey(token *jwt.Token) (any, error) {
+	var errs []error
+	for _, keys := range c {
+		key, err := keys.VerificationKey(token)
+		if err == nil {
+			return key, nil
+		}
+		errs = append(errs, err)
+	}
+	return nil, errors.Join(errs...)
+}
+
+// ChainKeys returns Keys that try each of keys in order and use the first that
+// resolves.
+func ChainKeys(keys ...Keys) Keys {
+	if len(keys) == 1 {
+		return keys[0]
+	}
+	return keyChain(keys)
+}
+
+// NewKeys returns the keys a service validates console tokens with: the key
+// set authn-api publishes at jwksURL, the shared secret, or both. Accepting
+// both lets a deployment move off the shared secret without invalidating the
+// HS256 tokens that are still in flight.
+func NewKeys(jwksURL string, secret []byte, logger *slog.Logger) (Keys, error) {
+	var chain keyChain
+	if jwksURL != "" {
+		chain = append(chain, NewRemoteKeys(jwksURL, logger))
+	}
+	if len(secret) > 0 {
+		chain = append(chain, SharedSecret(secret))
+	}
+
+	switch len(chain) {
+	case 0:
+		return nil, fmt.Errorf("either a JWKS URL or a JWT secret is required")
+	case 1:
+		return chain[0], nil
+	default:
+		return chain, nil
+	}
+}
@@ -31,18 +31,13 @@ type PluginClaims struct {
 	DefinitionHash string `json:"definition_hash"`
 }
 
-// ParsePluginToken parses and verifies a PluginToken with the given HMAC
-// secret. It checks signing method, signature, expiry, issuer, that the
+// ParsePluginToken parses and verifies a PluginToken against the given keys.
+// It checks signing method, signature, expiry, issuer, that the
 // audience contains fundament-plugin, and that the subject is a UUID. It does
 // NOT check the cluster/installation binding — that is the caller's job.
-func ParsePluginToken(tokenStr string, secret []byte) (*PluginClaims, error) {
-	token, err := jwt.ParseWithClaims(tokenStr, &PluginClaims{}, func(t *jwt.Token) (any, error) {
-		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", t.Header["alg"])
-		}
-		return secret, nil
-	},
-		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+func ParsePluginToken(tokenStr string, keys Keys) (*PluginClaims, error) {
+	token, err := jwt.ParseWithClaims(tokenStr, &PluginClaims{}, keys.VerificationKey,
+		jwt.WithValidMethods(signingMethods),
 		jwt.WithExpirationRequired(),
 		jwt.WithIssuer(ConsoleIssuer),
 	)
@@ -46,7 +46,7 @@ func TestParsePluginToken_AcceptsValidToken(t *testing.T) {
 	want := validPluginClaims()
 	tokenStr := signPluginToken(t, secret, want)
 
-	got, err := ParsePluginToken(tokenStr, secret)
+	got, err := ParsePluginToken(tokenStr, SharedSecret(secret))
 	require.NoError(t, err, "ParsePluginToken")
 	assert.Equal(t, want.ClusterID, got.ClusterID)
 	assert.Equal(t, want.InstallationID, got.InstallationID)
@@ -64,7 +64,7 @@ func TestParsePluginToken_AcceptsMultiAudience(t *testing.T) {
 	c.Audience = jwt.ClaimStrings{TokenTypeUser, TokenTypePlugin}
 	tokenStr := signPluginToken(t, secret, c)
 
-	_, err := ParsePluginToken(tokenStr, secret)
+	_, err := ParsePluginToken(tokenStr, SharedSecret(secret))
 	require.NoError(t, err, "ParsePluginToken")
 }
 
@@ -74,7 +74,7 @@ func TestParsePluginToken_RejectsUserAudience(t *testing.T) {
 	c.Audience = jwt.ClaimStrings{TokenTypeUser}
 	tokenStr := signPluginToken(t, secret, c)
 
-	_, err := ParsePluginToken(tokenStr, secret)
+	_, err := ParsePluginToken(tokenStr, SharedSecret(secret))
 	require.Error(t, err, "expected error for fundament-user audience")
 }
 
@@ -84,13 +84,13 @@ func TestParsePluginToken_RejectsExpiredToken(t *testing.T) {
 	c.ExpiresAt = jwt.NewNumericDate(time.Now().Add(-time.Hour))
 	tokenStr := signPluginToken(t, secret, c)
 
-	_, err := ParsePluginToken(tokenStr, secret)
+	_, err := ParsePluginToken(tokenStr, SharedSecret(secret))
 	require.Error(t, err, "expected error for expired token")
 }
 
 func TestParsePluginToken_RejectsWrongSecret(t *testing.T) {
 	tokenStr := signPluginToken(t, []byte("secret-a"), validPluginClaims())
-	_, err := ParsePluginToken(tokenStr, []byte("secret-b"))
+	_, err := ParsePluginToken(tokenStr, SharedSecret("secret-b"))
 	require.Error(t, err, "expected error for wrong signing secret")
 }
 
@@ -100,7 +100,7 @@ func TestParsePluginToken_RejectsMissingExp(t *testing.T) {
 	c.ExpiresAt = nil
 	tokenStr := signPluginToken(t, secret, c)
 
-	_, err := ParsePluginToken(tokenStr, secret)
+	_, err := ParsePluginToken(tokenStr, SharedSecret(secret))
 	require.Error(t, err, "token without exp must be rejected")
 }
 
@@ -110,7 +110,7 @@ func TestParsePluginToken_RejectsWrongIssuer(t *testing.T) {
 	c.Issuer = "evil-issuer"
 	tokenStr := signPluginToken(t, secret, c)
 
-	_, err := ParsePluginToken(tokenStr, secret)
+	_, err := ParsePluginToken(tokenStr, SharedSecret(secret))
 	require.Error(t, err, "token with unexpected issuer must be rejected")
 }
 
@@ -120,7 +120,7 @@ func TestParsePluginToken_RejectsNonUUIDSubject(t *testing.T) {
 	c.Subject = "not-a-uuid"
 	tokenStr := signPluginToken(t, secret, c)
 
-	_, err := ParsePluginToken(tokenStr, secret)
+	_, err := ParsePluginToken(tokenStr, SharedSecret(secret))
 	require.Error(t, err, "token with non-UUID subject must be rejected")
 }
 
@@ -131,6 +131,6 @@ func TestParsePluginToken_RejectsNonHS256Method(t *testing.T) {
 	tokenStr, err := tok.SignedString(secret)
 	require.NoError(t, err)
 
-	_, err = ParsePluginToken(tokenStr, secret)
+	_, err = ParsePluginToken(tokenStr, SharedSecret(secret))
 	require.Error(t, err, "non-HS256 signing method must be rejected")
 }
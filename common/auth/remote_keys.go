@@ -0,0 +1,158 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	// remoteKeysMaxAge is how long a fetched key set is trusted before it is
+	// fetched again, so a key the issuer retired stops verifying.
+	remoteKeysMaxAge = 15 * time.Minute
+	// remoteKeysMinInterval rate-limits fetches, so a stream of tokens with an
+	// unknown kid cannot turn every validation into a request to the issuer.
+	remoteKeysMinInterval  = 30 * time.Second
+	remoteKeysFetchTimeout = 10 * time.Second
+	maxJWKSBytes           = 1 << 20
+)
+
+// RemoteKeys verifies tokens with the JSON Web Key Set published by the
+// issuer. Keys are cached by kid; a token signed with a kid the cache does
+// not know triggers a refetch, which is how a validator picks up a key the
+// issuer has just rotated in.
+type RemoteKeys struct {
+	url    string
+	client *http.Client
+	logger *slog.Logger
+	now    func() time.Time
+
+	// fetchMu serializes fetches; mu guards the cached state below.
+	fetchMu     sync.Mutex
+	mu          sync.RWMutex
+	keys        map[string]jose.JSONWebKey
+	fetchedAt   time.Time
+	attemptedAt time.Time
+}
+
+// NewRemoteKeys creates a RemoteKeys for the key set at url. Nothing is
+// fetched until the first token is verified. Logger is optional.
+func NewRemoteKeys(url string, logger *slog.Logger) *RemoteKeys {
+	if logger == nil {
+		logger = slog.New(slog.NewTextHandler(io.Discard, nil))
+	}
+	return &RemoteKeys{
+		url:    url,
+		client: &http.Client{Timeout: remoteKeysFetchTimeout},
+		logger: logger,
+		now:    time.Now,
+		keys:   map[string]jose.JSONWebKey{},
+	}
+}
+
+// VerificationKey implements Keys.
+func (r *RemoteKeys) VerificationKey(token *jwt.Token) (any, error) {
+	kid, _ := token.Header["kid"].(string)
+	if kid == "" {
+		return nil, fmt.Errorf("token has no kid header")
+	}
+
+	key, found, stale := r.lookup(kid)
+	if !found || stale {
+		if err := r.refresh(); err != nil {
+			if !found {
+				return nil, err
+			}
+			// A stale key is still better than none while the issuer is down.
+			r.logger.Warn("refreshing JWKS failed, using cached keys", "url", r.url, "error", err)
+		}
+		key, found, _ = r.lookup(kid)
+	}
+	if !found {
+		return nil, fmt.Errorf("unknown signing key %q", kid)
+	}
+
+	if key.Algorithm != token.Method.Alg() {
+		return nil, fmt.Errorf("signing key %q is for %s, token is signed with %s", kid, key.Algorithm, token.Method.Alg())
+	}
+	return key.Key, nil
+}
+
+func (r *RemoteKeys) lookup(kid string) (jose.JSONWebKey, bool, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	key, found := r.keys[kid]
+	return key, found, r.now().Sub(r.fetchedAt) > remoteKeysMaxAge
+}
+
+// refresh fetches the key set unless another fetch was attempted within
+// remoteKeysMinInterval.
+func (r *RemoteKeys) refresh() error {
+	r.fetchMu.Lock()
+	defer r.fetchMu.Unlock()
+
+	r.mu.RLock()
+	attemptedAt := r.attemptedAt
+	r.mu.RUnlock()
+	if r.now().Sub(attemptedAt) < remoteKeysMinInterval {
+		return nil
+	}
+
+	keys, err := r.fetch()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.attemptedAt = r.now()
+	if err != nil {
+		return err
+	}
+	r.keys = keys
+	r.fetchedAt = r.attemptedAt
+	r.logger.Debug("fetched JWKS", "url", r.url, "keys", len(keys))
+	return nil
+}
+
+func (r *RemoteKeys) fetch() (map[string]jose.JSONWebKey, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), remoteKeysFetchTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, r.url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("creating JWKS request: %w", err)
+	}
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetching JWKS: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetching JWKS: unexpected status %d", resp.StatusCode)
+	}
+
+	var set jose.JSONWebKeySet
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxJWKSBytes)).Decode(&set); err != nil {
+		return nil, fmt.Errorf("decoding JWKS: %w", err)
+	}
+
+	keys := make(map[string]jose.JSONWebKey, len(set.Keys))
+	for _, key := range set.Keys {
+		if key.KeyID == "" || key.Algorithm == "" || !key.IsPublic() || !key.Valid() {
+			r.logger.Warn("skipping unusable JWKS entry", "url", r.url, "kid", key.KeyID, "alg", key.Algorithm)
+			continue
+		}
+		if key.Use != "" && key.Use != "sig" {
+			continue
+		}
+		keys[key.KeyID] = key
+	}
+	return keys, nil
+}
@@ -0,0 +1,146 @@
+package auth
+
+import (
+	"crypto/x509"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+// jwksServer serves the JWKS of whichever signer is current and counts fetches.
+type jwksServer struct {
+	*httptest.Server
+	signer  atomic.Pointer[Signer]
+	fetches atomic.Int32
+}
+
+func newJWKSServer(t *testing.T, signer *Signer) *jwksServer {
+	t.Helper()
+	s := &jwksServer{}
+	s.signer.Store(signer)
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.fetches.Add(1)
+		s.signer.Load().JWKSHandler().ServeHTTP(w, r)
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func TestRemoteKeys_VerifiesIssuerToken(t *testing.T) {
+	signer, err := NewSigner(newEd25519Key(t))
+	require.NoError(t, err)
+	srv := newJWKSServer(t, signer)
+
+	token, err := signer.Sign(validUserClaims(uuid.New().String()))
+	require.NoError(t, err)
+
+	keys := NewRemoteKeys(srv.URL, nil)
+	v := NewValidatorForAudience(keys, ConsoleAuthCookieName, ConsoleIssuer, TokenTypeUser, nil)
+	for range 3 {
+		_, err = v.Validate(bearer(token))
+		require.NoError(t, err)
+	}
+	require.Equal(t, int32(1), srv.fetches.Load(), "key set must be cached")
+}
+
+func TestRemoteKeys_PicksUpRotatedKey(t *testing.T) {
+	oldKey := newRSAKey(t)
+	before, err := NewSigner(oldKey)
+	require.NoError(t, err)
+	srv := newJWKSServer(t, before)
+
+	now := time.Now()
+	keys := NewRemoteKeys(srv.URL, nil)
+	keys.now = func() time.Time { return now }
+	v := NewValidator(keys, ConsoleAuthCookieName, ConsoleIssuer, nil)
+
+	oldToken, err := before.Sign(validUserClaims(uuid.New().String()))
+	require.NoError(t, err)
+	_, err = v.Validate(bearer(oldToken))
+	require.NoError(t, err)
+
+	after, err := NewSigner(oldKey, newEd25519Key(t))
+	require.NoError(t, err)
+	srv.signer.Store(after)
+	newToken, err := after.Sign(validUserClaims(uuid.New().String()))
+	require.NoError(t, err)
+
+	// Within the rate limit the unknown kid is not fetched.
+	_, err = v.Validate(bearer(newToken))
+	require.Error(t, err)
+
+	now = now.Add(remoteKeysMinInterval)
+	_, err = v.Validate(bearer(newToken))
+	require.NoError(t, err, "unknown kid must trigger a refetch")
+	_, err = v.Validate(bearer(oldToken))
+	require.NoError(t, err, "the retired key is still published")
+	require.Equal(t, int32(2), srv.fetches.Load())
+}
+
+func TestRemoteKeys_KeepsCachedKeysWhenIssuerIsDown(t *testing.T) {
+	signer, err := NewSigner(newEd25519Key(t))
+	require.NoError(t, err)
+	srv := newJWKSServer(t, signer)
+
+	now := time.Now()
+	keys := NewRemoteKeys(srv.URL, nil)
+	keys.now = func() time.Time { return now }
+	v := NewValidator(keys, ConsoleAuthCookieName, ConsoleIssuer, nil)
+
+	token, err := signer.Sign(validUserClaims(uuid.New().String()))
+	require.NoError(t, err)
+	_, err = v.Validate(bearer(token))
+	require.NoError(t, err)
+
+	srv.Close()
+	now = now.Add(remoteKeysMaxAge + time.Second)
+	_, err = v.Validate(bearer(token))
+	require.NoError(t, err)
+}
+
+func TestRemoteKeys_RejectsAlgorithmConfusion(t *testing.T) {
+	signer, err := NewSigner(newRSAKey(t))
+	require.NoError(t, err)
+	srv := newJWKSServer(t, signer)
+
+	// Sign HS256 with the published public key as the HMAC secret, claiming
+	// the RSA key's kid.
+	jwk := signer.JWKS().Keys[0]
+	der, err := x509.MarshalPKIXPublicKey(jwk.Key)
+	require.NoError(t, err)
+	tok := jwt.NewWithClaims(jwt.SigningMethodHS256, validUserClaims(uuid.New().String()))
+	tok.Header["kid"] = jwk.KeyID
+	forged, err := tok.SignedString(der)
+	require.NoError(t, err)
+
+	v := NewValidator(NewRemoteKeys(srv.URL, nil), ConsoleAuthCookieName, ConsoleIssuer, nil)
+	_, err = v.Validate(bearer(forged))
+	require.Error(t, err)
+}
+
+func TestNewKeys_AcceptsBothDuringMigration(t *testing.T) {
+	signer, err := NewSigner(newEd25519Key(t))
+	require.NoError(t, err)
+	srv := newJWKSServer(t, signer)
+
+	keys, err := NewKeys(srv.URL, testSecret, nil)
+	require.NoError(t, err)
+	v := NewValidator(keys, ConsoleAuthCookieName, ConsoleIssuer, nil)
+
+	asymmetric, err := signer.Sign(validUserClaims(uuid.New().String()))
+	require.NoError(t, err)
+	_, err = v.Validate(bearer(asymmetric))
+	require.NoError(t, err)
+
+	_, err = v.Validate(bearer(signToken(t, validUserClaims(uuid.New().String()))))
+	require.NoError(t, err)
+
+	_, err = NewKeys("", nil, nil)
+	require.Error(t, err)
+}
@@ -0,0 +1,65 @@
+package auth
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// Scope restricts a token exchanged for a scoped API key. It narrows what
+// the user behind the token may do; it never grants anything the user's own
+// permissions do not. An empty list does not restrict on that dimension.
+type Scope struct {
+	// OrganizationID is the organization the API key belongs to. A scoped
+	// token is only valid there.
+	OrganizationID uuid.UUID   `json:"organization_id"`
+	ProjectIDs     []uuid.UUID `json:"project_ids,omitempty"`
+	ClusterIDs     []uuid.UUID `json:"cluster_ids,omitempty"`
+	Actions        []string    `json:"actions,omitempty"`
+	ReadOnly       bool        `json:"read_only,omitempty"`
+}
+
+// RestrictsResources reports whether the scope limits the token to specific
+// projects or clusters.
+func (s *Scope) RestrictsResources() bool {
+	return len(s.ProjectIDs) > 0 || len(s.ClusterIDs) > 0
+}
+
+// AllowsAction reports whether the scope permits the authorization action,
+// e.g. "can_view" or "can_create_namespace".
+func (s *Scope) AllowsAction(action string) bool {
+	if s.ReadOnly && !IsReadAction(action) {
+		return false
+	}
+	return len(s.Actions) == 0 || slices.Contains(s.Actions, action)
+}
+
+// IncludesProject reports whether the project, which belongs to clusterID,
+// is in scope.
+func (s *Scope) IncludesProject(projectID, clusterID uuid.UUID) bool {
+	return !s.RestrictsResources() || slices.Contains(s.ProjectIDs, projectID) || slices.Contains(s.ClusterIDs, clusterID)
+}
+
+// IncludesCluster reports whether the cluster is in scope. A token scoped to
+// projects only does not reach the clusters those projects live on.
+func (s *Scope) IncludesCluster(clusterID uuid.UUID) bool {
+	return !s.RestrictsResources() || slices.Contains(s.ClusterIDs, clusterID)
+}
+
+// IsReadAction reports whether an authorization action only reads.
+func IsReadAction(action string) bool {
+	return action == "can_view" || strings.HasPrefix(action, "can_list_")
+}
+
+// IsReadProcedure reports whether a Connect procedure only reads, judged by
+// the Get/List/Watch/Stream/Export naming convention of its method.
+func IsReadProcedure(procedure string) bool {
+	method := procedure[strings.LastIndex(procedure, "/")+1:]
+	for _, prefix := range []string{"Get", "List", "Watch", "Stream", "Export"} {
+		if strings.HasPrefix(method, prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,47 @@
+package auth
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScope_AllowsAction(t *testing.T) {
+	readOnly := &Scope{ReadOnly: true}
+	require.True(t, readOnly.AllowsAction("can_view"))
+	require.True(t, readOnly.AllowsAction("can_list_projects"))
+	require.False(t, readOnly.AllowsAction("can_edit"))
+
+	actions := &Scope{Actions: []string{"can_view", "can_create_namespace"}}
+	require.True(t, actions.AllowsAction("can_create_namespace"))
+	require.False(t, actions.AllowsAction("can_delete"))
+
+	require.True(t, (&Scope{}).AllowsAction("can_delete"))
+}
+
+func TestScope_Includes(t *testing.T) {
+	clusterID, projectID, otherID := uuid.New(), uuid.New(), uuid.New()
+
+	byCluster := &Scope{ClusterIDs: []uuid.UUID{clusterID}}
+	require.True(t, byCluster.IncludesCluster(clusterID))
+	require.True(t, byCluster.IncludesProject(otherID, clusterID), "a cluster in scope includes its projects")
+	require.False(t, byCluster.IncludesProject(projectID, otherID))
+
+	byProject := &Scope{ProjectIDs: []uuid.UUID{projectID}}
+	require.True(t, byProject.IncludesProject(projectID, clusterID))
+	require.False(t, byProject.IncludesCluster(clusterID), "a project in scope does not include its cluster")
+
+	unrestricted := &Scope{ReadOnly: true}
+	require.True(t, unrestricted.IncludesCluster(clusterID))
+	require.True(t, unrestricted.IncludesProject(projectID, clusterID))
+}
+
+func TestIsReadProcedure(t *testing.T) {
+	require.True(t, IsReadProcedure("/organization.v1.ProjectService/GetProject"))
+	require.True(t, IsReadProcedure("/organization.v1.ClusterService/ListClusters"))
+	require.True(t, IsReadProcedure("/organization.v1.MetricsService/StreamOrgWorkloadMetrics"))
+	require.True(t, IsReadProcedure("/organization.v1.AuditService/ExportAuditEvents"))
+	require.False(t, IsReadProcedure("/organization.v1.ProjectService/DeleteProject"))
+	require.False(t, IsReadProcedure("/organization.v1.InviteService/AcceptInvitation"))
+}
@@ -0,0 +1,76 @@
+package auth
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func signServiceToken(t *testing.T, secret []byte, c *ServiceClaims) string {
+	t.Helper()
+	tok := jwt.NewWithClaims(jwt.SigningMethodHS256, c)
+	s, err := tok.SignedString(secret)
+	require.NoError(t, err, "sign")
+	return s
+}
+
+func validServiceClaims() *ServiceClaims {
+	return &ServiceClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    ConsoleIssuer,
+			Subject:   "plugin-controller",
+			Audience:  jwt.ClaimStrings{TokenTypeService},
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+		},
+		OrganizationID: uuid.New(),
+		ClusterID:      uuid.New().String(),
+	}
+}
+
+func TestParseServiceToken_AcceptsValidToken(t *testing.T) {
+	secret := []byte("test-secret")
+	want := validServiceClaims()
+
+	got, err := ParseServiceToken(signServiceToken(t, secret, want), SharedSecret(secret))
+	require.NoError(t, err, "ParseServiceToken")
+	assert.Equal(t, "plugin-controller", got.Subject)
+	assert.Equal(t, want.OrganizationID, got.OrganizationID)
+	assert.Equal(t, want.ClusterID, got.ClusterID)
+}
+
+func TestParseServiceToken_RejectsInvalidTokens(t *testing.T) {
+	secret := []byte("test-secret")
+	tests := map[string]func(c *ServiceClaims){
+		"user audience":   func(c *ServiceClaims) { c.Audience = jwt.ClaimStrings{TokenTypeUser} },
+		"plugin audience": func(c *ServiceClaims) { c.Audience = jwt.ClaimStrings{TokenTypePlugin} },
+		"expired":         func(c *ServiceClaims) { c.ExpiresAt = jwt.NewNumericDate(time.Now().Add(-time.Hour)) },
+		"missing exp":     func(c *ServiceClaims) { c.ExpiresAt = nil },
+		"wrong issuer":    func(c *ServiceClaims) { c.Issuer = DCIMIssuer },
+		"no subject":      func(c *ServiceClaims) { c.Subject = "" },
+		"no organization": func(c *ServiceClaims) { c.OrganizationID = uuid.Nil },
+	}
+	for name, mutate := range tests {
+		t.Run(name, func(t *testing.T) {
+			c := validServiceClaims()
+			mutate(c)
+			_, err := ParseServiceToken(signServiceToken(t, secret, c), SharedSecret(secret))
+			require.Error(t, err)
+		})
+	}
+}
+
+// TestParseServiceToken_NotAUserToken verifies a service token cannot stand in
+// for a user token, even at a validator that accepts any audience.
+func TestParseServiceToken_NotAUserToken(t *testing.T) {
+	secret := []byte("test-secret")
+	tokenStr := signServiceToken(t, secret, validServiceClaims())
+
+	v := NewValidator(SharedSecret(secret), ConsoleAuthCookieName, ConsoleIssuer, nil)
+	_, err := v.validateToken(tokenStr)
+	require.Error(t, err)
+}
@@ -0,0 +1,326 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// JWKSPath is where authn-api publishes the public half of its signing keys.
+const JWKSPath = "/.well-known/jwks.json"
+
+// signingKey is one key of a Signer. For a shared secret, kid and public are
+// empty and key is the secret itself.
+type signingKey struct {
+	kid    string
+	method jwt.SigningMethod
+	key    any
+	public jose.JSONWebKey
+}
+
+// keySet is the state a Signer swaps as a whole when its keys are reloaded:
+// the keys, the one that signs, and the JWKS document published for them.
+type keySet struct {
+	keys   []signingKey
+	active signingKey
+	body   []byte
+	etag   string
+}
+
+// Signer mints tokens with the newest key of a rotating key set and
+// publishes every key in the set, so tokens signed with a retired key keep
+// verifying until the key is removed from the set.
+type Signer struct {
+	dir    string
+	secret SharedSecret
+	set    atomic.Pointer[keySet]
+}
+
+// NewSigner creates a Signer over RSA and Ed25519 private keys, ordered
+// oldest first. The last key signs new tokens.
+func NewSigner(keys ...crypto.Signer) (*Signer, error) {
+	set, err := newKeySet(keys)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Signer{}
+	s.set.Store(set)
+	return s, nil
+}
+
+// NewSharedSecretSigner creates a Signer that signs HS256 with a secret
+// shared with every validator. Its key set is empty.
+func NewSharedSecretSigner(secret []byte) *Signer {
+	body := []byte(`{"keys":[]}`)
+	s := &Signer{secret: SharedSecret(secret)}
+	s.set.Store(&keySet{
+		active: signingKey{method: jwt.SigningMethodHS256, key: secret},
+		body:   body,
+		etag:   fmt.Sprintf(`"%x"`, sha256.Sum256(body)),
+	})
+	return s
+}
+
+func newKeySet(keys []crypto.Signer) (*keySet, error) {
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("at least one signing key is required")
+	}
+
+	set := &keySet{}
+	for _, key := range keys {
+		sk, err := newSigningKey(key)
+		if err != nil {
+			return nil, err
+		}
+		if slices.ContainsFunc(set.keys, func(k signingKey) bool { return k.kid == sk.kid }) {
+			return nil, fmt.Errorf("duplicate signing key %q", sk.kid)
+		}
+		set.keys = append(set.keys, sk)
+	}
+	set.active = set.keys[len(set.keys)-1]
+
+	body, err := json.Marshal(set.jwks())
+	if err != nil {
+		return nil, fmt.Errorf("marshaling JWKS: %w", err)
+	}
+	set.body = body
+	set.etag = fmt.Sprintf(`"%x"`, sha256.Sum256(body))
+	return set, nil
+}
+
+func (k *keySet) jwks() jose.JSONWebKeySet {
+	set := jose.JSONWebKeySet{Keys: make([]jose.JSONWebKey, 0, len(k.keys))}
+	for _, key := range k.keys {
+		set.Keys = append(set.Keys, key.public)
+	}
+	return set
+}
+
+func (k *keySet) kids() []string {
+	kids := make([]string, 0, len(k.keys))
+	for _, key := range k.keys {
+		kids = append(kids, key.kid)
+	}
+	return kids
+}
+
+// LoadSigner creates a Signer from the PEM private keys in dir, typically a
+// mounted Secret. Files are ordered by name, so naming them by creation date
+// makes the newest key the active one. Rotating is adding a key, and later,
+// once every token it could have signed has expired, removing the oldest.
+// Use Watch to pick up such changes without a restart.
+func LoadSigner(dir string) (*Signer, error) {
+	set, err := readKeySet(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Signer{dir: dir}
+	s.set.Store(set)
+	return s, nil
+}
+
+// Reload reads the key directory again and, if it is valid, swaps the key set
+// in. On error the current keys stay in use. It reports whether the set of
+// keys changed; a Signer not created by LoadSigner never changes.
+func (s *Signer) Reload() (bool, error) {
+	if s.dir == "" {
+		return false, nil
+	}
+
+	set, err := readKeySet(s.dir)
+	if err != nil {
+		return false, err
+	}
+	if slices.Equal(set.kids(), s.set.Load().kids()) {
+		return false, nil
+	}
+	s.set.Store(set)
+	return true, nil
+}
+
+// Watch calls Reload every interval until ctx is done, so keys added to or
+// removed from a mounted Secret take effect without a restart. A directory
+// that fails to load, for example halfway through an update, is logged and
+// retried on the next tick.
+func (s *Signer) Watch(ctx context.Context, interval time.Duration, logger *slog.Logger) {
+	if s.dir == "" {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		changed, err := s.Reload()
+		if err != nil {
+			logger.Error("failed to reload JWT signing keys, keeping the current ones", "error", err)
+			continue
+		}
+		if changed {
+			logger.Info("reloaded JWT signing keys", "keys", len(s.JWKS().Keys))
+		}
+	}
+}
+
+func readKeySet(dir string) (*keySet, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("reading signing keys: %w", err)
+	}
+
+	var keys []crypto.Signer
+	for _, entry := range entries {
+		// Kubernetes projects Secret keys through ..data symlinks; skip those
+		// and anything else that is not a regular key file.
+		if entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		key, err := readPrivateKey(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+
+	return newKeySet(keys)
+}
+
+func readPrivateKey(path string) (crypto.Signer, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // path comes from the operator-configured key directory
+	if err != nil {
+		return nil, fmt.Errorf("reading signing key %s: %w", path, err)
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("signing key %s: no PEM block found", path)
+	}
+
+	var parsed any
+	switch block.Type {
+	case "PRIVATE KEY":
+		parsed, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		parsed, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("signing key %s: unsupported PEM block %q", path, block.Type)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("parsing signing key %s: %w", path, err)
+	}
+
+	key, ok := parsed.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("signing key %s: unsupported key type %T", path, parsed)
+	}
+	return key, nil
+}
+
+func newSigningKey(key crypto.Signer) (signingKey, error) {
+	var method jwt.SigningMethod
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		if k.N.BitLen() < 2048 {
+			return signingKey{}, fmt.Errorf("RSA signing key must be at least 2048 bits, got %d", k.N.BitLen())
+		}
+		method = jwt.SigningMethodRS256
+	case ed25519.PrivateKey:
+		method = jwt.SigningMethodEdDSA
+	default:
+		return signingKey{}, fmt.Errorf("unsupported signing key type %T", key)
+	}
+
+	public := jose.JSONWebKey{
+		Key:       key.Public(),
+		Algorithm: method.Alg(),
+		Use:       "sig",
+	}
+	thumbprint, err := public.Thumbprint(crypto.SHA256)
+	if err != nil {
+		return signingKey{}, fmt.Errorf("computing key thumbprint: %w", err)
+	}
+	public.KeyID = base64.RawURLEncoding.EncodeToString(thumbprint)
+
+	return signingKey{kid: public.KeyID, method: method, key: key, public: public}, nil
+}
+
+// Sign signs the claims with the active key.
+func (s *Signer) Sign(claims jwt.Claims) (string, error) {
+	active := s.set.Load().active
+	token := jwt.NewWithClaims(active.method, claims)
+	if active.kid != "" {
+		token.Header["kid"] = active.kid
+	}
+	signed, err := token.SignedString(active.key)
+	if err != nil {
+		return "", fmt.Errorf("signing token: %w", err)
+	}
+	return signed, nil
+}
+
+// VerificationKey implements Keys, so the issuer verifies its own tokens
+// without a round trip through its JWKS endpoint.
+func (s *Signer) VerificationKey(token *jwt.Token) (any, error) {
+	if s.secret != nil {
+		return s.secret.VerificationKey(token)
+	}
+
+	kid, _ := token.Header["kid"].(string)
+	for _, key := range s.set.Load().keys {
+		if key.kid != kid {
+			continue
+		}
+		if key.method.Alg() != token.Method.Alg() {
+			return nil, fmt.Errorf("signing key %q is for %s, token is signed with %s", kid, key.method.Alg(), token.Method.Alg())
+		}
+		return key.public.Key, nil
+	}
+	return nil, fmt.Errorf("unknown signing key %q", kid)
+}
+
+// JWKS returns the public keys of the set.
+func (s *Signer) JWKS() jose.JSONWebKeySet {
+	return s.set.Load().jwks()
+}
+
+// JWKSHandler serves the current key set as application/json.
+func (s *Signer) JWKSHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.Header().Set("Allow", "GET, HEAD")
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		set := s.set.Load()
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "public, max-age=300")
+		w.Header().Set("ETag", set.etag)
+		_, _ = w.Write(set.body)
+	})
+}
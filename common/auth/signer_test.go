@@ -0,0 +1,191 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-jose/go-jose/v4"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func newEd25519Key(t *testing.T) ed25519.PrivateKey {
+	t.Helper()
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	return key
+}
+
+func newRSAKey(t *testing.T) *rsa.PrivateKey {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	return key
+}
+
+func bearer(token string) http.Header {
+	header := http.Header{}
+	header.Set("Authorization", "Bearer "+token)
+	return header
+}
+
+func TestSigner_SignsWithNewestKey(t *testing.T) {
+	signer, err := NewSigner(newRSAKey(t), newEd25519Key(t))
+	require.NoError(t, err)
+
+	token, err := signer.Sign(validUserClaims(uuid.New().String()))
+	require.NoError(t, err)
+
+	parsed, _, err := jwt.NewParser().ParseUnverified(token, &Claims{})
+	require.NoError(t, err)
+	require.Equal(t, jwt.SigningMethodEdDSA.Alg(), parsed.Method.Alg())
+	require.Equal(t, signer.JWKS().Keys[1].KeyID, parsed.Header["kid"])
+
+	v := NewValidatorForAudience(signer, ConsoleAuthCookieName, ConsoleIssuer, TokenTypeUser, nil)
+	_, err = v.Validate(bearer(token))
+	require.NoError(t, err)
+}
+
+func TestSigner_RetiredKeyStillVerifies(t *testing.T) {
+	oldKey := newRSAKey(t)
+	before, err := NewSigner(oldKey)
+	require.NoError(t, err)
+	token, err := before.Sign(validUserClaims(uuid.New().String()))
+	require.NoError(t, err)
+
+	after, err := NewSigner(oldKey, newEd25519Key(t))
+	require.NoError(t, err)
+
+	v := NewValidator(after, ConsoleAuthCookieName, ConsoleIssuer, nil)
+	_, err = v.Validate(bearer(token))
+	require.NoError(t, err, "a token signed before rotation must verify while its key is published")
+
+	removed, err := NewSigner(newEd25519Key(t))
+	require.NoError(t, err)
+	v = NewValidator(removed, ConsoleAuthCookieName, ConsoleIssuer, nil)
+	_, err = v.Validate(bearer(token))
+	require.Error(t, err, "a token signed with a removed key must not verify")
+}
+
+func TestSigner_JWKSHasNoPrivateMaterial(t *testing.T) {
+	signer, err := NewSigner(newRSAKey(t), newEd25519Key(t))
+	require.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	signer.JWKSHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, JWKSPath, nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var set jose.JSONWebKeySet
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &set))
+	require.Len(t, set.Keys, 2)
+	for _, key := range set.Keys {
+		require.True(t, key.IsPublic(), "kid %s", key.KeyID)
+		require.NotEmpty(t, key.KeyID)
+		require.Equal(t, "sig", key.Use)
+	}
+	require.NotContains(t, rec.Body.String(), `"d":`)
+}
+
+func TestNewSigner_RejectsWeakRSAKey(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 1024) //nolint:gosec // deliberately weak
+	require.NoError(t, err)
+
+	_, err = NewSigner(key)
+	require.Error(t, err)
+}
+
+func writePEMKey(t *testing.T, path string, key crypto.Signer) {
+	t.Helper()
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0o600))
+}
+
+func TestLoadSigner_NewestFileSigns(t *testing.T) {
+	dir := t.TempDir()
+	writePEMKey(t, filepath.Join(dir, "2026-01.pem"), newRSAKey(t))
+	writePEMKey(t, filepath.Join(dir, "2026-07.pem"), newEd25519Key(t))
+	// Kubernetes Secret mounts carry ..data bookkeeping next to the keys.
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "..data"), 0o700))
+
+	signer, err := LoadSigner(dir)
+	require.NoError(t, err)
+	require.Len(t, signer.JWKS().Keys, 2)
+	require.Equal(t, jwt.SigningMethodEdDSA, signer.set.Load().active.method)
+}
+
+func TestSigner_ReloadPicksUpRotation(t *testing.T) {
+	dir := t.TempDir()
+	writePEMKey(t, filepath.Join(dir, "2026-01.pem"), newRSAKey(t))
+	signer, err := LoadSigner(dir)
+	require.NoError(t, err)
+
+	oldToken, err := signer.Sign(validUserClaims(uuid.New().String()))
+	require.NoError(t, err)
+
+	changed, err := signer.Reload()
+	require.NoError(t, err)
+	require.False(t, changed, "an unchanged directory must not swap the key set")
+
+	writePEMKey(t, filepath.Join(dir, "2026-07.pem"), newEd25519Key(t))
+	changed, err = signer.Reload()
+	require.NoError(t, err)
+	require.True(t, changed)
+
+	rec := httptest.NewRecorder()
+	signer.JWKSHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, JWKSPath, nil))
+	var set jose.JSONWebKeySet
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &set))
+	require.Len(t, set.Keys, 2, "the JWKS endpoint must publish the added key")
+
+	newToken, err := signer.Sign(validUserClaims(uuid.New().String()))
+	require.NoError(t, err)
+	parsed, _, err := jwt.NewParser().ParseUnverified(newToken, &Claims{})
+	require.NoError(t, err)
+	require.Equal(t, jwt.SigningMethodEdDSA.Alg(), parsed.Method.Alg())
+
+	v := NewValidator(signer, ConsoleAuthCookieName, ConsoleIssuer, nil)
+	_, err = v.Validate(bearer(oldToken))
+	require.NoError(t, err, "the retired key stays published until it is removed")
+
+	require.NoError(t, os.Remove(filepath.Join(dir, "2026-01.pem")))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "2026-09.pem"), []byte("not a key"), 0o600))
+	_, err = signer.Reload()
+	require.Error(t, err)
+	require.Len(t, signer.JWKS().Keys, 2, "a broken directory must leave the current keys in place")
+
+	require.NoError(t, os.Remove(filepath.Join(dir, "2026-09.pem")))
+	changed, err = signer.Reload()
+	require.NoError(t, err)
+	require.True(t, changed)
+	_, err = v.Validate(bearer(oldToken))
+	require.Error(t, err, "a token signed with a removed key must not verify")
+}
+
+func TestLoadSigner_EmptyDirectory(t *testing.T) {
+	_, err := LoadSigner(t.TempDir())
+	require.Error(t, err)
+}
+
+func TestSharedSecretSigner_PublishesNoKeys(t *testing.T) {
+	signer := NewSharedSecretSigner(testSecret)
+	require.Empty(t, signer.JWKS().Keys)
+
+	token, err := signer.Sign(validUserClaims(uuid.New().String()))
+	require.NoError(t, err)
+
+	v := NewValidator(SharedSecret(testSecret), ConsoleAuthCookieName, ConsoleIssuer, nil)
+	_, err = v.Validate(bearer(token))
+	require.NoError(t, err, "shared-secret signer must stay compatible with existing validators")
+}
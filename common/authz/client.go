@@ -5,8 +5,11 @@ import (
 	"errors"
 	"fmt"
 	"maps"
+	"strconv"
+	"time"
 
 	"github.com/google/uuid"
+	openfga "github.com/openfga/go-sdk"
 	"github.com/openfga/go-sdk/client"
 )
 
@@ -69,6 +72,21 @@ func (c *Client) Healthy(ctx context.Context) error {
 // Evaluate performs a single access evaluation following the AuthZEN Access Evaluation API.
 // Returns a Decision indicating whether the subject can perform the action on the resource.
 func (c *Client) Evaluate(ctx context.Context, req EvaluationRequest) (Decision, error) {
+	resp, err := c.fga.Check(ctx).Body(checkRequest(req)).Execute()
+	if err != nil {
+		return Decision{Decision: false}, fmt.Errorf("check: %w", err)
+	}
+
+	decision := false
+	if resp.Allowed != nil {
+		decision = *resp.Allowed
+	}
+
+	return Decision{Decision: decision}, nil
+}
+
+// checkRequest builds the OpenFGA check request for an evaluation.
+func checkRequest(req EvaluationRequest) client.ClientCheckRequest {
 	// Build OpenFGA check context from the AuthZEN request context and any
 	// object/action properties, so that conditions can be evaluated correctly.
 	checkContext := map[string]any{}
@@ -76,6 +94,12 @@ func (c *Client) Evaluate(ctx context.Context, req EvaluationRequest) (Decision,
 		checkContext = maps.Clone(req.Context)
 	}
 
+	// Conditional tuples such as elevated roles expire; without current_time
+	// OpenFGA cannot evaluate their condition and the check errors.
+	if _, ok := checkContext["current_time"]; !ok {
+		checkContext["current_time"] = time.Now().UTC().Format(time.RFC3339)
+	}
+
 	// Map resource (object) properties into the context under the "object" key.
 	if req.Resource.Properties != nil {
 		objProps, ok := checkContext["object"].(map[string]any)
@@ -109,17 +133,7 @@ func (c *Client) Evaluate(ctx context.Context, req EvaluationRequest) (Decision,
 		checkReq.Context = &checkContext
 	}
 
-	resp, err := c.fga.Check(ctx).Body(checkReq).Execute()
-	if err != nil {
-		return Decision{Decision: false}, fmt.Errorf("check: %w", err)
-	}
-
-	decision := false
-	if resp.Allowed != nil {
-		decision = *resp.Allowed
-	}
-
-	return Decision{Decision: decision}, nil
+	return checkReq
 }
 
 // Evaluations performs batch access evaluations following the AuthZEN Access Evaluations API.
@@ -135,6 +149,12 @@ func (c *Client) Evaluations(ctx context.Context, req EvaluationsRequest) (Evalu
 		}
 	}
 
+	// Without short-circuiting, all evaluations go to OpenFGA in one batch
+	// check instead of one check each.
+	if semantic == ExecuteAll && len(req.Evaluations) > 1 {
+		return c.batchEvaluate(ctx, req)
+	}
+
 	results := make([]Decision, 0, len(req.Evaluations))
 
 	for _, eval := range req.Evaluations {
@@ -166,6 +186,47 @@ func (c *Client) Evaluations(ctx context.Context, req EvaluationsRequest) (Evalu
 	return EvaluationsResponse{Evaluations: results}, nil
 }
 
+// batchEvaluate performs the evaluations of req with a single OpenFGA batch
+// check, which the SDK splits into requests of at most 50 checks.
+func (c *Client) batchEvaluate(ctx context.Context, req EvaluationsRequest) (EvaluationsResponse, error) {
+	checks := make([]client.ClientBatchCheckItem, 0, len(req.Evaluations))
+	for i, eval := range req.Evaluations {
+		checkReq := checkRequest(mergeEvaluation(eval, req))
+		checks = append(checks, client.ClientBatchCheckItem{
+			User:          checkReq.User,
+			Relation:      checkReq.Relation,
+			Object:        checkReq.Object,
+			CorrelationId: strconv.Itoa(i),
+			Context:       checkReq.Context,
+		})
+	}
+
+	resp, err := c.fga.BatchCheck(ctx).Body(client.ClientBatchCheckRequest{Checks: checks}).Execute()
+	if err != nil {
+		return EvaluationsResponse{}, fmt.Errorf("batch check: %w", err)
+	}
+
+	return batchDecisions(len(checks), resp.GetResult())
+}
+
+// batchDecisions orders the results of a batch check, keyed by the index of
+// their evaluation, into decisions.
+func batchDecisions(n int, results map[string]openfga.BatchCheckSingleResult) (EvaluationsResponse, error) {
+	decisions := make([]Decision, n)
+	for i := range decisions {
+		result, ok := results[strconv.Itoa(i)]
+		if !ok {
+			return EvaluationsResponse{}, fmt.Errorf("batch check: no result for evaluation %d", i)
+		}
+		if result.Error != nil {
+			return EvaluationsResponse{}, fmt.Errorf("batch check: evaluation %d: %s", i, result.Error.GetMessage())
+		}
+		decisions[i] = Decision{Decision: result.GetAllowed()}
+	}
+
+	return EvaluationsResponse{Evaluations: decisions}, nil
+}
+
 // mergeEvaluation applies defaults from the batch request to an individual evaluation.
 func mergeEvaluation(eval EvaluationRequest, req EvaluationsRequest) EvaluationRequest {
 	result := eval
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/google/uuid"
+	openfga "github.com/openfga/go-sdk"
 )
 
 func TestObjectConstructors(t *testing.T) {
@@ -240,3 +241,33 @@ func TestDecision_DefaultsToFalse(t *testing.T) {
 		t.Errorf("zero-value Decision.Decision = %v, want false", d.Decision)
 	}
 }
+
+func TestBatchDecisions(t *testing.T) {
+	allowed, denied := true, false
+
+	resp, err := batchDecisions(3, map[string]openfga.BatchCheckSingleResult{
+		"0": {Allowed: &denied},
+		"1": {Allowed: &allowed},
+		"2": {},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []bool{false, true, false}
+	for i, d := range resp.Evaluations {
+		if d.Decision != want[i] {
+			t.Errorf("Evaluations[%d].Decision = %v, want %v", i, d.Decision, want[i])
+		}
+	}
+
+	if _, err := batchDecisions(2, map[string]openfga.BatchCheckSingleResult{"0": {Allowed: &allowed}}); err == nil {
+		t.Error("expected error for a missing result, got nil")
+	}
+
+	message := "timeout"
+	_, err = batchDecisions(1, map[string]openfga.BatchCheckSingleResult{"0": {Error: &openfga.CheckError{Message: &message}}})
+	if err == nil || err.Error() != "batch check: evaluation 0: timeout" {
+		t.Errorf("error = %v, want %q", err, "batch check: evaluation 0: timeout")
+	}
+}
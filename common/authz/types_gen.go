@@ -10,6 +10,7 @@ const (
 	ObjectTypeUser          ObjectType = "user"
 	ObjectTypeOrganization  ObjectType = "organization"
 	ObjectTypeProject       ObjectType = "project"
+	ObjectTypeTeam          ObjectType = "team"
 	ObjectTypeProjectMember ObjectType = "project_member"
 	ObjectTypeCluster       ObjectType = "cluster"
 	ObjectTypeNodePool      ObjectType = "node_pool"
@@ -23,33 +24,50 @@ const (
 type ActionName string
 
 const (
-	ActionAdmin              ActionName = "admin"
-	ActionViewer             ActionName = "viewer"
-	ActionCanView            ActionName = "can_view"
-	ActionCanEdit            ActionName = "can_edit"
-	ActionCanCreateApikey    ActionName = "can_create_apikey"
-	ActionCanListApikeys     ActionName = "can_list_apikeys"
-	ActionCanCreateCluster   ActionName = "can_create_cluster"
-	ActionCanListClusters    ActionName = "can_list_clusters"
-	ActionCanInviteMember    ActionName = "can_invite_member"
-	ActionCanEditMember      ActionName = "can_edit_member"
-	ActionCanDeleteMember    ActionName = "can_delete_member"
-	ActionCanListMembers     ActionName = "can_list_members"
-	ActionParent             ActionName = "parent"
-	ActionProjectAdmin       ActionName = "project_admin"
-	ActionProjectViewer      ActionName = "project_viewer"
-	ActionCanDelete          ActionName = "can_delete"
-	ActionCanManageMembers   ActionName = "can_manage_members"
-	ActionCanCreateNamespace ActionName = "can_create_namespace"
-	ActionCanListNamespaces  ActionName = "can_list_namespaces"
-	ActionOwner              ActionName = "owner"
-	ActionCanCreateNodePool  ActionName = "can_create_node_pool"
-	ActionCanListNodePools   ActionName = "can_list_node_pools"
-	ActionCanCreateProject   ActionName = "can_create_project"
-	ActionCanListProjects    ActionName = "can_list_projects"
-	ActionCreator            ActionName = "creator"
-	ActionUsableBy           ActionName = "usable_by"
-	ActionCanUse             ActionName = "can_use"
+	ActionElevatedAdmin               ActionName = "elevated_admin"
+	ActionAdmin                       ActionName = "admin"
+	ActionViewer                      ActionName = "viewer"
+	ActionCanView                     ActionName = "can_view"
+	ActionCanEdit                     ActionName = "can_edit"
+	ActionCanCreateApikey             ActionName = "can_create_apikey"
+	ActionCanListApikeys              ActionName = "can_list_apikeys"
+	ActionCanCreateCluster            ActionName = "can_create_cluster"
+	ActionCanListClusters             ActionName = "can_list_clusters"
+	ActionCanInviteMember             ActionName = "can_invite_member"
+	ActionCanEditMember               ActionName = "can_edit_member"
+	ActionCanDeleteMember             ActionName = "can_delete_member"
+	ActionCanListMembers              ActionName = "can_list_members"
+	ActionCanListAuditEvents          ActionName = "can_list_audit_events"
+	ActionCanManageWebhooks           ActionName = "can_manage_webhooks"
+	ActionCanApprovePluginDefinitions ActionName = "can_approve_plugin_definitions"
+	ActionCanManageTeams              ActionName = "can_manage_teams"
+	ActionCanListTeams                ActionName = "can_list_teams"
+	ActionCanApproveAccessElevations  ActionName = "can_approve_access_elevations"
+	ActionParent                      ActionName = "parent"
+	ActionProjectAdmin                ActionName = "project_admin"
+	ActionProjectViewer               ActionName = "project_viewer"
+	ActionCanDelete                   ActionName = "can_delete"
+	ActionCanManageMembers            ActionName = "can_manage_members"
+	ActionCanCreateNamespace          ActionName = "can_create_namespace"
+	ActionCanListNamespaces           ActionName = "can_list_namespaces"
+	ActionElevated                    ActionName = "elevated"
+	ActionOwner                       ActionName = "owner"
+	ActionMember                      ActionName = "member"
+	ActionCanCreateNodePool           ActionName = "can_create_node_pool"
+	ActionCanListNodePools            ActionName = "can_list_node_pools"
+	ActionCanCreateProject            ActionName = "can_create_project"
+	ActionCanListProjects             ActionName = "can_list_projects"
+	ActionDeployer                    ActionName = "deployer"
+	ActionPodViewer                   ActionName = "pod_viewer"
+	ActionLogViewer                   ActionName = "log_viewer"
+	ActionServiceManager              ActionName = "service_manager"
+	ActionCanDeploy                   ActionName = "can_deploy"
+	ActionCanViewPods                 ActionName = "can_view_pods"
+	ActionCanViewLogs                 ActionName = "can_view_logs"
+	ActionCanManageServices           ActionName = "can_manage_services"
+	ActionCreator                     ActionName = "creator"
+	ActionUsableBy                    ActionName = "usable_by"
+	ActionCanUse                      ActionName = "can_use"
 )
 
 // Object represents an entity in an authorization check (subject or resource).
@@ -148,6 +166,14 @@ func Project(id uuid.UUID) Object {
 	}
 }
 
+// Team creates an Object of type team.
+func Team(id uuid.UUID) Object {
+	return Object{
+		Type: ObjectTypeTeam,
+		ID:   id.String(),
+	}
+}
+
 // ProjectMember creates an Object of type project_member.
 func ProjectMember(id uuid.UUID) Object {
 	return Object{
@@ -198,6 +224,11 @@ func Plugin(id uuid.UUID) Object {
 
 // Action constructors
 
+// ElevatedAdmin creates an Action for the elevated_admin relation.
+func ElevatedAdmin() Action {
+	return Action{Name: ActionElevatedAdmin}
+}
+
 // Admin creates an Action for the admin relation.
 func Admin() Action {
 	return Action{Name: ActionAdmin}
@@ -258,6 +289,36 @@ func CanListMembers() Action {
 	return Action{Name: ActionCanListMembers}
 }
 
+// CanListAuditEvents creates an Action for the can_list_audit_events relation.
+func CanListAuditEvents() Action {
+	return Action{Name: ActionCanListAuditEvents}
+}
+
+// CanManageWebhooks creates an Action for the can_manage_webhooks relation.
+func CanManageWebhooks() Action {
+	return Action{Name: ActionCanManageWebhooks}
+}
+
+// CanApprovePluginDefinitions creates an Action for the can_approve_plugin_definitions relation.
+func CanApprovePluginDefinitions() Action {
+	return Action{Name: ActionCanApprovePluginDefinitions}
+}
+
+// CanManageTeams creates an Action for the can_manage_teams relation.
+func CanManageTeams() Action {
+	return Action{Name: ActionCanManageTeams}
+}
+
+// CanListTeams creates an Action for the can_list_teams relation.
+func CanListTeams() Action {
+	return Action{Name: ActionCanListTeams}
+}
+
+// CanApproveAccessElevations creates an Action for the can_approve_access_elevations relation.
+func CanApproveAccessElevations() Action {
+	return Action{Name: ActionCanApproveAccessElevations}
+}
+
 // Parent creates an Action for the parent relation.
 func Parent() Action {
 	return Action{Name: ActionParent}
@@ -293,11 +354,21 @@ func CanListNamespaces() Action {
 	return Action{Name: ActionCanListNamespaces}
 }
 
+// Elevated creates an Action for the elevated relation.
+func Elevated() Action {
+	return Action{Name: ActionElevated}
+}
+
 // Owner creates an Action for the owner relation.
 func Owner() Action {
 	return Action{Name: ActionOwner}
 }
 
+// Member creates an Action for the member relation.
+func Member() Action {
+	return Action{Name: ActionMember}
+}
+
 // CanCreateNodePool creates an Action for the can_create_node_pool relation.
 func CanCreateNodePool() Action {
 	return Action{Name: ActionCanCreateNodePool}
@@ -318,6 +389,46 @@ func CanListProjects() Action {
 	return Action{Name: ActionCanListProjects}
 }
 
+// Deployer creates an Action for the deployer relation.
+func Deployer() Action {
+	return Action{Name: ActionDeployer}
+}
+
+// PodViewer creates an Action for the pod_viewer relation.
+func PodViewer() Action {
+	return Action{Name: ActionPodViewer}
+}
+
+// LogViewer creates an Action for the log_viewer relation.
+func LogViewer() Action {
+	return Action{Name: ActionLogViewer}
+}
+
+// ServiceManager creates an Action for the service_manager relation.
+func ServiceManager() Action {
+	return Action{Name: ActionServiceManager}
+}
+
+// CanDeploy creates an Action for the can_deploy relation.
+func CanDeploy() Action {
+	return Action{Name: ActionCanDeploy}
+}
+
+// CanViewPods creates an Action for the can_view_pods relation.
+func CanViewPods() Action {
+	return Action{Name: ActionCanViewPods}
+}
+
+// CanViewLogs creates an Action for the can_view_logs relation.
+func CanViewLogs() Action {
+	return Action{Name: ActionCanViewLogs}
+}
+
+// CanManageServices creates an Action for the can_manage_services relation.
+func CanManageServices() Action {
+	return Action{Name: ActionCanManageServices}
+}
+
 // Creator creates an Action for the creator relation.
 func Creator() Action {
 	return Action{Name: ActionCreator}
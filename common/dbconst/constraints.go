@@ -3,6 +3,28 @@
 package dbconst
 
 const (
+	// ConstraintAccessElevationsCkDuration is defined on tenant.access_elevations.
+	ConstraintAccessElevationsCkDuration = "access_elevations_ck_duration"
+	// ConstraintAccessElevationsCkExpires is defined on tenant.access_elevations.
+	ConstraintAccessElevationsCkExpires = "access_elevations_ck_expires"
+	// ConstraintAccessElevationsCkProject is defined on tenant.access_elevations.
+	ConstraintAccessElevationsCkProject = "access_elevations_ck_project"
+	// ConstraintAccessElevationsCkReason is defined on tenant.access_elevations.
+	ConstraintAccessElevationsCkReason = "access_elevations_ck_reason"
+	// ConstraintAccessElevationsCkRole is defined on tenant.access_elevations.
+	ConstraintAccessElevationsCkRole = "access_elevations_ck_role"
+	// ConstraintAccessElevationsCkStatus is defined on tenant.access_elevations.
+	ConstraintAccessElevationsCkStatus = "access_elevations_ck_status"
+	// ConstraintAccessElevationsFkDecidedBy is defined on tenant.access_elevations.
+	ConstraintAccessElevationsFkDecidedBy = "access_elevations_fk_decided_by"
+	// ConstraintAccessElevationsFkOrganization is defined on tenant.access_elevations.
+	ConstraintAccessElevationsFkOrganization = "access_elevations_fk_organization"
+	// ConstraintAccessElevationsFkProject is defined on tenant.access_elevations.
+	ConstraintAccessElevationsFkProject = "access_elevations_fk_project"
+	// ConstraintAccessElevationsFkUser is defined on tenant.access_elevations.
+	ConstraintAccessElevationsFkUser = "access_elevations_fk_user"
+	// ConstraintAccessElevationsUqOpen is defined on tenant.access_elevations.
+	ConstraintAccessElevationsUqOpen = "access_elevations_uq_open"
 	// ConstraintApiKeysFkOrganization is defined on authn.api_keys.
 	ConstraintApiKeysFkOrganization = "api_keys_fk_organization"
 	// ConstraintApiKeysFkUser is defined on authn.api_keys.
@@ -19,6 +41,10 @@ const (
 	ConstraintAssetsUqAssetTag = "assets_uq_asset_tag"
 	// ConstraintAssetsUqSerialNumber is defined on dcim.assets.
 	ConstraintAssetsUqSerialNumber = "assets_uq_serial_number"
+	// ConstraintAuditEventsCkOutcome is defined on tenant.audit_events.
+	ConstraintAuditEventsCkOutcome = "audit_events_ck_outcome"
+	// ConstraintAuditEventsCkSource is defined on tenant.audit_events.
+	ConstraintAuditEventsCkSource = "audit_events_ck_source"
 	// ConstraintCategoriesUqName is defined on appstore.categories.
 	ConstraintCategoriesUqName = "categories_uq_name"
 	// ConstraintClusterEventsCkEventType is defined on tenant.cluster_events.
@@ -27,6 +53,10 @@ const (
 	ConstraintClusterEventsCkSyncAction = "cluster_events_ck_sync_action"
 	// ConstraintClusterEventsFkCluster is defined on tenant.cluster_events.
 	ConstraintClusterEventsFkCluster = "cluster_events_fk_cluster"
+	// ConstraintClusterHibernationSchedulesCkStartOrEnd is defined on tenant.cluster_hibernation_schedules.
+	ConstraintClusterHibernationSchedulesCkStartOrEnd = "cluster_hibernation_schedules_ck_start_or_end"
+	// ConstraintClusterHibernationSchedulesFkCluster is defined on tenant.cluster_hibernation_schedules.
+	ConstraintClusterHibernationSchedulesFkCluster = "cluster_hibernation_schedules_fk_cluster"
 	// ConstraintClusterOutboxCkEvent is defined on tenant.cluster_outbox.
 	ConstraintClusterOutboxCkEvent = "cluster_outbox_ck_event"
 	// ConstraintClusterOutboxCkSingleFk is defined on tenant.cluster_outbox.
@@ -35,6 +65,8 @@ const (
 	ConstraintClusterOutboxCkSource = "cluster_outbox_ck_source"
 	// ConstraintClusterOutboxCkStatus is defined on tenant.cluster_outbox.
 	ConstraintClusterOutboxCkStatus = "cluster_outbox_ck_status"
+	// ConstraintClusterOutboxFkAccessElevation is defined on tenant.cluster_outbox.
+	ConstraintClusterOutboxFkAccessElevation = "cluster_outbox_fk_access_elevation"
 	// ConstraintClusterOutboxFkCluster is defined on tenant.cluster_outbox.
 	ConstraintClusterOutboxFkCluster = "cluster_outbox_fk_cluster"
 	// ConstraintClusterOutboxFkNamespace is defined on tenant.cluster_outbox.
@@ -47,6 +79,18 @@ const (
 	ConstraintClusterOutboxFkProjectMember = "cluster_outbox_fk_project_member"
 	// ConstraintClusterOutboxUqNsReconcile is defined on tenant.cluster_outbox.
 	ConstraintClusterOutboxUqNsReconcile = "cluster_outbox_uq_ns_reconcile"
+	// ConstraintClusterPluginStatusFkCluster is defined on tenant.cluster_plugin_status.
+	ConstraintClusterPluginStatusFkCluster = "cluster_plugin_status_fk_cluster"
+	// ConstraintClusterUpgradesCkStatus is defined on tenant.cluster_upgrades.
+	ConstraintClusterUpgradesCkStatus = "cluster_upgrades_ck_status"
+	// ConstraintClusterUpgradesFkCluster is defined on tenant.cluster_upgrades.
+	ConstraintClusterUpgradesFkCluster = "cluster_upgrades_fk_cluster"
+	// ConstraintClusterUpgradesUqActive is defined on tenant.cluster_upgrades.
+	ConstraintClusterUpgradesUqActive = "cluster_upgrades_uq_active"
+	// ConstraintClustersCkLabels is defined on tenant.clusters.
+	ConstraintClustersCkLabels = "clusters_ck_labels"
+	// ConstraintClustersCkMaintenanceWindow is defined on tenant.clusters.
+	ConstraintClustersCkMaintenanceWindow = "clusters_ck_maintenance_window"
 	// ConstraintClustersFkOrganization is defined on tenant.clusters.
 	ConstraintClustersFkOrganization = "clusters_fk_organization"
 	// ConstraintClustersFkRegionVersion is defined on tenant.clusters.
@@ -177,12 +221,52 @@ const (
 	ConstraintLogicalDevicesUqDesignLabel = "logical_devices_uq_design_label"
 	// ConstraintMachineTypesUqName is defined on catalog.machine_types.
 	ConstraintMachineTypesUqName = "machine_types_uq_name"
+	// ConstraintNamespaceLimitsCkQuotaCpuLimitGteRequest is defined on tenant.namespace_limits.
+	ConstraintNamespaceLimitsCkQuotaCpuLimitGteRequest = "namespace_limits_ck_quota_cpu_limit_gte_request"
+	// ConstraintNamespaceLimitsCkQuotaCpuLimitM is defined on tenant.namespace_limits.
+	ConstraintNamespaceLimitsCkQuotaCpuLimitM = "namespace_limits_ck_quota_cpu_limit_m"
+	// ConstraintNamespaceLimitsCkQuotaCpuRequestM is defined on tenant.namespace_limits.
+	ConstraintNamespaceLimitsCkQuotaCpuRequestM = "namespace_limits_ck_quota_cpu_request_m"
+	// ConstraintNamespaceLimitsCkQuotaMemoryLimitGteRequest is defined on tenant.namespace_limits.
+	ConstraintNamespaceLimitsCkQuotaMemoryLimitGteRequest = "namespace_limits_ck_quota_memory_limit_gte_request"
+	// ConstraintNamespaceLimitsCkQuotaMemoryLimitMi is defined on tenant.namespace_limits.
+	ConstraintNamespaceLimitsCkQuotaMemoryLimitMi = "namespace_limits_ck_quota_memory_limit_mi"
+	// ConstraintNamespaceLimitsCkQuotaMemoryRequestMi is defined on tenant.namespace_limits.
+	ConstraintNamespaceLimitsCkQuotaMemoryRequestMi = "namespace_limits_ck_quota_memory_request_mi"
+	// ConstraintNamespaceLimitsCkQuotaPersistentVolumeClaims is defined on tenant.namespace_limits.
+	ConstraintNamespaceLimitsCkQuotaPersistentVolumeClaims = "namespace_limits_ck_quota_persistent_volume_claims"
+	// ConstraintNamespaceLimitsCkQuotaPods is defined on tenant.namespace_limits.
+	ConstraintNamespaceLimitsCkQuotaPods = "namespace_limits_ck_quota_pods"
+	// ConstraintNamespaceLimitsCkQuotaServices is defined on tenant.namespace_limits.
+	ConstraintNamespaceLimitsCkQuotaServices = "namespace_limits_ck_quota_services"
+	// ConstraintNamespaceLimitsCkQuotaStorageGi is defined on tenant.namespace_limits.
+	ConstraintNamespaceLimitsCkQuotaStorageGi = "namespace_limits_ck_quota_storage_gi"
+	// ConstraintNamespaceLimitsFkNamespace is defined on tenant.namespace_limits.
+	ConstraintNamespaceLimitsFkNamespace = "namespace_limits_fk_namespace"
+	// ConstraintNamespaceLimitsUqNamespace is defined on tenant.namespace_limits.
+	ConstraintNamespaceLimitsUqNamespace = "namespace_limits_uq_namespace"
+	// ConstraintNamespaceRoleBindingsCkNamespacePattern is defined on tenant.namespace_role_bindings.
+	ConstraintNamespaceRoleBindingsCkNamespacePattern = "namespace_role_bindings_ck_namespace_pattern"
+	// ConstraintNamespaceRoleBindingsCkRole is defined on tenant.namespace_role_bindings.
+	ConstraintNamespaceRoleBindingsCkRole = "namespace_role_bindings_ck_role"
+	// ConstraintNamespaceRoleBindingsFkProject is defined on tenant.namespace_role_bindings.
+	ConstraintNamespaceRoleBindingsFkProject = "namespace_role_bindings_fk_project"
+	// ConstraintNamespaceRoleBindingsFkProjectMember is defined on tenant.namespace_role_bindings.
+	ConstraintNamespaceRoleBindingsFkProjectMember = "namespace_role_bindings_fk_project_member"
+	// ConstraintNamespaceRoleBindingsUqBinding is defined on tenant.namespace_role_bindings.
+	ConstraintNamespaceRoleBindingsUqBinding = "namespace_role_bindings_uq_binding"
 	// ConstraintNamespacesCkName is defined on tenant.namespaces.
 	ConstraintNamespacesCkName = "namespaces_ck_name"
 	// ConstraintNamespacesFkProject is defined on tenant.namespaces.
 	ConstraintNamespacesFkProject = "namespaces_fk_project"
 	// ConstraintNamespacesUqName is defined on tenant.namespaces.
 	ConstraintNamespacesUqName = "namespaces_uq_name"
+	// ConstraintNodePoolsCkLabels is defined on tenant.node_pools.
+	ConstraintNodePoolsCkLabels = "node_pools_ck_labels"
+	// ConstraintNodePoolsCkRollingUpdate is defined on tenant.node_pools.
+	ConstraintNodePoolsCkRollingUpdate = "node_pools_ck_rolling_update"
+	// ConstraintNodePoolsCkTaints is defined on tenant.node_pools.
+	ConstraintNodePoolsCkTaints = "node_pools_ck_taints"
 	// ConstraintNodePoolsFkCluster is defined on tenant.node_pools.
 	ConstraintNodePoolsFkCluster = "node_pools_fk_cluster"
 	// ConstraintNodePoolsFkRegionMachineType is defined on tenant.node_pools.
@@ -201,18 +285,30 @@ const (
 	ConstraintOrganizationLimitsCkDefaultMemoryLimitMi = "organization_limits_ck_default_memory_limit_mi"
 	// ConstraintOrganizationLimitsCkDefaultMemoryRequestMi is defined on tenant.organization_limits.
 	ConstraintOrganizationLimitsCkDefaultMemoryRequestMi = "organization_limits_ck_default_memory_request_mi"
+	// ConstraintOrganizationLimitsCkMaxClusters is defined on tenant.organization_limits.
+	ConstraintOrganizationLimitsCkMaxClusters = "organization_limits_ck_max_clusters"
+	// ConstraintOrganizationLimitsCkMaxNamespaces is defined on tenant.organization_limits.
+	ConstraintOrganizationLimitsCkMaxNamespaces = "organization_limits_ck_max_namespaces"
 	// ConstraintOrganizationLimitsCkMaxNodePoolsPerCluster is defined on tenant.organization_limits.
 	ConstraintOrganizationLimitsCkMaxNodePoolsPerCluster = "organization_limits_ck_max_node_pools_per_cluster"
 	// ConstraintOrganizationLimitsCkMaxNodesPerCluster is defined on tenant.organization_limits.
 	ConstraintOrganizationLimitsCkMaxNodesPerCluster = "organization_limits_ck_max_nodes_per_cluster"
 	// ConstraintOrganizationLimitsCkMaxNodesPerNodePool is defined on tenant.organization_limits.
 	ConstraintOrganizationLimitsCkMaxNodesPerNodePool = "organization_limits_ck_max_nodes_per_node_pool"
+	// ConstraintOrganizationLimitsCkMaxProjects is defined on tenant.organization_limits.
+	ConstraintOrganizationLimitsCkMaxProjects = "organization_limits_ck_max_projects"
 	// ConstraintOrganizationLimitsCkMemoryLimitGteRequest is defined on tenant.organization_limits.
 	ConstraintOrganizationLimitsCkMemoryLimitGteRequest = "organization_limits_ck_memory_limit_gte_request"
 	// ConstraintOrganizationLimitsFkOrganization is defined on tenant.organization_limits.
 	ConstraintOrganizationLimitsFkOrganization = "organization_limits_fk_organization"
 	// ConstraintOrganizationLimitsUqOrg is defined on tenant.organization_limits.
 	ConstraintOrganizationLimitsUqOrg = "organization_limits_uq_org"
+	// ConstraintOrganizationPoliciesCkMaxNodesPerNodePool is defined on tenant.organization_policies.
+	ConstraintOrganizationPoliciesCkMaxNodesPerNodePool = "organization_policies_ck_max_nodes_per_node_pool"
+	// ConstraintOrganizationPoliciesFkOrganization is defined on tenant.organization_policies.
+	ConstraintOrganizationPoliciesFkOrganization = "organization_policies_fk_organization"
+	// ConstraintOrganizationPoliciesUqOrg is defined on tenant.organization_policies.
+	ConstraintOrganizationPoliciesUqOrg = "organization_policies_uq_org"
 	// ConstraintOrganizationsCkAlias is defined on tenant.organizations.
 	ConstraintOrganizationsCkAlias = "organizations_ck_alias"
 	// ConstraintOrganizationsCkName is defined on tenant.organizations.
@@ -233,12 +329,16 @@ const (
 	ConstraintOutboxCkSingleFk = "outbox_ck_single_fk"
 	// ConstraintOutboxCkStatus is defined on authz.outbox.
 	ConstraintOutboxCkStatus = "outbox_ck_status"
+	// ConstraintOutboxFkAccessElevation is defined on authz.outbox.
+	ConstraintOutboxFkAccessElevation = "outbox_fk_access_elevation"
 	// ConstraintOutboxFkApiKey is defined on authz.outbox.
 	ConstraintOutboxFkApiKey = "outbox_fk_api_key"
 	// ConstraintOutboxFkCluster is defined on authz.outbox.
 	ConstraintOutboxFkCluster = "outbox_fk_cluster"
 	// ConstraintOutboxFkNamespace is defined on authz.outbox.
 	ConstraintOutboxFkNamespace = "outbox_fk_namespace"
+	// ConstraintOutboxFkNamespaceRoleBinding is defined on authz.outbox.
+	ConstraintOutboxFkNamespaceRoleBinding = "outbox_fk_namespace_role_binding"
 	// ConstraintOutboxFkNodePool is defined on authz.outbox.
 	ConstraintOutboxFkNodePool = "outbox_fk_node_pool"
 	// ConstraintOutboxFkOrganizationUser is defined on authz.outbox.
@@ -247,6 +347,12 @@ const (
 	ConstraintOutboxFkProject = "outbox_fk_project"
 	// ConstraintOutboxFkProjectMember is defined on authz.outbox.
 	ConstraintOutboxFkProjectMember = "outbox_fk_project_member"
+	// ConstraintOutboxFkProjectTeam is defined on authz.outbox.
+	ConstraintOutboxFkProjectTeam = "outbox_fk_project_team"
+	// ConstraintOutboxFkTeam is defined on authz.outbox.
+	ConstraintOutboxFkTeam = "outbox_fk_team"
+	// ConstraintOutboxFkTeamMember is defined on authz.outbox.
+	ConstraintOutboxFkTeamMember = "outbox_fk_team_member"
 	// ConstraintPhysicalConnectionsCkCableType is defined on dcim.physical_connections.
 	ConstraintPhysicalConnectionsCkCableType = "physical_connections_ck_cable_type"
 	// ConstraintPhysicalConnectionsCkColor is defined on dcim.physical_connections.
@@ -259,16 +365,38 @@ const (
 	ConstraintPlacementsCkSlotType = "placements_ck_slot_type"
 	// ConstraintPlacementsCkUnitStart is defined on dcim.placements.
 	ConstraintPlacementsCkUnitStart = "placements_ck_unit_start"
+	// ConstraintPluginDefinitionApprovalsFkOrganization is defined on tenant.plugin_definition_approvals.
+	ConstraintPluginDefinitionApprovalsFkOrganization = "plugin_definition_approvals_fk_organization"
+	// ConstraintPluginDefinitionApprovalsFkPluginDefinition is defined on tenant.plugin_definition_approvals.
+	ConstraintPluginDefinitionApprovalsFkPluginDefinition = "plugin_definition_approvals_fk_plugin_definition"
+	// ConstraintPluginDefinitionApprovalsFkUser is defined on tenant.plugin_definition_approvals.
+	ConstraintPluginDefinitionApprovalsFkUser = "plugin_definition_approvals_fk_user"
+	// ConstraintPluginDefinitionApprovalsUqDefinition is defined on tenant.plugin_definition_approvals.
+	ConstraintPluginDefinitionApprovalsUqDefinition = "plugin_definition_approvals_uq_definition"
+	// ConstraintPluginDefinitionsCkStatus is defined on appstore.plugin_definitions.
+	ConstraintPluginDefinitionsCkStatus = "plugin_definitions_ck_status"
 	// ConstraintPluginDefinitionsFkPlugin is defined on appstore.plugin_definitions.
 	ConstraintPluginDefinitionsFkPlugin = "plugin_definitions_fk_plugin"
 	// ConstraintPluginDefinitionsUqPluginVersion is defined on appstore.plugin_definitions.
 	ConstraintPluginDefinitionsUqPluginVersion = "plugin_definitions_uq_plugin_version"
 	// ConstraintPluginDocumentationLinksFkPlugin is defined on appstore.plugin_documentation_links.
 	ConstraintPluginDocumentationLinksFkPlugin = "plugin_documentation_links_fk_plugin"
+	// ConstraintPluginFeaturesFkPlugin is defined on appstore.plugin_features.
+	ConstraintPluginFeaturesFkPlugin = "plugin_features_fk_plugin"
+	// ConstraintPluginFeaturesUqOrdinal is defined on appstore.plugin_features.
+	ConstraintPluginFeaturesUqOrdinal = "plugin_features_uq_ordinal"
+	// ConstraintPluginsAllowedOrganizationsFkOrganization is defined on appstore.plugins_allowed_organizations.
+	ConstraintPluginsAllowedOrganizationsFkOrganization = "plugins_allowed_organizations_fk_organization"
+	// ConstraintPluginsAllowedOrganizationsFkPlugin is defined on appstore.plugins_allowed_organizations.
+	ConstraintPluginsAllowedOrganizationsFkPlugin = "plugins_allowed_organizations_fk_plugin"
 	// ConstraintPluginsCategoriesCategoryId is defined on appstore.categories_plugins.
 	ConstraintPluginsCategoriesCategoryId = "plugins_categories_category_id"
 	// ConstraintPluginsCategoriesPluginId is defined on appstore.categories_plugins.
 	ConstraintPluginsCategoriesPluginId = "plugins_categories_plugin_id"
+	// ConstraintPluginsCkLabels is defined on appstore.plugins.
+	ConstraintPluginsCkLabels = "plugins_ck_labels"
+	// ConstraintPluginsCkVisibility is defined on appstore.plugins.
+	ConstraintPluginsCkVisibility = "plugins_ck_visibility"
 	// ConstraintPluginsFkOrganization is defined on appstore.plugins.
 	ConstraintPluginsFkOrganization = "plugins_fk_organization"
 	// ConstraintPluginsPresetsPluginId is defined on appstore.preset_plugins.
@@ -303,6 +431,26 @@ const (
 	ConstraintProjectLimitsCkDefaultMemoryRequestMi = "project_limits_ck_default_memory_request_mi"
 	// ConstraintProjectLimitsCkMemoryLimitGteRequest is defined on tenant.project_limits.
 	ConstraintProjectLimitsCkMemoryLimitGteRequest = "project_limits_ck_memory_limit_gte_request"
+	// ConstraintProjectLimitsCkQuotaCpuLimitGteRequest is defined on tenant.project_limits.
+	ConstraintProjectLimitsCkQuotaCpuLimitGteRequest = "project_limits_ck_quota_cpu_limit_gte_request"
+	// ConstraintProjectLimitsCkQuotaCpuLimitM is defined on tenant.project_limits.
+	ConstraintProjectLimitsCkQuotaCpuLimitM = "project_limits_ck_quota_cpu_limit_m"
+	// ConstraintProjectLimitsCkQuotaCpuRequestM is defined on tenant.project_limits.
+	ConstraintProjectLimitsCkQuotaCpuRequestM = "project_limits_ck_quota_cpu_request_m"
+	// ConstraintProjectLimitsCkQuotaMemoryLimitGteRequest is defined on tenant.project_limits.
+	ConstraintProjectLimitsCkQuotaMemoryLimitGteRequest = "project_limits_ck_quota_memory_limit_gte_request"
+	// ConstraintProjectLimitsCkQuotaMemoryLimitMi is defined on tenant.project_limits.
+	ConstraintProjectLimitsCkQuotaMemoryLimitMi = "project_limits_ck_quota_memory_limit_mi"
+	// ConstraintProjectLimitsCkQuotaMemoryRequestMi is defined on tenant.project_limits.
+	ConstraintProjectLimitsCkQuotaMemoryRequestMi = "project_limits_ck_quota_memory_request_mi"
+	// ConstraintProjectLimitsCkQuotaPersistentVolumeClaims is defined on tenant.project_limits.
+	ConstraintProjectLimitsCkQuotaPersistentVolumeClaims = "project_limits_ck_quota_persistent_volume_claims"
+	// ConstraintProjectLimitsCkQuotaPods is defined on tenant.project_limits.
+	ConstraintProjectLimitsCkQuotaPods = "project_limits_ck_quota_pods"
+	// ConstraintProjectLimitsCkQuotaServices is defined on tenant.project_limits.
+	ConstraintProjectLimitsCkQuotaServices = "project_limits_ck_quota_services"
+	// ConstraintProjectLimitsCkQuotaStorageGi is defined on tenant.project_limits.
+	ConstraintProjectLimitsCkQuotaStorageGi = "project_limits_ck_quota_storage_gi"
 	// ConstraintProjectLimitsFkProject is defined on tenant.project_limits.
 	ConstraintProjectLimitsFkProject = "project_limits_fk_project"
 	// ConstraintProjectLimitsUqProject is defined on tenant.project_limits.
@@ -315,6 +463,14 @@ const (
 	ConstraintProjectMembersFkUser = "project_members_fk_user"
 	// ConstraintProjectMembersUqProjectUser is defined on tenant.project_members.
 	ConstraintProjectMembersUqProjectUser = "project_members_uq_project_user"
+	// ConstraintProjectTeamsCkRole is defined on tenant.project_teams.
+	ConstraintProjectTeamsCkRole = "project_teams_ck_role"
+	// ConstraintProjectTeamsFkProject is defined on tenant.project_teams.
+	ConstraintProjectTeamsFkProject = "project_teams_fk_project"
+	// ConstraintProjectTeamsFkTeam is defined on tenant.project_teams.
+	ConstraintProjectTeamsFkTeam = "project_teams_fk_team"
+	// ConstraintProjectTeamsUqProjectTeam is defined on tenant.project_teams.
+	ConstraintProjectTeamsUqProjectTeam = "project_teams_uq_project_team"
 	// ConstraintProjectsCkAlias is defined on tenant.projects.
 	ConstraintProjectsCkAlias = "projects_ck_alias"
 	// ConstraintProjectsFkCluster is defined on tenant.projects.
@@ -345,6 +501,16 @@ const (
 	ConstraintRoomsUqSiteName = "rooms_uq_site_name"
 	// ConstraintSitesUqName is defined on dcim.sites.
 	ConstraintSitesUqName = "sites_uq_name"
+	// ConstraintSubmissionsCkRejectionReason is defined on appstore.submissions.
+	ConstraintSubmissionsCkRejectionReason = "submissions_ck_rejection_reason"
+	// ConstraintSubmissionsCkStatus is defined on appstore.submissions.
+	ConstraintSubmissionsCkStatus = "submissions_ck_status"
+	// ConstraintSubmissionsFkPluginDefinition is defined on appstore.submissions.
+	ConstraintSubmissionsFkPluginDefinition = "submissions_fk_plugin_definition"
+	// ConstraintSubmissionsFkSubmitter is defined on appstore.submissions.
+	ConstraintSubmissionsFkSubmitter = "submissions_fk_submitter"
+	// ConstraintSubmissionsUqPending is defined on appstore.submissions.
+	ConstraintSubmissionsUqPending = "submissions_uq_pending"
 	// ConstraintTagsUqName is defined on appstore.tags.
 	ConstraintTagsUqName = "tags_uq_name"
 	// ConstraintTaskStepsUqTaskOrdinal is defined on dcim.task_steps.
@@ -355,8 +521,36 @@ const (
 	ConstraintTasksCkPriority = "tasks_ck_priority"
 	// ConstraintTasksCkStatus is defined on dcim.tasks.
 	ConstraintTasksCkStatus = "tasks_ck_status"
+	// ConstraintTeamMembersCkSource is defined on tenant.team_members.
+	ConstraintTeamMembersCkSource = "team_members_ck_source"
+	// ConstraintTeamMembersFkTeam is defined on tenant.team_members.
+	ConstraintTeamMembersFkTeam = "team_members_fk_team"
+	// ConstraintTeamMembersFkUser is defined on tenant.team_members.
+	ConstraintTeamMembersFkUser = "team_members_fk_user"
+	// ConstraintTeamMembersUqTeamUser is defined on tenant.team_members.
+	ConstraintTeamMembersUqTeamUser = "team_members_uq_team_user"
+	// ConstraintTeamsCkName is defined on tenant.teams.
+	ConstraintTeamsCkName = "teams_ck_name"
+	// ConstraintTeamsCkPermission is defined on tenant.teams.
+	ConstraintTeamsCkPermission = "teams_ck_permission"
+	// ConstraintTeamsFkOrganization is defined on tenant.teams.
+	ConstraintTeamsFkOrganization = "teams_fk_organization"
+	// ConstraintTeamsUqOrganizationName is defined on tenant.teams.
+	ConstraintTeamsUqOrganizationName = "teams_uq_organization_name"
 	// ConstraintUsersUqExternalRef is defined on tenant.users.
 	ConstraintUsersUqExternalRef = "users_uq_external_ref"
 	// ConstraintVerifyDeleted is defined on (constraint trigger).
 	ConstraintVerifyDeleted = "verify_deleted"
+	// ConstraintWebhookDeliveriesCkEventType is defined on tenant.webhook_deliveries.
+	ConstraintWebhookDeliveriesCkEventType = "webhook_deliveries_ck_event_type"
+	// ConstraintWebhookDeliveriesCkStatus is defined on tenant.webhook_deliveries.
+	ConstraintWebhookDeliveriesCkStatus = "webhook_deliveries_ck_status"
+	// ConstraintWebhookDeliveriesFkWebhook is defined on tenant.webhook_deliveries.
+	ConstraintWebhookDeliveriesFkWebhook = "webhook_deliveries_fk_webhook"
+	// ConstraintWebhooksCkEventTypes is defined on tenant.webhooks.
+	ConstraintWebhooksCkEventTypes = "webhooks_ck_event_types"
+	// ConstraintWebhooksFkOrganization is defined on tenant.webhooks.
+	ConstraintWebhooksFkOrganization = "webhooks_fk_organization"
+	// ConstraintWebhooksUqName is defined on tenant.webhooks.
+	ConstraintWebhooksUqName = "webhooks_uq_name"
 )
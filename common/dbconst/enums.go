@@ -2,6 +2,26 @@
 
 package dbconst
 
+// AccessElevationRole represents valid values for tenant.access_elevations.role.
+type AccessElevationRole string
+
+const (
+	AccessElevationRole_Admin        AccessElevationRole = "admin"
+	AccessElevationRole_ProjectAdmin AccessElevationRole = "project_admin"
+)
+
+// AccessElevationStatus represents valid values for tenant.access_elevations.status.
+type AccessElevationStatus string
+
+const (
+	AccessElevationStatus_Pending   AccessElevationStatus = "pending"
+	AccessElevationStatus_Approved  AccessElevationStatus = "approved"
+	AccessElevationStatus_Denied    AccessElevationStatus = "denied"
+	AccessElevationStatus_Cancelled AccessElevationStatus = "cancelled"
+	AccessElevationStatus_Revoked   AccessElevationStatus = "revoked"
+	AccessElevationStatus_Expired   AccessElevationStatus = "expired"
+)
+
 // AssetEventEventType represents valid values for dcim.asset_events.event_type.
 type AssetEventEventType string
 
@@ -28,20 +48,48 @@ const (
 	AssetStatus_Reserved       AssetStatus = "reserved"
 )
 
+// AuditEventOutcome represents valid values for tenant.audit_events.outcome.
+type AuditEventOutcome string
+
+const (
+	AuditEventOutcome_Succeeded AuditEventOutcome = "succeeded"
+	AuditEventOutcome_Failed    AuditEventOutcome = "failed"
+	AuditEventOutcome_Denied    AuditEventOutcome = "denied"
+)
+
+// AuditEventSource represents valid values for tenant.audit_events.source.
+type AuditEventSource string
+
+const (
+	AuditEventSource_OrganizationApi AuditEventSource = "organization_api"
+	AuditEventSource_DcimApi         AuditEventSource = "dcim_api"
+	AuditEventSource_KubeApiProxy    AuditEventSource = "kube_api_proxy"
+)
+
 // ClusterEventEventType represents valid values for tenant.cluster_events.event_type.
 type ClusterEventEventType string
 
 const (
-	ClusterEventEventType_SyncRequested     ClusterEventEventType = "sync_requested"
-	ClusterEventEventType_SyncClaimed       ClusterEventEventType = "sync_claimed"
-	ClusterEventEventType_SyncSucceeded     ClusterEventEventType = "sync_succeeded"
-	ClusterEventEventType_SyncFailed        ClusterEventEventType = "sync_failed"
-	ClusterEventEventType_StatusProgressing ClusterEventEventType = "status_progressing"
-	ClusterEventEventType_StatusReady       ClusterEventEventType = "status_ready"
-	ClusterEventEventType_StatusError       ClusterEventEventType = "status_error"
-	ClusterEventEventType_StatusDeleted     ClusterEventEventType = "status_deleted"
-	ClusterEventEventType_UserSyncSucceeded ClusterEventEventType = "user_sync_succeeded"
-	ClusterEventEventType_UserSyncFailed    ClusterEventEventType = "user_sync_failed"
+	ClusterEventEventType_SyncRequested          ClusterEventEventType = "sync_requested"
+	ClusterEventEventType_SyncClaimed            ClusterEventEventType = "sync_claimed"
+	ClusterEventEventType_SyncSucceeded          ClusterEventEventType = "sync_succeeded"
+	ClusterEventEventType_SyncFailed             ClusterEventEventType = "sync_failed"
+	ClusterEventEventType_StatusProgressing      ClusterEventEventType = "status_progressing"
+	ClusterEventEventType_StatusReady            ClusterEventEventType = "status_ready"
+	ClusterEventEventType_StatusError            ClusterEventEventType = "status_error"
+	ClusterEventEventType_StatusDeleted          ClusterEventEventType = "status_deleted"
+	ClusterEventEventType_UserSyncSucceeded      ClusterEventEventType = "user_sync_succeeded"
+	ClusterEventEventType_UserSyncFailed         ClusterEventEventType = "user_sync_failed"
+	ClusterEventEventType_UpgradeStarted         ClusterEventEventType = "upgrade_started"
+	ClusterEventEventType_UpgradeCompleted       ClusterEventEventType = "upgrade_completed"
+	ClusterEventEventType_UpgradeFailed          ClusterEventEventType = "upgrade_failed"
+	ClusterEventEventType_HibernationRequested   ClusterEventEventType = "hibernation_requested"
+	ClusterEventEventType_WakeRequested          ClusterEventEventType = "wake_requested"
+	ClusterEventEventType_StatusHibernated       ClusterEventEventType = "status_hibernated"
+	ClusterEventEventType_OutboxReplayed         ClusterEventEventType = "outbox_replayed"
+	ClusterEventEventType_OutboxAbandoned        ClusterEventEventType = "outbox_abandoned"
+	ClusterEventEventType_AccessElevationGranted ClusterEventEventType = "access_elevation_granted"
+	ClusterEventEventType_AccessElevationEnded   ClusterEventEventType = "access_elevation_ended"
 )
 
 // ClusterEventSyncAction represents valid values for tenant.cluster_events.sync_action.
@@ -81,6 +129,18 @@ const (
 	ClusterOutboxStatus_Completed ClusterOutboxStatus = "completed"
 	ClusterOutboxStatus_Retrying  ClusterOutboxStatus = "retrying"
 	ClusterOutboxStatus_Failed    ClusterOutboxStatus = "failed"
+	ClusterOutboxStatus_Abandoned ClusterOutboxStatus = "abandoned"
+)
+
+// ClusterUpgradeStatus represents valid values for tenant.cluster_upgrades.status.
+type ClusterUpgradeStatus string
+
+const (
+	ClusterUpgradeStatus_Scheduled  ClusterUpgradeStatus = "scheduled"
+	ClusterUpgradeStatus_InProgress ClusterUpgradeStatus = "in_progress"
+	ClusterUpgradeStatus_Completed  ClusterUpgradeStatus = "completed"
+	ClusterUpgradeStatus_Failed     ClusterUpgradeStatus = "failed"
+	ClusterUpgradeStatus_Cancelled  ClusterUpgradeStatus = "cancelled"
 )
 
 // DeviceCatalogCategory represents valid values for dcim.device_catalogs.category.
@@ -146,6 +206,16 @@ const (
 	LogicalDeviceRole_Adapter       LogicalDeviceRole = "adapter"
 )
 
+// NamespaceRoleBindingRole represents valid values for tenant.namespace_role_bindings.role.
+type NamespaceRoleBindingRole string
+
+const (
+	NamespaceRoleBindingRole_Deploy         NamespaceRoleBindingRole = "deploy"
+	NamespaceRoleBindingRole_ViewPods       NamespaceRoleBindingRole = "view_pods"
+	NamespaceRoleBindingRole_ViewLogs       NamespaceRoleBindingRole = "view_logs"
+	NamespaceRoleBindingRole_ManageServices NamespaceRoleBindingRole = "manage_services"
+)
+
 // OrganizationsUserPermission represents valid values for tenant.organizations_users.permission.
 type OrganizationsUserPermission string
 
@@ -172,6 +242,7 @@ const (
 	OutboxStatus_Completed OutboxStatus = "completed"
 	OutboxStatus_Retrying  OutboxStatus = "retrying"
 	OutboxStatus_Failed    OutboxStatus = "failed"
+	OutboxStatus_Abandoned OutboxStatus = "abandoned"
 )
 
 // PhysicalConnectionCableType represents valid values for dcim.physical_connections.cable_type.
@@ -227,6 +298,26 @@ const (
 	PlacementSlotType_ZeroU PlacementSlotType = "zero_u"
 )
 
+// PluginDefinitionStatus represents valid values for appstore.plugin_definitions.status.
+type PluginDefinitionStatus string
+
+const (
+	PluginDefinitionStatus_Draft            PluginDefinitionStatus = "draft"
+	PluginDefinitionStatus_Pending          PluginDefinitionStatus = "pending"
+	PluginDefinitionStatus_ChangesRequested PluginDefinitionStatus = "changes_requested"
+	PluginDefinitionStatus_Approved         PluginDefinitionStatus = "approved"
+	PluginDefinitionStatus_Rejected         PluginDefinitionStatus = "rejected"
+	PluginDefinitionStatus_Withdrawn        PluginDefinitionStatus = "withdrawn"
+)
+
+// PluginVisibility represents valid values for appstore.plugins.visibility.
+type PluginVisibility string
+
+const (
+	PluginVisibility_Public     PluginVisibility = "public"
+	PluginVisibility_Restricted PluginVisibility = "restricted"
+)
+
 // PortCompatibilitieCompatibleCategory represents valid values for dcim.port_compatibilities.compatible_category.
 type PortCompatibilitieCompatibleCategory string
 
@@ -276,6 +367,37 @@ const (
 	ProjectMemberRole_Viewer ProjectMemberRole = "viewer"
 )
 
+// ProjectTeamRole represents valid values for tenant.project_teams.role.
+type ProjectTeamRole string
+
+const (
+	ProjectTeamRole_Admin  ProjectTeamRole = "admin"
+	ProjectTeamRole_Viewer ProjectTeamRole = "viewer"
+)
+
+// SubmissionRejectionReason represents valid values for appstore.submissions.rejection_reason.
+type SubmissionRejectionReason string
+
+const (
+	SubmissionRejectionReason_IncompleteMetadata SubmissionRejectionReason = "incomplete_metadata"
+	SubmissionRejectionReason_Duplicate          SubmissionRejectionReason = "duplicate"
+	SubmissionRejectionReason_SecurityConcerns   SubmissionRejectionReason = "security_concerns"
+	SubmissionRejectionReason_NamingGuidelines   SubmissionRejectionReason = "naming_guidelines"
+	SubmissionRejectionReason_OutOfScope         SubmissionRejectionReason = "out_of_scope"
+	SubmissionRejectionReason_Other              SubmissionRejectionReason = "other"
+)
+
+// SubmissionStatus represents valid values for appstore.submissions.status.
+type SubmissionStatus string
+
+const (
+	SubmissionStatus_Pending          SubmissionStatus = "pending"
+	SubmissionStatus_ChangesRequested SubmissionStatus = "changes_requested"
+	SubmissionStatus_Approved         SubmissionStatus = "approved"
+	SubmissionStatus_Rejected         SubmissionStatus = "rejected"
+	SubmissionStatus_Withdrawn        SubmissionStatus = "withdrawn"
+)
+
 // TaskCategory represents valid values for dcim.tasks.category.
 type TaskCategory string
 
@@ -308,3 +430,48 @@ const (
 	TaskStatus_Blocked    TaskStatus = "blocked"
 	TaskStatus_Done       TaskStatus = "done"
 )
+
+// TeamMemberSource represents valid values for tenant.team_members.source.
+type TeamMemberSource string
+
+const (
+	TeamMemberSource_Manual TeamMemberSource = "manual"
+	TeamMemberSource_Oidc   TeamMemberSource = "oidc"
+	TeamMemberSource_Scim   TeamMemberSource = "scim"
+)
+
+// TeamPermission represents valid values for tenant.teams.permission.
+type TeamPermission string
+
+const (
+	TeamPermission_Admin  TeamPermission = "admin"
+	TeamPermission_Viewer TeamPermission = "viewer"
+)
+
+// WebhookDeliverieEventType represents valid values for tenant.webhook_deliveries.event_type.
+type WebhookDeliverieEventType string
+
+const (
+	WebhookDeliverieEventType_ClusterReady            WebhookDeliverieEventType = "cluster_ready"
+	WebhookDeliverieEventType_ClusterError            WebhookDeliverieEventType = "cluster_error"
+	WebhookDeliverieEventType_ClusterDeleted          WebhookDeliverieEventType = "cluster_deleted"
+	WebhookDeliverieEventType_ClusterHibernated       WebhookDeliverieEventType = "cluster_hibernated"
+	WebhookDeliverieEventType_ClusterSyncFailed       WebhookDeliverieEventType = "cluster_sync_failed"
+	WebhookDeliverieEventType_ClusterUpgradeCompleted WebhookDeliverieEventType = "cluster_upgrade_completed"
+	WebhookDeliverieEventType_ClusterUpgradeFailed    WebhookDeliverieEventType = "cluster_upgrade_failed"
+	WebhookDeliverieEventType_PluginReady             WebhookDeliverieEventType = "plugin_ready"
+	WebhookDeliverieEventType_PluginDegraded          WebhookDeliverieEventType = "plugin_degraded"
+	WebhookDeliverieEventType_PluginFailed            WebhookDeliverieEventType = "plugin_failed"
+	WebhookDeliverieEventType_MemberInvited           WebhookDeliverieEventType = "member_invited"
+	WebhookDeliverieEventType_Ping                    WebhookDeliverieEventType = "ping"
+)
+
+// WebhookDeliverieStatus represents valid values for tenant.webhook_deliveries.status.
+type WebhookDeliverieStatus string
+
+const (
+	WebhookDeliverieStatus_Pending   WebhookDeliverieStatus = "pending"
+	WebhookDeliverieStatus_Retrying  WebhookDeliverieStatus = "retrying"
+	WebhookDeliverieStatus_Succeeded WebhookDeliverieStatus = "succeeded"
+	WebhookDeliverieStatus_Failed    WebhookDeliverieStatus = "failed"
+)
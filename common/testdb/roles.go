@@ -28,6 +28,8 @@ var Roles = []Role{
 	{Name: "fun_cluster_worker"},
 	{Name: "fun_authz_worker", BypassRLS: true},
 	{Name: "fun_dcim_api"},
+	{Name: "fun_marketplace_api"},
+	{Name: "fun_kube_api_proxy"},
 }
 
 // CreateRoles ensures every role in [Roles] exists with the configured
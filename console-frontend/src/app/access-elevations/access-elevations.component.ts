@@ -0,0 +1,296 @@
+import {
+  Component,
+  inject,
+  OnInit,
+  signal,
+  computed,
+  ChangeDetectionStrategy,
+  CUSTOM_ELEMENTS_SCHEMA,
+} from '@angular/core';
+import { firstValueFrom } from 'rxjs';
+import { ConnectError, Code } from '@connectrpc/connect';
+import { timestampDate } from '@bufbuild/protobuf/wkt';
+import { createIdempotencyRef, withIdempotency } from '../../connect/idempotency';
+import { TitleService } from '../title.service';
+import { ToastService } from '../toast.service';
+import AuthnApiService from '../authn-api.service';
+import { OrganizationDataService } from '../organization-data.service';
+import { ACCESS_ELEVATION } from '../../connect/tokens';
+import { AccessElevationRole, AccessElevationStatus } from '../../generated/v1/access_elevation_pb';
+import SheetSyncDirective from '../sheet-sync.directive';
+import AutofocusDirective from '../autofocus.directive';
+import LoadingIndicatorComponent from '../icons/loading-indicator.component';
+import { formatTimeAgo } from '../utils/date-format';
+
+interface Elevation {
+  id: string;
+  userName: string;
+  isOwn: boolean;
+  role: AccessElevationRole;
+  projectName?: string;
+  durationMinutes: number;
+  reason: string;
+  status: AccessElevationStatus;
+  expires?: Date;
+  created?: Date;
+}
+
+const statusLabels: Record<AccessElevationStatus, string> = {
+  [AccessElevationStatus.UNSPECIFIED]: 'Unknown',
+  [AccessElevationStatus.PENDING]: 'Pending',
+  [AccessElevationStatus.APPROVED]: 'Active',
+  [AccessElevationStatus.DENIED]: 'Denied',
+  [AccessElevationStatus.CANCELLED]: 'Cancelled',
+  [AccessElevationStatus.REVOKED]: 'Revoked',
+  [AccessElevationStatus.EXPIRED]: 'Expired',
+};
+
+const statusColors: Record<AccessElevationStatus, string> = {
+  [AccessElevationStatus.UNSPECIFIED]: 'neutral',
+  [AccessElevationStatus.PENDING]: 'warning',
+  [AccessElevationStatus.APPROVED]: 'paars',
+  [AccessElevationStatus.DENIED]: 'critical',
+  [AccessElevationStatus.CANCELLED]: 'neutral',
+  [AccessElevationStatus.REVOKED]: 'neutral',
+  [AccessElevationStatus.EXPIRED]: 'neutral',
+};
+
+const formatDuration = (minutes: number): string =>
+  minutes % 60 === 0 ? `${minutes / 60}h` : `${minutes}m`;
+
+@Component({
+  selector: 'app-access-elevations',
+  imports: [SheetSyncDirective, LoadingIndicatorComponent, AutofocusDirective],
+  schemas: [CUSTOM_ELEMENTS_SCHEMA],
+  templateUrl: './access-elevations.component.html',
+  changeDetection: ChangeDetectionStrategy.OnPush,
+})
+export default class AccessElevationsComponent implements OnInit {
+  private titleService = inject(TitleService);
+
+  private toastService = inject(ToastService);
+
+  private accessElevationClient = inject(ACCESS_ELEVATION);
+
+  private idempotency = createIdempotencyRef();
+
+  private authnService = inject(AuthnApiService);
+
+  private organizationDataService = inject(OrganizationDataService);
+
+  readonly Role = AccessElevationRole;
+
+  readonly Status = AccessElevationStatus;
+
+  readonly durations = [30, 60, 120, 240, 480, 1440];
+
+  isLoading = signal(true);
+
+  error = signal<string | null>(null);
+
+  isSubmitting = signal(false);
+
+  elevations = signal<Elevation[]>([]);
+
+  openElevations = computed(() =>
+    this.elevations().filter(
+      (e) =>
+        e.status === AccessElevationStatus.PENDING || e.status === AccessElevationStatus.APPROVED,
+    ),
+  );
+
+  pastElevations = computed(() =>
+    this.elevations().filter(
+      (e) =>
+        e.status !== AccessElevationStatus.PENDING && e.status !== AccessElevationStatus.APPROVED,
+    ),
+  );
+
+  projects = computed(() =>
+    this.organizationDataService
+      .organizations()
+      .flatMap((org) => org.clusters.flatMap((cluster) => cluster.projects))
+      .sort((a, b) => (a.alias || a.name).localeCompare(b.alias || b.name)),
+  );
+
+  // Request sheet state
+  isRequestOpen = signal(false);
+
+  requestRole = signal(AccessElevationRole.ADMIN);
+
+  requestProjectId = signal('');
+
+  requestDuration = signal(60);
+
+  requestReason = signal('');
+
+  requestError = signal<string | null>(null);
+
+  constructor() {
+    this.titleService.setTitle('Elevated access');
+  }
+
+  ngOnInit() {
+    this.organizationDataService.loadProjectsAndNamespaces().catch(() => {});
+    this.loadElevations();
+  }
+
+  async loadElevations() {
+    this.isLoading.set(true);
+    this.error.set(null);
+
+    try {
+      const currentUser = await firstValueFrom(this.authnService.currentUser$);
+      const response = await firstValueFrom(this.accessElevationClient.listAccessElevations({}));
+
+      this.elevations.set(
+        response.accessElevations.map((elevation) => ({
+          id: elevation.id,
+          userName: elevation.userName,
+          isOwn: currentUser?.id === elevation.userId,
+          role: elevation.role,
+          projectName: elevation.projectId ? this.projectName(elevation.projectId) : undefined,
+          durationMinutes: elevation.durationMinutes,
+          reason: elevation.reason,
+          status: elevation.status,
+          expires: elevation.expires ? timestampDate(elevation.expires) : undefined,
+          created: elevation.created ? timestampDate(elevation.created) : undefined,
+        })),
+      );
+    } catch (err) {
+      this.error.set(
+        err instanceof Error
+          ? `Failed to load access elevations: ${err.message}`
+          : 'Failed to load access elevations',
+      );
+    } finally {
+      this.isLoading.set(false);
+    }
+  }
+
+  openRequest() {
+    this.requestRole.set(AccessElevationRole.ADMIN);
+    this.requestProjectId.set(this.projects()[0]?.id ?? '');
+    this.requestDuration.set(60);
+    this.requestReason.set('');
+    this.requestError.set(null);
+    this.isRequestOpen.set(true);
+  }
+
+  closeRequest() {
+    this.isRequestOpen.set(false);
+  }
+
+  async submitRequest(event?: Event) {
+    event?.preventDefault();
+
+    const role = this.requestRole();
+    const reason = this.requestReason().trim();
+    if (!reason) {
+      this.requestError.set('A reason is required');
+      return;
+    }
+
+    this.isSubmitting.set(true);
+    this.requestError.set(null);
+
+    try {
+      await withIdempotency(
+        (opts) =>
+          this.accessElevationClient.requestAccessElevation(
+            {
+              role,
+              durationMinutes: this.requestDuration(),
+              reason,
+              ...(role === AccessElevationRole.PROJECT_ADMIN
+                ? { projectId: this.requestProjectId() }
+                : {}),
+            },
+            opts,
+          ),
+        { signal: this.idempotency.reset() },
+      );
+      this.closeRequest();
+      this.toastService.success('Access requested; an admin has to approve it');
+      await this.loadElevations();
+    } catch (err: unknown) {
+      if (err instanceof ConnectError && err.code === Code.AlreadyExists) {
+        this.requestError.set('You already have an open request for this role.');
+      } else if (err instanceof ConnectError && err.code === Code.FailedPrecondition) {
+        this.requestError.set('You already have this role.');
+      } else {
+        this.requestError.set('Failed to request access. Please try again.');
+      }
+    } finally {
+      this.isSubmitting.set(false);
+    }
+  }
+
+  async approve(elevation: Elevation) {
+    await this.decide(
+      () =>
+        firstValueFrom(
+          this.accessElevationClient.approveAccessElevation({ accessElevationId: elevation.id }),
+        ),
+      `Access for '${elevation.userName}' approved`,
+      'approve',
+    );
+  }
+
+  async deny(elevation: Elevation) {
+    await this.decide(
+      () =>
+        firstValueFrom(
+          this.accessElevationClient.denyAccessElevation({ accessElevationId: elevation.id }),
+        ),
+      `Access for '${elevation.userName}' denied`,
+      'deny',
+    );
+  }
+
+  async revoke(elevation: Elevation) {
+    await this.decide(
+      () =>
+        firstValueFrom(
+          this.accessElevationClient.revokeAccessElevation({ accessElevationId: elevation.id }),
+        ),
+      elevation.status === AccessElevationStatus.PENDING ? 'Request cancelled' : 'Access revoked',
+      'revoke',
+    );
+  }
+
+  private async decide(call: () => Promise<unknown>, success: string, verb: string) {
+    try {
+      await call();
+      this.toastService.success(success);
+    } catch (err) {
+      if (err instanceof ConnectError && err.code === Code.PermissionDenied) {
+        this.toastService.error(`You are not allowed to ${verb} this request`);
+      } else {
+        this.toastService.error(
+          err instanceof Error ? `Failed to ${verb}: ${err.message}` : `Failed to ${verb}`,
+        );
+      }
+    }
+    await this.loadElevations();
+  }
+
+  private projectName(projectId: string): string {
+    const project = this.organizationDataService.getProjectById(projectId)?.project;
+    return project ? project.alias || project.name : projectId;
+  }
+
+  roleLabel(elevation: Elevation): string {
+    return elevation.role === AccessElevationRole.PROJECT_ADMIN
+      ? `Project admin of ${elevation.projectName}`
+      : 'Organization admin';
+  }
+
+  statusLabel = (status: AccessElevationStatus) => statusLabels[status];
+
+  statusColor = (status: AccessElevationStatus) => statusColors[status];
+
+  formatDuration = formatDuration;
+
+  formatTimeAgo = formatTimeAgo;
+}
@@ -208,6 +208,14 @@ const routes: Routes = [
           breadcrumbs: [{ label: 'Organization members' }],
         },
       },
+      {
+        path: 'organization/access',
+        loadComponent: () =>
+          import('./access-elevations/access-elevations.component').then((m) => m.default),
+        data: {
+          breadcrumbs: [{ label: 'Elevated access' }],
+        },
+      },
       {
         path: 'organization/limits',
         loadComponent: () =>
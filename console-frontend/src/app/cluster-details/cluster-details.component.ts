@@ -20,6 +20,8 @@ import {
   GetClusterRequestSchema,
   ListNodePoolsRequestSchema,
   DeleteClusterRequestSchema,
+  HibernateClusterRequestSchema,
+  WakeClusterRequestSchema,
   GetClusterActivityRequestSchema,
   GetKubeconfigRequestSchema,
   NodePool,
@@ -64,6 +66,7 @@ const getSyncStatusTagColor = (status: string | undefined): string => {
     pending: 'lichtblauw',
     error: 'critical',
     deleting: 'oranje',
+    hibernated: 'lichtblauw',
   };
   return colors[status ?? ''] || 'neutral';
 };
@@ -74,6 +77,7 @@ const getSyncStatusLabel = (syncState: SyncState | null): string => {
   if (syncState.outboxError) return 'Error';
   if (syncState.outboxStatus === 'completed') return 'Synced';
   if (syncState.outboxStatus === 'failed') return 'Failed';
+  if (syncState.outboxStatus === 'abandoned') return 'Abandoned';
   if (syncState.outboxStatus) return 'Syncing';
   return 'Pending';
 };
@@ -88,6 +92,13 @@ const getEventTypeLabel = (eventType: string): string => {
     status_ready: 'Cluster ready',
     status_error: 'Cluster error',
     status_deleted: 'Cluster deleted',
+    status_hibernated: 'Cluster hibernated',
+    hibernation_requested: 'Hibernation requested',
+    wake_requested: 'Wake-up requested',
+    outbox_replayed: 'Sync replayed',
+    outbox_abandoned: 'Sync abandoned',
+    access_elevation_granted: 'Elevated access granted',
+    access_elevation_ended: 'Elevated access ended',
   };
   return labels[eventType] || eventType;
 };
@@ -102,6 +113,13 @@ const getEventTypeColor = (eventType: string): string => {
     status_ready: 'bg-green-500',
     status_error: 'bg-danger-500',
     status_deleted: 'bg-gray-500',
+    status_hibernated: 'bg-gray-500',
+    hibernation_requested: 'bg-blue-500',
+    wake_requested: 'bg-blue-500',
+    outbox_replayed: 'bg-blue-500',
+    outbox_abandoned: 'bg-gray-500',
+    access_elevation_granted: 'bg-blue-500',
+    access_elevation_ended: 'bg-gray-500',
   };
   return colors[eventType] || 'bg-gray-500';
 };
@@ -393,6 +411,42 @@ export default class ClusterDetailsComponent implements OnInit, OnDestroy {
     }
   }
 
+  isChangingHibernation = signal<boolean>(false);
+
+  // Hibernation is a request: the status follows once Gardener has scaled the
+  // cluster down or up, so only the activity list is refreshed here.
+  async toggleHibernation(): Promise<void> {
+    if (this.isChangingHibernation()) {
+      return;
+    }
+    this.isChangingHibernation.set(true);
+    const clusterId = this.clusterData.basics.id;
+    const wake = this.clusterData.status === ClusterStatus.HIBERNATED;
+    try {
+      if (wake) {
+        await firstValueFrom(
+          this.client.wakeCluster(create(WakeClusterRequestSchema, { clusterId })),
+        );
+        this.toastService.info(`The cluster '${this.clusterData.basics.name}' is waking up`);
+      } else {
+        await firstValueFrom(
+          this.client.hibernateCluster(create(HibernateClusterRequestSchema, { clusterId })),
+        );
+        this.toastService.info(`The cluster '${this.clusterData.basics.name}' is being hibernated`);
+      }
+      await this.loadClusterEvents(clusterId);
+    } catch (error) {
+      const action = wake ? 'wake' : 'hibernate';
+      this.toastService.error(
+        error instanceof Error
+          ? `Failed to ${action} cluster: ${error.message}`
+          : `Failed to ${action} cluster`,
+      );
+    } finally {
+      this.isChangingHibernation.set(false);
+    }
+  }
+
   getNodePoolStatusLabel = getNodePoolStatusLabel;
 
   deleteConfirmationInput = signal<string>('');
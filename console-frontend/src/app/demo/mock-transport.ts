@@ -41,6 +41,10 @@ import {
   ListPluginDefinitionsResponseSchema,
 } from '../../generated/v1/plugin_pb';
 import { APIKeyService, ListAPIKeysResponseSchema } from '../../generated/v1/apikey_pb';
+import {
+  AccessElevationService,
+  ListAccessElevationsResponseSchema,
+} from '../../generated/v1/access_elevation_pb';
 import { AuthnService, GetUserInfoResponseSchema } from '../../generated/authn/v1/authn_pb';
 import { ClusterStatus } from '../../generated/v1/common_pb';
 import * as fx from './fixtures';
@@ -231,5 +235,12 @@ export default function createDemoTransport(): Transport {
         return create(ListAPIKeysResponseSchema, { apiKeys: [] });
       },
     });
+
+    router.service(AccessElevationService, {
+      listAccessElevations: async () => {
+        await delay(80);
+        return create(ListAccessElevationsResponseSchema, { accessElevations: [] });
+      },
+    });
   });
 }
@@ -117,6 +117,15 @@ export default class OrganizationLimitsComponent implements OnInit {
     defaultCpuLimitM: undefined,
   });
 
+  // Caps on the number of clusters, projects and namespaces. They are set by
+  // platform operators and not edited here, but every save replaces all limits,
+  // so both forms send the stored values back unchanged.
+  private savedResourceCaps: {
+    maxClusters: number | undefined;
+    maxProjects: number | undefined;
+    maxNamespaces: number | undefined;
+  } = { maxClusters: undefined, maxProjects: undefined, maxNamespaces: undefined };
+
   protected namespaceDefaults = signal<NamespaceDefaults>({
     defaultMemoryRequestMi: undefined,
     defaultMemoryLimitMi: undefined,
@@ -175,6 +184,11 @@ export default class OrganizationLimitsComponent implements OnInit {
       };
       this.savedCluster.set(savedCluster);
       this.savedNamespace.set(savedNamespace);
+      this.savedResourceCaps = {
+        maxClusters: positive(limits?.maxClusters),
+        maxProjects: positive(limits?.maxProjects),
+        maxNamespaces: positive(limits?.maxNamespaces),
+      };
 
       // Show only what the organization has actually saved; an empty field means
       // "no limit". Platform defaults are offered via "Reset to defaults", never
@@ -216,6 +230,7 @@ export default class OrganizationLimitsComponent implements OnInit {
             maxNodePoolsPerCluster: maxNodePools,
             maxNodesPerNodePool,
             ...this.savedNamespace(),
+            ...this.savedResourceCaps,
           }),
         ),
       );
@@ -314,6 +329,7 @@ export default class OrganizationLimitsComponent implements OnInit {
             defaultMemoryLimitMi,
             defaultCpuRequestM,
             defaultCpuLimitM,
+            ...this.savedResourceCaps,
           }),
         ),
       );
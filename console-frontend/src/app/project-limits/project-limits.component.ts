@@ -12,6 +12,7 @@ import { firstValueFrom } from 'rxjs';
 
 import {
   GetProjectLimitsRequestSchema,
+  type ProjectLimits,
   UpdateProjectLimitsRequestSchema,
 } from '../../generated/v1/project_pb';
 import { PROJECT } from '../../connect/tokens';
@@ -56,6 +57,22 @@ export default class ProjectLimitsComponent implements OnInit {
 
   saving = signal(false);
 
+  // The ResourceQuota fields are not edited on this page, but an update replaces
+  // every field, so the stored values are sent back unchanged on save.
+  private quota: Partial<
+    Pick<
+      ProjectLimits,
+      | 'quotaCpuRequestM'
+      | 'quotaCpuLimitM'
+      | 'quotaMemoryRequestMi'
+      | 'quotaMemoryLimitMi'
+      | 'quotaStorageGi'
+      | 'quotaPods'
+      | 'quotaPersistentVolumeClaims'
+      | 'quotaServices'
+    >
+  > = {};
+
   // Platform defaults returned by the API, used by the "Reset to defaults" action.
   protected namespaceDefaults = signal<NamespaceDefaults>({
     defaultMemoryRequestMi: undefined,
@@ -93,6 +110,16 @@ export default class ProjectLimitsComponent implements OnInit {
       this.defaultMemoryLimitMi.set(positive(limits?.defaultMemoryLimitMi));
       this.defaultCpuRequestM.set(positive(limits?.defaultCpuRequestM));
       this.defaultCpuLimitM.set(positive(limits?.defaultCpuLimitM));
+      this.quota = {
+        quotaCpuRequestM: positive(limits?.quotaCpuRequestM),
+        quotaCpuLimitM: positive(limits?.quotaCpuLimitM),
+        quotaMemoryRequestMi: positive(limits?.quotaMemoryRequestMi),
+        quotaMemoryLimitMi: positive(limits?.quotaMemoryLimitMi),
+        quotaStorageGi: positive(limits?.quotaStorageGi),
+        quotaPods: positive(limits?.quotaPods),
+        quotaPersistentVolumeClaims: positive(limits?.quotaPersistentVolumeClaims),
+        quotaServices: positive(limits?.quotaServices),
+      };
       this.syncToggles();
     } catch {
       this.toastService.error('Failed to load project limits');
@@ -134,6 +161,7 @@ export default class ProjectLimitsComponent implements OnInit {
             defaultMemoryLimitMi: this.defaultMemoryLimitMi(),
             defaultCpuRequestM: this.defaultCpuRequestM(),
             defaultCpuLimitM: this.defaultCpuLimitM(),
+            ...this.quota,
           }),
         ),
       );
@@ -71,6 +71,7 @@ export function getStatusTagColor(status: ClusterStatus): string {
     [ClusterStatus.STOPPED]: 'neutral',
     [ClusterStatus.UNSPECIFIED]: 'neutral',
     [ClusterStatus.DELETING]: 'robijnrood',
+    [ClusterStatus.HIBERNATED]: 'lichtblauw',
   };
   return colors[status];
 }
@@ -86,6 +87,7 @@ export function getStatusLabel(status: ClusterStatus): string {
     [ClusterStatus.STOPPED]: 'Stopped',
     [ClusterStatus.UNSPECIFIED]: 'Unknown status',
     [ClusterStatus.DELETING]: 'Deleting',
+    [ClusterStatus.HIBERNATED]: 'Hibernated',
   };
   return labels[status];
 }
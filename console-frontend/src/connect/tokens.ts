@@ -8,6 +8,7 @@ import { ProjectService } from '../generated/v1/project_pb';
 import { MemberService } from '../generated/v1/member_pb';
 import { InviteService } from '../generated/v1/invite_pb';
 import { APIKeyService } from '../generated/v1/apikey_pb';
+import { AccessElevationService } from '../generated/v1/access_elevation_pb';
 import { createClientToken, AUTHN_TRANSPORT, ORGANIZATION_TRANSPORT } from './connect.module';
 
 // Create an injection token for the Authn service client
@@ -42,3 +43,6 @@ export const APIKEY = createClientToken(APIKeyService, ORGANIZATION_TRANSPORT);
 
 // Create an injection token for the Metrics service client
 export const METRICS = createClientToken(MetricsService, ORGANIZATION_TRANSPORT);
+
+// Create an injection token for the Access Elevation service client
+export const ACCESS_ELEVATION = createClientToken(AccessElevationService, ORGANIZATION_TRANSPORT);
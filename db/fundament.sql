@@ -83,6 +83,9 @@ CREATE TABLE tenant.organization_limits (
 	default_memory_limit_mi integer,
 	default_cpu_request_m integer,
 	default_cpu_limit_m integer,
+	max_clusters integer,
+	max_projects integer,
+	max_namespaces integer,
 	created timestamptz NOT NULL DEFAULT now(),
 	deleted timestamptz,
 	CONSTRAINT organization_limits_pk PRIMARY KEY (id),
@@ -95,14 +98,66 @@ CREATE TABLE tenant.organization_limits (
 	CONSTRAINT organization_limits_ck_default_cpu_request_m CHECK (default_cpu_request_m IS NULL OR default_cpu_request_m > 0),
 	CONSTRAINT organization_limits_ck_default_cpu_limit_m CHECK (default_cpu_limit_m IS NULL OR default_cpu_limit_m > 0),
 	CONSTRAINT organization_limits_ck_memory_limit_gte_request CHECK (default_memory_limit_mi IS NULL OR default_memory_request_mi IS NULL OR default_memory_limit_mi >= default_memory_request_mi),
-	CONSTRAINT organization_limits_ck_cpu_limit_gte_request CHECK (default_cpu_limit_m IS NULL OR default_cpu_request_m IS NULL OR default_cpu_limit_m >= default_cpu_request_m)
+	CONSTRAINT organization_limits_ck_cpu_limit_gte_request CHECK (default_cpu_limit_m IS NULL OR default_cpu_request_m IS NULL OR default_cpu_limit_m >= default_cpu_request_m),
+	CONSTRAINT organization_limits_ck_max_clusters CHECK (max_clusters IS NULL OR max_clusters > 0),
+	CONSTRAINT organization_limits_ck_max_projects CHECK (max_projects IS NULL OR max_projects > 0),
+	CONSTRAINT organization_limits_ck_max_namespaces CHECK (max_namespaces IS NULL OR max_namespaces > 0)
 );
 -- ddl-end --
+COMMENT ON COLUMN tenant.organization_limits.max_clusters IS E'Maximum number of active clusters in the organization.';
+-- ddl-end --
+COMMENT ON COLUMN tenant.organization_limits.max_projects IS E'Maximum number of active projects across all clusters of the organization.';
+-- ddl-end --
+COMMENT ON COLUMN tenant.organization_limits.max_namespaces IS E'Maximum number of active namespaces across all projects of the organization.';
+-- ddl-end --
 ALTER TABLE tenant.organization_limits OWNER TO fun_owner;
 -- ddl-end --
 ALTER TABLE tenant.organization_limits ENABLE ROW LEVEL SECURITY;
 -- ddl-end --
 
+-- object: tenant.organization_policies | type: TABLE --
+-- DROP TABLE IF EXISTS tenant.organization_policies CASCADE;
+CREATE TABLE tenant.organization_policies (
+	id uuid NOT NULL DEFAULT uuidv7(),
+	organization_id uuid NOT NULL,
+	allowed_regions text[] NOT NULL DEFAULT '{}',
+	denied_regions text[] NOT NULL DEFAULT '{}',
+	allowed_kubernetes_versions text[] NOT NULL DEFAULT '{}',
+	denied_kubernetes_versions text[] NOT NULL DEFAULT '{}',
+	allowed_machine_types text[] NOT NULL DEFAULT '{}',
+	denied_machine_types text[] NOT NULL DEFAULT '{}',
+	max_nodes_per_node_pool integer,
+	required_labels text[] NOT NULL DEFAULT '{}',
+	created timestamptz NOT NULL DEFAULT now(),
+	deleted timestamptz,
+	CONSTRAINT organization_policies_pk PRIMARY KEY (id),
+	CONSTRAINT organization_policies_uq_org UNIQUE NULLS NOT DISTINCT (organization_id,deleted),
+	CONSTRAINT organization_policies_ck_max_nodes_per_node_pool CHECK (max_nodes_per_node_pool IS NULL OR max_nodes_per_node_pool > 0)
+);
+-- ddl-end --
+COMMENT ON TABLE tenant.organization_policies IS E'Rules the organization API checks when clusters and node pools are created or changed. Entries of the allowed and denied lists may use * wildcards.';
+-- ddl-end --
+COMMENT ON COLUMN tenant.organization_policies.allowed_regions IS E'Regions clusters may be created in; empty allows every region.';
+-- ddl-end --
+COMMENT ON COLUMN tenant.organization_policies.denied_regions IS E'Regions clusters may not be created in, also when allowed_regions matches.';
+-- ddl-end --
+COMMENT ON COLUMN tenant.organization_policies.allowed_kubernetes_versions IS E'Kubernetes versions clusters may run; empty allows every version.';
+-- ddl-end --
+COMMENT ON COLUMN tenant.organization_policies.denied_kubernetes_versions IS E'Kubernetes versions clusters may not run, also when allowed_kubernetes_versions matches.';
+-- ddl-end --
+COMMENT ON COLUMN tenant.organization_policies.allowed_machine_types IS E'Machine types node pools may use; empty allows every machine type.';
+-- ddl-end --
+COMMENT ON COLUMN tenant.organization_policies.denied_machine_types IS E'Machine types node pools may not use, also when allowed_machine_types matches.';
+-- ddl-end --
+COMMENT ON COLUMN tenant.organization_policies.max_nodes_per_node_pool IS E'Largest autoscale maximum a node pool may ask for. Unlike the organization limit of the same name, a larger request is rejected instead of clamped.';
+-- ddl-end --
+COMMENT ON COLUMN tenant.organization_policies.required_labels IS E'Label keys every new cluster and node pool must carry.';
+-- ddl-end --
+ALTER TABLE tenant.organization_policies OWNER TO fun_owner;
+-- ddl-end --
+ALTER TABLE tenant.organization_policies ENABLE ROW LEVEL SECURITY;
+-- ddl-end --
+
 -- object: tenant.project_limits | type: TABLE --
 -- DROP TABLE IF EXISTS tenant.project_limits CASCADE;
 CREATE TABLE tenant.project_limits (
@@ -112,6 +167,14 @@ CREATE TABLE tenant.project_limits (
 	default_memory_limit_mi integer,
 	default_cpu_request_m integer,
 	default_cpu_limit_m integer,
+	quota_cpu_request_m integer,
+	quota_cpu_limit_m integer,
+	quota_memory_request_mi integer,
+	quota_memory_limit_mi integer,
+	quota_storage_gi integer,
+	quota_pods integer,
+	quota_persistent_volume_claims integer,
+	quota_services integer,
 	created timestamptz NOT NULL DEFAULT now(),
 	deleted timestamptz,
 	CONSTRAINT project_limits_pk PRIMARY KEY (id),
@@ -121,14 +184,76 @@ CREATE TABLE tenant.project_limits (
 	CONSTRAINT project_limits_ck_default_cpu_request_m CHECK (default_cpu_request_m IS NULL OR default_cpu_request_m > 0),
 	CONSTRAINT project_limits_ck_default_cpu_limit_m CHECK (default_cpu_limit_m IS NULL OR default_cpu_limit_m > 0),
 	CONSTRAINT project_limits_ck_memory_limit_gte_request CHECK (default_memory_limit_mi IS NULL OR default_memory_request_mi IS NULL OR default_memory_limit_mi >= default_memory_request_mi),
-	CONSTRAINT project_limits_ck_cpu_limit_gte_request CHECK (default_cpu_limit_m IS NULL OR default_cpu_request_m IS NULL OR default_cpu_limit_m >= default_cpu_request_m)
+	CONSTRAINT project_limits_ck_cpu_limit_gte_request CHECK (default_cpu_limit_m IS NULL OR default_cpu_request_m IS NULL OR default_cpu_limit_m >= default_cpu_request_m),
+	CONSTRAINT project_limits_ck_quota_cpu_request_m CHECK (quota_cpu_request_m IS NULL OR quota_cpu_request_m > 0),
+	CONSTRAINT project_limits_ck_quota_cpu_limit_m CHECK (quota_cpu_limit_m IS NULL OR quota_cpu_limit_m > 0),
+	CONSTRAINT project_limits_ck_quota_memory_request_mi CHECK (quota_memory_request_mi IS NULL OR quota_memory_request_mi > 0),
+	CONSTRAINT project_limits_ck_quota_memory_limit_mi CHECK (quota_memory_limit_mi IS NULL OR quota_memory_limit_mi > 0),
+	CONSTRAINT project_limits_ck_quota_storage_gi CHECK (quota_storage_gi IS NULL OR quota_storage_gi > 0),
+	CONSTRAINT project_limits_ck_quota_pods CHECK (quota_pods IS NULL OR quota_pods > 0),
+	CONSTRAINT project_limits_ck_quota_persistent_volume_claims CHECK (quota_persistent_volume_claims IS NULL OR quota_persistent_volume_claims > 0),
+	CONSTRAINT project_limits_ck_quota_services CHECK (quota_services IS NULL OR quota_services > 0),
+	CONSTRAINT project_limits_ck_quota_cpu_limit_gte_request CHECK (quota_cpu_limit_m IS NULL OR quota_cpu_request_m IS NULL OR quota_cpu_limit_m >= quota_cpu_request_m),
+	CONSTRAINT project_limits_ck_quota_memory_limit_gte_request CHECK (quota_memory_limit_mi IS NULL OR quota_memory_request_mi IS NULL OR quota_memory_limit_mi >= quota_memory_request_mi)
 );
 -- ddl-end --
+COMMENT ON COLUMN tenant.project_limits.quota_cpu_request_m IS E'Total CPU requests of all pods in a namespace (millicores), as the requests.cpu ResourceQuota.';
+-- ddl-end --
+COMMENT ON COLUMN tenant.project_limits.quota_cpu_limit_m IS E'Total CPU limits of all pods in a namespace (millicores), as the limits.cpu ResourceQuota.';
+-- ddl-end --
+COMMENT ON COLUMN tenant.project_limits.quota_memory_request_mi IS E'Total memory requests of all pods in a namespace (mebibytes), as the requests.memory ResourceQuota.';
+-- ddl-end --
+COMMENT ON COLUMN tenant.project_limits.quota_memory_limit_mi IS E'Total memory limits of all pods in a namespace (mebibytes), as the limits.memory ResourceQuota.';
+-- ddl-end --
+COMMENT ON COLUMN tenant.project_limits.quota_storage_gi IS E'Total storage requested by the PersistentVolumeClaims in a namespace (gibibytes).';
+-- ddl-end --
+COMMENT ON COLUMN tenant.project_limits.quota_pods IS E'Maximum number of pods in a namespace.';
+-- ddl-end --
+COMMENT ON COLUMN tenant.project_limits.quota_persistent_volume_claims IS E'Maximum number of PersistentVolumeClaims in a namespace.';
+-- ddl-end --
+COMMENT ON COLUMN tenant.project_limits.quota_services IS E'Maximum number of Services in a namespace.';
+-- ddl-end --
 ALTER TABLE tenant.project_limits OWNER TO fun_owner;
 -- ddl-end --
 ALTER TABLE tenant.project_limits ENABLE ROW LEVEL SECURITY;
 -- ddl-end --
 
+-- object: tenant.namespace_limits | type: TABLE --
+-- DROP TABLE IF EXISTS tenant.namespace_limits CASCADE;
+CREATE TABLE tenant.namespace_limits (
+	id uuid NOT NULL DEFAULT uuidv7(),
+	namespace_id uuid NOT NULL,
+	quota_cpu_request_m integer,
+	quota_cpu_limit_m integer,
+	quota_memory_request_mi integer,
+	quota_memory_limit_mi integer,
+	quota_storage_gi integer,
+	quota_pods integer,
+	quota_persistent_volume_claims integer,
+	quota_services integer,
+	created timestamptz NOT NULL DEFAULT now(),
+	deleted timestamptz,
+	CONSTRAINT namespace_limits_pk PRIMARY KEY (id),
+	CONSTRAINT namespace_limits_uq_namespace UNIQUE NULLS NOT DISTINCT (namespace_id,deleted),
+	CONSTRAINT namespace_limits_ck_quota_cpu_request_m CHECK (quota_cpu_request_m IS NULL OR quota_cpu_request_m > 0),
+	CONSTRAINT namespace_limits_ck_quota_cpu_limit_m CHECK (quota_cpu_limit_m IS NULL OR quota_cpu_limit_m > 0),
+	CONSTRAINT namespace_limits_ck_quota_memory_request_mi CHECK (quota_memory_request_mi IS NULL OR quota_memory_request_mi > 0),
+	CONSTRAINT namespace_limits_ck_quota_memory_limit_mi CHECK (quota_memory_limit_mi IS NULL OR quota_memory_limit_mi > 0),
+	CONSTRAINT namespace_limits_ck_quota_storage_gi CHECK (quota_storage_gi IS NULL OR quota_storage_gi > 0),
+	CONSTRAINT namespace_limits_ck_quota_pods CHECK (quota_pods IS NULL OR quota_pods > 0),
+	CONSTRAINT namespace_limits_ck_quota_persistent_volume_claims CHECK (quota_persistent_volume_claims IS NULL OR quota_persistent_volume_claims > 0),
+	CONSTRAINT namespace_limits_ck_quota_services CHECK (quota_services IS NULL OR quota_services > 0),
+	CONSTRAINT namespace_limits_ck_quota_cpu_limit_gte_request CHECK (quota_cpu_limit_m IS NULL OR quota_cpu_request_m IS NULL OR quota_cpu_limit_m >= quota_cpu_request_m),
+	CONSTRAINT namespace_limits_ck_quota_memory_limit_gte_request CHECK (quota_memory_limit_mi IS NULL OR quota_memory_request_mi IS NULL OR quota_memory_limit_mi >= quota_memory_request_mi)
+);
+-- ddl-end --
+COMMENT ON TABLE tenant.namespace_limits IS E'Per-namespace ResourceQuota overrides. Per field the lowest of the namespace and project value applies, so an override can only tighten the project quota.';
+-- ddl-end --
+ALTER TABLE tenant.namespace_limits OWNER TO fun_owner;
+-- ddl-end --
+ALTER TABLE tenant.namespace_limits ENABLE ROW LEVEL SECURITY;
+-- ddl-end --
+
 -- object: tenant.projects | type: TABLE --
 -- DROP TABLE IF EXISTS tenant.projects CASCADE;
 CREATE TABLE tenant.projects (
@@ -286,6 +411,52 @@ $function$;
 ALTER FUNCTION tenant.namespace_outbox_trigger() OWNER TO fun_owner;
 -- ddl-end --
 
+-- object: tenant.namespace_matches_pattern | type: FUNCTION --
+-- DROP FUNCTION IF EXISTS tenant.namespace_matches_pattern(text,text) CASCADE;
+CREATE OR REPLACE FUNCTION tenant.namespace_matches_pattern (IN p_name text, IN p_pattern text)
+	RETURNS boolean
+	LANGUAGE sql
+	IMMUTABLE 
+	CALLED ON NULL INPUT
+	SECURITY INVOKER
+	PARALLEL SAFE
+	COST 1
+	AS 
+$function$
+SELECT p_name LIKE replace(p_pattern, '*', '%')
+$function$;
+-- ddl-end --
+ALTER FUNCTION tenant.namespace_matches_pattern(text,text) OWNER TO fun_owner;
+-- ddl-end --
+
+-- object: tenant.namespace_role_binding_outbox_trigger | type: FUNCTION --
+-- DROP FUNCTION IF EXISTS tenant.namespace_role_binding_outbox_trigger() CASCADE;
+CREATE OR REPLACE FUNCTION tenant.namespace_role_binding_outbox_trigger ()
+	RETURNS trigger
+	LANGUAGE plpgsql
+	VOLATILE 
+	CALLED ON NULL INPUT
+	SECURITY DEFINER
+	PARALLEL UNSAFE
+	COST 1
+	AS 
+$function$
+BEGIN
+    IF TG_OP = 'INSERT' OR NEW IS DISTINCT FROM OLD THEN
+        INSERT INTO tenant.cluster_outbox (namespace_id, event, source)
+        SELECT tenant.namespaces.id, 'updated', 'trigger'
+        FROM tenant.namespaces
+        WHERE tenant.namespaces.project_id = NEW.project_id
+          AND tenant.namespaces.deleted IS NULL
+          AND tenant.namespace_matches_pattern(tenant.namespaces.name, NEW.namespace_pattern);
+    END IF;
+    RETURN NULL;
+END;
+$function$;
+-- ddl-end --
+ALTER FUNCTION tenant.namespace_role_binding_outbox_trigger() OWNER TO fun_owner;
+-- ddl-end --
+
 -- object: tenant.organization_limits_outbox_trigger | type: FUNCTION --
 -- DROP FUNCTION IF EXISTS tenant.organization_limits_outbox_trigger() CASCADE;
 CREATE OR REPLACE FUNCTION tenant.organization_limits_outbox_trigger ()
@@ -372,25 +543,41 @@ CREATE OR REPLACE FUNCTION tenant.project_limits_outbox_trigger ()
 	AS 
 $function$
 BEGIN
-    -- A deleted change only matters when the row carries default values in
-    -- OLD or NEW; otherwise the LimitRange reconcile would be a no-op.
-    IF (TG_OP = 'INSERT' AND (NEW.default_memory_request_mi IS NOT NULL
-                              OR NEW.default_memory_limit_mi IS NOT NULL
-                              OR NEW.default_cpu_request_m IS NOT NULL
-                              OR NEW.default_cpu_limit_m IS NOT NULL))
-       OR (TG_OP = 'UPDATE' AND (OLD.default_memory_request_mi IS DISTINCT FROM NEW.default_memory_request_mi
-                                 OR OLD.default_memory_limit_mi IS DISTINCT FROM NEW.default_memory_limit_mi
-                                 OR OLD.default_cpu_request_m IS DISTINCT FROM NEW.default_cpu_request_m
-                                 OR OLD.default_cpu_limit_m IS DISTINCT FROM NEW.default_cpu_limit_m
+    -- Both the LimitRange defaults and the ResourceQuota of a namespace come
+    -- from these columns. A deleted change only matters when the row carries
+    -- values in OLD or NEW; otherwise the namespace reconcile would be a no-op.
+    IF (TG_OP = 'INSERT' AND num_nonnulls(NEW.default_memory_request_mi, NEW.default_memory_limit_mi,
+                                          NEW.default_cpu_request_m, NEW.default_cpu_limit_m,
+                                          NEW.quota_cpu_request_m, NEW.quota_cpu_limit_m,
+                                          NEW.quota_memory_request_mi, NEW.quota_memory_limit_mi,
+                                          NEW.quota_storage_gi, NEW.quota_pods,
+                                          NEW.quota_persistent_volume_claims, NEW.quota_services) > 0)
+       OR (TG_OP = 'UPDATE' AND ((OLD.default_memory_request_mi, OLD.default_memory_limit_mi,
+                                  OLD.default_cpu_request_m, OLD.default_cpu_limit_m,
+                                  OLD.quota_cpu_request_m, OLD.quota_cpu_limit_m,
+                                  OLD.quota_memory_request_mi, OLD.quota_memory_limit_mi,
+                                  OLD.quota_storage_gi, OLD.quota_pods,
+                                  OLD.quota_persistent_volume_claims, OLD.quota_services)
+                                 IS DISTINCT FROM
+                                 (NEW.default_memory_request_mi, NEW.default_memory_limit_mi,
+                                  NEW.default_cpu_request_m, NEW.default_cpu_limit_m,
+                                  NEW.quota_cpu_request_m, NEW.quota_cpu_limit_m,
+                                  NEW.quota_memory_request_mi, NEW.quota_memory_limit_mi,
+                                  NEW.quota_storage_gi, NEW.quota_pods,
+                                  NEW.quota_persistent_volume_claims, NEW.quota_services)
                                  OR (OLD.deleted IS DISTINCT FROM NEW.deleted
-                                     AND (OLD.default_memory_request_mi IS NOT NULL
-                                          OR OLD.default_memory_limit_mi IS NOT NULL
-                                          OR OLD.default_cpu_request_m IS NOT NULL
-                                          OR OLD.default_cpu_limit_m IS NOT NULL
-                                          OR NEW.default_memory_request_mi IS NOT NULL
-                                          OR NEW.default_memory_limit_mi IS NOT NULL
-                                          OR NEW.default_cpu_request_m IS NOT NULL
-                                          OR NEW.default_cpu_limit_m IS NOT NULL))))
+                                     AND num_nonnulls(OLD.default_memory_request_mi, OLD.default_memory_limit_mi,
+                                                      OLD.default_cpu_request_m, OLD.default_cpu_limit_m,
+                                                      OLD.quota_cpu_request_m, OLD.quota_cpu_limit_m,
+                                                      OLD.quota_memory_request_mi, OLD.quota_memory_limit_mi,
+                                                      OLD.quota_storage_gi, OLD.quota_pods,
+                                                      OLD.quota_persistent_volume_claims, OLD.quota_services,
+                                                      NEW.default_memory_request_mi, NEW.default_memory_limit_mi,
+                                                      NEW.default_cpu_request_m, NEW.default_cpu_limit_m,
+                                                      NEW.quota_cpu_request_m, NEW.quota_cpu_limit_m,
+                                                      NEW.quota_memory_request_mi, NEW.quota_memory_limit_mi,
+                                                      NEW.quota_storage_gi, NEW.quota_pods,
+                                                      NEW.quota_persistent_volume_claims, NEW.quota_services) > 0)))
     THEN
         INSERT INTO tenant.cluster_outbox (namespace_id, event, source)
         SELECT tenant.namespaces.id, 'updated', 'trigger'
@@ -406,6 +593,33 @@ $function$;
 ALTER FUNCTION tenant.project_limits_outbox_trigger() OWNER TO fun_owner;
 -- ddl-end --
 
+-- object: tenant.namespace_limits_outbox_trigger | type: FUNCTION --
+-- DROP FUNCTION IF EXISTS tenant.namespace_limits_outbox_trigger() CASCADE;
+CREATE OR REPLACE FUNCTION tenant.namespace_limits_outbox_trigger ()
+	RETURNS trigger
+	LANGUAGE plpgsql
+	VOLATILE 
+	CALLED ON NULL INPUT
+	SECURITY DEFINER
+	PARALLEL UNSAFE
+	COST 1
+	AS 
+$function$
+BEGIN
+    -- Every change to an override re-renders the namespace's ResourceQuota;
+    -- overrides are written one row at a time, so there is no fan-out.
+    IF TG_OP = 'INSERT' OR NEW IS DISTINCT FROM OLD THEN
+        INSERT INTO tenant.cluster_outbox (namespace_id, event, source)
+        VALUES (NEW.namespace_id, 'updated', 'trigger');
+    END IF;
+
+    RETURN NULL;
+END;
+$function$;
+-- ddl-end --
+ALTER FUNCTION tenant.namespace_limits_outbox_trigger() OWNER TO fun_owner;
+-- ddl-end --
+
 -- object: tenant.cluster_outbox_cluster_trigger | type: FUNCTION --
 -- DROP FUNCTION IF EXISTS tenant.cluster_outbox_cluster_trigger() CASCADE;
 CREATE OR REPLACE FUNCTION tenant.cluster_outbox_cluster_trigger ()
@@ -423,6 +637,11 @@ BEGIN
        OR OLD.deleted IS DISTINCT FROM NEW.deleted
        OR OLD.region IS DISTINCT FROM NEW.region
        OR OLD.kubernetes_version IS DISTINCT FROM NEW.kubernetes_version
+       OR OLD.maintenance_window_start IS DISTINCT FROM NEW.maintenance_window_start
+       OR OLD.maintenance_window_end IS DISTINCT FROM NEW.maintenance_window_end
+       OR OLD.auto_update_kubernetes_version IS DISTINCT FROM NEW.auto_update_kubernetes_version
+       OR OLD.auto_update_machine_image_version IS DISTINCT FROM NEW.auto_update_machine_image_version
+       OR OLD.hibernation_requested IS DISTINCT FROM NEW.hibernation_requested
     THEN
         INSERT INTO tenant.cluster_outbox (cluster_id, event, source)
         VALUES (COALESCE(NEW.id, OLD.id),
@@ -440,9 +659,9 @@ $function$;
 ALTER FUNCTION tenant.cluster_outbox_cluster_trigger() OWNER TO fun_owner;
 -- ddl-end --
 
--- object: tenant.cluster_outbox_organization_user_trigger | type: FUNCTION --
--- DROP FUNCTION IF EXISTS tenant.cluster_outbox_organization_user_trigger() CASCADE;
-CREATE OR REPLACE FUNCTION tenant.cluster_outbox_organization_user_trigger ()
+-- object: tenant.cluster_hibernation_schedule_outbox_trigger | type: FUNCTION --
+-- DROP FUNCTION IF EXISTS tenant.cluster_hibernation_schedule_outbox_trigger() CASCADE;
+CREATE OR REPLACE FUNCTION tenant.cluster_hibernation_schedule_outbox_trigger ()
 	RETURNS trigger
 	LANGUAGE plpgsql
 	VOLATILE 
@@ -452,29 +671,24 @@ CREATE OR REPLACE FUNCTION tenant.cluster_outbox_organization_user_trigger ()
 	COST 1
 	AS 
 $function$
+DECLARE
+    schedule_cluster_id uuid := COALESCE(NEW.cluster_id, OLD.cluster_id);
 BEGIN
-    IF TG_OP = 'INSERT' OR NEW IS DISTINCT FROM OLD THEN
-        INSERT INTO tenant.cluster_outbox (organization_user_id, event, source)
-        VALUES (
-            COALESCE(NEW.id, OLD.id),
-            CASE
-                WHEN TG_OP = 'INSERT' THEN 'created'
-                WHEN OLD.deleted IS NULL AND NEW.deleted IS NOT NULL THEN 'deleted'
-                ELSE 'updated'
-            END,
-            'trigger'
-        );
+    -- Rows removed by a cascading cluster delete have nothing left to sync.
+    IF EXISTS (SELECT 1 FROM tenant.clusters WHERE tenant.clusters.id = schedule_cluster_id) THEN
+        INSERT INTO tenant.cluster_outbox (cluster_id, event, source)
+        VALUES (schedule_cluster_id, 'updated', 'trigger');
     END IF;
-    RETURN COALESCE(NEW, OLD);
+    RETURN NULL;
 END;
 $function$;
 -- ddl-end --
-ALTER FUNCTION tenant.cluster_outbox_organization_user_trigger() OWNER TO fun_owner;
+ALTER FUNCTION tenant.cluster_hibernation_schedule_outbox_trigger() OWNER TO fun_owner;
 -- ddl-end --
 
--- object: tenant.cluster_outbox_project_member_trigger | type: FUNCTION --
--- DROP FUNCTION IF EXISTS tenant.cluster_outbox_project_member_trigger() CASCADE;
-CREATE OR REPLACE FUNCTION tenant.cluster_outbox_project_member_trigger ()
+-- object: tenant.audit_events_organization_trigger | type: FUNCTION --
+-- DROP FUNCTION IF EXISTS tenant.audit_events_organization_trigger() CASCADE;
+CREATE OR REPLACE FUNCTION tenant.audit_events_organization_trigger ()
 	RETURNS trigger
 	LANGUAGE plpgsql
 	VOLATILE 
@@ -485,28 +699,22 @@ CREATE OR REPLACE FUNCTION tenant.cluster_outbox_project_member_trigger ()
 	AS 
 $function$
 BEGIN
-    IF TG_OP = 'INSERT' OR NEW IS DISTINCT FROM OLD THEN
-        INSERT INTO tenant.cluster_outbox (project_member_id, event, source)
-        VALUES (
-            COALESCE(NEW.id, OLD.id),
-            CASE
-                WHEN TG_OP = 'INSERT' THEN 'created'
-                WHEN OLD.deleted IS NULL AND NEW.deleted IS NOT NULL THEN 'deleted'
-                ELSE 'updated'
-            END,
-            'trigger'
-        );
+    -- kube-api-proxy only knows the cluster a request was made against.
+    IF NEW.organization_id IS NULL AND NEW.cluster_id IS NOT NULL THEN
+        SELECT tenant.clusters.organization_id INTO NEW.organization_id
+        FROM tenant.clusters
+        WHERE tenant.clusters.id = NEW.cluster_id;
     END IF;
-    RETURN COALESCE(NEW, OLD);
+    RETURN NEW;
 END;
 $function$;
 -- ddl-end --
-ALTER FUNCTION tenant.cluster_outbox_project_member_trigger() OWNER TO fun_owner;
+ALTER FUNCTION tenant.audit_events_organization_trigger() OWNER TO fun_owner;
 -- ddl-end --
 
--- object: tenant.cluster_outbox_notify | type: FUNCTION --
--- DROP FUNCTION IF EXISTS tenant.cluster_outbox_notify() CASCADE;
-CREATE OR REPLACE FUNCTION tenant.cluster_outbox_notify ()
+-- object: tenant.audit_events_append_only_trigger | type: FUNCTION --
+-- DROP FUNCTION IF EXISTS tenant.audit_events_append_only_trigger() CASCADE;
+CREATE OR REPLACE FUNCTION tenant.audit_events_append_only_trigger ()
 	RETURNS trigger
 	LANGUAGE plpgsql
 	VOLATILE 
@@ -517,1419 +725,3232 @@ CREATE OR REPLACE FUNCTION tenant.cluster_outbox_notify ()
 	AS 
 $function$
 BEGIN
-    PERFORM pg_notify('cluster_outbox', '');
-    RETURN NEW;
+    RAISE EXCEPTION 'tenant.audit_events is append-only (% rejected)', TG_OP;
 END;
 $function$;
 -- ddl-end --
-ALTER FUNCTION tenant.cluster_outbox_notify() OWNER TO fun_owner;
+ALTER FUNCTION tenant.audit_events_append_only_trigger() OWNER TO fun_owner;
 -- ddl-end --
 
--- object: tenant.cluster_outbox_update_cluster_status | type: FUNCTION --
--- DROP FUNCTION IF EXISTS tenant.cluster_outbox_update_cluster_status() CASCADE;
-CREATE OR REPLACE FUNCTION tenant.cluster_outbox_update_cluster_status ()
-	RETURNS trigger
+-- object: tenant.webhook_enqueue | type: FUNCTION --
+-- DROP FUNCTION IF EXISTS tenant.webhook_enqueue(uuid,text,jsonb) CASCADE;
+CREATE OR REPLACE FUNCTION tenant.webhook_enqueue (p_organization_id uuid, p_event_type text, p_data jsonb)
+	RETURNS void
 	LANGUAGE plpgsql
 	VOLATILE 
 	CALLED ON NULL INPUT
-	SECURITY INVOKER
+	SECURITY DEFINER
 	PARALLEL UNSAFE
 	COST 1
 	AS 
 $function$
-DECLARE
-    resolved_cluster_id uuid;
 BEGIN
-    IF NEW.cluster_id IS NOT NULL THEN
-        UPDATE tenant.clusters
-        SET outbox_status = NEW.status,
-            outbox_retries = NEW.retries,
-            outbox_error = NEW.status_info
-        WHERE tenant.clusters.id = NEW.cluster_id;
-    ELSIF NEW.node_pool_id IS NOT NULL THEN
-        SELECT tenant.node_pools.cluster_id INTO resolved_cluster_id
-        FROM tenant.node_pools
-        WHERE tenant.node_pools.id = NEW.node_pool_id;
-
-        IF resolved_cluster_id IS NOT NULL THEN
-            UPDATE tenant.clusters
-            SET outbox_status = NEW.status,
-                outbox_retries = NEW.retries,
-                outbox_error = NEW.status_info
-            WHERE tenant.clusters.id = resolved_cluster_id;
-        END IF;
-    END IF;
-    RETURN NULL;
+    INSERT INTO tenant.webhook_deliveries (webhook_id, event_type, payload)
+    SELECT tenant.webhooks.id,
+           p_event_type,
+           jsonb_build_object(
+               'type', p_event_type,
+               'organization_id', p_organization_id,
+               'created', now(),
+               'data', p_data)
+    FROM tenant.webhooks
+    WHERE tenant.webhooks.organization_id = p_organization_id
+      AND tenant.webhooks.deleted IS NULL
+      AND tenant.webhooks.enabled
+      AND (cardinality(tenant.webhooks.event_types) = 0
+           OR p_event_type = ANY (tenant.webhooks.event_types));
 END;
 $function$;
 -- ddl-end --
-ALTER FUNCTION tenant.cluster_outbox_update_cluster_status() OWNER TO fun_owner;
+ALTER FUNCTION tenant.webhook_enqueue(uuid,text,jsonb) OWNER TO fun_owner;
 -- ddl-end --
 
--- object: authn.current_user_id | type: FUNCTION --
--- DROP FUNCTION IF EXISTS authn.current_user_id() CASCADE;
-CREATE OR REPLACE FUNCTION authn.current_user_id ()
-	RETURNS uuid
-	LANGUAGE sql
-	STABLE 
+-- object: tenant.cluster_events_webhook_trigger | type: FUNCTION --
+-- DROP FUNCTION IF EXISTS tenant.cluster_events_webhook_trigger() CASCADE;
+CREATE OR REPLACE FUNCTION tenant.cluster_events_webhook_trigger ()
+	RETURNS trigger
+	LANGUAGE plpgsql
+	VOLATILE 
 	CALLED ON NULL INPUT
-	SECURITY INVOKER
-	PARALLEL SAFE
+	SECURITY DEFINER
+	PARALLEL UNSAFE
 	COST 1
 	AS 
 $function$
-SELECT NULLIF(current_setting('app.current_user_id', true), '')::uuid
+DECLARE
+    webhook_event text;
+    cluster_organization_id uuid;
+    cluster_name text;
+BEGIN
+    webhook_event := CASE NEW.event_type
+        WHEN 'status_ready' THEN 'cluster_ready'
+        WHEN 'status_error' THEN 'cluster_error'
+        WHEN 'status_deleted' THEN 'cluster_deleted'
+        WHEN 'status_hibernated' THEN 'cluster_hibernated'
+        WHEN 'sync_failed' THEN 'cluster_sync_failed'
+        WHEN 'upgrade_completed' THEN 'cluster_upgrade_completed'
+        WHEN 'upgrade_failed' THEN 'cluster_upgrade_failed'
+    END;
+    IF webhook_event IS NULL THEN
+        RETURN NULL;
+    END IF;
+
+    SELECT tenant.clusters.organization_id, tenant.clusters.name
+    INTO cluster_organization_id, cluster_name
+    FROM tenant.clusters
+    WHERE tenant.clusters.id = NEW.cluster_id;
+
+    PERFORM tenant.webhook_enqueue(cluster_organization_id, webhook_event, jsonb_strip_nulls(jsonb_build_object(
+        'cluster_id', NEW.cluster_id,
+        'cluster_name', cluster_name,
+        'message', NEW.message,
+        'attempt', NEW.attempt)));
+    RETURN NULL;
+END;
 $function$;
 -- ddl-end --
-ALTER FUNCTION authn.current_user_id() OWNER TO fun_fundament_api;
+ALTER FUNCTION tenant.cluster_events_webhook_trigger() OWNER TO fun_owner;
 -- ddl-end --
 
--- object: authn.current_organization_id | type: FUNCTION --
--- DROP FUNCTION IF EXISTS authn.current_organization_id() CASCADE;
-CREATE OR REPLACE FUNCTION authn.current_organization_id ()
-	RETURNS uuid
-	LANGUAGE sql
-	STABLE 
+-- object: tenant.cluster_plugin_status_webhook_trigger | type: FUNCTION --
+-- DROP FUNCTION IF EXISTS tenant.cluster_plugin_status_webhook_trigger() CASCADE;
+CREATE OR REPLACE FUNCTION tenant.cluster_plugin_status_webhook_trigger ()
+	RETURNS trigger
+	LANGUAGE plpgsql
+	VOLATILE 
 	CALLED ON NULL INPUT
-	SECURITY INVOKER
-	PARALLEL SAFE
+	SECURITY DEFINER
+	PARALLEL UNSAFE
 	COST 1
 	AS 
 $function$
-SELECT NULLIF(current_setting('app.current_organization_id', true), '')::uuid
+DECLARE
+    webhook_event text;
+    cluster_organization_id uuid;
+    cluster_name text;
+BEGIN
+    IF TG_OP = 'UPDATE' AND OLD.phase = NEW.phase THEN
+        RETURN NULL;
+    END IF;
+
+    webhook_event := CASE NEW.phase
+        WHEN 'Running' THEN 'plugin_ready'
+        WHEN 'Degraded' THEN 'plugin_degraded'
+        WHEN 'Failed' THEN 'plugin_failed'
+    END;
+    -- An installation that is already running when it is first seen did not
+    -- just become ready; only a transition into Running is reported.
+    IF webhook_event IS NULL OR (TG_OP = 'INSERT' AND NEW.phase = 'Running') THEN
+        RETURN NULL;
+    END IF;
+
+    SELECT tenant.clusters.organization_id, tenant.clusters.name
+    INTO cluster_organization_id, cluster_name
+    FROM tenant.clusters
+    WHERE tenant.clusters.id = NEW.cluster_id;
+
+    PERFORM tenant.webhook_enqueue(cluster_organization_id, webhook_event, jsonb_strip_nulls(jsonb_build_object(
+        'cluster_id', NEW.cluster_id,
+        'cluster_name', cluster_name,
+        'plugin_installation', NEW.name,
+        'phase', NEW.phase,
+        'message', NEW.message)));
+    RETURN NULL;
+END;
 $function$;
 -- ddl-end --
-ALTER FUNCTION authn.current_organization_id() OWNER TO fun_fundament_api;
+ALTER FUNCTION tenant.cluster_plugin_status_webhook_trigger() OWNER TO fun_owner;
 -- ddl-end --
 
--- object: authn.is_project_in_organization | type: FUNCTION --
--- DROP FUNCTION IF EXISTS authn.is_project_in_organization(uuid) CASCADE;
-CREATE OR REPLACE FUNCTION authn.is_project_in_organization (IN p_project_id uuid)
-	RETURNS boolean
-	LANGUAGE sql
-	STABLE 
+-- object: tenant.organizations_users_webhook_trigger | type: FUNCTION --
+-- DROP FUNCTION IF EXISTS tenant.organizations_users_webhook_trigger() CASCADE;
+CREATE OR REPLACE FUNCTION tenant.organizations_users_webhook_trigger ()
+	RETURNS trigger
+	LANGUAGE plpgsql
+	VOLATILE 
 	CALLED ON NULL INPUT
 	SECURITY DEFINER
-	PARALLEL SAFE
+	PARALLEL UNSAFE
 	COST 1
 	AS 
 $function$
-SELECT EXISTS (
-    SELECT 1 FROM tenant.projects
-    JOIN tenant.clusters ON tenant.clusters.id = tenant.projects.cluster_id
-    WHERE tenant.projects.id = p_project_id
-    AND tenant.clusters.organization_id = authn.current_organization_id()
-)
+BEGIN
+    IF NEW.status = 'pending' THEN
+        PERFORM tenant.webhook_enqueue(NEW.organization_id, 'member_invited', jsonb_strip_nulls(jsonb_build_object(
+            'user_id', NEW.user_id,
+            'email', (SELECT tenant.users.email FROM tenant.users WHERE tenant.users.id = NEW.user_id),
+            'permission', NEW.permission)));
+    END IF;
+    RETURN NULL;
+END;
 $function$;
 -- ddl-end --
-ALTER FUNCTION authn.is_project_in_organization(uuid) OWNER TO fun_authz;
+ALTER FUNCTION tenant.organizations_users_webhook_trigger() OWNER TO fun_owner;
 -- ddl-end --
 
--- object: authn.is_cluster_in_organization | type: FUNCTION --
--- DROP FUNCTION IF EXISTS authn.is_cluster_in_organization(uuid) CASCADE;
-CREATE OR REPLACE FUNCTION authn.is_cluster_in_organization (IN p_cluster_id uuid)
-	RETURNS boolean
-	LANGUAGE sql
-	STABLE 
+-- object: tenant.webhook_deliveries_notify | type: FUNCTION --
+-- DROP FUNCTION IF EXISTS tenant.webhook_deliveries_notify() CASCADE;
+CREATE OR REPLACE FUNCTION tenant.webhook_deliveries_notify ()
+	RETURNS trigger
+	LANGUAGE plpgsql
+	VOLATILE 
 	CALLED ON NULL INPUT
-	SECURITY DEFINER
-	PARALLEL SAFE
+	SECURITY INVOKER
+	PARALLEL UNSAFE
 	COST 1
 	AS 
 $function$
-SELECT EXISTS (
-    SELECT 1 FROM tenant.clusters
-    WHERE id = p_cluster_id
-    AND organization_id = authn.current_organization_id()
-)
+BEGIN
+    PERFORM pg_notify('webhook_deliveries', '');
+    RETURN NEW;
+END;
 $function$;
 -- ddl-end --
-ALTER FUNCTION authn.is_cluster_in_organization(uuid) OWNER TO fun_authz;
+ALTER FUNCTION tenant.webhook_deliveries_notify() OWNER TO fun_owner;
 -- ddl-end --
 
--- object: authn.is_organization_member | type: FUNCTION --
--- DROP FUNCTION IF EXISTS authn.is_organization_member(uuid) CASCADE;
-CREATE OR REPLACE FUNCTION authn.is_organization_member (IN p_organization_id uuid)
-	RETURNS boolean
-	LANGUAGE sql
-	STABLE 
+-- object: tenant.cluster_outbox_organization_user_trigger | type: FUNCTION --
+-- DROP FUNCTION IF EXISTS tenant.cluster_outbox_organization_user_trigger() CASCADE;
+CREATE OR REPLACE FUNCTION tenant.cluster_outbox_organization_user_trigger ()
+	RETURNS trigger
+	LANGUAGE plpgsql
+	VOLATILE 
 	CALLED ON NULL INPUT
 	SECURITY DEFINER
-	PARALLEL SAFE
+	PARALLEL UNSAFE
 	COST 1
 	AS 
 $function$
-SELECT EXISTS (
-    SELECT 1 FROM tenant.organizations_users
-    WHERE organization_id = p_organization_id
-    AND user_id = authn.current_user_id()
-    AND deleted IS NULL
-)
+BEGIN
+    IF TG_OP = 'INSERT' OR NEW IS DISTINCT FROM OLD THEN
+        INSERT INTO tenant.cluster_outbox (organization_user_id, event, source)
+        VALUES (
+            COALESCE(NEW.id, OLD.id),
+            CASE
+                WHEN TG_OP = 'INSERT' THEN 'created'
+                WHEN OLD.deleted IS NULL AND NEW.deleted IS NOT NULL THEN 'deleted'
+                ELSE 'updated'
+            END,
+            'trigger'
+        );
+    END IF;
+    RETURN COALESCE(NEW, OLD);
+END;
 $function$;
 -- ddl-end --
-ALTER FUNCTION authn.is_organization_member(uuid) OWNER TO fun_authz;
+ALTER FUNCTION tenant.cluster_outbox_organization_user_trigger() OWNER TO fun_owner;
 -- ddl-end --
 
--- object: tenant.projects_tr_verify_deleted | type: FUNCTION --
--- DROP FUNCTION IF EXISTS tenant.projects_tr_verify_deleted() CASCADE;
-CREATE OR REPLACE FUNCTION tenant.projects_tr_verify_deleted ()
+-- object: tenant.cluster_outbox_project_member_trigger | type: FUNCTION --
+-- DROP FUNCTION IF EXISTS tenant.cluster_outbox_project_member_trigger() CASCADE;
+CREATE OR REPLACE FUNCTION tenant.cluster_outbox_project_member_trigger ()
 	RETURNS trigger
 	LANGUAGE plpgsql
 	VOLATILE 
 	CALLED ON NULL INPUT
-	SECURITY INVOKER
+	SECURITY DEFINER
 	PARALLEL UNSAFE
 	COST 1
 	AS 
 $function$
 BEGIN
-	IF NEW.deleted IS NOT NULL AND EXISTS (
-		SELECT 1
-		FROM tenant.namespaces
-		WHERE
-			project_id = NEW.id
-			AND deleted IS NULL
-	) THEN
-		RAISE EXCEPTION 'Cannot delete project with undeleted namespaces';
-	END IF;
-	RETURN NEW;
+    IF TG_OP = 'INSERT' OR NEW IS DISTINCT FROM OLD THEN
+        INSERT INTO tenant.cluster_outbox (project_member_id, event, source)
+        VALUES (
+            COALESCE(NEW.id, OLD.id),
+            CASE
+                WHEN TG_OP = 'INSERT' THEN 'created'
+                WHEN OLD.deleted IS NULL AND NEW.deleted IS NOT NULL THEN 'deleted'
+                ELSE 'updated'
+            END,
+            'trigger'
+        );
+    END IF;
+    RETURN COALESCE(NEW, OLD);
 END;
 $function$;
 -- ddl-end --
-ALTER FUNCTION tenant.projects_tr_verify_deleted() OWNER TO postgres;
+ALTER FUNCTION tenant.cluster_outbox_project_member_trigger() OWNER TO fun_owner;
 -- ddl-end --
 
--- object: tenant.project_members_tr_protect_last_admin | type: FUNCTION --
--- DROP FUNCTION IF EXISTS tenant.project_members_tr_protect_last_admin() CASCADE;
-CREATE OR REPLACE FUNCTION tenant.project_members_tr_protect_last_admin ()
+-- object: tenant.cluster_outbox_access_elevation_trigger | type: FUNCTION --
+-- DROP FUNCTION IF EXISTS tenant.cluster_outbox_access_elevation_trigger() CASCADE;
+CREATE OR REPLACE FUNCTION tenant.cluster_outbox_access_elevation_trigger ()
 	RETURNS trigger
 	LANGUAGE plpgsql
 	VOLATILE 
 	CALLED ON NULL INPUT
-	SECURITY INVOKER
+	SECURITY DEFINER
 	PARALLEL UNSAFE
 	COST 1
 	AS 
 $function$
-DECLARE
-    admin_count integer;
 BEGIN
-    -- Only check if we're soft-deleting an admin or demoting an admin (UPDATE role from admin)
-    IF OLD.role = 'admin' AND OLD.deleted IS NULL THEN
-        -- Check if this is a soft delete (setting deleted) or role demotion
-        IF (NEW.deleted IS NOT NULL) OR (NEW.role != 'admin') THEN
-            SELECT COUNT(*) INTO admin_count
-            FROM tenant.project_members
-            WHERE project_id = OLD.project_id
-            AND role = 'admin'
-            AND id != OLD.id
-            AND deleted IS NULL;
-
-            IF admin_count = 0 THEN
-                RAISE EXCEPTION 'Cannot remove or demote the last admin of a project'
-                            USING HINT = 'project_contains_one_admin';
-            END IF;
-        END IF;
+    IF NEW.status = 'approved' AND (TG_OP = 'INSERT' OR OLD.status <> 'approved') THEN
+        INSERT INTO tenant.cluster_outbox (access_elevation_id, event, source)
+        VALUES (NEW.id, 'created', 'trigger');
+    ELSIF TG_OP = 'UPDATE' AND OLD.status = 'approved' AND NEW.status <> 'approved' THEN
+        INSERT INTO tenant.cluster_outbox (access_elevation_id, event, source)
+        VALUES (NEW.id, 'deleted', 'trigger');
     END IF;
-
     RETURN NEW;
 END;
 $function$;
 -- ddl-end --
-ALTER FUNCTION tenant.project_members_tr_protect_last_admin() OWNER TO postgres;
+ALTER FUNCTION tenant.cluster_outbox_access_elevation_trigger() OWNER TO fun_owner;
 -- ddl-end --
 
--- object: tenant.projects_tr_require_admin | type: FUNCTION --
--- DROP FUNCTION IF EXISTS tenant.projects_tr_require_admin() CASCADE;
-CREATE OR REPLACE FUNCTION tenant.projects_tr_require_admin ()
+-- object: tenant.cluster_outbox_team_member_trigger | type: FUNCTION --
+-- DROP FUNCTION IF EXISTS tenant.cluster_outbox_team_member_trigger() CASCADE;
+CREATE OR REPLACE FUNCTION tenant.cluster_outbox_team_member_trigger ()
 	RETURNS trigger
 	LANGUAGE plpgsql
 	VOLATILE 
 	CALLED ON NULL INPUT
-	SECURITY INVOKER
+	SECURITY DEFINER
 	PARALLEL UNSAFE
 	COST 1
 	AS 
 $function$
 BEGIN
-    IF NOT EXISTS (
-        SELECT 1 FROM tenant.project_members
-        WHERE project_id = NEW.id
-        AND role = 'admin'
-        AND deleted IS NULL
-    ) THEN
-        RAISE EXCEPTION 'Project must have at least one admin';
+    IF TG_OP = 'INSERT' OR OLD.deleted IS DISTINCT FROM NEW.deleted THEN
+        INSERT INTO tenant.cluster_outbox (organization_user_id, event, source)
+        SELECT tenant.organizations_users.id, 'updated', 'trigger'
+        FROM tenant.teams
+        JOIN tenant.organizations_users
+            ON tenant.organizations_users.organization_id = tenant.teams.organization_id
+            AND tenant.organizations_users.user_id = NEW.user_id
+            AND tenant.organizations_users.deleted IS NULL
+        WHERE tenant.teams.id = NEW.team_id;
     END IF;
     RETURN NEW;
 END;
 $function$;
 -- ddl-end --
-ALTER FUNCTION tenant.projects_tr_require_admin() OWNER TO postgres;
--- ddl-end --
-
--- object: tenant.users | type: TABLE --
--- DROP TABLE IF EXISTS tenant.users CASCADE;
-CREATE TABLE tenant.users (
-	id uuid NOT NULL DEFAULT uuidv7(),
-	name text NOT NULL,
-	external_ref text,
-	email text,
-	created timestamptz NOT NULL DEFAULT now(),
-	deleted timestamptz,
-	CONSTRAINT users_pk PRIMARY KEY (id)
-);
--- ddl-end --
-ALTER TABLE tenant.users OWNER TO fun_owner;
--- ddl-end --
-ALTER TABLE tenant.users ENABLE ROW LEVEL SECURITY;
--- ddl-end --
-
--- object: users_uq_external_ref | type: INDEX --
--- DROP INDEX IF EXISTS tenant.users_uq_external_ref CASCADE;
-CREATE UNIQUE INDEX users_uq_external_ref ON tenant.users
-USING btree
-(
-	external_ref
-)
-WHERE (deleted IS NULL);
--- ddl-end --
-
--- object: authn.api_keys | type: TABLE --
--- DROP TABLE IF EXISTS authn.api_keys CASCADE;
-CREATE TABLE authn.api_keys (
-	id uuid NOT NULL DEFAULT uuidv7(),
-	organization_id uuid NOT NULL,
-	user_id uuid NOT NULL,
-	name text NOT NULL,
-	token_hash bytea NOT NULL,
-	token_prefix text NOT NULL,
-	expires timestamptz,
-	revoked timestamptz,
-	last_used timestamptz,
-	created timestamptz NOT NULL DEFAULT now(),
-	deleted timestamptz,
-	CONSTRAINT api_keys_pk PRIMARY KEY (id),
-	CONSTRAINT api_keys_uq_token_hash UNIQUE (token_hash),
-	CONSTRAINT api_keys_uq_name UNIQUE NULLS NOT DISTINCT (organization_id,name,deleted)
-);
--- ddl-end --
-ALTER TABLE authn.api_keys OWNER TO fun_owner;
--- ddl-end --
-ALTER TABLE authn.api_keys ENABLE ROW LEVEL SECURITY;
--- ddl-end --
-
--- object: api_keys_organization_policy | type: POLICY --
--- DROP POLICY IF EXISTS api_keys_organization_policy ON authn.api_keys CASCADE;
-CREATE POLICY api_keys_organization_policy ON authn.api_keys
-	AS PERMISSIVE
-	FOR ALL
-	TO fun_fundament_api
-	USING (organization_id = current_setting('app.current_organization_id')::uuid);
+ALTER FUNCTION tenant.cluster_outbox_team_member_trigger() OWNER TO fun_owner;
 -- ddl-end --
 
--- object: authn.api_key_get_by_hash | type: FUNCTION --
--- DROP FUNCTION IF EXISTS authn.api_key_get_by_hash(bytea) CASCADE;
-CREATE OR REPLACE FUNCTION authn.api_key_get_by_hash (IN p_token_hash bytea)
-	RETURNS authn.api_keys
+-- object: tenant.cluster_outbox_project_team_trigger | type: FUNCTION --
+-- DROP FUNCTION IF EXISTS tenant.cluster_outbox_project_team_trigger() CASCADE;
+CREATE OR REPLACE FUNCTION tenant.cluster_outbox_project_team_trigger ()
+	RETURNS trigger
 	LANGUAGE plpgsql
 	VOLATILE 
 	CALLED ON NULL INPUT
 	SECURITY DEFINER
 	PARALLEL UNSAFE
-	COST 10
+	COST 1
 	AS 
 $function$
-DECLARE
-	result authn.api_keys;
 BEGIN
-	SELECT * INTO result FROM authn.api_keys WHERE token_hash = p_token_hash;
-
-	IF NOT FOUND THEN
-		RETURN NULL;
-	END IF;
-
-	RETURN result;
+    IF TG_OP = 'INSERT' OR OLD.deleted IS DISTINCT FROM NEW.deleted THEN
+        INSERT INTO tenant.cluster_outbox (organization_user_id, event, source)
+        SELECT tenant.organizations_users.id, 'updated', 'trigger'
+        FROM tenant.teams
+        JOIN tenant.team_members
+            ON tenant.team_members.team_id = tenant.teams.id
+            AND tenant.team_members.deleted IS NULL
+        JOIN tenant.organizations_users
+            ON tenant.organizations_users.organization_id = tenant.teams.organization_id
+            AND tenant.organizations_users.user_id = tenant.team_members.user_id
+            AND tenant.organizations_users.deleted IS NULL
+        WHERE tenant.teams.id = NEW.team_id;
+    END IF;
+    RETURN NEW;
 END;
 $function$;
 -- ddl-end --
-ALTER FUNCTION authn.api_key_get_by_hash(bytea) OWNER TO fun_owner;
+ALTER FUNCTION tenant.cluster_outbox_project_team_trigger() OWNER TO fun_owner;
 -- ddl-end --
 
--- object: authn.api_key_update_last_used | type: FUNCTION --
--- DROP FUNCTION IF EXISTS authn.api_key_update_last_used(uuid) CASCADE;
-CREATE OR REPLACE FUNCTION authn.api_key_update_last_used (IN p_id uuid)
-	RETURNS void
+-- object: tenant.cluster_outbox_team_trigger | type: FUNCTION --
+-- DROP FUNCTION IF EXISTS tenant.cluster_outbox_team_trigger() CASCADE;
+CREATE OR REPLACE FUNCTION tenant.cluster_outbox_team_trigger ()
+	RETURNS trigger
 	LANGUAGE plpgsql
 	VOLATILE 
 	CALLED ON NULL INPUT
 	SECURITY DEFINER
 	PARALLEL UNSAFE
-	COST 10
+	COST 1
 	AS 
 $function$
 BEGIN
-	UPDATE authn.api_keys SET last_used = NOW() WHERE id = p_id;
+    IF OLD.permission IS DISTINCT FROM NEW.permission OR OLD.deleted IS DISTINCT FROM NEW.deleted THEN
+        INSERT INTO tenant.cluster_outbox (organization_user_id, event, source)
+        SELECT tenant.organizations_users.id, 'updated', 'trigger'
+        FROM tenant.team_members
+        JOIN tenant.organizations_users
+            ON tenant.organizations_users.organization_id = NEW.organization_id
+            AND tenant.organizations_users.user_id = tenant.team_members.user_id
+            AND tenant.organizations_users.deleted IS NULL
+        WHERE tenant.team_members.team_id = NEW.id
+            AND tenant.team_members.deleted IS NULL;
+    END IF;
+    RETURN NEW;
 END;
 $function$;
 -- ddl-end --
-ALTER FUNCTION authn.api_key_update_last_used(uuid) OWNER TO fun_owner;
+ALTER FUNCTION tenant.cluster_outbox_team_trigger() OWNER TO fun_owner;
 -- ddl-end --
 
--- object: catalog.kubernetes_versions | type: TABLE --
--- DROP TABLE IF EXISTS catalog.kubernetes_versions CASCADE;
-CREATE TABLE catalog.kubernetes_versions (
-	id uuid NOT NULL DEFAULT uuidv7(),
-	version text NOT NULL,
-	CONSTRAINT kubernetes_versions_pk PRIMARY KEY (id),
-	CONSTRAINT kubernetes_versions_uq_version UNIQUE (version)
-);
+-- object: tenant.cluster_outbox_notify | type: FUNCTION --
+-- DROP FUNCTION IF EXISTS tenant.cluster_outbox_notify() CASCADE;
+CREATE OR REPLACE FUNCTION tenant.cluster_outbox_notify ()
+	RETURNS trigger
+	LANGUAGE plpgsql
+	VOLATILE 
+	CALLED ON NULL INPUT
+	SECURITY INVOKER
+	PARALLEL UNSAFE
+	COST 1
+	AS 
+$function$
+BEGIN
+    PERFORM pg_notify('cluster_outbox', '');
+    RETURN NEW;
+END;
+$function$;
 -- ddl-end --
-ALTER TABLE catalog.kubernetes_versions OWNER TO fun_owner;
+ALTER FUNCTION tenant.cluster_outbox_notify() OWNER TO fun_owner;
 -- ddl-end --
 
--- object: catalog.machine_types | type: TABLE --
--- DROP TABLE IF EXISTS catalog.machine_types CASCADE;
-CREATE TABLE catalog.machine_types (
-	id uuid NOT NULL DEFAULT uuidv7(),
-	name text NOT NULL,
-	lcpu integer NOT NULL,
-	memory bigint NOT NULL,
-	CONSTRAINT machine_types_pk PRIMARY KEY (id),
-	CONSTRAINT machine_types_uq_name UNIQUE (name)
-);
--- ddl-end --
-ALTER TABLE catalog.machine_types OWNER TO fun_owner;
--- ddl-end --
+-- object: tenant.cluster_outbox_update_cluster_status | type: FUNCTION --
+-- DROP FUNCTION IF EXISTS tenant.cluster_outbox_update_cluster_status() CASCADE;
+CREATE OR REPLACE FUNCTION tenant.cluster_outbox_update_cluster_status ()
+	RETURNS trigger
+	LANGUAGE plpgsql
+	VOLATILE 
+	CALLED ON NULL INPUT
+	SECURITY INVOKER
+	PARALLEL UNSAFE
+	COST 1
+	AS 
+$function$
+DECLARE
+    resolved_cluster_id uuid;
+BEGIN
+    IF NEW.cluster_id IS NOT NULL THEN
+        UPDATE tenant.clusters
+        SET outbox_status = NEW.status,
+            outbox_retries = NEW.retries,
+            outbox_error = NEW.status_info
+        WHERE tenant.clusters.id = NEW.cluster_id;
+    ELSIF NEW.node_pool_id IS NOT NULL THEN
+        SELECT tenant.node_pools.cluster_id INTO resolved_cluster_id
+        FROM tenant.node_pools
+        WHERE tenant.node_pools.id = NEW.node_pool_id;
 
--- object: catalog.regions | type: TABLE --
--- DROP TABLE IF EXISTS catalog.regions CASCADE;
-CREATE TABLE catalog.regions (
-	id uuid NOT NULL DEFAULT uuidv7(),
-	name text NOT NULL,
-	cloud_profile text NOT NULL,
-	cloud_profile_region text NOT NULL,
-	CONSTRAINT regions_pk PRIMARY KEY (id),
-	CONSTRAINT regions_uq_name UNIQUE (name)
-);
+        IF resolved_cluster_id IS NOT NULL THEN
+            UPDATE tenant.clusters
+            SET outbox_status = NEW.status,
+                outbox_retries = NEW.retries,
+                outbox_error = NEW.status_info
+            WHERE tenant.clusters.id = resolved_cluster_id;
+        END IF;
+    END IF;
+    RETURN NULL;
+END;
+$function$;
 -- ddl-end --
-ALTER TABLE catalog.regions OWNER TO fun_owner;
+ALTER FUNCTION tenant.cluster_outbox_update_cluster_status() OWNER TO fun_owner;
 -- ddl-end --
 
--- object: catalog.region_kubernetes_versions | type: TABLE --
--- DROP TABLE IF EXISTS catalog.region_kubernetes_versions CASCADE;
-CREATE TABLE catalog.region_kubernetes_versions (
-	region_id uuid NOT NULL,
-	kubernetes_version_id uuid NOT NULL,
-	CONSTRAINT region_kubernetes_versions_pk PRIMARY KEY (region_id,kubernetes_version_id)
-);
--- ddl-end --
-ALTER TABLE catalog.region_kubernetes_versions OWNER TO fun_owner;
--- ddl-end --
+-- object: tenant.cluster_outbox_partition_trigger | type: FUNCTION --
+-- DROP FUNCTION IF EXISTS tenant.cluster_outbox_partition_trigger() CASCADE;
+CREATE OR REPLACE FUNCTION tenant.cluster_outbox_partition_trigger ()
+	RETURNS trigger
+	LANGUAGE plpgsql
+	VOLATILE 
+	CALLED ON NULL INPUT
+	SECURITY DEFINER
+	PARALLEL UNSAFE
+	COST 1
+	AS 
+$function$
+BEGIN
+    IF NEW.cluster_id IS NOT NULL THEN
+        SELECT tenant.clusters.organization_id, tenant.clusters.id
+        INTO NEW.organization_id, NEW.partition_key
+        FROM tenant.clusters
+        WHERE tenant.clusters.id = NEW.cluster_id;
+    ELSIF NEW.node_pool_id IS NOT NULL THEN
+        SELECT tenant.clusters.organization_id, tenant.clusters.id
+        INTO NEW.organization_id, NEW.partition_key
+        FROM tenant.node_pools
+        JOIN tenant.clusters ON tenant.clusters.id = tenant.node_pools.cluster_id
+        WHERE tenant.node_pools.id = NEW.node_pool_id;
+    ELSIF NEW.namespace_id IS NOT NULL THEN
+        SELECT tenant.clusters.organization_id, tenant.clusters.id
+        INTO NEW.organization_id, NEW.partition_key
+        FROM tenant.namespaces
+        JOIN tenant.projects ON tenant.projects.id = tenant.namespaces.project_id
+        JOIN tenant.clusters ON tenant.clusters.id = tenant.projects.cluster_id
+        WHERE tenant.namespaces.id = NEW.namespace_id;
+    ELSIF NEW.project_member_id IS NOT NULL THEN
+        SELECT tenant.clusters.organization_id, tenant.clusters.id
+        INTO NEW.organization_id, NEW.partition_key
+        FROM tenant.project_members
+        JOIN tenant.projects ON tenant.projects.id = tenant.project_members.project_id
+        JOIN tenant.clusters ON tenant.clusters.id = tenant.projects.cluster_id
+        WHERE tenant.project_members.id = NEW.project_member_id;
+    ELSIF NEW.organization_user_id IS NOT NULL THEN
+        SELECT tenant.organizations_users.organization_id, tenant.organizations_users.organization_id
+        INTO NEW.organization_id, NEW.partition_key
+        FROM tenant.organizations_users
+        WHERE tenant.organizations_users.id = NEW.organization_user_id;
+    ELSIF NEW.access_elevation_id IS NOT NULL THEN
+        SELECT tenant.access_elevations.organization_id,
+               COALESCE(tenant.projects.cluster_id, tenant.access_elevations.organization_id)
+        INTO NEW.organization_id, NEW.partition_key
+        FROM tenant.access_elevations
+        LEFT JOIN tenant.projects ON tenant.projects.id = tenant.access_elevations.project_id
+        WHERE tenant.access_elevations.id = NEW.access_elevation_id;
+    END IF;
 
--- object: catalog.region_machine_types | type: TABLE --
--- DROP TABLE IF EXISTS catalog.region_machine_types CASCADE;
-CREATE TABLE catalog.region_machine_types (
-	id uuid NOT NULL DEFAULT uuidv7(),
-	region_id uuid NOT NULL,
-	machine_type_id uuid NOT NULL,
-	CONSTRAINT region_machine_types_pk PRIMARY KEY (id),
-	CONSTRAINT region_machine_types_uq UNIQUE (region_id,machine_type_id)
-);
+    -- A row whose entity is already gone still needs a stable shard.
+    NEW.partition_key := COALESCE(
+        NEW.partition_key,
+        NEW.cluster_id,
+        NEW.node_pool_id,
+        NEW.namespace_id,
+        NEW.project_member_id,
+        NEW.organization_user_id,
+        NEW.access_elevation_id
+    );
+    RETURN NEW;
+END;
+$function$;
 -- ddl-end --
-ALTER TABLE catalog.region_machine_types OWNER TO fun_owner;
+ALTER FUNCTION tenant.cluster_outbox_partition_trigger() OWNER TO fun_owner;
 -- ddl-end --
 
--- object: tenant.clusters | type: TABLE --
--- DROP TABLE IF EXISTS tenant.clusters CASCADE;
-CREATE TABLE tenant.clusters (
-	id uuid NOT NULL DEFAULT uuidv7(),
-	organization_id uuid NOT NULL,
-	name text NOT NULL,
-	region text NOT NULL,
-	kubernetes_version text NOT NULL,
-	created timestamptz NOT NULL DEFAULT now(),
-	deleted timestamptz,
-	synced timestamptz,
-	shoot_status text,
-	shoot_status_message text,
-	shoot_status_updated timestamptz,
-	outbox_status text,
-	outbox_retries integer NOT NULL DEFAULT 0,
-	outbox_error text,
-	region_id uuid,
-	kubernetes_version_id uuid,
-	CONSTRAINT clusters_pk PRIMARY KEY (id),
-	CONSTRAINT clusters_uq_name UNIQUE NULLS NOT DISTINCT (organization_id,name,deleted)
-);
--- ddl-end --
-ALTER TABLE tenant.clusters OWNER TO fun_owner;
+-- object: tenant.cluster_outbox_shard | type: FUNCTION --
+-- DROP FUNCTION IF EXISTS tenant.cluster_outbox_shard(uuid,integer) CASCADE;
+CREATE OR REPLACE FUNCTION tenant.cluster_outbox_shard (partition_key uuid, shard_count integer)
+	RETURNS integer
+	LANGUAGE sql
+	IMMUTABLE 
+	CALLED ON NULL INPUT
+	SECURITY INVOKER
+	PARALLEL SAFE
+	COST 1
+	AS 
+$function$
+SELECT abs(hashtextextended(partition_key::text, 0) % shard_count)::integer
+$function$;
 -- ddl-end --
-ALTER TABLE tenant.clusters ENABLE ROW LEVEL SECURITY;
+ALTER FUNCTION tenant.cluster_outbox_shard(uuid,integer) OWNER TO fun_owner;
 -- ddl-end --
 
--- object: verify_deleted | type: TRIGGER --
--- verify_deleted ON tenant.clusters CASCADE;
-CREATE CONSTRAINT TRIGGER verify_deleted
-	AFTER INSERT OR UPDATE
-	ON tenant.clusters
-	NOT DEFERRABLE 
-	FOR EACH ROW
-	EXECUTE PROCEDURE tenant.clusters_tr_verify_deleted();
+-- object: authn.current_user_id | type: FUNCTION --
+-- DROP FUNCTION IF EXISTS authn.current_user_id() CASCADE;
+CREATE OR REPLACE FUNCTION authn.current_user_id ()
+	RETURNS uuid
+	LANGUAGE sql
+	STABLE 
+	CALLED ON NULL INPUT
+	SECURITY INVOKER
+	PARALLEL SAFE
+	COST 1
+	AS 
+$function$
+SELECT NULLIF(current_setting('app.current_user_id', true), '')::uuid
+$function$;
 -- ddl-end --
-
--- object: organization_isolation | type: POLICY --
--- DROP POLICY IF EXISTS organization_isolation ON tenant.clusters CASCADE;
-CREATE POLICY organization_isolation ON tenant.clusters
-	AS PERMISSIVE
-	FOR ALL
-	TO fun_fundament_api
-	USING (organization_id = authn.current_organization_id());
+ALTER FUNCTION authn.current_user_id() OWNER TO fun_fundament_api;
 -- ddl-end --
 
--- object: cluster_worker_all_access | type: POLICY --
--- DROP POLICY IF EXISTS cluster_worker_all_access ON tenant.clusters CASCADE;
-CREATE POLICY cluster_worker_all_access ON tenant.clusters
-	AS PERMISSIVE
-	FOR ALL
-	TO fun_cluster_worker
-	USING (true);
+-- object: authn.current_organization_id | type: FUNCTION --
+-- DROP FUNCTION IF EXISTS authn.current_organization_id() CASCADE;
+CREATE OR REPLACE FUNCTION authn.current_organization_id ()
+	RETURNS uuid
+	LANGUAGE sql
+	STABLE 
+	CALLED ON NULL INPUT
+	SECURITY INVOKER
+	PARALLEL SAFE
+	COST 1
+	AS 
+$function$
+SELECT NULLIF(current_setting('app.current_organization_id', true), '')::uuid
+$function$;
 -- ddl-end --
-
--- object: clusters_authn_api_policy | type: POLICY --
--- DROP POLICY IF EXISTS clusters_authn_api_policy ON tenant.clusters CASCADE;
-CREATE POLICY clusters_authn_api_policy ON tenant.clusters
-	AS PERMISSIVE
-	FOR SELECT
-	TO fun_authn_api
-	USING (true);
+ALTER FUNCTION authn.current_organization_id() OWNER TO fun_fundament_api;
 -- ddl-end --
 
--- object: clusters_idx_needs_sync | type: INDEX --
--- DROP INDEX IF EXISTS tenant.clusters_idx_needs_sync CASCADE;
-CREATE INDEX clusters_idx_needs_sync ON tenant.clusters
-USING btree
-(
-	created
+-- object: authn.is_project_in_organization | type: FUNCTION --
+-- DROP FUNCTION IF EXISTS authn.is_project_in_organization(uuid) CASCADE;
+CREATE OR REPLACE FUNCTION authn.is_project_in_organization (IN p_project_id uuid)
+	RETURNS boolean
+	LANGUAGE sql
+	STABLE 
+	CALLED ON NULL INPUT
+	SECURITY DEFINER
+	PARALLEL SAFE
+	COST 1
+	AS 
+$function$
+SELECT EXISTS (
+    SELECT 1 FROM tenant.projects
+    JOIN tenant.clusters ON tenant.clusters.id = tenant.projects.cluster_id
+    WHERE tenant.projects.id = p_project_id
+    AND tenant.clusters.organization_id = authn.current_organization_id()
 )
-WHERE (synced IS NULL);
+$function$;
 -- ddl-end --
-
--- object: tenant.node_pools | type: TABLE --
--- DROP TABLE IF EXISTS tenant.node_pools CASCADE;
-CREATE TABLE tenant.node_pools (
-	id uuid NOT NULL DEFAULT uuidv7(),
-	cluster_id uuid NOT NULL,
-	name text NOT NULL,
-	machine_type text NOT NULL,
-	autoscale_min integer NOT NULL,
-	autoscale_max integer NOT NULL,
-	created timestamptz NOT NULL DEFAULT now(),
-	deleted timestamptz,
-	region_machine_type_id uuid,
-	CONSTRAINT node_pools_pk PRIMARY KEY (id),
-	CONSTRAINT node_pools_uq_name UNIQUE NULLS NOT DISTINCT (cluster_id,name,deleted)
-);
+ALTER FUNCTION authn.is_project_in_organization(uuid) OWNER TO fun_authz;
 -- ddl-end --
-ALTER TABLE tenant.node_pools OWNER TO fun_owner;
+
+-- object: authn.is_cluster_in_organization | type: FUNCTION --
+-- DROP FUNCTION IF EXISTS authn.is_cluster_in_organization(uuid) CASCADE;
+CREATE OR REPLACE FUNCTION authn.is_cluster_in_organization (IN p_cluster_id uuid)
+	RETURNS boolean
+	LANGUAGE sql
+	STABLE 
+	CALLED ON NULL INPUT
+	SECURITY DEFINER
+	PARALLEL SAFE
+	COST 1
+	AS 
+$function$
+SELECT EXISTS (
+    SELECT 1 FROM tenant.clusters
+    WHERE id = p_cluster_id
+    AND organization_id = authn.current_organization_id()
+)
+$function$;
 -- ddl-end --
-ALTER TABLE tenant.node_pools ENABLE ROW LEVEL SECURITY;
+ALTER FUNCTION authn.is_cluster_in_organization(uuid) OWNER TO fun_authz;
 -- ddl-end --
 
--- object: node_pools_organization_policy | type: POLICY --
--- DROP POLICY IF EXISTS node_pools_organization_policy ON tenant.node_pools CASCADE;
-CREATE POLICY node_pools_organization_policy ON tenant.node_pools
-	AS PERMISSIVE
-	FOR ALL
-	TO fun_fundament_api
-	USING (authn.is_cluster_in_organization(cluster_id));
+-- object: authn.is_organization_member | type: FUNCTION --
+-- DROP FUNCTION IF EXISTS authn.is_organization_member(uuid) CASCADE;
+CREATE OR REPLACE FUNCTION authn.is_organization_member (IN p_organization_id uuid)
+	RETURNS boolean
+	LANGUAGE sql
+	STABLE 
+	CALLED ON NULL INPUT
+	SECURITY DEFINER
+	PARALLEL SAFE
+	COST 1
+	AS 
+$function$
+SELECT EXISTS (
+    SELECT 1 FROM tenant.organizations_users
+    WHERE organization_id = p_organization_id
+    AND user_id = authn.current_user_id()
+    AND deleted IS NULL
+)
+$function$;
+-- ddl-end --
+ALTER FUNCTION authn.is_organization_member(uuid) OWNER TO fun_authz;
 -- ddl-end --
 
--- object: node_pools_cluster_worker_read | type: POLICY --
--- DROP POLICY IF EXISTS node_pools_cluster_worker_read ON tenant.node_pools CASCADE;
-CREATE POLICY node_pools_cluster_worker_read ON tenant.node_pools
-	AS PERMISSIVE
-	FOR SELECT
-	TO fun_cluster_worker
-	USING (true);
+-- object: authn.is_plugin_in_organization | type: FUNCTION --
+-- DROP FUNCTION IF EXISTS authn.is_plugin_in_organization(uuid) CASCADE;
+CREATE OR REPLACE FUNCTION authn.is_plugin_in_organization (IN p_plugin_id uuid)
+	RETURNS boolean
+	LANGUAGE sql
+	STABLE 
+	CALLED ON NULL INPUT
+	SECURITY DEFINER
+	PARALLEL SAFE
+	COST 1
+	AS 
+$function$
+SELECT EXISTS (
+    SELECT 1 FROM appstore.plugins
+    WHERE id = p_plugin_id
+    AND organization_id = authn.current_organization_id()
+)
+$function$;
+-- ddl-end --
+ALTER FUNCTION authn.is_plugin_in_organization(uuid) OWNER TO fun_authz;
 -- ddl-end --
 
--- object: appstore.plugins | type: TABLE --
--- DROP TABLE IF EXISTS appstore.plugins CASCADE;
-CREATE TABLE appstore.plugins (
+-- object: authn.is_marketplace_reviewer | type: FUNCTION --
+-- DROP FUNCTION IF EXISTS authn.is_marketplace_reviewer() CASCADE;
+CREATE OR REPLACE FUNCTION authn.is_marketplace_reviewer ()
+	RETURNS boolean
+	LANGUAGE sql
+	STABLE 
+	CALLED ON NULL INPUT
+	SECURITY DEFINER
+	PARALLEL SAFE
+	COST 1
+	AS 
+$function$
+SELECT EXISTS (
+    SELECT 1 FROM tenant.organizations_users
+    WHERE organization_id = '019b4000-0000-7000-8000-000000000000'
+    AND user_id = authn.current_user_id()
+    AND permission = 'admin'
+    AND status = 'accepted'
+    AND deleted IS NULL
+)
+$function$;
+-- ddl-end --
+ALTER FUNCTION authn.is_marketplace_reviewer() OWNER TO fun_authz;
+-- ddl-end --
+
+-- object: tenant.projects_tr_verify_deleted | type: FUNCTION --
+-- DROP FUNCTION IF EXISTS tenant.projects_tr_verify_deleted() CASCADE;
+CREATE OR REPLACE FUNCTION tenant.projects_tr_verify_deleted ()
+	RETURNS trigger
+	LANGUAGE plpgsql
+	VOLATILE 
+	CALLED ON NULL INPUT
+	SECURITY INVOKER
+	PARALLEL UNSAFE
+	COST 1
+	AS 
+$function$
+BEGIN
+	IF NEW.deleted IS NOT NULL AND EXISTS (
+		SELECT 1
+		FROM tenant.namespaces
+		WHERE
+			project_id = NEW.id
+			AND deleted IS NULL
+	) THEN
+		RAISE EXCEPTION 'Cannot delete project with undeleted namespaces';
+	END IF;
+	RETURN NEW;
+END;
+$function$;
+-- ddl-end --
+ALTER FUNCTION tenant.projects_tr_verify_deleted() OWNER TO postgres;
+-- ddl-end --
+
+-- object: tenant.project_members_tr_protect_last_admin | type: FUNCTION --
+-- DROP FUNCTION IF EXISTS tenant.project_members_tr_protect_last_admin() CASCADE;
+CREATE OR REPLACE FUNCTION tenant.project_members_tr_protect_last_admin ()
+	RETURNS trigger
+	LANGUAGE plpgsql
+	VOLATILE 
+	CALLED ON NULL INPUT
+	SECURITY INVOKER
+	PARALLEL UNSAFE
+	COST 1
+	AS 
+$function$
+DECLARE
+    admin_count integer;
+BEGIN
+    -- Only check if we're soft-deleting an admin or demoting an admin (UPDATE role from admin)
+    IF OLD.role = 'admin' AND OLD.deleted IS NULL THEN
+        -- Check if this is a soft delete (setting deleted) or role demotion
+        IF (NEW.deleted IS NOT NULL) OR (NEW.role != 'admin') THEN
+            SELECT COUNT(*) INTO admin_count
+            FROM tenant.project_members
+            WHERE project_id = OLD.project_id
+            AND role = 'admin'
+            AND id != OLD.id
+            AND deleted IS NULL;
+
+            IF admin_count = 0 THEN
+                RAISE EXCEPTION 'Cannot remove or demote the last admin of a project'
+                            USING HINT = 'project_contains_one_admin';
+            END IF;
+        END IF;
+    END IF;
+
+    RETURN NEW;
+END;
+$function$;
+-- ddl-end --
+ALTER FUNCTION tenant.project_members_tr_protect_last_admin() OWNER TO postgres;
+-- ddl-end --
+
+-- object: tenant.projects_tr_require_admin | type: FUNCTION --
+-- DROP FUNCTION IF EXISTS tenant.projects_tr_require_admin() CASCADE;
+CREATE OR REPLACE FUNCTION tenant.projects_tr_require_admin ()
+	RETURNS trigger
+	LANGUAGE plpgsql
+	VOLATILE 
+	CALLED ON NULL INPUT
+	SECURITY INVOKER
+	PARALLEL UNSAFE
+	COST 1
+	AS 
+$function$
+BEGIN
+    IF NOT EXISTS (
+        SELECT 1 FROM tenant.project_members
+        WHERE project_id = NEW.id
+        AND role = 'admin'
+        AND deleted IS NULL
+    ) THEN
+        RAISE EXCEPTION 'Project must have at least one admin';
+    END IF;
+    RETURN NEW;
+END;
+$function$;
+-- ddl-end --
+ALTER FUNCTION tenant.projects_tr_require_admin() OWNER TO postgres;
+-- ddl-end --
+
+-- object: tenant.users | type: TABLE --
+-- DROP TABLE IF EXISTS tenant.users CASCADE;
+CREATE TABLE tenant.users (
+	id uuid NOT NULL DEFAULT uuidv7(),
+	name text NOT NULL,
+	external_ref text,
+	email text,
+	created timestamptz NOT NULL DEFAULT now(),
+	deleted timestamptz,
+	scim_organization_id uuid,
+	CONSTRAINT users_pk PRIMARY KEY (id)
+);
+-- ddl-end --
+COMMENT ON COLUMN tenant.users.scim_organization_id IS E'Organization whose SCIM provisioning created the user; only its identity provider may set the name. NULL for users created any other way.';
+-- ddl-end --
+ALTER TABLE tenant.users OWNER TO fun_owner;
+-- ddl-end --
+ALTER TABLE tenant.users ENABLE ROW LEVEL SECURITY;
+-- ddl-end --
+
+-- object: users_uq_external_ref | type: INDEX --
+-- DROP INDEX IF EXISTS tenant.users_uq_external_ref CASCADE;
+CREATE UNIQUE INDEX users_uq_external_ref ON tenant.users
+USING btree
+(
+	external_ref
+)
+WHERE (deleted IS NULL);
+-- ddl-end --
+
+-- object: authn.api_keys | type: TABLE --
+-- DROP TABLE IF EXISTS authn.api_keys CASCADE;
+CREATE TABLE authn.api_keys (
 	id uuid NOT NULL DEFAULT uuidv7(),
 	organization_id uuid NOT NULL,
+	user_id uuid NOT NULL,
 	name text NOT NULL,
-	display_name text NOT NULL DEFAULT '',
-	description_short text NOT NULL DEFAULT '',
-	description text NOT NULL,
-	author_name text,
-	author_url text,
-	repository_url text,
-	image text NOT NULL DEFAULT '',
+	token_hash bytea NOT NULL,
+	token_prefix text NOT NULL,
+	expires timestamptz,
+	revoked timestamptz,
+	last_used timestamptz,
 	created timestamptz NOT NULL DEFAULT now(),
 	deleted timestamptz,
-	CONSTRAINT plugins_uq_name UNIQUE NULLS NOT DISTINCT (name,deleted),
-	CONSTRAINT plugins_pk PRIMARY KEY (id)
+	scope_project_ids uuid[] NOT NULL DEFAULT '{}',
+	scope_cluster_ids uuid[] NOT NULL DEFAULT '{}',
+	scope_actions text[] NOT NULL DEFAULT '{}',
+	scope_read_only boolean NOT NULL DEFAULT false,
+	CONSTRAINT api_keys_pk PRIMARY KEY (id),
+	CONSTRAINT api_keys_uq_token_hash UNIQUE (token_hash),
+	CONSTRAINT api_keys_uq_name UNIQUE NULLS NOT DISTINCT (organization_id,name,deleted)
 );
 -- ddl-end --
-COMMENT ON COLUMN appstore.plugins.organization_id IS E'Owning organization. Gates who may publish/edit this plugin (RLS). Catalog visibility is NOT org-scoped — reads stay global.';
+COMMENT ON COLUMN authn.api_keys.scope_project_ids IS E'Projects the key is limited to, together with scope_cluster_ids. Both empty means every resource the creator can reach.';
 -- ddl-end --
-COMMENT ON COLUMN appstore.plugins.name IS E'Stable identifier, matching the plugin''s definition.yaml metadata.name (e.g. "openfsc"). Used as the PluginInstallation resource name in the cluster — not for display.';
+COMMENT ON COLUMN authn.api_keys.scope_cluster_ids IS E'Clusters the key is limited to; a cluster in scope includes its projects, namespaces and node pools.';
+-- ddl-end --
+COMMENT ON COLUMN authn.api_keys.scope_actions IS E'OpenFGA action names (can_view, can_edit, ...) the key may exercise. Empty means every action.';
+-- ddl-end --
+COMMENT ON COLUMN authn.api_keys.scope_read_only IS E'Limits the key to can_view and can_list_* actions.';
+-- ddl-end --
+ALTER TABLE authn.api_keys OWNER TO fun_owner;
+-- ddl-end --
+ALTER TABLE authn.api_keys ENABLE ROW LEVEL SECURITY;
+-- ddl-end --
+
+-- object: api_keys_organization_policy | type: POLICY --
+-- DROP POLICY IF EXISTS api_keys_organization_policy ON authn.api_keys CASCADE;
+CREATE POLICY api_keys_organization_policy ON authn.api_keys
+	AS PERMISSIVE
+	FOR ALL
+	TO fun_fundament_api
+	USING (organization_id = current_setting('app.current_organization_id')::uuid);
+-- ddl-end --
+
+-- object: authn.api_key_get_by_hash | type: FUNCTION --
+-- DROP FUNCTION IF EXISTS authn.api_key_get_by_hash(bytea) CASCADE;
+CREATE OR REPLACE FUNCTION authn.api_key_get_by_hash (IN p_token_hash bytea)
+	RETURNS authn.api_keys
+	LANGUAGE plpgsql
+	VOLATILE 
+	CALLED ON NULL INPUT
+	SECURITY DEFINER
+	PARALLEL UNSAFE
+	COST 10
+	AS 
+$function$
+DECLARE
+	result authn.api_keys;
+BEGIN
+	SELECT * INTO result FROM authn.api_keys WHERE token_hash = p_token_hash;
+
+	IF NOT FOUND THEN
+		RETURN NULL;
+	END IF;
+
+	RETURN result;
+END;
+$function$;
+-- ddl-end --
+ALTER FUNCTION authn.api_key_get_by_hash(bytea) OWNER TO fun_owner;
+-- ddl-end --
+
+-- object: authn.api_key_update_last_used | type: FUNCTION --
+-- DROP FUNCTION IF EXISTS authn.api_key_update_last_used(uuid) CASCADE;
+CREATE OR REPLACE FUNCTION authn.api_key_update_last_used (IN p_id uuid)
+	RETURNS void
+	LANGUAGE plpgsql
+	VOLATILE 
+	CALLED ON NULL INPUT
+	SECURITY DEFINER
+	PARALLEL UNSAFE
+	COST 10
+	AS 
+$function$
+BEGIN
+	UPDATE authn.api_keys SET last_used = NOW() WHERE id = p_id;
+END;
+$function$;
+-- ddl-end --
+ALTER FUNCTION authn.api_key_update_last_used(uuid) OWNER TO fun_owner;
+-- ddl-end --
+
+-- object: catalog.kubernetes_versions | type: TABLE --
+-- DROP TABLE IF EXISTS catalog.kubernetes_versions CASCADE;
+CREATE TABLE catalog.kubernetes_versions (
+	id uuid NOT NULL DEFAULT uuidv7(),
+	version text NOT NULL,
+	deprecated timestamptz,
+	CONSTRAINT kubernetes_versions_pk PRIMARY KEY (id),
+	CONSTRAINT kubernetes_versions_uq_version UNIQUE (version)
+);
+-- ddl-end --
+COMMENT ON COLUMN catalog.kubernetes_versions.deprecated IS E'When the version stops being offered for new clusters and upgrades; existing clusters keep running. NULL when not deprecated.';
+-- ddl-end --
+ALTER TABLE catalog.kubernetes_versions OWNER TO fun_owner;
+-- ddl-end --
+
+-- object: catalog.machine_types | type: TABLE --
+-- DROP TABLE IF EXISTS catalog.machine_types CASCADE;
+CREATE TABLE catalog.machine_types (
+	id uuid NOT NULL DEFAULT uuidv7(),
+	name text NOT NULL,
+	lcpu integer NOT NULL,
+	memory bigint NOT NULL,
+	deprecated timestamptz,
+	CONSTRAINT machine_types_pk PRIMARY KEY (id),
+	CONSTRAINT machine_types_uq_name UNIQUE (name)
+);
+-- ddl-end --
+COMMENT ON COLUMN catalog.machine_types.deprecated IS E'When the machine type stops being offered for new node pools; existing node pools keep running. NULL when not deprecated.';
+-- ddl-end --
+ALTER TABLE catalog.machine_types OWNER TO fun_owner;
+-- ddl-end --
+
+-- object: catalog.regions | type: TABLE --
+-- DROP TABLE IF EXISTS catalog.regions CASCADE;
+CREATE TABLE catalog.regions (
+	id uuid NOT NULL DEFAULT uuidv7(),
+	name text NOT NULL,
+	cloud_profile text NOT NULL,
+	cloud_profile_region text NOT NULL,
+	deprecated timestamptz,
+	CONSTRAINT regions_pk PRIMARY KEY (id),
+	CONSTRAINT regions_uq_name UNIQUE (name)
+);
+-- ddl-end --
+COMMENT ON COLUMN catalog.regions.deprecated IS E'When the region stops being offered for new clusters; existing clusters keep running. NULL when not deprecated.';
+-- ddl-end --
+ALTER TABLE catalog.regions OWNER TO fun_owner;
+-- ddl-end --
+
+-- object: catalog.region_kubernetes_versions | type: TABLE --
+-- DROP TABLE IF EXISTS catalog.region_kubernetes_versions CASCADE;
+CREATE TABLE catalog.region_kubernetes_versions (
+	region_id uuid NOT NULL,
+	kubernetes_version_id uuid NOT NULL,
+	CONSTRAINT region_kubernetes_versions_pk PRIMARY KEY (region_id,kubernetes_version_id)
+);
+-- ddl-end --
+ALTER TABLE catalog.region_kubernetes_versions OWNER TO fun_owner;
+-- ddl-end --
+
+-- object: catalog.region_machine_types | type: TABLE --
+-- DROP TABLE IF EXISTS catalog.region_machine_types CASCADE;
+CREATE TABLE catalog.region_machine_types (
+	id uuid NOT NULL DEFAULT uuidv7(),
+	region_id uuid NOT NULL,
+	machine_type_id uuid NOT NULL,
+	CONSTRAINT region_machine_types_pk PRIMARY KEY (id),
+	CONSTRAINT region_machine_types_uq UNIQUE (region_id,machine_type_id)
+);
+-- ddl-end --
+ALTER TABLE catalog.region_machine_types OWNER TO fun_owner;
+-- ddl-end --
+
+-- object: tenant.clusters | type: TABLE --
+-- DROP TABLE IF EXISTS tenant.clusters CASCADE;
+CREATE TABLE tenant.clusters (
+	id uuid NOT NULL DEFAULT uuidv7(),
+	organization_id uuid NOT NULL,
+	name text NOT NULL,
+	region text NOT NULL,
+	kubernetes_version text NOT NULL,
+	created timestamptz NOT NULL DEFAULT now(),
+	deleted timestamptz,
+	synced timestamptz,
+	shoot_status text,
+	shoot_status_message text,
+	shoot_status_updated timestamptz,
+	outbox_status text,
+	outbox_retries integer NOT NULL DEFAULT 0,
+	outbox_error text,
+	region_id uuid,
+	kubernetes_version_id uuid,
+	maintenance_window_start time,
+	maintenance_window_end time,
+	auto_update_kubernetes_version boolean NOT NULL DEFAULT false,
+	auto_update_machine_image_version boolean NOT NULL DEFAULT true,
+	hibernated boolean NOT NULL DEFAULT false,
+	hibernation_requested timestamptz,
+	labels jsonb NOT NULL DEFAULT '{}',
+	CONSTRAINT clusters_pk PRIMARY KEY (id),
+	CONSTRAINT clusters_uq_name UNIQUE NULLS NOT DISTINCT (organization_id,name,deleted),
+	CONSTRAINT clusters_ck_maintenance_window CHECK ((maintenance_window_start IS NULL) = (maintenance_window_end IS NULL)),
+	CONSTRAINT clusters_ck_labels CHECK (jsonb_typeof(labels) = 'object')
+);
+-- ddl-end --
+COMMENT ON COLUMN tenant.clusters.maintenance_window_start IS E'Daily maintenance window start (UTC). NULL together with maintenance_window_end leaves the window to Gardener.';
+-- ddl-end --
+COMMENT ON COLUMN tenant.clusters.maintenance_window_end IS E'Daily maintenance window end (UTC); may be earlier than the start for a window spanning midnight.';
+-- ddl-end --
+COMMENT ON COLUMN tenant.clusters.auto_update_kubernetes_version IS E'Let Gardener apply Kubernetes patch releases in the maintenance window.';
+-- ddl-end --
+COMMENT ON COLUMN tenant.clusters.auto_update_machine_image_version IS E'Let Gardener roll nodes onto new machine image versions in the maintenance window.';
+-- ddl-end --
+COMMENT ON COLUMN tenant.clusters.hibernated IS E'Hibernation state last requested through HibernateCluster/WakeCluster. Hibernation schedules may change the actual state in between.';
+-- ddl-end --
+COMMENT ON COLUMN tenant.clusters.hibernation_requested IS E'When hibernated was last set. cluster-worker records it on the Shoot so a later sync does not undo a schedule-driven state change.';
+-- ddl-end --
+COMMENT ON COLUMN tenant.clusters.labels IS E'Key/value metadata set when the cluster is created, checked against required_labels of the organization policy.';
+-- ddl-end --
+ALTER TABLE tenant.clusters OWNER TO fun_owner;
+-- ddl-end --
+ALTER TABLE tenant.clusters ENABLE ROW LEVEL SECURITY;
+-- ddl-end --
+
+-- object: verify_deleted | type: TRIGGER --
+-- verify_deleted ON tenant.clusters CASCADE;
+CREATE CONSTRAINT TRIGGER verify_deleted
+	AFTER INSERT OR UPDATE
+	ON tenant.clusters
+	NOT DEFERRABLE 
+	FOR EACH ROW
+	EXECUTE PROCEDURE tenant.clusters_tr_verify_deleted();
+-- ddl-end --
+
+-- object: organization_isolation | type: POLICY --
+-- DROP POLICY IF EXISTS organization_isolation ON tenant.clusters CASCADE;
+CREATE POLICY organization_isolation ON tenant.clusters
+	AS PERMISSIVE
+	FOR ALL
+	TO fun_fundament_api
+	USING (organization_id = authn.current_organization_id());
+-- ddl-end --
+
+-- object: cluster_worker_all_access | type: POLICY --
+-- DROP POLICY IF EXISTS cluster_worker_all_access ON tenant.clusters CASCADE;
+CREATE POLICY cluster_worker_all_access ON tenant.clusters
+	AS PERMISSIVE
+	FOR ALL
+	TO fun_cluster_worker
+	USING (true);
+-- ddl-end --
+
+-- object: clusters_authn_api_policy | type: POLICY --
+-- DROP POLICY IF EXISTS clusters_authn_api_policy ON tenant.clusters CASCADE;
+CREATE POLICY clusters_authn_api_policy ON tenant.clusters
+	AS PERMISSIVE
+	FOR SELECT
+	TO fun_authn_api
+	USING (true);
+-- ddl-end --
+
+-- object: clusters_idx_needs_sync | type: INDEX --
+-- DROP INDEX IF EXISTS tenant.clusters_idx_needs_sync CASCADE;
+CREATE INDEX clusters_idx_needs_sync ON tenant.clusters
+USING btree
+(
+	created
+)
+WHERE (synced IS NULL);
+-- ddl-end --
+
+-- object: tenant.node_pools | type: TABLE --
+-- DROP TABLE IF EXISTS tenant.node_pools CASCADE;
+CREATE TABLE tenant.node_pools (
+	id uuid NOT NULL DEFAULT uuidv7(),
+	cluster_id uuid NOT NULL,
+	name text NOT NULL,
+	machine_type text NOT NULL,
+	autoscale_min integer NOT NULL,
+	autoscale_max integer NOT NULL,
+	created timestamptz NOT NULL DEFAULT now(),
+	deleted timestamptz,
+	region_machine_type_id uuid,
+	labels jsonb NOT NULL DEFAULT '{}',
+	taints jsonb NOT NULL DEFAULT '[]',
+	zones text[] NOT NULL DEFAULT '{}',
+	max_surge integer NOT NULL DEFAULT 1,
+	max_unavailable integer NOT NULL DEFAULT 0,
+	CONSTRAINT node_pools_pk PRIMARY KEY (id),
+	CONSTRAINT node_pools_uq_name UNIQUE NULLS NOT DISTINCT (cluster_id,name,deleted),
+	CONSTRAINT node_pools_ck_labels CHECK (jsonb_typeof(labels) = 'object'),
+	CONSTRAINT node_pools_ck_taints CHECK (jsonb_typeof(taints) = 'array'),
+	CONSTRAINT node_pools_ck_rolling_update CHECK (max_surge >= 0 AND max_unavailable >= 0 AND max_surge + max_unavailable > 0)
+);
+-- ddl-end --
+COMMENT ON COLUMN tenant.node_pools.labels IS E'Kubernetes labels of the pool\'s nodes, also checked against required_labels of the organization policy.';
+-- ddl-end --
+COMMENT ON COLUMN tenant.node_pools.taints IS E'Kubernetes taints of the pool\'s nodes, as an array of {key, value, effect} objects.';
+-- ddl-end --
+COMMENT ON COLUMN tenant.node_pools.zones IS E'Availability zones the pool\'s nodes are spread over; empty leaves the choice to the provider.';
+-- ddl-end --
+COMMENT ON COLUMN tenant.node_pools.max_surge IS E'Nodes the pool may add above its maximum while rolling nodes.';
+-- ddl-end --
+COMMENT ON COLUMN tenant.node_pools.max_unavailable IS E'Nodes the pool may take out of service at once while rolling nodes.';
+-- ddl-end --
+ALTER TABLE tenant.node_pools OWNER TO fun_owner;
+-- ddl-end --
+ALTER TABLE tenant.node_pools ENABLE ROW LEVEL SECURITY;
+-- ddl-end --
+
+-- object: node_pools_organization_policy | type: POLICY --
+-- DROP POLICY IF EXISTS node_pools_organization_policy ON tenant.node_pools CASCADE;
+CREATE POLICY node_pools_organization_policy ON tenant.node_pools
+	AS PERMISSIVE
+	FOR ALL
+	TO fun_fundament_api
+	USING (authn.is_cluster_in_organization(cluster_id));
+-- ddl-end --
+
+-- object: node_pools_cluster_worker_read | type: POLICY --
+-- DROP POLICY IF EXISTS node_pools_cluster_worker_read ON tenant.node_pools CASCADE;
+CREATE POLICY node_pools_cluster_worker_read ON tenant.node_pools
+	AS PERMISSIVE
+	FOR SELECT
+	TO fun_cluster_worker
+	USING (true);
+-- ddl-end --
+
+-- object: appstore.plugins | type: TABLE --
+-- DROP TABLE IF EXISTS appstore.plugins CASCADE;
+CREATE TABLE appstore.plugins (
+	id uuid NOT NULL DEFAULT uuidv7(),
+	organization_id uuid NOT NULL,
+	name text NOT NULL,
+	display_name text NOT NULL DEFAULT '',
+	description_short text NOT NULL DEFAULT '',
+	description text NOT NULL,
+	author_name text,
+	author_url text,
+	repository_url text,
+	image text NOT NULL DEFAULT '',
+	license text NOT NULL DEFAULT '',
+	visibility text NOT NULL DEFAULT 'public',
+	featured boolean NOT NULL DEFAULT false,
+	labels text[] NOT NULL DEFAULT '{}',
+	created timestamptz NOT NULL DEFAULT now(),
+	updated timestamptz NOT NULL DEFAULT now(),
+	deleted timestamptz,
+	CONSTRAINT plugins_uq_name UNIQUE NULLS NOT DISTINCT (name,deleted),
+	CONSTRAINT plugins_pk PRIMARY KEY (id),
+	CONSTRAINT plugins_ck_visibility CHECK (visibility IN ('public', 'restricted')),
+	CONSTRAINT plugins_ck_labels CHECK (labels <@ ARRAY['core', 'rijksoverheid', 'support_9_to_17']::text[])
+);
+-- ddl-end --
+COMMENT ON COLUMN appstore.plugins.organization_id IS E'Owning organization. Gates who may publish/edit this plugin (RLS). Catalog visibility is NOT org-scoped — reads stay global.';
+-- ddl-end --
+COMMENT ON COLUMN appstore.plugins.name IS E'Stable identifier, matching the plugin''s definition.yaml metadata.name (e.g. "openfsc"). Used as the PluginInstallation resource name in the cluster — not for display.';
+-- ddl-end --
+COMMENT ON COLUMN appstore.plugins.display_name IS E'Human-readable name shown in the Console, matching the plugin''s definition.yaml metadata.displayName (e.g. "OpenFSC").';
+-- ddl-end --
+COMMENT ON COLUMN appstore.plugins.visibility IS E'public listings appear in the marketplace catalog once a version is approved; restricted listings never do and are installable only by the organizations in plugins_allowed_organizations.';
+-- ddl-end --
+COMMENT ON COLUMN appstore.plugins.featured IS E'Fundament curation decision. No API writes it yet.';
+-- ddl-end --
+COMMENT ON COLUMN appstore.plugins.labels IS E'Trust and support badges (core, rijksoverheid, support_9_to_17). Fundament curation decision; no API writes it yet.';
+-- ddl-end --
+ALTER TABLE appstore.plugins OWNER TO fun_owner;
+-- ddl-end --
+ALTER TABLE appstore.plugins ENABLE ROW LEVEL SECURITY;
+-- ddl-end --
+
+-- object: plugins_select_all | type: POLICY --
+-- DROP POLICY IF EXISTS plugins_select_all ON appstore.plugins CASCADE;
+CREATE POLICY plugins_select_all ON appstore.plugins
+	AS PERMISSIVE
+	FOR SELECT
+	TO fun_fundament_api,fun_marketplace_api
+	USING (true);
+-- ddl-end --
+
+-- object: plugins_insert_owner | type: POLICY --
+-- DROP POLICY IF EXISTS plugins_insert_owner ON appstore.plugins CASCADE;
+CREATE POLICY plugins_insert_owner ON appstore.plugins
+	AS PERMISSIVE
+	FOR INSERT
+	TO fun_fundament_api,fun_marketplace_api
+	WITH CHECK (organization_id = authn.current_organization_id());
+-- ddl-end --
+
+-- object: plugins_update_owner | type: POLICY --
+-- DROP POLICY IF EXISTS plugins_update_owner ON appstore.plugins CASCADE;
+CREATE POLICY plugins_update_owner ON appstore.plugins
+	AS PERMISSIVE
+	FOR UPDATE
+	TO fun_fundament_api,fun_marketplace_api
+	USING (organization_id = authn.current_organization_id())
+	WITH CHECK (organization_id = authn.current_organization_id());
+-- ddl-end --
+
+-- object: appstore.plugin_definitions | type: TABLE --
+-- DROP TABLE IF EXISTS appstore.plugin_definitions CASCADE;
+CREATE TABLE appstore.plugin_definitions (
+	id uuid NOT NULL DEFAULT uuidv7(),
+	plugin_id uuid NOT NULL,
+	plugin_version text NOT NULL,
+	manifest bytea NOT NULL,
+	hash text NOT NULL,
+	release_notes text NOT NULL DEFAULT '',
+	status text NOT NULL DEFAULT 'draft',
+	created timestamptz NOT NULL DEFAULT now(),
+	submitted timestamptz,
+	published timestamptz,
+	deleted timestamptz,
+	CONSTRAINT plugin_definitions_uq_plugin_version UNIQUE NULLS NOT DISTINCT (plugin_id,plugin_version,deleted),
+	CONSTRAINT plugin_definitions_pk PRIMARY KEY (id),
+	CONSTRAINT plugin_definitions_ck_status CHECK (status IN ('draft', 'pending', 'changes_requested', 'approved', 'rejected', 'withdrawn'))
+);
+-- ddl-end --
+COMMENT ON COLUMN appstore.plugin_definitions.status IS E'Where the version sits in the review pipeline. Mirrors the status of its most recent appstore.submissions row; draft means it was never submitted.';
+-- ddl-end --
+ALTER TABLE appstore.plugin_definitions OWNER TO fun_owner;
+-- ddl-end --
+ALTER TABLE appstore.plugin_definitions ENABLE ROW LEVEL SECURITY;
+-- ddl-end --
+
+-- object: plugin_definitions_select_all | type: POLICY --
+-- DROP POLICY IF EXISTS plugin_definitions_select_all ON appstore.plugin_definitions CASCADE;
+CREATE POLICY plugin_definitions_select_all ON appstore.plugin_definitions
+	AS PERMISSIVE
+	FOR SELECT
+	TO fun_fundament_api,fun_marketplace_api
+	USING (true);
+-- ddl-end --
+
+-- object: plugin_definitions_insert_owner | type: POLICY --
+-- DROP POLICY IF EXISTS plugin_definitions_insert_owner ON appstore.plugin_definitions CASCADE;
+CREATE POLICY plugin_definitions_insert_owner ON appstore.plugin_definitions
+	AS PERMISSIVE
+	FOR INSERT
+	TO fun_fundament_api,fun_marketplace_api
+	WITH CHECK (EXISTS (SELECT 1 FROM appstore.plugins WHERE appstore.plugins.id = appstore.plugin_definitions.plugin_id AND appstore.plugins.organization_id = authn.current_organization_id()));
+-- ddl-end --
+
+-- object: plugin_definitions_update_owner | type: POLICY --
+-- DROP POLICY IF EXISTS plugin_definitions_update_owner ON appstore.plugin_definitions CASCADE;
+CREATE POLICY plugin_definitions_update_owner ON appstore.plugin_definitions
+	AS PERMISSIVE
+	FOR UPDATE
+	TO fun_fundament_api,fun_marketplace_api
+	USING (EXISTS (SELECT 1 FROM appstore.plugins WHERE appstore.plugins.id = appstore.plugin_definitions.plugin_id AND appstore.plugins.organization_id = authn.current_organization_id()));
+-- ddl-end --
+
+-- object: plugin_definitions_update_reviewer | type: POLICY --
+-- DROP POLICY IF EXISTS plugin_definitions_update_reviewer ON appstore.plugin_definitions CASCADE;
+CREATE POLICY plugin_definitions_update_reviewer ON appstore.plugin_definitions
+	AS PERMISSIVE
+	FOR UPDATE
+	TO fun_marketplace_api
+	USING (authn.is_marketplace_reviewer());
+-- ddl-end --
+
+-- object: appstore.submissions | type: TABLE --
+-- DROP TABLE IF EXISTS appstore.submissions CASCADE;
+CREATE TABLE appstore.submissions (
+	id uuid NOT NULL DEFAULT uuidv7(),
+	plugin_definition_id uuid NOT NULL,
+	submitter_user_id uuid NOT NULL,
+	status text NOT NULL DEFAULT 'pending',
+	submitted timestamptz NOT NULL DEFAULT now(),
+	reviewed timestamptz,
+	reviewer_user_id uuid,
+	rejection_reason text,
+	feedback text NOT NULL DEFAULT '',
+	CONSTRAINT submissions_pk PRIMARY KEY (id),
+	CONSTRAINT submissions_ck_status CHECK (status IN ('pending', 'changes_requested', 'approved', 'rejected', 'withdrawn')),
+	CONSTRAINT submissions_ck_rejection_reason CHECK (rejection_reason IN ('incomplete_metadata', 'duplicate', 'security_concerns', 'naming_guidelines', 'out_of_scope', 'other'))
+);
+-- ddl-end --
+COMMENT ON COLUMN appstore.submissions.reviewer_user_id IS E'Deciding Fundament staff member. No foreign key: which user store reviewers come from is not settled (FUN-20).';
+-- ddl-end --
+ALTER TABLE appstore.submissions OWNER TO fun_owner;
+-- ddl-end --
+ALTER TABLE appstore.submissions ENABLE ROW LEVEL SECURITY;
+-- ddl-end --
+
+-- object: submissions_uq_pending | type: INDEX --
+-- DROP INDEX IF EXISTS appstore.submissions_uq_pending CASCADE;
+CREATE UNIQUE INDEX submissions_uq_pending ON appstore.submissions
+USING btree
+(
+	plugin_definition_id
+)
+WHERE (status = 'pending');
+-- ddl-end --
+
+-- object: submissions_select_owner | type: POLICY --
+-- DROP POLICY IF EXISTS submissions_select_owner ON appstore.submissions CASCADE;
+CREATE POLICY submissions_select_owner ON appstore.submissions
+	AS PERMISSIVE
+	FOR SELECT
+	TO fun_marketplace_api
+	USING ((EXISTS (SELECT 1 FROM appstore.plugin_definitions WHERE appstore.plugin_definitions.id = appstore.submissions.plugin_definition_id AND authn.is_plugin_in_organization(appstore.plugin_definitions.plugin_id))) OR authn.is_marketplace_reviewer());
+-- ddl-end --
+
+-- object: submissions_insert_owner | type: POLICY --
+-- DROP POLICY IF EXISTS submissions_insert_owner ON appstore.submissions CASCADE;
+CREATE POLICY submissions_insert_owner ON appstore.submissions
+	AS PERMISSIVE
+	FOR INSERT
+	TO fun_marketplace_api
+	WITH CHECK (EXISTS (SELECT 1 FROM appstore.plugin_definitions WHERE appstore.plugin_definitions.id = appstore.submissions.plugin_definition_id AND authn.is_plugin_in_organization(appstore.plugin_definitions.plugin_id)));
+-- ddl-end --
+
+-- object: submissions_update_owner | type: POLICY --
+-- DROP POLICY IF EXISTS submissions_update_owner ON appstore.submissions CASCADE;
+CREATE POLICY submissions_update_owner ON appstore.submissions
+	AS PERMISSIVE
+	FOR UPDATE
+	TO fun_marketplace_api
+	USING ((EXISTS (SELECT 1 FROM appstore.plugin_definitions WHERE appstore.plugin_definitions.id = appstore.submissions.plugin_definition_id AND authn.is_plugin_in_organization(appstore.plugin_definitions.plugin_id))) OR authn.is_marketplace_reviewer());
+-- ddl-end --
+
+-- object: appstore.presets | type: TABLE --
+-- DROP TABLE IF EXISTS appstore.presets CASCADE;
+CREATE TABLE appstore.presets (
+	id uuid NOT NULL DEFAULT uuidv7(),
+	name text NOT NULL,
+	description text,
+	CONSTRAINT presets_pk PRIMARY KEY (id),
+	CONSTRAINT presets_uq_name UNIQUE (name)
+);
+-- ddl-end --
+ALTER TABLE appstore.presets OWNER TO fun_owner;
+-- ddl-end --
+
+-- object: appstore.preset_plugins | type: TABLE --
+-- DROP TABLE IF EXISTS appstore.preset_plugins CASCADE;
+CREATE TABLE appstore.preset_plugins (
+	preset_id uuid NOT NULL,
+	plugin_id uuid NOT NULL,
+	CONSTRAINT preset_plugins_pk PRIMARY KEY (preset_id,plugin_id)
+);
+-- ddl-end --
+ALTER TABLE appstore.preset_plugins OWNER TO fun_owner;
+-- ddl-end --
+
+-- object: appstore.tags | type: TABLE --
+-- DROP TABLE IF EXISTS appstore.tags CASCADE;
+CREATE TABLE appstore.tags (
+	id uuid NOT NULL DEFAULT uuidv7(),
+	name text NOT NULL,
+	created timestamptz NOT NULL DEFAULT now(),
+	deleted timestamptz,
+	CONSTRAINT tags_uq_name UNIQUE NULLS NOT DISTINCT (name,deleted),
+	CONSTRAINT tags_pk PRIMARY KEY (id)
+);
+-- ddl-end --
+ALTER TABLE appstore.tags OWNER TO fun_owner;
+-- ddl-end --
+
+-- object: appstore.plugins_tags | type: TABLE --
+-- DROP TABLE IF EXISTS appstore.plugins_tags CASCADE;
+CREATE TABLE appstore.plugins_tags (
+	plugin_id uuid NOT NULL,
+	tag_id uuid NOT NULL,
+	CONSTRAINT plugins_tags_pk PRIMARY KEY (plugin_id,tag_id)
+);
+-- ddl-end --
+ALTER TABLE appstore.plugins_tags OWNER TO fun_owner;
+-- ddl-end --
+ALTER TABLE appstore.plugins_tags ENABLE ROW LEVEL SECURITY;
+-- ddl-end --
+
+-- object: plugins_tags_select_all | type: POLICY --
+-- DROP POLICY IF EXISTS plugins_tags_select_all ON appstore.plugins_tags CASCADE;
+CREATE POLICY plugins_tags_select_all ON appstore.plugins_tags
+	AS PERMISSIVE
+	FOR SELECT
+	TO fun_fundament_api,fun_marketplace_api
+	USING (true);
+-- ddl-end --
+
+-- object: plugins_tags_write_owner | type: POLICY --
+-- DROP POLICY IF EXISTS plugins_tags_write_owner ON appstore.plugins_tags CASCADE;
+CREATE POLICY plugins_tags_write_owner ON appstore.plugins_tags
+	AS PERMISSIVE
+	FOR ALL
+	TO fun_marketplace_api
+	USING (authn.is_plugin_in_organization(plugin_id))
+	WITH CHECK (authn.is_plugin_in_organization(plugin_id));
+-- ddl-end --
+
+-- object: appstore.categories | type: TABLE --
+-- DROP TABLE IF EXISTS appstore.categories CASCADE;
+CREATE TABLE appstore.categories (
+	id uuid NOT NULL DEFAULT uuidv7(),
+	name text NOT NULL,
+	created timestamptz NOT NULL DEFAULT now(),
+	deleted timestamptz,
+	CONSTRAINT categories_uq_name UNIQUE NULLS NOT DISTINCT (name,deleted),
+	CONSTRAINT categories_pk PRIMARY KEY (id)
+);
+-- ddl-end --
+ALTER TABLE appstore.categories OWNER TO fun_owner;
+-- ddl-end --
+
+-- object: appstore.categories_plugins | type: TABLE --
+-- DROP TABLE IF EXISTS appstore.categories_plugins CASCADE;
+CREATE TABLE appstore.categories_plugins (
+	plugin_id uuid NOT NULL,
+	category_id uuid NOT NULL,
+	CONSTRAINT categories_plugins_pk PRIMARY KEY (plugin_id,category_id)
+);
+-- ddl-end --
+ALTER TABLE appstore.categories_plugins OWNER TO fun_owner;
+-- ddl-end --
+ALTER TABLE appstore.categories_plugins ENABLE ROW LEVEL SECURITY;
+-- ddl-end --
+
+-- object: categories_plugins_select_all | type: POLICY --
+-- DROP POLICY IF EXISTS categories_plugins_select_all ON appstore.categories_plugins CASCADE;
+CREATE POLICY categories_plugins_select_all ON appstore.categories_plugins
+	AS PERMISSIVE
+	FOR SELECT
+	TO fun_fundament_api,fun_marketplace_api
+	USING (true);
+-- ddl-end --
+
+-- object: categories_plugins_write_owner | type: POLICY --
+-- DROP POLICY IF EXISTS categories_plugins_write_owner ON appstore.categories_plugins CASCADE;
+CREATE POLICY categories_plugins_write_owner ON appstore.categories_plugins
+	AS PERMISSIVE
+	FOR ALL
+	TO fun_marketplace_api
+	USING (authn.is_plugin_in_organization(plugin_id))
+	WITH CHECK (authn.is_plugin_in_organization(plugin_id));
+-- ddl-end --
+
+-- object: appstore.plugin_documentation_links | type: TABLE --
+-- DROP TABLE IF EXISTS appstore.plugin_documentation_links CASCADE;
+CREATE TABLE appstore.plugin_documentation_links (
+	id uuid NOT NULL DEFAULT uuidv7(),
+	plugin_id uuid NOT NULL,
+	title text NOT NULL,
+	url_name text NOT NULL,
+	url text NOT NULL,
+	CONSTRAINT plugin_documentation_links_pk PRIMARY KEY (id)
+);
+-- ddl-end --
+ALTER TABLE appstore.plugin_documentation_links OWNER TO fun_owner;
+-- ddl-end --
+ALTER TABLE appstore.plugin_documentation_links ENABLE ROW LEVEL SECURITY;
+-- ddl-end --
+
+-- object: plugin_documentation_links_select_all | type: POLICY --
+-- DROP POLICY IF EXISTS plugin_documentation_links_select_all ON appstore.plugin_documentation_links CASCADE;
+CREATE POLICY plugin_documentation_links_select_all ON appstore.plugin_documentation_links
+	AS PERMISSIVE
+	FOR SELECT
+	TO fun_fundament_api,fun_marketplace_api
+	USING (true);
+-- ddl-end --
+
+-- object: plugin_documentation_links_write_owner | type: POLICY --
+-- DROP POLICY IF EXISTS plugin_documentation_links_write_owner ON appstore.plugin_documentation_links CASCADE;
+CREATE POLICY plugin_documentation_links_write_owner ON appstore.plugin_documentation_links
+	AS PERMISSIVE
+	FOR ALL
+	TO fun_marketplace_api
+	USING (authn.is_plugin_in_organization(plugin_id))
+	WITH CHECK (authn.is_plugin_in_organization(plugin_id));
+-- ddl-end --
+
+-- object: appstore.plugin_features | type: TABLE --
+-- DROP TABLE IF EXISTS appstore.plugin_features CASCADE;
+CREATE TABLE appstore.plugin_features (
+	id uuid NOT NULL DEFAULT uuidv7(),
+	plugin_id uuid NOT NULL,
+	ordinal integer NOT NULL,
+	title text NOT NULL,
+	body text NOT NULL,
+	CONSTRAINT plugin_features_pk PRIMARY KEY (id),
+	CONSTRAINT plugin_features_uq_ordinal UNIQUE (plugin_id,ordinal)
+);
+-- ddl-end --
+ALTER TABLE appstore.plugin_features OWNER TO fun_owner;
+-- ddl-end --
+ALTER TABLE appstore.plugin_features ENABLE ROW LEVEL SECURITY;
+-- ddl-end --
+
+-- object: plugin_features_select_all | type: POLICY --
+-- DROP POLICY IF EXISTS plugin_features_select_all ON appstore.plugin_features CASCADE;
+CREATE POLICY plugin_features_select_all ON appstore.plugin_features
+	AS PERMISSIVE
+	FOR SELECT
+	TO fun_marketplace_api
+	USING (true);
+-- ddl-end --
+
+-- object: plugin_features_write_owner | type: POLICY --
+-- DROP POLICY IF EXISTS plugin_features_write_owner ON appstore.plugin_features CASCADE;
+CREATE POLICY plugin_features_write_owner ON appstore.plugin_features
+	AS PERMISSIVE
+	FOR ALL
+	TO fun_marketplace_api
+	USING (authn.is_plugin_in_organization(plugin_id))
+	WITH CHECK (authn.is_plugin_in_organization(plugin_id));
+-- ddl-end --
+
+-- object: appstore.plugins_allowed_organizations | type: TABLE --
+-- DROP TABLE IF EXISTS appstore.plugins_allowed_organizations CASCADE;
+CREATE TABLE appstore.plugins_allowed_organizations (
+	plugin_id uuid NOT NULL,
+	organization_id uuid NOT NULL,
+	CONSTRAINT plugins_allowed_organizations_pk PRIMARY KEY (plugin_id,organization_id)
+);
+-- ddl-end --
+ALTER TABLE appstore.plugins_allowed_organizations OWNER TO fun_owner;
+-- ddl-end --
+ALTER TABLE appstore.plugins_allowed_organizations ENABLE ROW LEVEL SECURITY;
+-- ddl-end --
+
+-- object: plugins_allowed_organizations_owner | type: POLICY --
+-- DROP POLICY IF EXISTS plugins_allowed_organizations_owner ON appstore.plugins_allowed_organizations CASCADE;
+CREATE POLICY plugins_allowed_organizations_owner ON appstore.plugins_allowed_organizations
+	AS PERMISSIVE
+	FOR ALL
+	TO fun_marketplace_api
+	USING (authn.is_plugin_in_organization(plugin_id))
+	WITH CHECK (authn.is_plugin_in_organization(plugin_id));
+-- ddl-end --
+
+-- object: require_admin | type: TRIGGER --
+-- require_admin ON tenant.projects CASCADE;
+CREATE CONSTRAINT TRIGGER require_admin
+	AFTER INSERT 
+	ON tenant.projects
+	DEFERRABLE INITIALLY DEFERRED
+	FOR EACH ROW
+	EXECUTE PROCEDURE tenant.projects_tr_require_admin();
+-- ddl-end --
+
+-- object: verify_deleted | type: TRIGGER --
+-- verify_deleted ON tenant.projects CASCADE;
+CREATE CONSTRAINT TRIGGER verify_deleted
+	AFTER UPDATE
+	ON tenant.projects
+	NOT DEFERRABLE 
+	FOR EACH ROW
+	EXECUTE PROCEDURE tenant.projects_tr_verify_deleted();
+-- ddl-end --
+
+-- object: tenant.project_members | type: TABLE --
+-- DROP TABLE IF EXISTS tenant.project_members CASCADE;
+CREATE TABLE tenant.project_members (
+	id uuid NOT NULL DEFAULT uuidv7(),
+	project_id uuid NOT NULL,
+	user_id uuid NOT NULL,
+	role text NOT NULL,
+	created timestamptz NOT NULL DEFAULT now(),
+	deleted timestamptz,
+	CONSTRAINT project_members_pk PRIMARY KEY (id),
+	CONSTRAINT project_members_ck_role CHECK (role IN ('admin', 'viewer')),
+	CONSTRAINT project_members_uq_project_user UNIQUE NULLS NOT DISTINCT (project_id,user_id,deleted)
+);
+-- ddl-end --
+ALTER TABLE tenant.project_members OWNER TO fun_owner;
+-- ddl-end --
+ALTER TABLE tenant.project_members ENABLE ROW LEVEL SECURITY;
+-- ddl-end --
+
+-- object: project_members_idx_project_id | type: INDEX --
+-- DROP INDEX IF EXISTS tenant.project_members_idx_project_id CASCADE;
+CREATE INDEX project_members_idx_project_id ON tenant.project_members
+USING btree
+(
+	project_id
+);
+-- ddl-end --
+
+-- object: protect_last_admin | type: TRIGGER --
+-- DROP TRIGGER IF EXISTS protect_last_admin ON tenant.project_members CASCADE;
+CREATE OR REPLACE TRIGGER protect_last_admin
+	BEFORE UPDATE
+	ON tenant.project_members
+	FOR EACH ROW
+	EXECUTE PROCEDURE tenant.project_members_tr_protect_last_admin();
+-- ddl-end --
+
+-- object: project_members_organization_policy | type: POLICY --
+-- DROP POLICY IF EXISTS project_members_organization_policy ON tenant.project_members CASCADE;
+CREATE POLICY project_members_organization_policy ON tenant.project_members
+	AS PERMISSIVE
+	FOR ALL
+	TO fun_fundament_api
+	USING (authn.is_project_in_organization(project_id));
+-- ddl-end --
+
+-- object: project_members_authn_api_policy | type: POLICY --
+-- DROP POLICY IF EXISTS project_members_authn_api_policy ON tenant.project_members CASCADE;
+CREATE POLICY project_members_authn_api_policy ON tenant.project_members
+	AS PERMISSIVE
+	FOR SELECT
+	TO fun_authn_api
+	USING (true);
+-- ddl-end --
+
+-- object: projects_organization_policy | type: POLICY --
+-- DROP POLICY IF EXISTS projects_organization_policy ON tenant.projects CASCADE;
+CREATE POLICY projects_organization_policy ON tenant.projects
+	AS PERMISSIVE
+	FOR ALL
+	TO fun_fundament_api
+	USING (authn.is_cluster_in_organization(cluster_id));
+-- ddl-end --
+
+-- object: projects_cluster_worker_policy | type: POLICY --
+-- DROP POLICY IF EXISTS projects_cluster_worker_policy ON tenant.projects CASCADE;
+CREATE POLICY projects_cluster_worker_policy ON tenant.projects
+	AS PERMISSIVE
+	FOR SELECT
+	TO fun_cluster_worker
+	USING (true);
+-- ddl-end --
+
+-- object: projects_authn_api_policy | type: POLICY --
+-- DROP POLICY IF EXISTS projects_authn_api_policy ON tenant.projects CASCADE;
+CREATE POLICY projects_authn_api_policy ON tenant.projects
+	AS PERMISSIVE
+	FOR SELECT
+	TO fun_authn_api
+	USING (true);
+-- ddl-end --
+
+-- object: namespaces_organization_policy | type: POLICY --
+-- DROP POLICY IF EXISTS namespaces_organization_policy ON tenant.namespaces CASCADE;
+CREATE POLICY namespaces_organization_policy ON tenant.namespaces
+	AS PERMISSIVE
+	FOR ALL
+	TO fun_fundament_api
+	USING (authn.is_project_in_organization(project_id));
+-- ddl-end --
+
+-- object: namespaces_cluster_worker_policy | type: POLICY --
+-- DROP POLICY IF EXISTS namespaces_cluster_worker_policy ON tenant.namespaces CASCADE;
+CREATE POLICY namespaces_cluster_worker_policy ON tenant.namespaces
+	AS PERMISSIVE
+	FOR SELECT
+	TO fun_cluster_worker
+	USING (true);
+-- ddl-end --
+
+-- object: organizations_organization_policy | type: POLICY --
+-- DROP POLICY IF EXISTS organizations_organization_policy ON tenant.organizations CASCADE;
+CREATE POLICY organizations_organization_policy ON tenant.organizations
+	AS PERMISSIVE
+	FOR ALL
+	TO fun_fundament_api
+	USING (id = authn.current_organization_id());
+-- ddl-end --
+
+-- object: organizations_user_select_policy | type: POLICY --
+-- DROP POLICY IF EXISTS organizations_user_select_policy ON tenant.organizations CASCADE;
+CREATE POLICY organizations_user_select_policy ON tenant.organizations
+	AS PERMISSIVE
+	FOR SELECT
+	TO fun_fundament_api
+	USING (authn.is_organization_member(id));
+-- ddl-end --
+
+-- object: organizations_authn_api_policy | type: POLICY --
+-- DROP POLICY IF EXISTS organizations_authn_api_policy ON tenant.organizations CASCADE;
+CREATE POLICY organizations_authn_api_policy ON tenant.organizations
+	AS PERMISSIVE
+	FOR ALL
+	TO fun_authn_api
+	USING (true);
+-- ddl-end --
+
+-- object: organizations_cluster_worker_policy | type: POLICY --
+-- DROP POLICY IF EXISTS organizations_cluster_worker_policy ON tenant.organizations CASCADE;
+CREATE POLICY organizations_cluster_worker_policy ON tenant.organizations
+	AS PERMISSIVE
+	FOR SELECT
+	TO fun_cluster_worker
+	USING (true);
+-- ddl-end --
+
+-- object: organizations_marketplace_api_policy | type: POLICY --
+-- DROP POLICY IF EXISTS organizations_marketplace_api_policy ON tenant.organizations CASCADE;
+CREATE POLICY organizations_marketplace_api_policy ON tenant.organizations
+	AS PERMISSIVE
+	FOR SELECT
+	TO fun_marketplace_api
+	USING (EXISTS (SELECT 1 FROM appstore.plugins WHERE appstore.plugins.organization_id = tenant.organizations.id AND appstore.plugins.deleted IS NULL));
+-- ddl-end --
+
+-- object: organization_limits_organization_policy | type: POLICY --
+-- DROP POLICY IF EXISTS organization_limits_organization_policy ON tenant.organization_limits CASCADE;
+CREATE POLICY organization_limits_organization_policy ON tenant.organization_limits
+	AS PERMISSIVE
+	FOR ALL
+	TO fun_fundament_api
+	USING (organization_id = authn.current_organization_id());
+-- ddl-end --
+
+-- object: organization_policies_organization_policy | type: POLICY --
+-- DROP POLICY IF EXISTS organization_policies_organization_policy ON tenant.organization_policies CASCADE;
+CREATE POLICY organization_policies_organization_policy ON tenant.organization_policies
+	AS PERMISSIVE
+	FOR ALL
+	TO fun_fundament_api
+	USING (organization_id = authn.current_organization_id());
+-- ddl-end --
+
+-- object: project_limits_project_policy | type: POLICY --
+-- DROP POLICY IF EXISTS project_limits_project_policy ON tenant.project_limits CASCADE;
+CREATE POLICY project_limits_project_policy ON tenant.project_limits
+	AS PERMISSIVE
+	FOR ALL
+	TO fun_fundament_api
+	USING (authn.is_project_in_organization(project_id));
+-- ddl-end --
+
+-- object: organization_limits_cluster_worker_read | type: POLICY --
+-- DROP POLICY IF EXISTS organization_limits_cluster_worker_read ON tenant.organization_limits CASCADE;
+CREATE POLICY organization_limits_cluster_worker_read ON tenant.organization_limits
+	AS PERMISSIVE
+	FOR SELECT
+	TO fun_cluster_worker
+	USING (true);
+-- ddl-end --
+
+-- object: project_limits_cluster_worker_read | type: POLICY --
+-- DROP POLICY IF EXISTS project_limits_cluster_worker_read ON tenant.project_limits CASCADE;
+CREATE POLICY project_limits_cluster_worker_read ON tenant.project_limits
+	AS PERMISSIVE
+	FOR SELECT
+	TO fun_cluster_worker
+	USING (true);
+-- ddl-end --
+
+-- object: namespace_limits_organization_policy | type: POLICY --
+-- DROP POLICY IF EXISTS namespace_limits_organization_policy ON tenant.namespace_limits CASCADE;
+CREATE POLICY namespace_limits_organization_policy ON tenant.namespace_limits
+	AS PERMISSIVE
+	FOR ALL
+	TO fun_fundament_api
+	USING (EXISTS (SELECT 1 FROM tenant.namespaces WHERE namespaces.id = namespace_limits.namespace_id AND authn.is_project_in_organization(namespaces.project_id)));
+-- ddl-end --
+
+-- object: namespace_limits_cluster_worker_read | type: POLICY --
+-- DROP POLICY IF EXISTS namespace_limits_cluster_worker_read ON tenant.namespace_limits CASCADE;
+CREATE POLICY namespace_limits_cluster_worker_read ON tenant.namespace_limits
+	AS PERMISSIVE
+	FOR SELECT
+	TO fun_cluster_worker
+	USING (true);
+-- ddl-end --
+
+-- object: tenant.cluster_events | type: TABLE --
+-- DROP TABLE IF EXISTS tenant.cluster_events CASCADE;
+CREATE TABLE tenant.cluster_events (
+	id uuid NOT NULL DEFAULT uuidv7(),
+	cluster_id uuid NOT NULL,
+	event_type text NOT NULL,
+	created timestamptz NOT NULL DEFAULT now(),
+	sync_action text,
+	message text,
+	attempt integer,
+	CONSTRAINT cluster_events_pk PRIMARY KEY (id),
+	CONSTRAINT cluster_events_ck_event_type CHECK (event_type IN ('sync_requested','sync_claimed','sync_succeeded','sync_failed','status_progressing','status_ready','status_error','status_deleted','user_sync_succeeded','user_sync_failed','upgrade_started','upgrade_completed','upgrade_failed','hibernation_requested','wake_requested','status_hibernated','outbox_replayed','outbox_abandoned','access_elevation_granted','access_elevation_ended')),
+	CONSTRAINT cluster_events_ck_sync_action CHECK (sync_action IN ('sync','delete'))
+);
+-- ddl-end --
+ALTER TABLE tenant.cluster_events OWNER TO fun_owner;
+-- ddl-end --
+ALTER TABLE tenant.cluster_events ENABLE ROW LEVEL SECURITY;
+-- ddl-end --
+
+-- object: cluster_events_worker_all_access | type: POLICY --
+-- DROP POLICY IF EXISTS cluster_events_worker_all_access ON tenant.cluster_events CASCADE;
+CREATE POLICY cluster_events_worker_all_access ON tenant.cluster_events
+	AS PERMISSIVE
+	FOR ALL
+	TO fun_cluster_worker
+	USING (true);
+-- ddl-end --
+
+-- object: cluster_events_organization_isolation | type: POLICY --
+-- DROP POLICY IF EXISTS cluster_events_organization_isolation ON tenant.cluster_events CASCADE;
+CREATE POLICY cluster_events_organization_isolation ON tenant.cluster_events
+	AS PERMISSIVE
+	FOR ALL
+	TO fun_fundament_api
+	USING (EXISTS (
+    SELECT 1 FROM tenant.clusters c
+    WHERE c.id = cluster_events.cluster_id
+    AND c.organization_id = authn.current_organization_id()
+));
+-- ddl-end --
+
+-- object: cluster_events_idx_cluster_created | type: INDEX --
+-- DROP INDEX IF EXISTS tenant.cluster_events_idx_cluster_created CASCADE;
+CREATE INDEX cluster_events_idx_cluster_created ON tenant.cluster_events
+USING btree
+(
+	cluster_id DESC NULLS LAST,
+	created DESC NULLS LAST
+);
+-- ddl-end --
+
+-- object: tenant.cluster_upgrades | type: TABLE --
+-- DROP TABLE IF EXISTS tenant.cluster_upgrades CASCADE;
+CREATE TABLE tenant.cluster_upgrades (
+	id uuid NOT NULL DEFAULT uuidv7(),
+	cluster_id uuid NOT NULL,
+	from_kubernetes_version text NOT NULL,
+	to_kubernetes_version text NOT NULL,
+	to_kubernetes_version_id uuid,
+	not_before timestamptz NOT NULL DEFAULT now(),
+	skip_maintenance_window boolean NOT NULL DEFAULT false,
+	status text NOT NULL DEFAULT 'scheduled',
+	message text,
+	created timestamptz NOT NULL DEFAULT now(),
+	started timestamptz,
+	finished timestamptz,
+	CONSTRAINT cluster_upgrades_pk PRIMARY KEY (id),
+	CONSTRAINT cluster_upgrades_ck_status CHECK (status IN ('scheduled','in_progress','completed','failed','cancelled'))
+);
+-- ddl-end --
+COMMENT ON TABLE tenant.cluster_upgrades IS E'Kubernetes version upgrades scheduled for a cluster. cluster-worker starts a scheduled upgrade once not_before has passed and the cluster is inside its maintenance window (unless skip_maintenance_window), by setting tenant.clusters.kubernetes_version.';
+-- ddl-end --
+COMMENT ON COLUMN tenant.cluster_upgrades.to_kubernetes_version_id IS E'Catalog reference for to_kubernetes_version, resolved when the upgrade is scheduled.';
+-- ddl-end --
+ALTER TABLE tenant.cluster_upgrades OWNER TO fun_owner;
+-- ddl-end --
+ALTER TABLE tenant.cluster_upgrades ENABLE ROW LEVEL SECURITY;
+-- ddl-end --
+
+-- object: cluster_upgrades_worker_all_access | type: POLICY --
+-- DROP POLICY IF EXISTS cluster_upgrades_worker_all_access ON tenant.cluster_upgrades CASCADE;
+CREATE POLICY cluster_upgrades_worker_all_access ON tenant.cluster_upgrades
+	AS PERMISSIVE
+	FOR ALL
+	TO fun_cluster_worker
+	USING (true);
+-- ddl-end --
+
+-- object: cluster_upgrades_organization_isolation | type: POLICY --
+-- DROP POLICY IF EXISTS cluster_upgrades_organization_isolation ON tenant.cluster_upgrades CASCADE;
+CREATE POLICY cluster_upgrades_organization_isolation ON tenant.cluster_upgrades
+	AS PERMISSIVE
+	FOR ALL
+	TO fun_fundament_api
+	USING (EXISTS (
+    SELECT 1 FROM tenant.clusters c
+    WHERE c.id = cluster_upgrades.cluster_id
+    AND c.organization_id = authn.current_organization_id()
+));
+-- ddl-end --
+
+-- object: cluster_upgrades_uq_active | type: INDEX --
+-- DROP INDEX IF EXISTS tenant.cluster_upgrades_uq_active CASCADE;
+CREATE UNIQUE INDEX cluster_upgrades_uq_active ON tenant.cluster_upgrades
+USING btree
+(
+	cluster_id
+)
+WHERE (status IN ('scheduled','in_progress'));
+-- ddl-end --
+
+-- object: cluster_upgrades_idx_cluster_created | type: INDEX --
+-- DROP INDEX IF EXISTS tenant.cluster_upgrades_idx_cluster_created CASCADE;
+CREATE INDEX cluster_upgrades_idx_cluster_created ON tenant.cluster_upgrades
+USING btree
+(
+	cluster_id DESC NULLS LAST,
+	created DESC NULLS LAST
+);
+-- ddl-end --
+
+-- object: tenant.cluster_hibernation_schedules | type: TABLE --
+-- DROP TABLE IF EXISTS tenant.cluster_hibernation_schedules CASCADE;
+CREATE TABLE tenant.cluster_hibernation_schedules (
+	id uuid NOT NULL DEFAULT uuidv7(),
+	cluster_id uuid NOT NULL,
+	start text,
+	"end" text,
+	location text NOT NULL DEFAULT 'UTC',
+	created timestamptz NOT NULL DEFAULT now(),
+	CONSTRAINT cluster_hibernation_schedules_pk PRIMARY KEY (id),
+	CONSTRAINT cluster_hibernation_schedules_ck_start_or_end CHECK (start IS NOT NULL OR "end" IS NOT NULL)
+);
+-- ddl-end --
+COMMENT ON TABLE tenant.cluster_hibernation_schedules IS E'Cron schedules (Gardener Shoot spec.hibernation.schedules) that hibernate a cluster at start and wake it at end, evaluated in location.';
+-- ddl-end --
+ALTER TABLE tenant.cluster_hibernation_schedules OWNER TO fun_owner;
+-- ddl-end --
+ALTER TABLE tenant.cluster_hibernation_schedules ENABLE ROW LEVEL SECURITY;
+-- ddl-end --
+
+-- object: cluster_hibernation_schedules_worker_all_access | type: POLICY --
+-- DROP POLICY IF EXISTS cluster_hibernation_schedules_worker_all_access ON tenant.cluster_hibernation_schedules CASCADE;
+CREATE POLICY cluster_hibernation_schedules_worker_all_access ON tenant.cluster_hibernation_schedules
+	AS PERMISSIVE
+	FOR ALL
+	TO fun_cluster_worker
+	USING (true);
+-- ddl-end --
+
+-- object: cluster_hibernation_schedules_organization_isolation | type: POLICY --
+-- DROP POLICY IF EXISTS cluster_hibernation_schedules_organization_isolation ON tenant.cluster_hibernation_schedules CASCADE;
+CREATE POLICY cluster_hibernation_schedules_organization_isolation ON tenant.cluster_hibernation_schedules
+	AS PERMISSIVE
+	FOR ALL
+	TO fun_fundament_api
+	USING (EXISTS (
+    SELECT 1 FROM tenant.clusters c
+    WHERE c.id = cluster_hibernation_schedules.cluster_id
+    AND c.organization_id = authn.current_organization_id()
+));
+-- ddl-end --
+
+-- object: cluster_hibernation_schedules_idx_cluster | type: INDEX --
+-- DROP INDEX IF EXISTS tenant.cluster_hibernation_schedules_idx_cluster CASCADE;
+CREATE INDEX cluster_hibernation_schedules_idx_cluster ON tenant.cluster_hibernation_schedules
+USING btree
+(
+	cluster_id
+);
+-- ddl-end --
+
+-- object: tenant.cluster_outbox | type: TABLE --
+-- DROP TABLE IF EXISTS tenant.cluster_outbox CASCADE;
+CREATE TABLE tenant.cluster_outbox (
+	id uuid NOT NULL DEFAULT uuidv7(),
+	cluster_id uuid,
+	organization_user_id uuid,
+	project_member_id uuid,
+	node_pool_id uuid,
+	namespace_id uuid,
+	access_elevation_id uuid,
+	event text NOT NULL DEFAULT 'updated',
+	source text NOT NULL DEFAULT 'trigger',
+	status text NOT NULL DEFAULT 'pending',
+	retries integer NOT NULL DEFAULT 0,
+	retry_after timestamptz,
+	processed timestamptz,
+	failed timestamptz,
+	status_info text,
+	created timestamptz NOT NULL DEFAULT now(),
+	deferrals integer NOT NULL DEFAULT 0,
+	organization_id uuid,
+	partition_key uuid,
+	CONSTRAINT cluster_outbox_pk PRIMARY KEY (id),
+	CONSTRAINT cluster_outbox_ck_single_fk CHECK (num_nonnulls(cluster_id, organization_user_id, project_member_id, node_pool_id, namespace_id, access_elevation_id) = 1),
+	CONSTRAINT cluster_outbox_ck_status CHECK (status IN ('pending', 'completed', 'retrying', 'failed', 'abandoned')),
+	CONSTRAINT cluster_outbox_ck_event CHECK (event IN ('created', 'updated', 'deleted', 'reconcile', 'ready')),
+	CONSTRAINT cluster_outbox_ck_source CHECK (source IN ('trigger', 'reconcile', 'manual', 'status'))
+);
+-- ddl-end --
+COMMENT ON COLUMN tenant.cluster_outbox.organization_id IS E'Organization the row belongs to; workers take turns between organizations within a shard.';
+-- ddl-end --
+COMMENT ON COLUMN tenant.cluster_outbox.partition_key IS E'Cluster the row touches, or the organization for organization-wide rows. Rows with the same key land in the same shard.';
+-- ddl-end --
+ALTER TABLE tenant.cluster_outbox OWNER TO fun_owner;
+-- ddl-end --
+
+-- object: tenant.cluster_worker_leases | type: TABLE --
+-- DROP TABLE IF EXISTS tenant.cluster_worker_leases CASCADE;
+CREATE TABLE tenant.cluster_worker_leases (
+	name text NOT NULL,
+	holder text NOT NULL,
+	acquired timestamptz NOT NULL DEFAULT now(),
+	expires timestamptz NOT NULL,
+	CONSTRAINT cluster_worker_leases_pk PRIMARY KEY (name)
+);
+-- ddl-end --
+COMMENT ON TABLE tenant.cluster_worker_leases IS E'Leases through which cluster-worker replicas divide the outbox shards and elect the replica that runs the periodic loops.';
+-- ddl-end --
+COMMENT ON COLUMN tenant.cluster_worker_leases.name IS E'replica/<holder> for a replica heartbeat, leader, or outbox-shard/<n>.';
+-- ddl-end --
+COMMENT ON COLUMN tenant.cluster_worker_leases.holder IS E'Replica holding the lease (hostname and process ID).';
+-- ddl-end --
+ALTER TABLE tenant.cluster_worker_leases OWNER TO fun_owner;
+-- ddl-end --
+
+-- object: cluster_outbox_status_retry_idx | type: INDEX --
+-- DROP INDEX IF EXISTS tenant.cluster_outbox_status_retry_idx CASCADE;
+CREATE INDEX cluster_outbox_status_retry_idx ON tenant.cluster_outbox
+USING btree
+(
+	status,
+	retry_after,
+	id
+);
+-- ddl-end --
+
+-- object: cluster_outbox_idx_cluster_id | type: INDEX --
+-- DROP INDEX IF EXISTS tenant.cluster_outbox_idx_cluster_id CASCADE;
+CREATE INDEX cluster_outbox_idx_cluster_id ON tenant.cluster_outbox
+USING btree
+(
+	cluster_id,
+	id DESC NULLS LAST
+);
+-- ddl-end --
+
+-- object: cluster_outbox_idx_node_pool_id | type: INDEX --
+-- DROP INDEX IF EXISTS tenant.cluster_outbox_idx_node_pool_id CASCADE;
+CREATE INDEX cluster_outbox_idx_node_pool_id ON tenant.cluster_outbox
+USING btree
+(
+	node_pool_id,
+	id DESC NULLS LAST
+);
+-- ddl-end --
+
+-- object: cluster_outbox_idx_namespace_id | type: INDEX --
+-- DROP INDEX IF EXISTS tenant.cluster_outbox_idx_namespace_id CASCADE;
+CREATE INDEX cluster_outbox_idx_namespace_id ON tenant.cluster_outbox
+USING btree
+(
+	namespace_id,
+	id DESC NULLS LAST
+);
+-- ddl-end --
+
+-- object: cluster_outbox_idx_organization_id_queued | type: INDEX --
+-- DROP INDEX IF EXISTS tenant.cluster_outbox_idx_organization_id_queued CASCADE;
+CREATE INDEX cluster_outbox_idx_organization_id_queued ON tenant.cluster_outbox
+USING btree
+(
+	organization_id,
+	id
+)
+WHERE (status IN ('pending', 'retrying'));
+-- ddl-end --
+
+-- object: cluster_outbox_uq_ns_reconcile | type: INDEX --
+-- DROP INDEX IF EXISTS tenant.cluster_outbox_uq_ns_reconcile CASCADE;
+CREATE UNIQUE INDEX cluster_outbox_uq_ns_reconcile ON tenant.cluster_outbox
+USING btree
+(
+	namespace_id
+)
+WHERE (source = 'reconcile' AND status IN ('pending', 'retrying'));
+-- ddl-end --
+
+-- object: node_pools_idx_cluster_id | type: INDEX --
+-- DROP INDEX IF EXISTS tenant.node_pools_idx_cluster_id CASCADE;
+CREATE INDEX node_pools_idx_cluster_id ON tenant.node_pools
+USING btree
+(
+	cluster_id
+);
+-- ddl-end --
+
+-- object: authz.outbox | type: TABLE --
+-- DROP TABLE IF EXISTS authz.outbox CASCADE;
+CREATE TABLE authz.outbox (
+	id uuid NOT NULL DEFAULT uuidv7(),
+	project_id uuid,
+	project_member_id uuid,
+	cluster_id uuid,
+	node_pool_id uuid,
+	namespace_id uuid,
+	api_key_id uuid,
+	organization_user_id uuid,
+	plugin_id uuid,
+	namespace_role_binding_id uuid,
+	team_id uuid,
+	team_member_id uuid,
+	project_team_id uuid,
+	access_elevation_id uuid,
+	created timestamptz NOT NULL DEFAULT now(),
+	processed timestamptz,
+	retries integer NOT NULL DEFAULT 0,
+	retry_after timestamptz,
+	failed timestamptz,
+	status text NOT NULL DEFAULT 'pending',
+	status_info text,
+	CONSTRAINT outbox_pk PRIMARY KEY (id),
+	CONSTRAINT outbox_ck_single_fk CHECK (num_nonnulls(
+	project_id,
+	project_member_id,
+	cluster_id,
+	node_pool_id,
+	namespace_id,
+	api_key_id,
+	organization_user_id,
+	plugin_id,
+	namespace_role_binding_id,
+	team_id,
+	team_member_id,
+	project_team_id,
+	access_elevation_id
+) = 1),
+	CONSTRAINT outbox_ck_status CHECK (status IN ('pending', 'completed', 'retrying', 'failed', 'abandoned'))
+);
+-- ddl-end --
+ALTER TABLE authz.outbox OWNER TO fun_owner;
+-- ddl-end --
+
+-- object: outbox_idx_unprocessed | type: INDEX --
+-- DROP INDEX IF EXISTS authz.outbox_idx_unprocessed CASCADE;
+CREATE INDEX outbox_idx_unprocessed ON authz.outbox
+USING btree
+(
+	created
+)
+WHERE (processed IS NULL);
+-- ddl-end --
+
+-- object: authz.projects_sync_trigger | type: FUNCTION --
+-- DROP FUNCTION IF EXISTS authz.projects_sync_trigger() CASCADE;
+CREATE OR REPLACE FUNCTION authz.projects_sync_trigger ()
+	RETURNS trigger
+	LANGUAGE plpgsql
+	VOLATILE 
+	CALLED ON NULL INPUT
+	SECURITY INVOKER
+	PARALLEL UNSAFE
+	COST 1
+	AS 
+$function$
+BEGIN
+    -- Only insert into outbox if this is an INSERT or if data actually changed
+    IF TG_OP = 'INSERT' OR NEW IS DISTINCT FROM OLD THEN
+        INSERT INTO authz.outbox (project_id)
+        VALUES (COALESCE(NEW.id, OLD.id));
+    END IF;
+    RETURN COALESCE(NEW, OLD);
+END;
+$function$;
+-- ddl-end --
+ALTER FUNCTION authz.projects_sync_trigger() OWNER TO fun_owner;
+-- ddl-end --
+
+-- object: authz.project_members_sync_trigger | type: FUNCTION --
+-- DROP FUNCTION IF EXISTS authz.project_members_sync_trigger() CASCADE;
+CREATE OR REPLACE FUNCTION authz.project_members_sync_trigger ()
+	RETURNS trigger
+	LANGUAGE plpgsql
+	VOLATILE 
+	CALLED ON NULL INPUT
+	SECURITY INVOKER
+	PARALLEL UNSAFE
+	COST 1
+	AS 
+$function$
+BEGIN
+    -- Only insert into outbox if this is an INSERT or if data actually changed
+    IF TG_OP = 'INSERT' OR NEW IS DISTINCT FROM OLD THEN
+        INSERT INTO authz.outbox (project_member_id)
+        VALUES (COALESCE(NEW.id, OLD.id));
+    END IF;
+    RETURN COALESCE(NEW, OLD);
+END;
+$function$;
+-- ddl-end --
+ALTER FUNCTION authz.project_members_sync_trigger() OWNER TO fun_owner;
+-- ddl-end --
+
+-- object: authz.clusters_sync_trigger | type: FUNCTION --
+-- DROP FUNCTION IF EXISTS authz.clusters_sync_trigger() CASCADE;
+CREATE OR REPLACE FUNCTION authz.clusters_sync_trigger ()
+	RETURNS trigger
+	LANGUAGE plpgsql
+	VOLATILE 
+	CALLED ON NULL INPUT
+	SECURITY INVOKER
+	PARALLEL UNSAFE
+	COST 1
+	AS 
+$function$
+BEGIN
+    -- Only insert into outbox if this is an INSERT or if data actually changed
+    IF TG_OP = 'INSERT' OR NEW IS DISTINCT FROM OLD THEN
+        INSERT INTO authz.outbox (cluster_id)
+        VALUES (COALESCE(NEW.id, OLD.id));
+    END IF;
+    RETURN COALESCE(NEW, OLD);
+END;
+$function$;
+-- ddl-end --
+ALTER FUNCTION authz.clusters_sync_trigger() OWNER TO fun_owner;
+-- ddl-end --
+
+-- object: authz.node_pools_sync_trigger | type: FUNCTION --
+-- DROP FUNCTION IF EXISTS authz.node_pools_sync_trigger() CASCADE;
+CREATE OR REPLACE FUNCTION authz.node_pools_sync_trigger ()
+	RETURNS trigger
+	LANGUAGE plpgsql
+	VOLATILE 
+	CALLED ON NULL INPUT
+	SECURITY INVOKER
+	PARALLEL UNSAFE
+	COST 1
+	AS 
+$function$
+BEGIN
+    -- Only insert into outbox if this is an INSERT or if data actually changed
+    IF TG_OP = 'INSERT' OR NEW IS DISTINCT FROM OLD THEN
+        INSERT INTO authz.outbox (node_pool_id)
+        VALUES (COALESCE(NEW.id, OLD.id));
+    END IF;
+    RETURN COALESCE(NEW, OLD);
+END;
+$function$;
+-- ddl-end --
+ALTER FUNCTION authz.node_pools_sync_trigger() OWNER TO fun_owner;
+-- ddl-end --
+
+-- object: authz.namespaces_sync_trigger | type: FUNCTION --
+-- DROP FUNCTION IF EXISTS authz.namespaces_sync_trigger() CASCADE;
+CREATE OR REPLACE FUNCTION authz.namespaces_sync_trigger ()
+	RETURNS trigger
+	LANGUAGE plpgsql
+	VOLATILE 
+	CALLED ON NULL INPUT
+	SECURITY INVOKER
+	PARALLEL UNSAFE
+	COST 1
+	AS 
+$function$
+BEGIN
+    -- Only insert into outbox if this is an INSERT or if data actually changed
+    IF TG_OP = 'INSERT' OR NEW IS DISTINCT FROM OLD THEN
+        INSERT INTO authz.outbox (namespace_id)
+        VALUES (COALESCE(NEW.id, OLD.id));
+    END IF;
+    RETURN COALESCE(NEW, OLD);
+END;
+$function$;
+-- ddl-end --
+ALTER FUNCTION authz.namespaces_sync_trigger() OWNER TO fun_owner;
+-- ddl-end --
+
+-- object: authz.api_keys_sync_trigger | type: FUNCTION --
+-- DROP FUNCTION IF EXISTS authz.api_keys_sync_trigger() CASCADE;
+CREATE OR REPLACE FUNCTION authz.api_keys_sync_trigger ()
+	RETURNS trigger
+	LANGUAGE plpgsql
+	VOLATILE 
+	CALLED ON NULL INPUT
+	SECURITY INVOKER
+	PARALLEL UNSAFE
+	COST 1
+	AS 
+$function$
+BEGIN
+    -- Only insert into outbox if this is an INSERT, DELETE, or if data actually changed
+    IF TG_OP = 'INSERT' OR NEW IS DISTINCT FROM OLD THEN
+        INSERT INTO authz.outbox (api_key_id)
+        VALUES (COALESCE(NEW.id, OLD.id));
+    END IF;
+    RETURN COALESCE(NEW, OLD);
+END;
+$function$;
+-- ddl-end --
+ALTER FUNCTION authz.api_keys_sync_trigger() OWNER TO fun_owner;
+-- ddl-end --
+
+-- object: authz.plugins_sync_trigger | type: FUNCTION --
+-- DROP FUNCTION IF EXISTS authz.plugins_sync_trigger() CASCADE;
+CREATE OR REPLACE FUNCTION authz.plugins_sync_trigger ()
+	RETURNS trigger
+	LANGUAGE plpgsql
+	VOLATILE 
+	CALLED ON NULL INPUT
+	SECURITY INVOKER
+	PARALLEL UNSAFE
+	COST 1
+	AS 
+$function$
+BEGIN
+    -- Only insert into outbox if this is an INSERT, DELETE, or if data actually changed
+    IF TG_OP = 'INSERT' OR NEW IS DISTINCT FROM OLD THEN
+        INSERT INTO authz.outbox (plugin_id)
+        VALUES (COALESCE(NEW.id, OLD.id));
+    END IF;
+    RETURN COALESCE(NEW, OLD);
+END;
+$function$;
+-- ddl-end --
+ALTER FUNCTION authz.plugins_sync_trigger() OWNER TO fun_owner;
+-- ddl-end --
+
+-- object: authz.organizations_users_sync_trigger | type: FUNCTION --
+-- DROP FUNCTION IF EXISTS authz.organizations_users_sync_trigger() CASCADE;
+CREATE OR REPLACE FUNCTION authz.organizations_users_sync_trigger ()
+	RETURNS trigger
+	LANGUAGE plpgsql
+	VOLATILE 
+	CALLED ON NULL INPUT
+	SECURITY INVOKER
+	PARALLEL UNSAFE
+	COST 1
+	AS 
+$function$
+BEGIN
+    -- Only insert into outbox if this is an INSERT or if data actually changed
+    IF TG_OP = 'INSERT' OR NEW IS DISTINCT FROM OLD THEN
+        INSERT INTO authz.outbox (organization_user_id)
+        VALUES (COALESCE(NEW.id, OLD.id));
+    END IF;
+    RETURN COALESCE(NEW, OLD);
+END;
+$function$;
+-- ddl-end --
+ALTER FUNCTION authz.organizations_users_sync_trigger() OWNER TO fun_owner;
+-- ddl-end --
+
+-- object: authz.namespace_role_bindings_sync_trigger | type: FUNCTION --
+-- DROP FUNCTION IF EXISTS authz.namespace_role_bindings_sync_trigger() CASCADE;
+CREATE OR REPLACE FUNCTION authz.namespace_role_bindings_sync_trigger ()
+	RETURNS trigger
+	LANGUAGE plpgsql
+	VOLATILE 
+	CALLED ON NULL INPUT
+	SECURITY INVOKER
+	PARALLEL UNSAFE
+	COST 1
+	AS 
+$function$
+BEGIN
+    -- Only insert into outbox if this is an INSERT or if data actually changed
+    IF TG_OP = 'INSERT' OR NEW IS DISTINCT FROM OLD THEN
+        INSERT INTO authz.outbox (namespace_role_binding_id)
+        VALUES (COALESCE(NEW.id, OLD.id));
+    END IF;
+    RETURN COALESCE(NEW, OLD);
+END;
+$function$;
+-- ddl-end --
+ALTER FUNCTION authz.namespace_role_bindings_sync_trigger() OWNER TO fun_owner;
+-- ddl-end --
+
+-- object: authz.teams_sync_trigger | type: FUNCTION --
+-- DROP FUNCTION IF EXISTS authz.teams_sync_trigger() CASCADE;
+CREATE OR REPLACE FUNCTION authz.teams_sync_trigger ()
+	RETURNS trigger
+	LANGUAGE plpgsql
+	VOLATILE 
+	CALLED ON NULL INPUT
+	SECURITY INVOKER
+	PARALLEL UNSAFE
+	COST 1
+	AS 
+$function$
+BEGIN
+    -- Only insert into outbox if this is an INSERT or if data actually changed
+    IF TG_OP = 'INSERT' OR NEW IS DISTINCT FROM OLD THEN
+        INSERT INTO authz.outbox (team_id)
+        VALUES (COALESCE(NEW.id, OLD.id));
+    END IF;
+    RETURN COALESCE(NEW, OLD);
+END;
+$function$;
 -- ddl-end --
-COMMENT ON COLUMN appstore.plugins.display_name IS E'Human-readable name shown in the Console, matching the plugin''s definition.yaml metadata.displayName (e.g. "OpenFSC").';
+ALTER FUNCTION authz.teams_sync_trigger() OWNER TO fun_owner;
 -- ddl-end --
-ALTER TABLE appstore.plugins OWNER TO fun_owner;
+
+-- object: authz.team_members_sync_trigger | type: FUNCTION --
+-- DROP FUNCTION IF EXISTS authz.team_members_sync_trigger() CASCADE;
+CREATE OR REPLACE FUNCTION authz.team_members_sync_trigger ()
+	RETURNS trigger
+	LANGUAGE plpgsql
+	VOLATILE 
+	CALLED ON NULL INPUT
+	SECURITY INVOKER
+	PARALLEL UNSAFE
+	COST 1
+	AS 
+$function$
+BEGIN
+    -- Only insert into outbox if this is an INSERT or if data actually changed
+    IF TG_OP = 'INSERT' OR NEW IS DISTINCT FROM OLD THEN
+        INSERT INTO authz.outbox (team_member_id)
+        VALUES (COALESCE(NEW.id, OLD.id));
+    END IF;
+    RETURN COALESCE(NEW, OLD);
+END;
+$function$;
 -- ddl-end --
-ALTER TABLE appstore.plugins ENABLE ROW LEVEL SECURITY;
+ALTER FUNCTION authz.team_members_sync_trigger() OWNER TO fun_owner;
 -- ddl-end --
 
--- object: plugins_select_all | type: POLICY --
--- DROP POLICY IF EXISTS plugins_select_all ON appstore.plugins CASCADE;
-CREATE POLICY plugins_select_all ON appstore.plugins
-	AS PERMISSIVE
-	FOR SELECT
-	TO fun_fundament_api
-	USING (true);
+-- object: authz.project_teams_sync_trigger | type: FUNCTION --
+-- DROP FUNCTION IF EXISTS authz.project_teams_sync_trigger() CASCADE;
+CREATE OR REPLACE FUNCTION authz.project_teams_sync_trigger ()
+	RETURNS trigger
+	LANGUAGE plpgsql
+	VOLATILE 
+	CALLED ON NULL INPUT
+	SECURITY INVOKER
+	PARALLEL UNSAFE
+	COST 1
+	AS 
+$function$
+BEGIN
+    -- Only insert into outbox if this is an INSERT or if data actually changed
+    IF TG_OP = 'INSERT' OR NEW IS DISTINCT FROM OLD THEN
+        INSERT INTO authz.outbox (project_team_id)
+        VALUES (COALESCE(NEW.id, OLD.id));
+    END IF;
+    RETURN COALESCE(NEW, OLD);
+END;
+$function$;
 -- ddl-end --
-
--- object: plugins_insert_owner | type: POLICY --
--- DROP POLICY IF EXISTS plugins_insert_owner ON appstore.plugins CASCADE;
-CREATE POLICY plugins_insert_owner ON appstore.plugins
-	AS PERMISSIVE
-	FOR INSERT
-	TO fun_fundament_api
-	WITH CHECK (organization_id = authn.current_organization_id());
+ALTER FUNCTION authz.project_teams_sync_trigger() OWNER TO fun_owner;
 -- ddl-end --
 
--- object: plugins_update_owner | type: POLICY --
--- DROP POLICY IF EXISTS plugins_update_owner ON appstore.plugins CASCADE;
-CREATE POLICY plugins_update_owner ON appstore.plugins
-	AS PERMISSIVE
-	FOR UPDATE
-	TO fun_fundament_api
-	USING (organization_id = authn.current_organization_id())
-	WITH CHECK (organization_id = authn.current_organization_id());
+-- object: authz.access_elevations_sync_trigger | type: FUNCTION --
+-- DROP FUNCTION IF EXISTS authz.access_elevations_sync_trigger() CASCADE;
+CREATE OR REPLACE FUNCTION authz.access_elevations_sync_trigger ()
+	RETURNS trigger
+	LANGUAGE plpgsql
+	VOLATILE 
+	CALLED ON NULL INPUT
+	SECURITY INVOKER
+	PARALLEL UNSAFE
+	COST 1
+	AS 
+$function$
+BEGIN
+    -- Only insert into outbox if this is an INSERT or if data actually changed
+    IF TG_OP = 'INSERT' OR NEW IS DISTINCT FROM OLD THEN
+        INSERT INTO authz.outbox (access_elevation_id)
+        VALUES (COALESCE(NEW.id, OLD.id));
+    END IF;
+    RETURN COALESCE(NEW, OLD);
+END;
+$function$;
+-- ddl-end --
+ALTER FUNCTION authz.access_elevations_sync_trigger() OWNER TO fun_owner;
 -- ddl-end --
 
--- object: appstore.plugin_definitions | type: TABLE --
--- DROP TABLE IF EXISTS appstore.plugin_definitions CASCADE;
-CREATE TABLE appstore.plugin_definitions (
-	id uuid NOT NULL DEFAULT uuidv7(),
-	plugin_id uuid NOT NULL,
-	plugin_version text NOT NULL,
-	manifest bytea NOT NULL,
-	hash text NOT NULL,
-	created timestamptz NOT NULL DEFAULT now(),
-	deleted timestamptz,
-	CONSTRAINT plugin_definitions_uq_plugin_version UNIQUE NULLS NOT DISTINCT (plugin_id,plugin_version,deleted),
-	CONSTRAINT plugin_definitions_pk PRIMARY KEY (id)
-);
+-- object: authz.outbox_notify_trigger | type: FUNCTION --
+-- DROP FUNCTION IF EXISTS authz.outbox_notify_trigger() CASCADE;
+CREATE OR REPLACE FUNCTION authz.outbox_notify_trigger ()
+	RETURNS trigger
+	LANGUAGE plpgsql
+	VOLATILE 
+	CALLED ON NULL INPUT
+	SECURITY INVOKER
+	PARALLEL UNSAFE
+	COST 1
+	AS 
+$function$
+BEGIN
+    PERFORM pg_notify('authz_outbox', '');
+    RETURN NEW;
+END;
+$function$;
 -- ddl-end --
-ALTER TABLE appstore.plugin_definitions OWNER TO fun_owner;
+ALTER FUNCTION authz.outbox_notify_trigger() OWNER TO fun_owner;
 -- ddl-end --
-ALTER TABLE appstore.plugin_definitions ENABLE ROW LEVEL SECURITY;
+
+-- object: project_members_outbox | type: TRIGGER --
+-- DROP TRIGGER IF EXISTS project_members_outbox ON tenant.project_members CASCADE;
+CREATE OR REPLACE TRIGGER project_members_outbox
+	AFTER INSERT OR UPDATE
+	ON tenant.project_members
+	FOR EACH ROW
+	EXECUTE PROCEDURE authz.project_members_sync_trigger();
 -- ddl-end --
 
--- object: plugin_definitions_select_all | type: POLICY --
--- DROP POLICY IF EXISTS plugin_definitions_select_all ON appstore.plugin_definitions CASCADE;
-CREATE POLICY plugin_definitions_select_all ON appstore.plugin_definitions
-	AS PERMISSIVE
-	FOR SELECT
-	TO fun_fundament_api
-	USING (true);
+-- object: projects_outbox | type: TRIGGER --
+-- DROP TRIGGER IF EXISTS projects_outbox ON tenant.projects CASCADE;
+CREATE OR REPLACE TRIGGER projects_outbox
+	AFTER INSERT OR UPDATE
+	ON tenant.projects
+	FOR EACH ROW
+	EXECUTE PROCEDURE authz.projects_sync_trigger();
 -- ddl-end --
 
--- object: plugin_definitions_insert_owner | type: POLICY --
--- DROP POLICY IF EXISTS plugin_definitions_insert_owner ON appstore.plugin_definitions CASCADE;
-CREATE POLICY plugin_definitions_insert_owner ON appstore.plugin_definitions
-	AS PERMISSIVE
-	FOR INSERT
-	TO fun_fundament_api
-	WITH CHECK (EXISTS (SELECT 1 FROM appstore.plugins WHERE appstore.plugins.id = appstore.plugin_definitions.plugin_id AND appstore.plugins.organization_id = authn.current_organization_id()));
+-- object: clusters_outbox | type: TRIGGER --
+-- DROP TRIGGER IF EXISTS clusters_outbox ON tenant.clusters CASCADE;
+CREATE OR REPLACE TRIGGER clusters_outbox
+	AFTER INSERT OR UPDATE
+	ON tenant.clusters
+	FOR EACH ROW
+	EXECUTE PROCEDURE authz.clusters_sync_trigger();
 -- ddl-end --
 
--- object: plugin_definitions_update_owner | type: POLICY --
--- DROP POLICY IF EXISTS plugin_definitions_update_owner ON appstore.plugin_definitions CASCADE;
-CREATE POLICY plugin_definitions_update_owner ON appstore.plugin_definitions
-	AS PERMISSIVE
-	FOR UPDATE
-	TO fun_fundament_api
-	USING (EXISTS (SELECT 1 FROM appstore.plugins WHERE appstore.plugins.id = appstore.plugin_definitions.plugin_id AND appstore.plugins.organization_id = authn.current_organization_id()));
+-- object: node_pools_outbox | type: TRIGGER --
+-- DROP TRIGGER IF EXISTS node_pools_outbox ON tenant.node_pools CASCADE;
+CREATE OR REPLACE TRIGGER node_pools_outbox
+	AFTER INSERT OR UPDATE
+	ON tenant.node_pools
+	FOR EACH ROW
+	EXECUTE PROCEDURE authz.node_pools_sync_trigger();
 -- ddl-end --
 
--- object: appstore.presets | type: TABLE --
--- DROP TABLE IF EXISTS appstore.presets CASCADE;
-CREATE TABLE appstore.presets (
-	id uuid NOT NULL DEFAULT uuidv7(),
-	name text NOT NULL,
-	description text,
-	CONSTRAINT presets_pk PRIMARY KEY (id),
-	CONSTRAINT presets_uq_name UNIQUE (name)
-);
+-- object: node_pool_outbox | type: TRIGGER --
+-- DROP TRIGGER IF EXISTS node_pool_outbox ON tenant.node_pools CASCADE;
+CREATE OR REPLACE TRIGGER node_pool_outbox
+	AFTER INSERT OR UPDATE OF name,machine_type,region_machine_type_id,autoscale_min,autoscale_max,labels,taints,zones,max_surge,max_unavailable,deleted
+	ON tenant.node_pools
+	FOR EACH ROW
+	EXECUTE PROCEDURE tenant.node_pool_outbox_trigger();
 -- ddl-end --
-ALTER TABLE appstore.presets OWNER TO fun_owner;
+
+-- object: region_match | type: TRIGGER --
+-- region_match ON tenant.node_pools CASCADE;
+CREATE CONSTRAINT TRIGGER region_match
+	AFTER INSERT OR UPDATE
+	ON tenant.node_pools
+	NOT DEFERRABLE 
+	FOR EACH ROW
+	EXECUTE PROCEDURE tenant.node_pool_region_match_trigger();
 -- ddl-end --
 
--- object: appstore.preset_plugins | type: TABLE --
--- DROP TABLE IF EXISTS appstore.preset_plugins CASCADE;
-CREATE TABLE appstore.preset_plugins (
-	preset_id uuid NOT NULL,
-	plugin_id uuid NOT NULL,
-	CONSTRAINT preset_plugins_pk PRIMARY KEY (preset_id,plugin_id)
-);
+-- object: namespace_outbox | type: TRIGGER --
+-- DROP TRIGGER IF EXISTS namespace_outbox ON tenant.namespaces CASCADE;
+CREATE OR REPLACE TRIGGER namespace_outbox
+	AFTER INSERT OR UPDATE OF name,deleted
+	ON tenant.namespaces
+	FOR EACH ROW
+	EXECUTE PROCEDURE tenant.namespace_outbox_trigger();
 -- ddl-end --
-ALTER TABLE appstore.preset_plugins OWNER TO fun_owner;
+
+-- object: organization_limits_outbox | type: TRIGGER --
+-- DROP TRIGGER IF EXISTS organization_limits_outbox ON tenant.organization_limits CASCADE;
+CREATE OR REPLACE TRIGGER organization_limits_outbox
+	AFTER INSERT OR UPDATE
+	ON tenant.organization_limits
+	FOR EACH ROW
+	EXECUTE PROCEDURE tenant.organization_limits_outbox_trigger();
 -- ddl-end --
 
--- object: appstore.tags | type: TABLE --
--- DROP TABLE IF EXISTS appstore.tags CASCADE;
-CREATE TABLE appstore.tags (
-	id uuid NOT NULL DEFAULT uuidv7(),
-	name text NOT NULL,
-	created timestamptz NOT NULL DEFAULT now(),
-	deleted timestamptz,
-	CONSTRAINT tags_uq_name UNIQUE NULLS NOT DISTINCT (name,deleted),
-	CONSTRAINT tags_pk PRIMARY KEY (id)
-);
+-- object: project_limits_outbox | type: TRIGGER --
+-- DROP TRIGGER IF EXISTS project_limits_outbox ON tenant.project_limits CASCADE;
+CREATE OR REPLACE TRIGGER project_limits_outbox
+	AFTER INSERT OR UPDATE
+	ON tenant.project_limits
+	FOR EACH ROW
+	EXECUTE PROCEDURE tenant.project_limits_outbox_trigger();
 -- ddl-end --
-ALTER TABLE appstore.tags OWNER TO fun_owner;
+
+-- object: namespace_limits_outbox | type: TRIGGER --
+-- DROP TRIGGER IF EXISTS namespace_limits_outbox ON tenant.namespace_limits CASCADE;
+CREATE OR REPLACE TRIGGER namespace_limits_outbox
+	AFTER INSERT OR UPDATE
+	ON tenant.namespace_limits
+	FOR EACH ROW
+	EXECUTE PROCEDURE tenant.namespace_limits_outbox_trigger();
 -- ddl-end --
 
--- object: appstore.plugins_tags | type: TABLE --
--- DROP TABLE IF EXISTS appstore.plugins_tags CASCADE;
-CREATE TABLE appstore.plugins_tags (
-	plugin_id uuid NOT NULL,
-	tag_id uuid NOT NULL,
-	CONSTRAINT plugins_tags_pk PRIMARY KEY (plugin_id,tag_id)
-);
+-- object: namespaces_idx_project_id | type: INDEX --
+-- DROP INDEX IF EXISTS tenant.namespaces_idx_project_id CASCADE;
+CREATE INDEX namespaces_idx_project_id ON tenant.namespaces
+USING btree
+(
+	project_id
+)
+WHERE (deleted IS NULL);
 -- ddl-end --
-ALTER TABLE appstore.plugins_tags OWNER TO fun_owner;
+
+-- object: namespaces_outbox | type: TRIGGER --
+-- DROP TRIGGER IF EXISTS namespaces_outbox ON tenant.namespaces CASCADE;
+CREATE OR REPLACE TRIGGER namespaces_outbox
+	AFTER INSERT OR UPDATE
+	ON tenant.namespaces
+	FOR EACH ROW
+	EXECUTE PROCEDURE authz.namespaces_sync_trigger();
 -- ddl-end --
 
--- object: appstore.categories | type: TABLE --
--- DROP TABLE IF EXISTS appstore.categories CASCADE;
-CREATE TABLE appstore.categories (
-	id uuid NOT NULL DEFAULT uuidv7(),
-	name text NOT NULL,
-	created timestamptz NOT NULL DEFAULT now(),
-	deleted timestamptz,
-	CONSTRAINT categories_uq_name UNIQUE NULLS NOT DISTINCT (name,deleted),
-	CONSTRAINT categories_pk PRIMARY KEY (id)
-);
--- ddl-end --
-ALTER TABLE appstore.categories OWNER TO fun_owner;
+-- object: api_keys_outbox | type: TRIGGER --
+-- DROP TRIGGER IF EXISTS api_keys_outbox ON authn.api_keys CASCADE;
+CREATE OR REPLACE TRIGGER api_keys_outbox
+	AFTER INSERT OR UPDATE
+	ON authn.api_keys
+	FOR EACH ROW
+	EXECUTE PROCEDURE authz.api_keys_sync_trigger();
 -- ddl-end --
 
--- object: appstore.categories_plugins | type: TABLE --
--- DROP TABLE IF EXISTS appstore.categories_plugins CASCADE;
-CREATE TABLE appstore.categories_plugins (
-	plugin_id uuid NOT NULL,
-	category_id uuid NOT NULL,
-	CONSTRAINT categories_plugins_pk PRIMARY KEY (plugin_id,category_id)
-);
+-- object: plugins_outbox | type: TRIGGER --
+-- DROP TRIGGER IF EXISTS plugins_outbox ON appstore.plugins CASCADE;
+CREATE OR REPLACE TRIGGER plugins_outbox
+	AFTER INSERT OR UPDATE
+	ON appstore.plugins
+	FOR EACH ROW
+	EXECUTE PROCEDURE authz.plugins_sync_trigger();
 -- ddl-end --
-ALTER TABLE appstore.categories_plugins OWNER TO fun_owner;
+
+-- object: outbox_notify | type: TRIGGER --
+-- DROP TRIGGER IF EXISTS outbox_notify ON authz.outbox CASCADE;
+CREATE OR REPLACE TRIGGER outbox_notify
+	AFTER INSERT 
+	ON authz.outbox
+	FOR EACH ROW
+	EXECUTE PROCEDURE authz.outbox_notify_trigger();
 -- ddl-end --
 
--- object: appstore.plugin_documentation_links | type: TABLE --
--- DROP TABLE IF EXISTS appstore.plugin_documentation_links CASCADE;
-CREATE TABLE appstore.plugin_documentation_links (
-	id uuid NOT NULL DEFAULT uuidv7(),
-	plugin_id uuid NOT NULL,
-	title text NOT NULL,
-	url_name text NOT NULL,
-	url text NOT NULL,
-	CONSTRAINT plugin_documentation_links_pk PRIMARY KEY (id)
-);
+-- object: cluster_outbox_cluster | type: TRIGGER --
+-- DROP TRIGGER IF EXISTS cluster_outbox_cluster ON tenant.clusters CASCADE;
+CREATE OR REPLACE TRIGGER cluster_outbox_cluster
+	AFTER INSERT OR UPDATE
+	ON tenant.clusters
+	FOR EACH ROW
+	EXECUTE PROCEDURE tenant.cluster_outbox_cluster_trigger();
 -- ddl-end --
-ALTER TABLE appstore.plugin_documentation_links OWNER TO fun_owner;
+
+-- object: cluster_hibernation_schedule_outbox | type: TRIGGER --
+-- DROP TRIGGER IF EXISTS cluster_hibernation_schedule_outbox ON tenant.cluster_hibernation_schedules CASCADE;
+CREATE OR REPLACE TRIGGER cluster_hibernation_schedule_outbox
+	AFTER INSERT OR DELETE OR UPDATE
+	ON tenant.cluster_hibernation_schedules
+	FOR EACH ROW
+	EXECUTE PROCEDURE tenant.cluster_hibernation_schedule_outbox_trigger();
 -- ddl-end --
 
--- object: require_admin | type: TRIGGER --
--- require_admin ON tenant.projects CASCADE;
-CREATE CONSTRAINT TRIGGER require_admin
+-- object: cluster_outbox_notify | type: TRIGGER --
+-- DROP TRIGGER IF EXISTS cluster_outbox_notify ON tenant.cluster_outbox CASCADE;
+CREATE OR REPLACE TRIGGER cluster_outbox_notify
 	AFTER INSERT 
-	ON tenant.projects
-	DEFERRABLE INITIALLY DEFERRED
+	ON tenant.cluster_outbox
 	FOR EACH ROW
-	EXECUTE PROCEDURE tenant.projects_tr_require_admin();
+	EXECUTE PROCEDURE tenant.cluster_outbox_notify();
 -- ddl-end --
 
--- object: verify_deleted | type: TRIGGER --
--- verify_deleted ON tenant.projects CASCADE;
-CREATE CONSTRAINT TRIGGER verify_deleted
-	AFTER UPDATE
-	ON tenant.projects
-	NOT DEFERRABLE 
+-- object: cluster_outbox_update_cluster_status | type: TRIGGER --
+-- DROP TRIGGER IF EXISTS cluster_outbox_update_cluster_status ON tenant.cluster_outbox CASCADE;
+CREATE OR REPLACE TRIGGER cluster_outbox_update_cluster_status
+	AFTER INSERT OR UPDATE
+	ON tenant.cluster_outbox
 	FOR EACH ROW
-	EXECUTE PROCEDURE tenant.projects_tr_verify_deleted();
+	EXECUTE PROCEDURE tenant.cluster_outbox_update_cluster_status();
 -- ddl-end --
 
--- object: tenant.project_members | type: TABLE --
--- DROP TABLE IF EXISTS tenant.project_members CASCADE;
-CREATE TABLE tenant.project_members (
+-- object: cluster_outbox_partition | type: TRIGGER --
+-- DROP TRIGGER IF EXISTS cluster_outbox_partition ON tenant.cluster_outbox CASCADE;
+CREATE OR REPLACE TRIGGER cluster_outbox_partition
+	BEFORE INSERT 
+	ON tenant.cluster_outbox
+	FOR EACH ROW
+	EXECUTE PROCEDURE tenant.cluster_outbox_partition_trigger();
+-- ddl-end --
+
+-- object: tenant.organizations_users | type: TABLE --
+-- DROP TABLE IF EXISTS tenant.organizations_users CASCADE;
+CREATE TABLE tenant.organizations_users (
 	id uuid NOT NULL DEFAULT uuidv7(),
-	project_id uuid NOT NULL,
+	organization_id uuid NOT NULL,
 	user_id uuid NOT NULL,
-	role text NOT NULL,
+	permission text NOT NULL,
+	status text NOT NULL,
 	created timestamptz NOT NULL DEFAULT now(),
 	deleted timestamptz,
-	CONSTRAINT project_members_pk PRIMARY KEY (id),
-	CONSTRAINT project_members_ck_role CHECK (role IN ('admin', 'viewer')),
-	CONSTRAINT project_members_uq_project_user UNIQUE NULLS NOT DISTINCT (project_id,user_id,deleted)
+	CONSTRAINT organizations_users_ck_permission CHECK (permission IN ('admin', 'viewer')),
+	CONSTRAINT organizations_users_pk PRIMARY KEY (id),
+	CONSTRAINT organizations_users_ck_status CHECK (status IN ('pending', 'accepted', 'declined', 'revoked'))
 );
 -- ddl-end --
-ALTER TABLE tenant.project_members OWNER TO fun_owner;
--- ddl-end --
-ALTER TABLE tenant.project_members ENABLE ROW LEVEL SECURITY;
--- ddl-end --
-
--- object: project_members_idx_project_id | type: INDEX --
--- DROP INDEX IF EXISTS tenant.project_members_idx_project_id CASCADE;
-CREATE INDEX project_members_idx_project_id ON tenant.project_members
-USING btree
-(
-	project_id
-);
+ALTER TABLE tenant.organizations_users OWNER TO fun_owner;
 -- ddl-end --
-
--- object: protect_last_admin | type: TRIGGER --
--- DROP TRIGGER IF EXISTS protect_last_admin ON tenant.project_members CASCADE;
-CREATE OR REPLACE TRIGGER protect_last_admin
-	BEFORE UPDATE
-	ON tenant.project_members
-	FOR EACH ROW
-	EXECUTE PROCEDURE tenant.project_members_tr_protect_last_admin();
+ALTER TABLE tenant.organizations_users ENABLE ROW LEVEL SECURITY;
 -- ddl-end --
 
--- object: project_members_organization_policy | type: POLICY --
--- DROP POLICY IF EXISTS project_members_organization_policy ON tenant.project_members CASCADE;
-CREATE POLICY project_members_organization_policy ON tenant.project_members
+-- object: organizations_users_organization_policy | type: POLICY --
+-- DROP POLICY IF EXISTS organizations_users_organization_policy ON tenant.organizations_users CASCADE;
+CREATE POLICY organizations_users_organization_policy ON tenant.organizations_users
 	AS PERMISSIVE
 	FOR ALL
 	TO fun_fundament_api
-	USING (authn.is_project_in_organization(project_id));
+	USING (organization_id = authn.current_organization_id());
 -- ddl-end --
 
--- object: project_members_authn_api_policy | type: POLICY --
--- DROP POLICY IF EXISTS project_members_authn_api_policy ON tenant.project_members CASCADE;
-CREATE POLICY project_members_authn_api_policy ON tenant.project_members
+-- object: organizations_users_user_select_policy | type: POLICY --
+-- DROP POLICY IF EXISTS organizations_users_user_select_policy ON tenant.organizations_users CASCADE;
+CREATE POLICY organizations_users_user_select_policy ON tenant.organizations_users
 	AS PERMISSIVE
 	FOR SELECT
-	TO fun_authn_api
-	USING (true);
+	TO fun_fundament_api
+	USING (user_id = authn.current_user_id());
 -- ddl-end --
 
--- object: projects_organization_policy | type: POLICY --
--- DROP POLICY IF EXISTS projects_organization_policy ON tenant.projects CASCADE;
-CREATE POLICY projects_organization_policy ON tenant.projects
+-- object: organizations_users_user_update_policy | type: POLICY --
+-- DROP POLICY IF EXISTS organizations_users_user_update_policy ON tenant.organizations_users CASCADE;
+CREATE POLICY organizations_users_user_update_policy ON tenant.organizations_users
 	AS PERMISSIVE
-	FOR ALL
+	FOR UPDATE
 	TO fun_fundament_api
-	USING (authn.is_cluster_in_organization(cluster_id));
+	USING (user_id = authn.current_user_id());
 -- ddl-end --
 
--- object: projects_cluster_worker_policy | type: POLICY --
--- DROP POLICY IF EXISTS projects_cluster_worker_policy ON tenant.projects CASCADE;
-CREATE POLICY projects_cluster_worker_policy ON tenant.projects
+-- object: organizations_users_authn_api_policy | type: POLICY --
+-- DROP POLICY IF EXISTS organizations_users_authn_api_policy ON tenant.organizations_users CASCADE;
+CREATE POLICY organizations_users_authn_api_policy ON tenant.organizations_users
 	AS PERMISSIVE
-	FOR SELECT
-	TO fun_cluster_worker
+	FOR ALL
+	TO fun_authn_api
 	USING (true);
 -- ddl-end --
 
--- object: projects_authn_api_policy | type: POLICY --
--- DROP POLICY IF EXISTS projects_authn_api_policy ON tenant.projects CASCADE;
-CREATE POLICY projects_authn_api_policy ON tenant.projects
+-- object: organizations_users_authz_worker_policy | type: POLICY --
+-- DROP POLICY IF EXISTS organizations_users_authz_worker_policy ON tenant.organizations_users CASCADE;
+CREATE POLICY organizations_users_authz_worker_policy ON tenant.organizations_users
 	AS PERMISSIVE
 	FOR SELECT
-	TO fun_authn_api
+	TO fun_authz_worker
 	USING (true);
 -- ddl-end --
 
--- object: namespaces_organization_policy | type: POLICY --
--- DROP POLICY IF EXISTS namespaces_organization_policy ON tenant.namespaces CASCADE;
-CREATE POLICY namespaces_organization_policy ON tenant.namespaces
-	AS PERMISSIVE
-	FOR ALL
-	TO fun_fundament_api
-	USING (authn.is_project_in_organization(project_id));
+-- object: organizations_users_outbox | type: TRIGGER --
+-- DROP TRIGGER IF EXISTS organizations_users_outbox ON tenant.organizations_users CASCADE;
+CREATE OR REPLACE TRIGGER organizations_users_outbox
+	AFTER INSERT OR UPDATE
+	ON tenant.organizations_users
+	FOR EACH ROW
+	EXECUTE PROCEDURE authz.organizations_users_sync_trigger();
 -- ddl-end --
 
--- object: namespaces_cluster_worker_policy | type: POLICY --
--- DROP POLICY IF EXISTS namespaces_cluster_worker_policy ON tenant.namespaces CASCADE;
-CREATE POLICY namespaces_cluster_worker_policy ON tenant.namespaces
+-- object: users_select_policy | type: POLICY --
+-- DROP POLICY IF EXISTS users_select_policy ON tenant.users CASCADE;
+CREATE POLICY users_select_policy ON tenant.users
 	AS PERMISSIVE
 	FOR SELECT
-	TO fun_cluster_worker
-	USING (true);
--- ddl-end --
-
--- object: organizations_organization_policy | type: POLICY --
--- DROP POLICY IF EXISTS organizations_organization_policy ON tenant.organizations CASCADE;
-CREATE POLICY organizations_organization_policy ON tenant.organizations
-	AS PERMISSIVE
-	FOR ALL
 	TO fun_fundament_api
-	USING (id = authn.current_organization_id());
+	USING (true);
 -- ddl-end --
 
--- object: organizations_user_select_policy | type: POLICY --
--- DROP POLICY IF EXISTS organizations_user_select_policy ON tenant.organizations CASCADE;
-CREATE POLICY organizations_user_select_policy ON tenant.organizations
+-- object: users_insert_policy | type: POLICY --
+-- DROP POLICY IF EXISTS users_insert_policy ON tenant.users CASCADE;
+CREATE POLICY users_insert_policy ON tenant.users
 	AS PERMISSIVE
-	FOR SELECT
+	FOR INSERT
 	TO fun_fundament_api
-	USING (authn.is_organization_member(id));
+	WITH CHECK (true);
 -- ddl-end --
 
--- object: organizations_authn_api_policy | type: POLICY --
--- DROP POLICY IF EXISTS organizations_authn_api_policy ON tenant.organizations CASCADE;
-CREATE POLICY organizations_authn_api_policy ON tenant.organizations
+-- object: users_authn_api_policy | type: POLICY --
+-- DROP POLICY IF EXISTS users_authn_api_policy ON tenant.users CASCADE;
+CREATE POLICY users_authn_api_policy ON tenant.users
 	AS PERMISSIVE
 	FOR ALL
 	TO fun_authn_api
 	USING (true);
 -- ddl-end --
 
--- object: organizations_cluster_worker_policy | type: POLICY --
--- DROP POLICY IF EXISTS organizations_cluster_worker_policy ON tenant.organizations CASCADE;
-CREATE POLICY organizations_cluster_worker_policy ON tenant.organizations
-	AS PERMISSIVE
-	FOR SELECT
-	TO fun_cluster_worker
-	USING (true);
--- ddl-end --
-
--- object: organization_limits_organization_policy | type: POLICY --
--- DROP POLICY IF EXISTS organization_limits_organization_policy ON tenant.organization_limits CASCADE;
-CREATE POLICY organization_limits_organization_policy ON tenant.organization_limits
-	AS PERMISSIVE
-	FOR ALL
-	TO fun_fundament_api
-	USING (organization_id = authn.current_organization_id());
--- ddl-end --
-
--- object: project_limits_project_policy | type: POLICY --
--- DROP POLICY IF EXISTS project_limits_project_policy ON tenant.project_limits CASCADE;
-CREATE POLICY project_limits_project_policy ON tenant.project_limits
-	AS PERMISSIVE
-	FOR ALL
-	TO fun_fundament_api
-	USING (authn.is_project_in_organization(project_id));
+-- object: organizations_users_uq_user | type: INDEX --
+-- DROP INDEX IF EXISTS tenant.organizations_users_uq_user CASCADE;
+CREATE UNIQUE INDEX organizations_users_uq_user ON tenant.organizations_users
+USING btree
+(
+	organization_id,
+	user_id
+)
+WHERE (deleted IS NULL AND status NOT IN ('declined', 'revoked'));
 -- ddl-end --
 
--- object: organization_limits_cluster_worker_read | type: POLICY --
--- DROP POLICY IF EXISTS organization_limits_cluster_worker_read ON tenant.organization_limits CASCADE;
-CREATE POLICY organization_limits_cluster_worker_read ON tenant.organization_limits
+-- object: organizations_users_cluster_worker_policy | type: POLICY --
+-- DROP POLICY IF EXISTS organizations_users_cluster_worker_policy ON tenant.organizations_users CASCADE;
+CREATE POLICY organizations_users_cluster_worker_policy ON tenant.organizations_users
 	AS PERMISSIVE
 	FOR SELECT
 	TO fun_cluster_worker
 	USING (true);
 -- ddl-end --
 
--- object: project_limits_cluster_worker_read | type: POLICY --
--- DROP POLICY IF EXISTS project_limits_cluster_worker_read ON tenant.project_limits CASCADE;
-CREATE POLICY project_limits_cluster_worker_read ON tenant.project_limits
+-- object: project_members_cluster_worker_policy | type: POLICY --
+-- DROP POLICY IF EXISTS project_members_cluster_worker_policy ON tenant.project_members CASCADE;
+CREATE POLICY project_members_cluster_worker_policy ON tenant.project_members
 	AS PERMISSIVE
 	FOR SELECT
 	TO fun_cluster_worker
 	USING (true);
 -- ddl-end --
 
--- object: tenant.cluster_events | type: TABLE --
--- DROP TABLE IF EXISTS tenant.cluster_events CASCADE;
-CREATE TABLE tenant.cluster_events (
-	id uuid NOT NULL DEFAULT uuidv7(),
-	cluster_id uuid NOT NULL,
-	event_type text NOT NULL,
-	created timestamptz NOT NULL DEFAULT now(),
-	sync_action text,
-	message text,
-	attempt integer,
-	CONSTRAINT cluster_events_pk PRIMARY KEY (id),
-	CONSTRAINT cluster_events_ck_event_type CHECK (event_type IN ('sync_requested','sync_claimed','sync_succeeded','sync_failed','status_progressing','status_ready','status_error','status_deleted','user_sync_succeeded','user_sync_failed')),
-	CONSTRAINT cluster_events_ck_sync_action CHECK (sync_action IN ('sync','delete'))
-);
--- ddl-end --
-ALTER TABLE tenant.cluster_events OWNER TO fun_owner;
--- ddl-end --
-ALTER TABLE tenant.cluster_events ENABLE ROW LEVEL SECURITY;
--- ddl-end --
-
--- object: cluster_events_worker_all_access | type: POLICY --
--- DROP POLICY IF EXISTS cluster_events_worker_all_access ON tenant.cluster_events CASCADE;
-CREATE POLICY cluster_events_worker_all_access ON tenant.cluster_events
+-- object: users_cluster_worker_policy | type: POLICY --
+-- DROP POLICY IF EXISTS users_cluster_worker_policy ON tenant.users CASCADE;
+CREATE POLICY users_cluster_worker_policy ON tenant.users
 	AS PERMISSIVE
-	FOR ALL
+	FOR SELECT
 	TO fun_cluster_worker
 	USING (true);
 -- ddl-end --
 
--- object: cluster_events_organization_isolation | type: POLICY --
--- DROP POLICY IF EXISTS cluster_events_organization_isolation ON tenant.cluster_events CASCADE;
-CREATE POLICY cluster_events_organization_isolation ON tenant.cluster_events
-	AS PERMISSIVE
-	FOR ALL
-	TO fun_fundament_api
-	USING (EXISTS (
-    SELECT 1 FROM tenant.clusters c
-    WHERE c.id = cluster_events.cluster_id
-    AND c.organization_id = authn.current_organization_id()
-));
+-- object: cluster_outbox_organization_user | type: TRIGGER --
+-- DROP TRIGGER IF EXISTS cluster_outbox_organization_user ON tenant.organizations_users CASCADE;
+CREATE OR REPLACE TRIGGER cluster_outbox_organization_user
+	AFTER INSERT OR UPDATE
+	ON tenant.organizations_users
+	FOR EACH ROW
+	EXECUTE PROCEDURE tenant.cluster_outbox_organization_user_trigger();
 -- ddl-end --
 
--- object: cluster_events_idx_cluster_created | type: INDEX --
--- DROP INDEX IF EXISTS tenant.cluster_events_idx_cluster_created CASCADE;
-CREATE INDEX cluster_events_idx_cluster_created ON tenant.cluster_events
-USING btree
-(
-	cluster_id DESC NULLS LAST,
-	created DESC NULLS LAST
-);
+-- object: cluster_outbox_project_member | type: TRIGGER --
+-- DROP TRIGGER IF EXISTS cluster_outbox_project_member ON tenant.project_members CASCADE;
+CREATE OR REPLACE TRIGGER cluster_outbox_project_member
+	AFTER INSERT OR UPDATE
+	ON tenant.project_members
+	FOR EACH ROW
+	EXECUTE PROCEDURE tenant.cluster_outbox_project_member_trigger();
 -- ddl-end --
 
--- object: tenant.cluster_outbox | type: TABLE --
--- DROP TABLE IF EXISTS tenant.cluster_outbox CASCADE;
-CREATE TABLE tenant.cluster_outbox (
+-- object: tenant.idempotency_keys | type: TABLE --
+-- DROP TABLE IF EXISTS tenant.idempotency_keys CASCADE;
+CREATE TABLE tenant.idempotency_keys (
 	id uuid NOT NULL DEFAULT uuidv7(),
-	cluster_id uuid,
-	organization_user_id uuid,
+	idempotency_key uuid NOT NULL,
+	user_id uuid NOT NULL,
+	project_id uuid,
 	project_member_id uuid,
+	cluster_id uuid,
 	node_pool_id uuid,
 	namespace_id uuid,
-	event text NOT NULL DEFAULT 'updated',
-	source text NOT NULL DEFAULT 'trigger',
-	status text NOT NULL DEFAULT 'pending',
-	retries integer NOT NULL DEFAULT 0,
-	retry_after timestamptz,
-	processed timestamptz,
-	failed timestamptz,
-	status_info text,
+	api_key_id uuid,
+	organization_user_id uuid,
+	procedure text NOT NULL,
+	request_hash bytea NOT NULL,
+	response_bytes bytea,
 	created timestamptz NOT NULL DEFAULT now(),
-	deferrals integer NOT NULL DEFAULT 0,
-	CONSTRAINT cluster_outbox_pk PRIMARY KEY (id),
-	CONSTRAINT cluster_outbox_ck_single_fk CHECK (num_nonnulls(cluster_id, organization_user_id, project_member_id, node_pool_id, namespace_id) = 1),
-	CONSTRAINT cluster_outbox_ck_status CHECK (status IN ('pending', 'completed', 'retrying', 'failed')),
-	CONSTRAINT cluster_outbox_ck_event CHECK (event IN ('created', 'updated', 'deleted', 'reconcile', 'ready')),
-	CONSTRAINT cluster_outbox_ck_source CHECK (source IN ('trigger', 'reconcile', 'manual', 'status'))
+	expires timestamptz NOT NULL,
+	CONSTRAINT idempotency_keys_pk PRIMARY KEY (id),
+	CONSTRAINT idempotency_keys_uq_key_user UNIQUE (idempotency_key,user_id),
+	CONSTRAINT idempotency_keys_ck_single_fk CHECK (num_nonnulls(
+	project_id,
+	project_member_id,
+	cluster_id,
+	node_pool_id,
+	namespace_id,
+	api_key_id,
+	organization_user_id
+) <= 1)
 );
 -- ddl-end --
-ALTER TABLE tenant.cluster_outbox OWNER TO fun_owner;
+ALTER TABLE tenant.idempotency_keys OWNER TO fun_owner;
+-- ddl-end --
+ALTER TABLE tenant.idempotency_keys ENABLE ROW LEVEL SECURITY;
 -- ddl-end --
 
--- object: cluster_outbox_status_retry_idx | type: INDEX --
--- DROP INDEX IF EXISTS tenant.cluster_outbox_status_retry_idx CASCADE;
-CREATE INDEX cluster_outbox_status_retry_idx ON tenant.cluster_outbox
+-- object: idempotency_keys_idx_expires | type: INDEX --
+-- DROP INDEX IF EXISTS tenant.idempotency_keys_idx_expires CASCADE;
+CREATE INDEX idempotency_keys_idx_expires ON tenant.idempotency_keys
 USING btree
 (
-	status,
-	retry_after,
-	id
+	expires
 );
 -- ddl-end --
 
--- object: cluster_outbox_idx_cluster_id | type: INDEX --
--- DROP INDEX IF EXISTS tenant.cluster_outbox_idx_cluster_id CASCADE;
-CREATE INDEX cluster_outbox_idx_cluster_id ON tenant.cluster_outbox
-USING btree
-(
-	cluster_id,
-	id DESC NULLS LAST
-);
+-- object: idempotency_keys_fundament_api_policy | type: POLICY --
+-- DROP POLICY IF EXISTS idempotency_keys_fundament_api_policy ON tenant.idempotency_keys CASCADE;
+CREATE POLICY idempotency_keys_fundament_api_policy ON tenant.idempotency_keys
+	AS PERMISSIVE
+	FOR ALL
+	TO fun_fundament_api
+	USING (user_id = authn.current_user_id());
 -- ddl-end --
 
--- object: cluster_outbox_idx_node_pool_id | type: INDEX --
--- DROP INDEX IF EXISTS tenant.cluster_outbox_idx_node_pool_id CASCADE;
-CREATE INDEX cluster_outbox_idx_node_pool_id ON tenant.cluster_outbox
-USING btree
-(
-	node_pool_id,
-	id DESC NULLS LAST
-);
+-- object: idempotency_keys_cleanup_policy | type: POLICY --
+-- DROP POLICY IF EXISTS idempotency_keys_cleanup_policy ON tenant.idempotency_keys CASCADE;
+CREATE POLICY idempotency_keys_cleanup_policy ON tenant.idempotency_keys
+	AS PERMISSIVE
+	FOR DELETE
+	TO fun_fundament_api
+	USING (expires < now());
 -- ddl-end --
 
--- object: cluster_outbox_idx_namespace_id | type: INDEX --
--- DROP INDEX IF EXISTS tenant.cluster_outbox_idx_namespace_id CASCADE;
-CREATE INDEX cluster_outbox_idx_namespace_id ON tenant.cluster_outbox
-USING btree
-(
-	namespace_id,
-	id DESC NULLS LAST
+-- object: tenant.audit_events | type: TABLE --
+-- DROP TABLE IF EXISTS tenant.audit_events CASCADE;
+CREATE TABLE tenant.audit_events (
+	id uuid NOT NULL DEFAULT uuidv7(),
+	source text NOT NULL,
+	organization_id uuid,
+	cluster_id uuid,
+	actor_user_id uuid,
+	action text NOT NULL,
+	resource_type text,
+	resource_id text,
+	outcome text NOT NULL,
+	error_code text,
+	details jsonb,
+	remote_addr text,
+	user_agent text,
+	created timestamptz NOT NULL DEFAULT now(),
+	CONSTRAINT audit_events_pk PRIMARY KEY (id),
+	CONSTRAINT audit_events_ck_source CHECK (source IN ('organization_api','dcim_api','kube_api_proxy')),
+	CONSTRAINT audit_events_ck_outcome CHECK (outcome IN ('succeeded','failed','denied'))
 );
 -- ddl-end --
-
--- object: cluster_outbox_uq_ns_reconcile | type: INDEX --
--- DROP INDEX IF EXISTS tenant.cluster_outbox_uq_ns_reconcile CASCADE;
-CREATE UNIQUE INDEX cluster_outbox_uq_ns_reconcile ON tenant.cluster_outbox
-USING btree
-(
-	namespace_id
-)
-WHERE (source = 'reconcile' AND status IN ('pending', 'retrying'));
+COMMENT ON TABLE tenant.audit_events IS E'Append-only audit log of mutating API calls and Kubernetes API requests. Deliberately has no foreign keys: events must outlive the users, clusters and organizations they mention.';
+-- ddl-end --
+COMMENT ON COLUMN tenant.audit_events.organization_id IS E'Organization the event belongs to; filled in from cluster_id on insert when the writer only knows the cluster. NULL for dcim-api events, which are not tied to an organization.';
+-- ddl-end --
+COMMENT ON COLUMN tenant.audit_events.actor_user_id IS E'tenant.users.id for organization-api and kube-api-proxy events, dcim.users.id for dcim-api events.';
+-- ddl-end --
+COMMENT ON COLUMN tenant.audit_events.action IS E'Connect procedure (e.g. /organization.v1.ClusterService/DeleteCluster) or Kubernetes verb (e.g. kube:delete).';
+-- ddl-end --
+COMMENT ON COLUMN tenant.audit_events.error_code IS E'Connect error code or HTTP status of a failed or denied request.';
+-- ddl-end --
+ALTER TABLE tenant.audit_events OWNER TO fun_owner;
+-- ddl-end --
+ALTER TABLE tenant.audit_events ENABLE ROW LEVEL SECURITY;
 -- ddl-end --
 
--- object: node_pools_idx_cluster_id | type: INDEX --
--- DROP INDEX IF EXISTS tenant.node_pools_idx_cluster_id CASCADE;
-CREATE INDEX node_pools_idx_cluster_id ON tenant.node_pools
+-- object: audit_events_idx_organization_created | type: INDEX --
+-- DROP INDEX IF EXISTS tenant.audit_events_idx_organization_created CASCADE;
+CREATE INDEX audit_events_idx_organization_created ON tenant.audit_events
 USING btree
 (
-	cluster_id
+	organization_id,
+	created DESC NULLS LAST,
+	id DESC NULLS LAST
 );
 -- ddl-end --
 
--- object: authz.outbox | type: TABLE --
--- DROP TABLE IF EXISTS authz.outbox CASCADE;
-CREATE TABLE authz.outbox (
-	id uuid NOT NULL DEFAULT uuidv7(),
-	project_id uuid,
-	project_member_id uuid,
-	cluster_id uuid,
-	node_pool_id uuid,
-	namespace_id uuid,
-	api_key_id uuid,
-	organization_user_id uuid,
-	plugin_id uuid,
-	created timestamptz NOT NULL DEFAULT now(),
-	processed timestamptz,
-	retries integer NOT NULL DEFAULT 0,
-	retry_after timestamptz,
-	failed timestamptz,
-	status text NOT NULL DEFAULT 'pending',
-	status_info text,
-	CONSTRAINT outbox_pk PRIMARY KEY (id),
-	CONSTRAINT outbox_ck_single_fk CHECK (num_nonnulls(
-	project_id,
-	project_member_id,
-	cluster_id,
-	node_pool_id,
-	namespace_id,
-	api_key_id,
-	organization_user_id,
-	plugin_id
-) = 1),
-	CONSTRAINT outbox_ck_status CHECK (status IN ('pending', 'completed', 'retrying', 'failed'))
+-- object: audit_events_idx_actor_created | type: INDEX --
+-- DROP INDEX IF EXISTS tenant.audit_events_idx_actor_created CASCADE;
+CREATE INDEX audit_events_idx_actor_created ON tenant.audit_events
+USING btree
+(
+	actor_user_id,
+	created DESC NULLS LAST
 );
 -- ddl-end --
-ALTER TABLE authz.outbox OWNER TO fun_owner;
--- ddl-end --
 
--- object: outbox_idx_unprocessed | type: INDEX --
--- DROP INDEX IF EXISTS authz.outbox_idx_unprocessed CASCADE;
-CREATE INDEX outbox_idx_unprocessed ON authz.outbox
+-- object: audit_events_idx_resource | type: INDEX --
+-- DROP INDEX IF EXISTS tenant.audit_events_idx_resource CASCADE;
+CREATE INDEX audit_events_idx_resource ON tenant.audit_events
 USING btree
 (
-	created
-)
-WHERE (processed IS NULL);
--- ddl-end --
-
--- object: authz.projects_sync_trigger | type: FUNCTION --
--- DROP FUNCTION IF EXISTS authz.projects_sync_trigger() CASCADE;
-CREATE OR REPLACE FUNCTION authz.projects_sync_trigger ()
-	RETURNS trigger
-	LANGUAGE plpgsql
-	VOLATILE 
-	CALLED ON NULL INPUT
-	SECURITY INVOKER
-	PARALLEL UNSAFE
-	COST 1
-	AS 
-$function$
-BEGIN
-    -- Only insert into outbox if this is an INSERT or if data actually changed
-    IF TG_OP = 'INSERT' OR NEW IS DISTINCT FROM OLD THEN
-        INSERT INTO authz.outbox (project_id)
-        VALUES (COALESCE(NEW.id, OLD.id));
-    END IF;
-    RETURN COALESCE(NEW, OLD);
-END;
-$function$;
+	resource_id
+);
 -- ddl-end --
-ALTER FUNCTION authz.projects_sync_trigger() OWNER TO fun_owner;
+
+-- object: audit_events_api_insert | type: POLICY --
+-- DROP POLICY IF EXISTS audit_events_api_insert ON tenant.audit_events CASCADE;
+CREATE POLICY audit_events_api_insert ON tenant.audit_events
+	AS PERMISSIVE
+	FOR INSERT
+	TO fun_fundament_api
+	WITH CHECK (source = 'organization_api');
 -- ddl-end --
 
--- object: authz.project_members_sync_trigger | type: FUNCTION --
--- DROP FUNCTION IF EXISTS authz.project_members_sync_trigger() CASCADE;
-CREATE OR REPLACE FUNCTION authz.project_members_sync_trigger ()
-	RETURNS trigger
-	LANGUAGE plpgsql
-	VOLATILE 
-	CALLED ON NULL INPUT
-	SECURITY INVOKER
-	PARALLEL UNSAFE
-	COST 1
-	AS 
-$function$
-BEGIN
-    -- Only insert into outbox if this is an INSERT or if data actually changed
-    IF TG_OP = 'INSERT' OR NEW IS DISTINCT FROM OLD THEN
-        INSERT INTO authz.outbox (project_member_id)
-        VALUES (COALESCE(NEW.id, OLD.id));
-    END IF;
-    RETURN COALESCE(NEW, OLD);
-END;
-$function$;
+-- object: audit_events_organization_isolation | type: POLICY --
+-- DROP POLICY IF EXISTS audit_events_organization_isolation ON tenant.audit_events CASCADE;
+CREATE POLICY audit_events_organization_isolation ON tenant.audit_events
+	AS PERMISSIVE
+	FOR SELECT
+	TO fun_fundament_api
+	USING (organization_id = authn.current_organization_id());
 -- ddl-end --
-ALTER FUNCTION authz.project_members_sync_trigger() OWNER TO fun_owner;
+
+-- object: audit_events_dcim_insert | type: POLICY --
+-- DROP POLICY IF EXISTS audit_events_dcim_insert ON tenant.audit_events CASCADE;
+CREATE POLICY audit_events_dcim_insert ON tenant.audit_events
+	AS PERMISSIVE
+	FOR INSERT
+	TO fun_dcim_api
+	WITH CHECK (source = 'dcim_api');
 -- ddl-end --
 
--- object: authz.clusters_sync_trigger | type: FUNCTION --
--- DROP FUNCTION IF EXISTS authz.clusters_sync_trigger() CASCADE;
-CREATE OR REPLACE FUNCTION authz.clusters_sync_trigger ()
-	RETURNS trigger
-	LANGUAGE plpgsql
-	VOLATILE 
-	CALLED ON NULL INPUT
-	SECURITY INVOKER
-	PARALLEL UNSAFE
-	COST 1
-	AS 
-$function$
-BEGIN
-    -- Only insert into outbox if this is an INSERT or if data actually changed
-    IF TG_OP = 'INSERT' OR NEW IS DISTINCT FROM OLD THEN
-        INSERT INTO authz.outbox (cluster_id)
-        VALUES (COALESCE(NEW.id, OLD.id));
-    END IF;
-    RETURN COALESCE(NEW, OLD);
-END;
-$function$;
+-- object: audit_events_kube_api_proxy_insert | type: POLICY --
+-- DROP POLICY IF EXISTS audit_events_kube_api_proxy_insert ON tenant.audit_events CASCADE;
+CREATE POLICY audit_events_kube_api_proxy_insert ON tenant.audit_events
+	AS PERMISSIVE
+	FOR INSERT
+	TO fun_kube_api_proxy
+	WITH CHECK (source = 'kube_api_proxy');
 -- ddl-end --
-ALTER FUNCTION authz.clusters_sync_trigger() OWNER TO fun_owner;
+
+-- object: audit_events_organization | type: TRIGGER --
+-- DROP TRIGGER IF EXISTS audit_events_organization ON tenant.audit_events CASCADE;
+CREATE OR REPLACE TRIGGER audit_events_organization
+	BEFORE INSERT 
+	ON tenant.audit_events
+	FOR EACH ROW
+	EXECUTE PROCEDURE tenant.audit_events_organization_trigger();
 -- ddl-end --
 
--- object: authz.node_pools_sync_trigger | type: FUNCTION --
--- DROP FUNCTION IF EXISTS authz.node_pools_sync_trigger() CASCADE;
-CREATE OR REPLACE FUNCTION authz.node_pools_sync_trigger ()
-	RETURNS trigger
-	LANGUAGE plpgsql
-	VOLATILE 
-	CALLED ON NULL INPUT
-	SECURITY INVOKER
-	PARALLEL UNSAFE
-	COST 1
-	AS 
-$function$
-BEGIN
-    -- Only insert into outbox if this is an INSERT or if data actually changed
-    IF TG_OP = 'INSERT' OR NEW IS DISTINCT FROM OLD THEN
-        INSERT INTO authz.outbox (node_pool_id)
-        VALUES (COALESCE(NEW.id, OLD.id));
-    END IF;
-    RETURN COALESCE(NEW, OLD);
-END;
-$function$;
+-- object: audit_events_append_only | type: TRIGGER --
+-- DROP TRIGGER IF EXISTS audit_events_append_only ON tenant.audit_events CASCADE;
+CREATE OR REPLACE TRIGGER audit_events_append_only
+	BEFORE DELETE OR UPDATE
+	ON tenant.audit_events
+	FOR EACH ROW
+	EXECUTE PROCEDURE tenant.audit_events_append_only_trigger();
 -- ddl-end --
-ALTER FUNCTION authz.node_pools_sync_trigger() OWNER TO fun_owner;
+
+-- object: audit_events_append_only_truncate | type: TRIGGER --
+-- DROP TRIGGER IF EXISTS audit_events_append_only_truncate ON tenant.audit_events CASCADE;
+CREATE OR REPLACE TRIGGER audit_events_append_only_truncate
+	BEFORE TRUNCATE 
+	ON tenant.audit_events
+	FOR EACH STATEMENT
+	EXECUTE PROCEDURE tenant.audit_events_append_only_trigger();
 -- ddl-end --
 
--- object: authz.namespaces_sync_trigger | type: FUNCTION --
--- DROP FUNCTION IF EXISTS authz.namespaces_sync_trigger() CASCADE;
-CREATE OR REPLACE FUNCTION authz.namespaces_sync_trigger ()
-	RETURNS trigger
-	LANGUAGE plpgsql
-	VOLATILE 
-	CALLED ON NULL INPUT
-	SECURITY INVOKER
-	PARALLEL UNSAFE
-	COST 1
-	AS 
-$function$
-BEGIN
-    -- Only insert into outbox if this is an INSERT or if data actually changed
-    IF TG_OP = 'INSERT' OR NEW IS DISTINCT FROM OLD THEN
-        INSERT INTO authz.outbox (namespace_id)
-        VALUES (COALESCE(NEW.id, OLD.id));
-    END IF;
-    RETURN COALESCE(NEW, OLD);
-END;
-$function$;
+-- object: tenant.webhooks | type: TABLE --
+-- DROP TABLE IF EXISTS tenant.webhooks CASCADE;
+CREATE TABLE tenant.webhooks (
+	id uuid NOT NULL DEFAULT uuidv7(),
+	organization_id uuid NOT NULL,
+	name text NOT NULL,
+	url text NOT NULL,
+	secret text NOT NULL,
+	event_types text[] NOT NULL DEFAULT '{}',
+	enabled boolean NOT NULL DEFAULT true,
+	created timestamptz NOT NULL DEFAULT now(),
+	deleted timestamptz,
+	CONSTRAINT webhooks_pk PRIMARY KEY (id),
+	CONSTRAINT webhooks_uq_name UNIQUE NULLS NOT DISTINCT (organization_id,name,deleted),
+	CONSTRAINT webhooks_ck_event_types CHECK (event_types <@ ARRAY['cluster_ready','cluster_error','cluster_deleted','cluster_hibernated','cluster_sync_failed','cluster_upgrade_completed','cluster_upgrade_failed','plugin_ready','plugin_degraded','plugin_failed','member_invited']::text[])
+);
 -- ddl-end --
-ALTER FUNCTION authz.namespaces_sync_trigger() OWNER TO fun_owner;
+COMMENT ON TABLE tenant.webhooks IS E'Endpoints an organization registered to receive platform events. cluster-worker POSTs each event in tenant.webhook_deliveries to url, signed with secret.';
+-- ddl-end --
+COMMENT ON COLUMN tenant.webhooks.secret IS E'HMAC-SHA256 key for the Fundament-Signature header. Only returned when the webhook is created.';
+-- ddl-end --
+COMMENT ON COLUMN tenant.webhooks.event_types IS E'Event types delivered to the endpoint; empty means every type.';
+-- ddl-end --
+ALTER TABLE tenant.webhooks OWNER TO fun_owner;
+-- ddl-end --
+ALTER TABLE tenant.webhooks ENABLE ROW LEVEL SECURITY;
 -- ddl-end --
 
--- object: authz.api_keys_sync_trigger | type: FUNCTION --
--- DROP FUNCTION IF EXISTS authz.api_keys_sync_trigger() CASCADE;
-CREATE OR REPLACE FUNCTION authz.api_keys_sync_trigger ()
-	RETURNS trigger
-	LANGUAGE plpgsql
-	VOLATILE 
-	CALLED ON NULL INPUT
-	SECURITY INVOKER
-	PARALLEL UNSAFE
-	COST 1
-	AS 
-$function$
-BEGIN
-    -- Only insert into outbox if this is an INSERT, DELETE, or if data actually changed
-    IF TG_OP = 'INSERT' OR NEW IS DISTINCT FROM OLD THEN
-        INSERT INTO authz.outbox (api_key_id)
-        VALUES (COALESCE(NEW.id, OLD.id));
-    END IF;
-    RETURN COALESCE(NEW, OLD);
-END;
-$function$;
+-- object: webhooks_organization_isolation | type: POLICY --
+-- DROP POLICY IF EXISTS webhooks_organization_isolation ON tenant.webhooks CASCADE;
+CREATE POLICY webhooks_organization_isolation ON tenant.webhooks
+	AS PERMISSIVE
+	FOR ALL
+	TO fun_fundament_api
+	USING (organization_id = authn.current_organization_id());
 -- ddl-end --
-ALTER FUNCTION authz.api_keys_sync_trigger() OWNER TO fun_owner;
+
+-- object: webhooks_worker_read | type: POLICY --
+-- DROP POLICY IF EXISTS webhooks_worker_read ON tenant.webhooks CASCADE;
+CREATE POLICY webhooks_worker_read ON tenant.webhooks
+	AS PERMISSIVE
+	FOR SELECT
+	TO fun_cluster_worker
+	USING (true);
 -- ddl-end --
 
--- object: authz.plugins_sync_trigger | type: FUNCTION --
--- DROP FUNCTION IF EXISTS authz.plugins_sync_trigger() CASCADE;
-CREATE OR REPLACE FUNCTION authz.plugins_sync_trigger ()
-	RETURNS trigger
-	LANGUAGE plpgsql
-	VOLATILE 
-	CALLED ON NULL INPUT
-	SECURITY INVOKER
-	PARALLEL UNSAFE
-	COST 1
-	AS 
-$function$
-BEGIN
-    -- Only insert into outbox if this is an INSERT, DELETE, or if data actually changed
-    IF TG_OP = 'INSERT' OR NEW IS DISTINCT FROM OLD THEN
-        INSERT INTO authz.outbox (plugin_id)
-        VALUES (COALESCE(NEW.id, OLD.id));
-    END IF;
-    RETURN COALESCE(NEW, OLD);
-END;
-$function$;
+-- object: tenant.webhook_deliveries | type: TABLE --
+-- DROP TABLE IF EXISTS tenant.webhook_deliveries CASCADE;
+CREATE TABLE tenant.webhook_deliveries (
+	id uuid NOT NULL DEFAULT uuidv7(),
+	webhook_id uuid NOT NULL,
+	event_type text NOT NULL,
+	payload jsonb NOT NULL,
+	status text NOT NULL DEFAULT 'pending',
+	attempts integer NOT NULL DEFAULT 0,
+	retry_after timestamptz,
+	response_status integer,
+	status_info text,
+	created timestamptz NOT NULL DEFAULT now(),
+	completed timestamptz,
+	CONSTRAINT webhook_deliveries_pk PRIMARY KEY (id),
+	CONSTRAINT webhook_deliveries_ck_status CHECK (status IN ('pending','retrying','succeeded','failed')),
+	CONSTRAINT webhook_deliveries_ck_event_type CHECK (event_type IN ('cluster_ready','cluster_error','cluster_deleted','cluster_hibernated','cluster_sync_failed','cluster_upgrade_completed','cluster_upgrade_failed','plugin_ready','plugin_degraded','plugin_failed','member_invited','ping'))
+);
 -- ddl-end --
-ALTER FUNCTION authz.plugins_sync_trigger() OWNER TO fun_owner;
+COMMENT ON TABLE tenant.webhook_deliveries IS E'Outbox of webhook events, one row per event and endpoint. Rows are written by triggers on the tables the events come from, so an event is queued in the same transaction that caused it.';
+-- ddl-end --
+COMMENT ON COLUMN tenant.webhook_deliveries.payload IS E'Request body, sent as is.';
+-- ddl-end --
+COMMENT ON COLUMN tenant.webhook_deliveries.response_status IS E'HTTP status of the last attempt; NULL when the endpoint could not be reached.';
+-- ddl-end --
+COMMENT ON COLUMN tenant.webhook_deliveries.status_info IS E'Error of the last failed attempt.';
+-- ddl-end --
+ALTER TABLE tenant.webhook_deliveries OWNER TO fun_owner;
+-- ddl-end --
+ALTER TABLE tenant.webhook_deliveries ENABLE ROW LEVEL SECURITY;
 -- ddl-end --
 
--- object: authz.organizations_users_sync_trigger | type: FUNCTION --
--- DROP FUNCTION IF EXISTS authz.organizations_users_sync_trigger() CASCADE;
-CREATE OR REPLACE FUNCTION authz.organizations_users_sync_trigger ()
-	RETURNS trigger
-	LANGUAGE plpgsql
-	VOLATILE 
-	CALLED ON NULL INPUT
-	SECURITY INVOKER
-	PARALLEL UNSAFE
-	COST 1
-	AS 
-$function$
-BEGIN
-    -- Only insert into outbox if this is an INSERT or if data actually changed
-    IF TG_OP = 'INSERT' OR NEW IS DISTINCT FROM OLD THEN
-        INSERT INTO authz.outbox (organization_user_id)
-        VALUES (COALESCE(NEW.id, OLD.id));
-    END IF;
-    RETURN COALESCE(NEW, OLD);
-END;
-$function$;
+-- object: webhook_deliveries_idx_pending | type: INDEX --
+-- DROP INDEX IF EXISTS tenant.webhook_deliveries_idx_pending CASCADE;
+CREATE INDEX webhook_deliveries_idx_pending ON tenant.webhook_deliveries
+USING btree
+(
+	id
+)
+WHERE (status IN ('pending', 'retrying'));
 -- ddl-end --
-ALTER FUNCTION authz.organizations_users_sync_trigger() OWNER TO fun_owner;
+
+-- object: webhook_deliveries_idx_webhook_created | type: INDEX --
+-- DROP INDEX IF EXISTS tenant.webhook_deliveries_idx_webhook_created CASCADE;
+CREATE INDEX webhook_deliveries_idx_webhook_created ON tenant.webhook_deliveries
+USING btree
+(
+	webhook_id,
+	created DESC NULLS LAST,
+	id DESC NULLS LAST
+);
 -- ddl-end --
 
--- object: authz.outbox_notify_trigger | type: FUNCTION --
--- DROP FUNCTION IF EXISTS authz.outbox_notify_trigger() CASCADE;
-CREATE OR REPLACE FUNCTION authz.outbox_notify_trigger ()
-	RETURNS trigger
-	LANGUAGE plpgsql
-	VOLATILE 
-	CALLED ON NULL INPUT
-	SECURITY INVOKER
-	PARALLEL UNSAFE
-	COST 1
-	AS 
-$function$
-BEGIN
-    PERFORM pg_notify('authz_outbox', '');
-    RETURN NEW;
-END;
-$function$;
+-- object: webhook_deliveries_organization_isolation | type: POLICY --
+-- DROP POLICY IF EXISTS webhook_deliveries_organization_isolation ON tenant.webhook_deliveries CASCADE;
+CREATE POLICY webhook_deliveries_organization_isolation ON tenant.webhook_deliveries
+	AS PERMISSIVE
+	FOR ALL
+	TO fun_fundament_api
+	USING (EXISTS (
+    SELECT 1 FROM tenant.webhooks w
+    WHERE w.id = webhook_deliveries.webhook_id
+    AND w.organization_id = authn.current_organization_id()
+));
 -- ddl-end --
-ALTER FUNCTION authz.outbox_notify_trigger() OWNER TO fun_owner;
+
+-- object: webhook_deliveries_worker_all_access | type: POLICY --
+-- DROP POLICY IF EXISTS webhook_deliveries_worker_all_access ON tenant.webhook_deliveries CASCADE;
+CREATE POLICY webhook_deliveries_worker_all_access ON tenant.webhook_deliveries
+	AS PERMISSIVE
+	FOR ALL
+	TO fun_cluster_worker
+	USING (true);
 -- ddl-end --
 
--- object: project_members_outbox | type: TRIGGER --
--- DROP TRIGGER IF EXISTS project_members_outbox ON tenant.project_members CASCADE;
-CREATE OR REPLACE TRIGGER project_members_outbox
-	AFTER INSERT OR UPDATE
-	ON tenant.project_members
-	FOR EACH ROW
-	EXECUTE PROCEDURE authz.project_members_sync_trigger();
+-- object: tenant.cluster_plugin_status | type: TABLE --
+-- DROP TABLE IF EXISTS tenant.cluster_plugin_status CASCADE;
+CREATE TABLE tenant.cluster_plugin_status (
+	cluster_id uuid NOT NULL,
+	name text NOT NULL,
+	phase text NOT NULL,
+	message text,
+	updated timestamptz NOT NULL DEFAULT now(),
+	CONSTRAINT cluster_plugin_status_pk PRIMARY KEY (cluster_id,name)
+);
+-- ddl-end --
+COMMENT ON TABLE tenant.cluster_plugin_status IS E'Last PluginInstallation phase cluster-worker observed per cluster. Phase changes are fanned out to webhooks.';
+-- ddl-end --
+COMMENT ON COLUMN tenant.cluster_plugin_status.name IS E'PluginInstallation metadata.name.';
+-- ddl-end --
+ALTER TABLE tenant.cluster_plugin_status OWNER TO fun_owner;
+-- ddl-end --
+ALTER TABLE tenant.cluster_plugin_status ENABLE ROW LEVEL SECURITY;
 -- ddl-end --
 
--- object: projects_outbox | type: TRIGGER --
--- DROP TRIGGER IF EXISTS projects_outbox ON tenant.projects CASCADE;
-CREATE OR REPLACE TRIGGER projects_outbox
-	AFTER INSERT OR UPDATE
-	ON tenant.projects
-	FOR EACH ROW
-	EXECUTE PROCEDURE authz.projects_sync_trigger();
+-- object: cluster_plugin_status_worker_all_access | type: POLICY --
+-- DROP POLICY IF EXISTS cluster_plugin_status_worker_all_access ON tenant.cluster_plugin_status CASCADE;
+CREATE POLICY cluster_plugin_status_worker_all_access ON tenant.cluster_plugin_status
+	AS PERMISSIVE
+	FOR ALL
+	TO fun_cluster_worker
+	USING (true);
 -- ddl-end --
 
--- object: clusters_outbox | type: TRIGGER --
--- DROP TRIGGER IF EXISTS clusters_outbox ON tenant.clusters CASCADE;
-CREATE OR REPLACE TRIGGER clusters_outbox
-	AFTER INSERT OR UPDATE
-	ON tenant.clusters
+-- object: cluster_events_webhook | type: TRIGGER --
+-- DROP TRIGGER IF EXISTS cluster_events_webhook ON tenant.cluster_events CASCADE;
+CREATE OR REPLACE TRIGGER cluster_events_webhook
+	AFTER INSERT 
+	ON tenant.cluster_events
 	FOR EACH ROW
-	EXECUTE PROCEDURE authz.clusters_sync_trigger();
+	EXECUTE PROCEDURE tenant.cluster_events_webhook_trigger();
 -- ddl-end --
 
--- object: node_pools_outbox | type: TRIGGER --
--- DROP TRIGGER IF EXISTS node_pools_outbox ON tenant.node_pools CASCADE;
-CREATE OR REPLACE TRIGGER node_pools_outbox
+-- object: cluster_plugin_status_webhook | type: TRIGGER --
+-- DROP TRIGGER IF EXISTS cluster_plugin_status_webhook ON tenant.cluster_plugin_status CASCADE;
+CREATE OR REPLACE TRIGGER cluster_plugin_status_webhook
 	AFTER INSERT OR UPDATE
-	ON tenant.node_pools
+	ON tenant.cluster_plugin_status
 	FOR EACH ROW
-	EXECUTE PROCEDURE authz.node_pools_sync_trigger();
+	EXECUTE PROCEDURE tenant.cluster_plugin_status_webhook_trigger();
 -- ddl-end --
 
--- object: node_pool_outbox | type: TRIGGER --
--- DROP TRIGGER IF EXISTS node_pool_outbox ON tenant.node_pools CASCADE;
-CREATE OR REPLACE TRIGGER node_pool_outbox
-	AFTER INSERT OR UPDATE OF name,machine_type,region_machine_type_id,autoscale_min,autoscale_max,deleted
-	ON tenant.node_pools
+-- object: organizations_users_webhook | type: TRIGGER --
+-- DROP TRIGGER IF EXISTS organizations_users_webhook ON tenant.organizations_users CASCADE;
+CREATE OR REPLACE TRIGGER organizations_users_webhook
+	AFTER INSERT 
+	ON tenant.organizations_users
 	FOR EACH ROW
-	EXECUTE PROCEDURE tenant.node_pool_outbox_trigger();
+	EXECUTE PROCEDURE tenant.organizations_users_webhook_trigger();
 -- ddl-end --
 
--- object: region_match | type: TRIGGER --
--- region_match ON tenant.node_pools CASCADE;
-CREATE CONSTRAINT TRIGGER region_match
-	AFTER INSERT OR UPDATE
-	ON tenant.node_pools
-	NOT DEFERRABLE 
+-- object: webhook_deliveries_notify | type: TRIGGER --
+-- DROP TRIGGER IF EXISTS webhook_deliveries_notify ON tenant.webhook_deliveries CASCADE;
+CREATE OR REPLACE TRIGGER webhook_deliveries_notify
+	AFTER INSERT 
+	ON tenant.webhook_deliveries
 	FOR EACH ROW
-	EXECUTE PROCEDURE tenant.node_pool_region_match_trigger();
+	EXECUTE PROCEDURE tenant.webhook_deliveries_notify();
 -- ddl-end --
 
--- object: namespace_outbox | type: TRIGGER --
--- DROP TRIGGER IF EXISTS namespace_outbox ON tenant.namespaces CASCADE;
-CREATE OR REPLACE TRIGGER namespace_outbox
-	AFTER INSERT OR UPDATE OF name,deleted
-	ON tenant.namespaces
-	FOR EACH ROW
-	EXECUTE PROCEDURE tenant.namespace_outbox_trigger();
+-- object: tenant.plugin_definition_approvals | type: TABLE --
+-- DROP TABLE IF EXISTS tenant.plugin_definition_approvals CASCADE;
+CREATE TABLE tenant.plugin_definition_approvals (
+	id uuid NOT NULL DEFAULT uuidv7(),
+	organization_id uuid NOT NULL,
+	plugin_definition_id uuid NOT NULL,
+	user_id uuid NOT NULL,
+	created timestamptz NOT NULL DEFAULT now(),
+	CONSTRAINT plugin_definition_approvals_pk PRIMARY KEY (id),
+	CONSTRAINT plugin_definition_approvals_uq_definition UNIQUE (organization_id,plugin_definition_id)
+);
+-- ddl-end --
+COMMENT ON TABLE tenant.plugin_definition_approvals IS E'Plugin definitions an organization admin approved as upgrade targets. plugin-controller does not roll a PluginInstallation over to a definition that adds permissions until it is approved here.';
+-- ddl-end --
+COMMENT ON COLUMN tenant.plugin_definition_approvals.user_id IS E'The admin who approved the definition.';
+-- ddl-end --
+ALTER TABLE tenant.plugin_definition_approvals OWNER TO fun_owner;
+-- ddl-end --
+ALTER TABLE tenant.plugin_definition_approvals ENABLE ROW LEVEL SECURITY;
 -- ddl-end --
 
--- object: organization_limits_outbox | type: TRIGGER --
--- DROP TRIGGER IF EXISTS organization_limits_outbox ON tenant.organization_limits CASCADE;
-CREATE OR REPLACE TRIGGER organization_limits_outbox
-	AFTER INSERT OR UPDATE
-	ON tenant.organization_limits
-	FOR EACH ROW
-	EXECUTE PROCEDURE tenant.organization_limits_outbox_trigger();
+-- object: plugin_definition_approvals_organization_isolation | type: POLICY --
+-- DROP POLICY IF EXISTS plugin_definition_approvals_organization_isolation ON tenant.plugin_definition_approvals CASCADE;
+CREATE POLICY plugin_definition_approvals_organization_isolation ON tenant.plugin_definition_approvals
+	AS PERMISSIVE
+	FOR ALL
+	TO fun_fundament_api
+	USING (organization_id = authn.current_organization_id());
 -- ddl-end --
 
--- object: project_limits_outbox | type: TRIGGER --
--- DROP TRIGGER IF EXISTS project_limits_outbox ON tenant.project_limits CASCADE;
-CREATE OR REPLACE TRIGGER project_limits_outbox
-	AFTER INSERT OR UPDATE
-	ON tenant.project_limits
-	FOR EACH ROW
-	EXECUTE PROCEDURE tenant.project_limits_outbox_trigger();
+-- object: tenant.namespace_role_bindings | type: TABLE --
+-- DROP TABLE IF EXISTS tenant.namespace_role_bindings CASCADE;
+CREATE TABLE tenant.namespace_role_bindings (
+	id uuid NOT NULL DEFAULT uuidv7(),
+	project_id uuid NOT NULL,
+	project_member_id uuid NOT NULL,
+	namespace_pattern text NOT NULL,
+	role text NOT NULL,
+	created timestamptz NOT NULL DEFAULT now(),
+	deleted timestamptz,
+	CONSTRAINT namespace_role_bindings_pk PRIMARY KEY (id),
+	CONSTRAINT namespace_role_bindings_ck_role CHECK (role IN ('deploy', 'view_pods', 'view_logs', 'manage_services')),
+	CONSTRAINT namespace_role_bindings_ck_namespace_pattern CHECK (namespace_pattern ~ '^[a-z0-9*][-a-z0-9*]{0,62}$'),
+	CONSTRAINT namespace_role_bindings_uq_binding UNIQUE NULLS NOT DISTINCT (project_member_id,namespace_pattern,role,deleted)
+);
+-- ddl-end --
+COMMENT ON TABLE tenant.namespace_role_bindings IS E'Kubernetes roles a project member holds in the project\'s namespaces. A binding applies to every namespace of the project whose name matches namespace_pattern.';
+-- ddl-end --
+COMMENT ON COLUMN tenant.namespace_role_bindings.namespace_pattern IS E'A namespace name, or a pattern where * matches any run of characters (staging-*, *).';
+-- ddl-end --
+ALTER TABLE tenant.namespace_role_bindings OWNER TO fun_owner;
+-- ddl-end --
+ALTER TABLE tenant.namespace_role_bindings ENABLE ROW LEVEL SECURITY;
 -- ddl-end --
 
--- object: namespaces_idx_project_id | type: INDEX --
--- DROP INDEX IF EXISTS tenant.namespaces_idx_project_id CASCADE;
-CREATE INDEX namespaces_idx_project_id ON tenant.namespaces
+-- object: namespace_role_bindings_idx_project_id | type: INDEX --
+-- DROP INDEX IF EXISTS tenant.namespace_role_bindings_idx_project_id CASCADE;
+CREATE INDEX namespace_role_bindings_idx_project_id ON tenant.namespace_role_bindings
 USING btree
 (
 	project_id
@@ -1937,287 +3958,387 @@ USING btree
 WHERE (deleted IS NULL);
 -- ddl-end --
 
--- object: namespaces_outbox | type: TRIGGER --
--- DROP TRIGGER IF EXISTS namespaces_outbox ON tenant.namespaces CASCADE;
-CREATE OR REPLACE TRIGGER namespaces_outbox
-	AFTER INSERT OR UPDATE
-	ON tenant.namespaces
-	FOR EACH ROW
-	EXECUTE PROCEDURE authz.namespaces_sync_trigger();
+-- object: namespace_role_bindings_organization_policy | type: POLICY --
+-- DROP POLICY IF EXISTS namespace_role_bindings_organization_policy ON tenant.namespace_role_bindings CASCADE;
+CREATE POLICY namespace_role_bindings_organization_policy ON tenant.namespace_role_bindings
+	AS PERMISSIVE
+	FOR ALL
+	TO fun_fundament_api
+	USING (authn.is_project_in_organization(project_id));
 -- ddl-end --
 
--- object: api_keys_outbox | type: TRIGGER --
--- DROP TRIGGER IF EXISTS api_keys_outbox ON authn.api_keys CASCADE;
-CREATE OR REPLACE TRIGGER api_keys_outbox
+-- object: namespace_role_bindings_cluster_worker_policy | type: POLICY --
+-- DROP POLICY IF EXISTS namespace_role_bindings_cluster_worker_policy ON tenant.namespace_role_bindings CASCADE;
+CREATE POLICY namespace_role_bindings_cluster_worker_policy ON tenant.namespace_role_bindings
+	AS PERMISSIVE
+	FOR SELECT
+	TO fun_cluster_worker
+	USING (true);
+-- ddl-end --
+
+-- object: namespace_role_bindings_outbox | type: TRIGGER --
+-- DROP TRIGGER IF EXISTS namespace_role_bindings_outbox ON tenant.namespace_role_bindings CASCADE;
+CREATE OR REPLACE TRIGGER namespace_role_bindings_outbox
 	AFTER INSERT OR UPDATE
-	ON authn.api_keys
+	ON tenant.namespace_role_bindings
 	FOR EACH ROW
-	EXECUTE PROCEDURE authz.api_keys_sync_trigger();
+	EXECUTE PROCEDURE authz.namespace_role_bindings_sync_trigger();
 -- ddl-end --
 
--- object: plugins_outbox | type: TRIGGER --
--- DROP TRIGGER IF EXISTS plugins_outbox ON appstore.plugins CASCADE;
-CREATE OR REPLACE TRIGGER plugins_outbox
+-- object: namespace_role_binding_outbox | type: TRIGGER --
+-- DROP TRIGGER IF EXISTS namespace_role_binding_outbox ON tenant.namespace_role_bindings CASCADE;
+CREATE OR REPLACE TRIGGER namespace_role_binding_outbox
 	AFTER INSERT OR UPDATE
-	ON appstore.plugins
+	ON tenant.namespace_role_bindings
 	FOR EACH ROW
-	EXECUTE PROCEDURE authz.plugins_sync_trigger();
+	EXECUTE PROCEDURE tenant.namespace_role_binding_outbox_trigger();
 -- ddl-end --
 
--- object: outbox_notify | type: TRIGGER --
--- DROP TRIGGER IF EXISTS outbox_notify ON authz.outbox CASCADE;
-CREATE OR REPLACE TRIGGER outbox_notify
-	AFTER INSERT 
-	ON authz.outbox
-	FOR EACH ROW
-	EXECUTE PROCEDURE authz.outbox_notify_trigger();
+-- object: tenant.teams | type: TABLE --
+-- DROP TABLE IF EXISTS tenant.teams CASCADE;
+CREATE TABLE tenant.teams (
+	id uuid NOT NULL DEFAULT uuidv7(),
+	organization_id uuid NOT NULL,
+	name text NOT NULL,
+	permission text,
+	oidc_group text,
+	created timestamptz NOT NULL DEFAULT now(),
+	deleted timestamptz,
+	CONSTRAINT teams_pk PRIMARY KEY (id),
+	CONSTRAINT teams_ck_name CHECK (name ~ '^[a-z][a-z0-9-]*[a-z0-9]$'),
+	CONSTRAINT teams_ck_permission CHECK (permission IN ('admin', 'viewer')),
+	CONSTRAINT teams_uq_organization_name UNIQUE NULLS NOT DISTINCT (organization_id,name,deleted)
+);
+-- ddl-end --
+COMMENT ON TABLE tenant.teams IS E'Groups of organization members that are granted roles as a whole.';
+-- ddl-end --
+COMMENT ON COLUMN tenant.teams.permission IS E'Organization role of every team member, or NULL when the team only has project roles.';
+-- ddl-end --
+COMMENT ON COLUMN tenant.teams.oidc_group IS E'OIDC group whose members join the team when they log in and leave it when the group is no longer in their claims.';
+-- ddl-end --
+ALTER TABLE tenant.teams OWNER TO fun_owner;
+-- ddl-end --
+ALTER TABLE tenant.teams ENABLE ROW LEVEL SECURITY;
 -- ddl-end --
 
--- object: cluster_outbox_cluster | type: TRIGGER --
--- DROP TRIGGER IF EXISTS cluster_outbox_cluster ON tenant.clusters CASCADE;
-CREATE OR REPLACE TRIGGER cluster_outbox_cluster
-	AFTER INSERT OR UPDATE
-	ON tenant.clusters
-	FOR EACH ROW
-	EXECUTE PROCEDURE tenant.cluster_outbox_cluster_trigger();
+-- object: teams_idx_oidc_group | type: INDEX --
+-- DROP INDEX IF EXISTS tenant.teams_idx_oidc_group CASCADE;
+CREATE INDEX teams_idx_oidc_group ON tenant.teams
+USING btree
+(
+	oidc_group
+)
+WHERE ((oidc_group IS NOT NULL) AND (deleted IS NULL));
 -- ddl-end --
 
--- object: cluster_outbox_notify | type: TRIGGER --
--- DROP TRIGGER IF EXISTS cluster_outbox_notify ON tenant.cluster_outbox CASCADE;
-CREATE OR REPLACE TRIGGER cluster_outbox_notify
-	AFTER INSERT 
-	ON tenant.cluster_outbox
-	FOR EACH ROW
-	EXECUTE PROCEDURE tenant.cluster_outbox_notify();
+-- object: teams_organization_policy | type: POLICY --
+-- DROP POLICY IF EXISTS teams_organization_policy ON tenant.teams CASCADE;
+CREATE POLICY teams_organization_policy ON tenant.teams
+	AS PERMISSIVE
+	FOR ALL
+	TO fun_fundament_api
+	USING (organization_id = authn.current_organization_id());
 -- ddl-end --
 
--- object: cluster_outbox_update_cluster_status | type: TRIGGER --
--- DROP TRIGGER IF EXISTS cluster_outbox_update_cluster_status ON tenant.cluster_outbox CASCADE;
-CREATE OR REPLACE TRIGGER cluster_outbox_update_cluster_status
+-- object: teams_authn_api_policy | type: POLICY --
+-- DROP POLICY IF EXISTS teams_authn_api_policy ON tenant.teams CASCADE;
+CREATE POLICY teams_authn_api_policy ON tenant.teams
+	AS PERMISSIVE
+	FOR ALL
+	TO fun_authn_api
+	USING (true);
+-- ddl-end --
+
+-- object: teams_cluster_worker_policy | type: POLICY --
+-- DROP POLICY IF EXISTS teams_cluster_worker_policy ON tenant.teams CASCADE;
+CREATE POLICY teams_cluster_worker_policy ON tenant.teams
+	AS PERMISSIVE
+	FOR SELECT
+	TO fun_cluster_worker
+	USING (true);
+-- ddl-end --
+
+-- object: teams_outbox | type: TRIGGER --
+-- DROP TRIGGER IF EXISTS teams_outbox ON tenant.teams CASCADE;
+CREATE OR REPLACE TRIGGER teams_outbox
 	AFTER INSERT OR UPDATE
-	ON tenant.cluster_outbox
+	ON tenant.teams
 	FOR EACH ROW
-	EXECUTE PROCEDURE tenant.cluster_outbox_update_cluster_status();
+	EXECUTE PROCEDURE authz.teams_sync_trigger();
 -- ddl-end --
 
--- object: tenant.organizations_users | type: TABLE --
--- DROP TABLE IF EXISTS tenant.organizations_users CASCADE;
-CREATE TABLE tenant.organizations_users (
+-- object: cluster_outbox_team | type: TRIGGER --
+-- DROP TRIGGER IF EXISTS cluster_outbox_team ON tenant.teams CASCADE;
+CREATE OR REPLACE TRIGGER cluster_outbox_team
+	AFTER UPDATE
+	ON tenant.teams
+	FOR EACH ROW
+	EXECUTE PROCEDURE tenant.cluster_outbox_team_trigger();
+-- ddl-end --
+
+-- object: tenant.team_members | type: TABLE --
+-- DROP TABLE IF EXISTS tenant.team_members CASCADE;
+CREATE TABLE tenant.team_members (
 	id uuid NOT NULL DEFAULT uuidv7(),
-	organization_id uuid NOT NULL,
+	team_id uuid NOT NULL,
 	user_id uuid NOT NULL,
-	permission text NOT NULL,
-	status text NOT NULL,
+	source text NOT NULL DEFAULT 'manual',
 	created timestamptz NOT NULL DEFAULT now(),
 	deleted timestamptz,
-	CONSTRAINT organizations_users_ck_permission CHECK (permission IN ('admin', 'viewer')),
-	CONSTRAINT organizations_users_pk PRIMARY KEY (id),
-	CONSTRAINT organizations_users_ck_status CHECK (status IN ('pending', 'accepted', 'declined', 'revoked'))
+	CONSTRAINT team_members_pk PRIMARY KEY (id),
+	CONSTRAINT team_members_ck_source CHECK (source IN ('manual', 'oidc', 'scim')),
+	CONSTRAINT team_members_uq_team_user UNIQUE NULLS NOT DISTINCT (team_id,user_id,deleted)
 );
 -- ddl-end --
-ALTER TABLE tenant.organizations_users OWNER TO fun_owner;
+COMMENT ON COLUMN tenant.team_members.source IS E'manual when added through the API, oidc when derived from the user\'s groups claim, scim when pushed by the identity provider. Only oidc memberships are removed on login.';
 -- ddl-end --
-ALTER TABLE tenant.organizations_users ENABLE ROW LEVEL SECURITY;
+ALTER TABLE tenant.team_members OWNER TO fun_owner;
 -- ddl-end --
-
--- object: organizations_users_organization_policy | type: POLICY --
--- DROP POLICY IF EXISTS organizations_users_organization_policy ON tenant.organizations_users CASCADE;
-CREATE POLICY organizations_users_organization_policy ON tenant.organizations_users
-	AS PERMISSIVE
-	FOR ALL
-	TO fun_fundament_api
-	USING (organization_id = authn.current_organization_id());
+ALTER TABLE tenant.team_members ENABLE ROW LEVEL SECURITY;
 -- ddl-end --
 
--- object: organizations_users_user_select_policy | type: POLICY --
--- DROP POLICY IF EXISTS organizations_users_user_select_policy ON tenant.organizations_users CASCADE;
-CREATE POLICY organizations_users_user_select_policy ON tenant.organizations_users
-	AS PERMISSIVE
-	FOR SELECT
-	TO fun_fundament_api
-	USING (user_id = authn.current_user_id());
+-- object: team_members_idx_user_id | type: INDEX --
+-- DROP INDEX IF EXISTS tenant.team_members_idx_user_id CASCADE;
+CREATE INDEX team_members_idx_user_id ON tenant.team_members
+USING btree
+(
+	user_id
+)
+WHERE (deleted IS NULL);
 -- ddl-end --
 
--- object: organizations_users_user_update_policy | type: POLICY --
--- DROP POLICY IF EXISTS organizations_users_user_update_policy ON tenant.organizations_users CASCADE;
-CREATE POLICY organizations_users_user_update_policy ON tenant.organizations_users
+-- object: team_members_organization_policy | type: POLICY --
+-- DROP POLICY IF EXISTS team_members_organization_policy ON tenant.team_members CASCADE;
+CREATE POLICY team_members_organization_policy ON tenant.team_members
 	AS PERMISSIVE
-	FOR UPDATE
+	FOR ALL
 	TO fun_fundament_api
-	USING (user_id = authn.current_user_id());
+	USING (EXISTS (SELECT 1 FROM tenant.teams WHERE teams.id = team_members.team_id AND teams.organization_id = authn.current_organization_id()));
 -- ddl-end --
 
--- object: organizations_users_authn_api_policy | type: POLICY --
--- DROP POLICY IF EXISTS organizations_users_authn_api_policy ON tenant.organizations_users CASCADE;
-CREATE POLICY organizations_users_authn_api_policy ON tenant.organizations_users
+-- object: team_members_authn_api_policy | type: POLICY --
+-- DROP POLICY IF EXISTS team_members_authn_api_policy ON tenant.team_members CASCADE;
+CREATE POLICY team_members_authn_api_policy ON tenant.team_members
 	AS PERMISSIVE
 	FOR ALL
 	TO fun_authn_api
 	USING (true);
 -- ddl-end --
 
--- object: organizations_users_authz_worker_policy | type: POLICY --
--- DROP POLICY IF EXISTS organizations_users_authz_worker_policy ON tenant.organizations_users CASCADE;
-CREATE POLICY organizations_users_authz_worker_policy ON tenant.organizations_users
+-- object: team_members_cluster_worker_policy | type: POLICY --
+-- DROP POLICY IF EXISTS team_members_cluster_worker_policy ON tenant.team_members CASCADE;
+CREATE POLICY team_members_cluster_worker_policy ON tenant.team_members
 	AS PERMISSIVE
 	FOR SELECT
-	TO fun_authz_worker
+	TO fun_cluster_worker
 	USING (true);
 -- ddl-end --
 
--- object: organizations_users_outbox | type: TRIGGER --
--- DROP TRIGGER IF EXISTS organizations_users_outbox ON tenant.organizations_users CASCADE;
-CREATE OR REPLACE TRIGGER organizations_users_outbox
+-- object: team_members_outbox | type: TRIGGER --
+-- DROP TRIGGER IF EXISTS team_members_outbox ON tenant.team_members CASCADE;
+CREATE OR REPLACE TRIGGER team_members_outbox
 	AFTER INSERT OR UPDATE
-	ON tenant.organizations_users
+	ON tenant.team_members
 	FOR EACH ROW
-	EXECUTE PROCEDURE authz.organizations_users_sync_trigger();
+	EXECUTE PROCEDURE authz.team_members_sync_trigger();
 -- ddl-end --
 
--- object: users_select_policy | type: POLICY --
--- DROP POLICY IF EXISTS users_select_policy ON tenant.users CASCADE;
-CREATE POLICY users_select_policy ON tenant.users
-	AS PERMISSIVE
-	FOR SELECT
-	TO fun_fundament_api
-	USING (true);
+-- object: cluster_outbox_team_member | type: TRIGGER --
+-- DROP TRIGGER IF EXISTS cluster_outbox_team_member ON tenant.team_members CASCADE;
+CREATE OR REPLACE TRIGGER cluster_outbox_team_member
+	AFTER INSERT OR UPDATE
+	ON tenant.team_members
+	FOR EACH ROW
+	EXECUTE PROCEDURE tenant.cluster_outbox_team_member_trigger();
 -- ddl-end --
 
--- object: users_insert_policy | type: POLICY --
--- DROP POLICY IF EXISTS users_insert_policy ON tenant.users CASCADE;
-CREATE POLICY users_insert_policy ON tenant.users
-	AS PERMISSIVE
-	FOR INSERT
-	TO fun_fundament_api
-	WITH CHECK (true);
+-- object: tenant.project_teams | type: TABLE --
+-- DROP TABLE IF EXISTS tenant.project_teams CASCADE;
+CREATE TABLE tenant.project_teams (
+	id uuid NOT NULL DEFAULT uuidv7(),
+	project_id uuid NOT NULL,
+	team_id uuid NOT NULL,
+	role text NOT NULL,
+	created timestamptz NOT NULL DEFAULT now(),
+	deleted timestamptz,
+	CONSTRAINT project_teams_pk PRIMARY KEY (id),
+	CONSTRAINT project_teams_ck_role CHECK (role IN ('admin', 'viewer')),
+	CONSTRAINT project_teams_uq_project_team UNIQUE NULLS NOT DISTINCT (project_id,team_id,deleted)
+);
 -- ddl-end --
-
--- object: users_authn_api_policy | type: POLICY --
--- DROP POLICY IF EXISTS users_authn_api_policy ON tenant.users CASCADE;
-CREATE POLICY users_authn_api_policy ON tenant.users
-	AS PERMISSIVE
-	FOR ALL
-	TO fun_authn_api
-	USING (true);
+COMMENT ON TABLE tenant.project_teams IS E'Project roles granted to every member of a team.';
+-- ddl-end --
+ALTER TABLE tenant.project_teams OWNER TO fun_owner;
+-- ddl-end --
+ALTER TABLE tenant.project_teams ENABLE ROW LEVEL SECURITY;
 -- ddl-end --
 
--- object: organizations_users_uq_user | type: INDEX --
--- DROP INDEX IF EXISTS tenant.organizations_users_uq_user CASCADE;
-CREATE UNIQUE INDEX organizations_users_uq_user ON tenant.organizations_users
+-- object: project_teams_idx_team_id | type: INDEX --
+-- DROP INDEX IF EXISTS tenant.project_teams_idx_team_id CASCADE;
+CREATE INDEX project_teams_idx_team_id ON tenant.project_teams
 USING btree
 (
-	organization_id,
-	user_id
+	team_id
 )
-WHERE (deleted IS NULL AND status NOT IN ('declined', 'revoked'));
--- ddl-end --
-
--- object: organizations_users_cluster_worker_policy | type: POLICY --
--- DROP POLICY IF EXISTS organizations_users_cluster_worker_policy ON tenant.organizations_users CASCADE;
-CREATE POLICY organizations_users_cluster_worker_policy ON tenant.organizations_users
-	AS PERMISSIVE
-	FOR SELECT
-	TO fun_cluster_worker
-	USING (true);
+WHERE (deleted IS NULL);
 -- ddl-end --
 
--- object: project_members_cluster_worker_policy | type: POLICY --
--- DROP POLICY IF EXISTS project_members_cluster_worker_policy ON tenant.project_members CASCADE;
-CREATE POLICY project_members_cluster_worker_policy ON tenant.project_members
+-- object: project_teams_organization_policy | type: POLICY --
+-- DROP POLICY IF EXISTS project_teams_organization_policy ON tenant.project_teams CASCADE;
+CREATE POLICY project_teams_organization_policy ON tenant.project_teams
 	AS PERMISSIVE
-	FOR SELECT
-	TO fun_cluster_worker
-	USING (true);
+	FOR ALL
+	TO fun_fundament_api
+	USING (authn.is_project_in_organization(project_id));
 -- ddl-end --
 
--- object: users_cluster_worker_policy | type: POLICY --
--- DROP POLICY IF EXISTS users_cluster_worker_policy ON tenant.users CASCADE;
-CREATE POLICY users_cluster_worker_policy ON tenant.users
+-- object: project_teams_cluster_worker_policy | type: POLICY --
+-- DROP POLICY IF EXISTS project_teams_cluster_worker_policy ON tenant.project_teams CASCADE;
+CREATE POLICY project_teams_cluster_worker_policy ON tenant.project_teams
 	AS PERMISSIVE
 	FOR SELECT
 	TO fun_cluster_worker
 	USING (true);
 -- ddl-end --
 
--- object: cluster_outbox_organization_user | type: TRIGGER --
--- DROP TRIGGER IF EXISTS cluster_outbox_organization_user ON tenant.organizations_users CASCADE;
-CREATE OR REPLACE TRIGGER cluster_outbox_organization_user
+-- object: project_teams_outbox | type: TRIGGER --
+-- DROP TRIGGER IF EXISTS project_teams_outbox ON tenant.project_teams CASCADE;
+CREATE OR REPLACE TRIGGER project_teams_outbox
 	AFTER INSERT OR UPDATE
-	ON tenant.organizations_users
+	ON tenant.project_teams
 	FOR EACH ROW
-	EXECUTE PROCEDURE tenant.cluster_outbox_organization_user_trigger();
+	EXECUTE PROCEDURE authz.project_teams_sync_trigger();
 -- ddl-end --
 
--- object: cluster_outbox_project_member | type: TRIGGER --
--- DROP TRIGGER IF EXISTS cluster_outbox_project_member ON tenant.project_members CASCADE;
-CREATE OR REPLACE TRIGGER cluster_outbox_project_member
+-- object: cluster_outbox_project_team | type: TRIGGER --
+-- DROP TRIGGER IF EXISTS cluster_outbox_project_team ON tenant.project_teams CASCADE;
+CREATE OR REPLACE TRIGGER cluster_outbox_project_team
 	AFTER INSERT OR UPDATE
-	ON tenant.project_members
+	ON tenant.project_teams
 	FOR EACH ROW
-	EXECUTE PROCEDURE tenant.cluster_outbox_project_member_trigger();
+	EXECUTE PROCEDURE tenant.cluster_outbox_project_team_trigger();
 -- ddl-end --
 
--- object: tenant.idempotency_keys | type: TABLE --
--- DROP TABLE IF EXISTS tenant.idempotency_keys CASCADE;
-CREATE TABLE tenant.idempotency_keys (
+-- object: tenant.access_elevations | type: TABLE --
+-- DROP TABLE IF EXISTS tenant.access_elevations CASCADE;
+CREATE TABLE tenant.access_elevations (
 	id uuid NOT NULL DEFAULT uuidv7(),
-	idempotency_key uuid NOT NULL,
+	organization_id uuid NOT NULL,
 	user_id uuid NOT NULL,
+	role text NOT NULL,
 	project_id uuid,
-	project_member_id uuid,
-	cluster_id uuid,
-	node_pool_id uuid,
-	namespace_id uuid,
-	api_key_id uuid,
-	organization_user_id uuid,
-	procedure text NOT NULL,
-	request_hash bytea NOT NULL,
-	response_bytes bytea,
+	duration_minutes integer NOT NULL,
+	reason text NOT NULL,
+	status text NOT NULL DEFAULT 'pending',
+	decided_by uuid,
+	decided timestamptz,
+	expires timestamptz,
+	ended timestamptz,
 	created timestamptz NOT NULL DEFAULT now(),
-	expires timestamptz NOT NULL,
-	CONSTRAINT idempotency_keys_pk PRIMARY KEY (id),
-	CONSTRAINT idempotency_keys_uq_key_user UNIQUE (idempotency_key,user_id),
-	CONSTRAINT idempotency_keys_ck_single_fk CHECK (num_nonnulls(
-	project_id,
-	project_member_id,
-	cluster_id,
-	node_pool_id,
-	namespace_id,
-	api_key_id,
-	organization_user_id
-) <= 1)
+	CONSTRAINT access_elevations_pk PRIMARY KEY (id),
+	CONSTRAINT access_elevations_ck_role CHECK (role IN ('admin', 'project_admin')),
+	CONSTRAINT access_elevations_ck_project CHECK ((role = 'project_admin') = (project_id IS NOT NULL)),
+	CONSTRAINT access_elevations_ck_duration CHECK (duration_minutes BETWEEN 1 AND 1440),
+	CONSTRAINT access_elevations_ck_reason CHECK (btrim(reason) <> ''),
+	CONSTRAINT access_elevations_ck_status CHECK (status IN ('pending', 'approved', 'denied', 'cancelled', 'revoked', 'expired')),
+	CONSTRAINT access_elevations_ck_expires CHECK ((expires IS NOT NULL) = (status IN ('approved', 'revoked', 'expired')))
 );
 -- ddl-end --
-ALTER TABLE tenant.idempotency_keys OWNER TO fun_owner;
+COMMENT ON TABLE tenant.access_elevations IS E'Requests for a temporary organization admin or project admin role, and the grants that follow their approval.';
 -- ddl-end --
-ALTER TABLE tenant.idempotency_keys ENABLE ROW LEVEL SECURITY;
+COMMENT ON COLUMN tenant.access_elevations.user_id IS E'User who requested the role and holds it once approved.';
+-- ddl-end --
+COMMENT ON COLUMN tenant.access_elevations.project_id IS E'Project of a project_admin elevation; NULL for organization admin.';
+-- ddl-end --
+COMMENT ON COLUMN tenant.access_elevations.decided_by IS E'User who approved or denied the request.';
+-- ddl-end --
+COMMENT ON COLUMN tenant.access_elevations.expires IS E'End of the granted role: set on approval to decided plus duration_minutes.';
+-- ddl-end --
+COMMENT ON COLUMN tenant.access_elevations.ended IS E'When the request was cancelled, or the granted role was revoked or expired.';
+-- ddl-end --
+ALTER TABLE tenant.access_elevations OWNER TO fun_owner;
+-- ddl-end --
+ALTER TABLE tenant.access_elevations ENABLE ROW LEVEL SECURITY;
 -- ddl-end --
 
--- object: idempotency_keys_idx_expires | type: INDEX --
--- DROP INDEX IF EXISTS tenant.idempotency_keys_idx_expires CASCADE;
-CREATE INDEX idempotency_keys_idx_expires ON tenant.idempotency_keys
+-- object: access_elevations_uq_open | type: INDEX --
+-- DROP INDEX IF EXISTS tenant.access_elevations_uq_open CASCADE;
+CREATE UNIQUE INDEX access_elevations_uq_open ON tenant.access_elevations
 USING btree
 (
-	expires
+	organization_id,
+	user_id,
+	role,
+	project_id
+)
+NULLS NOT DISTINCT
+WHERE (status IN ('pending', 'approved'));
+-- ddl-end --
+
+-- object: access_elevations_idx_organization_created | type: INDEX --
+-- DROP INDEX IF EXISTS tenant.access_elevations_idx_organization_created CASCADE;
+CREATE INDEX access_elevations_idx_organization_created ON tenant.access_elevations
+USING btree
+(
+	organization_id,
+	created,
+	id
 );
 -- ddl-end --
 
--- object: idempotency_keys_fundament_api_policy | type: POLICY --
--- DROP POLICY IF EXISTS idempotency_keys_fundament_api_policy ON tenant.idempotency_keys CASCADE;
-CREATE POLICY idempotency_keys_fundament_api_policy ON tenant.idempotency_keys
+-- object: access_elevations_idx_expires | type: INDEX --
+-- DROP INDEX IF EXISTS tenant.access_elevations_idx_expires CASCADE;
+CREATE INDEX access_elevations_idx_expires ON tenant.access_elevations
+USING btree
+(
+	expires
+)
+WHERE (status = 'approved');
+-- ddl-end --
+
+-- object: access_elevations_organization_policy | type: POLICY --
+-- DROP POLICY IF EXISTS access_elevations_organization_policy ON tenant.access_elevations CASCADE;
+CREATE POLICY access_elevations_organization_policy ON tenant.access_elevations
 	AS PERMISSIVE
 	FOR ALL
 	TO fun_fundament_api
-	USING (user_id = authn.current_user_id());
+	USING (organization_id = authn.current_organization_id());
 -- ddl-end --
 
--- object: idempotency_keys_cleanup_policy | type: POLICY --
--- DROP POLICY IF EXISTS idempotency_keys_cleanup_policy ON tenant.idempotency_keys CASCADE;
-CREATE POLICY idempotency_keys_cleanup_policy ON tenant.idempotency_keys
+-- object: access_elevations_cluster_worker_policy | type: POLICY --
+-- DROP POLICY IF EXISTS access_elevations_cluster_worker_policy ON tenant.access_elevations CASCADE;
+CREATE POLICY access_elevations_cluster_worker_policy ON tenant.access_elevations
 	AS PERMISSIVE
-	FOR DELETE
-	TO fun_fundament_api
-	USING (expires < now());
+	FOR ALL
+	TO fun_cluster_worker
+	USING (true);
+-- ddl-end --
+
+-- object: access_elevations_authn_api_policy | type: POLICY --
+-- DROP POLICY IF EXISTS access_elevations_authn_api_policy ON tenant.access_elevations CASCADE;
+CREATE POLICY access_elevations_authn_api_policy ON tenant.access_elevations
+	AS PERMISSIVE
+	FOR SELECT
+	TO fun_authn_api
+	USING (true);
+-- ddl-end --
+
+-- object: access_elevations_outbox | type: TRIGGER --
+-- DROP TRIGGER IF EXISTS access_elevations_outbox ON tenant.access_elevations CASCADE;
+CREATE OR REPLACE TRIGGER access_elevations_outbox
+	AFTER INSERT OR UPDATE
+	ON tenant.access_elevations
+	FOR EACH ROW
+	EXECUTE PROCEDURE authz.access_elevations_sync_trigger();
+-- ddl-end --
+
+-- object: cluster_outbox_access_elevation | type: TRIGGER --
+-- DROP TRIGGER IF EXISTS cluster_outbox_access_elevation ON tenant.access_elevations CASCADE;
+CREATE OR REPLACE TRIGGER cluster_outbox_access_elevation
+	AFTER INSERT OR UPDATE
+	ON tenant.access_elevations
+	FOR EACH ROW
+	EXECUTE PROCEDURE tenant.cluster_outbox_access_elevation_trigger();
 -- ddl-end --
 
 -- object: dcim.sites | type: TABLE --
@@ -2623,6 +4744,20 @@ REFERENCES tenant.organizations (id) MATCH SIMPLE
 ON DELETE NO ACTION ON UPDATE NO ACTION;
 -- ddl-end --
 
+-- object: organization_policies_fk_organization | type: CONSTRAINT --
+-- ALTER TABLE tenant.organization_policies DROP CONSTRAINT IF EXISTS organization_policies_fk_organization CASCADE;
+ALTER TABLE tenant.organization_policies ADD CONSTRAINT organization_policies_fk_organization FOREIGN KEY (organization_id)
+REFERENCES tenant.organizations (id) MATCH SIMPLE
+ON DELETE NO ACTION ON UPDATE NO ACTION;
+-- ddl-end --
+
+-- object: namespace_limits_fk_namespace | type: CONSTRAINT --
+-- ALTER TABLE tenant.namespace_limits DROP CONSTRAINT IF EXISTS namespace_limits_fk_namespace CASCADE;
+ALTER TABLE tenant.namespace_limits ADD CONSTRAINT namespace_limits_fk_namespace FOREIGN KEY (namespace_id)
+REFERENCES tenant.namespaces (id) MATCH SIMPLE
+ON DELETE NO ACTION ON UPDATE NO ACTION;
+-- ddl-end --
+
 -- object: project_limits_fk_project | type: CONSTRAINT --
 -- ALTER TABLE tenant.project_limits DROP CONSTRAINT IF EXISTS project_limits_fk_project CASCADE;
 ALTER TABLE tenant.project_limits ADD CONSTRAINT project_limits_fk_project FOREIGN KEY (project_id)
@@ -2763,39 +4898,263 @@ REFERENCES appstore.plugins (id) MATCH SIMPLE
 ON DELETE NO ACTION ON UPDATE NO ACTION;
 -- ddl-end --
 
--- object: plugins_categories_category_id | type: CONSTRAINT --
--- ALTER TABLE appstore.categories_plugins DROP CONSTRAINT IF EXISTS plugins_categories_category_id CASCADE;
-ALTER TABLE appstore.categories_plugins ADD CONSTRAINT plugins_categories_category_id FOREIGN KEY (category_id)
-REFERENCES appstore.categories (id) MATCH SIMPLE
+-- object: plugins_categories_category_id | type: CONSTRAINT --
+-- ALTER TABLE appstore.categories_plugins DROP CONSTRAINT IF EXISTS plugins_categories_category_id CASCADE;
+ALTER TABLE appstore.categories_plugins ADD CONSTRAINT plugins_categories_category_id FOREIGN KEY (category_id)
+REFERENCES appstore.categories (id) MATCH SIMPLE
+ON DELETE NO ACTION ON UPDATE NO ACTION;
+-- ddl-end --
+
+-- object: plugin_documentation_links_fk_plugin | type: CONSTRAINT --
+-- ALTER TABLE appstore.plugin_documentation_links DROP CONSTRAINT IF EXISTS plugin_documentation_links_fk_plugin CASCADE;
+ALTER TABLE appstore.plugin_documentation_links ADD CONSTRAINT plugin_documentation_links_fk_plugin FOREIGN KEY (plugin_id)
+REFERENCES appstore.plugins (id) MATCH SIMPLE
+ON DELETE NO ACTION ON UPDATE NO ACTION;
+-- ddl-end --
+
+-- object: submissions_fk_plugin_definition | type: CONSTRAINT --
+-- ALTER TABLE appstore.submissions DROP CONSTRAINT IF EXISTS submissions_fk_plugin_definition CASCADE;
+ALTER TABLE appstore.submissions ADD CONSTRAINT submissions_fk_plugin_definition FOREIGN KEY (plugin_definition_id)
+REFERENCES appstore.plugin_definitions (id) MATCH SIMPLE
+ON DELETE NO ACTION ON UPDATE NO ACTION;
+-- ddl-end --
+
+-- object: submissions_fk_submitter | type: CONSTRAINT --
+-- ALTER TABLE appstore.submissions DROP CONSTRAINT IF EXISTS submissions_fk_submitter CASCADE;
+ALTER TABLE appstore.submissions ADD CONSTRAINT submissions_fk_submitter FOREIGN KEY (submitter_user_id)
+REFERENCES tenant.users (id) MATCH SIMPLE
+ON DELETE NO ACTION ON UPDATE NO ACTION;
+-- ddl-end --
+
+-- object: plugin_features_fk_plugin | type: CONSTRAINT --
+-- ALTER TABLE appstore.plugin_features DROP CONSTRAINT IF EXISTS plugin_features_fk_plugin CASCADE;
+ALTER TABLE appstore.plugin_features ADD CONSTRAINT plugin_features_fk_plugin FOREIGN KEY (plugin_id)
+REFERENCES appstore.plugins (id) MATCH SIMPLE
+ON DELETE NO ACTION ON UPDATE NO ACTION;
+-- ddl-end --
+
+-- object: plugins_allowed_organizations_fk_plugin | type: CONSTRAINT --
+-- ALTER TABLE appstore.plugins_allowed_organizations DROP CONSTRAINT IF EXISTS plugins_allowed_organizations_fk_plugin CASCADE;
+ALTER TABLE appstore.plugins_allowed_organizations ADD CONSTRAINT plugins_allowed_organizations_fk_plugin FOREIGN KEY (plugin_id)
+REFERENCES appstore.plugins (id) MATCH SIMPLE
+ON DELETE NO ACTION ON UPDATE NO ACTION;
+-- ddl-end --
+
+-- object: plugins_allowed_organizations_fk_organization | type: CONSTRAINT --
+-- ALTER TABLE appstore.plugins_allowed_organizations DROP CONSTRAINT IF EXISTS plugins_allowed_organizations_fk_organization CASCADE;
+ALTER TABLE appstore.plugins_allowed_organizations ADD CONSTRAINT plugins_allowed_organizations_fk_organization FOREIGN KEY (organization_id)
+REFERENCES tenant.organizations (id) MATCH SIMPLE
+ON DELETE NO ACTION ON UPDATE NO ACTION;
+-- ddl-end --
+
+-- object: project_members_fk_project | type: CONSTRAINT --
+-- ALTER TABLE tenant.project_members DROP CONSTRAINT IF EXISTS project_members_fk_project CASCADE;
+ALTER TABLE tenant.project_members ADD CONSTRAINT project_members_fk_project FOREIGN KEY (project_id)
+REFERENCES tenant.projects (id) MATCH SIMPLE
+ON DELETE NO ACTION ON UPDATE NO ACTION;
+-- ddl-end --
+
+-- object: project_members_fk_user | type: CONSTRAINT --
+-- ALTER TABLE tenant.project_members DROP CONSTRAINT IF EXISTS project_members_fk_user CASCADE;
+ALTER TABLE tenant.project_members ADD CONSTRAINT project_members_fk_user FOREIGN KEY (user_id)
+REFERENCES tenant.users (id) MATCH SIMPLE
+ON DELETE NO ACTION ON UPDATE NO ACTION;
+-- ddl-end --
+
+-- object: cluster_events_fk_cluster | type: CONSTRAINT --
+-- ALTER TABLE tenant.cluster_events DROP CONSTRAINT IF EXISTS cluster_events_fk_cluster CASCADE;
+ALTER TABLE tenant.cluster_events ADD CONSTRAINT cluster_events_fk_cluster FOREIGN KEY (cluster_id)
+REFERENCES tenant.clusters (id) MATCH SIMPLE
+ON DELETE CASCADE ON UPDATE NO ACTION;
+-- ddl-end --
+
+-- object: cluster_upgrades_fk_cluster | type: CONSTRAINT --
+-- ALTER TABLE tenant.cluster_upgrades DROP CONSTRAINT IF EXISTS cluster_upgrades_fk_cluster CASCADE;
+ALTER TABLE tenant.cluster_upgrades ADD CONSTRAINT cluster_upgrades_fk_cluster FOREIGN KEY (cluster_id)
+REFERENCES tenant.clusters (id) MATCH SIMPLE
+ON DELETE CASCADE ON UPDATE NO ACTION;
+-- ddl-end --
+
+-- object: cluster_upgrades_fk_to_kubernetes_version | type: CONSTRAINT --
+-- ALTER TABLE tenant.cluster_upgrades DROP CONSTRAINT IF EXISTS cluster_upgrades_fk_to_kubernetes_version CASCADE;
+ALTER TABLE tenant.cluster_upgrades ADD CONSTRAINT cluster_upgrades_fk_to_kubernetes_version FOREIGN KEY (to_kubernetes_version_id)
+REFERENCES catalog.kubernetes_versions (id) MATCH SIMPLE
+ON DELETE RESTRICT ON UPDATE CASCADE;
+-- ddl-end --
+
+-- object: cluster_hibernation_schedules_fk_cluster | type: CONSTRAINT --
+-- ALTER TABLE tenant.cluster_hibernation_schedules DROP CONSTRAINT IF EXISTS cluster_hibernation_schedules_fk_cluster CASCADE;
+ALTER TABLE tenant.cluster_hibernation_schedules ADD CONSTRAINT cluster_hibernation_schedules_fk_cluster FOREIGN KEY (cluster_id)
+REFERENCES tenant.clusters (id) MATCH SIMPLE
+ON DELETE CASCADE ON UPDATE NO ACTION;
+-- ddl-end --
+
+-- object: webhooks_fk_organization | type: CONSTRAINT --
+-- ALTER TABLE tenant.webhooks DROP CONSTRAINT IF EXISTS webhooks_fk_organization CASCADE;
+ALTER TABLE tenant.webhooks ADD CONSTRAINT webhooks_fk_organization FOREIGN KEY (organization_id)
+REFERENCES tenant.organizations (id) MATCH SIMPLE
+ON DELETE NO ACTION ON UPDATE NO ACTION;
+-- ddl-end --
+
+-- object: webhook_deliveries_fk_webhook | type: CONSTRAINT --
+-- ALTER TABLE tenant.webhook_deliveries DROP CONSTRAINT IF EXISTS webhook_deliveries_fk_webhook CASCADE;
+ALTER TABLE tenant.webhook_deliveries ADD CONSTRAINT webhook_deliveries_fk_webhook FOREIGN KEY (webhook_id)
+REFERENCES tenant.webhooks (id) MATCH SIMPLE
+ON DELETE CASCADE ON UPDATE NO ACTION;
+-- ddl-end --
+
+-- object: cluster_plugin_status_fk_cluster | type: CONSTRAINT --
+-- ALTER TABLE tenant.cluster_plugin_status DROP CONSTRAINT IF EXISTS cluster_plugin_status_fk_cluster CASCADE;
+ALTER TABLE tenant.cluster_plugin_status ADD CONSTRAINT cluster_plugin_status_fk_cluster FOREIGN KEY (cluster_id)
+REFERENCES tenant.clusters (id) MATCH SIMPLE
+ON DELETE CASCADE ON UPDATE NO ACTION;
+-- ddl-end --
+
+-- object: plugin_definition_approvals_fk_organization | type: CONSTRAINT --
+-- ALTER TABLE tenant.plugin_definition_approvals DROP CONSTRAINT IF EXISTS plugin_definition_approvals_fk_organization CASCADE;
+ALTER TABLE tenant.plugin_definition_approvals ADD CONSTRAINT plugin_definition_approvals_fk_organization FOREIGN KEY (organization_id)
+REFERENCES tenant.organizations (id) MATCH SIMPLE
+ON DELETE NO ACTION ON UPDATE NO ACTION;
+-- ddl-end --
+
+-- object: plugin_definition_approvals_fk_plugin_definition | type: CONSTRAINT --
+-- ALTER TABLE tenant.plugin_definition_approvals DROP CONSTRAINT IF EXISTS plugin_definition_approvals_fk_plugin_definition CASCADE;
+ALTER TABLE tenant.plugin_definition_approvals ADD CONSTRAINT plugin_definition_approvals_fk_plugin_definition FOREIGN KEY (plugin_definition_id)
+REFERENCES appstore.plugin_definitions (id) MATCH SIMPLE
+ON DELETE NO ACTION ON UPDATE NO ACTION;
+-- ddl-end --
+
+-- object: plugin_definition_approvals_fk_user | type: CONSTRAINT --
+-- ALTER TABLE tenant.plugin_definition_approvals DROP CONSTRAINT IF EXISTS plugin_definition_approvals_fk_user CASCADE;
+ALTER TABLE tenant.plugin_definition_approvals ADD CONSTRAINT plugin_definition_approvals_fk_user FOREIGN KEY (user_id)
+REFERENCES tenant.users (id) MATCH SIMPLE
+ON DELETE NO ACTION ON UPDATE NO ACTION;
+-- ddl-end --
+
+-- object: namespace_role_bindings_fk_project | type: CONSTRAINT --
+-- ALTER TABLE tenant.namespace_role_bindings DROP CONSTRAINT IF EXISTS namespace_role_bindings_fk_project CASCADE;
+ALTER TABLE tenant.namespace_role_bindings ADD CONSTRAINT namespace_role_bindings_fk_project FOREIGN KEY (project_id)
+REFERENCES tenant.projects (id) MATCH SIMPLE
+ON DELETE NO ACTION ON UPDATE NO ACTION;
+-- ddl-end --
+
+-- object: namespace_role_bindings_fk_project_member | type: CONSTRAINT --
+-- ALTER TABLE tenant.namespace_role_bindings DROP CONSTRAINT IF EXISTS namespace_role_bindings_fk_project_member CASCADE;
+ALTER TABLE tenant.namespace_role_bindings ADD CONSTRAINT namespace_role_bindings_fk_project_member FOREIGN KEY (project_member_id)
+REFERENCES tenant.project_members (id) MATCH SIMPLE
+ON DELETE NO ACTION ON UPDATE NO ACTION;
+-- ddl-end --
+
+-- object: outbox_fk_namespace_role_binding | type: CONSTRAINT --
+-- ALTER TABLE authz.outbox DROP CONSTRAINT IF EXISTS outbox_fk_namespace_role_binding CASCADE;
+ALTER TABLE authz.outbox ADD CONSTRAINT outbox_fk_namespace_role_binding FOREIGN KEY (namespace_role_binding_id)
+REFERENCES tenant.namespace_role_bindings (id) MATCH SIMPLE
+ON DELETE NO ACTION ON UPDATE NO ACTION;
+-- ddl-end --
+
+-- object: teams_fk_organization | type: CONSTRAINT --
+-- ALTER TABLE tenant.teams DROP CONSTRAINT IF EXISTS teams_fk_organization CASCADE;
+ALTER TABLE tenant.teams ADD CONSTRAINT teams_fk_organization FOREIGN KEY (organization_id)
+REFERENCES tenant.organizations (id) MATCH SIMPLE
+ON DELETE NO ACTION ON UPDATE NO ACTION;
+-- ddl-end --
+
+-- object: team_members_fk_team | type: CONSTRAINT --
+-- ALTER TABLE tenant.team_members DROP CONSTRAINT IF EXISTS team_members_fk_team CASCADE;
+ALTER TABLE tenant.team_members ADD CONSTRAINT team_members_fk_team FOREIGN KEY (team_id)
+REFERENCES tenant.teams (id) MATCH SIMPLE
+ON DELETE NO ACTION ON UPDATE NO ACTION;
+-- ddl-end --
+
+-- object: team_members_fk_user | type: CONSTRAINT --
+-- ALTER TABLE tenant.team_members DROP CONSTRAINT IF EXISTS team_members_fk_user CASCADE;
+ALTER TABLE tenant.team_members ADD CONSTRAINT team_members_fk_user FOREIGN KEY (user_id)
+REFERENCES tenant.users (id) MATCH SIMPLE
+ON DELETE NO ACTION ON UPDATE NO ACTION;
+-- ddl-end --
+
+-- object: project_teams_fk_project | type: CONSTRAINT --
+-- ALTER TABLE tenant.project_teams DROP CONSTRAINT IF EXISTS project_teams_fk_project CASCADE;
+ALTER TABLE tenant.project_teams ADD CONSTRAINT project_teams_fk_project FOREIGN KEY (project_id)
+REFERENCES tenant.projects (id) MATCH SIMPLE
+ON DELETE NO ACTION ON UPDATE NO ACTION;
+-- ddl-end --
+
+-- object: project_teams_fk_team | type: CONSTRAINT --
+-- ALTER TABLE tenant.project_teams DROP CONSTRAINT IF EXISTS project_teams_fk_team CASCADE;
+ALTER TABLE tenant.project_teams ADD CONSTRAINT project_teams_fk_team FOREIGN KEY (team_id)
+REFERENCES tenant.teams (id) MATCH SIMPLE
+ON DELETE NO ACTION ON UPDATE NO ACTION;
+-- ddl-end --
+
+-- object: outbox_fk_team | type: CONSTRAINT --
+-- ALTER TABLE authz.outbox DROP CONSTRAINT IF EXISTS outbox_fk_team CASCADE;
+ALTER TABLE authz.outbox ADD CONSTRAINT outbox_fk_team FOREIGN KEY (team_id)
+REFERENCES tenant.teams (id) MATCH SIMPLE
+ON DELETE NO ACTION ON UPDATE NO ACTION;
+-- ddl-end --
+
+-- object: outbox_fk_team_member | type: CONSTRAINT --
+-- ALTER TABLE authz.outbox DROP CONSTRAINT IF EXISTS outbox_fk_team_member CASCADE;
+ALTER TABLE authz.outbox ADD CONSTRAINT outbox_fk_team_member FOREIGN KEY (team_member_id)
+REFERENCES tenant.team_members (id) MATCH SIMPLE
+ON DELETE NO ACTION ON UPDATE NO ACTION;
+-- ddl-end --
+
+-- object: outbox_fk_project_team | type: CONSTRAINT --
+-- ALTER TABLE authz.outbox DROP CONSTRAINT IF EXISTS outbox_fk_project_team CASCADE;
+ALTER TABLE authz.outbox ADD CONSTRAINT outbox_fk_project_team FOREIGN KEY (project_team_id)
+REFERENCES tenant.project_teams (id) MATCH SIMPLE
+ON DELETE NO ACTION ON UPDATE NO ACTION;
+-- ddl-end --
+
+-- object: access_elevations_fk_organization | type: CONSTRAINT --
+-- ALTER TABLE tenant.access_elevations DROP CONSTRAINT IF EXISTS access_elevations_fk_organization CASCADE;
+ALTER TABLE tenant.access_elevations ADD CONSTRAINT access_elevations_fk_organization FOREIGN KEY (organization_id)
+REFERENCES tenant.organizations (id) MATCH SIMPLE
 ON DELETE NO ACTION ON UPDATE NO ACTION;
 -- ddl-end --
 
--- object: plugin_documentation_links_fk_plugin | type: CONSTRAINT --
--- ALTER TABLE appstore.plugin_documentation_links DROP CONSTRAINT IF EXISTS plugin_documentation_links_fk_plugin CASCADE;
-ALTER TABLE appstore.plugin_documentation_links ADD CONSTRAINT plugin_documentation_links_fk_plugin FOREIGN KEY (plugin_id)
-REFERENCES appstore.plugins (id) MATCH SIMPLE
+-- object: access_elevations_fk_user | type: CONSTRAINT --
+-- ALTER TABLE tenant.access_elevations DROP CONSTRAINT IF EXISTS access_elevations_fk_user CASCADE;
+ALTER TABLE tenant.access_elevations ADD CONSTRAINT access_elevations_fk_user FOREIGN KEY (user_id)
+REFERENCES tenant.users (id) MATCH SIMPLE
 ON DELETE NO ACTION ON UPDATE NO ACTION;
 -- ddl-end --
 
--- object: project_members_fk_project | type: CONSTRAINT --
--- ALTER TABLE tenant.project_members DROP CONSTRAINT IF EXISTS project_members_fk_project CASCADE;
-ALTER TABLE tenant.project_members ADD CONSTRAINT project_members_fk_project FOREIGN KEY (project_id)
+-- object: access_elevations_fk_project | type: CONSTRAINT --
+-- ALTER TABLE tenant.access_elevations DROP CONSTRAINT IF EXISTS access_elevations_fk_project CASCADE;
+ALTER TABLE tenant.access_elevations ADD CONSTRAINT access_elevations_fk_project FOREIGN KEY (project_id)
 REFERENCES tenant.projects (id) MATCH SIMPLE
 ON DELETE NO ACTION ON UPDATE NO ACTION;
 -- ddl-end --
 
--- object: project_members_fk_user | type: CONSTRAINT --
--- ALTER TABLE tenant.project_members DROP CONSTRAINT IF EXISTS project_members_fk_user CASCADE;
-ALTER TABLE tenant.project_members ADD CONSTRAINT project_members_fk_user FOREIGN KEY (user_id)
+-- object: access_elevations_fk_decided_by | type: CONSTRAINT --
+-- ALTER TABLE tenant.access_elevations DROP CONSTRAINT IF EXISTS access_elevations_fk_decided_by CASCADE;
+ALTER TABLE tenant.access_elevations ADD CONSTRAINT access_elevations_fk_decided_by FOREIGN KEY (decided_by)
 REFERENCES tenant.users (id) MATCH SIMPLE
 ON DELETE NO ACTION ON UPDATE NO ACTION;
 -- ddl-end --
 
--- object: cluster_events_fk_cluster | type: CONSTRAINT --
--- ALTER TABLE tenant.cluster_events DROP CONSTRAINT IF EXISTS cluster_events_fk_cluster CASCADE;
-ALTER TABLE tenant.cluster_events ADD CONSTRAINT cluster_events_fk_cluster FOREIGN KEY (cluster_id)
-REFERENCES tenant.clusters (id) MATCH SIMPLE
-ON DELETE CASCADE ON UPDATE NO ACTION;
+-- object: users_fk_scim_organization | type: CONSTRAINT --
+-- ALTER TABLE tenant.users DROP CONSTRAINT IF EXISTS users_fk_scim_organization CASCADE;
+ALTER TABLE tenant.users ADD CONSTRAINT users_fk_scim_organization FOREIGN KEY (scim_organization_id)
+REFERENCES tenant.organizations (id) MATCH SIMPLE
+ON DELETE NO ACTION ON UPDATE NO ACTION;
+-- ddl-end --
+
+-- object: outbox_fk_access_elevation | type: CONSTRAINT --
+-- ALTER TABLE authz.outbox DROP CONSTRAINT IF EXISTS outbox_fk_access_elevation CASCADE;
+ALTER TABLE authz.outbox ADD CONSTRAINT outbox_fk_access_elevation FOREIGN KEY (access_elevation_id)
+REFERENCES tenant.access_elevations (id) MATCH SIMPLE
+ON DELETE NO ACTION ON UPDATE NO ACTION;
+-- ddl-end --
+
+-- object: cluster_outbox_fk_access_elevation | type: CONSTRAINT --
+-- ALTER TABLE tenant.cluster_outbox DROP CONSTRAINT IF EXISTS cluster_outbox_fk_access_elevation CASCADE;
+ALTER TABLE tenant.cluster_outbox ADD CONSTRAINT cluster_outbox_fk_access_elevation FOREIGN KEY (access_elevation_id)
+REFERENCES tenant.access_elevations (id) MATCH SIMPLE
+ON DELETE NO ACTION ON UPDATE NO ACTION;
 -- ddl-end --
 
 -- object: cluster_outbox_fk_cluster | type: CONSTRAINT --
@@ -3232,208 +5591,448 @@ REFERENCES dcim.users (id) MATCH SIMPLE
 ON DELETE NO ACTION ON UPDATE NO ACTION;
 -- ddl-end --
 
--- object: dcim_task_steps_fk_task | type: CONSTRAINT --
--- ALTER TABLE dcim.task_steps DROP CONSTRAINT IF EXISTS dcim_task_steps_fk_task CASCADE;
-ALTER TABLE dcim.task_steps ADD CONSTRAINT dcim_task_steps_fk_task FOREIGN KEY (task_id)
-REFERENCES dcim.tasks (id) MATCH SIMPLE
-ON DELETE NO ACTION ON UPDATE NO ACTION;
+-- object: dcim_task_steps_fk_task | type: CONSTRAINT --
+-- ALTER TABLE dcim.task_steps DROP CONSTRAINT IF EXISTS dcim_task_steps_fk_task CASCADE;
+ALTER TABLE dcim.task_steps ADD CONSTRAINT dcim_task_steps_fk_task FOREIGN KEY (task_id)
+REFERENCES dcim.tasks (id) MATCH SIMPLE
+ON DELETE NO ACTION ON UPDATE NO ACTION;
+-- ddl-end --
+
+-- object: "grant_U_83c2dafa93" | type: PERMISSION --
+GRANT USAGE
+   ON SCHEMA appstore
+   TO fun_fundament_api;
+
+-- ddl-end --
+
+
+-- object: "grant_U_a95c3ca815" | type: PERMISSION --
+GRANT USAGE
+   ON SCHEMA catalog
+   TO fun_fundament_api,fun_cluster_worker,fun_authn_api;
+
+-- ddl-end --
+
+
+-- object: grant_r_104545bc50 | type: PERMISSION --
+GRANT SELECT
+   ON TABLE catalog.regions
+   TO fun_fundament_api,fun_cluster_worker,fun_authn_api;
+
+-- ddl-end --
+
+
+-- object: grant_r_33051191dc | type: PERMISSION --
+GRANT SELECT
+   ON TABLE catalog.kubernetes_versions
+   TO fun_fundament_api,fun_cluster_worker,fun_authn_api;
+
+-- ddl-end --
+
+
+-- object: grant_r_43f1a84e46 | type: PERMISSION --
+GRANT SELECT
+   ON TABLE catalog.machine_types
+   TO fun_fundament_api,fun_cluster_worker,fun_authn_api;
+
+-- ddl-end --
+
+
+-- object: grant_r_f407865fdb | type: PERMISSION --
+GRANT SELECT
+   ON TABLE catalog.region_kubernetes_versions
+   TO fun_fundament_api,fun_cluster_worker,fun_authn_api;
+
+-- ddl-end --
+
+
+-- object: grant_r_5e092e716d | type: PERMISSION --
+GRANT SELECT
+   ON TABLE catalog.region_machine_types
+   TO fun_fundament_api,fun_cluster_worker,fun_authn_api;
+
+-- ddl-end --
+
+
+-- object: "grant_U_fc33f17a39" | type: PERMISSION --
+GRANT USAGE
+   ON SCHEMA tenant
+   TO fun_fundament_api;
+
+-- ddl-end --
+
+
+-- object: "grant_U_a09934b29e" | type: PERMISSION --
+GRANT USAGE
+   ON SCHEMA tenant
+   TO fun_authn_api;
+
+-- ddl-end --
+
+
+-- object: "grant_U_94ccb226af" | type: PERMISSION --
+GRANT USAGE
+   ON SCHEMA tenant
+   TO fun_cluster_worker;
+
+-- ddl-end --
+
+
+-- object: grant_raw_26cce2624c | type: PERMISSION --
+GRANT SELECT,INSERT,UPDATE
+   ON TABLE tenant.organization_limits
+   TO fun_fundament_api;
+
+-- ddl-end --
+
+
+-- object: grant_raw_95f0b5dd11 | type: PERMISSION --
+GRANT SELECT,INSERT,UPDATE
+   ON TABLE tenant.organization_policies
+   TO fun_fundament_api;
+
+-- ddl-end --
+
+
+-- object: grant_raw_15384c10e5 | type: PERMISSION --
+GRANT SELECT,INSERT,UPDATE
+   ON TABLE tenant.project_limits
+   TO fun_fundament_api;
+
+-- ddl-end --
+
+
+-- object: grant_raw_537079360c | type: PERMISSION --
+GRANT SELECT,INSERT,UPDATE
+   ON TABLE tenant.namespace_limits
+   TO fun_fundament_api;
+
+-- ddl-end --
+
+
+-- object: grant_r_562d4034a1 | type: PERMISSION --
+GRANT SELECT
+   ON TABLE tenant.namespace_limits
+   TO fun_cluster_worker;
+
+-- ddl-end --
+
+
+-- object: grant_r_931c18b720 | type: PERMISSION --
+GRANT SELECT
+   ON TABLE tenant.organization_limits
+   TO fun_cluster_worker;
+
+-- ddl-end --
+
+
+-- object: grant_r_c195a6ae79 | type: PERMISSION --
+GRANT SELECT
+   ON TABLE tenant.project_limits
+   TO fun_cluster_worker;
+
+-- ddl-end --
+
+
+-- object: grant_raw_6dafe3fd95 | type: PERMISSION --
+GRANT SELECT,INSERT,UPDATE
+   ON TABLE tenant.organizations
+   TO fun_fundament_api;
+
+-- ddl-end --
+
+
+-- object: grant_r_5db3d57700 | type: PERMISSION --
+GRANT SELECT
+   ON TABLE tenant.organizations
+   TO fun_cluster_worker;
+
+-- ddl-end --
+
+
+-- object: grant_raw_c16308945d | type: PERMISSION --
+GRANT SELECT,INSERT,UPDATE
+   ON TABLE tenant.organizations
+   TO fun_authn_api;
+
+-- ddl-end --
+
+
+-- object: grant_raw_b5e3aab1d0 | type: PERMISSION --
+GRANT SELECT,INSERT,UPDATE
+   ON TABLE tenant.projects
+   TO fun_fundament_api;
+
+-- ddl-end --
+
+
+-- object: grant_raw_125a3754db | type: PERMISSION --
+GRANT SELECT,INSERT,UPDATE
+   ON TABLE tenant.namespaces
+   TO fun_fundament_api;
+
+-- ddl-end --
+
+
+-- object: grant_raw_d972e5c22a | type: PERMISSION --
+GRANT SELECT,INSERT,UPDATE
+   ON TABLE tenant.users
+   TO fun_authn_api;
+
+-- ddl-end --
+
+
+-- object: grant_raw_5940e5f705 | type: PERMISSION --
+GRANT SELECT,INSERT,UPDATE
+   ON TABLE tenant.users
+   TO fun_fundament_api;
+
+-- ddl-end --
+
+
+-- object: grant_raw_940738ac34 | type: PERMISSION --
+GRANT SELECT,INSERT,UPDATE
+   ON TABLE tenant.clusters
+   TO fun_fundament_api;
+
+-- ddl-end --
+
+
+-- object: grant_rw_8317ece277 | type: PERMISSION --
+GRANT SELECT,UPDATE
+   ON TABLE tenant.clusters
+   TO fun_cluster_worker;
+
+-- ddl-end --
+
+
+-- object: grant_ra_eefd069f6a | type: PERMISSION --
+GRANT SELECT,INSERT
+   ON TABLE tenant.cluster_events
+   TO fun_fundament_api;
+
+-- ddl-end --
+
+
+-- object: grant_ra_fcaa9ce53e | type: PERMISSION --
+GRANT SELECT,INSERT
+   ON TABLE tenant.cluster_events
+   TO fun_cluster_worker;
+
+-- ddl-end --
+
+
+-- object: grant_raw_3c1f7b52d4 | type: PERMISSION --
+GRANT SELECT,INSERT,UPDATE
+   ON TABLE tenant.cluster_upgrades
+   TO fun_fundament_api;
+
+-- ddl-end --
+
+
+-- object: grant_rw_8d24e6a0b9 | type: PERMISSION --
+GRANT SELECT,UPDATE
+   ON TABLE tenant.cluster_upgrades
+   TO fun_cluster_worker;
+
 -- ddl-end --
 
--- object: "grant_U_83c2dafa93" | type: PERMISSION --
-GRANT USAGE
-   ON SCHEMA appstore
+
+-- object: grant_rad_5e0c9b7f21 | type: PERMISSION --
+GRANT SELECT,INSERT,DELETE
+   ON TABLE tenant.cluster_hibernation_schedules
    TO fun_fundament_api;
 
 -- ddl-end --
 
 
--- object: "grant_U_a95c3ca815" | type: PERMISSION --
-GRANT USAGE
-   ON SCHEMA catalog
-   TO fun_fundament_api,fun_cluster_worker,fun_authn_api;
+-- object: grant_r_b7d3e81a46 | type: PERMISSION --
+GRANT SELECT
+   ON TABLE tenant.cluster_hibernation_schedules
+   TO fun_cluster_worker;
 
 -- ddl-end --
 
 
--- object: grant_r_104545bc50 | type: PERMISSION --
-GRANT SELECT
-   ON TABLE catalog.regions
-   TO fun_fundament_api,fun_cluster_worker,fun_authn_api;
+-- object: grant_ra_4f6d0c2b93 | type: PERMISSION --
+GRANT SELECT,INSERT
+   ON TABLE tenant.audit_events
+   TO fun_fundament_api;
 
 -- ddl-end --
 
 
--- object: grant_r_33051191dc | type: PERMISSION --
-GRANT SELECT
-   ON TABLE catalog.kubernetes_versions
-   TO fun_fundament_api,fun_cluster_worker,fun_authn_api;
+-- object: grant_a_9e15b7d3c0 | type: PERMISSION --
+GRANT INSERT
+   ON TABLE tenant.audit_events
+   TO fun_dcim_api,fun_kube_api_proxy;
 
 -- ddl-end --
 
 
--- object: grant_r_43f1a84e46 | type: PERMISSION --
-GRANT SELECT
-   ON TABLE catalog.machine_types
-   TO fun_fundament_api,fun_cluster_worker,fun_authn_api;
+-- object: "grant_U_2c8e4a61f7" | type: PERMISSION --
+GRANT USAGE
+   ON SCHEMA tenant
+   TO fun_dcim_api,fun_kube_api_proxy;
 
 -- ddl-end --
 
 
--- object: grant_r_f407865fdb | type: PERMISSION --
-GRANT SELECT
-   ON TABLE catalog.region_kubernetes_versions
-   TO fun_fundament_api,fun_cluster_worker,fun_authn_api;
+-- object: grant_raw_08924770d3 | type: PERMISSION --
+GRANT SELECT,INSERT,UPDATE
+   ON TABLE tenant.webhooks
+   TO fun_fundament_api;
 
 -- ddl-end --
 
 
--- object: grant_r_5e092e716d | type: PERMISSION --
+-- object: grant_r_7b6dcbac50 | type: PERMISSION --
 GRANT SELECT
-   ON TABLE catalog.region_machine_types
-   TO fun_fundament_api,fun_cluster_worker,fun_authn_api;
+   ON TABLE tenant.webhooks
+   TO fun_cluster_worker;
 
 -- ddl-end --
 
 
--- object: "grant_U_fc33f17a39" | type: PERMISSION --
-GRANT USAGE
-   ON SCHEMA tenant
+-- object: grant_ra_0393fdcab8 | type: PERMISSION --
+GRANT SELECT,INSERT
+   ON TABLE tenant.webhook_deliveries
    TO fun_fundament_api;
 
 -- ddl-end --
 
 
--- object: "grant_U_a09934b29e" | type: PERMISSION --
-GRANT USAGE
-   ON SCHEMA tenant
-   TO fun_authn_api;
+-- object: grant_rw_7634c2da80 | type: PERMISSION --
+GRANT SELECT,UPDATE
+   ON TABLE tenant.webhook_deliveries
+   TO fun_cluster_worker;
 
 -- ddl-end --
 
 
--- object: "grant_U_94ccb226af" | type: PERMISSION --
-GRANT USAGE
-   ON SCHEMA tenant
+-- object: grant_rawd_7dd035d259 | type: PERMISSION --
+GRANT SELECT,INSERT,UPDATE,DELETE
+   ON TABLE tenant.cluster_plugin_status
    TO fun_cluster_worker;
 
 -- ddl-end --
 
 
--- object: grant_raw_26cce2624c | type: PERMISSION --
-GRANT SELECT,INSERT,UPDATE
-   ON TABLE tenant.organization_limits
+-- object: grant_ra_5e41c09b7d | type: PERMISSION --
+GRANT SELECT,INSERT
+   ON TABLE tenant.plugin_definition_approvals
    TO fun_fundament_api;
 
 -- ddl-end --
 
 
--- object: grant_raw_15384c10e5 | type: PERMISSION --
+-- object: grant_raw_4c1f0e7a92 | type: PERMISSION --
 GRANT SELECT,INSERT,UPDATE
-   ON TABLE tenant.project_limits
+   ON TABLE tenant.namespace_role_bindings
    TO fun_fundament_api;
 
 -- ddl-end --
 
 
--- object: grant_r_931c18b720 | type: PERMISSION --
+-- object: grant_r_8e5d2b61c3 | type: PERMISSION --
 GRANT SELECT
-   ON TABLE tenant.organization_limits
-   TO fun_cluster_worker;
+   ON TABLE tenant.namespace_role_bindings
+   TO fun_authz_worker;
 
 -- ddl-end --
 
 
--- object: grant_r_c195a6ae79 | type: PERMISSION --
+-- object: grant_r_d39a7f04b8 | type: PERMISSION --
 GRANT SELECT
-   ON TABLE tenant.project_limits
+   ON TABLE tenant.namespace_role_bindings
    TO fun_cluster_worker;
 
 -- ddl-end --
 
 
--- object: grant_raw_6dafe3fd95 | type: PERMISSION --
+-- object: grant_raw_48dc33cb47 | type: PERMISSION --
 GRANT SELECT,INSERT,UPDATE
-   ON TABLE tenant.organizations
+   ON TABLE tenant.teams
    TO fun_fundament_api;
 
 -- ddl-end --
 
 
--- object: grant_r_5db3d57700 | type: PERMISSION --
+-- object: grant_r_37bd84450a | type: PERMISSION --
 GRANT SELECT
-   ON TABLE tenant.organizations
-   TO fun_cluster_worker;
+   ON TABLE tenant.teams
+   TO fun_authz_worker;
 
 -- ddl-end --
 
 
--- object: grant_raw_c16308945d | type: PERMISSION --
+-- object: grant_r_181fb7395a | type: PERMISSION --
 GRANT SELECT,INSERT,UPDATE
-   ON TABLE tenant.organizations
+   ON TABLE tenant.teams
    TO fun_authn_api;
 
 -- ddl-end --
 
 
--- object: grant_raw_b5e3aab1d0 | type: PERMISSION --
-GRANT SELECT,INSERT,UPDATE
-   ON TABLE tenant.projects
-   TO fun_fundament_api;
+-- object: grant_r_7fb9f00759 | type: PERMISSION --
+GRANT SELECT
+   ON TABLE tenant.teams
+   TO fun_cluster_worker;
 
 -- ddl-end --
 
 
--- object: grant_raw_125a3754db | type: PERMISSION --
+-- object: grant_raw_e307e2a7c6 | type: PERMISSION --
 GRANT SELECT,INSERT,UPDATE
-   ON TABLE tenant.namespaces
+   ON TABLE tenant.team_members
    TO fun_fundament_api;
 
 -- ddl-end --
 
 
--- object: grant_raw_d972e5c22a | type: PERMISSION --
-GRANT SELECT,INSERT,UPDATE
-   ON TABLE tenant.users
-   TO fun_authn_api;
+-- object: grant_r_6e8e17941d | type: PERMISSION --
+GRANT SELECT
+   ON TABLE tenant.team_members
+   TO fun_authz_worker;
 
 -- ddl-end --
 
 
--- object: grant_raw_5940e5f705 | type: PERMISSION --
+-- object: grant_raw_29863c35f0 | type: PERMISSION --
 GRANT SELECT,INSERT,UPDATE
-   ON TABLE tenant.users
-   TO fun_fundament_api;
+   ON TABLE tenant.team_members
+   TO fun_authn_api;
 
 -- ddl-end --
 
 
--- object: grant_raw_940738ac34 | type: PERMISSION --
-GRANT SELECT,INSERT,UPDATE
-   ON TABLE tenant.clusters
-   TO fun_fundament_api;
+-- object: grant_r_ca17416922 | type: PERMISSION --
+GRANT SELECT
+   ON TABLE tenant.team_members
+   TO fun_cluster_worker;
 
 -- ddl-end --
 
 
--- object: grant_rw_8317ece277 | type: PERMISSION --
-GRANT SELECT,UPDATE
-   ON TABLE tenant.clusters
-   TO fun_cluster_worker;
+-- object: grant_raw_8c299bd14f | type: PERMISSION --
+GRANT SELECT,INSERT,UPDATE
+   ON TABLE tenant.project_teams
+   TO fun_fundament_api;
 
 -- ddl-end --
 
 
--- object: grant_ra_eefd069f6a | type: PERMISSION --
-GRANT SELECT,INSERT
-   ON TABLE tenant.cluster_events
-   TO fun_fundament_api;
+-- object: grant_r_65e7c2aea1 | type: PERMISSION --
+GRANT SELECT
+   ON TABLE tenant.project_teams
+   TO fun_authz_worker;
 
 -- ddl-end --
 
 
--- object: grant_ra_fcaa9ce53e | type: PERMISSION --
-GRANT SELECT,INSERT
-   ON TABLE tenant.cluster_events
+-- object: grant_r_0e59186263 | type: PERMISSION --
+GRANT SELECT
+   ON TABLE tenant.project_teams
    TO fun_cluster_worker;
 
 -- ddl-end --
@@ -3831,8 +6430,16 @@ GRANT SELECT,INSERT,UPDATE
 -- ddl-end --
 
 
--- object: grant_ra_aa475c9278 | type: PERMISSION --
-GRANT SELECT,INSERT
+-- object: grant_rawd_3c51e0a7d2 | type: PERMISSION --
+GRANT SELECT,INSERT,UPDATE,DELETE
+   ON TABLE tenant.cluster_worker_leases
+   TO fun_cluster_worker;
+
+-- ddl-end --
+
+
+-- object: grant_raw_aa475c9278 | type: PERMISSION --
+GRANT SELECT,INSERT,UPDATE
    ON TABLE tenant.cluster_outbox
    TO fun_fundament_api;
 
@@ -4055,4 +6662,178 @@ GRANT SELECT
 -- ddl-end --
 
 
+-- object: "grant_U_7ffd60c0b0" | type: PERMISSION --
+GRANT USAGE
+   ON SCHEMA appstore
+   TO fun_authz;
+
+-- ddl-end --
+
+
+-- object: grant_r_a7b5bd01bc | type: PERMISSION --
+GRANT SELECT
+   ON TABLE appstore.plugins
+   TO fun_authz;
+
+-- ddl-end --
+
+
+-- object: "grant_U_1ab2555176" | type: PERMISSION --
+GRANT USAGE
+   ON SCHEMA appstore
+   TO fun_marketplace_api;
+
+-- ddl-end --
+
+
+-- object: "grant_U_d76e63d23f" | type: PERMISSION --
+GRANT USAGE
+   ON SCHEMA tenant
+   TO fun_marketplace_api;
+
+-- ddl-end --
+
+
+-- object: "grant_U_992f9bf522" | type: PERMISSION --
+GRANT USAGE
+   ON SCHEMA authn
+   TO fun_marketplace_api;
+
+-- ddl-end --
+
+
+-- object: "grant_U_837b0d9261" | type: PERMISSION --
+GRANT USAGE
+   ON SCHEMA authz
+   TO fun_marketplace_api;
+
+-- ddl-end --
+
+
+-- object: grant_raw_e10a502c80 | type: PERMISSION --
+GRANT SELECT,INSERT,UPDATE
+   ON TABLE appstore.plugins
+   TO fun_marketplace_api;
+
+-- ddl-end --
+
+
+-- object: grant_raw_a98e66f0a4 | type: PERMISSION --
+GRANT SELECT,INSERT,UPDATE
+   ON TABLE appstore.plugin_definitions
+   TO fun_marketplace_api;
+
+-- ddl-end --
+
+
+-- object: grant_raw_9f4752cf73 | type: PERMISSION --
+GRANT SELECT,INSERT,UPDATE
+   ON TABLE appstore.submissions
+   TO fun_marketplace_api;
+
+-- ddl-end --
+
+
+-- object: grant_r_f6f1cef22c | type: PERMISSION --
+GRANT SELECT
+   ON TABLE appstore.categories
+   TO fun_marketplace_api;
+
+-- ddl-end --
+
+
+-- object: grant_ra_e836213552 | type: PERMISSION --
+GRANT SELECT,INSERT
+   ON TABLE appstore.tags
+   TO fun_marketplace_api;
+
+-- ddl-end --
+
+
+-- object: grant_rad_3002568d53 | type: PERMISSION --
+GRANT SELECT,INSERT,DELETE
+   ON TABLE appstore.plugins_tags
+   TO fun_marketplace_api;
+
+-- ddl-end --
+
+
+-- object: grant_rad_eb00923e67 | type: PERMISSION --
+GRANT SELECT,INSERT,DELETE
+   ON TABLE appstore.categories_plugins
+   TO fun_marketplace_api;
+
+-- ddl-end --
+
+
+-- object: grant_rawd_6522912156 | type: PERMISSION --
+GRANT SELECT,INSERT,UPDATE,DELETE
+   ON TABLE appstore.plugin_documentation_links
+   TO fun_marketplace_api;
+
+-- ddl-end --
+
+
+-- object: grant_rad_d8c638bf67 | type: PERMISSION --
+GRANT SELECT,INSERT,DELETE
+   ON TABLE appstore.plugin_features
+   TO fun_marketplace_api;
+
+-- ddl-end --
+
+
+-- object: grant_rad_f0deb0e718 | type: PERMISSION --
+GRANT SELECT,INSERT,DELETE
+   ON TABLE appstore.plugins_allowed_organizations
+   TO fun_marketplace_api;
+
+-- ddl-end --
+
+
+-- object: grant_r_c390db4345 | type: PERMISSION --
+GRANT SELECT
+   ON TABLE tenant.organizations
+   TO fun_marketplace_api;
+
+-- ddl-end --
+
+
+-- object: grant_ra_fb6b6b8032 | type: PERMISSION --
+GRANT SELECT,INSERT
+   ON TABLE authz.outbox
+   TO fun_marketplace_api;
+
+-- ddl-end --
+
+
+-- object: grant_raw_b2dcac9a46 | type: PERMISSION --
+GRANT SELECT,INSERT,UPDATE
+   ON TABLE tenant.access_elevations
+   TO fun_fundament_api;
+
+-- ddl-end --
+
+
+-- object: grant_r_fe859ffb5a | type: PERMISSION --
+GRANT SELECT
+   ON TABLE tenant.access_elevations
+   TO fun_authz_worker;
+
+-- ddl-end --
+
+
+-- object: grant_rw_e591c7734d | type: PERMISSION --
+GRANT SELECT,UPDATE
+   ON TABLE tenant.access_elevations
+   TO fun_cluster_worker;
+
+-- ddl-end --
+
+
+-- object: grant_r_4d0edb790b | type: PERMISSION --
+GRANT SELECT
+   ON TABLE tenant.access_elevations
+   TO fun_authn_api;
+
+-- ddl-end --
 
@@ -0,0 +1,326 @@
+-- Marketplace schema (FUN-20): listing fields, per-version review state and
+-- the review queue behind marketplace-api, plus the fun_marketplace_api role's
+-- grants and row-level security.
+--
+-- The plugin name stays globally unique for now. FUN-20 asks for uniqueness per
+-- owning organization, but organization-api's GetPluginDefinition and the
+-- PluginInstallation resource name still resolve a plugin by its bare name; the
+-- constraint can be narrowed once installs are keyed by organization as well.
+
+SET SESSION statement_timeout = 3000;
+SET SESSION lock_timeout = 3000;
+
+-- Listing fields.
+ALTER TABLE "appstore"."plugins" ADD COLUMN "license" text DEFAULT '' NOT NULL;
+
+ALTER TABLE "appstore"."plugins" ADD COLUMN "visibility" text DEFAULT 'public' NOT NULL;
+
+ALTER TABLE "appstore"."plugins" ADD COLUMN "featured" boolean DEFAULT false NOT NULL;
+
+ALTER TABLE "appstore"."plugins" ADD COLUMN "labels" text[] DEFAULT '{}' NOT NULL;
+
+ALTER TABLE "appstore"."plugins" ADD COLUMN "updated" timestamp with time zone DEFAULT now() NOT NULL;
+
+ALTER TABLE "appstore"."plugins" ADD CONSTRAINT "plugins_ck_visibility" CHECK (visibility IN ('public', 'restricted'));
+
+ALTER TABLE "appstore"."plugins" ADD CONSTRAINT "plugins_ck_labels" CHECK (labels <@ ARRAY['core', 'rijksoverheid', 'support_9_to_17']::text[]);
+
+COMMENT ON COLUMN "appstore"."plugins"."visibility" IS E'public listings appear in the marketplace catalog once a version is approved; restricted listings never do and are installable only by the organizations in plugins_allowed_organizations.';
+
+COMMENT ON COLUMN "appstore"."plugins"."featured" IS E'Fundament curation decision. No API writes it yet.';
+
+COMMENT ON COLUMN "appstore"."plugins"."labels" IS E'Trust and support badges (core, rijksoverheid, support_9_to_17). Fundament curation decision; no API writes it yet.';
+
+-- Review state lives on the version: each pushed definition is reviewed on its
+-- own. Definitions that exist before the marketplace are first-party catalog
+-- entries and count as published; new ones start as drafts.
+ALTER TABLE "appstore"."plugin_definitions" ADD COLUMN "release_notes" text DEFAULT '' NOT NULL;
+
+ALTER TABLE "appstore"."plugin_definitions" ADD COLUMN "status" text DEFAULT 'approved' NOT NULL;
+
+ALTER TABLE "appstore"."plugin_definitions" ADD COLUMN "submitted" timestamp with time zone;
+
+ALTER TABLE "appstore"."plugin_definitions" ADD COLUMN "published" timestamp with time zone;
+
+UPDATE "appstore"."plugin_definitions" SET "published" = "created";
+
+ALTER TABLE "appstore"."plugin_definitions" ALTER COLUMN "status" SET DEFAULT 'draft';
+
+ALTER TABLE "appstore"."plugin_definitions" ADD CONSTRAINT "plugin_definitions_ck_status" CHECK (status IN ('draft', 'pending', 'changes_requested', 'approved', 'rejected', 'withdrawn'));
+
+COMMENT ON COLUMN "appstore"."plugin_definitions"."status" IS E'Where the version sits in the review pipeline. Mirrors the status of its most recent appstore.submissions row; draft means it was never submitted.';
+
+-- The review queue. One row per submit; a resubmit after changes were requested
+-- opens a new row, so the history of decisions is kept.
+CREATE TABLE "appstore"."submissions" (
+	"id" uuid DEFAULT uuidv7() NOT NULL,
+	"plugin_definition_id" uuid NOT NULL,
+	"submitter_user_id" uuid NOT NULL,
+	"status" text DEFAULT 'pending' NOT NULL,
+	"submitted" timestamp with time zone DEFAULT now() NOT NULL,
+	"reviewed" timestamp with time zone,
+	"reviewer_user_id" uuid,
+	"rejection_reason" text,
+	"feedback" text DEFAULT '' NOT NULL
+);
+
+ALTER TABLE "appstore"."submissions" OWNER TO fun_owner;
+
+COMMENT ON COLUMN "appstore"."submissions"."reviewer_user_id" IS E'Deciding Fundament staff member. No foreign key: which user store reviewers come from is not settled (FUN-20).';
+
+CREATE UNIQUE INDEX submissions_pk ON appstore.submissions USING btree (id);
+
+ALTER TABLE "appstore"."submissions" ADD CONSTRAINT "submissions_pk" PRIMARY KEY USING INDEX "submissions_pk";
+
+-- At most one open submission per version.
+CREATE UNIQUE INDEX submissions_uq_pending ON appstore.submissions USING btree (plugin_definition_id) WHERE (status = 'pending');
+
+ALTER TABLE "appstore"."submissions" ADD CONSTRAINT "submissions_ck_status" CHECK (status IN ('pending', 'changes_requested', 'approved', 'rejected', 'withdrawn'));
+
+ALTER TABLE "appstore"."submissions" ADD CONSTRAINT "submissions_ck_rejection_reason" CHECK (rejection_reason IN ('incomplete_metadata', 'duplicate', 'security_concerns', 'naming_guidelines', 'out_of_scope', 'other'));
+
+ALTER TABLE "appstore"."submissions" ADD CONSTRAINT "submissions_fk_plugin_definition" FOREIGN KEY ("plugin_definition_id") REFERENCES "appstore"."plugin_definitions"("id") MATCH SIMPLE ON DELETE NO ACTION ON UPDATE NO ACTION;
+
+ALTER TABLE "appstore"."submissions" ADD CONSTRAINT "submissions_fk_submitter" FOREIGN KEY ("submitter_user_id") REFERENCES "tenant"."users"("id") MATCH SIMPLE ON DELETE NO ACTION ON UPDATE NO ACTION;
+
+-- Feature blocks rendered as cards on the public detail page, in ordinal order.
+CREATE TABLE "appstore"."plugin_features" (
+	"id" uuid DEFAULT uuidv7() NOT NULL,
+	"plugin_id" uuid NOT NULL,
+	"ordinal" integer NOT NULL,
+	"title" text NOT NULL,
+	"body" text NOT NULL
+);
+
+ALTER TABLE "appstore"."plugin_features" OWNER TO fun_owner;
+
+CREATE UNIQUE INDEX plugin_features_pk ON appstore.plugin_features USING btree (id);
+
+ALTER TABLE "appstore"."plugin_features" ADD CONSTRAINT "plugin_features_pk" PRIMARY KEY USING INDEX "plugin_features_pk";
+
+ALTER TABLE "appstore"."plugin_features" ADD CONSTRAINT "plugin_features_uq_ordinal" UNIQUE (plugin_id, ordinal);
+
+ALTER TABLE "appstore"."plugin_features" ADD CONSTRAINT "plugin_features_fk_plugin" FOREIGN KEY ("plugin_id") REFERENCES "appstore"."plugins"("id") MATCH SIMPLE ON DELETE NO ACTION ON UPDATE NO ACTION;
+
+-- Organizations permitted to install a restricted plugin.
+CREATE TABLE "appstore"."plugins_allowed_organizations" (
+	"plugin_id" uuid NOT NULL,
+	"organization_id" uuid NOT NULL
+);
+
+ALTER TABLE "appstore"."plugins_allowed_organizations" OWNER TO fun_owner;
+
+CREATE UNIQUE INDEX plugins_allowed_organizations_pk ON appstore.plugins_allowed_organizations USING btree (plugin_id, organization_id);
+
+ALTER TABLE "appstore"."plugins_allowed_organizations" ADD CONSTRAINT "plugins_allowed_organizations_pk" PRIMARY KEY USING INDEX "plugins_allowed_organizations_pk";
+
+ALTER TABLE "appstore"."plugins_allowed_organizations" ADD CONSTRAINT "plugins_allowed_organizations_fk_plugin" FOREIGN KEY ("plugin_id") REFERENCES "appstore"."plugins"("id") MATCH SIMPLE ON DELETE NO ACTION ON UPDATE NO ACTION;
+
+ALTER TABLE "appstore"."plugins_allowed_organizations" ADD CONSTRAINT "plugins_allowed_organizations_fk_organization" FOREIGN KEY ("organization_id") REFERENCES "tenant"."organizations"("id") MATCH SIMPLE ON DELETE NO ACTION ON UPDATE NO ACTION;
+
+-- RLS helpers. SECURITY DEFINER so policies on tables the caller can only
+-- partially see still resolve ownership, same as is_cluster_in_organization.
+CREATE OR REPLACE FUNCTION authn.is_plugin_in_organization (IN p_plugin_id uuid)
+	RETURNS boolean
+	LANGUAGE sql
+	STABLE
+	CALLED ON NULL INPUT
+	SECURITY DEFINER
+	PARALLEL SAFE
+	COST 1
+	AS
+$function$
+SELECT EXISTS (
+    SELECT 1 FROM appstore.plugins
+    WHERE id = p_plugin_id
+    AND organization_id = authn.current_organization_id()
+)
+$function$;
+
+ALTER FUNCTION authn.is_plugin_in_organization(uuid) OWNER TO fun_authz;
+
+-- Marketplace reviewers are the admins of the system organization, which owns
+-- the first-party plugins.
+CREATE OR REPLACE FUNCTION authn.is_marketplace_reviewer ()
+	RETURNS boolean
+	LANGUAGE sql
+	STABLE
+	CALLED ON NULL INPUT
+	SECURITY DEFINER
+	PARALLEL SAFE
+	COST 1
+	AS
+$function$
+SELECT EXISTS (
+    SELECT 1 FROM tenant.organizations_users
+    WHERE organization_id = '019b4000-0000-7000-8000-000000000000'
+    AND user_id = authn.current_user_id()
+    AND permission = 'admin'
+    AND status = 'accepted'
+    AND deleted IS NULL
+)
+$function$;
+
+ALTER FUNCTION authn.is_marketplace_reviewer() OWNER TO fun_authz;
+
+GRANT USAGE ON SCHEMA appstore TO fun_authz;
+
+GRANT SELECT ON TABLE appstore.plugins TO fun_authz;
+
+-- fun_marketplace_api: the catalog reads globally, publishers write their own
+-- organization's listings and reviewers decide on submissions.
+GRANT USAGE ON SCHEMA appstore TO fun_marketplace_api;
+
+GRANT USAGE ON SCHEMA tenant TO fun_marketplace_api;
+
+GRANT USAGE ON SCHEMA authn TO fun_marketplace_api;
+
+GRANT USAGE ON SCHEMA authz TO fun_marketplace_api;
+
+GRANT SELECT, INSERT, UPDATE ON TABLE appstore.plugins TO fun_marketplace_api;
+
+GRANT SELECT, INSERT, UPDATE ON TABLE appstore.plugin_definitions TO fun_marketplace_api;
+
+GRANT SELECT, INSERT, UPDATE ON TABLE appstore.submissions TO fun_marketplace_api;
+
+GRANT SELECT ON TABLE appstore.categories TO fun_marketplace_api;
+
+GRANT SELECT, INSERT ON TABLE appstore.tags TO fun_marketplace_api;
+
+GRANT SELECT, INSERT, DELETE ON TABLE appstore.plugins_tags TO fun_marketplace_api;
+
+GRANT SELECT, INSERT, DELETE ON TABLE appstore.categories_plugins TO fun_marketplace_api;
+
+GRANT SELECT, INSERT, UPDATE, DELETE ON TABLE appstore.plugin_documentation_links TO fun_marketplace_api;
+
+GRANT SELECT, INSERT, DELETE ON TABLE appstore.plugin_features TO fun_marketplace_api;
+
+GRANT SELECT, INSERT, DELETE ON TABLE appstore.plugins_allowed_organizations TO fun_marketplace_api;
+
+GRANT SELECT ON TABLE tenant.organizations TO fun_marketplace_api;
+
+-- The plugins outbox trigger runs as the invoker, and the circuit breaker reads
+-- the outbox lag.
+GRANT SELECT, INSERT ON TABLE authz.outbox TO fun_marketplace_api;
+
+-- Existing appstore policies cover the marketplace role as well.
+ALTER POLICY "plugins_select_all" ON "appstore"."plugins" TO fun_fundament_api, fun_marketplace_api;
+
+ALTER POLICY "plugins_insert_owner" ON "appstore"."plugins" TO fun_fundament_api, fun_marketplace_api;
+
+ALTER POLICY "plugins_update_owner" ON "appstore"."plugins" TO fun_fundament_api, fun_marketplace_api;
+
+ALTER POLICY "plugin_definitions_select_all" ON "appstore"."plugin_definitions" TO fun_fundament_api, fun_marketplace_api;
+
+ALTER POLICY "plugin_definitions_insert_owner" ON "appstore"."plugin_definitions" TO fun_fundament_api, fun_marketplace_api;
+
+ALTER POLICY "plugin_definitions_update_owner" ON "appstore"."plugin_definitions" TO fun_fundament_api, fun_marketplace_api;
+
+-- A reviewer's decision moves the version of a plugin they do not own.
+CREATE POLICY "plugin_definitions_update_reviewer" ON "appstore"."plugin_definitions"
+	AS PERMISSIVE
+	FOR UPDATE
+	TO fun_marketplace_api
+	USING (authn.is_marketplace_reviewer());
+
+-- Submissions carry the backoffice-only rejection reason, so only the owning
+-- organization and reviewers see them.
+CREATE POLICY "submissions_select_owner" ON "appstore"."submissions"
+	AS PERMISSIVE
+	FOR SELECT
+	TO fun_marketplace_api
+	USING ((EXISTS (SELECT 1 FROM appstore.plugin_definitions WHERE appstore.plugin_definitions.id = appstore.submissions.plugin_definition_id AND authn.is_plugin_in_organization(appstore.plugin_definitions.plugin_id))) OR authn.is_marketplace_reviewer());
+
+CREATE POLICY "submissions_insert_owner" ON "appstore"."submissions"
+	AS PERMISSIVE
+	FOR INSERT
+	TO fun_marketplace_api
+	WITH CHECK ((EXISTS (SELECT 1 FROM appstore.plugin_definitions WHERE appstore.plugin_definitions.id = appstore.submissions.plugin_definition_id AND authn.is_plugin_in_organization(appstore.plugin_definitions.plugin_id))));
+
+CREATE POLICY "submissions_update_owner" ON "appstore"."submissions"
+	AS PERMISSIVE
+	FOR UPDATE
+	TO fun_marketplace_api
+	USING ((EXISTS (SELECT 1 FROM appstore.plugin_definitions WHERE appstore.plugin_definitions.id = appstore.submissions.plugin_definition_id AND authn.is_plugin_in_organization(appstore.plugin_definitions.plugin_id))) OR authn.is_marketplace_reviewer());
+
+ALTER TABLE "appstore"."submissions" ENABLE ROW LEVEL SECURITY;
+
+-- Listing detail tables: reads stay global like appstore.plugins, writes are
+-- gated to the owning organization.
+CREATE POLICY "plugins_tags_select_all" ON "appstore"."plugins_tags"
+	AS PERMISSIVE
+	FOR SELECT
+	TO fun_fundament_api, fun_marketplace_api
+	USING (true);
+
+CREATE POLICY "plugins_tags_write_owner" ON "appstore"."plugins_tags"
+	AS PERMISSIVE
+	FOR ALL
+	TO fun_marketplace_api
+	USING (authn.is_plugin_in_organization(plugin_id))
+	WITH CHECK (authn.is_plugin_in_organization(plugin_id));
+
+ALTER TABLE "appstore"."plugins_tags" ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY "categories_plugins_select_all" ON "appstore"."categories_plugins"
+	AS PERMISSIVE
+	FOR SELECT
+	TO fun_fundament_api, fun_marketplace_api
+	USING (true);
+
+CREATE POLICY "categories_plugins_write_owner" ON "appstore"."categories_plugins"
+	AS PERMISSIVE
+	FOR ALL
+	TO fun_marketplace_api
+	USING (authn.is_plugin_in_organization(plugin_id))
+	WITH CHECK (authn.is_plugin_in_organization(plugin_id));
+
+ALTER TABLE "appstore"."categories_plugins" ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY "plugin_documentation_links_select_all" ON "appstore"."plugin_documentation_links"
+	AS PERMISSIVE
+	FOR SELECT
+	TO fun_fundament_api, fun_marketplace_api
+	USING (true);
+
+CREATE POLICY "plugin_documentation_links_write_owner" ON "appstore"."plugin_documentation_links"
+	AS PERMISSIVE
+	FOR ALL
+	TO fun_marketplace_api
+	USING (authn.is_plugin_in_organization(plugin_id))
+	WITH CHECK (authn.is_plugin_in_organization(plugin_id));
+
+ALTER TABLE "appstore"."plugin_documentation_links" ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY "plugin_features_select_all" ON "appstore"."plugin_features"
+	AS PERMISSIVE
+	FOR SELECT
+	TO fun_marketplace_api
+	USING (true);
+
+CREATE POLICY "plugin_features_write_owner" ON "appstore"."plugin_features"
+	AS PERMISSIVE
+	FOR ALL
+	TO fun_marketplace_api
+	USING (authn.is_plugin_in_organization(plugin_id))
+	WITH CHECK (authn.is_plugin_in_organization(plugin_id));
+
+ALTER TABLE "appstore"."plugin_features" ENABLE ROW LEVEL SECURITY;
+
+-- The allow-list names other organizations, so it is not served globally.
+CREATE POLICY "plugins_allowed_organizations_owner" ON "appstore"."plugins_allowed_organizations"
+	AS PERMISSIVE
+	FOR ALL
+	TO fun_marketplace_api
+	USING (authn.is_plugin_in_organization(plugin_id))
+	WITH CHECK (authn.is_plugin_in_organization(plugin_id));
+
+ALTER TABLE "appstore"."plugins_allowed_organizations" ENABLE ROW LEVEL SECURITY;
+
+-- Publishers are the organizations that own at least one listing. Nothing else
+-- about tenant.organizations is reachable from the marketplace role.
+CREATE POLICY "organizations_marketplace_api_policy" ON "tenant"."organizations"
+	AS PERMISSIVE
+	FOR SELECT
+	TO fun_marketplace_api
+	USING ((EXISTS (SELECT 1 FROM appstore.plugins WHERE appstore.plugins.organization_id = tenant.organizations.id AND appstore.plugins.deleted IS NULL)));
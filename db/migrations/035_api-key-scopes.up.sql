@@ -0,0 +1,24 @@
+-- Scoped API keys: a key can be limited to projects and/or clusters and to an
+-- allow-list of actions or read-only access. Empty lists mean "unrestricted"
+-- on that dimension, so existing keys keep the full rights of their creator.
+-- authn.api_key_get_by_hash returns the whole row and picks the columns up
+-- without changes.
+
+SET SESSION statement_timeout = 3000;
+SET SESSION lock_timeout = 3000;
+
+ALTER TABLE "authn"."api_keys" ADD COLUMN "scope_project_ids" uuid[] DEFAULT '{}' NOT NULL;
+
+ALTER TABLE "authn"."api_keys" ADD COLUMN "scope_cluster_ids" uuid[] DEFAULT '{}' NOT NULL;
+
+ALTER TABLE "authn"."api_keys" ADD COLUMN "scope_actions" text[] DEFAULT '{}' NOT NULL;
+
+ALTER TABLE "authn"."api_keys" ADD COLUMN "scope_read_only" boolean DEFAULT false NOT NULL;
+
+COMMENT ON COLUMN "authn"."api_keys"."scope_project_ids" IS E'Projects the key is limited to, together with scope_cluster_ids. Both empty means every resource the creator can reach.';
+
+COMMENT ON COLUMN "authn"."api_keys"."scope_cluster_ids" IS E'Clusters the key is limited to; a cluster in scope includes its projects, namespaces and node pools.';
+
+COMMENT ON COLUMN "authn"."api_keys"."scope_actions" IS E'OpenFGA action names (can_view, can_edit, ...) the key may exercise. Empty means every action.';
+
+COMMENT ON COLUMN "authn"."api_keys"."scope_read_only" IS E'Limits the key to can_view and can_list_* actions.';
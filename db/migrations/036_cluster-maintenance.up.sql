@@ -0,0 +1,137 @@
+-- Cluster maintenance: a daily maintenance window and auto-update policy per
+-- cluster (mapped onto the Gardener Shoot maintenance spec), and
+-- tenant.cluster_upgrades to schedule Kubernetes version upgrades that
+-- cluster-worker starts once they are due and inside the window.
+
+SET SESSION statement_timeout = 3000;
+SET SESSION lock_timeout = 3000;
+
+ALTER TABLE "tenant"."clusters" ADD COLUMN "maintenance_window_start" time without time zone;
+
+ALTER TABLE "tenant"."clusters" ADD COLUMN "maintenance_window_end" time without time zone;
+
+ALTER TABLE "tenant"."clusters" ADD COLUMN "auto_update_kubernetes_version" boolean DEFAULT false NOT NULL;
+
+ALTER TABLE "tenant"."clusters" ADD COLUMN "auto_update_machine_image_version" boolean DEFAULT true NOT NULL;
+
+ALTER TABLE "tenant"."clusters" ADD CONSTRAINT "clusters_ck_maintenance_window" CHECK(((maintenance_window_start IS NULL) = (maintenance_window_end IS NULL))) NOT VALID;
+
+ALTER TABLE "tenant"."clusters" VALIDATE CONSTRAINT "clusters_ck_maintenance_window";
+
+COMMENT ON COLUMN "tenant"."clusters"."maintenance_window_start" IS E'Daily maintenance window start (UTC). NULL together with maintenance_window_end leaves the window to Gardener.';
+
+COMMENT ON COLUMN "tenant"."clusters"."maintenance_window_end" IS E'Daily maintenance window end (UTC); may be earlier than the start for a window spanning midnight.';
+
+COMMENT ON COLUMN "tenant"."clusters"."auto_update_kubernetes_version" IS E'Let Gardener apply Kubernetes patch releases in the maintenance window.';
+
+COMMENT ON COLUMN "tenant"."clusters"."auto_update_machine_image_version" IS E'Let Gardener roll nodes onto new machine image versions in the maintenance window.';
+
+/* Hazards:
+ - HAS_UNTRACKABLE_DEPENDENCIES: Dependencies, i.e. other functions used in the function body, of non-sql functions cannot be tracked. As a result, we cannot guarantee that function dependencies are ordered properly relative to this statement. For adds, this means you need to ensure that all functions this function depends on are created/altered before this statement.
+*/
+CREATE OR REPLACE FUNCTION tenant.cluster_outbox_cluster_trigger()
+ RETURNS trigger
+ LANGUAGE plpgsql
+ SECURITY DEFINER COST 1
+AS $function$
+BEGIN
+    IF TG_OP = 'INSERT'
+       OR OLD.deleted IS DISTINCT FROM NEW.deleted
+       OR OLD.region IS DISTINCT FROM NEW.region
+       OR OLD.kubernetes_version IS DISTINCT FROM NEW.kubernetes_version
+       OR OLD.maintenance_window_start IS DISTINCT FROM NEW.maintenance_window_start
+       OR OLD.maintenance_window_end IS DISTINCT FROM NEW.maintenance_window_end
+       OR OLD.auto_update_kubernetes_version IS DISTINCT FROM NEW.auto_update_kubernetes_version
+       OR OLD.auto_update_machine_image_version IS DISTINCT FROM NEW.auto_update_machine_image_version
+    THEN
+        INSERT INTO tenant.cluster_outbox (cluster_id, event, source)
+        VALUES (COALESCE(NEW.id, OLD.id),
+                CASE
+                    WHEN TG_OP = 'INSERT' THEN 'created'
+                    WHEN OLD.deleted IS NULL AND NEW.deleted IS NOT NULL THEN 'deleted'
+                    ELSE 'updated'
+                END,
+                'trigger');
+    END IF;
+    RETURN NEW;
+END;
+$function$
+;
+
+ALTER TABLE "tenant"."cluster_events" DROP CONSTRAINT "cluster_events_ck_event_type";
+
+ALTER TABLE "tenant"."cluster_events" ADD CONSTRAINT "cluster_events_ck_event_type" CHECK((event_type = ANY (ARRAY['sync_requested'::text, 'sync_claimed'::text, 'sync_succeeded'::text, 'sync_failed'::text, 'status_progressing'::text, 'status_ready'::text, 'status_error'::text, 'status_deleted'::text, 'user_sync_succeeded'::text, 'user_sync_failed'::text, 'upgrade_started'::text, 'upgrade_completed'::text, 'upgrade_failed'::text]))) NOT VALID;
+
+ALTER TABLE "tenant"."cluster_events" VALIDATE CONSTRAINT "cluster_events_ck_event_type";
+
+CREATE TABLE "tenant"."cluster_upgrades" (
+	"id" uuid DEFAULT uuidv7() NOT NULL,
+	"cluster_id" uuid NOT NULL,
+	"from_kubernetes_version" text COLLATE "pg_catalog"."default" NOT NULL,
+	"to_kubernetes_version" text COLLATE "pg_catalog"."default" NOT NULL,
+	"to_kubernetes_version_id" uuid,
+	"not_before" timestamp with time zone DEFAULT now() NOT NULL,
+	"skip_maintenance_window" boolean DEFAULT false NOT NULL,
+	"status" text COLLATE "pg_catalog"."default" DEFAULT 'scheduled'::text NOT NULL,
+	"message" text COLLATE "pg_catalog"."default",
+	"created" timestamp with time zone DEFAULT now() NOT NULL,
+	"started" timestamp with time zone,
+	"finished" timestamp with time zone
+);
+
+COMMENT ON TABLE "tenant"."cluster_upgrades" IS E'Kubernetes version upgrades scheduled for a cluster. cluster-worker starts a scheduled upgrade once not_before has passed and the cluster is inside its maintenance window (unless skip_maintenance_window), by setting tenant.clusters.kubernetes_version.';
+
+COMMENT ON COLUMN "tenant"."cluster_upgrades"."to_kubernetes_version_id" IS E'Catalog reference for to_kubernetes_version, resolved when the upgrade is scheduled.';
+
+ALTER TABLE "tenant"."cluster_upgrades" ADD CONSTRAINT "cluster_upgrades_ck_status" CHECK((status = ANY (ARRAY['scheduled'::text, 'in_progress'::text, 'completed'::text, 'failed'::text, 'cancelled'::text])));
+
+ALTER TABLE "tenant"."cluster_upgrades" ENABLE ROW LEVEL SECURITY;
+
+/* Hazards:
+ - AUTHZ_UPDATE: Granting privileges could allow unauthorized access to data.
+*/
+GRANT INSERT ON "tenant"."cluster_upgrades" TO "fun_fundament_api";
+
+GRANT SELECT ON "tenant"."cluster_upgrades" TO "fun_fundament_api";
+
+GRANT UPDATE ON "tenant"."cluster_upgrades" TO "fun_fundament_api";
+
+GRANT SELECT ON "tenant"."cluster_upgrades" TO "fun_cluster_worker";
+
+GRANT UPDATE ON "tenant"."cluster_upgrades" TO "fun_cluster_worker";
+
+CREATE UNIQUE INDEX cluster_upgrades_pk ON tenant.cluster_upgrades USING btree (id);
+
+ALTER TABLE "tenant"."cluster_upgrades" ADD CONSTRAINT "cluster_upgrades_pk" PRIMARY KEY USING INDEX "cluster_upgrades_pk";
+
+CREATE UNIQUE INDEX cluster_upgrades_uq_active ON tenant.cluster_upgrades USING btree (cluster_id) WHERE (status = ANY (ARRAY['scheduled'::text, 'in_progress'::text]));
+
+CREATE INDEX cluster_upgrades_idx_cluster_created ON tenant.cluster_upgrades USING btree (cluster_id DESC NULLS LAST, created DESC NULLS LAST);
+
+ALTER TABLE "tenant"."cluster_upgrades" ADD CONSTRAINT "cluster_upgrades_fk_cluster" FOREIGN KEY (cluster_id) REFERENCES tenant.clusters(id) ON DELETE CASCADE NOT VALID;
+
+ALTER TABLE "tenant"."cluster_upgrades" VALIDATE CONSTRAINT "cluster_upgrades_fk_cluster";
+
+/* Hazards:
+ - AUTHZ_UPDATE: Adding a permissive policy could allow unauthorized access to data.
+*/
+CREATE POLICY "cluster_upgrades_worker_all_access" ON "tenant"."cluster_upgrades"
+	AS PERMISSIVE
+	FOR ALL
+	TO fun_cluster_worker
+	USING (true);
+
+/* Hazards:
+ - AUTHZ_UPDATE: Adding a permissive policy could allow unauthorized access to data.
+*/
+CREATE POLICY "cluster_upgrades_organization_isolation" ON "tenant"."cluster_upgrades"
+	AS PERMISSIVE
+	FOR ALL
+	TO fun_fundament_api
+	USING ((EXISTS ( SELECT 1
+   FROM tenant.clusters c
+  WHERE ((c.id = cluster_upgrades.cluster_id) AND (c.organization_id = authn.current_organization_id())))));
+
+
+-- Statements generated automatically, please review:
+ALTER TABLE tenant.cluster_upgrades OWNER TO fun_owner;
@@ -0,0 +1,137 @@
+-- Cluster hibernation: a manually requested hibernation state on
+-- tenant.clusters and cron-style schedules in tenant.cluster_hibernation_schedules,
+-- both mapped onto the Gardener Shoot hibernation spec by cluster-worker.
+
+SET SESSION statement_timeout = 3000;
+SET SESSION lock_timeout = 3000;
+
+ALTER TABLE "tenant"."clusters" ADD COLUMN "hibernated" boolean DEFAULT false NOT NULL;
+
+ALTER TABLE "tenant"."clusters" ADD COLUMN "hibernation_requested" timestamp with time zone;
+
+COMMENT ON COLUMN "tenant"."clusters"."hibernated" IS E'Hibernation state last requested through HibernateCluster/WakeCluster. Hibernation schedules may change the actual state in between.';
+
+COMMENT ON COLUMN "tenant"."clusters"."hibernation_requested" IS E'When hibernated was last set. cluster-worker records it on the Shoot so a later sync does not undo a schedule-driven state change.';
+
+/* Hazards:
+ - HAS_UNTRACKABLE_DEPENDENCIES: Dependencies, i.e. other functions used in the function body, of non-sql functions cannot be tracked. As a result, we cannot guarantee that function dependencies are ordered properly relative to this statement. For adds, this means you need to ensure that all functions this function depends on are created/altered before this statement.
+*/
+CREATE OR REPLACE FUNCTION tenant.cluster_outbox_cluster_trigger()
+ RETURNS trigger
+ LANGUAGE plpgsql
+ SECURITY DEFINER COST 1
+AS $function$
+BEGIN
+    IF TG_OP = 'INSERT'
+       OR OLD.deleted IS DISTINCT FROM NEW.deleted
+       OR OLD.region IS DISTINCT FROM NEW.region
+       OR OLD.kubernetes_version IS DISTINCT FROM NEW.kubernetes_version
+       OR OLD.maintenance_window_start IS DISTINCT FROM NEW.maintenance_window_start
+       OR OLD.maintenance_window_end IS DISTINCT FROM NEW.maintenance_window_end
+       OR OLD.auto_update_kubernetes_version IS DISTINCT FROM NEW.auto_update_kubernetes_version
+       OR OLD.auto_update_machine_image_version IS DISTINCT FROM NEW.auto_update_machine_image_version
+       OR OLD.hibernation_requested IS DISTINCT FROM NEW.hibernation_requested
+    THEN
+        INSERT INTO tenant.cluster_outbox (cluster_id, event, source)
+        VALUES (COALESCE(NEW.id, OLD.id),
+                CASE
+                    WHEN TG_OP = 'INSERT' THEN 'created'
+                    WHEN OLD.deleted IS NULL AND NEW.deleted IS NOT NULL THEN 'deleted'
+                    ELSE 'updated'
+                END,
+                'trigger');
+    END IF;
+    RETURN NEW;
+END;
+$function$
+;
+
+ALTER TABLE "tenant"."cluster_events" DROP CONSTRAINT "cluster_events_ck_event_type";
+
+ALTER TABLE "tenant"."cluster_events" ADD CONSTRAINT "cluster_events_ck_event_type" CHECK((event_type = ANY (ARRAY['sync_requested'::text, 'sync_claimed'::text, 'sync_succeeded'::text, 'sync_failed'::text, 'status_progressing'::text, 'status_ready'::text, 'status_error'::text, 'status_deleted'::text, 'user_sync_succeeded'::text, 'user_sync_failed'::text, 'upgrade_started'::text, 'upgrade_completed'::text, 'upgrade_failed'::text, 'hibernation_requested'::text, 'wake_requested'::text, 'status_hibernated'::text]))) NOT VALID;
+
+ALTER TABLE "tenant"."cluster_events" VALIDATE CONSTRAINT "cluster_events_ck_event_type";
+
+CREATE TABLE "tenant"."cluster_hibernation_schedules" (
+	"id" uuid DEFAULT uuidv7() NOT NULL,
+	"cluster_id" uuid NOT NULL,
+	"start" text COLLATE "pg_catalog"."default",
+	"end" text COLLATE "pg_catalog"."default",
+	"location" text COLLATE "pg_catalog"."default" DEFAULT 'UTC'::text NOT NULL,
+	"created" timestamp with time zone DEFAULT now() NOT NULL
+);
+
+COMMENT ON TABLE "tenant"."cluster_hibernation_schedules" IS E'Cron schedules (Gardener Shoot spec.hibernation.schedules) that hibernate a cluster at start and wake it at end, evaluated in location.';
+
+ALTER TABLE "tenant"."cluster_hibernation_schedules" ADD CONSTRAINT "cluster_hibernation_schedules_ck_start_or_end" CHECK(((start IS NOT NULL) OR ("end" IS NOT NULL)));
+
+ALTER TABLE "tenant"."cluster_hibernation_schedules" ENABLE ROW LEVEL SECURITY;
+
+/* Hazards:
+ - AUTHZ_UPDATE: Granting privileges could allow unauthorized access to data.
+*/
+GRANT DELETE ON "tenant"."cluster_hibernation_schedules" TO "fun_fundament_api";
+
+GRANT INSERT ON "tenant"."cluster_hibernation_schedules" TO "fun_fundament_api";
+
+GRANT SELECT ON "tenant"."cluster_hibernation_schedules" TO "fun_fundament_api";
+
+GRANT SELECT ON "tenant"."cluster_hibernation_schedules" TO "fun_cluster_worker";
+
+CREATE UNIQUE INDEX cluster_hibernation_schedules_pk ON tenant.cluster_hibernation_schedules USING btree (id);
+
+ALTER TABLE "tenant"."cluster_hibernation_schedules" ADD CONSTRAINT "cluster_hibernation_schedules_pk" PRIMARY KEY USING INDEX "cluster_hibernation_schedules_pk";
+
+CREATE INDEX cluster_hibernation_schedules_idx_cluster ON tenant.cluster_hibernation_schedules USING btree (cluster_id);
+
+ALTER TABLE "tenant"."cluster_hibernation_schedules" ADD CONSTRAINT "cluster_hibernation_schedules_fk_cluster" FOREIGN KEY (cluster_id) REFERENCES tenant.clusters(id) ON DELETE CASCADE NOT VALID;
+
+ALTER TABLE "tenant"."cluster_hibernation_schedules" VALIDATE CONSTRAINT "cluster_hibernation_schedules_fk_cluster";
+
+/* Hazards:
+ - AUTHZ_UPDATE: Adding a permissive policy could allow unauthorized access to data.
+*/
+CREATE POLICY "cluster_hibernation_schedules_worker_all_access" ON "tenant"."cluster_hibernation_schedules"
+	AS PERMISSIVE
+	FOR ALL
+	TO fun_cluster_worker
+	USING (true);
+
+/* Hazards:
+ - AUTHZ_UPDATE: Adding a permissive policy could allow unauthorized access to data.
+*/
+CREATE POLICY "cluster_hibernation_schedules_organization_isolation" ON "tenant"."cluster_hibernation_schedules"
+	AS PERMISSIVE
+	FOR ALL
+	TO fun_fundament_api
+	USING ((EXISTS ( SELECT 1
+   FROM tenant.clusters c
+  WHERE ((c.id = cluster_hibernation_schedules.cluster_id) AND (c.organization_id = authn.current_organization_id())))));
+
+/* Hazards:
+ - HAS_UNTRACKABLE_DEPENDENCIES: Dependencies, i.e. other functions used in the function body, of non-sql functions cannot be tracked. As a result, we cannot guarantee that function dependencies are ordered properly relative to this statement. For adds, this means you need to ensure that all functions this function depends on are created/altered before this statement.
+*/
+CREATE OR REPLACE FUNCTION tenant.cluster_hibernation_schedule_outbox_trigger()
+ RETURNS trigger
+ LANGUAGE plpgsql
+ SECURITY DEFINER COST 1
+AS $function$
+DECLARE
+    schedule_cluster_id uuid := COALESCE(NEW.cluster_id, OLD.cluster_id);
+BEGIN
+    -- Rows removed by a cascading cluster delete have nothing left to sync.
+    IF EXISTS (SELECT 1 FROM tenant.clusters WHERE tenant.clusters.id = schedule_cluster_id) THEN
+        INSERT INTO tenant.cluster_outbox (cluster_id, event, source)
+        VALUES (schedule_cluster_id, 'updated', 'trigger');
+    END IF;
+    RETURN NULL;
+END;
+$function$
+;
+
+CREATE TRIGGER cluster_hibernation_schedule_outbox AFTER INSERT OR DELETE OR UPDATE ON tenant.cluster_hibernation_schedules FOR EACH ROW EXECUTE FUNCTION tenant.cluster_hibernation_schedule_outbox_trigger();
+
+
+-- Statements generated automatically, please review:
+ALTER TABLE tenant.cluster_hibernation_schedules OWNER TO fun_owner;
+ALTER FUNCTION tenant.cluster_hibernation_schedule_outbox_trigger() OWNER TO fun_owner;
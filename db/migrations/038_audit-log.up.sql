@@ -0,0 +1,147 @@
+-- Audit log: tenant.audit_events records who did what through
+-- organization-api, dcim-api and kube-api-proxy. Rows are only ever inserted;
+-- updates, deletes and truncates are rejected. Adds the fun_kube_api_proxy
+-- role, which may only append kube-api-proxy events.
+
+SET SESSION statement_timeout = 3000;
+SET SESSION lock_timeout = 3000;
+
+CREATE TABLE "tenant"."audit_events" (
+	"id" uuid DEFAULT uuidv7() NOT NULL,
+	"source" text COLLATE "pg_catalog"."default" NOT NULL,
+	"organization_id" uuid,
+	"cluster_id" uuid,
+	"actor_user_id" uuid,
+	"action" text COLLATE "pg_catalog"."default" NOT NULL,
+	"resource_type" text COLLATE "pg_catalog"."default",
+	"resource_id" text COLLATE "pg_catalog"."default",
+	"outcome" text COLLATE "pg_catalog"."default" NOT NULL,
+	"error_code" text COLLATE "pg_catalog"."default",
+	"details" jsonb,
+	"remote_addr" text COLLATE "pg_catalog"."default",
+	"user_agent" text COLLATE "pg_catalog"."default",
+	"created" timestamp with time zone DEFAULT now() NOT NULL
+);
+
+COMMENT ON TABLE "tenant"."audit_events" IS E'Append-only audit log of mutating API calls and Kubernetes API requests. Deliberately has no foreign keys: events must outlive the users, clusters and organizations they mention.';
+
+COMMENT ON COLUMN "tenant"."audit_events"."organization_id" IS E'Organization the event belongs to; filled in from cluster_id on insert when the writer only knows the cluster. NULL for dcim-api events, which are not tied to an organization.';
+
+COMMENT ON COLUMN "tenant"."audit_events"."actor_user_id" IS E'tenant.users.id for organization-api and kube-api-proxy events, dcim.users.id for dcim-api events.';
+
+COMMENT ON COLUMN "tenant"."audit_events"."action" IS E'Connect procedure (e.g. /organization.v1.ClusterService/DeleteCluster) or Kubernetes verb (e.g. kube:delete).';
+
+COMMENT ON COLUMN "tenant"."audit_events"."error_code" IS E'Connect error code or HTTP status of a failed or denied request.';
+
+ALTER TABLE "tenant"."audit_events" ADD CONSTRAINT "audit_events_ck_source" CHECK((source = ANY (ARRAY['organization_api'::text, 'dcim_api'::text, 'kube_api_proxy'::text])));
+
+ALTER TABLE "tenant"."audit_events" ADD CONSTRAINT "audit_events_ck_outcome" CHECK((outcome = ANY (ARRAY['succeeded'::text, 'failed'::text, 'denied'::text])));
+
+ALTER TABLE "tenant"."audit_events" ENABLE ROW LEVEL SECURITY;
+
+/* Hazards:
+ - AUTHZ_UPDATE: Granting privileges could allow unauthorized access to data.
+*/
+GRANT INSERT ON "tenant"."audit_events" TO "fun_fundament_api";
+
+GRANT SELECT ON "tenant"."audit_events" TO "fun_fundament_api";
+
+GRANT INSERT ON "tenant"."audit_events" TO "fun_dcim_api";
+
+GRANT INSERT ON "tenant"."audit_events" TO "fun_kube_api_proxy";
+
+CREATE UNIQUE INDEX audit_events_pk ON tenant.audit_events USING btree (id);
+
+ALTER TABLE "tenant"."audit_events" ADD CONSTRAINT "audit_events_pk" PRIMARY KEY USING INDEX "audit_events_pk";
+
+CREATE INDEX audit_events_idx_organization_created ON tenant.audit_events USING btree (organization_id, created DESC NULLS LAST, id DESC NULLS LAST);
+
+CREATE INDEX audit_events_idx_actor_created ON tenant.audit_events USING btree (actor_user_id, created DESC NULLS LAST);
+
+CREATE INDEX audit_events_idx_resource ON tenant.audit_events USING btree (resource_id);
+
+/* Hazards:
+ - AUTHZ_UPDATE: Adding a permissive policy could allow unauthorized access to data.
+*/
+CREATE POLICY "audit_events_api_insert" ON "tenant"."audit_events"
+	AS PERMISSIVE
+	FOR INSERT
+	TO fun_fundament_api
+	WITH CHECK ((source = 'organization_api'::text));
+
+/* Hazards:
+ - AUTHZ_UPDATE: Adding a permissive policy could allow unauthorized access to data.
+*/
+CREATE POLICY "audit_events_organization_isolation" ON "tenant"."audit_events"
+	AS PERMISSIVE
+	FOR SELECT
+	TO fun_fundament_api
+	USING ((organization_id = authn.current_organization_id()));
+
+/* Hazards:
+ - AUTHZ_UPDATE: Adding a permissive policy could allow unauthorized access to data.
+*/
+CREATE POLICY "audit_events_dcim_insert" ON "tenant"."audit_events"
+	AS PERMISSIVE
+	FOR INSERT
+	TO fun_dcim_api
+	WITH CHECK ((source = 'dcim_api'::text));
+
+/* Hazards:
+ - AUTHZ_UPDATE: Adding a permissive policy could allow unauthorized access to data.
+*/
+CREATE POLICY "audit_events_kube_api_proxy_insert" ON "tenant"."audit_events"
+	AS PERMISSIVE
+	FOR INSERT
+	TO fun_kube_api_proxy
+	WITH CHECK ((source = 'kube_api_proxy'::text));
+
+/* Hazards:
+ - HAS_UNTRACKABLE_DEPENDENCIES: Dependencies, i.e. other functions used in the function body, of non-sql functions cannot be tracked. As a result, we cannot guarantee that function dependencies are ordered properly relative to this statement. For adds, this means you need to ensure that all functions this function depends on are created/altered before this statement.
+*/
+CREATE OR REPLACE FUNCTION tenant.audit_events_organization_trigger()
+ RETURNS trigger
+ LANGUAGE plpgsql
+ SECURITY DEFINER COST 1
+AS $function$
+BEGIN
+    -- kube-api-proxy only knows the cluster a request was made against.
+    IF NEW.organization_id IS NULL AND NEW.cluster_id IS NOT NULL THEN
+        SELECT tenant.clusters.organization_id INTO NEW.organization_id
+        FROM tenant.clusters
+        WHERE tenant.clusters.id = NEW.cluster_id;
+    END IF;
+    RETURN NEW;
+END;
+$function$
+;
+
+/* Hazards:
+ - HAS_UNTRACKABLE_DEPENDENCIES: Dependencies, i.e. other functions used in the function body, of non-sql functions cannot be tracked. As a result, we cannot guarantee that function dependencies are ordered properly relative to this statement. For adds, this means you need to ensure that all functions this function depends on are created/altered before this statement.
+*/
+CREATE OR REPLACE FUNCTION tenant.audit_events_append_only_trigger()
+ RETURNS trigger
+ LANGUAGE plpgsql
+ COST 1
+AS $function$
+BEGIN
+    RAISE EXCEPTION 'tenant.audit_events is append-only (% rejected)', TG_OP;
+END;
+$function$
+;
+
+CREATE TRIGGER audit_events_organization BEFORE INSERT ON tenant.audit_events FOR EACH ROW EXECUTE FUNCTION tenant.audit_events_organization_trigger();
+
+CREATE TRIGGER audit_events_append_only BEFORE DELETE OR UPDATE ON tenant.audit_events FOR EACH ROW EXECUTE FUNCTION tenant.audit_events_append_only_trigger();
+
+CREATE TRIGGER audit_events_append_only_truncate BEFORE TRUNCATE ON tenant.audit_events FOR EACH STATEMENT EXECUTE FUNCTION tenant.audit_events_append_only_trigger();
+
+GRANT USAGE ON SCHEMA tenant TO fun_dcim_api;
+
+GRANT USAGE ON SCHEMA tenant TO fun_kube_api_proxy;
+
+
+-- Statements generated automatically, please review:
+ALTER TABLE tenant.audit_events OWNER TO fun_owner;
+ALTER FUNCTION tenant.audit_events_organization_trigger() OWNER TO fun_owner;
+ALTER FUNCTION tenant.audit_events_append_only_trigger() OWNER TO fun_owner;
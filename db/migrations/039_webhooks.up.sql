@@ -0,0 +1,351 @@
+-- Outbound webhooks: organizations register endpoints in tenant.webhooks;
+-- triggers on cluster_events, the new cluster_plugin_status and
+-- organizations_users queue matching events in tenant.webhook_deliveries,
+-- which cluster-worker delivers with retries.
+
+SET SESSION statement_timeout = 3000;
+SET SESSION lock_timeout = 3000;
+
+CREATE TABLE "tenant"."webhooks" (
+	"id" uuid DEFAULT uuidv7() NOT NULL,
+	"organization_id" uuid NOT NULL,
+	"name" text COLLATE "pg_catalog"."default" NOT NULL,
+	"url" text COLLATE "pg_catalog"."default" NOT NULL,
+	"secret" text COLLATE "pg_catalog"."default" NOT NULL,
+	"event_types" text[] COLLATE "pg_catalog"."default" DEFAULT '{}'::text[] NOT NULL,
+	"enabled" boolean DEFAULT true NOT NULL,
+	"created" timestamp with time zone DEFAULT now() NOT NULL,
+	"deleted" timestamp with time zone
+);
+
+COMMENT ON TABLE "tenant"."webhooks" IS E'Endpoints an organization registered to receive platform events. cluster-worker POSTs each event in tenant.webhook_deliveries to url, signed with secret.';
+
+COMMENT ON COLUMN "tenant"."webhooks"."secret" IS E'HMAC-SHA256 key for the Fundament-Signature header. Only returned when the webhook is created.';
+
+COMMENT ON COLUMN "tenant"."webhooks"."event_types" IS E'Event types delivered to the endpoint; empty means every type.';
+
+ALTER TABLE "tenant"."webhooks" ADD CONSTRAINT "webhooks_ck_event_types" CHECK((event_types <@ ARRAY['cluster_ready'::text, 'cluster_error'::text, 'cluster_deleted'::text, 'cluster_hibernated'::text, 'cluster_sync_failed'::text, 'cluster_upgrade_completed'::text, 'cluster_upgrade_failed'::text, 'plugin_ready'::text, 'plugin_degraded'::text, 'plugin_failed'::text, 'member_invited'::text]));
+
+ALTER TABLE "tenant"."webhooks" ENABLE ROW LEVEL SECURITY;
+
+CREATE UNIQUE INDEX webhooks_pk ON tenant.webhooks USING btree (id);
+
+ALTER TABLE "tenant"."webhooks" ADD CONSTRAINT "webhooks_pk" PRIMARY KEY USING INDEX "webhooks_pk";
+
+CREATE UNIQUE INDEX webhooks_uq_name ON tenant.webhooks USING btree (organization_id, name, deleted) NULLS NOT DISTINCT;
+
+ALTER TABLE "tenant"."webhooks" ADD CONSTRAINT "webhooks_uq_name" UNIQUE USING INDEX "webhooks_uq_name";
+
+ALTER TABLE "tenant"."webhooks" ADD CONSTRAINT "webhooks_fk_organization" FOREIGN KEY (organization_id) REFERENCES tenant.organizations(id) NOT VALID;
+
+ALTER TABLE "tenant"."webhooks" VALIDATE CONSTRAINT "webhooks_fk_organization";
+
+/* Hazards:
+ - AUTHZ_UPDATE: Granting privileges could allow unauthorized access to data.
+*/
+GRANT INSERT ON "tenant"."webhooks" TO "fun_fundament_api";
+
+GRANT SELECT ON "tenant"."webhooks" TO "fun_fundament_api";
+
+GRANT UPDATE ON "tenant"."webhooks" TO "fun_fundament_api";
+
+GRANT SELECT ON "tenant"."webhooks" TO "fun_cluster_worker";
+
+/* Hazards:
+ - AUTHZ_UPDATE: Adding a permissive policy could allow unauthorized access to data.
+*/
+CREATE POLICY "webhooks_organization_isolation" ON "tenant"."webhooks"
+	AS PERMISSIVE
+	FOR ALL
+	TO fun_fundament_api
+	USING (organization_id = authn.current_organization_id());
+
+/* Hazards:
+ - AUTHZ_UPDATE: Adding a permissive policy could allow unauthorized access to data.
+*/
+CREATE POLICY "webhooks_worker_read" ON "tenant"."webhooks"
+	AS PERMISSIVE
+	FOR SELECT
+	TO fun_cluster_worker
+	USING (true);
+
+CREATE TABLE "tenant"."webhook_deliveries" (
+	"id" uuid DEFAULT uuidv7() NOT NULL,
+	"webhook_id" uuid NOT NULL,
+	"event_type" text COLLATE "pg_catalog"."default" NOT NULL,
+	"payload" jsonb NOT NULL,
+	"status" text COLLATE "pg_catalog"."default" DEFAULT 'pending'::text NOT NULL,
+	"attempts" integer DEFAULT 0 NOT NULL,
+	"retry_after" timestamp with time zone,
+	"response_status" integer,
+	"status_info" text COLLATE "pg_catalog"."default",
+	"created" timestamp with time zone DEFAULT now() NOT NULL,
+	"completed" timestamp with time zone
+);
+
+COMMENT ON TABLE "tenant"."webhook_deliveries" IS E'Outbox of webhook events, one row per event and endpoint. Rows are written by triggers on the tables the events come from, so an event is queued in the same transaction that caused it.';
+
+COMMENT ON COLUMN "tenant"."webhook_deliveries"."payload" IS E'Request body, sent as is.';
+
+COMMENT ON COLUMN "tenant"."webhook_deliveries"."response_status" IS E'HTTP status of the last attempt; NULL when the endpoint could not be reached.';
+
+COMMENT ON COLUMN "tenant"."webhook_deliveries"."status_info" IS E'Error of the last failed attempt.';
+
+ALTER TABLE "tenant"."webhook_deliveries" ADD CONSTRAINT "webhook_deliveries_ck_status" CHECK((status = ANY (ARRAY['pending'::text, 'retrying'::text, 'succeeded'::text, 'failed'::text])));
+
+ALTER TABLE "tenant"."webhook_deliveries" ADD CONSTRAINT "webhook_deliveries_ck_event_type" CHECK((event_type = ANY (ARRAY['cluster_ready'::text, 'cluster_error'::text, 'cluster_deleted'::text, 'cluster_hibernated'::text, 'cluster_sync_failed'::text, 'cluster_upgrade_completed'::text, 'cluster_upgrade_failed'::text, 'plugin_ready'::text, 'plugin_degraded'::text, 'plugin_failed'::text, 'member_invited'::text, 'ping'::text])));
+
+ALTER TABLE "tenant"."webhook_deliveries" ENABLE ROW LEVEL SECURITY;
+
+CREATE UNIQUE INDEX webhook_deliveries_pk ON tenant.webhook_deliveries USING btree (id);
+
+ALTER TABLE "tenant"."webhook_deliveries" ADD CONSTRAINT "webhook_deliveries_pk" PRIMARY KEY USING INDEX "webhook_deliveries_pk";
+
+CREATE INDEX webhook_deliveries_idx_pending ON tenant.webhook_deliveries USING btree (id) WHERE (status = ANY (ARRAY['pending'::text, 'retrying'::text]));
+
+CREATE INDEX webhook_deliveries_idx_webhook_created ON tenant.webhook_deliveries USING btree (webhook_id, created DESC, id DESC);
+
+ALTER TABLE "tenant"."webhook_deliveries" ADD CONSTRAINT "webhook_deliveries_fk_webhook" FOREIGN KEY (webhook_id) REFERENCES tenant.webhooks(id) ON DELETE CASCADE NOT VALID;
+
+ALTER TABLE "tenant"."webhook_deliveries" VALIDATE CONSTRAINT "webhook_deliveries_fk_webhook";
+
+/* Hazards:
+ - AUTHZ_UPDATE: Granting privileges could allow unauthorized access to data.
+*/
+GRANT INSERT ON "tenant"."webhook_deliveries" TO "fun_fundament_api";
+
+GRANT SELECT ON "tenant"."webhook_deliveries" TO "fun_fundament_api";
+
+GRANT SELECT ON "tenant"."webhook_deliveries" TO "fun_cluster_worker";
+
+GRANT UPDATE ON "tenant"."webhook_deliveries" TO "fun_cluster_worker";
+
+/* Hazards:
+ - AUTHZ_UPDATE: Adding a permissive policy could allow unauthorized access to data.
+*/
+CREATE POLICY "webhook_deliveries_organization_isolation" ON "tenant"."webhook_deliveries"
+	AS PERMISSIVE
+	FOR ALL
+	TO fun_fundament_api
+	USING (EXISTS (
+    SELECT 1 FROM tenant.webhooks w
+    WHERE w.id = webhook_deliveries.webhook_id
+    AND w.organization_id = authn.current_organization_id()
+));
+
+/* Hazards:
+ - AUTHZ_UPDATE: Adding a permissive policy could allow unauthorized access to data.
+*/
+CREATE POLICY "webhook_deliveries_worker_all_access" ON "tenant"."webhook_deliveries"
+	AS PERMISSIVE
+	FOR ALL
+	TO fun_cluster_worker
+	USING (true);
+
+CREATE TABLE "tenant"."cluster_plugin_status" (
+	"cluster_id" uuid NOT NULL,
+	"name" text COLLATE "pg_catalog"."default" NOT NULL,
+	"phase" text COLLATE "pg_catalog"."default" NOT NULL,
+	"message" text COLLATE "pg_catalog"."default",
+	"updated" timestamp with time zone DEFAULT now() NOT NULL
+);
+
+COMMENT ON TABLE "tenant"."cluster_plugin_status" IS E'Last PluginInstallation phase cluster-worker observed per cluster. Phase changes are fanned out to webhooks.';
+
+COMMENT ON COLUMN "tenant"."cluster_plugin_status"."name" IS E'PluginInstallation metadata.name.';
+
+ALTER TABLE "tenant"."cluster_plugin_status" ENABLE ROW LEVEL SECURITY;
+
+CREATE UNIQUE INDEX cluster_plugin_status_pk ON tenant.cluster_plugin_status USING btree (cluster_id, name);
+
+ALTER TABLE "tenant"."cluster_plugin_status" ADD CONSTRAINT "cluster_plugin_status_pk" PRIMARY KEY USING INDEX "cluster_plugin_status_pk";
+
+ALTER TABLE "tenant"."cluster_plugin_status" ADD CONSTRAINT "cluster_plugin_status_fk_cluster" FOREIGN KEY (cluster_id) REFERENCES tenant.clusters(id) ON DELETE CASCADE NOT VALID;
+
+ALTER TABLE "tenant"."cluster_plugin_status" VALIDATE CONSTRAINT "cluster_plugin_status_fk_cluster";
+
+/* Hazards:
+ - AUTHZ_UPDATE: Granting privileges could allow unauthorized access to data.
+*/
+GRANT DELETE ON "tenant"."cluster_plugin_status" TO "fun_cluster_worker";
+
+GRANT INSERT ON "tenant"."cluster_plugin_status" TO "fun_cluster_worker";
+
+GRANT SELECT ON "tenant"."cluster_plugin_status" TO "fun_cluster_worker";
+
+GRANT UPDATE ON "tenant"."cluster_plugin_status" TO "fun_cluster_worker";
+
+/* Hazards:
+ - AUTHZ_UPDATE: Adding a permissive policy could allow unauthorized access to data.
+*/
+CREATE POLICY "cluster_plugin_status_worker_all_access" ON "tenant"."cluster_plugin_status"
+	AS PERMISSIVE
+	FOR ALL
+	TO fun_cluster_worker
+	USING (true);
+
+/* Hazards:
+ - HAS_UNTRACKABLE_DEPENDENCIES: Dependencies, i.e. other functions used in the function body, of non-sql functions cannot be tracked. As a result, we cannot guarantee that function dependencies are ordered properly relative to this statement. For adds, this means you need to ensure that all functions this function depends on are created/altered before this statement.
+*/
+CREATE OR REPLACE FUNCTION tenant.webhook_enqueue(p_organization_id uuid, p_event_type text, p_data jsonb)
+ RETURNS void
+ LANGUAGE plpgsql
+ SECURITY DEFINER COST 1
+AS $function$
+BEGIN
+    INSERT INTO tenant.webhook_deliveries (webhook_id, event_type, payload)
+    SELECT tenant.webhooks.id,
+           p_event_type,
+           jsonb_build_object(
+               'type', p_event_type,
+               'organization_id', p_organization_id,
+               'created', now(),
+               'data', p_data)
+    FROM tenant.webhooks
+    WHERE tenant.webhooks.organization_id = p_organization_id
+      AND tenant.webhooks.deleted IS NULL
+      AND tenant.webhooks.enabled
+      AND (cardinality(tenant.webhooks.event_types) = 0
+           OR p_event_type = ANY (tenant.webhooks.event_types));
+END;
+$function$
+;
+
+/* Hazards:
+ - HAS_UNTRACKABLE_DEPENDENCIES: Dependencies, i.e. other functions used in the function body, of non-sql functions cannot be tracked. As a result, we cannot guarantee that function dependencies are ordered properly relative to this statement. For adds, this means you need to ensure that all functions this function depends on are created/altered before this statement.
+*/
+CREATE OR REPLACE FUNCTION tenant.cluster_events_webhook_trigger()
+ RETURNS trigger
+ LANGUAGE plpgsql
+ SECURITY DEFINER COST 1
+AS $function$
+DECLARE
+    webhook_event text;
+    cluster_organization_id uuid;
+    cluster_name text;
+BEGIN
+    webhook_event := CASE NEW.event_type
+        WHEN 'status_ready' THEN 'cluster_ready'
+        WHEN 'status_error' THEN 'cluster_error'
+        WHEN 'status_deleted' THEN 'cluster_deleted'
+        WHEN 'status_hibernated' THEN 'cluster_hibernated'
+        WHEN 'sync_failed' THEN 'cluster_sync_failed'
+        WHEN 'upgrade_completed' THEN 'cluster_upgrade_completed'
+        WHEN 'upgrade_failed' THEN 'cluster_upgrade_failed'
+    END;
+    IF webhook_event IS NULL THEN
+        RETURN NULL;
+    END IF;
+
+    SELECT tenant.clusters.organization_id, tenant.clusters.name
+    INTO cluster_organization_id, cluster_name
+    FROM tenant.clusters
+    WHERE tenant.clusters.id = NEW.cluster_id;
+
+    PERFORM tenant.webhook_enqueue(cluster_organization_id, webhook_event, jsonb_strip_nulls(jsonb_build_object(
+        'cluster_id', NEW.cluster_id,
+        'cluster_name', cluster_name,
+        'message', NEW.message,
+        'attempt', NEW.attempt)));
+    RETURN NULL;
+END;
+$function$
+;
+
+/* Hazards:
+ - HAS_UNTRACKABLE_DEPENDENCIES: Dependencies, i.e. other functions used in the function body, of non-sql functions cannot be tracked. As a result, we cannot guarantee that function dependencies are ordered properly relative to this statement. For adds, this means you need to ensure that all functions this function depends on are created/altered before this statement.
+*/
+CREATE OR REPLACE FUNCTION tenant.cluster_plugin_status_webhook_trigger()
+ RETURNS trigger
+ LANGUAGE plpgsql
+ SECURITY DEFINER COST 1
+AS $function$
+DECLARE
+    webhook_event text;
+    cluster_organization_id uuid;
+    cluster_name text;
+BEGIN
+    IF TG_OP = 'UPDATE' AND OLD.phase = NEW.phase THEN
+        RETURN NULL;
+    END IF;
+
+    webhook_event := CASE NEW.phase
+        WHEN 'Running' THEN 'plugin_ready'
+        WHEN 'Degraded' THEN 'plugin_degraded'
+        WHEN 'Failed' THEN 'plugin_failed'
+    END;
+    -- An installation that is already running when it is first seen did not
+    -- just become ready; only a transition into Running is reported.
+    IF webhook_event IS NULL OR (TG_OP = 'INSERT' AND NEW.phase = 'Running') THEN
+        RETURN NULL;
+    END IF;
+
+    SELECT tenant.clusters.organization_id, tenant.clusters.name
+    INTO cluster_organization_id, cluster_name
+    FROM tenant.clusters
+    WHERE tenant.clusters.id = NEW.cluster_id;
+
+    PERFORM tenant.webhook_enqueue(cluster_organization_id, webhook_event, jsonb_strip_nulls(jsonb_build_object(
+        'cluster_id', NEW.cluster_id,
+        'cluster_name', cluster_name,
+        'plugin_installation', NEW.name,
+        'phase', NEW.phase,
+        'message', NEW.message)));
+    RETURN NULL;
+END;
+$function$
+;
+
+/* Hazards:
+ - HAS_UNTRACKABLE_DEPENDENCIES: Dependencies, i.e. other functions used in the function body, of non-sql functions cannot be tracked. As a result, we cannot guarantee that function dependencies are ordered properly relative to this statement. For adds, this means you need to ensure that all functions this function depends on are created/altered before this statement.
+*/
+CREATE OR REPLACE FUNCTION tenant.organizations_users_webhook_trigger()
+ RETURNS trigger
+ LANGUAGE plpgsql
+ SECURITY DEFINER COST 1
+AS $function$
+BEGIN
+    IF NEW.status = 'pending' THEN
+        PERFORM tenant.webhook_enqueue(NEW.organization_id, 'member_invited', jsonb_strip_nulls(jsonb_build_object(
+            'user_id', NEW.user_id,
+            'email', (SELECT tenant.users.email FROM tenant.users WHERE tenant.users.id = NEW.user_id),
+            'permission', NEW.permission)));
+    END IF;
+    RETURN NULL;
+END;
+$function$
+;
+
+/* Hazards:
+ - HAS_UNTRACKABLE_DEPENDENCIES: Dependencies, i.e. other functions used in the function body, of non-sql functions cannot be tracked. As a result, we cannot guarantee that function dependencies are ordered properly relative to this statement. For adds, this means you need to ensure that all functions this function depends on are created/altered before this statement.
+*/
+CREATE OR REPLACE FUNCTION tenant.webhook_deliveries_notify()
+ RETURNS trigger
+ LANGUAGE plpgsql
+ COST 1
+AS $function$
+BEGIN
+    PERFORM pg_notify('webhook_deliveries', '');
+    RETURN NEW;
+END;
+$function$
+;
+
+CREATE TRIGGER cluster_events_webhook AFTER INSERT ON tenant.cluster_events FOR EACH ROW EXECUTE FUNCTION tenant.cluster_events_webhook_trigger();
+
+CREATE TRIGGER cluster_plugin_status_webhook AFTER INSERT OR UPDATE ON tenant.cluster_plugin_status FOR EACH ROW EXECUTE FUNCTION tenant.cluster_plugin_status_webhook_trigger();
+
+CREATE TRIGGER organizations_users_webhook AFTER INSERT ON tenant.organizations_users FOR EACH ROW EXECUTE FUNCTION tenant.organizations_users_webhook_trigger();
+
+CREATE TRIGGER webhook_deliveries_notify AFTER INSERT ON tenant.webhook_deliveries FOR EACH ROW EXECUTE FUNCTION tenant.webhook_deliveries_notify();
+
+
+-- Statements generated automatically, please review:
+ALTER TABLE tenant.webhooks OWNER TO fun_owner;
+ALTER TABLE tenant.webhook_deliveries OWNER TO fun_owner;
+ALTER TABLE tenant.cluster_plugin_status OWNER TO fun_owner;
+ALTER FUNCTION tenant.webhook_enqueue(uuid,text,jsonb) OWNER TO fun_owner;
+ALTER FUNCTION tenant.cluster_events_webhook_trigger() OWNER TO fun_owner;
+ALTER FUNCTION tenant.cluster_plugin_status_webhook_trigger() OWNER TO fun_owner;
+ALTER FUNCTION tenant.organizations_users_webhook_trigger() OWNER TO fun_owner;
+ALTER FUNCTION tenant.webhook_deliveries_notify() OWNER TO fun_owner;
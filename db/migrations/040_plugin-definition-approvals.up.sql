@@ -0,0 +1,60 @@
+-- Plugin upgrade consent: an organization admin approves a plugin definition
+-- that adds permissions before plugin-controller rolls an installation over
+-- to it.
+
+SET SESSION statement_timeout = 3000;
+SET SESSION lock_timeout = 3000;
+
+CREATE TABLE "tenant"."plugin_definition_approvals" (
+	"id" uuid DEFAULT uuidv7() NOT NULL,
+	"organization_id" uuid NOT NULL,
+	"plugin_definition_id" uuid NOT NULL,
+	"user_id" uuid NOT NULL,
+	"created" timestamp with time zone DEFAULT now() NOT NULL
+);
+
+COMMENT ON TABLE "tenant"."plugin_definition_approvals" IS E'Plugin definitions an organization admin approved as upgrade targets. plugin-controller does not roll a PluginInstallation over to a definition that adds permissions until it is approved here.';
+
+COMMENT ON COLUMN "tenant"."plugin_definition_approvals"."user_id" IS E'The admin who approved the definition.';
+
+ALTER TABLE "tenant"."plugin_definition_approvals" ENABLE ROW LEVEL SECURITY;
+
+CREATE UNIQUE INDEX plugin_definition_approvals_pk ON tenant.plugin_definition_approvals USING btree (id);
+
+ALTER TABLE "tenant"."plugin_definition_approvals" ADD CONSTRAINT "plugin_definition_approvals_pk" PRIMARY KEY USING INDEX "plugin_definition_approvals_pk";
+
+CREATE UNIQUE INDEX plugin_definition_approvals_uq_definition ON tenant.plugin_definition_approvals USING btree (organization_id, plugin_definition_id);
+
+ALTER TABLE "tenant"."plugin_definition_approvals" ADD CONSTRAINT "plugin_definition_approvals_uq_definition" UNIQUE USING INDEX "plugin_definition_approvals_uq_definition";
+
+ALTER TABLE "tenant"."plugin_definition_approvals" ADD CONSTRAINT "plugin_definition_approvals_fk_organization" FOREIGN KEY (organization_id) REFERENCES tenant.organizations(id) NOT VALID;
+
+ALTER TABLE "tenant"."plugin_definition_approvals" VALIDATE CONSTRAINT "plugin_definition_approvals_fk_organization";
+
+ALTER TABLE "tenant"."plugin_definition_approvals" ADD CONSTRAINT "plugin_definition_approvals_fk_plugin_definition" FOREIGN KEY (plugin_definition_id) REFERENCES appstore.plugin_definitions(id) NOT VALID;
+
+ALTER TABLE "tenant"."plugin_definition_approvals" VALIDATE CONSTRAINT "plugin_definition_approvals_fk_plugin_definition";
+
+ALTER TABLE "tenant"."plugin_definition_approvals" ADD CONSTRAINT "plugin_definition_approvals_fk_user" FOREIGN KEY (user_id) REFERENCES tenant.users(id) NOT VALID;
+
+ALTER TABLE "tenant"."plugin_definition_approvals" VALIDATE CONSTRAINT "plugin_definition_approvals_fk_user";
+
+/* Hazards:
+ - AUTHZ_UPDATE: Granting privileges could allow unauthorized access to data.
+*/
+GRANT INSERT ON "tenant"."plugin_definition_approvals" TO "fun_fundament_api";
+
+GRANT SELECT ON "tenant"."plugin_definition_approvals" TO "fun_fundament_api";
+
+/* Hazards:
+ - AUTHZ_UPDATE: Adding a permissive policy could allow unauthorized access to data.
+*/
+CREATE POLICY "plugin_definition_approvals_organization_isolation" ON "tenant"."plugin_definition_approvals"
+	AS PERMISSIVE
+	FOR ALL
+	TO fun_fundament_api
+	USING (organization_id = authn.current_organization_id());
+
+
+-- Statements generated automatically, please review:
+ALTER TABLE tenant.plugin_definition_approvals OWNER TO fun_owner;
@@ -0,0 +1,163 @@
+-- Namespace role bindings: a project member gets a fixed Kubernetes role
+-- (deploy, view_pods, view_logs, manage_services) in the project's namespaces
+-- whose name matches a pattern. authz-worker mirrors them as namespace
+-- relations in OpenFGA; cluster-worker materialises them as Roles and
+-- RoleBindings in the shoot.
+
+SET SESSION statement_timeout = 3000;
+SET SESSION lock_timeout = 3000;
+
+CREATE OR REPLACE FUNCTION tenant.namespace_matches_pattern(p_name text, p_pattern text)
+ RETURNS boolean
+ LANGUAGE sql
+ IMMUTABLE PARALLEL SAFE COST 1
+AS $function$
+SELECT p_name LIKE replace(p_pattern, '*', '%')
+$function$
+;
+
+CREATE TABLE "tenant"."namespace_role_bindings" (
+	"id" uuid DEFAULT uuidv7() NOT NULL,
+	"project_id" uuid NOT NULL,
+	"project_member_id" uuid NOT NULL,
+	"namespace_pattern" text COLLATE "pg_catalog"."default" NOT NULL,
+	"role" text COLLATE "pg_catalog"."default" NOT NULL,
+	"created" timestamp with time zone DEFAULT now() NOT NULL,
+	"deleted" timestamp with time zone
+);
+
+COMMENT ON TABLE "tenant"."namespace_role_bindings" IS E'Kubernetes roles a project member holds in the project''s namespaces. A binding applies to every namespace of the project whose name matches namespace_pattern.';
+
+COMMENT ON COLUMN "tenant"."namespace_role_bindings"."namespace_pattern" IS E'A namespace name, or a pattern where * matches any run of characters (staging-*, *).';
+
+ALTER TABLE "tenant"."namespace_role_bindings" ADD CONSTRAINT "namespace_role_bindings_ck_role" CHECK (role IN ('deploy', 'view_pods', 'view_logs', 'manage_services'));
+
+ALTER TABLE "tenant"."namespace_role_bindings" ADD CONSTRAINT "namespace_role_bindings_ck_namespace_pattern" CHECK ((namespace_pattern ~ '^[a-z0-9*][-a-z0-9*]{0,62}$'::text));
+
+ALTER TABLE "tenant"."namespace_role_bindings" ENABLE ROW LEVEL SECURITY;
+
+CREATE UNIQUE INDEX namespace_role_bindings_pk ON tenant.namespace_role_bindings USING btree (id);
+
+ALTER TABLE "tenant"."namespace_role_bindings" ADD CONSTRAINT "namespace_role_bindings_pk" PRIMARY KEY USING INDEX "namespace_role_bindings_pk";
+
+CREATE UNIQUE INDEX namespace_role_bindings_uq_binding ON tenant.namespace_role_bindings USING btree (project_member_id, namespace_pattern, role, deleted) NULLS NOT DISTINCT;
+
+ALTER TABLE "tenant"."namespace_role_bindings" ADD CONSTRAINT "namespace_role_bindings_uq_binding" UNIQUE USING INDEX "namespace_role_bindings_uq_binding";
+
+CREATE INDEX namespace_role_bindings_idx_project_id ON tenant.namespace_role_bindings USING btree (project_id) WHERE (deleted IS NULL);
+
+ALTER TABLE "tenant"."namespace_role_bindings" ADD CONSTRAINT "namespace_role_bindings_fk_project" FOREIGN KEY (project_id) REFERENCES tenant.projects(id) NOT VALID;
+
+ALTER TABLE "tenant"."namespace_role_bindings" VALIDATE CONSTRAINT "namespace_role_bindings_fk_project";
+
+ALTER TABLE "tenant"."namespace_role_bindings" ADD CONSTRAINT "namespace_role_bindings_fk_project_member" FOREIGN KEY (project_member_id) REFERENCES tenant.project_members(id) NOT VALID;
+
+ALTER TABLE "tenant"."namespace_role_bindings" VALIDATE CONSTRAINT "namespace_role_bindings_fk_project_member";
+
+/* Hazards:
+ - AUTHZ_UPDATE: Granting privileges could allow unauthorized access to data.
+*/
+GRANT SELECT, INSERT, UPDATE ON "tenant"."namespace_role_bindings" TO "fun_fundament_api";
+
+GRANT SELECT ON "tenant"."namespace_role_bindings" TO "fun_authz_worker";
+
+GRANT SELECT ON "tenant"."namespace_role_bindings" TO "fun_cluster_worker";
+
+/* Hazards:
+ - AUTHZ_UPDATE: Adding a permissive policy could allow unauthorized access to data.
+*/
+CREATE POLICY "namespace_role_bindings_organization_policy" ON "tenant"."namespace_role_bindings"
+	AS PERMISSIVE
+	FOR ALL
+	TO fun_fundament_api
+	USING (authn.is_project_in_organization(project_id));
+
+/* Hazards:
+ - AUTHZ_UPDATE: Adding a permissive policy could allow unauthorized access to data.
+*/
+CREATE POLICY "namespace_role_bindings_cluster_worker_policy" ON "tenant"."namespace_role_bindings"
+	AS PERMISSIVE
+	FOR SELECT
+	TO fun_cluster_worker
+	USING (true);
+
+-- OpenFGA: authz-worker recomputes the namespace relations of the binding's
+-- user for every namespace the pattern matches.
+ALTER TABLE "authz"."outbox" ADD COLUMN "namespace_role_binding_id" uuid;
+
+ALTER TABLE "authz"."outbox" DROP CONSTRAINT "outbox_ck_single_fk";
+
+ALTER TABLE "authz"."outbox" ADD CONSTRAINT "outbox_ck_single_fk" CHECK (num_nonnulls(
+	project_id,
+	project_member_id,
+	cluster_id,
+	node_pool_id,
+	namespace_id,
+	api_key_id,
+	organization_user_id,
+	plugin_id,
+	namespace_role_binding_id
+) = 1);
+
+ALTER TABLE "authz"."outbox" ADD CONSTRAINT "outbox_fk_namespace_role_binding" FOREIGN KEY (namespace_role_binding_id) REFERENCES tenant.namespace_role_bindings(id) NOT VALID;
+
+ALTER TABLE "authz"."outbox" VALIDATE CONSTRAINT "outbox_fk_namespace_role_binding";
+
+CREATE OR REPLACE FUNCTION authz.namespace_role_bindings_sync_trigger ()
+	RETURNS trigger
+	LANGUAGE plpgsql
+	VOLATILE
+	CALLED ON NULL INPUT
+	SECURITY INVOKER
+	PARALLEL UNSAFE
+	COST 1
+	AS
+$function$
+BEGIN
+    -- Only insert into outbox if this is an INSERT or if data actually changed
+    IF TG_OP = 'INSERT' OR NEW IS DISTINCT FROM OLD THEN
+        INSERT INTO authz.outbox (namespace_role_binding_id)
+        VALUES (COALESCE(NEW.id, OLD.id));
+    END IF;
+    RETURN COALESCE(NEW, OLD);
+END;
+$function$;
+
+CREATE OR REPLACE TRIGGER namespace_role_bindings_outbox
+	AFTER INSERT OR UPDATE
+	ON tenant.namespace_role_bindings
+	FOR EACH ROW
+	EXECUTE PROCEDURE authz.namespace_role_bindings_sync_trigger();
+
+-- Shoot: a binding change re-syncs every active namespace it matches, which
+-- reconciles that namespace's Roles and RoleBindings.
+/* Hazards:
+ - HAS_UNTRACKABLE_DEPENDENCIES: Dependencies, i.e. other functions used in the function body, of non-sql functions cannot be tracked. As a result, we cannot guarantee that function dependencies are ordered properly relative to this statement. For adds, this means you need to ensure that all functions this function depends on are created/altered before this statement.
+*/
+CREATE OR REPLACE FUNCTION tenant.namespace_role_binding_outbox_trigger()
+ RETURNS trigger
+ LANGUAGE plpgsql
+ SECURITY DEFINER COST 1
+AS $function$
+BEGIN
+    IF TG_OP = 'INSERT' OR NEW IS DISTINCT FROM OLD THEN
+        INSERT INTO tenant.cluster_outbox (namespace_id, event, source)
+        SELECT tenant.namespaces.id, 'updated', 'trigger'
+        FROM tenant.namespaces
+        WHERE tenant.namespaces.project_id = NEW.project_id
+          AND tenant.namespaces.deleted IS NULL
+          AND tenant.namespace_matches_pattern(tenant.namespaces.name, NEW.namespace_pattern);
+    END IF;
+    RETURN NULL;
+END;
+$function$
+;
+
+CREATE TRIGGER namespace_role_binding_outbox AFTER INSERT OR UPDATE ON tenant.namespace_role_bindings FOR EACH ROW EXECUTE FUNCTION tenant.namespace_role_binding_outbox_trigger();
+
+
+-- Statements generated automatically, please review:
+ALTER FUNCTION tenant.namespace_matches_pattern(text, text) OWNER TO fun_owner;
+ALTER TABLE tenant.namespace_role_bindings OWNER TO fun_owner;
+ALTER FUNCTION authz.namespace_role_bindings_sync_trigger() OWNER TO fun_owner;
+ALTER FUNCTION tenant.namespace_role_binding_outbox_trigger() OWNER TO fun_owner;
@@ -0,0 +1,299 @@
+-- Teams: named groups of users in an organization that can be granted an
+-- organization role and project roles as a whole. authz-worker mirrors them
+-- in OpenFGA as team#member usersets; authn-api keeps the memberships of
+-- teams linked to an OIDC group in step with the user's groups claim.
+
+SET SESSION statement_timeout = 3000;
+SET SESSION lock_timeout = 3000;
+
+CREATE TABLE "tenant"."teams" (
+	"id" uuid DEFAULT uuidv7() NOT NULL,
+	"organization_id" uuid NOT NULL,
+	"name" text COLLATE "pg_catalog"."default" NOT NULL,
+	"permission" text COLLATE "pg_catalog"."default",
+	"oidc_group" text COLLATE "pg_catalog"."default",
+	"created" timestamp with time zone DEFAULT now() NOT NULL,
+	"deleted" timestamp with time zone
+);
+
+COMMENT ON TABLE "tenant"."teams" IS E'Groups of organization members that are granted roles as a whole.';
+
+COMMENT ON COLUMN "tenant"."teams"."permission" IS E'Organization role of every team member, or NULL when the team only has project roles.';
+
+COMMENT ON COLUMN "tenant"."teams"."oidc_group" IS E'OIDC group whose members join the team when they log in and leave it when the group is no longer in their claims.';
+
+ALTER TABLE "tenant"."teams" ADD CONSTRAINT "teams_ck_name" CHECK ((name ~ '^[a-z][a-z0-9-]*[a-z0-9]$'::text));
+
+ALTER TABLE "tenant"."teams" ADD CONSTRAINT "teams_ck_permission" CHECK (permission IN ('admin', 'viewer'));
+
+ALTER TABLE "tenant"."teams" ENABLE ROW LEVEL SECURITY;
+
+CREATE UNIQUE INDEX teams_pk ON tenant.teams USING btree (id);
+
+ALTER TABLE "tenant"."teams" ADD CONSTRAINT "teams_pk" PRIMARY KEY USING INDEX "teams_pk";
+
+CREATE UNIQUE INDEX teams_uq_organization_name ON tenant.teams USING btree (organization_id, name, deleted) NULLS NOT DISTINCT;
+
+ALTER TABLE "tenant"."teams" ADD CONSTRAINT "teams_uq_organization_name" UNIQUE USING INDEX "teams_uq_organization_name";
+
+CREATE INDEX teams_idx_oidc_group ON tenant.teams USING btree (oidc_group) WHERE ((oidc_group IS NOT NULL) AND (deleted IS NULL));
+
+ALTER TABLE "tenant"."teams" ADD CONSTRAINT "teams_fk_organization" FOREIGN KEY (organization_id) REFERENCES tenant.organizations(id) NOT VALID;
+
+ALTER TABLE "tenant"."teams" VALIDATE CONSTRAINT "teams_fk_organization";
+
+CREATE TABLE "tenant"."team_members" (
+	"id" uuid DEFAULT uuidv7() NOT NULL,
+	"team_id" uuid NOT NULL,
+	"user_id" uuid NOT NULL,
+	"source" text COLLATE "pg_catalog"."default" DEFAULT 'manual'::text NOT NULL,
+	"created" timestamp with time zone DEFAULT now() NOT NULL,
+	"deleted" timestamp with time zone
+);
+
+COMMENT ON COLUMN "tenant"."team_members"."source" IS E'manual when added through the API, oidc when derived from the user''s groups claim. Only oidc memberships are removed on login.';
+
+ALTER TABLE "tenant"."team_members" ADD CONSTRAINT "team_members_ck_source" CHECK (source IN ('manual', 'oidc'));
+
+ALTER TABLE "tenant"."team_members" ENABLE ROW LEVEL SECURITY;
+
+CREATE UNIQUE INDEX team_members_pk ON tenant.team_members USING btree (id);
+
+ALTER TABLE "tenant"."team_members" ADD CONSTRAINT "team_members_pk" PRIMARY KEY USING INDEX "team_members_pk";
+
+CREATE UNIQUE INDEX team_members_uq_team_user ON tenant.team_members USING btree (team_id, user_id, deleted) NULLS NOT DISTINCT;
+
+ALTER TABLE "tenant"."team_members" ADD CONSTRAINT "team_members_uq_team_user" UNIQUE USING INDEX "team_members_uq_team_user";
+
+CREATE INDEX team_members_idx_user_id ON tenant.team_members USING btree (user_id) WHERE (deleted IS NULL);
+
+ALTER TABLE "tenant"."team_members" ADD CONSTRAINT "team_members_fk_team" FOREIGN KEY (team_id) REFERENCES tenant.teams(id) NOT VALID;
+
+ALTER TABLE "tenant"."team_members" VALIDATE CONSTRAINT "team_members_fk_team";
+
+ALTER TABLE "tenant"."team_members" ADD CONSTRAINT "team_members_fk_user" FOREIGN KEY (user_id) REFERENCES tenant.users(id) NOT VALID;
+
+ALTER TABLE "tenant"."team_members" VALIDATE CONSTRAINT "team_members_fk_user";
+
+CREATE TABLE "tenant"."project_teams" (
+	"id" uuid DEFAULT uuidv7() NOT NULL,
+	"project_id" uuid NOT NULL,
+	"team_id" uuid NOT NULL,
+	"role" text COLLATE "pg_catalog"."default" NOT NULL,
+	"created" timestamp with time zone DEFAULT now() NOT NULL,
+	"deleted" timestamp with time zone
+);
+
+COMMENT ON TABLE "tenant"."project_teams" IS E'Project roles granted to every member of a team.';
+
+ALTER TABLE "tenant"."project_teams" ADD CONSTRAINT "project_teams_ck_role" CHECK (role IN ('admin', 'viewer'));
+
+ALTER TABLE "tenant"."project_teams" ENABLE ROW LEVEL SECURITY;
+
+CREATE UNIQUE INDEX project_teams_pk ON tenant.project_teams USING btree (id);
+
+ALTER TABLE "tenant"."project_teams" ADD CONSTRAINT "project_teams_pk" PRIMARY KEY USING INDEX "project_teams_pk";
+
+CREATE UNIQUE INDEX project_teams_uq_project_team ON tenant.project_teams USING btree (project_id, team_id, deleted) NULLS NOT DISTINCT;
+
+ALTER TABLE "tenant"."project_teams" ADD CONSTRAINT "project_teams_uq_project_team" UNIQUE USING INDEX "project_teams_uq_project_team";
+
+CREATE INDEX project_teams_idx_team_id ON tenant.project_teams USING btree (team_id) WHERE (deleted IS NULL);
+
+ALTER TABLE "tenant"."project_teams" ADD CONSTRAINT "project_teams_fk_project" FOREIGN KEY (project_id) REFERENCES tenant.projects(id) NOT VALID;
+
+ALTER TABLE "tenant"."project_teams" VALIDATE CONSTRAINT "project_teams_fk_project";
+
+ALTER TABLE "tenant"."project_teams" ADD CONSTRAINT "project_teams_fk_team" FOREIGN KEY (team_id) REFERENCES tenant.teams(id) NOT VALID;
+
+ALTER TABLE "tenant"."project_teams" VALIDATE CONSTRAINT "project_teams_fk_team";
+
+/* Hazards:
+ - AUTHZ_UPDATE: Granting privileges could allow unauthorized access to data.
+*/
+GRANT SELECT, INSERT, UPDATE ON "tenant"."teams" TO "fun_fundament_api";
+
+GRANT SELECT ON "tenant"."teams" TO "fun_authz_worker";
+
+GRANT SELECT ON "tenant"."teams" TO "fun_authn_api";
+
+GRANT SELECT, INSERT, UPDATE ON "tenant"."team_members" TO "fun_fundament_api";
+
+GRANT SELECT ON "tenant"."team_members" TO "fun_authz_worker";
+
+GRANT SELECT, INSERT, UPDATE ON "tenant"."team_members" TO "fun_authn_api";
+
+GRANT SELECT, INSERT, UPDATE ON "tenant"."project_teams" TO "fun_fundament_api";
+
+GRANT SELECT ON "tenant"."project_teams" TO "fun_authz_worker";
+
+/* Hazards:
+ - AUTHZ_UPDATE: Adding a permissive policy could allow unauthorized access to data.
+*/
+CREATE POLICY "teams_organization_policy" ON "tenant"."teams"
+	AS PERMISSIVE
+	FOR ALL
+	TO fun_fundament_api
+	USING ((organization_id = authn.current_organization_id()));
+
+/* Hazards:
+ - AUTHZ_UPDATE: Adding a permissive policy could allow unauthorized access to data.
+*/
+CREATE POLICY "teams_authn_api_policy" ON "tenant"."teams"
+	AS PERMISSIVE
+	FOR SELECT
+	TO fun_authn_api
+	USING (true);
+
+/* Hazards:
+ - AUTHZ_UPDATE: Adding a permissive policy could allow unauthorized access to data.
+*/
+CREATE POLICY "team_members_organization_policy" ON "tenant"."team_members"
+	AS PERMISSIVE
+	FOR ALL
+	TO fun_fundament_api
+	USING ((EXISTS ( SELECT 1
+   FROM tenant.teams
+  WHERE ((teams.id = team_members.team_id) AND (teams.organization_id = authn.current_organization_id())))));
+
+/* Hazards:
+ - AUTHZ_UPDATE: Adding a permissive policy could allow unauthorized access to data.
+*/
+CREATE POLICY "team_members_authn_api_policy" ON "tenant"."team_members"
+	AS PERMISSIVE
+	FOR ALL
+	TO fun_authn_api
+	USING (true);
+
+/* Hazards:
+ - AUTHZ_UPDATE: Adding a permissive policy could allow unauthorized access to data.
+*/
+CREATE POLICY "project_teams_organization_policy" ON "tenant"."project_teams"
+	AS PERMISSIVE
+	FOR ALL
+	TO fun_fundament_api
+	USING (authn.is_project_in_organization(project_id));
+
+-- OpenFGA sync: one outbox row per team, team membership and project
+-- assignment change.
+ALTER TABLE "authz"."outbox" ADD COLUMN "team_id" uuid;
+
+ALTER TABLE "authz"."outbox" ADD COLUMN "team_member_id" uuid;
+
+ALTER TABLE "authz"."outbox" ADD COLUMN "project_team_id" uuid;
+
+ALTER TABLE "authz"."outbox" DROP CONSTRAINT "outbox_ck_single_fk";
+
+ALTER TABLE "authz"."outbox" ADD CONSTRAINT "outbox_ck_single_fk" CHECK (num_nonnulls(
+	project_id,
+	project_member_id,
+	cluster_id,
+	node_pool_id,
+	namespace_id,
+	api_key_id,
+	organization_user_id,
+	plugin_id,
+	namespace_role_binding_id,
+	team_id,
+	team_member_id,
+	project_team_id
+) = 1);
+
+ALTER TABLE "authz"."outbox" ADD CONSTRAINT "outbox_fk_team" FOREIGN KEY (team_id) REFERENCES tenant.teams(id) NOT VALID;
+
+ALTER TABLE "authz"."outbox" VALIDATE CONSTRAINT "outbox_fk_team";
+
+ALTER TABLE "authz"."outbox" ADD CONSTRAINT "outbox_fk_team_member" FOREIGN KEY (team_member_id) REFERENCES tenant.team_members(id) NOT VALID;
+
+ALTER TABLE "authz"."outbox" VALIDATE CONSTRAINT "outbox_fk_team_member";
+
+ALTER TABLE "authz"."outbox" ADD CONSTRAINT "outbox_fk_project_team" FOREIGN KEY (project_team_id) REFERENCES tenant.project_teams(id) NOT VALID;
+
+ALTER TABLE "authz"."outbox" VALIDATE CONSTRAINT "outbox_fk_project_team";
+
+CREATE OR REPLACE FUNCTION authz.teams_sync_trigger ()
+	RETURNS trigger
+	LANGUAGE plpgsql
+	VOLATILE
+	CALLED ON NULL INPUT
+	SECURITY INVOKER
+	PARALLEL UNSAFE
+	COST 1
+	AS
+$function$
+BEGIN
+    -- Only insert into outbox if this is an INSERT or if data actually changed
+    IF TG_OP = 'INSERT' OR NEW IS DISTINCT FROM OLD THEN
+        INSERT INTO authz.outbox (team_id)
+        VALUES (COALESCE(NEW.id, OLD.id));
+    END IF;
+    RETURN COALESCE(NEW, OLD);
+END;
+$function$;
+
+CREATE OR REPLACE FUNCTION authz.team_members_sync_trigger ()
+	RETURNS trigger
+	LANGUAGE plpgsql
+	VOLATILE
+	CALLED ON NULL INPUT
+	SECURITY INVOKER
+	PARALLEL UNSAFE
+	COST 1
+	AS
+$function$
+BEGIN
+    -- Only insert into outbox if this is an INSERT or if data actually changed
+    IF TG_OP = 'INSERT' OR NEW IS DISTINCT FROM OLD THEN
+        INSERT INTO authz.outbox (team_member_id)
+        VALUES (COALESCE(NEW.id, OLD.id));
+    END IF;
+    RETURN COALESCE(NEW, OLD);
+END;
+$function$;
+
+CREATE OR REPLACE FUNCTION authz.project_teams_sync_trigger ()
+	RETURNS trigger
+	LANGUAGE plpgsql
+	VOLATILE
+	CALLED ON NULL INPUT
+	SECURITY INVOKER
+	PARALLEL UNSAFE
+	COST 1
+	AS
+$function$
+BEGIN
+    -- Only insert into outbox if this is an INSERT or if data actually changed
+    IF TG_OP = 'INSERT' OR NEW IS DISTINCT FROM OLD THEN
+        INSERT INTO authz.outbox (project_team_id)
+        VALUES (COALESCE(NEW.id, OLD.id));
+    END IF;
+    RETURN COALESCE(NEW, OLD);
+END;
+$function$;
+
+CREATE OR REPLACE TRIGGER teams_outbox
+	AFTER INSERT OR UPDATE
+	ON tenant.teams
+	FOR EACH ROW
+	EXECUTE PROCEDURE authz.teams_sync_trigger();
+
+CREATE OR REPLACE TRIGGER team_members_outbox
+	AFTER INSERT OR UPDATE
+	ON tenant.team_members
+	FOR EACH ROW
+	EXECUTE PROCEDURE authz.team_members_sync_trigger();
+
+CREATE OR REPLACE TRIGGER project_teams_outbox
+	AFTER INSERT OR UPDATE
+	ON tenant.project_teams
+	FOR EACH ROW
+	EXECUTE PROCEDURE authz.project_teams_sync_trigger();
+
+
+-- Statements generated automatically, please review:
+ALTER TABLE tenant.teams OWNER TO fun_owner;
+ALTER TABLE tenant.team_members OWNER TO fun_owner;
+ALTER TABLE tenant.project_teams OWNER TO fun_owner;
+ALTER FUNCTION authz.teams_sync_trigger() OWNER TO fun_owner;
+ALTER FUNCTION authz.team_members_sync_trigger() OWNER TO fun_owner;
+ALTER FUNCTION authz.project_teams_sync_trigger() OWNER TO fun_owner;
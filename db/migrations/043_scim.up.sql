@@ -0,0 +1,33 @@
+-- SCIM provisioning: authn-api maps SCIM groups onto teams, creating teams
+-- for groups it does not know yet and keeping their memberships in step with
+-- the identity provider.
+
+SET SESSION statement_timeout = 3000;
+SET SESSION lock_timeout = 3000;
+
+ALTER TABLE "tenant"."team_members" DROP CONSTRAINT "team_members_ck_source";
+
+ALTER TABLE "tenant"."team_members" ADD CONSTRAINT "team_members_ck_source" CHECK((source = ANY (ARRAY['manual'::text, 'oidc'::text, 'scim'::text]))) NOT VALID;
+
+ALTER TABLE "tenant"."team_members" VALIDATE CONSTRAINT "team_members_ck_source";
+
+COMMENT ON COLUMN "tenant"."team_members"."source" IS E'manual when added through the API, oidc when derived from the user''s groups claim, scim when pushed by the identity provider. Only oidc memberships are removed on login.';
+
+/* Hazards:
+ - AUTHZ_UPDATE: Granting privileges could allow unauthorized access to data.
+*/
+GRANT INSERT, UPDATE ON "tenant"."teams" TO "fun_authn_api";
+
+/* Hazards:
+ - AUTHZ_UPDATE: Removing a permissive policy could cause queries to fail if not correctly configured.
+*/
+DROP POLICY "teams_authn_api_policy" ON "tenant"."teams";
+
+/* Hazards:
+ - AUTHZ_UPDATE: Adding a permissive policy could allow unauthorized access to data.
+*/
+CREATE POLICY "teams_authn_api_policy" ON "tenant"."teams"
+	AS PERMISSIVE
+	FOR ALL
+	TO fun_authn_api
+	USING (true);
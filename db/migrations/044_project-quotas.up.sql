@@ -0,0 +1,236 @@
+-- Project quotas: project_limits gains ResourceQuota fields that cluster-worker
+-- renders as a fundament-quota ResourceQuota in every namespace of the
+-- project, and namespace_limits holds per-namespace overrides of them.
+
+SET SESSION statement_timeout = 3000;
+SET SESSION lock_timeout = 3000;
+
+ALTER TABLE "tenant"."project_limits" ADD COLUMN "quota_cpu_request_m" integer;
+
+ALTER TABLE "tenant"."project_limits" ADD COLUMN "quota_cpu_limit_m" integer;
+
+ALTER TABLE "tenant"."project_limits" ADD COLUMN "quota_memory_request_mi" integer;
+
+ALTER TABLE "tenant"."project_limits" ADD COLUMN "quota_memory_limit_mi" integer;
+
+ALTER TABLE "tenant"."project_limits" ADD COLUMN "quota_storage_gi" integer;
+
+ALTER TABLE "tenant"."project_limits" ADD COLUMN "quota_pods" integer;
+
+ALTER TABLE "tenant"."project_limits" ADD COLUMN "quota_persistent_volume_claims" integer;
+
+ALTER TABLE "tenant"."project_limits" ADD COLUMN "quota_services" integer;
+
+COMMENT ON COLUMN "tenant"."project_limits"."quota_cpu_request_m" IS E'Total CPU requests of all pods in a namespace (millicores), as the requests.cpu ResourceQuota.';
+
+COMMENT ON COLUMN "tenant"."project_limits"."quota_cpu_limit_m" IS E'Total CPU limits of all pods in a namespace (millicores), as the limits.cpu ResourceQuota.';
+
+COMMENT ON COLUMN "tenant"."project_limits"."quota_memory_request_mi" IS E'Total memory requests of all pods in a namespace (mebibytes), as the requests.memory ResourceQuota.';
+
+COMMENT ON COLUMN "tenant"."project_limits"."quota_memory_limit_mi" IS E'Total memory limits of all pods in a namespace (mebibytes), as the limits.memory ResourceQuota.';
+
+COMMENT ON COLUMN "tenant"."project_limits"."quota_storage_gi" IS E'Total storage requested by the PersistentVolumeClaims in a namespace (gibibytes).';
+
+COMMENT ON COLUMN "tenant"."project_limits"."quota_pods" IS E'Maximum number of pods in a namespace.';
+
+COMMENT ON COLUMN "tenant"."project_limits"."quota_persistent_volume_claims" IS E'Maximum number of PersistentVolumeClaims in a namespace.';
+
+COMMENT ON COLUMN "tenant"."project_limits"."quota_services" IS E'Maximum number of Services in a namespace.';
+
+ALTER TABLE "tenant"."project_limits" ADD CONSTRAINT "project_limits_ck_quota_cpu_request_m" CHECK((quota_cpu_request_m IS NULL OR quota_cpu_request_m > 0)) NOT VALID;
+
+ALTER TABLE "tenant"."project_limits" VALIDATE CONSTRAINT "project_limits_ck_quota_cpu_request_m";
+
+ALTER TABLE "tenant"."project_limits" ADD CONSTRAINT "project_limits_ck_quota_cpu_limit_m" CHECK((quota_cpu_limit_m IS NULL OR quota_cpu_limit_m > 0)) NOT VALID;
+
+ALTER TABLE "tenant"."project_limits" VALIDATE CONSTRAINT "project_limits_ck_quota_cpu_limit_m";
+
+ALTER TABLE "tenant"."project_limits" ADD CONSTRAINT "project_limits_ck_quota_memory_request_mi" CHECK((quota_memory_request_mi IS NULL OR quota_memory_request_mi > 0)) NOT VALID;
+
+ALTER TABLE "tenant"."project_limits" VALIDATE CONSTRAINT "project_limits_ck_quota_memory_request_mi";
+
+ALTER TABLE "tenant"."project_limits" ADD CONSTRAINT "project_limits_ck_quota_memory_limit_mi" CHECK((quota_memory_limit_mi IS NULL OR quota_memory_limit_mi > 0)) NOT VALID;
+
+ALTER TABLE "tenant"."project_limits" VALIDATE CONSTRAINT "project_limits_ck_quota_memory_limit_mi";
+
+ALTER TABLE "tenant"."project_limits" ADD CONSTRAINT "project_limits_ck_quota_storage_gi" CHECK((quota_storage_gi IS NULL OR quota_storage_gi > 0)) NOT VALID;
+
+ALTER TABLE "tenant"."project_limits" VALIDATE CONSTRAINT "project_limits_ck_quota_storage_gi";
+
+ALTER TABLE "tenant"."project_limits" ADD CONSTRAINT "project_limits_ck_quota_pods" CHECK((quota_pods IS NULL OR quota_pods > 0)) NOT VALID;
+
+ALTER TABLE "tenant"."project_limits" VALIDATE CONSTRAINT "project_limits_ck_quota_pods";
+
+ALTER TABLE "tenant"."project_limits" ADD CONSTRAINT "project_limits_ck_quota_persistent_volume_claims" CHECK((quota_persistent_volume_claims IS NULL OR quota_persistent_volume_claims > 0)) NOT VALID;
+
+ALTER TABLE "tenant"."project_limits" VALIDATE CONSTRAINT "project_limits_ck_quota_persistent_volume_claims";
+
+ALTER TABLE "tenant"."project_limits" ADD CONSTRAINT "project_limits_ck_quota_services" CHECK((quota_services IS NULL OR quota_services > 0)) NOT VALID;
+
+ALTER TABLE "tenant"."project_limits" VALIDATE CONSTRAINT "project_limits_ck_quota_services";
+
+ALTER TABLE "tenant"."project_limits" ADD CONSTRAINT "project_limits_ck_quota_cpu_limit_gte_request" CHECK((quota_cpu_limit_m IS NULL OR quota_cpu_request_m IS NULL OR quota_cpu_limit_m >= quota_cpu_request_m)) NOT VALID;
+
+ALTER TABLE "tenant"."project_limits" VALIDATE CONSTRAINT "project_limits_ck_quota_cpu_limit_gte_request";
+
+ALTER TABLE "tenant"."project_limits" ADD CONSTRAINT "project_limits_ck_quota_memory_limit_gte_request" CHECK((quota_memory_limit_mi IS NULL OR quota_memory_request_mi IS NULL OR quota_memory_limit_mi >= quota_memory_request_mi)) NOT VALID;
+
+ALTER TABLE "tenant"."project_limits" VALIDATE CONSTRAINT "project_limits_ck_quota_memory_limit_gte_request";
+
+/* Hazards:
+ - HAS_UNTRACKABLE_DEPENDENCIES: Dependencies, i.e. other functions used in the function body, of non-sql functions cannot be tracked. As a result, we cannot guarantee that function dependencies are ordered properly relative to this statement. For adds, this means you need to ensure that all functions this function depends on are created/altered before this statement.
+*/
+CREATE OR REPLACE FUNCTION tenant.project_limits_outbox_trigger()
+ RETURNS trigger
+ LANGUAGE plpgsql
+ SECURITY DEFINER COST 1
+AS $function$
+BEGIN
+    -- Both the LimitRange defaults and the ResourceQuota of a namespace come
+    -- from these columns. A deleted change only matters when the row carries
+    -- values in OLD or NEW; otherwise the namespace reconcile would be a no-op.
+    IF (TG_OP = 'INSERT' AND num_nonnulls(NEW.default_memory_request_mi, NEW.default_memory_limit_mi,
+                                          NEW.default_cpu_request_m, NEW.default_cpu_limit_m,
+                                          NEW.quota_cpu_request_m, NEW.quota_cpu_limit_m,
+                                          NEW.quota_memory_request_mi, NEW.quota_memory_limit_mi,
+                                          NEW.quota_storage_gi, NEW.quota_pods,
+                                          NEW.quota_persistent_volume_claims, NEW.quota_services) > 0)
+       OR (TG_OP = 'UPDATE' AND ((OLD.default_memory_request_mi, OLD.default_memory_limit_mi,
+                                  OLD.default_cpu_request_m, OLD.default_cpu_limit_m,
+                                  OLD.quota_cpu_request_m, OLD.quota_cpu_limit_m,
+                                  OLD.quota_memory_request_mi, OLD.quota_memory_limit_mi,
+                                  OLD.quota_storage_gi, OLD.quota_pods,
+                                  OLD.quota_persistent_volume_claims, OLD.quota_services)
+                                 IS DISTINCT FROM
+                                 (NEW.default_memory_request_mi, NEW.default_memory_limit_mi,
+                                  NEW.default_cpu_request_m, NEW.default_cpu_limit_m,
+                                  NEW.quota_cpu_request_m, NEW.quota_cpu_limit_m,
+                                  NEW.quota_memory_request_mi, NEW.quota_memory_limit_mi,
+                                  NEW.quota_storage_gi, NEW.quota_pods,
+                                  NEW.quota_persistent_volume_claims, NEW.quota_services)
+                                 OR (OLD.deleted IS DISTINCT FROM NEW.deleted
+                                     AND num_nonnulls(OLD.default_memory_request_mi, OLD.default_memory_limit_mi,
+                                                      OLD.default_cpu_request_m, OLD.default_cpu_limit_m,
+                                                      OLD.quota_cpu_request_m, OLD.quota_cpu_limit_m,
+                                                      OLD.quota_memory_request_mi, OLD.quota_memory_limit_mi,
+                                                      OLD.quota_storage_gi, OLD.quota_pods,
+                                                      OLD.quota_persistent_volume_claims, OLD.quota_services,
+                                                      NEW.default_memory_request_mi, NEW.default_memory_limit_mi,
+                                                      NEW.default_cpu_request_m, NEW.default_cpu_limit_m,
+                                                      NEW.quota_cpu_request_m, NEW.quota_cpu_limit_m,
+                                                      NEW.quota_memory_request_mi, NEW.quota_memory_limit_mi,
+                                                      NEW.quota_storage_gi, NEW.quota_pods,
+                                                      NEW.quota_persistent_volume_claims, NEW.quota_services) > 0)))
+    THEN
+        INSERT INTO tenant.cluster_outbox (namespace_id, event, source)
+        SELECT tenant.namespaces.id, 'updated', 'trigger'
+        FROM tenant.namespaces
+        WHERE tenant.namespaces.project_id = NEW.project_id
+          AND tenant.namespaces.deleted IS NULL;
+    END IF;
+
+    RETURN NULL;
+END;
+$function$
+;
+
+/* Hazards:
+ - HAS_UNTRACKABLE_DEPENDENCIES: Dependencies, i.e. other functions used in the function body, of non-sql functions cannot be tracked. As a result, we cannot guarantee that function dependencies are ordered properly relative to this statement. For adds, this means you need to ensure that all functions this function depends on are created/altered before this statement.
+*/
+CREATE OR REPLACE FUNCTION tenant.namespace_limits_outbox_trigger()
+ RETURNS trigger
+ LANGUAGE plpgsql
+ SECURITY DEFINER COST 1
+AS $function$
+BEGIN
+    -- Every change to an override re-renders the namespace's ResourceQuota;
+    -- overrides are written one row at a time, so there is no fan-out.
+    IF TG_OP = 'INSERT' OR NEW IS DISTINCT FROM OLD THEN
+        INSERT INTO tenant.cluster_outbox (namespace_id, event, source)
+        VALUES (NEW.namespace_id, 'updated', 'trigger');
+    END IF;
+
+    RETURN NULL;
+END;
+$function$
+;
+
+CREATE TABLE "tenant"."namespace_limits" (
+	"id" uuid DEFAULT uuidv7() NOT NULL,
+	"namespace_id" uuid NOT NULL,
+	"quota_cpu_request_m" integer,
+	"quota_cpu_limit_m" integer,
+	"quota_memory_request_mi" integer,
+	"quota_memory_limit_mi" integer,
+	"quota_storage_gi" integer,
+	"quota_pods" integer,
+	"quota_persistent_volume_claims" integer,
+	"quota_services" integer,
+	"created" timestamp with time zone DEFAULT now() NOT NULL,
+	"deleted" timestamp with time zone
+);
+
+COMMENT ON TABLE "tenant"."namespace_limits" IS E'Per-namespace ResourceQuota overrides. Per field the lowest of the namespace and project value applies, so an override can only tighten the project quota.';
+
+ALTER TABLE "tenant"."namespace_limits" ADD CONSTRAINT "namespace_limits_ck_quota_cpu_request_m" CHECK((quota_cpu_request_m IS NULL OR quota_cpu_request_m > 0));
+
+ALTER TABLE "tenant"."namespace_limits" ADD CONSTRAINT "namespace_limits_ck_quota_cpu_limit_m" CHECK((quota_cpu_limit_m IS NULL OR quota_cpu_limit_m > 0));
+
+ALTER TABLE "tenant"."namespace_limits" ADD CONSTRAINT "namespace_limits_ck_quota_memory_request_mi" CHECK((quota_memory_request_mi IS NULL OR quota_memory_request_mi > 0));
+
+ALTER TABLE "tenant"."namespace_limits" ADD CONSTRAINT "namespace_limits_ck_quota_memory_limit_mi" CHECK((quota_memory_limit_mi IS NULL OR quota_memory_limit_mi > 0));
+
+ALTER TABLE "tenant"."namespace_limits" ADD CONSTRAINT "namespace_limits_ck_quota_storage_gi" CHECK((quota_storage_gi IS NULL OR quota_storage_gi > 0));
+
+ALTER TABLE "tenant"."namespace_limits" ADD CONSTRAINT "namespace_limits_ck_quota_pods" CHECK((quota_pods IS NULL OR quota_pods > 0));
+
+ALTER TABLE "tenant"."namespace_limits" ADD CONSTRAINT "namespace_limits_ck_quota_persistent_volume_claims" CHECK((quota_persistent_volume_claims IS NULL OR quota_persistent_volume_claims > 0));
+
+ALTER TABLE "tenant"."namespace_limits" ADD CONSTRAINT "namespace_limits_ck_quota_services" CHECK((quota_services IS NULL OR quota_services > 0));
+
+ALTER TABLE "tenant"."namespace_limits" ADD CONSTRAINT "namespace_limits_ck_quota_cpu_limit_gte_request" CHECK((quota_cpu_limit_m IS NULL OR quota_cpu_request_m IS NULL OR quota_cpu_limit_m >= quota_cpu_request_m));
+
+ALTER TABLE "tenant"."namespace_limits" ADD CONSTRAINT "namespace_limits_ck_quota_memory_limit_gte_request" CHECK((quota_memory_limit_mi IS NULL OR quota_memory_request_mi IS NULL OR quota_memory_limit_mi >= quota_memory_request_mi));
+
+CREATE POLICY "namespace_limits_organization_policy" ON "tenant"."namespace_limits"
+	AS PERMISSIVE
+	FOR ALL
+	TO fun_fundament_api
+	USING (EXISTS (SELECT 1 FROM tenant.namespaces WHERE namespaces.id = namespace_limits.namespace_id AND authn.is_project_in_organization(namespaces.project_id)));
+
+CREATE POLICY "namespace_limits_cluster_worker_read" ON "tenant"."namespace_limits"
+	AS PERMISSIVE
+	FOR SELECT
+	TO fun_cluster_worker
+	USING (true);
+
+ALTER TABLE "tenant"."namespace_limits" ENABLE ROW LEVEL SECURITY;
+
+/* Hazards:
+ - AUTHZ_UPDATE: Granting privileges could allow unauthorized access to data.
+*/
+GRANT SELECT, INSERT, UPDATE ON "tenant"."namespace_limits" TO "fun_fundament_api";
+
+/* Hazards:
+ - AUTHZ_UPDATE: Granting privileges could allow unauthorized access to data.
+*/
+GRANT SELECT ON "tenant"."namespace_limits" TO "fun_cluster_worker";
+
+CREATE UNIQUE INDEX namespace_limits_pk ON tenant.namespace_limits USING btree (id);
+
+ALTER TABLE "tenant"."namespace_limits" ADD CONSTRAINT "namespace_limits_pk" PRIMARY KEY USING INDEX "namespace_limits_pk";
+
+CREATE UNIQUE INDEX namespace_limits_uq_namespace ON tenant.namespace_limits USING btree (namespace_id, deleted) NULLS NOT DISTINCT;
+
+ALTER TABLE "tenant"."namespace_limits" ADD CONSTRAINT "namespace_limits_uq_namespace" UNIQUE USING INDEX "namespace_limits_uq_namespace";
+
+ALTER TABLE "tenant"."namespace_limits" ADD CONSTRAINT "namespace_limits_fk_namespace" FOREIGN KEY (namespace_id) REFERENCES tenant.namespaces(id) NOT VALID;
+
+ALTER TABLE "tenant"."namespace_limits" VALIDATE CONSTRAINT "namespace_limits_fk_namespace";
+
+CREATE TRIGGER namespace_limits_outbox AFTER INSERT OR UPDATE ON tenant.namespace_limits FOR EACH ROW EXECUTE FUNCTION tenant.namespace_limits_outbox_trigger();
+
+
+-- Statements generated automatically, please review:
+ALTER TABLE tenant.namespace_limits OWNER TO fun_owner;
+ALTER FUNCTION tenant.namespace_limits_outbox_trigger() OWNER TO fun_owner;
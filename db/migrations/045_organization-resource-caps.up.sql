@@ -0,0 +1,29 @@
+-- Organization caps on the number of clusters, projects and namespaces. The
+-- organization API enforces them when creating each resource.
+
+SET SESSION statement_timeout = 3000;
+SET SESSION lock_timeout = 3000;
+
+ALTER TABLE "tenant"."organization_limits" ADD COLUMN "max_clusters" integer;
+
+ALTER TABLE "tenant"."organization_limits" ADD COLUMN "max_projects" integer;
+
+ALTER TABLE "tenant"."organization_limits" ADD COLUMN "max_namespaces" integer;
+
+COMMENT ON COLUMN "tenant"."organization_limits"."max_clusters" IS E'Maximum number of active clusters in the organization.';
+
+COMMENT ON COLUMN "tenant"."organization_limits"."max_projects" IS E'Maximum number of active projects across all clusters of the organization.';
+
+COMMENT ON COLUMN "tenant"."organization_limits"."max_namespaces" IS E'Maximum number of active namespaces across all projects of the organization.';
+
+ALTER TABLE "tenant"."organization_limits" ADD CONSTRAINT "organization_limits_ck_max_clusters" CHECK((max_clusters IS NULL OR max_clusters > 0)) NOT VALID;
+
+ALTER TABLE "tenant"."organization_limits" VALIDATE CONSTRAINT "organization_limits_ck_max_clusters";
+
+ALTER TABLE "tenant"."organization_limits" ADD CONSTRAINT "organization_limits_ck_max_projects" CHECK((max_projects IS NULL OR max_projects > 0)) NOT VALID;
+
+ALTER TABLE "tenant"."organization_limits" VALIDATE CONSTRAINT "organization_limits_ck_max_projects";
+
+ALTER TABLE "tenant"."organization_limits" ADD CONSTRAINT "organization_limits_ck_max_namespaces" CHECK((max_namespaces IS NULL OR max_namespaces > 0)) NOT VALID;
+
+ALTER TABLE "tenant"."organization_limits" VALIDATE CONSTRAINT "organization_limits_ck_max_namespaces";
@@ -0,0 +1,86 @@
+-- Organization policies: rules on the regions, Kubernetes versions, machine
+-- types, node pool sizes and labels of new and changed clusters and node
+-- pools, checked by the organization API. Clusters and node pools gain the
+-- labels the required_labels rule checks.
+
+SET SESSION statement_timeout = 3000;
+SET SESSION lock_timeout = 3000;
+
+ALTER TABLE "tenant"."clusters" ADD COLUMN "labels" jsonb DEFAULT '{}'::jsonb NOT NULL;
+
+COMMENT ON COLUMN "tenant"."clusters"."labels" IS E'Key/value metadata set when the cluster is created, checked against required_labels of the organization policy.';
+
+ALTER TABLE "tenant"."clusters" ADD CONSTRAINT "clusters_ck_labels" CHECK((jsonb_typeof(labels) = 'object'::text)) NOT VALID;
+
+ALTER TABLE "tenant"."clusters" VALIDATE CONSTRAINT "clusters_ck_labels";
+
+ALTER TABLE "tenant"."node_pools" ADD COLUMN "labels" jsonb DEFAULT '{}'::jsonb NOT NULL;
+
+COMMENT ON COLUMN "tenant"."node_pools"."labels" IS E'Key/value metadata set when the node pool is created, checked against required_labels of the organization policy.';
+
+ALTER TABLE "tenant"."node_pools" ADD CONSTRAINT "node_pools_ck_labels" CHECK((jsonb_typeof(labels) = 'object'::text)) NOT VALID;
+
+ALTER TABLE "tenant"."node_pools" VALIDATE CONSTRAINT "node_pools_ck_labels";
+
+CREATE TABLE "tenant"."organization_policies" (
+	"id" uuid DEFAULT uuidv7() NOT NULL,
+	"organization_id" uuid NOT NULL,
+	"allowed_regions" text[] COLLATE "pg_catalog"."default" DEFAULT '{}'::text[] NOT NULL,
+	"denied_regions" text[] COLLATE "pg_catalog"."default" DEFAULT '{}'::text[] NOT NULL,
+	"allowed_kubernetes_versions" text[] COLLATE "pg_catalog"."default" DEFAULT '{}'::text[] NOT NULL,
+	"denied_kubernetes_versions" text[] COLLATE "pg_catalog"."default" DEFAULT '{}'::text[] NOT NULL,
+	"allowed_machine_types" text[] COLLATE "pg_catalog"."default" DEFAULT '{}'::text[] NOT NULL,
+	"denied_machine_types" text[] COLLATE "pg_catalog"."default" DEFAULT '{}'::text[] NOT NULL,
+	"max_nodes_per_node_pool" integer,
+	"required_labels" text[] COLLATE "pg_catalog"."default" DEFAULT '{}'::text[] NOT NULL,
+	"created" timestamp with time zone DEFAULT now() NOT NULL,
+	"deleted" timestamp with time zone
+);
+
+COMMENT ON TABLE "tenant"."organization_policies" IS E'Rules the organization API checks when clusters and node pools are created or changed. Entries of the allowed and denied lists may use * wildcards.';
+
+COMMENT ON COLUMN "tenant"."organization_policies"."allowed_regions" IS E'Regions clusters may be created in; empty allows every region.';
+
+COMMENT ON COLUMN "tenant"."organization_policies"."denied_regions" IS E'Regions clusters may not be created in, also when allowed_regions matches.';
+
+COMMENT ON COLUMN "tenant"."organization_policies"."allowed_kubernetes_versions" IS E'Kubernetes versions clusters may run; empty allows every version.';
+
+COMMENT ON COLUMN "tenant"."organization_policies"."denied_kubernetes_versions" IS E'Kubernetes versions clusters may not run, also when allowed_kubernetes_versions matches.';
+
+COMMENT ON COLUMN "tenant"."organization_policies"."allowed_machine_types" IS E'Machine types node pools may use; empty allows every machine type.';
+
+COMMENT ON COLUMN "tenant"."organization_policies"."denied_machine_types" IS E'Machine types node pools may not use, also when allowed_machine_types matches.';
+
+COMMENT ON COLUMN "tenant"."organization_policies"."max_nodes_per_node_pool" IS E'Largest autoscale maximum a node pool may ask for. Unlike the organization limit of the same name, a larger request is rejected instead of clamped.';
+
+COMMENT ON COLUMN "tenant"."organization_policies"."required_labels" IS E'Label keys every new cluster and node pool must carry.';
+
+ALTER TABLE "tenant"."organization_policies" ADD CONSTRAINT "organization_policies_ck_max_nodes_per_node_pool" CHECK ((max_nodes_per_node_pool IS NULL OR max_nodes_per_node_pool > 0));
+
+ALTER TABLE "tenant"."organization_policies" ENABLE ROW LEVEL SECURITY;
+
+CREATE UNIQUE INDEX organization_policies_pk ON tenant.organization_policies USING btree (id);
+
+ALTER TABLE "tenant"."organization_policies" ADD CONSTRAINT "organization_policies_pk" PRIMARY KEY USING INDEX "organization_policies_pk";
+
+CREATE UNIQUE INDEX organization_policies_uq_org ON tenant.organization_policies USING btree (organization_id, deleted) NULLS NOT DISTINCT;
+
+ALTER TABLE "tenant"."organization_policies" ADD CONSTRAINT "organization_policies_uq_org" UNIQUE USING INDEX "organization_policies_uq_org";
+
+ALTER TABLE "tenant"."organization_policies" ADD CONSTRAINT "organization_policies_fk_organization" FOREIGN KEY (organization_id) REFERENCES tenant.organizations(id) NOT VALID;
+
+ALTER TABLE "tenant"."organization_policies" VALIDATE CONSTRAINT "organization_policies_fk_organization";
+
+/* Hazards:
+ - AUTHZ_UPDATE: Granting privileges could allow unauthorized access to data.
+*/
+GRANT SELECT, INSERT, UPDATE ON "tenant"."organization_policies" TO "fun_fundament_api";
+
+/* Hazards:
+ - AUTHZ_UPDATE: Adding a permissive policy could allow unauthorized access to data.
+*/
+CREATE POLICY "organization_policies_organization_policy" ON "tenant"."organization_policies"
+	AS PERMISSIVE
+	FOR ALL
+	TO fun_fundament_api
+	USING ((organization_id = authn.current_organization_id()));
@@ -0,0 +1,34 @@
+-- Node pool labels, taints, zones and rolling update settings, applied to the
+-- Gardener worker of the pool. The labels added in 046 become the Kubernetes
+-- labels of the pool's nodes.
+
+SET SESSION statement_timeout = 3000;
+SET SESSION lock_timeout = 3000;
+
+COMMENT ON COLUMN "tenant"."node_pools"."labels" IS E'Kubernetes labels of the pool\'s nodes, also checked against required_labels of the organization policy.';
+
+ALTER TABLE "tenant"."node_pools" ADD COLUMN "taints" jsonb DEFAULT '[]'::jsonb NOT NULL;
+
+COMMENT ON COLUMN "tenant"."node_pools"."taints" IS E'Kubernetes taints of the pool\'s nodes, as an array of {key, value, effect} objects.';
+
+ALTER TABLE "tenant"."node_pools" ADD COLUMN "zones" text[] COLLATE "pg_catalog"."default" DEFAULT '{}'::text[] NOT NULL;
+
+COMMENT ON COLUMN "tenant"."node_pools"."zones" IS E'Availability zones the pool\'s nodes are spread over; empty leaves the choice to the provider.';
+
+ALTER TABLE "tenant"."node_pools" ADD COLUMN "max_surge" integer DEFAULT 1 NOT NULL;
+
+COMMENT ON COLUMN "tenant"."node_pools"."max_surge" IS E'Nodes the pool may add above its maximum while rolling nodes.';
+
+ALTER TABLE "tenant"."node_pools" ADD COLUMN "max_unavailable" integer DEFAULT 0 NOT NULL;
+
+COMMENT ON COLUMN "tenant"."node_pools"."max_unavailable" IS E'Nodes the pool may take out of service at once while rolling nodes.';
+
+ALTER TABLE "tenant"."node_pools" ADD CONSTRAINT "node_pools_ck_taints" CHECK((jsonb_typeof(taints) = 'array'::text)) NOT VALID;
+
+ALTER TABLE "tenant"."node_pools" VALIDATE CONSTRAINT "node_pools_ck_taints";
+
+ALTER TABLE "tenant"."node_pools" ADD CONSTRAINT "node_pools_ck_rolling_update" CHECK(((max_surge >= 0) AND (max_unavailable >= 0) AND ((max_surge + max_unavailable) > 0))) NOT VALID;
+
+ALTER TABLE "tenant"."node_pools" VALIDATE CONSTRAINT "node_pools_ck_rolling_update";
+
+CREATE OR REPLACE TRIGGER node_pool_outbox AFTER INSERT OR UPDATE OF name, machine_type, region_machine_type_id, autoscale_min, autoscale_max, labels, taints, zones, max_surge, max_unavailable, deleted ON tenant.node_pools FOR EACH ROW EXECUTE FUNCTION tenant.node_pool_outbox_trigger();
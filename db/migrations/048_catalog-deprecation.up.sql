@@ -0,0 +1,18 @@
+-- Deprecation dates on the region catalog. A deprecated entry is no longer
+-- offered for new clusters and node pools once its date has passed; existing
+-- ones keep running.
+
+SET SESSION statement_timeout = 3000;
+SET SESSION lock_timeout = 3000;
+
+ALTER TABLE "catalog"."kubernetes_versions" ADD COLUMN "deprecated" timestamp with time zone;
+
+COMMENT ON COLUMN "catalog"."kubernetes_versions"."deprecated" IS E'When the version stops being offered for new clusters and upgrades; existing clusters keep running. NULL when not deprecated.';
+
+ALTER TABLE "catalog"."machine_types" ADD COLUMN "deprecated" timestamp with time zone;
+
+COMMENT ON COLUMN "catalog"."machine_types"."deprecated" IS E'When the machine type stops being offered for new node pools; existing node pools keep running. NULL when not deprecated.';
+
+ALTER TABLE "catalog"."regions" ADD COLUMN "deprecated" timestamp with time zone;
+
+COMMENT ON COLUMN "catalog"."regions"."deprecated" IS E'When the region stops being offered for new clusters; existing clusters keep running. NULL when not deprecated.';
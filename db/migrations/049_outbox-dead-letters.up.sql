@@ -0,0 +1,29 @@
+-- Dead-letter handling for tenant.cluster_outbox and authz.outbox: rows that
+-- exhausted their retries can be replayed or marked abandoned, which workers
+-- skip. Both actions are recorded in the cluster activity.
+
+SET SESSION statement_timeout = 3000;
+SET SESSION lock_timeout = 3000;
+
+ALTER TABLE "authz"."outbox" DROP CONSTRAINT "outbox_ck_status";
+
+ALTER TABLE "authz"."outbox" ADD CONSTRAINT "outbox_ck_status" CHECK((status = ANY (ARRAY['pending'::text, 'completed'::text, 'retrying'::text, 'failed'::text, 'abandoned'::text]))) NOT VALID;
+
+ALTER TABLE "authz"."outbox" VALIDATE CONSTRAINT "outbox_ck_status";
+
+ALTER TABLE "tenant"."cluster_events" DROP CONSTRAINT "cluster_events_ck_event_type";
+
+ALTER TABLE "tenant"."cluster_events" ADD CONSTRAINT "cluster_events_ck_event_type" CHECK((event_type = ANY (ARRAY['sync_requested'::text, 'sync_claimed'::text, 'sync_succeeded'::text, 'sync_failed'::text, 'status_progressing'::text, 'status_ready'::text, 'status_error'::text, 'status_deleted'::text, 'user_sync_succeeded'::text, 'user_sync_failed'::text, 'upgrade_started'::text, 'upgrade_completed'::text, 'upgrade_failed'::text, 'hibernation_requested'::text, 'wake_requested'::text, 'status_hibernated'::text, 'outbox_replayed'::text, 'outbox_abandoned'::text]))) NOT VALID;
+
+ALTER TABLE "tenant"."cluster_events" VALIDATE CONSTRAINT "cluster_events_ck_event_type";
+
+ALTER TABLE "tenant"."cluster_outbox" DROP CONSTRAINT "cluster_outbox_ck_status";
+
+ALTER TABLE "tenant"."cluster_outbox" ADD CONSTRAINT "cluster_outbox_ck_status" CHECK((status = ANY (ARRAY['pending'::text, 'completed'::text, 'retrying'::text, 'failed'::text, 'abandoned'::text]))) NOT VALID;
+
+ALTER TABLE "tenant"."cluster_outbox" VALIDATE CONSTRAINT "cluster_outbox_ck_status";
+
+/* Hazards:
+ - AUTHZ_UPDATE: Granting privileges could allow unauthorized access to data.
+*/
+GRANT UPDATE ON "tenant"."cluster_outbox" TO "fun_fundament_api";
@@ -0,0 +1,217 @@
+-- Just-in-time elevated access: a member requests the organization admin or
+-- a project admin role for a limited time, and another admin approves it.
+-- authz-worker mirrors an approved elevation as an OpenFGA tuple that expires
+-- with it; cluster-worker grants the matching cluster access and takes it
+-- away again once the elevation has ended.
+
+SET SESSION statement_timeout = 3000;
+SET SESSION lock_timeout = 3000;
+
+CREATE TABLE "tenant"."access_elevations" (
+	"id" uuid DEFAULT uuidv7() NOT NULL,
+	"organization_id" uuid NOT NULL,
+	"user_id" uuid NOT NULL,
+	"role" text COLLATE "pg_catalog"."default" NOT NULL,
+	"project_id" uuid,
+	"duration_minutes" integer NOT NULL,
+	"reason" text COLLATE "pg_catalog"."default" NOT NULL,
+	"status" text COLLATE "pg_catalog"."default" DEFAULT 'pending'::text NOT NULL,
+	"decided_by" uuid,
+	"decided" timestamp with time zone,
+	"expires" timestamp with time zone,
+	"ended" timestamp with time zone,
+	"created" timestamp with time zone DEFAULT now() NOT NULL
+);
+
+COMMENT ON TABLE "tenant"."access_elevations" IS E'Requests for a temporary organization admin or project admin role, and the grants that follow their approval.';
+
+COMMENT ON COLUMN "tenant"."access_elevations"."user_id" IS E'User who requested the role and holds it once approved.';
+
+COMMENT ON COLUMN "tenant"."access_elevations"."project_id" IS E'Project of a project_admin elevation; NULL for organization admin.';
+
+COMMENT ON COLUMN "tenant"."access_elevations"."decided_by" IS E'User who approved or denied the request.';
+
+COMMENT ON COLUMN "tenant"."access_elevations"."expires" IS E'End of the granted role: set on approval to decided plus duration_minutes.';
+
+COMMENT ON COLUMN "tenant"."access_elevations"."ended" IS E'When the request was cancelled, or the granted role was revoked or expired.';
+
+ALTER TABLE "tenant"."access_elevations" ADD CONSTRAINT "access_elevations_ck_role" CHECK (role IN ('admin', 'project_admin'));
+
+ALTER TABLE "tenant"."access_elevations" ADD CONSTRAINT "access_elevations_ck_project" CHECK ((role = 'project_admin') = (project_id IS NOT NULL));
+
+ALTER TABLE "tenant"."access_elevations" ADD CONSTRAINT "access_elevations_ck_duration" CHECK (duration_minutes BETWEEN 1 AND 1440);
+
+ALTER TABLE "tenant"."access_elevations" ADD CONSTRAINT "access_elevations_ck_reason" CHECK (btrim(reason) <> '');
+
+ALTER TABLE "tenant"."access_elevations" ADD CONSTRAINT "access_elevations_ck_status" CHECK (status IN ('pending', 'approved', 'denied', 'cancelled', 'revoked', 'expired'));
+
+ALTER TABLE "tenant"."access_elevations" ADD CONSTRAINT "access_elevations_ck_expires" CHECK ((expires IS NOT NULL) = (status IN ('approved', 'revoked', 'expired')));
+
+ALTER TABLE "tenant"."access_elevations" ENABLE ROW LEVEL SECURITY;
+
+CREATE UNIQUE INDEX access_elevations_pk ON tenant.access_elevations USING btree (id);
+
+ALTER TABLE "tenant"."access_elevations" ADD CONSTRAINT "access_elevations_pk" PRIMARY KEY USING INDEX "access_elevations_pk";
+
+CREATE UNIQUE INDEX access_elevations_uq_open ON tenant.access_elevations USING btree (organization_id, user_id, role, project_id) NULLS NOT DISTINCT WHERE (status IN ('pending', 'approved'));
+
+CREATE INDEX access_elevations_idx_organization_created ON tenant.access_elevations USING btree (organization_id, created, id);
+
+CREATE INDEX access_elevations_idx_expires ON tenant.access_elevations USING btree (expires) WHERE (status = 'approved');
+
+ALTER TABLE "tenant"."access_elevations" ADD CONSTRAINT "access_elevations_fk_organization" FOREIGN KEY (organization_id) REFERENCES tenant.organizations(id) NOT VALID;
+
+ALTER TABLE "tenant"."access_elevations" VALIDATE CONSTRAINT "access_elevations_fk_organization";
+
+ALTER TABLE "tenant"."access_elevations" ADD CONSTRAINT "access_elevations_fk_user" FOREIGN KEY (user_id) REFERENCES tenant.users(id) NOT VALID;
+
+ALTER TABLE "tenant"."access_elevations" VALIDATE CONSTRAINT "access_elevations_fk_user";
+
+ALTER TABLE "tenant"."access_elevations" ADD CONSTRAINT "access_elevations_fk_project" FOREIGN KEY (project_id) REFERENCES tenant.projects(id) NOT VALID;
+
+ALTER TABLE "tenant"."access_elevations" VALIDATE CONSTRAINT "access_elevations_fk_project";
+
+ALTER TABLE "tenant"."access_elevations" ADD CONSTRAINT "access_elevations_fk_decided_by" FOREIGN KEY (decided_by) REFERENCES tenant.users(id) NOT VALID;
+
+ALTER TABLE "tenant"."access_elevations" VALIDATE CONSTRAINT "access_elevations_fk_decided_by";
+
+/* Hazards:
+ - AUTHZ_UPDATE: Granting privileges could allow unauthorized access to data.
+*/
+GRANT SELECT, INSERT, UPDATE ON "tenant"."access_elevations" TO "fun_fundament_api";
+
+GRANT SELECT ON "tenant"."access_elevations" TO "fun_authz_worker";
+
+GRANT SELECT, UPDATE ON "tenant"."access_elevations" TO "fun_cluster_worker";
+
+GRANT SELECT ON "tenant"."access_elevations" TO "fun_authn_api";
+
+/* Hazards:
+ - AUTHZ_UPDATE: Adding a permissive policy could allow unauthorized access to data.
+*/
+CREATE POLICY "access_elevations_organization_policy" ON "tenant"."access_elevations"
+	AS PERMISSIVE
+	FOR ALL
+	TO fun_fundament_api
+	USING ((organization_id = authn.current_organization_id()));
+
+/* Hazards:
+ - AUTHZ_UPDATE: Adding a permissive policy could allow unauthorized access to data.
+*/
+CREATE POLICY "access_elevations_cluster_worker_policy" ON "tenant"."access_elevations"
+	AS PERMISSIVE
+	FOR ALL
+	TO fun_cluster_worker
+	USING (true);
+
+/* Hazards:
+ - AUTHZ_UPDATE: Adding a permissive policy could allow unauthorized access to data.
+*/
+CREATE POLICY "access_elevations_authn_api_policy" ON "tenant"."access_elevations"
+	AS PERMISSIVE
+	FOR SELECT
+	TO fun_authn_api
+	USING (true);
+
+-- OpenFGA sync: one outbox row per elevation change.
+ALTER TABLE "authz"."outbox" ADD COLUMN "access_elevation_id" uuid;
+
+ALTER TABLE "authz"."outbox" DROP CONSTRAINT "outbox_ck_single_fk";
+
+ALTER TABLE "authz"."outbox" ADD CONSTRAINT "outbox_ck_single_fk" CHECK (num_nonnulls(
+	project_id,
+	project_member_id,
+	cluster_id,
+	node_pool_id,
+	namespace_id,
+	api_key_id,
+	organization_user_id,
+	plugin_id,
+	namespace_role_binding_id,
+	team_id,
+	team_member_id,
+	project_team_id,
+	access_elevation_id
+) = 1);
+
+ALTER TABLE "authz"."outbox" ADD CONSTRAINT "outbox_fk_access_elevation" FOREIGN KEY (access_elevation_id) REFERENCES tenant.access_elevations(id) NOT VALID;
+
+ALTER TABLE "authz"."outbox" VALIDATE CONSTRAINT "outbox_fk_access_elevation";
+
+CREATE OR REPLACE FUNCTION authz.access_elevations_sync_trigger ()
+	RETURNS trigger
+	LANGUAGE plpgsql
+	VOLATILE
+	CALLED ON NULL INPUT
+	SECURITY INVOKER
+	PARALLEL UNSAFE
+	COST 1
+	AS
+$function$
+BEGIN
+    -- Only insert into outbox if this is an INSERT or if data actually changed
+    IF TG_OP = 'INSERT' OR NEW IS DISTINCT FROM OLD THEN
+        INSERT INTO authz.outbox (access_elevation_id)
+        VALUES (COALESCE(NEW.id, OLD.id));
+    END IF;
+    RETURN COALESCE(NEW, OLD);
+END;
+$function$;
+
+CREATE OR REPLACE TRIGGER access_elevations_outbox
+	AFTER INSERT OR UPDATE
+	ON tenant.access_elevations
+	FOR EACH ROW
+	EXECUTE PROCEDURE authz.access_elevations_sync_trigger();
+
+-- Cluster access sync: one outbox row when an elevation is granted and one
+-- when it ends. Pending, denied and cancelled requests never reach a cluster.
+ALTER TABLE "tenant"."cluster_outbox" ADD COLUMN "access_elevation_id" uuid;
+
+ALTER TABLE "tenant"."cluster_outbox" DROP CONSTRAINT "cluster_outbox_ck_single_fk";
+
+ALTER TABLE "tenant"."cluster_outbox" ADD CONSTRAINT "cluster_outbox_ck_single_fk" CHECK (num_nonnulls(cluster_id, organization_user_id, project_member_id, node_pool_id, namespace_id, access_elevation_id) = 1);
+
+ALTER TABLE "tenant"."cluster_outbox" ADD CONSTRAINT "cluster_outbox_fk_access_elevation" FOREIGN KEY (access_elevation_id) REFERENCES tenant.access_elevations(id) NOT VALID;
+
+ALTER TABLE "tenant"."cluster_outbox" VALIDATE CONSTRAINT "cluster_outbox_fk_access_elevation";
+
+CREATE OR REPLACE FUNCTION tenant.cluster_outbox_access_elevation_trigger ()
+	RETURNS trigger
+	LANGUAGE plpgsql
+	VOLATILE
+	CALLED ON NULL INPUT
+	SECURITY DEFINER
+	PARALLEL UNSAFE
+	COST 1
+	AS
+$function$
+BEGIN
+    IF NEW.status = 'approved' AND (TG_OP = 'INSERT' OR OLD.status <> 'approved') THEN
+        INSERT INTO tenant.cluster_outbox (access_elevation_id, event, source)
+        VALUES (NEW.id, 'created', 'trigger');
+    ELSIF TG_OP = 'UPDATE' AND OLD.status = 'approved' AND NEW.status <> 'approved' THEN
+        INSERT INTO tenant.cluster_outbox (access_elevation_id, event, source)
+        VALUES (NEW.id, 'deleted', 'trigger');
+    END IF;
+    RETURN NEW;
+END;
+$function$;
+
+CREATE OR REPLACE TRIGGER cluster_outbox_access_elevation
+	AFTER INSERT OR UPDATE
+	ON tenant.access_elevations
+	FOR EACH ROW
+	EXECUTE PROCEDURE tenant.cluster_outbox_access_elevation_trigger();
+
+ALTER TABLE "tenant"."cluster_events" DROP CONSTRAINT "cluster_events_ck_event_type";
+
+ALTER TABLE "tenant"."cluster_events" ADD CONSTRAINT "cluster_events_ck_event_type" CHECK((event_type = ANY (ARRAY['sync_requested'::text, 'sync_claimed'::text, 'sync_succeeded'::text, 'sync_failed'::text, 'status_progressing'::text, 'status_ready'::text, 'status_error'::text, 'status_deleted'::text, 'user_sync_succeeded'::text, 'user_sync_failed'::text, 'upgrade_started'::text, 'upgrade_completed'::text, 'upgrade_failed'::text, 'hibernation_requested'::text, 'wake_requested'::text, 'status_hibernated'::text, 'outbox_replayed'::text, 'outbox_abandoned'::text, 'access_elevation_granted'::text, 'access_elevation_ended'::text]))) NOT VALID;
+
+ALTER TABLE "tenant"."cluster_events" VALIDATE CONSTRAINT "cluster_events_ck_event_type";
+
+
+-- Statements generated automatically, please review:
+ALTER TABLE tenant.access_elevations OWNER TO fun_owner;
+ALTER FUNCTION authz.access_elevations_sync_trigger() OWNER TO fun_owner;
+ALTER FUNCTION tenant.cluster_outbox_access_elevation_trigger() OWNER TO fun_owner;
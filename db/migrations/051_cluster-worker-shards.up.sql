@@ -0,0 +1,207 @@
+-- Lets several cluster-worker replicas share tenant.cluster_outbox. Every
+-- outbox row records the organization it belongs to and the key it is
+-- partitioned by: the cluster it touches, or the organization for rows that
+-- touch all of its clusters. Replicas hash the partition keys into shards and
+-- hold a lease per shard in tenant.cluster_worker_leases, so the rows of one
+-- cluster are never processed by two workers at once.
+
+SET SESSION statement_timeout = 3000;
+SET SESSION lock_timeout = 3000;
+
+ALTER TABLE "tenant"."cluster_outbox" ADD COLUMN "organization_id" uuid;
+
+ALTER TABLE "tenant"."cluster_outbox" ADD COLUMN "partition_key" uuid;
+
+COMMENT ON COLUMN "tenant"."cluster_outbox"."organization_id" IS E'Organization the row belongs to; workers take turns between organizations within a shard.';
+
+COMMENT ON COLUMN "tenant"."cluster_outbox"."partition_key" IS E'Cluster the row touches, or the organization for organization-wide rows. Rows with the same key land in the same shard.';
+
+CREATE TABLE "tenant"."cluster_worker_leases" (
+	"name" text COLLATE "pg_catalog"."default" NOT NULL,
+	"holder" text COLLATE "pg_catalog"."default" NOT NULL,
+	"acquired" timestamp with time zone DEFAULT now() NOT NULL,
+	"expires" timestamp with time zone NOT NULL
+);
+
+COMMENT ON TABLE "tenant"."cluster_worker_leases" IS E'Leases through which cluster-worker replicas divide the outbox shards and elect the replica that runs the periodic loops.';
+
+COMMENT ON COLUMN "tenant"."cluster_worker_leases"."name" IS E'replica/<holder> for a replica heartbeat, leader, or outbox-shard/<n>.';
+
+COMMENT ON COLUMN "tenant"."cluster_worker_leases"."holder" IS E'Replica holding the lease (hostname and process ID).';
+
+CREATE UNIQUE INDEX cluster_worker_leases_pk ON tenant.cluster_worker_leases USING btree (name);
+
+ALTER TABLE "tenant"."cluster_worker_leases" ADD CONSTRAINT "cluster_worker_leases_pk" PRIMARY KEY USING INDEX "cluster_worker_leases_pk";
+
+/* Hazards:
+ - AUTHZ_UPDATE: Granting privileges could allow unauthorized access to data.
+*/
+GRANT SELECT, INSERT, UPDATE, DELETE ON "tenant"."cluster_worker_leases" TO "fun_cluster_worker";
+
+CREATE OR REPLACE FUNCTION tenant.cluster_outbox_partition_trigger ()
+	RETURNS trigger
+	LANGUAGE plpgsql
+	VOLATILE
+	CALLED ON NULL INPUT
+	SECURITY DEFINER
+	PARALLEL UNSAFE
+	COST 1
+	AS
+$function$
+BEGIN
+    IF NEW.cluster_id IS NOT NULL THEN
+        SELECT tenant.clusters.organization_id, tenant.clusters.id
+        INTO NEW.organization_id, NEW.partition_key
+        FROM tenant.clusters
+        WHERE tenant.clusters.id = NEW.cluster_id;
+    ELSIF NEW.node_pool_id IS NOT NULL THEN
+        SELECT tenant.clusters.organization_id, tenant.clusters.id
+        INTO NEW.organization_id, NEW.partition_key
+        FROM tenant.node_pools
+        JOIN tenant.clusters ON tenant.clusters.id = tenant.node_pools.cluster_id
+        WHERE tenant.node_pools.id = NEW.node_pool_id;
+    ELSIF NEW.namespace_id IS NOT NULL THEN
+        SELECT tenant.clusters.organization_id, tenant.clusters.id
+        INTO NEW.organization_id, NEW.partition_key
+        FROM tenant.namespaces
+        JOIN tenant.projects ON tenant.projects.id = tenant.namespaces.project_id
+        JOIN tenant.clusters ON tenant.clusters.id = tenant.projects.cluster_id
+        WHERE tenant.namespaces.id = NEW.namespace_id;
+    ELSIF NEW.project_member_id IS NOT NULL THEN
+        SELECT tenant.clusters.organization_id, tenant.clusters.id
+        INTO NEW.organization_id, NEW.partition_key
+        FROM tenant.project_members
+        JOIN tenant.projects ON tenant.projects.id = tenant.project_members.project_id
+        JOIN tenant.clusters ON tenant.clusters.id = tenant.projects.cluster_id
+        WHERE tenant.project_members.id = NEW.project_member_id;
+    ELSIF NEW.organization_user_id IS NOT NULL THEN
+        SELECT tenant.organizations_users.organization_id, tenant.organizations_users.organization_id
+        INTO NEW.organization_id, NEW.partition_key
+        FROM tenant.organizations_users
+        WHERE tenant.organizations_users.id = NEW.organization_user_id;
+    ELSIF NEW.access_elevation_id IS NOT NULL THEN
+        SELECT tenant.access_elevations.organization_id,
+               COALESCE(tenant.projects.cluster_id, tenant.access_elevations.organization_id)
+        INTO NEW.organization_id, NEW.partition_key
+        FROM tenant.access_elevations
+        LEFT JOIN tenant.projects ON tenant.projects.id = tenant.access_elevations.project_id
+        WHERE tenant.access_elevations.id = NEW.access_elevation_id;
+    END IF;
+
+    -- A row whose entity is already gone still needs a stable shard.
+    NEW.partition_key := COALESCE(
+        NEW.partition_key,
+        NEW.cluster_id,
+        NEW.node_pool_id,
+        NEW.namespace_id,
+        NEW.project_member_id,
+        NEW.organization_user_id,
+        NEW.access_elevation_id
+    );
+    RETURN NEW;
+END;
+$function$;
+
+CREATE OR REPLACE TRIGGER cluster_outbox_partition
+	BEFORE INSERT
+	ON tenant.cluster_outbox
+	FOR EACH ROW
+	EXECUTE PROCEDURE tenant.cluster_outbox_partition_trigger();
+
+-- Rows queued before this migration get the same organization and partition
+-- key the trigger would have given them, so rows of a cluster that are still
+-- waiting do not end up in different shards. Completed rows are never claimed
+-- again; failed and abandoned ones can be replayed, so they are included.
+-- The status trigger is held off: it would copy these older rows' status onto
+-- their clusters.
+ALTER TABLE "tenant"."cluster_outbox" DISABLE TRIGGER "cluster_outbox_update_cluster_status";
+
+UPDATE tenant.cluster_outbox
+SET organization_id = tenant.clusters.organization_id,
+    partition_key = tenant.clusters.id
+FROM tenant.clusters
+WHERE tenant.cluster_outbox.status <> 'completed'
+  AND tenant.clusters.id = tenant.cluster_outbox.cluster_id;
+
+UPDATE tenant.cluster_outbox
+SET organization_id = tenant.clusters.organization_id,
+    partition_key = tenant.clusters.id
+FROM tenant.node_pools
+JOIN tenant.clusters ON tenant.clusters.id = tenant.node_pools.cluster_id
+WHERE tenant.cluster_outbox.status <> 'completed'
+  AND tenant.cluster_outbox.cluster_id IS NULL
+  AND tenant.node_pools.id = tenant.cluster_outbox.node_pool_id;
+
+UPDATE tenant.cluster_outbox
+SET organization_id = tenant.clusters.organization_id,
+    partition_key = tenant.clusters.id
+FROM tenant.namespaces
+JOIN tenant.projects ON tenant.projects.id = tenant.namespaces.project_id
+JOIN tenant.clusters ON tenant.clusters.id = tenant.projects.cluster_id
+WHERE tenant.cluster_outbox.status <> 'completed'
+  AND tenant.cluster_outbox.cluster_id IS NULL
+  AND tenant.cluster_outbox.node_pool_id IS NULL
+  AND tenant.namespaces.id = tenant.cluster_outbox.namespace_id;
+
+UPDATE tenant.cluster_outbox
+SET organization_id = tenant.clusters.organization_id,
+    partition_key = tenant.clusters.id
+FROM tenant.project_members
+JOIN tenant.projects ON tenant.projects.id = tenant.project_members.project_id
+JOIN tenant.clusters ON tenant.clusters.id = tenant.projects.cluster_id
+WHERE tenant.cluster_outbox.status <> 'completed'
+  AND tenant.cluster_outbox.cluster_id IS NULL
+  AND tenant.cluster_outbox.node_pool_id IS NULL
+  AND tenant.cluster_outbox.namespace_id IS NULL
+  AND tenant.project_members.id = tenant.cluster_outbox.project_member_id;
+
+UPDATE tenant.cluster_outbox
+SET organization_id = tenant.organizations_users.organization_id,
+    partition_key = tenant.organizations_users.organization_id
+FROM tenant.organizations_users
+WHERE tenant.cluster_outbox.status <> 'completed'
+  AND tenant.cluster_outbox.cluster_id IS NULL
+  AND tenant.cluster_outbox.node_pool_id IS NULL
+  AND tenant.cluster_outbox.namespace_id IS NULL
+  AND tenant.cluster_outbox.project_member_id IS NULL
+  AND tenant.organizations_users.id = tenant.cluster_outbox.organization_user_id;
+
+UPDATE tenant.cluster_outbox
+SET organization_id = tenant.access_elevations.organization_id,
+    partition_key = COALESCE(tenant.projects.cluster_id, tenant.access_elevations.organization_id)
+FROM tenant.access_elevations
+LEFT JOIN tenant.projects ON tenant.projects.id = tenant.access_elevations.project_id
+WHERE tenant.cluster_outbox.status <> 'completed'
+  AND tenant.cluster_outbox.cluster_id IS NULL
+  AND tenant.cluster_outbox.node_pool_id IS NULL
+  AND tenant.cluster_outbox.namespace_id IS NULL
+  AND tenant.cluster_outbox.project_member_id IS NULL
+  AND tenant.cluster_outbox.organization_user_id IS NULL
+  AND tenant.access_elevations.id = tenant.cluster_outbox.access_elevation_id;
+
+UPDATE tenant.cluster_outbox
+SET partition_key = COALESCE(
+        cluster_id,
+        node_pool_id,
+        namespace_id,
+        project_member_id,
+        organization_user_id,
+        access_elevation_id
+    )
+WHERE status <> 'completed'
+  AND partition_key IS NULL;
+
+ALTER TABLE "tenant"."cluster_outbox" ENABLE TRIGGER "cluster_outbox_update_cluster_status";
+
+CREATE OR REPLACE FUNCTION tenant.cluster_outbox_shard (partition_key uuid, shard_count integer)
+	RETURNS integer
+	LANGUAGE sql
+	IMMUTABLE
+	CALLED ON NULL INPUT
+	SECURITY INVOKER
+	PARALLEL SAFE
+	COST 1
+	AS
+$function$
+SELECT abs(hashtextextended(partition_key::text, 0) % shard_count)::integer
+$function$;
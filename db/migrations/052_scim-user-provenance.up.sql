@@ -0,0 +1,15 @@
+-- Records which organization's SCIM provisioning created a user. tenant.users
+-- is shared by all organizations, so only that organization's identity
+-- provider may change the user's name; users who existed before are invited
+-- instead of added as accepted members.
+
+SET SESSION statement_timeout = 3000;
+SET SESSION lock_timeout = 3000;
+
+ALTER TABLE "tenant"."users" ADD COLUMN "scim_organization_id" uuid;
+
+COMMENT ON COLUMN "tenant"."users"."scim_organization_id" IS E'Organization whose SCIM provisioning created the user; only its identity provider may set the name. NULL for users created any other way.';
+
+ALTER TABLE "tenant"."users" ADD CONSTRAINT "users_fk_scim_organization" FOREIGN KEY (scim_organization_id) REFERENCES tenant.organizations(id) NOT VALID;
+
+ALTER TABLE "tenant"."users" VALIDATE CONSTRAINT "users_fk_scim_organization";
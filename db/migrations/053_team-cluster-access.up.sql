@@ -0,0 +1,143 @@
+-- Team memberships grant cluster access: cluster-worker reads teams when it
+-- resolves a user's access, and changes to teams, team members and project
+-- teams resync every affected member through their organization membership,
+-- which fans out to all ready clusters of the organization.
+
+SET SESSION statement_timeout = 3000;
+SET SESSION lock_timeout = 3000;
+
+GRANT SELECT ON "tenant"."teams" TO "fun_cluster_worker";
+
+GRANT SELECT ON "tenant"."team_members" TO "fun_cluster_worker";
+
+GRANT SELECT ON "tenant"."project_teams" TO "fun_cluster_worker";
+
+/* Hazards:
+ - AUTHZ_UPDATE: Adding a permissive policy could allow unauthorized access to data.
+*/
+CREATE POLICY "teams_cluster_worker_policy" ON "tenant"."teams"
+	AS PERMISSIVE
+	FOR SELECT
+	TO fun_cluster_worker
+	USING (true);
+
+/* Hazards:
+ - AUTHZ_UPDATE: Adding a permissive policy could allow unauthorized access to data.
+*/
+CREATE POLICY "team_members_cluster_worker_policy" ON "tenant"."team_members"
+	AS PERMISSIVE
+	FOR SELECT
+	TO fun_cluster_worker
+	USING (true);
+
+/* Hazards:
+ - AUTHZ_UPDATE: Adding a permissive policy could allow unauthorized access to data.
+*/
+CREATE POLICY "project_teams_cluster_worker_policy" ON "tenant"."project_teams"
+	AS PERMISSIVE
+	FOR SELECT
+	TO fun_cluster_worker
+	USING (true);
+
+CREATE OR REPLACE FUNCTION tenant.cluster_outbox_team_member_trigger ()
+	RETURNS trigger
+	LANGUAGE plpgsql
+	VOLATILE
+	CALLED ON NULL INPUT
+	SECURITY DEFINER
+	PARALLEL UNSAFE
+	COST 1
+	AS
+$function$
+BEGIN
+    IF TG_OP = 'INSERT' OR OLD.deleted IS DISTINCT FROM NEW.deleted THEN
+        INSERT INTO tenant.cluster_outbox (organization_user_id, event, source)
+        SELECT tenant.organizations_users.id, 'updated', 'trigger'
+        FROM tenant.teams
+        JOIN tenant.organizations_users
+            ON tenant.organizations_users.organization_id = tenant.teams.organization_id
+            AND tenant.organizations_users.user_id = NEW.user_id
+            AND tenant.organizations_users.deleted IS NULL
+        WHERE tenant.teams.id = NEW.team_id;
+    END IF;
+    RETURN NEW;
+END;
+$function$;
+
+CREATE OR REPLACE TRIGGER cluster_outbox_team_member
+	AFTER INSERT OR UPDATE
+	ON tenant.team_members
+	FOR EACH ROW
+	EXECUTE PROCEDURE tenant.cluster_outbox_team_member_trigger();
+
+CREATE OR REPLACE FUNCTION tenant.cluster_outbox_project_team_trigger ()
+	RETURNS trigger
+	LANGUAGE plpgsql
+	VOLATILE
+	CALLED ON NULL INPUT
+	SECURITY DEFINER
+	PARALLEL UNSAFE
+	COST 1
+	AS
+$function$
+BEGIN
+    IF TG_OP = 'INSERT' OR OLD.deleted IS DISTINCT FROM NEW.deleted THEN
+        INSERT INTO tenant.cluster_outbox (organization_user_id, event, source)
+        SELECT tenant.organizations_users.id, 'updated', 'trigger'
+        FROM tenant.teams
+        JOIN tenant.team_members
+            ON tenant.team_members.team_id = tenant.teams.id
+            AND tenant.team_members.deleted IS NULL
+        JOIN tenant.organizations_users
+            ON tenant.organizations_users.organization_id = tenant.teams.organization_id
+            AND tenant.organizations_users.user_id = tenant.team_members.user_id
+            AND tenant.organizations_users.deleted IS NULL
+        WHERE tenant.teams.id = NEW.team_id;
+    END IF;
+    RETURN NEW;
+END;
+$function$;
+
+CREATE OR REPLACE TRIGGER cluster_outbox_project_team
+	AFTER INSERT OR UPDATE
+	ON tenant.project_teams
+	FOR EACH ROW
+	EXECUTE PROCEDURE tenant.cluster_outbox_project_team_trigger();
+
+CREATE OR REPLACE FUNCTION tenant.cluster_outbox_team_trigger ()
+	RETURNS trigger
+	LANGUAGE plpgsql
+	VOLATILE
+	CALLED ON NULL INPUT
+	SECURITY DEFINER
+	PARALLEL UNSAFE
+	COST 1
+	AS
+$function$
+BEGIN
+    IF OLD.permission IS DISTINCT FROM NEW.permission OR OLD.deleted IS DISTINCT FROM NEW.deleted THEN
+        INSERT INTO tenant.cluster_outbox (organization_user_id, event, source)
+        SELECT tenant.organizations_users.id, 'updated', 'trigger'
+        FROM tenant.team_members
+        JOIN tenant.organizations_users
+            ON tenant.organizations_users.organization_id = NEW.organization_id
+            AND tenant.organizations_users.user_id = tenant.team_members.user_id
+            AND tenant.organizations_users.deleted IS NULL
+        WHERE tenant.team_members.team_id = NEW.id
+            AND tenant.team_members.deleted IS NULL;
+    END IF;
+    RETURN NEW;
+END;
+$function$;
+
+CREATE OR REPLACE TRIGGER cluster_outbox_team
+	AFTER UPDATE
+	ON tenant.teams
+	FOR EACH ROW
+	EXECUTE PROCEDURE tenant.cluster_outbox_team_trigger();
+
+
+-- Statements generated automatically, please review:
+ALTER FUNCTION tenant.cluster_outbox_team_member_trigger() OWNER TO fun_owner;
+ALTER FUNCTION tenant.cluster_outbox_project_team_trigger() OWNER TO fun_owner;
+ALTER FUNCTION tenant.cluster_outbox_team_trigger() OWNER TO fun_owner;
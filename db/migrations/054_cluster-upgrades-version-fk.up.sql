@@ -0,0 +1,25 @@
+-- Upgrades keep their target Kubernetes version in the catalog. Scheduled
+-- upgrades to a version that was already removed could never start; they are
+-- cancelled, and finished upgrades lose the dangling reference, before the
+-- constraint is validated.
+
+SET SESSION statement_timeout = 3000;
+SET SESSION lock_timeout = 3000;
+
+UPDATE "tenant"."cluster_upgrades"
+SET
+	"status" = 'cancelled',
+	"message" = 'Kubernetes version was removed from the catalog',
+	"finished" = now()
+WHERE "status" = 'scheduled'
+	AND "to_kubernetes_version_id" IS NOT NULL
+	AND NOT EXISTS (SELECT 1 FROM "catalog"."kubernetes_versions" WHERE "kubernetes_versions"."id" = "cluster_upgrades"."to_kubernetes_version_id");
+
+UPDATE "tenant"."cluster_upgrades"
+SET "to_kubernetes_version_id" = NULL
+WHERE "to_kubernetes_version_id" IS NOT NULL
+	AND NOT EXISTS (SELECT 1 FROM "catalog"."kubernetes_versions" WHERE "kubernetes_versions"."id" = "cluster_upgrades"."to_kubernetes_version_id");
+
+ALTER TABLE "tenant"."cluster_upgrades" ADD CONSTRAINT "cluster_upgrades_fk_to_kubernetes_version" FOREIGN KEY (to_kubernetes_version_id) REFERENCES catalog.kubernetes_versions(id) ON UPDATE CASCADE ON DELETE RESTRICT NOT VALID;
+
+ALTER TABLE "tenant"."cluster_upgrades" VALIDATE CONSTRAINT "cluster_upgrades_fk_to_kubernetes_version";
@@ -0,0 +1,8 @@
+-- cluster-worker keeps organization-wide outbox rows in order with the rows of
+-- the organization's clusters, which sit in other shards. The claim looks up
+-- an organization's queued rows for that; this index keeps the lookup cheap.
+
+SET SESSION statement_timeout = 3000;
+SET SESSION lock_timeout = 3000;
+
+CREATE INDEX cluster_outbox_idx_organization_id_queued ON tenant.cluster_outbox USING btree (organization_id, id) WHERE (status = ANY (ARRAY['pending'::text, 'retrying'::text]));
@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/caarlos0/env/v11"
+	"github.com/fundament-oss/fundament/common/auth"
 	"github.com/fundament-oss/fundament/common/psqldb"
 	"github.com/fundament-oss/fundament/dcim-api/pkg/dcim"
 	"github.com/rs/cors"
@@ -20,7 +21,8 @@ import (
 
 type config struct {
 	Database           psqldb.Config
-	JWTSecret          string   `env:"JWT_SECRET,required,notEmpty"`
+	JWKSURL            string   `env:"JWKS_URL"` // dcim-authn-api key set; with JWT_SECRET also set, both are accepted
+	JWTSecret          string   `env:"JWT_SECRET"`
 	ListenAddr         string   `env:"LISTEN_ADDR" envDefault:":8080"`
 	LogLevel           string   `env:"LOG_LEVEL" envDefault:"info"`
 	CORSAllowedOrigins []string `env:"CORS_ALLOWED_ORIGINS"`
@@ -46,13 +48,18 @@ func run() error {
 
 	ctx := context.Background()
 
+	jwtKeys, err := auth.NewKeys(cfg.JWKSURL, []byte(cfg.JWTSecret), logger)
+	if err != nil {
+		return fmt.Errorf("token verification: %w", err)
+	}
+
 	database, err := psqldb.New(ctx, logger, cfg.Database)
 	if err != nil {
 		return fmt.Errorf("database: %w", err)
 	}
 	defer database.Close()
 
-	server := dcim.New(logger, database, []byte(cfg.JWTSecret))
+	server := dcim.New(logger, database, jwtKeys)
 
 	mux := http.NewServeMux()
 	mux.HandleFunc("GET /livez", func(w http.ResponseWriter, _ *http.Request) {
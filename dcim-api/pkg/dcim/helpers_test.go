@@ -93,7 +93,7 @@ func newTestAPI(t *testing.T, options ...APIOption) *testEnv {
 
 	testDB, adminPool := createTestDB(t, testLogger)
 
-	srv := dcim.New(testLogger, testDB, []byte(testJWTSecret))
+	srv := dcim.New(testLogger, testDB, auth.SharedSecret(testJWTSecret))
 	ts := httptest.NewServer(srv.Handler())
 	t.Cleanup(ts.Close)
 
@@ -8,8 +8,10 @@ import (
 	"connectrpc.com/connect"
 	"connectrpc.com/grpcreflect"
 	"connectrpc.com/validate"
+	"github.com/fundament-oss/fundament/common/audit"
 	"github.com/fundament-oss/fundament/common/auth"
 	"github.com/fundament-oss/fundament/common/connectrecovery"
+	"github.com/fundament-oss/fundament/common/dbconst"
 	"github.com/fundament-oss/fundament/common/psqldb"
 	db "github.com/fundament-oss/fundament/dcim-api/pkg/db/gen"
 	"github.com/fundament-oss/fundament/dcim-api/pkg/proto/gen/v1/dcimv1connect"
@@ -24,12 +26,13 @@ type Server struct {
 	handler       http.Handler
 }
 
-func New(logger *slog.Logger, database *psqldb.DB, jwtSecret []byte) *Server {
+// New creates a Server that accepts DCIM tokens verified with jwtKeys.
+func New(logger *slog.Logger, database *psqldb.DB, jwtKeys auth.Keys) *Server {
 	s := &Server{
 		logger:        logger,
 		db:            database,
 		queries:       db.New(database.Pool),
-		authValidator: auth.NewValidator(jwtSecret, auth.DCIMAuthCookieName, auth.DCIMIssuer, logger),
+		authValidator: auth.NewValidator(jwtKeys, auth.DCIMAuthCookieName, auth.DCIMIssuer, logger),
 	}
 
 	mux := http.NewServeMux()
@@ -42,11 +45,14 @@ func New(logger *slog.Logger, database *psqldb.DB, jwtSecret []byte) *Server {
 	)
 
 	// TODO(FUN-17): when dcim-api gains JWT validation it MUST build its
-	// validator with auth.NewValidatorForAudience(secret, auth.TokenTypeUser, …)
+	// validator with auth.NewValidatorForAudience(jwtKeys, auth.TokenTypeUser, …)
 	// so a fundament-plugin token cannot be replayed against this surface.
 	interceptors := connect.WithInterceptors(
 		connectrecovery.NewInterceptor(logger),
 		s.authInterceptor(),
+		// DCIM is not organization-scoped, so its events have no organization
+		// and are only readable from the database.
+		audit.NewInterceptor(logger, audit.NewStore(database.Pool, logger), dbconst.AuditEventSource_DcimApi, auth.UserIDFromContext, nil),
 		loggingInterceptor,
 		validate.NewInterceptor(),
 	)
@@ -17,11 +17,15 @@ import (
 	"golang.org/x/net/http2/h2c"
 	"golang.org/x/oauth2"
 
+	"github.com/fundament-oss/fundament/common/auth"
 	"github.com/fundament-oss/fundament/dcim-authn-api/pkg/dcimauthn"
 )
 
 type config struct {
 	JWTSecret          string        `env:"JWT_SECRET,required,notEmpty"`
+	JWTSigningKeysDir  string        `env:"JWT_SIGNING_KEYS_DIR"`     // PEM private keys; when empty, tokens are signed HS256 with JWTSecret
+	JWTAcceptSecret    bool          `env:"JWT_ACCEPT_SHARED_SECRET"` // keep accepting HS256 tokens signed with JWTSecret next to the signing keys
+	JWTKeysReload      time.Duration `env:"JWT_SIGNING_KEYS_RELOAD_INTERVAL" envDefault:"1m"`
 	OIDCIssuer         string        `env:"OIDC_ISSUER,required,notEmpty" envDefault:"https://dex-dcim.fundament.localhost:8443"`
 	OIDCDiscoveryURL   string        `env:"OIDC_DISCOVERY_URL"`
 	ClientID           string        `env:"OIDC_CLIENT_ID,required,notEmpty" envDefault:"dcim"`
@@ -106,17 +110,33 @@ func run() error {
 	}
 	sessionStore.ConfigureOptions(cfg.CookieDomain, cfg.CookieSecure)
 
+	// JWTSecret keeps protecting the OAuth session cookie either way; only
+	// token signing moves to the key set.
+	signer := auth.NewSharedSecretSigner([]byte(cfg.JWTSecret))
+	if cfg.JWTSigningKeysDir != "" {
+		signer, err = auth.LoadSigner(cfg.JWTSigningKeysDir)
+		if err != nil {
+			return fmt.Errorf("failed to load JWT signing keys: %w", err)
+		}
+		logger.Info("signing tokens with asymmetric keys", "keys", len(signer.JWKS().Keys))
+		go signer.Watch(ctx, cfg.JWTKeysReload, logger)
+	}
+
 	serverCfg := &dcimauthn.Config{
 		TokenExpiry:  cfg.TokenExpiry,
-		JWTSecret:    []byte(cfg.JWTSecret),
+		Signer:       signer,
 		CookieDomain: cfg.CookieDomain,
 		CookieSecure: cfg.CookieSecure,
 		FrontendURL:  cfg.FrontendURL,
 	}
+	if cfg.JWTSigningKeysDir != "" && cfg.JWTAcceptSecret {
+		serverCfg.SharedSecret = []byte(cfg.JWTSecret)
+	}
 
 	server := dcimauthn.New(logger, serverCfg, oauth2Config, verifier, sessionStore)
 
 	mux := http.NewServeMux()
+	mux.Handle(auth.JWKSPath, signer.JWKSHandler())
 	mux.HandleFunc(http.MethodGet+" /livez", func(w http.ResponseWriter, _ *http.Request) {
 		w.WriteHeader(http.StatusOK)
 		_, _ = w.Write([]byte("ok"))
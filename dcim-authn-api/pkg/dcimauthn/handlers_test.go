@@ -1,6 +1,8 @@
 package dcimauthn
 
 import (
+	"crypto/ed25519"
+	"crypto/rand"
 	"io"
 	"log/slog"
 	"net/http"
@@ -13,24 +15,29 @@ import (
 	"github.com/fundament-oss/fundament/common/auth"
 )
 
-func refreshTestServer() *Server {
+var testSecret = []byte("test-secret-test-secret-test!!")
+
+func refreshTestServer(t *testing.T) *Server {
+	t.Helper()
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("generating key: %v", err)
+	}
+	signer, err := auth.NewSigner(key)
+	if err != nil {
+		t.Fatalf("creating signer: %v", err)
+	}
 	cfg := &Config{
-		JWTSecret:    []byte("test-secret-test-secret-test!!"),
+		Signer:       signer,
+		SharedSecret: testSecret,
 		TokenExpiry:  time.Hour,
 		CookieDomain: "localhost",
 	}
-	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
-	return &Server{
-		config:        cfg,
-		logger:        logger,
-		validator:     auth.NewValidator(cfg.JWTSecret, auth.DCIMAuthCookieName, auth.DCIMIssuer, logger),
-		cookieBuilder: auth.NewCookieBuilder(cfg.CookieDomain, cfg.CookieSecure, auth.DCIMAuthCookieName),
-	}
+	return New(slog.New(slog.NewTextHandler(io.Discard, nil)), cfg, nil, nil, nil)
 }
 
-func signRefreshToken(t *testing.T, secret []byte) string {
-	t.Helper()
-	claims := auth.Claims{
+func refreshClaims() auth.Claims {
+	return auth.Claims{
 		RegisteredClaims: jwt.RegisteredClaims{
 			Issuer:    auth.DCIMIssuer,
 			Subject:   "019b4000-1000-7000-8000-000000000001",
@@ -39,16 +46,37 @@ func signRefreshToken(t *testing.T, secret []byte) string {
 		},
 		Name: "Alice",
 	}
-	signed, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(secret)
+}
+
+func TestHandleRefresh_ValidToken(t *testing.T) {
+	s := refreshTestServer(t)
+	token, err := s.config.Signer.Sign(refreshClaims())
 	if err != nil {
 		t.Fatalf("signing token: %v", err)
 	}
-	return signed
+
+	req := httptest.NewRequest(http.MethodPost, "/refresh", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	rec := httptest.NewRecorder()
+
+	s.HandleRefresh(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", rec.Code)
+	}
+	if len(rec.Result().Cookies()) == 0 {
+		t.Fatal("expected a refreshed auth cookie to be set")
+	}
 }
 
-func TestHandleRefresh_ValidToken(t *testing.T) {
-	s := refreshTestServer()
-	token := signRefreshToken(t, s.config.JWTSecret)
+// A DCIM session started before the switch to signing keys is refreshed into
+// a token signed with the active key.
+func TestHandleRefresh_SharedSecretToken(t *testing.T) {
+	s := refreshTestServer(t)
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, refreshClaims()).SignedString(testSecret)
+	if err != nil {
+		t.Fatalf("signing token: %v", err)
+	}
 
 	req := httptest.NewRequest(http.MethodPost, "/refresh", nil)
 	req.Header.Set("Authorization", "Bearer "+token)
@@ -59,13 +87,21 @@ func TestHandleRefresh_ValidToken(t *testing.T) {
 	if rec.Code != http.StatusOK {
 		t.Fatalf("status = %d, want 200", rec.Code)
 	}
-	if len(rec.Result().Cookies()) == 0 {
+	cookies := rec.Result().Cookies()
+	if len(cookies) == 0 {
 		t.Fatal("expected a refreshed auth cookie to be set")
 	}
+	refreshed, _, err := jwt.NewParser().ParseUnverified(cookies[0].Value, &auth.Claims{})
+	if err != nil {
+		t.Fatalf("parsing refreshed token: %v", err)
+	}
+	if refreshed.Method.Alg() != jwt.SigningMethodEdDSA.Alg() {
+		t.Fatalf("refreshed token alg = %s, want %s", refreshed.Method.Alg(), jwt.SigningMethodEdDSA.Alg())
+	}
 }
 
 func TestHandleRefresh_InvalidToken(t *testing.T) {
-	s := refreshTestServer()
+	s := refreshTestServer(t)
 
 	req := httptest.NewRequest(http.MethodPost, "/refresh", nil)
 	req.Header.Set("Authorization", "Bearer not-a-valid-token")
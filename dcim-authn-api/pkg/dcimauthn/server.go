@@ -21,7 +21,8 @@ import (
 // Config holds the configuration for the DCIM auth server.
 type Config struct {
 	TokenExpiry  time.Duration
-	JWTSecret    []byte
+	Signer       *auth.Signer
+	SharedSecret []byte // when set, HS256 tokens signed with it stay valid next to Signer's keys
 	CookieDomain string
 	CookieSecure bool
 	FrontendURL  string
@@ -40,13 +41,20 @@ type Server struct {
 
 // New creates a new DCIM auth Server.
 func New(logger *slog.Logger, cfg *Config, oauth2Config *oauth2.Config, verifier *oidc.IDTokenVerifier, sessionStore *SessionStore) *Server {
+	// Trust the Signer's keys and, while switching over, the shared secret,
+	// so DCIM sessions started before the switch can still be refreshed.
+	var keys auth.Keys = cfg.Signer
+	if len(cfg.SharedSecret) > 0 {
+		keys = auth.ChainKeys(cfg.Signer, auth.SharedSecret(cfg.SharedSecret))
+	}
+
 	return &Server{
 		config:        cfg,
 		logger:        logger,
 		oauth2Config:  oauth2Config,
 		oidcVerifier:  verifier,
 		sessionStore:  sessionStore,
-		validator:     auth.NewValidator(cfg.JWTSecret, auth.DCIMAuthCookieName, auth.DCIMIssuer, logger),
+		validator:     auth.NewValidator(keys, auth.DCIMAuthCookieName, auth.DCIMIssuer, logger),
 		cookieBuilder: auth.NewCookieBuilder(cfg.CookieDomain, cfg.CookieSecure, auth.DCIMAuthCookieName),
 	}
 }
@@ -102,12 +110,7 @@ func (s *Server) mintJWT(subject, name string) (string, error) {
 		Name: name,
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwtClaims)
-	signed, err := token.SignedString(s.config.JWTSecret)
-	if err != nil {
-		return "", fmt.Errorf("signing token: %w", err)
-	}
-	return signed, nil
+	return s.config.Signer.Sign(jwtClaims)
 }
 
 func (s *Server) buildAuthCookie(token string) *http.Cookie {
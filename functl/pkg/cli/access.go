@@ -0,0 +1,230 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	organizationv1 "github.com/fundament-oss/fundament/organization-api/pkg/proto/gen/v1"
+)
+
+// AccessCmd contains access elevation subcommands.
+type AccessCmd struct {
+	Request AccessRequestCmd `cmd:"" help:"Request a temporary admin role."`
+	List    AccessListCmd    `cmd:"" help:"List access elevations."`
+	Approve AccessApproveCmd `cmd:"" help:"Approve a pending request."`
+	Deny    AccessDenyCmd    `cmd:"" help:"Deny a pending request."`
+	Revoke  AccessRevokeCmd  `cmd:"" help:"Cancel a pending request or end an approved elevation early."`
+}
+
+// AccessRequestCmd handles requesting an access elevation.
+type AccessRequestCmd struct {
+	Role     string        `help:"Role to request (admin or project-admin)." required:"" enum:"admin,project-admin"`
+	Project  string        `help:"Project ID, for the project-admin role."`
+	Duration time.Duration `help:"How long to hold the role once approved, at most 24h." default:"1h"`
+	Reason   string        `help:"Why the role is needed, shown to approvers." required:""`
+}
+
+// Run executes the access request command.
+func (c *AccessRequestCmd) Run(ctx *Context) error {
+	if c.Duration < time.Minute || c.Duration > 24*time.Hour {
+		return fmt.Errorf("duration must be between 1m and 24h")
+	}
+
+	apiClient, err := NewClientFromConfigWithOrg(ctx)
+	if err != nil {
+		return err
+	}
+
+	req := organizationv1.RequestAccessElevationRequest_builder{
+		Role:            accessElevationRole(c.Role),
+		DurationMinutes: int32(c.Duration / time.Minute),
+		Reason:          c.Reason,
+	}.Build()
+	if c.Project != "" {
+		req.SetProjectId(c.Project)
+	}
+
+	resp, err := apiClient.AccessElevations().RequestAccessElevation(context.Background(), req)
+	if err != nil {
+		return fmt.Errorf("failed to request access elevation: %w", err)
+	}
+
+	if ctx.Output == OutputJSON {
+		return PrintJSON(map[string]string{
+			"access_elevation_id": resp.GetAccessElevationId(),
+		})
+	}
+
+	fmt.Printf("Requested %s for %s (request %s); waiting for approval\n", c.Role, c.Duration, resp.GetAccessElevationId())
+	return nil
+}
+
+// AccessListCmd handles listing access elevations.
+type AccessListCmd struct {
+	Project string `help:"Only list elevations for this project ID."`
+	Status  string `help:"Only list elevations in this state." enum:",pending,approved,denied,cancelled,revoked,expired" default:""`
+}
+
+// Run executes the access list command.
+func (c *AccessListCmd) Run(ctx *Context) error {
+	apiClient, err := NewClientFromConfigWithOrg(ctx)
+	if err != nil {
+		return err
+	}
+
+	status := organizationv1.AccessElevationStatus_ACCESS_ELEVATION_STATUS_UNSPECIFIED
+	if c.Status != "" {
+		status = organizationv1.AccessElevationStatus(
+			organizationv1.AccessElevationStatus_value["ACCESS_ELEVATION_STATUS_"+strings.ToUpper(c.Status)])
+	}
+
+	elevations, err := listAllPages(func(pageToken string) ([]*organizationv1.AccessElevation, string, error) {
+		req := organizationv1.ListAccessElevationsRequest_builder{
+			PageSize:  listPageSize,
+			PageToken: pageToken,
+			Status:    status,
+		}.Build()
+		if c.Project != "" {
+			req.SetProjectId(c.Project)
+		}
+		resp, err := apiClient.AccessElevations().ListAccessElevations(context.Background(), req)
+		return resp.GetAccessElevations(), resp.GetNextPageToken(), err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list access elevations: %w", err)
+	}
+
+	if ctx.Output == OutputJSON {
+		return PrintJSON(elevations)
+	}
+
+	if len(elevations) == 0 {
+		fmt.Println("No access elevations found")
+		return nil
+	}
+
+	w := NewTableWriter()
+	fmt.Fprintln(w, "ID\tUSER\tROLE\tPROJECT\tDURATION\tSTATUS\tEXPIRES\tREASON")
+	for _, elevation := range elevations {
+		expires := ""
+		if elevation.GetExpires().IsValid() {
+			expires = elevation.GetExpires().AsTime().Format(TimeFormat)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			elevation.GetId(),
+			elevation.GetUserName(),
+			enumName(elevation.GetRole().String(), "ACCESS_ELEVATION_ROLE_"),
+			elevation.GetProjectId(),
+			time.Duration(elevation.GetDurationMinutes())*time.Minute,
+			enumName(elevation.GetStatus().String(), "ACCESS_ELEVATION_STATUS_"),
+			expires,
+			elevation.GetReason(),
+		)
+	}
+	return w.Flush()
+}
+
+// AccessApproveCmd handles approving an access elevation.
+type AccessApproveCmd struct {
+	ID string `arg:"" help:"Access elevation ID." name:"id"`
+}
+
+// Run executes the access approve command.
+func (c *AccessApproveCmd) Run(ctx *Context) error {
+	apiClient, err := NewClientFromConfigWithOrg(ctx)
+	if err != nil {
+		return err
+	}
+
+	resp, err := apiClient.AccessElevations().ApproveAccessElevation(context.Background(), organizationv1.ApproveAccessElevationRequest_builder{
+		AccessElevationId: c.ID,
+	}.Build())
+	if err != nil {
+		return fmt.Errorf("failed to approve access elevation: %w", err)
+	}
+
+	expires := resp.GetExpires().AsTime().Format(TimeFormat)
+
+	if ctx.Output == OutputJSON {
+		return PrintJSON(map[string]string{
+			"access_elevation_id": c.ID,
+			"expires":             expires,
+		})
+	}
+
+	fmt.Printf("Approved access elevation %s until %s\n", c.ID, expires)
+	return nil
+}
+
+// AccessDenyCmd handles denying an access elevation.
+type AccessDenyCmd struct {
+	ID string `arg:"" help:"Access elevation ID." name:"id"`
+}
+
+// Run executes the access deny command.
+func (c *AccessDenyCmd) Run(ctx *Context) error {
+	apiClient, err := NewClientFromConfigWithOrg(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = apiClient.AccessElevations().DenyAccessElevation(context.Background(), organizationv1.DenyAccessElevationRequest_builder{
+		AccessElevationId: c.ID,
+	}.Build())
+	if err != nil {
+		return fmt.Errorf("failed to deny access elevation: %w", err)
+	}
+
+	if ctx.Output == OutputJSON {
+		return PrintJSON(map[string]string{
+			"access_elevation_id": c.ID,
+		})
+	}
+
+	fmt.Printf("Denied access elevation %s\n", c.ID)
+	return nil
+}
+
+// AccessRevokeCmd handles revoking an access elevation.
+type AccessRevokeCmd struct {
+	ID string `arg:"" help:"Access elevation ID." name:"id"`
+}
+
+// Run executes the access revoke command.
+func (c *AccessRevokeCmd) Run(ctx *Context) error {
+	apiClient, err := NewClientFromConfigWithOrg(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = apiClient.AccessElevations().RevokeAccessElevation(context.Background(), organizationv1.RevokeAccessElevationRequest_builder{
+		AccessElevationId: c.ID,
+	}.Build())
+	if err != nil {
+		return fmt.Errorf("failed to revoke access elevation: %w", err)
+	}
+
+	if ctx.Output == OutputJSON {
+		return PrintJSON(map[string]string{
+			"access_elevation_id": c.ID,
+		})
+	}
+
+	fmt.Printf("Revoked access elevation %s\n", c.ID)
+	return nil
+}
+
+func accessElevationRole(role string) organizationv1.AccessElevationRole {
+	if role == "project-admin" {
+		return organizationv1.AccessElevationRole_ACCESS_ELEVATION_ROLE_PROJECT_ADMIN
+	}
+	return organizationv1.AccessElevationRole_ACCESS_ELEVATION_ROLE_ADMIN
+}
+
+// enumName turns a proto enum value name into its lower-case suffix:
+// ACCESS_ELEVATION_STATUS_PENDING becomes pending.
+func enumName(name, prefix string) string {
+	return strings.ToLower(strings.TrimPrefix(name, prefix))
+}
@@ -16,7 +16,9 @@ type APIKeyCmd struct {
 }
 
 // APIKeyListCmd handles the apikey list command.
-type APIKeyListCmd struct{}
+type APIKeyListCmd struct {
+	Filter string `help:"Only list API keys whose name contains this text."`
+}
 
 // Run executes the apikey list command.
 func (c *APIKeyListCmd) Run(ctx *Context) error {
@@ -25,13 +27,18 @@ func (c *APIKeyListCmd) Run(ctx *Context) error {
 		return err
 	}
 
-	resp, err := apiClient.APIKeys().ListAPIKeys(context.Background(), organizationv1.ListAPIKeysRequest_builder{}.Build())
+	apiKeys, err := listAllPages(func(pageToken string) ([]*organizationv1.APIKey, string, error) {
+		resp, err := apiClient.APIKeys().ListAPIKeys(context.Background(), organizationv1.ListAPIKeysRequest_builder{
+			PageSize:   listPageSize,
+			PageToken:  pageToken,
+			NameFilter: c.Filter,
+		}.Build())
+		return resp.GetApiKeys(), resp.GetNextPageToken(), err
+	})
 	if err != nil {
 		return fmt.Errorf("failed to list API keys: %w", err)
 	}
 
-	apiKeys := resp.GetApiKeys()
-
 	if ctx.Output == OutputJSON {
 		return PrintJSON(apiKeys)
 	}
@@ -75,8 +82,12 @@ func (c *APIKeyListCmd) Run(ctx *Context) error {
 
 // APIKeyCreateCmd handles the apikey create command.
 type APIKeyCreateCmd struct {
-	Name      string `arg:"" help:"Name for the API key."`
-	ExpiresIn string `help:"How long until the Key expires. Format is specified in string, e.g. '1h', '300s', or '5m'. Omit for no expiry." short:"e"`
+	Name      string   `arg:"" help:"Name for the API key."`
+	ExpiresIn string   `help:"How long until the Key expires. Format is specified in string, e.g. '1h', '300s', or '5m'. Omit for no expiry." short:"e"`
+	Projects  []string `name:"project" help:"Restrict the key to this project ID. Repeatable."`
+	Clusters  []string `name:"cluster" help:"Restrict the key to this cluster ID, including its projects and node pools. Repeatable."`
+	Actions   []string `name:"action" help:"Restrict the key to this action, e.g. 'can_view' or 'can_create_namespace'. Repeatable."`
+	ReadOnly  bool     `help:"Restrict the key to viewing and listing."`
 }
 
 // Run executes the apikey create command.
@@ -90,6 +101,14 @@ func (c *APIKeyCreateCmd) Run(ctx *Context) error {
 		Name:      c.Name,
 		ExpiresIn: c.ExpiresIn,
 	}.Build()
+	if len(c.Projects) > 0 || len(c.Clusters) > 0 || len(c.Actions) > 0 || c.ReadOnly {
+		req.SetScope(organizationv1.APIKeyScope_builder{
+			ProjectIds: c.Projects,
+			ClusterIds: c.Clusters,
+			Actions:    c.Actions,
+			ReadOnly:   c.ReadOnly,
+		}.Build())
+	}
 
 	resp, err := apiClient.APIKeys().CreateAPIKey(context.Background(), req)
 	if err != nil {
@@ -21,11 +21,14 @@ type CLI struct {
 
 	Auth      AuthCmd      `cmd:"" help:"Authentication commands."`
 	Cluster   ClusterCmd   `cmd:"" help:"Manage clusters."`
+	NodePool  NodePoolCmd  `cmd:"" name:"nodepool" help:"Manage node pools."`
 	Config    ConfigCmd    `cmd:"" help:"Configuration introspection."`
 	Org       OrgCmd       `cmd:"" help:"Manage organization."`
 	Project   ProjectCmd   `cmd:"" help:"Manage projects."`
 	Namespace NamespaceCmd `cmd:"" help:"Manage namespaces."`
 	APIKey    APIKeyCmd    `cmd:"" name:"apikey" help:"Manage API keys."`
+	Plugin    PluginCmd    `cmd:"" help:"Browse the plugin catalog and manage plugin installations."`
+	Access    AccessCmd    `cmd:"" help:"Request and approve temporary elevated access."`
 	Version   VersionCmd   `cmd:"" help:"Print the functl version."`
 }
 
@@ -11,12 +11,19 @@ import (
 type ClusterCmd struct {
 	List       ClusterListCmd       `cmd:"" help:"List all clusters."`
 	Get        ClusterGetCmd        `cmd:"" help:"Get cluster details."`
+	Create     ClusterCreateCmd     `cmd:"" help:"Create a new cluster."`
+	Update     ClusterUpdateCmd     `cmd:"" help:"Update a cluster."`
+	Delete     ClusterDeleteCmd     `cmd:"" help:"Delete a cluster."`
+	Hibernate  ClusterHibernateCmd  `cmd:"" help:"Hibernate a cluster."`
+	Wake       ClusterWakeCmd       `cmd:"" help:"Wake a hibernated cluster."`
 	Kubeconfig ClusterKubeconfigCmd `cmd:"" help:"Generate kubeconfig for a cluster."`
 	Token      ClusterTokenCmd      `cmd:"" help:"Get a service account token for a cluster."`
 }
 
 // ClusterListCmd handles the cluster list command.
-type ClusterListCmd struct{}
+type ClusterListCmd struct {
+	Filter string `help:"Only list clusters whose name contains this text."`
+}
 
 // Run executes the cluster list command.
 func (c *ClusterListCmd) Run(ctx *Context) error {
@@ -25,13 +32,18 @@ func (c *ClusterListCmd) Run(ctx *Context) error {
 		return err
 	}
 
-	resp, err := apiClient.Clusters().ListClusters(context.Background(), organizationv1.ListClustersRequest_builder{}.Build())
+	clusters, err := listAllPages(func(pageToken string) ([]*organizationv1.ListClustersResponse_ClusterSummary, string, error) {
+		resp, err := apiClient.Clusters().ListClusters(context.Background(), organizationv1.ListClustersRequest_builder{
+			PageSize:   listPageSize,
+			PageToken:  pageToken,
+			NameFilter: c.Filter,
+		}.Build())
+		return resp.GetClusters(), resp.GetNextPageToken(), err
+	})
 	if err != nil {
 		return fmt.Errorf("failed to list clusters: %w", err)
 	}
 
-	clusters := resp.GetClusters()
-
 	if ctx.Output == OutputJSON {
 		return PrintJSON(clusters)
 	}
@@ -88,9 +100,274 @@ func (c *ClusterGetCmd) Run(ctx *Context) error {
 	if cluster.GetCreated() != nil {
 		PrintKeyValue(w, "Created", cluster.GetCreated().AsTime().Format(TimeFormat))
 	}
+	for _, schedule := range cluster.GetHibernation().GetSchedules() {
+		PrintKeyValue(w, "Hibernation Schedule", formatHibernationSchedule(schedule))
+	}
 	return w.Flush()
 }
 
+// ClusterCreateCmd handles the cluster create command.
+type ClusterCreateCmd struct {
+	Name              string            `arg:"" help:"Name of the cluster to create."`
+	Region            string            `required:"" help:"Region to create the cluster in."`
+	KubernetesVersion string            `required:"" name:"kubernetes-version" help:"Kubernetes version of the cluster."`
+	Label             map[string]string `help:"Label of the cluster as key=value. Repeatable."`
+	ClusterWaitFlags
+}
+
+// Run executes the cluster create command.
+func (c *ClusterCreateCmd) Run(ctx *Context) error {
+	apiClient, err := NewClientFromConfigWithOrg(ctx)
+	if err != nil {
+		return err
+	}
+
+	resp, err := apiClient.Clusters().CreateCluster(context.Background(), organizationv1.CreateClusterRequest_builder{
+		Name:              c.Name,
+		Region:            c.Region,
+		KubernetesVersion: c.KubernetesVersion,
+		Labels:            c.Label,
+	}.Build())
+	if err != nil {
+		return fmt.Errorf("failed to create cluster: %w", err)
+	}
+
+	clusterID := resp.GetClusterId()
+
+	if c.Wait {
+		if err := waitForCluster(apiClient.Clusters(), clusterID, &clusterWaiter{}, c.WaitTimeout); err != nil {
+			return err
+		}
+	}
+
+	if ctx.Output == OutputJSON {
+		return PrintJSON(map[string]string{
+			"cluster_id": clusterID,
+			"name":       c.Name,
+		})
+	}
+
+	fmt.Printf("Created cluster %s (ID: %s)\n", c.Name, clusterID)
+	return nil
+}
+
+// ClusterUpdateCmd handles the cluster update command.
+type ClusterUpdateCmd struct {
+	ClusterID         string `arg:"" help:"Cluster ID to update."`
+	KubernetesVersion string `required:"" name:"kubernetes-version" help:"Kubernetes version to upgrade the cluster to."`
+	ClusterWaitFlags
+}
+
+// Run executes the cluster update command.
+func (c *ClusterUpdateCmd) Run(ctx *Context) error {
+	apiClient, err := NewClientFromConfigWithOrg(ctx)
+	if err != nil {
+		return err
+	}
+
+	var waiter *clusterWaiter
+	if c.Wait {
+		if waiter, err = newClusterWaiter(apiClient.Clusters(), c.ClusterID); err != nil {
+			return err
+		}
+	}
+
+	version := c.KubernetesVersion
+	_, err = apiClient.Clusters().UpdateCluster(context.Background(), organizationv1.UpdateClusterRequest_builder{
+		ClusterId:         c.ClusterID,
+		KubernetesVersion: &version,
+	}.Build())
+	if err != nil {
+		return fmt.Errorf("failed to update cluster: %w", err)
+	}
+
+	if c.Wait {
+		if err := waitForCluster(apiClient.Clusters(), c.ClusterID, waiter, c.WaitTimeout); err != nil {
+			return err
+		}
+	}
+
+	if ctx.Output == OutputJSON {
+		return PrintJSON(map[string]string{
+			"cluster_id":         c.ClusterID,
+			"kubernetes_version": c.KubernetesVersion,
+		})
+	}
+
+	if c.Wait {
+		fmt.Printf("Updated cluster %s to Kubernetes %s\n", c.ClusterID, c.KubernetesVersion)
+		return nil
+	}
+
+	fmt.Printf("Cluster %s is being upgraded to Kubernetes %s\n", c.ClusterID, c.KubernetesVersion)
+	return nil
+}
+
+// ClusterDeleteCmd handles the cluster delete command.
+type ClusterDeleteCmd struct {
+	ClusterID string `arg:"" help:"Cluster ID to delete."`
+	ClusterWaitFlags
+}
+
+// Run executes the cluster delete command.
+func (c *ClusterDeleteCmd) Run(ctx *Context) error {
+	apiClient, err := NewClientFromConfigWithOrg(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = apiClient.Clusters().DeleteCluster(context.Background(), organizationv1.DeleteClusterRequest_builder{
+		ClusterId: c.ClusterID,
+	}.Build())
+	if err != nil {
+		return fmt.Errorf("failed to delete cluster: %w", err)
+	}
+
+	if c.Wait {
+		if err := waitForCluster(apiClient.Clusters(), c.ClusterID, &clusterWaiter{deleting: true}, c.WaitTimeout); err != nil {
+			return err
+		}
+	}
+
+	if ctx.Output == OutputJSON {
+		status := "deleting"
+		if c.Wait {
+			status = "deleted"
+		}
+		return PrintJSON(map[string]string{
+			"cluster_id": c.ClusterID,
+			"status":     status,
+		})
+	}
+
+	if c.Wait {
+		fmt.Printf("Cluster %s has been deleted\n", c.ClusterID)
+		return nil
+	}
+
+	fmt.Printf("Cluster %s is being deleted\n", c.ClusterID)
+	return nil
+}
+
+// ClusterHibernateCmd handles the cluster hibernate command.
+type ClusterHibernateCmd struct {
+	ClusterID string `arg:"" help:"Cluster ID to hibernate."`
+	ClusterWaitFlags
+}
+
+// Run executes the cluster hibernate command.
+func (c *ClusterHibernateCmd) Run(ctx *Context) error {
+	apiClient, err := NewClientFromConfigWithOrg(ctx)
+	if err != nil {
+		return err
+	}
+
+	var waiter *clusterWaiter
+	if c.Wait {
+		if waiter, err = newClusterWaiter(apiClient.Clusters(), c.ClusterID); err != nil {
+			return err
+		}
+		waiter.hibernating = true
+	}
+
+	_, err = apiClient.Clusters().HibernateCluster(context.Background(), organizationv1.HibernateClusterRequest_builder{
+		ClusterId: c.ClusterID,
+	}.Build())
+	if err != nil {
+		return fmt.Errorf("failed to hibernate cluster: %w", err)
+	}
+
+	if c.Wait {
+		if err := waitForCluster(apiClient.Clusters(), c.ClusterID, waiter, c.WaitTimeout); err != nil {
+			return err
+		}
+	}
+
+	if ctx.Output == OutputJSON {
+		status := "stopping"
+		if c.Wait {
+			status = "hibernated"
+		}
+		return PrintJSON(map[string]string{
+			"cluster_id": c.ClusterID,
+			"status":     status,
+		})
+	}
+
+	if c.Wait {
+		fmt.Printf("Cluster %s is hibernated\n", c.ClusterID)
+		return nil
+	}
+
+	fmt.Printf("Cluster %s is being hibernated\n", c.ClusterID)
+	return nil
+}
+
+// ClusterWakeCmd handles the cluster wake command.
+type ClusterWakeCmd struct {
+	ClusterID string `arg:"" help:"Cluster ID to wake."`
+	ClusterWaitFlags
+}
+
+// Run executes the cluster wake command.
+func (c *ClusterWakeCmd) Run(ctx *Context) error {
+	apiClient, err := NewClientFromConfigWithOrg(ctx)
+	if err != nil {
+		return err
+	}
+
+	var waiter *clusterWaiter
+	if c.Wait {
+		if waiter, err = newClusterWaiter(apiClient.Clusters(), c.ClusterID); err != nil {
+			return err
+		}
+	}
+
+	_, err = apiClient.Clusters().WakeCluster(context.Background(), organizationv1.WakeClusterRequest_builder{
+		ClusterId: c.ClusterID,
+	}.Build())
+	if err != nil {
+		return fmt.Errorf("failed to wake cluster: %w", err)
+	}
+
+	if c.Wait {
+		if err := waitForCluster(apiClient.Clusters(), c.ClusterID, waiter, c.WaitTimeout); err != nil {
+			return err
+		}
+	}
+
+	if ctx.Output == OutputJSON {
+		status := "starting"
+		if c.Wait {
+			status = "running"
+		}
+		return PrintJSON(map[string]string{
+			"cluster_id": c.ClusterID,
+			"status":     status,
+		})
+	}
+
+	if c.Wait {
+		fmt.Printf("Cluster %s is awake\n", c.ClusterID)
+		return nil
+	}
+
+	fmt.Printf("Cluster %s is waking up\n", c.ClusterID)
+	return nil
+}
+
+// formatHibernationSchedule formats a hibernation schedule for display.
+func formatHibernationSchedule(schedule *organizationv1.HibernationSchedule) string {
+	start, end := schedule.GetStart(), schedule.GetEnd()
+	if start == "" {
+		start = "-"
+	}
+	if end == "" {
+		end = "-"
+	}
+	return fmt.Sprintf("hibernate %s, wake %s (%s)", start, end, schedule.GetLocation())
+}
+
 // formatClusterStatus formats a cluster status for display.
 func formatClusterStatus(status organizationv1.ClusterStatus) string {
 	switch status {
@@ -110,6 +387,10 @@ func formatClusterStatus(status organizationv1.ClusterStatus) string {
 		return "stopping"
 	case organizationv1.ClusterStatus_CLUSTER_STATUS_STOPPED:
 		return "stopped"
+	case organizationv1.ClusterStatus_CLUSTER_STATUS_DELETING:
+		return "deleting"
+	case organizationv1.ClusterStatus_CLUSTER_STATUS_HIBERNATED:
+		return "hibernated"
 	default:
 		return "unknown"
 	}
@@ -0,0 +1,196 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"connectrpc.com/connect"
+
+	organizationv1 "github.com/fundament-oss/fundament/organization-api/pkg/proto/gen/v1"
+	"github.com/fundament-oss/fundament/organization-api/pkg/proto/gen/v1/organizationv1connect"
+)
+
+const clusterWaitPollInterval = 10 * time.Second
+
+// Outbox and Gardener shoot states as reported in SyncState, and the cluster
+// event cluster-worker records once Gardener accepted a sync.
+const (
+	outboxStatusCompleted = "completed"
+	outboxStatusFailed    = "failed"
+	shootStatusReady      = "ready"
+	shootStatusError      = "error"
+	shootStatusDeleted    = "deleted"
+	shootStatusHibernated = "hibernated"
+
+	clusterEventSyncSucceeded = "sync_succeeded"
+)
+
+// ClusterWaitFlags are shared by the cluster commands that change a cluster.
+type ClusterWaitFlags struct {
+	Wait        bool          `help:"Wait until the change has been synced to the cluster."`
+	WaitTimeout time.Duration `default:"30m" help:"How long --wait waits before giving up."`
+}
+
+// clusterWaiter decides from successive GetCluster and GetClusterActivity
+// polls whether a change has reached the cluster.
+type clusterWaiter struct {
+	deleting bool
+	// hibernating waits for the shoot to report hibernated instead of ready.
+	hibernating bool
+
+	// baselineSyncID is the newest sync_succeeded event from before the
+	// change was requested. The change has reached Gardener once a newer
+	// sync_succeeded event shows up. The status worker does not re-poll a
+	// ready cluster after a plain sync, so the shoot status alone cannot
+	// tell an old "ready" from a new one.
+	baselineSyncID string
+}
+
+// newClusterWaiter records the cluster's newest sync_succeeded event before a
+// change is requested, so the wait ignores syncs that happened earlier.
+func newClusterWaiter(clusters organizationv1connect.ClusterServiceClient, clusterID string) (*clusterWaiter, error) {
+	events, err := getClusterEvents(context.Background(), clusters, clusterID)
+	if err != nil {
+		return nil, err
+	}
+	return &clusterWaiter{baselineSyncID: latestSyncSucceeded(events).GetId()}, nil
+}
+
+// observe returns true once the cluster has reached the desired state, or an
+// error once it cannot anymore. events is the cluster's activity, newest
+// first.
+func (w *clusterWaiter) observe(cluster *organizationv1.ClusterDetails, events []*organizationv1.ClusterEvent) (bool, error) {
+	state := cluster.GetSyncState()
+
+	if state.GetOutboxStatus() == outboxStatusFailed {
+		return false, fmt.Errorf("cluster sync failed: %s", state.GetOutboxError())
+	}
+
+	if w.deleting {
+		return state.GetShootStatus() == shootStatusDeleted, nil
+	}
+
+	if state.GetOutboxStatus() != outboxStatusCompleted {
+		return false, nil
+	}
+
+	synced := latestSyncSucceeded(events)
+	if synced == nil || synced.GetId() == w.baselineSyncID {
+		return false, nil
+	}
+
+	switch state.GetShootStatus() {
+	case shootStatusReady:
+		return !w.hibernating, nil
+	case shootStatusHibernated:
+		return w.hibernating, nil
+	case shootStatusError:
+		// An error from before the sync may be cleared by it; the status
+		// worker re-polls clusters in error, so wait for a fresh one.
+		if !state.GetStatusUpdatedAt().AsTime().After(synced.GetCreatedAt().AsTime()) {
+			return false, nil
+		}
+		return false, fmt.Errorf("cluster reported an error: %s", state.GetShootMessage())
+	default:
+		return false, nil
+	}
+}
+
+// latestSyncSucceeded returns the newest sync_succeeded event, or nil.
+func latestSyncSucceeded(events []*organizationv1.ClusterEvent) *organizationv1.ClusterEvent {
+	for _, event := range events {
+		if event.GetEventType() == clusterEventSyncSucceeded {
+			return event
+		}
+	}
+	return nil
+}
+
+// getClusterEvents fetches the cluster's recent activity, newest first.
+func getClusterEvents(ctx context.Context, clusters organizationv1connect.ClusterServiceClient, clusterID string) ([]*organizationv1.ClusterEvent, error) {
+	resp, err := clusters.GetClusterActivity(ctx, organizationv1.GetClusterActivityRequest_builder{
+		ClusterId: clusterID,
+	}.Build())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cluster activity: %w", err)
+	}
+	return resp.GetEvents(), nil
+}
+
+// waitForCluster polls the cluster until the waiter is satisfied. A deleted
+// cluster that can no longer be found counts as done.
+func waitForCluster(clusters organizationv1connect.ClusterServiceClient, clusterID string, waiter *clusterWaiter, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	ticker := time.NewTicker(clusterWaitPollInterval)
+	defer ticker.Stop()
+
+	lastProgress := ""
+	for {
+		resp, err := clusters.GetCluster(ctx, organizationv1.GetClusterRequest_builder{
+			ClusterId: clusterID,
+		}.Build())
+		switch {
+		case waiter.deleting && connect.CodeOf(err) == connect.CodeNotFound:
+			return nil
+		case ctx.Err() != nil:
+			return fmt.Errorf("timed out after %s waiting for cluster %s", timeout, clusterID)
+		case err != nil:
+			return fmt.Errorf("failed to get cluster: %w", err)
+		}
+
+		var events []*organizationv1.ClusterEvent
+		if !waiter.deleting {
+			events, err = getClusterEvents(ctx, clusters, clusterID)
+			switch {
+			case ctx.Err() != nil:
+				return fmt.Errorf("timed out after %s waiting for cluster %s", timeout, clusterID)
+			case err != nil:
+				return err
+			}
+		}
+
+		cluster := resp.GetCluster()
+		done, err := waiter.observe(cluster, events)
+		if err != nil {
+			return err
+		}
+		if done {
+			return nil
+		}
+
+		progress := formatSyncProgress(cluster)
+		if progress != lastProgress {
+			fmt.Fprintf(os.Stderr, "Waiting for cluster %s: %s\n", clusterID, progress)
+			lastProgress = progress
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("timed out after %s waiting for cluster %s", timeout, clusterID)
+		case <-ticker.C:
+		}
+	}
+}
+
+// formatSyncProgress summarizes a cluster's status and sync state on one line.
+func formatSyncProgress(cluster *organizationv1.ClusterDetails) string {
+	state := cluster.GetSyncState()
+
+	sync := "unknown"
+	if state.HasOutboxStatus() {
+		sync = state.GetOutboxStatus()
+	}
+	if state.GetOutboxRetries() > 0 {
+		sync = fmt.Sprintf("%s (retry %d)", sync, state.GetOutboxRetries())
+	}
+
+	progress := fmt.Sprintf("status %s, sync %s", formatClusterStatus(cluster.GetStatus()), sync)
+	if state.HasShootMessage() {
+		progress += ": " + state.GetShootMessage()
+	}
+	return progress
+}
@@ -0,0 +1,142 @@
+package cli
+
+import (
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	organizationv1 "github.com/fundament-oss/fundament/organization-api/pkg/proto/gen/v1"
+)
+
+func clusterWithState(outbox, shoot string, updated time.Time) *organizationv1.ClusterDetails {
+	state := organizationv1.SyncState_builder{
+		StatusUpdatedAt: timestamppb.New(updated),
+	}.Build()
+	if outbox != "" {
+		state.SetOutboxStatus(outbox)
+	}
+	if shoot != "" {
+		state.SetShootStatus(shoot)
+	}
+	return organizationv1.ClusterDetails_builder{SyncState: state}.Build()
+}
+
+func clusterEvent(id, eventType string, created time.Time) *organizationv1.ClusterEvent {
+	return organizationv1.ClusterEvent_builder{
+		Id:        id,
+		EventType: eventType,
+		CreatedAt: timestamppb.New(created),
+	}.Build()
+}
+
+type clusterPoll struct {
+	cluster *organizationv1.ClusterDetails
+	events  []*organizationv1.ClusterEvent
+	done    bool
+}
+
+func observeAll(t *testing.T, w *clusterWaiter, polls []clusterPoll) {
+	t.Helper()
+	for i, poll := range polls {
+		done, err := w.observe(poll.cluster, poll.events)
+		if err != nil {
+			t.Fatalf("poll %d: unexpected error: %v", i, err)
+		}
+		if done != poll.done {
+			t.Fatalf("poll %d: expected done=%v, got %v", i, poll.done, done)
+		}
+	}
+}
+
+// A plain sync of a ready cluster: ClusterListNeedingStatusCheck does not
+// pick the cluster up again, so shoot_status and its timestamp never change.
+// The wait ends on the new sync_succeeded event.
+func TestClusterWaiter_UpdateOfReadyCluster(t *testing.T) {
+	t.Parallel()
+	checked := time.Now().Add(-time.Hour)
+	previousSync := clusterEvent("sync-1", clusterEventSyncSucceeded, checked.Add(-time.Minute))
+	w := &clusterWaiter{baselineSyncID: previousSync.GetId()}
+
+	before := []*organizationv1.ClusterEvent{previousSync}
+	after := []*organizationv1.ClusterEvent{
+		clusterEvent("sync-2", clusterEventSyncSucceeded, time.Now()),
+		previousSync,
+	}
+
+	observeAll(t, w, []clusterPoll{
+		{clusterWithState("pending", shootStatusReady, checked), before, false},
+		// The previous sync's completed row is still the latest until the
+		// new row is claimed.
+		{clusterWithState(outboxStatusCompleted, shootStatusReady, checked), before, false},
+		{clusterWithState(outboxStatusCompleted, shootStatusReady, checked), after, true},
+	})
+}
+
+func TestClusterWaiter_Create(t *testing.T) {
+	t.Parallel()
+	start := time.Now()
+	w := &clusterWaiter{}
+	synced := []*organizationv1.ClusterEvent{clusterEvent("sync-1", clusterEventSyncSucceeded, start)}
+
+	observeAll(t, w, []clusterPoll{
+		{clusterWithState("pending", "", start), nil, false},
+		{clusterWithState(outboxStatusCompleted, "", start), synced, false},
+		{clusterWithState(outboxStatusCompleted, "progressing", start.Add(30*time.Second)), synced, false},
+		{clusterWithState(outboxStatusCompleted, shootStatusReady, time.Now().Add(time.Minute)), synced, true},
+	})
+}
+
+func TestClusterWaiter_Failures(t *testing.T) {
+	t.Parallel()
+	start := time.Now()
+
+	w := &clusterWaiter{}
+	if _, err := w.observe(clusterWithState(outboxStatusFailed, "", start), nil); err == nil {
+		t.Fatal("expected error for failed sync")
+	}
+
+	// An error reported before the sync may be fixed by it.
+	previousError := clusterWithState(outboxStatusCompleted, shootStatusError, start)
+	events := []*organizationv1.ClusterEvent{clusterEvent("sync-1", clusterEventSyncSucceeded, start.Add(time.Second))}
+	w = &clusterWaiter{}
+	if done, err := w.observe(previousError, events); err != nil || done {
+		t.Fatalf("expected to keep waiting on a stale error, got done=%v err=%v", done, err)
+	}
+	freshError := clusterWithState(outboxStatusCompleted, shootStatusError, start.Add(time.Minute))
+	if _, err := w.observe(freshError, events); err == nil {
+		t.Fatal("expected error for shoot in error state")
+	}
+}
+
+func TestClusterWaiter_Hibernating(t *testing.T) {
+	t.Parallel()
+	start := time.Now()
+	previousSync := clusterEvent("sync-1", clusterEventSyncSucceeded, start.Add(-time.Hour))
+	w := &clusterWaiter{hibernating: true, baselineSyncID: previousSync.GetId()}
+	after := []*organizationv1.ClusterEvent{
+		clusterEvent("sync-2", clusterEventSyncSucceeded, start),
+		previousSync,
+	}
+
+	observeAll(t, w, []clusterPoll{
+		{clusterWithState(outboxStatusCompleted, shootStatusReady, start), []*organizationv1.ClusterEvent{previousSync}, false},
+		{clusterWithState(outboxStatusCompleted, shootStatusReady, start.Add(30*time.Second)), after, false},
+		{clusterWithState(outboxStatusCompleted, shootStatusHibernated, start.Add(time.Minute)), after, true},
+	})
+}
+
+func TestClusterWaiter_Deleting(t *testing.T) {
+	t.Parallel()
+	start := time.Now()
+	w := &clusterWaiter{deleting: true}
+
+	done, err := w.observe(clusterWithState(outboxStatusCompleted, "deleting", start), nil)
+	if err != nil || done {
+		t.Fatalf("expected to keep waiting, got done=%v err=%v", done, err)
+	}
+	done, err = w.observe(clusterWithState(outboxStatusCompleted, shootStatusDeleted, start), nil)
+	if err != nil || !done {
+		t.Fatalf("expected done, got done=%v err=%v", done, err)
+	}
+}
@@ -0,0 +1,227 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	organizationv1 "github.com/fundament-oss/fundament/organization-api/pkg/proto/gen/v1"
+)
+
+// NodePoolCmd contains node pool subcommands.
+type NodePoolCmd struct {
+	List   NodePoolListCmd   `cmd:"" help:"List the node pools of a cluster."`
+	Create NodePoolCreateCmd `cmd:"" help:"Create a new node pool."`
+	Update NodePoolUpdateCmd `cmd:"" help:"Update the autoscaling and rolling update settings of a node pool."`
+	Delete NodePoolDeleteCmd `cmd:"" help:"Delete a node pool."`
+}
+
+// NodePoolListCmd handles the node pool list command.
+type NodePoolListCmd struct {
+	Cluster string `arg:"" help:"Cluster ID to list node pools for."`
+}
+
+// Run executes the node pool list command.
+func (c *NodePoolListCmd) Run(ctx *Context) error {
+	apiClient, err := NewClientFromConfigWithOrg(ctx)
+	if err != nil {
+		return err
+	}
+
+	resp, err := apiClient.Clusters().ListNodePools(context.Background(), organizationv1.ListNodePoolsRequest_builder{
+		ClusterId: c.Cluster,
+	}.Build())
+	if err != nil {
+		return fmt.Errorf("failed to list node pools: %w", err)
+	}
+
+	nodePools := resp.GetNodePools()
+
+	if ctx.Output == OutputJSON {
+		return PrintJSON(nodePools)
+	}
+
+	if len(nodePools) == 0 {
+		fmt.Println("No node pools found")
+		return nil
+	}
+
+	w := NewTableWriter()
+	fmt.Fprintln(w, "ID\tNAME\tMACHINE TYPE\tNODES\tMIN\tMAX\tSTATUS")
+	for _, nodePool := range nodePools {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%d\t%d\t%s\n",
+			nodePool.GetId(),
+			nodePool.GetName(),
+			nodePool.GetMachineType(),
+			nodePool.GetCurrentNodes(),
+			nodePool.GetMinNodes(),
+			nodePool.GetMaxNodes(),
+			formatNodePoolStatus(nodePool.GetStatus()),
+		)
+	}
+	return w.Flush()
+}
+
+// NodePoolCreateCmd handles the node pool create command.
+type NodePoolCreateCmd struct {
+	Cluster        string            `arg:"" help:"Cluster ID to create the node pool in."`
+	Name           string            `arg:"" help:"Name of the node pool to create."`
+	MachineType    string            `required:"" help:"Machine type of the nodes."`
+	Min            int32             `required:"" help:"Minimum number of nodes."`
+	Max            int32             `required:"" help:"Maximum number of nodes."`
+	Label          map[string]string `help:"Kubernetes label of the nodes as key=value. Repeatable."`
+	Taint          []string          `help:"Kubernetes taint of the nodes as key[=value]:effect, e.g. dedicated=batch:NoSchedule. Repeatable."`
+	Zone           []string          `help:"Availability zone to spread the nodes over. Repeatable."`
+	MaxSurge       *int32            `help:"Number of nodes added above the maximum while nodes are rolled (default 1)."`
+	MaxUnavailable *int32            `help:"Number of nodes taken out of service at once while nodes are rolled (default 0)."`
+}
+
+// Run executes the node pool create command.
+func (c *NodePoolCreateCmd) Run(ctx *Context) error {
+	apiClient, err := NewClientFromConfigWithOrg(ctx)
+	if err != nil {
+		return err
+	}
+
+	taints, err := parseTaints(c.Taint)
+	if err != nil {
+		return err
+	}
+
+	resp, err := apiClient.Clusters().CreateNodePool(context.Background(), organizationv1.CreateNodePoolRequest_builder{
+		ClusterId:      c.Cluster,
+		Name:           c.Name,
+		MachineType:    c.MachineType,
+		AutoscaleMin:   c.Min,
+		AutoscaleMax:   c.Max,
+		Labels:         c.Label,
+		Taints:         taints,
+		Zones:          c.Zone,
+		MaxSurge:       c.MaxSurge,
+		MaxUnavailable: c.MaxUnavailable,
+	}.Build())
+	if err != nil {
+		return fmt.Errorf("failed to create node pool: %w", err)
+	}
+
+	nodePoolID := resp.GetNodePoolId()
+
+	if ctx.Output == OutputJSON {
+		return PrintJSON(map[string]string{
+			"node_pool_id": nodePoolID,
+			"name":         c.Name,
+		})
+	}
+
+	fmt.Printf("Created node pool %s (ID: %s)\n", c.Name, nodePoolID)
+	return nil
+}
+
+// NodePoolUpdateCmd handles the node pool update command.
+type NodePoolUpdateCmd struct {
+	NodePoolID     string `arg:"" help:"Node pool ID to update."`
+	Min            int32  `required:"" help:"Minimum number of nodes."`
+	Max            int32  `required:"" help:"Maximum number of nodes."`
+	MaxSurge       *int32 `help:"Number of nodes added above the maximum while nodes are rolled."`
+	MaxUnavailable *int32 `help:"Number of nodes taken out of service at once while nodes are rolled."`
+}
+
+// Run executes the node pool update command.
+func (c *NodePoolUpdateCmd) Run(ctx *Context) error {
+	apiClient, err := NewClientFromConfigWithOrg(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = apiClient.Clusters().UpdateNodePool(context.Background(), organizationv1.UpdateNodePoolRequest_builder{
+		NodePoolId:     c.NodePoolID,
+		AutoscaleMin:   c.Min,
+		AutoscaleMax:   c.Max,
+		MaxSurge:       c.MaxSurge,
+		MaxUnavailable: c.MaxUnavailable,
+	}.Build())
+	if err != nil {
+		return fmt.Errorf("failed to update node pool: %w", err)
+	}
+
+	if ctx.Output == OutputJSON {
+		return PrintJSON(map[string]any{
+			"node_pool_id": c.NodePoolID,
+			"min":          c.Min,
+			"max":          c.Max,
+		})
+	}
+
+	fmt.Printf("Updated node pool %s to %d-%d nodes\n", c.NodePoolID, c.Min, c.Max)
+	return nil
+}
+
+// NodePoolDeleteCmd handles the node pool delete command.
+type NodePoolDeleteCmd struct {
+	NodePoolID string `arg:"" help:"Node pool ID to delete."`
+}
+
+// Run executes the node pool delete command.
+func (c *NodePoolDeleteCmd) Run(ctx *Context) error {
+	apiClient, err := NewClientFromConfigWithOrg(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = apiClient.Clusters().DeleteNodePool(context.Background(), organizationv1.DeleteNodePoolRequest_builder{
+		NodePoolId: c.NodePoolID,
+	}.Build())
+	if err != nil {
+		return fmt.Errorf("failed to delete node pool: %w", err)
+	}
+
+	fmt.Printf("Node pool %s has been deleted\n", c.NodePoolID)
+	return nil
+}
+
+// parseTaints parses taints given as key[=value]:effect.
+func parseTaints(specs []string) ([]*organizationv1.NodeTaint, error) {
+	taints := make([]*organizationv1.NodeTaint, 0, len(specs))
+	for _, spec := range specs {
+		keyValue, effectName, ok := strings.Cut(spec, ":")
+		if !ok {
+			return nil, fmt.Errorf("invalid taint %q: expected key[=value]:effect", spec)
+		}
+
+		var effect organizationv1.TaintEffect
+		switch effectName {
+		case "NoSchedule":
+			effect = organizationv1.TaintEffect_TAINT_EFFECT_NO_SCHEDULE
+		case "PreferNoSchedule":
+			effect = organizationv1.TaintEffect_TAINT_EFFECT_PREFER_NO_SCHEDULE
+		case "NoExecute":
+			effect = organizationv1.TaintEffect_TAINT_EFFECT_NO_EXECUTE
+		default:
+			return nil, fmt.Errorf("invalid taint %q: effect must be NoSchedule, PreferNoSchedule or NoExecute", spec)
+		}
+
+		key, value, _ := strings.Cut(keyValue, "=")
+		taints = append(taints, organizationv1.NodeTaint_builder{
+			Key:    key,
+			Value:  value,
+			Effect: effect,
+		}.Build())
+	}
+	return taints, nil
+}
+
+// formatNodePoolStatus formats a node pool status for display.
+func formatNodePoolStatus(status organizationv1.NodePoolStatus) string {
+	switch status {
+	case organizationv1.NodePoolStatus_NODE_POOL_STATUS_UNSPECIFIED:
+		return "unspecified"
+	case organizationv1.NodePoolStatus_NODE_POOL_STATUS_HEALTHY:
+		return "healthy"
+	case organizationv1.NodePoolStatus_NODE_POOL_STATUS_DEGRADED:
+		return "degraded"
+	case organizationv1.NodePoolStatus_NODE_POOL_STATUS_UNHEALTHY:
+		return "unhealthy"
+	default:
+		return "unknown"
+	}
+}
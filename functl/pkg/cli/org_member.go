@@ -23,7 +23,9 @@ type OrgMemberCmd struct {
 }
 
 // OrgMemberListCmd handles listing organization members.
-type OrgMemberListCmd struct{}
+type OrgMemberListCmd struct {
+	Filter string `help:"Only list members whose name or email contains this text."`
+}
 
 // Run executes the org member list command.
 func (c *OrgMemberListCmd) Run(ctx *Context) error {
@@ -32,13 +34,18 @@ func (c *OrgMemberListCmd) Run(ctx *Context) error {
 		return err
 	}
 
-	resp, err := apiClient.Members().ListMembers(context.Background(), organizationv1.ListMembersRequest_builder{}.Build())
+	members, err := listAllPages(func(pageToken string) ([]*organizationv1.Member, string, error) {
+		resp, err := apiClient.Members().ListMembers(context.Background(), organizationv1.ListMembersRequest_builder{
+			PageSize:   listPageSize,
+			PageToken:  pageToken,
+			NameFilter: c.Filter,
+		}.Build())
+		return resp.GetMembers(), resp.GetNextPageToken(), err
+	})
 	if err != nil {
 		return fmt.Errorf("failed to list members: %w", err)
 	}
 
-	members := resp.GetMembers()
-
 	if ctx.Output == OutputJSON {
 		return PrintJSON(members)
 	}
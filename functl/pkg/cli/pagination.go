@@ -0,0 +1,27 @@
+package cli
+
+import "fmt"
+
+// listPageSize is the page size functl requests from paginated list RPCs.
+const listPageSize = 100
+
+// listAllPages follows the page tokens of a paginated list RPC and returns
+// the items of all pages.
+func listAllPages[T any](fetch func(pageToken string) ([]T, string, error)) ([]T, error) {
+	var all []T
+	pageToken := ""
+	for {
+		items, next, err := fetch(pageToken)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, items...)
+		if next == "" {
+			return all, nil
+		}
+		if next == pageToken {
+			return nil, fmt.Errorf("server returned the same page token twice")
+		}
+		pageToken = next
+	}
+}
@@ -0,0 +1,46 @@
+package cli
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestListAllPages(t *testing.T) {
+	t.Parallel()
+	pages := map[string]struct {
+		items []string
+		next  string
+	}{
+		"":   {items: []string{"a", "b"}, next: "t1"},
+		"t1": {items: []string{"c"}, next: "t2"},
+		"t2": {items: nil},
+	}
+
+	got, err := listAllPages(func(pageToken string) ([]string, string, error) {
+		page := pages[pageToken]
+		return page.items, page.next, nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := []string{"a", "b", "c"}; !slices.Equal(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+}
+
+func TestListAllPages_Errors(t *testing.T) {
+	t.Parallel()
+	wantErr := errors.New("unavailable")
+	if _, err := listAllPages(func(string) ([]string, string, error) {
+		return nil, "", wantErr
+	}); !errors.Is(err, wantErr) {
+		t.Fatalf("expected %v, got %v", wantErr, err)
+	}
+
+	if _, err := listAllPages(func(string) ([]string, string, error) {
+		return []string{"a"}, "loop", nil
+	}); err == nil {
+		t.Fatal("expected error for a repeated page token")
+	}
+}
@@ -0,0 +1,438 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/fundament-oss/fundament/functl/pkg/client"
+	organizationv1 "github.com/fundament-oss/fundament/organization-api/pkg/proto/gen/v1"
+)
+
+const pluginWaitPollInterval = 5 * time.Second
+
+// PluginCmd contains plugin subcommands.
+type PluginCmd struct {
+	List        PluginListCmd        `cmd:"" help:"List the plugins in the catalog."`
+	Describe    PluginDescribeCmd    `cmd:"" help:"Show plugin details."`
+	Definitions PluginDefinitionsCmd `cmd:"" help:"List the published definitions of a plugin."`
+	Install     PluginInstallCmd     `cmd:"" help:"Install a plugin on a cluster."`
+	Uninstall   PluginUninstallCmd   `cmd:"" help:"Uninstall a plugin from a cluster."`
+	Status      PluginStatusCmd      `cmd:"" help:"Show the installation status of a plugin on a cluster."`
+}
+
+// PluginListCmd handles the plugin list command.
+type PluginListCmd struct {
+	Filter string `help:"Only list plugins whose name or display name contains this text."`
+}
+
+// Run executes the plugin list command.
+func (c *PluginListCmd) Run(ctx *Context) error {
+	apiClient, err := NewClientFromConfigWithOrg(ctx)
+	if err != nil {
+		return err
+	}
+
+	plugins, err := listAllPages(func(pageToken string) ([]*organizationv1.PluginSummary, string, error) {
+		resp, err := apiClient.Plugins().ListPlugins(context.Background(), organizationv1.ListPluginsRequest_builder{
+			PageSize:   listPageSize,
+			PageToken:  pageToken,
+			NameFilter: c.Filter,
+		}.Build())
+		return resp.GetPlugins(), resp.GetNextPageToken(), err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list plugins: %w", err)
+	}
+
+	if ctx.Output == OutputJSON {
+		return PrintJSON(plugins)
+	}
+
+	if len(plugins) == 0 {
+		fmt.Println("No plugins found")
+		return nil
+	}
+
+	w := NewTableWriter()
+	fmt.Fprintln(w, "ID\tNAME\tDISPLAY NAME\tVERSION\tDESCRIPTION")
+	for _, plugin := range plugins {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n",
+			plugin.GetId(),
+			plugin.GetName(),
+			plugin.GetDisplayName(),
+			plugin.GetPluginVersion(),
+			plugin.GetDescriptionShort(),
+		)
+	}
+	return w.Flush()
+}
+
+// PluginDescribeCmd handles the plugin describe command.
+type PluginDescribeCmd struct {
+	Plugin string `arg:"" help:"Plugin ID or name."`
+}
+
+// Run executes the plugin describe command.
+func (c *PluginDescribeCmd) Run(ctx *Context) error {
+	apiClient, err := NewClientFromConfigWithOrg(ctx)
+	if err != nil {
+		return err
+	}
+
+	pluginID, err := resolvePluginID(apiClient, c.Plugin)
+	if err != nil {
+		return err
+	}
+
+	resp, err := apiClient.Plugins().GetPluginDetail(context.Background(), organizationv1.GetPluginDetailRequest_builder{
+		PluginId: pluginID,
+	}.Build())
+	if err != nil {
+		return fmt.Errorf("failed to get plugin: %w", err)
+	}
+
+	plugin := resp.GetPlugin()
+
+	if ctx.Output == OutputJSON {
+		return PrintJSON(plugin)
+	}
+
+	w := NewTableWriter()
+	PrintKeyValue(w, "ID", plugin.GetId())
+	PrintKeyValue(w, "Name", plugin.GetName())
+	PrintKeyValue(w, "Display Name", plugin.GetDisplayName())
+	PrintKeyValue(w, "Description", plugin.GetDescription())
+	if plugin.GetAuthor().GetName() != "" {
+		PrintKeyValue(w, "Author", plugin.GetAuthor().GetName())
+	}
+	if plugin.GetRepositoryUrl() != "" {
+		PrintKeyValue(w, "Repository", plugin.GetRepositoryUrl())
+	}
+	PrintKeyValue(w, "Latest Version", plugin.GetPluginVersion())
+	PrintKeyValue(w, "Definition Hash", plugin.GetDefinitionHash())
+	for _, link := range plugin.GetDocumentationLinks() {
+		PrintKeyValue(w, "Documentation", fmt.Sprintf("%s (%s)", link.GetTitle(), link.GetUrl()))
+	}
+	return w.Flush()
+}
+
+// PluginDefinitionsCmd handles the plugin definitions command.
+type PluginDefinitionsCmd struct {
+	Plugin string `arg:"" help:"Plugin ID or name."`
+}
+
+// Run executes the plugin definitions command.
+func (c *PluginDefinitionsCmd) Run(ctx *Context) error {
+	apiClient, err := NewClientFromConfigWithOrg(ctx)
+	if err != nil {
+		return err
+	}
+
+	pluginID, err := resolvePluginID(apiClient, c.Plugin)
+	if err != nil {
+		return err
+	}
+
+	definitions, err := listPluginDefinitions(apiClient, pluginID)
+	if err != nil {
+		return err
+	}
+
+	if ctx.Output == OutputJSON {
+		return PrintJSON(definitions)
+	}
+
+	if len(definitions) == 0 {
+		fmt.Println("No published definitions found")
+		return nil
+	}
+
+	w := NewTableWriter()
+	fmt.Fprintln(w, "VERSION\tHASH")
+	for _, definition := range definitions {
+		fmt.Fprintf(w, "%s\t%s\n", definition.GetVersion(), definition.GetHash())
+	}
+	return w.Flush()
+}
+
+// PluginInstallCmd handles the plugin install command.
+type PluginInstallCmd struct {
+	ClusterID   string        `arg:"" help:"Cluster ID to install the plugin on."`
+	Plugin      string        `arg:"" help:"Plugin name (see 'functl plugin list')."`
+	Version     string        `help:"Published definition version to pin (defaults to the latest)."`
+	Wait        bool          `help:"Wait until the plugin is running."`
+	WaitTimeout time.Duration `default:"10m" help:"How long --wait waits before giving up."`
+}
+
+// Run executes the plugin install command.
+func (c *PluginInstallCmd) Run(ctx *Context) error {
+	apiClient, err := NewClientFromConfigWithOrg(ctx)
+	if err != nil {
+		return err
+	}
+
+	ref, err := resolveDefinitionRef(apiClient, c.Plugin, c.Version)
+	if err != nil {
+		return err
+	}
+
+	kube, err := clusterKubeClient(apiClient, c.ClusterID)
+	if err != nil {
+		return err
+	}
+
+	if err := createPluginInstallation(context.Background(), kube, ref); err != nil {
+		return err
+	}
+
+	installation, err := getPluginInstallation(context.Background(), kube, ref.PluginName)
+	if err != nil {
+		return err
+	}
+	if c.Wait {
+		installation, err = waitForPluginRunning(kube, ref.PluginName, c.WaitTimeout)
+		if err != nil {
+			return err
+		}
+	}
+
+	if ctx.Output == OutputJSON {
+		return PrintJSON(installation)
+	}
+
+	fmt.Printf("Installed plugin %s %s on cluster %s (phase: %s)\n",
+		ref.PluginName, ref.PluginVersion, c.ClusterID, pluginPhase(installation))
+	return nil
+}
+
+// PluginUninstallCmd handles the plugin uninstall command.
+type PluginUninstallCmd struct {
+	ClusterID string `arg:"" help:"Cluster ID to uninstall the plugin from."`
+	Plugin    string `arg:"" help:"Plugin name."`
+}
+
+// Run executes the plugin uninstall command.
+func (c *PluginUninstallCmd) Run(ctx *Context) error {
+	apiClient, err := NewClientFromConfigWithOrg(ctx)
+	if err != nil {
+		return err
+	}
+
+	kube, err := clusterKubeClient(apiClient, c.ClusterID)
+	if err != nil {
+		return err
+	}
+
+	resp, err := kube.Do(context.Background(), http.MethodDelete, pluginInstallationPath(c.Plugin), nil)
+	if err != nil {
+		return fmt.Errorf("failed to delete plugin installation: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusAccepted, http.StatusNoContent:
+	case http.StatusNotFound:
+		return fmt.Errorf("plugin %s is not installed on cluster %s", c.Plugin, c.ClusterID)
+	default:
+		return kubeError("delete plugin installation", resp)
+	}
+
+	fmt.Printf("Plugin %s is being uninstalled from cluster %s\n", c.Plugin, c.ClusterID)
+	return nil
+}
+
+// PluginStatusCmd handles the plugin status command.
+type PluginStatusCmd struct {
+	ClusterID string `arg:"" help:"Cluster ID the plugin is installed on."`
+	Plugin    string `arg:"" help:"Plugin name."`
+}
+
+// Run executes the plugin status command.
+func (c *PluginStatusCmd) Run(ctx *Context) error {
+	apiClient, err := NewClientFromConfigWithOrg(ctx)
+	if err != nil {
+		return err
+	}
+
+	kube, err := clusterKubeClient(apiClient, c.ClusterID)
+	if err != nil {
+		return err
+	}
+
+	installation, err := getPluginInstallation(context.Background(), kube, c.Plugin)
+	if errors.Is(err, errPluginInstallationNotFound) {
+		return fmt.Errorf("plugin %s is not installed on cluster %s", c.Plugin, c.ClusterID)
+	}
+	if err != nil {
+		return err
+	}
+
+	if ctx.Output == OutputJSON {
+		return PrintJSON(installation)
+	}
+
+	ref := installation.Spec.DefinitionRef
+	w := NewTableWriter()
+	PrintKeyValue(w, "Name", installation.Metadata.Name)
+	PrintKeyValue(w, "Plugin", ref.PluginName)
+	PrintKeyValue(w, "Version", ref.PluginVersion)
+	PrintKeyValue(w, "Definition Hash", ref.DefinitionHash)
+	PrintKeyValue(w, "Phase", pluginPhase(installation))
+	if installation.Status != nil {
+		PrintKeyValue(w, "Ready", fmt.Sprintf("%t", installation.Status.Ready))
+		if installation.Status.Message != "" {
+			PrintKeyValue(w, "Message", installation.Status.Message)
+		}
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	if installation.Status == nil || len(installation.Status.Conditions) == 0 {
+		return nil
+	}
+
+	fmt.Println()
+	w = NewTableWriter()
+	fmt.Fprintln(w, "CONDITION\tSTATUS\tREASON\tMESSAGE")
+	for _, condition := range installation.Status.Conditions {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n",
+			condition.Type,
+			condition.Status,
+			condition.Reason,
+			condition.Message,
+		)
+	}
+	return w.Flush()
+}
+
+// resolvePluginID returns the ID of the catalog plugin with the given ID or
+// name.
+func resolvePluginID(apiClient *client.Client, plugin string) (string, error) {
+	if _, err := uuid.Parse(plugin); err == nil {
+		return plugin, nil
+	}
+	summary, err := findPlugin(apiClient, plugin)
+	if err != nil {
+		return "", err
+	}
+	return summary.GetId(), nil
+}
+
+func findPlugin(apiClient *client.Client, name string) (*organizationv1.PluginSummary, error) {
+	plugins, err := listAllPages(func(pageToken string) ([]*organizationv1.PluginSummary, string, error) {
+		resp, err := apiClient.Plugins().ListPlugins(context.Background(), organizationv1.ListPluginsRequest_builder{
+			PageSize:   listPageSize,
+			PageToken:  pageToken,
+			NameFilter: name,
+		}.Build())
+		return resp.GetPlugins(), resp.GetNextPageToken(), err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list plugins: %w", err)
+	}
+	for _, plugin := range plugins {
+		if plugin.GetName() == name {
+			return plugin, nil
+		}
+	}
+	return nil, fmt.Errorf("plugin %q not found in the catalog", name)
+}
+
+func listPluginDefinitions(apiClient *client.Client, pluginID string) ([]*organizationv1.PluginDefinitionVersion, error) {
+	resp, err := apiClient.Plugins().ListPluginDefinitions(context.Background(), organizationv1.ListPluginDefinitionsRequest_builder{
+		PluginId: pluginID,
+	}.Build())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list plugin definitions: %w", err)
+	}
+	return resp.GetDefinitions(), nil
+}
+
+// resolveDefinitionRef pins the published definition to install: the given
+// version, or the latest one when version is empty. Version and hash always
+// come from the catalog, so the pin matches what organization-api serves.
+func resolveDefinitionRef(apiClient *client.Client, name, version string) (pluginDefinitionRef, error) {
+	plugin, err := findPlugin(apiClient, name)
+	if err != nil {
+		return pluginDefinitionRef{}, err
+	}
+
+	ref := pluginDefinitionRef{PluginName: plugin.GetName()}
+
+	if version == "" {
+		if plugin.GetPluginVersion() == "" || plugin.GetDefinitionHash() == "" {
+			return pluginDefinitionRef{}, fmt.Errorf("plugin %q has no published definition to install", name)
+		}
+		ref.PluginVersion = plugin.GetPluginVersion()
+		ref.DefinitionHash = plugin.GetDefinitionHash()
+		return ref, nil
+	}
+
+	definitions, err := listPluginDefinitions(apiClient, plugin.GetId())
+	if err != nil {
+		return pluginDefinitionRef{}, err
+	}
+	for _, definition := range definitions {
+		if definition.GetVersion() == version {
+			ref.PluginVersion = definition.GetVersion()
+			ref.DefinitionHash = definition.GetHash()
+			return ref, nil
+		}
+	}
+	return pluginDefinitionRef{}, fmt.Errorf("plugin %q has no published definition for version %q", name, version)
+}
+
+// waitForPluginRunning polls the installation until plugin-controller reports
+// it running, or a phase it will not recover from on its own.
+func waitForPluginRunning(kube *client.KubeClient, name string, timeout time.Duration) (*pluginInstallation, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	ticker := time.NewTicker(pluginWaitPollInterval)
+	defer ticker.Stop()
+
+	lastPhase := ""
+	for {
+		installation, err := getPluginInstallation(ctx, kube, name)
+		if ctx.Err() != nil {
+			return nil, fmt.Errorf("timed out after %s waiting for plugin %s (last phase: %s)", timeout, name, lastPhase)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		phase := pluginPhase(installation)
+		switch phase {
+		case pluginPhaseRunning:
+			return installation, nil
+		case pluginPhaseFailed, pluginPhaseDegraded, pluginPhaseTerminating:
+			return nil, fmt.Errorf("plugin %s entered phase %s: %s", name, phase, installation.Status.Message)
+		}
+		if phase != lastPhase {
+			fmt.Fprintf(os.Stderr, "Waiting for plugin %s: phase %s\n", name, phase)
+			lastPhase = phase
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("timed out after %s waiting for plugin %s (last phase: %s)", timeout, name, lastPhase)
+		case <-ticker.C:
+		}
+	}
+}
+
+// pluginPhase returns the phase of an installation, or "Pending" before the
+// controller has reported one.
+func pluginPhase(installation *pluginInstallation) string {
+	if installation.Status == nil || installation.Status.Phase == "" {
+		return "Pending"
+	}
+	return installation.Status.Phase
+}
@@ -0,0 +1,176 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/fundament-oss/fundament/functl/pkg/client"
+	organizationv1 "github.com/fundament-oss/fundament/organization-api/pkg/proto/gen/v1"
+)
+
+const (
+	pluginInstallationAPIVersion = "plugins.fundament.io/v1"
+	pluginInstallationsPath      = "/apis/" + pluginInstallationAPIVersion + "/plugininstallations"
+)
+
+// Plugin installation phases reported by plugin-controller.
+const (
+	pluginPhaseRunning     = "Running"
+	pluginPhaseDegraded    = "Degraded"
+	pluginPhaseFailed      = "Failed"
+	pluginPhaseTerminating = "Terminating"
+)
+
+var errPluginInstallationNotFound = errors.New("plugin installation not found")
+
+// pluginInstallation mirrors the PluginInstallation custom resource of
+// plugin-controller, limited to the fields functl reads and writes.
+type pluginInstallation struct {
+	APIVersion string                     `json:"apiVersion"`
+	Kind       string                     `json:"kind"`
+	Metadata   pluginInstallationMetadata `json:"metadata"`
+	Spec       pluginInstallationSpec     `json:"spec"`
+	Status     *pluginInstallationStatus  `json:"status,omitempty"`
+}
+
+type pluginInstallationMetadata struct {
+	Name              string `json:"name"`
+	CreationTimestamp string `json:"creationTimestamp,omitempty"`
+}
+
+type pluginInstallationSpec struct {
+	DefinitionRef pluginDefinitionRef `json:"definitionRef"`
+}
+
+// pluginDefinitionRef pins the published definition the installer consented
+// to; plugin-controller derives the plugin's RBAC from the definition with
+// this hash.
+type pluginDefinitionRef struct {
+	PluginName     string `json:"pluginName"`
+	PluginVersion  string `json:"pluginVersion"`
+	DefinitionHash string `json:"definitionHash"`
+}
+
+type pluginInstallationStatus struct {
+	Phase      string                        `json:"phase,omitempty"`
+	Message    string                        `json:"message,omitempty"`
+	Ready      bool                          `json:"ready,omitempty"`
+	Conditions []pluginInstallationCondition `json:"conditions,omitempty"`
+}
+
+type pluginInstallationCondition struct {
+	Type               string `json:"type"`
+	Status             string `json:"status"`
+	Reason             string `json:"reason,omitempty"`
+	Message            string `json:"message,omitempty"`
+	LastTransitionTime string `json:"lastTransitionTime,omitempty"`
+}
+
+// clusterKubeClient returns a client for the Kubernetes API of the cluster,
+// using the proxy URL from the kubeconfig organization-api generates for it.
+func clusterKubeClient(apiClient *client.Client, clusterID string) (*client.KubeClient, error) {
+	resp, err := apiClient.Clusters().GetKubeconfig(context.Background(), organizationv1.GetKubeconfigRequest_builder{
+		ClusterId: clusterID,
+	}.Build())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get kubeconfig: %w", err)
+	}
+
+	var kubeconfig struct {
+		Clusters []struct {
+			Cluster struct {
+				Server string `yaml:"server"`
+			} `yaml:"cluster"`
+		} `yaml:"clusters"`
+	}
+	if err := yaml.Unmarshal([]byte(resp.GetKubeconfigContent()), &kubeconfig); err != nil {
+		return nil, fmt.Errorf("failed to parse kubeconfig: %w", err)
+	}
+	if len(kubeconfig.Clusters) == 0 || kubeconfig.Clusters[0].Cluster.Server == "" {
+		return nil, fmt.Errorf("kubeconfig for cluster %s has no server", clusterID)
+	}
+
+	return apiClient.Kube(kubeconfig.Clusters[0].Cluster.Server), nil
+}
+
+func pluginInstallationPath(name string) string {
+	return pluginInstallationsPath + "/" + url.PathEscape(name)
+}
+
+// getPluginInstallation fetches the installation, returning
+// errPluginInstallationNotFound if the plugin is not installed.
+func getPluginInstallation(ctx context.Context, kube *client.KubeClient, name string) (*pluginInstallation, error) {
+	resp, err := kube.Do(ctx, http.MethodGet, pluginInstallationPath(name), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get plugin installation: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, errPluginInstallationNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, kubeError("get plugin installation", resp)
+	}
+
+	var installation pluginInstallation
+	if err := json.NewDecoder(resp.Body).Decode(&installation); err != nil {
+		return nil, fmt.Errorf("failed to decode plugin installation: %w", err)
+	}
+	return &installation, nil
+}
+
+// createPluginInstallation creates the installation. An existing installation
+// with the same pin is accepted, so a retried install succeeds.
+func createPluginInstallation(ctx context.Context, kube *client.KubeClient, ref pluginDefinitionRef) error {
+	installation := pluginInstallation{
+		APIVersion: pluginInstallationAPIVersion,
+		Kind:       "PluginInstallation",
+		Metadata:   pluginInstallationMetadata{Name: ref.PluginName},
+		Spec:       pluginInstallationSpec{DefinitionRef: ref},
+	}
+
+	resp, err := kube.Do(ctx, http.MethodPost, pluginInstallationsPath, installation)
+	if err != nil {
+		return fmt.Errorf("failed to create plugin installation: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusCreated:
+		return nil
+	case http.StatusConflict:
+		existing, err := getPluginInstallation(ctx, kube, ref.PluginName)
+		if err != nil {
+			return err
+		}
+		if existing.Spec.DefinitionRef != ref {
+			return fmt.Errorf("plugin %s is already installed with version %s (%s); uninstall it first to change the pinned definition",
+				ref.PluginName, existing.Spec.DefinitionRef.PluginVersion, existing.Spec.DefinitionRef.DefinitionHash)
+		}
+		return nil
+	default:
+		return kubeError("create plugin installation", resp)
+	}
+}
+
+// kubeError turns an unexpected response from the cluster API into an error,
+// preferring the message of a Kubernetes Status body.
+func kubeError(action string, resp *http.Response) error {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+
+	var status struct {
+		Message string `json:"message"`
+	}
+	if json.Unmarshal(body, &status) == nil && status.Message != "" {
+		return fmt.Errorf("failed to %s: %s", action, status.Message)
+	}
+	return fmt.Errorf("failed to %s: HTTP %d: %s", action, resp.StatusCode, body)
+}
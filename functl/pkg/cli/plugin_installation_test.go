@@ -0,0 +1,84 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// existingInstallation answers creates with a conflict and GETs with an
+// installation pinned to ref, as the cluster API does for an installed plugin.
+func existingInstallation(ref pluginDefinitionRef) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			w.WriteHeader(http.StatusConflict)
+			_, _ = w.Write([]byte(`{"kind":"Status","message":"already exists"}`))
+			return
+		}
+		_ = json.NewEncoder(w).Encode(pluginInstallation{
+			APIVersion: pluginInstallationAPIVersion,
+			Kind:       "PluginInstallation",
+			Metadata:   pluginInstallationMetadata{Name: ref.PluginName},
+			Spec:       pluginInstallationSpec{DefinitionRef: ref},
+		})
+	})
+}
+
+func TestCreatePluginInstallation_Conflict(t *testing.T) {
+	t.Parallel()
+	installed := pluginDefinitionRef{PluginName: "cert-manager", PluginVersion: "v1.18.0", DefinitionHash: "sha256:latest"}
+
+	tests := []struct {
+		name    string
+		ref     pluginDefinitionRef
+		wantErr string
+	}{
+		// A retried install of the same pin succeeds.
+		{name: "same pin", ref: installed},
+		{
+			// Republished under the same version: the pinned hash differs,
+			// so the installation is not silently taken over.
+			name:    "hash mismatch",
+			ref:     pluginDefinitionRef{PluginName: "cert-manager", PluginVersion: "v1.18.0", DefinitionHash: "sha256:republished"},
+			wantErr: "already installed with version v1.18.0 (sha256:latest)",
+		},
+		{
+			name:    "other version",
+			ref:     pluginDefinitionRef{PluginName: "cert-manager", PluginVersion: "v1.17.2", DefinitionHash: "sha256:older"},
+			wantErr: "already installed with version v1.18.0",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			kube := newTestKubeClient(t, existingInstallation(installed))
+
+			err := createPluginInstallation(context.Background(), kube, tt.ref)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestCreatePluginInstallation_KubeError(t *testing.T) {
+	t.Parallel()
+	kube := newTestKubeClient(t, http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"kind":"Status","message":"plugininstallations is forbidden"}`))
+	}))
+
+	err := createPluginInstallation(context.Background(), kube, pluginDefinitionRef{PluginName: "cert-manager"})
+	if err == nil || err.Error() != "failed to create plugin installation: plugininstallations is forbidden" {
+		t.Fatalf("expected the Status message, got %v", err)
+	}
+}
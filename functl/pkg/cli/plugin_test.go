@@ -0,0 +1,254 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	authnv1 "github.com/fundament-oss/fundament/authn-api/pkg/proto/gen/authn/v1"
+	"github.com/fundament-oss/fundament/authn-api/pkg/proto/gen/authn/v1/authnv1connect"
+	"github.com/fundament-oss/fundament/functl/pkg/client"
+	organizationv1 "github.com/fundament-oss/fundament/organization-api/pkg/proto/gen/v1"
+	"github.com/fundament-oss/fundament/organization-api/pkg/proto/gen/v1/organizationv1connect"
+)
+
+// fakeTokens hands out a token for any API key.
+type fakeTokens struct {
+	authnv1connect.UnimplementedTokenServiceHandler
+}
+
+func (fakeTokens) ExchangeToken(context.Context, *authnv1.ExchangeTokenRequest) (*authnv1.ExchangeTokenResponse, error) {
+	return authnv1.ExchangeTokenResponse_builder{AccessToken: "test-token", TokenType: "Bearer", ExpiresIn: 3600}.Build(), nil
+}
+
+// fakeCatalog serves a plugin catalog with one plugin and its published
+// definitions.
+type fakeCatalog struct {
+	organizationv1connect.UnimplementedPluginServiceHandler
+	plugin      *organizationv1.PluginSummary
+	definitions []*organizationv1.PluginDefinitionVersion
+}
+
+func (c fakeCatalog) ListPlugins(_ context.Context, req *organizationv1.ListPluginsRequest) (*organizationv1.ListPluginsResponse, error) {
+	var plugins []*organizationv1.PluginSummary
+	if strings.Contains(c.plugin.GetName(), req.GetNameFilter()) {
+		plugins = append(plugins, c.plugin)
+	}
+	return organizationv1.ListPluginsResponse_builder{Plugins: plugins}.Build(), nil
+}
+
+func (c fakeCatalog) ListPluginDefinitions(_ context.Context, req *organizationv1.ListPluginDefinitionsRequest) (*organizationv1.ListPluginDefinitionsResponse, error) {
+	var definitions []*organizationv1.PluginDefinitionVersion
+	if req.GetPluginId() == c.plugin.GetId() {
+		definitions = c.definitions
+	}
+	return organizationv1.ListPluginDefinitionsResponse_builder{Definitions: definitions}.Build(), nil
+}
+
+// newTestAPIClient returns a client for an API that serves the catalog.
+func newTestAPIClient(t *testing.T, catalog fakeCatalog) *client.Client {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	mux.Handle(authnv1connect.NewTokenServiceHandler(fakeTokens{}))
+	mux.Handle(organizationv1connect.NewPluginServiceHandler(catalog))
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	return client.New("test-key", server.URL, server.URL, "")
+}
+
+func testCatalog() fakeCatalog {
+	return fakeCatalog{
+		plugin: organizationv1.PluginSummary_builder{
+			Id:             "plugin-1",
+			Name:           "cert-manager",
+			PluginVersion:  "v1.18.0",
+			DefinitionHash: "sha256:latest",
+		}.Build(),
+		definitions: []*organizationv1.PluginDefinitionVersion{
+			organizationv1.PluginDefinitionVersion_builder{Version: "v1.17.2", Hash: "sha256:older"}.Build(),
+			organizationv1.PluginDefinitionVersion_builder{Version: "v1.18.0", Hash: "sha256:latest"}.Build(),
+		},
+	}
+}
+
+func TestResolveDefinitionRef(t *testing.T) {
+	t.Parallel()
+
+	unpublished := testCatalog()
+	unpublished.plugin = organizationv1.PluginSummary_builder{Id: "plugin-1", Name: "cert-manager"}.Build()
+	unpublished.definitions = nil
+
+	tests := []struct {
+		name    string
+		catalog fakeCatalog
+		plugin  string
+		version string
+		want    pluginDefinitionRef
+		wantErr string
+	}{
+		{
+			name:    "latest",
+			catalog: testCatalog(),
+			plugin:  "cert-manager",
+			want:    pluginDefinitionRef{PluginName: "cert-manager", PluginVersion: "v1.18.0", DefinitionHash: "sha256:latest"},
+		},
+		{
+			// The pin takes the hash of the requested version, not the
+			// latest one, so it matches what plugin-controller fetches.
+			name:    "explicit version",
+			catalog: testCatalog(),
+			plugin:  "cert-manager",
+			version: "v1.17.2",
+			want:    pluginDefinitionRef{PluginName: "cert-manager", PluginVersion: "v1.17.2", DefinitionHash: "sha256:older"},
+		},
+		{
+			name:    "unpublished version",
+			catalog: testCatalog(),
+			plugin:  "cert-manager",
+			version: "v1.19.0",
+			wantErr: `no published definition for version "v1.19.0"`,
+		},
+		{
+			name:    "nothing published",
+			catalog: unpublished,
+			plugin:  "cert-manager",
+			wantErr: "no published definition to install",
+		},
+		{
+			// A name filter match is not enough; the name must be exact.
+			name:    "unknown plugin",
+			catalog: testCatalog(),
+			plugin:  "cert",
+			wantErr: "not found in the catalog",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			apiClient := newTestAPIClient(t, tt.catalog)
+
+			got, err := resolveDefinitionRef(apiClient, tt.plugin, tt.version)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Fatalf("expected %+v, got %+v", tt.want, got)
+			}
+		})
+	}
+}
+
+// installationServer answers GETs of the installation with the status the
+// test last stored.
+type installationServer struct {
+	status atomic.Pointer[pluginInstallationStatus]
+}
+
+func (s *installationServer) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	_ = json.NewEncoder(w).Encode(pluginInstallation{
+		APIVersion: pluginInstallationAPIVersion,
+		Kind:       "PluginInstallation",
+		Metadata:   pluginInstallationMetadata{Name: "cert-manager"},
+		Status:     s.status.Load(),
+	})
+}
+
+// newTestKubeClient returns a client for a cluster API served by kube.
+func newTestKubeClient(t *testing.T, kube http.Handler) *client.KubeClient {
+	t.Helper()
+
+	apiClient := newTestAPIClient(t, testCatalog())
+	server := httptest.NewServer(kube)
+	t.Cleanup(server.Close)
+
+	return apiClient.Kube(server.URL)
+}
+
+func TestWaitForPluginRunning_TerminalPhases(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		phase   string
+		wantErr bool
+	}{
+		{phase: pluginPhaseRunning},
+		{phase: pluginPhaseFailed, wantErr: true},
+		{phase: pluginPhaseDegraded, wantErr: true},
+		{phase: pluginPhaseTerminating, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.phase, func(t *testing.T) {
+			t.Parallel()
+			kube := &installationServer{}
+			kube.status.Store(&pluginInstallationStatus{Phase: tt.phase, Message: "image pull failed"})
+
+			installation, err := waitForPluginRunning(newTestKubeClient(t, kube), "cert-manager", time.Minute)
+			if tt.wantErr {
+				if err == nil || !strings.Contains(err.Error(), "entered phase "+tt.phase+": image pull failed") {
+					t.Fatalf("expected error for phase %s, got %v", tt.phase, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if pluginPhase(installation) != pluginPhaseRunning {
+				t.Fatalf("expected phase %s, got %s", pluginPhaseRunning, pluginPhase(installation))
+			}
+		})
+	}
+}
+
+func TestWaitForPluginRunning_Timeout(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		status *pluginInstallationStatus
+		want   string
+	}{
+		{name: "no status yet", status: nil, want: "last phase: Pending"},
+		{name: "upgrading", status: &pluginInstallationStatus{Phase: "Upgrading"}, want: "last phase: Upgrading"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			kube := &installationServer{}
+			kube.status.Store(tt.status)
+
+			_, err := waitForPluginRunning(newTestKubeClient(t, kube), "cert-manager", 100*time.Millisecond)
+			if err == nil || !strings.Contains(err.Error(), "timed out") || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("expected timeout with %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestWaitForPluginRunning_NotInstalled(t *testing.T) {
+	t.Parallel()
+	kube := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	_, err := waitForPluginRunning(newTestKubeClient(t, kube), "cert-manager", time.Minute)
+	if !errors.Is(err, errPluginInstallationNotFound) {
+		t.Fatalf("expected %v, got %v", errPluginInstallationNotFound, err)
+	}
+}
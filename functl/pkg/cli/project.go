@@ -19,6 +19,7 @@ type ProjectCmd struct {
 // ProjectListCmd handles the project list command.
 type ProjectListCmd struct {
 	Cluster string `arg:"" help:"Cluster ID to list projects for."`
+	Filter  string `help:"Only list projects whose name or alias contains this text."`
 }
 
 // Run executes the project list command.
@@ -28,14 +29,19 @@ func (c *ProjectListCmd) Run(ctx *Context) error {
 		return err
 	}
 
-	listReq := organizationv1.ListProjectsRequest_builder{ClusterId: c.Cluster}.Build()
-	resp, err := apiClient.Projects().ListProjects(context.Background(), listReq)
+	projects, err := listAllPages(func(pageToken string) ([]*organizationv1.Project, string, error) {
+		resp, err := apiClient.Projects().ListProjects(context.Background(), organizationv1.ListProjectsRequest_builder{
+			ClusterId:  c.Cluster,
+			PageSize:   listPageSize,
+			PageToken:  pageToken,
+			NameFilter: c.Filter,
+		}.Build())
+		return resp.GetProjects(), resp.GetNextPageToken(), err
+	})
 	if err != nil {
 		return fmt.Errorf("failed to list projects: %w", err)
 	}
 
-	projects := resp.GetProjects()
-
 	if ctx.Output == OutputJSON {
 		return PrintJSON(projects)
 	}
@@ -170,6 +170,24 @@ func (c *Client) Invites() organizationv1connect.InviteServiceClient {
 	)
 }
 
+// Plugins returns the plugin catalog service client.
+func (c *Client) Plugins() organizationv1connect.PluginServiceClient {
+	return organizationv1connect.NewPluginServiceClient(
+		c.httpClient,
+		c.apiEndpoint,
+		connect.WithInterceptors(c.idempotencyInterceptor(), c.authInterceptor(), c.orgInterceptor()),
+	)
+}
+
+// AccessElevations returns the access elevation service client.
+func (c *Client) AccessElevations() organizationv1connect.AccessElevationServiceClient {
+	return organizationv1connect.NewAccessElevationServiceClient(
+		c.httpClient,
+		c.apiEndpoint,
+		connect.WithInterceptors(c.idempotencyInterceptor(), c.authInterceptor(), c.orgInterceptor()),
+	)
+}
+
 // Authn returns the authn service client (for user info).
 func (c *Client) Authn() authnv1connect.AuthnServiceClient {
 	return authnv1connect.NewAuthnServiceClient(
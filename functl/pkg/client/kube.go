@@ -0,0 +1,57 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// KubeClient sends requests to the Kubernetes API of a cluster through the
+// kube-api-proxy, authenticated with the same platform token as the API calls.
+type KubeClient struct {
+	client    *Client
+	serverURL string
+}
+
+// Kube returns a KubeClient for the cluster API served at serverURL, the
+// server of the kubeconfig organization-api generates for the cluster.
+func (c *Client) Kube(serverURL string) *KubeClient {
+	return &KubeClient{
+		client:    c,
+		serverURL: strings.TrimRight(serverURL, "/"),
+	}
+}
+
+// Do sends a request for path, relative to the cluster API. A non-nil body is
+// sent as JSON. The caller closes the response body.
+func (k *KubeClient) Do(ctx context.Context, method, path string, body any) (*http.Response, error) {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode request body: %w", err)
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, k.serverURL+path, reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build request: %w", err)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set("Accept", "application/json")
+
+	token, err := k.client.ensureToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	return k.client.httpClient.Do(req)
+}
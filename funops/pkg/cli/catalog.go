@@ -0,0 +1,160 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/fundament-oss/fundament/common/rollback"
+	db "github.com/fundament-oss/fundament/funops/pkg/db/gen"
+)
+
+// CatalogCmd groups the commands for the region catalog: the regions, Kubernetes
+// versions and machine types that clusters and node pools can be created with.
+type CatalogCmd struct {
+	Region      CatalogRegionCmd      `cmd:"" help:"Manage catalog regions."`
+	Version     CatalogVersionCmd     `cmd:"" help:"Manage catalog Kubernetes versions."`
+	MachineType CatalogMachineTypeCmd `cmd:"" name:"machine-type" help:"Manage catalog machine types."`
+}
+
+// CatalogDeprecation holds the flags shared by the deprecate commands.
+type CatalogDeprecation struct {
+	Date string `help:"Date from which the entry is no longer offered, as YYYY-MM-DD (UTC). Defaults to now."`
+	Undo bool   `help:"Withdraw the deprecation."`
+}
+
+// catalogNamePattern matches region and machine type names: lowercase
+// alphanumerics, dashes and dots, starting and ending with an alphanumeric.
+var catalogNamePattern = regexp.MustCompile(`^[a-z0-9]([-a-z0-9.]*[a-z0-9])?$`)
+
+// kubernetesVersionPattern matches versions as the create endpoints accept
+// them, with or without a patch version.
+var kubernetesVersionPattern = regexp.MustCompile(`^[0-9]+\.[0-9]+(\.[0-9]+)?$`)
+
+func validateCatalogName(kind, name string) error {
+	if len(name) > 63 || !catalogNamePattern.MatchString(name) {
+		return fmt.Errorf("invalid %s name %q: must be at most 63 lowercase alphanumerics, '-' or '.', starting and ending with an alphanumeric", kind, name)
+	}
+	return nil
+}
+
+func validateKubernetesVersion(version string) error {
+	if !kubernetesVersionPattern.MatchString(version) {
+		return fmt.Errorf("invalid Kubernetes version %q: must be MAJOR.MINOR or MAJOR.MINOR.PATCH", version)
+	}
+	return nil
+}
+
+// deprecatedArg converts the deprecation flags into the value to store: NULL
+// to withdraw a deprecation, otherwise the given day or now.
+func (d *CatalogDeprecation) deprecatedArg(now time.Time) (pgtype.Timestamptz, error) {
+	if d.Undo {
+		if d.Date != "" {
+			return pgtype.Timestamptz{}, errors.New("--date and --undo cannot be combined")
+		}
+		return pgtype.Timestamptz{}, nil
+	}
+	if d.Date == "" {
+		return pgtype.Timestamptz{Time: now, Valid: true}, nil
+	}
+	date, err := time.Parse(time.DateOnly, d.Date)
+	if err != nil {
+		return pgtype.Timestamptz{}, fmt.Errorf("invalid --date %q: expected YYYY-MM-DD", d.Date)
+	}
+	return pgtype.Timestamptz{Time: date, Valid: true}, nil
+}
+
+// lookupRegionIDs resolves region names to their IDs and fails on the first
+// name that is not in the catalog.
+func lookupRegionIDs(ctx context.Context, queries *db.Queries, names []string) ([]uuid.UUID, error) {
+	if len(names) == 0 {
+		return []uuid.UUID{}, nil
+	}
+
+	rows, err := queries.CatalogRegionIDsByName(ctx, db.CatalogRegionIDsByNameParams{Names: names})
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up regions: %w", err)
+	}
+
+	ids := make([]uuid.UUID, 0, len(names))
+	for _, name := range names {
+		i := slices.IndexFunc(rows, func(r db.CatalogRegionIDsByNameRow) bool { return r.Name == name })
+		if i < 0 {
+			return nil, fmt.Errorf("region %q not found", name)
+		}
+		ids = append(ids, rows[i].ID)
+	}
+	return ids, nil
+}
+
+// checkUnused refuses to remove a catalog entry that clusters or node pools
+// still reference. Deleted ones count too: their rows keep the reference.
+func checkUnused(entry string, live, deleted int32, noun string) error {
+	switch {
+	case live > 0:
+		return fmt.Errorf("%s is still used by %d %s; deprecate it instead", entry, live, noun)
+	case deleted > 0:
+		return fmt.Errorf("%s is still referenced by %d deleted %s; deprecate it instead", entry, deleted, noun)
+	default:
+		return nil
+	}
+}
+
+// checkNoUpgrades refuses to remove a Kubernetes version that cluster upgrades
+// still target: a pending upgrade could no longer start, and a finished one
+// keeps the catalog reference. Finished upgrades only block removing the
+// version entirely, not from some regions.
+func checkNoUpgrades(entry string, pending, finished int32, allRegions bool) error {
+	switch {
+	case pending > 0:
+		return fmt.Errorf("%s is the target of %d scheduled or in-progress cluster upgrades; cancel them or deprecate it instead", entry, pending)
+	case allRegions && finished > 0:
+		return fmt.Errorf("%s is still referenced by %d finished cluster upgrades; deprecate it instead", entry, finished)
+	default:
+		return nil
+	}
+}
+
+// inTx runs fn with queries bound to a transaction, committing when it
+// returns nil.
+func inTx(ctx *Context, fn func(queries *db.Queries) error) error {
+	bgCtx := context.Background()
+
+	tx, err := ctx.DB.Pool.Begin(bgCtx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer rollback.Rollback(bgCtx, tx, ctx.Logger)
+
+	if err := fn(ctx.Queries.WithTx(tx)); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(bgCtx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+func formatDeprecated(deprecated pgtype.Timestamptz) string {
+	if !deprecated.Valid {
+		return "-"
+	}
+	return deprecated.Time.Format(TimeFormat)
+}
+
+// deprecatedOutput returns the JSON value of a deprecation date, nil when the
+// entry is not deprecated.
+func deprecatedOutput(deprecated pgtype.Timestamptz) *string {
+	if !deprecated.Valid {
+		return nil
+	}
+	s := deprecated.Time.Format(TimeFormat)
+	return &s
+}
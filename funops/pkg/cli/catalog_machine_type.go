@@ -0,0 +1,251 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	db "github.com/fundament-oss/fundament/funops/pkg/db/gen"
+)
+
+// CatalogMachineTypeCmd groups the catalog machine type commands.
+type CatalogMachineTypeCmd struct {
+	List      CatalogMachineTypeListCmd      `cmd:"" help:"List the machine types in the catalog."`
+	Add       CatalogMachineTypeAddCmd       `cmd:"" help:"Offer a machine type in regions."`
+	Deprecate CatalogMachineTypeDeprecateCmd `cmd:"" help:"Stop offering a machine type for new node pools."`
+	Remove    CatalogMachineTypeRemoveCmd    `cmd:"" help:"Remove an unused machine type from regions or from the catalog."`
+}
+
+// CatalogMachineTypeListCmd lists the machine types in the catalog.
+type CatalogMachineTypeListCmd struct{}
+
+// CatalogMachineTypeAddCmd adds a machine type to the catalog and offers it in
+// regions. Adding a machine type that exists offers it in more regions; its
+// size must then match.
+type CatalogMachineTypeAddCmd struct {
+	Name   string   `arg:"" help:"Machine type name, as the infrastructure provider knows it." required:""`
+	Lcpu   int32    `help:"Number of logical CPUs." required:""`
+	Memory string   `help:"Memory as a Kubernetes quantity, e.g. 16Gi." required:""`
+	Region []string `help:"Region to offer the machine type in. Repeatable." required:""`
+}
+
+// CatalogMachineTypeDeprecateCmd deprecates a machine type.
+type CatalogMachineTypeDeprecateCmd struct {
+	Name string `arg:"" help:"Machine type name." required:""`
+	CatalogDeprecation
+}
+
+// CatalogMachineTypeRemoveCmd removes a machine type from regions, or from the
+// catalog when no region is given.
+type CatalogMachineTypeRemoveCmd struct {
+	Name   string   `arg:"" help:"Machine type name." required:""`
+	Region []string `help:"Only stop offering the machine type in this region. Repeatable."`
+}
+
+// Run executes the catalog machine type list command.
+func (c *CatalogMachineTypeListCmd) Run(ctx *Context) error {
+	ctx.Logger.Debug("listing catalog machine types")
+
+	machineTypes, err := ctx.Queries.CatalogMachineTypeList(context.Background())
+	if err != nil {
+		return fmt.Errorf("failed to list machine types: %w", err)
+	}
+
+	return outputCatalogMachineTypeList(ctx.Output, machineTypes)
+}
+
+// Run executes the catalog machine type add command.
+func (c *CatalogMachineTypeAddCmd) Run(ctx *Context) error {
+	if err := validateCatalogName("machine type", c.Name); err != nil {
+		return err
+	}
+	if c.Lcpu <= 0 {
+		return errors.New("--lcpu must be positive")
+	}
+	memory, err := parseMemory(c.Memory)
+	if err != nil {
+		return err
+	}
+
+	ctx.Logger.Debug("adding catalog machine type", "name", c.Name, "regions", c.Region)
+
+	err = inTx(ctx, func(queries *db.Queries) error {
+		bgCtx := context.Background()
+
+		regionIDs, err := lookupRegionIDs(bgCtx, queries, c.Region)
+		if err != nil {
+			return err
+		}
+
+		existing, err := queries.CatalogMachineTypeGetByName(bgCtx, db.CatalogMachineTypeGetByNameParams{Name: c.Name})
+		switch {
+		case errors.Is(err, pgx.ErrNoRows):
+			existing.ID, err = queries.CatalogMachineTypeCreate(bgCtx, db.CatalogMachineTypeCreateParams{
+				Name:   c.Name,
+				Lcpu:   c.Lcpu,
+				Memory: memory,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to add machine type: %w", err)
+			}
+		case err != nil:
+			return fmt.Errorf("failed to look up machine type: %w", err)
+		case existing.Lcpu != c.Lcpu || existing.Memory != memory:
+			return fmt.Errorf("machine type '%s' already exists with %d lcpu and %s memory",
+				c.Name, existing.Lcpu, resource.NewQuantity(existing.Memory, resource.BinarySI))
+		}
+
+		err = queries.CatalogRegionMachineTypesAdd(bgCtx, db.CatalogRegionMachineTypesAddParams{
+			MachineTypeID: existing.ID,
+			RegionIds:     regionIDs,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to offer machine type: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	ctx.Logger.Info("added machine type", "name", c.Name, "regions", strings.Join(c.Region, ","))
+
+	return nil
+}
+
+// Run executes the catalog machine type deprecate command.
+func (c *CatalogMachineTypeDeprecateCmd) Run(ctx *Context) error {
+	deprecated, err := c.deprecatedArg(time.Now())
+	if err != nil {
+		return err
+	}
+
+	ctx.Logger.Debug("deprecating catalog machine type", "name", c.Name)
+
+	rowsAffected, err := ctx.Queries.CatalogMachineTypeDeprecate(context.Background(), db.CatalogMachineTypeDeprecateParams{
+		Deprecated: deprecated,
+		Name:       c.Name,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to deprecate machine type: %w", err)
+	}
+	if rowsAffected != 1 {
+		return fmt.Errorf("machine type '%s' not found", c.Name)
+	}
+
+	ctx.Logger.Info("updated machine type deprecation", "name", c.Name, "deprecated", formatDeprecated(deprecated))
+
+	return nil
+}
+
+// Run executes the catalog machine type remove command.
+func (c *CatalogMachineTypeRemoveCmd) Run(ctx *Context) error {
+	ctx.Logger.Debug("removing catalog machine type", "name", c.Name, "regions", c.Region)
+
+	err := inTx(ctx, func(queries *db.Queries) error {
+		bgCtx := context.Background()
+
+		regionIDs, err := lookupRegionIDs(bgCtx, queries, c.Region)
+		if err != nil {
+			return err
+		}
+
+		usage, err := queries.CatalogMachineTypeUsage(bgCtx, db.CatalogMachineTypeUsageParams{
+			RegionIds: regionIDs,
+			Name:      c.Name,
+		})
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return fmt.Errorf("machine type '%s' not found", c.Name)
+			}
+			return fmt.Errorf("failed to check machine type usage: %w", err)
+		}
+		if err := checkUnused(fmt.Sprintf("machine type '%s'", c.Name), usage.NodePools, usage.DeletedNodePools, "node pools"); err != nil {
+			return err
+		}
+
+		err = queries.CatalogRegionMachineTypesDelete(bgCtx, db.CatalogRegionMachineTypesDeleteParams{
+			RegionIds:     regionIDs,
+			MachineTypeID: usage.ID,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to stop offering machine type: %w", err)
+		}
+
+		if len(regionIDs) > 0 {
+			return nil
+		}
+		if err := queries.CatalogMachineTypeDelete(bgCtx, db.CatalogMachineTypeDeleteParams{ID: usage.ID}); err != nil {
+			return fmt.Errorf("failed to remove machine type: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	ctx.Logger.Info("removed machine type", "name", c.Name, "regions", strings.Join(c.Region, ","))
+
+	return nil
+}
+
+// parseMemory parses a memory quantity into bytes.
+func parseMemory(value string) (int64, error) {
+	q, err := resource.ParseQuantity(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid --memory %q: %w", value, err)
+	}
+	if q.Sign() <= 0 {
+		return 0, fmt.Errorf("invalid --memory %q: must be positive", value)
+	}
+	return q.Value(), nil
+}
+
+// catalogMachineTypeOutput is the JSON output structure for a catalog machine type.
+type catalogMachineTypeOutput struct {
+	Name       string   `json:"name"`
+	Lcpu       int32    `json:"lcpu"`
+	Memory     int64    `json:"memory"`
+	Deprecated *string  `json:"deprecated,omitempty"`
+	Regions    []string `json:"regions"`
+	NodePools  int32    `json:"node_pools"`
+}
+
+func outputCatalogMachineTypeList(format OutputFormat, machineTypes []db.CatalogMachineTypeListRow) error {
+	switch format {
+	case OutputJSON:
+		output := make([]catalogMachineTypeOutput, len(machineTypes))
+		for i, mt := range machineTypes {
+			output[i] = catalogMachineTypeOutput{
+				Name:       mt.Name,
+				Lcpu:       mt.Lcpu,
+				Memory:     mt.Memory,
+				Deprecated: deprecatedOutput(mt.Deprecated),
+				Regions:    mt.Regions,
+				NodePools:  mt.NodePools,
+			}
+		}
+		return PrintJSON(output)
+	case OutputTable:
+		w := NewTableWriter()
+		fmt.Fprintln(w, "NAME\tLCPU\tMEMORY\tREGIONS\tDEPRECATED\tNODE POOLS")
+		for _, mt := range machineTypes {
+			fmt.Fprintf(w, "%s\t%d\t%s\t%s\t%s\t%d\n",
+				mt.Name,
+				mt.Lcpu,
+				resource.NewQuantity(mt.Memory, resource.BinarySI),
+				strings.Join(mt.Regions, ","),
+				formatDeprecated(mt.Deprecated),
+				mt.NodePools,
+			)
+		}
+		return w.Flush()
+	default:
+		panic(fmt.Sprintf("unknown output format: %s", format))
+	}
+}
@@ -0,0 +1,181 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	db "github.com/fundament-oss/fundament/funops/pkg/db/gen"
+)
+
+// CatalogRegionCmd groups the catalog region commands.
+type CatalogRegionCmd struct {
+	List      CatalogRegionListCmd      `cmd:"" help:"List the regions in the catalog."`
+	Add       CatalogRegionAddCmd       `cmd:"" help:"Add a region to the catalog."`
+	Deprecate CatalogRegionDeprecateCmd `cmd:"" help:"Stop offering a region for new clusters."`
+	Remove    CatalogRegionRemoveCmd    `cmd:"" help:"Remove an unused region and everything it offers."`
+}
+
+// CatalogRegionListCmd lists the regions in the catalog.
+type CatalogRegionListCmd struct{}
+
+// CatalogRegionAddCmd adds a region to the catalog.
+type CatalogRegionAddCmd struct {
+	Name               string `arg:"" help:"Region name, as users pass it to cluster create." required:""`
+	CloudProfile       string `help:"Gardener CloudProfile the region's clusters use." required:""`
+	CloudProfileRegion string `help:"Region within the CloudProfile." required:""`
+}
+
+// CatalogRegionDeprecateCmd deprecates a region.
+type CatalogRegionDeprecateCmd struct {
+	Name string `arg:"" help:"Region name." required:""`
+	CatalogDeprecation
+}
+
+// CatalogRegionRemoveCmd removes a region from the catalog.
+type CatalogRegionRemoveCmd struct {
+	Name string `arg:"" help:"Region name." required:""`
+}
+
+// Run executes the catalog region list command.
+func (c *CatalogRegionListCmd) Run(ctx *Context) error {
+	ctx.Logger.Debug("listing catalog regions")
+
+	regions, err := ctx.Queries.CatalogRegionList(context.Background())
+	if err != nil {
+		return fmt.Errorf("failed to list regions: %w", err)
+	}
+
+	return outputCatalogRegionList(ctx.Output, regions)
+}
+
+// Run executes the catalog region add command.
+func (c *CatalogRegionAddCmd) Run(ctx *Context) error {
+	if err := validateCatalogName("region", c.Name); err != nil {
+		return err
+	}
+
+	ctx.Logger.Debug("adding catalog region", "name", c.Name)
+
+	err := ctx.Queries.CatalogRegionCreate(context.Background(), db.CatalogRegionCreateParams{
+		Name:               c.Name,
+		CloudProfile:       c.CloudProfile,
+		CloudProfileRegion: c.CloudProfileRegion,
+	})
+	if err != nil {
+		if pgErr, ok := err.(*pgconn.PgError); ok && pgErr.Code == pgerrcode.UniqueViolation {
+			return fmt.Errorf("region '%s' already exists", c.Name)
+		}
+		return fmt.Errorf("failed to add region: %w", err)
+	}
+
+	ctx.Logger.Info("added region", "name", c.Name)
+
+	return nil
+}
+
+// Run executes the catalog region deprecate command.
+func (c *CatalogRegionDeprecateCmd) Run(ctx *Context) error {
+	deprecated, err := c.deprecatedArg(time.Now())
+	if err != nil {
+		return err
+	}
+
+	ctx.Logger.Debug("deprecating catalog region", "name", c.Name)
+
+	rowsAffected, err := ctx.Queries.CatalogRegionDeprecate(context.Background(), db.CatalogRegionDeprecateParams{
+		Deprecated: deprecated,
+		Name:       c.Name,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to deprecate region: %w", err)
+	}
+	if rowsAffected != 1 {
+		return fmt.Errorf("region '%s' not found", c.Name)
+	}
+
+	ctx.Logger.Info("updated region deprecation", "name", c.Name, "deprecated", formatDeprecated(deprecated))
+
+	return nil
+}
+
+// Run executes the catalog region remove command.
+func (c *CatalogRegionRemoveCmd) Run(ctx *Context) error {
+	ctx.Logger.Debug("removing catalog region", "name", c.Name)
+
+	err := inTx(ctx, func(queries *db.Queries) error {
+		bgCtx := context.Background()
+
+		usage, err := queries.CatalogRegionUsage(bgCtx, db.CatalogRegionUsageParams{Name: c.Name})
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return fmt.Errorf("region '%s' not found", c.Name)
+			}
+			return fmt.Errorf("failed to check region usage: %w", err)
+		}
+		if err := checkUnused(fmt.Sprintf("region '%s'", c.Name), usage.Clusters, usage.DeletedClusters, "clusters"); err != nil {
+			return err
+		}
+
+		if err := queries.CatalogRegionOfferingsDelete(bgCtx, db.CatalogRegionOfferingsDeleteParams{RegionID: usage.ID}); err != nil {
+			return fmt.Errorf("failed to remove region offerings: %w", err)
+		}
+		if err := queries.CatalogRegionDelete(bgCtx, db.CatalogRegionDeleteParams{ID: usage.ID}); err != nil {
+			return fmt.Errorf("failed to remove region: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	ctx.Logger.Info("removed region", "name", c.Name)
+
+	return nil
+}
+
+// catalogRegionOutput is the JSON output structure for a catalog region.
+type catalogRegionOutput struct {
+	Name               string  `json:"name"`
+	CloudProfile       string  `json:"cloud_profile"`
+	CloudProfileRegion string  `json:"cloud_profile_region"`
+	Deprecated         *string `json:"deprecated,omitempty"`
+	Clusters           int32   `json:"clusters"`
+}
+
+func outputCatalogRegionList(format OutputFormat, regions []db.CatalogRegionListRow) error {
+	switch format {
+	case OutputJSON:
+		output := make([]catalogRegionOutput, len(regions))
+		for i, r := range regions {
+			output[i] = catalogRegionOutput{
+				Name:               r.Name,
+				CloudProfile:       r.CloudProfile,
+				CloudProfileRegion: r.CloudProfileRegion,
+				Deprecated:         deprecatedOutput(r.Deprecated),
+				Clusters:           r.Clusters,
+			}
+		}
+		return PrintJSON(output)
+	case OutputTable:
+		w := NewTableWriter()
+		fmt.Fprintln(w, "NAME\tCLOUD PROFILE\tCLOUD PROFILE REGION\tDEPRECATED\tCLUSTERS")
+		for _, r := range regions {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%d\n",
+				r.Name,
+				r.CloudProfile,
+				r.CloudProfileRegion,
+				formatDeprecated(r.Deprecated),
+				r.Clusters,
+			)
+		}
+		return w.Flush()
+	default:
+		panic(fmt.Sprintf("unknown output format: %s", format))
+	}
+}
@@ -0,0 +1,143 @@
+package cli
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func TestDeprecatedArg(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		deprecation CatalogDeprecation
+		want        pgtype.Timestamptz
+		wantErrMsg  string
+	}{
+		{
+			name: "defaults to now",
+			want: pgtype.Timestamptz{Time: now, Valid: true},
+		},
+		{
+			name:        "date",
+			deprecation: CatalogDeprecation{Date: "2026-06-30"},
+			want:        pgtype.Timestamptz{Time: time.Date(2026, 6, 30, 0, 0, 0, 0, time.UTC), Valid: true},
+		},
+		{
+			name:        "undo clears the date",
+			deprecation: CatalogDeprecation{Undo: true},
+			want:        pgtype.Timestamptz{},
+		},
+		{
+			name:        "invalid date",
+			deprecation: CatalogDeprecation{Date: "30-06-2026"},
+			wantErrMsg:  `invalid --date "30-06-2026": expected YYYY-MM-DD`,
+		},
+		{
+			name:        "date and undo",
+			deprecation: CatalogDeprecation{Date: "2026-06-30", Undo: true},
+			wantErrMsg:  "--date and --undo cannot be combined",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.deprecation.deprecatedArg(now)
+
+			if tt.wantErrMsg != "" {
+				if err == nil || err.Error() != tt.wantErrMsg {
+					t.Errorf("deprecatedArg() error = %v, want %q", err, tt.wantErrMsg)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("deprecatedArg() unexpected error: %v", err)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("deprecatedArg() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateCatalogNames(t *testing.T) {
+	for _, name := range []string{"eu-west-1", "n1-standard-2", "c5.xlarge"} {
+		if err := validateCatalogName("region", name); err != nil {
+			t.Errorf("validateCatalogName(%q) unexpected error: %v", name, err)
+		}
+	}
+	for _, name := range []string{"", "EU-West-1", "-eu", "eu-", "eu west"} {
+		if err := validateCatalogName("region", name); err == nil {
+			t.Errorf("validateCatalogName(%q) expected an error", name)
+		}
+	}
+
+	for _, version := range []string{"1.31", "1.31.2"} {
+		if err := validateKubernetesVersion(version); err != nil {
+			t.Errorf("validateKubernetesVersion(%q) unexpected error: %v", version, err)
+		}
+	}
+	for _, version := range []string{"", "v1.31", "1", "1.31.2-gke.1"} {
+		if err := validateKubernetesVersion(version); err == nil {
+			t.Errorf("validateKubernetesVersion(%q) expected an error", version)
+		}
+	}
+}
+
+func TestParseMemory(t *testing.T) {
+	got, err := parseMemory("16Gi")
+	if err != nil {
+		t.Fatalf("parseMemory() unexpected error: %v", err)
+	}
+	if got != 16<<30 {
+		t.Errorf("parseMemory() = %d, want %d", got, int64(16<<30))
+	}
+
+	for _, value := range []string{"", "lots", "0", "-1Gi"} {
+		if _, err := parseMemory(value); err == nil {
+			t.Errorf("parseMemory(%q) expected an error", value)
+		}
+	}
+}
+
+func TestCheckUnused(t *testing.T) {
+	if err := checkUnused("region 'eu-west-1'", 0, 0, "clusters"); err != nil {
+		t.Errorf("checkUnused() unexpected error: %v", err)
+	}
+
+	err := checkUnused("region 'eu-west-1'", 2, 1, "clusters")
+	if err == nil || err.Error() != "region 'eu-west-1' is still used by 2 clusters; deprecate it instead" {
+		t.Errorf("checkUnused() error = %v", err)
+	}
+
+	err = checkUnused("region 'eu-west-1'", 0, 1, "clusters")
+	if err == nil || err.Error() != "region 'eu-west-1' is still referenced by 1 deleted clusters; deprecate it instead" {
+		t.Errorf("checkUnused() error = %v", err)
+	}
+}
+
+func TestCheckNoUpgrades(t *testing.T) {
+	if err := checkNoUpgrades("kubernetes version '1.31.2'", 0, 0, true); err != nil {
+		t.Errorf("checkNoUpgrades() unexpected error: %v", err)
+	}
+
+	err := checkNoUpgrades("kubernetes version '1.31.2'", 2, 1, false)
+	if err == nil || err.Error() != "kubernetes version '1.31.2' is the target of 2 scheduled or in-progress cluster upgrades; cancel them or deprecate it instead" {
+		t.Errorf("checkNoUpgrades() error = %v", err)
+	}
+
+	err = checkNoUpgrades("kubernetes version '1.31.2'", 0, 1, true)
+	if err == nil || err.Error() != "kubernetes version '1.31.2' is still referenced by 1 finished cluster upgrades; deprecate it instead" {
+		t.Errorf("checkNoUpgrades() error = %v", err)
+	}
+
+	// Only removing the version entirely drops the row finished upgrades
+	// reference.
+	if err := checkNoUpgrades("kubernetes version '1.31.2'", 0, 1, false); err != nil {
+		t.Errorf("checkNoUpgrades() unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,214 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	db "github.com/fundament-oss/fundament/funops/pkg/db/gen"
+)
+
+// CatalogVersionCmd groups the catalog Kubernetes version commands.
+type CatalogVersionCmd struct {
+	List      CatalogVersionListCmd      `cmd:"" help:"List the Kubernetes versions in the catalog."`
+	Add       CatalogVersionAddCmd       `cmd:"" help:"Offer a Kubernetes version in regions."`
+	Deprecate CatalogVersionDeprecateCmd `cmd:"" help:"Stop offering a Kubernetes version for new clusters and upgrades."`
+	Remove    CatalogVersionRemoveCmd    `cmd:"" help:"Remove an unused Kubernetes version from regions or from the catalog."`
+}
+
+// CatalogVersionListCmd lists the Kubernetes versions in the catalog.
+type CatalogVersionListCmd struct{}
+
+// CatalogVersionAddCmd adds a Kubernetes version to the catalog and offers it
+// in regions. Adding a version that exists offers it in more regions.
+type CatalogVersionAddCmd struct {
+	Version string   `arg:"" help:"Kubernetes version, e.g. 1.31 or 1.31.2." required:""`
+	Region  []string `help:"Region to offer the version in. Repeatable." required:""`
+}
+
+// CatalogVersionDeprecateCmd deprecates a Kubernetes version.
+type CatalogVersionDeprecateCmd struct {
+	Version string `arg:"" help:"Kubernetes version." required:""`
+	CatalogDeprecation
+}
+
+// CatalogVersionRemoveCmd removes a Kubernetes version from regions, or from
+// the catalog when no region is given.
+type CatalogVersionRemoveCmd struct {
+	Version string   `arg:"" help:"Kubernetes version." required:""`
+	Region  []string `help:"Only stop offering the version in this region. Repeatable."`
+}
+
+// Run executes the catalog version list command.
+func (c *CatalogVersionListCmd) Run(ctx *Context) error {
+	ctx.Logger.Debug("listing catalog kubernetes versions")
+
+	versions, err := ctx.Queries.CatalogKubernetesVersionList(context.Background())
+	if err != nil {
+		return fmt.Errorf("failed to list kubernetes versions: %w", err)
+	}
+
+	return outputCatalogVersionList(ctx.Output, versions)
+}
+
+// Run executes the catalog version add command.
+func (c *CatalogVersionAddCmd) Run(ctx *Context) error {
+	if err := validateKubernetesVersion(c.Version); err != nil {
+		return err
+	}
+
+	ctx.Logger.Debug("adding catalog kubernetes version", "version", c.Version, "regions", c.Region)
+
+	err := inTx(ctx, func(queries *db.Queries) error {
+		bgCtx := context.Background()
+
+		regionIDs, err := lookupRegionIDs(bgCtx, queries, c.Region)
+		if err != nil {
+			return err
+		}
+
+		versionID, err := queries.CatalogKubernetesVersionUpsert(bgCtx, db.CatalogKubernetesVersionUpsertParams{
+			Version: c.Version,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to add kubernetes version: %w", err)
+		}
+
+		err = queries.CatalogRegionKubernetesVersionsAdd(bgCtx, db.CatalogRegionKubernetesVersionsAddParams{
+			KubernetesVersionID: versionID,
+			RegionIds:           regionIDs,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to offer kubernetes version: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	ctx.Logger.Info("added kubernetes version", "version", c.Version, "regions", strings.Join(c.Region, ","))
+
+	return nil
+}
+
+// Run executes the catalog version deprecate command.
+func (c *CatalogVersionDeprecateCmd) Run(ctx *Context) error {
+	deprecated, err := c.deprecatedArg(time.Now())
+	if err != nil {
+		return err
+	}
+
+	ctx.Logger.Debug("deprecating catalog kubernetes version", "version", c.Version)
+
+	rowsAffected, err := ctx.Queries.CatalogKubernetesVersionDeprecate(context.Background(), db.CatalogKubernetesVersionDeprecateParams{
+		Deprecated: deprecated,
+		Version:    c.Version,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to deprecate kubernetes version: %w", err)
+	}
+	if rowsAffected != 1 {
+		return fmt.Errorf("kubernetes version '%s' not found", c.Version)
+	}
+
+	ctx.Logger.Info("updated kubernetes version deprecation", "version", c.Version, "deprecated", formatDeprecated(deprecated))
+
+	return nil
+}
+
+// Run executes the catalog version remove command.
+func (c *CatalogVersionRemoveCmd) Run(ctx *Context) error {
+	ctx.Logger.Debug("removing catalog kubernetes version", "version", c.Version, "regions", c.Region)
+
+	err := inTx(ctx, func(queries *db.Queries) error {
+		bgCtx := context.Background()
+
+		regionIDs, err := lookupRegionIDs(bgCtx, queries, c.Region)
+		if err != nil {
+			return err
+		}
+
+		usage, err := queries.CatalogKubernetesVersionUsage(bgCtx, db.CatalogKubernetesVersionUsageParams{
+			RegionIds: regionIDs,
+			Version:   c.Version,
+		})
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return fmt.Errorf("kubernetes version '%s' not found", c.Version)
+			}
+			return fmt.Errorf("failed to check kubernetes version usage: %w", err)
+		}
+		if err := checkUnused(fmt.Sprintf("kubernetes version '%s'", c.Version), usage.Clusters, usage.DeletedClusters, "clusters"); err != nil {
+			return err
+		}
+		if err := checkNoUpgrades(fmt.Sprintf("kubernetes version '%s'", c.Version), usage.PendingUpgrades, usage.FinishedUpgrades, len(regionIDs) == 0); err != nil {
+			return err
+		}
+
+		err = queries.CatalogRegionKubernetesVersionsDelete(bgCtx, db.CatalogRegionKubernetesVersionsDeleteParams{
+			RegionIds:           regionIDs,
+			KubernetesVersionID: usage.ID,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to stop offering kubernetes version: %w", err)
+		}
+
+		if len(regionIDs) > 0 {
+			return nil
+		}
+		if err := queries.CatalogKubernetesVersionDelete(bgCtx, db.CatalogKubernetesVersionDeleteParams{ID: usage.ID}); err != nil {
+			return fmt.Errorf("failed to remove kubernetes version: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	ctx.Logger.Info("removed kubernetes version", "version", c.Version, "regions", strings.Join(c.Region, ","))
+
+	return nil
+}
+
+// catalogVersionOutput is the JSON output structure for a catalog Kubernetes version.
+type catalogVersionOutput struct {
+	Version    string   `json:"version"`
+	Deprecated *string  `json:"deprecated,omitempty"`
+	Regions    []string `json:"regions"`
+	Clusters   int32    `json:"clusters"`
+}
+
+func outputCatalogVersionList(format OutputFormat, versions []db.CatalogKubernetesVersionListRow) error {
+	switch format {
+	case OutputJSON:
+		output := make([]catalogVersionOutput, len(versions))
+		for i, v := range versions {
+			output[i] = catalogVersionOutput{
+				Version:    v.Version,
+				Deprecated: deprecatedOutput(v.Deprecated),
+				Regions:    v.Regions,
+				Clusters:   v.Clusters,
+			}
+		}
+		return PrintJSON(output)
+	case OutputTable:
+		w := NewTableWriter()
+		fmt.Fprintln(w, "VERSION\tREGIONS\tDEPRECATED\tCLUSTERS")
+		for _, v := range versions {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%d\n",
+				v.Version,
+				strings.Join(v.Regions, ","),
+				formatDeprecated(v.Deprecated),
+				v.Clusters,
+			)
+		}
+		return w.Flush()
+	default:
+		panic(fmt.Sprintf("unknown output format: %s", format))
+	}
+}
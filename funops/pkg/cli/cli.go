@@ -4,6 +4,7 @@ package cli
 import (
 	"log/slog"
 
+	"github.com/fundament-oss/fundament/common/psqldb"
 	db "github.com/fundament-oss/fundament/funops/pkg/db/gen"
 )
 
@@ -14,6 +15,9 @@ type CLI struct {
 
 	Organization OrganizationCmd `cmd:"" help:"Manage organizations."`
 	User         UserCmd         `cmd:"" help:"Manage users."`
+	Catalog      CatalogCmd      `cmd:"" help:"Manage the region catalog."`
+	Outbox       OutboxCmd       `cmd:"" help:"Inspect and replay outbox rows the workers could not process."`
+	Token        TokenCmd        `cmd:"" help:"Mint service tokens for platform components."`
 }
 
 // Context holds shared dependencies for command execution.
@@ -21,5 +25,6 @@ type Context struct {
 	Debug   bool
 	Output  OutputFormat
 	Logger  *slog.Logger
+	DB      *psqldb.DB
 	Queries *db.Queries
 }
@@ -0,0 +1,157 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	db "github.com/fundament-oss/fundament/funops/pkg/db/gen"
+)
+
+// OrganizationLimitsCmd groups the commands for the resource caps of an organization.
+type OrganizationLimitsCmd struct {
+	Get OrganizationLimitsGetCmd `cmd:"" help:"Show the resource caps of an organization."`
+	Set OrganizationLimitsSetCmd `cmd:"" help:"Change the resource caps of an organization."`
+}
+
+// OrganizationLimitsGetCmd shows the resource caps of an organization.
+type OrganizationLimitsGetCmd struct {
+	Name string `arg:"" help:"Organization name." required:""`
+}
+
+// OrganizationLimitsSetCmd changes the resource caps of an organization.
+// Caps that are not given keep their value.
+type OrganizationLimitsSetCmd struct {
+	Name          string `arg:"" help:"Organization name." required:""`
+	MaxClusters   *int32 `help:"Maximum number of clusters, 0 removes the cap."`
+	MaxProjects   *int32 `help:"Maximum number of projects, 0 removes the cap."`
+	MaxNamespaces *int32 `help:"Maximum number of namespaces, 0 removes the cap."`
+}
+
+// Run executes the organization limits get command.
+func (c *OrganizationLimitsGetCmd) Run(ctx *Context) error {
+	ctx.Logger.Debug("getting organization limits", "name", c.Name)
+
+	orgID, err := lookupOrganizationID(ctx, c.Name)
+	if err != nil {
+		return err
+	}
+
+	caps, err := ctx.Queries.OrganizationCapsGet(context.Background(), db.OrganizationCapsGetParams{
+		OrganizationID: orgID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get organization limits: %w", err)
+	}
+
+	return outputOrganizationLimits(ctx.Output, &caps)
+}
+
+// Run executes the organization limits set command.
+func (c *OrganizationLimitsSetCmd) Run(ctx *Context) error {
+	params := db.OrganizationCapsUpsertParams{}
+
+	var err error
+	if params.MaxClusters, err = capArg("max-clusters", c.MaxClusters); err != nil {
+		return err
+	}
+	if params.MaxProjects, err = capArg("max-projects", c.MaxProjects); err != nil {
+		return err
+	}
+	if params.MaxNamespaces, err = capArg("max-namespaces", c.MaxNamespaces); err != nil {
+		return err
+	}
+	if !params.MaxClusters.Valid && !params.MaxProjects.Valid && !params.MaxNamespaces.Valid {
+		return errors.New("nothing to change: pass --max-clusters, --max-projects or --max-namespaces")
+	}
+
+	ctx.Logger.Debug("setting organization limits", "name", c.Name)
+
+	params.OrganizationID, err = lookupOrganizationID(ctx, c.Name)
+	if err != nil {
+		return err
+	}
+
+	if err := ctx.Queries.OrganizationCapsUpsert(context.Background(), params); err != nil {
+		return fmt.Errorf("failed to set organization limits: %w", err)
+	}
+
+	ctx.Logger.Info("updated organization limits", "name", c.Name)
+
+	return nil
+}
+
+func lookupOrganizationID(ctx *Context, name string) (uuid.UUID, error) {
+	orgID, err := ctx.Queries.OrganizationGetIDByName(context.Background(), db.OrganizationGetIDByNameParams{
+		Name: name,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return uuid.Nil, fmt.Errorf("organization %q not found", name)
+		}
+		return uuid.Nil, fmt.Errorf("failed to look up organization: %w", err)
+	}
+	return orgID, nil
+}
+
+// capArg converts a cap flag into a query argument: NULL when the flag is not
+// given, so the stored cap is kept. Zero is passed on and removes the cap.
+func capArg(flag string, value *int32) (pgtype.Int4, error) {
+	if value == nil {
+		return pgtype.Int4{}, nil
+	}
+	if *value < 0 {
+		return pgtype.Int4{}, fmt.Errorf("--%s must not be negative", flag)
+	}
+	return pgtype.Int4{Int32: *value, Valid: true}, nil
+}
+
+// organizationLimitOutput is the JSON output structure for one resource cap.
+// Max is omitted when the organization has no cap.
+type organizationLimitOutput struct {
+	Limit   string `json:"limit"`
+	Current int32  `json:"current"`
+	Max     *int32 `json:"max,omitempty"`
+}
+
+func outputOrganizationLimits(format OutputFormat, caps *db.OrganizationCapsGetRow) error {
+	limits := []struct {
+		name    string
+		current int32
+		max     pgtype.Int4
+	}{
+		{"max_clusters", caps.Clusters, caps.MaxClusters},
+		{"max_projects", caps.Projects, caps.MaxProjects},
+		{"max_namespaces", caps.Namespaces, caps.MaxNamespaces},
+	}
+
+	switch format {
+	case OutputJSON:
+		output := make([]organizationLimitOutput, len(limits))
+		for i, l := range limits {
+			output[i] = organizationLimitOutput{Limit: l.name, Current: l.current}
+			if l.max.Valid {
+				output[i].Max = &l.max.Int32
+			}
+		}
+		return PrintJSON(output)
+	case OutputTable:
+		w := NewTableWriter()
+		fmt.Fprintln(w, "LIMIT\tCURRENT\tMAX")
+		for _, l := range limits {
+			maximum := "unlimited"
+			if l.max.Valid {
+				maximum = strconv.Itoa(int(l.max.Int32))
+			}
+			fmt.Fprintf(w, "%s\t%d\t%s\n", l.name, l.current, maximum)
+		}
+		return w.Flush()
+	default:
+		panic(fmt.Sprintf("unknown output format: %s", format))
+	}
+}
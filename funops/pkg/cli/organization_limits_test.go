@@ -0,0 +1,60 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func TestCapArg(t *testing.T) {
+	ptr := func(v int32) *int32 { return &v }
+
+	tests := []struct {
+		name       string
+		value      *int32
+		want       pgtype.Int4
+		wantErrMsg string
+	}{
+		{
+			name:  "not given keeps the cap",
+			value: nil,
+			want:  pgtype.Int4{},
+		},
+		{
+			name:  "zero removes the cap",
+			value: ptr(0),
+			want:  pgtype.Int4{Int32: 0, Valid: true},
+		},
+		{
+			name:  "positive sets the cap",
+			value: ptr(25),
+			want:  pgtype.Int4{Int32: 25, Valid: true},
+		},
+		{
+			name:       "negative",
+			value:      ptr(-1),
+			wantErrMsg: "--max-clusters must not be negative",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := capArg("max-clusters", tt.value)
+
+			if tt.wantErrMsg != "" {
+				if err == nil || err.Error() != tt.wantErrMsg {
+					t.Errorf("capArg() error = %v, want %q", err, tt.wantErrMsg)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("capArg() unexpected error: %v", err)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("capArg() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,344 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	db "github.com/fundament-oss/fundament/funops/pkg/db/gen"
+)
+
+// OutboxCmd groups the commands for outbox rows that cluster-worker or
+// authz-worker could not process: rows that used up their retries, and rows a
+// precondition keeps deferring.
+type OutboxCmd struct {
+	List    OutboxListCmd    `cmd:"" help:"List failed, deferred and abandoned outbox rows."`
+	Replay  OutboxReplayCmd  `cmd:"" help:"Hand outbox rows back to their worker with a fresh retry budget."`
+	Abandon OutboxAbandonCmd `cmd:"" help:"Stop the workers from processing outbox rows."`
+}
+
+// OutboxSelection holds the flag that picks the outbox a command works on.
+type OutboxSelection struct {
+	Outbox string `help:"Outbox to work on: cluster (cluster-worker) or authz (authz-worker)." default:"cluster" enum:"cluster,authz"`
+}
+
+// OutboxListCmd lists outbox rows with their last error and retry counts.
+type OutboxListCmd struct {
+	OutboxSelection
+	State        []string `help:"Only list rows in this state: failed, deferred, retrying or abandoned. Repeatable." default:"failed,deferred"`
+	Entity       []string `help:"Only list rows for this entity type, e.g. node_pool. Repeatable."`
+	Organization string   `help:"Only list rows belonging to this organization."`
+	Limit        int32    `help:"Maximum number of rows to list." default:"100"`
+}
+
+// OutboxReplayCmd resets outbox rows so their worker processes them again.
+type OutboxReplayCmd struct {
+	OutboxSelection
+	IDs []string `arg:"" name:"id" help:"Outbox row ID." required:""`
+}
+
+// OutboxAbandonCmd marks outbox rows abandoned so no worker processes them.
+type OutboxAbandonCmd struct {
+	OutboxSelection
+	IDs []string `arg:"" name:"id" help:"Outbox row ID." required:""`
+}
+
+// outboxActor names who replayed or abandoned a row in the cluster activity.
+const outboxActor = "an operator"
+
+// outboxStates lists the states each outbox has rows in that can be listed.
+// authz-worker never defers rows.
+var outboxStates = map[string][]string{
+	"cluster": {"failed", "deferred", "retrying", "abandoned"},
+	"authz":   {"failed", "retrying", "abandoned"},
+}
+
+// outboxEntityTypes lists the entity types of the rows in each outbox.
+var outboxEntityTypes = map[string][]string{
+	"cluster": {"cluster", "organization_user", "project_member", "node_pool", "namespace", "access_elevation"},
+	"authz": {
+		"project", "project_member", "cluster", "node_pool", "namespace", "api_key", "organization_user",
+		"plugin", "namespace_role_binding", "team", "team_member", "project_team", "access_elevation",
+	},
+}
+
+// validateOutboxFilters checks the list filters against the selected outbox.
+// A deferred state is dropped for authz.outbox rather than rejected, so the
+// default filter works for both outboxes.
+func validateOutboxFilters(outbox string, states, entityTypes []string) ([]string, error) {
+	valid := make([]string, 0, len(states))
+	for _, state := range states {
+		switch {
+		case slices.Contains(outboxStates[outbox], state):
+			valid = append(valid, state)
+		case state == "deferred":
+		default:
+			return nil, fmt.Errorf("invalid --state %q for the %s outbox: must be one of %s",
+				state, outbox, strings.Join(outboxStates[outbox], ", "))
+		}
+	}
+	if len(valid) == 0 {
+		return nil, fmt.Errorf("the %s outbox has no deferred rows; pass another --state", outbox)
+	}
+
+	for _, entityType := range entityTypes {
+		if !slices.Contains(outboxEntityTypes[outbox], entityType) {
+			return nil, fmt.Errorf("invalid --entity %q for the %s outbox: must be one of %s",
+				entityType, outbox, strings.Join(outboxEntityTypes[outbox], ", "))
+		}
+	}
+	return valid, nil
+}
+
+// parseOutboxIDs parses the row IDs given on the command line.
+func parseOutboxIDs(args []string) ([]uuid.UUID, error) {
+	ids := make([]uuid.UUID, 0, len(args))
+	for _, arg := range args {
+		id, err := uuid.Parse(arg)
+		if err != nil {
+			return nil, fmt.Errorf("invalid outbox row ID %q", arg)
+		}
+		if !slices.Contains(ids, id) {
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+// checkAllUpdated fails on the first requested row that the query left alone,
+// so a replay or abandon applies to every given row or to none.
+func checkAllUpdated(requested, updated []uuid.UUID, action string) error {
+	for _, id := range requested {
+		if !slices.Contains(updated, id) {
+			return fmt.Errorf("outbox row '%s' not found or cannot be %s in its current state", id, action)
+		}
+	}
+	return nil
+}
+
+// Run executes the outbox list command.
+func (c *OutboxListCmd) Run(ctx *Context) error {
+	states, err := validateOutboxFilters(c.Outbox, c.State, c.Entity)
+	if err != nil {
+		return err
+	}
+	if c.Limit <= 0 {
+		return errors.New("--limit must be positive")
+	}
+
+	params := db.OutboxClusterListParams{
+		States:      states,
+		EntityTypes: c.Entity,
+		RowLimit:    c.Limit,
+	}
+	if params.EntityTypes == nil {
+		params.EntityTypes = []string{}
+	}
+	if c.Organization != "" {
+		orgID, err := lookupOrganizationID(ctx, c.Organization)
+		if err != nil {
+			return err
+		}
+		params.OrganizationID = pgtype.UUID{Bytes: orgID, Valid: true}
+	}
+
+	ctx.Logger.Debug("listing outbox rows", "outbox", c.Outbox, "states", states, "entity_types", c.Entity)
+
+	var entries []outboxEntryOutput
+	if c.Outbox == "authz" {
+		rows, err := ctx.Queries.OutboxAuthzList(context.Background(), db.OutboxAuthzListParams(params))
+		if err != nil {
+			return fmt.Errorf("failed to list outbox rows: %w", err)
+		}
+		entries = make([]outboxEntryOutput, len(rows))
+		for i := range rows {
+			entries[i] = authzOutboxEntryOutput(&rows[i])
+		}
+	} else {
+		rows, err := ctx.Queries.OutboxClusterList(context.Background(), params)
+		if err != nil {
+			return fmt.Errorf("failed to list outbox rows: %w", err)
+		}
+		entries = make([]outboxEntryOutput, len(rows))
+		for i := range rows {
+			entries[i] = clusterOutboxEntryOutput(&rows[i])
+		}
+	}
+
+	return outputOutboxList(ctx.Output, entries)
+}
+
+// Run executes the outbox replay command.
+func (c *OutboxReplayCmd) Run(ctx *Context) error {
+	ids, err := parseOutboxIDs(c.IDs)
+	if err != nil {
+		return err
+	}
+
+	ctx.Logger.Debug("replaying outbox rows", "outbox", c.Outbox, "ids", ids)
+
+	err = inTx(ctx, func(queries *db.Queries) error {
+		params := db.OutboxClusterReplayParams{Ids: ids, Actor: outboxActor}
+
+		var replayed []uuid.UUID
+		var err error
+		if c.Outbox == "authz" {
+			replayed, err = queries.OutboxAuthzReplay(context.Background(), db.OutboxAuthzReplayParams(params))
+		} else {
+			replayed, err = queries.OutboxClusterReplay(context.Background(), params)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to replay outbox rows: %w", err)
+		}
+		return checkAllUpdated(ids, replayed, "replayed")
+	})
+	if err != nil {
+		return err
+	}
+
+	ctx.Logger.Info("replayed outbox rows", "outbox", c.Outbox, "count", len(ids))
+
+	return nil
+}
+
+// Run executes the outbox abandon command.
+func (c *OutboxAbandonCmd) Run(ctx *Context) error {
+	ids, err := parseOutboxIDs(c.IDs)
+	if err != nil {
+		return err
+	}
+
+	ctx.Logger.Debug("abandoning outbox rows", "outbox", c.Outbox, "ids", ids)
+
+	err = inTx(ctx, func(queries *db.Queries) error {
+		params := db.OutboxClusterAbandonParams{Ids: ids, Actor: outboxActor}
+
+		var abandoned []uuid.UUID
+		var err error
+		if c.Outbox == "authz" {
+			abandoned, err = queries.OutboxAuthzAbandon(context.Background(), db.OutboxAuthzAbandonParams(params))
+		} else {
+			abandoned, err = queries.OutboxClusterAbandon(context.Background(), params)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to abandon outbox rows: %w", err)
+		}
+		return checkAllUpdated(ids, abandoned, "abandoned")
+	})
+	if err != nil {
+		return err
+	}
+
+	ctx.Logger.Info("abandoned outbox rows", "outbox", c.Outbox, "count", len(ids))
+
+	return nil
+}
+
+// outboxEntryOutput is the JSON output structure for an outbox row.
+type outboxEntryOutput struct {
+	ID           string  `json:"id"`
+	EntityType   string  `json:"entity_type"`
+	EntityID     string  `json:"entity_id"`
+	Event        string  `json:"event,omitempty"`
+	State        string  `json:"state"`
+	Retries      int32   `json:"retries"`
+	Deferrals    int32   `json:"deferrals"`
+	LastError    *string `json:"last_error,omitempty"`
+	Organization *string `json:"organization,omitempty"`
+	ClusterID    *string `json:"cluster_id,omitempty"`
+	Created      string  `json:"created"`
+	Failed       *string `json:"failed,omitempty"`
+}
+
+func clusterOutboxEntryOutput(row *db.OutboxClusterListRow) outboxEntryOutput {
+	return outboxEntryOutput{
+		ID:           row.ID.String(),
+		EntityType:   row.EntityType,
+		EntityID:     row.EntityID.String(),
+		Event:        row.Event,
+		State:        row.State,
+		Retries:      row.Retries,
+		Deferrals:    row.Deferrals,
+		LastError:    textOutput(row.StatusInfo),
+		Organization: textOutput(row.OrganizationName),
+		ClusterID:    uuidOutput(row.ClusterID),
+		Created:      row.Created.Time.Format(TimeFormat),
+		Failed:       timeOutput(row.Failed),
+	}
+}
+
+func authzOutboxEntryOutput(row *db.OutboxAuthzListRow) outboxEntryOutput {
+	return outboxEntryOutput{
+		ID:           row.ID.String(),
+		EntityType:   row.EntityType,
+		EntityID:     row.EntityID.String(),
+		State:        row.State,
+		Retries:      row.Retries,
+		LastError:    textOutput(row.StatusInfo),
+		Organization: textOutput(row.OrganizationName),
+		ClusterID:    uuidOutput(row.ClusterID),
+		Created:      row.Created.Time.Format(TimeFormat),
+		Failed:       timeOutput(row.Failed),
+	}
+}
+
+func textOutput(t pgtype.Text) *string {
+	if !t.Valid {
+		return nil
+	}
+	return &t.String
+}
+
+func uuidOutput(u pgtype.UUID) *string {
+	if !u.Valid {
+		return nil
+	}
+	s := uuid.UUID(u.Bytes).String()
+	return &s
+}
+
+func timeOutput(t pgtype.Timestamptz) *string {
+	if !t.Valid {
+		return nil
+	}
+	s := t.Time.Format(TimeFormat)
+	return &s
+}
+
+func outputOutboxList(format OutputFormat, entries []outboxEntryOutput) error {
+	switch format {
+	case OutputJSON:
+		return PrintJSON(entries)
+	case OutputTable:
+		w := NewTableWriter()
+		fmt.Fprintln(w, "ID\tENTITY\tENTITY ID\tORGANIZATION\tSTATE\tRETRIES\tDEFERRALS\tCREATED\tLAST ERROR")
+		for _, e := range entries {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%d\t%d\t%s\t%s\n",
+				e.ID,
+				e.EntityType,
+				e.EntityID,
+				valueOrDash(e.Organization),
+				e.State,
+				e.Retries,
+				e.Deferrals,
+				e.Created,
+				valueOrDash(e.LastError),
+			)
+		}
+		return w.Flush()
+	default:
+		panic(fmt.Sprintf("unknown output format: %s", format))
+	}
+}
+
+func valueOrDash(s *string) string {
+	if s == nil {
+		return "-"
+	}
+	return *s
+}
@@ -0,0 +1,109 @@
+package cli
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestValidateOutboxFilters(t *testing.T) {
+	tests := []struct {
+		name        string
+		outbox      string
+		states      []string
+		entityTypes []string
+		want        []string
+		wantErrMsg  string
+	}{
+		{
+			name:   "cluster defaults",
+			outbox: "cluster",
+			states: []string{"failed", "deferred"},
+			want:   []string{"failed", "deferred"},
+		},
+		{
+			name:   "authz drops deferred",
+			outbox: "authz",
+			states: []string{"failed", "deferred"},
+			want:   []string{"failed"},
+		},
+		{
+			name:       "authz only deferred",
+			outbox:     "authz",
+			states:     []string{"deferred"},
+			wantErrMsg: "the authz outbox has no deferred rows; pass another --state",
+		},
+		{
+			name:       "unknown state",
+			outbox:     "cluster",
+			states:     []string{"completed"},
+			wantErrMsg: `invalid --state "completed" for the cluster outbox: must be one of failed, deferred, retrying, abandoned`,
+		},
+		{
+			name:        "entity type",
+			outbox:      "authz",
+			states:      []string{"abandoned"},
+			entityTypes: []string{"team_member"},
+			want:        []string{"abandoned"},
+		},
+		{
+			name:        "entity type of the other outbox",
+			outbox:      "cluster",
+			states:      []string{"failed"},
+			entityTypes: []string{"team"},
+			wantErrMsg:  `invalid --entity "team" for the cluster outbox: must be one of cluster, organization_user, project_member, node_pool, namespace, access_elevation`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := validateOutboxFilters(tt.outbox, tt.states, tt.entityTypes)
+
+			if tt.wantErrMsg != "" {
+				if err == nil || err.Error() != tt.wantErrMsg {
+					t.Errorf("validateOutboxFilters() error = %v, want %q", err, tt.wantErrMsg)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("validateOutboxFilters() unexpected error: %v", err)
+				return
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("validateOutboxFilters() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseOutboxIDs(t *testing.T) {
+	id := uuid.New()
+
+	got, err := parseOutboxIDs([]string{id.String(), id.String()})
+	if err != nil {
+		t.Fatalf("parseOutboxIDs() unexpected error: %v", err)
+	}
+	if !slices.Equal(got, []uuid.UUID{id}) {
+		t.Errorf("parseOutboxIDs() = %v, want [%s]", got, id)
+	}
+
+	if _, err := parseOutboxIDs([]string{"not-a-uuid"}); err == nil {
+		t.Error("parseOutboxIDs() expected an error")
+	}
+}
+
+func TestCheckAllUpdated(t *testing.T) {
+	first, second := uuid.New(), uuid.New()
+
+	if err := checkAllUpdated([]uuid.UUID{first, second}, []uuid.UUID{second, first}, "replayed"); err != nil {
+		t.Errorf("checkAllUpdated() unexpected error: %v", err)
+	}
+
+	err := checkAllUpdated([]uuid.UUID{first, second}, []uuid.UUID{first}, "replayed")
+	want := "outbox row '" + second.String() + "' not found or cannot be replayed in its current state"
+	if err == nil || err.Error() != want {
+		t.Errorf("checkAllUpdated() error = %v, want %q", err, want)
+	}
+}
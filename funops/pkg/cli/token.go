@@ -0,0 +1,109 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+
+	"github.com/fundament-oss/fundament/common/auth"
+)
+
+// TokenCmd groups the commands that mint service tokens for platform
+// components that run outside the main cluster and have no user to act for.
+type TokenCmd struct {
+	PluginController TokenPluginControllerCmd `cmd:"" help:"Mint the service token plugin-controller presents to organization-api."`
+}
+
+// TokenPluginControllerCmd mints a ServiceToken for the plugin-controller of
+// one organization's cluster. organization-api reads that organization's
+// plugin approvals for whoever presents it, so store it only in the Secret
+// the controller mounts.
+type TokenPluginControllerCmd struct {
+	Organization string        `help:"Organization the controller's cluster belongs to." required:""`
+	Cluster      string        `help:"ID of the cluster the controller runs in; recorded for auditing."`
+	KeysDir      string        `help:"Directory with authn-api's signing keys." env:"JWT_SIGNING_KEYS_DIR"`
+	JWTSecret    string        `help:"Shared JWT secret, when authn-api signs with one." env:"JWT_SECRET"`
+	TTL          time.Duration `help:"How long the token is valid." default:"2160h"`
+}
+
+// serviceTokenOutput is the JSON shape of a minted service token.
+type serviceTokenOutput struct {
+	Token          string    `json:"token"`
+	OrganizationID uuid.UUID `json:"organization_id"`
+	ExpiresAt      time.Time `json:"expires_at"`
+}
+
+// Run executes the token plugin-controller command.
+func (c *TokenPluginControllerCmd) Run(ctx *Context) error {
+	if c.TTL <= 0 {
+		return errors.New("--ttl must be positive")
+	}
+	if c.Cluster != "" {
+		if _, err := uuid.Parse(c.Cluster); err != nil {
+			return fmt.Errorf("invalid --cluster %q", c.Cluster)
+		}
+	}
+	signer, err := c.signer()
+	if err != nil {
+		return err
+	}
+
+	orgID, err := lookupOrganizationID(ctx, c.Organization)
+	if err != nil {
+		return err
+	}
+
+	token, expiresAt, err := mintServiceToken(signer, "plugin-controller", orgID, c.Cluster, time.Now(), c.TTL)
+	if err != nil {
+		return err
+	}
+
+	ctx.Logger.Debug("service token minted", "subject", "plugin-controller", "organization_id", orgID, "expires_at", expiresAt)
+
+	if ctx.Output == OutputJSON {
+		return PrintJSON(serviceTokenOutput{Token: token, OrganizationID: orgID, ExpiresAt: expiresAt})
+	}
+	fmt.Println(token)
+	return nil
+}
+
+// signer loads authn-api's signing keys, or the shared secret when no key
+// directory is given.
+func (c *TokenPluginControllerCmd) signer() (*auth.Signer, error) {
+	switch {
+	case c.KeysDir != "":
+		signer, err := auth.LoadSigner(c.KeysDir)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load signing keys: %w", err)
+		}
+		return signer, nil
+	case c.JWTSecret != "":
+		return auth.NewSharedSecretSigner([]byte(c.JWTSecret)), nil
+	default:
+		return nil, errors.New("--keys-dir or --jwt-secret is required")
+	}
+}
+
+// mintServiceToken signs a ServiceToken issued to subject for orgID, valid for
+// ttl from now.
+func mintServiceToken(signer *auth.Signer, subject string, orgID uuid.UUID, clusterID string, now time.Time, ttl time.Duration) (string, time.Time, error) {
+	expiresAt := now.Add(ttl)
+	token, err := signer.Sign(auth.ServiceClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    auth.ConsoleIssuer,
+			Subject:   subject,
+			Audience:  jwt.ClaimStrings{auth.TokenTypeService},
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+		OrganizationID: orgID,
+		ClusterID:      clusterID,
+	})
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to sign service token: %w", err)
+	}
+	return token, expiresAt, nil
+}
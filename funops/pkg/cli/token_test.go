@@ -0,0 +1,33 @@
+package cli
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/fundament-oss/fundament/common/auth"
+)
+
+func TestMintServiceToken(t *testing.T) {
+	secret := []byte("test-secret")
+	orgID := uuid.New()
+	clusterID := uuid.NewString()
+
+	token, expiresAt, err := mintServiceToken(auth.NewSharedSecretSigner(secret), "plugin-controller", orgID, clusterID, time.Now(), time.Hour)
+	if err != nil {
+		t.Fatalf("mintServiceToken() error = %v", err)
+	}
+
+	claims, err := auth.ParseServiceToken(token, auth.SharedSecret(secret))
+	if err != nil {
+		t.Fatalf("ParseServiceToken() error = %v", err)
+	}
+	if claims.Subject != "plugin-controller" || claims.OrganizationID != orgID || claims.ClusterID != clusterID {
+		t.Errorf("claims = %s/%s/%s, want plugin-controller/%s/%s",
+			claims.Subject, claims.OrganizationID, claims.ClusterID, orgID, clusterID)
+	}
+	if !claims.ExpiresAt.Time.Equal(expiresAt.Truncate(time.Second)) {
+		t.Errorf("expires at %s, want %s", claims.ExpiresAt.Time, expiresAt)
+	}
+}
@@ -0,0 +1,225 @@
+-- Region catalog management. The catalog is global: no RLS, no organization
+-- filter. Clusters and node pools reference catalog rows with ON DELETE
+-- RESTRICT, soft-deleted ones included, so the usage queries count both.
+
+-- name: CatalogRegionList :many
+SELECT
+  regions.name,
+  regions.cloud_profile,
+  regions.cloud_profile_region,
+  regions.deprecated,
+  (
+    SELECT COUNT(*)
+    FROM tenant.clusters
+    WHERE clusters.region_id = regions.id
+      AND clusters.deleted IS NULL
+  )::int4 AS clusters
+FROM catalog.regions
+ORDER BY regions.name;
+
+-- name: CatalogRegionIDsByName :many
+SELECT
+  regions.id,
+  regions.name
+FROM catalog.regions
+WHERE regions.name = ANY(@names::text[]);
+
+-- name: CatalogRegionCreate :exec
+INSERT INTO catalog.regions (name, cloud_profile, cloud_profile_region)
+VALUES (@name, @cloud_profile, @cloud_profile_region);
+
+-- name: CatalogRegionDeprecate :execrows
+-- Sets the deprecation date of a region; NULL undoes a deprecation.
+UPDATE catalog.regions
+SET deprecated = sqlc.narg('deprecated')
+WHERE name = @name;
+
+-- name: CatalogRegionUsage :one
+-- The clusters in a region. No row means the region does not exist.
+SELECT
+  regions.id,
+  COUNT(clusters.id) FILTER (WHERE clusters.deleted IS NULL)::int4 AS clusters,
+  COUNT(clusters.id) FILTER (WHERE clusters.deleted IS NOT NULL)::int4 AS deleted_clusters
+FROM catalog.regions
+LEFT JOIN tenant.clusters
+  ON clusters.region_id = regions.id
+WHERE regions.name = @name
+GROUP BY regions.id;
+
+-- name: CatalogRegionOfferingsDelete :exec
+-- Removes everything a region offers, ahead of removing the region itself.
+WITH region_versions AS (
+  DELETE FROM catalog.region_kubernetes_versions
+  WHERE region_kubernetes_versions.region_id = @region_id
+)
+DELETE FROM catalog.region_machine_types
+WHERE region_machine_types.region_id = @region_id;
+
+-- name: CatalogRegionDelete :exec
+DELETE FROM catalog.regions
+WHERE id = @id;
+
+-- name: CatalogKubernetesVersionList :many
+SELECT
+  kubernetes_versions.version,
+  kubernetes_versions.deprecated,
+  COALESCE(
+    ARRAY(
+      SELECT regions.name
+      FROM catalog.region_kubernetes_versions
+      JOIN catalog.regions
+        ON regions.id = region_kubernetes_versions.region_id
+      WHERE region_kubernetes_versions.kubernetes_version_id = kubernetes_versions.id
+      ORDER BY regions.name
+    ),
+    '{}'
+  )::text[] AS regions,
+  (
+    SELECT COUNT(*)
+    FROM tenant.clusters
+    WHERE clusters.kubernetes_version_id = kubernetes_versions.id
+      AND clusters.deleted IS NULL
+  )::int4 AS clusters
+FROM catalog.kubernetes_versions
+ORDER BY kubernetes_versions.version;
+
+-- name: CatalogKubernetesVersionUpsert :one
+-- Creates a Kubernetes version, or returns the existing one.
+INSERT INTO catalog.kubernetes_versions (version)
+VALUES (@version)
+ON CONFLICT ON CONSTRAINT kubernetes_versions_uq_version DO UPDATE SET
+  version = EXCLUDED.version
+RETURNING id;
+
+-- name: CatalogRegionKubernetesVersionsAdd :exec
+-- Offers a Kubernetes version in the given regions. Regions that already offer
+-- it are left alone.
+INSERT INTO catalog.region_kubernetes_versions (region_id, kubernetes_version_id)
+SELECT region_id, @kubernetes_version_id::uuid
+FROM unnest(@region_ids::uuid[]) AS region_id
+ON CONFLICT ON CONSTRAINT region_kubernetes_versions_pk DO NOTHING;
+
+-- name: CatalogKubernetesVersionDeprecate :execrows
+-- Sets the deprecation date of a Kubernetes version; NULL undoes a deprecation.
+UPDATE catalog.kubernetes_versions
+SET deprecated = sqlc.narg('deprecated')
+WHERE version = @version;
+
+-- name: CatalogKubernetesVersionUsage :one
+-- The clusters on a Kubernetes version and the cluster upgrades to it, in the
+-- given regions or in all regions when none are given. No row means the version
+-- does not exist.
+SELECT
+  kubernetes_versions.id,
+  COUNT(clusters.id) FILTER (WHERE clusters.deleted IS NULL)::int4 AS clusters,
+  COUNT(clusters.id) FILTER (WHERE clusters.deleted IS NOT NULL)::int4 AS deleted_clusters,
+  upgrades.pending::int4 AS pending_upgrades,
+  upgrades.finished::int4 AS finished_upgrades
+FROM catalog.kubernetes_versions
+LEFT JOIN tenant.clusters
+  ON clusters.kubernetes_version_id = kubernetes_versions.id
+  AND (cardinality(@region_ids::uuid[]) = 0 OR clusters.region_id = ANY(@region_ids::uuid[]))
+CROSS JOIN LATERAL (
+  SELECT
+    COUNT(*) FILTER (WHERE cluster_upgrades.status IN ('scheduled', 'in_progress')) AS pending,
+    COUNT(*) FILTER (WHERE cluster_upgrades.status NOT IN ('scheduled', 'in_progress')) AS finished
+  FROM tenant.cluster_upgrades
+  JOIN tenant.clusters AS upgrade_clusters ON upgrade_clusters.id = cluster_upgrades.cluster_id
+  WHERE cluster_upgrades.to_kubernetes_version_id = kubernetes_versions.id
+    AND (cardinality(@region_ids::uuid[]) = 0 OR upgrade_clusters.region_id = ANY(@region_ids::uuid[]))
+) AS upgrades
+WHERE kubernetes_versions.version = @version
+GROUP BY kubernetes_versions.id, upgrades.pending, upgrades.finished;
+
+-- name: CatalogRegionKubernetesVersionsDelete :exec
+-- Stops offering a Kubernetes version in the given regions, or in all regions
+-- when none are given.
+DELETE FROM catalog.region_kubernetes_versions
+WHERE (cardinality(@region_ids::uuid[]) = 0 OR region_kubernetes_versions.region_id = ANY(@region_ids::uuid[]))
+  AND region_kubernetes_versions.kubernetes_version_id = @kubernetes_version_id;
+
+-- name: CatalogKubernetesVersionDelete :exec
+DELETE FROM catalog.kubernetes_versions
+WHERE id = @id;
+
+-- name: CatalogMachineTypeList :many
+SELECT
+  machine_types.name,
+  machine_types.lcpu,
+  machine_types.memory,
+  machine_types.deprecated,
+  COALESCE(
+    ARRAY(
+      SELECT regions.name
+      FROM catalog.region_machine_types
+      JOIN catalog.regions
+        ON regions.id = region_machine_types.region_id
+      WHERE region_machine_types.machine_type_id = machine_types.id
+      ORDER BY regions.name
+    ),
+    '{}'
+  )::text[] AS regions,
+  (
+    SELECT COUNT(*)
+    FROM tenant.node_pools
+    JOIN catalog.region_machine_types
+      ON region_machine_types.id = node_pools.region_machine_type_id
+    WHERE region_machine_types.machine_type_id = machine_types.id
+      AND node_pools.deleted IS NULL
+  )::int4 AS node_pools
+FROM catalog.machine_types
+ORDER BY machine_types.name;
+
+-- name: CatalogMachineTypeGetByName :one
+SELECT
+  machine_types.id,
+  machine_types.lcpu,
+  machine_types.memory
+FROM catalog.machine_types
+WHERE machine_types.name = @name;
+
+-- name: CatalogMachineTypeCreate :one
+INSERT INTO catalog.machine_types (name, lcpu, memory)
+VALUES (@name, @lcpu, @memory)
+RETURNING id;
+
+-- name: CatalogRegionMachineTypesAdd :exec
+-- Offers a machine type in the given regions. Regions that already offer it
+-- are left alone.
+INSERT INTO catalog.region_machine_types (region_id, machine_type_id)
+SELECT region_id, @machine_type_id::uuid
+FROM unnest(@region_ids::uuid[]) AS region_id
+ON CONFLICT ON CONSTRAINT region_machine_types_uq DO NOTHING;
+
+-- name: CatalogMachineTypeDeprecate :execrows
+-- Sets the deprecation date of a machine type; NULL undoes a deprecation.
+UPDATE catalog.machine_types
+SET deprecated = sqlc.narg('deprecated')
+WHERE name = @name;
+
+-- name: CatalogMachineTypeUsage :one
+-- The node pools on a machine type, in the given regions or in all regions
+-- when none are given. No row means the machine type does not exist.
+SELECT
+  machine_types.id,
+  COUNT(node_pools.id) FILTER (WHERE node_pools.deleted IS NULL)::int4 AS node_pools,
+  COUNT(node_pools.id) FILTER (WHERE node_pools.deleted IS NOT NULL)::int4 AS deleted_node_pools
+FROM catalog.machine_types
+LEFT JOIN catalog.region_machine_types
+  ON region_machine_types.machine_type_id = machine_types.id
+  AND (cardinality(@region_ids::uuid[]) = 0 OR region_machine_types.region_id = ANY(@region_ids::uuid[]))
+LEFT JOIN tenant.node_pools
+  ON node_pools.region_machine_type_id = region_machine_types.id
+WHERE machine_types.name = @name
+GROUP BY machine_types.id;
+
+-- name: CatalogRegionMachineTypesDelete :exec
+-- Stops offering a machine type in the given regions, or in all regions when
+-- none are given.
+DELETE FROM catalog.region_machine_types
+WHERE (cardinality(@region_ids::uuid[]) = 0 OR region_machine_types.region_id = ANY(@region_ids::uuid[]))
+  AND region_machine_types.machine_type_id = @machine_type_id;
+
+-- name: CatalogMachineTypeDelete :exec
+DELETE FROM catalog.machine_types
+WHERE id = @id;
@@ -0,0 +1,64 @@
+-- name: OrganizationCapsGet :one
+-- The resource caps of an organization next to what it currently has. The caps
+-- are NULL when unset.
+SELECT
+  organization_limits.max_clusters,
+  organization_limits.max_projects,
+  organization_limits.max_namespaces,
+  (
+    SELECT COUNT(*)
+    FROM tenant.clusters
+    WHERE clusters.organization_id = organizations.id
+      AND clusters.deleted IS NULL
+  )::int4 AS clusters,
+  (
+    SELECT COUNT(*)
+    FROM tenant.projects
+    JOIN tenant.clusters
+      ON clusters.id = projects.cluster_id
+    WHERE clusters.organization_id = organizations.id
+      AND projects.deleted IS NULL
+  )::int4 AS projects,
+  (
+    SELECT COUNT(*)
+    FROM tenant.namespaces
+    JOIN tenant.projects
+      ON projects.id = namespaces.project_id
+    JOIN tenant.clusters
+      ON clusters.id = projects.cluster_id
+    WHERE clusters.organization_id = organizations.id
+      AND namespaces.deleted IS NULL
+  )::int4 AS namespaces
+FROM tenant.organizations
+LEFT JOIN tenant.organization_limits
+  ON organization_limits.organization_id = organizations.id
+  AND organization_limits.deleted IS NULL
+WHERE organizations.id = @organization_id;
+
+-- name: OrganizationCapsUpsert :exec
+-- Sets the resource caps of an organization and leaves its other limits alone.
+-- A NULL argument keeps the stored cap, 0 clears it.
+INSERT INTO tenant.organization_limits (
+  organization_id,
+  max_clusters,
+  max_projects,
+  max_namespaces
+) VALUES (
+  @organization_id,
+  NULLIF(sqlc.narg('max_clusters')::int4, 0),
+  NULLIF(sqlc.narg('max_projects')::int4, 0),
+  NULLIF(sqlc.narg('max_namespaces')::int4, 0)
+)
+ON CONFLICT ON CONSTRAINT organization_limits_uq_org DO UPDATE SET
+  max_clusters = CASE
+    WHEN sqlc.narg('max_clusters')::int4 IS NULL THEN organization_limits.max_clusters
+    ELSE NULLIF(sqlc.narg('max_clusters')::int4, 0)
+  END,
+  max_projects = CASE
+    WHEN sqlc.narg('max_projects')::int4 IS NULL THEN organization_limits.max_projects
+    ELSE NULLIF(sqlc.narg('max_projects')::int4, 0)
+  END,
+  max_namespaces = CASE
+    WHEN sqlc.narg('max_namespaces')::int4 IS NULL THEN organization_limits.max_namespaces
+    ELSE NULLIF(sqlc.narg('max_namespaces')::int4, 0)
+  END;
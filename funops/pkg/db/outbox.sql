@@ -0,0 +1,421 @@
+-- name: OutboxClusterList :many
+-- Cluster outbox rows in the given states, oldest first, with the cluster and
+-- organization they belong to. A pending or retrying row that a precondition
+-- held back is 'deferred'. An empty entity_types matches every entity type; a
+-- NULL organization_id matches every organization.
+WITH entries AS (
+  SELECT
+    cluster_outbox.id,
+    CASE
+      WHEN cluster_outbox.cluster_id IS NOT NULL THEN 'cluster'
+      WHEN cluster_outbox.organization_user_id IS NOT NULL THEN 'organization_user'
+      WHEN cluster_outbox.project_member_id IS NOT NULL THEN 'project_member'
+      WHEN cluster_outbox.node_pool_id IS NOT NULL THEN 'node_pool'
+      WHEN cluster_outbox.namespace_id IS NOT NULL THEN 'namespace'
+      ELSE 'access_elevation'
+    END::text AS entity_type,
+    COALESCE(
+      cluster_outbox.cluster_id,
+      cluster_outbox.organization_user_id,
+      cluster_outbox.project_member_id,
+      cluster_outbox.node_pool_id,
+      cluster_outbox.namespace_id,
+      cluster_outbox.access_elevation_id
+    )::uuid AS entity_id,
+    cluster_outbox.event,
+    CASE
+      WHEN cluster_outbox.status IN ('pending', 'retrying') AND cluster_outbox.deferrals > 0 THEN 'deferred'
+      ELSE cluster_outbox.status
+    END::text AS state,
+    cluster_outbox.retries,
+    cluster_outbox.deferrals,
+    cluster_outbox.status_info,
+    cluster_outbox.created,
+    cluster_outbox.failed,
+    clusters.id AS cluster_id,
+    COALESCE(clusters.organization_id, organizations_users.organization_id, access_elevations.organization_id) AS organization_id
+  FROM tenant.cluster_outbox
+  LEFT JOIN tenant.node_pools
+    ON node_pools.id = cluster_outbox.node_pool_id
+  LEFT JOIN tenant.namespaces
+    ON namespaces.id = cluster_outbox.namespace_id
+  LEFT JOIN tenant.project_members
+    ON project_members.id = cluster_outbox.project_member_id
+  LEFT JOIN tenant.access_elevations
+    ON access_elevations.id = cluster_outbox.access_elevation_id
+  LEFT JOIN tenant.projects
+    ON projects.id = COALESCE(namespaces.project_id, project_members.project_id, access_elevations.project_id)
+  LEFT JOIN tenant.clusters
+    ON clusters.id = COALESCE(cluster_outbox.cluster_id, node_pools.cluster_id, projects.cluster_id)
+  LEFT JOIN tenant.organizations_users
+    ON organizations_users.id = cluster_outbox.organization_user_id
+  WHERE cluster_outbox.status <> 'completed'
+)
+SELECT
+  entries.id,
+  entries.entity_type,
+  entries.entity_id,
+  entries.event,
+  entries.state,
+  entries.retries,
+  entries.deferrals,
+  entries.status_info,
+  entries.created,
+  entries.failed,
+  entries.cluster_id,
+  organizations.name AS organization_name
+FROM entries
+LEFT JOIN tenant.organizations
+  ON organizations.id = entries.organization_id
+WHERE entries.state = ANY(@states::text[])
+  AND (cardinality(@entity_types::text[]) = 0 OR entries.entity_type = ANY(@entity_types::text[]))
+  AND (sqlc.narg('organization_id')::uuid IS NULL OR entries.organization_id = sqlc.narg('organization_id')::uuid)
+ORDER BY entries.created, entries.id
+LIMIT @row_limit;
+
+-- name: OutboxClusterReplay :many
+-- Hands failed, deferred, retrying and abandoned rows back to cluster-worker
+-- with a fresh retry budget and records outbox_replayed on the cluster each
+-- row belongs to, with the error it last failed with. Returns the IDs of the
+-- rows replayed; rows in another state are left alone.
+WITH entries AS (
+  SELECT
+    cluster_outbox.id,
+    cluster_outbox.retries,
+    cluster_outbox.status_info,
+    CASE
+      WHEN cluster_outbox.cluster_id IS NOT NULL THEN 'cluster'
+      WHEN cluster_outbox.organization_user_id IS NOT NULL THEN 'organization_user'
+      WHEN cluster_outbox.project_member_id IS NOT NULL THEN 'project_member'
+      WHEN cluster_outbox.node_pool_id IS NOT NULL THEN 'node_pool'
+      WHEN cluster_outbox.namespace_id IS NOT NULL THEN 'namespace'
+      ELSE 'access_elevation'
+    END AS entity_type,
+    COALESCE(cluster_outbox.cluster_id, node_pools.cluster_id, projects.cluster_id) AS cluster_id
+  FROM tenant.cluster_outbox
+  LEFT JOIN tenant.node_pools
+    ON node_pools.id = cluster_outbox.node_pool_id
+  LEFT JOIN tenant.namespaces
+    ON namespaces.id = cluster_outbox.namespace_id
+  LEFT JOIN tenant.project_members
+    ON project_members.id = cluster_outbox.project_member_id
+  LEFT JOIN tenant.access_elevations
+    ON access_elevations.id = cluster_outbox.access_elevation_id
+  LEFT JOIN tenant.projects
+    ON projects.id = COALESCE(namespaces.project_id, project_members.project_id, access_elevations.project_id)
+  WHERE cluster_outbox.id = ANY(@ids::uuid[])
+    AND (
+      cluster_outbox.status IN ('failed', 'retrying', 'abandoned')
+      OR (cluster_outbox.status = 'pending' AND cluster_outbox.deferrals > 0)
+    )
+  FOR NO KEY UPDATE OF cluster_outbox
+),
+replayed AS (
+  UPDATE tenant.cluster_outbox
+  SET status = 'pending',
+      retries = 0,
+      deferrals = 0,
+      retry_after = NULL,
+      failed = NULL,
+      status_info = NULL
+  FROM entries
+  WHERE cluster_outbox.id = entries.id
+  RETURNING cluster_outbox.id
+),
+events AS (
+  INSERT INTO tenant.cluster_events (cluster_id, event_type, message, attempt)
+  SELECT
+    entries.cluster_id,
+    'outbox_replayed',
+    format('%s sync replayed by %s', entries.entity_type, @actor::text) || COALESCE('; last error: ' || entries.status_info, ''),
+    entries.retries
+  FROM entries
+  WHERE entries.cluster_id IS NOT NULL
+)
+SELECT replayed.id
+FROM replayed;
+
+-- name: OutboxClusterAbandon :many
+-- Marks failed, deferred and retrying rows abandoned so cluster-worker stops
+-- trying them, and records outbox_abandoned on the cluster each row belongs
+-- to. Returns the IDs of the rows abandoned; rows in another state are left
+-- alone.
+WITH entries AS (
+  SELECT
+    cluster_outbox.id,
+    cluster_outbox.retries,
+    cluster_outbox.status_info,
+    CASE
+      WHEN cluster_outbox.cluster_id IS NOT NULL THEN 'cluster'
+      WHEN cluster_outbox.organization_user_id IS NOT NULL THEN 'organization_user'
+      WHEN cluster_outbox.project_member_id IS NOT NULL THEN 'project_member'
+      WHEN cluster_outbox.node_pool_id IS NOT NULL THEN 'node_pool'
+      WHEN cluster_outbox.namespace_id IS NOT NULL THEN 'namespace'
+      ELSE 'access_elevation'
+    END AS entity_type,
+    COALESCE(cluster_outbox.cluster_id, node_pools.cluster_id, projects.cluster_id) AS cluster_id
+  FROM tenant.cluster_outbox
+  LEFT JOIN tenant.node_pools
+    ON node_pools.id = cluster_outbox.node_pool_id
+  LEFT JOIN tenant.namespaces
+    ON namespaces.id = cluster_outbox.namespace_id
+  LEFT JOIN tenant.project_members
+    ON project_members.id = cluster_outbox.project_member_id
+  LEFT JOIN tenant.access_elevations
+    ON access_elevations.id = cluster_outbox.access_elevation_id
+  LEFT JOIN tenant.projects
+    ON projects.id = COALESCE(namespaces.project_id, project_members.project_id, access_elevations.project_id)
+  WHERE cluster_outbox.id = ANY(@ids::uuid[])
+    AND (
+      cluster_outbox.status IN ('failed', 'retrying')
+      OR (cluster_outbox.status = 'pending' AND cluster_outbox.deferrals > 0)
+    )
+  FOR NO KEY UPDATE OF cluster_outbox
+),
+abandoned AS (
+  UPDATE tenant.cluster_outbox
+  SET status = 'abandoned',
+      retry_after = NULL
+  FROM entries
+  WHERE cluster_outbox.id = entries.id
+  RETURNING cluster_outbox.id
+),
+events AS (
+  INSERT INTO tenant.cluster_events (cluster_id, event_type, message, attempt)
+  SELECT
+    entries.cluster_id,
+    'outbox_abandoned',
+    format('%s sync abandoned by %s', entries.entity_type, @actor::text) || COALESCE('; last error: ' || entries.status_info, ''),
+    entries.retries
+  FROM entries
+  WHERE entries.cluster_id IS NOT NULL
+)
+SELECT abandoned.id
+FROM abandoned;
+
+-- name: OutboxAuthzList :many
+-- authz.outbox rows in the given states, oldest first, with the cluster and
+-- organization they belong to. authz-worker does not defer rows. An empty
+-- entity_types matches every entity type; a NULL organization_id matches every
+-- organization.
+WITH entries AS (
+  SELECT
+    outbox.id,
+    CASE
+      WHEN outbox.project_id IS NOT NULL THEN 'project'
+      WHEN outbox.project_member_id IS NOT NULL THEN 'project_member'
+      WHEN outbox.cluster_id IS NOT NULL THEN 'cluster'
+      WHEN outbox.node_pool_id IS NOT NULL THEN 'node_pool'
+      WHEN outbox.namespace_id IS NOT NULL THEN 'namespace'
+      WHEN outbox.api_key_id IS NOT NULL THEN 'api_key'
+      WHEN outbox.organization_user_id IS NOT NULL THEN 'organization_user'
+      WHEN outbox.plugin_id IS NOT NULL THEN 'plugin'
+      WHEN outbox.namespace_role_binding_id IS NOT NULL THEN 'namespace_role_binding'
+      WHEN outbox.team_id IS NOT NULL THEN 'team'
+      WHEN outbox.team_member_id IS NOT NULL THEN 'team_member'
+      WHEN outbox.project_team_id IS NOT NULL THEN 'project_team'
+      ELSE 'access_elevation'
+    END::text AS entity_type,
+    COALESCE(
+      outbox.project_id,
+      outbox.project_member_id,
+      outbox.cluster_id,
+      outbox.node_pool_id,
+      outbox.namespace_id,
+      outbox.api_key_id,
+      outbox.organization_user_id,
+      outbox.plugin_id,
+      outbox.namespace_role_binding_id,
+      outbox.team_id,
+      outbox.team_member_id,
+      outbox.project_team_id,
+      outbox.access_elevation_id
+    )::uuid AS entity_id,
+    outbox.status::text AS state,
+    outbox.retries,
+    outbox.status_info,
+    outbox.created,
+    outbox.failed,
+    clusters.id AS cluster_id,
+    COALESCE(
+      clusters.organization_id,
+      api_keys.organization_id,
+      organizations_users.organization_id,
+      plugins.organization_id,
+      teams.organization_id,
+      access_elevations.organization_id
+    ) AS organization_id
+  FROM authz.outbox
+  LEFT JOIN tenant.node_pools
+    ON node_pools.id = outbox.node_pool_id
+  LEFT JOIN tenant.namespaces
+    ON namespaces.id = outbox.namespace_id
+  LEFT JOIN tenant.project_members
+    ON project_members.id = outbox.project_member_id
+  LEFT JOIN tenant.namespace_role_bindings
+    ON namespace_role_bindings.id = outbox.namespace_role_binding_id
+  LEFT JOIN tenant.project_teams
+    ON project_teams.id = outbox.project_team_id
+  LEFT JOIN tenant.access_elevations
+    ON access_elevations.id = outbox.access_elevation_id
+  LEFT JOIN tenant.projects
+    ON projects.id = COALESCE(
+      outbox.project_id,
+      namespaces.project_id,
+      project_members.project_id,
+      namespace_role_bindings.project_id,
+      project_teams.project_id,
+      access_elevations.project_id
+    )
+  LEFT JOIN tenant.clusters
+    ON clusters.id = COALESCE(outbox.cluster_id, node_pools.cluster_id, projects.cluster_id)
+  LEFT JOIN authn.api_keys
+    ON api_keys.id = outbox.api_key_id
+  LEFT JOIN tenant.organizations_users
+    ON organizations_users.id = outbox.organization_user_id
+  LEFT JOIN appstore.plugins
+    ON plugins.id = outbox.plugin_id
+  LEFT JOIN tenant.team_members
+    ON team_members.id = outbox.team_member_id
+  LEFT JOIN tenant.teams
+    ON teams.id = COALESCE(outbox.team_id, team_members.team_id)
+  WHERE outbox.status <> 'completed'
+)
+SELECT
+  entries.id,
+  entries.entity_type,
+  entries.entity_id,
+  entries.state,
+  entries.retries,
+  entries.status_info,
+  entries.created,
+  entries.failed,
+  entries.cluster_id,
+  organizations.name AS organization_name
+FROM entries
+LEFT JOIN tenant.organizations
+  ON organizations.id = entries.organization_id
+WHERE entries.state = ANY(@states::text[])
+  AND (cardinality(@entity_types::text[]) = 0 OR entries.entity_type = ANY(@entity_types::text[]))
+  AND (sqlc.narg('organization_id')::uuid IS NULL OR entries.organization_id = sqlc.narg('organization_id')::uuid)
+ORDER BY entries.created, entries.id
+LIMIT @row_limit;
+
+-- name: OutboxAuthzReplay :many
+-- Hands failed, retrying and abandoned rows back to authz-worker with a fresh
+-- retry budget and records outbox_replayed on the cluster each row belongs to,
+-- if any. Returns the IDs of the rows replayed; rows in another state are left
+-- alone.
+WITH entries AS (
+  SELECT
+    outbox.id,
+    outbox.retries,
+    outbox.status_info,
+    COALESCE(outbox.cluster_id, node_pools.cluster_id, projects.cluster_id) AS cluster_id
+  FROM authz.outbox
+  LEFT JOIN tenant.node_pools
+    ON node_pools.id = outbox.node_pool_id
+  LEFT JOIN tenant.namespaces
+    ON namespaces.id = outbox.namespace_id
+  LEFT JOIN tenant.project_members
+    ON project_members.id = outbox.project_member_id
+  LEFT JOIN tenant.namespace_role_bindings
+    ON namespace_role_bindings.id = outbox.namespace_role_binding_id
+  LEFT JOIN tenant.project_teams
+    ON project_teams.id = outbox.project_team_id
+  LEFT JOIN tenant.access_elevations
+    ON access_elevations.id = outbox.access_elevation_id
+  LEFT JOIN tenant.projects
+    ON projects.id = COALESCE(
+      outbox.project_id,
+      namespaces.project_id,
+      project_members.project_id,
+      namespace_role_bindings.project_id,
+      project_teams.project_id,
+      access_elevations.project_id
+    )
+  WHERE outbox.id = ANY(@ids::uuid[])
+    AND outbox.status IN ('failed', 'retrying', 'abandoned')
+  FOR NO KEY UPDATE OF outbox
+),
+replayed AS (
+  UPDATE authz.outbox
+  SET status = 'pending',
+      retries = 0,
+      retry_after = NULL,
+      failed = NULL,
+      status_info = NULL
+  FROM entries
+  WHERE outbox.id = entries.id
+  RETURNING outbox.id
+),
+events AS (
+  INSERT INTO tenant.cluster_events (cluster_id, event_type, message, attempt)
+  SELECT
+    entries.cluster_id,
+    'outbox_replayed',
+    format('authorization sync replayed by %s', @actor::text) || COALESCE('; last error: ' || entries.status_info, ''),
+    entries.retries
+  FROM entries
+  WHERE entries.cluster_id IS NOT NULL
+)
+SELECT replayed.id
+FROM replayed;
+
+-- name: OutboxAuthzAbandon :many
+-- Marks failed and retrying rows abandoned so authz-worker stops trying them,
+-- also across restarts, and records outbox_abandoned on the cluster each row
+-- belongs to, if any. Returns the IDs of the rows abandoned; rows in another
+-- state are left alone.
+WITH entries AS (
+  SELECT
+    outbox.id,
+    outbox.retries,
+    outbox.status_info,
+    COALESCE(outbox.cluster_id, node_pools.cluster_id, projects.cluster_id) AS cluster_id
+  FROM authz.outbox
+  LEFT JOIN tenant.node_pools
+    ON node_pools.id = outbox.node_pool_id
+  LEFT JOIN tenant.namespaces
+    ON namespaces.id = outbox.namespace_id
+  LEFT JOIN tenant.project_members
+    ON project_members.id = outbox.project_member_id
+  LEFT JOIN tenant.namespace_role_bindings
+    ON namespace_role_bindings.id = outbox.namespace_role_binding_id
+  LEFT JOIN tenant.project_teams
+    ON project_teams.id = outbox.project_team_id
+  LEFT JOIN tenant.access_elevations
+    ON access_elevations.id = outbox.access_elevation_id
+  LEFT JOIN tenant.projects
+    ON projects.id = COALESCE(
+      outbox.project_id,
+      namespaces.project_id,
+      project_members.project_id,
+      namespace_role_bindings.project_id,
+      project_teams.project_id,
+      access_elevations.project_id
+    )
+  WHERE outbox.id = ANY(@ids::uuid[])
+    AND outbox.status IN ('failed', 'retrying')
+  FOR NO KEY UPDATE OF outbox
+),
+abandoned AS (
+  UPDATE authz.outbox
+  SET status = 'abandoned',
+      retry_after = NULL
+  FROM entries
+  WHERE outbox.id = entries.id
+  RETURNING outbox.id
+),
+events AS (
+  INSERT INTO tenant.cluster_events (cluster_id, event_type, message, attempt)
+  SELECT
+    entries.cluster_id,
+    'outbox_abandoned',
+    format('authorization sync abandoned by %s', @actor::text) || COALESCE('; last error: ' || entries.status_info, ''),
+    entries.retries
+  FROM entries
+  WHERE entries.cluster_id IS NOT NULL
+)
+SELECT abandoned.id
+FROM abandoned;
@@ -16,14 +16,18 @@ import (
 	"golang.org/x/net/http2"
 	"golang.org/x/net/http2/h2c"
 
+	"github.com/fundament-oss/fundament/common/audit"
+	"github.com/fundament-oss/fundament/common/auth"
 	"github.com/fundament-oss/fundament/common/authz"
+	"github.com/fundament-oss/fundament/common/psqldb"
 	"github.com/fundament-oss/fundament/kube-api-proxy/pkg/gardener"
 	"github.com/fundament-oss/fundament/kube-api-proxy/pkg/proxy"
 )
 
 type config struct {
 	OpenFGA                authz.Config
-	JWTSecret              string     `env:"JWT_SECRET,required,notEmpty"`
+	JWTSecret              string     `env:"JWT_SECRET"`
+	JWKSURL                string     `env:"JWKS_URL"` // authn-api key set; with JWT_SECRET also set, both are accepted
 	ListenAddr             string     `env:"LISTEN_ADDR" envDefault:":8081"`
 	LogLevel               slog.Level `env:"LOG_LEVEL" envDefault:"info"`
 	CORSAllowedOrigins     []string   `env:"CORS_ALLOWED_ORIGINS"`
@@ -37,6 +41,10 @@ type config struct {
 	// request to that cluster instead of serving MockClient's canned
 	// responses. Ignored in real mode.
 	PluginSandboxKubeconfig string `env:"PLUGIN_SANDBOX_KUBECONFIG"`
+
+	// AuditDatabaseURL connects as fun_kube_api_proxy, which may only append
+	// to the audit log. When unset, requests are not audited.
+	AuditDatabaseURL string `env:"AUDIT_DATABASE_URL"`
 }
 
 func main() {
@@ -90,13 +98,31 @@ func run() error {
 		}
 	}
 
+	var auditRecorder audit.Recorder
+	if cfg.AuditDatabaseURL != "" {
+		auditDB, err := psqldb.New(context.Background(), logger, psqldb.Config{URL: cfg.AuditDatabaseURL})
+		if err != nil {
+			return fmt.Errorf("failed to connect to audit database: %w", err)
+		}
+		defer auditDB.Close()
+		auditRecorder = audit.NewStore(auditDB.Pool, logger)
+	} else {
+		logger.Warn("AUDIT_DATABASE_URL not set, kube API requests are not audited")
+	}
+
+	jwtKeys, err := auth.NewKeys(cfg.JWKSURL, []byte(cfg.JWTSecret), logger)
+	if err != nil {
+		return fmt.Errorf("failed to configure token verification: %w", err)
+	}
+
 	server, err := proxy.New(logger, &proxy.Config{
-		JWTSecret:               []byte(cfg.JWTSecret),
+		JWTKeys:                 jwtKeys,
 		CORSAllowedOrigins:      cfg.CORSAllowedOrigins,
 		Mode:                    cfg.KubeProxyMode,
 		GardenerClient:          gardenerClient,
 		MockPluginTemplatesDir:  cfg.MockPluginTemplatesDir,
 		PluginSandboxKubeconfig: cfg.PluginSandboxKubeconfig,
+		AuditRecorder:           auditRecorder,
 	}, authzClient)
 	if err != nil {
 		return fmt.Errorf("failed to create proxy server: %w", err)
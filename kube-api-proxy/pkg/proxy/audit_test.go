@@ -0,0 +1,162 @@
+package proxy
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fundament-oss/fundament/common/audit"
+	"github.com/fundament-oss/fundament/common/dbconst"
+	"github.com/fundament-oss/fundament/kube-api-proxy/pkg/kubereq"
+)
+
+type fakeRecorder struct {
+	mu     sync.Mutex
+	events []*audit.Event
+}
+
+func (f *fakeRecorder) Record(_ context.Context, e *audit.Event) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.events = append(f.events, e)
+	return nil
+}
+
+func TestAuditable(t *testing.T) {
+	cases := []struct {
+		name   string
+		verb   string
+		status int
+		want   bool
+	}{
+		{"successful get", "get", http.StatusOK, false},
+		{"successful watch", "watch", http.StatusOK, false},
+		{"denied get", "get", http.StatusForbidden, true},
+		{"unauthenticated list", "list", http.StatusUnauthorized, true},
+		{"successful create", "create", http.StatusCreated, true},
+		{"failed delete", "delete", http.StatusNotFound, true},
+		{"patch", "patch", http.StatusOK, true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, auditable(&kubereq.Attributes{Verb: tc.verb}, tc.status))
+		})
+	}
+
+	assert.False(t, auditable(nil, http.StatusOK))
+	assert.True(t, auditable(nil, http.StatusForbidden))
+}
+
+func TestKubeOutcome(t *testing.T) {
+	assert.Equal(t, dbconst.AuditEventOutcome_Succeeded, kubeOutcome(http.StatusOK))
+	assert.Equal(t, dbconst.AuditEventOutcome_Succeeded, kubeOutcome(http.StatusCreated))
+	assert.Equal(t, dbconst.AuditEventOutcome_Denied, kubeOutcome(http.StatusUnauthorized))
+	assert.Equal(t, dbconst.AuditEventOutcome_Denied, kubeOutcome(http.StatusForbidden))
+	assert.Equal(t, dbconst.AuditEventOutcome_Failed, kubeOutcome(http.StatusConflict))
+	assert.Equal(t, dbconst.AuditEventOutcome_Failed, kubeOutcome(http.StatusBadGateway))
+}
+
+func TestRecordKubeRequest(t *testing.T) {
+	rec := &fakeRecorder{}
+	userID, clusterID := uuid.New(), uuid.New()
+
+	r := httptest.NewRequestWithContext(context.Background(), "DELETE", "/apis/apps/v1/namespaces/team-a/deployments/web", http.NoBody)
+	attrs, err := kubereq.Parse(r)
+	require.NoError(t, err)
+
+	recordKubeRequest(r.Context(), discardLogger(), rec, r, &kubeAuditEntry{
+		userID:    userID,
+		clusterID: clusterID,
+		attrs:     &attrs,
+		status:    http.StatusOK,
+	})
+
+	require.Len(t, rec.events, 1)
+	e := rec.events[0]
+	assert.Equal(t, dbconst.AuditEventSource_KubeApiProxy, e.Source)
+	assert.Equal(t, userID, e.ActorUserID)
+	assert.Equal(t, clusterID, e.ClusterID)
+	assert.Equal(t, uuid.Nil, e.OrganizationID, "filled in from the cluster by the database")
+	assert.Equal(t, "kube:delete", e.Action)
+	assert.Equal(t, "deployments.apps", e.ResourceType)
+	assert.Equal(t, "team-a/web", e.ResourceID)
+	assert.Equal(t, dbconst.AuditEventOutcome_Succeeded, e.Outcome)
+	assert.Empty(t, e.ErrorCode)
+
+	var details map[string]string
+	require.NoError(t, json.Unmarshal(e.Details, &details))
+	assert.Equal(t, "team-a", details["namespace"])
+	assert.Equal(t, "/apis/apps/v1/namespaces/team-a/deployments/web", details["path"])
+}
+
+func TestRecordKubeRequest_SkipsReads(t *testing.T) {
+	rec := &fakeRecorder{}
+	r := httptest.NewRequestWithContext(context.Background(), "GET", "/api/v1/pods", http.NoBody)
+	attrs, err := kubereq.Parse(r)
+	require.NoError(t, err)
+
+	recordKubeRequest(r.Context(), discardLogger(), rec, r, &kubeAuditEntry{attrs: &attrs, status: http.StatusOK})
+	assert.Empty(t, rec.events)
+}
+
+func TestPluginGateway_RecordsDenialWithPluginDetails(t *testing.T) {
+	secret := []byte("s")
+	clusterID := uuid.New()
+	userID := uuid.New()
+	g := newPluginGateway(t, secret, false, true)
+	rec := &fakeRecorder{}
+	g.recorder = rec
+	tok := mintPluginToken(t, secret, userID.String(), clusterID.String())
+
+	r := httptest.NewRequestWithContext(context.Background(), "GET", "/api/v1/namespaces/team-a/secrets", http.NoBody)
+	r.Header.Set("Authorization", "Bearer "+tok)
+	w := httptest.NewRecorder()
+	g.serve(w, r, clusterID.String())
+	require.Equal(t, http.StatusForbidden, w.Code)
+
+	require.Len(t, rec.events, 1)
+	e := rec.events[0]
+	assert.Equal(t, "kube:list", e.Action)
+	assert.Equal(t, userID, e.ActorUserID)
+	assert.Equal(t, clusterID, e.ClusterID)
+	assert.Equal(t, dbconst.AuditEventOutcome_Denied, e.Outcome)
+	assert.Equal(t, "403", e.ErrorCode)
+
+	var details map[string]string
+	require.NoError(t, json.Unmarshal(e.Details, &details))
+	assert.Equal(t, "cert-manager", details["plugin_name"])
+	assert.Equal(t, "v1.17.2", details["plugin_version"])
+	assert.NotEmpty(t, details["installation_id"])
+}
+
+func TestPluginGateway_RecordsForwardedMutation(t *testing.T) {
+	secret := []byte("s")
+	clusterID := uuid.New()
+	g := newPluginGateway(t, secret, true, true)
+	rec := &fakeRecorder{}
+	g.recorder = rec
+	tok := mintPluginToken(t, secret, uuid.NewString(), clusterID.String())
+
+	get := httptest.NewRequestWithContext(context.Background(), "GET", "/apis/cert-manager.io/v1/namespaces/team-a/certificates", http.NoBody)
+	get.Header.Set("Authorization", "Bearer "+tok)
+	g.serve(httptest.NewRecorder(), get, clusterID.String())
+	assert.Empty(t, rec.events, "successful reads are not audited")
+
+	post := httptest.NewRequestWithContext(context.Background(), "POST", "/apis/cert-manager.io/v1/namespaces/team-a/certificates", http.NoBody)
+	post.Header.Set("Authorization", "Bearer "+tok)
+	w := httptest.NewRecorder()
+	g.serve(w, post, clusterID.String())
+	require.Equal(t, http.StatusOK, w.Code, "body: %s", w.Body.String())
+
+	require.Len(t, rec.events, 1)
+	assert.Equal(t, "kube:create", rec.events[0].Action)
+	assert.Equal(t, "certificates.cert-manager.io", rec.events[0].ResourceType)
+	assert.Equal(t, dbconst.AuditEventOutcome_Succeeded, rec.events[0].Outcome)
+}
@@ -4,7 +4,11 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
 
+	"github.com/google/uuid"
+
+	"github.com/fundament-oss/fundament/common/auth"
 	"github.com/fundament-oss/fundament/common/authz"
 )
 
@@ -40,3 +44,19 @@ func (s *Server) checkPermission(ctx context.Context, action authz.Action, resou
 }
 
 var errPermissionDenied = errors.New("permission denied")
+
+// scopeAllowsClusterRequest reports whether a token exchanged for a scoped API
+// key may make the request against the cluster's Kubernetes API. Reads need
+// can_view in scope, anything else can_edit. A key scoped to projects only
+// does not reach the cluster.
+func scopeAllowsClusterRequest(scope *auth.Scope, clusterID uuid.UUID, method string) bool {
+	if !scope.IncludesCluster(clusterID) {
+		return false
+	}
+	action := authz.ActionCanEdit
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		action = authz.ActionCanView
+	}
+	return scope.AllowsAction(string(action))
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/fundament-oss/fundament/common/authz"
 	"github.com/fundament-oss/fundament/kube-api-proxy/pkg/gardener"
 	"github.com/fundament-oss/fundament/kube-api-proxy/pkg/kube"
+	"github.com/fundament-oss/fundament/kube-api-proxy/pkg/kubereq"
 )
 
 // allowedPathRoots are the Kubernetes API path roots the proxy forwards,
@@ -90,11 +91,27 @@ func (s *Server) handleUserClusterProxy(w http.ResponseWriter, r *http.Request,
 
 	ctx := WithUserID(r.Context(), claims.UserID())
 
+	// Paths outside the resource grammar (/version, /openapi) parse to nil
+	// and are only audited when denied.
+	entry := &kubeAuditEntry{userID: claims.UserID(), clusterID: clusterID}
+	if attrs, err := kubereq.Parse(r); err == nil {
+		entry.attrs = &attrs
+	}
+
 	// --- Authorization ---
 
+	if claims.Scope != nil && !scopeAllowsClusterRequest(claims.Scope, clusterID, r.Method) {
+		http.Error(w, "permission denied", http.StatusForbidden)
+		entry.status = http.StatusForbidden
+		recordKubeRequest(ctx, s.logger, s.auditRecorder, r, entry)
+		return
+	}
+
 	if err := s.checkPermission(ctx, authz.CanView(), authz.Cluster(clusterID)); err != nil {
 		if errors.Is(err, errPermissionDenied) {
 			http.Error(w, "permission denied", http.StatusForbidden)
+			entry.status = http.StatusForbidden
+			recordKubeRequest(ctx, s.logger, s.auditRecorder, r, entry)
 			return
 		}
 		s.logger.ErrorContext(ctx, "authorization check failed", "error", err)
@@ -124,7 +141,11 @@ func (s *Server) handleUserClusterProxy(w http.ResponseWriter, r *http.Request,
 	ctx = context.WithValue(ctx, kube.ClusterIDContextKey{}, clusterID.String())
 	r = r.WithContext(ctx)
 
-	s.kubeHandler.ServeHTTP(w, r)
+	rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+	s.kubeHandler.ServeHTTP(rec, r)
+
+	entry.status = rec.status
+	recordKubeRequest(ctx, s.logger, s.auditRecorder, r, entry)
 }
 
 // peekTokenType returns the audience-derived token type of the request's
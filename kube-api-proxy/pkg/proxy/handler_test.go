@@ -98,3 +98,26 @@ func TestIsAllowedPath(t *testing.T) {
 		})
 	}
 }
+
+func TestScopeAllowsClusterRequest(t *testing.T) {
+	clusterID := uuid.New()
+	cases := []struct {
+		name   string
+		scope  auth.Scope
+		method string
+		want   bool
+	}{
+		{"read-only get", auth.Scope{ReadOnly: true}, http.MethodGet, true},
+		{"read-only patch", auth.Scope{ReadOnly: true}, http.MethodPatch, false},
+		{"cluster in scope", auth.Scope{ClusterIDs: []uuid.UUID{clusterID}}, http.MethodPost, true},
+		{"other cluster", auth.Scope{ClusterIDs: []uuid.UUID{uuid.New()}}, http.MethodGet, false},
+		{"projects only", auth.Scope{ProjectIDs: []uuid.UUID{uuid.New()}}, http.MethodGet, false},
+		{"view action delete", auth.Scope{Actions: []string{"can_view"}}, http.MethodDelete, false},
+		{"edit action delete", auth.Scope{Actions: []string{"can_edit"}}, http.MethodDelete, true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, scopeAllowsClusterRequest(&tc.scope, clusterID, tc.method))
+		})
+	}
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/google/uuid"
 
+	"github.com/fundament-oss/fundament/common/audit"
 	"github.com/fundament-oss/fundament/common/auth"
 	"github.com/fundament-oss/fundament/kube-api-proxy/pkg/kube"
 	"github.com/fundament-oss/fundament/kube-api-proxy/pkg/kubereq"
@@ -22,11 +23,12 @@ type ClusterViewChecker func(ctx context.Context, userID, clusterID uuid.UUID) (
 // pluginGateway implements the PluginToken path of the kube-api-proxy gateway.
 type pluginGateway struct {
 	logger      *slog.Logger
-	jwtSecret   []byte
+	jwtKeys     auth.Keys
 	userSAR     useraccess.Checker
 	pluginSA    pluginsa.Resolver
 	canView     ClusterViewChecker
 	kubeHandler http.Handler
+	recorder    audit.Recorder
 
 	// lastForwarded is a test hook; nil in production.
 	lastForwarded func() *http.Request
@@ -35,7 +37,7 @@ type pluginGateway struct {
 // serve runs the PluginToken gates in order and forwards on success.
 func (g *pluginGateway) serve(w http.ResponseWriter, r *http.Request, pathClusterID string) {
 	bearer := bearerToken(r)
-	claims, err := auth.ParsePluginToken(bearer, g.jwtSecret)
+	claims, err := auth.ParsePluginToken(bearer, g.jwtKeys)
 	if err != nil {
 		g.logger.Debug("plugin token invalid", "err", err)
 		http.Error(w, "unauthorized", http.StatusUnauthorized)
@@ -54,6 +56,7 @@ func (g *pluginGateway) serve(w http.ResponseWriter, r *http.Request, pathCluste
 	claimCluster, err := uuid.Parse(claims.ClusterID)
 	if err != nil || claimCluster != clusterUUID {
 		g.audit(claims, nil, "", "denied:cluster-mismatch")
+		g.record(r, claims, clusterUUID, nil, http.StatusForbidden)
 		http.Error(w, "cluster_id mismatch", http.StatusForbidden)
 		return
 	}
@@ -75,6 +78,7 @@ func (g *pluginGateway) serve(w http.ResponseWriter, r *http.Request, pathCluste
 	}
 	if !ok {
 		g.audit(claims, nil, "", "denied:can-view")
+		g.record(r, claims, clusterUUID, nil, http.StatusForbidden)
 		http.Error(w, "permission denied", http.StatusForbidden)
 		return
 	}
@@ -97,6 +101,7 @@ func (g *pluginGateway) serve(w http.ResponseWriter, r *http.Request, pathCluste
 	}
 	if !allowed {
 		g.audit(claims, &attrs, "", "denied:user-sar")
+		g.record(r, claims, clusterUUID, &attrs, http.StatusForbidden)
 		http.Error(w, "forbidden", http.StatusForbidden)
 		return
 	}
@@ -117,7 +122,27 @@ func (g *pluginGateway) serve(w http.ResponseWriter, r *http.Request, pathCluste
 	ctx = WithUserID(ctx, userID)
 	// The multi-cluster proxy resolves the target apiserver from this key.
 	ctx = context.WithValue(ctx, kube.ClusterIDContextKey{}, clusterUUID.String())
-	g.kubeHandler.ServeHTTP(w, r.WithContext(ctx))
+	rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+	g.kubeHandler.ServeHTTP(rec, r.WithContext(ctx))
+	g.record(r, claims, clusterUUID, &attrs, rec.status)
+}
+
+// record adds a plugin request to the audit log, attributed to the user the
+// plugin acts for.
+func (g *pluginGateway) record(r *http.Request, c *auth.PluginClaims, clusterID uuid.UUID, a *kubereq.Attributes, status int) {
+	userID, _ := uuid.Parse(c.Subject)
+	recordKubeRequest(r.Context(), g.logger, g.recorder, r, &kubeAuditEntry{
+		userID:    userID,
+		clusterID: clusterID,
+		attrs:     a,
+		status:    status,
+		plugin: map[string]string{
+			"plugin_name":     c.PluginName,
+			"plugin_version":  c.PluginVersion,
+			"installation_id": c.InstallationID,
+			"definition_hash": c.DefinitionHash,
+		},
+	})
 }
 
 func bearerToken(r *http.Request) string {
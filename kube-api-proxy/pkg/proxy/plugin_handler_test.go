@@ -75,7 +75,7 @@ func newPluginGateway(t *testing.T, secret []byte, sarAllow, canView bool) *plug
 	})
 	g := &pluginGateway{
 		logger:      discardLogger(),
-		jwtSecret:   secret,
+		jwtKeys:     auth.SharedSecret(secret),
 		userSAR:     stubUserSAR{allow: sarAllow},
 		pluginSA:    stubPluginSA{},
 		canView:     func(ctx context.Context, userID, clusterID uuid.UUID) (bool, error) { return canView, nil },
@@ -197,7 +197,7 @@ func TestPluginGateway_AuditsOnErrorPaths(t *testing.T) {
 		var buf bytes.Buffer
 		return &pluginGateway{
 			logger:      slog.New(slog.NewJSONHandler(&buf, nil)),
-			jwtSecret:   secret,
+			jwtKeys:     auth.SharedSecret(secret),
 			userSAR:     userSAR,
 			pluginSA:    pluginSA,
 			canView:     canView,
@@ -9,6 +9,7 @@ import (
 
 	"github.com/rs/cors"
 
+	"github.com/fundament-oss/fundament/common/audit"
 	"github.com/fundament-oss/fundament/common/auth"
 	"github.com/fundament-oss/fundament/common/authz"
 	"github.com/fundament-oss/fundament/kube-api-proxy/pkg/gardener"
@@ -19,7 +20,7 @@ import (
 )
 
 type Config struct {
-	JWTSecret          []byte
+	JWTKeys            auth.Keys
 	CORSAllowedOrigins []string
 	Mode               string // "mock" (default) or "real"
 	GardenerClient     *gardener.Client
@@ -31,6 +32,9 @@ type Config struct {
 	// a proxy that forwards every request to a locally-running plugin sandbox
 	// cluster identified by the kubeconfig at this path. Ignored otherwise.
 	PluginSandboxKubeconfig string
+	// AuditRecorder receives mutating and denied cluster requests. Nil leaves
+	// the audit trail to the structured logs.
+	AuditRecorder audit.Recorder
 }
 
 type Server struct {
@@ -41,6 +45,7 @@ type Server struct {
 	kubeHandler   http.Handler
 	handler       http.Handler
 	pluginGateway *pluginGateway
+	auditRecorder audit.Recorder
 	// serveUnauthedMockAssets enables the unauthenticated plugin-console-asset
 	// branch in handleClusterProxy. Only true for the pure-mock in-memory file
 	// server (local dev), never for the sandbox/real credentialed proxies — so
@@ -81,10 +86,11 @@ func New(logger *slog.Logger, cfg *Config, authzClient *authz.Client) (*Server,
 
 	s := &Server{
 		logger:                  logger,
-		authValidator:           auth.NewValidatorForAudience(cfg.JWTSecret, auth.ConsoleAuthCookieName, auth.ConsoleIssuer, auth.TokenTypeUser, logger),
+		authValidator:           auth.NewValidatorForAudience(cfg.JWTKeys, auth.ConsoleAuthCookieName, auth.ConsoleIssuer, auth.TokenTypeUser, logger),
 		authz:                   authzClient,
 		tokenCache:              tokenCache,
 		kubeHandler:             kubeHandler,
+		auditRecorder:           cfg.AuditRecorder,
 		serveUnauthedMockAssets: serveUnauthedMockAssets,
 	}
 
@@ -100,11 +106,12 @@ func New(logger *slog.Logger, cfg *Config, authzClient *authz.Client) (*Server,
 
 	s.pluginGateway = &pluginGateway{
 		logger:      logger,
-		jwtSecret:   cfg.JWTSecret,
+		jwtKeys:     cfg.JWTKeys,
 		userSAR:     userSAR,
 		pluginSA:    pluginSA,
 		canView:     authzClient.CanViewCluster,
 		kubeHandler: kubeHandler,
+		recorder:    cfg.AuditRecorder,
 	}
 
 	mux := http.NewServeMux()
@@ -161,6 +168,12 @@ func (r *statusRecorder) WriteHeader(code int) {
 	r.ResponseWriter.WriteHeader(code)
 }
 
+// Unwrap lets http.ResponseController reach the underlying writer, so
+// flushing (watches) and hijacking (exec, port-forward) keep working.
+func (r *statusRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
+
 func newUserAccessChecker(cfg *Config, logger *slog.Logger) (useraccess.Checker, error) {
 	if cfg.Mode == "mock" {
 		if cfg.PluginSandboxKubeconfig == "" {
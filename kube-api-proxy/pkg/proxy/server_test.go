@@ -38,7 +38,7 @@ func newMockServer(t *testing.T, cfg *proxy.Config) *httptest.Server {
 // are the CI-runnable interface equivalents of the removed real-Gardener
 // negative scenarios.
 func TestClusterProxy_RequestGating(t *testing.T) {
-	ts := newMockServer(t, &proxy.Config{JWTSecret: []byte("test-secret")})
+	ts := newMockServer(t, &proxy.Config{JWTKeys: auth.SharedSecret("test-secret")})
 	validID := uuid.NewString()
 
 	tests := []struct {
@@ -74,7 +74,7 @@ func TestClusterProxy_ConsoleAssetPublicAndCORS(t *testing.T) {
 	require.NoError(t, os.WriteFile(filepath.Join(assetDir, "_shared.js"), []byte("export const x = 1;"), 0o600))
 
 	ts := newMockServer(t, &proxy.Config{
-		JWTSecret:              []byte("test-secret"),
+		JWTKeys:                auth.SharedSecret("test-secret"),
 		MockPluginTemplatesDir: dir,
 	})
 
@@ -107,8 +107,8 @@ func TestClusterProxy_RejectsPluginToken(t *testing.T) {
 	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
 
 	srv, err := proxy.New(logger, &proxy.Config{
-		JWTSecret: secret,
-		Mode:      "mock",
+		JWTKeys: auth.SharedSecret(secret),
+		Mode:    "mock",
 	}, nil)
 	require.NoError(t, err)
 
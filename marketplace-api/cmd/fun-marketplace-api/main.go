@@ -0,0 +1,149 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"log/slog"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/caarlos0/env/v11"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+
+	"github.com/fundament-oss/fundament/common/auth"
+	"github.com/fundament-oss/fundament/common/authz"
+	"github.com/fundament-oss/fundament/common/circuitbreaker"
+	"github.com/fundament-oss/fundament/common/dbversion"
+	"github.com/fundament-oss/fundament/common/psqldb"
+	dbgen "github.com/fundament-oss/fundament/marketplace-api/pkg/db/gen"
+	"github.com/fundament-oss/fundament/marketplace-api/pkg/marketplace"
+)
+
+type config struct {
+	Database                   psqldb.Config
+	OpenFGA                    authz.Config
+	JWTSecret                  string        `env:"JWT_SECRET"`
+	JWKSURL                    string        `env:"JWKS_URL"` // authn-api key set; with JWT_SECRET also set, both are accepted
+	ListenAddr                 string        `env:"LISTEN_ADDR" envDefault:":8080"`
+	LogLevel                   slog.Level    `env:"LOG_LEVEL" envDefault:"info"`
+	CORSAllowedOrigins         []string      `env:"CORS_ALLOWED_ORIGINS"`
+	CircuitBreakerThreshold    time.Duration `env:"CIRCUIT_BREAKER_THRESHOLD" envDefault:"5s"`
+	CircuitBreakerPollInterval time.Duration `env:"CIRCUIT_BREAKER_POLL_INTERVAL" envDefault:"2s"`
+	DeploymentVersion          string        `env:"DEPLOYMENT_VERSION" envDefault:"unknown"`
+}
+
+func main() {
+	if err := run(); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func run() error {
+	var cfg config
+	if err := env.Parse(&cfg); err != nil {
+		return fmt.Errorf("env parse: %w", err)
+	}
+
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
+		Level: cfg.LogLevel,
+	}))
+	slog.SetDefault(logger)
+
+	logger.Info("starting marketplace-api",
+		"listen_addr", cfg.ListenAddr,
+		"log_level", cfg.LogLevel.String(),
+	)
+
+	ctx := context.Background()
+
+	db, err := marketplace.NewDB(ctx, logger, cfg.Database)
+	if err != nil {
+		return fmt.Errorf("failed to setup to database: %w", err)
+	}
+
+	defer db.Close()
+
+	dbversion.MustAssertLatestVersion(ctx, logger, db.Pool)
+
+	authzClient, err := authz.New(cfg.OpenFGA)
+	if err != nil {
+		return fmt.Errorf("failed to create OpenFGA client: %w", err)
+	}
+
+	q := dbgen.New(db.Pool)
+
+	cbfn := func(ctx context.Context) (bool, error) {
+		lag, err := q.AuthzOutboxLagSeconds(ctx)
+		if err != nil {
+			return false, err
+		}
+		return lag > cfg.CircuitBreakerThreshold.Seconds(), nil
+	}
+
+	breaker := circuitbreaker.New(logger, circuitbreaker.Config{PollInterval: cfg.CircuitBreakerPollInterval}, cbfn)
+	go breaker.Start(ctx)
+
+	jwtKeys, err := auth.NewKeys(cfg.JWKSURL, []byte(cfg.JWTSecret), logger)
+	if err != nil {
+		return fmt.Errorf("failed to configure token verification: %w", err)
+	}
+
+	server := marketplace.New(logger, &marketplace.Config{
+		JWTKeys:            jwtKeys,
+		CORSAllowedOrigins: cfg.CORSAllowedOrigins,
+	}, db, authzClient, marketplace.WithCircuitBreaker(breaker))
+
+	// Health endpoints are registered on an outer mux so they bypass CORS
+	// and Connect interceptors.
+	outerMux := http.NewServeMux()
+	outerMux.HandleFunc("/livez", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("ok"))
+	})
+	outerMux.HandleFunc("/version", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(cfg.DeploymentVersion))
+	})
+	outerMux.HandleFunc("/readyz", func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+		defer cancel()
+
+		var errs []string
+		if err := db.Pool.Ping(ctx); err != nil {
+			errs = append(errs, "database: "+err.Error())
+		}
+		if err := authzClient.Healthy(ctx); err != nil {
+			errs = append(errs, "openfga: "+err.Error())
+		}
+		if breaker.IsOpen() {
+			errs = append(errs, "authz_outbox: sync lag exceeds threshold")
+		}
+
+		if len(errs) > 0 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = w.Write([]byte(strings.Join(errs, "\n")))
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("ok"))
+	})
+	outerMux.Handle("/", server.Handler())
+
+	httpServer := &http.Server{
+		Addr:              cfg.ListenAddr,
+		Handler:           h2c.NewHandler(outerMux, &http2.Server{}),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	logger.Info("server listening", "addr", cfg.ListenAddr)
+	if err := httpServer.ListenAndServe(); err != nil {
+		return fmt.Errorf("server failed: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,266 @@
+-- name: PluginListByOrganizationID :many
+SELECT appstore.plugins.id, appstore.plugins.organization_id, appstore.plugins.name, appstore.plugins.display_name, appstore.plugins.description_short, appstore.plugins.description, appstore.plugins.image, appstore.plugins.author_name, appstore.plugins.author_url, appstore.plugins.repository_url, appstore.plugins.license, appstore.plugins.visibility, appstore.plugins.created, appstore.plugins.updated,
+  (
+    SELECT appstore.plugin_definitions.id
+    FROM appstore.plugin_definitions
+    WHERE appstore.plugin_definitions.plugin_id = appstore.plugins.id
+      AND appstore.plugin_definitions.status = 'approved'
+      AND appstore.plugin_definitions.deleted IS NULL
+    ORDER BY appstore.plugin_definitions.published DESC
+    LIMIT 1
+  ) AS latest_published_version_id
+FROM appstore.plugins
+WHERE appstore.plugins.organization_id = @organization_id
+  AND appstore.plugins.deleted IS NULL
+ORDER BY appstore.plugins.name;
+
+-- name: PluginGetByID :one
+SELECT appstore.plugins.id, appstore.plugins.organization_id, appstore.plugins.name, appstore.plugins.display_name, appstore.plugins.description_short, appstore.plugins.description, appstore.plugins.image, appstore.plugins.author_name, appstore.plugins.author_url, appstore.plugins.repository_url, appstore.plugins.license, appstore.plugins.visibility, appstore.plugins.created, appstore.plugins.updated,
+  (
+    SELECT appstore.plugin_definitions.id
+    FROM appstore.plugin_definitions
+    WHERE appstore.plugin_definitions.plugin_id = appstore.plugins.id
+      AND appstore.plugin_definitions.status = 'approved'
+      AND appstore.plugin_definitions.deleted IS NULL
+    ORDER BY appstore.plugin_definitions.published DESC
+    LIMIT 1
+  ) AS latest_published_version_id
+FROM appstore.plugins
+WHERE appstore.plugins.id = @id
+  AND appstore.plugins.deleted IS NULL;
+
+-- name: PluginListSubmitted :many
+-- Listings with at least one submission, i.e. everything in the review queue.
+SELECT appstore.plugins.id, appstore.plugins.organization_id, appstore.plugins.name, appstore.plugins.display_name, appstore.plugins.description_short, appstore.plugins.description, appstore.plugins.image, appstore.plugins.author_name, appstore.plugins.author_url, appstore.plugins.repository_url, appstore.plugins.license, appstore.plugins.visibility, appstore.plugins.created, appstore.plugins.updated,
+  (
+    SELECT appstore.plugin_definitions.id
+    FROM appstore.plugin_definitions
+    WHERE appstore.plugin_definitions.plugin_id = appstore.plugins.id
+      AND appstore.plugin_definitions.status = 'approved'
+      AND appstore.plugin_definitions.deleted IS NULL
+    ORDER BY appstore.plugin_definitions.published DESC
+    LIMIT 1
+  ) AS latest_published_version_id
+FROM appstore.plugins
+WHERE appstore.plugins.deleted IS NULL
+  AND EXISTS (
+    SELECT 1
+    FROM appstore.submissions
+    JOIN appstore.plugin_definitions ON appstore.plugin_definitions.id = appstore.submissions.plugin_definition_id
+    WHERE appstore.plugin_definitions.plugin_id = appstore.plugins.id
+  )
+ORDER BY appstore.plugins.name;
+
+-- name: PluginCreate :one
+INSERT INTO appstore.plugins (organization_id, name, display_name, description_short, description, image, repository_url, license, visibility)
+VALUES (@organization_id, @name, @display_name, @description_short, @description, @image, @repository_url, @license, @visibility)
+RETURNING id;
+
+-- name: PluginUpdate :execrows
+UPDATE appstore.plugins
+SET display_name = @display_name,
+    description_short = @description_short,
+    description = @description,
+    image = @image,
+    author_name = @author_name,
+    author_url = @author_url,
+    repository_url = @repository_url,
+    license = @license,
+    visibility = @visibility,
+    updated = now()
+WHERE appstore.plugins.id = @id
+  AND appstore.plugins.deleted IS NULL;
+
+-- name: PluginDelete :execrows
+UPDATE appstore.plugins
+SET deleted = now()
+WHERE appstore.plugins.id = @id
+  AND appstore.plugins.deleted IS NULL;
+
+-- name: PluginListPublic :many
+-- Public listings with at least one approved version. Filters are AND-ed;
+-- the caller escapes LIKE wildcards in query.
+SELECT appstore.plugins.id, appstore.plugins.organization_id, appstore.plugins.name, appstore.plugins.display_name, appstore.plugins.description_short, appstore.plugins.image, appstore.plugins.featured, appstore.plugins.labels,
+  (
+    SELECT appstore.plugin_definitions.id
+    FROM appstore.plugin_definitions
+    WHERE appstore.plugin_definitions.plugin_id = appstore.plugins.id
+      AND appstore.plugin_definitions.status = 'approved'
+      AND appstore.plugin_definitions.deleted IS NULL
+    ORDER BY appstore.plugin_definitions.published DESC
+    LIMIT 1
+  ) AS latest_version_id,
+  (
+    SELECT MIN(appstore.plugin_definitions.published)
+    FROM appstore.plugin_definitions
+    WHERE appstore.plugin_definitions.plugin_id = appstore.plugins.id
+      AND appstore.plugin_definitions.status = 'approved'
+      AND appstore.plugin_definitions.deleted IS NULL
+  )::timestamptz AS published
+FROM appstore.plugins
+WHERE appstore.plugins.deleted IS NULL
+  AND appstore.plugins.visibility = 'public'
+  AND EXISTS (
+    SELECT 1
+    FROM appstore.plugin_definitions
+    WHERE appstore.plugin_definitions.plugin_id = appstore.plugins.id
+      AND appstore.plugin_definitions.status = 'approved'
+      AND appstore.plugin_definitions.deleted IS NULL
+  )
+  AND (NOT @featured_only::boolean OR appstore.plugins.featured)
+  AND (
+    sqlc.narg('category_id')::uuid IS NULL
+    OR EXISTS (
+      SELECT 1
+      FROM appstore.categories_plugins
+      WHERE appstore.categories_plugins.plugin_id = appstore.plugins.id
+        AND appstore.categories_plugins.category_id = sqlc.narg('category_id')::uuid
+    )
+  )
+  AND (
+    @query::text = ''
+    OR appstore.plugins.display_name ILIKE '%' || @query::text || '%'
+    OR appstore.plugins.description_short ILIKE '%' || @query::text || '%'
+    OR appstore.plugins.description ILIKE '%' || @query::text || '%'
+    OR EXISTS (
+      SELECT 1
+      FROM tenant.organizations
+      WHERE tenant.organizations.id = appstore.plugins.organization_id
+        AND tenant.organizations.alias ILIKE '%' || @query::text || '%'
+    )
+    OR EXISTS (
+      SELECT 1
+      FROM appstore.plugins_tags
+      JOIN appstore.tags ON appstore.tags.id = appstore.plugins_tags.tag_id
+      WHERE appstore.plugins_tags.plugin_id = appstore.plugins.id
+        AND appstore.tags.deleted IS NULL
+        AND appstore.tags.name ILIKE '%' || @query::text || '%'
+    )
+  )
+ORDER BY appstore.plugins.name;
+
+-- name: PluginGetPublicByID :one
+SELECT appstore.plugins.id, appstore.plugins.organization_id, appstore.plugins.name, appstore.plugins.display_name, appstore.plugins.description_short, appstore.plugins.description, appstore.plugins.image, appstore.plugins.featured, appstore.plugins.labels, appstore.plugins.author_name, appstore.plugins.author_url, appstore.plugins.repository_url, appstore.plugins.license,
+  (
+    SELECT appstore.plugin_definitions.id
+    FROM appstore.plugin_definitions
+    WHERE appstore.plugin_definitions.plugin_id = appstore.plugins.id
+      AND appstore.plugin_definitions.status = 'approved'
+      AND appstore.plugin_definitions.deleted IS NULL
+    ORDER BY appstore.plugin_definitions.published DESC
+    LIMIT 1
+  ) AS latest_version_id,
+  (
+    SELECT MIN(appstore.plugin_definitions.published)
+    FROM appstore.plugin_definitions
+    WHERE appstore.plugin_definitions.plugin_id = appstore.plugins.id
+      AND appstore.plugin_definitions.status = 'approved'
+      AND appstore.plugin_definitions.deleted IS NULL
+  )::timestamptz AS published
+FROM appstore.plugins
+WHERE appstore.plugins.id = @id
+  AND appstore.plugins.deleted IS NULL
+  AND appstore.plugins.visibility = 'public'
+  AND EXISTS (
+    SELECT 1
+    FROM appstore.plugin_definitions
+    WHERE appstore.plugin_definitions.plugin_id = appstore.plugins.id
+      AND appstore.plugin_definitions.status = 'approved'
+      AND appstore.plugin_definitions.deleted IS NULL
+  );
+
+-- name: PluginCategoryListByPluginIDs :many
+SELECT appstore.categories_plugins.plugin_id, appstore.categories_plugins.category_id
+FROM appstore.categories_plugins
+JOIN appstore.categories ON appstore.categories.id = appstore.categories_plugins.category_id
+WHERE appstore.categories_plugins.plugin_id = ANY(@plugin_ids::uuid[])
+  AND appstore.categories.deleted IS NULL
+ORDER BY appstore.categories.name;
+
+-- name: PluginCategoryDeleteByPluginID :exec
+DELETE FROM appstore.categories_plugins
+WHERE appstore.categories_plugins.plugin_id = @plugin_id;
+
+-- name: PluginCategoryCreate :execrows
+-- Links only categories that exist, so the caller can detect unknown ids by
+-- comparing the affected row count.
+INSERT INTO appstore.categories_plugins (plugin_id, category_id)
+SELECT @plugin_id::uuid, appstore.categories.id
+FROM appstore.categories
+WHERE appstore.categories.id = ANY(@category_ids::uuid[])
+  AND appstore.categories.deleted IS NULL;
+
+-- name: TagCreateIfNotExists :exec
+INSERT INTO appstore.tags (name)
+SELECT unnest(@names::text[])
+ON CONFLICT ON CONSTRAINT tags_uq_name DO NOTHING;
+
+-- name: PluginTagListByPluginIDs :many
+SELECT appstore.plugins_tags.plugin_id, appstore.tags.name
+FROM appstore.plugins_tags
+JOIN appstore.tags ON appstore.tags.id = appstore.plugins_tags.tag_id
+WHERE appstore.plugins_tags.plugin_id = ANY(@plugin_ids::uuid[])
+  AND appstore.tags.deleted IS NULL
+ORDER BY appstore.tags.name;
+
+-- name: PluginTagDeleteByPluginID :exec
+DELETE FROM appstore.plugins_tags
+WHERE appstore.plugins_tags.plugin_id = @plugin_id;
+
+-- name: PluginTagCreate :exec
+INSERT INTO appstore.plugins_tags (plugin_id, tag_id)
+SELECT @plugin_id::uuid, appstore.tags.id
+FROM appstore.tags
+WHERE appstore.tags.name = ANY(@names::text[])
+  AND appstore.tags.deleted IS NULL;
+
+-- name: PluginDocumentationLinkListByPluginIDs :many
+SELECT appstore.plugin_documentation_links.id, appstore.plugin_documentation_links.plugin_id, appstore.plugin_documentation_links.title, appstore.plugin_documentation_links.url_name, appstore.plugin_documentation_links.url
+FROM appstore.plugin_documentation_links
+WHERE appstore.plugin_documentation_links.plugin_id = ANY(@plugin_ids::uuid[])
+ORDER BY appstore.plugin_documentation_links.id;
+
+-- name: PluginDocumentationLinkDeleteExcept :exec
+DELETE FROM appstore.plugin_documentation_links
+WHERE appstore.plugin_documentation_links.plugin_id = @plugin_id
+  AND NOT (appstore.plugin_documentation_links.id = ANY(@keep_ids::uuid[]));
+
+-- name: PluginDocumentationLinkUpdate :execrows
+UPDATE appstore.plugin_documentation_links
+SET title = @title,
+    url_name = @url_name,
+    url = @url
+WHERE appstore.plugin_documentation_links.id = @id
+  AND appstore.plugin_documentation_links.plugin_id = @plugin_id;
+
+-- name: PluginDocumentationLinkCreate :exec
+INSERT INTO appstore.plugin_documentation_links (plugin_id, title, url_name, url)
+VALUES (@plugin_id, @title, @url_name, @url);
+
+-- name: PluginFeatureListByPluginIDs :many
+SELECT appstore.plugin_features.plugin_id, appstore.plugin_features.title, appstore.plugin_features.body
+FROM appstore.plugin_features
+WHERE appstore.plugin_features.plugin_id = ANY(@plugin_ids::uuid[])
+ORDER BY appstore.plugin_features.plugin_id, appstore.plugin_features.ordinal;
+
+-- name: PluginFeatureDeleteByPluginID :exec
+DELETE FROM appstore.plugin_features
+WHERE appstore.plugin_features.plugin_id = @plugin_id;
+
+-- name: PluginFeatureCreate :exec
+INSERT INTO appstore.plugin_features (plugin_id, ordinal, title, body)
+VALUES (@plugin_id, @ordinal, @title, @body);
+
+-- name: PluginAllowedOrganizationListByPluginIDs :many
+SELECT appstore.plugins_allowed_organizations.plugin_id, appstore.plugins_allowed_organizations.organization_id
+FROM appstore.plugins_allowed_organizations
+WHERE appstore.plugins_allowed_organizations.plugin_id = ANY(@plugin_ids::uuid[])
+ORDER BY appstore.plugins_allowed_organizations.organization_id;
+
+-- name: PluginAllowedOrganizationDeleteByPluginID :exec
+DELETE FROM appstore.plugins_allowed_organizations
+WHERE appstore.plugins_allowed_organizations.plugin_id = @plugin_id;
+
+-- name: PluginAllowedOrganizationCreate :exec
+INSERT INTO appstore.plugins_allowed_organizations (plugin_id, organization_id)
+SELECT @plugin_id::uuid, unnest(@organization_ids::uuid[]);
@@ -0,0 +1,66 @@
+-- name: PluginVersionListByPluginID :many
+SELECT appstore.plugin_definitions.id, appstore.plugin_definitions.plugin_id, appstore.plugin_definitions.plugin_version, appstore.plugin_definitions.manifest, appstore.plugin_definitions.hash, appstore.plugin_definitions.release_notes, appstore.plugin_definitions.status, appstore.plugin_definitions.created, appstore.plugin_definitions.submitted, appstore.plugin_definitions.published,
+  COALESCE((
+    SELECT appstore.submissions.feedback
+    FROM appstore.submissions
+    WHERE appstore.submissions.plugin_definition_id = appstore.plugin_definitions.id
+    ORDER BY appstore.submissions.submitted DESC
+    LIMIT 1
+  ), '')::text AS review_feedback
+FROM appstore.plugin_definitions
+WHERE appstore.plugin_definitions.plugin_id = @plugin_id
+  AND appstore.plugin_definitions.deleted IS NULL
+ORDER BY appstore.plugin_definitions.created DESC;
+
+-- name: PluginVersionGetByID :one
+SELECT appstore.plugin_definitions.id, appstore.plugin_definitions.plugin_id, appstore.plugin_definitions.plugin_version, appstore.plugin_definitions.manifest, appstore.plugin_definitions.hash, appstore.plugin_definitions.release_notes, appstore.plugin_definitions.status, appstore.plugin_definitions.created, appstore.plugin_definitions.submitted, appstore.plugin_definitions.published,
+  COALESCE((
+    SELECT appstore.submissions.feedback
+    FROM appstore.submissions
+    WHERE appstore.submissions.plugin_definition_id = appstore.plugin_definitions.id
+    ORDER BY appstore.submissions.submitted DESC
+    LIMIT 1
+  ), '')::text AS review_feedback
+FROM appstore.plugin_definitions
+WHERE appstore.plugin_definitions.id = @id
+  AND appstore.plugin_definitions.deleted IS NULL;
+
+-- name: PluginVersionGetForUpdate :one
+SELECT appstore.plugin_definitions.id, appstore.plugin_definitions.plugin_id, appstore.plugin_definitions.status
+FROM appstore.plugin_definitions
+WHERE appstore.plugin_definitions.id = @id
+  AND appstore.plugin_definitions.deleted IS NULL
+FOR UPDATE;
+
+-- name: PluginVersionCreate :one
+INSERT INTO appstore.plugin_definitions (plugin_id, plugin_version, manifest, hash, release_notes)
+VALUES (@plugin_id, @plugin_version, @manifest, @hash, @release_notes)
+RETURNING id;
+
+-- name: PluginVersionSubmit :execrows
+UPDATE appstore.plugin_definitions
+SET status = 'pending',
+    submitted = now()
+WHERE appstore.plugin_definitions.id = @id;
+
+-- name: PluginVersionUpdateStatus :execrows
+UPDATE appstore.plugin_definitions
+SET status = @status
+WHERE appstore.plugin_definitions.id = @id;
+
+-- name: PluginVersionPublish :execrows
+UPDATE appstore.plugin_definitions
+SET status = 'approved',
+    published = now()
+WHERE appstore.plugin_definitions.id = @id;
+
+-- name: PluginVersionListPublishedByPluginID :many
+SELECT appstore.plugin_definitions.id, appstore.plugin_definitions.plugin_version, appstore.plugin_definitions.hash, appstore.plugin_definitions.release_notes, appstore.plugin_definitions.published
+FROM appstore.plugin_definitions
+JOIN appstore.plugins ON appstore.plugins.id = appstore.plugin_definitions.plugin_id
+WHERE appstore.plugin_definitions.plugin_id = @plugin_id
+  AND appstore.plugin_definitions.status = 'approved'
+  AND appstore.plugin_definitions.deleted IS NULL
+  AND appstore.plugins.visibility = 'public'
+  AND appstore.plugins.deleted IS NULL
+ORDER BY appstore.plugin_definitions.published DESC;
@@ -0,0 +1,27 @@
+-- name: PublisherList :many
+-- RLS limits the marketplace role to organizations that own a listing.
+SELECT tenant.organizations.id, tenant.organizations.name, tenant.organizations.alias
+FROM tenant.organizations
+WHERE tenant.organizations.deleted IS NULL
+ORDER BY tenant.organizations.alias;
+
+-- name: PublisherListPublic :many
+-- Organizations with at least one listing the public catalog serves.
+SELECT tenant.organizations.id, tenant.organizations.name, tenant.organizations.alias
+FROM tenant.organizations
+WHERE tenant.organizations.deleted IS NULL
+  AND EXISTS (
+    SELECT 1
+    FROM appstore.plugins
+    WHERE appstore.plugins.organization_id = tenant.organizations.id
+      AND appstore.plugins.deleted IS NULL
+      AND appstore.plugins.visibility = 'public'
+      AND EXISTS (
+        SELECT 1
+        FROM appstore.plugin_definitions
+        WHERE appstore.plugin_definitions.plugin_id = appstore.plugins.id
+          AND appstore.plugin_definitions.status = 'approved'
+          AND appstore.plugin_definitions.deleted IS NULL
+      )
+  )
+ORDER BY tenant.organizations.alias;
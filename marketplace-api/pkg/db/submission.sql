@@ -0,0 +1,42 @@
+-- name: SubmissionList :many
+SELECT appstore.submissions.id, appstore.submissions.plugin_definition_id, appstore.plugin_definitions.plugin_id, appstore.plugins.organization_id, appstore.submissions.submitter_user_id, appstore.submissions.status, appstore.submissions.submitted, appstore.submissions.reviewed, appstore.submissions.reviewer_user_id, appstore.submissions.rejection_reason, appstore.submissions.feedback
+FROM appstore.submissions
+JOIN appstore.plugin_definitions ON appstore.plugin_definitions.id = appstore.submissions.plugin_definition_id
+JOIN appstore.plugins ON appstore.plugins.id = appstore.plugin_definitions.plugin_id
+WHERE sqlc.narg('status')::text IS NULL
+   OR appstore.submissions.status = sqlc.narg('status')::text
+ORDER BY appstore.submissions.submitted DESC;
+
+-- name: SubmissionGetByID :one
+SELECT appstore.submissions.id, appstore.submissions.plugin_definition_id, appstore.plugin_definitions.plugin_id, appstore.plugins.organization_id, appstore.submissions.submitter_user_id, appstore.submissions.status, appstore.submissions.submitted, appstore.submissions.reviewed, appstore.submissions.reviewer_user_id, appstore.submissions.rejection_reason, appstore.submissions.feedback
+FROM appstore.submissions
+JOIN appstore.plugin_definitions ON appstore.plugin_definitions.id = appstore.submissions.plugin_definition_id
+JOIN appstore.plugins ON appstore.plugins.id = appstore.plugin_definitions.plugin_id
+WHERE appstore.submissions.id = @id;
+
+-- name: SubmissionGetForUpdate :one
+SELECT appstore.submissions.id, appstore.submissions.plugin_definition_id, appstore.submissions.status
+FROM appstore.submissions
+WHERE appstore.submissions.id = @id
+FOR UPDATE;
+
+-- name: SubmissionCreate :one
+INSERT INTO appstore.submissions (plugin_definition_id, submitter_user_id)
+VALUES (@plugin_definition_id, @submitter_user_id)
+RETURNING id;
+
+-- name: SubmissionDecide :execrows
+UPDATE appstore.submissions
+SET status = @status,
+    reviewed = now(),
+    reviewer_user_id = @reviewer_user_id,
+    rejection_reason = @rejection_reason,
+    feedback = @feedback
+WHERE appstore.submissions.id = @id
+  AND appstore.submissions.status = 'pending';
+
+-- name: SubmissionWithdraw :execrows
+UPDATE appstore.submissions
+SET status = 'withdrawn'
+WHERE appstore.submissions.plugin_definition_id = @plugin_definition_id
+  AND appstore.submissions.status = 'pending';
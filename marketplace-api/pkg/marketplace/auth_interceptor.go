@@ -0,0 +1,88 @@
+package marketplace
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"connectrpc.com/connect"
+	"github.com/google/uuid"
+
+	"github.com/fundament-oss/fundament/common/auth"
+	"github.com/fundament-oss/fundament/marketplace-api/pkg/proto/gen/admin/v1/adminv1connect"
+	"github.com/fundament-oss/fundament/marketplace-api/pkg/proto/gen/catalog/v1/catalogv1connect"
+)
+
+// OrganizationHeader is the header name for selecting the active organization.
+const OrganizationHeader = "Fun-Organization"
+
+func (s *Server) authInterceptor() connect.Interceptor {
+	return auth.NewInterceptor(s.authenticate)
+}
+
+// authenticate validates the JWT and injects user/org info into ctx.
+// Returns an enriched context or a Connect error.
+func (s *Server) authenticate(ctx context.Context, procedure string, header http.Header) (context.Context, error) {
+	if s.isPublicEndpoint(procedure) {
+		s.logger.DebugContext(ctx, "skipping auth for public endpoint", "procedure", procedure)
+		return ctx, nil
+	}
+
+	claims, err := s.authValidator.Validate(header)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	// API key scopes name organization-api resources; a scoped key may browse
+	// the marketplace but not publish or review.
+	if claims.Scope != nil && !auth.IsReadProcedure(procedure) {
+		return nil, connect.NewError(connect.CodePermissionDenied, fmt.Errorf("API key scope does not allow %s", procedure))
+	}
+
+	userID := claims.UserID()
+	ctx = WithUserID(ctx, userID)
+
+	if s.isUserScopedEndpoint(procedure) {
+		s.logger.DebugContext(ctx, "skipping organization check for user-scoped endpoint",
+			"procedure", procedure, "user_id", userID)
+		return ctx, nil
+	}
+
+	orgHeader := header.Get(OrganizationHeader)
+	if orgHeader == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("missing %s header", OrganizationHeader))
+	}
+
+	organizationID, err := uuid.Parse(orgHeader)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid organization ID: %w", err))
+	}
+
+	if !slices.Contains(claims.OrganizationIDs, organizationID) {
+		return nil, connect.NewError(connect.CodePermissionDenied, fmt.Errorf("user is not a member of organization %s", organizationID))
+	}
+
+	if claims.Scope != nil && claims.Scope.OrganizationID != organizationID {
+		return nil, connect.NewError(connect.CodePermissionDenied, fmt.Errorf("API key is not valid for organization %s", organizationID))
+	}
+
+	ctx = WithOrganizationID(ctx, organizationID)
+	s.logger.DebugContext(ctx, "request authenticated", "organization_id", organizationID, "user_id", userID)
+	return ctx, nil
+}
+
+// isPublicEndpoint checks if an endpoint should skip authentication. The whole
+// storefront is public: CatalogService only ever serves approved public listings.
+func (s *Server) isPublicEndpoint(procedure string) bool {
+	return strings.HasPrefix(procedure, "/"+catalogv1connect.CatalogServiceName+"/")
+}
+
+// isUserScopedEndpoint checks if an endpoint is user-scoped and should skip organization header check.
+// A reviewer acts on submissions from every publishing organization, so the
+// review surface is authorized on the user (see checkReviewer), not on the
+// organization they happen to select.
+func (s *Server) isUserScopedEndpoint(procedure string) bool {
+	return strings.HasPrefix(procedure, "/"+adminv1connect.ReviewServiceName+"/")
+}
@@ -0,0 +1,78 @@
+package marketplace
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"connectrpc.com/connect"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	db "github.com/fundament-oss/fundament/marketplace-api/pkg/db/gen"
+	catalogv1 "github.com/fundament-oss/fundament/marketplace-api/pkg/proto/gen/catalog/v1"
+	"github.com/fundament-oss/fundament/plugin-sdk/pluginruntime"
+)
+
+func (s catalogService) GetPlugin(
+	ctx context.Context,
+	req *catalogv1.GetPluginRequest,
+) (*catalogv1.GetPluginResponse, error) {
+	pluginID := uuid.MustParse(req.GetPluginId())
+
+	plugin, err := s.queries.PluginGetPublicByID(ctx, db.PluginGetPublicByIDParams{ID: pluginID})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("plugin not found"))
+		}
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to get plugin: %w", err))
+	}
+
+	rel, err := s.loadPluginRelations(ctx, []uuid.UUID{pluginID}, true)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to get plugin details: %w", err))
+	}
+
+	details := catalogv1.PluginDetails_builder{
+		Id:                 plugin.ID.String(),
+		Name:               plugin.Name,
+		DisplayName:        plugin.DisplayName,
+		DescriptionShort:   plugin.DescriptionShort,
+		OrganizationId:     plugin.OrganizationID.String(),
+		Image:              plugin.Image,
+		CategoryIds:        rel.categoryIDs[plugin.ID],
+		Tags:               rel.tags[plugin.ID],
+		Labels:             labelsFromDB(plugin.Labels),
+		LatestVersionId:    optionalUUID(plugin.LatestVersionID),
+		Featured:           plugin.Featured,
+		Published:          optionalTimestamp(plugin.Published),
+		Description:        plugin.Description,
+		AuthorName:         optionalText(plugin.AuthorName),
+		AuthorUrl:          optionalText(plugin.AuthorUrl),
+		RepositoryUrl:      optionalText(plugin.RepositoryUrl),
+		License:            plugin.License,
+		Features:           rel.features[plugin.ID],
+		DocumentationLinks: rel.documentationLinks[plugin.ID],
+	}
+
+	// Capabilities and permissions describe what an install grants, so they
+	// come from the definition of the version the storefront would install.
+	if plugin.LatestVersionID.Valid {
+		version, err := s.queries.PluginVersionGetByID(ctx, db.PluginVersionGetByIDParams{ID: plugin.LatestVersionID.Bytes})
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to get latest plugin version: %w", err))
+		}
+
+		def, err := pluginruntime.ParseDefinition(version.Manifest)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to parse plugin definition: %w", err))
+		}
+
+		details.Capabilities = def.Spec.Permissions.Capabilities
+		details.Permissions = permissionsFromRules(def.Spec.Permissions.RBAC)
+	}
+
+	return catalogv1.GetPluginResponse_builder{
+		Plugin: details.Build(),
+	}.Build(), nil
+}
@@ -0,0 +1,105 @@
+package marketplace
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"connectrpc.com/connect"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	db "github.com/fundament-oss/fundament/marketplace-api/pkg/db/gen"
+	catalogv1 "github.com/fundament-oss/fundament/marketplace-api/pkg/proto/gen/catalog/v1"
+)
+
+// likeEscaper escapes the LIKE wildcards in a free-text query so that "100%"
+// matches literally.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+func (s catalogService) ListPlugins(
+	ctx context.Context,
+	req *catalogv1.ListPluginsRequest,
+) (*catalogv1.ListPluginsResponse, error) {
+	params := db.PluginListPublicParams{
+		FeaturedOnly: req.GetFeaturedOnly(),
+		Query:        likeEscaper.Replace(strings.TrimSpace(req.GetQuery())),
+	}
+	if req.GetCategoryId() != "" {
+		params.CategoryID = pgtype.UUID{Bytes: uuid.MustParse(req.GetCategoryId()), Valid: true}
+	}
+
+	plugins, err := s.queries.PluginListPublic(ctx, params)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to list plugins: %w", err))
+	}
+
+	sortPublicPlugins(plugins, req.GetSort())
+
+	pluginIDs := make([]uuid.UUID, 0, len(plugins))
+	for i := range plugins {
+		pluginIDs = append(pluginIDs, plugins[i].ID)
+	}
+
+	rel, err := s.loadPluginRelations(ctx, pluginIDs, false)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to list plugins: %w", err))
+	}
+
+	result := make([]*catalogv1.PluginSummary, 0, len(plugins))
+	for i := range plugins {
+		p := &plugins[i]
+		result = append(result, catalogv1.PluginSummary_builder{
+			Id:               p.ID.String(),
+			Name:             p.Name,
+			DisplayName:      p.DisplayName,
+			DescriptionShort: p.DescriptionShort,
+			OrganizationId:   p.OrganizationID.String(),
+			Image:            p.Image,
+			CategoryIds:      rel.categoryIDs[p.ID],
+			Tags:             rel.tags[p.ID],
+			Labels:           labelsFromDB(p.Labels),
+			LatestVersionId:  optionalUUID(p.LatestVersionID),
+			Featured:         p.Featured,
+			Published:        optionalTimestamp(p.Published),
+		}.Build())
+	}
+
+	return catalogv1.ListPluginsResponse_builder{
+		Plugins: result,
+	}.Build(), nil
+}
+
+// sortPublicPlugins orders the storefront results. The query returns them by
+// name; every order falls back to display name so ties are stable.
+func sortPublicPlugins(plugins []db.PluginListPublicRow, sort catalogv1.PluginSort) {
+	byDisplayName := func(a, b *db.PluginListPublicRow) int {
+		return cmp.Or(
+			cmp.Compare(strings.ToLower(a.DisplayName), strings.ToLower(b.DisplayName)),
+			cmp.Compare(a.Name, b.Name),
+		)
+	}
+
+	switch sort {
+	case catalogv1.PluginSort_PLUGIN_SORT_NAME:
+		slices.SortStableFunc(plugins, func(a, b db.PluginListPublicRow) int {
+			return byDisplayName(&a, &b)
+		})
+	case catalogv1.PluginSort_PLUGIN_SORT_RECENTLY_ADDED:
+		slices.SortStableFunc(plugins, func(a, b db.PluginListPublicRow) int {
+			return cmp.Or(b.Published.Time.Compare(a.Published.Time), byDisplayName(&a, &b))
+		})
+	case catalogv1.PluginSort_PLUGIN_SORT_UNSPECIFIED, catalogv1.PluginSort_PLUGIN_SORT_FEATURED:
+		slices.SortStableFunc(plugins, func(a, b db.PluginListPublicRow) int {
+			if a.Featured != b.Featured {
+				if a.Featured {
+					return -1
+				}
+				return 1
+			}
+			return byDisplayName(&a, &b)
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package marketplace
+
+import (
+	"context"
+	"fmt"
+
+	"connectrpc.com/connect"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	db "github.com/fundament-oss/fundament/marketplace-api/pkg/db/gen"
+	catalogv1 "github.com/fundament-oss/fundament/marketplace-api/pkg/proto/gen/catalog/v1"
+)
+
+func (s catalogService) ListPluginVersions(
+	ctx context.Context,
+	req *catalogv1.ListPluginVersionsRequest,
+) (*catalogv1.ListPluginVersionsResponse, error) {
+	pluginID := uuid.MustParse(req.GetPluginId())
+
+	versions, err := s.queries.PluginVersionListPublishedByPluginID(ctx, db.PluginVersionListPublishedByPluginIDParams{PluginID: pluginID})
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to list plugin versions: %w", err))
+	}
+
+	// A listing the storefront may show always has a published version, so an
+	// empty result means the listing is unknown, restricted or unpublished.
+	if len(versions) == 0 {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("plugin not found"))
+	}
+
+	result := make([]*catalogv1.PublishedVersion, 0, len(versions))
+	for _, v := range versions {
+		result = append(result, catalogv1.PublishedVersion_builder{
+			Id:             v.ID.String(),
+			Version:        v.PluginVersion,
+			Published:      timestamppb.New(v.Published.Time),
+			DefinitionHash: v.Hash,
+			ReleaseNotes:   v.ReleaseNotes,
+		}.Build())
+	}
+
+	return catalogv1.ListPluginVersionsResponse_builder{
+		Versions: result,
+	}.Build(), nil
+}
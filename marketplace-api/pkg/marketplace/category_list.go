@@ -0,0 +1,78 @@
+package marketplace
+
+import (
+	"context"
+	"fmt"
+
+	"connectrpc.com/connect"
+
+	adminv1 "github.com/fundament-oss/fundament/marketplace-api/pkg/proto/gen/admin/v1"
+	catalogv1 "github.com/fundament-oss/fundament/marketplace-api/pkg/proto/gen/catalog/v1"
+	marketplacev1 "github.com/fundament-oss/fundament/marketplace-api/pkg/proto/gen/marketplace/v1"
+	registryv1 "github.com/fundament-oss/fundament/marketplace-api/pkg/proto/gen/registry/v1"
+)
+
+func (s catalogService) ListCategories(
+	ctx context.Context,
+	req *catalogv1.ListCategoriesRequest,
+) (*catalogv1.ListCategoriesResponse, error) {
+	categories, err := s.listCategories(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return catalogv1.ListCategoriesResponse_builder{
+		Categories: categories,
+	}.Build(), nil
+}
+
+func (s publicationService) ListCategories(
+	ctx context.Context,
+	req *registryv1.ListCategoriesRequest,
+) (*registryv1.ListCategoriesResponse, error) {
+	categories, err := s.listCategories(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return registryv1.ListCategoriesResponse_builder{
+		Categories: categories,
+	}.Build(), nil
+}
+
+func (s reviewService) ListCategories(
+	ctx context.Context,
+	req *adminv1.ListCategoriesRequest,
+) (*adminv1.ListCategoriesResponse, error) {
+	if err := s.checkReviewer(ctx); err != nil {
+		return nil, err
+	}
+
+	categories, err := s.listCategories(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return adminv1.ListCategoriesResponse_builder{
+		Categories: categories,
+	}.Build(), nil
+}
+
+// listCategories returns the curated category vocabulary, which is the same
+// on every surface.
+func (s *Server) listCategories(ctx context.Context) ([]*marketplacev1.Category, error) {
+	rows, err := s.queries.CategoryList(ctx)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to list categories: %w", err))
+	}
+
+	categories := make([]*marketplacev1.Category, 0, len(rows))
+	for _, row := range rows {
+		categories = append(categories, marketplacev1.Category_builder{
+			Id:   row.ID.String(),
+			Name: row.Name,
+		}.Build())
+	}
+
+	return categories, nil
+}
@@ -0,0 +1,234 @@
+package marketplace
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/fundament-oss/fundament/common/dbconst"
+	adminv1 "github.com/fundament-oss/fundament/marketplace-api/pkg/proto/gen/admin/v1"
+	catalogv1 "github.com/fundament-oss/fundament/marketplace-api/pkg/proto/gen/catalog/v1"
+	marketplacev1 "github.com/fundament-oss/fundament/marketplace-api/pkg/proto/gen/marketplace/v1"
+	registryv1 "github.com/fundament-oss/fundament/marketplace-api/pkg/proto/gen/registry/v1"
+	"github.com/fundament-oss/fundament/plugin-sdk/pluginruntime"
+)
+
+func submissionStatusFromVersion(status dbconst.PluginDefinitionStatus) marketplacev1.SubmissionStatus {
+	switch status {
+	case dbconst.PluginDefinitionStatus_Draft:
+		return marketplacev1.SubmissionStatus_SUBMISSION_STATUS_DRAFT
+	case dbconst.PluginDefinitionStatus_Pending:
+		return marketplacev1.SubmissionStatus_SUBMISSION_STATUS_PENDING
+	case dbconst.PluginDefinitionStatus_ChangesRequested:
+		return marketplacev1.SubmissionStatus_SUBMISSION_STATUS_CHANGES_REQUESTED
+	case dbconst.PluginDefinitionStatus_Approved:
+		return marketplacev1.SubmissionStatus_SUBMISSION_STATUS_APPROVED
+	case dbconst.PluginDefinitionStatus_Rejected:
+		return marketplacev1.SubmissionStatus_SUBMISSION_STATUS_REJECTED
+	case dbconst.PluginDefinitionStatus_Withdrawn:
+		return marketplacev1.SubmissionStatus_SUBMISSION_STATUS_WITHDRAWN
+	default:
+		return marketplacev1.SubmissionStatus_SUBMISSION_STATUS_UNSPECIFIED
+	}
+}
+
+func submissionStatusFromSubmission(status dbconst.SubmissionStatus) marketplacev1.SubmissionStatus {
+	switch status {
+	case dbconst.SubmissionStatus_Pending:
+		return marketplacev1.SubmissionStatus_SUBMISSION_STATUS_PENDING
+	case dbconst.SubmissionStatus_ChangesRequested:
+		return marketplacev1.SubmissionStatus_SUBMISSION_STATUS_CHANGES_REQUESTED
+	case dbconst.SubmissionStatus_Approved:
+		return marketplacev1.SubmissionStatus_SUBMISSION_STATUS_APPROVED
+	case dbconst.SubmissionStatus_Rejected:
+		return marketplacev1.SubmissionStatus_SUBMISSION_STATUS_REJECTED
+	case dbconst.SubmissionStatus_Withdrawn:
+		return marketplacev1.SubmissionStatus_SUBMISSION_STATUS_WITHDRAWN
+	default:
+		return marketplacev1.SubmissionStatus_SUBMISSION_STATUS_UNSPECIFIED
+	}
+}
+
+// submissionStatusFilter maps a ListSubmissions filter onto the submissions
+// status column. UNSPECIFIED means no filter; DRAFT is kept as a value so it
+// matches nothing, since a draft has no submission.
+func submissionStatusFilter(status marketplacev1.SubmissionStatus) pgtype.Text {
+	switch status {
+	case marketplacev1.SubmissionStatus_SUBMISSION_STATUS_DRAFT:
+		return pgtype.Text{String: string(dbconst.PluginDefinitionStatus_Draft), Valid: true}
+	case marketplacev1.SubmissionStatus_SUBMISSION_STATUS_PENDING:
+		return pgtype.Text{String: string(dbconst.SubmissionStatus_Pending), Valid: true}
+	case marketplacev1.SubmissionStatus_SUBMISSION_STATUS_CHANGES_REQUESTED:
+		return pgtype.Text{String: string(dbconst.SubmissionStatus_ChangesRequested), Valid: true}
+	case marketplacev1.SubmissionStatus_SUBMISSION_STATUS_APPROVED:
+		return pgtype.Text{String: string(dbconst.SubmissionStatus_Approved), Valid: true}
+	case marketplacev1.SubmissionStatus_SUBMISSION_STATUS_REJECTED:
+		return pgtype.Text{String: string(dbconst.SubmissionStatus_Rejected), Valid: true}
+	case marketplacev1.SubmissionStatus_SUBMISSION_STATUS_WITHDRAWN:
+		return pgtype.Text{String: string(dbconst.SubmissionStatus_Withdrawn), Valid: true}
+	default:
+		return pgtype.Text{}
+	}
+}
+
+func visibilityFromDB(visibility dbconst.PluginVisibility) registryv1.PluginVisibility {
+	switch visibility {
+	case dbconst.PluginVisibility_Public:
+		return registryv1.PluginVisibility_PLUGIN_VISIBILITY_PUBLIC
+	case dbconst.PluginVisibility_Restricted:
+		return registryv1.PluginVisibility_PLUGIN_VISIBILITY_RESTRICTED
+	default:
+		return registryv1.PluginVisibility_PLUGIN_VISIBILITY_UNSPECIFIED
+	}
+}
+
+// visibilityToDB maps a validated visibility; the request rules already
+// reject UNSPECIFIED.
+func visibilityToDB(visibility registryv1.PluginVisibility) dbconst.PluginVisibility {
+	if visibility == registryv1.PluginVisibility_PLUGIN_VISIBILITY_RESTRICTED {
+		return dbconst.PluginVisibility_Restricted
+	}
+	return dbconst.PluginVisibility_Public
+}
+
+func rejectionReasonFromDB(reason pgtype.Text) adminv1.RejectionReason {
+	if !reason.Valid {
+		return adminv1.RejectionReason_REJECTION_REASON_UNSPECIFIED
+	}
+	switch dbconst.SubmissionRejectionReason(reason.String) {
+	case dbconst.SubmissionRejectionReason_IncompleteMetadata:
+		return adminv1.RejectionReason_REJECTION_REASON_INCOMPLETE_METADATA
+	case dbconst.SubmissionRejectionReason_Duplicate:
+		return adminv1.RejectionReason_REJECTION_REASON_DUPLICATE
+	case dbconst.SubmissionRejectionReason_SecurityConcerns:
+		return adminv1.RejectionReason_REJECTION_REASON_SECURITY_CONCERNS
+	case dbconst.SubmissionRejectionReason_NamingGuidelines:
+		return adminv1.RejectionReason_REJECTION_REASON_NAMING_GUIDELINES
+	case dbconst.SubmissionRejectionReason_OutOfScope:
+		return adminv1.RejectionReason_REJECTION_REASON_OUT_OF_SCOPE
+	case dbconst.SubmissionRejectionReason_Other:
+		return adminv1.RejectionReason_REJECTION_REASON_OTHER
+	default:
+		return adminv1.RejectionReason_REJECTION_REASON_UNSPECIFIED
+	}
+}
+
+func rejectionReasonToDB(reason adminv1.RejectionReason) pgtype.Text {
+	var value dbconst.SubmissionRejectionReason
+	switch reason {
+	case adminv1.RejectionReason_REJECTION_REASON_INCOMPLETE_METADATA:
+		value = dbconst.SubmissionRejectionReason_IncompleteMetadata
+	case adminv1.RejectionReason_REJECTION_REASON_DUPLICATE:
+		value = dbconst.SubmissionRejectionReason_Duplicate
+	case adminv1.RejectionReason_REJECTION_REASON_SECURITY_CONCERNS:
+		value = dbconst.SubmissionRejectionReason_SecurityConcerns
+	case adminv1.RejectionReason_REJECTION_REASON_NAMING_GUIDELINES:
+		value = dbconst.SubmissionRejectionReason_NamingGuidelines
+	case adminv1.RejectionReason_REJECTION_REASON_OUT_OF_SCOPE:
+		value = dbconst.SubmissionRejectionReason_OutOfScope
+	case adminv1.RejectionReason_REJECTION_REASON_OTHER:
+		value = dbconst.SubmissionRejectionReason_Other
+	default:
+		return pgtype.Text{}
+	}
+	return pgtype.Text{String: string(value), Valid: true}
+}
+
+func labelsFromDB(labels []string) []catalogv1.PluginLabel {
+	out := make([]catalogv1.PluginLabel, 0, len(labels))
+	for _, label := range labels {
+		switch label {
+		case "core":
+			out = append(out, catalogv1.PluginLabel_PLUGIN_LABEL_CORE)
+		case "rijksoverheid":
+			out = append(out, catalogv1.PluginLabel_PLUGIN_LABEL_RIJKSOVERHEID)
+		case "support_9_to_17":
+			out = append(out, catalogv1.PluginLabel_PLUGIN_LABEL_SUPPORT_9_TO_17)
+		}
+	}
+	return out
+}
+
+func optionalTimestamp(ts pgtype.Timestamptz) *timestamppb.Timestamp {
+	if !ts.Valid {
+		return nil
+	}
+	return timestamppb.New(ts.Time)
+}
+
+func optionalText(text pgtype.Text) string {
+	if !text.Valid {
+		return ""
+	}
+	return text.String
+}
+
+func textOrNull(s string) pgtype.Text {
+	return pgtype.Text{String: s, Valid: s != ""}
+}
+
+func optionalUUID(id pgtype.UUID) string {
+	if !id.Valid {
+		return ""
+	}
+	return id.String()
+}
+
+// reviewFeedback is the reviewer's note a developer gets to see. Only a
+// decision that sends the version back carries one; an approval note stays in
+// the backoffice.
+func reviewFeedback(status dbconst.PluginDefinitionStatus, feedback string) string {
+	switch status {
+	case dbconst.PluginDefinitionStatus_ChangesRequested, dbconst.PluginDefinitionStatus_Rejected:
+		return feedback
+	default:
+		return ""
+	}
+}
+
+// mutatingVerbs are the RBAC verbs that make a permission "Read and write".
+var mutatingVerbs = []string{"*", "create", "update", "patch", "delete", "deletecollection"}
+
+// permissionsFromRules projects a definition's RBAC rules onto one row per
+// resource, in the order the manifest first mentions them.
+func permissionsFromRules(rules []pluginruntime.PolicyRule) []*marketplacev1.PluginPermission {
+	var resources []string
+	writable := map[string]bool{}
+
+	for _, rule := range rules {
+		writes := slices.ContainsFunc(rule.Verbs, func(verb string) bool {
+			return slices.Contains(mutatingVerbs, verb)
+		})
+		for _, resource := range rule.Resources {
+			if _, seen := writable[resource]; !seen {
+				resources = append(resources, resource)
+			}
+			writable[resource] = writable[resource] || writes
+		}
+	}
+
+	permissions := make([]*marketplacev1.PluginPermission, 0, len(resources))
+	for _, resource := range resources {
+		access := "Read only"
+		if writable[resource] {
+			access = "Read and write"
+		}
+		permissions = append(permissions, marketplacev1.PluginPermission_builder{
+			Resource: resourceDisplayName(resource),
+			Access:   access,
+		}.Build())
+	}
+	return permissions
+}
+
+func resourceDisplayName(resource string) string {
+	if resource == "*" {
+		return "All resources"
+	}
+	if resource == "" {
+		return resource
+	}
+	return strings.ToUpper(resource[:1]) + resource[1:]
+}
@@ -0,0 +1,35 @@
+package marketplace
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/fundament-oss/fundament/plugin-sdk/pluginruntime"
+)
+
+func Test_permissionsFromRules(t *testing.T) {
+	t.Parallel()
+
+	rules := []pluginruntime.PolicyRule{
+		{APIGroups: []string{"cert-manager.io"}, Resources: []string{"certificates", "issuers"}, Verbs: []string{"get", "list"}},
+		{APIGroups: []string{""}, Resources: []string{"secrets"}, Verbs: []string{"get"}},
+		{APIGroups: []string{"cert-manager.io"}, Resources: []string{"certificates"}, Verbs: []string{"patch"}},
+		{APIGroups: []string{"*"}, Resources: []string{"*"}, Verbs: []string{"*"}},
+	}
+
+	got := permissionsFromRules(rules)
+
+	type row struct{ resource, access string }
+	var rows []row
+	for _, p := range got {
+		rows = append(rows, row{p.GetResource(), p.GetAccess()})
+	}
+
+	assert.Equal(t, []row{
+		{"Certificates", "Read and write"},
+		{"Issuers", "Read only"},
+		{"Secrets", "Read only"},
+		{"All resources", "Read and write"},
+	}, rows)
+}
@@ -0,0 +1,123 @@
+package marketplace
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"golang.org/x/sync/errgroup"
+
+	db "github.com/fundament-oss/fundament/marketplace-api/pkg/db/gen"
+	marketplacev1 "github.com/fundament-oss/fundament/marketplace-api/pkg/proto/gen/marketplace/v1"
+)
+
+// pluginRelations holds the child rows of a set of listings, keyed by plugin id.
+type pluginRelations struct {
+	categoryIDs            map[uuid.UUID][]string
+	tags                   map[uuid.UUID][]string
+	documentationLinks     map[uuid.UUID][]*marketplacev1.DocumentationLink
+	features               map[uuid.UUID][]*marketplacev1.FeatureBlock
+	allowedOrganizationIDs map[uuid.UUID][]string
+}
+
+// loadPluginRelations fetches categories and tags for the given listings, and
+// with details also their documentation links, features and allow-list.
+func (s *Server) loadPluginRelations(ctx context.Context, pluginIDs []uuid.UUID, details bool) (*pluginRelations, error) {
+	rel := &pluginRelations{
+		categoryIDs:            map[uuid.UUID][]string{},
+		tags:                   map[uuid.UUID][]string{},
+		documentationLinks:     map[uuid.UUID][]*marketplacev1.DocumentationLink{},
+		features:               map[uuid.UUID][]*marketplacev1.FeatureBlock{},
+		allowedOrganizationIDs: map[uuid.UUID][]string{},
+	}
+	if len(pluginIDs) == 0 {
+		return rel, nil
+	}
+
+	var (
+		categories  []db.PluginCategoryListByPluginIDsRow
+		tags        []db.PluginTagListByPluginIDsRow
+		docLinks    []db.AppstorePluginDocumentationLink
+		features    []db.PluginFeatureListByPluginIDsRow
+		allowedOrgs []db.PluginAllowedOrganizationListByPluginIDsRow
+	)
+
+	g, ctx := errgroup.WithContext(ctx)
+
+	g.Go(func() error {
+		var err error
+		categories, err = s.queries.PluginCategoryListByPluginIDs(ctx, db.PluginCategoryListByPluginIDsParams{PluginIds: pluginIDs})
+		if err != nil {
+			return fmt.Errorf("querying plugin categories: %w", err)
+		}
+		return nil
+	})
+
+	g.Go(func() error {
+		var err error
+		tags, err = s.queries.PluginTagListByPluginIDs(ctx, db.PluginTagListByPluginIDsParams{PluginIds: pluginIDs})
+		if err != nil {
+			return fmt.Errorf("querying plugin tags: %w", err)
+		}
+		return nil
+	})
+
+	if details {
+		g.Go(func() error {
+			var err error
+			docLinks, err = s.queries.PluginDocumentationLinkListByPluginIDs(ctx, db.PluginDocumentationLinkListByPluginIDsParams{PluginIds: pluginIDs})
+			if err != nil {
+				return fmt.Errorf("querying plugin documentation links: %w", err)
+			}
+			return nil
+		})
+
+		g.Go(func() error {
+			var err error
+			features, err = s.queries.PluginFeatureListByPluginIDs(ctx, db.PluginFeatureListByPluginIDsParams{PluginIds: pluginIDs})
+			if err != nil {
+				return fmt.Errorf("querying plugin features: %w", err)
+			}
+			return nil
+		})
+
+		g.Go(func() error {
+			var err error
+			allowedOrgs, err = s.queries.PluginAllowedOrganizationListByPluginIDs(ctx, db.PluginAllowedOrganizationListByPluginIDsParams{PluginIds: pluginIDs})
+			if err != nil {
+				return fmt.Errorf("querying plugin allowed organizations: %w", err)
+			}
+			return nil
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		return nil, fmt.Errorf("loading plugin relations: %w", err)
+	}
+
+	for _, c := range categories {
+		rel.categoryIDs[c.PluginID] = append(rel.categoryIDs[c.PluginID], c.CategoryID.String())
+	}
+	for _, t := range tags {
+		rel.tags[t.PluginID] = append(rel.tags[t.PluginID], t.Name)
+	}
+	for _, d := range docLinks {
+		rel.documentationLinks[d.PluginID] = append(rel.documentationLinks[d.PluginID], marketplacev1.DocumentationLink_builder{
+			Id:      d.ID.String(),
+			Title:   d.Title,
+			UrlName: d.UrlName,
+			Url:     d.Url,
+		}.Build())
+	}
+	for _, f := range features {
+		rel.features[f.PluginID] = append(rel.features[f.PluginID], marketplacev1.FeatureBlock_builder{
+			Title: f.Title,
+			Body:  f.Body,
+		}.Build())
+	}
+	for _, o := range allowedOrgs {
+		rel.allowedOrganizationIDs[o.PluginID] = append(rel.allowedOrganizationIDs[o.PluginID], o.OrganizationID.String())
+	}
+
+	return rel, nil
+}
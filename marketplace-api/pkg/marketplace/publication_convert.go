@@ -0,0 +1,124 @@
+package marketplace
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"connectrpc.com/connect"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	db "github.com/fundament-oss/fundament/marketplace-api/pkg/db/gen"
+	registryv1 "github.com/fundament-oss/fundament/marketplace-api/pkg/proto/gen/registry/v1"
+	"github.com/fundament-oss/fundament/plugin-sdk/pluginruntime"
+)
+
+// getOwnPlugin loads a listing owned by the organization in ctx. Every
+// listing is readable under RLS, so a listing of another organization is
+// reported as not found rather than leaked.
+func (s *Server) getOwnPlugin(ctx context.Context, pluginID uuid.UUID) (*db.PluginGetByIDRow, error) {
+	organizationID, ok := OrganizationIDFromContext(ctx)
+	if !ok {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("organization_id missing from context"))
+	}
+
+	plugin, err := s.queries.PluginGetByID(ctx, db.PluginGetByIDParams{ID: pluginID})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("plugin not found"))
+		}
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to get plugin: %w", err))
+	}
+
+	if plugin.OrganizationID != organizationID {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("plugin not found"))
+	}
+
+	return &plugin, nil
+}
+
+// getOwnPluginVersion loads a version whose listing is owned by the
+// organization in ctx.
+func (s *Server) getOwnPluginVersion(ctx context.Context, versionID uuid.UUID) (*db.PluginVersionGetByIDRow, error) {
+	version, err := s.queries.PluginVersionGetByID(ctx, db.PluginVersionGetByIDParams{ID: versionID})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("plugin version not found"))
+		}
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to get plugin version: %w", err))
+	}
+
+	if _, err := s.getOwnPlugin(ctx, version.PluginID); err != nil {
+		if connect.CodeOf(err) == connect.CodeNotFound {
+			return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("plugin version not found"))
+		}
+		return nil, err
+	}
+
+	return &version, nil
+}
+
+// getRegistryPlugin reads a listing back with all of its child rows.
+func (s *Server) getRegistryPlugin(ctx context.Context, pluginID uuid.UUID) (*registryv1.Plugin, error) {
+	plugin, err := s.getOwnPlugin(ctx, pluginID)
+	if err != nil {
+		return nil, err
+	}
+
+	rel, err := s.loadPluginRelations(ctx, []uuid.UUID{plugin.ID}, true)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to get plugin details: %w", err))
+	}
+
+	return registryPluginFromRow(plugin, rel), nil
+}
+
+func registryPluginFromRow(plugin *db.PluginGetByIDRow, rel *pluginRelations) *registryv1.Plugin {
+	return registryv1.Plugin_builder{
+		Id:                       plugin.ID.String(),
+		Name:                     plugin.Name,
+		DisplayName:              plugin.DisplayName,
+		DescriptionShort:         plugin.DescriptionShort,
+		Description:              plugin.Description,
+		OrganizationId:           plugin.OrganizationID.String(),
+		Image:                    plugin.Image,
+		CategoryIds:              rel.categoryIDs[plugin.ID],
+		Tags:                     rel.tags[plugin.ID],
+		AuthorName:               optionalText(plugin.AuthorName),
+		AuthorUrl:                optionalText(plugin.AuthorUrl),
+		RepositoryUrl:            optionalText(plugin.RepositoryUrl),
+		License:                  plugin.License,
+		DocumentationLinks:       rel.documentationLinks[plugin.ID],
+		Features:                 rel.features[plugin.ID],
+		Visibility:               visibilityFromDB(plugin.Visibility),
+		AllowedOrganizationIds:   rel.allowedOrganizationIDs[plugin.ID],
+		LatestPublishedVersionId: optionalUUID(plugin.LatestPublishedVersionID),
+		Created:                  timestamppb.New(plugin.Created.Time),
+		Updated:                  timestamppb.New(plugin.Updated.Time),
+	}.Build()
+}
+
+func registryPluginVersionFromRow(version *db.PluginVersionGetByIDRow) *registryv1.PluginVersion {
+	// The manifest was parsed when the version was pushed; the image is read
+	// back out of it rather than stored twice.
+	var image string
+	if def, err := pluginruntime.ParseDefinition(version.Manifest); err == nil {
+		image = def.Spec.Image
+	}
+
+	return registryv1.PluginVersion_builder{
+		Id:             version.ID.String(),
+		PluginId:       version.PluginID.String(),
+		Version:        version.PluginVersion,
+		Image:          image,
+		DefinitionHash: version.Hash,
+		ReleaseNotes:   version.ReleaseNotes,
+		Status:         submissionStatusFromVersion(version.Status),
+		Created:        timestamppb.New(version.Created.Time),
+		Submitted:      optionalTimestamp(version.Submitted),
+		Published:      optionalTimestamp(version.Published),
+		ReviewFeedback: reviewFeedback(version.Status, version.ReviewFeedback),
+	}.Build()
+}
@@ -0,0 +1,89 @@
+package marketplace
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"connectrpc.com/connect"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/fundament-oss/fundament/common/authz"
+	"github.com/fundament-oss/fundament/common/dbconst"
+	"github.com/fundament-oss/fundament/common/rollback"
+	db "github.com/fundament-oss/fundament/marketplace-api/pkg/db/gen"
+	registryv1 "github.com/fundament-oss/fundament/marketplace-api/pkg/proto/gen/registry/v1"
+)
+
+func (s publicationService) CreatePlugin(
+	ctx context.Context,
+	req *registryv1.CreatePluginRequest,
+) (*registryv1.CreatePluginResponse, error) {
+	organizationID, ok := OrganizationIDFromContext(ctx)
+	if !ok {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("organization_id missing from context"))
+	}
+
+	if err := s.checkPermission(ctx, authz.CanEdit(), authz.Organization(organizationID)); err != nil {
+		return nil, err
+	}
+
+	tx, err := s.db.Pool.Begin(ctx)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to begin transaction: %w", err))
+	}
+	defer rollback.Rollback(ctx, tx, s.logger)
+
+	qtx := s.queries.WithTx(tx)
+
+	pluginID, err := qtx.PluginCreate(ctx, db.PluginCreateParams{
+		OrganizationID:   organizationID,
+		Name:             req.GetName(),
+		DisplayName:      req.GetDisplayName(),
+		DescriptionShort: req.GetDescriptionShort(),
+		Description:      req.GetDescription(),
+		Image:            req.GetImage(),
+		RepositoryUrl:    textOrNull(req.GetRepositoryUrl()),
+		License:          req.GetLicense(),
+		Visibility:       visibilityToDB(req.GetVisibility()),
+	})
+	if err != nil {
+		if pgErr, ok := errors.AsType[*pgconn.PgError](err); ok {
+			switch {
+			case pgErr.Code == pgerrcode.UniqueViolation && pgErr.ConstraintName == dbconst.ConstraintPluginsUqName:
+				return nil, connect.NewError(connect.CodeAlreadyExists, fmt.Errorf("plugin name %q is already taken", req.GetName()))
+			case pgErr.Code == pgerrcode.InsufficientPrivilege:
+				return nil, connect.NewError(connect.CodePermissionDenied, fmt.Errorf("permission denied"))
+			}
+		}
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to create plugin: %w", err))
+	}
+
+	if err := replacePluginCategories(ctx, qtx, pluginID, req.GetCategoryIds()); err != nil {
+		return nil, err
+	}
+
+	if err := replacePluginTags(ctx, qtx, pluginID, req.GetTags()); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to commit transaction: %w", err))
+	}
+
+	s.logger.InfoContext(ctx, "plugin created",
+		"plugin_id", pluginID,
+		"organization_id", organizationID,
+		"name", req.GetName(),
+	)
+
+	plugin, err := s.getRegistryPlugin(ctx, pluginID)
+	if err != nil {
+		return nil, err
+	}
+
+	return registryv1.CreatePluginResponse_builder{
+		Plugin: plugin,
+	}.Build(), nil
+}
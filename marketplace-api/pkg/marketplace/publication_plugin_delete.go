@@ -0,0 +1,41 @@
+package marketplace
+
+import (
+	"context"
+	"fmt"
+
+	"connectrpc.com/connect"
+	"github.com/google/uuid"
+
+	"github.com/fundament-oss/fundament/common/authz"
+	db "github.com/fundament-oss/fundament/marketplace-api/pkg/db/gen"
+	registryv1 "github.com/fundament-oss/fundament/marketplace-api/pkg/proto/gen/registry/v1"
+)
+
+func (s publicationService) DeletePlugin(
+	ctx context.Context,
+	req *registryv1.DeletePluginRequest,
+) (*registryv1.DeletePluginResponse, error) {
+	pluginID := uuid.MustParse(req.GetPluginId())
+
+	if err := s.checkPermission(ctx, authz.CanDelete(), authz.Plugin(pluginID)); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.getOwnPlugin(ctx, pluginID); err != nil {
+		return nil, err
+	}
+
+	rowsAffected, err := s.queries.PluginDelete(ctx, db.PluginDeleteParams{ID: pluginID})
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to delete plugin: %w", err))
+	}
+
+	if rowsAffected != 1 {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("plugin not found"))
+	}
+
+	s.logger.InfoContext(ctx, "plugin deleted", "plugin_id", pluginID)
+
+	return registryv1.DeletePluginResponse_builder{}.Build(), nil
+}
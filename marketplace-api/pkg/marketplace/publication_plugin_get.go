@@ -0,0 +1,35 @@
+package marketplace
+
+import (
+	"context"
+	"fmt"
+
+	"connectrpc.com/connect"
+	"github.com/google/uuid"
+
+	"github.com/fundament-oss/fundament/common/authz"
+	registryv1 "github.com/fundament-oss/fundament/marketplace-api/pkg/proto/gen/registry/v1"
+)
+
+func (s publicationService) GetPlugin(
+	ctx context.Context,
+	req *registryv1.GetPluginRequest,
+) (*registryv1.GetPluginResponse, error) {
+	organizationID, ok := OrganizationIDFromContext(ctx)
+	if !ok {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("organization_id missing from context"))
+	}
+
+	if err := s.checkPermission(ctx, authz.CanView(), authz.Organization(organizationID)); err != nil {
+		return nil, err
+	}
+
+	plugin, err := s.getRegistryPlugin(ctx, uuid.MustParse(req.GetPluginId()))
+	if err != nil {
+		return nil, err
+	}
+
+	return registryv1.GetPluginResponse_builder{
+		Plugin: plugin,
+	}.Build(), nil
+}
@@ -0,0 +1,52 @@
+package marketplace
+
+import (
+	"context"
+	"fmt"
+
+	"connectrpc.com/connect"
+	"github.com/google/uuid"
+
+	"github.com/fundament-oss/fundament/common/authz"
+	db "github.com/fundament-oss/fundament/marketplace-api/pkg/db/gen"
+	registryv1 "github.com/fundament-oss/fundament/marketplace-api/pkg/proto/gen/registry/v1"
+)
+
+func (s publicationService) ListPlugins(
+	ctx context.Context,
+	req *registryv1.ListPluginsRequest,
+) (*registryv1.ListPluginsResponse, error) {
+	organizationID, ok := OrganizationIDFromContext(ctx)
+	if !ok {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("organization_id missing from context"))
+	}
+
+	if err := s.checkPermission(ctx, authz.CanView(), authz.Organization(organizationID)); err != nil {
+		return nil, err
+	}
+
+	plugins, err := s.queries.PluginListByOrganizationID(ctx, db.PluginListByOrganizationIDParams{OrganizationID: organizationID})
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to list plugins: %w", err))
+	}
+
+	pluginIDs := make([]uuid.UUID, 0, len(plugins))
+	for i := range plugins {
+		pluginIDs = append(pluginIDs, plugins[i].ID)
+	}
+
+	rel, err := s.loadPluginRelations(ctx, pluginIDs, true)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to list plugins: %w", err))
+	}
+
+	result := make([]*registryv1.Plugin, 0, len(plugins))
+	for i := range plugins {
+		row := db.PluginGetByIDRow(plugins[i])
+		result = append(result, registryPluginFromRow(&row, rel))
+	}
+
+	return registryv1.ListPluginsResponse_builder{
+		Plugins: result,
+	}.Build(), nil
+}
@@ -0,0 +1,259 @@
+package marketplace
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"connectrpc.com/connect"
+	"github.com/google/uuid"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/fundament-oss/fundament/common/authz"
+	"github.com/fundament-oss/fundament/common/dbconst"
+	"github.com/fundament-oss/fundament/common/rollback"
+	db "github.com/fundament-oss/fundament/marketplace-api/pkg/db/gen"
+	marketplacev1 "github.com/fundament-oss/fundament/marketplace-api/pkg/proto/gen/marketplace/v1"
+	registryv1 "github.com/fundament-oss/fundament/marketplace-api/pkg/proto/gen/registry/v1"
+)
+
+func (s publicationService) UpdatePlugin(
+	ctx context.Context,
+	req *registryv1.UpdatePluginRequest,
+) (*registryv1.UpdatePluginResponse, error) {
+	pluginID := uuid.MustParse(req.GetPluginId())
+
+	if err := s.checkPermissionWithRetry(ctx, authz.CanEdit(), authz.Plugin(pluginID)); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.getOwnPlugin(ctx, pluginID); err != nil {
+		return nil, err
+	}
+
+	tx, err := s.db.Pool.Begin(ctx)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to begin transaction: %w", err))
+	}
+	defer rollback.Rollback(ctx, tx, s.logger)
+
+	qtx := s.queries.WithTx(tx)
+
+	rowsAffected, err := qtx.PluginUpdate(ctx, db.PluginUpdateParams{
+		DisplayName:      req.GetDisplayName(),
+		DescriptionShort: req.GetDescriptionShort(),
+		Description:      req.GetDescription(),
+		Image:            req.GetImage(),
+		AuthorName:       textOrNull(req.GetAuthorName()),
+		AuthorUrl:        textOrNull(req.GetAuthorUrl()),
+		RepositoryUrl:    textOrNull(req.GetRepositoryUrl()),
+		License:          req.GetLicense(),
+		Visibility:       visibilityToDB(req.GetVisibility()),
+		ID:               pluginID,
+	})
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to update plugin: %w", err))
+	}
+
+	if rowsAffected != 1 {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("plugin not found"))
+	}
+
+	if err := replacePluginCategories(ctx, qtx, pluginID, req.GetCategoryIds()); err != nil {
+		return nil, err
+	}
+
+	if err := replacePluginTags(ctx, qtx, pluginID, req.GetTags()); err != nil {
+		return nil, err
+	}
+
+	if err := replacePluginDocumentationLinks(ctx, qtx, pluginID, req.GetDocumentationLinks()); err != nil {
+		return nil, err
+	}
+
+	if err := replacePluginFeatures(ctx, qtx, pluginID, req.GetFeatures()); err != nil {
+		return nil, err
+	}
+
+	if err := replacePluginAllowedOrganizations(ctx, qtx, pluginID, req.GetAllowedOrganizationIds()); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to commit transaction: %w", err))
+	}
+
+	s.logger.InfoContext(ctx, "plugin updated", "plugin_id", pluginID)
+
+	plugin, err := s.getRegistryPlugin(ctx, pluginID)
+	if err != nil {
+		return nil, err
+	}
+
+	return registryv1.UpdatePluginResponse_builder{
+		Plugin: plugin,
+	}.Build(), nil
+}
+
+// parseUUIDs parses and de-duplicates validated id strings.
+func parseUUIDs(ids []string) []uuid.UUID {
+	parsed := make([]uuid.UUID, 0, len(ids))
+	for _, id := range ids {
+		parsed = append(parsed, uuid.MustParse(id))
+	}
+	slices.SortFunc(parsed, func(a, b uuid.UUID) int { return bytes.Compare(a[:], b[:]) })
+	return slices.Compact(parsed)
+}
+
+func replacePluginCategories(ctx context.Context, qtx *db.Queries, pluginID uuid.UUID, categoryIDs []string) error {
+	ids := parseUUIDs(categoryIDs)
+
+	if err := qtx.PluginCategoryDeleteByPluginID(ctx, db.PluginCategoryDeleteByPluginIDParams{PluginID: pluginID}); err != nil {
+		return connect.NewError(connect.CodeInternal, fmt.Errorf("failed to clear plugin categories: %w", err))
+	}
+
+	if len(ids) == 0 {
+		return nil
+	}
+
+	rowsAffected, err := qtx.PluginCategoryCreate(ctx, db.PluginCategoryCreateParams{PluginID: pluginID, CategoryIds: ids})
+	if err != nil {
+		return connect.NewError(connect.CodeInternal, fmt.Errorf("failed to set plugin categories: %w", err))
+	}
+
+	if rowsAffected != int64(len(ids)) {
+		return connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("unknown category_ids"))
+	}
+
+	return nil
+}
+
+func replacePluginTags(ctx context.Context, qtx *db.Queries, pluginID uuid.UUID, tags []string) error {
+	names := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		if name := strings.TrimSpace(tag); name != "" {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+	names = slices.Compact(names)
+
+	if err := qtx.PluginTagDeleteByPluginID(ctx, db.PluginTagDeleteByPluginIDParams{PluginID: pluginID}); err != nil {
+		return connect.NewError(connect.CodeInternal, fmt.Errorf("failed to clear plugin tags: %w", err))
+	}
+
+	if len(names) == 0 {
+		return nil
+	}
+
+	if err := qtx.TagCreateIfNotExists(ctx, db.TagCreateIfNotExistsParams{Names: names}); err != nil {
+		return connect.NewError(connect.CodeInternal, fmt.Errorf("failed to create tags: %w", err))
+	}
+
+	if err := qtx.PluginTagCreate(ctx, db.PluginTagCreateParams{PluginID: pluginID, Names: names}); err != nil {
+		return connect.NewError(connect.CodeInternal, fmt.Errorf("failed to set plugin tags: %w", err))
+	}
+
+	return nil
+}
+
+// replacePluginDocumentationLinks keeps the ids of links that are sent back,
+// so a console that edits one link does not churn the ids of the others.
+func replacePluginDocumentationLinks(ctx context.Context, qtx *db.Queries, pluginID uuid.UUID, links []*marketplacev1.DocumentationLink) error {
+	keepIDs := make([]uuid.UUID, 0, len(links))
+	for _, link := range links {
+		if link.GetId() == "" {
+			continue
+		}
+		id, err := uuid.Parse(link.GetId())
+		if err != nil {
+			return connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid documentation link id: %w", err))
+		}
+		keepIDs = append(keepIDs, id)
+	}
+
+	if err := qtx.PluginDocumentationLinkDeleteExcept(ctx, db.PluginDocumentationLinkDeleteExceptParams{
+		PluginID: pluginID,
+		KeepIds:  keepIDs,
+	}); err != nil {
+		return connect.NewError(connect.CodeInternal, fmt.Errorf("failed to clear plugin documentation links: %w", err))
+	}
+
+	for _, link := range links {
+		if link.GetId() == "" {
+			if err := qtx.PluginDocumentationLinkCreate(ctx, db.PluginDocumentationLinkCreateParams{
+				PluginID: pluginID,
+				Title:    link.GetTitle(),
+				UrlName:  link.GetUrlName(),
+				Url:      link.GetUrl(),
+			}); err != nil {
+				return connect.NewError(connect.CodeInternal, fmt.Errorf("failed to create plugin documentation link: %w", err))
+			}
+			continue
+		}
+
+		rowsAffected, err := qtx.PluginDocumentationLinkUpdate(ctx, db.PluginDocumentationLinkUpdateParams{
+			Title:    link.GetTitle(),
+			UrlName:  link.GetUrlName(),
+			Url:      link.GetUrl(),
+			ID:       uuid.MustParse(link.GetId()),
+			PluginID: pluginID,
+		})
+		if err != nil {
+			return connect.NewError(connect.CodeInternal, fmt.Errorf("failed to update plugin documentation link: %w", err))
+		}
+		if rowsAffected != 1 {
+			return connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("documentation link %s does not belong to this plugin", link.GetId()))
+		}
+	}
+
+	return nil
+}
+
+func replacePluginFeatures(ctx context.Context, qtx *db.Queries, pluginID uuid.UUID, features []*marketplacev1.FeatureBlock) error {
+	if err := qtx.PluginFeatureDeleteByPluginID(ctx, db.PluginFeatureDeleteByPluginIDParams{PluginID: pluginID}); err != nil {
+		return connect.NewError(connect.CodeInternal, fmt.Errorf("failed to clear plugin features: %w", err))
+	}
+
+	for i, feature := range features {
+		if err := qtx.PluginFeatureCreate(ctx, db.PluginFeatureCreateParams{
+			PluginID: pluginID,
+			Ordinal:  int32(i), //nolint:gosec // bounded by the request's max_items
+			Title:    feature.GetTitle(),
+			Body:     feature.GetBody(),
+		}); err != nil {
+			return connect.NewError(connect.CodeInternal, fmt.Errorf("failed to create plugin feature: %w", err))
+		}
+	}
+
+	return nil
+}
+
+func replacePluginAllowedOrganizations(ctx context.Context, qtx *db.Queries, pluginID uuid.UUID, organizationIDs []string) error {
+	ids := parseUUIDs(organizationIDs)
+
+	if err := qtx.PluginAllowedOrganizationDeleteByPluginID(ctx, db.PluginAllowedOrganizationDeleteByPluginIDParams{PluginID: pluginID}); err != nil {
+		return connect.NewError(connect.CodeInternal, fmt.Errorf("failed to clear plugin allowed organizations: %w", err))
+	}
+
+	if len(ids) == 0 {
+		return nil
+	}
+
+	if err := qtx.PluginAllowedOrganizationCreate(ctx, db.PluginAllowedOrganizationCreateParams{
+		PluginID:        pluginID,
+		OrganizationIds: ids,
+	}); err != nil {
+		if pgErr, ok := errors.AsType[*pgconn.PgError](err); ok && pgErr.Code == pgerrcode.ForeignKeyViolation &&
+			pgErr.ConstraintName == dbconst.ConstraintPluginsAllowedOrganizationsFkOrganization {
+			return connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("unknown allowed_organization_ids"))
+		}
+		return connect.NewError(connect.CodeInternal, fmt.Errorf("failed to set plugin allowed organizations: %w", err))
+	}
+
+	return nil
+}
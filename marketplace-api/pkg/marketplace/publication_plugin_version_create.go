@@ -0,0 +1,92 @@
+package marketplace
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"connectrpc.com/connect"
+	"github.com/google/uuid"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/fundament-oss/fundament/common/authz"
+	"github.com/fundament-oss/fundament/common/dbconst"
+	db "github.com/fundament-oss/fundament/marketplace-api/pkg/db/gen"
+	registryv1 "github.com/fundament-oss/fundament/marketplace-api/pkg/proto/gen/registry/v1"
+	"github.com/fundament-oss/fundament/plugin-sdk/pluginruntime"
+)
+
+// maxManifestBytes mirrors the max_len on CreatePluginVersionRequest.manifest.
+// The transport read limit in server.go is derived from it.
+const maxManifestBytes = 1 << 20
+
+func (s publicationService) CreatePluginVersion(
+	ctx context.Context,
+	req *registryv1.CreatePluginVersionRequest,
+) (*registryv1.CreatePluginVersionResponse, error) {
+	pluginID := uuid.MustParse(req.GetPluginId())
+
+	if err := s.checkPermissionWithRetry(ctx, authz.CanEdit(), authz.Plugin(pluginID)); err != nil {
+		return nil, err
+	}
+
+	plugin, err := s.getOwnPlugin(ctx, pluginID)
+	if err != nil {
+		return nil, err
+	}
+
+	manifest := req.GetManifest()
+
+	def, err := pluginruntime.ParseDefinition(manifest)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid manifest: %w", err))
+	}
+
+	// Same invariants as organization-api's PutPluginDefinition: an approved
+	// version is served keyed on (name, version), so the manifest has to agree
+	// with both.
+	if def.Metadata.Name != plugin.Name {
+		return nil, connect.NewError(connect.CodeInvalidArgument,
+			fmt.Errorf("manifest metadata.name %q does not match plugin %q", def.Metadata.Name, plugin.Name))
+	}
+	if def.Metadata.Version != req.GetVersion() {
+		return nil, connect.NewError(connect.CodeInvalidArgument,
+			fmt.Errorf("version %q does not match manifest metadata.version %q", req.GetVersion(), def.Metadata.Version))
+	}
+
+	versionID, err := s.queries.PluginVersionCreate(ctx, db.PluginVersionCreateParams{
+		PluginID:      pluginID,
+		PluginVersion: req.GetVersion(),
+		Manifest:      manifest,
+		Hash:          pluginruntime.HashManifest(manifest),
+		ReleaseNotes:  req.GetReleaseNotes(),
+	})
+	if err != nil {
+		if pgErr, ok := errors.AsType[*pgconn.PgError](err); ok {
+			switch {
+			case pgErr.Code == pgerrcode.UniqueViolation && pgErr.ConstraintName == dbconst.ConstraintPluginDefinitionsUqPluginVersion:
+				return nil, connect.NewError(connect.CodeAlreadyExists,
+					fmt.Errorf("version %s of plugin %s already exists", req.GetVersion(), plugin.Name))
+			case pgErr.Code == pgerrcode.InsufficientPrivilege:
+				return nil, connect.NewError(connect.CodePermissionDenied, fmt.Errorf("permission denied"))
+			}
+		}
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to create plugin version: %w", err))
+	}
+
+	s.logger.InfoContext(ctx, "plugin version created",
+		"plugin_id", pluginID,
+		"plugin_version_id", versionID,
+		"version", req.GetVersion(),
+	)
+
+	version, err := s.getOwnPluginVersion(ctx, versionID)
+	if err != nil {
+		return nil, err
+	}
+
+	return registryv1.CreatePluginVersionResponse_builder{
+		Version: registryPluginVersionFromRow(version),
+	}.Build(), nil
+}
@@ -0,0 +1,35 @@
+package marketplace
+
+import (
+	"context"
+	"fmt"
+
+	"connectrpc.com/connect"
+	"github.com/google/uuid"
+
+	"github.com/fundament-oss/fundament/common/authz"
+	registryv1 "github.com/fundament-oss/fundament/marketplace-api/pkg/proto/gen/registry/v1"
+)
+
+func (s publicationService) GetPluginVersion(
+	ctx context.Context,
+	req *registryv1.GetPluginVersionRequest,
+) (*registryv1.GetPluginVersionResponse, error) {
+	organizationID, ok := OrganizationIDFromContext(ctx)
+	if !ok {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("organization_id missing from context"))
+	}
+
+	if err := s.checkPermission(ctx, authz.CanView(), authz.Organization(organizationID)); err != nil {
+		return nil, err
+	}
+
+	version, err := s.getOwnPluginVersion(ctx, uuid.MustParse(req.GetPluginVersionId()))
+	if err != nil {
+		return nil, err
+	}
+
+	return registryv1.GetPluginVersionResponse_builder{
+		Version: registryPluginVersionFromRow(version),
+	}.Build(), nil
+}
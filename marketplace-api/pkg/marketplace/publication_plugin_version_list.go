@@ -0,0 +1,47 @@
+package marketplace
+
+import (
+	"context"
+	"fmt"
+
+	"connectrpc.com/connect"
+	"github.com/google/uuid"
+
+	"github.com/fundament-oss/fundament/common/authz"
+	db "github.com/fundament-oss/fundament/marketplace-api/pkg/db/gen"
+	registryv1 "github.com/fundament-oss/fundament/marketplace-api/pkg/proto/gen/registry/v1"
+)
+
+func (s publicationService) ListPluginVersions(
+	ctx context.Context,
+	req *registryv1.ListPluginVersionsRequest,
+) (*registryv1.ListPluginVersionsResponse, error) {
+	organizationID, ok := OrganizationIDFromContext(ctx)
+	if !ok {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("organization_id missing from context"))
+	}
+
+	if err := s.checkPermission(ctx, authz.CanView(), authz.Organization(organizationID)); err != nil {
+		return nil, err
+	}
+
+	plugin, err := s.getOwnPlugin(ctx, uuid.MustParse(req.GetPluginId()))
+	if err != nil {
+		return nil, err
+	}
+
+	versions, err := s.queries.PluginVersionListByPluginID(ctx, db.PluginVersionListByPluginIDParams{PluginID: plugin.ID})
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to list plugin versions: %w", err))
+	}
+
+	result := make([]*registryv1.PluginVersion, 0, len(versions))
+	for i := range versions {
+		row := db.PluginVersionGetByIDRow(versions[i])
+		result = append(result, registryPluginVersionFromRow(&row))
+	}
+
+	return registryv1.ListPluginVersionsResponse_builder{
+		Versions: result,
+	}.Build(), nil
+}
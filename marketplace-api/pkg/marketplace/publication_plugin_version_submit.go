@@ -0,0 +1,92 @@
+package marketplace
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"connectrpc.com/connect"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/fundament-oss/fundament/common/authz"
+	"github.com/fundament-oss/fundament/common/dbconst"
+	"github.com/fundament-oss/fundament/common/rollback"
+	db "github.com/fundament-oss/fundament/marketplace-api/pkg/db/gen"
+	registryv1 "github.com/fundament-oss/fundament/marketplace-api/pkg/proto/gen/registry/v1"
+)
+
+func (s publicationService) SubmitPluginVersion(
+	ctx context.Context,
+	req *registryv1.SubmitPluginVersionRequest,
+) (*registryv1.SubmitPluginVersionResponse, error) {
+	userID, ok := UserIDFromContext(ctx)
+	if !ok {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("user_id missing from context"))
+	}
+
+	version, err := s.getOwnPluginVersion(ctx, uuid.MustParse(req.GetPluginVersionId()))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.checkPermissionWithRetry(ctx, authz.CanEdit(), authz.Plugin(version.PluginID)); err != nil {
+		return nil, err
+	}
+
+	tx, err := s.db.Pool.Begin(ctx)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to begin transaction: %w", err))
+	}
+	defer rollback.Rollback(ctx, tx, s.logger)
+
+	qtx := s.queries.WithTx(tx)
+
+	locked, err := qtx.PluginVersionGetForUpdate(ctx, db.PluginVersionGetForUpdateParams{ID: version.ID})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("plugin version not found"))
+		}
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to lock plugin version: %w", err))
+	}
+
+	switch locked.Status {
+	case dbconst.PluginDefinitionStatus_Draft,
+		dbconst.PluginDefinitionStatus_ChangesRequested,
+		dbconst.PluginDefinitionStatus_Withdrawn:
+	default:
+		return nil, connect.NewError(connect.CodeFailedPrecondition,
+			fmt.Errorf("a %s version cannot be submitted", locked.Status))
+	}
+
+	if _, err := qtx.PluginVersionSubmit(ctx, db.PluginVersionSubmitParams{ID: locked.ID}); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to submit plugin version: %w", err))
+	}
+
+	submissionID, err := qtx.SubmissionCreate(ctx, db.SubmissionCreateParams{
+		PluginDefinitionID: locked.ID,
+		SubmitterUserID:    userID,
+	})
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to create submission: %w", err))
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to commit transaction: %w", err))
+	}
+
+	s.logger.InfoContext(ctx, "plugin version submitted",
+		"plugin_version_id", locked.ID,
+		"submission_id", submissionID,
+		"user_id", userID,
+	)
+
+	version, err = s.getOwnPluginVersion(ctx, locked.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return registryv1.SubmitPluginVersionResponse_builder{
+		Version: registryPluginVersionFromRow(version),
+	}.Build(), nil
+}
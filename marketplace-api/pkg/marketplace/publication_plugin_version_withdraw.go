@@ -0,0 +1,78 @@
+package marketplace
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"connectrpc.com/connect"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/fundament-oss/fundament/common/authz"
+	"github.com/fundament-oss/fundament/common/dbconst"
+	"github.com/fundament-oss/fundament/common/rollback"
+	db "github.com/fundament-oss/fundament/marketplace-api/pkg/db/gen"
+	registryv1 "github.com/fundament-oss/fundament/marketplace-api/pkg/proto/gen/registry/v1"
+)
+
+func (s publicationService) WithdrawPluginVersion(
+	ctx context.Context,
+	req *registryv1.WithdrawPluginVersionRequest,
+) (*registryv1.WithdrawPluginVersionResponse, error) {
+	version, err := s.getOwnPluginVersion(ctx, uuid.MustParse(req.GetPluginVersionId()))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.checkPermissionWithRetry(ctx, authz.CanEdit(), authz.Plugin(version.PluginID)); err != nil {
+		return nil, err
+	}
+
+	tx, err := s.db.Pool.Begin(ctx)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to begin transaction: %w", err))
+	}
+	defer rollback.Rollback(ctx, tx, s.logger)
+
+	qtx := s.queries.WithTx(tx)
+
+	locked, err := qtx.PluginVersionGetForUpdate(ctx, db.PluginVersionGetForUpdateParams{ID: version.ID})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("plugin version not found"))
+		}
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to lock plugin version: %w", err))
+	}
+
+	if locked.Status != dbconst.PluginDefinitionStatus_Pending {
+		return nil, connect.NewError(connect.CodeFailedPrecondition,
+			fmt.Errorf("only a pending version can be withdrawn, this one is %s", locked.Status))
+	}
+
+	if _, err := qtx.PluginVersionUpdateStatus(ctx, db.PluginVersionUpdateStatusParams{
+		Status: dbconst.PluginDefinitionStatus_Withdrawn,
+		ID:     locked.ID,
+	}); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to withdraw plugin version: %w", err))
+	}
+
+	if _, err := qtx.SubmissionWithdraw(ctx, db.SubmissionWithdrawParams{PluginDefinitionID: locked.ID}); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to close submission: %w", err))
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to commit transaction: %w", err))
+	}
+
+	s.logger.InfoContext(ctx, "plugin version withdrawn", "plugin_version_id", locked.ID)
+
+	version, err = s.getOwnPluginVersion(ctx, locked.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return registryv1.WithdrawPluginVersionResponse_builder{
+		Version: registryPluginVersionFromRow(version),
+	}.Build(), nil
+}
@@ -0,0 +1,249 @@
+package marketplace_test
+
+import (
+	"fmt"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	marketplacev1 "github.com/fundament-oss/fundament/marketplace-api/pkg/proto/gen/marketplace/v1"
+	registryv1 "github.com/fundament-oss/fundament/marketplace-api/pkg/proto/gen/registry/v1"
+)
+
+func testManifest(name, version string) []byte {
+	return fmt.Appendf(nil, "apiVersion: fundament.io/v1\nkind: PluginDefinition\nmetadata:\n  name: %s\n  version: %s\nspec:\n  image: repo@sha256:deadbeefdeadbeefdeadbeefdeadbeefdeadbeefdeadbeefdeadbeefdeadbeef\n  permissions:\n    capabilities: [internet_access]\n    rbac:\n      - apiGroups: [cert-manager.io]\n        resources: [certificates]\n        verbs: [get, list, create]\n", name, version)
+}
+
+func createTestPlugin(t *testing.T, env *testEnv, userID, orgID uuid.UUID, name string, visibility registryv1.PluginVisibility) *registryv1.Plugin {
+	t.Helper()
+
+	resp, err := env.publicationClient().CreatePlugin(env.authedContext(t, userID, orgID), registryv1.CreatePluginRequest_builder{
+		Name:             name,
+		DisplayName:      "Plugin " + name,
+		DescriptionShort: "A test plugin",
+		Tags:             []string{"testing", "testing", "monitoring"},
+		License:          "Apache-2.0",
+		Visibility:       visibility,
+	}.Build())
+	require.NoError(t, err)
+
+	return resp.GetPlugin()
+}
+
+func createTestPluginVersion(t *testing.T, env *testEnv, userID, orgID uuid.UUID, plugin *registryv1.Plugin, version string) *registryv1.PluginVersion {
+	t.Helper()
+
+	resp, err := env.publicationClient().CreatePluginVersion(env.authedContext(t, userID, orgID), registryv1.CreatePluginVersionRequest_builder{
+		PluginId:     plugin.GetId(),
+		Version:      version,
+		Manifest:     testManifest(plugin.GetName(), version),
+		ReleaseNotes: "Initial release",
+	}.Build())
+	require.NoError(t, err)
+
+	return resp.GetVersion()
+}
+
+func Test_Publication_CreatePlugin(t *testing.T) {
+	t.Parallel()
+
+	orgID := uuid.New()
+	userID := uuid.New()
+
+	env := newTestAPI(t,
+		WithOrganization(orgID, "publisher"),
+		WithUser(&UserArgs{ID: userID, Name: "developer", OrgIDs: []uuid.UUID{orgID}}),
+	)
+
+	plugin := createTestPlugin(t, env, userID, orgID, "create-test", registryv1.PluginVisibility_PLUGIN_VISIBILITY_PUBLIC)
+
+	assert.Equal(t, "create-test", plugin.GetName())
+	assert.Equal(t, orgID.String(), plugin.GetOrganizationId())
+	assert.ElementsMatch(t, []string{"monitoring", "testing"}, plugin.GetTags())
+	assert.Equal(t, registryv1.PluginVisibility_PLUGIN_VISIBILITY_PUBLIC, plugin.GetVisibility())
+	assert.Empty(t, plugin.GetLatestPublishedVersionId())
+
+	t.Run("duplicate name", func(t *testing.T) {
+		_, err := env.publicationClient().CreatePlugin(env.authedContext(t, userID, orgID), registryv1.CreatePluginRequest_builder{
+			Name:        "create-test",
+			DisplayName: "Again",
+			Visibility:  registryv1.PluginVisibility_PLUGIN_VISIBILITY_PUBLIC,
+		}.Build())
+		require.Error(t, err)
+		assert.Equal(t, connect.CodeAlreadyExists, connect.CodeOf(err))
+	})
+
+	t.Run("unknown category", func(t *testing.T) {
+		_, err := env.publicationClient().CreatePlugin(env.authedContext(t, userID, orgID), registryv1.CreatePluginRequest_builder{
+			Name:        "category-test",
+			DisplayName: "Category test",
+			CategoryIds: []string{uuid.New().String()},
+			Visibility:  registryv1.PluginVisibility_PLUGIN_VISIBILITY_PUBLIC,
+		}.Build())
+		require.Error(t, err)
+		assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+	})
+}
+
+func Test_Publication_UpdatePlugin_ReplacesChildren(t *testing.T) {
+	t.Parallel()
+
+	orgID := uuid.New()
+	allowedOrgID := uuid.New()
+	userID := uuid.New()
+
+	env := newTestAPI(t,
+		WithOrganization(orgID, "publisher"),
+		WithOrganization(allowedOrgID, "customer"),
+		WithUser(&UserArgs{ID: userID, Name: "developer", OrgIDs: []uuid.UUID{orgID}}),
+	)
+
+	plugin := createTestPlugin(t, env, userID, orgID, "update-test", registryv1.PluginVisibility_PLUGIN_VISIBILITY_PUBLIC)
+	client := env.publicationClient()
+
+	update := func(links []*marketplacev1.DocumentationLink) *registryv1.Plugin {
+		resp, err := client.UpdatePlugin(env.authedContext(t, userID, orgID), registryv1.UpdatePluginRequest_builder{
+			PluginId:           plugin.GetId(),
+			DisplayName:        "Updated",
+			Tags:               []string{"observability"},
+			DocumentationLinks: links,
+			Features: []*marketplacev1.FeatureBlock{
+				marketplacev1.FeatureBlock_builder{Title: "First", Body: "one"}.Build(),
+				marketplacev1.FeatureBlock_builder{Title: "Second", Body: "two"}.Build(),
+			},
+			Visibility:             registryv1.PluginVisibility_PLUGIN_VISIBILITY_RESTRICTED,
+			AllowedOrganizationIds: []string{allowedOrgID.String()},
+			AuthorName:             "Upstream",
+		}.Build())
+		require.NoError(t, err)
+		return resp.GetPlugin()
+	}
+
+	updated := update([]*marketplacev1.DocumentationLink{
+		marketplacev1.DocumentationLink_builder{Title: "Docs", UrlName: "Guide", Url: "https://example.com/guide"}.Build(),
+	})
+
+	assert.Equal(t, "Updated", updated.GetDisplayName())
+	assert.Equal(t, []string{"observability"}, updated.GetTags())
+	assert.Equal(t, "Upstream", updated.GetAuthorName())
+	assert.Equal(t, registryv1.PluginVisibility_PLUGIN_VISIBILITY_RESTRICTED, updated.GetVisibility())
+	assert.Equal(t, []string{allowedOrgID.String()}, updated.GetAllowedOrganizationIds())
+	require.Len(t, updated.GetFeatures(), 2)
+	assert.Equal(t, "First", updated.GetFeatures()[0].GetTitle())
+	require.Len(t, updated.GetDocumentationLinks(), 1)
+
+	// Sending the link back with its id keeps the id.
+	link := updated.GetDocumentationLinks()[0]
+	link.SetUrlName("Handbook")
+	again := update([]*marketplacev1.DocumentationLink{link})
+	require.Len(t, again.GetDocumentationLinks(), 1)
+	assert.Equal(t, link.GetId(), again.GetDocumentationLinks()[0].GetId())
+	assert.Equal(t, "Handbook", again.GetDocumentationLinks()[0].GetUrlName())
+}
+
+func Test_Publication_OtherOrganization(t *testing.T) {
+	t.Parallel()
+
+	ownerOrgID := uuid.New()
+	otherOrgID := uuid.New()
+	ownerID := uuid.New()
+	otherID := uuid.New()
+
+	env := newTestAPI(t,
+		WithOrganization(ownerOrgID, "owner"),
+		WithOrganization(otherOrgID, "other"),
+		WithUser(&UserArgs{ID: ownerID, Name: "owner", OrgIDs: []uuid.UUID{ownerOrgID}}),
+		WithUser(&UserArgs{ID: otherID, Name: "other", OrgIDs: []uuid.UUID{otherOrgID}}),
+	)
+
+	plugin := createTestPlugin(t, env, ownerID, ownerOrgID, "isolation-test", registryv1.PluginVisibility_PLUGIN_VISIBILITY_PUBLIC)
+	client := env.publicationClient()
+	otherCtx := env.authedContext(t, otherID, otherOrgID)
+
+	list, err := client.ListPlugins(otherCtx, registryv1.ListPluginsRequest_builder{}.Build())
+	require.NoError(t, err)
+	assert.Empty(t, list.GetPlugins())
+
+	_, err = client.GetPlugin(otherCtx, registryv1.GetPluginRequest_builder{PluginId: plugin.GetId()}.Build())
+	assert.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+
+	_, err = client.CreatePluginVersion(otherCtx, registryv1.CreatePluginVersionRequest_builder{
+		PluginId: plugin.GetId(),
+		Version:  "v1.0.0",
+		Manifest: testManifest(plugin.GetName(), "v1.0.0"),
+	}.Build())
+	assert.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+
+	_, err = client.DeletePlugin(otherCtx, registryv1.DeletePluginRequest_builder{PluginId: plugin.GetId()}.Build())
+	assert.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+}
+
+func Test_Publication_PluginVersionLifecycle(t *testing.T) {
+	t.Parallel()
+
+	orgID := uuid.New()
+	userID := uuid.New()
+
+	env := newTestAPI(t,
+		WithOrganization(orgID, "publisher"),
+		WithUser(&UserArgs{ID: userID, Name: "developer", OrgIDs: []uuid.UUID{orgID}}),
+	)
+
+	plugin := createTestPlugin(t, env, userID, orgID, "lifecycle-test", registryv1.PluginVisibility_PLUGIN_VISIBILITY_PUBLIC)
+	client := env.publicationClient()
+	ctx := env.authedContext(t, userID, orgID)
+
+	t.Run("manifest must match", func(t *testing.T) {
+		_, err := client.CreatePluginVersion(ctx, registryv1.CreatePluginVersionRequest_builder{
+			PluginId: plugin.GetId(),
+			Version:  "v1.0.0",
+			Manifest: testManifest("another-plugin", "v1.0.0"),
+		}.Build())
+		assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+
+		_, err = client.CreatePluginVersion(ctx, registryv1.CreatePluginVersionRequest_builder{
+			PluginId: plugin.GetId(),
+			Version:  "v1.0.1",
+			Manifest: testManifest(plugin.GetName(), "v1.0.0"),
+		}.Build())
+		assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+	})
+
+	version := createTestPluginVersion(t, env, userID, orgID, plugin, "v1.0.0")
+	assert.Equal(t, marketplacev1.SubmissionStatus_SUBMISSION_STATUS_DRAFT, version.GetStatus())
+	assert.Equal(t, "repo@sha256:deadbeefdeadbeefdeadbeefdeadbeefdeadbeefdeadbeefdeadbeefdeadbeef", version.GetImage())
+	assert.Regexp(t, `^sha256:[0-9a-f]{64}$`, version.GetDefinitionHash())
+
+	_, err := client.CreatePluginVersion(ctx, registryv1.CreatePluginVersionRequest_builder{
+		PluginId: plugin.GetId(),
+		Version:  "v1.0.0",
+		Manifest: testManifest(plugin.GetName(), "v1.0.0"),
+	}.Build())
+	assert.Equal(t, connect.CodeAlreadyExists, connect.CodeOf(err))
+
+	_, err = client.WithdrawPluginVersion(ctx, registryv1.WithdrawPluginVersionRequest_builder{PluginVersionId: version.GetId()}.Build())
+	assert.Equal(t, connect.CodeFailedPrecondition, connect.CodeOf(err), "a draft cannot be withdrawn")
+
+	submitted, err := client.SubmitPluginVersion(ctx, registryv1.SubmitPluginVersionRequest_builder{PluginVersionId: version.GetId()}.Build())
+	require.NoError(t, err)
+	assert.Equal(t, marketplacev1.SubmissionStatus_SUBMISSION_STATUS_PENDING, submitted.GetVersion().GetStatus())
+	assert.NotNil(t, submitted.GetVersion().GetSubmitted())
+
+	_, err = client.SubmitPluginVersion(ctx, registryv1.SubmitPluginVersionRequest_builder{PluginVersionId: version.GetId()}.Build())
+	assert.Equal(t, connect.CodeFailedPrecondition, connect.CodeOf(err), "a pending version cannot be submitted twice")
+
+	withdrawn, err := client.WithdrawPluginVersion(ctx, registryv1.WithdrawPluginVersionRequest_builder{PluginVersionId: version.GetId()}.Build())
+	require.NoError(t, err)
+	assert.Equal(t, marketplacev1.SubmissionStatus_SUBMISSION_STATUS_WITHDRAWN, withdrawn.GetVersion().GetStatus())
+
+	resubmitted, err := client.SubmitPluginVersion(ctx, registryv1.SubmitPluginVersionRequest_builder{PluginVersionId: version.GetId()}.Build())
+	require.NoError(t, err)
+	assert.Equal(t, marketplacev1.SubmissionStatus_SUBMISSION_STATUS_PENDING, resubmitted.GetVersion().GetStatus())
+
+	versions, err := client.ListPluginVersions(ctx, registryv1.ListPluginVersionsRequest_builder{PluginId: plugin.GetId()}.Build())
+	require.NoError(t, err)
+	require.Len(t, versions.GetVersions(), 1)
+}
@@ -0,0 +1,62 @@
+package marketplace
+
+import (
+	"context"
+	"fmt"
+
+	"connectrpc.com/connect"
+
+	adminv1 "github.com/fundament-oss/fundament/marketplace-api/pkg/proto/gen/admin/v1"
+	catalogv1 "github.com/fundament-oss/fundament/marketplace-api/pkg/proto/gen/catalog/v1"
+	marketplacev1 "github.com/fundament-oss/fundament/marketplace-api/pkg/proto/gen/marketplace/v1"
+)
+
+func (s catalogService) ListPublishers(
+	ctx context.Context,
+	req *catalogv1.ListPublishersRequest,
+) (*catalogv1.ListPublishersResponse, error) {
+	rows, err := s.queries.PublisherListPublic(ctx)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to list publishers: %w", err))
+	}
+
+	publishers := make([]*marketplacev1.Publisher, 0, len(rows))
+	for _, row := range rows {
+		publishers = append(publishers, marketplacev1.Publisher_builder{
+			Id:          row.ID.String(),
+			Name:        row.Name,
+			DisplayName: row.Alias,
+		}.Build())
+	}
+
+	return catalogv1.ListPublishersResponse_builder{
+		Publishers: publishers,
+	}.Build(), nil
+}
+
+func (s reviewService) ListPublishers(
+	ctx context.Context,
+	req *adminv1.ListPublishersRequest,
+) (*adminv1.ListPublishersResponse, error) {
+	if err := s.checkReviewer(ctx); err != nil {
+		return nil, err
+	}
+
+	rows, err := s.queries.PublisherList(ctx)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to list publishers: %w", err))
+	}
+
+	publishers := make([]*marketplacev1.Publisher, 0, len(rows))
+	for _, row := range rows {
+		publishers = append(publishers, marketplacev1.Publisher_builder{
+			Id:          row.ID.String(),
+			Name:        row.Name,
+			DisplayName: row.Alias,
+		}.Build())
+	}
+
+	return adminv1.ListPublishersResponse_builder{
+		Publishers: publishers,
+	}.Build(), nil
+}
@@ -0,0 +1,84 @@
+package marketplace
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"connectrpc.com/connect"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	db "github.com/fundament-oss/fundament/marketplace-api/pkg/db/gen"
+	adminv1 "github.com/fundament-oss/fundament/marketplace-api/pkg/proto/gen/admin/v1"
+	"github.com/fundament-oss/fundament/plugin-sdk/pluginruntime"
+)
+
+func (s *Server) getSubmission(ctx context.Context, submissionID uuid.UUID) (*adminv1.Submission, error) {
+	submission, err := s.queries.SubmissionGetByID(ctx, db.SubmissionGetByIDParams{ID: submissionID})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("submission not found"))
+		}
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to get submission: %w", err))
+	}
+
+	return adminSubmissionFromRow(&submission), nil
+}
+
+func adminSubmissionFromRow(submission *db.SubmissionGetByIDRow) *adminv1.Submission {
+	return adminv1.Submission_builder{
+		Id:              submission.ID.String(),
+		PluginId:        submission.PluginID.String(),
+		PluginVersionId: submission.PluginDefinitionID.String(),
+		OrganizationId:  submission.OrganizationID.String(),
+		SubmitterUserId: submission.SubmitterUserID.String(),
+		Status:          submissionStatusFromSubmission(submission.Status),
+		Submitted:       timestamppb.New(submission.Submitted.Time),
+		Reviewed:        optionalTimestamp(submission.Reviewed),
+		ReviewerUserId:  optionalUUID(submission.ReviewerUserID),
+		RejectionReason: rejectionReasonFromDB(submission.RejectionReason),
+		Feedback:        submission.Feedback,
+	}.Build()
+}
+
+func adminPluginFromRow(plugin *db.PluginGetByIDRow, rel *pluginRelations) *adminv1.Plugin {
+	return adminv1.Plugin_builder{
+		Id:               plugin.ID.String(),
+		Name:             plugin.Name,
+		DisplayName:      plugin.DisplayName,
+		DescriptionShort: plugin.DescriptionShort,
+		Description:      plugin.Description,
+		OrganizationId:   plugin.OrganizationID.String(),
+		Image:            plugin.Image,
+		CategoryIds:      rel.categoryIDs[plugin.ID],
+		Tags:             rel.tags[plugin.ID],
+		Created:          timestamppb.New(plugin.Created.Time),
+		Updated:          timestamppb.New(plugin.Updated.Time),
+	}.Build()
+}
+
+// adminPluginVersionFromRow includes what the version would be granted on
+// install, since that is what a reviewer signs off on.
+func adminPluginVersionFromRow(version *db.PluginVersionGetByIDRow) (*adminv1.PluginVersion, error) {
+	def, err := pluginruntime.ParseDefinition(version.Manifest)
+	if err != nil {
+		return nil, fmt.Errorf("parsing plugin definition: %w", err)
+	}
+
+	return adminv1.PluginVersion_builder{
+		Id:             version.ID.String(),
+		PluginId:       version.PluginID.String(),
+		Version:        version.PluginVersion,
+		Image:          def.Spec.Image,
+		DefinitionHash: version.Hash,
+		ReleaseNotes:   version.ReleaseNotes,
+		Status:         submissionStatusFromVersion(version.Status),
+		Capabilities:   def.Spec.Permissions.Capabilities,
+		Permissions:    permissionsFromRules(def.Spec.Permissions.RBAC),
+		Created:        timestamppb.New(version.Created.Time),
+		Submitted:      optionalTimestamp(version.Submitted),
+		Published:      optionalTimestamp(version.Published),
+	}.Build(), nil
+}
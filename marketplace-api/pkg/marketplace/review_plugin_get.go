@@ -0,0 +1,42 @@
+package marketplace
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"connectrpc.com/connect"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	db "github.com/fundament-oss/fundament/marketplace-api/pkg/db/gen"
+	adminv1 "github.com/fundament-oss/fundament/marketplace-api/pkg/proto/gen/admin/v1"
+)
+
+func (s reviewService) GetPlugin(
+	ctx context.Context,
+	req *adminv1.GetPluginRequest,
+) (*adminv1.GetPluginResponse, error) {
+	if err := s.checkReviewer(ctx); err != nil {
+		return nil, err
+	}
+
+	pluginID := uuid.MustParse(req.GetPluginId())
+
+	plugin, err := s.queries.PluginGetByID(ctx, db.PluginGetByIDParams{ID: pluginID})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("plugin not found"))
+		}
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to get plugin: %w", err))
+	}
+
+	rel, err := s.loadPluginRelations(ctx, []uuid.UUID{pluginID}, false)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to get plugin details: %w", err))
+	}
+
+	return adminv1.GetPluginResponse_builder{
+		Plugin: adminPluginFromRow(&plugin, rel),
+	}.Build(), nil
+}
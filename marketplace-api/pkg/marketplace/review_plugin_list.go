@@ -0,0 +1,46 @@
+package marketplace
+
+import (
+	"context"
+	"fmt"
+
+	"connectrpc.com/connect"
+	"github.com/google/uuid"
+
+	db "github.com/fundament-oss/fundament/marketplace-api/pkg/db/gen"
+	adminv1 "github.com/fundament-oss/fundament/marketplace-api/pkg/proto/gen/admin/v1"
+)
+
+func (s reviewService) ListPlugins(
+	ctx context.Context,
+	req *adminv1.ListPluginsRequest,
+) (*adminv1.ListPluginsResponse, error) {
+	if err := s.checkReviewer(ctx); err != nil {
+		return nil, err
+	}
+
+	plugins, err := s.queries.PluginListSubmitted(ctx)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to list plugins: %w", err))
+	}
+
+	pluginIDs := make([]uuid.UUID, 0, len(plugins))
+	for i := range plugins {
+		pluginIDs = append(pluginIDs, plugins[i].ID)
+	}
+
+	rel, err := s.loadPluginRelations(ctx, pluginIDs, false)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to list plugins: %w", err))
+	}
+
+	result := make([]*adminv1.Plugin, 0, len(plugins))
+	for i := range plugins {
+		row := db.PluginGetByIDRow(plugins[i])
+		result = append(result, adminPluginFromRow(&row, rel))
+	}
+
+	return adminv1.ListPluginsResponse_builder{
+		Plugins: result,
+	}.Build(), nil
+}
@@ -0,0 +1,40 @@
+package marketplace
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"connectrpc.com/connect"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	db "github.com/fundament-oss/fundament/marketplace-api/pkg/db/gen"
+	adminv1 "github.com/fundament-oss/fundament/marketplace-api/pkg/proto/gen/admin/v1"
+)
+
+func (s reviewService) GetPluginVersion(
+	ctx context.Context,
+	req *adminv1.GetPluginVersionRequest,
+) (*adminv1.GetPluginVersionResponse, error) {
+	if err := s.checkReviewer(ctx); err != nil {
+		return nil, err
+	}
+
+	version, err := s.queries.PluginVersionGetByID(ctx, db.PluginVersionGetByIDParams{ID: uuid.MustParse(req.GetPluginVersionId())})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("plugin version not found"))
+		}
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to get plugin version: %w", err))
+	}
+
+	result, err := adminPluginVersionFromRow(&version)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to get plugin version: %w", err))
+	}
+
+	return adminv1.GetPluginVersionResponse_builder{
+		Version: result,
+	}.Build(), nil
+}
@@ -0,0 +1,156 @@
+package marketplace
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"connectrpc.com/connect"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/fundament-oss/fundament/common/dbconst"
+	"github.com/fundament-oss/fundament/common/rollback"
+	db "github.com/fundament-oss/fundament/marketplace-api/pkg/db/gen"
+	adminv1 "github.com/fundament-oss/fundament/marketplace-api/pkg/proto/gen/admin/v1"
+)
+
+func (s reviewService) ApproveSubmission(
+	ctx context.Context,
+	req *adminv1.ApproveSubmissionRequest,
+) (*adminv1.ApproveSubmissionResponse, error) {
+	submission, err := s.decideSubmission(ctx, uuid.MustParse(req.GetSubmissionId()), submissionDecision{
+		status:   dbconst.SubmissionStatus_Approved,
+		feedback: req.GetFeedback(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return adminv1.ApproveSubmissionResponse_builder{
+		Submission: submission,
+	}.Build(), nil
+}
+
+func (s reviewService) RequestChanges(
+	ctx context.Context,
+	req *adminv1.RequestChangesRequest,
+) (*adminv1.RequestChangesResponse, error) {
+	submission, err := s.decideSubmission(ctx, uuid.MustParse(req.GetSubmissionId()), submissionDecision{
+		status:   dbconst.SubmissionStatus_ChangesRequested,
+		feedback: req.GetFeedback(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return adminv1.RequestChangesResponse_builder{
+		Submission: submission,
+	}.Build(), nil
+}
+
+func (s reviewService) RejectSubmission(
+	ctx context.Context,
+	req *adminv1.RejectSubmissionRequest,
+) (*adminv1.RejectSubmissionResponse, error) {
+	submission, err := s.decideSubmission(ctx, uuid.MustParse(req.GetSubmissionId()), submissionDecision{
+		status:          dbconst.SubmissionStatus_Rejected,
+		rejectionReason: rejectionReasonToDB(req.GetReason()),
+		feedback:        req.GetFeedback(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return adminv1.RejectSubmissionResponse_builder{
+		Submission: submission,
+	}.Build(), nil
+}
+
+type submissionDecision struct {
+	status          dbconst.SubmissionStatus
+	rejectionReason pgtype.Text
+	feedback        string
+}
+
+// decideSubmission closes a pending submission and moves its version along
+// with it, in one transaction so the queue and the version never disagree.
+func (s *Server) decideSubmission(ctx context.Context, submissionID uuid.UUID, decision submissionDecision) (*adminv1.Submission, error) {
+	if err := s.checkReviewer(ctx); err != nil {
+		return nil, err
+	}
+
+	userID, ok := UserIDFromContext(ctx)
+	if !ok {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("user_id missing from context"))
+	}
+
+	tx, err := s.db.Pool.Begin(ctx)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to begin transaction: %w", err))
+	}
+	defer rollback.Rollback(ctx, tx, s.logger)
+
+	qtx := s.queries.WithTx(tx)
+
+	submission, err := qtx.SubmissionGetForUpdate(ctx, db.SubmissionGetForUpdateParams{ID: submissionID})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("submission not found"))
+		}
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to lock submission: %w", err))
+	}
+
+	if submission.Status != dbconst.SubmissionStatus_Pending {
+		return nil, connect.NewError(connect.CodeFailedPrecondition,
+			fmt.Errorf("submission is already %s", submission.Status))
+	}
+
+	if _, err := qtx.SubmissionDecide(ctx, db.SubmissionDecideParams{
+		Status:          decision.status,
+		ReviewerUserID:  pgtype.UUID{Bytes: userID, Valid: true},
+		RejectionReason: decision.rejectionReason,
+		Feedback:        decision.feedback,
+		ID:              submission.ID,
+	}); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to record decision: %w", err))
+	}
+
+	var rowsAffected int64
+	switch decision.status {
+	case dbconst.SubmissionStatus_Approved:
+		rowsAffected, err = qtx.PluginVersionPublish(ctx, db.PluginVersionPublishParams{ID: submission.PluginDefinitionID})
+	case dbconst.SubmissionStatus_ChangesRequested:
+		rowsAffected, err = qtx.PluginVersionUpdateStatus(ctx, db.PluginVersionUpdateStatusParams{
+			Status: dbconst.PluginDefinitionStatus_ChangesRequested,
+			ID:     submission.PluginDefinitionID,
+		})
+	case dbconst.SubmissionStatus_Rejected:
+		rowsAffected, err = qtx.PluginVersionUpdateStatus(ctx, db.PluginVersionUpdateStatusParams{
+			Status: dbconst.PluginDefinitionStatus_Rejected,
+			ID:     submission.PluginDefinitionID,
+		})
+	default:
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("unexpected decision %s", decision.status))
+	}
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to update plugin version: %w", err))
+	}
+	if rowsAffected != 1 {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("plugin version %s was not updated", submission.PluginDefinitionID))
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to commit transaction: %w", err))
+	}
+
+	s.logger.InfoContext(ctx, "submission decided",
+		"submission_id", submission.ID,
+		"plugin_version_id", submission.PluginDefinitionID,
+		"status", decision.status,
+		"reviewer_user_id", userID,
+	)
+
+	return s.getSubmission(ctx, submission.ID)
+}
@@ -0,0 +1,27 @@
+package marketplace
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	adminv1 "github.com/fundament-oss/fundament/marketplace-api/pkg/proto/gen/admin/v1"
+)
+
+func (s reviewService) GetSubmission(
+	ctx context.Context,
+	req *adminv1.GetSubmissionRequest,
+) (*adminv1.GetSubmissionResponse, error) {
+	if err := s.checkReviewer(ctx); err != nil {
+		return nil, err
+	}
+
+	submission, err := s.getSubmission(ctx, uuid.MustParse(req.GetSubmissionId()))
+	if err != nil {
+		return nil, err
+	}
+
+	return adminv1.GetSubmissionResponse_builder{
+		Submission: submission,
+	}.Build(), nil
+}
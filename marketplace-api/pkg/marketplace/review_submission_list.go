@@ -0,0 +1,37 @@
+package marketplace
+
+import (
+	"context"
+	"fmt"
+
+	"connectrpc.com/connect"
+
+	db "github.com/fundament-oss/fundament/marketplace-api/pkg/db/gen"
+	adminv1 "github.com/fundament-oss/fundament/marketplace-api/pkg/proto/gen/admin/v1"
+)
+
+func (s reviewService) ListSubmissions(
+	ctx context.Context,
+	req *adminv1.ListSubmissionsRequest,
+) (*adminv1.ListSubmissionsResponse, error) {
+	if err := s.checkReviewer(ctx); err != nil {
+		return nil, err
+	}
+
+	submissions, err := s.queries.SubmissionList(ctx, db.SubmissionListParams{
+		Status: submissionStatusFilter(req.GetStatus()),
+	})
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to list submissions: %w", err))
+	}
+
+	result := make([]*adminv1.Submission, 0, len(submissions))
+	for i := range submissions {
+		row := db.SubmissionGetByIDRow(submissions[i])
+		result = append(result, adminSubmissionFromRow(&row))
+	}
+
+	return adminv1.ListSubmissionsResponse_builder{
+		Submissions: result,
+	}.Build(), nil
+}
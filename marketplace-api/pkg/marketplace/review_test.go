@@ -0,0 +1,163 @@
+package marketplace_test
+
+import (
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	adminv1 "github.com/fundament-oss/fundament/marketplace-api/pkg/proto/gen/admin/v1"
+	catalogv1 "github.com/fundament-oss/fundament/marketplace-api/pkg/proto/gen/catalog/v1"
+	marketplacev1 "github.com/fundament-oss/fundament/marketplace-api/pkg/proto/gen/marketplace/v1"
+	registryv1 "github.com/fundament-oss/fundament/marketplace-api/pkg/proto/gen/registry/v1"
+)
+
+func Test_Review_SubmissionFlow(t *testing.T) {
+	t.Parallel()
+
+	orgID := uuid.New()
+	developerID := uuid.New()
+	reviewerID := uuid.New()
+
+	env := newTestAPI(t,
+		WithOrganization(orgID, "publisher"),
+		WithUser(&UserArgs{ID: developerID, Name: "developer", OrgIDs: []uuid.UUID{orgID}}),
+		WithUser(&UserArgs{ID: reviewerID, Name: "reviewer", OrgIDs: []uuid.UUID{systemOrgID}}),
+	)
+
+	publication := env.publicationClient()
+	review := env.reviewClient()
+	catalog := env.catalogClient()
+	developerCtx := env.authedContext(t, developerID, orgID)
+	reviewerCtx := env.authedContext(t, reviewerID, uuid.Nil)
+
+	plugin := createTestPlugin(t, env, developerID, orgID, "review-test", registryv1.PluginVisibility_PLUGIN_VISIBILITY_PUBLIC)
+	version := createTestPluginVersion(t, env, developerID, orgID, plugin, "v1.0.0")
+
+	_, err := publication.SubmitPluginVersion(developerCtx, registryv1.SubmitPluginVersionRequest_builder{PluginVersionId: version.GetId()}.Build())
+	require.NoError(t, err)
+
+	// RLS keeps the queue to reviewers, independently of the OpenFGA check.
+	notReviewer, err := review.ListSubmissions(env.authedContext(t, developerID, uuid.Nil), adminv1.ListSubmissionsRequest_builder{}.Build())
+	require.NoError(t, err)
+	assert.Empty(t, notReviewer.GetSubmissions())
+
+	pending, err := review.ListSubmissions(reviewerCtx, adminv1.ListSubmissionsRequest_builder{
+		Status: marketplacev1.SubmissionStatus_SUBMISSION_STATUS_PENDING,
+	}.Build())
+	require.NoError(t, err)
+	require.Len(t, pending.GetSubmissions(), 1)
+	submission := pending.GetSubmissions()[0]
+	assert.Equal(t, version.GetId(), submission.GetPluginVersionId())
+	assert.Equal(t, developerID.String(), submission.GetSubmitterUserId())
+
+	reviewVersion, err := review.GetPluginVersion(reviewerCtx, adminv1.GetPluginVersionRequest_builder{PluginVersionId: version.GetId()}.Build())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"internet_access"}, reviewVersion.GetVersion().GetCapabilities())
+
+	changes, err := review.RequestChanges(reviewerCtx, adminv1.RequestChangesRequest_builder{
+		SubmissionId: submission.GetId(),
+		Feedback:     "Please add a description",
+	}.Build())
+	require.NoError(t, err)
+	assert.Equal(t, marketplacev1.SubmissionStatus_SUBMISSION_STATUS_CHANGES_REQUESTED, changes.GetSubmission().GetStatus())
+	assert.Equal(t, reviewerID.String(), changes.GetSubmission().GetReviewerUserId())
+
+	_, err = review.ApproveSubmission(reviewerCtx, adminv1.ApproveSubmissionRequest_builder{SubmissionId: submission.GetId()}.Build())
+	assert.Equal(t, connect.CodeFailedPrecondition, connect.CodeOf(err), "a decided submission cannot be decided again")
+
+	developerVersion, err := publication.GetPluginVersion(developerCtx, registryv1.GetPluginVersionRequest_builder{PluginVersionId: version.GetId()}.Build())
+	require.NoError(t, err)
+	assert.Equal(t, "Please add a description", developerVersion.GetVersion().GetReviewFeedback())
+
+	resubmitted, err := publication.SubmitPluginVersion(developerCtx, registryv1.SubmitPluginVersionRequest_builder{PluginVersionId: version.GetId()}.Build())
+	require.NoError(t, err)
+	assert.Empty(t, resubmitted.GetVersion().GetReviewFeedback())
+
+	// Nothing is public until a version is approved.
+	list, err := catalog.ListPlugins(t.Context(), catalogv1.ListPluginsRequest_builder{Query: "review-test"}.Build())
+	require.NoError(t, err)
+	assert.Empty(t, list.GetPlugins())
+
+	pending, err = review.ListSubmissions(reviewerCtx, adminv1.ListSubmissionsRequest_builder{
+		Status: marketplacev1.SubmissionStatus_SUBMISSION_STATUS_PENDING,
+	}.Build())
+	require.NoError(t, err)
+	require.Len(t, pending.GetSubmissions(), 1)
+
+	approved, err := review.ApproveSubmission(reviewerCtx, adminv1.ApproveSubmissionRequest_builder{
+		SubmissionId: pending.GetSubmissions()[0].GetId(),
+	}.Build())
+	require.NoError(t, err)
+	assert.Equal(t, marketplacev1.SubmissionStatus_SUBMISSION_STATUS_APPROVED, approved.GetSubmission().GetStatus())
+
+	list, err = catalog.ListPlugins(t.Context(), catalogv1.ListPluginsRequest_builder{Query: "Plugin review"}.Build())
+	require.NoError(t, err)
+	require.Len(t, list.GetPlugins(), 1)
+	assert.Equal(t, version.GetId(), list.GetPlugins()[0].GetLatestVersionId())
+	assert.NotNil(t, list.GetPlugins()[0].GetPublished())
+
+	details, err := catalog.GetPlugin(t.Context(), catalogv1.GetPluginRequest_builder{PluginId: plugin.GetId()}.Build())
+	require.NoError(t, err)
+	require.Len(t, details.GetPlugin().GetPermissions(), 1)
+	assert.Equal(t, "Certificates", details.GetPlugin().GetPermissions()[0].GetResource())
+	assert.Equal(t, "Read and write", details.GetPlugin().GetPermissions()[0].GetAccess())
+
+	versions, err := catalog.ListPluginVersions(t.Context(), catalogv1.ListPluginVersionsRequest_builder{PluginId: plugin.GetId()}.Build())
+	require.NoError(t, err)
+	require.Len(t, versions.GetVersions(), 1)
+	assert.Equal(t, "v1.0.0", versions.GetVersions()[0].GetVersion())
+
+	publishers, err := catalog.ListPublishers(t.Context(), catalogv1.ListPublishersRequest_builder{}.Build())
+	require.NoError(t, err)
+	var publisherIDs []string
+	for _, p := range publishers.GetPublishers() {
+		publisherIDs = append(publisherIDs, p.GetId())
+	}
+	assert.Contains(t, publisherIDs, orgID.String())
+}
+
+func Test_Catalog_HidesRestrictedPlugins(t *testing.T) {
+	t.Parallel()
+
+	orgID := uuid.New()
+	developerID := uuid.New()
+	reviewerID := uuid.New()
+
+	env := newTestAPI(t,
+		WithOrganization(orgID, "publisher"),
+		WithUser(&UserArgs{ID: developerID, Name: "developer", OrgIDs: []uuid.UUID{orgID}}),
+		WithUser(&UserArgs{ID: reviewerID, Name: "reviewer", OrgIDs: []uuid.UUID{systemOrgID}}),
+	)
+
+	plugin := createTestPlugin(t, env, developerID, orgID, "restricted-test", registryv1.PluginVisibility_PLUGIN_VISIBILITY_RESTRICTED)
+	version := createTestPluginVersion(t, env, developerID, orgID, plugin, "v1.0.0")
+
+	_, err := env.publicationClient().SubmitPluginVersion(env.authedContext(t, developerID, orgID),
+		registryv1.SubmitPluginVersionRequest_builder{PluginVersionId: version.GetId()}.Build())
+	require.NoError(t, err)
+
+	reviewerCtx := env.authedContext(t, reviewerID, uuid.Nil)
+	pending, err := env.reviewClient().ListSubmissions(reviewerCtx, adminv1.ListSubmissionsRequest_builder{}.Build())
+	require.NoError(t, err)
+	require.Len(t, pending.GetSubmissions(), 1)
+
+	_, err = env.reviewClient().ApproveSubmission(reviewerCtx, adminv1.ApproveSubmissionRequest_builder{
+		SubmissionId: pending.GetSubmissions()[0].GetId(),
+	}.Build())
+	require.NoError(t, err)
+
+	list, err := env.catalogClient().ListPlugins(t.Context(), catalogv1.ListPluginsRequest_builder{Query: "restricted"}.Build())
+	require.NoError(t, err)
+	assert.Empty(t, list.GetPlugins())
+
+	_, err = env.catalogClient().GetPlugin(t.Context(), catalogv1.GetPluginRequest_builder{PluginId: plugin.GetId()}.Build())
+	assert.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+
+	// A literal wildcard in the query must not match everything.
+	list, err = env.catalogClient().ListPlugins(t.Context(), catalogv1.ListPluginsRequest_builder{Query: "%"}.Build())
+	require.NoError(t, err)
+	assert.Empty(t, list.GetPlugins())
+}
@@ -0,0 +1,137 @@
+package marketplace
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+
+	"connectrpc.com/connect"
+	"connectrpc.com/grpcreflect"
+	"connectrpc.com/validate"
+	"github.com/fundament-oss/fundament/common/auth"
+	"github.com/fundament-oss/fundament/common/authz"
+	"github.com/fundament-oss/fundament/common/circuitbreaker"
+	"github.com/fundament-oss/fundament/common/connectrecovery"
+	"github.com/fundament-oss/fundament/common/psqldb"
+	db "github.com/fundament-oss/fundament/marketplace-api/pkg/db/gen"
+	"github.com/fundament-oss/fundament/marketplace-api/pkg/proto/gen/admin/v1/adminv1connect"
+	"github.com/fundament-oss/fundament/marketplace-api/pkg/proto/gen/catalog/v1/catalogv1connect"
+	"github.com/fundament-oss/fundament/marketplace-api/pkg/proto/gen/registry/v1/registryv1connect"
+	"github.com/rs/cors"
+	"github.com/svrana/go-connect-middleware/interceptors/logging"
+)
+
+type Config struct {
+	JWTKeys            auth.Keys
+	CORSAllowedOrigins []string
+}
+
+type Server struct {
+	config         *Config
+	db             *psqldb.DB
+	queries        *db.Queries
+	logger         *slog.Logger
+	authValidator  *auth.Validator
+	authz          *authz.Client
+	circuitBreaker *circuitbreaker.Breaker
+	handler        http.Handler
+}
+
+// The three services share RPC names (ListPlugins, GetPlugin, ...) with
+// different request types, so each gets its own handler type over the shared
+// Server.
+type (
+	catalogService     struct{ *Server }
+	publicationService struct{ *Server }
+	reviewService      struct{ *Server }
+)
+
+// Option configures optional Server dependencies.
+type Option func(*Server)
+
+// WithCircuitBreaker adds a circuit breaker that blocks all requests when tripped.
+func WithCircuitBreaker(b *circuitbreaker.Breaker) Option {
+	return func(s *Server) {
+		s.circuitBreaker = b
+	}
+}
+
+func New(logger *slog.Logger, cfg *Config, database *psqldb.DB, authzClient *authz.Client, opts ...Option) *Server {
+	s := &Server{
+		logger:        logger,
+		config:        cfg,
+		db:            database,
+		queries:       db.New(database.Pool),
+		authValidator: auth.NewValidatorForAudience(cfg.JWTKeys, auth.ConsoleAuthCookieName, auth.ConsoleIssuer, auth.TokenTypeUser, logger),
+		authz:         authzClient,
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	mux := http.NewServeMux()
+	loggingInterceptor := logging.UnaryServerInterceptor(
+		logging.LoggerFunc(func(ctx context.Context, level logging.Level, msg string, fields ...any) {
+			logger.Log(ctx, slog.Level(level), msg, fields...)
+		}),
+		logging.WithLogOnEvents(logging.FinishCall),
+	)
+
+	chain := []connect.Interceptor{
+		connectrecovery.NewInterceptor(logger),
+	}
+
+	// Circuit breaker is placed before auth so that open-breaker requests
+	// are rejected early with CodeUnavailable, avoiding unnecessary auth work.
+	if s.circuitBreaker != nil {
+		chain = append(chain, circuitbreaker.NewInterceptor(s.circuitBreaker))
+	}
+
+	chain = append(chain,
+		s.authInterceptor(),
+		validate.NewInterceptor(),
+		loggingInterceptor,
+	)
+	interceptors := connect.WithInterceptors(chain...)
+
+	catalogPath, catalogHandler := catalogv1connect.NewCatalogServiceHandler(catalogService{s}, interceptors)
+	mux.Handle(catalogPath, catalogHandler)
+
+	// CreatePluginVersion carries a manifest body; cap the read so an oversized
+	// payload is rejected off the wire before it is buffered into memory. The
+	// request message validates the manifest length itself; this backstop sits
+	// a little above it to leave room for proto framing.
+	publicationPath, publicationHandler := registryv1connect.NewPublicationServiceHandler(publicationService{s}, interceptors, connect.WithReadMaxBytes(2*maxManifestBytes))
+	mux.Handle(publicationPath, publicationHandler)
+
+	reviewPath, reviewHandler := adminv1connect.NewReviewServiceHandler(reviewService{s}, interceptors)
+	mux.Handle(reviewPath, reviewHandler)
+
+	// gRPC reflection for API discovery (used by Bruno, grpcurl, etc.)
+	reflector := grpcreflect.NewStaticReflector(
+		catalogv1connect.CatalogServiceName,
+		registryv1connect.PublicationServiceName,
+		adminv1connect.ReviewServiceName,
+	)
+	reflectPath, reflectHandler := grpcreflect.NewHandlerV1(reflector)
+	mux.Handle(reflectPath, reflectHandler)
+	reflectPathAlpha, reflectHandlerAlpha := grpcreflect.NewHandlerV1Alpha(reflector)
+	mux.Handle(reflectPathAlpha, reflectHandlerAlpha)
+
+	corsHandler := cors.New(cors.Options{
+		AllowedOrigins:   cfg.CORSAllowedOrigins,
+		AllowedMethods:   []string{"GET", "POST"},
+		AllowedHeaders:   []string{"Authorization", "Content-Type", "Connect-Protocol-Version", "Connect-Timeout-Ms", "Grpc-Timeout", "X-Grpc-Web", "X-User-Agent", OrganizationHeader},
+		ExposedHeaders:   []string{"Grpc-Status", "Grpc-Message", "Grpc-Status-Details-Bin"},
+		AllowCredentials: true,
+	})
+
+	s.handler = corsHandler.Handler(mux)
+
+	return s
+}
+
+func (s *Server) Handler() http.Handler {
+	return s.handler
+}
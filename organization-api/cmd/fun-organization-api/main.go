@@ -9,12 +9,15 @@ import (
 	"os"
 	"strings"
 	"time"
+	_ "time/tzdata" // hibernation schedule locations; the image has no zoneinfo
 
 	"github.com/caarlos0/env/v11"
 	"github.com/fundament-oss/fundament/organization-api/pkg/clock"
 	"golang.org/x/net/http2"
 	"golang.org/x/net/http2/h2c"
 
+	"github.com/fundament-oss/fundament/common/audit"
+	"github.com/fundament-oss/fundament/common/auth"
 	"github.com/fundament-oss/fundament/common/authz"
 	"github.com/fundament-oss/fundament/common/circuitbreaker"
 	"github.com/fundament-oss/fundament/common/dbversion"
@@ -29,7 +32,8 @@ import (
 type config struct {
 	Database                   psqldb.Config
 	OpenFGA                    authz.Config
-	JWTSecret                  string        `env:"JWT_SECRET,required,notEmpty" `
+	JWTSecret                  string        `env:"JWT_SECRET"`
+	JWKSURL                    string        `env:"JWKS_URL"` // authn-api key set; with JWT_SECRET also set, both are accepted
 	ListenAddr                 string        `env:"LISTEN_ADDR" envDefault:":8080"`
 	LogLevel                   slog.Level    `env:"LOG_LEVEL" envDefault:"info"`
 	CORSAllowedOrigins         []string      `env:"CORS_ALLOWED_ORIGINS"`
@@ -39,6 +43,7 @@ type config struct {
 	GardenerKubeconfig         string        `env:"GARDENER_KUBECONFIG"`
 	CircuitBreakerThreshold    time.Duration `env:"CIRCUIT_BREAKER_THRESHOLD" envDefault:"5s"`
 	CircuitBreakerPollInterval time.Duration `env:"CIRCUIT_BREAKER_POLL_INTERVAL" envDefault:"2s"`
+	AuthzDecisionCacheTTL      time.Duration `env:"AUTHZ_DECISION_CACHE_TTL" envDefault:"1m"` // 0 disables the cache
 	// Served on /version so callers outside the cluster can tell which release
 	// is answering; the previous one keeps serving until Flux reconciles.
 	DeploymentVersion string `env:"DEPLOYMENT_VERSION" envDefault:"unknown"`
@@ -96,7 +101,7 @@ func run() error {
 	q := dbgen.New(db.Pool)
 
 	mockClient := prom.NewMockClient(func(ctx context.Context) ([]prom.ClusterInfo, error) {
-		rows, err := q.ClusterList(ctx)
+		rows, err := q.ClusterList(ctx, dbgen.ClusterListParams{})
 		if err != nil {
 			return nil, err
 		}
@@ -129,6 +134,8 @@ func run() error {
 	idempotencyStore := idempotency.NewStore(db.Pool, idempotency.Config{}, logger)
 	go idempotencyStore.StartCleanup(ctx)
 
+	opts = append(opts, organization.WithAuditLog(audit.NewStore(db.Pool, logger)))
+
 	cbcfg := circuitbreaker.Config{
 		PollInterval: cfg.CircuitBreakerPollInterval,
 	}
@@ -146,6 +153,15 @@ func run() error {
 
 	opts = append(opts, organization.WithCircuitBreaker(breaker))
 
+	if cfg.AuthzDecisionCacheTTL > 0 {
+		decisions := organization.NewDecisionCache(db.Pool, logger, organization.DecisionCacheConfig{
+			TTL: cfg.AuthzDecisionCacheTTL,
+		})
+		go decisions.Start(ctx)
+
+		opts = append(opts, organization.WithDecisionCache(decisions))
+	}
+
 	var gardenerClient gardener.Client = gardener.NoopClient{}
 	if cfg.GardenerKubeconfig != "" {
 		realGardener, err := gardener.NewReal(cfg.GardenerKubeconfig, logger)
@@ -157,8 +173,13 @@ func run() error {
 		logger.Info("GARDENER_KUBECONFIG not set, observability URLs disabled")
 	}
 
+	jwtKeys, err := auth.NewKeys(cfg.JWKSURL, []byte(cfg.JWTSecret), logger)
+	if err != nil {
+		return fmt.Errorf("failed to configure token verification: %w", err)
+	}
+
 	orgcfg := &organization.Config{
-		JWTSecret:            []byte(cfg.JWTSecret),
+		JWTKeys:              jwtKeys,
 		CORSAllowedOrigins:   cfg.CORSAllowedOrigins,
 		Clock:                clock.New(),
 		MockPrometheusClient: mockClient,
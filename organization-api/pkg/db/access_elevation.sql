@@ -0,0 +1,88 @@
+-- name: AccessElevationCreate :one
+INSERT INTO tenant.access_elevations (organization_id, user_id, role, project_id, duration_minutes, reason)
+VALUES (@organization_id, @user_id, @role, sqlc.narg('project_id'), @duration_minutes, @reason)
+RETURNING id;
+
+-- name: AccessElevationGetByID :one
+SELECT id, user_id, role, project_id, status
+FROM tenant.access_elevations
+WHERE id = $1;
+
+-- name: AccessElevationHeldByUser :one
+-- Whether the user currently holds an elevated role, at organization or
+-- project level.
+SELECT EXISTS (
+    SELECT 1
+    FROM tenant.access_elevations
+    WHERE user_id = @user_id
+      AND status = 'approved'
+      AND expires > now()
+);
+
+-- name: AccessElevationEarliestExpiry :one
+-- When the first of the user's current elevated roles ends; NULL when they
+-- hold none.
+SELECT MIN(expires)::timestamptz AS expires
+FROM tenant.access_elevations
+WHERE user_id = @user_id
+  AND status = 'approved'
+  AND expires > now();
+
+-- name: AccessElevationList :many
+-- Newest first, keyset-paginated on (created, id); NULL filters, cursor and limit are ignored.
+SELECT
+    access_elevations.id,
+    access_elevations.user_id,
+    users.name AS user_name,
+    access_elevations.role,
+    access_elevations.project_id,
+    access_elevations.duration_minutes,
+    access_elevations.reason,
+    access_elevations.status,
+    access_elevations.decided_by,
+    access_elevations.decided,
+    access_elevations.expires,
+    access_elevations.ended,
+    access_elevations.created
+FROM tenant.access_elevations
+INNER JOIN tenant.users
+    ON users.id = access_elevations.user_id
+WHERE (sqlc.narg('user_id')::uuid IS NULL OR access_elevations.user_id = sqlc.narg('user_id')::uuid)
+    AND (sqlc.narg('project_id')::uuid IS NULL OR access_elevations.project_id = sqlc.narg('project_id')::uuid)
+    AND (sqlc.narg('status')::text IS NULL OR access_elevations.status = sqlc.narg('status')::text)
+    AND (sqlc.narg('after_created')::timestamptz IS NULL
+        OR (access_elevations.created, access_elevations.id) < (sqlc.narg('after_created')::timestamptz, sqlc.narg('after_id')::uuid))
+ORDER BY access_elevations.created DESC, access_elevations.id DESC
+LIMIT sqlc.narg('page_limit')::integer;
+
+-- name: AccessElevationApprove :one
+-- The role is held for duration_minutes from the moment of approval, not of
+-- the request.
+UPDATE tenant.access_elevations
+SET status = 'approved',
+    decided_by = @decided_by,
+    decided = now(),
+    expires = now() + make_interval(mins => duration_minutes)
+WHERE id = @id AND status = 'pending'
+RETURNING expires;
+
+-- name: AccessElevationDeny :execrows
+UPDATE tenant.access_elevations
+SET status = 'denied',
+    decided_by = @decided_by,
+    decided = now()
+WHERE id = @id AND status = 'pending';
+
+-- name: AccessElevationRevoke :execrows
+-- A pending request is cancelled, an approved elevation revoked.
+UPDATE tenant.access_elevations
+SET status = CASE WHEN status = 'pending' THEN 'cancelled' ELSE 'revoked' END,
+    ended = now()
+WHERE id = $1 AND status IN ('pending', 'approved');
+
+-- name: AccessElevationEndByUser :exec
+-- Ends the open requests and elevations of a user leaving the organization.
+UPDATE tenant.access_elevations
+SET status = CASE WHEN status = 'pending' THEN 'cancelled' ELSE 'revoked' END,
+    ended = now()
+WHERE user_id = $1 AND status IN ('pending', 'approved');
@@ -1,18 +1,25 @@
 -- name: APIKeyCreate :one
-INSERT INTO authn.api_keys (organization_id, user_id, name, token_hash, token_prefix, expires)
-VALUES ($1, $2, $3, $4, $5, $6)
+INSERT INTO authn.api_keys (organization_id, user_id, name, token_hash, token_prefix, expires, scope_project_ids, scope_cluster_ids, scope_actions, scope_read_only)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
 RETURNING id;
 
 -- name: APIKeyGetByID :one
-SELECT id, organization_id, user_id, name, token_prefix, expires, revoked, last_used, created, deleted
+SELECT id, organization_id, user_id, name, token_prefix, expires, revoked, last_used, created, deleted,
+  scope_project_ids, scope_cluster_ids, scope_actions, scope_read_only
 FROM authn.api_keys
 WHERE id = $1 AND user_id = $2 AND deleted IS NULL;
 
 -- name: APIKeyListByOrganizationID :many
-SELECT id, organization_id, user_id, name, token_prefix, expires, revoked, last_used, created, deleted
+-- Newest first, keyset-paginated on (created, id); NULL filters, cursor and limit are ignored.
+SELECT id, organization_id, user_id, name, token_prefix, expires, revoked, last_used, created, deleted,
+  scope_project_ids, scope_cluster_ids, scope_actions, scope_read_only
 FROM authn.api_keys
-WHERE organization_id = $1 AND user_id = $2 AND deleted IS NULL
-ORDER BY created DESC;
+WHERE organization_id = sqlc.arg('organization_id') AND user_id = sqlc.arg('user_id') AND deleted IS NULL
+    AND (sqlc.narg('name_filter')::text IS NULL OR strpos(lower(name), lower(sqlc.narg('name_filter')::text)) > 0)
+    AND (sqlc.narg('after_created')::timestamptz IS NULL
+        OR (created, id) < (sqlc.narg('after_created')::timestamptz, sqlc.narg('after_id')::uuid))
+ORDER BY created DESC, id DESC
+LIMIT sqlc.narg('page_limit')::integer;
 
 -- name: APIKeyRevoke :execrows
 UPDATE authn.api_keys
@@ -0,0 +1,63 @@
+-- name: AuditEventList :many
+-- Newest first, keyset-paginated on (created, id); NULL filters, cursor and limit are ignored.
+-- RLS restricts the events to the current organization.
+SELECT
+    tenant.audit_events.id,
+    tenant.audit_events.source,
+    tenant.audit_events.organization_id,
+    tenant.audit_events.cluster_id,
+    tenant.audit_events.actor_user_id,
+    tenant.audit_events.action,
+    tenant.audit_events.resource_type,
+    tenant.audit_events.resource_id,
+    tenant.audit_events.outcome,
+    tenant.audit_events.error_code,
+    tenant.audit_events.details,
+    tenant.audit_events.remote_addr,
+    tenant.audit_events.user_agent,
+    tenant.audit_events.created
+FROM tenant.audit_events
+WHERE (sqlc.narg('actor_user_id')::uuid IS NULL OR tenant.audit_events.actor_user_id = sqlc.narg('actor_user_id')::uuid)
+    AND (sqlc.narg('action')::text IS NULL OR tenant.audit_events.action = sqlc.narg('action')::text)
+    AND (sqlc.narg('resource_id')::text IS NULL OR tenant.audit_events.resource_id = sqlc.narg('resource_id')::text)
+    AND (sqlc.narg('cluster_id')::uuid IS NULL OR tenant.audit_events.cluster_id = sqlc.narg('cluster_id')::uuid)
+    AND (sqlc.narg('source')::text IS NULL OR tenant.audit_events.source = sqlc.narg('source')::text)
+    AND (sqlc.narg('outcome')::text IS NULL OR tenant.audit_events.outcome = sqlc.narg('outcome')::text)
+    AND (sqlc.narg('start')::timestamptz IS NULL OR tenant.audit_events.created >= sqlc.narg('start')::timestamptz)
+    AND (sqlc.narg('end')::timestamptz IS NULL OR tenant.audit_events.created < sqlc.narg('end')::timestamptz)
+    AND (sqlc.narg('after_created')::timestamptz IS NULL
+        OR (tenant.audit_events.created, tenant.audit_events.id) < (sqlc.narg('after_created')::timestamptz, sqlc.narg('after_id')::uuid))
+ORDER BY tenant.audit_events.created DESC, tenant.audit_events.id DESC
+LIMIT sqlc.narg('page_limit')::integer;
+
+-- name: AuditEventExport :many
+-- Oldest first, keyset-paginated on (created, id) so an export can be read in
+-- batches while new events keep arriving. Filters as in AuditEventList.
+SELECT
+    tenant.audit_events.id,
+    tenant.audit_events.source,
+    tenant.audit_events.organization_id,
+    tenant.audit_events.cluster_id,
+    tenant.audit_events.actor_user_id,
+    tenant.audit_events.action,
+    tenant.audit_events.resource_type,
+    tenant.audit_events.resource_id,
+    tenant.audit_events.outcome,
+    tenant.audit_events.error_code,
+    tenant.audit_events.details,
+    tenant.audit_events.remote_addr,
+    tenant.audit_events.user_agent,
+    tenant.audit_events.created
+FROM tenant.audit_events
+WHERE (sqlc.narg('actor_user_id')::uuid IS NULL OR tenant.audit_events.actor_user_id = sqlc.narg('actor_user_id')::uuid)
+    AND (sqlc.narg('action')::text IS NULL OR tenant.audit_events.action = sqlc.narg('action')::text)
+    AND (sqlc.narg('resource_id')::text IS NULL OR tenant.audit_events.resource_id = sqlc.narg('resource_id')::text)
+    AND (sqlc.narg('cluster_id')::uuid IS NULL OR tenant.audit_events.cluster_id = sqlc.narg('cluster_id')::uuid)
+    AND (sqlc.narg('source')::text IS NULL OR tenant.audit_events.source = sqlc.narg('source')::text)
+    AND (sqlc.narg('outcome')::text IS NULL OR tenant.audit_events.outcome = sqlc.narg('outcome')::text)
+    AND (sqlc.narg('start')::timestamptz IS NULL OR tenant.audit_events.created >= sqlc.narg('start')::timestamptz)
+    AND (sqlc.narg('end')::timestamptz IS NULL OR tenant.audit_events.created < sqlc.narg('end')::timestamptz)
+    AND (sqlc.narg('after_created')::timestamptz IS NULL
+        OR (tenant.audit_events.created, tenant.audit_events.id) > (sqlc.narg('after_created')::timestamptz, sqlc.narg('after_id')::uuid))
+ORDER BY tenant.audit_events.created, tenant.audit_events.id
+LIMIT @batch_size::integer;
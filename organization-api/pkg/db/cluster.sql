@@ -1,6 +1,7 @@
 -- name: ClusterList :many
 -- List active clusters and clusters being deleted (not yet confirmed deleted in Gardener).
 -- Excludes clusters where Gardener has confirmed deletion (shoot_status = 'deleted').
+-- Newest first, keyset-paginated on (created, id); NULL filters, cursor and limit are ignored.
 SELECT
     id,
     organization_id,
@@ -17,7 +18,11 @@ SELECT
     tenant.clusters.outbox_error
 FROM tenant.clusters
 WHERE (deleted IS NULL OR shoot_status IS DISTINCT FROM 'deleted')
-ORDER BY created DESC;
+    AND (sqlc.narg('name_filter')::text IS NULL OR strpos(lower(name), lower(sqlc.narg('name_filter')::text)) > 0)
+    AND (sqlc.narg('after_created')::timestamptz IS NULL
+        OR (created, id) < (sqlc.narg('after_created')::timestamptz, sqlc.narg('after_id')::uuid))
+ORDER BY created DESC, id DESC
+LIMIT sqlc.narg('page_limit')::integer;
 
 -- name: ClusterGetByID :one
 -- Get cluster by ID, including deleted clusters for direct access.
@@ -34,7 +39,14 @@ SELECT
     shoot_status_updated,
     tenant.clusters.outbox_status,
     tenant.clusters.outbox_retries,
-    tenant.clusters.outbox_error
+    tenant.clusters.outbox_error,
+    tenant.clusters.maintenance_window_start,
+    tenant.clusters.maintenance_window_end,
+    tenant.clusters.auto_update_kubernetes_version,
+    tenant.clusters.auto_update_machine_image_version,
+    tenant.clusters.hibernated,
+    tenant.clusters.hibernation_requested,
+    tenant.clusters.labels
 FROM tenant.clusters
 WHERE tenant.clusters.id = $1;
 
@@ -52,7 +64,13 @@ SELECT
     shoot_status_updated,
     tenant.clusters.outbox_status,
     tenant.clusters.outbox_retries,
-    tenant.clusters.outbox_error
+    tenant.clusters.outbox_error,
+    tenant.clusters.maintenance_window_start,
+    tenant.clusters.maintenance_window_end,
+    tenant.clusters.auto_update_kubernetes_version,
+    tenant.clusters.auto_update_machine_image_version,
+    tenant.clusters.hibernated,
+    tenant.clusters.hibernation_requested
 FROM tenant.clusters
 WHERE name = $1 AND deleted IS NULL;
 
@@ -62,8 +80,8 @@ WHERE name = $1 AND deleted IS NULL;
 -- Returns NULL if blocked (caller should check for pgx.ErrNoRows).
 -- region_id/kubernetes_version_id are the catalog references (expand phase: the
 -- legacy text columns are written alongside them).
-INSERT INTO tenant.clusters (organization_id, name, region, kubernetes_version, region_id, kubernetes_version_id)
-SELECT $1, $2, $3, $4, sqlc.narg('region_id'), sqlc.narg('kubernetes_version_id')
+INSERT INTO tenant.clusters (organization_id, name, region, kubernetes_version, region_id, kubernetes_version_id, labels)
+SELECT $1, $2, $3, $4, sqlc.narg('region_id'), sqlc.narg('kubernetes_version_id'), sqlc.arg('labels')
 WHERE NOT EXISTS (
     SELECT 1
     FROM tenant.clusters
@@ -74,13 +92,29 @@ WHERE NOT EXISTS (
 RETURNING id;
 
 -- name: ClusterUpdate :execrows
--- kubernetes_version_id is resolved server-side from the catalog together with
--- the version text (expand phase: both columns updated in lockstep).
+-- The maintenance window is only written when set_maintenance_window is true,
+-- so it can be cleared (both ends NULL) as well as left alone. Version changes
+-- go through tenant.cluster_upgrades instead (ClusterUpgradeCreate).
 UPDATE tenant.clusters
-SET kubernetes_version = COALESCE(sqlc.narg('kubernetes_version'), kubernetes_version),
-    kubernetes_version_id = COALESCE(sqlc.narg('kubernetes_version_id'), kubernetes_version_id)
+SET maintenance_window_start = CASE WHEN sqlc.arg('set_maintenance_window')::boolean
+        THEN sqlc.narg('maintenance_window_start')::time
+        ELSE maintenance_window_start END,
+    maintenance_window_end = CASE WHEN sqlc.arg('set_maintenance_window')::boolean
+        THEN sqlc.narg('maintenance_window_end')::time
+        ELSE maintenance_window_end END,
+    auto_update_kubernetes_version = COALESCE(sqlc.narg('auto_update_kubernetes_version'), auto_update_kubernetes_version),
+    auto_update_machine_image_version = COALESCE(sqlc.narg('auto_update_machine_image_version'), auto_update_machine_image_version)
 WHERE id = $1 AND deleted IS NULL;
 
+-- name: ClusterSetHibernated :execrows
+-- Record a manual hibernate/wake request. hibernation_requested changes on
+-- every call, so the outbox trigger syncs the cluster even when hibernated
+-- is unchanged (e.g. to hibernate again after a schedule woke it).
+UPDATE tenant.clusters
+SET hibernated = @hibernated,
+    hibernation_requested = now()
+WHERE id = @cluster_id AND deleted IS NULL;
+
 -- name: ClusterDelete :execrows
 UPDATE tenant.clusters
 SET deleted = NOW()
@@ -110,3 +144,11 @@ INSERT INTO tenant.cluster_events (
 )
 VALUES ($1, 'sync_requested', $2);
 
+-- name: ClusterCreateEvent :exec
+-- Insert an event recorded by the API itself (hibernation_requested,
+-- wake_requested); sync and status events come from cluster-worker.
+INSERT INTO tenant.cluster_events (
+    cluster_id,
+    event_type
+)
+VALUES (@cluster_id, @event_type);
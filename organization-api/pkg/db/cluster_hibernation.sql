@@ -0,0 +1,26 @@
+-- name: ClusterHibernationScheduleList :many
+SELECT
+    tenant.cluster_hibernation_schedules.start,
+    tenant.cluster_hibernation_schedules."end",
+    tenant.cluster_hibernation_schedules.location
+FROM tenant.cluster_hibernation_schedules
+WHERE tenant.cluster_hibernation_schedules.cluster_id = @cluster_id
+ORDER BY tenant.cluster_hibernation_schedules.created, tenant.cluster_hibernation_schedules.id;
+
+-- name: ClusterHibernationScheduleDeleteByClusterID :exec
+DELETE FROM tenant.cluster_hibernation_schedules
+WHERE tenant.cluster_hibernation_schedules.cluster_id = @cluster_id;
+
+-- name: ClusterHibernationScheduleCreate :exec
+INSERT INTO tenant.cluster_hibernation_schedules (
+    cluster_id,
+    start,
+    "end",
+    location
+)
+VALUES (
+    @cluster_id,
+    sqlc.narg('start'),
+    sqlc.narg('end'),
+    @location
+);
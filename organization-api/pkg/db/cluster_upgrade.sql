@@ -0,0 +1,56 @@
+-- name: ClusterUpgradeCreate :one
+-- Schedule an upgrade. At most one upgrade per cluster can be scheduled or in
+-- progress (cluster_upgrades_uq_active).
+INSERT INTO tenant.cluster_upgrades (
+    cluster_id,
+    from_kubernetes_version,
+    to_kubernetes_version,
+    to_kubernetes_version_id,
+    not_before,
+    skip_maintenance_window
+)
+VALUES (
+    @cluster_id,
+    @from_kubernetes_version,
+    @to_kubernetes_version,
+    @to_kubernetes_version_id,
+    COALESCE(sqlc.narg('not_before'), now()),
+    @skip_maintenance_window
+)
+RETURNING id;
+
+-- name: ClusterUpgradeGetByID :one
+SELECT
+    tenant.cluster_upgrades.id,
+    tenant.cluster_upgrades.cluster_id,
+    tenant.cluster_upgrades.status
+FROM tenant.cluster_upgrades
+WHERE tenant.cluster_upgrades.id = @upgrade_id;
+
+-- name: ClusterUpgradeList :many
+SELECT
+    tenant.cluster_upgrades.id,
+    tenant.cluster_upgrades.cluster_id,
+    tenant.cluster_upgrades.from_kubernetes_version,
+    tenant.cluster_upgrades.to_kubernetes_version,
+    tenant.cluster_upgrades.not_before,
+    tenant.cluster_upgrades.skip_maintenance_window,
+    tenant.cluster_upgrades.status,
+    tenant.cluster_upgrades.message,
+    tenant.cluster_upgrades.created,
+    tenant.cluster_upgrades.started,
+    tenant.cluster_upgrades.finished
+FROM tenant.cluster_upgrades
+WHERE tenant.cluster_upgrades.cluster_id = @cluster_id
+ORDER BY tenant.cluster_upgrades.created DESC, tenant.cluster_upgrades.id DESC;
+
+-- name: ClusterUpgradeCancel :execrows
+-- Cancel an upgrade that has not started; 0 rows when it already started,
+-- finished or does not exist.
+UPDATE tenant.cluster_upgrades
+SET
+    status = 'cancelled',
+    finished = now()
+WHERE
+    tenant.cluster_upgrades.id = @upgrade_id
+    AND tenant.cluster_upgrades.status = 'scheduled';
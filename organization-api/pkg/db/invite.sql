@@ -22,18 +22,20 @@ WHERE organizations_users.user_id = @user_id
     AND organizations.deleted IS NULL
 ORDER BY organizations_users.created DESC;
 
--- name: InviteAccept :execrows
+-- name: InviteAccept :one
 -- User accepts a pending invitation to an organization
 UPDATE tenant.organizations_users
 SET status = 'accepted'
 WHERE id = @id
     AND status = 'pending'
-    AND deleted IS NULL;
+    AND deleted IS NULL
+RETURNING organization_id;
 
--- name: InviteDecline :execrows
+-- name: InviteDecline :one
 -- User declines a pending invitation to an organization
 UPDATE tenant.organizations_users
 SET status = 'declined'
 WHERE id = @id
     AND status = 'pending'
-    AND deleted IS NULL;
+    AND deleted IS NULL
+RETURNING organization_id;
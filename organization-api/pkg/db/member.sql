@@ -1,5 +1,6 @@
 
 -- name: MemberList :many
+-- Newest first, keyset-paginated on (created, id); NULL filters, cursor and limit are ignored.
 SELECT
     organizations_users.id,
     organizations_users.organization_id,
@@ -15,7 +16,13 @@ INNER JOIN tenant.organizations_users
     ON organizations_users.user_id = users.id
 WHERE organizations_users.deleted IS NULL
     AND users.deleted IS NULL
-ORDER BY organizations_users.created DESC;
+    AND (sqlc.narg('name_filter')::text IS NULL
+        OR strpos(lower(users.name), lower(sqlc.narg('name_filter')::text)) > 0
+        OR strpos(lower(users.email), lower(sqlc.narg('name_filter')::text)) > 0)
+    AND (sqlc.narg('after_created')::timestamptz IS NULL
+        OR (organizations_users.created, organizations_users.id) < (sqlc.narg('after_created')::timestamptz, sqlc.narg('after_id')::uuid))
+ORDER BY organizations_users.created DESC, organizations_users.id DESC
+LIMIT sqlc.narg('page_limit')::integer;
 
 -- name: MemberGetByID :one
 SELECT
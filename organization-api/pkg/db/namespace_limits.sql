@@ -0,0 +1,83 @@
+-- name: NamespaceLimitsGet :one
+SELECT
+    quota_cpu_request_m,
+    quota_cpu_limit_m,
+    quota_memory_request_mi,
+    quota_memory_limit_mi,
+    quota_storage_gi,
+    quota_pods,
+    quota_persistent_volume_claims,
+    quota_services
+FROM tenant.namespace_limits
+WHERE namespace_id = @namespace_id
+  AND deleted IS NULL;
+
+-- name: NamespaceLimitsUpsert :exec
+INSERT INTO tenant.namespace_limits (
+    namespace_id,
+    quota_cpu_request_m,
+    quota_cpu_limit_m,
+    quota_memory_request_mi,
+    quota_memory_limit_mi,
+    quota_storage_gi,
+    quota_pods,
+    quota_persistent_volume_claims,
+    quota_services
+) VALUES (
+    @namespace_id,
+    @quota_cpu_request_m,
+    @quota_cpu_limit_m,
+    @quota_memory_request_mi,
+    @quota_memory_limit_mi,
+    @quota_storage_gi,
+    @quota_pods,
+    @quota_persistent_volume_claims,
+    @quota_services
+)
+ON CONFLICT ON CONSTRAINT namespace_limits_uq_namespace DO UPDATE SET
+    quota_cpu_request_m            = EXCLUDED.quota_cpu_request_m,
+    quota_cpu_limit_m              = EXCLUDED.quota_cpu_limit_m,
+    quota_memory_request_mi        = EXCLUDED.quota_memory_request_mi,
+    quota_memory_limit_mi          = EXCLUDED.quota_memory_limit_mi,
+    quota_storage_gi               = EXCLUDED.quota_storage_gi,
+    quota_pods                     = EXCLUDED.quota_pods,
+    quota_persistent_volume_claims = EXCLUDED.quota_persistent_volume_claims,
+    quota_services                 = EXCLUDED.quota_services;
+
+-- name: NamespaceQuotaListByProjectID :many
+-- The project quota and the override of every active namespace in a project,
+-- for GetProjectQuotaUsage. project_name is needed to derive the cluster-side
+-- namespace name the metrics are labelled with.
+SELECT
+    namespaces.id,
+    namespaces.name,
+    projects.name AS project_name,
+    projects.cluster_id,
+    project_limits.quota_cpu_request_m AS project_quota_cpu_request_m,
+    project_limits.quota_cpu_limit_m AS project_quota_cpu_limit_m,
+    project_limits.quota_memory_request_mi AS project_quota_memory_request_mi,
+    project_limits.quota_memory_limit_mi AS project_quota_memory_limit_mi,
+    project_limits.quota_storage_gi AS project_quota_storage_gi,
+    project_limits.quota_pods AS project_quota_pods,
+    project_limits.quota_persistent_volume_claims AS project_quota_persistent_volume_claims,
+    project_limits.quota_services AS project_quota_services,
+    namespace_limits.quota_cpu_request_m AS namespace_quota_cpu_request_m,
+    namespace_limits.quota_cpu_limit_m AS namespace_quota_cpu_limit_m,
+    namespace_limits.quota_memory_request_mi AS namespace_quota_memory_request_mi,
+    namespace_limits.quota_memory_limit_mi AS namespace_quota_memory_limit_mi,
+    namespace_limits.quota_storage_gi AS namespace_quota_storage_gi,
+    namespace_limits.quota_pods AS namespace_quota_pods,
+    namespace_limits.quota_persistent_volume_claims AS namespace_quota_persistent_volume_claims,
+    namespace_limits.quota_services AS namespace_quota_services
+FROM tenant.namespaces
+JOIN tenant.projects
+    ON projects.id = namespaces.project_id
+LEFT JOIN tenant.project_limits
+    ON project_limits.project_id = namespaces.project_id
+    AND project_limits.deleted IS NULL
+LEFT JOIN tenant.namespace_limits
+    ON namespace_limits.namespace_id = namespaces.id
+    AND namespace_limits.deleted IS NULL
+WHERE namespaces.project_id = @project_id
+  AND namespaces.deleted IS NULL
+ORDER BY namespaces.name ASC;
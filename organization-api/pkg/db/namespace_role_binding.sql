@@ -0,0 +1,46 @@
+-- name: NamespaceRoleBindingList :many
+SELECT
+    namespace_role_bindings.id,
+    namespace_role_bindings.project_id,
+    namespace_role_bindings.project_member_id,
+    namespace_role_bindings.namespace_pattern,
+    namespace_role_bindings.role,
+    namespace_role_bindings.created,
+    project_members.user_id,
+    users.name as user_name
+FROM tenant.namespace_role_bindings
+INNER JOIN tenant.project_members
+  ON project_members.id = namespace_role_bindings.project_member_id
+INNER JOIN tenant.users
+  ON users.id = project_members.user_id
+WHERE namespace_role_bindings.project_id = $1
+  AND namespace_role_bindings.deleted IS NULL
+  AND project_members.deleted IS NULL
+ORDER BY namespace_role_bindings.created ASC;
+
+-- name: NamespaceRoleBindingGetByID :one
+SELECT
+    namespace_role_bindings.id,
+    namespace_role_bindings.project_id
+FROM tenant.namespace_role_bindings
+WHERE namespace_role_bindings.id = $1
+  AND namespace_role_bindings.deleted IS NULL;
+
+-- name: NamespaceRoleBindingCreate :one
+INSERT INTO tenant.namespace_role_bindings (project_id, project_member_id, namespace_pattern, role)
+VALUES ($1, $2, $3, $4)
+RETURNING id;
+
+-- name: NamespaceRoleBindingDelete :execrows
+UPDATE tenant.namespace_role_bindings
+SET deleted = now()
+WHERE id = $1
+AND deleted IS NULL;
+
+-- name: NamespaceRoleBindingDeleteByMember :exec
+-- Revokes every binding of a removed project member, so the outbox triggers
+-- take their namespace roles away.
+UPDATE tenant.namespace_role_bindings
+SET deleted = now()
+WHERE project_member_id = $1
+AND deleted IS NULL;
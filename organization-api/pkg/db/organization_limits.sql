@@ -6,7 +6,10 @@ SELECT
     default_memory_request_mi,
     default_memory_limit_mi,
     default_cpu_request_m,
-    default_cpu_limit_m
+    default_cpu_limit_m,
+    max_clusters,
+    max_projects,
+    max_namespaces
 FROM tenant.organization_limits
 WHERE organization_id = @organization_id
   AND deleted IS NULL;
@@ -20,7 +23,10 @@ INSERT INTO tenant.organization_limits (
     default_memory_request_mi,
     default_memory_limit_mi,
     default_cpu_request_m,
-    default_cpu_limit_m
+    default_cpu_limit_m,
+    max_clusters,
+    max_projects,
+    max_namespaces
 ) VALUES (
     @organization_id,
     @max_nodes_per_cluster,
@@ -29,7 +35,10 @@ INSERT INTO tenant.organization_limits (
     @default_memory_request_mi,
     @default_memory_limit_mi,
     @default_cpu_request_m,
-    @default_cpu_limit_m
+    @default_cpu_limit_m,
+    @max_clusters,
+    @max_projects,
+    @max_namespaces
 )
 ON CONFLICT ON CONSTRAINT organization_limits_uq_org DO UPDATE SET
     max_nodes_per_cluster      = EXCLUDED.max_nodes_per_cluster,
@@ -38,7 +47,10 @@ ON CONFLICT ON CONSTRAINT organization_limits_uq_org DO UPDATE SET
     default_memory_request_mi  = EXCLUDED.default_memory_request_mi,
     default_memory_limit_mi    = EXCLUDED.default_memory_limit_mi,
     default_cpu_request_m      = EXCLUDED.default_cpu_request_m,
-    default_cpu_limit_m        = EXCLUDED.default_cpu_limit_m
+    default_cpu_limit_m        = EXCLUDED.default_cpu_limit_m,
+    max_clusters               = EXCLUDED.max_clusters,
+    max_projects               = EXCLUDED.max_projects,
+    max_namespaces             = EXCLUDED.max_namespaces
 RETURNING
     max_nodes_per_cluster,
     max_node_pools_per_cluster,
@@ -46,4 +58,46 @@ RETURNING
     default_memory_request_mi,
     default_memory_limit_mi,
     default_cpu_request_m,
-    default_cpu_limit_m;
+    default_cpu_limit_m,
+    max_clusters,
+    max_projects,
+    max_namespaces;
+
+-- name: OrganizationCapsLock :one
+-- Locks the organization row until the end of the transaction and returns its
+-- resource caps. Creates in the same organization take this lock before
+-- counting, so two of them cannot both pass a cap with one slot left.
+SELECT
+    organization_limits.max_clusters,
+    organization_limits.max_projects,
+    organization_limits.max_namespaces
+FROM tenant.organizations
+LEFT JOIN tenant.organization_limits
+    ON organization_limits.organization_id = organizations.id
+    AND organization_limits.deleted IS NULL
+WHERE organizations.id = @organization_id
+FOR NO KEY UPDATE OF organizations;
+
+-- name: OrganizationClusterCount :one
+SELECT COUNT(*)::int4 AS total
+FROM tenant.clusters
+WHERE clusters.organization_id = @organization_id
+  AND clusters.deleted IS NULL;
+
+-- name: OrganizationProjectCount :one
+SELECT COUNT(*)::int4 AS total
+FROM tenant.projects
+JOIN tenant.clusters
+    ON clusters.id = projects.cluster_id
+WHERE clusters.organization_id = @organization_id
+  AND projects.deleted IS NULL;
+
+-- name: OrganizationNamespaceCount :one
+SELECT COUNT(*)::int4 AS total
+FROM tenant.namespaces
+JOIN tenant.projects
+    ON projects.id = namespaces.project_id
+JOIN tenant.clusters
+    ON clusters.id = projects.cluster_id
+WHERE clusters.organization_id = @organization_id
+  AND namespaces.deleted IS NULL;
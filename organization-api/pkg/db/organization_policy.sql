@@ -0,0 +1,45 @@
+-- name: OrganizationPolicyGet :one
+SELECT
+    allowed_regions,
+    denied_regions,
+    allowed_kubernetes_versions,
+    denied_kubernetes_versions,
+    allowed_machine_types,
+    denied_machine_types,
+    max_nodes_per_node_pool,
+    required_labels
+FROM tenant.organization_policies
+WHERE organization_id = @organization_id
+  AND deleted IS NULL;
+
+-- name: OrganizationPolicyUpsert :exec
+INSERT INTO tenant.organization_policies (
+    organization_id,
+    allowed_regions,
+    denied_regions,
+    allowed_kubernetes_versions,
+    denied_kubernetes_versions,
+    allowed_machine_types,
+    denied_machine_types,
+    max_nodes_per_node_pool,
+    required_labels
+) VALUES (
+    @organization_id,
+    @allowed_regions,
+    @denied_regions,
+    @allowed_kubernetes_versions,
+    @denied_kubernetes_versions,
+    @allowed_machine_types,
+    @denied_machine_types,
+    @max_nodes_per_node_pool,
+    @required_labels
+)
+ON CONFLICT ON CONSTRAINT organization_policies_uq_org DO UPDATE SET
+    allowed_regions             = EXCLUDED.allowed_regions,
+    denied_regions              = EXCLUDED.denied_regions,
+    allowed_kubernetes_versions = EXCLUDED.allowed_kubernetes_versions,
+    denied_kubernetes_versions  = EXCLUDED.denied_kubernetes_versions,
+    allowed_machine_types       = EXCLUDED.allowed_machine_types,
+    denied_machine_types        = EXCLUDED.denied_machine_types,
+    max_nodes_per_node_pool     = EXCLUDED.max_nodes_per_node_pool,
+    required_labels             = EXCLUDED.required_labels;
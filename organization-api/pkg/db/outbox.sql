@@ -0,0 +1,199 @@
+-- name: ClusterOutboxList :many
+-- Cluster outbox rows of an organization in the given states, oldest first. A
+-- pending or retrying row that a precondition held back is 'deferred'. An empty
+-- entity_types matches every entity type; a NULL cluster_id every cluster.
+WITH entries AS (
+  SELECT
+    cluster_outbox.id,
+    CASE
+      WHEN cluster_outbox.cluster_id IS NOT NULL THEN 'cluster'
+      WHEN cluster_outbox.organization_user_id IS NOT NULL THEN 'organization_user'
+      WHEN cluster_outbox.project_member_id IS NOT NULL THEN 'project_member'
+      WHEN cluster_outbox.node_pool_id IS NOT NULL THEN 'node_pool'
+      WHEN cluster_outbox.namespace_id IS NOT NULL THEN 'namespace'
+      ELSE 'access_elevation'
+    END::text AS entity_type,
+    COALESCE(
+      cluster_outbox.cluster_id,
+      cluster_outbox.organization_user_id,
+      cluster_outbox.project_member_id,
+      cluster_outbox.node_pool_id,
+      cluster_outbox.namespace_id,
+      cluster_outbox.access_elevation_id
+    )::uuid AS entity_id,
+    cluster_outbox.event,
+    CASE
+      WHEN cluster_outbox.status IN ('pending', 'retrying') AND cluster_outbox.deferrals > 0 THEN 'deferred'
+      ELSE cluster_outbox.status
+    END::text AS state,
+    cluster_outbox.retries,
+    cluster_outbox.deferrals,
+    cluster_outbox.status_info,
+    cluster_outbox.created,
+    cluster_outbox.failed,
+    clusters.id AS cluster_id,
+    COALESCE(clusters.organization_id, organizations_users.organization_id, access_elevations.organization_id) AS organization_id
+  FROM tenant.cluster_outbox
+  LEFT JOIN tenant.node_pools
+    ON node_pools.id = cluster_outbox.node_pool_id
+  LEFT JOIN tenant.namespaces
+    ON namespaces.id = cluster_outbox.namespace_id
+  LEFT JOIN tenant.project_members
+    ON project_members.id = cluster_outbox.project_member_id
+  LEFT JOIN tenant.access_elevations
+    ON access_elevations.id = cluster_outbox.access_elevation_id
+  LEFT JOIN tenant.projects
+    ON projects.id = COALESCE(namespaces.project_id, project_members.project_id, access_elevations.project_id)
+  LEFT JOIN tenant.clusters
+    ON clusters.id = COALESCE(cluster_outbox.cluster_id, node_pools.cluster_id, projects.cluster_id)
+  LEFT JOIN tenant.organizations_users
+    ON organizations_users.id = cluster_outbox.organization_user_id
+  WHERE cluster_outbox.status <> 'completed'
+)
+SELECT
+  entries.id,
+  entries.entity_type,
+  entries.entity_id,
+  entries.event,
+  entries.state,
+  entries.retries,
+  entries.deferrals,
+  entries.status_info,
+  entries.created,
+  entries.failed,
+  entries.cluster_id
+FROM entries
+WHERE entries.organization_id = @organization_id
+  AND entries.state = ANY(@states::text[])
+  AND (cardinality(@entity_types::text[]) = 0 OR entries.entity_type = ANY(@entity_types::text[]))
+  AND (sqlc.narg('cluster_id')::uuid IS NULL OR entries.cluster_id = sqlc.narg('cluster_id')::uuid)
+ORDER BY entries.created, entries.id
+LIMIT @row_limit;
+
+-- name: ClusterOutboxReplay :many
+-- Hands an organization's failed, deferred, retrying and abandoned rows back
+-- to cluster-worker with a fresh retry budget and records outbox_replayed on
+-- the cluster each row belongs to. Returns the IDs of the rows replayed.
+WITH entries AS (
+  SELECT
+    cluster_outbox.id,
+    cluster_outbox.retries,
+    cluster_outbox.status_info,
+    CASE
+      WHEN cluster_outbox.cluster_id IS NOT NULL THEN 'cluster'
+      WHEN cluster_outbox.organization_user_id IS NOT NULL THEN 'organization_user'
+      WHEN cluster_outbox.project_member_id IS NOT NULL THEN 'project_member'
+      WHEN cluster_outbox.node_pool_id IS NOT NULL THEN 'node_pool'
+      WHEN cluster_outbox.namespace_id IS NOT NULL THEN 'namespace'
+      ELSE 'access_elevation'
+    END AS entity_type,
+    clusters.id AS cluster_id
+  FROM tenant.cluster_outbox
+  LEFT JOIN tenant.node_pools
+    ON node_pools.id = cluster_outbox.node_pool_id
+  LEFT JOIN tenant.namespaces
+    ON namespaces.id = cluster_outbox.namespace_id
+  LEFT JOIN tenant.project_members
+    ON project_members.id = cluster_outbox.project_member_id
+  LEFT JOIN tenant.access_elevations
+    ON access_elevations.id = cluster_outbox.access_elevation_id
+  LEFT JOIN tenant.projects
+    ON projects.id = COALESCE(namespaces.project_id, project_members.project_id, access_elevations.project_id)
+  LEFT JOIN tenant.clusters
+    ON clusters.id = COALESCE(cluster_outbox.cluster_id, node_pools.cluster_id, projects.cluster_id)
+  LEFT JOIN tenant.organizations_users
+    ON organizations_users.id = cluster_outbox.organization_user_id
+  WHERE cluster_outbox.id = ANY(@ids::uuid[])
+    AND COALESCE(clusters.organization_id, organizations_users.organization_id, access_elevations.organization_id) = @organization_id
+    AND (
+      cluster_outbox.status IN ('failed', 'retrying', 'abandoned')
+      OR (cluster_outbox.status = 'pending' AND cluster_outbox.deferrals > 0)
+    )
+  FOR NO KEY UPDATE OF cluster_outbox
+),
+replayed AS (
+  UPDATE tenant.cluster_outbox
+  SET status = 'pending',
+      retries = 0,
+      deferrals = 0,
+      retry_after = NULL,
+      failed = NULL,
+      status_info = NULL
+  FROM entries
+  WHERE cluster_outbox.id = entries.id
+  RETURNING cluster_outbox.id
+),
+events AS (
+  INSERT INTO tenant.cluster_events (cluster_id, event_type, message, attempt)
+  SELECT
+    entries.cluster_id,
+    'outbox_replayed',
+    format('%s sync replayed by %s', entries.entity_type, @actor::text) || COALESCE('; last error: ' || entries.status_info, ''),
+    entries.retries
+  FROM entries
+  WHERE entries.cluster_id IS NOT NULL
+)
+SELECT replayed.id
+FROM replayed;
+
+-- name: ClusterOutboxAbandon :many
+-- Marks an organization's failed, deferred and retrying rows abandoned so
+-- cluster-worker stops trying them, and records outbox_abandoned on the
+-- cluster each row belongs to. Returns the IDs of the rows abandoned.
+WITH entries AS (
+  SELECT
+    cluster_outbox.id,
+    cluster_outbox.retries,
+    cluster_outbox.status_info,
+    CASE
+      WHEN cluster_outbox.cluster_id IS NOT NULL THEN 'cluster'
+      WHEN cluster_outbox.organization_user_id IS NOT NULL THEN 'organization_user'
+      WHEN cluster_outbox.project_member_id IS NOT NULL THEN 'project_member'
+      WHEN cluster_outbox.node_pool_id IS NOT NULL THEN 'node_pool'
+      WHEN cluster_outbox.namespace_id IS NOT NULL THEN 'namespace'
+      ELSE 'access_elevation'
+    END AS entity_type,
+    clusters.id AS cluster_id
+  FROM tenant.cluster_outbox
+  LEFT JOIN tenant.node_pools
+    ON node_pools.id = cluster_outbox.node_pool_id
+  LEFT JOIN tenant.namespaces
+    ON namespaces.id = cluster_outbox.namespace_id
+  LEFT JOIN tenant.project_members
+    ON project_members.id = cluster_outbox.project_member_id
+  LEFT JOIN tenant.access_elevations
+    ON access_elevations.id = cluster_outbox.access_elevation_id
+  LEFT JOIN tenant.projects
+    ON projects.id = COALESCE(namespaces.project_id, project_members.project_id, access_elevations.project_id)
+  LEFT JOIN tenant.clusters
+    ON clusters.id = COALESCE(cluster_outbox.cluster_id, node_pools.cluster_id, projects.cluster_id)
+  LEFT JOIN tenant.organizations_users
+    ON organizations_users.id = cluster_outbox.organization_user_id
+  WHERE cluster_outbox.id = ANY(@ids::uuid[])
+    AND COALESCE(clusters.organization_id, organizations_users.organization_id, access_elevations.organization_id) = @organization_id
+    AND (
+      cluster_outbox.status IN ('failed', 'retrying')
+      OR (cluster_outbox.status = 'pending' AND cluster_outbox.deferrals > 0)
+    )
+  FOR NO KEY UPDATE OF cluster_outbox
+),
+abandoned AS (
+  UPDATE tenant.cluster_outbox
+  SET status = 'abandoned',
+      retry_after = NULL
+  FROM entries
+  WHERE cluster_outbox.id = entries.id
+  RETURNING cluster_outbox.id
+),
+events AS (
+  INSERT INTO tenant.cluster_events (cluster_id, event_type, message, attempt)
+  SELECT
+    entries.cluster_id,
+    'outbox_abandoned',
+    format('%s sync abandoned by %s', entries.entity_type, @actor::text) || COALESCE('; last error: ' || entries.status_info, ''),
+    entries.retries
+  FROM entries
+  WHERE entries.cluster_id IS NOT NULL
+)
+SELECT abandoned.id
+FROM abandoned;
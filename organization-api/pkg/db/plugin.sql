@@ -1,4 +1,6 @@
 -- name: PluginList :many
+-- Ordered by display name (falling back to name), keyset-paginated on
+-- (sort_name, id); NULL filters, cursor and limit are ignored.
 SELECT appstore.plugins.id, appstore.plugins.name, appstore.plugins.display_name, appstore.plugins.description_short, appstore.plugins.description, appstore.plugins.image,
   COALESCE((
     SELECT appstore.plugin_definitions.plugin_version
@@ -13,10 +15,18 @@ SELECT appstore.plugins.id, appstore.plugins.name, appstore.plugins.display_name
     WHERE appstore.plugin_definitions.plugin_id = appstore.plugins.id AND appstore.plugin_definitions.deleted IS NULL
     ORDER BY appstore.plugin_definitions.created DESC
     LIMIT 1
-  ), '')::text AS latest_hash
+  ), '')::text AS latest_hash,
+  COALESCE(NULLIF(appstore.plugins.display_name, ''), appstore.plugins.name)::text AS sort_name
 FROM appstore.plugins
 WHERE appstore.plugins.deleted IS NULL
-ORDER BY COALESCE(NULLIF(appstore.plugins.display_name, ''), appstore.plugins.name);
+  AND (sqlc.narg('name_filter')::text IS NULL
+    OR strpos(lower(appstore.plugins.name), lower(sqlc.narg('name_filter')::text)) > 0
+    OR strpos(lower(appstore.plugins.display_name), lower(sqlc.narg('name_filter')::text)) > 0)
+  AND (sqlc.narg('after_sort_name')::text IS NULL
+    OR (COALESCE(NULLIF(appstore.plugins.display_name, ''), appstore.plugins.name), appstore.plugins.id)
+      > (sqlc.narg('after_sort_name')::text, sqlc.narg('after_id')::uuid))
+ORDER BY COALESCE(NULLIF(appstore.plugins.display_name, ''), appstore.plugins.name), appstore.plugins.id
+LIMIT sqlc.narg('page_limit')::integer;
 
 -- name: PluginTagsList :many
 SELECT pt.plugin_id, t.id, t.name
@@ -0,0 +1,10 @@
+-- name: PluginDefinitionApprovalCreate :exec
+-- Approving a definition again keeps the first approval.
+INSERT INTO tenant.plugin_definition_approvals (organization_id, plugin_definition_id, user_id)
+VALUES (@organization_id, @plugin_definition_id, @user_id)
+ON CONFLICT ON CONSTRAINT plugin_definition_approvals_uq_definition DO NOTHING;
+
+-- name: PluginDefinitionApprovalGet :one
+SELECT user_id, created
+FROM tenant.plugin_definition_approvals
+WHERE organization_id = @organization_id AND plugin_definition_id = @plugin_definition_id;
@@ -6,6 +6,7 @@ WHERE deleted IS NULL
 ORDER BY created DESC;
 
 -- name: ProjectListByClusterID :many
+-- Newest first, keyset-paginated on (created, id); NULL filters, cursor and limit are ignored.
 SELECT id, cluster_id, name, alias, created, deleted,
     (SELECT COUNT(*)
      FROM tenant.namespaces
@@ -14,8 +15,14 @@ SELECT id, cluster_id, name, alias, created, deleted,
      FROM tenant.project_members
      WHERE project_members.project_id = projects.id AND project_members.deleted IS NULL) AS member_count
 FROM tenant.projects
-WHERE cluster_id = $1 AND deleted IS NULL
-ORDER BY created DESC;
+WHERE cluster_id = sqlc.arg('cluster_id') AND deleted IS NULL
+    AND (sqlc.narg('name_filter')::text IS NULL
+        OR strpos(lower(name), lower(sqlc.narg('name_filter')::text)) > 0
+        OR strpos(lower(alias), lower(sqlc.narg('name_filter')::text)) > 0)
+    AND (sqlc.narg('after_created')::timestamptz IS NULL
+        OR (created, id) < (sqlc.narg('after_created')::timestamptz, sqlc.narg('after_id')::uuid))
+ORDER BY created DESC, id DESC
+LIMIT sqlc.narg('page_limit')::integer;
 
 -- name: ProjectGetByID :one
 SELECT id, cluster_id, name, alias, created, deleted
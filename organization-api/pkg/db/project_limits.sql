@@ -3,7 +3,15 @@ SELECT
     default_memory_request_mi,
     default_memory_limit_mi,
     default_cpu_request_m,
-    default_cpu_limit_m
+    default_cpu_limit_m,
+    quota_cpu_request_m,
+    quota_cpu_limit_m,
+    quota_memory_request_mi,
+    quota_memory_limit_mi,
+    quota_storage_gi,
+    quota_pods,
+    quota_persistent_volume_claims,
+    quota_services
 FROM tenant.project_limits
 WHERE project_id = @project_id
   AND deleted IS NULL;
@@ -14,21 +22,53 @@ INSERT INTO tenant.project_limits (
     default_memory_request_mi,
     default_memory_limit_mi,
     default_cpu_request_m,
-    default_cpu_limit_m
+    default_cpu_limit_m,
+    quota_cpu_request_m,
+    quota_cpu_limit_m,
+    quota_memory_request_mi,
+    quota_memory_limit_mi,
+    quota_storage_gi,
+    quota_pods,
+    quota_persistent_volume_claims,
+    quota_services
 ) VALUES (
     @project_id,
     @default_memory_request_mi,
     @default_memory_limit_mi,
     @default_cpu_request_m,
-    @default_cpu_limit_m
+    @default_cpu_limit_m,
+    @quota_cpu_request_m,
+    @quota_cpu_limit_m,
+    @quota_memory_request_mi,
+    @quota_memory_limit_mi,
+    @quota_storage_gi,
+    @quota_pods,
+    @quota_persistent_volume_claims,
+    @quota_services
 )
 ON CONFLICT ON CONSTRAINT project_limits_uq_project DO UPDATE SET
     default_memory_request_mi = EXCLUDED.default_memory_request_mi,
     default_memory_limit_mi   = EXCLUDED.default_memory_limit_mi,
     default_cpu_request_m     = EXCLUDED.default_cpu_request_m,
-    default_cpu_limit_m       = EXCLUDED.default_cpu_limit_m
+    default_cpu_limit_m       = EXCLUDED.default_cpu_limit_m,
+    quota_cpu_request_m            = EXCLUDED.quota_cpu_request_m,
+    quota_cpu_limit_m              = EXCLUDED.quota_cpu_limit_m,
+    quota_memory_request_mi        = EXCLUDED.quota_memory_request_mi,
+    quota_memory_limit_mi          = EXCLUDED.quota_memory_limit_mi,
+    quota_storage_gi               = EXCLUDED.quota_storage_gi,
+    quota_pods                     = EXCLUDED.quota_pods,
+    quota_persistent_volume_claims = EXCLUDED.quota_persistent_volume_claims,
+    quota_services                 = EXCLUDED.quota_services
 RETURNING
     default_memory_request_mi,
     default_memory_limit_mi,
     default_cpu_request_m,
-    default_cpu_limit_m;
+    default_cpu_limit_m,
+    quota_cpu_request_m,
+    quota_cpu_limit_m,
+    quota_memory_request_mi,
+    quota_memory_limit_mi,
+    quota_storage_gi,
+    quota_pods,
+    quota_persistent_volume_claims,
+    quota_services;
@@ -1,18 +1,23 @@
 -- Region catalog reads. Global catalog data (no RLS, no org filter) - what each
 -- region offers feeds the console's region -> versions/machine-types cascade.
+-- Entries whose deprecation date has passed are no longer offered; entries
+-- with a date still ahead are, and carry the date.
 
 -- name: RegionList :many
-SELECT id, name
+SELECT id, name, deprecated
 FROM catalog.regions
+WHERE deprecated IS NULL OR deprecated > now()
 ORDER BY name;
 
 -- name: RegionKubernetesVersionList :many
 SELECT
     catalog.region_kubernetes_versions.region_id,
     catalog.kubernetes_versions.id,
-    catalog.kubernetes_versions.version
+    catalog.kubernetes_versions.version,
+    catalog.kubernetes_versions.deprecated
 FROM catalog.region_kubernetes_versions
 JOIN catalog.kubernetes_versions ON catalog.kubernetes_versions.id = catalog.region_kubernetes_versions.kubernetes_version_id
+WHERE catalog.kubernetes_versions.deprecated IS NULL OR catalog.kubernetes_versions.deprecated > now()
 ORDER BY catalog.region_kubernetes_versions.region_id, catalog.kubernetes_versions.version;
 
 -- name: RegionMachineTypeList :many
@@ -21,22 +26,28 @@ SELECT
     catalog.region_machine_types.id,
     catalog.machine_types.name,
     catalog.machine_types.lcpu,
-    catalog.machine_types.memory
+    catalog.machine_types.memory,
+    catalog.machine_types.deprecated
 FROM catalog.region_machine_types
 JOIN catalog.machine_types ON catalog.machine_types.id = catalog.region_machine_types.machine_type_id
+WHERE catalog.machine_types.deprecated IS NULL OR catalog.machine_types.deprecated > now()
 ORDER BY catalog.region_machine_types.region_id, catalog.machine_types.name;
 
 -- name: RegionKubernetesVersionResolve :one
 -- Resolve (region name, version) to the catalog ids; no row means the version
--- is not offered in that region (or the region does not exist).
+-- is not offered in that region (or the region does not exist). A region past
+-- its deprecation date still resolves, for the clusters already in it, and is
+-- flagged so that no new clusters are created there.
 SELECT
     catalog.region_kubernetes_versions.region_id,
-    catalog.region_kubernetes_versions.kubernetes_version_id
+    catalog.region_kubernetes_versions.kubernetes_version_id,
+    COALESCE(catalog.regions.deprecated <= now(), false)::bool AS region_deprecated
 FROM catalog.region_kubernetes_versions
 JOIN catalog.regions ON catalog.regions.id = catalog.region_kubernetes_versions.region_id
 JOIN catalog.kubernetes_versions ON catalog.kubernetes_versions.id = catalog.region_kubernetes_versions.kubernetes_version_id
 WHERE catalog.regions.name = @region_name
-  AND catalog.kubernetes_versions.version = @version;
+  AND catalog.kubernetes_versions.version = @version
+  AND (catalog.kubernetes_versions.deprecated IS NULL OR catalog.kubernetes_versions.deprecated > now());
 
 -- name: RegionMachineTypeResolve :one
 -- Resolve (region name, machine type name) to the region_machine_types row; no
@@ -47,4 +58,5 @@ FROM catalog.region_machine_types
 JOIN catalog.regions ON catalog.regions.id = catalog.region_machine_types.region_id
 JOIN catalog.machine_types ON catalog.machine_types.id = catalog.region_machine_types.machine_type_id
 WHERE catalog.regions.name = @region_name
-  AND catalog.machine_types.name = @machine_type_name;
+  AND catalog.machine_types.name = @machine_type_name
+  AND (catalog.machine_types.deprecated IS NULL OR catalog.machine_types.deprecated > now());
@@ -0,0 +1,136 @@
+-- name: TeamList :many
+SELECT
+    teams.id,
+    teams.name,
+    teams.permission,
+    teams.oidc_group,
+    teams.created,
+    (SELECT COUNT(*) FROM tenant.team_members
+     WHERE team_members.team_id = teams.id AND team_members.deleted IS NULL)::int AS member_count
+FROM tenant.teams
+WHERE teams.organization_id = $1
+  AND teams.deleted IS NULL
+ORDER BY teams.name ASC;
+
+-- name: TeamGetByID :one
+SELECT
+    teams.id,
+    teams.name,
+    teams.permission,
+    teams.oidc_group,
+    teams.created,
+    (SELECT COUNT(*) FROM tenant.team_members
+     WHERE team_members.team_id = teams.id AND team_members.deleted IS NULL)::int AS member_count
+FROM tenant.teams
+WHERE teams.id = $1
+  AND teams.deleted IS NULL;
+
+-- name: TeamCreate :one
+INSERT INTO tenant.teams (organization_id, name, permission, oidc_group)
+VALUES (@organization_id, @name, sqlc.narg('permission'), sqlc.narg('oidc_group'))
+RETURNING id;
+
+-- name: TeamUpdate :execrows
+-- name is left unchanged when NULL. permission and oidc_group are only
+-- written when their set_ flag is true, so they can also be cleared.
+UPDATE tenant.teams
+SET name = COALESCE(sqlc.narg('name'), name),
+    permission = CASE WHEN @set_permission::boolean THEN sqlc.narg('permission')::text ELSE permission END,
+    oidc_group = CASE WHEN @set_oidc_group::boolean THEN sqlc.narg('oidc_group')::text ELSE oidc_group END
+WHERE id = @id AND deleted IS NULL;
+
+-- name: TeamDelete :execrows
+UPDATE tenant.teams
+SET deleted = now()
+WHERE id = $1
+AND deleted IS NULL;
+
+-- name: TeamMemberDeleteByTeam :exec
+UPDATE tenant.team_members
+SET deleted = now()
+WHERE team_id = $1
+AND deleted IS NULL;
+
+-- name: ProjectTeamDeleteByTeam :exec
+UPDATE tenant.project_teams
+SET deleted = now()
+WHERE team_id = $1
+AND deleted IS NULL;
+
+-- name: TeamMemberList :many
+SELECT
+    team_members.id,
+    team_members.team_id,
+    team_members.user_id,
+    team_members.source,
+    team_members.created,
+    users.name as user_name
+FROM tenant.team_members
+INNER JOIN tenant.users
+  ON users.id = team_members.user_id
+WHERE team_members.team_id = $1
+  AND team_members.deleted IS NULL
+ORDER BY users.name ASC;
+
+-- name: TeamMemberGetByID :one
+SELECT
+    team_members.id,
+    team_members.team_id
+FROM tenant.team_members
+WHERE team_members.id = $1
+  AND team_members.deleted IS NULL;
+
+-- name: TeamMemberCreate :one
+-- Only accepted members of the team's organization can join it; no row is
+-- inserted otherwise.
+INSERT INTO tenant.team_members (team_id, user_id)
+SELECT teams.id, organizations_users.user_id
+FROM tenant.teams
+INNER JOIN tenant.organizations_users
+  ON organizations_users.organization_id = teams.organization_id
+  AND organizations_users.user_id = @user_id
+  AND organizations_users.status = 'accepted'
+  AND organizations_users.deleted IS NULL
+WHERE teams.id = @team_id
+  AND teams.deleted IS NULL
+RETURNING id;
+
+-- name: TeamMemberDelete :execrows
+UPDATE tenant.team_members
+SET deleted = now()
+WHERE id = $1
+AND deleted IS NULL;
+
+-- name: ProjectTeamList :many
+SELECT
+    project_teams.id,
+    project_teams.project_id,
+    project_teams.team_id,
+    project_teams.role,
+    project_teams.created,
+    teams.name as team_name
+FROM tenant.project_teams
+INNER JOIN tenant.teams
+  ON teams.id = project_teams.team_id
+WHERE project_teams.project_id = $1
+  AND project_teams.deleted IS NULL
+ORDER BY teams.name ASC;
+
+-- name: ProjectTeamGetByID :one
+SELECT
+    project_teams.id,
+    project_teams.project_id
+FROM tenant.project_teams
+WHERE project_teams.id = $1
+  AND project_teams.deleted IS NULL;
+
+-- name: ProjectTeamCreate :one
+INSERT INTO tenant.project_teams (project_id, team_id, role)
+VALUES ($1, $2, $3)
+RETURNING id;
+
+-- name: ProjectTeamDelete :execrows
+UPDATE tenant.project_teams
+SET deleted = now()
+WHERE id = $1
+AND deleted IS NULL;
@@ -0,0 +1,58 @@
+-- name: WebhookCreate :one
+INSERT INTO tenant.webhooks (organization_id, name, url, secret, event_types)
+VALUES (@organization_id, @name, @url, @secret, @event_types)
+RETURNING id;
+
+-- name: WebhookList :many
+-- Newest first, keyset-paginated on (created, id); NULL cursor and limit are ignored.
+SELECT id, name, url, event_types, enabled, created
+FROM tenant.webhooks
+WHERE organization_id = @organization_id AND deleted IS NULL
+    AND (sqlc.narg('after_created')::timestamptz IS NULL
+        OR (created, id) < (sqlc.narg('after_created')::timestamptz, sqlc.narg('after_id')::uuid))
+ORDER BY created DESC, id DESC
+LIMIT sqlc.narg('page_limit')::integer;
+
+-- name: WebhookGetByID :one
+SELECT id, name, url, event_types, enabled, created
+FROM tenant.webhooks
+WHERE id = @id AND deleted IS NULL;
+
+-- name: WebhookUpdate :execrows
+-- NULL arguments leave the column unchanged.
+UPDATE tenant.webhooks
+SET name = COALESCE(sqlc.narg('name'), name),
+    url = COALESCE(sqlc.narg('url'), url),
+    event_types = COALESCE(sqlc.narg('event_types')::text[], event_types),
+    enabled = COALESCE(sqlc.narg('enabled'), enabled)
+WHERE id = @id AND deleted IS NULL;
+
+-- name: WebhookDelete :execrows
+UPDATE tenant.webhooks
+SET deleted = now()
+WHERE id = @id AND deleted IS NULL;
+
+-- name: WebhookDeliveryCreatePing :one
+-- Queues a ping with the same envelope tenant.webhook_enqueue builds for
+-- platform events. Pings bypass the event filter.
+INSERT INTO tenant.webhook_deliveries (webhook_id, event_type, payload)
+SELECT tenant.webhooks.id,
+       'ping',
+       jsonb_build_object(
+           'type', 'ping',
+           'organization_id', tenant.webhooks.organization_id,
+           'created', now(),
+           'data', jsonb_build_object('webhook_id', tenant.webhooks.id))
+FROM tenant.webhooks
+WHERE tenant.webhooks.id = @webhook_id AND tenant.webhooks.deleted IS NULL
+RETURNING id;
+
+-- name: WebhookDeliveryList :many
+-- Newest first, keyset-paginated on (created, id); NULL cursor and limit are ignored.
+SELECT id, webhook_id, event_type, payload, status, attempts, retry_after, response_status, status_info, created, completed
+FROM tenant.webhook_deliveries
+WHERE webhook_id = @webhook_id
+    AND (sqlc.narg('after_created')::timestamptz IS NULL
+        OR (created, id) < (sqlc.narg('after_created')::timestamptz, sqlc.narg('after_id')::uuid))
+ORDER BY created DESC, id DESC
+LIMIT sqlc.narg('page_limit')::integer;
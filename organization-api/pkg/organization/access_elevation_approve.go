@@ -0,0 +1,108 @@
+package organization
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"connectrpc.com/connect"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/fundament-oss/fundament/common/authz"
+	db "github.com/fundament-oss/fundament/organization-api/pkg/db/gen"
+	organizationv1 "github.com/fundament-oss/fundament/organization-api/pkg/proto/gen/v1"
+)
+
+func (s *Server) ApproveAccessElevation(
+	ctx context.Context,
+	req *organizationv1.ApproveAccessElevationRequest,
+) (*organizationv1.ApproveAccessElevationResponse, error) {
+	userID, ok := UserIDFromContext(ctx)
+	if !ok {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("user_id missing from context"))
+	}
+
+	elevationID := uuid.MustParse(req.GetAccessElevationId())
+
+	elevation, err := s.accessElevationToDecide(ctx, elevationID)
+	if err != nil {
+		return nil, err
+	}
+
+	expires, err := s.queries.AccessElevationApprove(ctx, db.AccessElevationApproveParams{
+		DecidedBy: pgtype.UUID{Bytes: userID, Valid: true},
+		ID:        elevationID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("access elevation is no longer pending"))
+		}
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to approve access elevation: %w", err))
+	}
+
+	s.logger.InfoContext(ctx, "access elevation approved",
+		"access_elevation_id", elevationID,
+		"user_id", elevation.UserID,
+		"role", elevation.Role,
+		"expires", expires.Time,
+	)
+
+	return organizationv1.ApproveAccessElevationResponse_builder{
+		Expires: timestamppb.New(expires.Time),
+	}.Build(), nil
+}
+
+// accessElevationToDecide returns the access elevation with the given ID once
+// the current user is found to be allowed to approve or deny it: they can
+// approve elevations to its role, it is not their own request, and they do not
+// hold an elevated role themselves. The last rule keeps someone elevated to
+// organization admin, who is project admin of every project through it, from
+// approving project elevations for others.
+func (s *Server) accessElevationToDecide(ctx context.Context, elevationID uuid.UUID) (*db.AccessElevationGetByIDRow, error) {
+	organizationID, ok := OrganizationIDFromContext(ctx)
+	if !ok {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("organization_id missing from context"))
+	}
+	userID, ok := UserIDFromContext(ctx)
+	if !ok {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("user_id missing from context"))
+	}
+
+	elevation, err := s.accessElevationGet(ctx, elevationID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.checkPermission(ctx, authz.CanApproveAccessElevations(),
+		accessElevationObject(elevation.Role, organizationID, elevation.ProjectID)); err != nil {
+		return nil, err
+	}
+
+	if elevation.UserID == userID {
+		return nil, connect.NewError(connect.CodePermissionDenied, fmt.Errorf("you cannot decide on your own access elevation"))
+	}
+
+	elevated, err := s.queries.AccessElevationHeldByUser(ctx, db.AccessElevationHeldByUserParams{UserID: userID})
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to check own access elevations: %w", err))
+	}
+	if elevated {
+		return nil, connect.NewError(connect.CodePermissionDenied, fmt.Errorf("you cannot decide on access elevations while holding an elevated role"))
+	}
+
+	return elevation, nil
+}
+
+func (s *Server) accessElevationGet(ctx context.Context, elevationID uuid.UUID) (*db.AccessElevationGetByIDRow, error) {
+	elevation, err := s.queries.AccessElevationGetByID(ctx, db.AccessElevationGetByIDParams{ID: elevationID})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("access elevation not found"))
+		}
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to get access elevation: %w", err))
+	}
+	return &elevation, nil
+}
@@ -0,0 +1,117 @@
+package organization
+
+import (
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/fundament-oss/fundament/common/authz"
+	"github.com/fundament-oss/fundament/common/dbconst"
+	db "github.com/fundament-oss/fundament/organization-api/pkg/db/gen"
+	organizationv1 "github.com/fundament-oss/fundament/organization-api/pkg/proto/gen/v1"
+)
+
+func accessElevationFromRow(row *db.AccessElevationListRow) *organizationv1.AccessElevation {
+	elevation := organizationv1.AccessElevation_builder{
+		Id:              row.ID.String(),
+		UserId:          row.UserID.String(),
+		UserName:        row.UserName,
+		Role:            accessElevationRoleFromDB(row.Role),
+		DurationMinutes: row.DurationMinutes,
+		Reason:          row.Reason,
+		Status:          accessElevationStatusFromDB(row.Status),
+		Created:         timestamppb.New(row.Created.Time),
+	}.Build()
+
+	if row.ProjectID.Valid {
+		elevation.SetProjectId(uuid.UUID(row.ProjectID.Bytes).String())
+	}
+	if row.DecidedBy.Valid {
+		elevation.SetDecidedBy(uuid.UUID(row.DecidedBy.Bytes).String())
+	}
+	if row.Decided.Valid {
+		elevation.SetDecided(timestamppb.New(row.Decided.Time))
+	}
+	if row.Expires.Valid {
+		elevation.SetExpires(timestamppb.New(row.Expires.Time))
+	}
+	if row.Ended.Valid {
+		elevation.SetEnded(timestamppb.New(row.Ended.Time))
+	}
+	return elevation
+}
+
+// accessElevationObject returns the object whose role an access elevation
+// grants: the organization for admin, the project for project_admin.
+func accessElevationObject(role dbconst.AccessElevationRole, organizationID uuid.UUID, projectID pgtype.UUID) authz.Object {
+	if role == dbconst.AccessElevationRole_ProjectAdmin {
+		return authz.Project(projectID.Bytes)
+	}
+	return authz.Organization(organizationID)
+}
+
+func accessElevationRoleFromDB(role dbconst.AccessElevationRole) organizationv1.AccessElevationRole {
+	switch role {
+	case dbconst.AccessElevationRole_Admin:
+		return organizationv1.AccessElevationRole_ACCESS_ELEVATION_ROLE_ADMIN
+	case dbconst.AccessElevationRole_ProjectAdmin:
+		return organizationv1.AccessElevationRole_ACCESS_ELEVATION_ROLE_PROJECT_ADMIN
+	default:
+		panic("unknown dbconst access elevation role")
+	}
+}
+
+func accessElevationRoleToDB(role organizationv1.AccessElevationRole) dbconst.AccessElevationRole {
+	switch role {
+	case organizationv1.AccessElevationRole_ACCESS_ELEVATION_ROLE_ADMIN:
+		return dbconst.AccessElevationRole_Admin
+	case organizationv1.AccessElevationRole_ACCESS_ELEVATION_ROLE_PROJECT_ADMIN:
+		return dbconst.AccessElevationRole_ProjectAdmin
+	default:
+		panic("unknown proto access elevation role")
+	}
+}
+
+func accessElevationStatusFromDB(status dbconst.AccessElevationStatus) organizationv1.AccessElevationStatus {
+	switch status {
+	case dbconst.AccessElevationStatus_Pending:
+		return organizationv1.AccessElevationStatus_ACCESS_ELEVATION_STATUS_PENDING
+	case dbconst.AccessElevationStatus_Approved:
+		return organizationv1.AccessElevationStatus_ACCESS_ELEVATION_STATUS_APPROVED
+	case dbconst.AccessElevationStatus_Denied:
+		return organizationv1.AccessElevationStatus_ACCESS_ELEVATION_STATUS_DENIED
+	case dbconst.AccessElevationStatus_Cancelled:
+		return organizationv1.AccessElevationStatus_ACCESS_ELEVATION_STATUS_CANCELLED
+	case dbconst.AccessElevationStatus_Revoked:
+		return organizationv1.AccessElevationStatus_ACCESS_ELEVATION_STATUS_REVOKED
+	case dbconst.AccessElevationStatus_Expired:
+		return organizationv1.AccessElevationStatus_ACCESS_ELEVATION_STATUS_EXPIRED
+	default:
+		panic("unknown dbconst access elevation status")
+	}
+}
+
+// accessElevationStatusFilter maps a status filter to the status query
+// parameter; unspecified filters nothing.
+func accessElevationStatusFilter(status organizationv1.AccessElevationStatus) pgtype.Text {
+	var value dbconst.AccessElevationStatus
+	switch status {
+	case organizationv1.AccessElevationStatus_ACCESS_ELEVATION_STATUS_UNSPECIFIED:
+		return pgtype.Text{}
+	case organizationv1.AccessElevationStatus_ACCESS_ELEVATION_STATUS_PENDING:
+		value = dbconst.AccessElevationStatus_Pending
+	case organizationv1.AccessElevationStatus_ACCESS_ELEVATION_STATUS_APPROVED:
+		value = dbconst.AccessElevationStatus_Approved
+	case organizationv1.AccessElevationStatus_ACCESS_ELEVATION_STATUS_DENIED:
+		value = dbconst.AccessElevationStatus_Denied
+	case organizationv1.AccessElevationStatus_ACCESS_ELEVATION_STATUS_CANCELLED:
+		value = dbconst.AccessElevationStatus_Cancelled
+	case organizationv1.AccessElevationStatus_ACCESS_ELEVATION_STATUS_REVOKED:
+		value = dbconst.AccessElevationStatus_Revoked
+	case organizationv1.AccessElevationStatus_ACCESS_ELEVATION_STATUS_EXPIRED:
+		value = dbconst.AccessElevationStatus_Expired
+	default:
+		panic("unknown proto access elevation status")
+	}
+	return pgtype.Text{String: string(value), Valid: true}
+}
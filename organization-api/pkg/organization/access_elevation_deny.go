@@ -0,0 +1,49 @@
+package organization
+
+import (
+	"context"
+	"fmt"
+
+	"connectrpc.com/connect"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	db "github.com/fundament-oss/fundament/organization-api/pkg/db/gen"
+	organizationv1 "github.com/fundament-oss/fundament/organization-api/pkg/proto/gen/v1"
+)
+
+func (s *Server) DenyAccessElevation(
+	ctx context.Context,
+	req *organizationv1.DenyAccessElevationRequest,
+) (*organizationv1.DenyAccessElevationResponse, error) {
+	userID, ok := UserIDFromContext(ctx)
+	if !ok {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("user_id missing from context"))
+	}
+
+	elevationID := uuid.MustParse(req.GetAccessElevationId())
+
+	elevation, err := s.accessElevationToDecide(ctx, elevationID)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.queries.AccessElevationDeny(ctx, db.AccessElevationDenyParams{
+		DecidedBy: pgtype.UUID{Bytes: userID, Valid: true},
+		ID:        elevationID,
+	})
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to deny access elevation: %w", err))
+	}
+	if rows == 0 {
+		return nil, connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("access elevation is no longer pending"))
+	}
+
+	s.logger.InfoContext(ctx, "access elevation denied",
+		"access_elevation_id", elevationID,
+		"user_id", elevation.UserID,
+		"role", elevation.Role,
+	)
+
+	return organizationv1.DenyAccessElevationResponse_builder{}.Build(), nil
+}
@@ -0,0 +1,95 @@
+package organization
+
+import (
+	"context"
+	"fmt"
+
+	"connectrpc.com/connect"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/fundament-oss/fundament/common/authz"
+	db "github.com/fundament-oss/fundament/organization-api/pkg/db/gen"
+	organizationv1 "github.com/fundament-oss/fundament/organization-api/pkg/proto/gen/v1"
+)
+
+func (s *Server) ListAccessElevations(
+	ctx context.Context,
+	req *organizationv1.ListAccessElevationsRequest,
+) (*organizationv1.ListAccessElevationsResponse, error) {
+	organizationID, ok := OrganizationIDFromContext(ctx)
+	if !ok {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("organization_id missing from context"))
+	}
+	userID, ok := UserIDFromContext(ctx)
+	if !ok {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("user_id missing from context"))
+	}
+
+	object := authz.Organization(organizationID)
+	var projectID pgtype.UUID
+	if req.HasProjectId() {
+		id := uuid.MustParse(req.GetProjectId())
+		object = authz.Project(id)
+		projectID = pgtype.UUID{Bytes: id, Valid: true}
+	}
+
+	if err := s.checkPermission(ctx, authz.CanView(), object); err != nil {
+		return nil, err
+	}
+
+	// Approvers see every elevation in scope, everyone else only their own.
+	approver, err := s.canApproveAccessElevations(ctx, object)
+	if err != nil {
+		return nil, err
+	}
+	var requester pgtype.UUID
+	if !approver {
+		requester = pgtype.UUID{Bytes: userID, Valid: true}
+	}
+
+	cursor, err := decodePageToken(req.GetPageToken())
+	if err != nil {
+		return nil, err
+	}
+	afterCreated, afterID := createdCursorParams(cursor)
+
+	rows, err := s.queries.AccessElevationList(ctx, db.AccessElevationListParams{
+		UserID:       requester,
+		ProjectID:    projectID,
+		Status:       accessElevationStatusFilter(req.GetStatus()),
+		AfterCreated: afterCreated,
+		AfterID:      afterID,
+		PageLimit:    pageLimit(req.GetPageSize()),
+	})
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to list access elevations: %w", err))
+	}
+
+	rows, nextPageToken := trimPage(rows, req.GetPageSize(), func(row *db.AccessElevationListRow) pageCursor {
+		return pageCursor{Created: row.Created.Time, ID: row.ID}
+	})
+
+	result := make([]*organizationv1.AccessElevation, 0, len(rows))
+	for i := range rows {
+		result = append(result, accessElevationFromRow(&rows[i]))
+	}
+
+	return organizationv1.ListAccessElevationsResponse_builder{
+		AccessElevations: result,
+		NextPageToken:    nextPageToken,
+	}.Build(), nil
+}
+
+// canApproveAccessElevations reports whether the current user may decide on
+// the access elevations of resource. A denial is an answer, not an error.
+func (s *Server) canApproveAccessElevations(ctx context.Context, resource authz.Object) (bool, error) {
+	err := s.checkPermission(ctx, authz.CanApproveAccessElevations(), resource)
+	if err == nil {
+		return true, nil
+	}
+	if connect.CodeOf(err) == connect.CodePermissionDenied {
+		return false, nil
+	}
+	return false, err
+}
@@ -0,0 +1,107 @@
+package organization
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"connectrpc.com/connect"
+	"github.com/google/uuid"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/fundament-oss/fundament/common/authz"
+	"github.com/fundament-oss/fundament/common/dbconst"
+	db "github.com/fundament-oss/fundament/organization-api/pkg/db/gen"
+	organizationv1 "github.com/fundament-oss/fundament/organization-api/pkg/proto/gen/v1"
+)
+
+func (s *Server) RequestAccessElevation(
+	ctx context.Context,
+	req *organizationv1.RequestAccessElevationRequest,
+) (*organizationv1.RequestAccessElevationResponse, error) {
+	organizationID, ok := OrganizationIDFromContext(ctx)
+	if !ok {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("organization_id missing from context"))
+	}
+	userID, ok := UserIDFromContext(ctx)
+	if !ok {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("user_id missing from context"))
+	}
+
+	role := accessElevationRoleToDB(req.GetRole())
+
+	var projectID pgtype.UUID
+	if role == dbconst.AccessElevationRole_ProjectAdmin {
+		if !req.HasProjectId() {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("project_id is required for the project admin role"))
+		}
+		id := uuid.MustParse(req.GetProjectId())
+		if _, err := s.queries.ProjectGetByID(ctx, db.ProjectGetByIDParams{ID: id}); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("project not found"))
+			}
+			return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to get project: %w", err))
+		}
+		projectID = pgtype.UUID{Bytes: id, Valid: true}
+	} else if req.HasProjectId() {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("project_id is only allowed for the project admin role"))
+	}
+
+	object := accessElevationObject(role, organizationID, projectID)
+
+	if err := s.checkPermission(ctx, authz.CanView(), object); err != nil {
+		return nil, err
+	}
+
+	// Someone who holds the role, directly or through a team, has nothing to
+	// request. Without authorization everyone holds every role.
+	if s.authz != nil {
+		action := authz.Admin()
+		if role == dbconst.AccessElevationRole_ProjectAdmin {
+			action = authz.ProjectAdmin()
+		}
+		err := s.evaluatePermission(ctx, action, object)
+		if err == nil {
+			return nil, connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("you already have the %s role", role))
+		}
+		if connect.CodeOf(err) != connect.CodePermissionDenied {
+			return nil, err
+		}
+	}
+
+	elevationID, err := s.queries.AccessElevationCreate(ctx, db.AccessElevationCreateParams{
+		OrganizationID:  organizationID,
+		UserID:          userID,
+		Role:            role,
+		ProjectID:       projectID,
+		DurationMinutes: req.GetDurationMinutes(),
+		Reason:          req.GetReason(),
+	})
+	if err != nil {
+		if pgErr, ok := errors.AsType[*pgconn.PgError](err); ok {
+			switch {
+			case pgErr.Code == pgerrcode.UniqueViolation && pgErr.ConstraintName == dbconst.ConstraintAccessElevationsUqOpen:
+				return nil, connect.NewError(connect.CodeAlreadyExists,
+					fmt.Errorf("you already have an open request for this role"))
+			case pgErr.Code == pgerrcode.CheckViolation && pgErr.ConstraintName == dbconst.ConstraintAccessElevationsCkReason:
+				return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("reason must not be blank"))
+			}
+		}
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to request access elevation: %w", err))
+	}
+
+	s.logger.InfoContext(ctx, "access elevation requested",
+		"access_elevation_id", elevationID,
+		"organization_id", organizationID,
+		"user_id", userID,
+		"role", role,
+		"duration_minutes", req.GetDurationMinutes(),
+	)
+
+	return organizationv1.RequestAccessElevationResponse_builder{
+		AccessElevationId: elevationID.String(),
+	}.Build(), nil
+}
@@ -0,0 +1,59 @@
+package organization
+
+import (
+	"context"
+	"fmt"
+
+	"connectrpc.com/connect"
+	"github.com/google/uuid"
+
+	"github.com/fundament-oss/fundament/common/authz"
+	db "github.com/fundament-oss/fundament/organization-api/pkg/db/gen"
+	organizationv1 "github.com/fundament-oss/fundament/organization-api/pkg/proto/gen/v1"
+)
+
+func (s *Server) RevokeAccessElevation(
+	ctx context.Context,
+	req *organizationv1.RevokeAccessElevationRequest,
+) (*organizationv1.RevokeAccessElevationResponse, error) {
+	organizationID, ok := OrganizationIDFromContext(ctx)
+	if !ok {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("organization_id missing from context"))
+	}
+	userID, ok := UserIDFromContext(ctx)
+	if !ok {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("user_id missing from context"))
+	}
+
+	elevationID := uuid.MustParse(req.GetAccessElevationId())
+
+	elevation, err := s.accessElevationGet(ctx, elevationID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Giving up your own request or role needs no permission.
+	if elevation.UserID != userID {
+		if err := s.checkPermission(ctx, authz.CanApproveAccessElevations(),
+			accessElevationObject(elevation.Role, organizationID, elevation.ProjectID)); err != nil {
+			return nil, err
+		}
+	}
+
+	rows, err := s.queries.AccessElevationRevoke(ctx, db.AccessElevationRevokeParams{ID: elevationID})
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to revoke access elevation: %w", err))
+	}
+	if rows == 0 {
+		return nil, connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("access elevation has already ended"))
+	}
+
+	s.logger.InfoContext(ctx, "access elevation revoked",
+		"access_elevation_id", elevationID,
+		"user_id", elevation.UserID,
+		"role", elevation.Role,
+		"status", elevation.Status,
+	)
+
+	return organizationv1.RevokeAccessElevationResponse_builder{}.Build(), nil
+}
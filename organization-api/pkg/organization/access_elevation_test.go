@@ -0,0 +1,214 @@
+package organization_test
+
+import (
+	"testing"
+
+	"connectrpc.com/connect"
+	organizationv1 "github.com/fundament-oss/fundament/organization-api/pkg/proto/gen/v1"
+	"github.com/fundament-oss/fundament/organization-api/pkg/proto/gen/v1/organizationv1connect"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func Test_AccessElevation_Lifecycle(t *testing.T) {
+	t.Parallel()
+
+	orgID := uuid.New()
+	requesterUserID := uuid.New()
+	approverUserID := uuid.New()
+
+	env := newTestAPI(t,
+		WithOrganization(orgID, "test-org"),
+		WithUser(&UserArgs{ID: requesterUserID, Name: "requester", OrgIDs: []uuid.UUID{orgID}}),
+		WithUser(&UserArgs{ID: approverUserID, Name: "approver", OrgIDs: []uuid.UUID{orgID}}),
+	)
+
+	requesterToken := env.createAuthnToken(t, requesterUserID)
+	approverToken := env.createAuthnToken(t, approverUserID)
+	client := organizationv1connect.NewAccessElevationServiceClient(env.server.Client(), env.server.URL)
+
+	requestReq := organizationv1.RequestAccessElevationRequest_builder{
+		Role:            organizationv1.AccessElevationRole_ACCESS_ELEVATION_ROLE_ADMIN,
+		DurationMinutes: 60,
+		Reason:          "incident 1234",
+	}.Build()
+
+	requestRes, err := client.RequestAccessElevation(authedContext(requesterToken, orgID), requestReq)
+	require.NoError(t, err)
+	elevationID := requestRes.GetAccessElevationId()
+
+	// One open request per role at a time.
+	_, err = client.RequestAccessElevation(authedContext(requesterToken, orgID), requestReq)
+	var connectErr *connect.Error
+	require.ErrorAs(t, err, &connectErr)
+	assert.Equal(t, connect.CodeAlreadyExists, connectErr.Code())
+
+	// Nobody approves their own request.
+	_, err = client.ApproveAccessElevation(authedContext(requesterToken, orgID), organizationv1.ApproveAccessElevationRequest_builder{
+		AccessElevationId: elevationID,
+	}.Build())
+	require.ErrorAs(t, err, &connectErr)
+	assert.Equal(t, connect.CodePermissionDenied, connectErr.Code())
+
+	approveRes, err := client.ApproveAccessElevation(authedContext(approverToken, orgID), organizationv1.ApproveAccessElevationRequest_builder{
+		AccessElevationId: elevationID,
+	}.Build())
+	require.NoError(t, err)
+	assert.True(t, approveRes.HasExpires())
+
+	_, err = client.DenyAccessElevation(authedContext(approverToken, orgID), organizationv1.DenyAccessElevationRequest_builder{
+		AccessElevationId: elevationID,
+	}.Build())
+	require.ErrorAs(t, err, &connectErr)
+	assert.Equal(t, connect.CodeFailedPrecondition, connectErr.Code())
+
+	listRes, err := client.ListAccessElevations(authedContext(requesterToken, orgID), organizationv1.ListAccessElevationsRequest_builder{
+		Status: organizationv1.AccessElevationStatus_ACCESS_ELEVATION_STATUS_APPROVED,
+	}.Build())
+	require.NoError(t, err)
+	require.Len(t, listRes.GetAccessElevations(), 1)
+	elevation := listRes.GetAccessElevations()[0]
+	assert.Equal(t, elevationID, elevation.GetId())
+	assert.Equal(t, "requester", elevation.GetUserName())
+	assert.Equal(t, approverUserID.String(), elevation.GetDecidedBy())
+	assert.Equal(t, int32(60), elevation.GetDurationMinutes())
+	assert.Equal(t, approveRes.GetExpires().AsTime(), elevation.GetExpires().AsTime())
+	assert.False(t, elevation.HasProjectId())
+
+	// The requester can give the role up early, once.
+	_, err = client.RevokeAccessElevation(authedContext(requesterToken, orgID), organizationv1.RevokeAccessElevationRequest_builder{
+		AccessElevationId: elevationID,
+	}.Build())
+	require.NoError(t, err)
+
+	_, err = client.RevokeAccessElevation(authedContext(requesterToken, orgID), organizationv1.RevokeAccessElevationRequest_builder{
+		AccessElevationId: elevationID,
+	}.Build())
+	require.ErrorAs(t, err, &connectErr)
+	assert.Equal(t, connect.CodeFailedPrecondition, connectErr.Code())
+
+	// With the first one ended, a new request for the role can be made.
+	requestRes, err = client.RequestAccessElevation(authedContext(requesterToken, orgID), requestReq)
+	require.NoError(t, err)
+
+	_, err = client.DenyAccessElevation(authedContext(approverToken, orgID), organizationv1.DenyAccessElevationRequest_builder{
+		AccessElevationId: requestRes.GetAccessElevationId(),
+	}.Build())
+	require.NoError(t, err)
+
+	listRes, err = client.ListAccessElevations(authedContext(requesterToken, orgID), organizationv1.ListAccessElevationsRequest_builder{}.Build())
+	require.NoError(t, err)
+	require.Len(t, listRes.GetAccessElevations(), 2)
+	assert.Equal(t, organizationv1.AccessElevationStatus_ACCESS_ELEVATION_STATUS_DENIED, listRes.GetAccessElevations()[0].GetStatus())
+	assert.Equal(t, organizationv1.AccessElevationStatus_ACCESS_ELEVATION_STATUS_REVOKED, listRes.GetAccessElevations()[1].GetStatus())
+	assert.True(t, listRes.GetAccessElevations()[1].HasEnded())
+}
+
+func Test_AccessElevation_ProjectAdminRequiresProject(t *testing.T) {
+	t.Parallel()
+
+	orgID := uuid.New()
+	userID := uuid.New()
+
+	env := newTestAPI(t,
+		WithOrganization(orgID, "test-org"),
+		WithUser(&UserArgs{ID: userID, Name: "requester", OrgIDs: []uuid.UUID{orgID}}),
+	)
+
+	token := env.createAuthnToken(t, userID)
+	client := organizationv1connect.NewAccessElevationServiceClient(env.server.Client(), env.server.URL)
+
+	_, err := client.RequestAccessElevation(authedContext(token, orgID), organizationv1.RequestAccessElevationRequest_builder{
+		Role:            organizationv1.AccessElevationRole_ACCESS_ELEVATION_ROLE_PROJECT_ADMIN,
+		DurationMinutes: 30,
+		Reason:          "deploy fix",
+	}.Build())
+
+	var connectErr *connect.Error
+	require.ErrorAs(t, err, &connectErr)
+	assert.Equal(t, connect.CodeInvalidArgument, connectErr.Code())
+}
+
+func Test_AccessElevation_ElevatedAdminCannotApproveProjectElevation(t *testing.T) {
+	t.Parallel()
+
+	orgID := uuid.New()
+	elevatedUserID := uuid.New()
+	approverUserID := uuid.New()
+	requesterUserID := uuid.New()
+
+	env := newTestAPI(t,
+		WithOrganization(orgID, "test-org"),
+		WithUser(&UserArgs{ID: elevatedUserID, Name: "elevated", OrgIDs: []uuid.UUID{orgID}}),
+		WithUser(&UserArgs{ID: approverUserID, Name: "approver", OrgIDs: []uuid.UUID{orgID}}),
+		WithUser(&UserArgs{ID: requesterUserID, Name: "requester", OrgIDs: []uuid.UUID{orgID}}),
+	)
+
+	elevatedToken := env.createAuthnToken(t, elevatedUserID)
+	approverToken := env.createAuthnToken(t, approverUserID)
+	requesterToken := env.createAuthnToken(t, requesterUserID)
+	client := organizationv1connect.NewAccessElevationServiceClient(env.server.Client(), env.server.URL)
+	clusterClient := organizationv1connect.NewClusterServiceClient(env.server.Client(), env.server.URL)
+	projectClient := organizationv1connect.NewProjectServiceClient(env.server.Client(), env.server.URL)
+
+	clusterRes, err := clusterClient.CreateCluster(authedContext(approverToken, orgID), organizationv1.CreateClusterRequest_builder{
+		Name:              "test-cluster",
+		Region:            "eu-west-1",
+		KubernetesVersion: "1.28",
+	}.Build())
+	require.NoError(t, err)
+
+	projectRes, err := projectClient.CreateProject(authedContext(approverToken, orgID), organizationv1.CreateProjectRequest_builder{
+		ClusterId: clusterRes.GetClusterId(),
+		Name:      "test-project",
+	}.Build())
+	require.NoError(t, err)
+
+	// Elevated to organization admin, and so project admin of every project.
+	orgRes, err := client.RequestAccessElevation(authedContext(elevatedToken, orgID), organizationv1.RequestAccessElevationRequest_builder{
+		Role:            organizationv1.AccessElevationRole_ACCESS_ELEVATION_ROLE_ADMIN,
+		DurationMinutes: 60,
+		Reason:          "incident 1234",
+	}.Build())
+	require.NoError(t, err)
+
+	_, err = client.ApproveAccessElevation(authedContext(approverToken, orgID), organizationv1.ApproveAccessElevationRequest_builder{
+		AccessElevationId: orgRes.GetAccessElevationId(),
+	}.Build())
+	require.NoError(t, err)
+
+	projectReq, err := client.RequestAccessElevation(authedContext(requesterToken, orgID), organizationv1.RequestAccessElevationRequest_builder{
+		Role:            organizationv1.AccessElevationRole_ACCESS_ELEVATION_ROLE_PROJECT_ADMIN,
+		ProjectId:       proto.String(projectRes.GetProjectId()),
+		DurationMinutes: 30,
+		Reason:          "deploy fix",
+	}.Build())
+	require.NoError(t, err)
+
+	// The elevation does not extend to approving elevations for others.
+	_, err = client.ApproveAccessElevation(authedContext(elevatedToken, orgID), organizationv1.ApproveAccessElevationRequest_builder{
+		AccessElevationId: projectReq.GetAccessElevationId(),
+	}.Build())
+	var connectErr *connect.Error
+	require.ErrorAs(t, err, &connectErr)
+	assert.Equal(t, connect.CodePermissionDenied, connectErr.Code())
+
+	_, err = client.DenyAccessElevation(authedContext(elevatedToken, orgID), organizationv1.DenyAccessElevationRequest_builder{
+		AccessElevationId: projectReq.GetAccessElevationId(),
+	}.Build())
+	require.ErrorAs(t, err, &connectErr)
+	assert.Equal(t, connect.CodePermissionDenied, connectErr.Code())
+
+	// Once the elevation is given up, the regular admin role applies again.
+	_, err = client.RevokeAccessElevation(authedContext(elevatedToken, orgID), organizationv1.RevokeAccessElevationRequest_builder{
+		AccessElevationId: orgRes.GetAccessElevationId(),
+	}.Build())
+	require.NoError(t, err)
+
+	_, err = client.ApproveAccessElevation(authedContext(elevatedToken, orgID), organizationv1.ApproveAccessElevationRequest_builder{
+		AccessElevationId: projectReq.GetAccessElevationId(),
+	}.Build())
+	require.NoError(t, err)
+}
@@ -23,6 +23,7 @@ func apiKeyFromGetRow(record *db.APIKeyGetByIDRow) *organizationv1.APIKey {
 	if record.Revoked.Valid {
 		apiKey.SetRevoked(timestamppb.New(record.Revoked.Time))
 	}
+	apiKey.SetScope(apiKeyScopeToProto(record.ScopeProjectIds, record.ScopeClusterIds, record.ScopeActions, record.ScopeReadOnly))
 	return apiKey
 }
 
@@ -42,5 +43,6 @@ func apiKeyFromListRow(record *db.APIKeyListByOrganizationIDRow) *organizationv1
 	if record.Revoked.Valid {
 		apiKey.SetRevoked(timestamppb.New(record.Revoked.Time))
 	}
+	apiKey.SetScope(apiKeyScopeToProto(record.ScopeProjectIds, record.ScopeClusterIds, record.ScopeActions, record.ScopeReadOnly))
 	return apiKey
 }
@@ -66,6 +66,10 @@ func (s *Server) CreateAPIKey(
 		Expires:        expires,
 	}
 
+	if err := s.apiKeyScopeParams(ctx, req.GetScope(), &params); err != nil {
+		return nil, err
+	}
+
 	id, err := s.queries.APIKeyCreate(ctx, params)
 	if err != nil {
 		if pgErr, ok := errors.AsType[*pgconn.PgError](err); ok {
@@ -82,6 +86,7 @@ func (s *Server) CreateAPIKey(
 		"organization_id", organizationID,
 		"user_id", userID,
 		"name", req.GetName(),
+		"scoped", req.HasScope(),
 	)
 
 	return organizationv1.CreateAPIKeyResponse_builder{
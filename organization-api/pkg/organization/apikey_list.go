@@ -29,20 +29,35 @@ func (s *Server) ListAPIKeys(
 		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("user_id missing from context"))
 	}
 
+	cursor, err := decodePageToken(req.GetPageToken())
+	if err != nil {
+		return nil, err
+	}
+	afterCreated, afterID := createdCursorParams(cursor)
+
 	keys, err := s.queries.APIKeyListByOrganizationID(ctx, db.APIKeyListByOrganizationIDParams{
 		OrganizationID: organizationID,
 		UserID:         userID,
+		NameFilter:     nameFilter(req.GetNameFilter()),
+		AfterCreated:   afterCreated,
+		AfterID:        afterID,
+		PageLimit:      pageLimit(req.GetPageSize()),
 	})
 	if err != nil {
 		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to list api keys: %w", err))
 	}
 
+	keys, nextPageToken := trimPage(keys, req.GetPageSize(), func(row *db.APIKeyListByOrganizationIDRow) pageCursor {
+		return pageCursor{Created: row.Created.Time, ID: row.ID}
+	})
+
 	result := make([]*organizationv1.APIKey, 0, len(keys))
 	for idx := range keys {
 		result = append(result, apiKeyFromListRow(&keys[idx]))
 	}
 
 	return organizationv1.ListAPIKeysResponse_builder{
-		ApiKeys: result,
+		ApiKeys:       result,
+		NextPageToken: nextPageToken,
 	}.Build(), nil
 }
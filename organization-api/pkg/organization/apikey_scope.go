@@ -0,0 +1,221 @@
+package organization
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"connectrpc.com/connect"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/fundament-oss/fundament/common/auth"
+	"github.com/fundament-oss/fundament/common/authz"
+	db "github.com/fundament-oss/fundament/organization-api/pkg/db/gen"
+	organizationv1 "github.com/fundament-oss/fundament/organization-api/pkg/proto/gen/v1"
+)
+
+// scopeActions are the actions an API key scope may list. can_create_apikey
+// is deliberately missing: a scoped key must not be able to create a key
+// broader than itself. can_approve_plugin_definitions is missing too: a scoped
+// key must not be able to consent to new plugin permissions. Nor is
+// can_approve_access_elevations: granting a role takes the approver in person.
+var scopeActions = []authz.ActionName{
+	authz.ActionCanView,
+	authz.ActionCanEdit,
+	authz.ActionCanDelete,
+	authz.ActionCanListApikeys,
+	authz.ActionCanCreateCluster,
+	authz.ActionCanListClusters,
+	authz.ActionCanInviteMember,
+	authz.ActionCanEditMember,
+	authz.ActionCanDeleteMember,
+	authz.ActionCanListMembers,
+	authz.ActionCanListAuditEvents,
+	authz.ActionCanManageWebhooks,
+	authz.ActionCanListTeams,
+	authz.ActionCanManageTeams,
+	authz.ActionCanManageMembers,
+	authz.ActionCanCreateNamespace,
+	authz.ActionCanListNamespaces,
+	authz.ActionCanCreateNodePool,
+	authz.ActionCanListNodePools,
+	authz.ActionCanCreateProject,
+	authz.ActionCanListProjects,
+}
+
+// checkAPIKeyScope enforces the scope of a token exchanged for a scoped API
+// key. It only narrows: the OpenFGA check still decides whether the user
+// behind the key holds the permission at all.
+func (s *Server) checkAPIKeyScope(ctx context.Context, action authz.Action, resource authz.Object) error {
+	scope, ok := APIKeyScopeFromContext(ctx)
+	if !ok {
+		return nil
+	}
+
+	if action.Name == authz.ActionCanCreateApikey || !scope.AllowsAction(string(action.Name)) {
+		return connect.NewError(connect.CodePermissionDenied, fmt.Errorf("API key scope does not allow %s", action.Name))
+	}
+
+	if !scope.RestrictsResources() {
+		return nil
+	}
+
+	inScope, err := s.resourceInScope(ctx, scope, action, resource)
+	if err != nil {
+		return connect.NewError(connect.CodeInternal, fmt.Errorf("failed to resolve API key scope: %w", err))
+	}
+	if !inScope {
+		return connect.NewError(connect.CodePermissionDenied, fmt.Errorf("%s %s is outside the API key scope", resource.Type, resource.ID))
+	}
+
+	return nil
+}
+
+// resourceInScope reports whether a key restricted to projects and clusters
+// reaches the resource. Namespaces, node pools and project members are
+// resolved to the project or cluster they belong to.
+func (s *Server) resourceInScope(ctx context.Context, scope *auth.Scope, action authz.Action, resource authz.Object) (bool, error) {
+	id, err := uuid.Parse(resource.ID)
+	if err != nil {
+		return false, nil
+	}
+
+	switch resource.Type {
+	case authz.ObjectTypeOrganization:
+		// Anything else on the organization (listing, creating, member
+		// management) reaches beyond the projects and clusters in scope.
+		return action.Name == authz.ActionCanView, nil
+
+	case authz.ObjectTypeCluster:
+		return scope.IncludesCluster(id), nil
+
+	case authz.ObjectTypeProject:
+		return s.projectInScope(ctx, scope, id)
+
+	case authz.ObjectTypeNamespace:
+		namespace, err := s.queries.NamespaceGetByID(ctx, db.NamespaceGetByIDParams{ID: id})
+		if err != nil {
+			return notFoundIsOutOfScope(err)
+		}
+		return scope.IncludesProject(namespace.ProjectID, namespace.ClusterID), nil
+
+	case authz.ObjectTypeNodePool:
+		nodePool, err := s.queries.NodePoolGetByID(ctx, db.NodePoolGetByIDParams{ID: id})
+		if err != nil {
+			return notFoundIsOutOfScope(err)
+		}
+		return scope.IncludesCluster(nodePool.ClusterID), nil
+
+	case authz.ObjectTypeProjectMember:
+		member, err := s.queries.ProjectMemberGetByID(ctx, db.ProjectMemberGetByIDParams{ID: id})
+		if err != nil {
+			return notFoundIsOutOfScope(err)
+		}
+		return s.projectInScope(ctx, scope, member.ProjectID)
+
+	default:
+		// API keys and plugins are not tied to a project or cluster.
+		return false, nil
+	}
+}
+
+func (s *Server) projectInScope(ctx context.Context, scope *auth.Scope, projectID uuid.UUID) (bool, error) {
+	if slices.Contains(scope.ProjectIDs, projectID) {
+		return true, nil
+	}
+	project, err := s.queries.ProjectGetByID(ctx, db.ProjectGetByIDParams{ID: projectID})
+	if err != nil {
+		return notFoundIsOutOfScope(err)
+	}
+	return scope.IncludesProject(project.ID, project.ClusterID), nil
+}
+
+// notFoundIsOutOfScope treats a resource that cannot be resolved as out of
+// scope, so the request is denied instead of failing with an internal error.
+func notFoundIsOutOfScope(err error) (bool, error) {
+	if errors.Is(err, pgx.ErrNoRows) {
+		return false, nil
+	}
+	return false, err
+}
+
+// apiKeyScopeParams validates the requested scope of a new API key and sets
+// the api_keys scope columns from it; a nil scope leaves the key unrestricted.
+// Projects and clusters must exist in the organization and be visible to the
+// creator.
+func (s *Server) apiKeyScopeParams(ctx context.Context, scope *organizationv1.APIKeyScope, params *db.APIKeyCreateParams) error {
+	// The columns are NOT NULL; pgx encodes a nil slice as NULL.
+	params.ScopeProjectIds = []uuid.UUID{}
+	params.ScopeClusterIds = []uuid.UUID{}
+	params.ScopeActions = []string{}
+	if scope == nil {
+		return nil
+	}
+
+	for _, action := range scope.GetActions() {
+		if !slices.Contains(scopeActions, authz.ActionName(action)) {
+			return connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("action %q cannot be granted to an API key", action))
+		}
+		if scope.GetReadOnly() && !auth.IsReadAction(action) {
+			return connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("action %q is not allowed on a read-only API key", action))
+		}
+	}
+
+	for _, raw := range scope.GetProjectIds() {
+		projectID := uuid.MustParse(raw)
+		if _, err := s.queries.ProjectGetByID(ctx, db.ProjectGetByIDParams{ID: projectID}); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("project %s not found", projectID))
+			}
+			return connect.NewError(connect.CodeInternal, fmt.Errorf("failed to get project: %w", err))
+		}
+		if err := s.checkPermission(ctx, authz.CanView(), authz.Project(projectID)); err != nil {
+			return err
+		}
+		params.ScopeProjectIds = append(params.ScopeProjectIds, projectID)
+	}
+
+	for _, raw := range scope.GetClusterIds() {
+		clusterID := uuid.MustParse(raw)
+		if _, err := s.queries.ClusterGetByID(ctx, db.ClusterGetByIDParams{ID: clusterID}); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("cluster %s not found", clusterID))
+			}
+			return connect.NewError(connect.CodeInternal, fmt.Errorf("failed to get cluster: %w", err))
+		}
+		if err := s.checkPermission(ctx, authz.CanView(), authz.Cluster(clusterID)); err != nil {
+			return err
+		}
+		params.ScopeClusterIds = append(params.ScopeClusterIds, clusterID)
+	}
+
+	params.ScopeActions = append(params.ScopeActions, scope.GetActions()...)
+	params.ScopeReadOnly = scope.GetReadOnly()
+	return nil
+}
+
+// apiKeyScopeToProto returns the scope of a stored API key, or nil if the key
+// is not restricted.
+func apiKeyScopeToProto(projectIDs, clusterIDs []uuid.UUID, actions []string, readOnly bool) *organizationv1.APIKeyScope {
+	if len(projectIDs) == 0 && len(clusterIDs) == 0 && len(actions) == 0 && !readOnly {
+		return nil
+	}
+
+	projects := make([]string, 0, len(projectIDs))
+	for _, id := range projectIDs {
+		projects = append(projects, id.String())
+	}
+	clusters := make([]string, 0, len(clusterIDs))
+	for _, id := range clusterIDs {
+		clusters = append(clusters, id.String())
+	}
+
+	return organizationv1.APIKeyScope_builder{
+		ProjectIds: projects,
+		ClusterIds: clusters,
+		ReadOnly:   readOnly,
+		Actions:    actions,
+	}.Build()
+}
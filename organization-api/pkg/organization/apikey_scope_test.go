@@ -0,0 +1,166 @@
+package organization_test
+
+import (
+	"context"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fundament-oss/fundament/common/auth"
+	organizationv1 "github.com/fundament-oss/fundament/organization-api/pkg/proto/gen/v1"
+	"github.com/fundament-oss/fundament/organization-api/pkg/proto/gen/v1/organizationv1connect"
+)
+
+func authedContext(token string, orgID uuid.UUID) context.Context {
+	ctx, callInfo := connect.NewClientContext(context.Background())
+	callInfo.RequestHeader().Set("Authorization", "Bearer "+token)
+	callInfo.RequestHeader().Set("Fun-Organization", orgID.String())
+	return ctx
+}
+
+func Test_APIKey_Create_Scoped(t *testing.T) {
+	t.Parallel()
+
+	orgID := uuid.New()
+	userID := uuid.New()
+
+	env := newTestAPI(t,
+		WithOrganization(orgID, "test-org"),
+		WithUser(&UserArgs{
+			ID:     userID,
+			Name:   "test-user",
+			OrgIDs: []uuid.UUID{orgID},
+		}),
+	)
+
+	ctx := authedContext(env.createAuthnToken(t, userID), orgID)
+
+	clusterRes, err := organizationv1connect.NewClusterServiceClient(env.server.Client(), env.server.URL).
+		CreateCluster(ctx, organizationv1.CreateClusterRequest_builder{
+			Name:              "test-cluster",
+			Region:            "eu-west-1",
+			KubernetesVersion: "1.28",
+		}.Build())
+	require.NoError(t, err)
+
+	client := organizationv1connect.NewAPIKeyServiceClient(env.server.Client(), env.server.URL)
+
+	res, err := client.CreateAPIKey(ctx, organizationv1.CreateAPIKeyRequest_builder{
+		Name: "ci-key",
+		Scope: organizationv1.APIKeyScope_builder{
+			ClusterIds: []string{clusterRes.GetClusterId()},
+			ReadOnly:   true,
+		}.Build(),
+	}.Build())
+	require.NoError(t, err)
+
+	getRes, err := client.GetAPIKey(ctx, organizationv1.GetAPIKeyRequest_builder{ApiKeyId: res.GetId()}.Build())
+	require.NoError(t, err)
+	require.True(t, getRes.GetApiKey().HasScope())
+	assert.Equal(t, []string{clusterRes.GetClusterId()}, getRes.GetApiKey().GetScope().GetClusterIds())
+	assert.True(t, getRes.GetApiKey().GetScope().GetReadOnly())
+
+	unscoped, err := client.CreateAPIKey(ctx, organizationv1.CreateAPIKeyRequest_builder{Name: "full-key"}.Build())
+	require.NoError(t, err)
+	getRes, err = client.GetAPIKey(ctx, organizationv1.GetAPIKeyRequest_builder{ApiKeyId: unscoped.GetId()}.Build())
+	require.NoError(t, err)
+	assert.False(t, getRes.GetApiKey().HasScope())
+
+	tests := map[string]*organizationv1.APIKeyScope{
+		"unknown_cluster": organizationv1.APIKeyScope_builder{ClusterIds: []string{uuid.New().String()}}.Build(),
+		"unknown_action":  organizationv1.APIKeyScope_builder{Actions: []string{"can_do_anything"}}.Build(),
+		"apikey_action":   organizationv1.APIKeyScope_builder{Actions: []string{"can_create_apikey"}}.Build(),
+		"write_read_only": organizationv1.APIKeyScope_builder{ReadOnly: true, Actions: []string{"can_edit"}}.Build(),
+	}
+	for name, scope := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := client.CreateAPIKey(ctx, organizationv1.CreateAPIKeyRequest_builder{
+				Name:  "invalid-" + name,
+				Scope: scope,
+			}.Build())
+			assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+		})
+	}
+}
+
+func Test_APIKey_Scope_Enforced(t *testing.T) {
+	t.Parallel()
+
+	orgID := uuid.New()
+	otherOrgID := uuid.New()
+	userID := uuid.New()
+
+	env := newTestAPI(t,
+		WithOrganization(orgID, "test-org"),
+		WithOrganization(otherOrgID, "other-org"),
+		WithUser(&UserArgs{
+			ID:     userID,
+			Name:   "test-user",
+			OrgIDs: []uuid.UUID{orgID, otherOrgID},
+		}),
+	)
+
+	ctx := authedContext(env.createAuthnToken(t, userID), orgID)
+
+	clusterRes, err := organizationv1connect.NewClusterServiceClient(env.server.Client(), env.server.URL).
+		CreateCluster(ctx, organizationv1.CreateClusterRequest_builder{
+			Name:              "test-cluster",
+			Region:            "eu-west-1",
+			KubernetesVersion: "1.28",
+		}.Build())
+	require.NoError(t, err)
+
+	projectClient := organizationv1connect.NewProjectServiceClient(env.server.Client(), env.server.URL)
+	createProject := func(name string) string {
+		res, err := projectClient.CreateProject(ctx, organizationv1.CreateProjectRequest_builder{
+			ClusterId: clusterRes.GetClusterId(),
+			Name:      name,
+		}.Build())
+		require.NoError(t, err)
+		return res.GetProjectId()
+	}
+	inScope := createProject("in-scope")
+	outOfScope := createProject("out-of-scope")
+
+	scope := &auth.Scope{
+		OrganizationID: orgID,
+		ProjectIDs:     []uuid.UUID{uuid.MustParse(inScope)},
+		Actions:        []string{"can_view", "can_edit"},
+	}
+	scopedCtx := authedContext(env.createScopedAuthnToken(t, userID, scope), orgID)
+
+	_, err = projectClient.GetProject(scopedCtx, organizationv1.GetProjectRequest_builder{ProjectId: inScope}.Build())
+	require.NoError(t, err)
+
+	_, err = projectClient.GetProject(scopedCtx, organizationv1.GetProjectRequest_builder{ProjectId: outOfScope}.Build())
+	assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err), "project outside the scope")
+
+	_, err = projectClient.DeleteProject(scopedCtx, organizationv1.DeleteProjectRequest_builder{ProjectId: inScope}.Build())
+	assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err), "action outside the scope")
+
+	_, err = organizationv1connect.NewAPIKeyServiceClient(env.server.Client(), env.server.URL).
+		CreateAPIKey(scopedCtx, organizationv1.CreateAPIKeyRequest_builder{Name: "escalation"}.Build())
+	assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err), "scoped key creating a key")
+
+	otherOrgCtx := authedContext(env.createScopedAuthnToken(t, userID, scope), otherOrgID)
+	_, err = organizationv1connect.NewClusterServiceClient(env.server.Client(), env.server.URL).
+		ListClusters(otherOrgCtx, organizationv1.ListClustersRequest_builder{}.Build())
+	assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err), "organization of another key")
+
+	readOnly := &auth.Scope{OrganizationID: orgID, ReadOnly: true}
+	readOnlyCtx := authedContext(env.createScopedAuthnToken(t, userID, readOnly), orgID)
+
+	_, err = projectClient.GetProject(readOnlyCtx, organizationv1.GetProjectRequest_builder{ProjectId: outOfScope}.Build())
+	require.NoError(t, err)
+
+	_, err = projectClient.CreateProject(readOnlyCtx, organizationv1.CreateProjectRequest_builder{
+		ClusterId: clusterRes.GetClusterId(),
+		Name:      "read-only",
+	}.Build())
+	assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err), "write with a read-only key")
+}
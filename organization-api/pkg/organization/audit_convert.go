@@ -0,0 +1,136 @@
+package organization
+
+import (
+	"fmt"
+
+	"connectrpc.com/connect"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/fundament-oss/fundament/common/dbconst"
+	db "github.com/fundament-oss/fundament/organization-api/pkg/db/gen"
+	organizationv1 "github.com/fundament-oss/fundament/organization-api/pkg/proto/gen/v1"
+)
+
+// auditFilter holds the NULL-when-unset filter parameters shared by
+// AuditEventList and AuditEventExport.
+type auditFilter struct {
+	ActorUserID pgtype.UUID
+	Action      pgtype.Text
+	ResourceID  pgtype.Text
+	ClusterID   pgtype.UUID
+	Source      pgtype.Text
+	Outcome     pgtype.Text
+	Start       pgtype.Timestamptz
+	End         pgtype.Timestamptz
+}
+
+func auditFilterFromProto(filter *organizationv1.AuditEventFilter) (auditFilter, error) {
+	var f auditFilter
+
+	if id := filter.GetActorUserId(); id != "" {
+		f.ActorUserID = pgtype.UUID{Bytes: uuid.MustParse(id), Valid: true}
+	}
+	if id := filter.GetClusterId(); id != "" {
+		f.ClusterID = pgtype.UUID{Bytes: uuid.MustParse(id), Valid: true}
+	}
+	f.Action = pgtype.Text{String: filter.GetAction(), Valid: filter.GetAction() != ""}
+	f.ResourceID = pgtype.Text{String: filter.GetResourceId(), Valid: filter.GetResourceId() != ""}
+
+	if source := auditEventSourceToDB(filter.GetSource()); source != "" {
+		f.Source = pgtype.Text{String: string(source), Valid: true}
+	}
+	if outcome := auditEventOutcomeToDB(filter.GetOutcome()); outcome != "" {
+		f.Outcome = pgtype.Text{String: string(outcome), Valid: true}
+	}
+
+	if filter.HasStart() {
+		f.Start = pgtype.Timestamptz{Time: filter.GetStart().AsTime(), Valid: true}
+	}
+	if filter.HasEnd() {
+		f.End = pgtype.Timestamptz{Time: filter.GetEnd().AsTime(), Valid: true}
+	}
+	if f.Start.Valid && f.End.Valid && !f.Start.Time.Before(f.End.Time) {
+		return auditFilter{}, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("filter start must be before end"))
+	}
+
+	return f, nil
+}
+
+func auditEventFromDB(event *db.TenantAuditEvent) *organizationv1.AuditEvent {
+	e := organizationv1.AuditEvent_builder{
+		Id:           event.ID.String(),
+		Source:       auditEventSourceFromDB(event.Source),
+		Action:       event.Action,
+		ResourceType: event.ResourceType.String,
+		ResourceId:   event.ResourceID.String,
+		Outcome:      auditEventOutcomeFromDB(event.Outcome),
+		ErrorCode:    event.ErrorCode.String,
+		Details:      string(event.Details),
+		RemoteAddr:   event.RemoteAddr.String,
+		UserAgent:    event.UserAgent.String,
+		Created:      timestamppb.New(event.Created.Time),
+	}.Build()
+
+	if event.ActorUserID.Valid {
+		e.SetActorUserId(uuid.UUID(event.ActorUserID.Bytes).String())
+	}
+	if event.ClusterID.Valid {
+		e.SetClusterId(uuid.UUID(event.ClusterID.Bytes).String())
+	}
+
+	return e
+}
+
+func auditEventSourceFromDB(source string) organizationv1.AuditEventSource {
+	switch dbconst.AuditEventSource(source) {
+	case dbconst.AuditEventSource_OrganizationApi:
+		return organizationv1.AuditEventSource_AUDIT_EVENT_SOURCE_ORGANIZATION_API
+	case dbconst.AuditEventSource_KubeApiProxy:
+		return organizationv1.AuditEventSource_AUDIT_EVENT_SOURCE_KUBE_API_PROXY
+	case dbconst.AuditEventSource_DcimApi:
+		return organizationv1.AuditEventSource_AUDIT_EVENT_SOURCE_DCIM_API
+	default:
+		return organizationv1.AuditEventSource_AUDIT_EVENT_SOURCE_UNSPECIFIED
+	}
+}
+
+func auditEventSourceToDB(source organizationv1.AuditEventSource) dbconst.AuditEventSource {
+	switch source {
+	case organizationv1.AuditEventSource_AUDIT_EVENT_SOURCE_ORGANIZATION_API:
+		return dbconst.AuditEventSource_OrganizationApi
+	case organizationv1.AuditEventSource_AUDIT_EVENT_SOURCE_KUBE_API_PROXY:
+		return dbconst.AuditEventSource_KubeApiProxy
+	case organizationv1.AuditEventSource_AUDIT_EVENT_SOURCE_DCIM_API:
+		return dbconst.AuditEventSource_DcimApi
+	default:
+		return ""
+	}
+}
+
+func auditEventOutcomeFromDB(outcome string) organizationv1.AuditEventOutcome {
+	switch dbconst.AuditEventOutcome(outcome) {
+	case dbconst.AuditEventOutcome_Succeeded:
+		return organizationv1.AuditEventOutcome_AUDIT_EVENT_OUTCOME_SUCCEEDED
+	case dbconst.AuditEventOutcome_Failed:
+		return organizationv1.AuditEventOutcome_AUDIT_EVENT_OUTCOME_FAILED
+	case dbconst.AuditEventOutcome_Denied:
+		return organizationv1.AuditEventOutcome_AUDIT_EVENT_OUTCOME_DENIED
+	default:
+		return organizationv1.AuditEventOutcome_AUDIT_EVENT_OUTCOME_UNSPECIFIED
+	}
+}
+
+func auditEventOutcomeToDB(outcome organizationv1.AuditEventOutcome) dbconst.AuditEventOutcome {
+	switch outcome {
+	case organizationv1.AuditEventOutcome_AUDIT_EVENT_OUTCOME_SUCCEEDED:
+		return dbconst.AuditEventOutcome_Succeeded
+	case organizationv1.AuditEventOutcome_AUDIT_EVENT_OUTCOME_FAILED:
+		return dbconst.AuditEventOutcome_Failed
+	case organizationv1.AuditEventOutcome_AUDIT_EVENT_OUTCOME_DENIED:
+		return dbconst.AuditEventOutcome_Denied
+	default:
+		return ""
+	}
+}
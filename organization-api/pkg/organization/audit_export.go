@@ -0,0 +1,79 @@
+package organization
+
+import (
+	"context"
+	"fmt"
+
+	"connectrpc.com/connect"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/fundament-oss/fundament/common/authz"
+	db "github.com/fundament-oss/fundament/organization-api/pkg/db/gen"
+	organizationv1 "github.com/fundament-oss/fundament/organization-api/pkg/proto/gen/v1"
+)
+
+// auditExportBatchSize is the number of events read per query and sent per
+// stream message.
+const auditExportBatchSize = 500
+
+// ExportAuditEvents streams every event matching the filter, oldest first.
+// Each batch is a separate keyset query, so the export does not hold a
+// transaction open and picks up events recorded while it runs.
+func (s *Server) ExportAuditEvents(
+	ctx context.Context,
+	req *organizationv1.ExportAuditEventsRequest,
+	stream *connect.ServerStream[organizationv1.ExportAuditEventsResponse],
+) error {
+	organizationID, ok := OrganizationIDFromContext(ctx)
+	if !ok {
+		return connect.NewError(connect.CodeInternal, fmt.Errorf("organization_id missing from context"))
+	}
+
+	if err := s.checkPermission(ctx, authz.CanListAuditEvents(), authz.Organization(organizationID)); err != nil {
+		return err
+	}
+
+	filter, err := auditFilterFromProto(req.GetFilter())
+	if err != nil {
+		return err
+	}
+
+	var afterCreated pgtype.Timestamptz
+	var afterID pgtype.UUID
+	for {
+		events, err := s.queries.AuditEventExport(ctx, db.AuditEventExportParams{
+			ActorUserID:  filter.ActorUserID,
+			Action:       filter.Action,
+			ResourceID:   filter.ResourceID,
+			ClusterID:    filter.ClusterID,
+			Source:       filter.Source,
+			Outcome:      filter.Outcome,
+			Start:        filter.Start,
+			End:          filter.End,
+			AfterCreated: afterCreated,
+			AfterID:      afterID,
+			BatchSize:    auditExportBatchSize,
+		})
+		if err != nil {
+			return connect.NewError(connect.CodeInternal, fmt.Errorf("failed to export audit events: %w", err))
+		}
+		if len(events) == 0 {
+			return nil
+		}
+
+		batch := make([]*organizationv1.AuditEvent, 0, len(events))
+		for i := range events {
+			batch = append(batch, auditEventFromDB(&events[i]))
+		}
+		if err := stream.Send(organizationv1.ExportAuditEventsResponse_builder{Events: batch}.Build()); err != nil {
+			return fmt.Errorf("send audit events: %w", err)
+		}
+
+		if len(events) < auditExportBatchSize {
+			return nil
+		}
+		last := &events[len(events)-1]
+		afterCreated = last.Created
+		afterID = pgtype.UUID{Bytes: last.ID, Valid: true}
+	}
+}
@@ -0,0 +1,77 @@
+package organization
+
+import (
+	"context"
+	"fmt"
+
+	"connectrpc.com/connect"
+
+	"github.com/fundament-oss/fundament/common/authz"
+	db "github.com/fundament-oss/fundament/organization-api/pkg/db/gen"
+	organizationv1 "github.com/fundament-oss/fundament/organization-api/pkg/proto/gen/v1"
+)
+
+// defaultAuditPageSize applies when ListAuditEvents is called without a
+// page_size: unlike other lists, the audit log is never returned whole.
+const defaultAuditPageSize = 100
+
+func (s *Server) ListAuditEvents(
+	ctx context.Context,
+	req *organizationv1.ListAuditEventsRequest,
+) (*organizationv1.ListAuditEventsResponse, error) {
+	organizationID, ok := OrganizationIDFromContext(ctx)
+	if !ok {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("organization_id missing from context"))
+	}
+
+	if err := s.checkPermission(ctx, authz.CanListAuditEvents(), authz.Organization(organizationID)); err != nil {
+		return nil, err
+	}
+
+	filter, err := auditFilterFromProto(req.GetFilter())
+	if err != nil {
+		return nil, err
+	}
+
+	cursor, err := decodePageToken(req.GetPageToken())
+	if err != nil {
+		return nil, err
+	}
+	afterCreated, afterID := createdCursorParams(cursor)
+
+	pageSize := req.GetPageSize()
+	if pageSize == 0 {
+		pageSize = defaultAuditPageSize
+	}
+
+	events, err := s.queries.AuditEventList(ctx, db.AuditEventListParams{
+		ActorUserID:  filter.ActorUserID,
+		Action:       filter.Action,
+		ResourceID:   filter.ResourceID,
+		ClusterID:    filter.ClusterID,
+		Source:       filter.Source,
+		Outcome:      filter.Outcome,
+		Start:        filter.Start,
+		End:          filter.End,
+		AfterCreated: afterCreated,
+		AfterID:      afterID,
+		PageLimit:    pageLimit(pageSize),
+	})
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to list audit events: %w", err))
+	}
+
+	events, nextPageToken := trimPage(events, pageSize, func(row *db.TenantAuditEvent) pageCursor {
+		return pageCursor{Created: row.Created.Time, ID: row.ID}
+	})
+
+	result := make([]*organizationv1.AuditEvent, 0, len(events))
+	for i := range events {
+		result = append(result, auditEventFromDB(&events[i]))
+	}
+
+	return organizationv1.ListAuditEventsResponse_builder{
+		Events:        result,
+		NextPageToken: nextPageToken,
+	}.Build(), nil
+}
@@ -0,0 +1,217 @@
+package organization_test
+
+import (
+	"context"
+	"testing"
+
+	"connectrpc.com/connect"
+	organizationv1 "github.com/fundament-oss/fundament/organization-api/pkg/proto/gen/v1"
+	"github.com/fundament-oss/fundament/organization-api/pkg/proto/gen/v1/organizationv1connect"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const createClusterAction = "/organization.v1.ClusterService/CreateCluster"
+
+func Test_Audit_Unauthenticated(t *testing.T) {
+	t.Parallel()
+
+	env := newTestAPI(t, WithAuditLog())
+	client := organizationv1connect.NewAuditServiceClient(env.server.Client(), env.server.URL)
+
+	_, err := client.ListAuditEvents(context.Background(), organizationv1.ListAuditEventsRequest_builder{}.Build())
+
+	var connectErr *connect.Error
+	require.ErrorAs(t, err, &connectErr)
+	assert.Equal(t, connect.CodeUnauthenticated, connectErr.Code())
+}
+
+func Test_Audit_RecordsMutations(t *testing.T) {
+	t.Parallel()
+
+	orgID := uuid.New()
+	userID := uuid.New()
+
+	env := newTestAPI(t,
+		WithAuditLog(),
+		WithOrganization(orgID, "test-org"),
+		WithUser(&UserArgs{ID: userID, Name: "test-user", OrgIDs: []uuid.UUID{orgID}}),
+	)
+
+	token := env.createAuthnToken(t, userID)
+	clusterClient := organizationv1connect.NewClusterServiceClient(env.server.Client(), env.server.URL)
+	auditClient := organizationv1connect.NewAuditServiceClient(env.server.Client(), env.server.URL)
+
+	createRes, err := clusterClient.CreateCluster(authedContext(token, orgID), organizationv1.CreateClusterRequest_builder{
+		Name:              "test-cluster",
+		Region:            "eu-west-1",
+		KubernetesVersion: "1.28",
+	}.Build())
+	require.NoError(t, err)
+	clusterID := createRes.GetClusterId()
+
+	// Reads are not recorded.
+	_, err = clusterClient.GetCluster(authedContext(token, orgID), organizationv1.GetClusterRequest_builder{
+		ClusterId: clusterID,
+	}.Build())
+	require.NoError(t, err)
+
+	// A failed mutation is recorded with its error code.
+	_, err = clusterClient.DeleteCluster(authedContext(token, orgID), organizationv1.DeleteClusterRequest_builder{
+		ClusterId: uuid.New().String(),
+	}.Build())
+	require.Error(t, err)
+
+	listRes, err := auditClient.ListAuditEvents(authedContext(token, orgID), organizationv1.ListAuditEventsRequest_builder{}.Build())
+	require.NoError(t, err)
+	require.Len(t, listRes.GetEvents(), 2)
+	assert.Empty(t, listRes.GetNextPageToken())
+
+	// Newest first.
+	failed := listRes.GetEvents()[0]
+	assert.Equal(t, "/organization.v1.ClusterService/DeleteCluster", failed.GetAction())
+	assert.Equal(t, organizationv1.AuditEventOutcome_AUDIT_EVENT_OUTCOME_FAILED, failed.GetOutcome())
+	assert.Equal(t, "not_found", failed.GetErrorCode())
+
+	created := listRes.GetEvents()[1]
+	assert.Equal(t, createClusterAction, created.GetAction())
+	assert.Equal(t, organizationv1.AuditEventSource_AUDIT_EVENT_SOURCE_ORGANIZATION_API, created.GetSource())
+	assert.Equal(t, organizationv1.AuditEventOutcome_AUDIT_EVENT_OUTCOME_SUCCEEDED, created.GetOutcome())
+	assert.Equal(t, userID.String(), created.GetActorUserId())
+	assert.Equal(t, "cluster", created.GetResourceType())
+	assert.Equal(t, clusterID, created.GetResourceId())
+	assert.Contains(t, created.GetDetails(), "test-cluster")
+	assert.NotNil(t, created.GetCreated())
+
+	filtered, err := auditClient.ListAuditEvents(authedContext(token, orgID), organizationv1.ListAuditEventsRequest_builder{
+		Filter: organizationv1.AuditEventFilter_builder{
+			Outcome: organizationv1.AuditEventOutcome_AUDIT_EVENT_OUTCOME_SUCCEEDED,
+		}.Build(),
+	}.Build())
+	require.NoError(t, err)
+	require.Len(t, filtered.GetEvents(), 1)
+	assert.Equal(t, createClusterAction, filtered.GetEvents()[0].GetAction())
+}
+
+func Test_Audit_OrganizationIsolation(t *testing.T) {
+	t.Parallel()
+
+	orgID := uuid.New()
+	otherOrgID := uuid.New()
+	userID := uuid.New()
+
+	env := newTestAPI(t,
+		WithAuditLog(),
+		WithOrganization(orgID, "test-org"),
+		WithOrganization(otherOrgID, "other-org"),
+		WithUser(&UserArgs{ID: userID, Name: "test-user", OrgIDs: []uuid.UUID{orgID, otherOrgID}}),
+	)
+
+	token := env.createAuthnToken(t, userID)
+	clusterClient := organizationv1connect.NewClusterServiceClient(env.server.Client(), env.server.URL)
+	auditClient := organizationv1connect.NewAuditServiceClient(env.server.Client(), env.server.URL)
+
+	_, err := clusterClient.CreateCluster(authedContext(token, orgID), organizationv1.CreateClusterRequest_builder{
+		Name:              "test-cluster",
+		Region:            "eu-west-1",
+		KubernetesVersion: "1.28",
+	}.Build())
+	require.NoError(t, err)
+
+	listRes, err := auditClient.ListAuditEvents(authedContext(token, otherOrgID), organizationv1.ListAuditEventsRequest_builder{}.Build())
+	require.NoError(t, err)
+	assert.Empty(t, listRes.GetEvents())
+}
+
+func Test_Audit_PaginationAndExport(t *testing.T) {
+	t.Parallel()
+
+	orgID := uuid.New()
+	userID := uuid.New()
+
+	env := newTestAPI(t,
+		WithAuditLog(),
+		WithOrganization(orgID, "test-org"),
+		WithUser(&UserArgs{ID: userID, Name: "test-user", OrgIDs: []uuid.UUID{orgID}}),
+	)
+
+	token := env.createAuthnToken(t, userID)
+	clusterClient := organizationv1connect.NewClusterServiceClient(env.server.Client(), env.server.URL)
+	auditClient := organizationv1connect.NewAuditServiceClient(env.server.Client(), env.server.URL)
+
+	var clusterIDs []string
+	for _, name := range []string{"cluster-a", "cluster-b", "cluster-c"} {
+		res, err := clusterClient.CreateCluster(authedContext(token, orgID), organizationv1.CreateClusterRequest_builder{
+			Name:              name,
+			Region:            "eu-west-1",
+			KubernetesVersion: "1.28",
+		}.Build())
+		require.NoError(t, err)
+		clusterIDs = append(clusterIDs, res.GetClusterId())
+	}
+
+	filter := organizationv1.AuditEventFilter_builder{Action: createClusterAction}.Build()
+
+	var listed []string
+	pageToken := ""
+	for {
+		res, err := auditClient.ListAuditEvents(authedContext(token, orgID), organizationv1.ListAuditEventsRequest_builder{
+			Filter:    filter,
+			PageSize:  2,
+			PageToken: pageToken,
+		}.Build())
+		require.NoError(t, err)
+		for _, event := range res.GetEvents() {
+			listed = append(listed, event.GetResourceId())
+		}
+		if res.GetNextPageToken() == "" {
+			break
+		}
+		pageToken = res.GetNextPageToken()
+	}
+	assert.Equal(t, []string{clusterIDs[2], clusterIDs[1], clusterIDs[0]}, listed)
+
+	stream, err := auditClient.ExportAuditEvents(authedContext(token, orgID), organizationv1.ExportAuditEventsRequest_builder{
+		Filter: filter,
+	}.Build())
+	require.NoError(t, err)
+
+	var exported []string
+	for stream.Receive() {
+		for _, event := range stream.Msg().GetEvents() {
+			exported = append(exported, event.GetResourceId())
+		}
+	}
+	require.NoError(t, stream.Err())
+	assert.Equal(t, clusterIDs, exported)
+}
+
+func Test_Audit_InvalidTimeRange(t *testing.T) {
+	t.Parallel()
+
+	orgID := uuid.New()
+	userID := uuid.New()
+
+	env := newTestAPI(t,
+		WithAuditLog(),
+		WithOrganization(orgID, "test-org"),
+		WithUser(&UserArgs{ID: userID, Name: "test-user", OrgIDs: []uuid.UUID{orgID}}),
+	)
+
+	token := env.createAuthnToken(t, userID)
+	client := organizationv1connect.NewAuditServiceClient(env.server.Client(), env.server.URL)
+
+	now := timestamppb.Now()
+	_, err := client.ListAuditEvents(authedContext(token, orgID), organizationv1.ListAuditEventsRequest_builder{
+		Filter: organizationv1.AuditEventFilter_builder{
+			Start: now,
+			End:   now,
+		}.Build(),
+	}.Build())
+
+	var connectErr *connect.Error
+	require.ErrorAs(t, err, &connectErr)
+	assert.Equal(t, connect.CodeInvalidArgument, connectErr.Code())
+}
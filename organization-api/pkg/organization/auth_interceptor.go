@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/http"
 	"slices"
+	"strings"
 
 	"connectrpc.com/connect"
 	"github.com/google/uuid"
@@ -27,6 +28,10 @@ func (s *Server) authenticate(ctx context.Context, procedure string, header http
 		return ctx, nil
 	}
 
+	if subject, ok := s.serviceEndpoint(procedure); ok {
+		return s.authenticateService(ctx, procedure, subject, header)
+	}
+
 	claims, err := s.authValidator.Validate(header)
 	if err != nil {
 		return nil, connect.NewError(connect.CodeUnauthenticated, err)
@@ -35,6 +40,17 @@ func (s *Server) authenticate(ctx context.Context, procedure string, header http
 	userID := claims.UserID()
 	ctx = WithUserID(ctx, userID)
 
+	if claims.Scope != nil {
+		// Projects, clusters and actions are checked with the OpenFGA check
+		// in checkPermission. Handlers that check no permission are covered
+		// here: a read-only key, and any scoped key outside its organization
+		// (user-scoped endpoints), is kept to read procedures.
+		if (claims.Scope.ReadOnly || s.isUserScopedEndpoint(procedure)) && !auth.IsReadProcedure(procedure) {
+			return nil, connect.NewError(connect.CodePermissionDenied, fmt.Errorf("API key scope does not allow %s", procedure))
+		}
+		ctx = WithAPIKeyScope(ctx, claims.Scope)
+	}
+
 	if s.isUserScopedEndpoint(procedure) {
 		s.logger.DebugContext(ctx, "skipping organization check for user-scoped endpoint",
 			"procedure", procedure, "user_id", userID)
@@ -55,6 +71,10 @@ func (s *Server) authenticate(ctx context.Context, procedure string, header http
 		return nil, connect.NewError(connect.CodePermissionDenied, fmt.Errorf("user is not a member of organization %s", organizationID))
 	}
 
+	if claims.Scope != nil && claims.Scope.OrganizationID != organizationID {
+		return nil, connect.NewError(connect.CodePermissionDenied, fmt.Errorf("API key is not valid for organization %s", organizationID))
+	}
+
 	ctx = WithOrganizationID(ctx, organizationID)
 	s.logger.DebugContext(ctx, "request authenticated", "organization_id", organizationID, "user_id", userID)
 	return ctx, nil
@@ -71,6 +91,38 @@ func (s *Server) isPublicEndpoint(procedure string) bool {
 	return publicEndpoints[procedure]
 }
 
+// serviceEndpoint returns the platform component allowed to call procedure
+// with a ServiceToken. Service endpoints accept no user tokens.
+func (s *Server) serviceEndpoint(procedure string) (string, bool) {
+	serviceEndpoints := map[string]string{
+		"/organization.v1.PluginService/GetPluginDefinitionApproval": "plugin-controller",
+	}
+	subject, ok := serviceEndpoints[procedure]
+	return subject, ok
+}
+
+// authenticateService validates a ServiceToken issued to subject and puts the
+// organization it names on ctx, so RLS only shows that organization's rows.
+func (s *Server) authenticateService(ctx context.Context, procedure, subject string, header http.Header) (context.Context, error) {
+	bearer, ok := strings.CutPrefix(header.Get("Authorization"), "Bearer ")
+	if !ok || bearer == "" {
+		return nil, connect.NewError(connect.CodeUnauthenticated, fmt.Errorf("missing service token"))
+	}
+
+	claims, err := auth.ParseServiceToken(bearer, s.config.JWTKeys)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+	if claims.Subject != subject {
+		return nil, connect.NewError(connect.CodePermissionDenied, fmt.Errorf("service %s may not call %s", claims.Subject, procedure))
+	}
+
+	ctx = WithOrganizationID(ctx, claims.OrganizationID)
+	s.logger.DebugContext(ctx, "service request authenticated",
+		"service", claims.Subject, "organization_id", claims.OrganizationID, "cluster_id", claims.ClusterID)
+	return ctx, nil
+}
+
 // isUserScopedEndpoint checks if an endpoint is user-scoped and should skip organization header check.
 // These endpoints operate on the user's data across all their organizations.
 func (s *Server) isUserScopedEndpoint(procedure string) bool {
@@ -25,7 +25,7 @@ func TestAuthenticate_RejectsPluginToken(t *testing.T) {
 	secret := []byte("test-secret")
 	s := &Server{
 		logger:        slog.New(slog.NewTextHandler(io.Discard, nil)),
-		authValidator: auth.NewValidatorForAudience(secret, auth.ConsoleAuthCookieName, auth.ConsoleIssuer, auth.TokenTypeUser, nil),
+		authValidator: auth.NewValidatorForAudience(auth.SharedSecret(secret), auth.ConsoleAuthCookieName, auth.ConsoleIssuer, auth.TokenTypeUser, nil),
 	}
 
 	pluginClaims := auth.Claims{
@@ -45,3 +45,103 @@ func TestAuthenticate_RejectsPluginToken(t *testing.T) {
 	_, err = s.authenticate(context.Background(), "/organization.v1.OrganizationService/ListOrganizations", header)
 	require.Equal(t, connect.CodeUnauthenticated, connect.CodeOf(err))
 }
+
+func TestAuthenticate_EnforcesAPIKeyScope(t *testing.T) {
+	secret := []byte("test-secret")
+	s := &Server{
+		logger:        slog.New(slog.NewTextHandler(io.Discard, nil)),
+		authValidator: auth.NewValidatorForAudience(auth.SharedSecret(secret), auth.ConsoleAuthCookieName, auth.ConsoleIssuer, auth.TokenTypeUser, nil),
+	}
+
+	orgID := uuid.New()
+	otherOrgID := uuid.New()
+	header := func(scope *auth.Scope, org uuid.UUID) http.Header {
+		claims := auth.Claims{
+			RegisteredClaims: jwt.RegisteredClaims{
+				Issuer:    auth.ConsoleIssuer,
+				Subject:   uuid.New().String(),
+				Audience:  jwt.ClaimStrings{auth.TokenTypeUser},
+				ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Minute)),
+			},
+			OrganizationIDs: []uuid.UUID{orgID, otherOrgID},
+			Scope:           scope,
+		}
+		tokenStr, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(secret)
+		require.NoError(t, err)
+
+		h := http.Header{}
+		h.Set("Authorization", "Bearer "+tokenStr)
+		h.Set(OrganizationHeader, org.String())
+		return h
+	}
+
+	readOnly := &auth.Scope{OrganizationID: orgID, ReadOnly: true}
+
+	ctx, err := s.authenticate(context.Background(), "/organization.v1.ProjectService/GetProject", header(readOnly, orgID))
+	require.NoError(t, err)
+	scope, ok := APIKeyScopeFromContext(ctx)
+	require.True(t, ok)
+	require.Equal(t, readOnly, scope)
+
+	_, err = s.authenticate(context.Background(), "/organization.v1.ProjectService/DeleteProject", header(readOnly, orgID))
+	require.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+
+	_, err = s.authenticate(context.Background(), "/organization.v1.ProjectService/GetProject", header(readOnly, otherOrgID))
+	require.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+
+	actions := &auth.Scope{OrganizationID: orgID, Actions: []string{"can_edit"}}
+	_, err = s.authenticate(context.Background(), "/organization.v1.InviteService/AcceptInvitation", header(actions, orgID))
+	require.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+
+	ctx, err = s.authenticate(context.Background(), "/organization.v1.ProjectService/DeleteProject", header(nil, otherOrgID))
+	require.NoError(t, err)
+	_, ok = APIKeyScopeFromContext(ctx)
+	require.False(t, ok)
+}
+
+func TestAuthenticate_ServiceToken(t *testing.T) {
+	secret := []byte("test-secret")
+	keys := auth.SharedSecret(secret)
+	s := &Server{
+		config:        &Config{JWTKeys: keys},
+		logger:        slog.New(slog.NewTextHandler(io.Discard, nil)),
+		authValidator: auth.NewValidatorForAudience(keys, auth.ConsoleAuthCookieName, auth.ConsoleIssuer, auth.TokenTypeUser, nil),
+	}
+
+	orgID := uuid.New()
+	header := func(subject string) http.Header {
+		claims := auth.ServiceClaims{
+			RegisteredClaims: jwt.RegisteredClaims{
+				Issuer:    auth.ConsoleIssuer,
+				Subject:   subject,
+				Audience:  jwt.ClaimStrings{auth.TokenTypeService},
+				ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Minute)),
+			},
+			OrganizationID: orgID,
+		}
+		tokenStr, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(secret)
+		require.NoError(t, err)
+
+		h := http.Header{}
+		h.Set("Authorization", "Bearer "+tokenStr)
+		h.Set(OrganizationHeader, uuid.New().String())
+		return h
+	}
+
+	const approval = "/organization.v1.PluginService/GetPluginDefinitionApproval"
+
+	ctx, err := s.authenticate(context.Background(), approval, header("plugin-controller"))
+	require.NoError(t, err)
+	got, ok := OrganizationIDFromContext(ctx)
+	require.True(t, ok)
+	require.Equal(t, orgID, got, "the organization comes from the token, not the header")
+
+	_, err = s.authenticate(context.Background(), approval, header("cluster-worker"))
+	require.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+
+	_, err = s.authenticate(context.Background(), approval, http.Header{})
+	require.Equal(t, connect.CodeUnauthenticated, connect.CodeOf(err))
+
+	_, err = s.authenticate(context.Background(), "/organization.v1.ProjectService/GetProject", header("plugin-controller"))
+	require.Equal(t, connect.CodeUnauthenticated, connect.CodeOf(err), "service tokens only open service endpoints")
+}
@@ -6,19 +6,34 @@ import (
 	"time"
 
 	"connectrpc.com/connect"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
 
 	"github.com/fundament-oss/fundament/common/authz"
+	db "github.com/fundament-oss/fundament/organization-api/pkg/db/gen"
 )
 
 const (
 	authzRetryInitialBackoff = 100 * time.Millisecond
 	authzRetryMaxBackoff     = 2 * time.Second
 	authzRetryBudget         = 8 * time.Second
+
+	// filterBatchSize is the minimum number of rows a filtered list reads at
+	// a time, so a caller who can see few rows does not cost a query per row.
+	filterBatchSize = 100
 )
 
-// checkPermission performs an OpenFGA authorization check for the current user.
-// Returns a connect PermissionDenied error if the check fails.
+// checkPermission performs an OpenFGA authorization check for the current user,
+// after checking the action against the scope of the API key the request was
+// made with, if any. Returns a connect PermissionDenied error if either fails.
 func (s *Server) checkPermission(ctx context.Context, action authz.Action, resource authz.Object) error {
+	if err := s.checkAPIKeyScope(ctx, action, resource); err != nil {
+		return err
+	}
+	return s.evaluatePermission(ctx, action, resource)
+}
+
+func (s *Server) evaluatePermission(ctx context.Context, action authz.Action, resource authz.Object) error {
 	if s.authz == nil {
 		return nil
 	}
@@ -57,11 +72,16 @@ func (s *Server) checkPermission(ctx context.Context, action authz.Action, resou
 // return immediately, and a genuinely unauthorized user fails once the budget is
 // exhausted.
 func (s *Server) checkPermissionWithRetry(ctx context.Context, action authz.Action, resource authz.Object) error {
+	// A scope denial is final; only the OpenFGA decision can be stale.
+	if err := s.checkAPIKeyScope(ctx, action, resource); err != nil {
+		return err
+	}
+
 	deadline := time.Now().Add(authzRetryBudget)
 	backoff := authzRetryInitialBackoff
 
 	for {
-		err := s.checkPermission(ctx, action, resource)
+		err := s.evaluatePermission(ctx, action, resource)
 		if err == nil || connect.CodeOf(err) != connect.CodePermissionDenied || time.Now().After(deadline) {
 			return err
 		}
@@ -81,3 +101,150 @@ func (s *Server) checkPermissionWithRetry(ctx context.Context, action authz.Acti
 		}
 	}
 }
+
+// canView returns the filter that keeps the resources the current user can
+// view. Permission to list the children of an object does not imply being
+// able to view each of them: an organization viewer may list a cluster's
+// projects but only view those they are a member of. It returns nil, which
+// keeps every row, when authorization is off.
+func (s *Server) canView(ctx context.Context) func([]authz.Object) ([]bool, error) {
+	if s.authz == nil {
+		return nil
+	}
+	return func(resources []authz.Object) ([]bool, error) {
+		return s.evaluatePermissions(ctx, authz.CanView(), resources)
+	}
+}
+
+// evaluatePermissions reports for each resource whether the current user may
+// perform action on it. Decisions come from the decision cache where
+// possible; the rest are evaluated in one batch.
+func (s *Server) evaluatePermissions(ctx context.Context, action authz.Action, resources []authz.Object) ([]bool, error) {
+	userID, ok := UserIDFromContext(ctx)
+	if !ok {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("user_id missing from context"))
+	}
+	user := authz.User(userID)
+
+	generation := s.decisions.currentGeneration()
+	notAfter, cacheable := s.decisionsNotAfter(ctx, userID)
+
+	allowed := make([]bool, len(resources))
+	var missing []int
+	var evaluations []authz.EvaluationRequest
+	for i, resource := range resources {
+		if decision, ok := s.decisions.lookup(decisionKey{user.String(), action.Name, resource.String()}); ok {
+			allowed[i] = decision
+			continue
+		}
+		missing = append(missing, i)
+		evaluations = append(evaluations, authz.EvaluationRequest{Resource: resource})
+	}
+	if len(evaluations) == 0 {
+		return allowed, nil
+	}
+
+	s.logger.DebugContext(ctx, "check permissions", "user", user, "action", action, "resources", len(evaluations))
+
+	resp, err := s.authz.Evaluations(ctx, authz.EvaluationsRequest{
+		Subject:     &user,
+		Action:      &action,
+		Evaluations: evaluations,
+	})
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("authorization check failed: %w", err))
+	}
+
+	for j, i := range missing {
+		allowed[i] = resp.Evaluations[j].Decision
+		if cacheable {
+			s.decisions.store(generation, decisionKey{user.String(), action.Name, resources[i].String()}, allowed[i], notAfter)
+		}
+	}
+
+	return allowed, nil
+}
+
+// decisionsNotAfter returns until when decisions for the user may be cached:
+// until their first elevated role expires, or zero when they hold none. It is
+// read before evaluating, so that a role expiring in between still bounds the
+// decisions it granted. cacheable is false when the expiry could not be read.
+func (s *Server) decisionsNotAfter(ctx context.Context, userID uuid.UUID) (notAfter time.Time, cacheable bool) {
+	if s.decisions == nil {
+		return time.Time{}, false
+	}
+	expires, err := s.queries.AccessElevationEarliestExpiry(ctx, db.AccessElevationEarliestExpiryParams{UserID: userID})
+	if err != nil {
+		s.logger.WarnContext(ctx, "failed to read elevated role expiry, not caching decisions", "error", err)
+		return time.Time{}, false
+	}
+	if !expires.Valid {
+		return time.Time{}, true
+	}
+	return expires.Time, true
+}
+
+// filterAllowed returns the rows whose resource allowed permits, in order. A
+// nil allowed permits every row.
+func filterAllowed[T any](rows []T, resource func(*T) authz.Object, allowed func([]authz.Object) ([]bool, error)) ([]T, error) {
+	if allowed == nil || len(rows) == 0 {
+		return rows, nil
+	}
+
+	resources := make([]authz.Object, len(rows))
+	for i := range rows {
+		resources[i] = resource(&rows[i])
+	}
+	decisions, err := allowed(resources)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]T, 0, len(rows))
+	for i := range rows {
+		if decisions[i] {
+			result = append(result, rows[i])
+		}
+	}
+	return result, nil
+}
+
+// allowedPage returns the page after cursor of the rows allowed permits, and
+// the token for the page after it. When rows are filtered they are read in
+// batches until the page is full, so a caller who can see few rows still gets
+// full pages.
+func allowedPage[T any](
+	pageSize int32,
+	cursor *pageCursor,
+	fetch func(cursor *pageCursor, limit pgtype.Int4) ([]T, error),
+	resource func(*T) authz.Object,
+	allowed func([]authz.Object) ([]bool, error),
+	cursorOf func(*T) pageCursor,
+) ([]T, string, error) {
+	limit := pageLimit(pageSize)
+	if allowed != nil && pageSize > 0 {
+		limit = pageLimit(max(pageSize, filterBatchSize-1))
+	}
+
+	var page []T
+	for {
+		rows, err := fetch(cursor, limit)
+		if err != nil {
+			return nil, "", err
+		}
+
+		visible, err := filterAllowed(rows, resource, allowed)
+		if err != nil {
+			return nil, "", err
+		}
+		page = append(page, visible...)
+
+		if !limit.Valid || len(rows) < int(limit.Int32) || len(page) > int(pageSize) {
+			page, nextPageToken := trimPage(page, pageSize, cursorOf)
+			return page, nextPageToken, nil
+		}
+
+		next := cursorOf(&rows[len(rows)-1])
+		cursor = &next
+	}
+}
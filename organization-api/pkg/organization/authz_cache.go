@@ -0,0 +1,200 @@
+package organization
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/fundament-oss/fundament/common/authz"
+)
+
+const (
+	// authzChangesChannel is notified by authz-worker whenever it has changed
+	// OpenFGA tuples: after every processed outbox row and after a repair.
+	authzChangesChannel = "authz_tuples_changed"
+
+	// decisionCacheHealthInterval is how long the listener waits for a
+	// notification before it pings its connection. A connection can die
+	// silently, and a cache that misses invalidations serves stale decisions.
+	decisionCacheHealthInterval = 30 * time.Second
+)
+
+// DecisionCacheConfig holds configuration for the decision cache.
+type DecisionCacheConfig struct {
+	// TTL bounds how long a decision is reused; invalidation normally clears
+	// it much sooner.
+	TTL time.Duration
+	// MaxEntries bounds the cache; reaching it clears the cache.
+	MaxEntries int
+	// ReconnectDelay is the wait before listening again after a lost
+	// connection.
+	ReconnectDelay time.Duration
+}
+
+// DecisionCache caches the OpenFGA decisions list endpoints filter their
+// items by. Every tuple change authz-worker reports clears the whole cache:
+// a single tuple can change decisions on many objects through the relations
+// of the model. An elevated role ends without a tuple change, when the
+// is_not_expired condition stops holding, so a caller's decisions are cached
+// no longer than their first elevated role lasts. While the cache is not
+// listening it serves nothing, since it would not learn about changes. A nil
+// cache caches nothing.
+type DecisionCache struct {
+	pool   *pgxpool.Pool
+	logger *slog.Logger
+	cfg    DecisionCacheConfig
+	now    func() time.Time
+
+	listening atomic.Bool
+
+	mu         sync.Mutex
+	generation uint64
+	entries    map[decisionKey]decisionEntry
+}
+
+type decisionKey struct {
+	subject  string
+	action   authz.ActionName
+	resource string
+}
+
+type decisionEntry struct {
+	allowed bool
+	expires time.Time
+}
+
+// NewDecisionCache creates a decision cache. It caches nothing until Start
+// listens for tuple changes.
+func NewDecisionCache(pool *pgxpool.Pool, logger *slog.Logger, cfg DecisionCacheConfig) *DecisionCache {
+	if cfg.TTL == 0 {
+		cfg.TTL = time.Minute
+	}
+	if cfg.MaxEntries == 0 {
+		cfg.MaxEntries = 100_000
+	}
+	if cfg.ReconnectDelay == 0 {
+		cfg.ReconnectDelay = 5 * time.Second
+	}
+	return &DecisionCache{
+		pool:    pool,
+		logger:  logger,
+		cfg:     cfg,
+		now:     time.Now,
+		entries: make(map[decisionKey]decisionEntry),
+	}
+}
+
+// Start listens for tuple changes until ctx is cancelled, listening again
+// after a lost connection.
+func (c *DecisionCache) Start(ctx context.Context) {
+	for {
+		err := c.listen(ctx)
+		c.listening.Store(false)
+		c.invalidate()
+		if ctx.Err() != nil {
+			return
+		}
+
+		c.logger.WarnContext(ctx, "authz decision cache stopped listening, reconnecting",
+			"error", err, "delay", c.cfg.ReconnectDelay)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(c.cfg.ReconnectDelay):
+		}
+	}
+}
+
+func (c *DecisionCache) listen(ctx context.Context) error {
+	conn, err := c.pool.Acquire(ctx)
+	if err != nil {
+		return fmt.Errorf("acquire connection for LISTEN: %w", err)
+	}
+	defer conn.Release()
+
+	if _, err := conn.Exec(ctx, "LISTEN "+authzChangesChannel); err != nil {
+		return fmt.Errorf("LISTEN: %w", err)
+	}
+
+	// Decisions cached before LISTEN took effect may predate a change.
+	c.invalidate()
+	c.listening.Store(true)
+
+	for {
+		waitCtx, cancel := context.WithTimeout(ctx, decisionCacheHealthInterval)
+		_, err := conn.Conn().WaitForNotification(waitCtx)
+		cancel()
+
+		switch {
+		case err == nil:
+			c.invalidate()
+		case ctx.Err() != nil:
+			return fmt.Errorf("decision cache stopped: %w", ctx.Err())
+		case errors.Is(err, context.DeadlineExceeded):
+			if err := conn.Conn().Ping(ctx); err != nil {
+				return fmt.Errorf("connection health check failed: %w", err)
+			}
+		default:
+			return fmt.Errorf("wait for notification: %w", err)
+		}
+	}
+}
+
+// currentGeneration returns the generation to pass to store for decisions
+// that are about to be evaluated.
+func (c *DecisionCache) currentGeneration() uint64 {
+	if c == nil {
+		return 0
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.generation
+}
+
+func (c *DecisionCache) lookup(key decisionKey) (allowed, ok bool) {
+	if c == nil || !c.listening.Load() {
+		return false, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[key]
+	if !ok || !c.now().Before(entry.expires) {
+		return false, false
+	}
+	return entry.allowed, true
+}
+
+// store caches a decision evaluated at generation until the TTL passes, or
+// until notAfter when that is sooner and not zero. A decision evaluated before
+// the last invalidation is dropped, as it may predate the change.
+func (c *DecisionCache) store(generation uint64, key decisionKey, allowed bool, notAfter time.Time) {
+	if c == nil || !c.listening.Load() {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if generation != c.generation {
+		return
+	}
+	if len(c.entries) >= c.cfg.MaxEntries {
+		clear(c.entries)
+	}
+	expires := c.now().Add(c.cfg.TTL)
+	if !notAfter.IsZero() && notAfter.Before(expires) {
+		expires = notAfter
+	}
+	c.entries[key] = decisionEntry{allowed: allowed, expires: expires}
+}
+
+func (c *DecisionCache) invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.generation++
+	clear(c.entries)
+}
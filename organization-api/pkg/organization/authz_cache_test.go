@@ -0,0 +1,166 @@
+package organization
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fundament-oss/fundament/common/authz"
+)
+
+func TestDecisionCache(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	cache := NewDecisionCache(nil, nil, DecisionCacheConfig{TTL: time.Minute, MaxEntries: 2})
+	cache.now = func() time.Time { return now }
+
+	key := decisionKey{subject: "user:a", action: authz.ActionCanView, resource: "project:b"}
+
+	// Until it listens, the cache would miss invalidations.
+	cache.store(cache.currentGeneration(), key, true, time.Time{})
+	_, ok := cache.lookup(key)
+	assert.False(t, ok)
+
+	cache.listening.Store(true)
+	cache.store(cache.currentGeneration(), key, true, time.Time{})
+	allowed, ok := cache.lookup(key)
+	assert.True(t, ok)
+	assert.True(t, allowed)
+
+	// A decision evaluated before an invalidation is dropped.
+	generation := cache.currentGeneration()
+	cache.invalidate()
+	_, ok = cache.lookup(key)
+	assert.False(t, ok)
+	cache.store(generation, key, true, time.Time{})
+	_, ok = cache.lookup(key)
+	assert.False(t, ok)
+
+	cache.store(cache.currentGeneration(), key, false, time.Time{})
+	allowed, ok = cache.lookup(key)
+	assert.True(t, ok)
+	assert.False(t, allowed)
+
+	now = now.Add(time.Minute)
+	_, ok = cache.lookup(key)
+	assert.False(t, ok)
+
+	// Reaching MaxEntries clears the cache.
+	other := decisionKey{subject: "user:a", action: authz.ActionCanView, resource: "project:c"}
+	third := decisionKey{subject: "user:a", action: authz.ActionCanView, resource: "project:d"}
+	cache.store(cache.currentGeneration(), other, true, time.Time{})
+	cache.store(cache.currentGeneration(), third, true, time.Time{})
+	_, ok = cache.lookup(other)
+	assert.False(t, ok)
+	_, ok = cache.lookup(third)
+	assert.True(t, ok)
+
+	var nilCache *DecisionCache
+	nilCache.store(nilCache.currentGeneration(), key, true, time.Time{})
+	_, ok = nilCache.lookup(key)
+	assert.False(t, ok)
+}
+
+func TestDecisionCache_ElevationExpiry(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	cache := NewDecisionCache(nil, nil, DecisionCacheConfig{TTL: time.Minute})
+	cache.now = func() time.Time { return now }
+	cache.listening.Store(true)
+
+	key := decisionKey{subject: "user:a", action: authz.ActionCanView, resource: "project:b"}
+
+	// The elevated role that allowed the decision ends halfway through the
+	// TTL, without a tuple change that would invalidate the cache.
+	expires := now.Add(30 * time.Second)
+	cache.store(cache.currentGeneration(), key, true, expires)
+
+	now = expires.Add(-time.Second)
+	allowed, ok := cache.lookup(key)
+	assert.True(t, ok)
+	assert.True(t, allowed)
+
+	now = expires
+	_, ok = cache.lookup(key)
+	assert.False(t, ok)
+
+	// An expiry beyond the TTL does not extend it.
+	now = time.Date(2026, 3, 1, 13, 0, 0, 0, time.UTC)
+	cache.store(cache.currentGeneration(), key, true, now.Add(time.Hour))
+	now = now.Add(time.Minute)
+	_, ok = cache.lookup(key)
+	assert.False(t, ok)
+}
+
+func TestAllowedPage(t *testing.T) {
+	t.Parallel()
+
+	rows := make([]uuid.UUID, 250)
+	for i := range rows {
+		rows[i] = uuid.New()
+	}
+	// The caller can see every tenth row.
+	visible := map[string]bool{}
+	for i := 0; i < len(rows); i += 10 {
+		visible[authz.Project(rows[i]).String()] = true
+	}
+
+	var fetches int
+	fetch := func(cursor *pageCursor, limit pgtype.Int4) ([]uuid.UUID, error) {
+		fetches++
+		start := 0
+		if cursor != nil {
+			start = slices.Index(rows, cursor.ID) + 1
+		}
+		end := len(rows)
+		if limit.Valid {
+			end = min(start+int(limit.Int32), len(rows))
+		}
+		return rows[start:end], nil
+	}
+	resource := func(id *uuid.UUID) authz.Object { return authz.Project(*id) }
+	allowed := func(resources []authz.Object) ([]bool, error) {
+		decisions := make([]bool, len(resources))
+		for i, resource := range resources {
+			decisions[i] = visible[resource.String()]
+		}
+		return decisions, nil
+	}
+	cursorOf := func(id *uuid.UUID) pageCursor { return pageCursor{ID: *id} }
+
+	// A full page takes more than one batch of rows.
+	page, next, err := allowedPage(15, nil, fetch, resource, allowed, cursorOf)
+	require.NoError(t, err)
+	assert.Len(t, page, 15)
+	assert.Equal(t, rows[0], page[0])
+	assert.Equal(t, rows[140], page[14])
+	assert.Equal(t, 2, fetches)
+
+	cursor, err := decodePageToken(next)
+	require.NoError(t, err)
+	page, next, err = allowedPage(15, cursor, fetch, resource, allowed, cursorOf)
+	require.NoError(t, err)
+	assert.Len(t, page, 10)
+	assert.Equal(t, rows[150], page[0])
+	assert.Empty(t, next)
+
+	page, next, err = allowedPage(0, nil, fetch, resource, allowed, cursorOf)
+	require.NoError(t, err)
+	assert.Len(t, page, 25)
+	assert.Empty(t, next)
+
+	// Without a filter every row is visible and one query suffices.
+	fetches = 0
+	page, next, err = allowedPage(15, nil, fetch, resource, nil, cursorOf)
+	require.NoError(t, err)
+	assert.Equal(t, rows[:15], page)
+	assert.NotEmpty(t, next)
+	assert.Equal(t, 1, fetches)
+}
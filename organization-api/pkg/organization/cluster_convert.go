@@ -1,6 +1,10 @@
 package organization
 
 import (
+	"encoding/json"
+	"fmt"
+	"time"
+
 	"github.com/jackc/pgx/v5/pgtype"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
@@ -27,6 +31,8 @@ func clusterStatusFromDB(deleted pgtype.Timestamptz, shootStatus pgtype.Text) or
 		return organizationv1.ClusterStatus_CLUSTER_STATUS_DELETING
 	case "deleted":
 		return organizationv1.ClusterStatus_CLUSTER_STATUS_STOPPED
+	case "hibernated":
+		return organizationv1.ClusterStatus_CLUSTER_STATUS_HIBERNATED
 	default:
 		return organizationv1.ClusterStatus_CLUSTER_STATUS_UNSPECIFIED
 	}
@@ -90,3 +96,92 @@ func clusterEventFromRow(e *db.TenantClusterEvent) *organizationv1.ClusterEvent
 
 	return event
 }
+
+// Gardener rejects maintenance windows shorter than 30 minutes or longer than
+// six hours.
+const (
+	minMaintenanceWindow = 30 * time.Minute
+	maxMaintenanceWindow = 6 * time.Hour
+)
+
+// maintenanceWindowFromProto parses the "HH:MM" window of a maintenance
+// policy into time columns. Both results are NULL when the window is unset.
+func maintenanceWindowFromProto(policy *organizationv1.MaintenancePolicy) (start, end pgtype.Time, err error) {
+	if policy.GetWindowStart() == "" && policy.GetWindowEnd() == "" {
+		return pgtype.Time{}, pgtype.Time{}, nil
+	}
+
+	startOffset, err := parseTimeOfDay(policy.GetWindowStart())
+	if err != nil {
+		return pgtype.Time{}, pgtype.Time{}, fmt.Errorf("invalid window_start: %w", err)
+	}
+	endOffset, err := parseTimeOfDay(policy.GetWindowEnd())
+	if err != nil {
+		return pgtype.Time{}, pgtype.Time{}, fmt.Errorf("invalid window_end: %w", err)
+	}
+
+	length := endOffset - startOffset
+	if length <= 0 {
+		length += 24 * time.Hour
+	}
+	if length < minMaintenanceWindow || length > maxMaintenanceWindow {
+		return pgtype.Time{}, pgtype.Time{}, fmt.Errorf("maintenance window must be between %s and %s long, got %s",
+			minMaintenanceWindow, maxMaintenanceWindow, length)
+	}
+
+	return pgtype.Time{Microseconds: startOffset.Microseconds(), Valid: true},
+		pgtype.Time{Microseconds: endOffset.Microseconds(), Valid: true},
+		nil
+}
+
+// parseTimeOfDay parses "HH:MM" into an offset from midnight.
+func parseTimeOfDay(s string) (time.Duration, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, fmt.Errorf("expected HH:MM, got %q", s)
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+// formatTimeOfDay formats a time column as "HH:MM"; empty when NULL.
+func formatTimeOfDay(t pgtype.Time) string {
+	if !t.Valid {
+		return ""
+	}
+	d := time.Duration(t.Microseconds) * time.Microsecond
+	return fmt.Sprintf("%02d:%02d", int(d.Hours()), int(d.Minutes())%60)
+}
+
+func maintenancePolicyFromRow(windowStart, windowEnd pgtype.Time, autoUpdateKubernetesVersion, autoUpdateMachineImageVersion bool) *organizationv1.MaintenancePolicy {
+	return organizationv1.MaintenancePolicy_builder{
+		WindowStart:                   formatTimeOfDay(windowStart),
+		WindowEnd:                     formatTimeOfDay(windowEnd),
+		AutoUpdateKubernetesVersion:   autoUpdateKubernetesVersion,
+		AutoUpdateMachineImageVersion: autoUpdateMachineImageVersion,
+	}.Build()
+}
+
+// labelsToJSON encodes labels for a jsonb labels column, which is NOT NULL:
+// no labels is stored as an empty object.
+func labelsToJSON(labels map[string]string) ([]byte, error) {
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	return json.Marshal(labels)
+}
+
+// labelsFromJSON decodes a jsonb labels column. Values that are not strings
+// can only be written outside the API and are left out.
+func labelsFromJSON(raw []byte) map[string]string {
+	var values map[string]any
+	if err := json.Unmarshal(raw, &values); err != nil {
+		return nil
+	}
+	labels := make(map[string]string, len(values))
+	for k, v := range values {
+		if s, ok := v.(string); ok {
+			labels[k] = s
+		}
+	}
+	return labels
+}
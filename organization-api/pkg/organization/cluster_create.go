@@ -13,6 +13,7 @@ import (
 
 	"github.com/fundament-oss/fundament/common/authz"
 	"github.com/fundament-oss/fundament/common/dbconst"
+	"github.com/fundament-oss/fundament/common/rollback"
 	db "github.com/fundament-oss/fundament/organization-api/pkg/db/gen"
 	organizationv1 "github.com/fundament-oss/fundament/organization-api/pkg/proto/gen/v1"
 )
@@ -43,6 +44,23 @@ func (s *Server) CreateCluster(
 		}
 		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to resolve region offering: %w", err))
 	}
+	if offering.RegionDeprecated {
+		return nil, connect.NewError(connect.CodeInvalidArgument,
+			fmt.Errorf("region %q is deprecated and no longer offered for new clusters", req.GetRegion()))
+	}
+
+	policy, err := s.organizationPolicy(ctx, organizationID)
+	if err != nil {
+		return nil, err
+	}
+	if err := policyError(policy.checkCreateCluster(req)); err != nil {
+		return nil, err
+	}
+
+	labels, err := labelsToJSON(req.GetLabels())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to encode labels: %w", err))
+	}
 
 	params := db.ClusterCreateParams{
 		OrganizationID:      organizationID,
@@ -51,9 +69,22 @@ func (s *Server) CreateCluster(
 		KubernetesVersion:   req.GetKubernetesVersion(),
 		RegionID:            pgtype.UUID{Bytes: offering.RegionID, Valid: true},
 		KubernetesVersionID: pgtype.UUID{Bytes: offering.KubernetesVersionID, Valid: true},
+		Labels:              labels,
 	}
 
-	clusterID, err := s.queries.ClusterCreate(ctx, params)
+	tx, err := s.db.Pool.Begin(ctx)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to begin transaction: %w", err))
+	}
+	defer rollback.Rollback(ctx, tx, s.logger)
+
+	qtx := s.queries.WithTx(tx)
+
+	if err := enforceOrganizationCap(ctx, qtx, organizationID, capClusters); err != nil {
+		return nil, err
+	}
+
+	clusterID, err := qtx.ClusterCreate(ctx, params)
 	if err != nil {
 		// ErrNoRows means the WHERE NOT EXISTS condition was false: a cluster with this name already exists
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -69,6 +100,10 @@ func (s *Server) CreateCluster(
 		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to create cluster: %w", err))
 	}
 
+	if err := tx.Commit(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to commit transaction: %w", err))
+	}
+
 	s.logger.InfoContext(ctx, "cluster created",
 		"cluster_id", clusterID,
 		"organization_id", organizationID,
@@ -54,9 +54,22 @@ func (s *Server) GetClusterByName(
 		OutboxStatus:       cluster.OutboxStatus,
 		OutboxRetries:      cluster.OutboxRetries,
 		OutboxError:        cluster.OutboxError,
+
+		MaintenanceWindowStart:        cluster.MaintenanceWindowStart,
+		MaintenanceWindowEnd:          cluster.MaintenanceWindowEnd,
+		AutoUpdateKubernetesVersion:   cluster.AutoUpdateKubernetesVersion,
+		AutoUpdateMachineImageVersion: cluster.AutoUpdateMachineImageVersion,
+		Hibernated:                    cluster.Hibernated,
+		HibernationRequested:          cluster.HibernationRequested,
 	}
 	details := clusterDetailsFromRow(row)
 
+	hibernation, err := s.clusterHibernationPolicy(ctx, cluster.ID)
+	if err != nil {
+		return nil, err
+	}
+	details.SetHibernation(hibernation)
+
 	return organizationv1.GetClusterResponse_builder{
 		Cluster: details,
 	}.Build(), nil
@@ -88,6 +101,12 @@ func (s *Server) GetCluster(
 
 	details := clusterDetailsFromRow(&cluster)
 
+	hibernation, err := s.clusterHibernationPolicy(ctx, cluster.ID)
+	if err != nil {
+		return nil, err
+	}
+	details.SetHibernation(hibernation)
+
 	return organizationv1.GetClusterResponse_builder{
 		Cluster: details,
 	}.Build(), nil
@@ -183,6 +202,14 @@ func clusterDetailsFromRow(row *db.ClusterGetByIDRow) *organizationv1.ClusterDet
 			row.ShootStatusMessage,
 			row.ShootStatusUpdated,
 		),
+		Maintenance: maintenancePolicyFromRow(
+			row.MaintenanceWindowStart,
+			row.MaintenanceWindowEnd,
+			row.AutoUpdateKubernetesVersion,
+			row.AutoUpdateMachineImageVersion,
+		),
+		Hibernated: row.Hibernated,
+		Labels:     labelsFromJSON(row.Labels),
 	}
 	return builder.Build()
 }
@@ -0,0 +1,161 @@
+package organization
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/fundament-oss/fundament/common/authz"
+	"github.com/fundament-oss/fundament/common/dbconst"
+	"github.com/fundament-oss/fundament/common/rollback"
+	db "github.com/fundament-oss/fundament/organization-api/pkg/db/gen"
+	organizationv1 "github.com/fundament-oss/fundament/organization-api/pkg/proto/gen/v1"
+)
+
+// defaultHibernationLocation is used for schedules without a location, as
+// Gardener does.
+const defaultHibernationLocation = "UTC"
+
+func (s *Server) HibernateCluster(
+	ctx context.Context,
+	req *organizationv1.HibernateClusterRequest,
+) (*organizationv1.HibernateClusterResponse, error) {
+	clusterID := uuid.MustParse(req.GetClusterId())
+
+	if err := s.setClusterHibernated(ctx, clusterID, true); err != nil {
+		return nil, err
+	}
+
+	return organizationv1.HibernateClusterResponse_builder{}.Build(), nil
+}
+
+func (s *Server) WakeCluster(
+	ctx context.Context,
+	req *organizationv1.WakeClusterRequest,
+) (*organizationv1.WakeClusterResponse, error) {
+	clusterID := uuid.MustParse(req.GetClusterId())
+
+	if err := s.setClusterHibernated(ctx, clusterID, false); err != nil {
+		return nil, err
+	}
+
+	return organizationv1.WakeClusterResponse_builder{}.Build(), nil
+}
+
+// setClusterHibernated records a manual hibernate or wake request and its
+// event. cluster-worker picks the request up through the cluster outbox.
+func (s *Server) setClusterHibernated(ctx context.Context, clusterID uuid.UUID, hibernated bool) error {
+	if err := s.checkPermission(ctx, authz.CanEdit(), authz.Cluster(clusterID)); err != nil {
+		return err
+	}
+
+	eventType := dbconst.ClusterEventEventType_WakeRequested
+	if hibernated {
+		eventType = dbconst.ClusterEventEventType_HibernationRequested
+	}
+
+	tx, err := s.db.Pool.Begin(ctx)
+	if err != nil {
+		return connect.NewError(connect.CodeInternal, fmt.Errorf("failed to begin transaction: %w", err))
+	}
+	defer rollback.Rollback(ctx, tx, s.logger)
+
+	qtx := s.queries.WithTx(tx)
+
+	rowsAffected, err := qtx.ClusterSetHibernated(ctx, db.ClusterSetHibernatedParams{
+		Hibernated: hibernated,
+		ClusterID:  clusterID,
+	})
+	if err != nil {
+		return connect.NewError(connect.CodeInternal, fmt.Errorf("failed to update cluster hibernation: %w", err))
+	}
+	if rowsAffected != 1 {
+		return connect.NewError(connect.CodeNotFound, fmt.Errorf("cluster not found"))
+	}
+
+	if err := qtx.ClusterCreateEvent(ctx, db.ClusterCreateEventParams{
+		ClusterID: clusterID,
+		EventType: string(eventType),
+	}); err != nil {
+		return connect.NewError(connect.CodeInternal, fmt.Errorf("failed to create %s event: %w", eventType, err))
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return connect.NewError(connect.CodeInternal, fmt.Errorf("failed to commit transaction: %w", err))
+	}
+
+	s.logger.InfoContext(ctx, "cluster hibernation requested", "cluster_id", clusterID, "hibernated", hibernated)
+
+	return nil
+}
+
+// replaceHibernationSchedules swaps the cluster's schedules for the policy's;
+// the schedule outbox trigger queues the sync.
+func replaceHibernationSchedules(ctx context.Context, qtx *db.Queries, clusterID uuid.UUID, policy *organizationv1.HibernationPolicy) error {
+	params, err := hibernationSchedulesFromProto(clusterID, policy)
+	if err != nil {
+		return connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	if err := qtx.ClusterHibernationScheduleDeleteByClusterID(ctx, db.ClusterHibernationScheduleDeleteByClusterIDParams{
+		ClusterID: clusterID,
+	}); err != nil {
+		return connect.NewError(connect.CodeInternal, fmt.Errorf("failed to delete hibernation schedules: %w", err))
+	}
+
+	for i := range params {
+		if err := qtx.ClusterHibernationScheduleCreate(ctx, params[i]); err != nil {
+			return connect.NewError(connect.CodeInternal, fmt.Errorf("failed to create hibernation schedule: %w", err))
+		}
+	}
+	return nil
+}
+
+// hibernationSchedulesFromProto converts the policy to insert params. The
+// cron syntax is checked by protovalidate; the location must be a time zone
+// Gardener can load.
+func hibernationSchedulesFromProto(clusterID uuid.UUID, policy *organizationv1.HibernationPolicy) ([]db.ClusterHibernationScheduleCreateParams, error) {
+	params := make([]db.ClusterHibernationScheduleCreateParams, 0, len(policy.GetSchedules()))
+	for i, schedule := range policy.GetSchedules() {
+		location := schedule.GetLocation()
+		if location == "" {
+			location = defaultHibernationLocation
+		}
+		if _, err := time.LoadLocation(location); err != nil {
+			return nil, fmt.Errorf("schedule %d: unknown location %q", i, location)
+		}
+
+		params = append(params, db.ClusterHibernationScheduleCreateParams{
+			ClusterID: clusterID,
+			Start:     pgtype.Text{String: schedule.GetStart(), Valid: schedule.GetStart() != ""},
+			End:       pgtype.Text{String: schedule.GetEnd(), Valid: schedule.GetEnd() != ""},
+			Location:  location,
+		})
+	}
+	return params, nil
+}
+
+// clusterHibernationPolicy loads the cluster's schedules for ClusterDetails.
+func (s *Server) clusterHibernationPolicy(ctx context.Context, clusterID uuid.UUID) (*organizationv1.HibernationPolicy, error) {
+	rows, err := s.queries.ClusterHibernationScheduleList(ctx, db.ClusterHibernationScheduleListParams{ClusterID: clusterID})
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to list hibernation schedules: %w", err))
+	}
+
+	schedules := make([]*organizationv1.HibernationSchedule, 0, len(rows))
+	for _, row := range rows {
+		schedules = append(schedules, organizationv1.HibernationSchedule_builder{
+			Start:    row.Start.String,
+			End:      row.End.String,
+			Location: row.Location,
+		}.Build())
+	}
+
+	return organizationv1.HibernationPolicy_builder{
+		Schedules: schedules,
+	}.Build(), nil
+}
@@ -0,0 +1,197 @@
+package organization_test
+
+import (
+	"context"
+	"testing"
+
+	"connectrpc.com/connect"
+	organizationv1 "github.com/fundament-oss/fundament/organization-api/pkg/proto/gen/v1"
+	"github.com/fundament-oss/fundament/organization-api/pkg/proto/gen/v1/organizationv1connect"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ClusterHibernation_Unauthenticated(t *testing.T) {
+	t.Parallel()
+
+	env := newTestAPI(t)
+	client := organizationv1connect.NewClusterServiceClient(env.server.Client(), env.server.URL)
+
+	_, err := client.HibernateCluster(context.Background(), organizationv1.HibernateClusterRequest_builder{
+		ClusterId: uuid.New().String(),
+	}.Build())
+
+	var connectErr *connect.Error
+	require.ErrorAs(t, err, &connectErr)
+	assert.Equal(t, connect.CodeUnauthenticated, connectErr.Code())
+}
+
+func Test_ClusterHibernation_HibernateWake(t *testing.T) {
+	t.Parallel()
+
+	orgID := uuid.New()
+	userID := uuid.New()
+
+	env := newTestAPI(t,
+		WithOrganization(orgID, "test-org"),
+		WithUser(&UserArgs{ID: userID, Name: "test-user", OrgIDs: []uuid.UUID{orgID}}),
+	)
+
+	token := env.createAuthnToken(t, userID)
+	client := organizationv1connect.NewClusterServiceClient(env.server.Client(), env.server.URL)
+
+	createRes, err := client.CreateCluster(authedContext(token, orgID), organizationv1.CreateClusterRequest_builder{
+		Name:              "test-cluster",
+		Region:            "eu-west-1",
+		KubernetesVersion: "1.28",
+	}.Build())
+	require.NoError(t, err)
+	clusterID := createRes.GetClusterId()
+
+	_, err = client.HibernateCluster(authedContext(token, orgID), organizationv1.HibernateClusterRequest_builder{
+		ClusterId: clusterID,
+	}.Build())
+	require.NoError(t, err)
+
+	getRes, err := client.GetCluster(authedContext(token, orgID), organizationv1.GetClusterRequest_builder{
+		ClusterId: clusterID,
+	}.Build())
+	require.NoError(t, err)
+	assert.True(t, getRes.GetCluster().GetHibernated())
+
+	_, err = client.WakeCluster(authedContext(token, orgID), organizationv1.WakeClusterRequest_builder{
+		ClusterId: clusterID,
+	}.Build())
+	require.NoError(t, err)
+
+	getRes, err = client.GetCluster(authedContext(token, orgID), organizationv1.GetClusterRequest_builder{
+		ClusterId: clusterID,
+	}.Build())
+	require.NoError(t, err)
+	assert.False(t, getRes.GetCluster().GetHibernated())
+
+	activityRes, err := client.GetClusterActivity(authedContext(token, orgID), organizationv1.GetClusterActivityRequest_builder{
+		ClusterId: clusterID,
+	}.Build())
+	require.NoError(t, err)
+
+	var eventTypes []string
+	for _, event := range activityRes.GetEvents() {
+		eventTypes = append(eventTypes, event.GetEventType())
+	}
+	assert.Contains(t, eventTypes, "hibernation_requested")
+	assert.Contains(t, eventTypes, "wake_requested")
+}
+
+func Test_ClusterHibernation_NotFound(t *testing.T) {
+	t.Parallel()
+
+	orgID := uuid.New()
+	userID := uuid.New()
+
+	env := newTestAPI(t,
+		WithOrganization(orgID, "test-org"),
+		WithUser(&UserArgs{ID: userID, Name: "test-user", OrgIDs: []uuid.UUID{orgID}}),
+	)
+
+	token := env.createAuthnToken(t, userID)
+	client := organizationv1connect.NewClusterServiceClient(env.server.Client(), env.server.URL)
+
+	_, err := client.WakeCluster(authedContext(token, orgID), organizationv1.WakeClusterRequest_builder{
+		ClusterId: uuid.New().String(),
+	}.Build())
+
+	var connectErr *connect.Error
+	require.ErrorAs(t, err, &connectErr)
+	assert.Equal(t, connect.CodeNotFound, connectErr.Code())
+}
+
+func Test_ClusterHibernation_Schedules(t *testing.T) {
+	t.Parallel()
+
+	orgID := uuid.New()
+	userID := uuid.New()
+
+	env := newTestAPI(t,
+		WithOrganization(orgID, "test-org"),
+		WithUser(&UserArgs{ID: userID, Name: "test-user", OrgIDs: []uuid.UUID{orgID}}),
+	)
+
+	token := env.createAuthnToken(t, userID)
+	client := organizationv1connect.NewClusterServiceClient(env.server.Client(), env.server.URL)
+
+	createRes, err := client.CreateCluster(authedContext(token, orgID), organizationv1.CreateClusterRequest_builder{
+		Name:              "test-cluster",
+		Region:            "eu-west-1",
+		KubernetesVersion: "1.28",
+	}.Build())
+	require.NoError(t, err)
+	clusterID := createRes.GetClusterId()
+
+	_, err = client.UpdateCluster(authedContext(token, orgID), organizationv1.UpdateClusterRequest_builder{
+		ClusterId: clusterID,
+		Hibernation: organizationv1.HibernationPolicy_builder{
+			Schedules: []*organizationv1.HibernationSchedule{
+				organizationv1.HibernationSchedule_builder{
+					Start:    "0 19 * * 1-5",
+					End:      "0 7 * * 1-5",
+					Location: "Europe/Amsterdam",
+				}.Build(),
+				organizationv1.HibernationSchedule_builder{
+					Start: "0 0 * * 6",
+				}.Build(),
+			},
+		}.Build(),
+	}.Build())
+	require.NoError(t, err)
+
+	getRes, err := client.GetCluster(authedContext(token, orgID), organizationv1.GetClusterRequest_builder{
+		ClusterId: clusterID,
+	}.Build())
+	require.NoError(t, err)
+	schedules := getRes.GetCluster().GetHibernation().GetSchedules()
+	require.Len(t, schedules, 2)
+	assert.Equal(t, "0 19 * * 1-5", schedules[0].GetStart())
+	assert.Equal(t, "0 7 * * 1-5", schedules[0].GetEnd())
+	assert.Equal(t, "Europe/Amsterdam", schedules[0].GetLocation())
+	assert.Equal(t, "0 0 * * 6", schedules[1].GetStart())
+	assert.Empty(t, schedules[1].GetEnd())
+	assert.Equal(t, "UTC", schedules[1].GetLocation())
+
+	// An empty policy removes all schedules.
+	_, err = client.UpdateCluster(authedContext(token, orgID), organizationv1.UpdateClusterRequest_builder{
+		ClusterId:   clusterID,
+		Hibernation: organizationv1.HibernationPolicy_builder{}.Build(),
+	}.Build())
+	require.NoError(t, err)
+
+	getRes, err = client.GetCluster(authedContext(token, orgID), organizationv1.GetClusterRequest_builder{
+		ClusterId: clusterID,
+	}.Build())
+	require.NoError(t, err)
+	assert.Empty(t, getRes.GetCluster().GetHibernation().GetSchedules())
+
+	tests := []struct {
+		name     string
+		schedule *organizationv1.HibernationSchedule
+	}{
+		{name: "no start or end", schedule: organizationv1.HibernationSchedule_builder{Location: "UTC"}.Build()},
+		{name: "not a cron expression", schedule: organizationv1.HibernationSchedule_builder{Start: "every evening"}.Build()},
+		{name: "unknown location", schedule: organizationv1.HibernationSchedule_builder{Start: "0 19 * * *", Location: "Mars/Olympus"}.Build()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := client.UpdateCluster(authedContext(token, orgID), organizationv1.UpdateClusterRequest_builder{
+				ClusterId: clusterID,
+				Hibernation: organizationv1.HibernationPolicy_builder{
+					Schedules: []*organizationv1.HibernationSchedule{tt.schedule},
+				}.Build(),
+			}.Build())
+
+			var connectErr *connect.Error
+			require.ErrorAs(t, err, &connectErr)
+			assert.Equal(t, connect.CodeInvalidArgument, connectErr.Code())
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"connectrpc.com/connect"
+	"github.com/jackc/pgx/v5/pgtype"
 
 	"github.com/fundament-oss/fundament/common/authz"
 	db "github.com/fundament-oss/fundament/organization-api/pkg/db/gen"
@@ -24,9 +25,33 @@ func (s *Server) ListClusters(
 		return nil, err
 	}
 
-	clusters, err := s.queries.ClusterList(ctx)
+	cursor, err := decodePageToken(req.GetPageToken())
 	if err != nil {
-		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to list clusters: %w", err))
+		return nil, err
+	}
+
+	clusters, nextPageToken, err := allowedPage(req.GetPageSize(), cursor,
+		func(cursor *pageCursor, limit pgtype.Int4) ([]db.ClusterListRow, error) {
+			afterCreated, afterID := createdCursorParams(cursor)
+			clusters, err := s.queries.ClusterList(ctx, db.ClusterListParams{
+				NameFilter:   nameFilter(req.GetNameFilter()),
+				AfterCreated: afterCreated,
+				AfterID:      afterID,
+				PageLimit:    limit,
+			})
+			if err != nil {
+				return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to list clusters: %w", err))
+			}
+			return clusters, nil
+		},
+		func(row *db.ClusterListRow) authz.Object { return authz.Cluster(row.ID) },
+		s.canView(ctx),
+		func(row *db.ClusterListRow) pageCursor {
+			return pageCursor{Created: row.Created.Time, ID: row.ID}
+		},
+	)
+	if err != nil {
+		return nil, err
 	}
 
 	summaries := make([]*organizationv1.ListClustersResponse_ClusterSummary, 0, len(clusters))
@@ -35,7 +60,8 @@ func (s *Server) ListClusters(
 	}
 
 	return organizationv1.ListClustersResponse_builder{
-		Clusters: summaries,
+		Clusters:      summaries,
+		NextPageToken: nextPageToken,
 	}.Build(), nil
 }
 
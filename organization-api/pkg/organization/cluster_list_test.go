@@ -124,3 +124,57 @@ func Test_Cluster_List_MultiOrg(t *testing.T) {
 	require.NoError(t, err)
 	assert.Len(t, resOrgA.GetClusters(), 1)
 }
+
+func Test_Cluster_List_Paginated(t *testing.T) {
+	t.Parallel()
+
+	orgID := uuid.New()
+	userID := uuid.New()
+
+	env := newTestAPI(t,
+		WithOrganization(orgID, "test-org"),
+		WithUser(&UserArgs{
+			ID:     userID,
+			Name:   "test-user",
+			OrgIDs: []uuid.UUID{orgID},
+		}),
+	)
+
+	ctx := authedContext(env.createAuthnToken(t, userID), orgID)
+	client := organizationv1connect.NewClusterServiceClient(env.server.Client(), env.server.URL)
+
+	for _, name := range []string{"prod-a", "prod-b", "prod-c", "staging"} {
+		_, err := client.CreateCluster(ctx, organizationv1.CreateClusterRequest_builder{
+			Name:              name,
+			Region:            "eu-west-1",
+			KubernetesVersion: "1.28",
+		}.Build())
+		require.NoError(t, err)
+	}
+
+	var names []string
+	pageToken := ""
+	for pages := 0; ; pages++ {
+		require.Less(t, pages, 3, "pagination did not terminate")
+
+		res, err := client.ListClusters(ctx, organizationv1.ListClustersRequest_builder{
+			PageSize:   2,
+			PageToken:  pageToken,
+			NameFilter: "PROD",
+		}.Build())
+		require.NoError(t, err)
+		require.LessOrEqual(t, len(res.GetClusters()), 2)
+
+		for _, cluster := range res.GetClusters() {
+			names = append(names, cluster.GetName())
+		}
+		pageToken = res.GetNextPageToken()
+		if pageToken == "" {
+			break
+		}
+	}
+	assert.Equal(t, []string{"prod-c", "prod-b", "prod-a"}, names, "newest first, filtered on name")
+
+	_, err := client.ListClusters(ctx, organizationv1.ListClustersRequest_builder{PageToken: "not-a-token"}.Build())
+	assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+}
@@ -0,0 +1,184 @@
+package organization
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"connectrpc.com/connect"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/fundament-oss/fundament/common/authz"
+	"github.com/fundament-oss/fundament/common/rollback"
+	db "github.com/fundament-oss/fundament/organization-api/pkg/db/gen"
+	organizationv1 "github.com/fundament-oss/fundament/organization-api/pkg/proto/gen/v1"
+)
+
+const defaultClusterOutboxListLimit = 100
+
+// clusterOutboxActor names who replayed or abandoned an entry in the cluster
+// activity; the audit log records which admin it was.
+const clusterOutboxActor = "an organization admin"
+
+func (s *Server) ListClusterOutboxEntries(
+	ctx context.Context,
+	req *organizationv1.ListClusterOutboxEntriesRequest,
+) (*organizationv1.ListClusterOutboxEntriesResponse, error) {
+	organizationID, err := s.checkClusterOutboxPermission(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	params := db.ClusterOutboxListParams{
+		OrganizationID: organizationID,
+		States:         req.GetStates(),
+		EntityTypes:    req.GetEntityTypes(),
+		RowLimit:       req.GetLimit(),
+	}
+	if len(params.States) == 0 {
+		params.States = []string{"failed", "deferred"}
+	}
+	if params.EntityTypes == nil {
+		params.EntityTypes = []string{}
+	}
+	if params.RowLimit == 0 {
+		params.RowLimit = defaultClusterOutboxListLimit
+	}
+	if req.HasClusterId() {
+		params.ClusterID = pgtype.UUID{Bytes: uuid.MustParse(req.GetClusterId()), Valid: true}
+	}
+
+	rows, err := s.queries.ClusterOutboxList(ctx, params)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to list cluster outbox entries: %w", err))
+	}
+
+	entries := make([]*organizationv1.ClusterOutboxEntry, 0, len(rows))
+	for i := range rows {
+		entries = append(entries, clusterOutboxEntryFromRow(&rows[i]))
+	}
+
+	return organizationv1.ListClusterOutboxEntriesResponse_builder{
+		Entries: entries,
+	}.Build(), nil
+}
+
+func (s *Server) ReplayClusterOutboxEntries(
+	ctx context.Context,
+	req *organizationv1.ReplayClusterOutboxEntriesRequest,
+) (*organizationv1.ReplayClusterOutboxEntriesResponse, error) {
+	err := s.updateClusterOutboxEntries(ctx, req.GetIds(), "replayed", func(qtx *db.Queries, params db.ClusterOutboxReplayParams) ([]uuid.UUID, error) {
+		return qtx.ClusterOutboxReplay(ctx, params)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return organizationv1.ReplayClusterOutboxEntriesResponse_builder{}.Build(), nil
+}
+
+func (s *Server) AbandonClusterOutboxEntries(
+	ctx context.Context,
+	req *organizationv1.AbandonClusterOutboxEntriesRequest,
+) (*organizationv1.AbandonClusterOutboxEntriesResponse, error) {
+	err := s.updateClusterOutboxEntries(ctx, req.GetIds(), "abandoned", func(qtx *db.Queries, params db.ClusterOutboxReplayParams) ([]uuid.UUID, error) {
+		return qtx.ClusterOutboxAbandon(ctx, db.ClusterOutboxAbandonParams(params))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return organizationv1.AbandonClusterOutboxEntriesResponse_builder{}.Build(), nil
+}
+
+// checkClusterOutboxPermission returns the organization of the request once
+// the caller is allowed to manage its cluster outbox.
+func (s *Server) checkClusterOutboxPermission(ctx context.Context) (uuid.UUID, error) {
+	organizationID, ok := OrganizationIDFromContext(ctx)
+	if !ok {
+		return uuid.Nil, connect.NewError(connect.CodeInternal, fmt.Errorf("organization_id missing from context"))
+	}
+	if err := s.checkPermission(ctx, authz.CanEdit(), authz.Organization(organizationID)); err != nil {
+		return uuid.Nil, err
+	}
+	return organizationID, nil
+}
+
+// updateClusterOutboxEntries replays or abandons the given entries in one
+// transaction. It applies to all of them or, when one is missing or in a
+// state the action does not apply to, to none.
+func (s *Server) updateClusterOutboxEntries(
+	ctx context.Context,
+	ids []string,
+	action string,
+	update func(qtx *db.Queries, params db.ClusterOutboxReplayParams) ([]uuid.UUID, error),
+) error {
+	organizationID, err := s.checkClusterOutboxPermission(ctx)
+	if err != nil {
+		return err
+	}
+
+	entryIDs := make([]uuid.UUID, 0, len(ids))
+	for _, id := range ids {
+		entryIDs = append(entryIDs, uuid.MustParse(id))
+	}
+
+	tx, err := s.db.Pool.Begin(ctx)
+	if err != nil {
+		return connect.NewError(connect.CodeInternal, fmt.Errorf("failed to begin transaction: %w", err))
+	}
+	defer rollback.Rollback(ctx, tx, s.logger)
+
+	updated, err := update(s.queries.WithTx(tx), db.ClusterOutboxReplayParams{
+		Ids:            entryIDs,
+		OrganizationID: organizationID,
+		Actor:          clusterOutboxActor,
+	})
+	if err != nil {
+		return connect.NewError(connect.CodeInternal, fmt.Errorf("failed to update cluster outbox entries: %w", err))
+	}
+	for _, id := range entryIDs {
+		if !slices.Contains(updated, id) {
+			return connect.NewError(connect.CodeFailedPrecondition,
+				fmt.Errorf("cluster outbox entry %s not found or cannot be %s in its current state", id, action))
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return connect.NewError(connect.CodeInternal, fmt.Errorf("failed to commit transaction: %w", err))
+	}
+
+	s.logger.InfoContext(ctx, "cluster outbox entries updated",
+		"organization_id", organizationID,
+		"action", action,
+		"ids", entryIDs,
+	)
+
+	return nil
+}
+
+func clusterOutboxEntryFromRow(row *db.ClusterOutboxListRow) *organizationv1.ClusterOutboxEntry {
+	entry := organizationv1.ClusterOutboxEntry_builder{
+		Id:         row.ID.String(),
+		EntityType: row.EntityType,
+		EntityId:   row.EntityID.String(),
+		Event:      row.Event,
+		State:      row.State,
+		Retries:    row.Retries,
+		Deferrals:  row.Deferrals,
+		Created:    timestamppb.New(row.Created.Time),
+	}.Build()
+
+	if row.ClusterID.Valid {
+		entry.SetClusterId(uuid.UUID(row.ClusterID.Bytes).String())
+	}
+	if row.StatusInfo.Valid {
+		entry.SetLastError(row.StatusInfo.String)
+	}
+	if row.Failed.Valid {
+		entry.SetFailed(timestamppb.New(row.Failed.Time))
+	}
+	return entry
+}
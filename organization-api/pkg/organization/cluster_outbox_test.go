@@ -0,0 +1,184 @@
+package organization_test
+
+import (
+	"testing"
+
+	"connectrpc.com/connect"
+	organizationv1 "github.com/fundament-oss/fundament/organization-api/pkg/proto/gen/v1"
+	"github.com/fundament-oss/fundament/organization-api/pkg/proto/gen/v1/organizationv1connect"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ClusterOutbox_ReplayAbandon(t *testing.T) {
+	t.Parallel()
+
+	orgID := uuid.New()
+	userID := uuid.New()
+
+	env := newTestAPI(t,
+		WithOrganization(orgID, "test-org"),
+		WithUser(&UserArgs{ID: userID, Name: "test-user", OrgIDs: []uuid.UUID{orgID}}),
+	)
+
+	token := env.createAuthnToken(t, userID)
+	client := organizationv1connect.NewClusterServiceClient(env.server.Client(), env.server.URL)
+
+	createRes, err := client.CreateCluster(authedContext(token, orgID), organizationv1.CreateClusterRequest_builder{
+		Name:              "test-cluster",
+		Region:            "eu-west-1",
+		KubernetesVersion: "1.28",
+	}.Build())
+	require.NoError(t, err)
+	clusterID := createRes.GetClusterId()
+
+	failEntries := func() {
+		t.Helper()
+		_, err := env.adminPool.Exec(t.Context(), `
+			UPDATE tenant.cluster_outbox
+			SET status = 'failed', retries = 10, failed = now(), status_info = 'shoot rejected'
+			WHERE cluster_id = $1`, clusterID)
+		require.NoError(t, err)
+	}
+	listEntries := func(states ...string) []*organizationv1.ClusterOutboxEntry {
+		t.Helper()
+		res, err := client.ListClusterOutboxEntries(authedContext(token, orgID), organizationv1.ListClusterOutboxEntriesRequest_builder{
+			States: states,
+		}.Build())
+		require.NoError(t, err)
+		return res.GetEntries()
+	}
+
+	// A fresh row is pending and neither failed nor deferred.
+	assert.Empty(t, listEntries())
+
+	failEntries()
+	entries := listEntries()
+	require.Len(t, entries, 1)
+	entry := entries[0]
+	assert.Equal(t, "cluster", entry.GetEntityType())
+	assert.Equal(t, clusterID, entry.GetEntityId())
+	assert.Equal(t, clusterID, entry.GetClusterId())
+	assert.Equal(t, "failed", entry.GetState())
+	assert.Equal(t, int32(10), entry.GetRetries())
+	assert.Equal(t, "shoot rejected", entry.GetLastError())
+	assert.True(t, entry.HasFailed())
+
+	_, err = client.ReplayClusterOutboxEntries(authedContext(token, orgID), organizationv1.ReplayClusterOutboxEntriesRequest_builder{
+		Ids: []string{entry.GetId()},
+	}.Build())
+	require.NoError(t, err)
+	assert.Empty(t, listEntries())
+
+	var status string
+	var retries int32
+	require.NoError(t, env.adminPool.QueryRow(t.Context(),
+		"SELECT status, retries FROM tenant.cluster_outbox WHERE id = $1", entry.GetId()).Scan(&status, &retries))
+	assert.Equal(t, "pending", status)
+	assert.Equal(t, int32(0), retries)
+
+	// A pending row is not replayed again.
+	_, err = client.ReplayClusterOutboxEntries(authedContext(token, orgID), organizationv1.ReplayClusterOutboxEntriesRequest_builder{
+		Ids: []string{entry.GetId()},
+	}.Build())
+	assert.Equal(t, connect.CodeFailedPrecondition, connect.CodeOf(err))
+
+	failEntries()
+	_, err = client.AbandonClusterOutboxEntries(authedContext(token, orgID), organizationv1.AbandonClusterOutboxEntriesRequest_builder{
+		Ids: []string{entry.GetId()},
+	}.Build())
+	require.NoError(t, err)
+	assert.Empty(t, listEntries())
+
+	abandoned := listEntries("abandoned")
+	require.Len(t, abandoned, 1)
+	assert.Equal(t, entry.GetId(), abandoned[0].GetId())
+
+	// Rows of other organizations cannot be touched.
+	_, err = client.AbandonClusterOutboxEntries(authedContext(token, orgID), organizationv1.AbandonClusterOutboxEntriesRequest_builder{
+		Ids: []string{uuid.New().String()},
+	}.Build())
+	assert.Equal(t, connect.CodeFailedPrecondition, connect.CodeOf(err))
+
+	activityRes, err := client.GetClusterActivity(authedContext(token, orgID), organizationv1.GetClusterActivityRequest_builder{
+		ClusterId: clusterID,
+	}.Build())
+	require.NoError(t, err)
+
+	var messages []string
+	for _, event := range activityRes.GetEvents() {
+		switch event.GetEventType() {
+		case "outbox_replayed", "outbox_abandoned":
+			messages = append(messages, event.GetMessage())
+		}
+	}
+	assert.Equal(t, []string{
+		"cluster sync abandoned by an organization admin; last error: shoot rejected",
+		"cluster sync replayed by an organization admin; last error: shoot rejected",
+	}, messages)
+}
+
+func Test_ClusterOutbox_AccessElevation(t *testing.T) {
+	t.Parallel()
+
+	orgID := uuid.New()
+	userID := uuid.New()
+
+	env := newTestAPI(t,
+		WithOrganization(orgID, "test-org"),
+		WithUser(&UserArgs{ID: userID, Name: "test-user", OrgIDs: []uuid.UUID{orgID}}),
+	)
+
+	token := env.createAuthnToken(t, userID)
+	client := organizationv1connect.NewClusterServiceClient(env.server.Client(), env.server.URL)
+
+	// Approving an organization-wide elevation queues a row that belongs to no
+	// cluster, only to the organization of the elevation.
+	var elevationID string
+	require.NoError(t, env.adminPool.QueryRow(t.Context(), `
+		INSERT INTO tenant.access_elevations (organization_id, user_id, role, duration_minutes, reason, status, decided, expires)
+		VALUES ($1, $2, 'admin', 60, 'incident', 'approved', now(), now() + interval '1 hour')
+		RETURNING id::text`, orgID, userID).Scan(&elevationID))
+	_, err := env.adminPool.Exec(t.Context(), `
+		UPDATE tenant.cluster_outbox
+		SET status = 'failed', retries = 10, failed = now(), status_info = 'cluster unreachable'
+		WHERE access_elevation_id = $1`, elevationID)
+	require.NoError(t, err)
+
+	listEntries := func(states ...string) []*organizationv1.ClusterOutboxEntry {
+		t.Helper()
+		res, err := client.ListClusterOutboxEntries(authedContext(token, orgID), organizationv1.ListClusterOutboxEntriesRequest_builder{
+			States:      states,
+			EntityTypes: []string{"access_elevation"},
+		}.Build())
+		require.NoError(t, err)
+		return res.GetEntries()
+	}
+
+	entries := listEntries()
+	require.Len(t, entries, 1)
+	entry := entries[0]
+	assert.Equal(t, "access_elevation", entry.GetEntityType())
+	assert.Equal(t, elevationID, entry.GetEntityId())
+	assert.False(t, entry.HasClusterId())
+	assert.Equal(t, "failed", entry.GetState())
+
+	_, err = client.ReplayClusterOutboxEntries(authedContext(token, orgID), organizationv1.ReplayClusterOutboxEntriesRequest_builder{
+		Ids: []string{entry.GetId()},
+	}.Build())
+	require.NoError(t, err)
+	assert.Empty(t, listEntries())
+
+	_, err = env.adminPool.Exec(t.Context(), `
+		UPDATE tenant.cluster_outbox SET status = 'failed', failed = now() WHERE id = $1`, entry.GetId())
+	require.NoError(t, err)
+	_, err = client.AbandonClusterOutboxEntries(authedContext(token, orgID), organizationv1.AbandonClusterOutboxEntriesRequest_builder{
+		Ids: []string{entry.GetId()},
+	}.Build())
+	require.NoError(t, err)
+
+	abandoned := listEntries("abandoned")
+	require.Len(t, abandoned, 1)
+	assert.Equal(t, entry.GetId(), abandoned[0].GetId())
+}
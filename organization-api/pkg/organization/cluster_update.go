@@ -11,6 +11,7 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 
 	"github.com/fundament-oss/fundament/common/authz"
+	"github.com/fundament-oss/fundament/common/rollback"
 	db "github.com/fundament-oss/fundament/organization-api/pkg/db/gen"
 	organizationv1 "github.com/fundament-oss/fundament/organization-api/pkg/proto/gen/v1"
 )
@@ -29,9 +30,25 @@ func (s *Server) UpdateCluster(
 		ID: clusterID,
 	}
 
+	// A version change is applied like an upgrade scheduled for now outside
+	// the maintenance window, so cluster-worker records its events and
+	// follows the shoot to the outcome.
+	var upgrade *db.ClusterUpgradeCreateParams
 	if req.HasKubernetesVersion() {
+		organizationID, ok := OrganizationIDFromContext(ctx)
+		if !ok {
+			return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("organization_id missing from context"))
+		}
+		policy, err := s.organizationPolicy(ctx, organizationID)
+		if err != nil {
+			return nil, err
+		}
+		if err := policyError(policy.checkUpdateCluster(req)); err != nil {
+			return nil, err
+		}
+
 		// Resolve the new version against the catalog within the cluster's
-		// region; the text and the catalog reference update in lockstep.
+		// region; the upgrade carries both the text and the catalog reference.
 		cluster, err := s.queries.ClusterGetByID(ctx, db.ClusterGetByIDParams{ID: clusterID})
 		if err != nil {
 			if errors.Is(err, pgx.ErrNoRows) {
@@ -39,6 +56,13 @@ func (s *Server) UpdateCluster(
 			}
 			return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to get cluster: %w", err))
 		}
+		if cluster.Deleted.Valid {
+			return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("cluster not found"))
+		}
+
+		if err := checkUpgradePath(cluster.KubernetesVersion, req.GetKubernetesVersion()); err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, err)
+		}
 
 		offering, err := s.queries.RegionKubernetesVersionResolve(ctx, db.RegionKubernetesVersionResolveParams{
 			RegionName: cluster.Region,
@@ -52,11 +76,38 @@ func (s *Server) UpdateCluster(
 			return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to resolve region offering: %w", err))
 		}
 
-		params.KubernetesVersion = pgtype.Text{String: req.GetKubernetesVersion(), Valid: true}
-		params.KubernetesVersionID = pgtype.UUID{Bytes: offering.KubernetesVersionID, Valid: true}
+		upgrade = &db.ClusterUpgradeCreateParams{
+			ClusterID:             clusterID,
+			FromKubernetesVersion: cluster.KubernetesVersion,
+			ToKubernetesVersion:   req.GetKubernetesVersion(),
+			ToKubernetesVersionID: pgtype.UUID{Bytes: offering.KubernetesVersionID, Valid: true},
+			SkipMaintenanceWindow: true,
+		}
+	}
+
+	if req.HasMaintenance() {
+		maintenance := req.GetMaintenance()
+		start, end, err := maintenanceWindowFromProto(maintenance)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, err)
+		}
+
+		params.SetMaintenanceWindow = true
+		params.MaintenanceWindowStart = start
+		params.MaintenanceWindowEnd = end
+		params.AutoUpdateKubernetesVersion = pgtype.Bool{Bool: maintenance.GetAutoUpdateKubernetesVersion(), Valid: true}
+		params.AutoUpdateMachineImageVersion = pgtype.Bool{Bool: maintenance.GetAutoUpdateMachineImageVersion(), Valid: true}
 	}
 
-	rowsAffected, err := s.queries.ClusterUpdate(ctx, params)
+	tx, err := s.db.Pool.Begin(ctx)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to begin transaction: %w", err))
+	}
+	defer rollback.Rollback(ctx, tx, s.logger)
+
+	qtx := s.queries.WithTx(tx)
+
+	rowsAffected, err := qtx.ClusterUpdate(ctx, params)
 	if err != nil {
 		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to update cluster: %w", err))
 	}
@@ -65,6 +116,22 @@ func (s *Server) UpdateCluster(
 		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("cluster not found"))
 	}
 
+	if upgrade != nil {
+		if _, err := createClusterUpgrade(ctx, qtx, *upgrade); err != nil {
+			return nil, err
+		}
+	}
+
+	if req.HasHibernation() {
+		if err := replaceHibernationSchedules(ctx, qtx, clusterID, req.GetHibernation()); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to commit transaction: %w", err))
+	}
+
 	s.logger.InfoContext(ctx, "cluster updated", "cluster_id", clusterID)
 
 	return organizationv1.UpdateClusterResponse_builder{}.Build(), nil
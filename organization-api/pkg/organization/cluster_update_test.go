@@ -81,7 +81,29 @@ func Test_Cluster_Update(t *testing.T) {
 
 	getRes, err := client.GetCluster(getCtx, getReq)
 	require.NoError(t, err)
-	assert.Equal(t, "1.29", getRes.GetCluster().GetKubernetesVersion())
+	// The version changes once cluster-worker starts the upgrade.
+	assert.Equal(t, "1.28", getRes.GetCluster().GetKubernetesVersion())
+
+	listCtx, listCallInfo := connect.NewClientContext(context.Background())
+	listCallInfo.RequestHeader().Set("Authorization", "Bearer "+token)
+	listCallInfo.RequestHeader().Set("Fun-Organization", orgID.String())
+
+	listRes, err := client.ListClusterUpgrades(listCtx, organizationv1.ListClusterUpgradesRequest_builder{
+		ClusterId: clusterID,
+	}.Build())
+	require.NoError(t, err)
+	require.Len(t, listRes.GetUpgrades(), 1)
+	upgrade := listRes.GetUpgrades()[0]
+	assert.Equal(t, "1.28", upgrade.GetFromKubernetesVersion())
+	assert.Equal(t, "1.29", upgrade.GetToKubernetesVersion())
+	assert.True(t, upgrade.GetSkipMaintenanceWindow())
+	assert.Equal(t, organizationv1.ClusterUpgradeStatus_CLUSTER_UPGRADE_STATUS_SCHEDULED, upgrade.GetStatus())
+
+	// A second version change conflicts with the scheduled upgrade.
+	_, err = client.UpdateCluster(updateCtx, updateReq)
+	var connectErr *connect.Error
+	require.ErrorAs(t, err, &connectErr)
+	assert.Equal(t, connect.CodeFailedPrecondition, connectErr.Code())
 }
 
 func Test_Cluster_Update_NotFound(t *testing.T) {
@@ -116,3 +138,140 @@ func Test_Cluster_Update_NotFound(t *testing.T) {
 	require.ErrorAs(t, err, &connectErr)
 	assert.Equal(t, connect.CodeNotFound, connectErr.Code())
 }
+
+func Test_Cluster_Update_Maintenance(t *testing.T) {
+	t.Parallel()
+
+	orgID := uuid.New()
+	userID := uuid.New()
+
+	env := newTestAPI(t,
+		WithOrganization(orgID, "test-org"),
+		WithUser(&UserArgs{
+			ID:     userID,
+			Name:   "test-user",
+			OrgIDs: []uuid.UUID{orgID},
+		}),
+	)
+
+	token := env.createAuthnToken(t, userID)
+	client := organizationv1connect.NewClusterServiceClient(env.server.Client(), env.server.URL)
+
+	createRes, err := client.CreateCluster(authedContext(token, orgID), organizationv1.CreateClusterRequest_builder{
+		Name:              "test-cluster",
+		Region:            "eu-west-1",
+		KubernetesVersion: "1.28",
+	}.Build())
+	require.NoError(t, err)
+	clusterID := createRes.GetClusterId()
+
+	getRes, err := client.GetCluster(authedContext(token, orgID), organizationv1.GetClusterRequest_builder{
+		ClusterId: clusterID,
+	}.Build())
+	require.NoError(t, err)
+	assert.Empty(t, getRes.GetCluster().GetMaintenance().GetWindowStart())
+	assert.False(t, getRes.GetCluster().GetMaintenance().GetAutoUpdateKubernetesVersion())
+	assert.True(t, getRes.GetCluster().GetMaintenance().GetAutoUpdateMachineImageVersion())
+
+	// A window spanning midnight.
+	_, err = client.UpdateCluster(authedContext(token, orgID), organizationv1.UpdateClusterRequest_builder{
+		ClusterId: clusterID,
+		Maintenance: organizationv1.MaintenancePolicy_builder{
+			WindowStart:                   "23:00",
+			WindowEnd:                     "01:30",
+			AutoUpdateKubernetesVersion:   true,
+			AutoUpdateMachineImageVersion: false,
+		}.Build(),
+	}.Build())
+	require.NoError(t, err)
+
+	getRes, err = client.GetCluster(authedContext(token, orgID), organizationv1.GetClusterRequest_builder{
+		ClusterId: clusterID,
+	}.Build())
+	require.NoError(t, err)
+	maintenance := getRes.GetCluster().GetMaintenance()
+	assert.Equal(t, "23:00", maintenance.GetWindowStart())
+	assert.Equal(t, "01:30", maintenance.GetWindowEnd())
+	assert.True(t, maintenance.GetAutoUpdateKubernetesVersion())
+	assert.False(t, maintenance.GetAutoUpdateMachineImageVersion())
+	assert.Equal(t, "1.28", getRes.GetCluster().GetKubernetesVersion())
+
+	// Updating only the version leaves the maintenance policy alone.
+	_, err = client.UpdateCluster(authedContext(token, orgID), organizationv1.UpdateClusterRequest_builder{
+		ClusterId:         clusterID,
+		KubernetesVersion: proto.String("1.29"),
+	}.Build())
+	require.NoError(t, err)
+
+	getRes, err = client.GetCluster(authedContext(token, orgID), organizationv1.GetClusterRequest_builder{
+		ClusterId: clusterID,
+	}.Build())
+	require.NoError(t, err)
+	assert.Equal(t, "23:00", getRes.GetCluster().GetMaintenance().GetWindowStart())
+
+	// An empty window hands the window back to Gardener.
+	_, err = client.UpdateCluster(authedContext(token, orgID), organizationv1.UpdateClusterRequest_builder{
+		ClusterId:   clusterID,
+		Maintenance: organizationv1.MaintenancePolicy_builder{}.Build(),
+	}.Build())
+	require.NoError(t, err)
+
+	getRes, err = client.GetCluster(authedContext(token, orgID), organizationv1.GetClusterRequest_builder{
+		ClusterId: clusterID,
+	}.Build())
+	require.NoError(t, err)
+	assert.Empty(t, getRes.GetCluster().GetMaintenance().GetWindowStart())
+	assert.Empty(t, getRes.GetCluster().GetMaintenance().GetWindowEnd())
+}
+
+func Test_Cluster_Update_Maintenance_InvalidWindow(t *testing.T) {
+	t.Parallel()
+
+	orgID := uuid.New()
+	userID := uuid.New()
+
+	env := newTestAPI(t,
+		WithOrganization(orgID, "test-org"),
+		WithUser(&UserArgs{
+			ID:     userID,
+			Name:   "test-user",
+			OrgIDs: []uuid.UUID{orgID},
+		}),
+	)
+
+	token := env.createAuthnToken(t, userID)
+	client := organizationv1connect.NewClusterServiceClient(env.server.Client(), env.server.URL)
+
+	createRes, err := client.CreateCluster(authedContext(token, orgID), organizationv1.CreateClusterRequest_builder{
+		Name:              "test-cluster",
+		Region:            "eu-west-1",
+		KubernetesVersion: "1.28",
+	}.Build())
+	require.NoError(t, err)
+
+	tests := []struct {
+		name  string
+		start string
+		end   string
+	}{
+		{name: "start without end", start: "22:00", end: ""},
+		{name: "not HH:MM", start: "10pm", end: "23:00"},
+		{name: "too short", start: "22:00", end: "22:15"},
+		{name: "too long", start: "20:00", end: "03:00"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := client.UpdateCluster(authedContext(token, orgID), organizationv1.UpdateClusterRequest_builder{
+				ClusterId: createRes.GetClusterId(),
+				Maintenance: organizationv1.MaintenancePolicy_builder{
+					WindowStart: tt.start,
+					WindowEnd:   tt.end,
+				}.Build(),
+			}.Build())
+
+			var connectErr *connect.Error
+			require.ErrorAs(t, err, &connectErr)
+			assert.Equal(t, connect.CodeInvalidArgument, connectErr.Code())
+		})
+	}
+}
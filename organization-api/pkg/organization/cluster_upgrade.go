@@ -0,0 +1,239 @@
+package organization
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"connectrpc.com/connect"
+	"github.com/google/uuid"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"k8s.io/apimachinery/pkg/util/version"
+
+	"github.com/fundament-oss/fundament/common/authz"
+	"github.com/fundament-oss/fundament/common/dbconst"
+	db "github.com/fundament-oss/fundament/organization-api/pkg/db/gen"
+	organizationv1 "github.com/fundament-oss/fundament/organization-api/pkg/proto/gen/v1"
+)
+
+func (s *Server) ScheduleClusterUpgrade(
+	ctx context.Context,
+	req *organizationv1.ScheduleClusterUpgradeRequest,
+) (*organizationv1.ScheduleClusterUpgradeResponse, error) {
+	clusterID := uuid.MustParse(req.GetClusterId())
+
+	if err := s.checkPermission(ctx, authz.CanEdit(), authz.Cluster(clusterID)); err != nil {
+		return nil, err
+	}
+
+	cluster, err := s.queries.ClusterGetByID(ctx, db.ClusterGetByIDParams{ID: clusterID})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("cluster not found"))
+		}
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to get cluster: %w", err))
+	}
+	if cluster.Deleted.Valid {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("cluster not found"))
+	}
+
+	policy, err := s.organizationPolicy(ctx, cluster.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+	if err := policyError(policy.checkScheduleClusterUpgrade(req)); err != nil {
+		return nil, err
+	}
+
+	if err := checkUpgradePath(cluster.KubernetesVersion, req.GetKubernetesVersion()); err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	offering, err := s.queries.RegionKubernetesVersionResolve(ctx, db.RegionKubernetesVersionResolveParams{
+		RegionName: cluster.Region,
+		Version:    req.GetKubernetesVersion(),
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, connect.NewError(connect.CodeInvalidArgument,
+				fmt.Errorf("kubernetes version %q is not offered in region %q", req.GetKubernetesVersion(), cluster.Region))
+		}
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to resolve region offering: %w", err))
+	}
+
+	params := db.ClusterUpgradeCreateParams{
+		ClusterID:             clusterID,
+		FromKubernetesVersion: cluster.KubernetesVersion,
+		ToKubernetesVersion:   req.GetKubernetesVersion(),
+		ToKubernetesVersionID: pgtype.UUID{Bytes: offering.KubernetesVersionID, Valid: true},
+		SkipMaintenanceWindow: req.GetSkipMaintenanceWindow(),
+	}
+	if req.HasNotBefore() {
+		params.NotBefore = pgtype.Timestamptz{Time: req.GetNotBefore().AsTime(), Valid: true}
+	}
+
+	upgradeID, err := createClusterUpgrade(ctx, s.queries, params)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.InfoContext(ctx, "cluster upgrade scheduled",
+		"cluster_id", clusterID,
+		"upgrade_id", upgradeID,
+		"from", cluster.KubernetesVersion,
+		"to", req.GetKubernetesVersion())
+
+	return organizationv1.ScheduleClusterUpgradeResponse_builder{
+		UpgradeId: upgradeID.String(),
+	}.Build(), nil
+}
+
+func (s *Server) ListClusterUpgrades(
+	ctx context.Context,
+	req *organizationv1.ListClusterUpgradesRequest,
+) (*organizationv1.ListClusterUpgradesResponse, error) {
+	clusterID := uuid.MustParse(req.GetClusterId())
+
+	if err := s.checkPermission(ctx, authz.CanView(), authz.Cluster(clusterID)); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.queries.ClusterGetByID(ctx, db.ClusterGetByIDParams{ID: clusterID}); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("cluster not found"))
+		}
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to get cluster: %w", err))
+	}
+
+	upgrades, err := s.queries.ClusterUpgradeList(ctx, db.ClusterUpgradeListParams{ClusterID: clusterID})
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to list cluster upgrades: %w", err))
+	}
+
+	result := make([]*organizationv1.ClusterUpgrade, 0, len(upgrades))
+	for i := range upgrades {
+		result = append(result, clusterUpgradeFromRow(&upgrades[i]))
+	}
+
+	return organizationv1.ListClusterUpgradesResponse_builder{
+		Upgrades: result,
+	}.Build(), nil
+}
+
+func (s *Server) CancelClusterUpgrade(
+	ctx context.Context,
+	req *organizationv1.CancelClusterUpgradeRequest,
+) (*organizationv1.CancelClusterUpgradeResponse, error) {
+	upgradeID := uuid.MustParse(req.GetUpgradeId())
+
+	upgrade, err := s.queries.ClusterUpgradeGetByID(ctx, db.ClusterUpgradeGetByIDParams{UpgradeID: upgradeID})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("cluster upgrade not found"))
+		}
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to get cluster upgrade: %w", err))
+	}
+
+	if err := s.checkPermission(ctx, authz.CanEdit(), authz.Cluster(upgrade.ClusterID)); err != nil {
+		return nil, err
+	}
+
+	rowsAffected, err := s.queries.ClusterUpgradeCancel(ctx, db.ClusterUpgradeCancelParams{UpgradeID: upgradeID})
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to cancel cluster upgrade: %w", err))
+	}
+
+	if rowsAffected != 1 {
+		return nil, connect.NewError(connect.CodeFailedPrecondition,
+			fmt.Errorf("cluster upgrade is %s and can no longer be cancelled", upgrade.Status))
+	}
+
+	s.logger.InfoContext(ctx, "cluster upgrade cancelled", "cluster_id", upgrade.ClusterID, "upgrade_id", upgradeID)
+
+	return organizationv1.CancelClusterUpgradeResponse_builder{}.Build(), nil
+}
+
+// createClusterUpgrade schedules an upgrade, rejecting it while another one is
+// scheduled or in progress for the cluster.
+func createClusterUpgrade(ctx context.Context, queries *db.Queries, params db.ClusterUpgradeCreateParams) (uuid.UUID, error) {
+	upgradeID, err := queries.ClusterUpgradeCreate(ctx, params)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) &&
+			pgErr.Code == pgerrcode.UniqueViolation && pgErr.ConstraintName == dbconst.ConstraintClusterUpgradesUqActive {
+			return uuid.Nil, connect.NewError(connect.CodeFailedPrecondition,
+				fmt.Errorf("cluster already has an upgrade scheduled or in progress; cancel it first"))
+		}
+		return uuid.Nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to schedule cluster upgrade: %w", err))
+	}
+	return upgradeID, nil
+}
+
+// checkUpgradePath rejects downgrades and upgrades that skip a minor version;
+// Kubernetes only supports moving the control plane one minor at a time.
+func checkUpgradePath(from, to string) error {
+	toVersion, err := version.ParseGeneric(to)
+	if err != nil {
+		return fmt.Errorf("invalid kubernetes version %q: %w", to, err)
+	}
+	fromVersion, err := version.ParseGeneric(from)
+	if err != nil {
+		// Legacy free-text versions cannot be compared; the catalog check
+		// still applies.
+		return nil //nolint:nilerr // unparseable current version is not the caller's fault
+	}
+
+	if !fromVersion.LessThan(toVersion) {
+		return fmt.Errorf("kubernetes version %s is not newer than the current version %s", to, from)
+	}
+	if toVersion.Major() != fromVersion.Major() || toVersion.Minor() > fromVersion.Minor()+1 {
+		return fmt.Errorf("cannot upgrade from %s to %s: upgrade one minor version at a time", from, to)
+	}
+	return nil
+}
+
+func clusterUpgradeFromRow(row *db.ClusterUpgradeListRow) *organizationv1.ClusterUpgrade {
+	upgrade := organizationv1.ClusterUpgrade_builder{
+		Id:                    row.ID.String(),
+		ClusterId:             row.ClusterID.String(),
+		FromKubernetesVersion: row.FromKubernetesVersion,
+		ToKubernetesVersion:   row.ToKubernetesVersion,
+		NotBefore:             timestamppb.New(row.NotBefore.Time),
+		SkipMaintenanceWindow: row.SkipMaintenanceWindow,
+		Status:                clusterUpgradeStatusFromDB(row.Status),
+		Created:               timestamppb.New(row.Created.Time),
+	}.Build()
+
+	if row.Message.Valid {
+		upgrade.SetMessage(row.Message.String)
+	}
+	if row.Started.Valid {
+		upgrade.SetStarted(timestamppb.New(row.Started.Time))
+	}
+	if row.Finished.Valid {
+		upgrade.SetFinished(timestamppb.New(row.Finished.Time))
+	}
+
+	return upgrade
+}
+
+func clusterUpgradeStatusFromDB(status string) organizationv1.ClusterUpgradeStatus {
+	switch dbconst.ClusterUpgradeStatus(status) {
+	case dbconst.ClusterUpgradeStatus_Scheduled:
+		return organizationv1.ClusterUpgradeStatus_CLUSTER_UPGRADE_STATUS_SCHEDULED
+	case dbconst.ClusterUpgradeStatus_InProgress:
+		return organizationv1.ClusterUpgradeStatus_CLUSTER_UPGRADE_STATUS_IN_PROGRESS
+	case dbconst.ClusterUpgradeStatus_Completed:
+		return organizationv1.ClusterUpgradeStatus_CLUSTER_UPGRADE_STATUS_COMPLETED
+	case dbconst.ClusterUpgradeStatus_Failed:
+		return organizationv1.ClusterUpgradeStatus_CLUSTER_UPGRADE_STATUS_FAILED
+	case dbconst.ClusterUpgradeStatus_Cancelled:
+		return organizationv1.ClusterUpgradeStatus_CLUSTER_UPGRADE_STATUS_CANCELLED
+	default:
+		return organizationv1.ClusterUpgradeStatus_CLUSTER_UPGRADE_STATUS_UNSPECIFIED
+	}
+}
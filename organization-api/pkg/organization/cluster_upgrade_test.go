@@ -0,0 +1,230 @@
+package organization_test
+
+import (
+	"context"
+	"testing"
+
+	"connectrpc.com/connect"
+	organizationv1 "github.com/fundament-oss/fundament/organization-api/pkg/proto/gen/v1"
+	"github.com/fundament-oss/fundament/organization-api/pkg/proto/gen/v1/organizationv1connect"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ClusterUpgrade_Schedule_Unauthenticated(t *testing.T) {
+	t.Parallel()
+
+	env := newTestAPI(t)
+	client := organizationv1connect.NewClusterServiceClient(env.server.Client(), env.server.URL)
+
+	_, err := client.ScheduleClusterUpgrade(context.Background(), organizationv1.ScheduleClusterUpgradeRequest_builder{
+		ClusterId:         uuid.New().String(),
+		KubernetesVersion: "1.29",
+	}.Build())
+
+	var connectErr *connect.Error
+	require.ErrorAs(t, err, &connectErr)
+	assert.Equal(t, connect.CodeUnauthenticated, connectErr.Code())
+}
+
+func Test_ClusterUpgrade_ScheduleListCancel(t *testing.T) {
+	t.Parallel()
+
+	orgID := uuid.New()
+	userID := uuid.New()
+
+	env := newTestAPI(t,
+		WithOrganization(orgID, "test-org"),
+		WithUser(&UserArgs{ID: userID, Name: "test-user", OrgIDs: []uuid.UUID{orgID}}),
+	)
+
+	token := env.createAuthnToken(t, userID)
+	client := organizationv1connect.NewClusterServiceClient(env.server.Client(), env.server.URL)
+
+	createRes, err := client.CreateCluster(authedContext(token, orgID), organizationv1.CreateClusterRequest_builder{
+		Name:              "test-cluster",
+		Region:            "eu-west-1",
+		KubernetesVersion: "1.28",
+	}.Build())
+	require.NoError(t, err)
+	clusterID := createRes.GetClusterId()
+
+	scheduleRes, err := client.ScheduleClusterUpgrade(authedContext(token, orgID), organizationv1.ScheduleClusterUpgradeRequest_builder{
+		ClusterId:         clusterID,
+		KubernetesVersion: "1.29",
+	}.Build())
+	require.NoError(t, err)
+	upgradeID := scheduleRes.GetUpgradeId()
+
+	// Only one upgrade can be pending at a time.
+	_, err = client.ScheduleClusterUpgrade(authedContext(token, orgID), organizationv1.ScheduleClusterUpgradeRequest_builder{
+		ClusterId:         clusterID,
+		KubernetesVersion: "1.29",
+	}.Build())
+	var connectErr *connect.Error
+	require.ErrorAs(t, err, &connectErr)
+	assert.Equal(t, connect.CodeFailedPrecondition, connectErr.Code())
+
+	listRes, err := client.ListClusterUpgrades(authedContext(token, orgID), organizationv1.ListClusterUpgradesRequest_builder{
+		ClusterId: clusterID,
+	}.Build())
+	require.NoError(t, err)
+	require.Len(t, listRes.GetUpgrades(), 1)
+	upgrade := listRes.GetUpgrades()[0]
+	assert.Equal(t, upgradeID, upgrade.GetId())
+	assert.Equal(t, "1.28", upgrade.GetFromKubernetesVersion())
+	assert.Equal(t, "1.29", upgrade.GetToKubernetesVersion())
+	assert.Equal(t, organizationv1.ClusterUpgradeStatus_CLUSTER_UPGRADE_STATUS_SCHEDULED, upgrade.GetStatus())
+	assert.False(t, upgrade.HasStarted())
+
+	_, err = client.CancelClusterUpgrade(authedContext(token, orgID), organizationv1.CancelClusterUpgradeRequest_builder{
+		UpgradeId: upgradeID,
+	}.Build())
+	require.NoError(t, err)
+
+	// Cancelling twice is rejected: the upgrade is no longer scheduled.
+	_, err = client.CancelClusterUpgrade(authedContext(token, orgID), organizationv1.CancelClusterUpgradeRequest_builder{
+		UpgradeId: upgradeID,
+	}.Build())
+	require.ErrorAs(t, err, &connectErr)
+	assert.Equal(t, connect.CodeFailedPrecondition, connectErr.Code())
+
+	listRes, err = client.ListClusterUpgrades(authedContext(token, orgID), organizationv1.ListClusterUpgradesRequest_builder{
+		ClusterId: clusterID,
+	}.Build())
+	require.NoError(t, err)
+	require.Len(t, listRes.GetUpgrades(), 1)
+	assert.Equal(t, organizationv1.ClusterUpgradeStatus_CLUSTER_UPGRADE_STATUS_CANCELLED, listRes.GetUpgrades()[0].GetStatus())
+	assert.True(t, listRes.GetUpgrades()[0].HasFinished())
+
+	// The cancelled upgrade no longer blocks a new one.
+	_, err = client.ScheduleClusterUpgrade(authedContext(token, orgID), organizationv1.ScheduleClusterUpgradeRequest_builder{
+		ClusterId:         clusterID,
+		KubernetesVersion: "1.29",
+	}.Build())
+	require.NoError(t, err)
+}
+
+func Test_ClusterUpgrade_Schedule_InvalidVersion(t *testing.T) {
+	t.Parallel()
+
+	orgID := uuid.New()
+	userID := uuid.New()
+
+	env := newTestAPI(t,
+		WithOrganization(orgID, "test-org"),
+		WithUser(&UserArgs{ID: userID, Name: "test-user", OrgIDs: []uuid.UUID{orgID}}),
+	)
+
+	token := env.createAuthnToken(t, userID)
+	client := organizationv1connect.NewClusterServiceClient(env.server.Client(), env.server.URL)
+
+	createRes, err := client.CreateCluster(authedContext(token, orgID), organizationv1.CreateClusterRequest_builder{
+		Name:              "test-cluster",
+		Region:            "eu-west-1",
+		KubernetesVersion: "1.29",
+	}.Build())
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		version string
+	}{
+		{name: "downgrade", version: "1.28"},
+		{name: "same version", version: "1.29"},
+		{name: "skips a minor version", version: "1.31.0"},
+		{name: "not offered in region", version: "1.30"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := client.ScheduleClusterUpgrade(authedContext(token, orgID), organizationv1.ScheduleClusterUpgradeRequest_builder{
+				ClusterId:         createRes.GetClusterId(),
+				KubernetesVersion: tt.version,
+			}.Build())
+
+			var connectErr *connect.Error
+			require.ErrorAs(t, err, &connectErr)
+			assert.Equal(t, connect.CodeInvalidArgument, connectErr.Code())
+		})
+	}
+}
+
+func Test_ClusterUpgrade_Schedule_OrganizationPolicy(t *testing.T) {
+	t.Parallel()
+
+	orgID := uuid.New()
+	userID := uuid.New()
+
+	env := newTestAPI(t,
+		WithOrganization(orgID, "test-org"),
+		WithUser(&UserArgs{ID: userID, Name: "test-user", OrgIDs: []uuid.UUID{orgID}}),
+	)
+
+	token := env.createAuthnToken(t, userID)
+	client := organizationv1connect.NewClusterServiceClient(env.server.Client(), env.server.URL)
+	orgClient := organizationv1connect.NewOrganizationServiceClient(env.server.Client(), env.server.URL)
+
+	createRes, err := client.CreateCluster(authedContext(token, orgID), organizationv1.CreateClusterRequest_builder{
+		Name:              "test-cluster",
+		Region:            "eu-west-1",
+		KubernetesVersion: "1.28",
+	}.Build())
+	require.NoError(t, err)
+
+	_, err = orgClient.UpdateOrganizationPolicy(authedContext(token, orgID), organizationv1.UpdateOrganizationPolicyRequest_builder{
+		Id: orgID.String(),
+		Policy: organizationv1.OrganizationPolicy_builder{
+			DeniedKubernetesVersions: []string{"1.29"},
+		}.Build(),
+	}.Build())
+	require.NoError(t, err)
+
+	upgradeReq := organizationv1.ScheduleClusterUpgradeRequest_builder{
+		ClusterId:         createRes.GetClusterId(),
+		KubernetesVersion: "1.29",
+	}.Build()
+
+	_, err = client.ScheduleClusterUpgrade(authedContext(token, orgID), upgradeReq)
+	var connectErr *connect.Error
+	require.ErrorAs(t, err, &connectErr)
+	require.Equal(t, connect.CodeFailedPrecondition, connectErr.Code())
+	assert.Equal(t, []string{"denied_kubernetes_versions"}, violationRules(t, connectErr))
+
+	evalRes, err := orgClient.EvaluateOrganizationPolicy(authedContext(token, orgID), organizationv1.EvaluateOrganizationPolicyRequest_builder{
+		ScheduleClusterUpgrade: upgradeReq,
+	}.Build())
+	require.NoError(t, err)
+	assert.False(t, evalRes.GetAllowed())
+	require.Len(t, evalRes.GetViolations(), 1)
+	assert.Equal(t, "denied_kubernetes_versions", evalRes.GetViolations()[0].GetRule())
+
+	listRes, err := client.ListClusterUpgrades(authedContext(token, orgID), organizationv1.ListClusterUpgradesRequest_builder{
+		ClusterId: createRes.GetClusterId(),
+	}.Build())
+	require.NoError(t, err)
+	assert.Empty(t, listRes.GetUpgrades(), "a rejected upgrade is not scheduled")
+}
+
+func Test_ClusterUpgrade_Cancel_NotFound(t *testing.T) {
+	t.Parallel()
+
+	orgID := uuid.New()
+	userID := uuid.New()
+
+	env := newTestAPI(t,
+		WithOrganization(orgID, "test-org"),
+		WithUser(&UserArgs{ID: userID, Name: "test-user", OrgIDs: []uuid.UUID{orgID}}),
+	)
+
+	token := env.createAuthnToken(t, userID)
+	client := organizationv1connect.NewClusterServiceClient(env.server.Client(), env.server.URL)
+
+	_, err := client.CancelClusterUpgrade(authedContext(token, orgID), organizationv1.CancelClusterUpgradeRequest_builder{
+		UpgradeId: uuid.New().String(),
+	}.Build())
+
+	var connectErr *connect.Error
+	require.ErrorAs(t, err, &connectErr)
+	assert.Equal(t, connect.CodeNotFound, connectErr.Code())
+}
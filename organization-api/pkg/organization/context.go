@@ -4,10 +4,13 @@ import (
 	"context"
 
 	"github.com/google/uuid"
+
+	"github.com/fundament-oss/fundament/common/auth"
 )
 
 type contextKeyOrganizationID struct{}
 type contextKeyUserID struct{}
+type contextKeyAPIKeyScope struct{}
 
 // WithOrganizationID stores organization_id in context.
 func WithOrganizationID(ctx context.Context, organizationID uuid.UUID) context.Context {
@@ -32,3 +35,16 @@ func UserIDFromContext(ctx context.Context) (uuid.UUID, bool) {
 	userID, ok := ctx.Value(contextKeyUserID{}).(uuid.UUID)
 	return userID, ok
 }
+
+// WithAPIKeyScope stores the scope of a token exchanged for a scoped API key
+// in context.
+func WithAPIKeyScope(ctx context.Context, scope *auth.Scope) context.Context {
+	return context.WithValue(ctx, contextKeyAPIKeyScope{}, scope)
+}
+
+// APIKeyScopeFromContext extracts the API key scope from context.
+// Returns the scope and true if the request was made with a scoped API key.
+func APIKeyScopeFromContext(ctx context.Context) (*auth.Scope, bool) {
+	scope, ok := ctx.Value(contextKeyAPIKeyScope{}).(*auth.Scope)
+	return scope, ok && scope != nil
+}
@@ -2,11 +2,14 @@ package organization
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"connectrpc.com/connect"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 
+	"github.com/fundament-oss/fundament/common/audit"
 	db "github.com/fundament-oss/fundament/organization-api/pkg/db/gen"
 	organizationv1 "github.com/fundament-oss/fundament/organization-api/pkg/proto/gen/v1"
 )
@@ -17,14 +20,17 @@ func (s *Server) AcceptInvitation(
 ) (*organizationv1.AcceptInvitationResponse, error) {
 	id := uuid.MustParse(req.GetId())
 
-	rows, err := s.queries.InviteAccept(ctx, db.InviteAcceptParams{ID: id})
+	organizationID, err := s.queries.InviteAccept(ctx, db.InviteAcceptParams{ID: id})
 	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("no pending invitation found"))
+		}
 		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to accept invitation: %w", err))
 	}
 
-	if rows == 0 {
-		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("no pending invitation found"))
-	}
+	// The request carries no organization header; attribute the event to
+	// the organization the invitation was for.
+	audit.SetOrganizationID(ctx, organizationID)
 
 	return organizationv1.AcceptInvitationResponse_builder{}.Build(), nil
 }
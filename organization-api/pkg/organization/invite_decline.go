@@ -2,11 +2,14 @@ package organization
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"connectrpc.com/connect"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 
+	"github.com/fundament-oss/fundament/common/audit"
 	db "github.com/fundament-oss/fundament/organization-api/pkg/db/gen"
 	organizationv1 "github.com/fundament-oss/fundament/organization-api/pkg/proto/gen/v1"
 )
@@ -17,14 +20,16 @@ func (s *Server) DeclineInvitation(
 ) (*organizationv1.DeclineInvitationResponse, error) {
 	id := uuid.MustParse(req.GetId())
 
-	rows, err := s.queries.InviteDecline(ctx, db.InviteDeclineParams{ID: id})
+	organizationID, err := s.queries.InviteDecline(ctx, db.InviteDeclineParams{ID: id})
 	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("no pending invitation found"))
+		}
 		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to decline invitation: %w", err))
 	}
 
-	if rows == 0 {
-		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("no pending invitation found"))
-	}
+	// Declining is audited against the inviting organization.
+	audit.SetOrganizationID(ctx, organizationID)
 
 	return organizationv1.DeclineInvitationResponse_builder{}.Build(), nil
 }
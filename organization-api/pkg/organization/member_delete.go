@@ -45,5 +45,11 @@ func (s *Server) DeleteMember(
 		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to delete member: %w", err))
 	}
 
+	// An elevated role is granted to the user, not the membership; it must
+	// not outlive it.
+	if err = s.queries.AccessElevationEndByUser(ctx, db.AccessElevationEndByUserParams{UserID: member.UserID}); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to end access elevations: %w", err))
+	}
+
 	return organizationv1.DeleteMemberResponse_builder{}.Build(), nil
 }
@@ -25,18 +25,34 @@ func (s *Server) ListMembers(
 		return nil, err
 	}
 
-	members, err := s.queries.MemberList(ctx)
+	cursor, err := decodePageToken(req.GetPageToken())
+	if err != nil {
+		return nil, err
+	}
+	afterCreated, afterID := createdCursorParams(cursor)
+
+	members, err := s.queries.MemberList(ctx, db.MemberListParams{
+		NameFilter:   nameFilter(req.GetNameFilter()),
+		AfterCreated: afterCreated,
+		AfterID:      afterID,
+		PageLimit:    pageLimit(req.GetPageSize()),
+	})
 	if err != nil {
 		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to list members: %w", err))
 	}
 
+	members, nextPageToken := trimPage(members, req.GetPageSize(), func(row *db.MemberListRow) pageCursor {
+		return pageCursor{Created: row.Created.Time, ID: row.ID}
+	})
+
 	result := make([]*organizationv1.Member, 0, len(members))
 	for i := range members {
 		result = append(result, memberFromListRow(&members[i]))
 	}
 
 	return organizationv1.ListMembersResponse_builder{
-		Members: result,
+		Members:       result,
+		NextPageToken: nextPageToken,
 	}.Build(), nil
 }
 
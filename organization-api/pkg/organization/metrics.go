@@ -278,7 +278,7 @@ func (s *Server) GetOrgWorkloadMetrics(
 	ctx context.Context,
 	_ *organizationv1.GetOrgWorkloadMetricsRequest,
 ) (*organizationv1.GetOrgWorkloadMetricsResponse, error) {
-	clusters, err := s.queries.ClusterList(ctx)
+	clusters, err := s.queries.ClusterList(ctx, db.ClusterListParams{})
 	if err != nil {
 		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("list clusters: %w", err))
 	}
@@ -445,7 +445,7 @@ func (s *Server) GetOrgWorkloadTimeSeries(
 	ctx context.Context,
 	req *organizationv1.GetOrgWorkloadTimeSeriesRequest,
 ) (*organizationv1.GetWorkloadTimeSeriesResponse, error) {
-	clusters, err := s.queries.ClusterList(ctx)
+	clusters, err := s.queries.ClusterList(ctx, db.ClusterListParams{})
 	if err != nil {
 		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("list clusters: %w", err))
 	}
@@ -0,0 +1,176 @@
+package organization
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/fundament-oss/fundament/common/authz"
+	"github.com/fundament-oss/fundament/common/kubename"
+	db "github.com/fundament-oss/fundament/organization-api/pkg/db/gen"
+	prom "github.com/fundament-oss/fundament/organization-api/pkg/prometheus"
+	organizationv1 "github.com/fundament-oss/fundament/organization-api/pkg/proto/gen/v1"
+)
+
+// quotaResourceQuotaName is the ResourceQuota the cluster-worker maintains in
+// every namespace with a quota (shoot.ResourceQuotaName).
+const quotaResourceQuotaName = "fundament-quota"
+
+// quotaResource describes one ResourceQuota resource: hardScale converts the
+// stored limit and usedScale the kube-state-metrics value into unit.
+type quotaResource struct {
+	resource  string
+	unit      string
+	hardScale float64
+	usedScale float64
+}
+
+// quotaResources is in the order of the hard limits returned by quotaHard.
+var quotaResources = []quotaResource{
+	{resource: "requests.cpu", unit: "cores", hardScale: 1.0 / 1000, usedScale: 1},
+	{resource: "limits.cpu", unit: "cores", hardScale: 1.0 / 1000, usedScale: 1},
+	{resource: "requests.memory", unit: "GiB", hardScale: 1.0 / 1024, usedScale: 1 / bytesPerGiB},
+	{resource: "limits.memory", unit: "GiB", hardScale: 1.0 / 1024, usedScale: 1 / bytesPerGiB},
+	{resource: "requests.storage", unit: "GiB", hardScale: 1, usedScale: 1 / bytesPerGiB},
+	{resource: "pods", unit: "pods", hardScale: 1, usedScale: 1},
+	{resource: "persistentvolumeclaims", unit: "claims", hardScale: 1, usedScale: 1},
+	{resource: "services", unit: "services", hardScale: 1, usedScale: 1},
+}
+
+func (s *Server) GetProjectQuotaUsage(
+	ctx context.Context,
+	req *organizationv1.GetProjectQuotaUsageRequest,
+) (*organizationv1.GetProjectQuotaUsageResponse, error) {
+	projectID := uuid.MustParse(req.GetProjectId())
+
+	if err := s.checkPermission(ctx, authz.CanView(), authz.Project(projectID)); err != nil {
+		return nil, err
+	}
+
+	rows, err := s.queries.NamespaceQuotaListByProjectID(ctx, db.NamespaceQuotaListByProjectIDParams{ProjectID: projectID})
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("list namespace quotas: %w", err))
+	}
+
+	// Only namespaces with a quota have a ResourceQuota to query.
+	var names []string
+	for i := range rows {
+		if hasQuota(quotaHard(&rows[i])) {
+			names = append(names, kubename.GenerateNamespace(rows[i].ProjectName, projectID, rows[i].Name))
+		}
+	}
+	if len(names) == 0 {
+		return organizationv1.GetProjectQuotaUsageResponse_builder{
+			Namespaces: buildNamespaceQuotaUsage(projectID, rows, nil),
+		}.Build(), nil
+	}
+
+	// As in GetProjectWorkloadMetrics, a project lives on a single cluster.
+	clusterID := rows[0].ClusterID
+
+	client, err := s.promClientFor(ctx, clusterID)
+	if err != nil {
+		s.logPromUnavailable(ctx, clusterID, err)
+		return organizationv1.GetProjectQuotaUsageResponse_builder{
+			Namespaces:         buildNamespaceQuotaUsage(projectID, rows, nil),
+			MetricsUnavailable: true,
+		}.Build(), nil
+	}
+
+	used, err := client.Query(ctx, fmt.Sprintf(
+		`sum(kube_resourcequota{resourcequota=%q,type="used",%s}) by (namespace, resource)`,
+		quotaResourceQuotaName, buildNamespaceFilter(names),
+	), time.Now())
+	if err != nil {
+		if isPromBadQuery(err) {
+			return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("project quota usage: %w", err))
+		}
+		s.logger.WarnContext(ctx, "project quota usage query failed", "project_id", projectID, "cluster_id", clusterID, "error", err)
+		return organizationv1.GetProjectQuotaUsageResponse_builder{
+			Namespaces:         buildNamespaceQuotaUsage(projectID, rows, nil),
+			MetricsUnavailable: true,
+		}.Build(), nil
+	}
+
+	return organizationv1.GetProjectQuotaUsageResponse_builder{
+		Namespaces: buildNamespaceQuotaUsage(projectID, rows, used),
+	}.Build(), nil
+}
+
+// buildNamespaceQuotaUsage reports, per namespace, every resource with a hard
+// limit. used holds kube_resourcequota samples labelled with the cluster-side
+// namespace name and the resource; resources without a sample report zero.
+func buildNamespaceQuotaUsage(
+	projectID uuid.UUID, rows []db.NamespaceQuotaListByProjectIDRow, used []prom.Sample,
+) []*organizationv1.NamespaceQuotaUsage {
+	usedByNamespace := make(map[string]map[string]float64)
+	for _, s := range used {
+		ns := s.Labels["namespace"]
+		if usedByNamespace[ns] == nil {
+			usedByNamespace[ns] = make(map[string]float64)
+		}
+		usedByNamespace[ns][s.Labels["resource"]] = s.Value
+	}
+
+	result := make([]*organizationv1.NamespaceQuotaUsage, 0, len(rows))
+	for i := range rows {
+		row := &rows[i]
+		nsUsed := usedByNamespace[kubename.GenerateNamespace(row.ProjectName, projectID, row.Name)]
+
+		var resources []*organizationv1.QuotaResourceUsage
+		for j, hard := range quotaHard(row) {
+			if !hard.Valid {
+				continue
+			}
+			r := quotaResources[j]
+			resources = append(resources, organizationv1.QuotaResourceUsage_builder{
+				Resource: r.resource,
+				Usage:    makeResourceUsage(nsUsed[r.resource]*r.usedScale, float64(hard.Int32)*r.hardScale, r.unit),
+			}.Build())
+		}
+
+		result = append(result, organizationv1.NamespaceQuotaUsage_builder{
+			NamespaceId: row.ID.String(),
+			Namespace:   row.Name,
+			Resources:   resources,
+		}.Build())
+	}
+	return result
+}
+
+// quotaHard merges the project quota with the namespace override into the hard
+// limits of the namespace's ResourceQuota, in quotaResources order. The lower
+// value wins, as in the cluster-worker.
+func quotaHard(row *db.NamespaceQuotaListByProjectIDRow) []pgtype.Int4 {
+	return []pgtype.Int4{
+		leastInt4(row.ProjectQuotaCpuRequestM, row.NamespaceQuotaCpuRequestM),
+		leastInt4(row.ProjectQuotaCpuLimitM, row.NamespaceQuotaCpuLimitM),
+		leastInt4(row.ProjectQuotaMemoryRequestMi, row.NamespaceQuotaMemoryRequestMi),
+		leastInt4(row.ProjectQuotaMemoryLimitMi, row.NamespaceQuotaMemoryLimitMi),
+		leastInt4(row.ProjectQuotaStorageGi, row.NamespaceQuotaStorageGi),
+		leastInt4(row.ProjectQuotaPods, row.NamespaceQuotaPods),
+		leastInt4(row.ProjectQuotaPersistentVolumeClaims, row.NamespaceQuotaPersistentVolumeClaims),
+		leastInt4(row.ProjectQuotaServices, row.NamespaceQuotaServices),
+	}
+}
+
+func hasQuota(hard []pgtype.Int4) bool {
+	for _, h := range hard {
+		if h.Valid {
+			return true
+		}
+	}
+	return false
+}
+
+// leastInt4 returns the lower of two nullable values, ignoring NULLs.
+func leastInt4(a, b pgtype.Int4) pgtype.Int4 {
+	if !a.Valid || (b.Valid && b.Int32 < a.Int32) {
+		return b
+	}
+	return a
+}
@@ -4,6 +4,11 @@ import (
 	"testing"
 	"time"
 
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/fundament-oss/fundament/common/kubename"
+	db "github.com/fundament-oss/fundament/organization-api/pkg/db/gen"
 	prom "github.com/fundament-oss/fundament/organization-api/pkg/prometheus"
 )
 
@@ -196,3 +201,55 @@ func TestBuildNamespaceMetrics(t *testing.T) {
 		t.Errorf("pods = %v, want 5", r.GetPods())
 	}
 }
+
+// ---- buildNamespaceQuotaUsage ----
+
+func TestBuildNamespaceQuotaUsage(t *testing.T) {
+	projectID := uuid.New()
+	rows := []db.NamespaceQuotaListByProjectIDRow{
+		{
+			// The override tightens the project's CPU request quota; the
+			// project's pod quota applies as is.
+			ID:                        uuid.New(),
+			Name:                      "api",
+			ProjectName:               "shop",
+			ProjectQuotaCpuRequestM:   pgtype.Int4{Int32: 2000, Valid: true},
+			ProjectQuotaPods:          pgtype.Int4{Int32: 20, Valid: true},
+			NamespaceQuotaCpuRequestM: pgtype.Int4{Int32: 500, Valid: true},
+			NamespaceQuotaStorageGi:   pgtype.Int4{Int32: 10, Valid: true},
+		},
+		{ID: uuid.New(), Name: "scratch", ProjectName: "shop"},
+	}
+	api := kubename.GenerateNamespace("shop", projectID, "api")
+	used := []prom.Sample{
+		{Labels: map[string]string{"namespace": api, "resource": "requests.cpu"}, Value: 0.25},
+		{Labels: map[string]string{"namespace": api, "resource": "requests.storage"}, Value: 5 * bytesPerGiB},
+		{Labels: map[string]string{"namespace": api, "resource": "services"}, Value: 3},
+	}
+
+	result := buildNamespaceQuotaUsage(projectID, rows, used)
+	if len(result) != 2 {
+		t.Fatalf("expected 2 namespaces, got %d", len(result))
+	}
+	if n := len(result[1].GetResources()); n != 0 {
+		t.Errorf("scratch has %d quota resources, want 0", n)
+	}
+
+	got := map[string][2]float64{}
+	for _, r := range result[0].GetResources() {
+		got[r.GetResource()] = [2]float64{r.GetUsage().GetUsed(), r.GetUsage().GetTotal()}
+	}
+	want := map[string][2]float64{
+		"requests.cpu":     {0.25, 0.5},
+		"requests.storage": {5, 10},
+		"pods":             {0, 20},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("resources = %v, want %v", got, want)
+	}
+	for resource, w := range want {
+		if got[resource] != w {
+			t.Errorf("%s = %v, want %v", resource, got[resource], w)
+		}
+	}
+}
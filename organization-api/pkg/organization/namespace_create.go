@@ -13,6 +13,7 @@ import (
 	"github.com/fundament-oss/fundament/common/authz"
 	"github.com/fundament-oss/fundament/common/dbconst"
 	"github.com/fundament-oss/fundament/common/kubename"
+	"github.com/fundament-oss/fundament/common/rollback"
 	db "github.com/fundament-oss/fundament/organization-api/pkg/db/gen"
 	organizationv1 "github.com/fundament-oss/fundament/organization-api/pkg/proto/gen/v1"
 )
@@ -23,6 +24,11 @@ func (s *Server) CreateNamespace(
 ) (*organizationv1.CreateNamespaceResponse, error) {
 	projectID := uuid.MustParse(req.GetProjectId())
 
+	organizationID, ok := OrganizationIDFromContext(ctx)
+	if !ok {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("organization_id missing from context"))
+	}
+
 	// Retry: a namespace is often created right after its project, before the
 	// project's authz tuple has synced to OpenFGA (see checkPermissionWithRetry).
 	if err := s.checkPermissionWithRetry(ctx, authz.CanCreateNamespace(), authz.Project(projectID)); err != nil {
@@ -41,7 +47,19 @@ func (s *Server) CreateNamespace(
 		Name:      req.GetName(),
 	}
 
-	namespaceID, err := s.queries.NamespaceCreate(ctx, params)
+	tx, err := s.db.Pool.Begin(ctx)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to begin transaction: %w", err))
+	}
+	defer rollback.Rollback(ctx, tx, s.logger)
+
+	qtx := s.queries.WithTx(tx)
+
+	if err := enforceOrganizationCap(ctx, qtx, organizationID, capNamespaces); err != nil {
+		return nil, err
+	}
+
+	namespaceID, err := qtx.NamespaceCreate(ctx, params)
 	if err != nil {
 		if pgErr, ok := errors.AsType[*pgconn.PgError](err); ok {
 			if pgErr.Code == pgerrcode.UniqueViolation && pgErr.ConstraintName == dbconst.ConstraintNamespacesUqName {
@@ -52,6 +70,10 @@ func (s *Server) CreateNamespace(
 		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to create namespace: %w", err))
 	}
 
+	if err := tx.Commit(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to commit transaction: %w", err))
+	}
+
 	s.logger.InfoContext(ctx, "namespace created",
 		"namespace_id", namespaceID,
 		"project_id", projectID,
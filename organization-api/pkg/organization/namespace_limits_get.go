@@ -0,0 +1,85 @@
+package organization
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"connectrpc.com/connect"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/fundament-oss/fundament/common/authz"
+	db "github.com/fundament-oss/fundament/organization-api/pkg/db/gen"
+	organizationv1 "github.com/fundament-oss/fundament/organization-api/pkg/proto/gen/v1"
+)
+
+func (s *Server) GetNamespaceLimits(
+	ctx context.Context,
+	req *organizationv1.GetNamespaceLimitsRequest,
+) (*organizationv1.GetNamespaceLimitsResponse, error) {
+	namespaceID := uuid.MustParse(req.GetNamespaceId())
+
+	if err := s.checkPermission(ctx, authz.CanView(), authz.Namespace(namespaceID)); err != nil {
+		return nil, err
+	}
+
+	if err := s.requireNamespace(ctx, namespaceID); err != nil {
+		return nil, err
+	}
+
+	row, err := s.queries.NamespaceLimitsGet(ctx, db.NamespaceLimitsGetParams{NamespaceID: namespaceID})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return organizationv1.GetNamespaceLimitsResponse_builder{
+				Limits: organizationv1.NamespaceLimits_builder{}.Build(),
+			}.Build(), nil
+		}
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to get namespace limits: %w", err))
+	}
+
+	return organizationv1.GetNamespaceLimitsResponse_builder{
+		Limits: namespaceLimitsFromRow(&row),
+	}.Build(), nil
+}
+
+// requireNamespace returns NotFound when the namespace does not exist or has
+// been deleted; the authz tuples of a deleted namespace outlive the row.
+func (s *Server) requireNamespace(ctx context.Context, namespaceID uuid.UUID) error {
+	if _, err := s.queries.NamespaceGetByID(ctx, db.NamespaceGetByIDParams{ID: namespaceID}); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return connect.NewError(connect.CodeNotFound, fmt.Errorf("namespace not found"))
+		}
+		return connect.NewError(connect.CodeInternal, fmt.Errorf("failed to get namespace: %w", err))
+	}
+	return nil
+}
+
+func namespaceLimitsFromRow(row *db.NamespaceLimitsGetRow) *organizationv1.NamespaceLimits {
+	limits := organizationv1.NamespaceLimits_builder{}.Build()
+	if row.QuotaCpuRequestM.Valid {
+		limits.SetQuotaCpuRequestM(row.QuotaCpuRequestM.Int32)
+	}
+	if row.QuotaCpuLimitM.Valid {
+		limits.SetQuotaCpuLimitM(row.QuotaCpuLimitM.Int32)
+	}
+	if row.QuotaMemoryRequestMi.Valid {
+		limits.SetQuotaMemoryRequestMi(row.QuotaMemoryRequestMi.Int32)
+	}
+	if row.QuotaMemoryLimitMi.Valid {
+		limits.SetQuotaMemoryLimitMi(row.QuotaMemoryLimitMi.Int32)
+	}
+	if row.QuotaStorageGi.Valid {
+		limits.SetQuotaStorageGi(row.QuotaStorageGi.Int32)
+	}
+	if row.QuotaPods.Valid {
+		limits.SetQuotaPods(row.QuotaPods.Int32)
+	}
+	if row.QuotaPersistentVolumeClaims.Valid {
+		limits.SetQuotaPersistentVolumeClaims(row.QuotaPersistentVolumeClaims.Int32)
+	}
+	if row.QuotaServices.Valid {
+		limits.SetQuotaServices(row.QuotaServices.Int32)
+	}
+	return limits
+}
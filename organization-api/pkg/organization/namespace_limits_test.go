@@ -0,0 +1,153 @@
+package organization_test
+
+import (
+	"context"
+	"testing"
+
+	"connectrpc.com/connect"
+	organizationv1 "github.com/fundament-oss/fundament/organization-api/pkg/proto/gen/v1"
+	"github.com/fundament-oss/fundament/organization-api/pkg/proto/gen/v1/organizationv1connect"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func Test_NamespaceLimits_Unauthenticated(t *testing.T) {
+	t.Parallel()
+
+	env := newTestAPI(t)
+	client := organizationv1connect.NewNamespaceServiceClient(env.server.Client(), env.server.URL)
+
+	_, err := client.UpdateNamespaceLimits(context.Background(),
+		organizationv1.UpdateNamespaceLimitsRequest_builder{NamespaceId: uuid.New().String()}.Build(),
+	)
+
+	var connectErr *connect.Error
+	require.ErrorAs(t, err, &connectErr)
+	assert.Equal(t, connect.CodeUnauthenticated, connectErr.Code())
+}
+
+func Test_NamespaceLimits_Update(t *testing.T) {
+	t.Parallel()
+
+	orgID := uuid.New()
+	userID := uuid.New()
+
+	env := newTestAPI(t,
+		WithOrganization(orgID, "test-org"),
+		WithUser(&UserArgs{ID: userID, Name: "test-user", OrgIDs: []uuid.UUID{orgID}}),
+	)
+
+	token := env.createAuthnToken(t, userID)
+	clusterClient := organizationv1connect.NewClusterServiceClient(env.server.Client(), env.server.URL)
+	projectClient := organizationv1connect.NewProjectServiceClient(env.server.Client(), env.server.URL)
+	client := organizationv1connect.NewNamespaceServiceClient(env.server.Client(), env.server.URL)
+
+	clusterRes, err := clusterClient.CreateCluster(authedContext(token, orgID), organizationv1.CreateClusterRequest_builder{
+		Name:              "test-cluster",
+		Region:            "eu-west-1",
+		KubernetesVersion: "1.28",
+	}.Build())
+	require.NoError(t, err)
+
+	projectRes, err := projectClient.CreateProject(authedContext(token, orgID), organizationv1.CreateProjectRequest_builder{
+		ClusterId: clusterRes.GetClusterId(),
+		Name:      "test-project",
+	}.Build())
+	require.NoError(t, err)
+
+	nsRes, err := client.CreateNamespace(authedContext(token, orgID), organizationv1.CreateNamespaceRequest_builder{
+		ProjectId: projectRes.GetProjectId(),
+		Name:      "web",
+	}.Build())
+	require.NoError(t, err)
+	namespaceID := nsRes.GetNamespaceId()
+
+	// A namespace without overrides reports none.
+	getRes, err := client.GetNamespaceLimits(authedContext(token, orgID), organizationv1.GetNamespaceLimitsRequest_builder{
+		NamespaceId: namespaceID,
+	}.Build())
+	require.NoError(t, err)
+	assert.False(t, getRes.GetLimits().HasQuotaPods())
+
+	_, err = client.UpdateNamespaceLimits(authedContext(token, orgID), organizationv1.UpdateNamespaceLimitsRequest_builder{
+		NamespaceId:      namespaceID,
+		QuotaCpuRequestM: proto.Int32(1000),
+		QuotaCpuLimitM:   proto.Int32(2000),
+		QuotaPods:        proto.Int32(10),
+	}.Build())
+	require.NoError(t, err)
+
+	getRes, err = client.GetNamespaceLimits(authedContext(token, orgID), organizationv1.GetNamespaceLimitsRequest_builder{
+		NamespaceId: namespaceID,
+	}.Build())
+	require.NoError(t, err)
+	limits := getRes.GetLimits()
+	assert.EqualValues(t, 1000, limits.GetQuotaCpuRequestM())
+	assert.EqualValues(t, 2000, limits.GetQuotaCpuLimitM())
+	assert.EqualValues(t, 10, limits.GetQuotaPods())
+	assert.False(t, limits.HasQuotaMemoryLimitMi())
+
+	// Every field is replaced: pods is cleared by the second update.
+	_, err = client.UpdateNamespaceLimits(authedContext(token, orgID), organizationv1.UpdateNamespaceLimitsRequest_builder{
+		NamespaceId:    namespaceID,
+		QuotaServices:  proto.Int32(5),
+		QuotaCpuLimitM: proto.Int32(2000),
+	}.Build())
+	require.NoError(t, err)
+
+	getRes, err = client.GetNamespaceLimits(authedContext(token, orgID), organizationv1.GetNamespaceLimitsRequest_builder{
+		NamespaceId: namespaceID,
+	}.Build())
+	require.NoError(t, err)
+	assert.EqualValues(t, 5, getRes.GetLimits().GetQuotaServices())
+	assert.False(t, getRes.GetLimits().HasQuotaPods())
+	assert.False(t, getRes.GetLimits().HasQuotaCpuRequestM())
+}
+
+func Test_NamespaceLimits_Update_QuotaMemoryLimitLessThanRequest(t *testing.T) {
+	t.Parallel()
+
+	orgID := uuid.New()
+	userID := uuid.New()
+
+	env := newTestAPI(t,
+		WithOrganization(orgID, "test-org"),
+		WithUser(&UserArgs{ID: userID, Name: "test-user", OrgIDs: []uuid.UUID{orgID}}),
+	)
+
+	token := env.createAuthnToken(t, userID)
+	clusterClient := organizationv1connect.NewClusterServiceClient(env.server.Client(), env.server.URL)
+	projectClient := organizationv1connect.NewProjectServiceClient(env.server.Client(), env.server.URL)
+	client := organizationv1connect.NewNamespaceServiceClient(env.server.Client(), env.server.URL)
+
+	clusterRes, err := clusterClient.CreateCluster(authedContext(token, orgID), organizationv1.CreateClusterRequest_builder{
+		Name:              "test-cluster",
+		Region:            "eu-west-1",
+		KubernetesVersion: "1.28",
+	}.Build())
+	require.NoError(t, err)
+
+	projectRes, err := projectClient.CreateProject(authedContext(token, orgID), organizationv1.CreateProjectRequest_builder{
+		ClusterId: clusterRes.GetClusterId(),
+		Name:      "test-project",
+	}.Build())
+	require.NoError(t, err)
+
+	nsRes, err := client.CreateNamespace(authedContext(token, orgID), organizationv1.CreateNamespaceRequest_builder{
+		ProjectId: projectRes.GetProjectId(),
+		Name:      "web",
+	}.Build())
+	require.NoError(t, err)
+
+	_, err = client.UpdateNamespaceLimits(authedContext(token, orgID), organizationv1.UpdateNamespaceLimitsRequest_builder{
+		NamespaceId:          nsRes.GetNamespaceId(),
+		QuotaMemoryRequestMi: proto.Int32(2048),
+		QuotaMemoryLimitMi:   proto.Int32(1024),
+	}.Build())
+
+	var connectErr *connect.Error
+	require.ErrorAs(t, err, &connectErr)
+	assert.Equal(t, connect.CodeInvalidArgument, connectErr.Code())
+}
@@ -0,0 +1,61 @@
+package organization
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"connectrpc.com/connect"
+	"github.com/google/uuid"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/fundament-oss/fundament/common/authz"
+	"github.com/fundament-oss/fundament/common/dbconst"
+	db "github.com/fundament-oss/fundament/organization-api/pkg/db/gen"
+	organizationv1 "github.com/fundament-oss/fundament/organization-api/pkg/proto/gen/v1"
+)
+
+func (s *Server) UpdateNamespaceLimits(
+	ctx context.Context,
+	req *organizationv1.UpdateNamespaceLimitsRequest,
+) (*organizationv1.UpdateNamespaceLimitsResponse, error) {
+	namespaceID := uuid.MustParse(req.GetNamespaceId())
+
+	if err := s.checkPermission(ctx, authz.CanEdit(), authz.Namespace(namespaceID)); err != nil {
+		return nil, err
+	}
+
+	if err := s.requireNamespace(ctx, namespaceID); err != nil {
+		return nil, err
+	}
+
+	params := db.NamespaceLimitsUpsertParams{
+		NamespaceID:                 namespaceID,
+		QuotaCpuRequestM:            pgtype.Int4{Int32: req.GetQuotaCpuRequestM(), Valid: req.HasQuotaCpuRequestM()},
+		QuotaCpuLimitM:              pgtype.Int4{Int32: req.GetQuotaCpuLimitM(), Valid: req.HasQuotaCpuLimitM()},
+		QuotaMemoryRequestMi:        pgtype.Int4{Int32: req.GetQuotaMemoryRequestMi(), Valid: req.HasQuotaMemoryRequestMi()},
+		QuotaMemoryLimitMi:          pgtype.Int4{Int32: req.GetQuotaMemoryLimitMi(), Valid: req.HasQuotaMemoryLimitMi()},
+		QuotaStorageGi:              pgtype.Int4{Int32: req.GetQuotaStorageGi(), Valid: req.HasQuotaStorageGi()},
+		QuotaPods:                   pgtype.Int4{Int32: req.GetQuotaPods(), Valid: req.HasQuotaPods()},
+		QuotaPersistentVolumeClaims: pgtype.Int4{Int32: req.GetQuotaPersistentVolumeClaims(), Valid: req.HasQuotaPersistentVolumeClaims()},
+		QuotaServices:               pgtype.Int4{Int32: req.GetQuotaServices(), Valid: req.HasQuotaServices()},
+	}
+
+	if err := s.queries.NamespaceLimitsUpsert(ctx, params); err != nil {
+		if pgErr, ok := errors.AsType[*pgconn.PgError](err); ok && pgErr.Code == pgerrcode.CheckViolation {
+			switch pgErr.ConstraintName {
+			case dbconst.ConstraintNamespaceLimitsCkQuotaMemoryLimitGteRequest:
+				return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("memory quota limit must be greater than or equal to memory quota request"))
+			case dbconst.ConstraintNamespaceLimitsCkQuotaCpuLimitGteRequest:
+				return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("CPU quota limit must be greater than or equal to CPU quota request"))
+			}
+		}
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to update namespace limits: %w", err))
+	}
+
+	s.logger.InfoContext(ctx, "namespace limits updated", "namespace_id", namespaceID)
+
+	return organizationv1.UpdateNamespaceLimitsResponse_builder{}.Build(), nil
+}
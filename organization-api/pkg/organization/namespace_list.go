@@ -28,6 +28,13 @@ func (s *Server) ListClusterNamespaces(
 		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to list namespaces: %w", err))
 	}
 
+	namespaces, err = filterAllowed(namespaces, func(row *db.NamespaceListByClusterIDRow) authz.Object {
+		return authz.Namespace(row.ID)
+	}, s.canView(ctx))
+	if err != nil {
+		return nil, err
+	}
+
 	result := make([]*organizationv1.Namespace, 0, len(namespaces))
 	for i := range namespaces {
 		result = append(result, namespaceFromRow(namespaces[i]))
@@ -53,6 +60,13 @@ func (s *Server) ListProjectNamespaces(
 		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to list namespaces: %w", err))
 	}
 
+	namespaces, err = filterAllowed(namespaces, func(row *db.NamespaceListByProjectIDRow) authz.Object {
+		return authz.Namespace(row.ID)
+	}, s.canView(ctx))
+	if err != nil {
+		return nil, err
+	}
+
 	result := make([]*organizationv1.Namespace, 0, len(namespaces))
 	for i := range namespaces {
 		result = append(result, namespaceFromRow((db.NamespaceListByClusterIDRow)(namespaces[i])))
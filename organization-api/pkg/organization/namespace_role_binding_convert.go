@@ -0,0 +1,52 @@
+package organization
+
+import (
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/fundament-oss/fundament/common/dbconst"
+	db "github.com/fundament-oss/fundament/organization-api/pkg/db/gen"
+	organizationv1 "github.com/fundament-oss/fundament/organization-api/pkg/proto/gen/v1"
+)
+
+func namespaceRoleFromDB(role dbconst.NamespaceRoleBindingRole) organizationv1.NamespaceRole {
+	switch role {
+	case dbconst.NamespaceRoleBindingRole_Deploy:
+		return organizationv1.NamespaceRole_NAMESPACE_ROLE_DEPLOY
+	case dbconst.NamespaceRoleBindingRole_ViewPods:
+		return organizationv1.NamespaceRole_NAMESPACE_ROLE_VIEW_PODS
+	case dbconst.NamespaceRoleBindingRole_ViewLogs:
+		return organizationv1.NamespaceRole_NAMESPACE_ROLE_VIEW_LOGS
+	case dbconst.NamespaceRoleBindingRole_ManageServices:
+		return organizationv1.NamespaceRole_NAMESPACE_ROLE_MANAGE_SERVICES
+	default:
+		panic("unknown dbconst namespace role binding role")
+	}
+}
+
+func namespaceRoleToDB(role organizationv1.NamespaceRole) dbconst.NamespaceRoleBindingRole {
+	switch role {
+	case organizationv1.NamespaceRole_NAMESPACE_ROLE_DEPLOY:
+		return dbconst.NamespaceRoleBindingRole_Deploy
+	case organizationv1.NamespaceRole_NAMESPACE_ROLE_VIEW_PODS:
+		return dbconst.NamespaceRoleBindingRole_ViewPods
+	case organizationv1.NamespaceRole_NAMESPACE_ROLE_VIEW_LOGS:
+		return dbconst.NamespaceRoleBindingRole_ViewLogs
+	case organizationv1.NamespaceRole_NAMESPACE_ROLE_MANAGE_SERVICES:
+		return dbconst.NamespaceRoleBindingRole_ManageServices
+	default:
+		panic("unknown proto namespace role")
+	}
+}
+
+func namespaceRoleBindingFromListRow(row *db.NamespaceRoleBindingListRow) *organizationv1.NamespaceRoleBinding {
+	return organizationv1.NamespaceRoleBinding_builder{
+		Id:               row.ID.String(),
+		ProjectId:        row.ProjectID.String(),
+		MemberId:         row.ProjectMemberID.String(),
+		UserId:           row.UserID.String(),
+		UserName:         row.UserName,
+		NamespacePattern: row.NamespacePattern,
+		Role:             namespaceRoleFromDB(row.Role),
+		Created:          timestamppb.New(row.Created.Time),
+	}.Build()
+}
@@ -0,0 +1,67 @@
+package organization
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"connectrpc.com/connect"
+	"github.com/google/uuid"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/fundament-oss/fundament/common/authz"
+	"github.com/fundament-oss/fundament/common/dbconst"
+	db "github.com/fundament-oss/fundament/organization-api/pkg/db/gen"
+	organizationv1 "github.com/fundament-oss/fundament/organization-api/pkg/proto/gen/v1"
+)
+
+func (s *Server) CreateNamespaceRoleBinding(
+	ctx context.Context,
+	req *organizationv1.CreateNamespaceRoleBindingRequest,
+) (*organizationv1.CreateNamespaceRoleBindingResponse, error) {
+	memberID := uuid.MustParse(req.GetMemberId())
+	role := namespaceRoleToDB(req.GetRole())
+
+	member, err := s.queries.ProjectMemberGetByID(ctx, db.ProjectMemberGetByIDParams{ID: memberID})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("project member not found"))
+		}
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to get project member: %w", err))
+	}
+
+	if err := s.checkPermission(ctx, authz.CanManageMembers(), authz.Project(member.ProjectID)); err != nil {
+		return nil, err
+	}
+
+	bindingID, err := s.queries.NamespaceRoleBindingCreate(ctx, db.NamespaceRoleBindingCreateParams{
+		ProjectID:        member.ProjectID,
+		ProjectMemberID:  memberID,
+		NamespacePattern: req.GetNamespacePattern(),
+		Role:             role,
+	})
+	if err != nil {
+		if pgErr, ok := errors.AsType[*pgconn.PgError](err); ok {
+			if pgErr.Code == pgerrcode.UniqueViolation && pgErr.ConstraintName == dbconst.ConstraintNamespaceRoleBindingsUqBinding {
+				return nil, connect.NewError(connect.CodeAlreadyExists,
+					fmt.Errorf("member already has this role for this namespace pattern"))
+			}
+		}
+
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to create namespace role binding: %w", err))
+	}
+
+	s.logger.InfoContext(ctx, "namespace role binding created",
+		"binding_id", bindingID,
+		"project_id", member.ProjectID,
+		"member_id", memberID,
+		"namespace_pattern", req.GetNamespacePattern(),
+		"role", role,
+	)
+
+	return organizationv1.CreateNamespaceRoleBindingResponse_builder{
+		BindingId: bindingID.String(),
+	}.Build(), nil
+}
@@ -0,0 +1,47 @@
+package organization
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"connectrpc.com/connect"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/fundament-oss/fundament/common/authz"
+	db "github.com/fundament-oss/fundament/organization-api/pkg/db/gen"
+	organizationv1 "github.com/fundament-oss/fundament/organization-api/pkg/proto/gen/v1"
+)
+
+func (s *Server) DeleteNamespaceRoleBinding(
+	ctx context.Context,
+	req *organizationv1.DeleteNamespaceRoleBindingRequest,
+) (*organizationv1.DeleteNamespaceRoleBindingResponse, error) {
+	bindingID := uuid.MustParse(req.GetBindingId())
+
+	binding, err := s.queries.NamespaceRoleBindingGetByID(ctx, db.NamespaceRoleBindingGetByIDParams{ID: bindingID})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("namespace role binding not found"))
+		}
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to get namespace role binding: %w", err))
+	}
+
+	if err := s.checkPermission(ctx, authz.CanManageMembers(), authz.Project(binding.ProjectID)); err != nil {
+		return nil, err
+	}
+
+	rowsAffected, err := s.queries.NamespaceRoleBindingDelete(ctx, db.NamespaceRoleBindingDeleteParams{ID: bindingID})
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to delete namespace role binding: %w", err))
+	}
+
+	if rowsAffected != 1 {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("namespace role binding not found"))
+	}
+
+	s.logger.InfoContext(ctx, "namespace role binding deleted", "binding_id", bindingID)
+
+	return organizationv1.DeleteNamespaceRoleBindingResponse_builder{}.Build(), nil
+}
@@ -0,0 +1,38 @@
+package organization
+
+import (
+	"context"
+	"fmt"
+
+	"connectrpc.com/connect"
+	"github.com/google/uuid"
+
+	"github.com/fundament-oss/fundament/common/authz"
+	db "github.com/fundament-oss/fundament/organization-api/pkg/db/gen"
+	organizationv1 "github.com/fundament-oss/fundament/organization-api/pkg/proto/gen/v1"
+)
+
+func (s *Server) ListNamespaceRoleBindings(
+	ctx context.Context,
+	req *organizationv1.ListNamespaceRoleBindingsRequest,
+) (*organizationv1.ListNamespaceRoleBindingsResponse, error) {
+	projectID := uuid.MustParse(req.GetProjectId())
+
+	if err := s.checkPermission(ctx, authz.CanListMembers(), authz.Project(projectID)); err != nil {
+		return nil, err
+	}
+
+	bindings, err := s.queries.NamespaceRoleBindingList(ctx, db.NamespaceRoleBindingListParams{ProjectID: projectID})
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to list namespace role bindings: %w", err))
+	}
+
+	result := make([]*organizationv1.NamespaceRoleBinding, 0, len(bindings))
+	for i := range bindings {
+		result = append(result, namespaceRoleBindingFromListRow(&bindings[i]))
+	}
+
+	return organizationv1.ListNamespaceRoleBindingsResponse_builder{
+		Bindings: result,
+	}.Build(), nil
+}
@@ -0,0 +1,172 @@
+package organization_test
+
+import (
+	"context"
+	"testing"
+
+	"connectrpc.com/connect"
+	organizationv1 "github.com/fundament-oss/fundament/organization-api/pkg/proto/gen/v1"
+	"github.com/fundament-oss/fundament/organization-api/pkg/proto/gen/v1/organizationv1connect"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_NamespaceRoleBinding_Unauthenticated(t *testing.T) {
+	t.Parallel()
+
+	env := newTestAPI(t)
+	client := organizationv1connect.NewProjectServiceClient(env.server.Client(), env.server.URL)
+
+	_, err := client.ListNamespaceRoleBindings(context.Background(), organizationv1.ListNamespaceRoleBindingsRequest_builder{
+		ProjectId: uuid.New().String(),
+	}.Build())
+
+	var connectErr *connect.Error
+	require.ErrorAs(t, err, &connectErr)
+	assert.Equal(t, connect.CodeUnauthenticated, connectErr.Code())
+}
+
+func Test_NamespaceRoleBinding_Lifecycle(t *testing.T) {
+	t.Parallel()
+
+	orgID := uuid.New()
+	callerUserID := uuid.New()
+	memberUserID := uuid.New()
+
+	env := newTestAPI(t,
+		WithOrganization(orgID, "test-org"),
+		WithUser(&UserArgs{ID: callerUserID, Name: "caller-user", OrgIDs: []uuid.UUID{orgID}}),
+		WithUser(&UserArgs{ID: memberUserID, Name: "deployer", OrgIDs: []uuid.UUID{orgID}}),
+	)
+
+	token := env.createAuthnToken(t, callerUserID)
+	clusterClient := organizationv1connect.NewClusterServiceClient(env.server.Client(), env.server.URL)
+	client := organizationv1connect.NewProjectServiceClient(env.server.Client(), env.server.URL)
+
+	clusterRes, err := clusterClient.CreateCluster(authedContext(token, orgID), organizationv1.CreateClusterRequest_builder{
+		Name:              "test-cluster",
+		Region:            "eu-west-1",
+		KubernetesVersion: "1.28",
+	}.Build())
+	require.NoError(t, err)
+
+	projectRes, err := client.CreateProject(authedContext(token, orgID), organizationv1.CreateProjectRequest_builder{
+		ClusterId: clusterRes.GetClusterId(),
+		Name:      "test-project",
+	}.Build())
+	require.NoError(t, err)
+	projectID := projectRes.GetProjectId()
+
+	memberRes, err := client.AddProjectMember(authedContext(token, orgID), organizationv1.AddProjectMemberRequest_builder{
+		ProjectId: projectID,
+		UserId:    memberUserID.String(),
+		Role:      organizationv1.ProjectMemberRole_PROJECT_MEMBER_ROLE_VIEWER,
+	}.Build())
+	require.NoError(t, err)
+	memberID := memberRes.GetMemberId()
+
+	createReq := organizationv1.CreateNamespaceRoleBindingRequest_builder{
+		MemberId:         memberID,
+		NamespacePattern: "staging-*",
+		Role:             organizationv1.NamespaceRole_NAMESPACE_ROLE_DEPLOY,
+	}.Build()
+
+	createRes, err := client.CreateNamespaceRoleBinding(authedContext(token, orgID), createReq)
+	require.NoError(t, err)
+	require.NotEmpty(t, createRes.GetBindingId())
+
+	_, err = client.CreateNamespaceRoleBinding(authedContext(token, orgID), createReq)
+	var connectErr *connect.Error
+	require.ErrorAs(t, err, &connectErr)
+	assert.Equal(t, connect.CodeAlreadyExists, connectErr.Code())
+
+	_, err = client.CreateNamespaceRoleBinding(authedContext(token, orgID), organizationv1.CreateNamespaceRoleBindingRequest_builder{
+		MemberId:         memberID,
+		NamespacePattern: "Staging_*",
+		Role:             organizationv1.NamespaceRole_NAMESPACE_ROLE_VIEW_LOGS,
+	}.Build())
+	require.ErrorAs(t, err, &connectErr)
+	assert.Equal(t, connect.CodeInvalidArgument, connectErr.Code())
+
+	logsRes, err := client.CreateNamespaceRoleBinding(authedContext(token, orgID), organizationv1.CreateNamespaceRoleBindingRequest_builder{
+		MemberId:         memberID,
+		NamespacePattern: "staging-api",
+		Role:             organizationv1.NamespaceRole_NAMESPACE_ROLE_VIEW_LOGS,
+	}.Build())
+	require.NoError(t, err)
+
+	listRes, err := client.ListNamespaceRoleBindings(authedContext(token, orgID), organizationv1.ListNamespaceRoleBindingsRequest_builder{
+		ProjectId: projectID,
+	}.Build())
+	require.NoError(t, err)
+	require.Len(t, listRes.GetBindings(), 2)
+	binding := listRes.GetBindings()[0]
+	assert.Equal(t, createRes.GetBindingId(), binding.GetId())
+	assert.Equal(t, projectID, binding.GetProjectId())
+	assert.Equal(t, memberID, binding.GetMemberId())
+	assert.Equal(t, memberUserID.String(), binding.GetUserId())
+	assert.Equal(t, "deployer", binding.GetUserName())
+	assert.Equal(t, "staging-*", binding.GetNamespacePattern())
+	assert.Equal(t, organizationv1.NamespaceRole_NAMESPACE_ROLE_DEPLOY, binding.GetRole())
+
+	_, err = client.DeleteNamespaceRoleBinding(authedContext(token, orgID), organizationv1.DeleteNamespaceRoleBindingRequest_builder{
+		BindingId: logsRes.GetBindingId(),
+	}.Build())
+	require.NoError(t, err)
+
+	_, err = client.DeleteNamespaceRoleBinding(authedContext(token, orgID), organizationv1.DeleteNamespaceRoleBindingRequest_builder{
+		BindingId: logsRes.GetBindingId(),
+	}.Build())
+	require.ErrorAs(t, err, &connectErr)
+	assert.Equal(t, connect.CodeNotFound, connectErr.Code())
+
+	listRes, err = client.ListNamespaceRoleBindings(authedContext(token, orgID), organizationv1.ListNamespaceRoleBindingsRequest_builder{
+		ProjectId: projectID,
+	}.Build())
+	require.NoError(t, err)
+	require.Len(t, listRes.GetBindings(), 1)
+
+	// Removing the member revokes their remaining bindings.
+	_, err = client.RemoveProjectMember(authedContext(token, orgID), organizationv1.RemoveProjectMemberRequest_builder{
+		MemberId: memberID,
+	}.Build())
+	require.NoError(t, err)
+
+	listRes, err = client.ListNamespaceRoleBindings(authedContext(token, orgID), organizationv1.ListNamespaceRoleBindingsRequest_builder{
+		ProjectId: projectID,
+	}.Build())
+	require.NoError(t, err)
+	assert.Empty(t, listRes.GetBindings())
+
+	_, err = client.DeleteNamespaceRoleBinding(authedContext(token, orgID), organizationv1.DeleteNamespaceRoleBindingRequest_builder{
+		BindingId: createRes.GetBindingId(),
+	}.Build())
+	require.ErrorAs(t, err, &connectErr)
+	assert.Equal(t, connect.CodeNotFound, connectErr.Code())
+}
+
+func Test_NamespaceRoleBinding_Create_MemberNotFound(t *testing.T) {
+	t.Parallel()
+
+	orgID := uuid.New()
+	userID := uuid.New()
+
+	env := newTestAPI(t,
+		WithOrganization(orgID, "test-org"),
+		WithUser(&UserArgs{ID: userID, Name: "test-user", OrgIDs: []uuid.UUID{orgID}}),
+	)
+
+	token := env.createAuthnToken(t, userID)
+	client := organizationv1connect.NewProjectServiceClient(env.server.Client(), env.server.URL)
+
+	_, err := client.CreateNamespaceRoleBinding(authedContext(token, orgID), organizationv1.CreateNamespaceRoleBindingRequest_builder{
+		MemberId:         uuid.New().String(),
+		NamespacePattern: "*",
+		Role:             organizationv1.NamespaceRole_NAMESPACE_ROLE_VIEW_PODS,
+	}.Build())
+
+	var connectErr *connect.Error
+	require.ErrorAs(t, err, &connectErr)
+	assert.Equal(t, connect.CodeNotFound, connectErr.Code())
+}
@@ -0,0 +1,148 @@
+package organization
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	db "github.com/fundament-oss/fundament/organization-api/pkg/db/gen"
+	organizationv1 "github.com/fundament-oss/fundament/organization-api/pkg/proto/gen/v1"
+)
+
+// Rolling update defaults of a node pool, as for Gardener workers.
+const (
+	defaultMaxSurge       = 1
+	defaultMaxUnavailable = 0
+)
+
+// nodeTaint is a taint as stored in tenant.node_pools.taints. The JSON shape
+// is that of a Kubernetes core/v1 Taint, which the cluster-worker decodes it
+// into.
+type nodeTaint struct {
+	Key    string `json:"key"`
+	Value  string `json:"value,omitempty"`
+	Effect string `json:"effect"`
+}
+
+var taintEffectNames = map[organizationv1.TaintEffect]string{
+	organizationv1.TaintEffect_TAINT_EFFECT_NO_SCHEDULE:        "NoSchedule",
+	organizationv1.TaintEffect_TAINT_EFFECT_PREFER_NO_SCHEDULE: "PreferNoSchedule",
+	organizationv1.TaintEffect_TAINT_EFFECT_NO_EXECUTE:         "NoExecute",
+}
+
+// reservedLabelDomains are label and taint key prefixes owned by Kubernetes
+// and Gardener; the kubelet refuses most of them on its own node.
+var reservedLabelDomains = []string{"kubernetes.io", "k8s.io", "gardener.cloud"}
+
+func nodePoolFromRow(row *db.TenantNodePool) *organizationv1.NodePool {
+	return organizationv1.NodePool_builder{
+		Id:             row.ID.String(),
+		Name:           row.Name,
+		MachineType:    row.MachineType,
+		CurrentNodes:   0, // Stub: would come from actual cluster state
+		MinNodes:       row.AutoscaleMin,
+		MaxNodes:       row.AutoscaleMax,
+		Status:         organizationv1.NodePoolStatus_NODE_POOL_STATUS_UNSPECIFIED, // Stub
+		Version:        "",                                                         // Stub: would come from actual cluster state
+		Labels:         labelsFromJSON(row.Labels),
+		ClusterId:      row.ClusterID.String(),
+		Taints:         taintsFromJSON(row.Taints),
+		Zones:          row.Zones,
+		MaxSurge:       row.MaxSurge,
+		MaxUnavailable: row.MaxUnavailable,
+	}.Build()
+}
+
+// validateNodeLabels checks that labels are valid Kubernetes labels outside
+// the reserved domains.
+func validateNodeLabels(labels map[string]string) error {
+	for key, value := range labels {
+		if err := validateNodeKey("label", key); err != nil {
+			return err
+		}
+		if errs := validation.IsValidLabelValue(value); len(errs) > 0 {
+			return fmt.Errorf("label %q: invalid value %q: %s", key, value, strings.Join(errs, "; "))
+		}
+	}
+	return nil
+}
+
+// validateNodeTaints checks taints like labels and rejects a key and effect
+// that appear twice, which Kubernetes does not allow.
+func validateNodeTaints(taints []*organizationv1.NodeTaint) error {
+	seen := make(map[string]bool, len(taints))
+	for _, t := range taints {
+		if err := validateNodeKey("taint", t.GetKey()); err != nil {
+			return err
+		}
+		if errs := validation.IsValidLabelValue(t.GetValue()); len(errs) > 0 {
+			return fmt.Errorf("taint %q: invalid value %q: %s", t.GetKey(), t.GetValue(), strings.Join(errs, "; "))
+		}
+		id := t.GetKey() + ":" + t.GetEffect().String()
+		if seen[id] {
+			return fmt.Errorf("taint %q with effect %s is given twice", t.GetKey(), taintEffectNames[t.GetEffect()])
+		}
+		seen[id] = true
+	}
+	return nil
+}
+
+func validateNodeKey(kind, key string) error {
+	if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+		return fmt.Errorf("%s %q: invalid key: %s", kind, key, strings.Join(errs, "; "))
+	}
+	prefix, _, ok := strings.Cut(key, "/")
+	if !ok {
+		return nil
+	}
+	for _, domain := range reservedLabelDomains {
+		if prefix == domain || strings.HasSuffix(prefix, "."+domain) {
+			return fmt.Errorf("%s %q: the %s domain is reserved", kind, key, domain)
+		}
+	}
+	return nil
+}
+
+// validateRollingUpdate rejects settings that would never let a node be
+// replaced.
+func validateRollingUpdate(maxSurge, maxUnavailable int32) error {
+	if maxSurge+maxUnavailable == 0 {
+		return fmt.Errorf("max_surge and max_unavailable cannot both be 0")
+	}
+	return nil
+}
+
+// taintsToJSON encodes taints for the NOT NULL jsonb column; no taints encode
+// as an empty array.
+func taintsToJSON(taints []*organizationv1.NodeTaint) ([]byte, error) {
+	stored := make([]nodeTaint, 0, len(taints))
+	for _, t := range taints {
+		stored = append(stored, nodeTaint{Key: t.GetKey(), Value: t.GetValue(), Effect: taintEffectNames[t.GetEffect()]})
+	}
+	return json.Marshal(stored)
+}
+
+func taintsFromJSON(raw []byte) []*organizationv1.NodeTaint {
+	var stored []nodeTaint
+	if err := json.Unmarshal(raw, &stored); err != nil {
+		return nil
+	}
+
+	taints := make([]*organizationv1.NodeTaint, 0, len(stored))
+	for _, t := range stored {
+		effect := organizationv1.TaintEffect_TAINT_EFFECT_UNSPECIFIED
+		for e, name := range taintEffectNames {
+			if name == t.Effect {
+				effect = e
+			}
+		}
+		taints = append(taints, organizationv1.NodeTaint_builder{
+			Key:    t.Key,
+			Value:  t.Value,
+			Effect: effect,
+		}.Build())
+	}
+	return taints
+}
@@ -0,0 +1,80 @@
+package organization
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	organizationv1 "github.com/fundament-oss/fundament/organization-api/pkg/proto/gen/v1"
+)
+
+func taint(key, value string, effect organizationv1.TaintEffect) *organizationv1.NodeTaint {
+	return organizationv1.NodeTaint_builder{Key: key, Value: value, Effect: effect}.Build()
+}
+
+func TestValidateNodeLabels(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, validateNodeLabels(map[string]string{
+		"workload":                "batch",
+		"example.com/cost-center": "",
+	}))
+
+	for _, labels := range []map[string]string{
+		{"-workload": "batch"},
+		{"workload": "not a value"},
+		{"node-role.kubernetes.io/ingress": ""},
+		{"worker.gardener.cloud/pool": "a"},
+	} {
+		assert.Error(t, validateNodeLabels(labels), labels)
+	}
+}
+
+func TestValidateNodeTaints(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, validateNodeTaints([]*organizationv1.NodeTaint{
+		taint("dedicated", "batch", organizationv1.TaintEffect_TAINT_EFFECT_NO_SCHEDULE),
+		taint("dedicated", "batch", organizationv1.TaintEffect_TAINT_EFFECT_NO_EXECUTE),
+	}))
+
+	err := validateNodeTaints([]*organizationv1.NodeTaint{
+		taint("dedicated", "batch", organizationv1.TaintEffect_TAINT_EFFECT_NO_SCHEDULE),
+		taint("dedicated", "ingress", organizationv1.TaintEffect_TAINT_EFFECT_NO_SCHEDULE),
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "given twice")
+}
+
+func TestTaintsJSON_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	raw, err := taintsToJSON(nil)
+	require.NoError(t, err)
+	assert.JSONEq(t, `[]`, string(raw))
+
+	taints := []*organizationv1.NodeTaint{
+		taint("dedicated", "batch", organizationv1.TaintEffect_TAINT_EFFECT_PREFER_NO_SCHEDULE),
+		taint("spot", "", organizationv1.TaintEffect_TAINT_EFFECT_NO_EXECUTE),
+	}
+	raw, err = taintsToJSON(taints)
+	require.NoError(t, err)
+	assert.JSONEq(t, `[{"key":"dedicated","value":"batch","effect":"PreferNoSchedule"},{"key":"spot","effect":"NoExecute"}]`, string(raw))
+
+	decoded := taintsFromJSON(raw)
+	require.Len(t, decoded, 2)
+	for i := range taints {
+		assert.Equal(t, taints[i].GetKey(), decoded[i].GetKey())
+		assert.Equal(t, taints[i].GetValue(), decoded[i].GetValue())
+		assert.Equal(t, taints[i].GetEffect(), decoded[i].GetEffect())
+	}
+}
+
+func TestValidateRollingUpdate(t *testing.T) {
+	t.Parallel()
+
+	assert.NoError(t, validateRollingUpdate(defaultMaxSurge, defaultMaxUnavailable))
+	assert.NoError(t, validateRollingUpdate(0, 1))
+	assert.Error(t, validateRollingUpdate(0, 0))
+}
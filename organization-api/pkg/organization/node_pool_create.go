@@ -52,6 +52,40 @@ func (s *Server) CreateNodePool(
 		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to resolve machine type: %w", err))
 	}
 
+	policy, err := s.organizationPolicy(ctx, cluster.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+	if err := policyError(policy.checkCreateNodePool(req)); err != nil {
+		return nil, err
+	}
+
+	maxSurge, maxUnavailable := int32(defaultMaxSurge), int32(defaultMaxUnavailable)
+	if req.HasMaxSurge() {
+		maxSurge = req.GetMaxSurge()
+	}
+	if req.HasMaxUnavailable() {
+		maxUnavailable = req.GetMaxUnavailable()
+	}
+	if err := validateRollingUpdate(maxSurge, maxUnavailable); err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	if err := validateNodeLabels(req.GetLabels()); err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	if err := validateNodeTaints(req.GetTaints()); err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	labels, err := labelsToJSON(req.GetLabels())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to encode labels: %w", err))
+	}
+	taints, err := taintsToJSON(req.GetTaints())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to encode taints: %w", err))
+	}
+
 	params := db.NodePoolCreateParams{
 		ClusterID:           clusterID,
 		Name:                req.GetName(),
@@ -59,6 +93,11 @@ func (s *Server) CreateNodePool(
 		RegionMachineTypeID: pgtype.UUID{Bytes: offering, Valid: true},
 		AutoscaleMin:        req.GetAutoscaleMin(),
 		AutoscaleMax:        req.GetAutoscaleMax(),
+		Labels:              labels,
+		Taints:              taints,
+		Zones:               nonNil(req.GetZones()),
+		MaxSurge:            maxSurge,
+		MaxUnavailable:      maxUnavailable,
 	}
 
 	nodePoolID, err := s.queries.NodePoolCreate(ctx, params)
@@ -36,16 +36,3 @@ func (s *Server) GetNodePool(
 		NodePool: nodePoolFromRow(&nodePool),
 	}.Build(), nil
 }
-
-func nodePoolFromRow(row *db.TenantNodePool) *organizationv1.NodePool {
-	return organizationv1.NodePool_builder{
-		Id:           row.ID.String(),
-		Name:         row.Name,
-		MachineType:  row.MachineType,
-		CurrentNodes: 0, // Stub: would come from actual cluster state
-		MinNodes:     row.AutoscaleMin,
-		MaxNodes:     row.AutoscaleMax,
-		Status:       organizationv1.NodePoolStatus_NODE_POOL_STATUS_UNSPECIFIED, // Stub
-		Version:      "",                                                         // Stub: would come from actual cluster state
-	}.Build()
-}
@@ -38,23 +38,10 @@ func (s *Server) ListNodePools(
 
 	result := make([]*organizationv1.NodePool, 0, len(nodePools))
 	for i := range nodePools {
-		result = append(result, nodePoolFromListRow(&nodePools[i]))
+		result = append(result, nodePoolFromRow(&nodePools[i]))
 	}
 
 	return organizationv1.ListNodePoolsResponse_builder{
 		NodePools: result,
 	}.Build(), nil
 }
-
-func nodePoolFromListRow(row *db.TenantNodePool) *organizationv1.NodePool {
-	return organizationv1.NodePool_builder{
-		Id:           row.ID.String(),
-		Name:         row.Name,
-		MachineType:  row.MachineType,
-		CurrentNodes: 0, // Stub: would come from actual cluster state
-		MinNodes:     row.AutoscaleMin,
-		MaxNodes:     row.AutoscaleMax,
-		Status:       organizationv1.NodePoolStatus_NODE_POOL_STATUS_UNSPECIFIED, // Stub
-		Version:      "",                                                         // Stub: would come from actual cluster state
-	}.Build()
-}
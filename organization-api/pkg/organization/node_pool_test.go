@@ -0,0 +1,131 @@
+package organization_test
+
+import (
+	"testing"
+
+	"connectrpc.com/connect"
+	organizationv1 "github.com/fundament-oss/fundament/organization-api/pkg/proto/gen/v1"
+	"github.com/fundament-oss/fundament/organization-api/pkg/proto/gen/v1/organizationv1connect"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func Test_NodePool_Placement(t *testing.T) {
+	t.Parallel()
+
+	orgID := uuid.New()
+	userID := uuid.New()
+
+	env := newTestAPI(t,
+		WithOrganization(orgID, "test-org"),
+		WithUser(&UserArgs{ID: userID, Name: "test-user", OrgIDs: []uuid.UUID{orgID}}),
+	)
+
+	token := env.createAuthnToken(t, userID)
+	client := organizationv1connect.NewClusterServiceClient(env.server.Client(), env.server.URL)
+
+	clusterRes, err := client.CreateCluster(authedContext(token, orgID), organizationv1.CreateClusterRequest_builder{
+		Name:              "placement",
+		Region:            "eu-west-1",
+		KubernetesVersion: "1.28",
+	}.Build())
+	require.NoError(t, err)
+
+	noSchedule := organizationv1.NodeTaint_builder{
+		Key:    "dedicated",
+		Value:  "batch",
+		Effect: organizationv1.TaintEffect_TAINT_EFFECT_NO_SCHEDULE,
+	}.Build()
+
+	createReq := func(name string) organizationv1.CreateNodePoolRequest_builder {
+		return organizationv1.CreateNodePoolRequest_builder{
+			ClusterId:    clusterRes.GetClusterId(),
+			Name:         name,
+			MachineType:  "n1-standard-2",
+			AutoscaleMin: 1,
+			AutoscaleMax: 3,
+		}
+	}
+
+	req := createReq("batch")
+	req.Labels = map[string]string{"workload": "batch"}
+	req.Taints = []*organizationv1.NodeTaint{noSchedule}
+	req.Zones = []string{"eu-west-1a", "eu-west-1b"}
+	req.MaxSurge = proto.Int32(0)
+	req.MaxUnavailable = proto.Int32(1)
+	createRes, err := client.CreateNodePool(authedContext(token, orgID), req.Build())
+	require.NoError(t, err)
+
+	getRes, err := client.GetNodePool(authedContext(token, orgID), organizationv1.GetNodePoolRequest_builder{
+		NodePoolId: createRes.GetNodePoolId(),
+	}.Build())
+	require.NoError(t, err)
+	pool := getRes.GetNodePool()
+	assert.Equal(t, clusterRes.GetClusterId(), pool.GetClusterId())
+	assert.Equal(t, map[string]string{"workload": "batch"}, pool.GetLabels())
+	require.Len(t, pool.GetTaints(), 1)
+	assert.True(t, proto.Equal(noSchedule, pool.GetTaints()[0]))
+	assert.Equal(t, []string{"eu-west-1a", "eu-west-1b"}, pool.GetZones())
+	assert.EqualValues(t, 0, pool.GetMaxSurge())
+	assert.EqualValues(t, 1, pool.GetMaxUnavailable())
+
+	// An update without nodes keeps the labels and taints; one with nodes
+	// replaces them.
+	_, err = client.UpdateNodePool(authedContext(token, orgID), organizationv1.UpdateNodePoolRequest_builder{
+		NodePoolId:   createRes.GetNodePoolId(),
+		AutoscaleMin: 1,
+		AutoscaleMax: 5,
+		MaxSurge:     proto.Int32(2),
+	}.Build())
+	require.NoError(t, err)
+
+	getRes, err = client.GetNodePool(authedContext(token, orgID), organizationv1.GetNodePoolRequest_builder{
+		NodePoolId: createRes.GetNodePoolId(),
+	}.Build())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"workload": "batch"}, getRes.GetNodePool().GetLabels())
+	assert.Len(t, getRes.GetNodePool().GetTaints(), 1)
+	assert.EqualValues(t, 2, getRes.GetNodePool().GetMaxSurge())
+	assert.EqualValues(t, 1, getRes.GetNodePool().GetMaxUnavailable())
+
+	_, err = client.UpdateNodePool(authedContext(token, orgID), organizationv1.UpdateNodePoolRequest_builder{
+		NodePoolId:   createRes.GetNodePoolId(),
+		AutoscaleMin: 1,
+		AutoscaleMax: 5,
+		Nodes: organizationv1.NodeTemplate_builder{
+			Labels: map[string]string{"workload": "stateful"},
+		}.Build(),
+	}.Build())
+	require.NoError(t, err)
+
+	getRes, err = client.GetNodePool(authedContext(token, orgID), organizationv1.GetNodePoolRequest_builder{
+		NodePoolId: createRes.GetNodePoolId(),
+	}.Build())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"workload": "stateful"}, getRes.GetNodePool().GetLabels())
+	assert.Empty(t, getRes.GetNodePool().GetTaints())
+
+	// A pool without placement settings gets the defaults.
+	createRes, err = client.CreateNodePool(authedContext(token, orgID), createReq("default").Build())
+	require.NoError(t, err)
+	getRes, err = client.GetNodePool(authedContext(token, orgID), organizationv1.GetNodePoolRequest_builder{
+		NodePoolId: createRes.GetNodePoolId(),
+	}.Build())
+	require.NoError(t, err)
+	assert.Empty(t, getRes.GetNodePool().GetLabels())
+	assert.Empty(t, getRes.GetNodePool().GetZones())
+	assert.EqualValues(t, 1, getRes.GetNodePool().GetMaxSurge())
+	assert.EqualValues(t, 0, getRes.GetNodePool().GetMaxUnavailable())
+
+	req = createReq("reserved")
+	req.Labels = map[string]string{"node-role.kubernetes.io/worker": ""}
+	_, err = client.CreateNodePool(authedContext(token, orgID), req.Build())
+	assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+
+	req = createReq("stuck")
+	req.MaxSurge = proto.Int32(0)
+	_, err = client.CreateNodePool(authedContext(token, orgID), req.Build())
+	assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+}
@@ -2,10 +2,12 @@ package organization
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"connectrpc.com/connect"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 
 	"github.com/fundament-oss/fundament/common/authz"
@@ -23,12 +25,63 @@ func (s *Server) UpdateNodePool(
 		return nil, err
 	}
 
+	organizationID, ok := OrganizationIDFromContext(ctx)
+	if !ok {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("organization_id missing from context"))
+	}
+	policy, err := s.organizationPolicy(ctx, organizationID)
+	if err != nil {
+		return nil, err
+	}
+	if err := policyError(policy.checkUpdateNodePool(req)); err != nil {
+		return nil, err
+	}
+
 	params := db.NodePoolUpdateParams{
 		ID:           nodePoolID,
 		AutoscaleMin: pgtype.Int4{Int32: req.GetAutoscaleMin(), Valid: true},
 		AutoscaleMax: pgtype.Int4{Int32: req.GetAutoscaleMax(), Valid: true},
 	}
 
+	if req.HasNodes() {
+		nodes := req.GetNodes()
+		if err := validateNodeLabels(nodes.GetLabels()); err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, err)
+		}
+		if err := validateNodeTaints(nodes.GetTaints()); err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, err)
+		}
+		if params.Labels, err = labelsToJSON(nodes.GetLabels()); err != nil {
+			return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to encode labels: %w", err))
+		}
+		if params.Taints, err = taintsToJSON(nodes.GetTaints()); err != nil {
+			return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to encode taints: %w", err))
+		}
+	}
+
+	if req.HasMaxSurge() || req.HasMaxUnavailable() {
+		// The setting left out keeps its stored value, which the check needs.
+		current, err := s.queries.NodePoolGetByID(ctx, db.NodePoolGetByIDParams{ID: nodePoolID})
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("node pool not found"))
+			}
+			return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to get node pool: %w", err))
+		}
+		maxSurge, maxUnavailable := current.MaxSurge, current.MaxUnavailable
+		if req.HasMaxSurge() {
+			maxSurge = req.GetMaxSurge()
+		}
+		if req.HasMaxUnavailable() {
+			maxUnavailable = req.GetMaxUnavailable()
+		}
+		if err := validateRollingUpdate(maxSurge, maxUnavailable); err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, err)
+		}
+		params.MaxSurge = pgtype.Int4{Int32: maxSurge, Valid: true}
+		params.MaxUnavailable = pgtype.Int4{Int32: maxUnavailable, Valid: true}
+	}
+
 	rowsAffected, err := s.queries.NodePoolUpdate(ctx, params)
 	if err != nil {
 		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to update node pool: %w", err))
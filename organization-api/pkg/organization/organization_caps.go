@@ -0,0 +1,83 @@
+package organization
+
+import (
+	"context"
+	"fmt"
+
+	"connectrpc.com/connect"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	db "github.com/fundament-oss/fundament/organization-api/pkg/db/gen"
+	organizationv1 "github.com/fundament-oss/fundament/organization-api/pkg/proto/gen/v1"
+)
+
+// organizationCap is one of the caps on the number of resources in an
+// organization, named after its OrganizationLimits field.
+type organizationCap string
+
+const (
+	capClusters   organizationCap = "max_clusters"
+	capProjects   organizationCap = "max_projects"
+	capNamespaces organizationCap = "max_namespaces"
+)
+
+// enforceOrganizationCap fails with FailedPrecondition when the organization
+// already has as many resources as the cap allows. It locks the organization
+// row for the rest of the transaction, so qtx must be bound to the transaction
+// that creates the resource.
+func enforceOrganizationCap(ctx context.Context, qtx *db.Queries, organizationID uuid.UUID, c organizationCap) error {
+	caps, err := qtx.OrganizationCapsLock(ctx, db.OrganizationCapsLockParams{OrganizationID: organizationID})
+	if err != nil {
+		return connect.NewError(connect.CodeInternal, fmt.Errorf("failed to lock organization limits: %w", err))
+	}
+
+	var (
+		maximum pgtype.Int4
+		count   func(context.Context, uuid.UUID) (int32, error)
+		noun    string
+	)
+	switch c {
+	case capClusters:
+		maximum, noun = caps.MaxClusters, "clusters"
+		count = func(ctx context.Context, id uuid.UUID) (int32, error) {
+			return qtx.OrganizationClusterCount(ctx, db.OrganizationClusterCountParams{OrganizationID: id})
+		}
+	case capProjects:
+		maximum, noun = caps.MaxProjects, "projects"
+		count = func(ctx context.Context, id uuid.UUID) (int32, error) {
+			return qtx.OrganizationProjectCount(ctx, db.OrganizationProjectCountParams{OrganizationID: id})
+		}
+	case capNamespaces:
+		maximum, noun = caps.MaxNamespaces, "namespaces"
+		count = func(ctx context.Context, id uuid.UUID) (int32, error) {
+			return qtx.OrganizationNamespaceCount(ctx, db.OrganizationNamespaceCountParams{OrganizationID: id})
+		}
+	default:
+		return connect.NewError(connect.CodeInternal, fmt.Errorf("unknown organization cap %q", c))
+	}
+	if !maximum.Valid {
+		return nil
+	}
+
+	current, err := count(ctx, organizationID)
+	if err != nil {
+		return connect.NewError(connect.CodeInternal, fmt.Errorf("failed to count %s: %w", noun, err))
+	}
+	if current < maximum.Int32 {
+		return nil
+	}
+
+	connectErr := connect.NewError(connect.CodeFailedPrecondition,
+		fmt.Errorf("organization limit reached: %d of %d %s in use", current, maximum.Int32, noun))
+	detail, err := connect.NewErrorDetail(organizationv1.OrganizationLimitExceeded_builder{
+		Limit:   string(c),
+		Current: current,
+		Max:     maximum.Int32,
+	}.Build())
+	if err != nil {
+		return connect.NewError(connect.CodeInternal, fmt.Errorf("failed to build limit error detail: %w", err))
+	}
+	connectErr.AddDetail(detail)
+	return connectErr
+}
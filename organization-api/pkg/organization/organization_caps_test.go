@@ -0,0 +1,117 @@
+package organization_test
+
+import (
+	"testing"
+
+	"connectrpc.com/connect"
+	organizationv1 "github.com/fundament-oss/fundament/organization-api/pkg/proto/gen/v1"
+	"github.com/fundament-oss/fundament/organization-api/pkg/proto/gen/v1/organizationv1connect"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func Test_OrganizationCaps(t *testing.T) {
+	t.Parallel()
+
+	orgID := uuid.New()
+	userID := uuid.New()
+
+	env := newTestAPI(t,
+		WithOrganization(orgID, "test-org"),
+		WithUser(&UserArgs{ID: userID, Name: "test-user", OrgIDs: []uuid.UUID{orgID}}),
+	)
+
+	token := env.createAuthnToken(t, userID)
+	orgClient := organizationv1connect.NewOrganizationServiceClient(env.server.Client(), env.server.URL)
+	clusterClient := organizationv1connect.NewClusterServiceClient(env.server.Client(), env.server.URL)
+	projectClient := organizationv1connect.NewProjectServiceClient(env.server.Client(), env.server.URL)
+	namespaceClient := organizationv1connect.NewNamespaceServiceClient(env.server.Client(), env.server.URL)
+
+	_, err := orgClient.UpdateOrganizationLimits(authedContext(token, orgID), organizationv1.UpdateOrganizationLimitsRequest_builder{
+		Id:            orgID.String(),
+		MaxClusters:   proto.Int32(1),
+		MaxProjects:   proto.Int32(1),
+		MaxNamespaces: proto.Int32(2),
+	}.Build())
+	require.NoError(t, err)
+
+	getRes, err := orgClient.GetOrganizationLimits(authedContext(token, orgID), organizationv1.GetOrganizationLimitsRequest_builder{
+		Id: orgID.String(),
+	}.Build())
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, getRes.GetLimits().GetMaxClusters())
+	assert.EqualValues(t, 1, getRes.GetLimits().GetMaxProjects())
+	assert.EqualValues(t, 2, getRes.GetLimits().GetMaxNamespaces())
+
+	clusterReq := func(name string) *organizationv1.CreateClusterRequest {
+		return organizationv1.CreateClusterRequest_builder{
+			Name:              name,
+			Region:            "eu-west-1",
+			KubernetesVersion: "1.28",
+		}.Build()
+	}
+
+	clusterRes, err := clusterClient.CreateCluster(authedContext(token, orgID), clusterReq("first"))
+	require.NoError(t, err)
+	_, err = clusterClient.CreateCluster(authedContext(token, orgID), clusterReq("second"))
+	requireOrganizationLimitExceeded(t, err, "max_clusters", 1, 1)
+
+	projectReq := func(name string) *organizationv1.CreateProjectRequest {
+		return organizationv1.CreateProjectRequest_builder{
+			ClusterId: clusterRes.GetClusterId(),
+			Name:      name,
+		}.Build()
+	}
+
+	projectRes, err := projectClient.CreateProject(authedContext(token, orgID), projectReq("first"))
+	require.NoError(t, err)
+	_, err = projectClient.CreateProject(authedContext(token, orgID), projectReq("second"))
+	requireOrganizationLimitExceeded(t, err, "max_projects", 1, 1)
+
+	namespaceReq := func(name string) *organizationv1.CreateNamespaceRequest {
+		return organizationv1.CreateNamespaceRequest_builder{
+			ProjectId: projectRes.GetProjectId(),
+			Name:      name,
+		}.Build()
+	}
+
+	for _, name := range []string{"web", "api"} {
+		_, err = namespaceClient.CreateNamespace(authedContext(token, orgID), namespaceReq(name))
+		require.NoError(t, err)
+	}
+	_, err = namespaceClient.CreateNamespace(authedContext(token, orgID), namespaceReq("jobs"))
+	requireOrganizationLimitExceeded(t, err, "max_namespaces", 2, 2)
+
+	// Without caps the organization can create as many as it likes.
+	_, err = orgClient.UpdateOrganizationLimits(authedContext(token, orgID), organizationv1.UpdateOrganizationLimitsRequest_builder{
+		Id: orgID.String(),
+	}.Build())
+	require.NoError(t, err)
+
+	_, err = clusterClient.CreateCluster(authedContext(token, orgID), clusterReq("second"))
+	require.NoError(t, err)
+	_, err = projectClient.CreateProject(authedContext(token, orgID), projectReq("second"))
+	require.NoError(t, err)
+	_, err = namespaceClient.CreateNamespace(authedContext(token, orgID), namespaceReq("jobs"))
+	require.NoError(t, err)
+}
+
+func requireOrganizationLimitExceeded(t *testing.T, err error, limit string, current, maximum int32) {
+	t.Helper()
+
+	var connectErr *connect.Error
+	require.ErrorAs(t, err, &connectErr)
+	require.Equal(t, connect.CodeFailedPrecondition, connectErr.Code())
+
+	require.Len(t, connectErr.Details(), 1)
+	value, err := connectErr.Details()[0].Value()
+	require.NoError(t, err)
+
+	exceeded, ok := value.(*organizationv1.OrganizationLimitExceeded)
+	require.True(t, ok, "unexpected detail %T", value)
+	assert.Equal(t, limit, exceeded.GetLimit())
+	assert.Equal(t, current, exceeded.GetCurrent())
+	assert.Equal(t, maximum, exceeded.GetMax())
+}
@@ -64,5 +64,14 @@ func organizationLimitsFromRow(row *db.OrganizationLimitsGetRow) *organizationv1
 	if row.DefaultCpuLimitM.Valid {
 		limits.SetDefaultCpuLimitM(row.DefaultCpuLimitM.Int32)
 	}
+	if row.MaxClusters.Valid {
+		limits.SetMaxClusters(row.MaxClusters.Int32)
+	}
+	if row.MaxProjects.Valid {
+		limits.SetMaxProjects(row.MaxProjects.Int32)
+	}
+	if row.MaxNamespaces.Valid {
+		limits.SetMaxNamespaces(row.MaxNamespaces.Int32)
+	}
 	return limits
 }
@@ -36,6 +36,9 @@ func (s *Server) UpdateOrganizationLimits(
 		DefaultMemoryLimitMi:   pgtype.Int4{Int32: req.GetDefaultMemoryLimitMi(), Valid: req.HasDefaultMemoryLimitMi()},
 		DefaultCpuRequestM:     pgtype.Int4{Int32: req.GetDefaultCpuRequestM(), Valid: req.HasDefaultCpuRequestM()},
 		DefaultCpuLimitM:       pgtype.Int4{Int32: req.GetDefaultCpuLimitM(), Valid: req.HasDefaultCpuLimitM()},
+		MaxClusters:            pgtype.Int4{Int32: req.GetMaxClusters(), Valid: req.HasMaxClusters()},
+		MaxProjects:            pgtype.Int4{Int32: req.GetMaxProjects(), Valid: req.HasMaxProjects()},
+		MaxNamespaces:          pgtype.Int4{Int32: req.GetMaxNamespaces(), Valid: req.HasMaxNamespaces()},
 	}
 
 	if _, err := s.queries.OrganizationLimitsUpsert(ctx, params); err != nil {
@@ -0,0 +1,178 @@
+package organization
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+
+	"connectrpc.com/connect"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	db "github.com/fundament-oss/fundament/organization-api/pkg/db/gen"
+	organizationv1 "github.com/fundament-oss/fundament/organization-api/pkg/proto/gen/v1"
+)
+
+// organizationPolicy holds the rules an organization sets on its clusters and
+// node pools. The zero value has no rules and allows everything.
+type organizationPolicy struct {
+	regions             nameRule
+	kubernetesVersions  nameRule
+	machineTypes        nameRule
+	maxNodesPerNodePool pgtype.Int4
+	requiredLabels      []string
+}
+
+// nameRule is a pair of allowed and denied lists for one kind of name. Its
+// violations are reported as the allowed_<field> and denied_<field> rules.
+type nameRule struct {
+	field   string
+	noun    string
+	allowed []string
+	denied  []string
+}
+
+func organizationPolicyFromRow(row *db.OrganizationPolicyGetRow) *organizationPolicy {
+	return &organizationPolicy{
+		regions:             nameRule{field: "regions", noun: "region", allowed: row.AllowedRegions, denied: row.DeniedRegions},
+		kubernetesVersions:  nameRule{field: "kubernetes_versions", noun: "kubernetes version", allowed: row.AllowedKubernetesVersions, denied: row.DeniedKubernetesVersions},
+		machineTypes:        nameRule{field: "machine_types", noun: "machine type", allowed: row.AllowedMachineTypes, denied: row.DeniedMachineTypes},
+		maxNodesPerNodePool: row.MaxNodesPerNodePool,
+		requiredLabels:      row.RequiredLabels,
+	}
+}
+
+// organizationPolicy loads the policy of the organization; an organization
+// without one gets the empty policy.
+func (s *Server) organizationPolicy(ctx context.Context, organizationID uuid.UUID) (*organizationPolicy, error) {
+	row, err := s.queries.OrganizationPolicyGet(ctx, db.OrganizationPolicyGetParams{OrganizationID: organizationID})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return organizationPolicyFromRow(&db.OrganizationPolicyGetRow{}), nil
+		}
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to get organization policy: %w", err))
+	}
+	return organizationPolicyFromRow(&row), nil
+}
+
+func (p *organizationPolicy) checkCreateCluster(req *organizationv1.CreateClusterRequest) []*organizationv1.PolicyViolation {
+	var violations []*organizationv1.PolicyViolation
+	violations = append(violations, p.regions.check(req.GetRegion())...)
+	violations = append(violations, p.kubernetesVersions.check(req.GetKubernetesVersion())...)
+	violations = append(violations, p.checkLabels("cluster", req.GetLabels())...)
+	return violations
+}
+
+// checkUpdateCluster only checks what the update changes.
+func (p *organizationPolicy) checkUpdateCluster(req *organizationv1.UpdateClusterRequest) []*organizationv1.PolicyViolation {
+	if !req.HasKubernetesVersion() {
+		return nil
+	}
+	return p.kubernetesVersions.check(req.GetKubernetesVersion())
+}
+
+func (p *organizationPolicy) checkScheduleClusterUpgrade(req *organizationv1.ScheduleClusterUpgradeRequest) []*organizationv1.PolicyViolation {
+	return p.kubernetesVersions.check(req.GetKubernetesVersion())
+}
+
+func (p *organizationPolicy) checkCreateNodePool(req *organizationv1.CreateNodePoolRequest) []*organizationv1.PolicyViolation {
+	var violations []*organizationv1.PolicyViolation
+	violations = append(violations, p.machineTypes.check(req.GetMachineType())...)
+	violations = append(violations, p.checkNodePoolSize(req.GetAutoscaleMax())...)
+	violations = append(violations, p.checkLabels("node pool", req.GetLabels())...)
+	return violations
+}
+
+func (p *organizationPolicy) checkUpdateNodePool(req *organizationv1.UpdateNodePoolRequest) []*organizationv1.PolicyViolation {
+	violations := p.checkNodePoolSize(req.GetAutoscaleMax())
+	if req.HasNodes() {
+		violations = append(violations, p.checkLabels("node pool", req.GetNodes().GetLabels())...)
+	}
+	return violations
+}
+
+func (p *organizationPolicy) checkNodePoolSize(autoscaleMax int32) []*organizationv1.PolicyViolation {
+	if !p.maxNodesPerNodePool.Valid || autoscaleMax <= p.maxNodesPerNodePool.Int32 {
+		return nil
+	}
+	return []*organizationv1.PolicyViolation{policyViolation("max_nodes_per_node_pool",
+		"node pool maximum of %d nodes exceeds the policy maximum of %d", autoscaleMax, p.maxNodesPerNodePool.Int32)}
+}
+
+func (p *organizationPolicy) checkLabels(noun string, labels map[string]string) []*organizationv1.PolicyViolation {
+	var missing []string
+	for _, key := range p.requiredLabels {
+		if _, ok := labels[key]; !ok {
+			missing = append(missing, key)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	slices.Sort(missing)
+	return []*organizationv1.PolicyViolation{policyViolation("required_labels",
+		"%s is missing required labels: %s", noun, strings.Join(missing, ", "))}
+}
+
+// check reports the value when a denied pattern matches it, and when the
+// allowed list is not empty and none of its patterns match.
+func (r nameRule) check(value string) []*organizationv1.PolicyViolation {
+	var violations []*organizationv1.PolicyViolation
+	if pattern, ok := matchPolicyPattern(r.denied, value); ok {
+		violations = append(violations, policyViolation("denied_"+r.field,
+			"%s %q is denied by %q", r.noun, value, pattern))
+	}
+	if len(r.allowed) > 0 {
+		if _, ok := matchPolicyPattern(r.allowed, value); !ok {
+			violations = append(violations, policyViolation("allowed_"+r.field,
+				"%s %q is not allowed; allowed are %s", r.noun, value, strings.Join(r.allowed, ", ")))
+		}
+	}
+	return violations
+}
+
+// matchPolicyPattern returns the first pattern that matches value. Patterns
+// are validated when the policy is stored, so a bad pattern matches nothing.
+func matchPolicyPattern(patterns []string, value string) (string, bool) {
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, value); ok {
+			return pattern, true
+		}
+	}
+	return "", false
+}
+
+func policyViolation(rule, format string, args ...any) *organizationv1.PolicyViolation {
+	return organizationv1.PolicyViolation_builder{
+		Rule:    rule,
+		Message: fmt.Sprintf(format, args...),
+	}.Build()
+}
+
+// policyError turns violations into a FailedPrecondition error carrying one
+// PolicyViolation detail per violation, or nil when there are none.
+func policyError(violations []*organizationv1.PolicyViolation) error {
+	if len(violations) == 0 {
+		return nil
+	}
+
+	messages := make([]string, 0, len(violations))
+	for _, v := range violations {
+		messages = append(messages, v.GetMessage())
+	}
+	connectErr := connect.NewError(connect.CodeFailedPrecondition,
+		fmt.Errorf("rejected by organization policy: %s", strings.Join(messages, "; ")))
+
+	for _, v := range violations {
+		detail, err := connect.NewErrorDetail(v)
+		if err != nil {
+			return connect.NewError(connect.CodeInternal, fmt.Errorf("failed to build policy error detail: %w", err))
+		}
+		connectErr.AddDetail(detail)
+	}
+	return connectErr
+}
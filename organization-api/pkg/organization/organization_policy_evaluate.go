@@ -0,0 +1,55 @@
+package organization
+
+import (
+	"context"
+	"fmt"
+
+	"connectrpc.com/connect"
+
+	"github.com/fundament-oss/fundament/common/authz"
+	organizationv1 "github.com/fundament-oss/fundament/organization-api/pkg/proto/gen/v1"
+)
+
+// EvaluateOrganizationPolicy reports the policy rules a request would break.
+// It only checks the policy: whether the catalog offers the region, version
+// or machine type, and whether the caller may send the request, is left to
+// the request itself.
+func (s *Server) EvaluateOrganizationPolicy(
+	ctx context.Context,
+	req *organizationv1.EvaluateOrganizationPolicyRequest,
+) (*organizationv1.EvaluateOrganizationPolicyResponse, error) {
+	organizationID, ok := OrganizationIDFromContext(ctx)
+	if !ok {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("organization_id missing from context"))
+	}
+
+	if err := s.checkPermission(ctx, authz.CanView(), authz.Organization(organizationID)); err != nil {
+		return nil, err
+	}
+
+	policy, err := s.organizationPolicy(ctx, organizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	var violations []*organizationv1.PolicyViolation
+	switch req.WhichRequest() {
+	case organizationv1.EvaluateOrganizationPolicyRequest_CreateCluster_case:
+		violations = policy.checkCreateCluster(req.GetCreateCluster())
+	case organizationv1.EvaluateOrganizationPolicyRequest_UpdateCluster_case:
+		violations = policy.checkUpdateCluster(req.GetUpdateCluster())
+	case organizationv1.EvaluateOrganizationPolicyRequest_ScheduleClusterUpgrade_case:
+		violations = policy.checkScheduleClusterUpgrade(req.GetScheduleClusterUpgrade())
+	case organizationv1.EvaluateOrganizationPolicyRequest_CreateNodePool_case:
+		violations = policy.checkCreateNodePool(req.GetCreateNodePool())
+	case organizationv1.EvaluateOrganizationPolicyRequest_UpdateNodePool_case:
+		violations = policy.checkUpdateNodePool(req.GetUpdateNodePool())
+	default:
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("request is required"))
+	}
+
+	return organizationv1.EvaluateOrganizationPolicyResponse_builder{
+		Allowed:    len(violations) == 0,
+		Violations: violations,
+	}.Build(), nil
+}
@@ -0,0 +1,46 @@
+package organization
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/fundament-oss/fundament/common/authz"
+	organizationv1 "github.com/fundament-oss/fundament/organization-api/pkg/proto/gen/v1"
+)
+
+func (s *Server) GetOrganizationPolicy(
+	ctx context.Context,
+	req *organizationv1.GetOrganizationPolicyRequest,
+) (*organizationv1.GetOrganizationPolicyResponse, error) {
+	organizationID := uuid.MustParse(req.GetId())
+
+	if err := s.checkPermission(ctx, authz.CanView(), authz.Organization(organizationID)); err != nil {
+		return nil, err
+	}
+
+	policy, err := s.organizationPolicy(ctx, organizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	return organizationv1.GetOrganizationPolicyResponse_builder{
+		Policy: policy.toProto(),
+	}.Build(), nil
+}
+
+func (p *organizationPolicy) toProto() *organizationv1.OrganizationPolicy {
+	policy := organizationv1.OrganizationPolicy_builder{
+		AllowedRegions:            p.regions.allowed,
+		DeniedRegions:             p.regions.denied,
+		AllowedKubernetesVersions: p.kubernetesVersions.allowed,
+		DeniedKubernetesVersions:  p.kubernetesVersions.denied,
+		AllowedMachineTypes:       p.machineTypes.allowed,
+		DeniedMachineTypes:        p.machineTypes.denied,
+		RequiredLabels:            p.requiredLabels,
+	}.Build()
+	if p.maxNodesPerNodePool.Valid {
+		policy.SetMaxNodesPerNodePool(p.maxNodesPerNodePool.Int32)
+	}
+	return policy
+}
@@ -0,0 +1,85 @@
+package organization
+
+import (
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	organizationv1 "github.com/fundament-oss/fundament/organization-api/pkg/proto/gen/v1"
+)
+
+func rules(violations []*organizationv1.PolicyViolation) []string {
+	var r []string
+	for _, v := range violations {
+		r = append(r, v.GetRule())
+	}
+	return r
+}
+
+func TestNameRule_Check(t *testing.T) {
+	t.Parallel()
+
+	r := nameRule{field: "regions", noun: "region", allowed: []string{"eu-*"}, denied: []string{"eu-south-?"}}
+
+	assert.Empty(t, r.check("eu-west-1"))
+	assert.Equal(t, []string{"denied_regions"}, rules(r.check("eu-south-1")))
+	assert.Equal(t, []string{"allowed_regions"}, rules(r.check("us-east-1")))
+
+	// An empty allowed list allows everything that is not denied.
+	assert.Empty(t, nameRule{field: "regions"}.check("us-east-1"))
+}
+
+func TestOrganizationPolicy_Checks(t *testing.T) {
+	t.Parallel()
+
+	p := &organizationPolicy{
+		maxNodesPerNodePool: pgtype.Int4{Int32: 5, Valid: true},
+		requiredLabels:      []string{"team", "cost-center"},
+	}
+
+	assert.Empty(t, p.checkNodePoolSize(5))
+	assert.Equal(t, []string{"max_nodes_per_node_pool"}, rules(p.checkNodePoolSize(6)))
+	assert.Empty(t, (&organizationPolicy{}).checkNodePoolSize(1000))
+
+	violations := p.checkLabels("cluster", map[string]string{"owner": "me"})
+	require.Len(t, violations, 1)
+	assert.Equal(t, "cluster is missing required labels: cost-center, team", violations[0].GetMessage())
+	assert.Empty(t, p.checkLabels("cluster", map[string]string{"team": "a", "cost-center": "b"}))
+
+	// Updates that do not change the version are not checked against it.
+	p.kubernetesVersions = nameRule{field: "kubernetes_versions", denied: []string{"*"}}
+	assert.Empty(t, p.checkUpdateCluster(organizationv1.UpdateClusterRequest_builder{}.Build()))
+
+	// Scheduled upgrades are held to the same versions as direct updates.
+	p.kubernetesVersions = nameRule{field: "kubernetes_versions", allowed: []string{"1.29*"}}
+	assert.Empty(t, p.checkScheduleClusterUpgrade(organizationv1.ScheduleClusterUpgradeRequest_builder{KubernetesVersion: "1.29.4"}.Build()))
+	assert.Equal(t, []string{"allowed_kubernetes_versions"}, rules(p.checkScheduleClusterUpgrade(
+		organizationv1.ScheduleClusterUpgradeRequest_builder{KubernetesVersion: "1.30.1"}.Build())))
+
+	// Node labels are only checked when an update replaces them.
+	assert.Empty(t, p.checkUpdateNodePool(organizationv1.UpdateNodePoolRequest_builder{AutoscaleMax: 3}.Build()))
+	assert.Equal(t, []string{"required_labels"}, rules(p.checkUpdateNodePool(organizationv1.UpdateNodePoolRequest_builder{
+		AutoscaleMax: 3,
+		Nodes:        organizationv1.NodeTemplate_builder{}.Build(),
+	}.Build())))
+}
+
+func TestPolicyError(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, policyError(nil))
+
+	err := policyError([]*organizationv1.PolicyViolation{
+		policyViolation("denied_regions", "region %q is denied", "eu-south-1"),
+		policyViolation("required_labels", "cluster is missing required labels: team"),
+	})
+
+	var connectErr *connect.Error
+	require.ErrorAs(t, err, &connectErr)
+	assert.Equal(t, connect.CodeFailedPrecondition, connectErr.Code())
+	assert.Equal(t, `rejected by organization policy: region "eu-south-1" is denied; cluster is missing required labels: team`, connectErr.Message())
+	assert.Len(t, connectErr.Details(), 2)
+}
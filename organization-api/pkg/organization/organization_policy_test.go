@@ -0,0 +1,153 @@
+package organization_test
+
+import (
+	"testing"
+
+	"connectrpc.com/connect"
+	organizationv1 "github.com/fundament-oss/fundament/organization-api/pkg/proto/gen/v1"
+	"github.com/fundament-oss/fundament/organization-api/pkg/proto/gen/v1/organizationv1connect"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func Test_OrganizationPolicy(t *testing.T) {
+	t.Parallel()
+
+	orgID := uuid.New()
+	userID := uuid.New()
+
+	env := newTestAPI(t,
+		WithOrganization(orgID, "test-org"),
+		WithUser(&UserArgs{ID: userID, Name: "test-user", OrgIDs: []uuid.UUID{orgID}}),
+	)
+
+	token := env.createAuthnToken(t, userID)
+	orgClient := organizationv1connect.NewOrganizationServiceClient(env.server.Client(), env.server.URL)
+	clusterClient := organizationv1connect.NewClusterServiceClient(env.server.Client(), env.server.URL)
+
+	// Without a policy everything is allowed.
+	getRes, err := orgClient.GetOrganizationPolicy(authedContext(token, orgID), organizationv1.GetOrganizationPolicyRequest_builder{
+		Id: orgID.String(),
+	}.Build())
+	require.NoError(t, err)
+	assert.Empty(t, getRes.GetPolicy().GetDeniedRegions())
+	assert.False(t, getRes.GetPolicy().HasMaxNodesPerNodePool())
+
+	_, err = orgClient.UpdateOrganizationPolicy(authedContext(token, orgID), organizationv1.UpdateOrganizationPolicyRequest_builder{
+		Id: orgID.String(),
+		Policy: organizationv1.OrganizationPolicy_builder{
+			DeniedRegions:       []string{"eu-west-*"},
+			MaxNodesPerNodePool: proto.Int32(5),
+			RequiredLabels:      []string{"team"},
+		}.Build(),
+	}.Build())
+	require.NoError(t, err)
+
+	getRes, err = orgClient.GetOrganizationPolicy(authedContext(token, orgID), organizationv1.GetOrganizationPolicyRequest_builder{
+		Id: orgID.String(),
+	}.Build())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"eu-west-*"}, getRes.GetPolicy().GetDeniedRegions())
+	assert.EqualValues(t, 5, getRes.GetPolicy().GetMaxNodesPerNodePool())
+	assert.Equal(t, []string{"team"}, getRes.GetPolicy().GetRequiredLabels())
+
+	clusterReq := organizationv1.CreateClusterRequest_builder{
+		Name:              "denied",
+		Region:            "eu-west-1",
+		KubernetesVersion: "1.28",
+	}.Build()
+
+	_, err = clusterClient.CreateCluster(authedContext(token, orgID), clusterReq)
+	var connectErr *connect.Error
+	require.ErrorAs(t, err, &connectErr)
+	require.Equal(t, connect.CodeFailedPrecondition, connectErr.Code())
+	assert.Equal(t, []string{"denied_regions", "required_labels"}, violationRules(t, connectErr))
+
+	// The dry run reports the same violations without creating anything.
+	evalRes, err := orgClient.EvaluateOrganizationPolicy(authedContext(token, orgID), organizationv1.EvaluateOrganizationPolicyRequest_builder{
+		CreateCluster: clusterReq,
+	}.Build())
+	require.NoError(t, err)
+	assert.False(t, evalRes.GetAllowed())
+	require.Len(t, evalRes.GetViolations(), 2)
+	assert.Equal(t, "denied_regions", evalRes.GetViolations()[0].GetRule())
+
+	evalRes, err = orgClient.EvaluateOrganizationPolicy(authedContext(token, orgID), organizationv1.EvaluateOrganizationPolicyRequest_builder{
+		CreateNodePool: organizationv1.CreateNodePoolRequest_builder{
+			ClusterId:    uuid.NewString(),
+			Name:         "workers",
+			MachineType:  "m1.large",
+			AutoscaleMin: 1,
+			AutoscaleMax: 10,
+			Labels:       map[string]string{"team": "payments"},
+		}.Build(),
+	}.Build())
+	require.NoError(t, err)
+	assert.False(t, evalRes.GetAllowed())
+	require.Len(t, evalRes.GetViolations(), 1)
+	assert.Equal(t, "max_nodes_per_node_pool", evalRes.GetViolations()[0].GetRule())
+
+	// Clearing the denied regions lets a labelled cluster through, and its
+	// labels are stored.
+	_, err = orgClient.UpdateOrganizationPolicy(authedContext(token, orgID), organizationv1.UpdateOrganizationPolicyRequest_builder{
+		Id: orgID.String(),
+		Policy: organizationv1.OrganizationPolicy_builder{
+			RequiredLabels: []string{"team"},
+		}.Build(),
+	}.Build())
+	require.NoError(t, err)
+
+	createRes, err := clusterClient.CreateCluster(authedContext(token, orgID), organizationv1.CreateClusterRequest_builder{
+		Name:              "allowed",
+		Region:            "eu-west-1",
+		KubernetesVersion: "1.28",
+		Labels:            map[string]string{"team": "payments"},
+	}.Build())
+	require.NoError(t, err)
+
+	clusterRes, err := clusterClient.GetCluster(authedContext(token, orgID), organizationv1.GetClusterRequest_builder{
+		ClusterId: createRes.GetClusterId(),
+	}.Build())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"team": "payments"}, clusterRes.GetCluster().GetLabels())
+}
+
+func Test_OrganizationPolicy_InvalidPattern(t *testing.T) {
+	t.Parallel()
+
+	orgID := uuid.New()
+	userID := uuid.New()
+
+	env := newTestAPI(t,
+		WithOrganization(orgID, "test-org"),
+		WithUser(&UserArgs{ID: userID, Name: "test-user", OrgIDs: []uuid.UUID{orgID}}),
+	)
+
+	token := env.createAuthnToken(t, userID)
+	orgClient := organizationv1connect.NewOrganizationServiceClient(env.server.Client(), env.server.URL)
+
+	_, err := orgClient.UpdateOrganizationPolicy(authedContext(token, orgID), organizationv1.UpdateOrganizationPolicyRequest_builder{
+		Id: orgID.String(),
+		Policy: organizationv1.OrganizationPolicy_builder{
+			AllowedMachineTypes: []string{"m1.[large"},
+		}.Build(),
+	}.Build())
+	require.Error(t, err)
+	assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+}
+
+func violationRules(t *testing.T, connectErr *connect.Error) []string {
+	t.Helper()
+
+	var rules []string
+	for _, detail := range connectErr.Details() {
+		value, err := detail.Value()
+		require.NoError(t, err)
+		violation, ok := value.(*organizationv1.PolicyViolation)
+		require.True(t, ok, "unexpected detail %T", value)
+		rules = append(rules, violation.GetRule())
+	}
+	return rules
+}
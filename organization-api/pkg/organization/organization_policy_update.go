@@ -0,0 +1,76 @@
+package organization
+
+import (
+	"context"
+	"fmt"
+	"path"
+
+	"connectrpc.com/connect"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/fundament-oss/fundament/common/authz"
+	db "github.com/fundament-oss/fundament/organization-api/pkg/db/gen"
+	organizationv1 "github.com/fundament-oss/fundament/organization-api/pkg/proto/gen/v1"
+)
+
+func (s *Server) UpdateOrganizationPolicy(
+	ctx context.Context,
+	req *organizationv1.UpdateOrganizationPolicyRequest,
+) (*organizationv1.UpdateOrganizationPolicyResponse, error) {
+	organizationID := uuid.MustParse(req.GetId())
+
+	if err := s.checkPermission(ctx, authz.CanEdit(), authz.Organization(organizationID)); err != nil {
+		return nil, err
+	}
+
+	policy := req.GetPolicy()
+	lists := []struct {
+		rule     string
+		patterns []string
+	}{
+		{"allowed_regions", policy.GetAllowedRegions()},
+		{"denied_regions", policy.GetDeniedRegions()},
+		{"allowed_kubernetes_versions", policy.GetAllowedKubernetesVersions()},
+		{"denied_kubernetes_versions", policy.GetDeniedKubernetesVersions()},
+		{"allowed_machine_types", policy.GetAllowedMachineTypes()},
+		{"denied_machine_types", policy.GetDeniedMachineTypes()},
+	}
+	for _, list := range lists {
+		for _, pattern := range list.patterns {
+			if _, err := path.Match(pattern, ""); pattern == "" || err != nil {
+				return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("%s: invalid pattern %q", list.rule, pattern))
+			}
+		}
+	}
+
+	// The text[] columns are NOT NULL, and pgx sends a nil slice as NULL.
+	params := db.OrganizationPolicyUpsertParams{
+		OrganizationID:            organizationID,
+		AllowedRegions:            nonNil(policy.GetAllowedRegions()),
+		DeniedRegions:             nonNil(policy.GetDeniedRegions()),
+		AllowedKubernetesVersions: nonNil(policy.GetAllowedKubernetesVersions()),
+		DeniedKubernetesVersions:  nonNil(policy.GetDeniedKubernetesVersions()),
+		AllowedMachineTypes:       nonNil(policy.GetAllowedMachineTypes()),
+		DeniedMachineTypes:        nonNil(policy.GetDeniedMachineTypes()),
+		MaxNodesPerNodePool:       pgtype.Int4{Int32: policy.GetMaxNodesPerNodePool(), Valid: policy.HasMaxNodesPerNodePool()},
+		RequiredLabels:            nonNil(policy.GetRequiredLabels()),
+	}
+
+	if err := s.queries.OrganizationPolicyUpsert(ctx, params); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to update organization policy: %w", err))
+	}
+
+	s.logger.InfoContext(ctx, "organization policy updated", "organization_id", organizationID)
+
+	return organizationv1.UpdateOrganizationPolicyResponse_builder{}.Build(), nil
+}
+
+// nonNil keeps pgx from sending a nil slice as NULL to a NOT NULL array
+// column.
+func nonNil(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
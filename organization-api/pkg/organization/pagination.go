@@ -0,0 +1,75 @@
+package organization
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// pageCursor is the keyset position a page token encodes: the sort key of
+// the last item on the previous page. Lists ordered by creation time set
+// Created, lists ordered by name set Name; ID breaks ties in both.
+type pageCursor struct {
+	Created time.Time `json:"c,omitzero"`
+	Name    string    `json:"n,omitempty"`
+	ID      uuid.UUID `json:"i"`
+}
+
+// decodePageToken parses a page_token; an empty token means the first page.
+func decodePageToken(token string) (*pageCursor, error) {
+	if token == "" {
+		return nil, nil
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid page_token"))
+	}
+	var cursor pageCursor
+	if err := json.Unmarshal(data, &cursor); err != nil || cursor.ID == uuid.Nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid page_token"))
+	}
+	return &cursor, nil
+}
+
+func encodePageToken(cursor pageCursor) string {
+	data, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// pageLimit is the LIMIT for a page of pageSize items. One extra row is
+// fetched to learn whether another page follows; 0 lists everything.
+func pageLimit(pageSize int32) pgtype.Int4 {
+	if pageSize == 0 {
+		return pgtype.Int4{}
+	}
+	return pgtype.Int4{Int32: pageSize + 1, Valid: true}
+}
+
+// nameFilter is the NULL-when-empty name_filter query parameter.
+func nameFilter(filter string) pgtype.Text {
+	return pgtype.Text{String: filter, Valid: filter != ""}
+}
+
+// createdCursorParams returns the (created, id) keyset parameters of a cursor.
+func createdCursorParams(cursor *pageCursor) (pgtype.Timestamptz, pgtype.UUID) {
+	if cursor == nil {
+		return pgtype.Timestamptz{}, pgtype.UUID{}
+	}
+	return pgtype.Timestamptz{Time: cursor.Created, Valid: true}, pgtype.UUID{Bytes: cursor.ID, Valid: true}
+}
+
+// trimPage cuts the extra row pageLimit asked for and returns the token for
+// the page after it, or "" when rows was the last page.
+func trimPage[T any](rows []T, pageSize int32, cursor func(*T) pageCursor) ([]T, string) {
+	if pageSize == 0 || len(rows) <= int(pageSize) {
+		return rows, ""
+	}
+	rows = rows[:pageSize]
+	return rows, encodePageToken(cursor(&rows[len(rows)-1]))
+}
@@ -0,0 +1,49 @@
+package organization
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPageToken_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	cursor := pageCursor{Created: time.Date(2026, 3, 1, 12, 0, 0, 123456000, time.UTC), ID: uuid.New()}
+
+	decoded, err := decodePageToken(encodePageToken(cursor))
+	require.NoError(t, err)
+	assert.True(t, cursor.Created.Equal(decoded.Created))
+	assert.Equal(t, cursor.ID, decoded.ID)
+
+	decoded, err = decodePageToken("")
+	require.NoError(t, err)
+	assert.Nil(t, decoded)
+
+	_, err = decodePageToken("e30") // {}
+	require.Error(t, err)
+}
+
+func TestTrimPage(t *testing.T) {
+	t.Parallel()
+
+	ids := []uuid.UUID{uuid.New(), uuid.New(), uuid.New()}
+	cursor := func(id *uuid.UUID) pageCursor { return pageCursor{ID: *id} }
+
+	page, next := trimPage(ids, 2, cursor)
+	assert.Equal(t, ids[:2], page)
+	decoded, err := decodePageToken(next)
+	require.NoError(t, err)
+	assert.Equal(t, ids[1], decoded.ID)
+
+	page, next = trimPage(ids, 3, cursor)
+	assert.Equal(t, ids, page)
+	assert.Empty(t, next)
+
+	page, next = trimPage(ids, 0, cursor)
+	assert.Equal(t, ids, page)
+	assert.Empty(t, next)
+}
@@ -0,0 +1,50 @@
+package organization
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"connectrpc.com/connect"
+	"github.com/jackc/pgx/v5"
+
+	db "github.com/fundament-oss/fundament/organization-api/pkg/db/gen"
+	organizationv1 "github.com/fundament-oss/fundament/organization-api/pkg/proto/gen/v1"
+)
+
+// GetPluginDefinitionApproval is called by plugin-controller with a
+// ServiceToken to ask whether its organization approved the target of an
+// upgrade that adds permissions. The organization is the one the token names
+// (see authenticateService); the request's organization_id is ignored.
+func (s *Server) GetPluginDefinitionApproval(
+	ctx context.Context,
+	req *organizationv1.GetPluginDefinitionApprovalRequest,
+) (*organizationv1.GetPluginDefinitionApprovalResponse, error) {
+	organizationID, ok := OrganizationIDFromContext(ctx)
+	if !ok {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("organization_id missing from context"))
+	}
+	name, version := req.GetPluginName(), req.GetPluginVersion()
+	if name == "" || version == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("plugin_name and plugin_version are required"))
+	}
+
+	row, _, err := s.activePluginDefinition(ctx, name, version)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.queries.PluginDefinitionApprovalGet(ctx, db.PluginDefinitionApprovalGetParams{
+		OrganizationID: organizationID, PluginDefinitionID: row.ID,
+	}); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return organizationv1.GetPluginDefinitionApprovalResponse_builder{}.Build(), nil
+		}
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("get plugin definition approval: %w", err))
+	}
+
+	return organizationv1.GetPluginDefinitionApprovalResponse_builder{
+		Approved:       true,
+		DefinitionHash: row.Hash,
+	}.Build(), nil
+}
@@ -0,0 +1,174 @@
+package organization_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	organizationv1 "github.com/fundament-oss/fundament/organization-api/pkg/proto/gen/v1"
+	"github.com/fundament-oss/fundament/organization-api/pkg/proto/gen/v1/organizationv1connect"
+)
+
+// versionedManifest is testManifest at version, with extraRBAC appended to its
+// rules.
+func versionedManifest(version, extraRBAC string) []byte {
+	return fmt.Appendf(nil, "apiVersion: fundament.io/v1\nkind: PluginDefinition\nmetadata:\n  name: %s\n  version: %s\nspec:\n  image: repo@sha256:deadbeefdeadbeefdeadbeefdeadbeefdeadbeefdeadbeefdeadbeefdeadbeef\n  permissions:\n    rbac:\n      - apiGroups: [cert-manager.io]\n        resources: [certificates]\n        verbs: [get]\n%s",
+		testPluginName, version, extraRBAC)
+}
+
+// serviceContext authenticates a request with a ServiceToken, which carries
+// its organization itself.
+func serviceContext(token string) context.Context {
+	ctx, callInfo := connect.NewClientContext(context.Background())
+	callInfo.RequestHeader().Set("Authorization", "Bearer "+token)
+	return ctx
+}
+
+func putDefinition(t *testing.T, client organizationv1connect.PluginServiceClient, ctx context.Context, pluginID uuid.UUID, version string, manifest []byte) string {
+	t.Helper()
+	resp, err := client.PutPluginDefinition(ctx, organizationv1.PutPluginDefinitionRequest_builder{
+		PluginId: pluginID.String(), PluginVersion: version, Manifest: manifest,
+	}.Build())
+	require.NoError(t, err)
+	return resp.GetHash()
+}
+
+func TestPluginDefinitionApproval(t *testing.T) {
+	t.Parallel()
+
+	orgID := uuid.New()
+	otherOrgID := uuid.New()
+	userID := uuid.New()
+
+	env := newTestAPI(t,
+		WithOrganization(orgID, "test-org"),
+		WithOrganization(otherOrgID, "other-org"),
+		WithUser(&UserArgs{ID: userID, Name: "test-user", OrgIDs: []uuid.UUID{orgID}}),
+	)
+
+	pluginID := seedCatalogPlugin(t, env, testPluginName, orgID)
+	token := env.createAuthnToken(t, userID)
+	client := newPluginServiceClient(env)
+	ctx := authedContext(token, orgID)
+
+	putDefinition(t, client, ctx, pluginID, "v1", versionedManifest("v1", ""))
+	v2Hash := putDefinition(t, client, ctx, pluginID, "v2", versionedManifest("v2",
+		"      - apiGroups: [\"\"]\n        resources: [secrets]\n        verbs: [get, list]\n"))
+
+	diff, err := client.DiffPluginDefinitions(ctx, organizationv1.DiffPluginDefinitionsRequest_builder{
+		PluginName: testPluginName, FromVersion: "v1", ToVersion: "v2",
+	}.Build())
+	require.NoError(t, err)
+	assert.True(t, diff.GetEscalates())
+	assert.False(t, diff.GetApproved())
+	assert.Equal(t, v2Hash, diff.GetToHash())
+	require.Len(t, diff.GetDiff().GetAddedRules(), 1)
+	assert.Equal(t, []string{"secrets"}, diff.GetDiff().GetAddedRules()[0].GetResources())
+	assert.Empty(t, diff.GetDiff().GetRemovedRules())
+
+	// Downgrading only removes access.
+	back, err := client.DiffPluginDefinitions(ctx, organizationv1.DiffPluginDefinitionsRequest_builder{
+		PluginName: testPluginName, FromVersion: "v2", ToVersion: "v1",
+	}.Build())
+	require.NoError(t, err)
+	assert.False(t, back.GetEscalates())
+	assert.Len(t, back.GetDiff().GetRemovedRules(), 1)
+
+	// plugin-controller's lookup with org's service token. The request names
+	// orgID every time: only the token decides whose approvals are read.
+	lookup := func(org uuid.UUID) *organizationv1.GetPluginDefinitionApprovalResponse {
+		t.Helper()
+		resp, err := client.GetPluginDefinitionApproval(serviceContext(env.createServiceToken(t, "plugin-controller", org)), organizationv1.GetPluginDefinitionApprovalRequest_builder{
+			OrganizationId: orgID.String(), PluginName: testPluginName, PluginVersion: "v2",
+		}.Build())
+		require.NoError(t, err)
+		return resp
+	}
+	assert.False(t, lookup(orgID).GetApproved())
+
+	_, err = client.ApprovePluginDefinition(ctx, organizationv1.ApprovePluginDefinitionRequest_builder{
+		PluginName: testPluginName, PluginVersion: "v2", DefinitionHash: "sha256:stale",
+	}.Build())
+	require.Error(t, err)
+	assert.Equal(t, connect.CodeFailedPrecondition, connect.CodeOf(err))
+
+	for range 2 {
+		_, err = client.ApprovePluginDefinition(ctx, organizationv1.ApprovePluginDefinitionRequest_builder{
+			PluginName: testPluginName, PluginVersion: "v2", DefinitionHash: v2Hash,
+		}.Build())
+		require.NoError(t, err, "approving is idempotent")
+	}
+
+	approval := lookup(orgID)
+	assert.True(t, approval.GetApproved())
+	assert.Equal(t, v2Hash, approval.GetDefinitionHash())
+	assert.False(t, lookup(otherOrgID).GetApproved(), "approvals are per organization")
+
+	diff, err = client.DiffPluginDefinitions(ctx, organizationv1.DiffPluginDefinitionsRequest_builder{
+		PluginName: testPluginName, FromVersion: "v1", ToVersion: "v2",
+	}.Build())
+	require.NoError(t, err)
+	assert.True(t, diff.GetApproved())
+
+	// Republishing v2 under another hash needs a fresh approval.
+	_, err = client.PutPluginDefinition(ctx, organizationv1.PutPluginDefinitionRequest_builder{
+		PluginId: pluginID.String(), PluginVersion: "v2", Replace: true,
+		Manifest: versionedManifest("v2", "      - apiGroups: [\"\"]\n        resources: [secrets]\n        verbs: [\"*\"]\n"),
+	}.Build())
+	require.NoError(t, err)
+	assert.False(t, lookup(orgID).GetApproved())
+}
+
+func TestPluginDefinitionApproval_InvalidRequests(t *testing.T) {
+	t.Parallel()
+
+	orgID := uuid.New()
+	userID := uuid.New()
+
+	env := newTestAPI(t,
+		WithOrganization(orgID, "test-org"),
+		WithUser(&UserArgs{ID: userID, Name: "test-user", OrgIDs: []uuid.UUID{orgID}}),
+	)
+
+	token := env.createAuthnToken(t, userID)
+	client := newPluginServiceClient(env)
+	ctx := authedContext(token, orgID)
+
+	_, err := client.DiffPluginDefinitions(ctx, organizationv1.DiffPluginDefinitionsRequest_builder{
+		PluginName: testPluginName, FromVersion: "v1",
+	}.Build())
+	assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+
+	_, err = client.DiffPluginDefinitions(ctx, organizationv1.DiffPluginDefinitionsRequest_builder{
+		PluginName: "nope", FromVersion: "v1", ToVersion: "v2",
+	}.Build())
+	assert.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+
+	// The approval lookup takes plugin-controller's service token only.
+	approvalReq := organizationv1.GetPluginDefinitionApprovalRequest_builder{
+		OrganizationId: orgID.String(), PluginName: testPluginName, PluginVersion: "v1",
+	}.Build()
+	_, err = client.GetPluginDefinitionApproval(context.Background(), approvalReq)
+	assert.Equal(t, connect.CodeUnauthenticated, connect.CodeOf(err))
+
+	_, err = client.GetPluginDefinitionApproval(ctx, approvalReq)
+	assert.Equal(t, connect.CodeUnauthenticated, connect.CodeOf(err), "user tokens are refused")
+
+	_, err = client.GetPluginDefinitionApproval(serviceContext(env.createServiceToken(t, "cluster-worker", orgID)), approvalReq)
+	assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+
+	_, err = client.GetPluginDefinitionApproval(serviceContext(env.createServiceToken(t, "plugin-controller", orgID)),
+		organizationv1.GetPluginDefinitionApprovalRequest_builder{PluginName: testPluginName}.Build())
+	assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+
+	// Approving needs a signed-in admin, unlike the lookup.
+	_, err = client.ApprovePluginDefinition(context.Background(), organizationv1.ApprovePluginDefinitionRequest_builder{
+		PluginName: testPluginName, PluginVersion: "v1", DefinitionHash: "sha256:x",
+	}.Build())
+	assert.Equal(t, connect.CodeUnauthenticated, connect.CodeOf(err))
+}
@@ -0,0 +1,60 @@
+package organization
+
+import (
+	"context"
+	"fmt"
+
+	"connectrpc.com/connect"
+
+	"github.com/fundament-oss/fundament/common/authz"
+	db "github.com/fundament-oss/fundament/organization-api/pkg/db/gen"
+	organizationv1 "github.com/fundament-oss/fundament/organization-api/pkg/proto/gen/v1"
+)
+
+func (s *Server) ApprovePluginDefinition(
+	ctx context.Context,
+	req *organizationv1.ApprovePluginDefinitionRequest,
+) (*organizationv1.ApprovePluginDefinitionResponse, error) {
+	organizationID, ok := OrganizationIDFromContext(ctx)
+	if !ok {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("organization_id missing from context"))
+	}
+	userID, ok := UserIDFromContext(ctx)
+	if !ok {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("user_id missing from context"))
+	}
+	name, version, hash := req.GetPluginName(), req.GetPluginVersion(), req.GetDefinitionHash()
+	if name == "" || version == "" || hash == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("plugin_name, plugin_version and definition_hash are required"))
+	}
+
+	if err := s.checkPermission(ctx, authz.CanApprovePluginDefinitions(), authz.Organization(organizationID)); err != nil {
+		return nil, err
+	}
+
+	row, _, err := s.activePluginDefinition(ctx, name, version)
+	if err != nil {
+		return nil, err
+	}
+	// A definition republished since the admin reviewed it has another hash;
+	// approving it would consent to a manifest nobody looked at.
+	if row.Hash != hash {
+		return nil, connect.NewError(connect.CodeFailedPrecondition,
+			fmt.Errorf("plugin definition %s@%s has hash %q, not %q; review the current definition and approve again", name, version, row.Hash, hash))
+	}
+
+	if err := s.queries.PluginDefinitionApprovalCreate(ctx, db.PluginDefinitionApprovalCreateParams{
+		OrganizationID: organizationID, PluginDefinitionID: row.ID, UserID: userID,
+	}); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("approve plugin definition: %w", err))
+	}
+
+	s.logger.InfoContext(ctx, "plugin definition approved",
+		"organization_id", organizationID,
+		"plugin", name,
+		"version", version,
+		"hash", hash,
+	)
+
+	return organizationv1.ApprovePluginDefinitionResponse_builder{}.Build(), nil
+}
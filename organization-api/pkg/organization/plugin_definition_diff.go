@@ -0,0 +1,93 @@
+package organization
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"connectrpc.com/connect"
+	"github.com/jackc/pgx/v5"
+
+	db "github.com/fundament-oss/fundament/organization-api/pkg/db/gen"
+	organizationv1 "github.com/fundament-oss/fundament/organization-api/pkg/proto/gen/v1"
+	"github.com/fundament-oss/fundament/plugin-sdk/pluginruntime"
+)
+
+// DiffPluginDefinitions compares the permissions of two published definitions
+// of a plugin, so an admin can review what re-pinning an installation grants
+// before approving the new version.
+func (s *Server) DiffPluginDefinitions(
+	ctx context.Context,
+	req *organizationv1.DiffPluginDefinitionsRequest,
+) (*organizationv1.DiffPluginDefinitionsResponse, error) {
+	organizationID, ok := OrganizationIDFromContext(ctx)
+	if !ok {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("organization_id missing from context"))
+	}
+	name := req.GetPluginName()
+	if name == "" || req.GetFromVersion() == "" || req.GetToVersion() == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("plugin_name, from_version and to_version are required"))
+	}
+
+	_, from, err := s.activePluginDefinition(ctx, name, req.GetFromVersion())
+	if err != nil {
+		return nil, err
+	}
+	toRow, to, err := s.activePluginDefinition(ctx, name, req.GetToVersion())
+	if err != nil {
+		return nil, err
+	}
+
+	approved := true
+	if _, err := s.queries.PluginDefinitionApprovalGet(ctx, db.PluginDefinitionApprovalGetParams{
+		OrganizationID: organizationID, PluginDefinitionID: toRow.ID,
+	}); err != nil {
+		if !errors.Is(err, pgx.ErrNoRows) {
+			return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("get plugin definition approval: %w", err))
+		}
+		approved = false
+	}
+
+	diff := pluginruntime.DiffPermissions(from.Spec, to.Spec)
+	return organizationv1.DiffPluginDefinitionsResponse_builder{
+		Diff:      permissionDiffToProto(&diff),
+		Escalates: diff.Escalates(),
+		Approved:  approved,
+		ToHash:    toRow.Hash,
+	}.Build(), nil
+}
+
+// activePluginDefinition loads and parses the active definition of a plugin
+// version, mapping a missing one to NotFound.
+func (s *Server) activePluginDefinition(
+	ctx context.Context,
+	name, version string,
+) (db.PluginDefinitionGetActiveRow, *pluginruntime.PluginDefinition, error) {
+	row, err := s.queries.PluginDefinitionGetActive(ctx, db.PluginDefinitionGetActiveParams{
+		Name: name, PluginVersion: version,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return row, nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("plugin definition %s@%s not found", name, version))
+		}
+		return row, nil, connect.NewError(connect.CodeInternal, fmt.Errorf("get plugin definition: %w", err))
+	}
+	def, err := pluginruntime.ParseDefinition(row.Manifest)
+	if err != nil {
+		return row, nil, connect.NewError(connect.CodeInternal, fmt.Errorf("parse stored manifest: %w", err))
+	}
+	return row, &def, nil
+}
+
+func permissionDiffToProto(diff *pluginruntime.PermissionDiff) *organizationv1.PluginPermissionDiff {
+	return organizationv1.PluginPermissionDiff_builder{
+		AddedRules:              policyRulesToProto(diff.AddedRules),
+		RemovedRules:            policyRulesToProto(diff.RemovedRules),
+		AddedCapabilities:       diff.AddedCapabilities,
+		RemovedCapabilities:     diff.RemovedCapabilities,
+		AddedCrds:               diff.AddedCRDs,
+		RemovedCrds:             diff.RemovedCRDs,
+		AddedAllowedResources:   allowedResourcesToProto(diff.AddedAllowedResources),
+		RemovedAllowedResources: allowedResourcesToProto(diff.RemovedAllowedResources),
+	}.Build()
+}
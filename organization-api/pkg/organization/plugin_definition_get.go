@@ -43,12 +43,6 @@ func (s *Server) GetPluginDefinition(
 }
 
 func pluginDefinitionToProto(def *pluginruntime.PluginDefinition) *organizationv1.PluginDefinition {
-	rbac := make([]*organizationv1.PluginPolicyRule, 0, len(def.Spec.Permissions.RBAC))
-	for _, r := range def.Spec.Permissions.RBAC {
-		rbac = append(rbac, organizationv1.PluginPolicyRule_builder{
-			ApiGroups: r.APIGroups, Resources: r.Resources, Verbs: r.Verbs,
-		}.Build())
-	}
 	mapMenu := func(entries []pluginruntime.MenuEntry) []*organizationv1.PluginMenuEntry {
 		out := make([]*organizationv1.PluginMenuEntry, 0, len(entries))
 		for _, e := range entries {
@@ -62,12 +56,6 @@ func pluginDefinitionToProto(def *pluginruntime.PluginDefinition) *organizationv
 	for k, c := range def.Spec.CustomComponents {
 		components[k] = organizationv1.PluginComponentMapping_builder{List: c.List, Detail: c.Detail, Create: c.Create}.Build()
 	}
-	allowed := make([]*organizationv1.PluginAllowedResource, 0, len(def.Spec.AllowedResources))
-	for _, a := range def.Spec.AllowedResources {
-		allowed = append(allowed, organizationv1.PluginAllowedResource_builder{
-			Group: a.Group, Version: a.Version, Resource: a.Resource, Verbs: a.Verbs,
-		}.Build())
-	}
 	uiHints := make(map[string]*organizationv1.PluginUIHint, len(def.Spec.UIHints))
 	for k, h := range def.Spec.UIHints {
 		formGroups := make([]*organizationv1.PluginFormGroup, 0, len(h.FormGroups))
@@ -101,11 +89,31 @@ func pluginDefinitionToProto(def *pluginruntime.PluginDefinition) *organizationv
 		}.Build(),
 		Image:            def.Spec.Image,
 		ImagePullPolicy:  def.Spec.ImagePullPolicy,
-		Permissions:      organizationv1.PluginPermissions_builder{Capabilities: def.Spec.Permissions.Capabilities, Rbac: rbac}.Build(),
+		Permissions:      organizationv1.PluginPermissions_builder{Capabilities: def.Spec.Permissions.Capabilities, Rbac: policyRulesToProto(def.Spec.Permissions.RBAC)}.Build(),
 		Menu:             organizationv1.PluginMenu_builder{Organization: mapMenu(def.Spec.Menu.Organization), Project: mapMenu(def.Spec.Menu.Project)}.Build(),
 		Crds:             def.Spec.CRDs,
 		CustomComponents: components,
-		AllowedResources: allowed,
+		AllowedResources: allowedResourcesToProto(def.Spec.AllowedResources),
 		UiHints:          uiHints,
 	}.Build()
 }
+
+func policyRulesToProto(rules []pluginruntime.PolicyRule) []*organizationv1.PluginPolicyRule {
+	out := make([]*organizationv1.PluginPolicyRule, 0, len(rules))
+	for _, r := range rules {
+		out = append(out, organizationv1.PluginPolicyRule_builder{
+			ApiGroups: r.APIGroups, Resources: r.Resources, Verbs: r.Verbs, ResourceNames: r.ResourceNames,
+		}.Build())
+	}
+	return out
+}
+
+func allowedResourcesToProto(resources []pluginruntime.AllowedResource) []*organizationv1.PluginAllowedResource {
+	out := make([]*organizationv1.PluginAllowedResource, 0, len(resources))
+	for _, a := range resources {
+		out = append(out, organizationv1.PluginAllowedResource_builder{
+			Group: a.Group, Version: a.Version, Resource: a.Resource, Verbs: a.Verbs,
+		}.Build())
+	}
+	return out
+}
@@ -6,6 +6,7 @@ import (
 
 	"connectrpc.com/connect"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
 	"golang.org/x/sync/errgroup"
 
 	db "github.com/fundament-oss/fundament/organization-api/pkg/db/gen"
@@ -16,6 +17,19 @@ func (s *Server) ListPlugins(
 	ctx context.Context,
 	req *organizationv1.ListPluginsRequest,
 ) (*organizationv1.ListPluginsResponse, error) {
+	cursor, err := decodePageToken(req.GetPageToken())
+	if err != nil {
+		return nil, err
+	}
+	params := db.PluginListParams{
+		NameFilter: nameFilter(req.GetNameFilter()),
+		PageLimit:  pageLimit(req.GetPageSize()),
+	}
+	if cursor != nil {
+		params.AfterSortName = pgtype.Text{String: cursor.Name, Valid: true}
+		params.AfterID = pgtype.UUID{Bytes: cursor.ID, Valid: true}
+	}
+
 	var (
 		plugins    []db.PluginListRow
 		tags       []db.PluginTagsListRow
@@ -26,7 +40,7 @@ func (s *Server) ListPlugins(
 
 	g.Go(func() error {
 		var err error
-		plugins, err = s.queries.PluginList(ctx)
+		plugins, err = s.queries.PluginList(ctx, params)
 		if err != nil {
 			return fmt.Errorf("querying plugins: %w", err)
 		}
@@ -55,6 +69,10 @@ func (s *Server) ListPlugins(
 		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to list plugins: %w", err))
 	}
 
+	plugins, nextPageToken := trimPage(plugins, req.GetPageSize(), func(row *db.PluginListRow) pageCursor {
+		return pageCursor{Name: row.SortName, ID: row.ID}
+	})
+
 	tagsByPlugin := buildTagsByPlugin(tags)
 	categoriesByPlugin := buildCategoriesByPlugin(categories)
 
@@ -64,7 +82,8 @@ func (s *Server) ListPlugins(
 	}
 
 	return organizationv1.ListPluginsResponse_builder{
-		Plugins: result,
+		Plugins:       result,
+		NextPageToken: nextPageToken,
 	}.Build(), nil
 }
 
@@ -31,6 +31,11 @@ func (s *Server) CreateProject(
 		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("user_id missing from context"))
 	}
 
+	organizationID, ok := OrganizationIDFromContext(ctx)
+	if !ok {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("organization_id missing from context"))
+	}
+
 	s.logger.DebugContext(ctx, "creating project with member",
 		"cluster_id", clusterID,
 		"user_id", userID,
@@ -45,6 +50,10 @@ func (s *Server) CreateProject(
 
 	qtx := s.queries.WithTx(tx)
 
+	if err := enforceOrganizationCap(ctx, qtx, organizationID, capProjects); err != nil {
+		return nil, err
+	}
+
 	alias := req.GetName()
 	if req.HasAlias() {
 		alias = req.GetAlias()
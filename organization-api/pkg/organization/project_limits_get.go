@@ -55,5 +55,29 @@ func projectLimitsFromRow(row *db.ProjectLimitsGetRow) *organizationv1.ProjectLi
 	if row.DefaultCpuLimitM.Valid {
 		limits.SetDefaultCpuLimitM(row.DefaultCpuLimitM.Int32)
 	}
+	if row.QuotaCpuRequestM.Valid {
+		limits.SetQuotaCpuRequestM(row.QuotaCpuRequestM.Int32)
+	}
+	if row.QuotaCpuLimitM.Valid {
+		limits.SetQuotaCpuLimitM(row.QuotaCpuLimitM.Int32)
+	}
+	if row.QuotaMemoryRequestMi.Valid {
+		limits.SetQuotaMemoryRequestMi(row.QuotaMemoryRequestMi.Int32)
+	}
+	if row.QuotaMemoryLimitMi.Valid {
+		limits.SetQuotaMemoryLimitMi(row.QuotaMemoryLimitMi.Int32)
+	}
+	if row.QuotaStorageGi.Valid {
+		limits.SetQuotaStorageGi(row.QuotaStorageGi.Int32)
+	}
+	if row.QuotaPods.Valid {
+		limits.SetQuotaPods(row.QuotaPods.Int32)
+	}
+	if row.QuotaPersistentVolumeClaims.Valid {
+		limits.SetQuotaPersistentVolumeClaims(row.QuotaPersistentVolumeClaims.Int32)
+	}
+	if row.QuotaServices.Valid {
+		limits.SetQuotaServices(row.QuotaServices.Int32)
+	}
 	return limits
 }
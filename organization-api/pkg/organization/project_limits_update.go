@@ -28,11 +28,19 @@ func (s *Server) UpdateProjectLimits(
 	}
 
 	params := db.ProjectLimitsUpsertParams{
-		ProjectID:              projectID,
-		DefaultMemoryRequestMi: pgtype.Int4{Int32: req.GetDefaultMemoryRequestMi(), Valid: req.HasDefaultMemoryRequestMi()},
-		DefaultMemoryLimitMi:   pgtype.Int4{Int32: req.GetDefaultMemoryLimitMi(), Valid: req.HasDefaultMemoryLimitMi()},
-		DefaultCpuRequestM:     pgtype.Int4{Int32: req.GetDefaultCpuRequestM(), Valid: req.HasDefaultCpuRequestM()},
-		DefaultCpuLimitM:       pgtype.Int4{Int32: req.GetDefaultCpuLimitM(), Valid: req.HasDefaultCpuLimitM()},
+		ProjectID:                   projectID,
+		DefaultMemoryRequestMi:      pgtype.Int4{Int32: req.GetDefaultMemoryRequestMi(), Valid: req.HasDefaultMemoryRequestMi()},
+		DefaultMemoryLimitMi:        pgtype.Int4{Int32: req.GetDefaultMemoryLimitMi(), Valid: req.HasDefaultMemoryLimitMi()},
+		DefaultCpuRequestM:          pgtype.Int4{Int32: req.GetDefaultCpuRequestM(), Valid: req.HasDefaultCpuRequestM()},
+		DefaultCpuLimitM:            pgtype.Int4{Int32: req.GetDefaultCpuLimitM(), Valid: req.HasDefaultCpuLimitM()},
+		QuotaCpuRequestM:            pgtype.Int4{Int32: req.GetQuotaCpuRequestM(), Valid: req.HasQuotaCpuRequestM()},
+		QuotaCpuLimitM:              pgtype.Int4{Int32: req.GetQuotaCpuLimitM(), Valid: req.HasQuotaCpuLimitM()},
+		QuotaMemoryRequestMi:        pgtype.Int4{Int32: req.GetQuotaMemoryRequestMi(), Valid: req.HasQuotaMemoryRequestMi()},
+		QuotaMemoryLimitMi:          pgtype.Int4{Int32: req.GetQuotaMemoryLimitMi(), Valid: req.HasQuotaMemoryLimitMi()},
+		QuotaStorageGi:              pgtype.Int4{Int32: req.GetQuotaStorageGi(), Valid: req.HasQuotaStorageGi()},
+		QuotaPods:                   pgtype.Int4{Int32: req.GetQuotaPods(), Valid: req.HasQuotaPods()},
+		QuotaPersistentVolumeClaims: pgtype.Int4{Int32: req.GetQuotaPersistentVolumeClaims(), Valid: req.HasQuotaPersistentVolumeClaims()},
+		QuotaServices:               pgtype.Int4{Int32: req.GetQuotaServices(), Valid: req.HasQuotaServices()},
 	}
 
 	if _, err := s.queries.ProjectLimitsUpsert(ctx, params); err != nil {
@@ -42,6 +50,10 @@ func (s *Server) UpdateProjectLimits(
 				return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("memory limit must be greater than or equal to memory request"))
 			case dbconst.ConstraintProjectLimitsCkCpuLimitGteRequest:
 				return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("CPU limit must be greater than or equal to CPU request"))
+			case dbconst.ConstraintProjectLimitsCkQuotaMemoryLimitGteRequest:
+				return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("memory quota limit must be greater than or equal to memory quota request"))
+			case dbconst.ConstraintProjectLimitsCkQuotaCpuLimitGteRequest:
+				return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("CPU quota limit must be greater than or equal to CPU quota request"))
 			}
 		}
 		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to update project limits: %w", err))
@@ -380,3 +380,96 @@ func Test_ProjectLimits_Update_CpuLimitLessThanRequest(t *testing.T) {
 	require.ErrorAs(t, err, &connectErr)
 	assert.Equal(t, connect.CodeInvalidArgument, connectErr.Code())
 }
+
+func Test_ProjectLimits_Update_Quota(t *testing.T) {
+	t.Parallel()
+
+	orgID := uuid.New()
+	userID := uuid.New()
+
+	env := newTestAPI(t,
+		WithOrganization(orgID, "test-org"),
+		WithUser(&UserArgs{ID: userID, Name: "test-user", OrgIDs: []uuid.UUID{orgID}}),
+	)
+
+	token := env.createAuthnToken(t, userID)
+	clusterClient := organizationv1connect.NewClusterServiceClient(env.server.Client(), env.server.URL)
+	projectClient := organizationv1connect.NewProjectServiceClient(env.server.Client(), env.server.URL)
+
+	clusterRes, err := clusterClient.CreateCluster(authedContext(token, orgID), organizationv1.CreateClusterRequest_builder{
+		Name: "test-cluster", Region: "eu-west-1", KubernetesVersion: "1.28",
+	}.Build())
+	require.NoError(t, err)
+
+	projectRes, err := projectClient.CreateProject(authedContext(token, orgID), organizationv1.CreateProjectRequest_builder{
+		ClusterId: clusterRes.GetClusterId(), Name: "test-project",
+	}.Build())
+	require.NoError(t, err)
+	projectID := projectRes.GetProjectId()
+
+	_, err = projectClient.UpdateProjectLimits(authedContext(token, orgID), organizationv1.UpdateProjectLimitsRequest_builder{
+		ProjectId:                   projectID,
+		DefaultCpuRequestM:          proto.Int32(100),
+		QuotaCpuRequestM:            proto.Int32(2000),
+		QuotaCpuLimitM:              proto.Int32(4000),
+		QuotaMemoryRequestMi:        proto.Int32(4096),
+		QuotaMemoryLimitMi:          proto.Int32(8192),
+		QuotaStorageGi:              proto.Int32(50),
+		QuotaPods:                   proto.Int32(20),
+		QuotaPersistentVolumeClaims: proto.Int32(5),
+		QuotaServices:               proto.Int32(10),
+	}.Build())
+	require.NoError(t, err)
+
+	getRes, err := projectClient.GetProjectLimits(authedContext(token, orgID), organizationv1.GetProjectLimitsRequest_builder{
+		ProjectId: projectID,
+	}.Build())
+	require.NoError(t, err)
+
+	limits := getRes.GetLimits()
+	assert.EqualValues(t, 100, limits.GetDefaultCpuRequestM())
+	assert.EqualValues(t, 2000, limits.GetQuotaCpuRequestM())
+	assert.EqualValues(t, 4000, limits.GetQuotaCpuLimitM())
+	assert.EqualValues(t, 4096, limits.GetQuotaMemoryRequestMi())
+	assert.EqualValues(t, 8192, limits.GetQuotaMemoryLimitMi())
+	assert.EqualValues(t, 50, limits.GetQuotaStorageGi())
+	assert.EqualValues(t, 20, limits.GetQuotaPods())
+	assert.EqualValues(t, 5, limits.GetQuotaPersistentVolumeClaims())
+	assert.EqualValues(t, 10, limits.GetQuotaServices())
+}
+
+func Test_ProjectLimits_Update_QuotaCpuLimitLessThanRequest(t *testing.T) {
+	t.Parallel()
+
+	orgID := uuid.New()
+	userID := uuid.New()
+
+	env := newTestAPI(t,
+		WithOrganization(orgID, "test-org"),
+		WithUser(&UserArgs{ID: userID, Name: "test-user", OrgIDs: []uuid.UUID{orgID}}),
+	)
+
+	token := env.createAuthnToken(t, userID)
+	clusterClient := organizationv1connect.NewClusterServiceClient(env.server.Client(), env.server.URL)
+	projectClient := organizationv1connect.NewProjectServiceClient(env.server.Client(), env.server.URL)
+
+	clusterRes, err := clusterClient.CreateCluster(authedContext(token, orgID), organizationv1.CreateClusterRequest_builder{
+		Name: "test-cluster", Region: "eu-west-1", KubernetesVersion: "1.28",
+	}.Build())
+	require.NoError(t, err)
+
+	projectRes, err := projectClient.CreateProject(authedContext(token, orgID), organizationv1.CreateProjectRequest_builder{
+		ClusterId: clusterRes.GetClusterId(), Name: "test-project",
+	}.Build())
+	require.NoError(t, err)
+
+	_, err = projectClient.UpdateProjectLimits(authedContext(token, orgID), organizationv1.UpdateProjectLimitsRequest_builder{
+		ProjectId:        projectRes.GetProjectId(),
+		QuotaCpuRequestM: proto.Int32(4000),
+		QuotaCpuLimitM:   proto.Int32(2000),
+	}.Build())
+
+	var connectErr *connect.Error
+	require.ErrorAs(t, err, &connectErr)
+	assert.Equal(t, connect.CodeInvalidArgument, connectErr.Code())
+}
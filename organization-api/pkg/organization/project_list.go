@@ -6,6 +6,7 @@ import (
 
 	"connectrpc.com/connect"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/fundament-oss/fundament/common/authz"
@@ -23,9 +24,34 @@ func (s *Server) ListProjects(
 		return nil, err
 	}
 
-	projects, err := s.queries.ProjectListByClusterID(ctx, db.ProjectListByClusterIDParams{ClusterID: clusterID})
+	cursor, err := decodePageToken(req.GetPageToken())
 	if err != nil {
-		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to list projects: %w", err))
+		return nil, err
+	}
+
+	projects, nextPageToken, err := allowedPage(req.GetPageSize(), cursor,
+		func(cursor *pageCursor, limit pgtype.Int4) ([]db.ProjectListByClusterIDRow, error) {
+			afterCreated, afterID := createdCursorParams(cursor)
+			projects, err := s.queries.ProjectListByClusterID(ctx, db.ProjectListByClusterIDParams{
+				ClusterID:    clusterID,
+				NameFilter:   nameFilter(req.GetNameFilter()),
+				AfterCreated: afterCreated,
+				AfterID:      afterID,
+				PageLimit:    limit,
+			})
+			if err != nil {
+				return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to list projects: %w", err))
+			}
+			return projects, nil
+		},
+		func(row *db.ProjectListByClusterIDRow) authz.Object { return authz.Project(row.ID) },
+		s.canView(ctx),
+		func(row *db.ProjectListByClusterIDRow) pageCursor {
+			return pageCursor{Created: row.Created.Time, ID: row.ID}
+		},
+	)
+	if err != nil {
+		return nil, err
 	}
 
 	result := make([]*organizationv1.Project, 0, len(projects))
@@ -34,7 +60,8 @@ func (s *Server) ListProjects(
 	}
 
 	return organizationv1.ListProjectsResponse_builder{
-		Projects: result,
+		Projects:      result,
+		NextPageToken: nextPageToken,
 	}.Build(), nil
 }
 
@@ -12,6 +12,7 @@ import (
 
 	"github.com/fundament-oss/fundament/common/authz"
 	"github.com/fundament-oss/fundament/common/dbconst"
+	"github.com/fundament-oss/fundament/common/rollback"
 	db "github.com/fundament-oss/fundament/organization-api/pkg/db/gen"
 	organizationv1 "github.com/fundament-oss/fundament/organization-api/pkg/proto/gen/v1"
 )
@@ -26,7 +27,15 @@ func (s *Server) RemoveProjectMember(
 		return nil, err
 	}
 
-	rowsAffected, err := s.queries.ProjectMemberDelete(ctx, db.ProjectMemberDeleteParams{ID: memberID})
+	tx, err := s.db.Pool.Begin(ctx)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to begin transaction: %w", err))
+	}
+	defer rollback.Rollback(ctx, tx, s.logger)
+
+	qtx := s.queries.WithTx(tx)
+
+	rowsAffected, err := qtx.ProjectMemberDelete(ctx, db.ProjectMemberDeleteParams{ID: memberID})
 	if err != nil {
 
 		if pgErr, ok := errors.AsType[*pgconn.PgError](err); ok {
@@ -43,6 +52,16 @@ func (s *Server) RemoveProjectMember(
 		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("member not found"))
 	}
 
+	if err := qtx.NamespaceRoleBindingDeleteByMember(ctx, db.NamespaceRoleBindingDeleteByMemberParams{
+		ProjectMemberID: memberID,
+	}); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to revoke namespace role bindings: %w", err))
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to commit transaction: %w", err))
+	}
+
 	s.logger.InfoContext(ctx, "project member removed",
 		"member_id", memberID,
 	)
@@ -0,0 +1,66 @@
+package organization
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"connectrpc.com/connect"
+	"github.com/google/uuid"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/fundament-oss/fundament/common/authz"
+	"github.com/fundament-oss/fundament/common/dbconst"
+	db "github.com/fundament-oss/fundament/organization-api/pkg/db/gen"
+	organizationv1 "github.com/fundament-oss/fundament/organization-api/pkg/proto/gen/v1"
+)
+
+func (s *Server) AddProjectTeam(
+	ctx context.Context,
+	req *organizationv1.AddProjectTeamRequest,
+) (*organizationv1.AddProjectTeamResponse, error) {
+	projectID := uuid.MustParse(req.GetProjectId())
+	teamID := uuid.MustParse(req.GetTeamId())
+	role := projectTeamRoleToDB(req.GetRole())
+
+	if err := s.checkPermission(ctx, authz.CanManageTeams(), authz.Project(projectID)); err != nil {
+		return nil, err
+	}
+
+	// Row-level security hides other organizations' teams; the foreign key
+	// alone would accept them.
+	if _, err := s.queries.TeamGetByID(ctx, db.TeamGetByIDParams{ID: teamID}); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("team not found"))
+		}
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to get team: %w", err))
+	}
+
+	projectTeamID, err := s.queries.ProjectTeamCreate(ctx, db.ProjectTeamCreateParams{
+		ProjectID: projectID,
+		TeamID:    teamID,
+		Role:      role,
+	})
+	if err != nil {
+		if pgErr, ok := errors.AsType[*pgconn.PgError](err); ok {
+			if pgErr.Code == pgerrcode.UniqueViolation && pgErr.ConstraintName == dbconst.ConstraintProjectTeamsUqProjectTeam {
+				return nil, connect.NewError(connect.CodeAlreadyExists,
+					fmt.Errorf("team already has a role in this project"))
+			}
+		}
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to add project team: %w", err))
+	}
+
+	s.logger.InfoContext(ctx, "project team added",
+		"project_team_id", projectTeamID,
+		"project_id", projectID,
+		"team_id", teamID,
+		"role", role,
+	)
+
+	return organizationv1.AddProjectTeamResponse_builder{
+		ProjectTeamId: projectTeamID.String(),
+	}.Build(), nil
+}
@@ -0,0 +1,38 @@
+package organization
+
+import (
+	"context"
+	"fmt"
+
+	"connectrpc.com/connect"
+	"github.com/google/uuid"
+
+	"github.com/fundament-oss/fundament/common/authz"
+	db "github.com/fundament-oss/fundament/organization-api/pkg/db/gen"
+	organizationv1 "github.com/fundament-oss/fundament/organization-api/pkg/proto/gen/v1"
+)
+
+func (s *Server) ListProjectTeams(
+	ctx context.Context,
+	req *organizationv1.ListProjectTeamsRequest,
+) (*organizationv1.ListProjectTeamsResponse, error) {
+	projectID := uuid.MustParse(req.GetProjectId())
+
+	if err := s.checkPermission(ctx, authz.CanListMembers(), authz.Project(projectID)); err != nil {
+		return nil, err
+	}
+
+	teams, err := s.queries.ProjectTeamList(ctx, db.ProjectTeamListParams{ProjectID: projectID})
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to list project teams: %w", err))
+	}
+
+	result := make([]*organizationv1.ProjectTeam, 0, len(teams))
+	for idx := range teams {
+		result = append(result, projectTeamFromRow(&teams[idx]))
+	}
+
+	return organizationv1.ListProjectTeamsResponse_builder{
+		Teams: result,
+	}.Build(), nil
+}
@@ -0,0 +1,46 @@
+package organization
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"connectrpc.com/connect"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/fundament-oss/fundament/common/authz"
+	db "github.com/fundament-oss/fundament/organization-api/pkg/db/gen"
+	organizationv1 "github.com/fundament-oss/fundament/organization-api/pkg/proto/gen/v1"
+)
+
+func (s *Server) RemoveProjectTeam(
+	ctx context.Context,
+	req *organizationv1.RemoveProjectTeamRequest,
+) (*organizationv1.RemoveProjectTeamResponse, error) {
+	projectTeamID := uuid.MustParse(req.GetProjectTeamId())
+
+	projectTeam, err := s.queries.ProjectTeamGetByID(ctx, db.ProjectTeamGetByIDParams{ID: projectTeamID})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("project team not found"))
+		}
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to get project team: %w", err))
+	}
+
+	if err := s.checkPermission(ctx, authz.CanManageTeams(), authz.Project(projectTeam.ProjectID)); err != nil {
+		return nil, err
+	}
+
+	rowsAffected, err := s.queries.ProjectTeamDelete(ctx, db.ProjectTeamDeleteParams{ID: projectTeamID})
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to remove project team: %w", err))
+	}
+	if rowsAffected != 1 {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("project team not found"))
+	}
+
+	s.logger.InfoContext(ctx, "project team removed", "project_team_id", projectTeamID)
+
+	return organizationv1.RemoveProjectTeamResponse_builder{}.Build(), nil
+}
@@ -6,6 +6,7 @@ import (
 
 	"connectrpc.com/connect"
 	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	organizationv1 "github.com/fundament-oss/fundament/organization-api/pkg/proto/gen/v1"
 )
@@ -13,7 +14,8 @@ import (
 // ListRegions returns the region catalog with per-region offerings (kubernetes
 // versions + machine types), text-only: the names are exactly what the create
 // endpoints accept. Global catalog data: authenticated like every RPC, but no
-// permission check or org filter (same pattern as ListPresets).
+// permission check or org filter (same pattern as ListPresets). Entries past
+// their deprecation date are left out; the others carry the date.
 func (s *Server) ListRegions(
 	ctx context.Context,
 	req *organizationv1.ListRegionsRequest,
@@ -34,26 +36,42 @@ func (s *Server) ListRegions(
 	}
 
 	versionsByRegion := make(map[uuid.UUID][]string)
+	versionDeprecations := make(map[uuid.UUID]map[string]*timestamppb.Timestamp)
 	for _, v := range versions {
 		versionsByRegion[v.RegionID] = append(versionsByRegion[v.RegionID], v.Version)
+		if v.Deprecated.Valid {
+			if versionDeprecations[v.RegionID] == nil {
+				versionDeprecations[v.RegionID] = make(map[string]*timestamppb.Timestamp)
+			}
+			versionDeprecations[v.RegionID][v.Version] = timestamppb.New(v.Deprecated.Time)
+		}
 	}
 
 	machineTypesByRegion := make(map[uuid.UUID][]*organizationv1.RegionMachineType)
 	for _, mt := range machineTypes {
-		machineTypesByRegion[mt.RegionID] = append(machineTypesByRegion[mt.RegionID], organizationv1.RegionMachineType_builder{
+		machineType := organizationv1.RegionMachineType_builder{
 			Name:   mt.Name,
 			Lcpu:   mt.Lcpu,
 			Memory: mt.Memory,
-		}.Build())
+		}.Build()
+		if mt.Deprecated.Valid {
+			machineType.SetDeprecated(timestamppb.New(mt.Deprecated.Time))
+		}
+		machineTypesByRegion[mt.RegionID] = append(machineTypesByRegion[mt.RegionID], machineType)
 	}
 
 	result := make([]*organizationv1.Region, 0, len(regions))
 	for _, r := range regions {
-		result = append(result, organizationv1.Region_builder{
-			Name:               r.Name,
-			KubernetesVersions: versionsByRegion[r.ID],
-			MachineTypes:       machineTypesByRegion[r.ID],
-		}.Build())
+		region := organizationv1.Region_builder{
+			Name:                          r.Name,
+			KubernetesVersions:            versionsByRegion[r.ID],
+			MachineTypes:                  machineTypesByRegion[r.ID],
+			KubernetesVersionDeprecations: versionDeprecations[r.ID],
+		}.Build()
+		if r.Deprecated.Valid {
+			region.SetDeprecated(timestamppb.New(r.Deprecated.Time))
+		}
+		result = append(result, region)
 	}
 
 	return organizationv1.ListRegionsResponse_builder{
@@ -0,0 +1,108 @@
+package organization_test
+
+import (
+	"slices"
+	"testing"
+
+	"connectrpc.com/connect"
+	organizationv1 "github.com/fundament-oss/fundament/organization-api/pkg/proto/gen/v1"
+	"github.com/fundament-oss/fundament/organization-api/pkg/proto/gen/v1/organizationv1connect"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ListRegions_Deprecation(t *testing.T) {
+	t.Parallel()
+
+	orgID := uuid.New()
+	userID := uuid.New()
+
+	env := newTestAPI(t,
+		WithOrganization(orgID, "test-org"),
+		WithUser(&UserArgs{ID: userID, Name: "test-user", OrgIDs: []uuid.UUID{orgID}}),
+	)
+
+	token := env.createAuthnToken(t, userID)
+	client := organizationv1connect.NewClusterServiceClient(env.server.Client(), env.server.URL)
+
+	_, err := env.adminPool.Exec(t.Context(), `
+		UPDATE catalog.kubernetes_versions SET deprecated = CASE version
+			WHEN '1.29' THEN now() + interval '30 days'
+			WHEN '1.31.0' THEN now() - interval '1 day'
+		END
+		WHERE version IN ('1.29', '1.31.0')`)
+	require.NoError(t, err)
+	_, err = env.adminPool.Exec(t.Context(),
+		"UPDATE catalog.machine_types SET deprecated = now() - interval '1 day' WHERE name = 'n1-standard-1'")
+	require.NoError(t, err)
+
+	findRegion := func(name string) *organizationv1.Region {
+		t.Helper()
+		res, err := client.ListRegions(authedContext(token, orgID), organizationv1.ListRegionsRequest_builder{}.Build())
+		require.NoError(t, err)
+		i := slices.IndexFunc(res.GetRegions(), func(r *organizationv1.Region) bool { return r.GetName() == name })
+		if i < 0 {
+			return nil
+		}
+		return res.GetRegions()[i]
+	}
+
+	region := findRegion("eu-west-1")
+	require.NotNil(t, region)
+	assert.Equal(t, []string{"1.28", "1.29"}, region.GetKubernetesVersions())
+	assert.Len(t, region.GetKubernetesVersionDeprecations(), 1)
+	assert.Contains(t, region.GetKubernetesVersionDeprecations(), "1.29")
+	assert.False(t, region.HasDeprecated())
+	require.Len(t, region.GetMachineTypes(), 1)
+	assert.Equal(t, "n1-standard-2", region.GetMachineTypes()[0].GetName())
+
+	// A version past its deprecation date is no longer offered; one with a date
+	// ahead still is.
+	_, err = client.CreateCluster(authedContext(token, orgID), organizationv1.CreateClusterRequest_builder{
+		Name:              "past",
+		Region:            "eu-west-1",
+		KubernetesVersion: "1.31.0",
+	}.Build())
+	assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+
+	clusterRes, err := client.CreateCluster(authedContext(token, orgID), organizationv1.CreateClusterRequest_builder{
+		Name:              "ahead",
+		Region:            "eu-west-1",
+		KubernetesVersion: "1.29",
+	}.Build())
+	require.NoError(t, err)
+
+	_, err = client.CreateNodePool(authedContext(token, orgID), organizationv1.CreateNodePoolRequest_builder{
+		ClusterId:    clusterRes.GetClusterId(),
+		Name:         "deprecated",
+		MachineType:  "n1-standard-1",
+		AutoscaleMin: 1,
+		AutoscaleMax: 1,
+	}.Build())
+	assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+
+	// A deprecated region takes no new clusters, but its clusters still get
+	// node pools.
+	_, err = env.adminPool.Exec(t.Context(),
+		"UPDATE catalog.regions SET deprecated = now() - interval '1 day' WHERE name = 'eu-west-1'")
+	require.NoError(t, err)
+
+	assert.Nil(t, findRegion("eu-west-1"))
+
+	_, err = client.CreateCluster(authedContext(token, orgID), organizationv1.CreateClusterRequest_builder{
+		Name:              "deprecated-region",
+		Region:            "eu-west-1",
+		KubernetesVersion: "1.28",
+	}.Build())
+	assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+
+	_, err = client.CreateNodePool(authedContext(token, orgID), organizationv1.CreateNodePoolRequest_builder{
+		ClusterId:    clusterRes.GetClusterId(),
+		Name:         "workers",
+		MachineType:  "n1-standard-2",
+		AutoscaleMin: 1,
+		AutoscaleMax: 1,
+	}.Build())
+	require.NoError(t, err)
+}
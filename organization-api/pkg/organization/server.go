@@ -9,10 +9,12 @@ import (
 	"connectrpc.com/connect"
 	"connectrpc.com/grpcreflect"
 	"connectrpc.com/validate"
+	"github.com/fundament-oss/fundament/common/audit"
 	"github.com/fundament-oss/fundament/common/auth"
 	"github.com/fundament-oss/fundament/common/authz"
 	"github.com/fundament-oss/fundament/common/circuitbreaker"
 	"github.com/fundament-oss/fundament/common/connectrecovery"
+	"github.com/fundament-oss/fundament/common/dbconst"
 	"github.com/fundament-oss/fundament/common/idempotency"
 	"github.com/fundament-oss/fundament/common/psqldb"
 	"github.com/fundament-oss/fundament/organization-api/pkg/clock"
@@ -25,7 +27,7 @@ import (
 )
 
 type Config struct {
-	JWTSecret            []byte
+	JWTKeys              auth.Keys
 	CORSAllowedOrigins   []string
 	Clock                clock.Clock
 	MockPrometheusClient *prom.MockClient
@@ -43,6 +45,7 @@ type Server struct {
 	authValidator  *auth.Validator
 	authz          *authz.Client
 	circuitBreaker *circuitbreaker.Breaker
+	auditRecorder  audit.Recorder
 	clock          clock.Clock
 	handler        http.Handler
 	mockPromClient *prom.MockClient
@@ -50,6 +53,7 @@ type Server struct {
 	promOpts       []prom.Option
 	gardener       gardener.Client
 	perShoot       *perShootClients
+	decisions      *DecisionCache
 }
 
 // Option configures optional Server dependencies.
@@ -62,6 +66,21 @@ func WithCircuitBreaker(b *circuitbreaker.Breaker) Option {
 	}
 }
 
+// WithAuditLog records every mutating request in the audit log.
+func WithAuditLog(r audit.Recorder) Option {
+	return func(s *Server) {
+		s.auditRecorder = r
+	}
+}
+
+// WithDecisionCache caches the authorization decisions list endpoints filter
+// their items by.
+func WithDecisionCache(c *DecisionCache) Option {
+	return func(s *Server) {
+		s.decisions = c
+	}
+}
+
 func New(logger *slog.Logger, cfg *Config, database *psqldb.DB, authzClient *authz.Client, idempotencyStore *idempotency.Store, opts ...Option) (*Server, error) {
 	clk := cfg.Clock
 	if clk == nil {
@@ -87,7 +106,7 @@ func New(logger *slog.Logger, cfg *Config, database *psqldb.DB, authzClient *aut
 		config:         cfg,
 		db:             database,
 		queries:        db.New(database.Pool),
-		authValidator:  auth.NewValidatorForAudience(cfg.JWTSecret, auth.ConsoleAuthCookieName, auth.ConsoleIssuer, auth.TokenTypeUser, logger),
+		authValidator:  auth.NewValidatorForAudience(cfg.JWTKeys, auth.ConsoleAuthCookieName, auth.ConsoleIssuer, auth.TokenTypeUser, logger),
 		authz:          authzClient,
 		clock:          clk,
 		mockPromClient: cfg.MockPrometheusClient,
@@ -121,8 +140,11 @@ func New(logger *slog.Logger, cfg *Config, database *psqldb.DB, authzClient *aut
 		chain = append(chain, circuitbreaker.NewInterceptor(s.circuitBreaker))
 	}
 
+	// The audit interceptor runs right after auth so that it sees the user
+	// and organization, and also records requests that fail validation.
 	chain = append(chain,
 		s.authInterceptor(),
+		audit.NewInterceptor(logger, s.auditRecorder, dbconst.AuditEventSource_OrganizationApi, UserIDFromContext, OrganizationIDFromContext),
 		validate.NewInterceptor(),
 		loggingInterceptor,
 		idempotency.NewInterceptor(logger, idempotencyStore, UserIDFromContext, procedures),
@@ -152,6 +174,10 @@ func New(logger *slog.Logger, cfg *Config, database *psqldb.DB, authzClient *aut
 		"organization.v1.APIKeyService",
 		"organization.v1.NamespaceService",
 		"organization.v1.MetricsService",
+		"organization.v1.AuditService",
+		"organization.v1.WebhookService",
+		"organization.v1.TeamService",
+		"organization.v1.AccessElevationService",
 	)
 	reflectPath, reflectHandler := grpcreflect.NewHandlerV1(reflector)
 	mux.Handle(reflectPath, reflectHandler)
@@ -176,6 +202,18 @@ func New(logger *slog.Logger, cfg *Config, database *psqldb.DB, authzClient *aut
 	metricsPath, metricsHandler := organizationv1connect.NewMetricsServiceHandler(s, interceptors)
 	mux.Handle(metricsPath, metricsHandler)
 
+	auditPath, auditHandler := organizationv1connect.NewAuditServiceHandler(s, interceptors)
+	mux.Handle(auditPath, auditHandler)
+
+	webhookPath, webhookHandler := organizationv1connect.NewWebhookServiceHandler(s, interceptors)
+	mux.Handle(webhookPath, webhookHandler)
+
+	teamPath, teamHandler := organizationv1connect.NewTeamServiceHandler(s, interceptors)
+	mux.Handle(teamPath, teamHandler)
+
+	accessElevationPath, accessElevationHandler := organizationv1connect.NewAccessElevationServiceHandler(s, interceptors)
+	mux.Handle(accessElevationPath, accessElevationHandler)
+
 	corsHandler := cors.New(cors.Options{
 		AllowedOrigins:   cfg.CORSAllowedOrigins,
 		AllowedMethods:   []string{"GET", "POST"},
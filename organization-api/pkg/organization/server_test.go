@@ -11,6 +11,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/fundament-oss/fundament/common/audit"
 	"github.com/fundament-oss/fundament/common/auth"
 	"github.com/fundament-oss/fundament/common/idempotency"
 	"github.com/golang-jwt/jwt/v5"
@@ -45,6 +46,7 @@ type apiOptions struct {
 	users           map[uuid.UUID]testUser
 	clock           clock.Clock
 	idempotency     bool
+	auditLog        bool
 	kubeAPIProxyURL string
 	prometheusURL   string
 	gardenerClient  gardener.Client
@@ -112,6 +114,12 @@ func WithIdempotency() APIOption {
 	}
 }
 
+func WithAuditLog() APIOption {
+	return func(o *apiOptions) {
+		o.auditLog = true
+	}
+}
+
 func WithKubeAPIProxy(url string) APIOption {
 	return func(o *apiOptions) {
 		o.kubeAPIProxyURL = url
@@ -148,7 +156,7 @@ func newTestAPI(t *testing.T, options ...APIOption) *testEnv {
 	jwtSecret := []byte(uuid.New().String())
 
 	organizationCfg := &organization.Config{
-		JWTSecret:          jwtSecret,
+		JWTKeys:            auth.SharedSecret(jwtSecret),
 		CORSAllowedOrigins: []string{"*"},
 		Clock:              opts.clock,
 		KubeAPIProxyURL:    opts.kubeAPIProxyURL,
@@ -161,7 +169,12 @@ func newTestAPI(t *testing.T, options ...APIOption) *testEnv {
 		idempotencyStore = idempotency.NewStore(testDb.Pool, idempotency.Config{}, testLogger)
 	}
 
-	organizationServer, err := organization.New(testLogger, organizationCfg, testDb, nil, idempotencyStore)
+	var serverOpts []organization.Option
+	if opts.auditLog {
+		serverOpts = append(serverOpts, organization.WithAuditLog(audit.NewStore(testDb.Pool, testLogger)))
+	}
+
+	organizationServer, err := organization.New(testLogger, organizationCfg, testDb, nil, idempotencyStore, serverOpts...)
 	require.NoError(t, err)
 
 	ts := httptest.NewServer(organizationServer.Handler())
@@ -259,6 +272,13 @@ func testNameToDbName(testName string) string {
 
 func (e *testEnv) createAuthnToken(t *testing.T, userID uuid.UUID) string {
 	t.Helper()
+	return e.createScopedAuthnToken(t, userID, nil)
+}
+
+// createScopedAuthnToken creates a token as authn-api exchanges it for an API
+// key with the given scope.
+func (e *testEnv) createScopedAuthnToken(t *testing.T, userID uuid.UUID, scope *auth.Scope) string {
+	t.Helper()
 
 	user, ok := e.users[userID]
 	require.True(t, ok, "user %s not found in test env", userID)
@@ -275,6 +295,7 @@ func (e *testEnv) createAuthnToken(t *testing.T, userID uuid.UUID) string {
 		},
 		OrganizationIDs: user.OrgIDs,
 		Name:            user.Name,
+		Scope:           scope,
 	}
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
@@ -283,3 +304,26 @@ func (e *testEnv) createAuthnToken(t *testing.T, userID uuid.UUID) string {
 
 	return signed
 }
+
+// createServiceToken creates a ServiceToken issued to subject for
+// organizationID, as plugin-controller presents it.
+func (e *testEnv) createServiceToken(t *testing.T, subject string, organizationID uuid.UUID) string {
+	t.Helper()
+
+	now := time.Now()
+	claims := auth.ServiceClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    auth.ConsoleIssuer,
+			Subject:   subject,
+			Audience:  jwt.ClaimStrings{auth.TokenTypeService},
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(time.Hour)),
+		},
+		OrganizationID: organizationID,
+	}
+
+	signed, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(e.jwtSecret)
+	require.NoError(t, err)
+
+	return signed
+}
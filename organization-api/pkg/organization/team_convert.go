@@ -0,0 +1,95 @@
+package organization
+
+import (
+	"github.com/jackc/pgx/v5/pgtype"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/fundament-oss/fundament/common/dbconst"
+	db "github.com/fundament-oss/fundament/organization-api/pkg/db/gen"
+	organizationv1 "github.com/fundament-oss/fundament/organization-api/pkg/proto/gen/v1"
+)
+
+// teamFields are the columns TeamList and TeamGetByID share.
+type teamFields interface {
+	db.TeamListRow | db.TeamGetByIDRow
+}
+
+func teamFromRow[T teamFields](row *T) *organizationv1.Team {
+	r := db.TeamListRow(*row)
+
+	team := organizationv1.Team_builder{
+		Id:          r.ID.String(),
+		Name:        r.Name,
+		Permission:  r.Permission.String,
+		MemberCount: r.MemberCount,
+		Created:     timestamppb.New(r.Created.Time),
+	}.Build()
+
+	if r.OidcGroup.Valid {
+		team.SetOidcGroup(r.OidcGroup.String)
+	}
+	return team
+}
+
+// optionalText maps an empty string to NULL, for the nullable permission and
+// oidc_group columns.
+func optionalText(value string) pgtype.Text {
+	return pgtype.Text{String: value, Valid: value != ""}
+}
+
+func teamMemberFromRow(row *db.TeamMemberListRow) *organizationv1.TeamMember {
+	return organizationv1.TeamMember_builder{
+		Id:       row.ID.String(),
+		TeamId:   row.TeamID.String(),
+		UserId:   row.UserID.String(),
+		UserName: row.UserName,
+		Source:   teamMemberSourceFromDB(row.Source),
+		Created:  timestamppb.New(row.Created.Time),
+	}.Build()
+}
+
+func teamMemberSourceFromDB(source dbconst.TeamMemberSource) organizationv1.TeamMemberSource {
+	switch source {
+	case dbconst.TeamMemberSource_Manual:
+		return organizationv1.TeamMemberSource_TEAM_MEMBER_SOURCE_MANUAL
+	case dbconst.TeamMemberSource_Oidc:
+		return organizationv1.TeamMemberSource_TEAM_MEMBER_SOURCE_OIDC
+	case dbconst.TeamMemberSource_Scim:
+		return organizationv1.TeamMemberSource_TEAM_MEMBER_SOURCE_SCIM
+	default:
+		panic("unknown dbconst team member source")
+	}
+}
+
+func projectTeamFromRow(row *db.ProjectTeamListRow) *organizationv1.ProjectTeam {
+	return organizationv1.ProjectTeam_builder{
+		Id:        row.ID.String(),
+		ProjectId: row.ProjectID.String(),
+		TeamId:    row.TeamID.String(),
+		TeamName:  row.TeamName,
+		Role:      projectTeamRoleFromDB(row.Role),
+		Created:   timestamppb.New(row.Created.Time),
+	}.Build()
+}
+
+func projectTeamRoleFromDB(role dbconst.ProjectTeamRole) organizationv1.ProjectMemberRole {
+	switch role {
+	case dbconst.ProjectTeamRole_Admin:
+		return organizationv1.ProjectMemberRole_PROJECT_MEMBER_ROLE_ADMIN
+	case dbconst.ProjectTeamRole_Viewer:
+		return organizationv1.ProjectMemberRole_PROJECT_MEMBER_ROLE_VIEWER
+	default:
+		panic("unknown dbconst project team role")
+	}
+}
+
+func projectTeamRoleToDB(role organizationv1.ProjectMemberRole) dbconst.ProjectTeamRole {
+	switch role {
+	case organizationv1.ProjectMemberRole_PROJECT_MEMBER_ROLE_ADMIN:
+		return dbconst.ProjectTeamRole_Admin
+	case organizationv1.ProjectMemberRole_PROJECT_MEMBER_ROLE_VIEWER:
+		return dbconst.ProjectTeamRole_Viewer
+	default:
+		panic("unknown proto project team role")
+	}
+}
@@ -0,0 +1,57 @@
+package organization
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"connectrpc.com/connect"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/fundament-oss/fundament/common/authz"
+	"github.com/fundament-oss/fundament/common/dbconst"
+	db "github.com/fundament-oss/fundament/organization-api/pkg/db/gen"
+	organizationv1 "github.com/fundament-oss/fundament/organization-api/pkg/proto/gen/v1"
+)
+
+func (s *Server) CreateTeam(
+	ctx context.Context,
+	req *organizationv1.CreateTeamRequest,
+) (*organizationv1.CreateTeamResponse, error) {
+	organizationID, ok := OrganizationIDFromContext(ctx)
+	if !ok {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("organization_id missing from context"))
+	}
+
+	if err := s.checkPermission(ctx, authz.CanManageTeams(), authz.Organization(organizationID)); err != nil {
+		return nil, err
+	}
+
+	teamID, err := s.queries.TeamCreate(ctx, db.TeamCreateParams{
+		OrganizationID: organizationID,
+		Name:           req.GetName(),
+		Permission:     optionalText(req.GetPermission()),
+		OidcGroup:      optionalText(req.GetOidcGroup()),
+	})
+	if err != nil {
+		if pgErr, ok := errors.AsType[*pgconn.PgError](err); ok {
+			if pgErr.Code == pgerrcode.UniqueViolation && pgErr.ConstraintName == dbconst.ConstraintTeamsUqOrganizationName {
+				return nil, connect.NewError(connect.CodeAlreadyExists,
+					fmt.Errorf("a team with this name already exists"))
+			}
+		}
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to create team: %w", err))
+	}
+
+	s.logger.InfoContext(ctx, "team created",
+		"team_id", teamID,
+		"organization_id", organizationID,
+		"name", req.GetName(),
+		"permission", req.GetPermission(),
+	)
+
+	return organizationv1.CreateTeamResponse_builder{
+		TeamId: teamID.String(),
+	}.Build(), nil
+}
@@ -0,0 +1,64 @@
+package organization
+
+import (
+	"context"
+	"fmt"
+
+	"connectrpc.com/connect"
+	"github.com/google/uuid"
+
+	"github.com/fundament-oss/fundament/common/authz"
+	"github.com/fundament-oss/fundament/common/rollback"
+	db "github.com/fundament-oss/fundament/organization-api/pkg/db/gen"
+	organizationv1 "github.com/fundament-oss/fundament/organization-api/pkg/proto/gen/v1"
+)
+
+// DeleteTeam soft-deletes a team together with its memberships and project
+// roles, so authz-worker revokes every tuple the team granted.
+func (s *Server) DeleteTeam(
+	ctx context.Context,
+	req *organizationv1.DeleteTeamRequest,
+) (*organizationv1.DeleteTeamResponse, error) {
+	organizationID, ok := OrganizationIDFromContext(ctx)
+	if !ok {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("organization_id missing from context"))
+	}
+
+	if err := s.checkPermission(ctx, authz.CanManageTeams(), authz.Organization(organizationID)); err != nil {
+		return nil, err
+	}
+
+	teamID := uuid.MustParse(req.GetTeamId())
+
+	tx, err := s.db.Pool.Begin(ctx)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to begin transaction: %w", err))
+	}
+	defer rollback.Rollback(ctx, tx, s.logger)
+
+	qtx := s.queries.WithTx(tx)
+
+	rowsAffected, err := qtx.TeamDelete(ctx, db.TeamDeleteParams{ID: teamID})
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to delete team: %w", err))
+	}
+	if rowsAffected != 1 {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("team not found"))
+	}
+
+	if err := qtx.TeamMemberDeleteByTeam(ctx, db.TeamMemberDeleteByTeamParams{TeamID: teamID}); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to remove team members: %w", err))
+	}
+
+	if err := qtx.ProjectTeamDeleteByTeam(ctx, db.ProjectTeamDeleteByTeamParams{TeamID: teamID}); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to remove team project roles: %w", err))
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to commit transaction: %w", err))
+	}
+
+	s.logger.InfoContext(ctx, "team deleted", "team_id", teamID)
+
+	return organizationv1.DeleteTeamResponse_builder{}.Build(), nil
+}
@@ -0,0 +1,41 @@
+package organization
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"connectrpc.com/connect"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/fundament-oss/fundament/common/authz"
+	db "github.com/fundament-oss/fundament/organization-api/pkg/db/gen"
+	organizationv1 "github.com/fundament-oss/fundament/organization-api/pkg/proto/gen/v1"
+)
+
+func (s *Server) GetTeam(
+	ctx context.Context,
+	req *organizationv1.GetTeamRequest,
+) (*organizationv1.GetTeamResponse, error) {
+	organizationID, ok := OrganizationIDFromContext(ctx)
+	if !ok {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("organization_id missing from context"))
+	}
+
+	if err := s.checkPermission(ctx, authz.CanListTeams(), authz.Organization(organizationID)); err != nil {
+		return nil, err
+	}
+
+	team, err := s.queries.TeamGetByID(ctx, db.TeamGetByIDParams{ID: uuid.MustParse(req.GetTeamId())})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("team not found"))
+		}
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to get team: %w", err))
+	}
+
+	return organizationv1.GetTeamResponse_builder{
+		Team: teamFromRow(&team),
+	}.Build(), nil
+}
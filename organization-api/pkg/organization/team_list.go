@@ -0,0 +1,40 @@
+package organization
+
+import (
+	"context"
+	"fmt"
+
+	"connectrpc.com/connect"
+
+	"github.com/fundament-oss/fundament/common/authz"
+	db "github.com/fundament-oss/fundament/organization-api/pkg/db/gen"
+	organizationv1 "github.com/fundament-oss/fundament/organization-api/pkg/proto/gen/v1"
+)
+
+func (s *Server) ListTeams(
+	ctx context.Context,
+	req *organizationv1.ListTeamsRequest,
+) (*organizationv1.ListTeamsResponse, error) {
+	organizationID, ok := OrganizationIDFromContext(ctx)
+	if !ok {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("organization_id missing from context"))
+	}
+
+	if err := s.checkPermission(ctx, authz.CanListTeams(), authz.Organization(organizationID)); err != nil {
+		return nil, err
+	}
+
+	teams, err := s.queries.TeamList(ctx, db.TeamListParams{OrganizationID: organizationID})
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to list teams: %w", err))
+	}
+
+	result := make([]*organizationv1.Team, 0, len(teams))
+	for idx := range teams {
+		result = append(result, teamFromRow(&teams[idx]))
+	}
+
+	return organizationv1.ListTeamsResponse_builder{
+		Teams: result,
+	}.Build(), nil
+}
@@ -0,0 +1,63 @@
+package organization
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"connectrpc.com/connect"
+	"github.com/google/uuid"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/fundament-oss/fundament/common/authz"
+	"github.com/fundament-oss/fundament/common/dbconst"
+	db "github.com/fundament-oss/fundament/organization-api/pkg/db/gen"
+	organizationv1 "github.com/fundament-oss/fundament/organization-api/pkg/proto/gen/v1"
+)
+
+func (s *Server) AddTeamMember(
+	ctx context.Context,
+	req *organizationv1.AddTeamMemberRequest,
+) (*organizationv1.AddTeamMemberResponse, error) {
+	organizationID, ok := OrganizationIDFromContext(ctx)
+	if !ok {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("organization_id missing from context"))
+	}
+
+	if err := s.checkPermission(ctx, authz.CanManageTeams(), authz.Organization(organizationID)); err != nil {
+		return nil, err
+	}
+
+	teamID := uuid.MustParse(req.GetTeamId())
+	userID := uuid.MustParse(req.GetUserId())
+
+	memberID, err := s.queries.TeamMemberCreate(ctx, db.TeamMemberCreateParams{
+		UserID: userID,
+		TeamID: teamID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, connect.NewError(connect.CodeNotFound,
+				fmt.Errorf("team not found or user is not a member of the organization"))
+		}
+		if pgErr, ok := errors.AsType[*pgconn.PgError](err); ok {
+			if pgErr.Code == pgerrcode.UniqueViolation && pgErr.ConstraintName == dbconst.ConstraintTeamMembersUqTeamUser {
+				return nil, connect.NewError(connect.CodeAlreadyExists,
+					fmt.Errorf("user is already a member of this team"))
+			}
+		}
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to add team member: %w", err))
+	}
+
+	s.logger.InfoContext(ctx, "team member added",
+		"member_id", memberID,
+		"team_id", teamID,
+		"user_id", userID,
+	)
+
+	return organizationv1.AddTeamMemberResponse_builder{
+		MemberId: memberID.String(),
+	}.Build(), nil
+}
@@ -0,0 +1,41 @@
+package organization
+
+import (
+	"context"
+	"fmt"
+
+	"connectrpc.com/connect"
+	"github.com/google/uuid"
+
+	"github.com/fundament-oss/fundament/common/authz"
+	db "github.com/fundament-oss/fundament/organization-api/pkg/db/gen"
+	organizationv1 "github.com/fundament-oss/fundament/organization-api/pkg/proto/gen/v1"
+)
+
+func (s *Server) ListTeamMembers(
+	ctx context.Context,
+	req *organizationv1.ListTeamMembersRequest,
+) (*organizationv1.ListTeamMembersResponse, error) {
+	organizationID, ok := OrganizationIDFromContext(ctx)
+	if !ok {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("organization_id missing from context"))
+	}
+
+	if err := s.checkPermission(ctx, authz.CanListTeams(), authz.Organization(organizationID)); err != nil {
+		return nil, err
+	}
+
+	members, err := s.queries.TeamMemberList(ctx, db.TeamMemberListParams{TeamID: uuid.MustParse(req.GetTeamId())})
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to list team members: %w", err))
+	}
+
+	result := make([]*organizationv1.TeamMember, 0, len(members))
+	for idx := range members {
+		result = append(result, teamMemberFromRow(&members[idx]))
+	}
+
+	return organizationv1.ListTeamMembersResponse_builder{
+		Members: result,
+	}.Build(), nil
+}
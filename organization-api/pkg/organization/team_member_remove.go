@@ -0,0 +1,44 @@
+package organization
+
+import (
+	"context"
+	"fmt"
+
+	"connectrpc.com/connect"
+	"github.com/google/uuid"
+
+	"github.com/fundament-oss/fundament/common/authz"
+	db "github.com/fundament-oss/fundament/organization-api/pkg/db/gen"
+	organizationv1 "github.com/fundament-oss/fundament/organization-api/pkg/proto/gen/v1"
+)
+
+// RemoveTeamMember removes a membership whatever its source. A member synced
+// from an OIDC group rejoins on their next login while they are still in the
+// group.
+func (s *Server) RemoveTeamMember(
+	ctx context.Context,
+	req *organizationv1.RemoveTeamMemberRequest,
+) (*organizationv1.RemoveTeamMemberResponse, error) {
+	organizationID, ok := OrganizationIDFromContext(ctx)
+	if !ok {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("organization_id missing from context"))
+	}
+
+	if err := s.checkPermission(ctx, authz.CanManageTeams(), authz.Organization(organizationID)); err != nil {
+		return nil, err
+	}
+
+	memberID := uuid.MustParse(req.GetMemberId())
+
+	rowsAffected, err := s.queries.TeamMemberDelete(ctx, db.TeamMemberDeleteParams{ID: memberID})
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to remove team member: %w", err))
+	}
+	if rowsAffected != 1 {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("team member not found"))
+	}
+
+	s.logger.InfoContext(ctx, "team member removed", "member_id", memberID)
+
+	return organizationv1.RemoveTeamMemberResponse_builder{}.Build(), nil
+}
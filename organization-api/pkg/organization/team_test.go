@@ -0,0 +1,220 @@
+package organization_test
+
+import (
+	"context"
+	"testing"
+
+	"connectrpc.com/connect"
+	organizationv1 "github.com/fundament-oss/fundament/organization-api/pkg/proto/gen/v1"
+	"github.com/fundament-oss/fundament/organization-api/pkg/proto/gen/v1/organizationv1connect"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func Test_Team_Unauthenticated(t *testing.T) {
+	t.Parallel()
+
+	env := newTestAPI(t)
+	client := organizationv1connect.NewTeamServiceClient(env.server.Client(), env.server.URL)
+
+	_, err := client.ListTeams(context.Background(), organizationv1.ListTeamsRequest_builder{}.Build())
+
+	var connectErr *connect.Error
+	require.ErrorAs(t, err, &connectErr)
+	assert.Equal(t, connect.CodeUnauthenticated, connectErr.Code())
+}
+
+func Test_Team_Lifecycle(t *testing.T) {
+	t.Parallel()
+
+	orgID := uuid.New()
+	otherOrgID := uuid.New()
+	callerUserID := uuid.New()
+	memberUserID := uuid.New()
+	outsiderUserID := uuid.New()
+
+	env := newTestAPI(t,
+		WithOrganization(orgID, "test-org"),
+		WithOrganization(otherOrgID, "other-org"),
+		WithUser(&UserArgs{ID: callerUserID, Name: "caller-user", OrgIDs: []uuid.UUID{orgID}}),
+		WithUser(&UserArgs{ID: memberUserID, Name: "team-member", OrgIDs: []uuid.UUID{orgID}}),
+		WithUser(&UserArgs{ID: outsiderUserID, Name: "outsider", OrgIDs: []uuid.UUID{otherOrgID}}),
+	)
+
+	token := env.createAuthnToken(t, callerUserID)
+	client := organizationv1connect.NewTeamServiceClient(env.server.Client(), env.server.URL)
+
+	createReq := organizationv1.CreateTeamRequest_builder{
+		Name:       "platform",
+		Permission: "viewer",
+		OidcGroup:  proto.String("platform-engineers"),
+	}.Build()
+
+	createRes, err := client.CreateTeam(authedContext(token, orgID), createReq)
+	require.NoError(t, err)
+	teamID := createRes.GetTeamId()
+
+	_, err = client.CreateTeam(authedContext(token, orgID), createReq)
+	var connectErr *connect.Error
+	require.ErrorAs(t, err, &connectErr)
+	assert.Equal(t, connect.CodeAlreadyExists, connectErr.Code())
+
+	memberRes, err := client.AddTeamMember(authedContext(token, orgID), organizationv1.AddTeamMemberRequest_builder{
+		TeamId: teamID,
+		UserId: memberUserID.String(),
+	}.Build())
+	require.NoError(t, err)
+
+	// Only members of the team's organization can join it.
+	_, err = client.AddTeamMember(authedContext(token, orgID), organizationv1.AddTeamMemberRequest_builder{
+		TeamId: teamID,
+		UserId: outsiderUserID.String(),
+	}.Build())
+	require.ErrorAs(t, err, &connectErr)
+	assert.Equal(t, connect.CodeNotFound, connectErr.Code())
+
+	getRes, err := client.GetTeam(authedContext(token, orgID), organizationv1.GetTeamRequest_builder{
+		TeamId: teamID,
+	}.Build())
+	require.NoError(t, err)
+	team := getRes.GetTeam()
+	assert.Equal(t, "platform", team.GetName())
+	assert.Equal(t, "viewer", team.GetPermission())
+	assert.Equal(t, "platform-engineers", team.GetOidcGroup())
+	assert.Equal(t, int32(1), team.GetMemberCount())
+
+	membersRes, err := client.ListTeamMembers(authedContext(token, orgID), organizationv1.ListTeamMembersRequest_builder{
+		TeamId: teamID,
+	}.Build())
+	require.NoError(t, err)
+	require.Len(t, membersRes.GetMembers(), 1)
+	member := membersRes.GetMembers()[0]
+	assert.Equal(t, memberRes.GetMemberId(), member.GetId())
+	assert.Equal(t, memberUserID.String(), member.GetUserId())
+	assert.Equal(t, "team-member", member.GetUserName())
+	assert.Equal(t, organizationv1.TeamMemberSource_TEAM_MEMBER_SOURCE_MANUAL, member.GetSource())
+
+	// Clearing the permission and OIDC group leaves the name alone.
+	_, err = client.UpdateTeam(authedContext(token, orgID), organizationv1.UpdateTeamRequest_builder{
+		TeamId:     teamID,
+		Permission: proto.String(""),
+		OidcGroup:  proto.String(""),
+	}.Build())
+	require.NoError(t, err)
+
+	getRes, err = client.GetTeam(authedContext(token, orgID), organizationv1.GetTeamRequest_builder{
+		TeamId: teamID,
+	}.Build())
+	require.NoError(t, err)
+	assert.Equal(t, "platform", getRes.GetTeam().GetName())
+	assert.Empty(t, getRes.GetTeam().GetPermission())
+	assert.False(t, getRes.GetTeam().HasOidcGroup())
+
+	_, err = client.DeleteTeam(authedContext(token, orgID), organizationv1.DeleteTeamRequest_builder{
+		TeamId: teamID,
+	}.Build())
+	require.NoError(t, err)
+
+	listRes, err := client.ListTeams(authedContext(token, orgID), organizationv1.ListTeamsRequest_builder{}.Build())
+	require.NoError(t, err)
+	assert.Empty(t, listRes.GetTeams())
+
+	membersRes, err = client.ListTeamMembers(authedContext(token, orgID), organizationv1.ListTeamMembersRequest_builder{
+		TeamId: teamID,
+	}.Build())
+	require.NoError(t, err)
+	assert.Empty(t, membersRes.GetMembers())
+
+	// The name can be reused once the team is deleted.
+	_, err = client.CreateTeam(authedContext(token, orgID), createReq)
+	require.NoError(t, err)
+}
+
+func Test_ProjectTeam_Lifecycle(t *testing.T) {
+	t.Parallel()
+
+	orgID := uuid.New()
+	userID := uuid.New()
+
+	env := newTestAPI(t,
+		WithOrganization(orgID, "test-org"),
+		WithUser(&UserArgs{ID: userID, Name: "test-user", OrgIDs: []uuid.UUID{orgID}}),
+	)
+
+	token := env.createAuthnToken(t, userID)
+	clusterClient := organizationv1connect.NewClusterServiceClient(env.server.Client(), env.server.URL)
+	projectClient := organizationv1connect.NewProjectServiceClient(env.server.Client(), env.server.URL)
+	client := organizationv1connect.NewTeamServiceClient(env.server.Client(), env.server.URL)
+
+	clusterRes, err := clusterClient.CreateCluster(authedContext(token, orgID), organizationv1.CreateClusterRequest_builder{
+		Name:              "test-cluster",
+		Region:            "eu-west-1",
+		KubernetesVersion: "1.28",
+	}.Build())
+	require.NoError(t, err)
+
+	projectRes, err := projectClient.CreateProject(authedContext(token, orgID), organizationv1.CreateProjectRequest_builder{
+		ClusterId: clusterRes.GetClusterId(),
+		Name:      "test-project",
+	}.Build())
+	require.NoError(t, err)
+	projectID := projectRes.GetProjectId()
+
+	teamRes, err := client.CreateTeam(authedContext(token, orgID), organizationv1.CreateTeamRequest_builder{
+		Name: "developers",
+	}.Build())
+	require.NoError(t, err)
+	teamID := teamRes.GetTeamId()
+
+	addReq := organizationv1.AddProjectTeamRequest_builder{
+		ProjectId: projectID,
+		TeamId:    teamID,
+		Role:      organizationv1.ProjectMemberRole_PROJECT_MEMBER_ROLE_ADMIN,
+	}.Build()
+
+	addRes, err := client.AddProjectTeam(authedContext(token, orgID), addReq)
+	require.NoError(t, err)
+
+	_, err = client.AddProjectTeam(authedContext(token, orgID), addReq)
+	var connectErr *connect.Error
+	require.ErrorAs(t, err, &connectErr)
+	assert.Equal(t, connect.CodeAlreadyExists, connectErr.Code())
+
+	_, err = client.AddProjectTeam(authedContext(token, orgID), organizationv1.AddProjectTeamRequest_builder{
+		ProjectId: projectID,
+		TeamId:    uuid.New().String(),
+		Role:      organizationv1.ProjectMemberRole_PROJECT_MEMBER_ROLE_VIEWER,
+	}.Build())
+	require.ErrorAs(t, err, &connectErr)
+	assert.Equal(t, connect.CodeNotFound, connectErr.Code())
+
+	listRes, err := client.ListProjectTeams(authedContext(token, orgID), organizationv1.ListProjectTeamsRequest_builder{
+		ProjectId: projectID,
+	}.Build())
+	require.NoError(t, err)
+	require.Len(t, listRes.GetTeams(), 1)
+	projectTeam := listRes.GetTeams()[0]
+	assert.Equal(t, addRes.GetProjectTeamId(), projectTeam.GetId())
+	assert.Equal(t, teamID, projectTeam.GetTeamId())
+	assert.Equal(t, "developers", projectTeam.GetTeamName())
+	assert.Equal(t, organizationv1.ProjectMemberRole_PROJECT_MEMBER_ROLE_ADMIN, projectTeam.GetRole())
+
+	_, err = client.RemoveProjectTeam(authedContext(token, orgID), organizationv1.RemoveProjectTeamRequest_builder{
+		ProjectTeamId: addRes.GetProjectTeamId(),
+	}.Build())
+	require.NoError(t, err)
+
+	_, err = client.RemoveProjectTeam(authedContext(token, orgID), organizationv1.RemoveProjectTeamRequest_builder{
+		ProjectTeamId: addRes.GetProjectTeamId(),
+	}.Build())
+	require.ErrorAs(t, err, &connectErr)
+	assert.Equal(t, connect.CodeNotFound, connectErr.Code())
+
+	listRes, err = client.ListProjectTeams(authedContext(token, orgID), organizationv1.ListProjectTeamsRequest_builder{
+		ProjectId: projectID,
+	}.Build())
+	require.NoError(t, err)
+	assert.Empty(t, listRes.GetTeams())
+}
@@ -0,0 +1,65 @@
+package organization
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"connectrpc.com/connect"
+	"github.com/google/uuid"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/fundament-oss/fundament/common/authz"
+	"github.com/fundament-oss/fundament/common/dbconst"
+	db "github.com/fundament-oss/fundament/organization-api/pkg/db/gen"
+	organizationv1 "github.com/fundament-oss/fundament/organization-api/pkg/proto/gen/v1"
+)
+
+func (s *Server) UpdateTeam(
+	ctx context.Context,
+	req *organizationv1.UpdateTeamRequest,
+) (*organizationv1.UpdateTeamResponse, error) {
+	organizationID, ok := OrganizationIDFromContext(ctx)
+	if !ok {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("organization_id missing from context"))
+	}
+
+	if err := s.checkPermission(ctx, authz.CanManageTeams(), authz.Organization(organizationID)); err != nil {
+		return nil, err
+	}
+
+	teamID := uuid.MustParse(req.GetTeamId())
+
+	params := db.TeamUpdateParams{ID: teamID}
+	if req.HasName() {
+		params.Name = pgtype.Text{String: req.GetName(), Valid: true}
+	}
+	if req.HasPermission() {
+		params.SetPermission = true
+		params.Permission = optionalText(req.GetPermission())
+	}
+	if req.HasOidcGroup() {
+		params.SetOidcGroup = true
+		params.OidcGroup = optionalText(req.GetOidcGroup())
+	}
+
+	rowsAffected, err := s.queries.TeamUpdate(ctx, params)
+	if err != nil {
+		if pgErr, ok := errors.AsType[*pgconn.PgError](err); ok {
+			if pgErr.Code == pgerrcode.UniqueViolation && pgErr.ConstraintName == dbconst.ConstraintTeamsUqOrganizationName {
+				return nil, connect.NewError(connect.CodeAlreadyExists,
+					fmt.Errorf("a team with this name already exists"))
+			}
+		}
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to update team: %w", err))
+	}
+	if rowsAffected != 1 {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("team not found"))
+	}
+
+	s.logger.InfoContext(ctx, "team updated", "team_id", teamID)
+
+	return organizationv1.UpdateTeamResponse_builder{}.Build(), nil
+}
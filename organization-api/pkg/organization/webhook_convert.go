@@ -0,0 +1,159 @@
+package organization
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/fundament-oss/fundament/common/dbconst"
+	db "github.com/fundament-oss/fundament/organization-api/pkg/db/gen"
+	organizationv1 "github.com/fundament-oss/fundament/organization-api/pkg/proto/gen/v1"
+)
+
+// webhookSecretPrefix marks webhook signing secrets so they are recognizable
+// when pasted into a receiver's configuration or leaked into logs.
+const webhookSecretPrefix = "whsec_"
+
+// newWebhookSecret returns a random HMAC key for signing deliveries.
+func newWebhookSecret() string {
+	b := make([]byte, 32)
+	_, _ = rand.Read(b) // never returns an error
+	return webhookSecretPrefix + base64.RawURLEncoding.EncodeToString(b)
+}
+
+// webhookFields are the columns WebhookList and WebhookGetByID share.
+type webhookFields interface {
+	db.WebhookListRow | db.WebhookGetByIDRow
+}
+
+func webhookFromRow[T webhookFields](row *T) *organizationv1.Webhook {
+	r := db.WebhookListRow(*row)
+
+	eventTypes := make([]organizationv1.WebhookEventType, 0, len(r.EventTypes))
+	for _, t := range r.EventTypes {
+		eventTypes = append(eventTypes, webhookEventTypeFromDB(t))
+	}
+
+	return organizationv1.Webhook_builder{
+		Id:         r.ID.String(),
+		Name:       r.Name,
+		Url:        r.Url,
+		EventTypes: eventTypes,
+		Enabled:    r.Enabled,
+		Created:    timestamppb.New(r.Created.Time),
+	}.Build()
+}
+
+// webhookEventTypesToDB converts an event filter to the event_types column;
+// an empty filter stays empty, which subscribes to every event.
+func webhookEventTypesToDB(filter *organizationv1.WebhookEventFilter) []string {
+	eventTypes := make([]string, 0, len(filter.GetEventTypes()))
+	for _, t := range filter.GetEventTypes() {
+		eventTypes = append(eventTypes, string(webhookEventTypeToDB(t)))
+	}
+	return eventTypes
+}
+
+func webhookDeliveryFromDB(d *db.TenantWebhookDelivery) *organizationv1.WebhookDelivery {
+	delivery := organizationv1.WebhookDelivery_builder{
+		Id:        d.ID.String(),
+		WebhookId: d.WebhookID.String(),
+		EventType: webhookEventTypeFromDB(d.EventType),
+		Status:    webhookDeliveryStatusFromDB(d.Status),
+		Attempts:  d.Attempts,
+		Payload:   string(d.Payload),
+		Created:   timestamppb.New(d.Created.Time),
+	}.Build()
+
+	if d.ResponseStatus.Valid {
+		delivery.SetResponseStatus(d.ResponseStatus.Int32)
+	}
+	if d.StatusInfo.Valid {
+		delivery.SetError(d.StatusInfo.String)
+	}
+	if d.RetryAfter.Valid {
+		delivery.SetNextAttempt(timestamppb.New(d.RetryAfter.Time))
+	}
+	if d.Completed.Valid {
+		delivery.SetCompleted(timestamppb.New(d.Completed.Time))
+	}
+	return delivery
+}
+
+func webhookEventTypeFromDB(value string) organizationv1.WebhookEventType {
+	switch dbconst.WebhookDeliverieEventType(value) {
+	case dbconst.WebhookDeliverieEventType_ClusterReady:
+		return organizationv1.WebhookEventType_WEBHOOK_EVENT_TYPE_CLUSTER_READY
+	case dbconst.WebhookDeliverieEventType_ClusterError:
+		return organizationv1.WebhookEventType_WEBHOOK_EVENT_TYPE_CLUSTER_ERROR
+	case dbconst.WebhookDeliverieEventType_ClusterDeleted:
+		return organizationv1.WebhookEventType_WEBHOOK_EVENT_TYPE_CLUSTER_DELETED
+	case dbconst.WebhookDeliverieEventType_ClusterHibernated:
+		return organizationv1.WebhookEventType_WEBHOOK_EVENT_TYPE_CLUSTER_HIBERNATED
+	case dbconst.WebhookDeliverieEventType_ClusterSyncFailed:
+		return organizationv1.WebhookEventType_WEBHOOK_EVENT_TYPE_CLUSTER_SYNC_FAILED
+	case dbconst.WebhookDeliverieEventType_ClusterUpgradeCompleted:
+		return organizationv1.WebhookEventType_WEBHOOK_EVENT_TYPE_CLUSTER_UPGRADE_COMPLETED
+	case dbconst.WebhookDeliverieEventType_ClusterUpgradeFailed:
+		return organizationv1.WebhookEventType_WEBHOOK_EVENT_TYPE_CLUSTER_UPGRADE_FAILED
+	case dbconst.WebhookDeliverieEventType_PluginReady:
+		return organizationv1.WebhookEventType_WEBHOOK_EVENT_TYPE_PLUGIN_READY
+	case dbconst.WebhookDeliverieEventType_PluginDegraded:
+		return organizationv1.WebhookEventType_WEBHOOK_EVENT_TYPE_PLUGIN_DEGRADED
+	case dbconst.WebhookDeliverieEventType_PluginFailed:
+		return organizationv1.WebhookEventType_WEBHOOK_EVENT_TYPE_PLUGIN_FAILED
+	case dbconst.WebhookDeliverieEventType_MemberInvited:
+		return organizationv1.WebhookEventType_WEBHOOK_EVENT_TYPE_MEMBER_INVITED
+	case dbconst.WebhookDeliverieEventType_Ping:
+		return organizationv1.WebhookEventType_WEBHOOK_EVENT_TYPE_PING
+	default:
+		return organizationv1.WebhookEventType_WEBHOOK_EVENT_TYPE_UNSPECIFIED
+	}
+}
+
+func webhookEventTypeToDB(value organizationv1.WebhookEventType) dbconst.WebhookDeliverieEventType {
+	switch value {
+	case organizationv1.WebhookEventType_WEBHOOK_EVENT_TYPE_CLUSTER_READY:
+		return dbconst.WebhookDeliverieEventType_ClusterReady
+	case organizationv1.WebhookEventType_WEBHOOK_EVENT_TYPE_CLUSTER_ERROR:
+		return dbconst.WebhookDeliverieEventType_ClusterError
+	case organizationv1.WebhookEventType_WEBHOOK_EVENT_TYPE_CLUSTER_DELETED:
+		return dbconst.WebhookDeliverieEventType_ClusterDeleted
+	case organizationv1.WebhookEventType_WEBHOOK_EVENT_TYPE_CLUSTER_HIBERNATED:
+		return dbconst.WebhookDeliverieEventType_ClusterHibernated
+	case organizationv1.WebhookEventType_WEBHOOK_EVENT_TYPE_CLUSTER_SYNC_FAILED:
+		return dbconst.WebhookDeliverieEventType_ClusterSyncFailed
+	case organizationv1.WebhookEventType_WEBHOOK_EVENT_TYPE_CLUSTER_UPGRADE_COMPLETED:
+		return dbconst.WebhookDeliverieEventType_ClusterUpgradeCompleted
+	case organizationv1.WebhookEventType_WEBHOOK_EVENT_TYPE_CLUSTER_UPGRADE_FAILED:
+		return dbconst.WebhookDeliverieEventType_ClusterUpgradeFailed
+	case organizationv1.WebhookEventType_WEBHOOK_EVENT_TYPE_PLUGIN_READY:
+		return dbconst.WebhookDeliverieEventType_PluginReady
+	case organizationv1.WebhookEventType_WEBHOOK_EVENT_TYPE_PLUGIN_DEGRADED:
+		return dbconst.WebhookDeliverieEventType_PluginDegraded
+	case organizationv1.WebhookEventType_WEBHOOK_EVENT_TYPE_PLUGIN_FAILED:
+		return dbconst.WebhookDeliverieEventType_PluginFailed
+	case organizationv1.WebhookEventType_WEBHOOK_EVENT_TYPE_MEMBER_INVITED:
+		return dbconst.WebhookDeliverieEventType_MemberInvited
+	case organizationv1.WebhookEventType_WEBHOOK_EVENT_TYPE_PING:
+		return dbconst.WebhookDeliverieEventType_Ping
+	default:
+		return ""
+	}
+}
+
+func webhookDeliveryStatusFromDB(value string) organizationv1.WebhookDeliveryStatus {
+	switch dbconst.WebhookDeliverieStatus(value) {
+	case dbconst.WebhookDeliverieStatus_Pending:
+		return organizationv1.WebhookDeliveryStatus_WEBHOOK_DELIVERY_STATUS_PENDING
+	case dbconst.WebhookDeliverieStatus_Retrying:
+		return organizationv1.WebhookDeliveryStatus_WEBHOOK_DELIVERY_STATUS_RETRYING
+	case dbconst.WebhookDeliverieStatus_Succeeded:
+		return organizationv1.WebhookDeliveryStatus_WEBHOOK_DELIVERY_STATUS_SUCCEEDED
+	case dbconst.WebhookDeliverieStatus_Failed:
+		return organizationv1.WebhookDeliveryStatus_WEBHOOK_DELIVERY_STATUS_FAILED
+	default:
+		return organizationv1.WebhookDeliveryStatus_WEBHOOK_DELIVERY_STATUS_UNSPECIFIED
+	}
+}
@@ -0,0 +1,60 @@
+package organization
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"connectrpc.com/connect"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/fundament-oss/fundament/common/authz"
+	"github.com/fundament-oss/fundament/common/dbconst"
+	db "github.com/fundament-oss/fundament/organization-api/pkg/db/gen"
+	organizationv1 "github.com/fundament-oss/fundament/organization-api/pkg/proto/gen/v1"
+)
+
+func (s *Server) CreateWebhook(
+	ctx context.Context,
+	req *organizationv1.CreateWebhookRequest,
+) (*organizationv1.CreateWebhookResponse, error) {
+	organizationID, ok := OrganizationIDFromContext(ctx)
+	if !ok {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("organization_id missing from context"))
+	}
+
+	if err := s.checkPermission(ctx, authz.CanManageWebhooks(), authz.Organization(organizationID)); err != nil {
+		return nil, err
+	}
+
+	secret := newWebhookSecret()
+
+	id, err := s.queries.WebhookCreate(ctx, db.WebhookCreateParams{
+		OrganizationID: organizationID,
+		Name:           req.GetName(),
+		Url:            req.GetUrl(),
+		Secret:         secret,
+		EventTypes:     webhookEventTypesToDB(req.GetEventFilter()),
+	})
+	if err != nil {
+		if pgErr, ok := errors.AsType[*pgconn.PgError](err); ok {
+			if pgErr.Code == pgerrcode.UniqueViolation && pgErr.ConstraintName == dbconst.ConstraintWebhooksUqName {
+				return nil, connect.NewError(connect.CodeAlreadyExists,
+					fmt.Errorf("a webhook with this name already exists"))
+			}
+		}
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to create webhook: %w", err))
+	}
+
+	s.logger.InfoContext(ctx, "webhook created",
+		"webhook_id", id,
+		"organization_id", organizationID,
+		"name", req.GetName(),
+	)
+
+	return organizationv1.CreateWebhookResponse_builder{
+		Id:     id.String(),
+		Secret: secret,
+	}.Build(), nil
+}
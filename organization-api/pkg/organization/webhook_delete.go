@@ -0,0 +1,35 @@
+package organization
+
+import (
+	"context"
+	"fmt"
+
+	"connectrpc.com/connect"
+	"github.com/google/uuid"
+
+	db "github.com/fundament-oss/fundament/organization-api/pkg/db/gen"
+	organizationv1 "github.com/fundament-oss/fundament/organization-api/pkg/proto/gen/v1"
+)
+
+func (s *Server) DeleteWebhook(
+	ctx context.Context,
+	req *organizationv1.DeleteWebhookRequest,
+) (*organizationv1.DeleteWebhookResponse, error) {
+	webhookID := uuid.MustParse(req.GetWebhookId())
+
+	if _, err := s.getWebhook(ctx, webhookID); err != nil {
+		return nil, err
+	}
+
+	rowsAffected, err := s.queries.WebhookDelete(ctx, db.WebhookDeleteParams{ID: webhookID})
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to delete webhook: %w", err))
+	}
+	if rowsAffected != 1 {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("webhook not found"))
+	}
+
+	s.logger.InfoContext(ctx, "webhook deleted", "webhook_id", webhookID)
+
+	return organizationv1.DeleteWebhookResponse_builder{}.Build(), nil
+}
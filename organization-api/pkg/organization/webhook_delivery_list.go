@@ -0,0 +1,62 @@
+package organization
+
+import (
+	"context"
+	"fmt"
+
+	"connectrpc.com/connect"
+	"github.com/google/uuid"
+
+	db "github.com/fundament-oss/fundament/organization-api/pkg/db/gen"
+	organizationv1 "github.com/fundament-oss/fundament/organization-api/pkg/proto/gen/v1"
+)
+
+// defaultWebhookDeliveryPageSize applies when ListWebhookDeliveries is called
+// without a page size; the delivery history of a busy webhook is unbounded.
+const defaultWebhookDeliveryPageSize = 100
+
+func (s *Server) ListWebhookDeliveries(
+	ctx context.Context,
+	req *organizationv1.ListWebhookDeliveriesRequest,
+) (*organizationv1.ListWebhookDeliveriesResponse, error) {
+	webhookID := uuid.MustParse(req.GetWebhookId())
+
+	if _, err := s.getWebhook(ctx, webhookID); err != nil {
+		return nil, err
+	}
+
+	cursor, err := decodePageToken(req.GetPageToken())
+	if err != nil {
+		return nil, err
+	}
+	afterCreated, afterID := createdCursorParams(cursor)
+
+	pageSize := req.GetPageSize()
+	if pageSize == 0 {
+		pageSize = defaultWebhookDeliveryPageSize
+	}
+
+	deliveries, err := s.queries.WebhookDeliveryList(ctx, db.WebhookDeliveryListParams{
+		WebhookID:    webhookID,
+		AfterCreated: afterCreated,
+		AfterID:      afterID,
+		PageLimit:    pageLimit(pageSize),
+	})
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to list webhook deliveries: %w", err))
+	}
+
+	deliveries, nextPageToken := trimPage(deliveries, pageSize, func(row *db.TenantWebhookDelivery) pageCursor {
+		return pageCursor{Created: row.Created.Time, ID: row.ID}
+	})
+
+	result := make([]*organizationv1.WebhookDelivery, 0, len(deliveries))
+	for idx := range deliveries {
+		result = append(result, webhookDeliveryFromDB(&deliveries[idx]))
+	}
+
+	return organizationv1.ListWebhookDeliveriesResponse_builder{
+		Deliveries:    result,
+		NextPageToken: nextPageToken,
+	}.Build(), nil
+}
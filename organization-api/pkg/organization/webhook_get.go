@@ -0,0 +1,52 @@
+package organization
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"connectrpc.com/connect"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/fundament-oss/fundament/common/authz"
+	db "github.com/fundament-oss/fundament/organization-api/pkg/db/gen"
+	organizationv1 "github.com/fundament-oss/fundament/organization-api/pkg/proto/gen/v1"
+)
+
+func (s *Server) GetWebhook(
+	ctx context.Context,
+	req *organizationv1.GetWebhookRequest,
+) (*organizationv1.GetWebhookResponse, error) {
+	webhook, err := s.getWebhook(ctx, uuid.MustParse(req.GetWebhookId()))
+	if err != nil {
+		return nil, err
+	}
+
+	return organizationv1.GetWebhookResponse_builder{
+		Webhook: webhookFromRow(webhook),
+	}.Build(), nil
+}
+
+// getWebhook checks that the caller may manage the organization's webhooks
+// and loads one of them. Row-level security hides other organizations'
+// webhooks, so those are reported as not found.
+func (s *Server) getWebhook(ctx context.Context, webhookID uuid.UUID) (*db.WebhookGetByIDRow, error) {
+	organizationID, ok := OrganizationIDFromContext(ctx)
+	if !ok {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("organization_id missing from context"))
+	}
+
+	if err := s.checkPermission(ctx, authz.CanManageWebhooks(), authz.Organization(organizationID)); err != nil {
+		return nil, err
+	}
+
+	webhook, err := s.queries.WebhookGetByID(ctx, db.WebhookGetByIDParams{ID: webhookID})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("webhook not found"))
+		}
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to get webhook: %w", err))
+	}
+	return &webhook, nil
+}
@@ -0,0 +1,56 @@
+package organization
+
+import (
+	"context"
+	"fmt"
+
+	"connectrpc.com/connect"
+
+	"github.com/fundament-oss/fundament/common/authz"
+	db "github.com/fundament-oss/fundament/organization-api/pkg/db/gen"
+	organizationv1 "github.com/fundament-oss/fundament/organization-api/pkg/proto/gen/v1"
+)
+
+func (s *Server) ListWebhooks(
+	ctx context.Context,
+	req *organizationv1.ListWebhooksRequest,
+) (*organizationv1.ListWebhooksResponse, error) {
+	organizationID, ok := OrganizationIDFromContext(ctx)
+	if !ok {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("organization_id missing from context"))
+	}
+
+	if err := s.checkPermission(ctx, authz.CanManageWebhooks(), authz.Organization(organizationID)); err != nil {
+		return nil, err
+	}
+
+	cursor, err := decodePageToken(req.GetPageToken())
+	if err != nil {
+		return nil, err
+	}
+	afterCreated, afterID := createdCursorParams(cursor)
+
+	webhooks, err := s.queries.WebhookList(ctx, db.WebhookListParams{
+		OrganizationID: organizationID,
+		AfterCreated:   afterCreated,
+		AfterID:        afterID,
+		PageLimit:      pageLimit(req.GetPageSize()),
+	})
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to list webhooks: %w", err))
+	}
+
+	webhooks, nextPageToken := trimPage(webhooks, req.GetPageSize(), func(row *db.WebhookListRow) pageCursor {
+		return pageCursor{Created: row.Created.Time, ID: row.ID}
+	})
+
+	result := make([]*organizationv1.Webhook, 0, len(webhooks))
+	for idx := range webhooks {
+		result = append(result, webhookFromRow(&webhooks[idx]))
+	}
+
+	return organizationv1.ListWebhooksResponse_builder{
+		Webhooks:      result,
+		NextPageToken: nextPageToken,
+	}.Build(), nil
+}
@@ -0,0 +1,37 @@
+package organization
+
+import (
+	"context"
+	"fmt"
+
+	"connectrpc.com/connect"
+	"github.com/google/uuid"
+
+	db "github.com/fundament-oss/fundament/organization-api/pkg/db/gen"
+	organizationv1 "github.com/fundament-oss/fundament/organization-api/pkg/proto/gen/v1"
+)
+
+func (s *Server) PingWebhook(
+	ctx context.Context,
+	req *organizationv1.PingWebhookRequest,
+) (*organizationv1.PingWebhookResponse, error) {
+	webhookID := uuid.MustParse(req.GetWebhookId())
+
+	webhook, err := s.getWebhook(ctx, webhookID)
+	if err != nil {
+		return nil, err
+	}
+	// cluster-worker fails deliveries to disabled webhooks without sending them.
+	if !webhook.Enabled {
+		return nil, connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("webhook is disabled"))
+	}
+
+	deliveryID, err := s.queries.WebhookDeliveryCreatePing(ctx, db.WebhookDeliveryCreatePingParams{WebhookID: webhookID})
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to queue ping: %w", err))
+	}
+
+	return organizationv1.PingWebhookResponse_builder{
+		DeliveryId: deliveryID.String(),
+	}.Build(), nil
+}
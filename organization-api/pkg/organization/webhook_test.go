@@ -0,0 +1,228 @@
+package organization_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"connectrpc.com/connect"
+	organizationv1 "github.com/fundament-oss/fundament/organization-api/pkg/proto/gen/v1"
+	"github.com/fundament-oss/fundament/organization-api/pkg/proto/gen/v1/organizationv1connect"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func Test_Webhook_Unauthenticated(t *testing.T) {
+	t.Parallel()
+
+	env := newTestAPI(t)
+	client := organizationv1connect.NewWebhookServiceClient(env.server.Client(), env.server.URL)
+
+	_, err := client.ListWebhooks(context.Background(), organizationv1.ListWebhooksRequest_builder{}.Build())
+
+	var connectErr *connect.Error
+	require.ErrorAs(t, err, &connectErr)
+	assert.Equal(t, connect.CodeUnauthenticated, connectErr.Code())
+}
+
+func Test_Webhook_CRUD(t *testing.T) {
+	t.Parallel()
+
+	orgID := uuid.New()
+	userID := uuid.New()
+
+	env := newTestAPI(t,
+		WithOrganization(orgID, "test-org"),
+		WithUser(&UserArgs{ID: userID, Name: "test-user", OrgIDs: []uuid.UUID{orgID}}),
+	)
+
+	token := env.createAuthnToken(t, userID)
+	client := organizationv1connect.NewWebhookServiceClient(env.server.Client(), env.server.URL)
+
+	createRes, err := client.CreateWebhook(authedContext(token, orgID), organizationv1.CreateWebhookRequest_builder{
+		Name: "alerts",
+		Url:  "https://example.com/hooks/fundament",
+		EventFilter: organizationv1.WebhookEventFilter_builder{
+			EventTypes: []organizationv1.WebhookEventType{
+				organizationv1.WebhookEventType_WEBHOOK_EVENT_TYPE_CLUSTER_READY,
+				organizationv1.WebhookEventType_WEBHOOK_EVENT_TYPE_PLUGIN_DEGRADED,
+			},
+		}.Build(),
+	}.Build())
+	require.NoError(t, err)
+	webhookID := createRes.GetId()
+	assert.True(t, strings.HasPrefix(createRes.GetSecret(), "whsec_"))
+
+	_, err = client.CreateWebhook(authedContext(token, orgID), organizationv1.CreateWebhookRequest_builder{
+		Name: "alerts",
+		Url:  "https://example.com/other",
+	}.Build())
+	var connectErr *connect.Error
+	require.ErrorAs(t, err, &connectErr)
+	assert.Equal(t, connect.CodeAlreadyExists, connectErr.Code())
+
+	getRes, err := client.GetWebhook(authedContext(token, orgID), organizationv1.GetWebhookRequest_builder{
+		WebhookId: webhookID,
+	}.Build())
+	require.NoError(t, err)
+	webhook := getRes.GetWebhook()
+	assert.Equal(t, "alerts", webhook.GetName())
+	assert.Equal(t, "https://example.com/hooks/fundament", webhook.GetUrl())
+	assert.True(t, webhook.GetEnabled())
+	assert.ElementsMatch(t, []organizationv1.WebhookEventType{
+		organizationv1.WebhookEventType_WEBHOOK_EVENT_TYPE_CLUSTER_READY,
+		organizationv1.WebhookEventType_WEBHOOK_EVENT_TYPE_PLUGIN_DEGRADED,
+	}, webhook.GetEventTypes())
+
+	_, err = client.UpdateWebhook(authedContext(token, orgID), organizationv1.UpdateWebhookRequest_builder{
+		WebhookId:   webhookID,
+		Enabled:     proto.Bool(false),
+		EventFilter: organizationv1.WebhookEventFilter_builder{}.Build(),
+	}.Build())
+	require.NoError(t, err)
+
+	listRes, err := client.ListWebhooks(authedContext(token, orgID), organizationv1.ListWebhooksRequest_builder{}.Build())
+	require.NoError(t, err)
+	require.Len(t, listRes.GetWebhooks(), 1)
+	updated := listRes.GetWebhooks()[0]
+	assert.Equal(t, "alerts", updated.GetName())
+	assert.False(t, updated.GetEnabled())
+	assert.Empty(t, updated.GetEventTypes())
+
+	_, err = client.DeleteWebhook(authedContext(token, orgID), organizationv1.DeleteWebhookRequest_builder{
+		WebhookId: webhookID,
+	}.Build())
+	require.NoError(t, err)
+
+	_, err = client.GetWebhook(authedContext(token, orgID), organizationv1.GetWebhookRequest_builder{
+		WebhookId: webhookID,
+	}.Build())
+	require.ErrorAs(t, err, &connectErr)
+	assert.Equal(t, connect.CodeNotFound, connectErr.Code())
+}
+
+func Test_Webhook_PingQueuesDelivery(t *testing.T) {
+	t.Parallel()
+
+	orgID := uuid.New()
+	userID := uuid.New()
+
+	env := newTestAPI(t,
+		WithOrganization(orgID, "test-org"),
+		WithUser(&UserArgs{ID: userID, Name: "test-user", OrgIDs: []uuid.UUID{orgID}}),
+	)
+
+	token := env.createAuthnToken(t, userID)
+	client := organizationv1connect.NewWebhookServiceClient(env.server.Client(), env.server.URL)
+
+	createRes, err := client.CreateWebhook(authedContext(token, orgID), organizationv1.CreateWebhookRequest_builder{
+		Name: "ping-target",
+		Url:  "https://example.com/hooks",
+	}.Build())
+	require.NoError(t, err)
+
+	pingRes, err := client.PingWebhook(authedContext(token, orgID), organizationv1.PingWebhookRequest_builder{
+		WebhookId: createRes.GetId(),
+	}.Build())
+	require.NoError(t, err)
+
+	listRes, err := client.ListWebhookDeliveries(authedContext(token, orgID), organizationv1.ListWebhookDeliveriesRequest_builder{
+		WebhookId: createRes.GetId(),
+	}.Build())
+	require.NoError(t, err)
+	require.Len(t, listRes.GetDeliveries(), 1)
+
+	delivery := listRes.GetDeliveries()[0]
+	assert.Equal(t, pingRes.GetDeliveryId(), delivery.GetId())
+	assert.Equal(t, organizationv1.WebhookEventType_WEBHOOK_EVENT_TYPE_PING, delivery.GetEventType())
+	assert.Equal(t, organizationv1.WebhookDeliveryStatus_WEBHOOK_DELIVERY_STATUS_PENDING, delivery.GetStatus())
+	assert.Contains(t, delivery.GetPayload(), orgID.String())
+	assert.False(t, delivery.HasResponseStatus())
+
+	_, err = client.UpdateWebhook(authedContext(token, orgID), organizationv1.UpdateWebhookRequest_builder{
+		WebhookId: createRes.GetId(),
+		Enabled:   proto.Bool(false),
+	}.Build())
+	require.NoError(t, err)
+
+	_, err = client.PingWebhook(authedContext(token, orgID), organizationv1.PingWebhookRequest_builder{
+		WebhookId: createRes.GetId(),
+	}.Build())
+	var connectErr *connect.Error
+	require.ErrorAs(t, err, &connectErr)
+	assert.Equal(t, connect.CodeFailedPrecondition, connectErr.Code())
+}
+
+func Test_Webhook_OrganizationIsolation(t *testing.T) {
+	t.Parallel()
+
+	orgID := uuid.New()
+	otherOrgID := uuid.New()
+	userID := uuid.New()
+
+	env := newTestAPI(t,
+		WithOrganization(orgID, "test-org"),
+		WithOrganization(otherOrgID, "other-org"),
+		WithUser(&UserArgs{ID: userID, Name: "test-user", OrgIDs: []uuid.UUID{orgID, otherOrgID}}),
+	)
+
+	token := env.createAuthnToken(t, userID)
+	client := organizationv1connect.NewWebhookServiceClient(env.server.Client(), env.server.URL)
+
+	createRes, err := client.CreateWebhook(authedContext(token, orgID), organizationv1.CreateWebhookRequest_builder{
+		Name: "alerts",
+		Url:  "https://example.com/hooks",
+	}.Build())
+	require.NoError(t, err)
+
+	listRes, err := client.ListWebhooks(authedContext(token, otherOrgID), organizationv1.ListWebhooksRequest_builder{}.Build())
+	require.NoError(t, err)
+	assert.Empty(t, listRes.GetWebhooks())
+
+	_, err = client.GetWebhook(authedContext(token, otherOrgID), organizationv1.GetWebhookRequest_builder{
+		WebhookId: createRes.GetId(),
+	}.Build())
+	var connectErr *connect.Error
+	require.ErrorAs(t, err, &connectErr)
+	assert.Equal(t, connect.CodeNotFound, connectErr.Code())
+}
+
+func Test_Webhook_InvalidRequest(t *testing.T) {
+	t.Parallel()
+
+	orgID := uuid.New()
+	userID := uuid.New()
+
+	env := newTestAPI(t,
+		WithOrganization(orgID, "test-org"),
+		WithUser(&UserArgs{ID: userID, Name: "test-user", OrgIDs: []uuid.UUID{orgID}}),
+	)
+
+	token := env.createAuthnToken(t, userID)
+	client := organizationv1connect.NewWebhookServiceClient(env.server.Client(), env.server.URL)
+
+	tests := map[string]*organizationv1.CreateWebhookRequest{
+		"non-http url": organizationv1.CreateWebhookRequest_builder{
+			Name: "ftp",
+			Url:  "ftp://example.com/hooks",
+		}.Build(),
+		"ping in filter": organizationv1.CreateWebhookRequest_builder{
+			Name: "ping",
+			Url:  "https://example.com/hooks",
+			EventFilter: organizationv1.WebhookEventFilter_builder{
+				EventTypes: []organizationv1.WebhookEventType{organizationv1.WebhookEventType_WEBHOOK_EVENT_TYPE_PING},
+			}.Build(),
+		}.Build(),
+	}
+	for name, req := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := client.CreateWebhook(authedContext(token, orgID), req)
+
+			var connectErr *connect.Error
+			require.ErrorAs(t, err, &connectErr)
+			assert.Equal(t, connect.CodeInvalidArgument, connectErr.Code())
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package organization
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"connectrpc.com/connect"
+	"github.com/google/uuid"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/fundament-oss/fundament/common/dbconst"
+	db "github.com/fundament-oss/fundament/organization-api/pkg/db/gen"
+	organizationv1 "github.com/fundament-oss/fundament/organization-api/pkg/proto/gen/v1"
+)
+
+func (s *Server) UpdateWebhook(
+	ctx context.Context,
+	req *organizationv1.UpdateWebhookRequest,
+) (*organizationv1.UpdateWebhookResponse, error) {
+	webhookID := uuid.MustParse(req.GetWebhookId())
+
+	if _, err := s.getWebhook(ctx, webhookID); err != nil {
+		return nil, err
+	}
+
+	params := db.WebhookUpdateParams{ID: webhookID}
+	if req.HasName() {
+		params.Name = pgtype.Text{String: req.GetName(), Valid: true}
+	}
+	if req.HasUrl() {
+		params.Url = pgtype.Text{String: req.GetUrl(), Valid: true}
+	}
+	if req.HasEventFilter() {
+		params.EventTypes = webhookEventTypesToDB(req.GetEventFilter())
+	}
+	if req.HasEnabled() {
+		params.Enabled = pgtype.Bool{Bool: req.GetEnabled(), Valid: true}
+	}
+
+	rowsAffected, err := s.queries.WebhookUpdate(ctx, params)
+	if err != nil {
+		if pgErr, ok := errors.AsType[*pgconn.PgError](err); ok {
+			if pgErr.Code == pgerrcode.UniqueViolation && pgErr.ConstraintName == dbconst.ConstraintWebhooksUqName {
+				return nil, connect.NewError(connect.CodeAlreadyExists,
+					fmt.Errorf("a webhook with this name already exists"))
+			}
+		}
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to update webhook: %w", err))
+	}
+	if rowsAffected != 1 {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("webhook not found"))
+	}
+
+	s.logger.InfoContext(ctx, "webhook updated", "webhook_id", webhookID)
+
+	return organizationv1.UpdateWebhookResponse_builder{}.Build(), nil
+}
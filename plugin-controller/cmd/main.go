@@ -88,7 +88,7 @@ func run() error {
 	// timeout is a broad safety net.
 	defHTTP := &http.Client{Timeout: 30 * time.Second}
 	reconciler := controller.NewReconciler(mgr.GetClient(), logger, &cfg,
-		controller.WithDefClient(defclient.New(cfg.OrganizationAPIURL, defHTTP)),
+		controller.WithDefClient(defclient.New(cfg.OrganizationAPIURL, defHTTP, cfg.ServiceTokenFile)),
 	)
 	if err := reconciler.SetupWithManager(mgr); err != nil {
 		return fmt.Errorf("setup controller: %w", err)
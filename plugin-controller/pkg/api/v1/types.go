@@ -12,6 +12,7 @@ type PluginPhase string
 const (
 	PluginPhasePending     PluginPhase = "Pending"
 	PluginPhaseDeploying   PluginPhase = "Deploying"
+	PluginPhaseUpgrading   PluginPhase = "Upgrading"
 	PluginPhaseRunning     PluginPhase = "Running"
 	PluginPhaseDegraded    PluginPhase = "Degraded"
 	PluginPhaseFailed      PluginPhase = "Failed"
@@ -29,8 +30,9 @@ type PluginInstallation struct {
 
 // +k8s:deepcopy-gen=true
 type PluginInstallationSpec struct {
-	// DefinitionRef is the immutable pin to the published PluginDefinition the
-	// installer consented to. plugin-controller resolves the definition by
+	// DefinitionRef is the pin to the published PluginDefinition the
+	// installer consented to. Moving it to another version of the same plugin
+	// upgrades the installation (see PluginInstallationStatus.Upgrade). plugin-controller resolves the definition by
 	// DefinitionHash and materialises the plugin SA's Role from it (FUN-17).
 	// It is the source of truth for the plugin's RBAC scope — no RBAC is
 	// copied onto this CR.
@@ -67,6 +69,22 @@ type PluginInstallationStatus struct {
 	Ready              bool               `json:"ready,omitempty"`
 	ObservedGeneration int64              `json:"observedGeneration,omitempty"`
 	Conditions         []metav1.Condition `json:"conditions,omitzero" patchStrategy:"merge" patchMergeKey:"type" protobuf:"bytes,1,rep,name=conditions"`
+
+	// InstalledDefinitionRef is the definition the plugin last reached
+	// Running with. A spec.definitionRef that differs from it is an upgrade.
+	InstalledDefinitionRef *DefinitionRef `json:"installedDefinitionRef,omitempty"`
+	// Upgrade records the most recent upgrade. It is kept after the upgrade
+	// finishes so the Deployment, which is derived from it, stays unchanged.
+	Upgrade *PluginUpgrade `json:"upgrade,omitempty"`
+}
+
+// PluginUpgrade is a move of spec.definitionRef from one pinned definition to
+// another. The outcome is reported by the Upgraded condition.
+//
+// +k8s:deepcopy-gen=true
+type PluginUpgrade struct {
+	From DefinitionRef `json:"from"`
+	To   DefinitionRef `json:"to"`
 }
 
 // PluginInstallationList is a list of PluginInstallation resources.
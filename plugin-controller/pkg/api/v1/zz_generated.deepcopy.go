@@ -116,6 +116,16 @@ func (in *PluginInstallationStatus) DeepCopyInto(out *PluginInstallationStatus)
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.InstalledDefinitionRef != nil {
+		in, out := &in.InstalledDefinitionRef, &out.InstalledDefinitionRef
+		*out = new(DefinitionRef)
+		**out = **in
+	}
+	if in.Upgrade != nil {
+		in, out := &in.Upgrade, &out.Upgrade
+		*out = new(PluginUpgrade)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PluginInstallationStatus.
@@ -127,3 +137,20 @@ func (in *PluginInstallationStatus) DeepCopy() *PluginInstallationStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PluginUpgrade) DeepCopyInto(out *PluginUpgrade) {
+	*out = *in
+	out.From = in.From
+	out.To = in.To
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PluginUpgrade.
+func (in *PluginUpgrade) DeepCopy() *PluginUpgrade {
+	if in == nil {
+		return nil
+	}
+	out := new(PluginUpgrade)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -15,6 +15,11 @@ type Config struct {
 	HealthPort         int           `env:"HEALTH_PORT" envDefault:"8097"`
 	StatusPollInterval time.Duration `env:"STATUS_POLL_INTERVAL" envDefault:"30s"`
 
+	// ServiceTokenFile holds the ServiceToken (sub=plugin-controller) the
+	// controller presents to organization-api's approval lookup. The token,
+	// not FundamentOrgID, decides whose approvals the controller reads.
+	ServiceTokenFile string `env:"SERVICE_TOKEN_FILE" envDefault:"/var/run/secrets/fundament/service-token/token"`
+
 	// AllowUnpinnedHash bypasses the definition-hash gate in
 	// reconcilePluginScope: when true, a PluginInstallation with an empty
 	// DefinitionHash is accepted and the definition is fetched from
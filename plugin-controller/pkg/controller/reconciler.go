@@ -161,6 +161,11 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 		return ctrl.Result{}, nil //nolint:nilerr // intentional: permanent validation error, don't requeue
 	}
 
+	beginUpgrade(&cr)
+	if upgradeHeld(&cr) {
+		r.reviewUpgrade(ctx, log, &cr)
+	}
+
 	// Materialise child resources on every reconcile — the controller is
 	// level-triggered, so out-of-band drift (a deleted scope ClusterRole,
 	// RoleBinding or Deployment) is repaired on the next poll rather than
@@ -181,16 +186,31 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 	}
 
 	// Poll plugin status and update CR. statusPoller.poll returns a fresh
-	// PluginInstallationStatus with no Conditions — carry the Conditions
-	// materialised inside reconcileChildren across the assignment.
-	status := r.statusPoller.poll(ctx, &cr)
+	// PluginInstallationStatus with no Conditions or upgrade state — carry
+	// those across the assignment.
+	status, version := r.statusPoller.poll(ctx, &cr)
 	status.Conditions = cr.Status.Conditions
+	status.InstalledDefinitionRef = cr.Status.InstalledDefinitionRef
+	status.Upgrade = cr.Status.Upgrade
 	cr.Status = status
+
+	if finishUpgrade(&cr, version) {
+		// The failed pin is now excluded by deployedDefinitionRef, so this
+		// rolls the Deployment and plugin scope back to the previous definition.
+		log.Info("upgrade failed, rolling back", "from", cr.Status.Upgrade.From.PluginVersion, "to", cr.Status.Upgrade.To.PluginVersion)
+		if err := r.reconcileChildren(ctx, log, &cr); err != nil {
+			if err := r.client.Status().Update(ctx, &cr); err != nil {
+				log.Error("persist status after rollback error failed", "err", err)
+			}
+			return ctrl.Result{}, fmt.Errorf("roll back upgrade: %w", err)
+		}
+	}
+
 	if err := r.client.Status().Update(ctx, &cr); err != nil {
 		return ctrl.Result{}, fmt.Errorf("update status: %w", err)
 	}
 
-	log.Info("reconciled", "phase", status.Phase)
+	log.Info("reconciled", "phase", cr.Status.Phase)
 	return ctrl.Result{RequeueAfter: r.cfg.StatusPollInterval}, nil
 }
 
@@ -263,14 +283,15 @@ func (r *Reconciler) requestPluginUninstall(ctx context.Context, log *slog.Logge
 }
 
 func (r *Reconciler) reconcileChildren(ctx context.Context, log *slog.Logger, cr *pluginsv1.PluginInstallation) error {
-	fundEnvVars := r.fundamentEnvVars()
+	deployed := deployedDefinitionRef(cr)
+	fundEnvVars := append(r.fundamentEnvVars(), versionEnvVars(cr, deployed)...)
 	nsName := pluginNamespace(cr.Name)
 
 	// Fetch + verify + parse the PluginDefinition first. The manifest is the
 	// source of truth for both the scope RBAC (feeding the ClusterRole) and
 	// the container image (feeding the Deployment). Doing this up front means
 	// a hash mismatch aborts before any RBAC or Pod is materialised.
-	def, err := r.fetchDefinition(ctx, cr)
+	def, _, err := r.fetchDefinition(ctx, cr, deployed)
 	if err != nil {
 		setPluginScopeCondition(cr, metav1.ConditionFalse, "MaterialisationFailed", err.Error())
 		return fmt.Errorf("reconcile plugin scope: %w", err)
@@ -369,20 +390,25 @@ func (r *Reconciler) reconcileChildren(ctx context.Context, log *slog.Logger, cr
 }
 
 // setPluginScopeCondition upserts the PluginScopeReady Condition on the CR's
-// status. Same-value updates preserve LastTransitionTime; changes reset it.
+// status.
 func setPluginScopeCondition(cr *pluginsv1.PluginInstallation, status metav1.ConditionStatus, reason, message string) {
-	cond := metav1.Condition{
+	setCondition(cr, metav1.Condition{
 		Type:               ConditionPluginScopeReady,
 		Status:             status,
 		ObservedGeneration: cr.Generation,
 		Reason:             reason,
 		Message:            message,
-	}
+	})
+}
+
+// setCondition upserts a Condition on the CR's status by type. Same-value
+// updates preserve LastTransitionTime; changes reset it.
+func setCondition(cr *pluginsv1.PluginInstallation, cond metav1.Condition) {
 	for i, existing := range cr.Status.Conditions {
-		if existing.Type != ConditionPluginScopeReady {
+		if existing.Type != cond.Type {
 			continue
 		}
-		if existing.Status == status {
+		if existing.Status == cond.Status {
 			cond.LastTransitionTime = existing.LastTransitionTime
 		} else {
 			cond.LastTransitionTime = metav1.Now()
@@ -394,34 +420,36 @@ func setPluginScopeCondition(cr *pluginsv1.PluginInstallation, status metav1.Con
 	cr.Status.Conditions = append(cr.Status.Conditions, cond)
 }
 
-// fetchDefinition fetches the PluginDefinition manifest from organization-api,
-// verifies its sha256 against the CR's pin, and returns the parsed definition.
+// fetchDefinition fetches the PluginDefinition manifest ref pins from
+// organization-api, verifies its sha256 against the pin, and returns the parsed
+// definition with the sha256 of the manifest it was parsed from. ref is
+// spec.definitionRef except while rolling back a failed upgrade.
 //
 // Unpinned (empty or the "sha256:unknown" placeholder) + AllowUnpinnedHash=true
 // → fetch, no comparison (dev loop).
 // Unpinned + AllowUnpinnedHash=false → fail-closed error.
 // Pinned → fetch and require the computed sha256 to match verbatim.
-func (r *Reconciler) fetchDefinition(ctx context.Context, cr *pluginsv1.PluginInstallation) (*pluginruntime.PluginDefinition, error) {
+func (r *Reconciler) fetchDefinition(ctx context.Context, cr *pluginsv1.PluginInstallation, ref pluginsv1.DefinitionRef) (*pluginruntime.PluginDefinition, string, error) {
 	if r.defClient == nil {
 		// Guards a misconfigured construction (NewReconciler without
 		// WithDefClient): fail with a clear error instead of a nil-panic.
-		return nil, fmt.Errorf("plugin-controller misconfigured: no definition client (WithDefClient) set")
+		return nil, "", fmt.Errorf("plugin-controller misconfigured: no definition client (WithDefClient) set")
 	}
 
-	pinned := cr.Spec.DefinitionRef.DefinitionHash
+	pinned := ref.DefinitionHash
 	if isUnpinned(pinned) && !r.cfg.AllowUnpinnedHash {
 		// Fail-closed: a CR without a real pin cannot materialise arbitrary RBAC.
 		// The operator opts into unpinned installs (empty or the "sha256:unknown"
 		// placeholder) by setting PLUGIN_CONTROLLER_ALLOW_UNPINNED_HASH=true on
 		// the Deployment.
-		return nil, fmt.Errorf("PluginInstallation %q has no pinned spec.definitionRef.definitionHash (%q) and PLUGIN_CONTROLLER_ALLOW_UNPINNED_HASH is false", cr.Name, pinned)
+		return nil, "", fmt.Errorf("PluginInstallation %q has no pinned spec.definitionRef.definitionHash (%q) and PLUGIN_CONTROLLER_ALLOW_UNPINNED_HASH is false", cr.Name, pinned)
 	}
 
 	// A definition is stored and fetched by its real metadata.version. The
 	// "unknown" placeholder resolves nothing, so fail fast with an actionable
 	// message instead of surfacing a confusing NotFound from the fetch below.
-	if isUnpinnedVersion(cr.Spec.DefinitionRef.PluginVersion) {
-		return nil, fmt.Errorf("PluginInstallation %q has no resolvable spec.definitionRef.pluginVersion (%q); a real published version is required to fetch its PluginDefinition (pending marketplace wiring, FUN-11)", cr.Name, cr.Spec.DefinitionRef.PluginVersion)
+	if isUnpinnedVersion(ref.PluginVersion) {
+		return nil, "", fmt.Errorf("PluginInstallation %q has no resolvable spec.definitionRef.pluginVersion (%q); a real published version is required to fetch its PluginDefinition (pending marketplace wiring, FUN-11)", cr.Name, ref.PluginVersion)
 	}
 
 	// A pinned definition is immutable and content-addressed, so a previously
@@ -431,7 +459,7 @@ func (r *Reconciler) fetchDefinition(ctx context.Context, cr *pluginsv1.PluginIn
 	// through to a fresh fetch each time (hot-reload).
 	if !isUnpinned(pinned) && r.defCache != nil {
 		if def, ok := r.defCache.get(pinned); ok {
-			return def, nil
+			return def, pinned, nil
 		}
 	}
 
@@ -439,9 +467,9 @@ func (r *Reconciler) fetchDefinition(ctx context.Context, cr *pluginsv1.PluginIn
 	rpcCtx, cancel := context.WithTimeout(ctx, scopeRPCTimeout)
 	defer cancel()
 
-	got, err := r.defClient.GetDefinition(rpcCtx, cr.Spec.DefinitionRef.PluginName, cr.Spec.DefinitionRef.PluginVersion)
+	got, err := r.defClient.GetDefinition(rpcCtx, ref.PluginName, ref.PluginVersion)
 	if err != nil {
-		return nil, fmt.Errorf("fetch definition: %w", err)
+		return nil, "", fmt.Errorf("fetch definition: %w", err)
 	}
 
 	computed := pluginruntime.HashManifest(got.Manifest)
@@ -450,12 +478,12 @@ func (r *Reconciler) fetchDefinition(ctx context.Context, cr *pluginsv1.PluginIn
 	// set (checked above) and skip comparison — the dev/marketplace-pending
 	// loop where no real consent hash exists yet (FUN-11).
 	if !isUnpinned(pinned) && computed != pinned {
-		return nil, fmt.Errorf("definition hash mismatch: pinned=%q, computed=%q", pinned, computed)
+		return nil, "", fmt.Errorf("definition hash mismatch: pinned=%q, computed=%q", pinned, computed)
 	}
 
 	def, err := pluginruntime.ParseDefinition(got.Manifest)
 	if err != nil {
-		return nil, fmt.Errorf("parse manifest: %w", err)
+		return nil, "", fmt.Errorf("parse manifest: %w", err)
 	}
 
 	// Cache only verified, pinned definitions — keyed by the consent hash so a
@@ -463,7 +491,7 @@ func (r *Reconciler) fetchDefinition(ctx context.Context, cr *pluginsv1.PluginIn
 	if !isUnpinned(pinned) && r.defCache != nil {
 		r.defCache.put(pinned, &def)
 	}
-	return &def, nil
+	return &def, computed, nil
 }
 
 // reconcilePluginScope materialises the plugin-scope ClusterRole + binding from
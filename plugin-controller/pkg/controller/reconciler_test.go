@@ -45,6 +45,10 @@ func (f fakeDefClient) GetDefinition(_ context.Context, _, _ string) (defclient.
 	return defclient.Definition{Manifest: f.manifest, Hash: f.hash}, nil
 }
 
+func (f fakeDefClient) GetApproval(_ context.Context, _, _ string) (defclient.Approval, error) {
+	return defclient.Approval{}, f.err
+}
+
 // countingDefClient counts GetDefinition calls to assert the reconciler does not
 // re-fetch an immutable definition on every poll.
 type countingDefClient struct {
@@ -57,6 +61,10 @@ func (c *countingDefClient) GetDefinition(ctx context.Context, name, version str
 	return c.inner.GetDefinition(ctx, name, version)
 }
 
+func (c *countingDefClient) GetApproval(ctx context.Context, name, version string) (defclient.Approval, error) {
+	return c.inner.GetApproval(ctx, name, version)
+}
+
 // sampleManifest returns a valid PluginDefinition YAML and its sha256 pin.
 func sampleManifest(t *testing.T) ([]byte, string) {
 	t.Helper()
@@ -571,6 +579,10 @@ func TestMapPhase(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, pluginsv1.PluginPhaseDeploying, phase)
 
+	phase, err = mapPhase("upgrading")
+	assert.NoError(t, err)
+	assert.Equal(t, pluginsv1.PluginPhaseUpgrading, phase)
+
 	phase, err = mapPhase("degraded")
 	assert.NoError(t, err)
 	assert.Equal(t, pluginsv1.PluginPhaseDegraded, phase)
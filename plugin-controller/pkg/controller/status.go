@@ -33,7 +33,10 @@ func (s *statusPoller) WithClient(client connect.HTTPClient) *statusPoller {
 	return s
 }
 
-func (s *statusPoller) poll(ctx context.Context, cr *pluginsv1.PluginInstallation) pluginsv1.PluginInstallationStatus {
+// poll fetches the plugin's status and the version of the pod that answered.
+// The version is empty when the pod is unreachable or its SDK does not report
+// one.
+func (s *statusPoller) poll(ctx context.Context, cr *pluginsv1.PluginInstallation) (pluginsv1.PluginInstallationStatus, string) {
 	url := pluginServiceURL(cr.Name)
 	client := pluginmetadatav1connect.NewPluginMetadataServiceClient(s.httpClient, url)
 
@@ -43,7 +46,7 @@ func (s *statusPoller) poll(ctx context.Context, cr *pluginsv1.PluginInstallatio
 			Phase:              pluginsv1.PluginPhaseDeploying,
 			Message:            fmt.Sprintf("plugin not reachable: %v", err),
 			ObservedGeneration: cr.Generation,
-		}
+		}, ""
 	}
 
 	phase, err := mapPhase(resp.GetPhase())
@@ -52,7 +55,7 @@ func (s *statusPoller) poll(ctx context.Context, cr *pluginsv1.PluginInstallatio
 			Phase:              pluginsv1.PluginPhaseDegraded,
 			Message:            err.Error(),
 			ObservedGeneration: cr.Generation,
-		}
+		}, resp.GetVersion()
 	}
 
 	return pluginsv1.PluginInstallationStatus{
@@ -60,7 +63,7 @@ func (s *statusPoller) poll(ctx context.Context, cr *pluginsv1.PluginInstallatio
 		Message:            resp.GetMessage(),
 		Ready:              phase == pluginsv1.PluginPhaseRunning,
 		ObservedGeneration: cr.Generation,
-	}
+	}, resp.GetVersion()
 }
 
 func mapPhase(phase string) (pluginsv1.PluginPhase, error) {
@@ -69,6 +72,8 @@ func mapPhase(phase string) (pluginsv1.PluginPhase, error) {
 		return pluginsv1.PluginPhaseRunning, nil
 	case "installing":
 		return pluginsv1.PluginPhaseDeploying, nil
+	case "upgrading":
+		return pluginsv1.PluginPhaseUpgrading, nil
 	case "degraded":
 		return pluginsv1.PluginPhaseDegraded, nil
 	case "failed":
@@ -0,0 +1,225 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	pluginsv1 "github.com/fundament-oss/fundament/plugin-controller/pkg/api/v1"
+	"github.com/fundament-oss/fundament/plugin-sdk/pluginruntime"
+)
+
+const (
+	// ConditionUpgraded reports the outcome of the most recent change of
+	// spec.definitionRef. Unknown while the new definition's permissions are
+	// reviewed and while the upgrade runs, True once the new version is
+	// running, False when it failed and was rolled back or waits for approval.
+	ConditionUpgraded = "Upgraded"
+
+	reasonUpgradeReviewing  = "ReviewingPermissions"
+	reasonApprovalRequired  = "ApprovalRequired"
+	reasonUpgradeInProgress = "UpgradeInProgress"
+	reasonUpgradeSucceeded  = "UpgradeSucceeded"
+	reasonUpgradeFailed     = "UpgradeFailed"
+	reasonUpgradeCancelled  = "UpgradeCancelled"
+)
+
+// beginUpgrade records an upgrade when spec.definitionRef has moved away from
+// the definition the plugin last ran. The upgrade starts held: the previous
+// definition stays deployed until reviewUpgrade has compared the permissions
+// of both. A CR that never reached Running has nothing to upgrade from; its
+// pod is simply replaced.
+func beginUpgrade(cr *pluginsv1.PluginInstallation) {
+	installed := cr.Status.InstalledDefinitionRef
+	if installed == nil {
+		return
+	}
+
+	spec := cr.Spec.DefinitionRef
+	if spec == *installed {
+		// Pinned back to the running definition before the upgrade finished.
+		if upgradeInProgress(cr) || upgradeHeld(cr) {
+			setUpgradedCondition(cr, metav1.ConditionFalse, reasonUpgradeCancelled,
+				fmt.Sprintf("upgrade to %s cancelled, staying on %s", cr.Status.Upgrade.To.PluginVersion, installed.PluginVersion))
+		}
+		return
+	}
+	if cr.Status.Upgrade != nil && cr.Status.Upgrade.To == spec {
+		return
+	}
+
+	cr.Status.Upgrade = &pluginsv1.PluginUpgrade{From: *installed, To: spec}
+	setUpgradedCondition(cr, metav1.ConditionUnknown, reasonUpgradeReviewing,
+		fmt.Sprintf("comparing the permissions of %s with %s", spec.PluginVersion, installed.PluginVersion))
+}
+
+// startUpgrade releases a held upgrade, so the next reconcileChildren rolls
+// the Deployment over to the new definition.
+func startUpgrade(cr *pluginsv1.PluginInstallation) {
+	upgrade := cr.Status.Upgrade
+	setUpgradedCondition(cr, metav1.ConditionUnknown, reasonUpgradeInProgress,
+		fmt.Sprintf("upgrading from %s to %s", upgrade.From.PluginVersion, upgrade.To.PluginVersion))
+}
+
+// reviewUpgrade decides whether a held upgrade may start. The DefinitionHash
+// pin is consent to the permissions of the installed definition only, so an
+// upgrade to a definition that grants more waits until an organization admin
+// approves that definition in organization-api. It is re-checked on every
+// reconcile while held, so the upgrade starts within a poll interval of the
+// approval. Failures leave the upgrade held.
+func (r *Reconciler) reviewUpgrade(ctx context.Context, log *slog.Logger, cr *pluginsv1.PluginInstallation) {
+	upgrade := cr.Status.Upgrade
+
+	to, toHash, err := r.fetchDefinition(ctx, cr, upgrade.To)
+	if err != nil {
+		setUpgradedCondition(cr, metav1.ConditionUnknown, reasonUpgradeReviewing,
+			fmt.Sprintf("cannot review the permissions of %s: %s", upgrade.To.PluginVersion, err))
+		return
+	}
+	var installed pluginruntime.PluginSpec
+	if from, _, err := r.fetchDefinition(ctx, cr, upgrade.From); err == nil {
+		installed = from.Spec
+	} else {
+		// The installed definition can be gone, e.g. republished under another
+		// hash. Compare against nothing, so every permission needs approval.
+		log.Info("installed definition unavailable, treating all permissions as new", "version", upgrade.From.PluginVersion, "err", err)
+	}
+
+	diff := pluginruntime.DiffPermissions(installed, to.Spec)
+	if diff.Escalates() {
+		approved, err := r.upgradeApproved(ctx, upgrade.To, toHash)
+		if err != nil {
+			setUpgradedCondition(cr, metav1.ConditionUnknown, reasonUpgradeReviewing,
+				fmt.Sprintf("cannot check the approval of %s: %s", upgrade.To.PluginVersion, err))
+			return
+		}
+		if !approved {
+			setUpgradedCondition(cr, metav1.ConditionFalse, reasonApprovalRequired,
+				fmt.Sprintf("upgrade from %s to %s %s; an organization admin must approve %s before it is rolled out",
+					upgrade.From.PluginVersion, upgrade.To.PluginVersion, diff.Summary(), upgrade.To.PluginVersion))
+			return
+		}
+		log.Info("upgrade adds permissions the organization approved", "from", upgrade.From.PluginVersion, "to", upgrade.To.PluginVersion)
+	}
+	startUpgrade(cr)
+}
+
+// upgradeApproved asks organization-api whether the organization approved
+// ref, fetched with the given hash. The approval must be for that hash, pinned
+// or not, so it never carries over to a definition republished under the same
+// version.
+func (r *Reconciler) upgradeApproved(ctx context.Context, ref pluginsv1.DefinitionRef, hash string) (bool, error) {
+	rpcCtx, cancel := context.WithTimeout(ctx, scopeRPCTimeout)
+	defer cancel()
+
+	approval, err := r.defClient.GetApproval(rpcCtx, ref.PluginName, ref.PluginVersion)
+	if err != nil {
+		return false, fmt.Errorf("get approval: %w", err)
+	}
+	return approval.Approved && approval.Hash == hash, nil
+}
+
+// finishUpgrade moves an in-progress upgrade to its outcome once the new pod
+// reports a terminal phase, and records the definition the plugin runs.
+// version is what the polled pod reported. It returns true when the upgrade
+// failed and the Deployment must be rolled back to the previous definition.
+func finishUpgrade(cr *pluginsv1.PluginInstallation, version string) bool {
+	status := &cr.Status
+	deployed := deployedDefinitionRef(cr)
+
+	if !upgradeInProgress(cr) {
+		// Plugins built against an SDK without version reporting answer with
+		// an empty version; outside an upgrade only one definition is rolled
+		// out, so the answer can only come from it.
+		if status.Phase == pluginsv1.PluginPhaseRunning && (version == "" || version == deployed.PluginVersion) {
+			status.InstalledDefinitionRef = &deployed
+		}
+		return false
+	}
+
+	upgrade := cr.Status.Upgrade
+	if version != upgrade.To.PluginVersion {
+		// The previous pod keeps serving until the new one is ready, so the
+		// poll may still reach it.
+		status.Phase = pluginsv1.PluginPhaseUpgrading
+		status.Message = fmt.Sprintf("upgrading from %s to %s", upgrade.From.PluginVersion, upgrade.To.PluginVersion)
+		status.Ready = false
+		return false
+	}
+
+	switch status.Phase {
+	case pluginsv1.PluginPhaseRunning:
+		to := upgrade.To
+		status.InstalledDefinitionRef = &to
+		setUpgradedCondition(cr, metav1.ConditionTrue, reasonUpgradeSucceeded,
+			fmt.Sprintf("upgraded from %s to %s", upgrade.From.PluginVersion, upgrade.To.PluginVersion))
+		return false
+	case pluginsv1.PluginPhaseFailed:
+		setUpgradedCondition(cr, metav1.ConditionFalse, reasonUpgradeFailed,
+			fmt.Sprintf("upgrade from %s to %s failed, rolled back to %s: %s",
+				upgrade.From.PluginVersion, upgrade.To.PluginVersion, upgrade.From.PluginVersion, status.Message))
+		return true
+	default:
+		status.Phase = pluginsv1.PluginPhaseUpgrading
+		status.Ready = false
+		return false
+	}
+}
+
+// deployedDefinitionRef is the definition the Deployment should run:
+// spec.definitionRef, unless the upgrade to that exact pin is held or failed,
+// in which case the definition it is upgraded from. Pinning another version
+// retries.
+func deployedDefinitionRef(cr *pluginsv1.PluginInstallation) pluginsv1.DefinitionRef {
+	upgrade := cr.Status.Upgrade
+	if upgrade != nil && upgrade.To == cr.Spec.DefinitionRef && (upgradeHeld(cr) || upgradeFailed(cr)) {
+		return upgrade.From
+	}
+	return cr.Spec.DefinitionRef
+}
+
+// versionEnvVars tells the plugin runtime which version it runs and, for the
+// target of an upgrade, which version it replaces so Run calls
+// Installer.Upgrade. They stay set after the upgrade finishes: removing them
+// would roll the pod again, so Upgrade must be idempotent, like Start.
+func versionEnvVars(cr *pluginsv1.PluginInstallation, deployed pluginsv1.DefinitionRef) []corev1.EnvVar {
+	envVars := []corev1.EnvVar{{Name: "FUNDAMENT_PLUGIN_VERSION", Value: deployed.PluginVersion}}
+	if upgrade := cr.Status.Upgrade; upgrade != nil && upgrade.To == deployed {
+		envVars = append(envVars, corev1.EnvVar{Name: "FUNDAMENT_UPGRADE_FROM_VERSION", Value: upgrade.From.PluginVersion})
+	}
+	return envVars
+}
+
+func upgradeInProgress(cr *pluginsv1.PluginInstallation) bool {
+	cond := meta.FindStatusCondition(cr.Status.Conditions, ConditionUpgraded)
+	return cr.Status.Upgrade != nil && cond != nil && cond.Reason == reasonUpgradeInProgress
+}
+
+// upgradeHeld reports whether the upgrade waits for its permission review or
+// for an admin to approve the permissions it adds.
+func upgradeHeld(cr *pluginsv1.PluginInstallation) bool {
+	cond := meta.FindStatusCondition(cr.Status.Conditions, ConditionUpgraded)
+	return cr.Status.Upgrade != nil && cond != nil &&
+		(cond.Reason == reasonUpgradeReviewing || cond.Reason == reasonApprovalRequired)
+}
+
+func upgradeFailed(cr *pluginsv1.PluginInstallation) bool {
+	cond := meta.FindStatusCondition(cr.Status.Conditions, ConditionUpgraded)
+	return cond != nil && cond.Reason == reasonUpgradeFailed
+}
+
+// setUpgradedCondition upserts the Upgraded Condition, the same way
+// setPluginScopeCondition does for PluginScopeReady.
+func setUpgradedCondition(cr *pluginsv1.PluginInstallation, status metav1.ConditionStatus, reason, message string) {
+	setCondition(cr, metav1.Condition{
+		Type:               ConditionUpgraded,
+		Status:             status,
+		ObservedGeneration: cr.Generation,
+		Reason:             reason,
+		Message:            message,
+	})
+}
@@ -0,0 +1,369 @@
+package controller
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	pluginsv1 "github.com/fundament-oss/fundament/plugin-controller/pkg/api/v1"
+	"github.com/fundament-oss/fundament/plugin-controller/pkg/config"
+	"github.com/fundament-oss/fundament/plugin-controller/pkg/defclient"
+	"github.com/fundament-oss/fundament/plugin-sdk/pluginruntime"
+	pb "github.com/fundament-oss/fundament/plugin-sdk/pluginruntime/metadata/proto/gen/v1"
+	"github.com/fundament-oss/fundament/plugin-sdk/pluginruntime/metadata/proto/gen/v1/pluginmetadatav1connect"
+)
+
+// statusHandler answers GetStatus with whatever phase and version the test
+// last stored, standing in for the plugin pod behind the Service.
+type statusHandler struct {
+	pluginmetadatav1connect.UnimplementedPluginMetadataServiceHandler
+	phase   atomic.Value
+	version atomic.Value
+}
+
+func (h *statusHandler) set(phase, version string) {
+	h.phase.Store(phase)
+	h.version.Store(version)
+}
+
+func (h *statusHandler) GetStatus(_ context.Context, _ *pb.GetStatusRequest) (*pb.GetStatusResponse, error) {
+	phase, _ := h.phase.Load().(string)
+	version, _ := h.version.Load().(string)
+	return &pb.GetStatusResponse{Phase: &phase, Message: new(string), Version: &version}, nil
+}
+
+// statusHTTPClient serves every request from h in-process, so the poller's
+// cluster-local Service URL needs no DNS.
+func statusHTTPClient(h *statusHandler) connect.HTTPClient {
+	_, handler := pluginmetadatav1connect.NewPluginMetadataServiceHandler(h)
+	return &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec.Result(), nil
+	})}
+}
+
+func upgradeTestCR() *pluginsv1.PluginInstallation {
+	cr := testCR()
+	cr.SetUID("test-uid")
+	cr.Finalizers = []string{finalizerName}
+	cr.Spec.DefinitionRef.PluginVersion = "v1.18.0"
+	cr.Status.InstalledDefinitionRef = &pluginsv1.DefinitionRef{PluginName: "cert-manager", PluginVersion: "v1.17.2"}
+	return cr
+}
+
+// versionedDefClient serves a manifest per plugin version and the
+// organization's approval, like organization-api.
+type versionedDefClient struct {
+	manifests map[string][]byte
+	approval  *defclient.Approval
+}
+
+func (c versionedDefClient) GetDefinition(_ context.Context, _, version string) (defclient.Definition, error) {
+	manifest, ok := c.manifests[version]
+	if !ok {
+		return defclient.Definition{}, connect.NewError(connect.CodeNotFound, fmt.Errorf("%s not found", version))
+	}
+	return defclient.Definition{Manifest: manifest, Hash: pluginruntime.HashManifest(manifest)}, nil
+}
+
+func (c versionedDefClient) GetApproval(_ context.Context, _, _ string) (defclient.Approval, error) {
+	return *c.approval, nil
+}
+
+func newUpgradeTestReconciler(t *testing.T, cr *pluginsv1.PluginInstallation, pod *statusHandler) (*Reconciler, client.Client) {
+	t.Helper()
+	manifest, _ := sampleManifest(t)
+	return newUpgradeTestReconcilerWithDefs(t, cr, pod, fakeDefClient{manifest: manifest})
+}
+
+func newUpgradeTestReconcilerWithDefs(t *testing.T, cr *pluginsv1.PluginInstallation, pod *statusHandler, defs defclient.Client) (*Reconciler, client.Client) {
+	t.Helper()
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(newTestScheme()).
+		WithObjects(cr).
+		WithStatusSubresource(cr).
+		Build()
+
+	r := NewReconciler(fakeClient, slog.Default(),
+		&config.Config{StatusPollInterval: 30 * time.Second, AllowUnpinnedHash: true},
+		WithHTTPClient(statusHTTPClient(pod)),
+		WithDefClient(defs),
+	)
+	return r, fakeClient
+}
+
+func reconcileUpgrade(t *testing.T, r *Reconciler, c client.Client) (*pluginsv1.PluginInstallation, map[string]string) {
+	t.Helper()
+	ctx := context.Background()
+	_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: "cert-manager"}})
+	require.NoError(t, err)
+
+	var cr pluginsv1.PluginInstallation
+	require.NoError(t, c.Get(ctx, types.NamespacedName{Name: "cert-manager"}, &cr))
+
+	var deploy appsv1.Deployment
+	require.NoError(t, c.Get(ctx, types.NamespacedName{Name: "plugin-cert-manager", Namespace: pluginNamespace("cert-manager")}, &deploy))
+	env := map[string]string{}
+	for _, e := range deploy.Spec.Template.Spec.Containers[0].Env {
+		env[e.Name] = e.Value
+	}
+	return &cr, env
+}
+
+func TestReconcile_UpgradeSucceeds(t *testing.T) {
+	pod := &statusHandler{}
+	pod.set("running", "v1.17.2")
+	r, c := newUpgradeTestReconciler(t, upgradeTestCR(), pod)
+
+	// The previous pod still answers while the new one upgrades.
+	cr, env := reconcileUpgrade(t, r, c)
+	assert.Equal(t, pluginsv1.PluginPhaseUpgrading, cr.Status.Phase)
+	assert.False(t, cr.Status.Ready)
+	require.NotNil(t, cr.Status.Upgrade)
+	assert.Equal(t, "v1.17.2", cr.Status.Upgrade.From.PluginVersion)
+	assert.Equal(t, "v1.18.0", cr.Status.Upgrade.To.PluginVersion)
+	cond := meta.FindStatusCondition(cr.Status.Conditions, ConditionUpgraded)
+	require.NotNil(t, cond)
+	assert.Equal(t, metav1.ConditionUnknown, cond.Status)
+	assert.Equal(t, "upgrading from v1.17.2 to v1.18.0", cond.Message)
+	assert.Equal(t, "v1.18.0", env["FUNDAMENT_PLUGIN_VERSION"])
+	assert.Equal(t, "v1.17.2", env["FUNDAMENT_UPGRADE_FROM_VERSION"])
+
+	pod.set("running", "v1.18.0")
+	cr, env = reconcileUpgrade(t, r, c)
+	assert.Equal(t, pluginsv1.PluginPhaseRunning, cr.Status.Phase)
+	assert.Equal(t, "v1.18.0", cr.Status.InstalledDefinitionRef.PluginVersion)
+	cond = meta.FindStatusCondition(cr.Status.Conditions, ConditionUpgraded)
+	assert.Equal(t, metav1.ConditionTrue, cond.Status)
+	assert.Equal(t, reasonUpgradeSucceeded, cond.Reason)
+	// The Deployment is left as it was, so finishing does not restart the pod.
+	assert.Equal(t, "v1.17.2", env["FUNDAMENT_UPGRADE_FROM_VERSION"])
+}
+
+func TestReconcile_UpgradeFailureRollsBack(t *testing.T) {
+	pod := &statusHandler{}
+	pod.set("upgrading", "v1.18.0")
+	r, c := newUpgradeTestReconciler(t, upgradeTestCR(), pod)
+
+	cr, _ := reconcileUpgrade(t, r, c)
+	assert.Equal(t, pluginsv1.PluginPhaseUpgrading, cr.Status.Phase)
+
+	pod.set("failed", "v1.18.0")
+	cr, env := reconcileUpgrade(t, r, c)
+	cond := meta.FindStatusCondition(cr.Status.Conditions, ConditionUpgraded)
+	require.NotNil(t, cond)
+	assert.Equal(t, metav1.ConditionFalse, cond.Status)
+	assert.Equal(t, reasonUpgradeFailed, cond.Reason)
+	assert.Equal(t, "v1.17.2", cr.Status.InstalledDefinitionRef.PluginVersion)
+	assert.Equal(t, "v1.17.2", env["FUNDAMENT_PLUGIN_VERSION"], "rolled back to the previous definition")
+	assert.NotContains(t, env, "FUNDAMENT_UPGRADE_FROM_VERSION")
+
+	// The failed pin is not retried while spec still points at it.
+	pod.set("running", "v1.17.2")
+	cr, env = reconcileUpgrade(t, r, c)
+	assert.Equal(t, pluginsv1.PluginPhaseRunning, cr.Status.Phase)
+	assert.Equal(t, reasonUpgradeFailed, meta.FindStatusCondition(cr.Status.Conditions, ConditionUpgraded).Reason)
+	assert.Equal(t, "v1.17.2", env["FUNDAMENT_PLUGIN_VERSION"])
+}
+
+func TestReconcile_UpgradeAddingPermissionsWaitsForApproval(t *testing.T) {
+	installed, installedHash := sampleManifest(t)
+	target := bytes.Replace(installed, []byte("version: v1.17.2"), []byte("version: v1.18.0"), 1)
+	target = append(target, []byte("      - apiGroups: [\"\"]\n        resources: [secrets]\n        verbs: [create]\n")...)
+	targetHash := pluginruntime.HashManifest(target)
+
+	cr := upgradeTestCR()
+	cr.Spec.DefinitionRef.DefinitionHash = targetHash
+	cr.Status.InstalledDefinitionRef.DefinitionHash = installedHash
+	approval := &defclient.Approval{}
+	defs := versionedDefClient{manifests: map[string][]byte{"v1.17.2": installed, "v1.18.0": target}, approval: approval}
+
+	pod := &statusHandler{}
+	pod.set("running", "v1.17.2")
+	r, c := newUpgradeTestReconcilerWithDefs(t, cr, pod, defs)
+
+	got, env := reconcileUpgrade(t, r, c)
+	cond := meta.FindStatusCondition(got.Status.Conditions, ConditionUpgraded)
+	require.NotNil(t, cond)
+	assert.Equal(t, metav1.ConditionFalse, cond.Status)
+	assert.Equal(t, reasonApprovalRequired, cond.Reason)
+	assert.Contains(t, cond.Message, "adds RBAC create secrets")
+	assert.Equal(t, pluginsv1.PluginPhaseRunning, got.Status.Phase, "the installed version keeps running")
+	assert.Equal(t, "v1.17.2", env["FUNDAMENT_PLUGIN_VERSION"])
+	assert.NotContains(t, env, "FUNDAMENT_UPGRADE_FROM_VERSION")
+
+	var role rbacv1.ClusterRole
+	require.NoError(t, c.Get(context.Background(), types.NamespacedName{Name: pluginScopeClusterRoleName("cert-manager")}, &role))
+	for _, rule := range role.Rules {
+		assert.NotContains(t, rule.Verbs, "create", "the new permissions are not granted yet")
+	}
+
+	// An approval of another manifest under the same version does not count.
+	*approval = defclient.Approval{Approved: true, Hash: "sha256:republished"}
+	got, _ = reconcileUpgrade(t, r, c)
+	assert.Equal(t, reasonApprovalRequired, meta.FindStatusCondition(got.Status.Conditions, ConditionUpgraded).Reason)
+
+	*approval = defclient.Approval{Approved: true, Hash: targetHash}
+	got, env = reconcileUpgrade(t, r, c)
+	assert.Equal(t, reasonUpgradeInProgress, meta.FindStatusCondition(got.Status.Conditions, ConditionUpgraded).Reason)
+	assert.Equal(t, "v1.18.0", env["FUNDAMENT_PLUGIN_VERSION"])
+	assert.Equal(t, "v1.17.2", env["FUNDAMENT_UPGRADE_FROM_VERSION"])
+}
+
+func TestReconcile_UnpinnedUpgradeRepublishedAfterApproval(t *testing.T) {
+	installed, _ := sampleManifest(t)
+	approved := bytes.Replace(installed, []byte("version: v1.17.2"), []byte("version: v1.18.0"), 1)
+	approved = append(approved, []byte("      - apiGroups: [\"\"]\n        resources: [secrets]\n        verbs: [create]\n")...)
+	republished := append(bytes.Clone(approved), []byte("      - apiGroups: [\"\"]\n        resources: [secrets]\n        verbs: [delete]\n")...)
+
+	// Unpinned, so the controller rolls out whatever v1.18.0 is published as.
+	approval := &defclient.Approval{Approved: true, Hash: pluginruntime.HashManifest(approved)}
+	manifests := map[string][]byte{"v1.17.2": installed, "v1.18.0": republished}
+	defs := versionedDefClient{manifests: manifests, approval: approval}
+
+	pod := &statusHandler{}
+	pod.set("running", "v1.17.2")
+	r, c := newUpgradeTestReconcilerWithDefs(t, upgradeTestCR(), pod, defs)
+
+	got, env := reconcileUpgrade(t, r, c)
+	cond := meta.FindStatusCondition(got.Status.Conditions, ConditionUpgraded)
+	require.NotNil(t, cond)
+	assert.Equal(t, reasonApprovalRequired, cond.Reason, "the approval was for the definition before it was republished")
+	assert.Equal(t, "v1.17.2", env["FUNDAMENT_PLUGIN_VERSION"])
+
+	*approval = defclient.Approval{Approved: true, Hash: pluginruntime.HashManifest(republished)}
+	got, env = reconcileUpgrade(t, r, c)
+	assert.Equal(t, reasonUpgradeInProgress, meta.FindStatusCondition(got.Status.Conditions, ConditionUpgraded).Reason)
+	assert.Equal(t, "v1.18.0", env["FUNDAMENT_PLUGIN_VERSION"])
+}
+
+func TestReconcile_UpgradeWithFewerPermissionsNeedsNoApproval(t *testing.T) {
+	installed, _ := sampleManifest(t)
+	target := bytes.Replace(installed, []byte("      - apiGroups: [\"\"]\n        resources: [secrets]\n        verbs: [get]\n"), nil, 1)
+	require.NotEqual(t, installed, target)
+
+	defs := versionedDefClient{manifests: map[string][]byte{"v1.17.2": installed, "v1.18.0": target}, approval: &defclient.Approval{}}
+	pod := &statusHandler{}
+	pod.set("running", "v1.17.2")
+	r, c := newUpgradeTestReconcilerWithDefs(t, upgradeTestCR(), pod, defs)
+
+	got, env := reconcileUpgrade(t, r, c)
+	assert.Equal(t, reasonUpgradeInProgress, meta.FindStatusCondition(got.Status.Conditions, ConditionUpgraded).Reason)
+	assert.Equal(t, "v1.18.0", env["FUNDAMENT_PLUGIN_VERSION"])
+}
+
+func TestReconcile_UpgradeReviewFailureHoldsUpgrade(t *testing.T) {
+	installed, _ := sampleManifest(t)
+	defs := versionedDefClient{manifests: map[string][]byte{"v1.17.2": installed}, approval: &defclient.Approval{}}
+	pod := &statusHandler{}
+	pod.set("running", "v1.17.2")
+	r, c := newUpgradeTestReconcilerWithDefs(t, upgradeTestCR(), pod, defs)
+
+	got, env := reconcileUpgrade(t, r, c)
+	cond := meta.FindStatusCondition(got.Status.Conditions, ConditionUpgraded)
+	assert.Equal(t, metav1.ConditionUnknown, cond.Status)
+	assert.Equal(t, reasonUpgradeReviewing, cond.Reason)
+	assert.Contains(t, cond.Message, "cannot review the permissions of v1.18.0")
+	assert.Equal(t, "v1.17.2", env["FUNDAMENT_PLUGIN_VERSION"])
+}
+
+func TestBeginUpgrade(t *testing.T) {
+	t.Run("first install is not an upgrade", func(t *testing.T) {
+		cr := testCR()
+		beginUpgrade(cr)
+		assert.Nil(t, cr.Status.Upgrade)
+		assert.Empty(t, cr.Status.Conditions)
+	})
+
+	t.Run("unchanged pin", func(t *testing.T) {
+		cr := testCR()
+		installed := cr.Spec.DefinitionRef
+		cr.Status.InstalledDefinitionRef = &installed
+		beginUpgrade(cr)
+		assert.Nil(t, cr.Status.Upgrade)
+	})
+
+	t.Run("held until reviewed", func(t *testing.T) {
+		cr := upgradeTestCR()
+		beginUpgrade(cr)
+		require.True(t, upgradeHeld(cr))
+		assert.Equal(t, "v1.17.2", deployedDefinitionRef(cr).PluginVersion)
+
+		startUpgrade(cr)
+		assert.True(t, upgradeInProgress(cr))
+		assert.Equal(t, "v1.18.0", deployedDefinitionRef(cr).PluginVersion)
+	})
+
+	t.Run("pinned back while held", func(t *testing.T) {
+		cr := upgradeTestCR()
+		beginUpgrade(cr)
+		setUpgradedCondition(cr, metav1.ConditionFalse, reasonApprovalRequired, "adds secrets")
+
+		cr.Spec.DefinitionRef = *cr.Status.InstalledDefinitionRef
+		beginUpgrade(cr)
+		assert.False(t, upgradeHeld(cr))
+		assert.Equal(t, reasonUpgradeCancelled, meta.FindStatusCondition(cr.Status.Conditions, ConditionUpgraded).Reason)
+	})
+
+	t.Run("pinned back before the upgrade finished", func(t *testing.T) {
+		cr := upgradeTestCR()
+		beginUpgrade(cr)
+		startUpgrade(cr)
+		require.True(t, upgradeInProgress(cr))
+
+		cr.Spec.DefinitionRef = *cr.Status.InstalledDefinitionRef
+		beginUpgrade(cr)
+		assert.False(t, upgradeInProgress(cr))
+		assert.Equal(t, reasonUpgradeCancelled, meta.FindStatusCondition(cr.Status.Conditions, ConditionUpgraded).Reason)
+		assert.Equal(t, "v1.17.2", deployedDefinitionRef(cr).PluginVersion)
+	})
+
+	t.Run("retry after failure with another version", func(t *testing.T) {
+		cr := upgradeTestCR()
+		beginUpgrade(cr)
+		setUpgradedCondition(cr, metav1.ConditionFalse, reasonUpgradeFailed, "boom")
+		require.Equal(t, "v1.17.2", deployedDefinitionRef(cr).PluginVersion)
+
+		cr.Spec.DefinitionRef.PluginVersion = "v1.18.1"
+		beginUpgrade(cr)
+		assert.True(t, upgradeHeld(cr))
+		startUpgrade(cr)
+		assert.True(t, upgradeInProgress(cr))
+		assert.Equal(t, "v1.18.1", deployedDefinitionRef(cr).PluginVersion)
+	})
+}
+
+func TestFinishUpgrade_RecordsFirstInstall(t *testing.T) {
+	cr := testCR()
+	cr.Status.Phase = pluginsv1.PluginPhaseRunning
+
+	// An SDK without version reporting answers with an empty version.
+	assert.False(t, finishUpgrade(cr, ""))
+	require.NotNil(t, cr.Status.InstalledDefinitionRef)
+	assert.Equal(t, cr.Spec.DefinitionRef, *cr.Status.InstalledDefinitionRef)
+}
+
+func TestVersionEnvVars(t *testing.T) {
+	cr := testCR()
+	assert.Equal(t, []corev1.EnvVar{{Name: "FUNDAMENT_PLUGIN_VERSION", Value: "v1.17.2"}}, versionEnvVars(cr, cr.Spec.DefinitionRef))
+}
@@ -3,6 +3,8 @@ package defclient
 import (
 	"context"
 	"fmt"
+	"os"
+	"strings"
 
 	"connectrpc.com/connect"
 
@@ -16,18 +18,35 @@ type Definition struct {
 	Hash     string
 }
 
-// Client fetches plugin definitions from organization-api.
+// Approval is an organization's approval of a definition as an upgrade
+// target. Hash is the hash of the approved definition.
+type Approval struct {
+	Approved bool
+	Hash     string
+}
+
+// Client fetches plugin definitions, and the organization's approvals of
+// them, from organization-api.
 type Client interface {
 	GetDefinition(ctx context.Context, pluginName, pluginVersion string) (Definition, error)
+	// GetApproval reads the approvals of the organization named by the
+	// controller's service token.
+	GetApproval(ctx context.Context, pluginName, pluginVersion string) (Approval, error)
 }
 
 type connectClient struct {
-	rpc organizationv1connect.PluginServiceClient
+	rpc       organizationv1connect.PluginServiceClient
+	tokenFile string
 }
 
-// New returns a Client that talks to organization-api at baseURL.
-func New(baseURL string, httpClient connect.HTTPClient) Client {
-	return &connectClient{rpc: organizationv1connect.NewPluginServiceClient(httpClient, baseURL)}
+// New returns a Client that talks to organization-api at baseURL. Approval
+// lookups carry the service token in tokenFile, read on every call so a
+// rotated Secret is picked up without a restart; definitions are public.
+func New(baseURL string, httpClient connect.HTTPClient, tokenFile string) Client {
+	return &connectClient{
+		rpc:       organizationv1connect.NewPluginServiceClient(httpClient, baseURL),
+		tokenFile: tokenFile,
+	}
 }
 
 func (c *connectClient) GetDefinition(ctx context.Context, pluginName, pluginVersion string) (Definition, error) {
@@ -39,3 +58,20 @@ func (c *connectClient) GetDefinition(ctx context.Context, pluginName, pluginVer
 	}
 	return Definition{Manifest: resp.GetManifest(), Hash: resp.GetHash()}, nil
 }
+
+func (c *connectClient) GetApproval(ctx context.Context, pluginName, pluginVersion string) (Approval, error) {
+	token, err := os.ReadFile(c.tokenFile)
+	if err != nil {
+		return Approval{}, fmt.Errorf("read service token: %w", err)
+	}
+	ctx, callInfo := connect.NewClientContext(ctx)
+	callInfo.RequestHeader().Set("Authorization", "Bearer "+strings.TrimSpace(string(token)))
+
+	resp, err := c.rpc.GetPluginDefinitionApproval(ctx, organizationv1.GetPluginDefinitionApprovalRequest_builder{
+		PluginName: pluginName, PluginVersion: pluginVersion,
+	}.Build())
+	if err != nil {
+		return Approval{}, fmt.Errorf("GetPluginDefinitionApproval RPC: %w", err)
+	}
+	return Approval{Approved: resp.GetApproved(), Hash: resp.GetDefinitionHash()}, nil
+}
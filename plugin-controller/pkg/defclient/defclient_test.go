@@ -4,8 +4,11 @@ import (
 	"context"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"testing"
 
+	"connectrpc.com/connect"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -24,16 +27,49 @@ func (stubPlugin) GetPluginDefinition(_ context.Context, _ *organizationv1.GetPl
 	}.Build(), nil
 }
 
-func TestGetDefinition(t *testing.T) {
+func (stubPlugin) GetPluginDefinitionApproval(ctx context.Context, _ *organizationv1.GetPluginDefinitionApprovalRequest) (*organizationv1.GetPluginDefinitionApprovalResponse, error) {
+	callInfo, _ := connect.CallInfoForHandlerContext(ctx)
+	if callInfo.RequestHeader().Get("Authorization") != "Bearer org-1-token" {
+		return organizationv1.GetPluginDefinitionApprovalResponse_builder{}.Build(), nil
+	}
+	return organizationv1.GetPluginDefinitionApprovalResponse_builder{
+		Approved: true, DefinitionHash: "sha256:abc",
+	}.Build(), nil
+}
+
+func newStubClient(t *testing.T, tokenFile string) defclient.Client {
+	t.Helper()
 	mux := http.NewServeMux()
 	path, h := organizationv1connect.NewPluginServiceHandler(stubPlugin{})
 	mux.Handle(path, h)
 	srv := httptest.NewServer(mux)
 	t.Cleanup(srv.Close)
+	return defclient.New(srv.URL, http.DefaultClient, tokenFile)
+}
 
-	c := defclient.New(srv.URL, http.DefaultClient)
+func TestGetDefinition(t *testing.T) {
+	c := newStubClient(t, filepath.Join(t.TempDir(), "missing"))
 	def, err := c.GetDefinition(context.Background(), "cert-manager", "v1")
 	require.NoError(t, err)
 	assert.Equal(t, []byte("manifest-bytes"), def.Manifest)
 	assert.Equal(t, "sha256:abc", def.Hash)
 }
+
+func TestGetApproval(t *testing.T) {
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	c := newStubClient(t, tokenFile)
+
+	_, err := c.GetApproval(context.Background(), "cert-manager", "v2")
+	require.Error(t, err, "no service token mounted")
+
+	require.NoError(t, os.WriteFile(tokenFile, []byte("org-1-token\n"), 0o600))
+	approval, err := c.GetApproval(context.Background(), "cert-manager", "v2")
+	require.NoError(t, err)
+	assert.Equal(t, defclient.Approval{Approved: true, Hash: "sha256:abc"}, approval)
+
+	// A rotated token is read on the next call.
+	require.NoError(t, os.WriteFile(tokenFile, []byte("org-2-token"), 0o600))
+	approval, err = c.GetApproval(context.Background(), "cert-manager", "v2")
+	require.NoError(t, err)
+	assert.False(t, approval.Approved)
+}
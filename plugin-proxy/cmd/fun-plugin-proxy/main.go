@@ -64,6 +64,11 @@ func run() error {
 		}
 	}
 
+	jwtKeys, err := auth.NewKeys(cfg.JWKSURL, []byte(cfg.JWTSecret), logger)
+	if err != nil {
+		return fmt.Errorf("configure token verification: %w", err)
+	}
+
 	publicMux := http.NewServeMux()
 	registerHealth(publicMux)
 
@@ -157,7 +162,7 @@ func run() error {
 	// Auth for the asset handler: parse the console's UserToken cookie and
 	// gate on OpenFGA can_view(user, cluster).
 	assetValidator := auth.NewValidatorForAudience(
-		[]byte(cfg.JWTSecret),
+		jwtKeys,
 		auth.ConsoleAuthCookieName,
 		auth.ConsoleIssuer,
 		auth.TokenTypeUser,
@@ -172,7 +177,7 @@ func run() error {
 	// Installation proxy (cross-site → wrap in CORS). The PluginToken rides
 	// in Authorization, so credentials mode stays off — do not enable
 	// AllowCredentials.
-	installHandler := installproxy.New([]byte(cfg.JWTSecret), authz, backend, logger)
+	installHandler := installproxy.New(jwtKeys, authz, backend, logger)
 	installCORS := cors.New(cors.Options{
 		AllowedOrigins: []string{cfg.PluginProxyOrigin},
 		AllowedMethods: []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete},
@@ -45,7 +45,7 @@ func newTestHandler() http.Handler {
 
 func newTestHandlerWithAuth(allow bool) http.Handler {
 	validator := auth.NewValidatorForAudience(
-		testJWTSecret,
+		auth.SharedSecret(testJWTSecret),
 		auth.ConsoleAuthCookieName,
 		auth.ConsoleIssuer,
 		auth.TokenTypeUser,
@@ -112,7 +112,7 @@ func (f errFetcher) Fetch(_ context.Context, _ uuid.UUID, _, _, _ string) ([]byt
 
 func TestHandler_VersionMismatchIs404(t *testing.T) {
 	validator := auth.NewValidatorForAudience(
-		testJWTSecret, auth.ConsoleAuthCookieName, auth.ConsoleIssuer, auth.TokenTypeUser, discardLogger(),
+		auth.SharedSecret(testJWTSecret), auth.ConsoleAuthCookieName, auth.ConsoleIssuer, auth.TokenTypeUser, discardLogger(),
 	)
 	for name, fetcher := range map[string]Fetcher{
 		"mismatch": errFetcher{err: ErrVersionMismatch},
@@ -17,9 +17,12 @@ type Config struct {
 	LogLevel           slog.Level `env:"LOG_LEVEL" envDefault:"info"`
 	Mode               string     `env:"PLUGIN_PROXY_MODE" envDefault:"mock"`
 
-	// JWTSecret signs and verifies PluginTokens AND validates the UserToken
-	// cookie on inbound asset requests. Required.
-	JWTSecret string `env:"JWT_SECRET,required,notEmpty"`
+	// JWKSURL and JWTSecret verify PluginTokens AND the UserToken cookie on
+	// inbound asset requests: JWKSURL against the keys authn-api publishes,
+	// JWTSecret against the legacy shared HS256 secret. At least one is
+	// required; with both set, tokens signed either way are accepted.
+	JWKSURL   string `env:"JWKS_URL"`
+	JWTSecret string `env:"JWT_SECRET"`
 
 	// OpenFGA drives the asset-handler's can_view(user, cluster) gate — the
 	// same check authn-api runs before minting a PluginToken.
@@ -67,6 +70,10 @@ func FromEnv() (Config, error) {
 		return Config{}, fmt.Errorf("env parse: %w", err)
 	}
 
+	if cfg.JWKSURL == "" && cfg.JWTSecret == "" {
+		return Config{}, fmt.Errorf("JWKS_URL or JWT_SECRET is required")
+	}
+
 	switch cfg.Mode {
 	case "mock":
 		if cfg.PluginProxyOrigin == "" {
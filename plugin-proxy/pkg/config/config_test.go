@@ -134,6 +134,7 @@ func TestFromEnv_UnknownModeErrors(t *testing.T) {
 
 func TestFromEnv_MissingJWTSecretErrors(t *testing.T) {
 	t.Setenv("JWT_SECRET", "")
+	t.Setenv("JWKS_URL", "")
 	t.Setenv("OPENFGA_API_URL", "http://openfga:8080")
 	t.Setenv("OPENFGA_STORE_ID", "test-store")
 	t.Setenv("PLUGIN_PROXY_MODE", "mock")
@@ -46,14 +46,14 @@ type ClusterAuthorizer interface {
 
 // Handler authenticates and forwards /installations/{id}/{runtime|controller}/*.
 type Handler struct {
-	jwtSecret []byte
-	authz     ClusterAuthorizer
-	backend   Backend
-	logger    *slog.Logger
+	jwtKeys auth.Keys
+	authz   ClusterAuthorizer
+	backend Backend
+	logger  *slog.Logger
 }
 
-func New(jwtSecret []byte, authz ClusterAuthorizer, backend Backend, logger *slog.Logger) *Handler {
-	return &Handler{jwtSecret: jwtSecret, authz: authz, backend: backend, logger: logger}
+func New(jwtKeys auth.Keys, authz ClusterAuthorizer, backend Backend, logger *slog.Logger) *Handler {
+	return &Handler{jwtKeys: jwtKeys, authz: authz, backend: backend, logger: logger}
 }
 
 func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
@@ -71,7 +71,7 @@ func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	claims, err := auth.ParsePluginToken(bearer, h.jwtSecret)
+	claims, err := auth.ParsePluginToken(bearer, h.jwtKeys)
 	if err != nil {
 		h.logger.Debug("plugin token invalid", "err", err)
 		http.Error(w, "invalid token", http.StatusUnauthorized)
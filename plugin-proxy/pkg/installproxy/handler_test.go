@@ -55,7 +55,7 @@ func stubBackend() Backend {
 }
 
 func TestRuntimeProxy_RejectsMissingToken(t *testing.T) {
-	h := New([]byte("s"), allowAuthz{}, stubBackend(), discardLogger())
+	h := New(auth.SharedSecret("s"), allowAuthz{}, stubBackend(), discardLogger())
 	r := httptest.NewRequestWithContext(t.Context(), http.MethodGet, "/installations/abc/runtime/api/ping", http.NoBody)
 	w := httptest.NewRecorder()
 	h.ServeHTTP(w, r)
@@ -65,7 +65,7 @@ func TestRuntimeProxy_RejectsMissingToken(t *testing.T) {
 func TestRuntimeProxy_RejectsInstallationIDMismatch(t *testing.T) {
 	secret := []byte("s")
 	tok := mintPluginToken(t, secret, "INSTALL-X", "CLUSTER-X")
-	h := New(secret, allowAuthz{}, stubBackend(), discardLogger())
+	h := New(auth.SharedSecret(secret), allowAuthz{}, stubBackend(), discardLogger())
 	r := httptest.NewRequestWithContext(t.Context(), http.MethodGet, "/installations/INSTALL-Y/runtime/api/ping", http.NoBody)
 	r.Header.Set("Authorization", "Bearer "+tok)
 	w := httptest.NewRecorder()
@@ -76,7 +76,7 @@ func TestRuntimeProxy_RejectsInstallationIDMismatch(t *testing.T) {
 func TestRuntimeProxy_RejectsWhenCanViewFalse(t *testing.T) {
 	secret := []byte("s")
 	tok := mintPluginToken(t, secret, "INSTALL-X", "CLUSTER-X")
-	h := New(secret, denyAuthz{}, stubBackend(), discardLogger())
+	h := New(auth.SharedSecret(secret), denyAuthz{}, stubBackend(), discardLogger())
 	r := httptest.NewRequestWithContext(t.Context(), http.MethodGet, "/installations/INSTALL-X/runtime/api/ping", http.NoBody)
 	r.Header.Set("Authorization", "Bearer "+tok)
 	w := httptest.NewRecorder()
@@ -87,7 +87,7 @@ func TestRuntimeProxy_RejectsWhenCanViewFalse(t *testing.T) {
 func TestRuntimeProxy_ForwardsAuthorizedRequest(t *testing.T) {
 	secret := []byte("s")
 	tok := mintPluginToken(t, secret, "INSTALL-X", "CLUSTER-X")
-	h := New(secret, allowAuthz{}, stubBackend(), discardLogger())
+	h := New(auth.SharedSecret(secret), allowAuthz{}, stubBackend(), discardLogger())
 	r := httptest.NewRequestWithContext(t.Context(), http.MethodGet, "/installations/INSTALL-X/runtime/api/ping", http.NoBody)
 	r.Header.Set("Authorization", "Bearer "+tok)
 	w := httptest.NewRecorder()
@@ -12,4 +12,13 @@ type Config struct {
 	OrganizationID    string        `env:"FUNDAMENT_ORGANIZATION_ID,required,notEmpty"`
 	LogLevel          slog.Level    `env:"FUNP_LOG_LEVEL" envDefault:"info"`
 	ReconcileInterval time.Duration `env:"FUNP_RECONCILE_INTERVAL" envDefault:"5m"`
+
+	// PluginVersion is the version of the pinned PluginDefinition this pod
+	// runs. It is reported by GetStatus so the controller can tell which side
+	// of a rollout answered.
+	PluginVersion string `env:"FUNDAMENT_PLUGIN_VERSION"`
+	// UpgradeFromVersion is set by plugin-controller when this pod replaces an
+	// installation of an older definition. Run calls Installer.Upgrade before
+	// Start while it is set.
+	UpgradeFromVersion string `env:"FUNDAMENT_UPGRADE_FROM_VERSION"`
 }
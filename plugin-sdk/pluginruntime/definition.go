@@ -195,6 +195,7 @@ type PluginPhase string
 
 const (
 	PhaseInstalling   PluginPhase = "installing"
+	PhaseUpgrading    PluginPhase = "upgrading"
 	PhaseRunning      PluginPhase = "running"
 	PhaseDegraded     PluginPhase = "degraded"
 	PhaseFailed       PluginPhase = "failed"
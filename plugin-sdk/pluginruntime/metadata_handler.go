@@ -17,6 +17,7 @@ type metadataHandler struct {
 	pluginmetadatav1connect.UnimplementedPluginMetadataServiceHandler
 	getStatus func() PluginStatus
 	uninstall func(context.Context) error
+	version   string
 }
 
 // NewMetadataHandler creates a metadata handler that serves plugin status
@@ -28,11 +29,18 @@ func NewMetadataHandler(statusFn func() PluginStatus, uninstallFn func(context.C
 	}
 }
 
+// WithVersion sets the plugin version reported alongside the status.
+func (h *metadataHandler) WithVersion(version string) *metadataHandler {
+	h.version = version
+	return h
+}
+
 func (h *metadataHandler) GetStatus(_ context.Context, _ *pb.GetStatusRequest) (*pb.GetStatusResponse, error) {
 	status := h.getStatus()
 	return &pb.GetStatusResponse{
 		Phase:   ptr(string(status.Phase)),
 		Message: ptr(status.Message),
+		Version: ptr(h.version),
 	}, nil
 }
 
@@ -0,0 +1,186 @@
+package pluginruntime
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// PermissionDiff is the change in what a plugin is granted when its
+// installation moves from one PluginDefinition to another. The install-time
+// DefinitionHash pin records consent to the old definition only, so anything
+// in the Added fields has not been consented to yet.
+type PermissionDiff struct {
+	// AddedRules are the RBAC rules of the new definition that grant access
+	// the old one did not. A rule that only partially widens access is listed
+	// whole.
+	AddedRules []PolicyRule
+	// RemovedRules are the RBAC rules of the old definition the new one no
+	// longer covers.
+	RemovedRules []PolicyRule
+
+	AddedCapabilities   []string
+	RemovedCapabilities []string
+
+	AddedCRDs   []string
+	RemovedCRDs []string
+
+	AddedAllowedResources   []AllowedResource
+	RemovedAllowedResources []AllowedResource
+}
+
+// DiffPermissions compares the permissions, CRDs and allowed resources of two
+// plugin specs. Rules are compared by what they grant rather than how they are
+// written: a rule is only added when some API group, resource, verb and
+// resource name it allows is not allowed by any old rule, taking "*" and an
+// empty ResourceNames into account. Splitting or merging rules without
+// widening them is no change.
+func DiffPermissions(from, to PluginSpec) PermissionDiff {
+	return PermissionDiff{
+		AddedRules:              uncoveredRules(to.Permissions.RBAC, from.Permissions.RBAC),
+		RemovedRules:            uncoveredRules(from.Permissions.RBAC, to.Permissions.RBAC),
+		AddedCapabilities:       missing(to.Permissions.Capabilities, from.Permissions.Capabilities),
+		RemovedCapabilities:     missing(from.Permissions.Capabilities, to.Permissions.Capabilities),
+		AddedCRDs:               missing(to.CRDs, from.CRDs),
+		RemovedCRDs:             missing(from.CRDs, to.CRDs),
+		AddedAllowedResources:   uncoveredAllowedResources(to.AllowedResources, from.AllowedResources),
+		RemovedAllowedResources: uncoveredAllowedResources(from.AllowedResources, to.AllowedResources),
+	}
+}
+
+// Escalates reports whether the new definition grants anything the old one
+// did not. Removals alone never need consent.
+func (d PermissionDiff) Escalates() bool {
+	return len(d.AddedRules) > 0 || len(d.AddedCapabilities) > 0 ||
+		len(d.AddedCRDs) > 0 || len(d.AddedAllowedResources) > 0
+}
+
+// Summary describes the additions in one line, for status messages and logs.
+// It is empty when the diff does not escalate.
+func (d PermissionDiff) Summary() string {
+	var parts []string
+	for _, r := range d.AddedRules {
+		parts = append(parts, "RBAC "+r.String())
+	}
+	for _, c := range d.AddedCapabilities {
+		parts = append(parts, fmt.Sprintf("capability %q", c))
+	}
+	for _, c := range d.AddedCRDs {
+		parts = append(parts, fmt.Sprintf("CRD %q", c))
+	}
+	for _, a := range d.AddedAllowedResources {
+		parts = append(parts, "allowed resource "+a.String())
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return "adds " + strings.Join(parts, "; ")
+}
+
+// String renders the rule as "<verbs> <resource>.<group>[<names>]", e.g.
+// "get,list secrets" or "create certificates.cert-manager.io".
+func (r PolicyRule) String() string {
+	var resources []string
+	for _, g := range r.APIGroups {
+		for _, res := range r.Resources {
+			if g != "" {
+				res += "." + g
+			}
+			resources = append(resources, res)
+		}
+	}
+	s := strings.Join(r.Verbs, ",") + " " + strings.Join(resources, ",")
+	if len(r.ResourceNames) > 0 {
+		s += "[" + strings.Join(r.ResourceNames, ",") + "]"
+	}
+	return s
+}
+
+// String renders the allowed resource as "<verbs> <group>/<version>/<resource>".
+func (a AllowedResource) String() string {
+	gvr := strings.TrimPrefix(a.Group+"/"+a.Version+"/"+a.Resource, "/")
+	if len(a.Verbs) == 0 {
+		return gvr
+	}
+	return strings.Join(a.Verbs, ",") + " " + gvr
+}
+
+// uncoveredRules returns the rules in rules that grant something no rule in
+// by grants.
+func uncoveredRules(rules, by []PolicyRule) []PolicyRule {
+	var out []PolicyRule
+	for _, r := range rules {
+		if !ruleCovered(r, by) {
+			out = append(out, r)
+		}
+	}
+	return out
+}
+
+func ruleCovered(r PolicyRule, by []PolicyRule) bool {
+	// An empty ResourceNames grants every object; "" stands in for that.
+	names := r.ResourceNames
+	if len(names) == 0 {
+		names = []string{""}
+	}
+	for _, g := range r.APIGroups {
+		for _, res := range r.Resources {
+			for _, v := range r.Verbs {
+				for _, n := range names {
+					if !slices.ContainsFunc(by, func(o PolicyRule) bool { return ruleGrants(o, g, res, v, n) }) {
+						return false
+					}
+				}
+			}
+		}
+	}
+	return true
+}
+
+// ruleGrants reports whether o allows verb on the named object (any object
+// when name is empty) of resource in group.
+func ruleGrants(o PolicyRule, group, resource, verb, name string) bool {
+	if !matches(o.APIGroups, group) || !matches(o.Resources, resource) || !matches(o.Verbs, verb) {
+		return false
+	}
+	if len(o.ResourceNames) == 0 {
+		return true
+	}
+	return name != "" && slices.Contains(o.ResourceNames, name)
+}
+
+func matches(values []string, v string) bool {
+	return slices.Contains(values, "*") || slices.Contains(values, v)
+}
+
+// uncoveredAllowedResources returns the entries in resources whose resource,
+// or one of whose verbs, no entry in by allows.
+func uncoveredAllowedResources(resources, by []AllowedResource) []AllowedResource {
+	var out []AllowedResource
+	for _, a := range resources {
+		same := func(o AllowedResource) bool {
+			return o.Group == a.Group && o.Version == a.Version && o.Resource == a.Resource
+		}
+		covered := slices.ContainsFunc(by, same)
+		for _, v := range a.Verbs {
+			covered = covered && slices.ContainsFunc(by, func(o AllowedResource) bool {
+				return same(o) && slices.Contains(o.Verbs, v)
+			})
+		}
+		if !covered {
+			out = append(out, a)
+		}
+	}
+	return out
+}
+
+// missing returns the values in values that are not in from, in order.
+func missing(values, from []string) []string {
+	var out []string
+	for _, v := range values {
+		if !slices.Contains(from, v) && !slices.Contains(out, v) {
+			out = append(out, v)
+		}
+	}
+	return out
+}
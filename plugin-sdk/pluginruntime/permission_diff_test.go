@@ -0,0 +1,124 @@
+package pluginruntime
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffPermissions_Unchanged(t *testing.T) {
+	spec := PluginSpec{
+		Permissions: Permissions{
+			Capabilities: []string{"network"},
+			RBAC:         []PolicyRule{{APIGroups: []string{""}, Resources: []string{"secrets"}, Verbs: []string{"get", "list"}}},
+		},
+		CRDs:             []string{"certificates.cert-manager.io"},
+		AllowedResources: []AllowedResource{{Group: "cert-manager.io", Version: "v1", Resource: "certificates", Verbs: []string{"list"}}},
+	}
+
+	diff := DiffPermissions(spec, spec)
+	assert.False(t, diff.Escalates())
+	assert.Equal(t, PermissionDiff{}, diff)
+	assert.Empty(t, diff.Summary())
+}
+
+func TestDiffPermissions_ComparesWhatRulesGrant(t *testing.T) {
+	from := PluginSpec{Permissions: Permissions{RBAC: []PolicyRule{
+		{APIGroups: []string{"cert-manager.io"}, Resources: []string{"*"}, Verbs: []string{"get", "list"}},
+		{APIGroups: []string{""}, Resources: []string{"configmaps"}, Verbs: []string{"*"}},
+	}}}
+
+	tests := []struct {
+		name      string
+		rule      PolicyRule
+		escalates bool
+	}{
+		{"covered by a resource wildcard", PolicyRule{APIGroups: []string{"cert-manager.io"}, Resources: []string{"issuers"}, Verbs: []string{"list"}}, false},
+		{"covered by a verb wildcard", PolicyRule{APIGroups: []string{""}, Resources: []string{"configmaps"}, Verbs: []string{"delete"}}, false},
+		{"narrowed to names", PolicyRule{APIGroups: []string{""}, Resources: []string{"configmaps"}, Verbs: []string{"update"}, ResourceNames: []string{"cfg"}}, false},
+		{"new verb", PolicyRule{APIGroups: []string{"cert-manager.io"}, Resources: []string{"issuers"}, Verbs: []string{"create"}}, true},
+		{"new group", PolicyRule{APIGroups: []string{"acme.cert-manager.io"}, Resources: []string{"orders"}, Verbs: []string{"get"}}, true},
+		{"partially new", PolicyRule{APIGroups: []string{""}, Resources: []string{"configmaps", "secrets"}, Verbs: []string{"get"}}, true},
+		{"wildcard is wider than any list", PolicyRule{APIGroups: []string{"*"}, Resources: []string{"configmaps"}, Verbs: []string{"get"}}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			to := PluginSpec{Permissions: Permissions{RBAC: append([]PolicyRule{}, from.Permissions.RBAC...)}}
+			to.Permissions.RBAC = append(to.Permissions.RBAC, tt.rule)
+
+			diff := DiffPermissions(from, to)
+			assert.Equal(t, tt.escalates, diff.Escalates())
+			if tt.escalates {
+				assert.Equal(t, []PolicyRule{tt.rule}, diff.AddedRules)
+			}
+			assert.Empty(t, diff.RemovedRules)
+		})
+	}
+}
+
+func TestDiffPermissions_NamedRuleDoesNotCoverAllObjects(t *testing.T) {
+	from := PluginSpec{Permissions: Permissions{RBAC: []PolicyRule{
+		{APIGroups: []string{""}, Resources: []string{"secrets"}, Verbs: []string{"get"}, ResourceNames: []string{"tls"}},
+	}}}
+	to := PluginSpec{Permissions: Permissions{RBAC: []PolicyRule{
+		{APIGroups: []string{""}, Resources: []string{"secrets"}, Verbs: []string{"get"}},
+	}}}
+
+	diff := DiffPermissions(from, to)
+	assert.True(t, diff.Escalates())
+	assert.Equal(t, to.Permissions.RBAC, diff.AddedRules)
+	assert.Empty(t, diff.RemovedRules, "the named grant is still covered")
+
+	back := DiffPermissions(to, from)
+	assert.False(t, back.Escalates())
+	assert.Equal(t, to.Permissions.RBAC, back.RemovedRules)
+}
+
+func TestDiffPermissions_CapabilitiesCRDsAndAllowedResources(t *testing.T) {
+	from := PluginSpec{
+		Permissions:      Permissions{Capabilities: []string{"network", "storage"}},
+		CRDs:             []string{"certificates.cert-manager.io"},
+		AllowedResources: []AllowedResource{{Group: "cert-manager.io", Version: "v1", Resource: "certificates", Verbs: []string{"get", "list"}}},
+	}
+	to := PluginSpec{
+		Permissions: Permissions{Capabilities: []string{"network", "host-network"}},
+		CRDs:        []string{"certificates.cert-manager.io", "issuers.cert-manager.io"},
+		AllowedResources: []AllowedResource{
+			{Group: "cert-manager.io", Version: "v1", Resource: "certificates", Verbs: []string{"list", "delete"}},
+			{Version: "v1", Resource: "secrets"},
+		},
+	}
+
+	diff := DiffPermissions(from, to)
+	assert.True(t, diff.Escalates())
+	assert.Equal(t, []string{"host-network"}, diff.AddedCapabilities)
+	assert.Equal(t, []string{"storage"}, diff.RemovedCapabilities)
+	assert.Equal(t, []string{"issuers.cert-manager.io"}, diff.AddedCRDs)
+	assert.Empty(t, diff.RemovedCRDs)
+	assert.Equal(t, to.AllowedResources, diff.AddedAllowedResources, "a new verb, and a new resource even without verbs")
+	assert.Equal(t, from.AllowedResources, diff.RemovedAllowedResources)
+	assert.Equal(t,
+		`adds capability "host-network"; CRD "issuers.cert-manager.io"; allowed resource list,delete cert-manager.io/v1/certificates; allowed resource v1/secrets`,
+		diff.Summary())
+}
+
+func TestDiffPermissions_RemovalsDoNotEscalate(t *testing.T) {
+	from := PluginSpec{
+		Permissions: Permissions{
+			Capabilities: []string{"network"},
+			RBAC:         []PolicyRule{{APIGroups: []string{""}, Resources: []string{"secrets"}, Verbs: []string{"get"}}},
+		},
+		CRDs: []string{"certificates.cert-manager.io"},
+	}
+
+	diff := DiffPermissions(from, PluginSpec{})
+	assert.False(t, diff.Escalates())
+	assert.Equal(t, from.Permissions.RBAC, diff.RemovedRules)
+	assert.Equal(t, []string{"network"}, diff.RemovedCapabilities)
+	assert.Equal(t, []string{"certificates.cert-manager.io"}, diff.RemovedCRDs)
+}
+
+func TestPolicyRuleString(t *testing.T) {
+	rule := PolicyRule{APIGroups: []string{"", "cert-manager.io"}, Resources: []string{"secrets"}, Verbs: []string{"get", "list"}, ResourceNames: []string{"tls"}}
+	assert.Equal(t, "get,list secrets,secrets.cert-manager.io[tls]", rule.String())
+}
@@ -76,7 +76,7 @@ func run(plugin Plugin, opts ...RunOption) error {
 	handler := NewMetadataHandler(
 		func() PluginStatus { return h.CurrentStatus() },
 		uninstallFn,
-	)
+	).WithVersion(envCfg.PluginVersion)
 	path, rpcHandler := pluginmetadatav1connect.NewPluginMetadataServiceHandler(handler)
 	mux.Handle(path, rpcHandler)
 
@@ -108,15 +108,20 @@ func run(plugin Plugin, opts ...RunOption) error {
 	})
 
 	g.Go(func() error {
+		if !upgrade(ctx, h, plugin, envCfg.UpgradeFromVersion, envCfg.PluginVersion) {
+			// Keep serving the failed status until plugin-controller rolls
+			// back; exiting would crash-loop the pod and hide the reason.
+			<-ctx.Done()
+			return nil
+		}
+		if reconciler, ok := plugin.(Reconciler); ok {
+			g.Go(func() error {
+				return runReconcileLoop(ctx, h, reconciler, envCfg.ReconcileInterval)
+			})
+		}
 		return plugin.Start(ctx, h)
 	})
 
-	if reconciler, ok := plugin.(Reconciler); ok {
-		g.Go(func() error {
-			return runReconcileLoop(ctx, h, reconciler, envCfg.ReconcileInterval)
-		})
-	}
-
 	err := g.Wait()
 	if err != nil {
 		switch {
@@ -140,6 +145,30 @@ func run(plugin Plugin, opts ...RunOption) error {
 	return nil
 }
 
+// upgrade runs Installer.Upgrade when the pod replaces an older version of
+// the plugin. Readiness is not reported until it succeeds, so the previous pod
+// keeps serving during the upgrade. It reports false after marking the plugin
+// failed.
+func upgrade(ctx context.Context, h *host, plugin Plugin, fromVersion, toVersion string) bool {
+	installer, ok := plugin.(Installer)
+	if !ok || fromVersion == "" {
+		return true
+	}
+
+	h.ReportStatus(PluginStatus{Phase: PhaseUpgrading, Message: fmt.Sprintf("upgrading from %s to %s", fromVersion, toVersion)})
+	if err := installer.Upgrade(ctx, h); err != nil {
+		if ctx.Err() != nil {
+			// Interrupted by shutdown, not a failed upgrade.
+			return false
+		}
+		h.ReportStatus(PluginStatus{Phase: PhaseFailed, Message: fmt.Sprintf("upgrade from %s failed: %v", fromVersion, err)})
+		return false
+	}
+
+	h.logger.Info("upgrade completed", "from", fromVersion, "to", toVersion)
+	return true
+}
+
 func runReconcileLoop(ctx context.Context, h *host, reconciler Reconciler, interval time.Duration) error {
 	ticker := time.NewTicker(interval)
 	defer ticker.Stop()
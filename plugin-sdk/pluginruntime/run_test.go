@@ -0,0 +1,78 @@
+package pluginruntime
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/fundament-oss/fundament/plugin-sdk/pluginruntime/observability"
+)
+
+type upgradePlugin struct {
+	upgradeErr error
+	upgrades   int
+}
+
+func (p *upgradePlugin) Start(context.Context, Host) error     { return nil }
+func (p *upgradePlugin) Shutdown(context.Context) error        { return nil }
+func (p *upgradePlugin) Install(context.Context, Host) error   { return nil }
+func (p *upgradePlugin) Uninstall(context.Context, Host) error { return nil }
+func (p *upgradePlugin) Upgrade(context.Context, Host) error   { p.upgrades++; return p.upgradeErr }
+
+type plainPlugin struct{}
+
+func (plainPlugin) Start(context.Context, Host) error { return nil }
+func (plainPlugin) Shutdown(context.Context) error    { return nil }
+
+func newTestHost() *host {
+	logger := slog.New(slog.NewJSONHandler(io.Discard, nil))
+	return newHost(logger, observability.NewTelemetryService(logger, nil, nil))
+}
+
+func TestUpgrade_SkippedWithoutPreviousVersion(t *testing.T) {
+	p := &upgradePlugin{}
+	h := newTestHost()
+
+	assert.True(t, upgrade(t.Context(), h, p, "", "v2"))
+	assert.Zero(t, p.upgrades)
+	assert.Equal(t, PhaseInstalling, h.CurrentStatus().Phase)
+}
+
+func TestUpgrade_SkippedWithoutInstaller(t *testing.T) {
+	assert.True(t, upgrade(t.Context(), newTestHost(), plainPlugin{}, "v1", "v2"))
+}
+
+func TestUpgrade_Succeeds(t *testing.T) {
+	p := &upgradePlugin{}
+	h := newTestHost()
+
+	assert.True(t, upgrade(t.Context(), h, p, "v1", "v2"))
+	assert.Equal(t, 1, p.upgrades)
+	assert.Equal(t, PhaseUpgrading, h.CurrentStatus().Phase)
+	assert.False(t, h.IsReady(), "readiness is left to Start")
+}
+
+func TestUpgrade_FailureReportsFailed(t *testing.T) {
+	p := &upgradePlugin{upgradeErr: errors.New("chart not found")}
+	h := newTestHost()
+
+	assert.False(t, upgrade(t.Context(), h, p, "v1", "v2"))
+	status := h.CurrentStatus()
+	assert.Equal(t, PhaseFailed, status.Phase)
+	assert.Contains(t, status.Message, "upgrade from v1 failed: chart not found")
+	assert.False(t, h.IsReady())
+}
+
+func TestUpgrade_InterruptedByShutdown(t *testing.T) {
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+	p := &upgradePlugin{upgradeErr: context.Canceled}
+	h := newTestHost()
+
+	assert.False(t, upgrade(ctx, h, p, "v1", "v2"))
+	assert.Equal(t, PhaseUpgrading, h.CurrentStatus().Phase)
+}
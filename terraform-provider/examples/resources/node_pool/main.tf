@@ -0,0 +1,52 @@
+terraform {
+  required_providers {
+    fundament = {
+      source = "fundament/fundament"
+    }
+  }
+}
+
+provider "fundament" {
+  endpoint        = "https://organization.fundament.localhost:8443"
+  organization_id = "019b4000-0000-7000-8000-000000000002" # Globex
+  # API Key can be set via FUNDAMENT_API_KEY environment variable
+  # api_key = ""
+}
+
+resource "fundament_cluster" "example" {
+  name               = "my-production-cluster"
+  region             = "eu-west-1"
+  kubernetes_version = "1.28"
+}
+
+# Create a node pool reserved for batch workloads
+resource "fundament_node_pool" "batch" {
+  cluster_id    = fundament_cluster.example.id
+  name          = "batch"
+  machine_type  = "n1-standard-2"
+  autoscale_min = 1
+  autoscale_max = 5
+
+  # Zones can only be chosen when the pool is created
+  zones = ["eu-west-1a", "eu-west-1b"]
+
+  labels = {
+    workload = "batch"
+  }
+
+  # Only pods that tolerate this taint are scheduled on the pool
+  taints = [{
+    key    = "dedicated"
+    value  = "batch"
+    effect = "NoSchedule"
+  }]
+
+  # Replace nodes one at a time without adding extra machines
+  max_surge       = 0
+  max_unavailable = 1
+}
+
+output "node_pool_id" {
+  description = "The ID of the created node pool"
+  value       = fundament_node_pool.batch.id
+}
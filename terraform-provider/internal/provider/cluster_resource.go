@@ -214,11 +214,26 @@ func (r *ClusterResource) Read(ctx context.Context, req resource.ReadRequest, re
 
 	cluster := getResp.GetCluster()
 
+	pendingVersion, err := r.pendingUpgradeVersion(ctx, cluster.GetId())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Cluster Upgrades",
+			fmt.Sprintf("Unable to list cluster upgrades: %s", err.Error()),
+		)
+		return
+	}
+
 	// Map response to state
 	state.ID = types.StringValue(cluster.GetId())
 	state.Name = types.StringValue(cluster.GetName())
 	state.Region = types.StringValue(cluster.GetRegion())
-	state.KubernetesVersion = types.StringValue(cluster.GetKubernetesVersion())
+	// A scheduled upgrade already moves the cluster to its target version, so
+	// report that instead of the version the cluster still runs.
+	if pendingVersion != "" {
+		state.KubernetesVersion = types.StringValue(pendingVersion)
+	} else {
+		state.KubernetesVersion = types.StringValue(cluster.GetKubernetesVersion())
+	}
 	state.Status = types.StringValue(clusterStatusToString(cluster.GetStatus()))
 
 	tflog.Debug(ctx, "Read cluster successfully", map[string]any{
@@ -254,40 +269,57 @@ func (r *ClusterResource) Update(ctx context.Context, req resource.UpdateRequest
 		"kubernetes_version_new": plan.KubernetesVersion.ValueString(),
 	})
 
-	// Only kubernetes_version can be updated
+	// Only kubernetes_version can be updated. An upgrade to the planned
+	// version may already be scheduled, e.g. when an earlier apply failed
+	// after scheduling it; the API refuses a second one.
 	kubernetesVersion := plan.KubernetesVersion.ValueString()
-	updateReq := organizationv1.UpdateClusterRequest_builder{
-		ClusterId:         state.ID.ValueString(),
-		KubernetesVersion: &kubernetesVersion,
-	}.Build()
-
-	_, err := r.client.ClusterService.UpdateCluster(ctx, updateReq)
+	pendingVersion, err := r.pendingUpgradeVersion(ctx, state.ID.ValueString())
 	if err != nil {
-		switch connect.CodeOf(err) {
-		case connect.CodeNotFound:
-			resp.Diagnostics.AddError(
-				"Cluster Not Found",
-				fmt.Sprintf("Cluster %q no longer exists. It may have been deleted outside of Terraform.", state.ID.ValueString()),
-			)
-		case connect.CodeFailedPrecondition:
-			resp.Diagnostics.AddError(
-				"Cluster Update Not Allowed",
-				fmt.Sprintf("Cluster %q cannot be updated in its current state: %s", state.ID.ValueString(), err.Error()),
-			)
-		case connect.CodeInvalidArgument:
-			resp.Diagnostics.AddError(
-				"Invalid Cluster Configuration",
-				fmt.Sprintf("Invalid update parameters: %s", err.Error()),
-			)
-		default:
-			resp.Diagnostics.AddError(
-				"Unable to Update Cluster",
-				fmt.Sprintf("Unable to update cluster: %s", err.Error()),
-			)
-		}
+		resp.Diagnostics.AddError(
+			"Unable to Read Cluster Upgrades",
+			fmt.Sprintf("Unable to list cluster upgrades: %s", err.Error()),
+		)
 		return
 	}
 
+	if pendingVersion == kubernetesVersion {
+		tflog.Debug(ctx, "Upgrade to planned version already scheduled", map[string]any{
+			"id":                 state.ID.ValueString(),
+			"kubernetes_version": kubernetesVersion,
+		})
+	} else {
+		updateReq := organizationv1.UpdateClusterRequest_builder{
+			ClusterId:         state.ID.ValueString(),
+			KubernetesVersion: &kubernetesVersion,
+		}.Build()
+
+		if _, err := r.client.ClusterService.UpdateCluster(ctx, updateReq); err != nil {
+			switch connect.CodeOf(err) {
+			case connect.CodeNotFound:
+				resp.Diagnostics.AddError(
+					"Cluster Not Found",
+					fmt.Sprintf("Cluster %q no longer exists. It may have been deleted outside of Terraform.", state.ID.ValueString()),
+				)
+			case connect.CodeFailedPrecondition:
+				resp.Diagnostics.AddError(
+					"Cluster Update Not Allowed",
+					fmt.Sprintf("Cluster %q cannot be updated in its current state: %s", state.ID.ValueString(), err.Error()),
+				)
+			case connect.CodeInvalidArgument:
+				resp.Diagnostics.AddError(
+					"Invalid Cluster Configuration",
+					fmt.Sprintf("Invalid update parameters: %s", err.Error()),
+				)
+			default:
+				resp.Diagnostics.AddError(
+					"Unable to Update Cluster",
+					fmt.Sprintf("Unable to update cluster: %s", err.Error()),
+				)
+			}
+			return
+		}
+	}
+
 	// Read the cluster to get the updated state.
 	// Retry on permission_denied, OpenFGA needs time to sync.
 	getReq := organizationv1.GetClusterRequest_builder{
@@ -317,7 +349,8 @@ func (r *ClusterResource) Update(ctx context.Context, req resource.UpdateRequest
 	plan.ID = types.StringValue(cluster.GetId())
 	plan.Name = types.StringValue(cluster.GetName())
 	plan.Region = types.StringValue(cluster.GetRegion())
-	plan.KubernetesVersion = types.StringValue(cluster.GetKubernetesVersion())
+	// kubernetes_version keeps the planned value: the API schedules the
+	// upgrade and the cluster reports the new version once it has started.
 	plan.Status = types.StringValue(clusterStatusToString(cluster.GetStatus()))
 
 	tflog.Info(ctx, "Updated cluster", map[string]any{
@@ -382,6 +415,34 @@ func (r *ClusterResource) Delete(ctx context.Context, req resource.DeleteRequest
 	})
 }
 
+// pendingUpgradeVersion returns the target version of the cluster's scheduled
+// or running upgrade, or "" when none is open.
+func (r *ClusterResource) pendingUpgradeVersion(ctx context.Context, clusterID string) (string, error) {
+	listReq := organizationv1.ListClusterUpgradesRequest_builder{
+		ClusterId: clusterID,
+	}.Build()
+
+	listResp, err := r.client.ClusterService.ListClusterUpgrades(ctx, listReq)
+	if err != nil {
+		return "", err
+	}
+
+	return openUpgradeVersion(listResp.GetUpgrades()), nil
+}
+
+// openUpgradeVersion returns the target version of the first upgrade that is
+// scheduled or in progress. A cluster has at most one such upgrade.
+func openUpgradeVersion(upgrades []*organizationv1.ClusterUpgrade) string {
+	for _, upgrade := range upgrades {
+		switch upgrade.GetStatus() {
+		case organizationv1.ClusterUpgradeStatus_CLUSTER_UPGRADE_STATUS_SCHEDULED,
+			organizationv1.ClusterUpgradeStatus_CLUSTER_UPGRADE_STATUS_IN_PROGRESS:
+			return upgrade.GetToKubernetesVersion()
+		}
+	}
+	return ""
+}
+
 // ImportState imports an existing cluster into Terraform state.
 func (r *ClusterResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
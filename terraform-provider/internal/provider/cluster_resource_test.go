@@ -3,6 +3,7 @@ package provider
 import (
 	"testing"
 
+	organizationv1 "github.com/fundament-oss/fundament/organization-api/pkg/proto/gen/v1"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -59,3 +60,51 @@ func TestClusterResourceModelNullValues(t *testing.T) {
 		t.Error("Expected Name to not be null")
 	}
 }
+
+func TestOpenUpgradeVersion(t *testing.T) {
+	upgrade := func(version string, status organizationv1.ClusterUpgradeStatus) *organizationv1.ClusterUpgrade {
+		return organizationv1.ClusterUpgrade_builder{
+			ToKubernetesVersion: version,
+			Status:              status,
+		}.Build()
+	}
+
+	tests := []struct {
+		name     string
+		upgrades []*organizationv1.ClusterUpgrade
+		want     string
+	}{
+		{name: "no upgrades", want: ""},
+		{
+			name: "only finished upgrades",
+			upgrades: []*organizationv1.ClusterUpgrade{
+				upgrade("1.32", organizationv1.ClusterUpgradeStatus_CLUSTER_UPGRADE_STATUS_CANCELLED),
+				upgrade("1.31", organizationv1.ClusterUpgradeStatus_CLUSTER_UPGRADE_STATUS_COMPLETED),
+			},
+			want: "",
+		},
+		{
+			name: "scheduled upgrade",
+			upgrades: []*organizationv1.ClusterUpgrade{
+				upgrade("1.32", organizationv1.ClusterUpgradeStatus_CLUSTER_UPGRADE_STATUS_SCHEDULED),
+				upgrade("1.31", organizationv1.ClusterUpgradeStatus_CLUSTER_UPGRADE_STATUS_COMPLETED),
+			},
+			want: "1.32",
+		},
+		{
+			name: "upgrade in progress",
+			upgrades: []*organizationv1.ClusterUpgrade{
+				upgrade("1.32", organizationv1.ClusterUpgradeStatus_CLUSTER_UPGRADE_STATUS_IN_PROGRESS),
+			},
+			want: "1.32",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := openUpgradeVersion(tt.upgrades); got != tt.want {
+				t.Errorf("openUpgradeVersion() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -119,10 +119,14 @@ func (d *ClustersDataSource) Read(ctx context.Context, req datasource.ReadReques
 
 	tflog.Debug(ctx, "Fetching clusters")
 
-	rpcReq := organizationv1.ListClustersRequest_builder{}.Build()
-
 	// Call the API
-	rpcResp, err := d.client.ClusterService.ListClusters(ctx, rpcReq)
+	clusters, err := listAllPages(func(pageToken string) ([]*organizationv1.ListClustersResponse_ClusterSummary, string, error) {
+		rpcResp, err := d.client.ClusterService.ListClusters(ctx, organizationv1.ListClustersRequest_builder{
+			PageSize:  listPageSize,
+			PageToken: pageToken,
+		}.Build())
+		return rpcResp.GetClusters(), rpcResp.GetNextPageToken(), err
+	})
 	if err != nil {
 		switch connect.CodeOf(err) {
 		case connect.CodeInvalidArgument:
@@ -150,8 +154,8 @@ func (d *ClustersDataSource) Read(ctx context.Context, req datasource.ReadReques
 	}
 
 	// Map response to state
-	state.Clusters = make([]ClusterModel, len(rpcResp.GetClusters()))
-	for i, cluster := range rpcResp.GetClusters() {
+	state.Clusters = make([]ClusterModel, len(clusters))
+	for i, cluster := range clusters {
 		state.Clusters[i] = ClusterModel{
 			ID:     types.StringValue(cluster.GetId()),
 			Name:   types.StringValue(cluster.GetName()),
@@ -187,6 +191,8 @@ func clusterStatusToString(status organizationv1.ClusterStatus) string {
 		return "stopping"
 	case organizationv1.ClusterStatus_CLUSTER_STATUS_STOPPED:
 		return "stopped"
+	case organizationv1.ClusterStatus_CLUSTER_STATUS_HIBERNATED:
+		return "hibernated"
 	default:
 		return "unspecified"
 	}
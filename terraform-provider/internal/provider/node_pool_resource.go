@@ -0,0 +1,519 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"connectrpc.com/connect"
+	organizationv1 "github.com/fundament-oss/fundament/organization-api/pkg/proto/gen/v1"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int32default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure NodePoolResource satisfies various resource interfaces.
+var _ resource.Resource = &NodePoolResource{}
+var _ resource.ResourceWithConfigure = &NodePoolResource{}
+var _ resource.ResourceWithImportState = &NodePoolResource{}
+
+// NodePoolResource defines the resource implementation.
+type NodePoolResource struct {
+	client *FundamentClient
+}
+
+// NodePoolResourceModel describes the resource data model.
+type NodePoolResourceModel struct {
+	ID             types.String `tfsdk:"id"`
+	ClusterID      types.String `tfsdk:"cluster_id"`
+	Name           types.String `tfsdk:"name"`
+	MachineType    types.String `tfsdk:"machine_type"`
+	AutoscaleMin   types.Int32  `tfsdk:"autoscale_min"`
+	AutoscaleMax   types.Int32  `tfsdk:"autoscale_max"`
+	Labels         types.Map    `tfsdk:"labels"`
+	Taints         types.List   `tfsdk:"taints"`
+	Zones          types.List   `tfsdk:"zones"`
+	MaxSurge       types.Int32  `tfsdk:"max_surge"`
+	MaxUnavailable types.Int32  `tfsdk:"max_unavailable"`
+}
+
+// NodePoolTaintModel describes one entry of the taints attribute.
+type NodePoolTaintModel struct {
+	Key    types.String `tfsdk:"key"`
+	Value  types.String `tfsdk:"value"`
+	Effect types.String `tfsdk:"effect"`
+}
+
+var nodePoolTaintAttrTypes = map[string]attr.Type{
+	"key":    types.StringType,
+	"value":  types.StringType,
+	"effect": types.StringType,
+}
+
+// taintEffects maps the Kubernetes effect names used in configuration to the API.
+var taintEffects = map[string]organizationv1.TaintEffect{
+	"NoSchedule":       organizationv1.TaintEffect_TAINT_EFFECT_NO_SCHEDULE,
+	"PreferNoSchedule": organizationv1.TaintEffect_TAINT_EFFECT_PREFER_NO_SCHEDULE,
+	"NoExecute":        organizationv1.TaintEffect_TAINT_EFFECT_NO_EXECUTE,
+}
+
+// NewNodePoolResource creates a new NodePoolResource.
+func NewNodePoolResource() resource.Resource {
+	return &NodePoolResource{}
+}
+
+// Metadata returns the resource type name.
+func (r *NodePoolResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_node_pool"
+}
+
+// Schema defines the schema for the resource.
+func (r *NodePoolResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages a node pool of a Kubernetes cluster in Fundament.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The unique identifier of the node pool.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"cluster_id": schema.StringAttribute{
+				Description: "The ID of the cluster the node pool belongs to.",
+				Required:    true,
+				Validators: []validator.String{
+					uuidValidator{},
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description: "The name of the node pool. Must be unique within the cluster.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"machine_type": schema.StringAttribute{
+				Description: "The machine type of the nodes. Must be offered by the cluster's region.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"autoscale_min": schema.Int32Attribute{
+				Description: "The minimum number of nodes.",
+				Required:    true,
+			},
+			"autoscale_max": schema.Int32Attribute{
+				Description: "The maximum number of nodes.",
+				Required:    true,
+			},
+			"labels": schema.MapAttribute{
+				Description: "Kubernetes labels set on every node of the pool.",
+				ElementType: types.StringType,
+				Optional:    true,
+				Computed:    true,
+				Default:     mapdefault.StaticValue(types.MapValueMust(types.StringType, map[string]attr.Value{})),
+			},
+			"taints": schema.ListNestedAttribute{
+				Description: "Kubernetes taints set on every node of the pool.",
+				Optional:    true,
+				Computed:    true,
+				Default:     listdefault.StaticValue(types.ListValueMust(types.ObjectType{AttrTypes: nodePoolTaintAttrTypes}, []attr.Value{})),
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"key": schema.StringAttribute{
+							Description: "The taint key.",
+							Required:    true,
+						},
+						"value": schema.StringAttribute{
+							Description: "The taint value.",
+							Optional:    true,
+							Computed:    true,
+							Default:     stringdefault.StaticString(""),
+						},
+						"effect": schema.StringAttribute{
+							Description: "The taint effect: NoSchedule, PreferNoSchedule or NoExecute.",
+							Required:    true,
+							Validators: []validator.String{
+								stringvalidator.OneOf("NoSchedule", "PreferNoSchedule", "NoExecute"),
+							},
+						},
+					},
+				},
+			},
+			"zones": schema.ListAttribute{
+				Description: "The availability zones to spread the nodes over. Empty leaves the choice to the provider. Changing this forces a new node pool.",
+				ElementType: types.StringType,
+				Optional:    true,
+				Computed:    true,
+				Default:     listdefault.StaticValue(types.ListValueMust(types.StringType, []attr.Value{})),
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.RequiresReplace(),
+				},
+			},
+			"max_surge": schema.Int32Attribute{
+				Description: "The number of nodes added above the maximum while nodes are rolled. Defaults to 1.",
+				Optional:    true,
+				Computed:    true,
+				Default:     int32default.StaticInt32(1),
+			},
+			"max_unavailable": schema.Int32Attribute{
+				Description: "The number of nodes taken out of service at once while nodes are rolled. Defaults to 0.",
+				Optional:    true,
+				Computed:    true,
+				Default:     int32default.StaticInt32(0),
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *NodePoolResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*FundamentClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *FundamentClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+// Create creates a new node pool.
+func (r *NodePoolResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan NodePoolResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if r.client == nil {
+		resp.Diagnostics.AddError(
+			"Client Not Configured",
+			"The Fundament client was not configured. Please report this issue to the provider developers.",
+		)
+		return
+	}
+
+	labels, taints, diags := nodeTemplateFromModel(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	var zones []string
+	resp.Diagnostics.Append(plan.Zones.ElementsAs(ctx, &zones, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Creating node pool", map[string]any{
+		"cluster_id":   plan.ClusterID.ValueString(),
+		"name":         plan.Name.ValueString(),
+		"machine_type": plan.MachineType.ValueString(),
+	})
+
+	createReq := organizationv1.CreateNodePoolRequest_builder{
+		ClusterId:      plan.ClusterID.ValueString(),
+		Name:           plan.Name.ValueString(),
+		MachineType:    plan.MachineType.ValueString(),
+		AutoscaleMin:   plan.AutoscaleMin.ValueInt32(),
+		AutoscaleMax:   plan.AutoscaleMax.ValueInt32(),
+		Labels:         labels,
+		Taints:         taints,
+		Zones:          zones,
+		MaxSurge:       plan.MaxSurge.ValueInt32Pointer(),
+		MaxUnavailable: plan.MaxUnavailable.ValueInt32Pointer(),
+	}.Build()
+
+	createResp, err := createIdempotent(ctx, r.client.ClusterService.CreateNodePool, createReq)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Create Node Pool",
+			fmt.Sprintf("Unable to create node pool: %s", err.Error()),
+		)
+		return
+	}
+
+	getResp, err := r.client.ClusterService.GetNodePool(ctx, organizationv1.GetNodePoolRequest_builder{
+		NodePoolId: createResp.GetNodePoolId(),
+	}.Build())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Created Node Pool",
+			fmt.Sprintf("Unable to read created node pool: %s", err.Error()),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(nodePoolToModel(ctx, getResp.GetNodePool(), &plan)...)
+
+	tflog.Info(ctx, "Created node pool", map[string]any{
+		"id": plan.ID.ValueString(),
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *NodePoolResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state NodePoolResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if r.client == nil {
+		resp.Diagnostics.AddError(
+			"Client Not Configured",
+			"The Fundament client was not configured. Please report this issue to the provider developers.",
+		)
+		return
+	}
+
+	tflog.Debug(ctx, "Reading node pool", map[string]any{
+		"id": state.ID.ValueString(),
+	})
+
+	getResp, err := r.client.ClusterService.GetNodePool(ctx, organizationv1.GetNodePoolRequest_builder{
+		NodePoolId: state.ID.ValueString(),
+	}.Build())
+	if err != nil {
+		if connect.CodeOf(err) == connect.CodeNotFound {
+			tflog.Info(ctx, "Node pool not found, removing from state", map[string]any{
+				"id": state.ID.ValueString(),
+			})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError(
+			"Unable to Read Node Pool",
+			fmt.Sprintf("Unable to read node pool: %s", err.Error()),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(nodePoolToModel(ctx, getResp.GetNodePool(), &state)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update updates the node pool's size, node labels and taints, and rolling
+// update settings.
+func (r *NodePoolResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan NodePoolResourceModel
+	var state NodePoolResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if r.client == nil {
+		resp.Diagnostics.AddError(
+			"Client Not Configured",
+			"The Fundament client was not configured. Please report this issue to the provider developers.",
+		)
+		return
+	}
+
+	labels, taints, diags := nodeTemplateFromModel(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Updating node pool", map[string]any{
+		"id": state.ID.ValueString(),
+	})
+
+	_, err := r.client.ClusterService.UpdateNodePool(ctx, organizationv1.UpdateNodePoolRequest_builder{
+		NodePoolId:   state.ID.ValueString(),
+		AutoscaleMin: plan.AutoscaleMin.ValueInt32(),
+		AutoscaleMax: plan.AutoscaleMax.ValueInt32(),
+		Nodes: organizationv1.NodeTemplate_builder{
+			Labels: labels,
+			Taints: taints,
+		}.Build(),
+		MaxSurge:       plan.MaxSurge.ValueInt32Pointer(),
+		MaxUnavailable: plan.MaxUnavailable.ValueInt32Pointer(),
+	}.Build())
+	if err != nil {
+		switch connect.CodeOf(err) {
+		case connect.CodeNotFound:
+			resp.Diagnostics.AddError(
+				"Node Pool Not Found",
+				fmt.Sprintf("Node pool %q no longer exists. It may have been deleted outside of Terraform.", state.ID.ValueString()),
+			)
+		case connect.CodeInvalidArgument, connect.CodeFailedPrecondition:
+			resp.Diagnostics.AddError(
+				"Invalid Node Pool Configuration",
+				fmt.Sprintf("Invalid update parameters: %s", err.Error()),
+			)
+		default:
+			resp.Diagnostics.AddError(
+				"Unable to Update Node Pool",
+				fmt.Sprintf("Unable to update node pool: %s", err.Error()),
+			)
+		}
+		return
+	}
+
+	getResp, err := r.client.ClusterService.GetNodePool(ctx, organizationv1.GetNodePoolRequest_builder{
+		NodePoolId: state.ID.ValueString(),
+	}.Build())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Updated Node Pool",
+			fmt.Sprintf("Unable to read updated node pool: %s", err.Error()),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(nodePoolToModel(ctx, getResp.GetNodePool(), &plan)...)
+
+	tflog.Info(ctx, "Updated node pool", map[string]any{
+		"id": plan.ID.ValueString(),
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Delete deletes the node pool.
+func (r *NodePoolResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state NodePoolResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if r.client == nil {
+		resp.Diagnostics.AddError(
+			"Client Not Configured",
+			"The Fundament client was not configured. Please report this issue to the provider developers.",
+		)
+		return
+	}
+
+	tflog.Debug(ctx, "Deleting node pool", map[string]any{
+		"id": state.ID.ValueString(),
+	})
+
+	_, err := r.client.ClusterService.DeleteNodePool(ctx, organizationv1.DeleteNodePoolRequest_builder{
+		NodePoolId: state.ID.ValueString(),
+	}.Build())
+	if err != nil {
+		if connect.CodeOf(err) == connect.CodeNotFound {
+			tflog.Info(ctx, "Node pool already deleted", map[string]any{
+				"id": state.ID.ValueString(),
+			})
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Unable to Delete Node Pool",
+			fmt.Sprintf("Unable to delete node pool: %s", err.Error()),
+		)
+		return
+	}
+
+	tflog.Info(ctx, "Deleted node pool", map[string]any{
+		"id": state.ID.ValueString(),
+	})
+}
+
+// ImportState imports an existing node pool into Terraform state.
+func (r *NodePoolResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// nodeTemplateFromModel converts the labels and taints attributes to their
+// API form.
+func nodeTemplateFromModel(ctx context.Context, m *NodePoolResourceModel) (map[string]string, []*organizationv1.NodeTaint, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	labels := map[string]string{}
+	diags.Append(m.Labels.ElementsAs(ctx, &labels, false)...)
+
+	var taintModels []NodePoolTaintModel
+	diags.Append(m.Taints.ElementsAs(ctx, &taintModels, false)...)
+
+	taints := make([]*organizationv1.NodeTaint, 0, len(taintModels))
+	for _, t := range taintModels {
+		taints = append(taints, organizationv1.NodeTaint_builder{
+			Key:    t.Key.ValueString(),
+			Value:  t.Value.ValueString(),
+			Effect: taintEffects[t.Effect.ValueString()],
+		}.Build())
+	}
+	return labels, taints, diags
+}
+
+// nodePoolToModel maps an API node pool onto the model.
+func nodePoolToModel(ctx context.Context, np *organizationv1.NodePool, m *NodePoolResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	m.ID = types.StringValue(np.GetId())
+	m.ClusterID = types.StringValue(np.GetClusterId())
+	m.Name = types.StringValue(np.GetName())
+	m.MachineType = types.StringValue(np.GetMachineType())
+	m.AutoscaleMin = types.Int32Value(np.GetMinNodes())
+	m.AutoscaleMax = types.Int32Value(np.GetMaxNodes())
+	m.MaxSurge = types.Int32Value(np.GetMaxSurge())
+	m.MaxUnavailable = types.Int32Value(np.GetMaxUnavailable())
+
+	labels, d := types.MapValueFrom(ctx, types.StringType, np.GetLabels())
+	diags.Append(d...)
+	if labels.IsNull() {
+		labels = types.MapValueMust(types.StringType, map[string]attr.Value{})
+	}
+	m.Labels = labels
+
+	zones, d := types.ListValueFrom(ctx, types.StringType, np.GetZones())
+	diags.Append(d...)
+	if zones.IsNull() {
+		zones = types.ListValueMust(types.StringType, []attr.Value{})
+	}
+	m.Zones = zones
+
+	taintModels := make([]NodePoolTaintModel, 0, len(np.GetTaints()))
+	for _, t := range np.GetTaints() {
+		effect := ""
+		for name, e := range taintEffects {
+			if e == t.GetEffect() {
+				effect = name
+			}
+		}
+		taintModels = append(taintModels, NodePoolTaintModel{
+			Key:    types.StringValue(t.GetKey()),
+			Value:  types.StringValue(t.GetValue()),
+			Effect: types.StringValue(effect),
+		})
+	}
+	taints, d := types.ListValueFrom(ctx, types.ObjectType{AttrTypes: nodePoolTaintAttrTypes}, taintModels)
+	diags.Append(d...)
+	m.Taints = taints
+
+	return diags
+}
@@ -0,0 +1,108 @@
+package provider
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccNodePoolResource_basic(t *testing.T) {
+	// Skip if not running acceptance tests
+	if os.Getenv("TF_ACC") == "" {
+		t.Skip("Acceptance tests skipped unless TF_ACC=1 is set")
+	}
+
+	// Ensure required environment variables are set
+	if os.Getenv("FUNDAMENT_API_KEY") == "" {
+		t.Fatal("FUNDAMENT_API_KEY must be set for acceptance tests")
+	}
+
+	endpoint := os.Getenv("FUNDAMENT_ENDPOINT")
+	if endpoint == "" {
+		t.Fatal("FUNDAMENT_ENDPOINT must be set for acceptance tests")
+	}
+
+	organizationID := os.Getenv("FUNDAMENT_ORGANIZATION_ID")
+	if organizationID == "" {
+		t.Fatal("FUNDAMENT_ORGANIZATION_ID must be set for acceptance tests")
+	}
+
+	suffix := acctest.RandString(6)
+	clusterName := "tf-acc-" + suffix
+	resourceName := "fundament_node_pool.test"
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccNodePoolResourceConfig(clusterName, "batch", 3, endpoint, organizationID),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", "workers"),
+					resource.TestCheckResourceAttr(resourceName, "machine_type", "n1-standard-2"),
+					resource.TestCheckResourceAttr(resourceName, "autoscale_max", "3"),
+					resource.TestCheckResourceAttr(resourceName, "labels.workload", "batch"),
+					resource.TestCheckResourceAttr(resourceName, "taints.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "taints.0.effect", "NoSchedule"),
+					resource.TestCheckResourceAttr(resourceName, "max_surge", "1"),
+					resource.TestCheckResourceAttr(resourceName, "max_unavailable", "0"),
+					resource.TestCheckResourceAttrPair(resourceName, "cluster_id", "fundament_cluster.test", "id"),
+					resource.TestCheckResourceAttrSet(resourceName, "id"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update labels and autoscaling in place
+			{
+				Config: testAccNodePoolResourceConfig(clusterName, "stateful", 5, endpoint, organizationID),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "autoscale_max", "5"),
+					resource.TestCheckResourceAttr(resourceName, "labels.workload", "stateful"),
+					resource.TestCheckResourceAttr(resourceName, "taints.0.value", "stateful"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccNodePoolResourceConfig(clusterName, workload string, autoscaleMax int, endpoint, organizationID string) string {
+	return fmt.Sprintf(`
+provider "fundament" {
+  endpoint        = %[4]q
+  organization_id = %[5]q
+  # api_key read from environment variable FUNDAMENT_API_KEY
+}
+
+resource "fundament_cluster" "test" {
+  name               = %[1]q
+  region             = "eu-west-1"
+  kubernetes_version = "1.28"
+}
+
+resource "fundament_node_pool" "test" {
+  cluster_id    = fundament_cluster.test.id
+  name          = "workers"
+  machine_type  = "n1-standard-2"
+  autoscale_min = 1
+  autoscale_max = %[3]d
+
+  labels = {
+    workload = %[2]q
+  }
+
+  taints = [{
+    key    = "dedicated"
+    value  = %[2]q
+    effect = "NoSchedule"
+  }]
+}
+`, clusterName, workload, autoscaleMax, endpoint, organizationID)
+}
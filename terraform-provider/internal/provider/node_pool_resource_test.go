@@ -0,0 +1,90 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	organizationv1 "github.com/fundament-oss/fundament/organization-api/pkg/proto/gen/v1"
+)
+
+func TestNodePoolToModel(t *testing.T) {
+	ctx := context.Background()
+	np := organizationv1.NodePool_builder{
+		Id:             "pool-id",
+		ClusterId:      "cluster-id",
+		Name:           "batch",
+		MachineType:    "n1-standard-2",
+		MinNodes:       1,
+		MaxNodes:       3,
+		Labels:         map[string]string{"workload": "batch"},
+		Zones:          []string{"eu-west-1a"},
+		MaxSurge:       0,
+		MaxUnavailable: 1,
+		Taints: []*organizationv1.NodeTaint{
+			organizationv1.NodeTaint_builder{
+				Key:    "dedicated",
+				Value:  "batch",
+				Effect: organizationv1.TaintEffect_TAINT_EFFECT_NO_SCHEDULE,
+			}.Build(),
+		},
+	}.Build()
+
+	var model NodePoolResourceModel
+	if diags := nodePoolToModel(ctx, np, &model); diags.HasError() {
+		t.Fatalf("Unexpected diagnostics: %v", diags)
+	}
+
+	if model.ClusterID.ValueString() != "cluster-id" {
+		t.Errorf("Expected cluster_id 'cluster-id', got '%s'", model.ClusterID.ValueString())
+	}
+
+	if model.MaxSurge.ValueInt32() != 0 || model.MaxUnavailable.ValueInt32() != 1 {
+		t.Errorf("Expected max_surge 0 and max_unavailable 1, got %d and %d", model.MaxSurge.ValueInt32(), model.MaxUnavailable.ValueInt32())
+	}
+
+	if len(model.Zones.Elements()) != 1 {
+		t.Errorf("Expected 1 zone, got %d", len(model.Zones.Elements()))
+	}
+
+	labels, taints, diags := nodeTemplateFromModel(ctx, &model)
+	if diags.HasError() {
+		t.Fatalf("Unexpected diagnostics: %v", diags)
+	}
+
+	if labels["workload"] != "batch" {
+		t.Errorf("Expected label workload=batch, got %v", labels)
+	}
+
+	if len(taints) != 1 {
+		t.Fatalf("Expected 1 taint, got %d", len(taints))
+	}
+
+	if taints[0].GetEffect() != organizationv1.TaintEffect_TAINT_EFFECT_NO_SCHEDULE {
+		t.Errorf("Expected effect NoSchedule, got %s", taints[0].GetEffect())
+	}
+}
+
+func TestNodePoolToModelEmpty(t *testing.T) {
+	// Pools without labels, taints or zones map to empty collections, not null,
+	// so they match the attribute defaults.
+	var model NodePoolResourceModel
+	if diags := nodePoolToModel(context.Background(), organizationv1.NodePool_builder{Name: "default"}.Build(), &model); diags.HasError() {
+		t.Fatalf("Unexpected diagnostics: %v", diags)
+	}
+
+	for name, v := range map[string]interface{ IsNull() bool }{
+		"labels": model.Labels,
+		"taints": model.Taints,
+		"zones":  model.Zones,
+	} {
+		if v.IsNull() {
+			t.Errorf("Expected %s to not be null", name)
+		}
+	}
+
+	if model.Name != types.StringValue("default") {
+		t.Errorf("Expected name 'default', got '%s'", model.Name.ValueString())
+	}
+}
@@ -116,9 +116,13 @@ func (d *OrganizationMembersDataSource) Read(ctx context.Context, req datasource
 
 	tflog.Debug(ctx, "Fetching organization members")
 
-	rpcReq := organizationv1.ListMembersRequest_builder{}.Build()
-
-	rpcResp, err := d.client.MemberService.ListMembers(ctx, rpcReq)
+	members, err := listAllPages(func(pageToken string) ([]*organizationv1.Member, string, error) {
+		rpcResp, err := d.client.MemberService.ListMembers(ctx, organizationv1.ListMembersRequest_builder{
+			PageSize:  listPageSize,
+			PageToken: pageToken,
+		}.Build())
+		return rpcResp.GetMembers(), rpcResp.GetNextPageToken(), err
+	})
 	if err != nil {
 		switch connect.CodeOf(err) {
 		case connect.CodePermissionDenied:
@@ -135,8 +139,8 @@ func (d *OrganizationMembersDataSource) Read(ctx context.Context, req datasource
 		return
 	}
 
-	state.Members = make([]OrganizationMemberModel, len(rpcResp.GetMembers()))
-	for i, member := range rpcResp.GetMembers() {
+	state.Members = make([]OrganizationMemberModel, len(members))
+	for i, member := range members {
 		m := OrganizationMemberModel{
 			ID:         types.StringValue(member.GetId()),
 			UserID:     types.StringValue(member.GetUserId()),
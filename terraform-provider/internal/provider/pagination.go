@@ -0,0 +1,28 @@
+package provider
+
+import "fmt"
+
+// listPageSize is the page size the provider requests from paginated list RPCs.
+const listPageSize = 100
+
+// listAllPages fetches every page of a paginated list RPC: fetch is called
+// with the page token of each page in turn, starting with the first, until
+// the server returns no next page token.
+func listAllPages[T any](fetch func(pageToken string) ([]T, string, error)) ([]T, error) {
+	var all []T
+	pageToken := ""
+	for {
+		items, next, err := fetch(pageToken)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, items...)
+		if next == "" {
+			return all, nil
+		}
+		if next == pageToken {
+			return nil, fmt.Errorf("server returned the same page token twice")
+		}
+		pageToken = next
+	}
+}
@@ -0,0 +1,50 @@
+package provider
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestListAllPages(t *testing.T) {
+	pages := map[string]struct {
+		items []int
+		next  string
+	}{
+		"":   {items: []int{1, 2}, next: "p2"},
+		"p2": {items: []int{3, 4}, next: "p3"},
+		"p3": {items: []int{5}},
+	}
+
+	var tokens []string
+	got, err := listAllPages(func(pageToken string) ([]int, string, error) {
+		tokens = append(tokens, pageToken)
+		page := pages[pageToken]
+		return page.items, page.next, nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) != 5 || got[0] != 1 || got[4] != 5 {
+		t.Errorf("listAllPages() = %v, want [1 2 3 4 5]", got)
+	}
+	if len(tokens) != 3 {
+		t.Errorf("fetched %d pages, want 3", len(tokens))
+	}
+}
+
+func TestListAllPages_Errors(t *testing.T) {
+	wantErr := errors.New("boom")
+	_, err := listAllPages(func(string) ([]int, string, error) {
+		return nil, "", wantErr
+	})
+	if !errors.Is(err, wantErr) {
+		t.Errorf("listAllPages() error = %v, want %v", err, wantErr)
+	}
+
+	_, err = listAllPages(func(string) ([]int, string, error) {
+		return []int{1}, "same", nil
+	})
+	if err == nil {
+		t.Error("listAllPages() with a repeating page token should fail")
+	}
+}
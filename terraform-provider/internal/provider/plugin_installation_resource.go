@@ -327,7 +327,7 @@ func (r *PluginInstallationResource) Create(ctx context.Context, req resource.Cr
 // headers are added by the client transport). It errors when the plugin is not
 // in the catalog or has no published definition to pin.
 func (r *PluginInstallationResource) resolveLatestDefinition(ctx context.Context, pluginName string) (string, string, error) {
-	listReq := organizationv1.ListPluginsRequest_builder{}.Build()
+	listReq := organizationv1.ListPluginsRequest_builder{NameFilter: pluginName}.Build()
 	listResp, err := r.client.PluginService.ListPlugins(ctx, listReq)
 	if err != nil {
 		return "", "", fmt.Errorf("list plugins: %w", err)
@@ -121,11 +121,15 @@ func (d *ProjectsDataSource) Read(ctx context.Context, req datasource.ReadReques
 
 	tflog.Debug(ctx, "Fetching projects")
 
-	listReq := organizationv1.ListProjectsRequest_builder{ClusterId: state.ClusterID.ValueString()}.Build()
-	rpcReq := listReq
-
 	// Call the API
-	rpcResp, err := d.client.ProjectService.ListProjects(ctx, rpcReq)
+	projects, err := listAllPages(func(pageToken string) ([]*organizationv1.Project, string, error) {
+		rpcResp, err := d.client.ProjectService.ListProjects(ctx, organizationv1.ListProjectsRequest_builder{
+			ClusterId: state.ClusterID.ValueString(),
+			PageSize:  listPageSize,
+			PageToken: pageToken,
+		}.Build())
+		return rpcResp.GetProjects(), rpcResp.GetNextPageToken(), err
+	})
 	if err != nil {
 		switch connect.CodeOf(err) {
 		case connect.CodeInvalidArgument:
@@ -149,7 +153,7 @@ func (d *ProjectsDataSource) Read(ctx context.Context, req datasource.ReadReques
 
 	// Build cluster name cache to avoid redundant API calls
 	clusterNames := make(map[string]string)
-	for _, project := range rpcResp.GetProjects() {
+	for _, project := range projects {
 		if _, ok := clusterNames[project.GetClusterId()]; !ok {
 			clusterReq := organizationv1.GetClusterRequest_builder{
 				ClusterId: project.GetClusterId(),
@@ -162,8 +166,8 @@ func (d *ProjectsDataSource) Read(ctx context.Context, req datasource.ReadReques
 	}
 
 	// Map response to state
-	state.Projects = make([]ProjectModel, len(rpcResp.GetProjects()))
-	for i, project := range rpcResp.GetProjects() {
+	state.Projects = make([]ProjectModel, len(projects))
+	for i, project := range projects {
 		var created basetypes.StringValue
 
 		if project.GetCreated().CheckValid() == nil {
@@ -210,6 +210,7 @@ func (p *FundamentProvider) Configure(ctx context.Context, req provider.Configur
 func (p *FundamentProvider) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewClusterResource,
+		NewNodePoolResource,
 		NewProjectResource,
 		NewProjectMemberResource,
 		NewNamespaceResource,